@@ -32,13 +32,15 @@ func NewActionsPolicyCmd() *cobra.Command {
 - 정책 생성 및 템플릿 관리
 - 저장소별 정책 검증 및 적용
 - 조직 단위 규정 준수 모니터링
+- 위반 워크플로우 자동 수정 Pull Request 생성
 - 세밀한 권한 및 보안 설정 관리
 
 예시:
   gz actions-policy list                           # 모든 정책 목록 표시
   gz actions-policy create my-policy --org myorg   # 새 정책 생성
   gz actions-policy validate policy-id org repo   # 저장소 정책 검증
-  gz actions-policy enforce policy-id org repo    # 정책 적용`,
+  gz actions-policy enforce policy-id org repo    # 정책 적용
+  gz actions-policy remediate myorg --dry-run     # 위반 워크플로우 수정 PR 생성`,
 		SilenceUsage: true,
 	}
 
@@ -106,6 +108,7 @@ func NewActionsPolicyCmd() *cobra.Command {
 	cmd.AddCommand(showCmd)
 	cmd.AddCommand(deleteCmd)
 	cmd.AddCommand(monitorCmd)
+	cmd.AddCommand(newRemediateCmd())
 
 	// 전역 플래그
 	cmd.PersistentFlags().String("token", "", "GitHub 토큰 (GITHUB_TOKEN 환경변수 사용 가능)")
@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package actionspolicy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/internal/actionsfix"
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+	"github.com/gizzahub/gzh-cli/pkg/github"
+)
+
+func newRemediateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remediate [org]",
+		Short: "정책 위반 워크플로우 수정 PR 생성",
+		Long: `조직 저장소의 GitHub Actions 워크플로우를 정책에 맞게 수정하고 Pull Request를 엽니다.
+
+수정 항목:
+- 태그/브랜치로 참조된 액션을 커밋 SHA로 고정 (원래 ref는 주석으로 유지)
+- 정책에서 허용하지 않는 액션을 사용하는 step 제거
+- 최상위 permissions 블록이 없는 워크플로우에 정책 권한 추가
+
+저장소는 --batch-size 단위로 처리되며 배치 사이에 --batch-delay 만큼 대기합니다.
+--rate로 초당 API 요청 수를 제한합니다. 같은 브랜치로 다시 실행하면 기존 PR이 갱신됩니다.
+
+예시:
+  gz actions-policy remediate myorg --dry-run
+  gz actions-policy remediate myorg --template strict --repos api,web
+  gz actions-policy remediate myorg --policy-file policy.yaml --no-remove`,
+		Args: cobra.ExactArgs(1),
+		RunE: remediatePolicy,
+	}
+
+	cmd.Flags().String("policy-file", "", "정책 파일 (YAML 또는 JSON)")
+	cmd.Flags().String("template", "default", "정책 파일이 없을 때 사용할 템플릿 (default, strict, permissive)")
+	cmd.Flags().StringSlice("repos", nil, "대상 저장소 (기본값: 보관되지 않은 모든 저장소)")
+	cmd.Flags().Bool("no-pin", false, "액션 SHA 고정 안함")
+	cmd.Flags().Bool("no-remove", false, "허용되지 않은 액션 제거 안함")
+	cmd.Flags().Bool("no-permissions", false, "permissions 블록 추가 안함")
+	cmd.Flags().String("branch", actionsfix.DefaultBranch, "수정 커밋을 푸시할 브랜치")
+	cmd.Flags().Int("batch-size", 10, "배치당 저장소 수")
+	cmd.Flags().Duration("batch-delay", 30*time.Second, "배치 사이 대기 시간")
+	cmd.Flags().Float64("rate", 5, "초당 최대 API 요청 수 (0: 제한 없음)")
+	cmd.Flags().String("base-url", "", "GitHub Enterprise API URL")
+	cmd.Flags().Bool("dry-run", false, "변경사항만 표시, 커밋/PR 생성 안함")
+
+	return cmd
+}
+
+func remediatePolicy(cmd *cobra.Command, args []string) error {
+	org := args[0]
+	policyFile, _ := cmd.Flags().GetString("policy-file")
+	template, _ := cmd.Flags().GetString("template")
+	repoNames, _ := cmd.Flags().GetStringSlice("repos")
+	noPin, _ := cmd.Flags().GetBool("no-pin")
+	noRemove, _ := cmd.Flags().GetBool("no-remove")
+	noPermissions, _ := cmd.Flags().GetBool("no-permissions")
+	branch, _ := cmd.Flags().GetString("branch")
+	batchSize, _ := cmd.Flags().GetInt("batch-size")
+	batchDelay, _ := cmd.Flags().GetDuration("batch-delay")
+	rate, _ := cmd.Flags().GetFloat64("rate")
+	baseURL, _ := cmd.Flags().GetString("base-url")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	format, _ := cmd.Flags().GetString("format")
+	token, _ := cmd.Flags().GetString("token")
+
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		return fmt.Errorf("GitHub token is required (--token or GITHUB_TOKEN)")
+	}
+
+	policy, err := loadRemediationPolicy(policyFile, template)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	client := actionsfix.NewClient(baseURL, token, rate)
+	opener, err := depupdate.NewPullRequestOpener("github", baseURL, token)
+	if err != nil {
+		return err
+	}
+
+	var repos []actionsfix.Repository
+	if len(repoNames) == 0 {
+		if repos, err = client.ListRepositories(ctx, org); err != nil {
+			return fmt.Errorf("failed to list repositories: %w", err)
+		}
+	} else {
+		for _, name := range repoNames {
+			if !strings.Contains(name, "/") {
+				name = org + "/" + name
+			}
+			repo, err := client.Repository(ctx, name)
+			if err != nil {
+				return fmt.Errorf("failed to get repository %s: %w", name, err)
+			}
+			repos = append(repos, repo)
+		}
+	}
+
+	quiet := format == "json"
+	if !quiet {
+		fmt.Printf("🔧 Remediating %d repositories in %s", len(repos), org)
+		if dryRun {
+			fmt.Print(" (dry run)")
+		}
+		fmt.Println()
+	}
+
+	remediator := &actionsfix.Remediator{
+		API:    client,
+		Opener: opener,
+		Rules:  actionsfix.RulesFromPolicy(policy, !noPin, !noRemove, !noPermissions),
+	}
+	if !quiet {
+		remediator.Progress = printRemediationProgress
+	}
+
+	report, runErr := remediator.Run(ctx, repos, actionsfix.Options{
+		Branch:     branch,
+		BatchSize:  batchSize,
+		BatchDelay: batchDelay,
+		DryRun:     dryRun,
+	})
+
+	if quiet {
+		if err := printJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printRemediationReport(report)
+	}
+	if runErr != nil {
+		return runErr
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("remediation failed for %d repositories", report.Failed)
+	}
+	return nil
+}
+
+// loadRemediationPolicy reads a policy file, falling back to a template.
+func loadRemediationPolicy(path, template string) (*github.ActionsPolicy, error) {
+	if path == "" {
+		switch template {
+		case "default":
+			return github.GetDefaultActionsPolicy(), nil
+		case "strict":
+			return createStrictPolicy(), nil
+		case "permissive":
+			return createPermissivePolicy(), nil
+		default:
+			return nil, fmt.Errorf("unknown template: %s", template)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	// YAML is a superset of JSON, so both formats decode through the yaml
+	// tags, which match the json ones.
+	policy := github.GetDefaultActionsPolicy()
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+func printRemediationProgress(res actionsfix.RepoResult) {
+	switch res.Status {
+	case actionsfix.StatusOpened:
+		fmt.Printf("   ✅ %s: %d changes, opened %s\n", res.Repository, len(res.Changes), res.PullRequest)
+	case actionsfix.StatusUpdated:
+		fmt.Printf("   🔄 %s: %d changes, updated existing pull request\n", res.Repository, len(res.Changes))
+	case actionsfix.StatusPlanned:
+		fmt.Printf("   📝 %s: %d changes\n", res.Repository, len(res.Changes))
+		for _, c := range res.Changes {
+			fmt.Printf("      %s:%d %s\n", c.File, c.Line, c.Description)
+		}
+	case actionsfix.StatusClean:
+		fmt.Printf("   ✓ %s: compliant\n", res.Repository)
+	default:
+		fmt.Printf("   ❌ %s: %s\n", res.Repository, res.Error)
+	}
+}
+
+func printRemediationReport(report *actionsfix.Report) {
+	fmt.Printf("\n📊 Remediation Summary\n")
+	fmt.Printf("   Opened: %d, Updated: %d, Planned: %d, Compliant: %d, Failed: %d\n",
+		report.Opened, report.Updated, report.Planned, report.Clean, report.Failed)
+
+	var opened []actionsfix.RepoResult
+	for _, res := range report.Repositories {
+		if res.Status == actionsfix.StatusOpened || res.Status == actionsfix.StatusUpdated {
+			opened = append(opened, res)
+		}
+	}
+	if len(opened) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%-40s %-8s %-8s %s\n", "REPOSITORY", "STATUS", "CHANGES", "PULL REQUEST")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, res := range opened {
+		fmt.Printf("%-40s %-8s %-8d %s\n", truncate(res.Repository, 40), res.Status, len(res.Changes), res.PullRequest)
+	}
+}
@@ -0,0 +1,418 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package api implements the `gz api` command.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+	"github.com/gizzahub/gzh-cli/internal/jq"
+)
+
+type options struct {
+	fields    []string
+	rawFields []string
+	headers   []string
+	input     string
+	paginate  bool
+	jq        string
+	include   bool
+	silent    bool
+	baseURL   string
+	org       string
+	maxWait   time.Duration
+}
+
+// NewAPICmd creates the api command.
+func NewAPICmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "api <provider> [method] <path>",
+		Short: "Make an authenticated request to a provider's REST API",
+		Long: `Make an authenticated request to the REST API of GitHub, GitLab or Gitea and
+print the response, for scripting against endpoints gz has no command for.
+
+The method defaults to GET. The path is relative to the provider's API base
+URL (https://api.github.com, https://gitlab.com/api/v4, https://gitea.com/api/v1),
+or the api_url of the provider in gzh.yaml for self-hosted instances.
+
+Credentials are the ones other gz commands use: GZH_<PROVIDER>_TOKEN or
+<PROVIDER>_TOKEN, then the provider's token in gzh.yaml. A GitHub App
+configured there gets an installation token for the organization in the
+path (/repos/{org}/..., /orgs/{org}/...) or --org.
+
+Fields are sent as query parameters of GET requests and as a JSON object in
+the body of others. -F converts true, false, null and integers to JSON
+values and reads "@file" from a file; -f always sends strings. Keys with
+"[]" append to an array ("-f labels[]=bug").
+
+With --paginate, list endpoints are followed through their Link (or GitLab
+X-Next-Page) headers and the pages of a JSON array are merged into one.
+Rate limited requests are retried once the limit resets, for up to
+--max-wait.
+
+--jq filters the response with a subset of jq: paths (.a.b, .[0], .[],
+.a[]?), pipes, commas, select() with comparisons, length, keys and not.
+Strings are printed without quotes, one result per line.`,
+		Example: `  # Get the authenticated user
+  gz api github user
+
+  # Names of every repository of an organization
+  gz api github orgs/my-org/repos --paginate --jq '.[].name'
+
+  # Create an issue
+  gz api github POST repos/my-org/app/issues -f title="Broken build" -f labels[]=bug
+
+  # Open merge requests of a GitLab project, with status and headers
+  gz api gitlab projects/42/merge_requests -f state=opened -i
+
+  # Archived Gitea repositories
+  gz api gitea orgs/my-org/repos --paginate --jq '.[] | select(.archived) | .full_name'`,
+		Args:         cobra.RangeArgs(2, 3),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAPI(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), args, opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&opts.fields, "field", "F", nil, "Add a typed parameter in key=value format")
+	cmd.Flags().StringArrayVarP(&opts.rawFields, "raw-field", "f", nil, "Add a string parameter in key=value format")
+	cmd.Flags().StringArrayVarP(&opts.headers, "header", "H", nil, "Add a request header in key:value format")
+	cmd.Flags().StringVar(&opts.input, "input", "", "Read the request body from a file (\"-\" for stdin)")
+	cmd.Flags().BoolVar(&opts.paginate, "paginate", false, "Fetch every page of a list endpoint")
+	cmd.Flags().StringVarP(&opts.jq, "jq", "q", "", "Filter the response with a jq expression")
+	cmd.Flags().BoolVarP(&opts.include, "include", "i", false, "Print the response status and headers")
+	cmd.Flags().BoolVar(&opts.silent, "silent", false, "Do not print the response body")
+	cmd.Flags().StringVar(&opts.baseURL, "base-url", "", "API base URL (default: from config, else the public instance)")
+	cmd.Flags().StringVar(&opts.org, "org", "", "Organization whose GitHub App installation token to use")
+	cmd.Flags().DurationVar(&opts.maxWait, "max-wait", 5*time.Minute, "Longest time to wait for a rate limit to reset")
+
+	return cmd
+}
+
+func runAPI(ctx context.Context, stdout, stderr io.Writer, args []string, opts *options) error {
+	providerName := strings.ToLower(args[0])
+	if !slices.Contains([]string{"github", "gitlab", "gitea"}, providerName) {
+		return fmt.Errorf("%w: %s (supported: github, gitlab, gitea)", errUnsupportedProvider, args[0])
+	}
+	method, path := http.MethodGet, args[len(args)-1]
+	if len(args) == 3 {
+		method = strings.ToUpper(args[1])
+	}
+
+	var query *jq.Query
+	if opts.jq != "" {
+		q, err := jq.Parse(opts.jq)
+		if err != nil {
+			return err
+		}
+		query = q
+	}
+
+	params, err := parseFields(opts.fields, opts.rawFields)
+	if err != nil {
+		return err
+	}
+	headers, err := parseHeaders(opts.headers)
+	if err != nil {
+		return err
+	}
+
+	var body []byte
+	switch {
+	case opts.input != "":
+		if body, err = readInput(opts.input); err != nil {
+			return err
+		}
+	case len(params) > 0 && method != http.MethodGet && method != http.MethodHead:
+		if body, err = json.Marshal(params); err != nil {
+			return fmt.Errorf("failed to encode fields: %w", err)
+		}
+		params = nil
+	}
+
+	org := opts.org
+	if org == "" {
+		org = orgFromPath(path)
+	}
+	t, err := resolveTarget(ctx, providerName, opts.baseURL, org)
+	if err != nil {
+		return err
+	}
+	if t.token == "" && !opts.silent {
+		fmt.Fprintf(stderr, "⚠️  No %s token found; sending an unauthenticated request\n", providerName)
+	}
+
+	rawURL, err := addQuery(t.url(path), params)
+	if err != nil {
+		return err
+	}
+	if opts.paginate && method == http.MethodGet {
+		rawURL = withPageSize(rawURL, t)
+	}
+
+	c := newClient(t, headers, opts.maxWait)
+	c.onWait = func(wait time.Duration) {
+		if !opts.silent {
+			fmt.Fprintf(stderr, "⏳ Rate limited by %s, waiting %s\n", providerName, wait.Round(time.Second))
+		}
+	}
+
+	var pages [][]byte
+	for rawURL != "" {
+		resp, err := c.do(ctx, method, rawURL, body)
+		if err != nil {
+			return err
+		}
+		if opts.include {
+			printHeaders(stdout, resp)
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			if !opts.silent {
+				writeBody(stdout, resp.Body)
+			}
+			return fmt.Errorf("%w: %s %s: HTTP %s", errHTTPStatus, method, path, resp.Status)
+		}
+
+		if query != nil {
+			if !opts.silent {
+				if err := printQuery(stdout, query, resp.Body); err != nil {
+					return err
+				}
+			}
+		} else {
+			pages = append(pages, resp.Body)
+		}
+
+		if !opts.paginate {
+			break
+		}
+		rawURL = nextPage(resp, rawURL)
+		if rawURL != "" {
+			if err := c.waitForReset(ctx, resp); err != nil {
+				return err
+			}
+		}
+	}
+
+	if query == nil && !opts.silent {
+		writeBody(stdout, mergePages(pages))
+	}
+	return nil
+}
+
+// parseFields builds the request parameters from -F (typed) and -f (raw)
+// key=value pairs. A key ending in "[]" collects its values in an array.
+func parseFields(typed, raw []string) (map[string]any, error) {
+	params := make(map[string]any)
+	add := func(field string, convert bool) error {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid field %q: expected key=value", field)
+		}
+		var v any = value
+		if convert {
+			var err error
+			if v, err = typedValue(value); err != nil {
+				return err
+			}
+		}
+		if name, isArray := strings.CutSuffix(key, "[]"); isArray {
+			values, _ := params[name].([]any)
+			params[name] = append(values, v)
+			return nil
+		}
+		params[key] = v
+		return nil
+	}
+
+	for _, field := range typed {
+		if err := add(field, true); err != nil {
+			return nil, err
+		}
+	}
+	for _, field := range raw {
+		if err := add(field, false); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
+
+// typedValue converts a -F value: true, false, null and integers become
+// JSON values and "@file" the contents of file.
+func typedValue(value string) (any, error) {
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n, nil
+	}
+	if file, ok := strings.CutPrefix(value, "@"); ok {
+		data, err := readInput(file)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+	return value, nil
+}
+
+// parseHeaders parses key:value request headers.
+func parseHeaders(headers []string) (http.Header, error) {
+	h := make(http.Header)
+	for _, header := range headers {
+		key, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid header %q: expected key:value", header)
+		}
+		h.Add(strings.TrimSpace(key), strings.TrimSpace(value))
+	}
+	return h, nil
+}
+
+// readInput reads a file, or stdin for "-".
+func readInput(name string) ([]byte, error) {
+	if name == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// addQuery adds params to the query of rawURL.
+func addQuery(rawURL string, params map[string]any) (string, error) {
+	if len(params) == 0 {
+		return rawURL, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid path: %w", err)
+	}
+	q := u.Query()
+	for key, value := range params {
+		if values, ok := value.([]any); ok {
+			for _, v := range values {
+				q.Add(key+"[]", fmt.Sprint(v))
+			}
+			continue
+		}
+		if value == nil {
+			value = ""
+		}
+		q.Set(key, fmt.Sprint(value))
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// withPageSize asks for the largest page the provider allows, so paginating
+// takes fewer requests, unless the request sets a page size itself.
+func withPageSize(rawURL string, t target) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	param, size := t.pageSizeParam()
+	q := u.Query()
+	if q.Has(param) {
+		return rawURL
+	}
+	q.Set(param, strconv.Itoa(size))
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// printHeaders prints the status line and headers of resp.
+func printHeaders(w io.Writer, resp *response) {
+	fmt.Fprintf(w, "%s %s\n", resp.Proto, resp.Status)
+	keys := make([]string, 0, len(resp.Header))
+	for key := range resp.Header {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s: %s\n", key, strings.Join(resp.Header[key], ", "))
+	}
+	fmt.Fprintln(w)
+}
+
+// writeBody prints a response body, indenting JSON.
+func writeBody(w io.Writer, body []byte) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err == nil {
+		body = out.Bytes()
+	}
+	_, _ = w.Write(body)
+	if !bytes.HasSuffix(body, []byte("\n")) {
+		fmt.Fprintln(w)
+	}
+}
+
+// mergePages joins pages that are JSON arrays into a single array. Other
+// pages are concatenated as they are.
+func mergePages(pages [][]byte) []byte {
+	if len(pages) == 1 {
+		return pages[0]
+	}
+	var merged []json.RawMessage
+	for _, page := range pages {
+		var items []json.RawMessage
+		if err := json.Unmarshal(page, &items); err != nil {
+			return bytes.Join(pages, []byte("\n"))
+		}
+		merged = append(merged, items...)
+	}
+	if merged == nil {
+		merged = []json.RawMessage{}
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return bytes.Join(pages, []byte("\n"))
+	}
+	return data
+}
+
+// printQuery prints the results of query against a JSON response body.
+func printQuery(w io.Writer, query *jq.Query, body []byte) error {
+	results, err := query.RunJSON(body)
+	if err != nil {
+		return fmt.Errorf("--jq %s: %w", query, err)
+	}
+	for _, result := range results {
+		line, err := jq.Format(result)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, line)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runCmd(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	cmd := NewAPICmd(nil)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestAPIPaginateWithJQ(t *testing.T) {
+	t.Setenv("GZH_GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "/orgs/acme/repos", r.URL.Path)
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		assert.Equal(t, "all", r.URL.Query().Get("type"))
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 2 {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/repos?page=2&per_page=100&type=all>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"name": "app"}, {"name": "web"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"name": "old"}]`)
+	}))
+	defer server.Close()
+
+	out, _, err := runCmd(t, "github", "orgs/acme/repos", "--base-url", server.URL,
+		"--paginate", "-f", "type=all", "--jq", ".[].name")
+	require.NoError(t, err)
+	assert.Equal(t, "app\nweb\nold\n", out)
+
+	out, _, err = runCmd(t, "github", "/orgs/acme/repos", "--base-url", server.URL, "--paginate", "-f", "type=all")
+	require.NoError(t, err)
+	var merged []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(out), &merged))
+	assert.Equal(t, []map[string]string{{"name": "app"}, {"name": "web"}, {"name": "old"}}, merged)
+}
+
+func TestAPIPostFields(t *testing.T) {
+	t.Setenv("GZH_GITLAB_TOKEN", "secret")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+		assert.Equal(t, "yes", r.Header.Get("X-Extra"))
+		data, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"title": "Broken", "confidential": true, "weight": 3, "labels": ["bug", "ci"]}`, string(data))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"iid": 7}`)
+	}))
+	defer server.Close()
+
+	out, _, err := runCmd(t, "gitlab", "post", "projects/42/issues", "--base-url", server.URL,
+		"-f", "title=Broken", "-F", "confidential=true", "-F", "weight=3",
+		"-f", "labels[]=bug", "-f", "labels[]=ci", "-H", "X-Extra: yes", "-q", ".iid")
+	require.NoError(t, err)
+	assert.Equal(t, "7\n", out)
+}
+
+func TestAPIRateLimitRetry(t *testing.T) {
+	t.Setenv("GZH_GITEA_TOKEN", "secret")
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"login": "me"}`)
+	}))
+	defer server.Close()
+
+	out, stderr, err := runCmd(t, "gitea", "user", "--base-url", server.URL, "--jq", ".login")
+	require.NoError(t, err)
+	assert.Equal(t, "me\n", out)
+	assert.Contains(t, stderr, "Rate limited by gitea")
+	assert.Equal(t, 2, calls)
+}
+
+func TestAPIErrors(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "secret")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Not Found"}`)
+	}))
+	defer server.Close()
+
+	out, _, err := runCmd(t, "github", "repos/acme/missing", "--base-url", server.URL)
+	require.ErrorIs(t, err, errHTTPStatus)
+	assert.Contains(t, err.Error(), "404")
+	assert.Contains(t, out, `"message": "Not Found"`)
+
+	_, _, err = runCmd(t, "bitbucket", "user")
+	require.ErrorIs(t, err, errUnsupportedProvider)
+
+	_, _, err = runCmd(t, "github", "user", "-f", "novalue")
+	require.ErrorContains(t, err, "expected key=value")
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	resp := func(status int, header map[string]string) *response {
+		h := make(http.Header)
+		for k, v := range header {
+			h.Set(k, v)
+		}
+		return &response{StatusCode: status, Header: h}
+	}
+
+	wait, limited := rateLimitWait(resp(http.StatusForbidden, map[string]string{
+		"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1700000030",
+	}), now)
+	assert.True(t, limited)
+	assert.Equal(t, 30*time.Second, wait)
+
+	wait, limited = rateLimitWait(resp(http.StatusTooManyRequests, map[string]string{
+		"RateLimit-Reset": "1700000010",
+	}), now)
+	assert.True(t, limited)
+	assert.Equal(t, 10*time.Second, wait)
+
+	_, limited = rateLimitWait(resp(http.StatusForbidden, map[string]string{"X-RateLimit-Remaining": "12"}), now)
+	assert.False(t, limited, "permission errors are not rate limits")
+}
+
+func TestNextPage(t *testing.T) {
+	h := make(http.Header)
+	h.Set("Link", `<https://gitlab.example/api/v4/groups?page=1>; rel="prev", <https://gitlab.example/api/v4/groups?page=3>; rel="next"`)
+	assert.Equal(t, "https://gitlab.example/api/v4/groups?page=3", nextPage(&response{Header: h}, ""))
+
+	h = make(http.Header)
+	h.Set("X-Next-Page", "4")
+	assert.Equal(t, "https://gitlab.example/api/v4/groups?page=4&per_page=100",
+		nextPage(&response{Header: h}, "https://gitlab.example/api/v4/groups?page=3&per_page=100"))
+
+	assert.Empty(t, nextPage(&response{Header: http.Header{}}, "https://gitlab.example/api/v4/groups"))
+}
+
+func TestOrgFromPath(t *testing.T) {
+	assert.Equal(t, "acme", orgFromPath("/repos/acme/app/issues"))
+	assert.Equal(t, "acme", orgFromPath("orgs/acme/members?role=admin"))
+	assert.Empty(t, orgFromPath("user/repos"))
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/env"
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+const (
+	// maxRateLimitRetries bounds how often one request is retried after
+	// being rate limited.
+	maxRateLimitRetries = 3
+	// fallbackRateLimitWait is used when a rate limited response says
+	// nothing about when to retry.
+	fallbackRateLimitWait = time.Minute
+)
+
+var (
+	// errUnsupportedProvider is returned for providers without a REST API
+	// passthrough.
+	errUnsupportedProvider = errors.New("unsupported provider")
+	// errRateLimited is returned when the rate limit does not reset within
+	// --max-wait.
+	errRateLimited = errors.New("rate limited")
+	// errHTTPStatus is returned for non-2xx responses.
+	errHTTPStatus = errors.New("request failed")
+)
+
+// linkNextPattern matches the rel="next" entry of a Link header.
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="?next"?`)
+
+// target is the API endpoint and credentials of one provider.
+type target struct {
+	provider string
+	baseURL  string
+	token    string
+}
+
+// resolveTarget finds the API base URL and token of provider. --base-url and
+// the environment (GZH_<PROVIDER>_TOKEN, <PROVIDER>_TOKEN) win over the
+// provider section of gzh.yaml. The configuration is only read when
+// something is still missing, and a GitHub App configured there gets an
+// installation token for org.
+func resolveTarget(ctx context.Context, provider, baseURL, org string) (target, error) {
+	t := target{provider: provider, baseURL: baseURL, token: env.GetToken(provider)}
+
+	if t.token == "" || t.baseURL == "" {
+		cfg, err := pkgconfig.NewConfigFactoryWithOptions(&pkgconfig.ConfigFactoryOptions{
+			PreferUnified: true,
+		}).LoadConfig()
+		if err == nil && cfg.Providers[provider] != nil {
+			pc := cfg.Providers[provider]
+			if t.baseURL == "" {
+				t.baseURL = pc.APIURL
+			}
+			if t.token == "" {
+				if pc.App != nil && org == "" {
+					return t, fmt.Errorf("the %s GitHub App needs an organization; use --org or a path naming one", provider)
+				}
+				token, err := pc.TokenForOrg(ctx, org)
+				if err != nil {
+					return t, fmt.Errorf("failed to get %s token: %w", provider, err)
+				}
+				t.token = token
+			}
+		}
+	}
+
+	if t.baseURL == "" {
+		switch provider {
+		case "github":
+			t.baseURL = depupdate.DefaultGitHubAPI
+		case "gitlab":
+			t.baseURL = depupdate.DefaultGitLabAPI
+		case "gitea":
+			t.baseURL = depupdate.DefaultGiteaAPI
+		}
+	}
+	t.baseURL = strings.TrimSuffix(t.baseURL, "/")
+	return t, nil
+}
+
+// orgFromPath returns the organization an API path refers to, for choosing
+// a GitHub App installation: /repos/{org}/..., /orgs/{org}/..., or
+// /users/{org}/....
+func orgFromPath(path string) string {
+	parts := strings.Split(strings.Trim(strings.SplitN(path, "?", 2)[0], "/"), "/")
+	if len(parts) >= 2 {
+		switch parts[0] {
+		case "repos", "orgs", "users":
+			return parts[1]
+		}
+	}
+	return ""
+}
+
+// authorize sets the provider's authentication header.
+func (t target) authorize(req *http.Request) {
+	if t.token == "" {
+		return
+	}
+	switch t.provider {
+	case "gitlab":
+		req.Header.Set("PRIVATE-TOKEN", t.token)
+	case "gitea":
+		req.Header.Set("Authorization", "token "+t.token)
+	default:
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+}
+
+// url resolves an API path against the base URL. Full URLs, such as those of
+// Link headers, are used as they are.
+func (t target) url(path string) string {
+	if strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://") {
+		return path
+	}
+	return t.baseURL + "/" + strings.TrimPrefix(path, "/")
+}
+
+// pageSizeParam returns the query parameter and maximum value for the page
+// size of list endpoints.
+func (t target) pageSizeParam() (string, int) {
+	if t.provider == "gitea" {
+		return "limit", 50
+	}
+	return "per_page", 100
+}
+
+// response is a fully read HTTP response.
+type response struct {
+	Status     string
+	StatusCode int
+	Proto      string
+	Header     http.Header
+	Body       []byte
+}
+
+// client sends requests to one provider, waiting out rate limits.
+type client struct {
+	target     target
+	httpClient *http.Client
+	headers    http.Header
+	maxWait    time.Duration
+	// onWait is called before waiting for a rate limit to reset.
+	onWait func(wait time.Duration)
+	// sleep waits for d unless ctx is done first.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newClient(t target, headers http.Header, maxWait time.Duration) *client {
+	return &client{
+		target:     t,
+		httpClient: httpclient.NewProviderClient(t.provider, 60*time.Second),
+		headers:    headers,
+		maxWait:    maxWait,
+		onWait:     func(time.Duration) {},
+		sleep:      sleepContext,
+	}
+}
+
+// do sends a request, retrying it when it is rate limited and the limit
+// resets within maxWait.
+func (c *client) do(ctx context.Context, method, rawURL string, body []byte) (*response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, rawURL, body)
+		if err != nil {
+			return nil, err
+		}
+		wait, limited := rateLimitWait(resp, time.Now())
+		if !limited || attempt >= maxRateLimitRetries {
+			return resp, nil
+		}
+		if err := c.wait(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// waitForReset waits for the rate limit window to reset when resp used up
+// the last request of it, so the next page is not rejected.
+func (c *client) waitForReset(ctx context.Context, resp *response) error {
+	if rateLimitRemaining(resp.Header) != 0 {
+		return nil
+	}
+	reset, ok := rateLimitReset(resp.Header)
+	if !ok {
+		return nil
+	}
+	if wait := time.Until(reset); wait > 0 {
+		return c.wait(ctx, wait)
+	}
+	return nil
+}
+
+// wait sleeps until a rate limit resets, or fails when that is more than
+// maxWait away.
+func (c *client) wait(ctx context.Context, d time.Duration) error {
+	if d > c.maxWait {
+		return fmt.Errorf("%w: %s resets in %s, longer than --max-wait %s",
+			errRateLimited, c.target.provider, d.Round(time.Second), c.maxWait)
+	}
+	c.onWait(d)
+	return c.sleep(ctx, d)
+}
+
+func (c *client) send(ctx context.Context, method, rawURL string, body []byte) (*response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "gzh-cli")
+	if c.target.provider == "github" {
+		req.Header.Set("Accept", "application/vnd.github+json")
+	} else {
+		req.Header.Set("Accept", "application/json")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.target.authorize(req)
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &response{
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+		Proto:      resp.Proto,
+		Header:     resp.Header,
+		Body:       data,
+	}, nil
+}
+
+// rateLimitWait reports whether resp was rejected by a rate limit and how
+// long to wait before retrying. GitHub and Gitea send X-RateLimit-*, GitLab
+// RateLimit-*; the reset is a Unix timestamp in both.
+func rateLimitWait(resp *response, now time.Time) (time.Duration, bool) {
+	remaining := rateLimitRemaining(resp.Header)
+	limited := resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusForbidden && (remaining == 0 || resp.Header.Get("Retry-After") != "")
+	if !limited {
+		return 0, false
+	}
+	if wait, ok := retryAfter(resp.Header.Get("Retry-After"), now); ok {
+		return wait, true
+	}
+	if reset, ok := rateLimitReset(resp.Header); ok {
+		return max(reset.Sub(now), 0), true
+	}
+	return fallbackRateLimitWait, true
+}
+
+// rateLimitRemaining returns the requests left in the current window, or -1
+// when the response does not say.
+func rateLimitRemaining(h http.Header) int {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		if remaining, err := strconv.Atoi(h.Get(prefix + "Remaining")); err == nil {
+			return remaining
+		}
+	}
+	return -1
+}
+
+// rateLimitReset returns when the current rate limit window ends.
+func rateLimitReset(h http.Header) (time.Time, bool) {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		if reset, err := strconv.ParseInt(h.Get(prefix+"Reset"), 10, 64); err == nil {
+			return time.Unix(reset, 0), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// retryAfter parses a Retry-After header in seconds or as an HTTP date.
+func retryAfter(header string, now time.Time) (time.Duration, bool) {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// nextPage returns the URL of the page after resp, from the Link header
+// (GitHub, Gitea, GitLab) or GitLab's X-Next-Page header.
+func nextPage(resp *response, current string) string {
+	if m := linkNextPattern.FindStringSubmatch(resp.Header.Get("Link")); m != nil {
+		return m[1]
+	}
+	if page := resp.Header.Get("X-Next-Page"); page != "" {
+		u, err := url.Parse(current)
+		if err != nil {
+			return ""
+		}
+		q := u.Query()
+		q.Set("page", page)
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+	return ""
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type apiCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p apiCmdProvider) Command() *cobra.Command {
+	return NewAPICmd(p.appCtx)
+}
+
+func (p apiCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "api",
+		Category:     registry.CategoryUtility,
+		Version:      "1.0.0",
+		Priority:     85,
+		Experimental: false,
+		Dependencies: []string{},
+		Tags:         []string{"api", "scripting", "github", "gitlab", "gitea"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterAPICmd registers the api command with the command registry.
+func RegisterAPICmd(appCtx *app.AppContext) {
+	registry.Register(apiCmdProvider{appCtx: appCtx})
+}
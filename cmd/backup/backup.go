@@ -0,0 +1,623 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package backup implements the `gz backup` command.
+package backup
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+	"github.com/gizzahub/gzh-cli/internal/backup"
+	"github.com/gizzahub/gzh-cli/internal/version"
+	"github.com/gizzahub/gzh-cli/pkg/cloud"
+)
+
+// PassphraseEnvVar supplies the archive passphrase non-interactively.
+const PassphraseEnvVar = "GZ_BACKUP_PASSPHRASE" //nolint:gosec // Environment variable name, not credential
+
+// NewBackupCmd creates the backup command.
+func NewBackupCmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up and restore gz state and configuration",
+		Long: `Snapshot gz state and configuration into an encrypted archive and restore
+it, for example when moving to a new machine.
+
+A backup covers:
+  ~/.gzh                  state databases, history, journal and jobs;
+                          caches are listed but not stored
+  ~/.config/gzh-manager   configuration and the plugin registry;
+                          plugin binaries are listed but not stored
+                          (reinstall them with 'gz plugin install')
+
+Archives are encrypted with AES-256-GCM under a key derived from a
+passphrase, read from --passphrase-file, $GZ_BACKUP_PASSPHRASE or the
+terminal. Local backups are kept in ~/.gzh/backups. Archives can be pushed
+to and pulled from object storage:
+
+  s3://bucket/prefix[?region=...&endpoint=...]
+  gs://bucket/prefix
+  azblob://account/container/prefix
+  file:///mnt/usb/gz-backups (or a plain path)`,
+		Example: `  # Back up to ~/.gzh/backups and upload to S3
+  gz backup create --push s3://my-bucket/gz-backups
+
+  # On the new machine
+  gz backup pull --from s3://my-bucket/gz-backups
+  gz backup restore ~/.gzh/backups/gz-backup-laptop-20251016-093000.gzbk \
+    --map /home/alice/src=/Users/alice/src`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newCreateCmd())
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newInspectCmd())
+	cmd.AddCommand(newRestoreCmd())
+	cmd.AddCommand(newPushCmd())
+	cmd.AddCommand(newPullCmd())
+
+	return cmd
+}
+
+func newCreateCmd() *cobra.Command {
+	var (
+		output         string
+		push           string
+		passphraseFile string
+		only           []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an encrypted backup",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			roots, err := selectRoots(only)
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				dir, err := backup.DefaultDir()
+				if err != nil {
+					return err
+				}
+				host, _ := os.Hostname()
+				output = filepath.Join(dir, backup.DefaultName(host, time.Now()))
+			}
+			passphrase, err := readPassphrase(cmd, passphraseFile, true)
+			if err != nil {
+				return err
+			}
+
+			manifest, err := writeBackup(output, backup.CreateOptions{
+				Roots:      roots,
+				Passphrase: passphrase,
+				GZVersion:  version.Version,
+			})
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "✅ Backup written to %s\n", output)
+			printSummary(out, manifest)
+			for _, s := range manifest.Skipped {
+				fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  Skipped %s/%s: %s\n", s.Root, s.Path, s.Reason)
+			}
+
+			if push != "" {
+				store, err := cloud.OpenObjectStore(cmd.Context(), push)
+				if err != nil {
+					return err
+				}
+				key, err := backup.Push(cmd.Context(), store, output)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "☁️  Pushed %s to %s\n", key, store)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Archive path (default: ~/.gzh/backups/gz-backup-<host>-<time>.gzbk)")
+	cmd.Flags().StringVar(&push, "push", "", "Also upload the archive to this object store URL")
+	cmd.Flags().StringVar(&passphraseFile, "passphrase-file", "", "Read the passphrase from this file (default: $"+PassphraseEnvVar+" or prompt)")
+	cmd.Flags().StringSliceVar(&only, "only", nil, "Back up only these roots (state, config)")
+
+	return cmd
+}
+
+// writeBackup creates the archive next to path and renames it into place,
+// so an interrupted run leaves no partial archive.
+func writeBackup(path string, opts backup.CreateOptions) (*backup.Manifest, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create backup: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	manifest, err := backup.Create(tmp, opts)
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write backup: %w", closeErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	return manifest, nil
+}
+
+func newListCmd() *cobra.Command {
+	var from string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List local or remote backups",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var (
+				objects []cloud.ObjectInfo
+				err     error
+			)
+			if from == "" {
+				dir, dirErr := backup.DefaultDir()
+				if dirErr != nil {
+					return dirErr
+				}
+				objects, err = backup.ListDir(dir)
+			} else {
+				store, storeErr := cloud.OpenObjectStore(cmd.Context(), from)
+				if storeErr != nil {
+					return storeErr
+				}
+				objects, err = backup.List(cmd.Context(), store)
+			}
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if len(objects) == 0 {
+				fmt.Fprintln(out, "No backups found")
+				return nil
+			}
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tSIZE\tMODIFIED")
+			for _, o := range objects {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", o.Key, formatBytes(o.Size), o.Modified.Local().Format("2006-01-02 15:04"))
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Object store URL to list (default: ~/.gzh/backups)")
+
+	return cmd
+}
+
+func newInspectCmd() *cobra.Command {
+	var (
+		passphraseFile string
+		files          bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "inspect <archive>",
+		Short: "Show the contents of a backup",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			passphrase, err := readPassphrase(cmd, passphraseFile, false)
+			if err != nil {
+				return err
+			}
+			a, err := backup.OpenFile(resolveArchive(args[0]), passphrase)
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+
+			m := a.Manifest
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Created:  %s\n", m.CreatedAt.Local().Format(time.RFC3339))
+			fmt.Fprintf(out, "Host:     %s (%s)\n", m.Hostname, m.OS)
+			if m.GZVersion != "" {
+				fmt.Fprintf(out, "gz:       %s\n", m.GZVersion)
+			}
+			for _, r := range m.Roots {
+				fmt.Fprintf(out, "Root:     %s = %s\n", r.Name, r.Path)
+			}
+			printSummary(out, m)
+
+			if files {
+				w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "\nROOT\tPATH\tSIZE\tSTORED")
+				for _, f := range m.Files {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", f.Root, f.Path, formatBytes(f.Size), f.Stored)
+				}
+				return w.Flush()
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&passphraseFile, "passphrase-file", "", "Read the passphrase from this file (default: $"+PassphraseEnvVar+" or prompt)")
+	cmd.Flags().BoolVar(&files, "files", false, "List every file")
+
+	return cmd
+}
+
+func newRestoreCmd() *cobra.Command {
+	var (
+		passphraseFile string
+		from           string
+		only           []string
+		maps           []string
+		stateDir       string
+		configDir      string
+		force          bool
+		dryRun         bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "Restore a backup, remapping paths for a new machine",
+		Long: `Restore the files of a backup into the current user's gz directories.
+
+Absolute paths of the source machine inside restored text files, such as
+clone targets in synclone.yaml or plugin paths in installed.json, are
+rewritten: the source home and gz directories are mapped to the current
+ones automatically, and --map adds mappings for other locations.
+
+Existing files are kept unless --force is given.`,
+		Example: `  # Preview a restore
+  gz backup restore gz-backup-laptop-20251016-093000.gzbk --dry-run
+
+  # Restore only the configuration, moving the clone root
+  gz backup restore backup.gzbk --only config --map /home/alice/src=/Users/alice/src
+
+  # Download from S3 and restore over existing files
+  gz backup restore gz-backup-laptop-20251016-093000.gzbk --from s3://my-bucket/gz-backups --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mappings := make([]backup.Mapping, 0, len(maps))
+			for _, s := range maps {
+				m, err := backup.ParseMapping(s)
+				if err != nil {
+					return err
+				}
+				mappings = append(mappings, m)
+			}
+			targets, err := restoreTargets(only, stateDir, configDir)
+			if err != nil {
+				return err
+			}
+
+			path := resolveArchive(args[0])
+			if from != "" {
+				store, err := cloud.OpenObjectStore(cmd.Context(), from)
+				if err != nil {
+					return err
+				}
+				dir, err := backup.DefaultDir()
+				if err != nil {
+					return err
+				}
+				if path, err = backup.Pull(cmd.Context(), store, args[0], dir); err != nil {
+					return err
+				}
+			}
+
+			passphrase, err := readPassphrase(cmd, passphraseFile, false)
+			if err != nil {
+				return err
+			}
+			a, err := backup.OpenFile(path, passphrase)
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+			// 백업에 없는 루트는 복원 대상에서 뺀다
+			for name := range targets {
+				if _, ok := a.Manifest.Root(name); !ok && len(only) == 0 {
+					delete(targets, name)
+				}
+			}
+
+			res, err := backup.Restore(a, backup.RestoreOptions{
+				Targets:   targets,
+				Mappings:  mappings,
+				Overwrite: force,
+				DryRun:    dryRun,
+			})
+			if err != nil {
+				return err
+			}
+			printRestore(cmd.OutOrStdout(), res, dryRun)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&passphraseFile, "passphrase-file", "", "Read the passphrase from this file (default: $"+PassphraseEnvVar+" or prompt)")
+	cmd.Flags().StringVar(&from, "from", "", "Download the archive by name from this object store URL first")
+	cmd.Flags().StringSliceVar(&only, "only", nil, "Restore only these roots (state, config)")
+	cmd.Flags().StringArrayVar(&maps, "map", nil, "Rewrite a path prefix in restored files (OLD=NEW, repeatable)")
+	cmd.Flags().StringVar(&stateDir, "state-dir", "", "Restore the state root here (default: ~/.gzh)")
+	cmd.Flags().StringVar(&configDir, "config-dir", "", "Restore the config root here (default: ~/.config/gzh-manager)")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite existing files")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be restored without writing")
+
+	return cmd
+}
+
+func newPushCmd() *cobra.Command {
+	var to string
+
+	cmd := &cobra.Command{
+		Use:   "push <archive>",
+		Short: "Upload a backup to object storage",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := cloud.OpenObjectStore(cmd.Context(), to)
+			if err != nil {
+				return err
+			}
+			key, err := backup.Push(cmd.Context(), store, resolveArchive(args[0]))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "☁️  Pushed %s to %s\n", key, store)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Object store URL")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func newPullCmd() *cobra.Command {
+	var (
+		from   string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pull [name]",
+		Short: "Download a backup from object storage (default: the newest)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			store, err := cloud.OpenObjectStore(ctx, from)
+			if err != nil {
+				return err
+			}
+
+			var key string
+			if len(args) == 1 {
+				key = args[0]
+			} else {
+				objects, err := backup.List(ctx, store)
+				if err != nil {
+					return err
+				}
+				if len(objects) == 0 {
+					return fmt.Errorf("no backups found in %s", store)
+				}
+				key = objects[0].Key
+			}
+
+			if output == "" {
+				if output, err = backup.DefaultDir(); err != nil {
+					return err
+				}
+			}
+			path, err := backup.Pull(ctx, store, key, output)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "📥 Downloaded %s\n", path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Object store URL")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Directory to download into (default: ~/.gzh/backups)")
+	_ = cmd.MarkFlagRequired("from")
+
+	return cmd
+}
+
+// selectRoots returns the default roots, limited to the named ones.
+func selectRoots(only []string) ([]backup.Root, error) {
+	roots, err := backup.DefaultRoots()
+	if err != nil {
+		return nil, err
+	}
+	if len(only) == 0 {
+		return roots, nil
+	}
+
+	out := make([]backup.Root, 0, len(only))
+	for _, name := range only {
+		found := false
+		for _, r := range roots {
+			if r.Name == name {
+				out = append(out, r)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown root %q (valid: %s, %s)", name, backup.RootState, backup.RootConfig)
+		}
+	}
+	return out, nil
+}
+
+// restoreTargets maps the selected roots to their directories on this
+// machine, applying --state-dir and --config-dir.
+func restoreTargets(only []string, stateDir, configDir string) (map[string]string, error) {
+	roots, err := selectRoots(only)
+	if err != nil {
+		return nil, err
+	}
+	overrides := map[string]string{backup.RootState: stateDir, backup.RootConfig: configDir}
+
+	targets := make(map[string]string, len(roots))
+	for _, r := range roots {
+		dir := r.Path
+		if o := overrides[r.Name]; o != "" {
+			if dir, err = filepath.Abs(o); err != nil {
+				return nil, err
+			}
+		}
+		targets[r.Name] = dir
+	}
+	return targets, nil
+}
+
+// resolveArchive returns name as given when it exists, else its path in
+// the local backup directory.
+func resolveArchive(name string) string {
+	if _, err := os.Stat(name); err == nil || strings.ContainsRune(name, filepath.Separator) {
+		return name
+	}
+	if dir, err := backup.DefaultDir(); err == nil {
+		if p := filepath.Join(dir, name); fileExists(p) {
+			return p
+		}
+	}
+	return name
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// readPassphrase reads the archive passphrase from a file, the environment
+// or the terminal, asking twice when confirm is set.
+func readPassphrase(cmd *cobra.Command, file string, confirm bool) (string, error) {
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	if p := os.Getenv(PassphraseEnvVar); p != "" {
+		return p, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		// 파이프 입력은 첫 줄을 암호로 사용한다
+		line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		if p := strings.TrimRight(line, "\r\n"); p != "" {
+			return p, nil
+		}
+		return "", fmt.Errorf("%w: use --passphrase-file or $%s", backup.ErrNoPassphrase, PassphraseEnvVar)
+	}
+
+	prompt := func(label string) (string, error) {
+		fmt.Fprint(cmd.ErrOrStderr(), label)
+		p, err := term.ReadPassword(fd)
+		fmt.Fprintln(cmd.ErrOrStderr())
+		if err != nil {
+			return "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		return string(p), nil
+	}
+	p, err := prompt("Backup passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", backup.ErrNoPassphrase
+	}
+	if confirm {
+		again, err := prompt("Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if again != p {
+			return "", errors.New("passphrases do not match")
+		}
+	}
+	return p, nil
+}
+
+func printSummary(out io.Writer, m *backup.Manifest) {
+	for _, s := range m.Summarize() {
+		line := fmt.Sprintf("   %-7s %d files, %s", s.Root+":", s.Stored, formatBytes(s.Size))
+		if s.Metadata > 0 {
+			line += fmt.Sprintf(" (+%d listed only)", s.Metadata)
+		}
+		fmt.Fprintln(out, line)
+	}
+}
+
+func printRestore(out io.Writer, res *backup.RestoreResult, dryRun bool) {
+	verb := "Restored"
+	if dryRun {
+		verb = "Would restore"
+	}
+	fmt.Fprintf(out, "✅ %s %d files\n", verb, len(res.Restored))
+	if len(res.Remapped) > 0 {
+		fmt.Fprintf(out, "   %d files had paths rewritten:\n", len(res.Remapped))
+		for _, m := range res.Mappings {
+			fmt.Fprintf(out, "     %s → %s\n", m.From, m.To)
+		}
+	}
+	if len(res.Existing) > 0 {
+		sort.Strings(res.Existing)
+		fmt.Fprintf(out, "   %d existing files kept (use --force to overwrite):\n", len(res.Existing))
+		for _, p := range res.Existing {
+			fmt.Fprintf(out, "     %s\n", p)
+		}
+	}
+	if res.Metadata > 0 {
+		fmt.Fprintf(out, "   %d cached or plugin files were not stored; caches refill on use and plugins\n", res.Metadata)
+		fmt.Fprintln(out, "   can be reinstalled with 'gz plugin install'")
+	}
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewBackupCmd(nil)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetIn(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestBackupCreatePushRestore(t *testing.T) {
+	oldHome := t.TempDir()
+	t.Setenv("HOME", oldHome)
+	t.Setenv("GZH_CONFIG_DIR", "")
+	t.Setenv(PassphraseEnvVar, "s3cret")
+
+	config := filepath.Join(oldHome, ".config", "gzh-manager")
+	require.NoError(t, os.MkdirAll(config, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(config, "gzh.yaml"), []byte("root: "+oldHome+"/repos\n"), 0o600))
+
+	out, err := run(t, "create")
+	require.NoError(t, err)
+	assert.Contains(t, out, "✅ Backup written to "+filepath.Join(oldHome, ".gzh", "backups"))
+	assert.Contains(t, out, "config: 1 files")
+
+	remote := t.TempDir()
+	out, err = run(t, "list")
+	require.NoError(t, err)
+	assert.Contains(t, out, "gz-backup-")
+
+	entries, err := os.ReadDir(filepath.Join(oldHome, ".gzh", "backups"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	name := entries[0].Name()
+	_, err = run(t, "push", name, "--to", remote)
+	require.NoError(t, err)
+
+	// 새 머신: 원격에서 받아 홈 경로를 바꿔 복원
+	newHome := t.TempDir()
+	t.Setenv("HOME", newHome)
+	out, err = run(t, "restore", name, "--from", remote, "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Would restore 1 files")
+	assert.NoFileExists(t, filepath.Join(newHome, ".config", "gzh-manager", "gzh.yaml"))
+
+	out, err = run(t, "restore", name)
+	require.NoError(t, err)
+	assert.Contains(t, out, oldHome+" → "+newHome)
+	data, err := os.ReadFile(filepath.Join(newHome, ".config", "gzh-manager", "gzh.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "root: "+newHome+"/repos\n", string(data))
+
+	t.Setenv(PassphraseEnvVar, "wrong")
+	_, err = run(t, "inspect", name)
+	assert.ErrorContains(t, err, "wrong passphrase")
+
+	_, err = run(t, "create", "--only", "cache")
+	assert.ErrorContains(t, err, `unknown root "cache"`)
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type backupCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p backupCmdProvider) Command() *cobra.Command {
+	return NewBackupCmd(p.appCtx)
+}
+
+func (p backupCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "backup",
+		Category:     registry.CategoryUtility,
+		Version:      "1.0.0",
+		Priority:     85,
+		Experimental: false,
+		Dependencies: []string{},
+		Tags:         []string{"backup", "restore", "state", "config", "migration"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterBackupCmd registers the backup command with the command registry.
+func RegisterBackupCmd(appCtx *app.AppContext) {
+	registry.Register(backupCmdProvider{appCtx: appCtx})
+}
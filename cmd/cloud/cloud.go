@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package cloud implements the `gz cloud` command.
+package cloud
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+// NewCloudCmd creates the cloud command.
+func NewCloudCmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+
+	cmd := &cobra.Command{
+		Use:   "cloud",
+		Short: "Report on the cloud resources gz manages",
+		Long: `Report on the cloud resources gz creates, such as backup buckets and
+CI runners, across AWS, GCP and Azure.`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newCostsCmd())
+
+	return cmd
+}
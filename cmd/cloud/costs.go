@@ -0,0 +1,294 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	pkgcloud "github.com/gizzahub/gzh-cli/pkg/cloud"
+)
+
+type costsOptions struct {
+	providers   []string
+	months      int
+	tags        []string
+	groupTag    string
+	resources   string
+	format      string
+	gcpProject  string
+	gcpTable    string
+	azureScope  string
+	awsEndpoint string
+}
+
+func newCostsCmd() *cobra.Command {
+	opts := &costsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "costs",
+		Short: "Show monthly cost trends of gz-managed resources",
+		Long: `Pull costs from AWS Cost Explorer, the GCP Cloud Billing export to
+BigQuery and Azure Cost Management, filtered by tags, and attribute them to
+the resources gz manages.
+
+Costs are grouped by the --group-tag tag (default gz-resource), whose value
+is "<kind>/<name>", e.g. "backup-bucket/gz-backups" or "runner/linux-x64".
+A --resources file lists the resources gz knows about so that idle ones show
+up and tags not following the convention are still attributed:
+
+  - kind: backup-bucket
+    name: gz-backups
+    provider: aws
+  - kind: runner
+    name: linux-x64
+    provider: gcp
+    tag: ci-runner-linux
+
+Credentials come from the usual places: the AWS default credential chain,
+GOOGLE_OAUTH_ACCESS_TOKEN or gcloud, and AZURE_ACCESS_TOKEN or the az CLI.
+A provider that fails is reported without failing the other providers.`,
+		Example: `
+  # Last six months of AWS costs of resources tagged gz-managed=true
+  gz cloud costs --provider aws
+
+  # Twelve months across all providers as JSON
+  gz cloud costs --provider aws,gcp,azure --months 12 \
+    --gcp-project billing-prj --gcp-table billing-prj.billing.gcp_billing_export_v1_0000 \
+    --azure-scope /subscriptions/00000000-0000-0000-0000-000000000000 --format json
+
+  # Only the costs of one team's resources
+  gz cloud costs --provider aws --tag gz-managed=true --tag team=infra`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCosts(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.providers, "provider", []string{"aws"}, "Providers to query: aws, gcp, azure")
+	cmd.Flags().IntVar(&opts.months, "months", 6, "Number of months up to and including the current one")
+	cmd.Flags().StringArrayVar(&opts.tags, "tag", []string{pkgcloud.TagManaged + "=true"}, "Only include resources with this key=value tag (repeatable)")
+	cmd.Flags().StringVar(&opts.groupTag, "group-tag", pkgcloud.TagResource, "Tag identifying the resource of a cost")
+	cmd.Flags().StringVar(&opts.resources, "resources", "", "YAML file listing the managed resources")
+	cmd.Flags().StringVar(&opts.format, "format", "table", "Output format: table, json")
+	cmd.Flags().StringVar(&opts.gcpProject, "gcp-project", "", "GCP project running the billing export query")
+	cmd.Flags().StringVar(&opts.gcpTable, "gcp-table", "", "GCP billing export table (project.dataset.table)")
+	cmd.Flags().StringVar(&opts.azureScope, "azure-scope", "", "Azure Cost Management scope (e.g. /subscriptions/<id>)")
+	cmd.Flags().StringVar(&opts.awsEndpoint, "aws-endpoint", "", "Override the AWS Cost Explorer endpoint")
+	_ = cmd.Flags().MarkHidden("aws-endpoint")
+
+	return cmd
+}
+
+func runCosts(ctx context.Context, out io.Writer, opts *costsOptions) error {
+	if opts.format != "table" && opts.format != "json" {
+		return fmt.Errorf("unsupported format %q (use table or json)", opts.format)
+	}
+
+	tags, err := parseTags(opts.tags)
+	if err != nil {
+		return err
+	}
+	q := pkgcloud.MonthlyCostQuery(time.Now(), opts.months)
+	q.Tags = tags
+	q.GroupTag = opts.groupTag
+
+	var resources []pkgcloud.ManagedResource
+	if opts.resources != "" {
+		if resources, err = pkgcloud.LoadManagedResources(opts.resources); err != nil {
+			return err
+		}
+	}
+
+	sources, err := costSources(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	report := pkgcloud.CollectCosts(ctx, q, sources, resources)
+	if len(report.Errors) == len(sources) {
+		var msgs []string
+		for provider, msg := range report.Errors {
+			msgs = append(msgs, provider+": "+msg)
+		}
+		sort.Strings(msgs)
+		return fmt.Errorf("failed to read costs:\n  %s", strings.Join(msgs, "\n  "))
+	}
+
+	if opts.format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printCostReport(out, report)
+	return nil
+}
+
+// parseTags turns key=value flags into a tag filter.
+func parseTags(values []string) (map[string]string, error) {
+	tags := make(map[string]string, len(values))
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid tag %q (expected key=value)", v)
+		}
+		tags[key] = value
+	}
+	return tags, nil
+}
+
+func costSources(ctx context.Context, opts *costsOptions) ([]pkgcloud.CostSource, error) {
+	var sources []pkgcloud.CostSource
+	seen := make(map[string]bool)
+	for _, provider := range opts.providers {
+		provider = strings.ToLower(strings.TrimSpace(provider))
+		if seen[provider] {
+			continue
+		}
+		seen[provider] = true
+
+		var (
+			src pkgcloud.CostSource
+			err error
+		)
+		switch provider {
+		case "aws":
+			src, err = pkgcloud.NewAWSCostExplorer(ctx, pkgcloud.AWSCostOptions{Endpoint: opts.awsEndpoint})
+		case "gcp":
+			src, err = pkgcloud.NewGCPBilling(pkgcloud.GCPBillingOptions{
+				Project: firstNonEmpty(opts.gcpProject, os.Getenv("GOOGLE_CLOUD_PROJECT")),
+				Table:   opts.gcpTable,
+			})
+		case "azure":
+			src, err = pkgcloud.NewAzureCostManagement(pkgcloud.AzureCostOptions{Scope: opts.azureScope})
+		default:
+			return nil, fmt.Errorf("unsupported provider %q (use aws, gcp or azure)", provider)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", provider, err)
+		}
+		sources = append(sources, src)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no provider selected")
+	}
+	return sources, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// printCostReport renders one row per resource and month column, followed by
+// per-kind subtotals and the overall total.
+func printCostReport(out io.Writer, report *pkgcloud.CostReport) {
+	fmt.Fprintf(out, "💰 Cloud costs %s – %s (%s)\n\n", first(report.Months), last(report.Months), report.Currency)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := []string{"KIND", "RESOURCE", "PROVIDER"}
+	header = append(header, report.Months...)
+	header = append(header, "TOTAL", "CHANGE")
+	fmt.Fprintln(w, strings.Join(header, "\t")+"\t")
+
+	for _, r := range report.Resources {
+		row := []string{r.Kind, r.Name, r.Provider}
+		row = append(row, amounts(r.Monthly)...)
+		row = append(row, formatAmount(r.Total), formatChange(r.Monthly, r.Change))
+		fmt.Fprintln(w, strings.Join(row, "\t")+"\t")
+	}
+
+	kinds := make([]string, 0, len(report.Kinds))
+	for kind := range report.Kinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	fmt.Fprintln(w, "\t")
+	for _, kind := range kinds {
+		row := []string{kind, "(all)", ""}
+		row = append(row, amounts(report.Kinds[kind])...)
+		row = append(row, formatAmount(sum(report.Kinds[kind])), formatChange(report.Kinds[kind], trend(report.Kinds[kind])))
+		fmt.Fprintln(w, strings.Join(row, "\t")+"\t")
+	}
+	row := []string{"TOTAL", "", ""}
+	row = append(row, amounts(report.Totals)...)
+	row = append(row, formatAmount(report.Total), formatChange(report.Totals, trend(report.Totals)))
+	fmt.Fprintln(w, strings.Join(row, "\t")+"\t")
+	_ = w.Flush()
+
+	if len(report.Errors) > 0 {
+		providers := make([]string, 0, len(report.Errors))
+		for p := range report.Errors {
+			providers = append(providers, p)
+		}
+		sort.Strings(providers)
+		fmt.Fprintln(out)
+		for _, p := range providers {
+			fmt.Fprintf(out, "⚠️  %s costs missing: %s\n", p, report.Errors[p])
+		}
+	}
+}
+
+func amounts(values []float64) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = formatAmount(v)
+	}
+	return out
+}
+
+func formatAmount(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+// formatChange shows the month-over-month change; "-" when the previous
+// month had no cost.
+func formatChange(monthly []float64, change float64) string {
+	if n := len(monthly); n < 2 || monthly[n-2] <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", change)
+}
+
+func trend(monthly []float64) float64 {
+	n := len(monthly)
+	if n < 2 || monthly[n-2] <= 0 {
+		return 0
+	}
+	return (monthly[n-1] - monthly[n-2]) / monthly[n-2] * 100
+}
+
+func sum(values []float64) float64 {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func last(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgcloud "github.com/gizzahub/gzh-cli/pkg/cloud"
+)
+
+func TestParseTags(t *testing.T) {
+	tags, err := parseTags([]string{"gz-managed=true", "team=infra", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"gz-managed": "true", "team": "infra", "empty": ""}, tags)
+
+	_, err = parseTags([]string{"missing-value"})
+	assert.Error(t, err)
+}
+
+func TestCostSourcesValidatesProviders(t *testing.T) {
+	_, err := costSources(t.Context(), &costsOptions{providers: []string{"oracle"}})
+	assert.ErrorContains(t, err, "unsupported provider")
+
+	_, err = costSources(t.Context(), &costsOptions{providers: []string{"gcp"}})
+	assert.ErrorContains(t, err, "billing export table")
+
+	_, err = costSources(t.Context(), &costsOptions{providers: []string{"azure"}})
+	assert.ErrorContains(t, err, "scope")
+}
+
+func TestPrintCostReport(t *testing.T) {
+	report := &pkgcloud.CostReport{
+		Months:   []string{"2025-01", "2025-02"},
+		Currency: "USD",
+		Resources: []pkgcloud.ResourceCost{
+			{Kind: "runner", Name: "linux-x64", Provider: "gcp", Monthly: []float64{40, 20}, Total: 60, Change: -50},
+			{Kind: "backup-bucket", Name: "gz-backups", Provider: "aws", Monthly: []float64{0, 16}, Total: 16},
+		},
+		Kinds: map[string][]float64{
+			"runner":        {40, 20},
+			"backup-bucket": {0, 16},
+		},
+		Totals: []float64{40, 36},
+		Total:  76,
+		Errors: map[string]string{"azure": "no Azure credentials"},
+	}
+
+	var buf bytes.Buffer
+	printCostReport(&buf, report)
+	out := buf.String()
+
+	assert.Contains(t, out, "2025-01 – 2025-02 (USD)")
+	assert.Regexp(t, `runner\s+linux-x64\s+gcp\s+40.00\s+20.00\s+60.00\s+-50.0%`, out)
+	assert.Regexp(t, `backup-bucket\s+gz-backups\s+aws\s+0.00\s+16.00\s+16.00\s+-`, out)
+	assert.Regexp(t, `TOTAL\s+40.00\s+36.00\s+76.00\s+-10.0%`, out)
+	assert.Contains(t, out, "azure costs missing: no Azure credentials")
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type cloudCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p cloudCmdProvider) Command() *cobra.Command {
+	return NewCloudCmd(p.appCtx)
+}
+
+func (p cloudCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "cloud",
+		Category:     registry.CategoryUtility,
+		Version:      "1.0.0",
+		Priority:     85,
+		Experimental: false,
+		Dependencies: []string{},
+		Tags:         []string{"cloud", "costs", "billing", "aws", "gcp", "azure"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterCloudCmd registers the cloud command with the command registry.
+func RegisterCloudCmd(appCtx *app.AppContext) {
+	registry.Register(cloudCmdProvider{appCtx: appCtx})
+}
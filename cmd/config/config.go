@@ -0,0 +1,228 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package config implements the gz config command for validating
+// configuration files and managing profiles.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
+	synclone "github.com/gizzahub/gzh-cli/pkg/synclone"
+)
+
+// NewConfigCmd creates the config command.
+func NewConfigCmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+	cmd := &cobra.Command{
+		Use:          "config",
+		Short:        "Inspect and validate configuration files and profiles",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newValidateCmd())
+	cmd.AddCommand(newSchemaCmd())
+	cmd.AddCommand(newProfileCmd())
+	cmd.AddCommand(newSetCmd())
+	cmd.AddCommand(newEncryptCmd())
+	cmd.AddCommand(newDecryptCmd())
+
+	return cmd
+}
+
+type validateOptions struct {
+	configType string
+	schema     bool
+	format     string
+}
+
+func newValidateCmd() *cobra.Command {
+	o := &validateOptions{format: "text"}
+
+	cmd := &cobra.Command{
+		Use:   "validate [file...]",
+		Short: "Validate configuration files",
+		Long: `Validate configuration files.
+
+By default each file is loaded the way the owning command loads it, which
+catches errors the command would fail on. With --schema the file is also
+checked against the JSON Schema of its type, which reports every problem at
+once with its line and column, the allowed values, and suggestions for
+misspelled keys.
+
+The config type is detected from the document unless --type is given.
+Without files, the gzh.yaml found in the standard locations is validated.
+
+Config types: ` + strings.Join(configTypeNames(), ", "),
+		Example: `  # Validate the default gzh.yaml against its schema
+  gz config validate --schema
+
+  # Validate several files, reporting JSON
+  gz config validate --schema --format json gzh.yaml repo-config.yaml
+
+  # Validate a legacy bulk-clone file
+  gz config validate --schema --type bulk-clone bulk-clone.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.OutOrStdout(), args)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.configType, "type", "", "Config type (default: detect; "+strings.Join(configTypeNames(), ", ")+")")
+	cmd.Flags().BoolVar(&o.schema, "schema", false, "Validate against the JSON Schema of the config type")
+	cmd.Flags().StringVar(&o.format, "format", o.format, "Output format (text, json)")
+
+	return cmd
+}
+
+func (o *validateOptions) run(out io.Writer, files []string) error {
+	if o.format != "text" && o.format != "json" {
+		return fmt.Errorf("invalid format: %s (valid: text, json)", o.format)
+	}
+	if o.configType != "" {
+		if _, err := pkgconfig.LookupConfigSchema(o.configType); err != nil {
+			return err
+		}
+	}
+	if len(files) == 0 {
+		path, err := pkgconfig.FindConfigFile()
+		if err != nil {
+			return fmt.Errorf("no file given and no gzh.yaml found: %w", err)
+		}
+		files = []string{path}
+	}
+
+	results := make([]*pkgconfig.SchemaValidationResult, 0, len(files))
+	for _, file := range files {
+		result, err := o.validateFile(file)
+		if err != nil {
+			return err
+		}
+		results = append(results, result)
+	}
+
+	if err := printResults(out, o.format, results); err != nil {
+		return err
+	}
+
+	invalid := 0
+	for _, r := range results {
+		if !r.Valid {
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d config files are invalid", invalid, len(results))
+	}
+	return nil
+}
+
+// validateFile runs the schema check (with --schema) and then the loader of
+// the config type. The loader only runs on schema-valid files, since its
+// error would usually repeat the first schema issue.
+func (o *validateOptions) validateFile(file string) (*pkgconfig.SchemaValidationResult, error) {
+	var result *pkgconfig.SchemaValidationResult
+	if o.schema {
+		r, err := pkgconfig.ValidateFileWithSchema(file, o.configType)
+		if err != nil {
+			return nil, err
+		}
+		if !r.Valid {
+			return r, nil
+		}
+		result = r
+	} else {
+		configType := o.configType
+		if configType == "" {
+			detected, err := pkgconfig.DetectConfigFileType(file)
+			if err != nil {
+				return nil, err
+			}
+			configType = detected
+		}
+		result = &pkgconfig.SchemaValidationResult{File: file, Type: configType, Valid: true}
+	}
+
+	if err := loadAs(result.Type, file); err != nil {
+		result.Valid = false
+		result.Issues = append(result.Issues, pkgconfig.SchemaIssue{Message: err.Error()})
+	}
+	return result, nil
+}
+
+// loadAs loads file with the loader of the given config type.
+func loadAs(configType, file string) error {
+	var err error
+	switch configType {
+	case pkgconfig.ConfigTypeGZH:
+		_, err = pkgconfig.NewConfigFactory().LoadConfigFromPath(file)
+	case pkgconfig.ConfigTypeRepoConfig:
+		_, err = pkgconfig.LoadRepoConfig(file)
+	case pkgconfig.ConfigTypeBulkClone:
+		_, err = synclone.LoadConfig(file)
+	}
+	return err
+}
+
+func printResults(out io.Writer, format string, results []*pkgconfig.SchemaValidationResult) error {
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+
+	for _, r := range results {
+		if r.Valid {
+			fmt.Fprintf(out, "✓ %s (%s): valid\n", r.File, r.Type)
+			continue
+		}
+		fmt.Fprintf(out, "✗ %s (%s): %d issue(s)\n", r.File, r.Type, len(r.Issues))
+		for _, issue := range r.Issues {
+			if issue.Line > 0 {
+				fmt.Fprintf(out, "  %s:%s\n", r.File, issue)
+			} else {
+				fmt.Fprintf(out, "  %s: %s\n", r.File, issue)
+			}
+		}
+	}
+	return nil
+}
+
+func newSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema <type>",
+		Short: "Print the JSON Schema of a config type",
+		Long: `Print the JSON Schema of a config type, e.g. for editor integration.
+
+Config types: ` + strings.Join(configTypeNames(), ", "),
+		Example: `  gz config schema gzh > gzh.schema.json`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := pkgconfig.LookupConfigSchema(args[0])
+			if err != nil {
+				return err
+			}
+			data, err := s.Schema()
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return err
+		},
+	}
+}
+
+func configTypeNames() []string {
+	schemas := pkgconfig.ConfigSchemas()
+	names := make([]string, 0, len(schemas))
+	for _, s := range schemas {
+		names = append(names, s.Name)
+	}
+	return names
+}
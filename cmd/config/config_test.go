@@ -0,0 +1,188 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestValidateCmdSchema(t *testing.T) {
+	bad := writeFile(t, "gzh.yaml", `version: "1.0.0"
+providers:
+  github:
+    token: x
+    organizations:
+      - name: acme
+        clone_dir: ./acme
+        visibility: secret
+`)
+
+	var out bytes.Buffer
+	cmd := NewConfigCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"validate", "--schema", bad})
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "1 of 1 config files are invalid")
+	assert.Contains(t, out.String(), bad+`:8:21: providers.github.organizations[0].visibility: invalid value "secret" (allowed: public, private, all)`)
+
+	out.Reset()
+	o := &validateOptions{schema: true, format: "json"}
+	require.Error(t, o.run(&out, []string{bad}))
+	var results []map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+	require.Len(t, results, 1)
+	assert.Equal(t, "gzh", results[0]["type"])
+}
+
+func TestValidateCmdLoader(t *testing.T) {
+	good := writeFile(t, "bulk-clone.yaml", `version: "1.0"
+default:
+  protocol: https
+repo_roots:
+  - root_path: ./repos
+    provider: github
+    protocol: https
+    org_name: acme
+`)
+
+	var out bytes.Buffer
+	o := &validateOptions{schema: true, format: "text"}
+	require.NoError(t, o.run(&out, []string{good}))
+	assert.Contains(t, out.String(), "(bulk-clone): valid")
+
+	o = &validateOptions{format: "text", configType: "nope"}
+	assert.ErrorContains(t, o.run(&out, []string{good}), "unknown config type")
+}
+
+func TestSchemaCmd(t *testing.T) {
+	var out bytes.Buffer
+	cmd := NewConfigCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"schema", "repo-config"})
+	require.NoError(t, cmd.Execute())
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &schema))
+	assert.Equal(t, "Repository configuration", schema["title"])
+}
+
+func TestProfileCmds(t *testing.T) {
+	t.Setenv("GZH_PROFILE", "work")
+	path := writeFile(t, "gzh.yaml", `version: "1.0.0"
+global:
+  clone_base_dir: ~/repos
+providers:
+  github:
+    token: ${GITHUB_TOKEN}
+    organizations:
+      - name: acme
+        clone_dir: ./acme
+profiles:
+  work:
+    description: Day job
+    global:
+      clone_base_dir: ~/work
+`)
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := NewConfigCmd(nil)
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"profile"}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("create", "customer-x", "--config", path, "--extends", "work",
+		"--log-level", "debug", "--token", "gitlab=${CUSTOMER_X_TOKEN}")
+	require.NoError(t, err)
+	assert.Contains(t, out, `Added profile "customer-x"`)
+
+	_, err = run("create", "bad", "--config", path, "--log-level", "loud")
+	assert.ErrorContains(t, err, "invalid log level")
+
+	out, err = run("list", "--config", path)
+	require.NoError(t, err)
+	assert.Contains(t, out, "customer-x  work")
+	assert.Regexp(t, `\* work\s+-\s+Day job`, out)
+
+	out, err = run("diff", "work", "customer-x", "--config", path)
+	require.NoError(t, err)
+	assert.Contains(t, out, "+ global.log_level: debug")
+	assert.Contains(t, out, "+ providers.gitlab.token: ${CUSTOMER_X_TOKEN}")
+
+	out, err = run("diff", "work", "--config", path, "--format", "json")
+	require.NoError(t, err)
+	var diffs []map[string]string
+	require.NoError(t, json.Unmarshal([]byte(out), &diffs))
+	assert.Equal(t, []map[string]string{{"path": "global.clone_base_dir", "from": "~/repos", "to": "~/work"}}, diffs)
+}
+
+func TestSetCmd(t *testing.T) {
+	path := writeFile(t, "gzh.yaml", "version: \"1.0.0\"\n# keep me\nglobal:\n  clone_base_dir: ~/repos\n")
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := NewConfigCmd(nil)
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"set"}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("locale", "ko_KR.UTF-8", "--config", path)
+	require.NoError(t, err)
+	assert.Contains(t, out, `locale 값을 "ko"(으)로 설정했습니다`, "the confirmation uses the new locale")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "locale: ko")
+	assert.Contains(t, string(data), "# keep me")
+
+	_, err = run("locale", "fr", "--config", path)
+	assert.ErrorContains(t, err, "supported: en, ja, ko, zh")
+	_, err = run("colour", "blue", "--config", path)
+	assert.ErrorContains(t, err, "unknown key")
+}
+
+func TestEncryptAndDecryptCmd(t *testing.T) {
+	path := writeFile(t, "gzh.yaml", "version: \"1.0.0\"\nproviders:\n  github:\n    token: ghp_x\n    organizations: []\n")
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := NewConfigCmd(nil)
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("encrypt", "--dry-run", path)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Would encrypt 1 field(s)")
+	assert.Contains(t, out, "token (line 4)")
+
+	// A file without encrypted values is printed as is.
+	out, err = run("decrypt", path)
+	require.NoError(t, err)
+	assert.Contains(t, out, "token: ghp_x")
+}
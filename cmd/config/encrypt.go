@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+func newEncryptCmd() *cobra.Command {
+	var opts pkgconfig.EncryptOptions
+
+	cmd := &cobra.Command{
+		Use:   "encrypt [file]",
+		Short: "Encrypt the secret fields of a configuration file",
+		Long: `Encrypt the secret fields of a configuration file in place so that it can
+be committed to git.
+
+By default every secret field (` + strings.Join(pkgconfig.DefaultEncryptedKeys(), ", ") + `)
+is encrypted with age and written as ENC[age,...]. With --sops the whole
+file is handed to sops, which encrypts the same fields and adds its
+metadata. Environment variable and secret store references are left alone.
+
+The recipients are the --recipient keys, then ` + pkgconfig.EnvAgeRecipients + `, then the
+public key of the age identity used for decryption. Encrypted files are
+decrypted transparently when gz loads them, using ` + pkgconfig.EnvAgeIdentity + `,
+` + pkgconfig.EnvAgeIdentityFile + `, SOPS_AGE_KEY_FILE, ~/.config/sops/age/keys.txt or
+the "` + pkgconfig.AgeIdentityAccount + `" entry of the OS secret store.
+
+Requires the age (or sops) command line tool.`,
+		Example: `  # Encrypt the tokens of the default gzh.yaml to your own key
+  gz config encrypt
+
+  # Encrypt for the team and CI, including an extra field
+  gz config encrypt gzh.yaml --recipient age1... --recipient age1... --key slack_url
+
+  # Encrypt with sops
+  gz config encrypt --sops --recipient age1...`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := resolveConfigPath(firstArg(args))
+			if err != nil {
+				return err
+			}
+			result, err := pkgconfig.EncryptConfigFile(path, opts)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if len(result.Encrypted) == 0 {
+				fmt.Fprintf(out, "No plaintext secrets found in %s\n", path)
+			} else {
+				verb := "Encrypted"
+				if opts.DryRun {
+					verb = "Would encrypt"
+				}
+				fmt.Fprintf(out, "%s %d field(s) in %s with %s:\n", verb, len(result.Encrypted), path, result.Method)
+				for _, field := range result.Encrypted {
+					fmt.Fprintf(out, "  %s\n", field)
+				}
+			}
+			if len(result.Skipped) > 0 {
+				fmt.Fprintf(out, "Left %d referenced field(s) unencrypted:\n", len(result.Skipped))
+				for _, field := range result.Skipped {
+					fmt.Fprintf(out, "  %s\n", field)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&opts.Recipients, "recipient", "r", nil, "age public key to encrypt to (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.Keys, "key", nil, "Additional field name to encrypt (repeatable)")
+	cmd.Flags().BoolVar(&opts.SOPS, "sops", false, "Encrypt the file with sops instead of value by value")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "List the fields that would be encrypted")
+
+	return cmd
+}
+
+func newDecryptCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "decrypt [file]",
+		Short: "Print a configuration file with its secrets decrypted",
+		Long: `Print a configuration file with its secrets decrypted.
+
+The decrypted file is only written to standard output; gz refuses to write
+decrypted secrets back to disk. To change a secret, replace the encrypted
+value with the new plaintext and run gz config encrypt again.`,
+		Example: `  gz config decrypt gzh.yaml | less`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := resolveConfigPath(firstArg(args))
+			if err != nil {
+				return err
+			}
+			data, err := pkgconfig.DecryptConfigFile(path)
+			if err != nil {
+				return err
+			}
+			_, err = cmd.OutOrStdout().Write(data)
+			return err
+		},
+	}
+}
+
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
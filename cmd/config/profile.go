@@ -0,0 +1,307 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+var profileLogLevels = []string{"debug", "info", "warn", "error"}
+
+func newProfileCmd() *cobra.Command {
+	var configPath string
+
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage configuration profiles",
+		Long: `Manage named configuration profiles in gzh.yaml.
+
+A profile layers over the base configuration: its global settings replace
+the base values, and its providers are merged by name, so a profile can
+swap just the token or target directory of a provider. A profile may
+extend another profile, which is applied first.
+
+The active profile is selected with the global --profile flag or the
+GZH_PROFILE environment variable.`,
+		Example: `  # profiles:
+  #   work:
+  #     global: {clone_base_dir: ~/work, log_level: info}
+  #     providers:
+  #       github: {token: ${WORK_GITHUB_TOKEN}}
+  #   customer-x:
+  #     extends: work
+  #     providers:
+  #       gitlab: {token: ${CUSTOMER_X_TOKEN}, api_url: https://git.customer-x.com}
+
+  gz config profile list
+  gz --profile customer-x synclone github
+  GZH_PROFILE=personal gz git repo clone --provider github --org me`,
+	}
+
+	cmd.PersistentFlags().StringVar(&configPath, "config", "", "Config file (default: the gzh.yaml found in the standard locations)")
+
+	cmd.AddCommand(newProfileListCmd(&configPath))
+	cmd.AddCommand(newProfileCreateCmd(&configPath))
+	cmd.AddCommand(newProfileDiffCmd(&configPath))
+
+	return cmd
+}
+
+// resolveConfigPath returns path, or the default gzh.yaml when it is empty.
+func resolveConfigPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	found, err := pkgconfig.FindConfigFile()
+	if err != nil {
+		return "", fmt.Errorf("no --config given and no gzh.yaml found: %w", err)
+	}
+	return found, nil
+}
+
+type profileSummary struct {
+	Name        string `json:"name"`
+	Extends     string `json:"extends,omitempty"`
+	Description string `json:"description,omitempty"`
+	Active      bool   `json:"active"`
+}
+
+func newProfileListCmd(configPath *string) *cobra.Command {
+	format := "text"
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List configured profiles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid format: %s (valid: text, json)", format)
+			}
+			path, err := resolveConfigPath(*configPath)
+			if err != nil {
+				return err
+			}
+			cfg, err := pkgconfig.ReadProfiles(path)
+			if err != nil {
+				return err
+			}
+			return printProfiles(cmd.OutOrStdout(), format, cfg, pkgconfig.ActiveProfileName("", nil))
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", format, "Output format (text, json)")
+
+	return cmd
+}
+
+func printProfiles(out io.Writer, format string, cfg *pkgconfig.UnifiedConfig, active string) error {
+	profiles := make([]profileSummary, 0, len(cfg.Profiles))
+	for _, name := range cfg.ProfileNames() {
+		p := cfg.Profiles[name]
+		summary := profileSummary{Name: name, Active: name == active}
+		if p != nil {
+			summary.Extends = p.Extends
+			summary.Description = p.Description
+		}
+		profiles = append(profiles, summary)
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(profiles)
+	}
+
+	if len(profiles) == 0 {
+		_, err := fmt.Fprintln(out, "No profiles configured")
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tEXTENDS\tDESCRIPTION")
+	for _, p := range profiles {
+		marker := " "
+		if p.Active {
+			marker = "*"
+		}
+		extends := p.Extends
+		if extends == "" {
+			extends = "-"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\t%s\n", marker, p.Name, extends, p.Description)
+	}
+	return w.Flush()
+}
+
+type profileCreateOptions struct {
+	extends         string
+	description     string
+	defaultProvider string
+	cloneDir        string
+	logLevel        string
+	tokens          map[string]string
+	usernames       map[string]string
+	apiURLs         map[string]string
+}
+
+func newProfileCreateCmd(configPath *string) *cobra.Command {
+	o := &profileCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Add a profile to the config file",
+		Long: `Add a profile to the config file. The file is edited in place, keeping
+comments and formatting of the existing content.
+
+Tokens are stored as given; prefer environment variable references such as
+'${WORK_GITHUB_TOKEN}' or keyring references over literal tokens.`,
+		Example: `  gz config profile create work --clone-dir ~/work --token 'github=${WORK_GITHUB_TOKEN}'
+  gz config profile create customer-x --extends work --log-level debug \
+    --token 'gitlab=${CUSTOMER_X_TOKEN}' --api-url gitlab=https://git.customer-x.com`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := resolveConfigPath(*configPath)
+			if err != nil {
+				return err
+			}
+			profile, err := o.profile()
+			if err != nil {
+				return err
+			}
+			if err := pkgconfig.AddProfile(path, args[0], profile); err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "✓ Added profile %q to %s\n", args[0], path)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&o.extends, "extends", "", "Profile to build on")
+	cmd.Flags().StringVar(&o.description, "description", "", "Description shown by profile list")
+	cmd.Flags().StringVar(&o.defaultProvider, "default-provider", "", "Default provider of the profile")
+	cmd.Flags().StringVar(&o.cloneDir, "clone-dir", "", "Base directory for clones")
+	cmd.Flags().StringVar(&o.logLevel, "log-level", "", "Log level ("+strings.Join(profileLogLevels, ", ")+")")
+	cmd.Flags().StringToStringVar(&o.tokens, "token", nil, "Provider token as provider=token (repeatable)")
+	cmd.Flags().StringToStringVar(&o.usernames, "username", nil, "Provider username as provider=name (repeatable)")
+	cmd.Flags().StringToStringVar(&o.apiURLs, "api-url", nil, "Provider API URL as provider=url (repeatable)")
+
+	return cmd
+}
+
+func (o *profileCreateOptions) profile() (*pkgconfig.ProfileConfig, error) {
+	if o.logLevel != "" && !slices.Contains(profileLogLevels, o.logLevel) {
+		return nil, fmt.Errorf("invalid log level %q (valid: %s)", o.logLevel, strings.Join(profileLogLevels, ", "))
+	}
+
+	p := &pkgconfig.ProfileConfig{
+		Extends:         o.extends,
+		Description:     o.description,
+		DefaultProvider: o.defaultProvider,
+	}
+	if o.cloneDir != "" || o.logLevel != "" {
+		p.Global = &pkgconfig.GlobalSettings{CloneBaseDir: o.cloneDir, LogLevel: o.logLevel}
+	}
+
+	provider := func(name string) *pkgconfig.ProviderConfig {
+		if p.Providers == nil {
+			p.Providers = make(map[string]*pkgconfig.ProviderConfig)
+		}
+		if p.Providers[name] == nil {
+			p.Providers[name] = &pkgconfig.ProviderConfig{}
+		}
+		return p.Providers[name]
+	}
+	for name, token := range o.tokens {
+		provider(name).Token = token
+	}
+	for name, username := range o.usernames {
+		provider(name).Username = username
+	}
+	for name, url := range o.apiURLs {
+		provider(name).APIURL = url
+	}
+	return p, nil
+}
+
+func newProfileDiffCmd(configPath *string) *cobra.Command {
+	format := "text"
+
+	cmd := &cobra.Command{
+		Use:   "diff <profile> [other-profile]",
+		Short: "Show the settings that differ between profiles",
+		Long: `Show the effective settings that differ between two profiles, or between
+the base configuration and a profile when only one is given. Inherited
+settings are resolved, and literal tokens are masked.`,
+		Example: `  gz config profile diff work
+  gz config profile diff work personal`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid format: %s (valid: text, json)", format)
+			}
+			path, err := resolveConfigPath(*configPath)
+			if err != nil {
+				return err
+			}
+			cfg, err := pkgconfig.ReadProfiles(path)
+			if err != nil {
+				return err
+			}
+
+			from, to := "", args[0]
+			if len(args) == 2 {
+				from, to = args[0], args[1]
+			}
+			diffs, err := cfg.DiffProfiles(from, to)
+			if err != nil {
+				return err
+			}
+			return printProfileDiff(cmd.OutOrStdout(), format, from, to, diffs)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", format, "Output format (text, json)")
+
+	return cmd
+}
+
+func printProfileDiff(out io.Writer, format, from, to string, diffs []pkgconfig.ProfileDifference) error {
+	if format == "json" {
+		if diffs == nil {
+			diffs = []pkgconfig.ProfileDifference{}
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diffs)
+	}
+
+	if from == "" {
+		from = "base"
+	}
+	if len(diffs) == 0 {
+		_, err := fmt.Fprintf(out, "No differences between %s and %s\n", from, to)
+		return err
+	}
+
+	fmt.Fprintf(out, "--- %s\n+++ %s\n", from, to)
+	for _, d := range diffs {
+		switch {
+		case d.From == "":
+			fmt.Fprintf(out, "+ %s: %s\n", d.Path, d.To)
+		case d.To == "":
+			fmt.Fprintf(out, "- %s: %s\n", d.Path, d.From)
+		default:
+			fmt.Fprintf(out, "~ %s: %s -> %s\n", d.Path, d.From, d.To)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type configCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p configCmdProvider) Command() *cobra.Command {
+	return NewConfigCmd(p.appCtx)
+}
+
+func (p configCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "config",
+		Category:     registry.CategoryConfig,
+		Version:      "1.0.0",
+		Priority:     30,
+		Experimental: false,
+		Tags:         []string{"config", "validate", "schema", "profile", "locale"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterConfigCmd registers the config command with the command registry.
+func RegisterConfigCmd(appCtx *app.AppContext) {
+	registry.Register(configCmdProvider{appCtx: appCtx})
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
+	"github.com/gizzahub/gzh-cli/pkg/i18n"
+)
+
+// settableKeys are the global settings `gz config set` can change. Each
+// normalizes and validates a value before it is written.
+var settableKeys = map[string]func(value string) (string, error){
+	"locale": func(value string) (string, error) {
+		locale := i18n.Normalize(value)
+		if !i18n.IsSupported(locale) {
+			return "", fmt.Errorf("unsupported locale %q (supported: %s)", value, strings.Join(i18n.Supported(), ", "))
+		}
+		return locale, nil
+	},
+}
+
+func settableKeyNames() []string {
+	names := make([]string, 0, len(settableKeys))
+	for name := range settableKeys {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func newSetCmd() *cobra.Command {
+	var configPath string
+
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a configuration value",
+		Long: `Set a global setting in gzh.yaml. The file is edited in place, keeping
+comments and formatting of the existing content.
+
+Keys: ` + strings.Join(settableKeyNames(), ", ") + `
+
+The locale selects the language of command help, error messages and doctor
+reports (` + strings.Join(i18n.Supported(), ", ") + `). Without it the language is detected from
+LC_ALL, LC_MESSAGES and LANG; $` + i18n.LocaleEnvVar + ` overrides both.`,
+		Example: `  gz config set locale ko
+  gz config set locale ja_JP.UTF-8 --config ./gzh.yaml`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key := args[0]
+			normalize, ok := settableKeys[key]
+			if !ok {
+				return fmt.Errorf("unknown key %q (available: %s)", key, strings.Join(settableKeyNames(), ", "))
+			}
+			value, err := normalize(args[1])
+			if err != nil {
+				return err
+			}
+
+			path, err := resolveConfigPath(configPath)
+			if err != nil {
+				return err
+			}
+			if err := pkgconfig.SetGlobalSetting(path, key, value); err != nil {
+				return err
+			}
+
+			// Confirm a new locale in the language just selected.
+			l := i18n.Default()
+			if key == "locale" {
+				if selected, err := i18n.New(value); err == nil {
+					l = selected
+				}
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "✓ %s\n", l.T("Set %s to %q in %s", key, value, path))
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&configPath, "config", "", "Config file (default: the gzh.yaml found in the standard locations)")
+
+	return cmd
+}
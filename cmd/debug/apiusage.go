@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/pkg/api"
+)
+
+// tokenEnvVars are checked to put a name on token fingerprints.
+var tokenEnvVars = []string{
+	"GITHUB_TOKEN", "GH_TOKEN", "GITLAB_TOKEN", "GITEA_TOKEN", "GOGS_TOKEN", "GERRIT_PASSWORD",
+}
+
+type apiUsageOptions struct {
+	path       string
+	since      time.Duration
+	provider   string
+	token      string
+	top        int
+	jsonOutput bool
+}
+
+func newAPIUsageCmd() *cobra.Command {
+	opts := &apiUsageOptions{}
+	defaultPath, _ := api.DefaultPath()
+
+	cmd := &cobra.Command{
+		Use:   "api-usage",
+		Short: "Report provider API usage per provider, token, endpoint and command",
+		Long: `Report the provider API calls recorded in ~/.gzh/api-usage.db.
+
+Every call gz makes to a Git provider API is recorded with its endpoint
+class, a fingerprint of the token (never the token itself), its rate limit
+cost, latency and result. Calls are kept for 30 days. Set GZH_API_USAGE=0 to
+disable recording.
+
+The report lists the top consumers by token, endpoint and gz command, the
+rate limit hits and an hourly or daily trend, which shows why a large sync
+ran into the rate limit. Fingerprints of tokens found in GITHUB_TOKEN,
+GITLAB_TOKEN and similar variables are labeled with the variable name.
+
+Examples:
+  gz debug api-usage
+  gz debug api-usage --since 24h --provider github
+  gz debug api-usage --token 1a2b3c4d --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runAPIUsage(cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.path, "usage-file", defaultPath, "API usage database path")
+	cmd.Flags().DurationVar(&opts.since, "since", 7*24*time.Hour, "Only include calls made within this duration (0 = all)")
+	cmd.Flags().StringVar(&opts.provider, "provider", "", "Only include calls to this provider")
+	cmd.Flags().StringVar(&opts.token, "token", "", "Only include calls made with this token fingerprint")
+	cmd.Flags().IntVarP(&opts.top, "top", "n", 10, "Number of top consumers to show")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runAPIUsage(out io.Writer, opts *apiUsageOptions) error {
+	if opts.path == "" {
+		return fmt.Errorf("api usage file path is not set; use --usage-file")
+	}
+
+	calls, err := api.NewStore(opts.path).Load()
+	if err != nil {
+		return err
+	}
+
+	filter := api.Filter{Provider: opts.provider, Token: opts.token}
+	if opts.since > 0 {
+		filter.Since = time.Now().Add(-opts.since)
+	}
+	report := api.Summarize(calls, filter, opts.top)
+	if opts.jsonOutput {
+		return writeJSON(out, report)
+	}
+	printAPIUsage(out, report, tokenLabels(os.Getenv))
+	return nil
+}
+
+// tokenLabels maps the fingerprints of tokens in the environment to the
+// variable holding them.
+func tokenLabels(getenv func(string) string) map[string]string {
+	labels := make(map[string]string)
+	for _, name := range tokenEnvVars {
+		if token := getenv(name); token != "" {
+			fp := api.Fingerprint(token)
+			if _, ok := labels[fp]; !ok {
+				labels[fp] = name
+			}
+		}
+	}
+	return labels
+}
+
+func printAPIUsage(out io.Writer, r *api.Report, labels map[string]string) {
+	if r.Calls == 0 {
+		fmt.Fprintln(out, "No recorded API calls match.")
+		return
+	}
+
+	fmt.Fprintf(out, "API usage %s – %s: %d calls, %d points, %d rate limited, %d errors, avg %s\n",
+		r.From.Local().Format("2006-01-02 15:04"), r.To.Local().Format("2006-01-02 15:04"),
+		r.Calls, r.Cost, r.RateLimited, r.Errors, r.AvgLatency.Round(time.Millisecond))
+
+	printGroups(out, "Providers", r.Providers, nil)
+	printGroups(out, "Top tokens", r.Tokens, labels)
+	printGroups(out, "Top endpoints", r.Endpoints, nil)
+	printGroups(out, "Top commands", r.Commands, nil)
+
+	if len(r.RateLimitHits) > 0 {
+		fmt.Fprintf(out, "\n⚠️  Rate limit hits (latest %d of %d):\n", len(r.RateLimitHits), r.RateLimited)
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TIME\tPROVIDER\tTOKEN\tENDPOINT\tSTATUS\tCOMMAND")
+		for _, c := range r.RateLimitHits {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s %s\t%d\t%s\n",
+				c.Time.Local().Format("2006-01-02 15:04:05"), c.Provider, tokenName(c.Token, labels),
+				c.Method, c.Class, c.Status, c.Command)
+		}
+		_ = w.Flush()
+	}
+
+	fmt.Fprintf(out, "\nTrend (%s):\n", trendUnit(r.Interval))
+	peak := 0
+	for _, b := range r.Trend {
+		peak = max(peak, b.Cost)
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "START\tCALLS\tPOINTS\tLIMITED\tERRORS\t")
+	for _, b := range r.Trend {
+		layout := "2006-01-02"
+		if r.Interval < 24*time.Hour {
+			layout = "2006-01-02 15:04"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\n", b.Start.Format(layout), b.Calls, b.Cost, b.RateLimited, b.Errors, bar(b.Cost, peak, 30))
+	}
+	_ = w.Flush()
+}
+
+func printGroups(out io.Writer, title string, groups []api.Group, labels map[string]string) {
+	if len(groups) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\n%s:\n", title)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPROVIDER\tCALLS\tPOINTS\tLIMITED\tERRORS\tAVG LATENCY\tMIN REMAINING")
+	for _, g := range groups {
+		name := g.Key
+		if labels != nil {
+			// Token keys are "<provider> <fingerprint>"
+			_, fp, _ := strings.Cut(g.Key, " ")
+			name = tokenName(fp, labels)
+		}
+		remaining := "-"
+		if g.MinRemaining >= 0 {
+			remaining = fmt.Sprint(g.MinRemaining)
+		}
+		provider := g.Provider
+		if provider == "" {
+			provider = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\n",
+			name, provider, g.Calls, g.Cost, g.RateLimited, g.Errors, g.AvgLatency.Round(time.Millisecond), remaining)
+	}
+	_ = w.Flush()
+}
+
+func tokenName(fp string, labels map[string]string) string {
+	if name, ok := labels[fp]; ok {
+		return fmt.Sprintf("%s (%s)", fp, name)
+	}
+	return fp
+}
+
+func trendUnit(interval time.Duration) string {
+	if interval < 24*time.Hour {
+		return "hourly"
+	}
+	return "daily"
+}
+
+// bar draws value as a bar of up to width cells relative to peak.
+func bar(value, peak, width int) string {
+	if peak <= 0 || value <= 0 {
+		return ""
+	}
+	return strings.Repeat("█", max(1, value*width/peak))
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package debug implements the `gz debug` command for inspecting recorded
+// execution history.
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+	pkgdebug "github.com/gizzahub/gzh-cli/pkg/debug"
+)
+
+// NewDebugCmd creates the debug command.
+func NewDebugCmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Inspect gz execution history, performance and logs",
+		Long: `Inspect diagnostics recorded by gz.
+
+Every gz invocation records its duration, exit status, flags and resource
+usage to ~/.gzh/history.db. Set GZH_HISTORY=0 to disable recording.
+
+Provider API calls are recorded to ~/.gzh/api-usage.db; 'gz debug api-usage'
+reports them per provider, token, endpoint and command. Set GZH_API_USAGE=0
+to disable recording.
+
+When file logging is enabled, 'gz debug logs query' searches the log files.
+
+'gz debug report' bundles all of this, anonymized, for a support request;
+with --upload, or later with 'gz debug upload', the bundle is sent to the
+support endpoint in resumable chunks.
+
+Any command run with --debug-addr streams its log events live over a
+WebSocket at ws://<addr>/debug/logs/stream. Clients filter by module, level
+and trace ID with query parameters (e.g. ?module=git&level=warn) or by
+sending a JSON filter such as {"modules":["git"],"level":"warn"}.`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newHistoryCmd())
+	cmd.AddCommand(newAPIUsageCmd())
+	cmd.AddCommand(newLogsCmd())
+	cmd.AddCommand(newReportCmd())
+	cmd.AddCommand(newUploadCmd())
+
+	return cmd
+}
+
+type historyOptions struct {
+	path        string
+	command     string
+	since       time.Duration
+	limit       int
+	regressions bool
+	minRuns     int
+	threshold   float64
+	jsonOutput  bool
+}
+
+func newHistoryCmd() *cobra.Command {
+	opts := &historyOptions{}
+	defaultPath, _ := pkgdebug.DefaultPath()
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the slowest recorded runs or performance regressions",
+		Long: `Query the execution history recorded in ~/.gzh/history.db.
+
+By default the slowest runs are listed. With --regressions, each command's
+most recent successful run is compared against the median of its earlier
+runs and reported when it is at least --threshold times slower.
+
+Examples:
+  gz debug history
+  gz debug history --command "gz synclone" --since 168h
+  gz debug history --regressions --threshold 2
+  gz debug history --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runHistory(cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.path, "history-file", defaultPath, "History database path")
+	cmd.Flags().StringVar(&opts.command, "command", "", "Only include commands starting with this path (e.g. \"gz synclone\")")
+	cmd.Flags().DurationVar(&opts.since, "since", 0, "Only include runs started within this duration")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 10, "Maximum number of runs to show")
+	cmd.Flags().BoolVar(&opts.regressions, "regressions", false, "Show commands whose latest run regressed against their history")
+	cmd.Flags().IntVar(&opts.minRuns, "min-runs", 5, "Earlier runs required before a command is checked for regressions")
+	cmd.Flags().Float64Var(&opts.threshold, "threshold", 1.5, "Slowdown ratio reported as a regression")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runHistory(out io.Writer, opts *historyOptions) error {
+	if opts.path == "" {
+		return fmt.Errorf("history file path is not set; use --history-file")
+	}
+	if opts.threshold <= 1 {
+		return fmt.Errorf("--threshold must be greater than 1, got %g", opts.threshold)
+	}
+
+	records, err := pkgdebug.NewHistory(opts.path).Load()
+	if err != nil {
+		return err
+	}
+
+	filter := pkgdebug.Filter{Command: opts.command}
+	if opts.since > 0 {
+		filter.Since = time.Now().Add(-opts.since)
+	}
+
+	if opts.regressions {
+		regressions := pkgdebug.Regressions(records, filter, opts.minRuns, opts.threshold)
+		if opts.jsonOutput {
+			return writeJSON(out, regressions)
+		}
+		printRegressions(out, regressions, opts)
+		return nil
+	}
+
+	slowest := pkgdebug.Slowest(records, filter, opts.limit)
+	if opts.jsonOutput {
+		return writeJSON(out, slowest)
+	}
+	printSlowest(out, slowest, len(records))
+	return nil
+}
+
+func writeJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func printSlowest(out io.Writer, records []pkgdebug.Record, total int) {
+	if len(records) == 0 {
+		fmt.Fprintln(out, "No recorded runs match.")
+		return
+	}
+
+	fmt.Fprintf(out, "Slowest runs (%d of %d recorded):\n\n", len(records), total)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STARTED\tDURATION\tEXIT\tCPU\tMAX RSS\tCOMMAND")
+	for _, r := range records {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n",
+			r.Start.Local().Format("2006-01-02 15:04"),
+			r.Duration.Round(time.Millisecond),
+			r.ExitCode,
+			(r.UserCPU + r.SystemCPU).Round(time.Millisecond),
+			formatBytes(r.MaxRSS),
+			commandLine(r))
+	}
+	_ = w.Flush()
+}
+
+func printRegressions(out io.Writer, regressions []pkgdebug.Regression, opts *historyOptions) {
+	if len(regressions) == 0 {
+		fmt.Fprintf(out, "No regressions (latest run ≥ %.1fx the median of at least %d earlier runs).\n", opts.threshold, opts.minRuns)
+		return
+	}
+
+	fmt.Fprintf(out, "⚠️  %d command(s) regressed:\n\n", len(regressions))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMMAND\tLATEST\tBASELINE\tRATIO\tRUNS\tSTARTED")
+	for _, r := range regressions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.1fx\t%d\t%s\n",
+			r.Command,
+			r.Latest.Duration.Round(time.Millisecond),
+			r.Baseline.Round(time.Millisecond),
+			r.Ratio,
+			r.Runs,
+			r.Latest.Start.Local().Format("2006-01-02 15:04"))
+	}
+	_ = w.Flush()
+}
+
+func commandLine(r pkgdebug.Record) string {
+	parts := []string{r.Command}
+	parts = append(parts, r.Args...)
+
+	names := make([]string, 0, len(r.Flags))
+	for name := range r.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("--%s=%s", name, r.Flags[name]))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatBytes(n int64) string {
+	if n <= 0 {
+		return "-"
+	}
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
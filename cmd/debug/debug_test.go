@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/api"
+	pkgdebug "github.com/gizzahub/gzh-cli/pkg/debug"
+)
+
+func TestHistoryCmd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	h := pkgdebug.NewHistory(path)
+	now := time.Now()
+	for i, d := range []time.Duration{2, 2, 2, 2, 2, 7} {
+		require.NoError(t, h.Append(pkgdebug.Record{
+			Command:  "gz synclone github",
+			Flags:    map[string]string{"org": "acme"},
+			Start:    now.Add(time.Duration(i-6) * time.Minute),
+			Duration: d * time.Second,
+		}))
+	}
+
+	var out bytes.Buffer
+	cmd := NewDebugCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"history", "--history-file", path, "-n", "1"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Slowest runs (1 of 6 recorded)")
+	assert.Contains(t, out.String(), "gz synclone github --org=acme")
+	assert.Contains(t, out.String(), "7s")
+
+	out.Reset()
+	cmd = NewDebugCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"history", "--history-file", path, "--regressions", "--json"})
+	require.NoError(t, cmd.Execute())
+	var regressions []pkgdebug.Regression
+	require.NoError(t, json.Unmarshal(out.Bytes(), &regressions))
+	require.Len(t, regressions, 1)
+	assert.InDelta(t, 3.5, regressions[0].Ratio, 0.001)
+}
+
+func TestHistoryCmd_InvalidThreshold(t *testing.T) {
+	cmd := NewDebugCmd(nil)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"history", "--history-file", filepath.Join(t.TempDir(), "h.db"), "--threshold", "1"})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestLogsQueryCmd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.log")
+	now := time.Now().UTC()
+	lines := []map[string]any{
+		{"time": now.Add(-2 * time.Hour).Format(time.RFC3339), "level": "ERROR", "msg": "too old", "component": "git"},
+		{"time": now.Add(-10 * time.Minute).Format(time.RFC3339), "level": "WARN", "msg": "rate limit low", "component": "git"},
+		{"time": now.Add(-5 * time.Minute).Format(time.RFC3339), "level": "INFO", "msg": "cloned", "component": "git"},
+		{"time": now.Add(-time.Minute).Format(time.RFC3339), "level": "ERROR", "msg": "failed", "component": "synclone"},
+	}
+	var data []byte
+	for _, l := range lines {
+		b, err := json.Marshal(l)
+		require.NoError(t, err)
+		data = append(append(data, b...), '\n')
+	}
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	var out bytes.Buffer
+	cmd := NewDebugCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"logs", "query", "module=git AND level>=warn SINCE 30m", "--log-file", path})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "rate limit low")
+	assert.NotContains(t, out.String(), "too old")
+	assert.NotContains(t, out.String(), "cloned")
+	assert.NotContains(t, out.String(), "failed")
+
+	out.Reset()
+	cmd = NewDebugCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"logs", "query", "level=error", "--log-file", path, "--json", "-n", "1"})
+	require.NoError(t, cmd.Execute())
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, "failed", got["msg"])
+
+	cmd = NewDebugCmd(nil)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"logs", "query", "level>=loud", "--log-file", path})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestAPIUsageCmd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-usage.db")
+	now := time.Now()
+	token := api.Fingerprint("ghp_usage")
+	require.NoError(t, api.NewStore(path).Append([]api.Call{
+		{Time: now.Add(-2 * time.Hour), Provider: "github", Method: "GET", Class: "orgs/repos", Token: token, Cost: 1, Result: api.ResultOK, Remaining: 10, Command: "gz synclone github"},
+		{Time: now.Add(-time.Hour), Provider: "github", Method: "POST", Class: "graphql", Token: token, Cost: 7, Result: api.ResultRateLimited, Remaining: 0, Command: "gz synclone github"},
+		{Time: now.AddDate(0, 0, -10), Provider: "gitlab", Method: "GET", Class: "projects", Token: api.AnonymousToken, Cost: 1, Result: api.ResultOK, Remaining: -1},
+	}))
+	t.Setenv("GITHUB_TOKEN", "ghp_usage")
+
+	var out bytes.Buffer
+	cmd := NewDebugCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"api-usage", "--usage-file", path})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "2 calls, 8 points, 1 rate limited")
+	assert.Contains(t, out.String(), token+" (GITHUB_TOKEN)")
+	assert.Contains(t, out.String(), "POST graphql")
+	assert.Contains(t, out.String(), "Rate limit hits")
+	assert.Contains(t, out.String(), "Trend (hourly)")
+
+	out.Reset()
+	cmd = NewDebugCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"api-usage", "--usage-file", path, "--since", "0", "--provider", "gitlab", "--json"})
+	require.NoError(t, cmd.Execute())
+	var report api.Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, 1, report.Calls)
+	assert.Equal(t, "gitlab", report.Providers[0].Key)
+}
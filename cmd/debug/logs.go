@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/logger/logquery"
+)
+
+type logsQueryOptions struct {
+	path       string
+	limit      int
+	jsonOutput bool
+}
+
+func newLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Search the local gz log files",
+		Long: `Search the JSON log files written by gz.
+
+File logging is enabled with logging.enabled in ~/.scripton/gzh/config.yaml;
+logs are written to logging.filePath (and rotated to .1, .2, ...).`,
+	}
+
+	cmd.AddCommand(newLogsQueryCmd())
+	return cmd
+}
+
+func newLogsQueryCmd() *cobra.Command {
+	opts := &logsQueryOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "query [QUERY]",
+		Short: "Filter log entries with a query",
+		Long: `Filter log entries by field, severity, message and time.
+
+Comparisons (= != < <= > >=, ~ and !~ for regular expressions) are combined
+with AND, OR, NOT and parentheses; adjacent terms are joined with AND.
+SINCE and UNTIL take a duration before now (30m, 12h, 7d) or a timestamp.
+
+Fields:
+  level           severity, compared as debug < info < warn < error
+  msg, message    log message
+  module          logging component
+  time            entry time
+  <name>          any logged attribute; dotted names reach nested values
+
+A bare word or quoted string matches messages containing it.
+
+Examples:
+  gz debug logs query 'module=git AND level>=warn SINCE 30m'
+  gz debug logs query 'msg~"timeout|refused" OR error.type=NetworkError'
+  gz debug logs query '"rate limit" SINCE 2h' --json
+  gz debug logs query 'level=error SINCE 2025-06-01 UNTIL 2025-06-02'`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := ""
+			if len(args) == 1 {
+				query = args[0]
+			}
+			return runLogsQuery(cmd.OutOrStdout(), query, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.path, "log-file", logquery.DefaultPath(), "Log file to search (rotated files are included)")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 100, "Maximum number of entries to show, newest last (0 for all)")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output matching entries as JSON lines")
+
+	return cmd
+}
+
+func runLogsQuery(out io.Writer, query string, opts *logsQueryOptions) error {
+	q, err := logquery.Parse(query, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	entries, err := logquery.NewStore(opts.path).Query(q, opts.limit)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w\nenable file logging with logging.enabled: true in ~/.scripton/gzh/config.yaml or pass --log-file", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.jsonOutput {
+		enc := json.NewEncoder(out)
+		for _, e := range entries {
+			if err := enc.Encode(e.Fields); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "No log entries match.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tLEVEL\tMODULE\tMESSAGE")
+	for _, e := range entries {
+		ts := "-"
+		if !e.Time.IsZero() {
+			ts = e.Time.Local().Format("2006-01-02 15:04:05")
+		}
+		module := e.Component()
+		if module == "" {
+			module = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ts, e.Level, module, strings.ReplaceAll(e.Message, "\n", " "))
+	}
+	return w.Flush()
+}
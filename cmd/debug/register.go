@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type debugCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p debugCmdProvider) Command() *cobra.Command {
+	return NewDebugCmd(p.appCtx)
+}
+
+func (p debugCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "debug",
+		Category:     registry.CategoryUtility,
+		Version:      "1.0.0",
+		Priority:     95,
+		Experimental: false,
+		Dependencies: []string{},
+		Tags:         []string{"debug", "history", "performance", "profiling", "logs", "report"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterDebugCmd registers the debug command with the command registry.
+func RegisterDebugCmd(appCtx *app.AppContext) {
+	registry.Register(debugCmdProvider{appCtx: appCtx})
+}
@@ -0,0 +1,368 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	rtdebug "runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/filesystem"
+	"github.com/gizzahub/gzh-cli/internal/logger/logquery"
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+	pkgdebug "github.com/gizzahub/gzh-cli/pkg/debug"
+)
+
+// reportEnvVars are the environment variables included in a report. Their
+// values go through the anonymizer like everything else.
+var reportEnvVars = []string{
+	"HTTPS_PROXY", "HTTP_PROXY", "ALL_PROXY", "NO_PROXY",
+	"GZH_API_USAGE", "GZH_CONFIG_PATH", "GZH_HISTORY", "GZH_LOG_LEVEL", "SHELL", "TERM",
+}
+
+type reportOptions struct {
+	output       string
+	historyPath  string
+	logPath      string
+	configPath   string
+	rulesPath    string
+	salt         string
+	mappingPath  string
+	historyLimit int
+	logLimit     int
+	noAnonymize  bool
+	force        bool
+	upload       bool
+	uploadOptions
+}
+
+// reportManifest describes a bundle. It never contains the placeholder
+// mapping.
+type reportManifest struct {
+	GeneratedAt  time.Time      `json:"generatedAt"`
+	Files        []string       `json:"files"`
+	Anonymized   bool           `json:"anonymized"`
+	Replacements map[string]int `json:"replacements,omitempty"`
+	Notes        []string       `json:"notes,omitempty"`
+}
+
+type reportSystem struct {
+	Version   string            `json:"version"`
+	GoVersion string            `json:"goVersion"`
+	OS        string            `json:"os"`
+	Arch      string            `json:"arch"`
+	NumCPU    int               `json:"numCpu"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+func newReportCmd() *cobra.Command {
+	opts := &reportOptions{}
+	defaultHistory, _ := pkgdebug.DefaultPath()
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Create an anonymized support bundle",
+		Long: `Collect system information, the gz configuration, recent execution
+history and recent log entries into a .tar.gz support bundle. The codec
+follows the extension of --output (.gz, .zst, .lz4) or, without one,
+global.compression.diagnostics.
+
+Before anything is written, tokens, credentials, e-mail addresses, host
+names, IP addresses, organization and user names are replaced with
+placeholders such as org-1a2b3c4d. The same value always gets the same
+placeholder, so relations stay visible. A verification pass then scans the
+bundle, including with the secret scanner used by --scan-secrets of
+'gz git repo clone', and refuses to write it if anything sensitive is left.
+
+Custom rules are read from ~/.config/gzh-manager/anonymize.yaml (or
+--rules):
+
+  salt: shared-by-the-team     # same placeholders across bundles
+  keep: [git.example.com]      # values never replaced
+  rules:
+    - name: internal-host
+      category: host
+      regex: '[a-z0-9-]+\.corp\.example\.com'
+    - name: jira-project
+      category: project
+      fields: [project, jira.key]
+
+Examples:
+  gz debug report
+  gz debug report -o /tmp/bundle.tar.gz --mapping-file ~/report-mapping.json
+  gz debug report --history 20 --log-lines 200
+  gz debug report --upload --upload-url https://support.example.com/files`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			now := time.Now()
+			if err := runReport(cmd.OutOrStdout(), opts, now); err != nil {
+				return err
+			}
+			if !opts.upload {
+				return nil
+			}
+			return runUpload(cmd.Context(), cmd.OutOrStdout(), reportOutput(opts, now), &opts.uploadOptions)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Bundle path (default gz-report-<timestamp>.tar.gz; .tar.zst or .tar.lz4 select another codec)")
+	cmd.Flags().StringVar(&opts.historyPath, "history-file", defaultHistory, "History database path")
+	cmd.Flags().StringVar(&opts.logPath, "log-file", logquery.DefaultPath(), "Log file to include (rotated files are included)")
+	cmd.Flags().StringVar(&opts.configPath, "config", "", "Configuration file to include (default: the one gz loads)")
+	cmd.Flags().StringVar(&opts.rulesPath, "rules", defaultRulesPath(), "Anonymizer rules file")
+	cmd.Flags().StringVar(&opts.salt, "salt", "", "Salt for placeholders, overriding the rules file")
+	cmd.Flags().StringVar(&opts.mappingPath, "mapping-file", "", "Write placeholder → original values to this local file")
+	cmd.Flags().IntVar(&opts.historyLimit, "history", 50, "Number of recent runs to include")
+	cmd.Flags().IntVar(&opts.logLimit, "log-lines", 500, "Number of recent log entries to include")
+	cmd.Flags().BoolVar(&opts.noAnonymize, "no-anonymize", false, "Include data as is; verification findings become warnings")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Write the bundle even if verification finds sensitive data")
+	cmd.Flags().BoolVar(&opts.upload, "upload", false, "Upload the bundle to the support endpoint (see 'gz debug upload')")
+	opts.uploadOptions.addFlags(cmd)
+
+	return cmd
+}
+
+func defaultRulesPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gzh-manager", "anonymize.yaml")
+}
+
+func runReport(out io.Writer, opts *reportOptions, now time.Time) error {
+	anonymizer, err := newReportAnonymizer(opts)
+	if err != nil {
+		return err
+	}
+
+	bundle, notes, err := collectReport(opts)
+	if err != nil {
+		return err
+	}
+	if !opts.noAnonymize {
+		if bundle, err = anonymizer.Anonymize(bundle); err != nil {
+			return fmt.Errorf("failed to anonymize report: %w", err)
+		}
+	}
+
+	manifest := reportManifest{GeneratedAt: now.UTC(), Anonymized: !opts.noAnonymize, Notes: notes}
+	for _, f := range bundle.Files {
+		manifest.Files = append(manifest.Files, f.Name)
+	}
+	if !opts.noAnonymize {
+		manifest.Replacements = anonymizer.Summary()
+	}
+	if err := bundle.AddJSON("manifest.json", manifest); err != nil {
+		return err
+	}
+
+	leaks, err := anonymizer.Verify(bundle)
+	if err != nil {
+		return fmt.Errorf("failed to verify report: %w", err)
+	}
+	if len(leaks) > 0 {
+		printLeaks(out, leaks)
+		if !opts.noAnonymize && !opts.force {
+			return fmt.Errorf("report not written: verification found %d possible leak(s); add anonymizer rules or use --force", len(leaks))
+		}
+	}
+
+	output := reportOutput(opts, now)
+	codec := reportCodec(output)
+	var buf bytes.Buffer
+	prefix := strings.TrimSuffix(filepath.Base(output), ".tar"+codec.Extension())
+	if err := bundle.WriteArchive(&buf, codec, prefix, now); err != nil {
+		return err
+	}
+	if err := filesystem.WriteFileAtomic(filesystem.OS(), output, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if opts.mappingPath != "" && !opts.noAnonymize {
+		data, err := json.MarshalIndent(anonymizer.Mapping(), "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := filesystem.WriteFileAtomic(filesystem.OS(), opts.mappingPath, append(data, '\n'), 0o600); err != nil {
+			return fmt.Errorf("failed to write mapping file: %w", err)
+		}
+	}
+
+	fmt.Fprintf(out, "✅ Wrote %s (%d files)\n", output, len(bundle.Files))
+	if opts.noAnonymize {
+		fmt.Fprintln(out, "⚠️  The report is not anonymized; review it before sharing.")
+	} else {
+		fmt.Fprintf(out, "   Pseudonymized: %s\n", formatReplacements(manifest.Replacements))
+	}
+	for _, note := range notes {
+		fmt.Fprintf(out, "   Note: %s\n", note)
+	}
+	return nil
+}
+
+// reportOutput returns the bundle path.
+func reportOutput(opts *reportOptions, now time.Time) string {
+	if opts.output != "" {
+		return opts.output
+	}
+	return "gz-report-" + now.Format("20060102-150405") + ".tar" + compress.ForUse(compress.UseDiagnostics).Extension()
+}
+
+// reportCodec returns the codec matching the extension of output, or the
+// one configured for diagnostics.
+func reportCodec(output string) compress.Codec {
+	if codec, ok := compress.FromExtension(output); ok {
+		return codec
+	}
+	return compress.ForUse(compress.UseDiagnostics)
+}
+
+func newReportAnonymizer(opts *reportOptions) (*pkgdebug.Anonymizer, error) {
+	cfg := &pkgdebug.AnonymizeConfig{}
+	if opts.rulesPath != "" {
+		loaded, err := pkgdebug.LoadAnonymizeConfig(opts.rulesPath)
+		switch {
+		case err == nil:
+			cfg = loaded
+		case errors.Is(err, os.ErrNotExist) && !explicitRules(opts):
+			// 기본 경로에 규칙 파일이 없으면 내장 규칙만 사용한다
+		default:
+			return nil, err
+		}
+	}
+	if opts.salt != "" {
+		cfg.Salt = opts.salt
+	}
+	return pkgdebug.NewAnonymizer(cfg)
+}
+
+func explicitRules(opts *reportOptions) bool {
+	return opts.rulesPath != defaultRulesPath()
+}
+
+// collectReport gathers the bundle contents. Missing sources are noted,
+// not treated as errors.
+func collectReport(opts *reportOptions) (*pkgdebug.Bundle, []string, error) {
+	bundle := &pkgdebug.Bundle{}
+	var notes []string
+
+	system := reportSystem{
+		Version:   buildVersion(),
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		NumCPU:    runtime.NumCPU(),
+		Env:       map[string]string{},
+	}
+	for _, name := range reportEnvVars {
+		if v := os.Getenv(name); v != "" {
+			system.Env[name] = v
+		}
+	}
+	if err := bundle.AddJSON("system.json", system); err != nil {
+		return nil, nil, err
+	}
+
+	configPath := opts.configPath
+	if configPath == "" {
+		facade := config.NewUnifiedConfigFacade()
+		if err := facade.LoadConfiguration(); err == nil && facade.GetLoadResult() != nil {
+			configPath = facade.GetLoadResult().ConfigPath
+		}
+	}
+	if configPath == "" {
+		notes = append(notes, "no configuration file found")
+	} else if data, err := os.ReadFile(configPath); err != nil {
+		notes = append(notes, fmt.Sprintf("configuration not included: %v", err))
+	} else {
+		name := "config/" + filepath.Base(configPath)
+		if ext := filepath.Ext(name); ext != ".yaml" && ext != ".yml" {
+			name += ".yaml"
+		}
+		bundle.Add(name, data)
+	}
+
+	if opts.historyLimit > 0 && opts.historyPath != "" {
+		records, err := pkgdebug.NewHistory(opts.historyPath).Load()
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("history not included: %v", err))
+		} else {
+			sort.SliceStable(records, func(i, j int) bool { return records[i].Start.Before(records[j].Start) })
+			if len(records) > opts.historyLimit {
+				records = records[len(records)-opts.historyLimit:]
+			}
+			items := make([]any, len(records))
+			for i, r := range records {
+				items[i] = r
+			}
+			if err := bundle.AddJSONLines("history.jsonl", items); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if opts.logLimit > 0 && opts.logPath != "" {
+		q, err := logquery.Parse("", time.Now())
+		if err != nil {
+			return nil, nil, err
+		}
+		entries, err := logquery.NewStore(opts.logPath).Query(q, opts.logLimit)
+		if err != nil {
+			notes = append(notes, fmt.Sprintf("logs not included: %v", err))
+		} else {
+			items := make([]any, len(entries))
+			for i, e := range entries {
+				items[i] = e.Fields
+			}
+			if err := bundle.AddJSONLines("logs.jsonl", items); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	return bundle, notes, nil
+}
+
+func buildVersion() string {
+	if info, ok := rtdebug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "unknown"
+}
+
+func printLeaks(out io.Writer, leaks []pkgdebug.Leak) {
+	fmt.Fprintf(out, "⚠️  Verification found %d possible leak(s):\n", len(leaks))
+	for _, l := range leaks {
+		fmt.Fprintf(out, "   %s:%d  %s  %s\n", l.File, l.Line, l.Rule, l.Value)
+	}
+}
+
+func formatReplacements(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "nothing"
+	}
+	categories := make([]string, 0, len(counts))
+	for c := range counts {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
+	parts := make([]string, len(categories))
+	for i, c := range categories {
+		parts[i] = fmt.Sprintf("%s %d", c, counts[c])
+	}
+	return strings.Join(parts, ", ")
+}
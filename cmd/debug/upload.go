@@ -0,0 +1,190 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/git/archival"
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/internal/workerpool"
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
+	pkgdebug "github.com/gizzahub/gzh-cli/pkg/debug"
+)
+
+// Environment variables that configure bundle uploads.
+const (
+	uploadURLEnv   = "GZH_SUPPORT_UPLOAD_URL"
+	uploadTokenEnv = "GZH_SUPPORT_UPLOAD_TOKEN"
+)
+
+// s3MinPartSize is the smallest part S3 accepts except for the last one.
+const s3MinPartSize = 5 << 20
+
+type uploadOptions struct {
+	url       string
+	chunkSize string
+	retries   int
+	stateDir  string
+	auditLog  string
+}
+
+func (o *uploadOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.url, "upload-url", os.Getenv(uploadURLEnv), "Upload destination: a tus HTTPS endpoint or s3://bucket/prefix (env "+uploadURLEnv+")")
+	cmd.Flags().StringVar(&o.chunkSize, "chunk-size", "8MB", "Size of each uploaded chunk (at least 5MB for S3)")
+	cmd.Flags().IntVar(&o.retries, "retries", 3, "Extra attempts per chunk before the upload is interrupted")
+	cmd.Flags().StringVar(&o.stateDir, "upload-state-dir", pkgdebug.DefaultUploadStateDir(), "Directory holding the state of unfinished uploads")
+	cmd.Flags().StringVar(&o.auditLog, "audit-log", pkgdebug.DefaultUploadAuditLogPath(), "Local audit log of uploads")
+}
+
+func newUploadCmd() *cobra.Command {
+	opts := &uploadOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "upload <bundle>",
+		Short: "Upload a support bundle, resuming an interrupted upload",
+		Long: `Upload a bundle written by 'gz debug report' to the support endpoint.
+
+The bundle is sent in chunks. The state of an unfinished upload is kept in
+~/.gzh/debug/uploads, so running the same command again after a network
+failure only sends the missing chunks. The destination is either an HTTPS
+endpoint speaking the tus resumable upload protocol or an S3 bucket, which
+receives a multipart upload. The bearer token for HTTPS endpoints is read
+from ` + uploadTokenEnv + `.
+
+On success the reference ID to quote in the support request is printed.
+Every attempt is recorded in the audit log, and shipped to the audit sinks
+of gzh.yaml when shipping is enabled.
+
+Examples:
+  gz debug upload gz-report-20250601-100000.tar.gz --upload-url https://support.example.com/files
+  gz debug upload report.tar.gz --upload-url s3://acme-support/bundles?region=eu-west-1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpload(cmd.Context(), cmd.OutOrStdout(), args[0], opts)
+		},
+	}
+	opts.addFlags(cmd)
+	return cmd
+}
+
+// runUpload uploads file and records the attempt in the audit log.
+func runUpload(ctx context.Context, out io.Writer, file string, opts *uploadOptions) error {
+	if opts.url == "" {
+		return fmt.Errorf("no upload destination: use --upload-url or set %s", uploadURLEnv)
+	}
+	chunkSize, err := workerpool.ParseByteSize(opts.chunkSize)
+	if err != nil {
+		return fmt.Errorf("invalid --chunk-size: %w", err)
+	}
+	uploader, err := pkgdebug.NewUploader(ctx, opts.url, os.Getenv(uploadTokenEnv))
+	if err != nil {
+		return err
+	}
+	if _, ok := uploader.(*pkgdebug.S3Uploader); ok && chunkSize < s3MinPartSize {
+		return fmt.Errorf("--chunk-size must be at least 5MB for S3")
+	}
+
+	recorder, shipper := uploadRecorders(opts.auditLog)
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := shipper.Close(closeCtx); err != nil {
+			logger.SimpleWarn("Failed to deliver upload audit events; they stay spooled for the next run", "error", err)
+		}
+	}()
+
+	fmt.Fprintf(out, "⬆️  Uploading %s to %s\n", filepath.Base(file), uploader)
+	result, uploadErr := pkgdebug.UploadBundle(ctx, uploader, file, pkgdebug.UploadOptions{
+		StateDir:  opts.stateDir,
+		ChunkSize: chunkSize,
+		Retries:   opts.retries,
+		Progress: func(done, total int64) {
+			fmt.Fprintf(out, "\r   %d%% (%d/%d bytes)", done*100/max(total, 1), done, total)
+			if done == total {
+				fmt.Fprintln(out)
+			}
+		},
+	})
+
+	event := audit.Event{
+		ID:        audit.NewEventID(),
+		Timestamp: time.Now().UTC(),
+		Action:    pkgdebug.ActionBundleUploaded,
+		Category:  "support",
+		Actor:     currentUsername(),
+		Source:    "gz debug",
+		Resource:  uploader.String(),
+		Metadata:  map[string]any{"file": filepath.Base(file)},
+	}
+	if uploadErr != nil {
+		event.Severity, event.Outcome, event.ErrMessage = audit.SeverityWarn, "failure", uploadErr.Error()
+		event.Message = "support bundle upload interrupted"
+	} else {
+		event.Outcome = "success"
+		event.Message = "support bundle uploaded as " + result.Reference
+		event.Metadata["reference"] = result.Reference
+		event.Metadata["size"] = result.Size
+		event.Metadata["sha256"] = result.SHA256
+		event.Metadata["chunks"] = result.Chunks
+		event.Metadata["resumed"] = result.Resumed
+	}
+	if err := recorder.Record(event); err != nil {
+		logger.SimpleWarn("Failed to record upload audit event", "error", err)
+	}
+
+	if uploadErr != nil {
+		if errors.Is(uploadErr, context.Canceled) {
+			return uploadErr
+		}
+		return fmt.Errorf("%w\n💡 Run 'gz debug upload %s --upload-url %s' to resume", uploadErr, file, uploader)
+	}
+	fmt.Fprintf(out, "✅ Uploaded %s (%d bytes", filepath.Base(file), result.Size)
+	if result.Resumed {
+		fmt.Fprint(out, ", resumed")
+	}
+	fmt.Fprintf(out, ")\n   Reference ID: %s\n", result.Reference)
+	return nil
+}
+
+// uploadRecorders returns the local audit log, followed by the audit
+// shipper of gzh.yaml when shipping is enabled there. The shipper is nil
+// otherwise; closing a nil shipper is a no-op.
+func uploadRecorders(auditLog string) (audit.Recorder, *audit.Shipper) {
+	if auditLog == "" {
+		auditLog = pkgdebug.DefaultUploadAuditLogPath()
+	}
+	recorders := audit.Recorders{archival.NewFileRecorder(auditLog)}
+
+	facade := pkgconfig.NewUnifiedConfigFacade()
+	if err := facade.LoadConfiguration(); err != nil {
+		return recorders, nil
+	}
+	shipper, err := audit.NewShipperFromConfig(facade.GetAuditConfig())
+	if err != nil {
+		logger.SimpleWarn("Audit shipping disabled for uploads", "error", err)
+		return recorders, nil
+	}
+	if shipper != nil {
+		recorders = append(recorders, shipper)
+	}
+	return recorders, shipper
+}
+
+func currentUsername() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+	pkgdebug "github.com/gizzahub/gzh-cli/pkg/debug"
+)
+
+// newTusServer accepts a single tus upload and answers its chunks with the
+// reference ID SUP-42.
+func newTusServer(t *testing.T) (*httptest.Server, *bytes.Buffer) {
+	t.Helper()
+	var received bytes.Buffer
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", "/files/abc")
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodHead:
+			w.Header().Set("Upload-Offset", strconv.Itoa(received.Len()))
+		case http.MethodPatch:
+			_, _ = io.Copy(&received, r.Body)
+			w.Header().Set("Upload-Offset", strconv.Itoa(received.Len()))
+			w.Header().Set("Upload-Reference", "SUP-42")
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &received
+}
+
+func TestUploadCmd(t *testing.T) {
+	srv, received := newTusServer(t)
+	dir := t.TempDir()
+	t.Setenv("GZH_CONFIG_PATH", filepath.Join(dir, "missing.yaml"))
+	bundle := filepath.Join(dir, "gz-report.tar.gz")
+	data := bytes.Repeat([]byte("x"), 3000)
+	require.NoError(t, os.WriteFile(bundle, data, 0o600))
+	auditLog := filepath.Join(dir, "audit.jsonl")
+
+	var out bytes.Buffer
+	cmd := NewDebugCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"upload", bundle, "--upload-url", srv.URL + "/files", "--chunk-size", "1KB",
+		"--upload-state-dir", filepath.Join(dir, "uploads"), "--audit-log", auditLog})
+	require.NoError(t, cmd.Execute(), out.String())
+	assert.Contains(t, out.String(), "Reference ID: SUP-42")
+	assert.Equal(t, data, received.Bytes())
+
+	raw, err := os.ReadFile(auditLog)
+	require.NoError(t, err)
+	var event audit.Event
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(raw), &event))
+	assert.Equal(t, pkgdebug.ActionBundleUploaded, event.Action)
+	assert.Equal(t, "success", event.Outcome)
+	assert.Equal(t, "SUP-42", event.Metadata["reference"])
+	assert.Equal(t, float64(3), event.Metadata["chunks"])
+	assert.NotEmpty(t, event.ID)
+	assert.False(t, event.Timestamp.IsZero())
+}
+
+func TestUploadCmd_Errors(t *testing.T) {
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "gz-report.tar.gz")
+	require.NoError(t, os.WriteFile(bundle, []byte("x"), 0o600))
+
+	for name, args := range map[string][]string{
+		"no destination": {"upload", bundle, "--upload-url", ""},
+		"plain http":     {"upload", bundle, "--upload-url", "http://support.example.com/files"},
+		"bad chunk size": {"upload", bundle, "--upload-url", "https://support.example.com/files", "--chunk-size", "lots"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cmd := NewDebugCmd(nil)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(append(args, "--audit-log", filepath.Join(dir, "audit.jsonl")))
+			assert.Error(t, cmd.Execute())
+		})
+	}
+}
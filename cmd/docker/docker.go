@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package docker implements the `gz docker` command.
+package docker
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+// NewDockerCmd creates the docker command.
+func NewDockerCmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+
+	cmd := &cobra.Command{
+		Use:   "docker",
+		Short: "Container image tooling",
+		Long: `Work with container images built for your repositories, such as
+scanning them for known vulnerabilities before they are pushed.`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newScanCmd())
+
+	return cmd
+}
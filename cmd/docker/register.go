@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package docker
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type dockerCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p dockerCmdProvider) Command() *cobra.Command {
+	return NewDockerCmd(p.appCtx)
+}
+
+func (p dockerCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "docker",
+		Category:     registry.CategoryQuality,
+		Version:      "1.0.0",
+		Priority:     72,
+		Experimental: false,
+		Dependencies: []string{},
+		Tags:         []string{"docker", "container", "security", "vulnerability"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterDockerCmd registers the docker command with the command registry.
+func RegisterDockerCmd(appCtx *app.AppContext) {
+	registry.Register(dockerCmdProvider{appCtx: appCtx})
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package docker
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/pkg/security/imagescan"
+)
+
+// newScanner is swapped out in tests.
+var newScanner = imagescan.NewScanner
+
+type scanOptions struct {
+	scanner       string
+	server        string
+	platforms     []string
+	failOn        string
+	ignoreUnfixed bool
+	onlyFixable   bool
+	ignore        []string
+	format        string
+	output        string
+	dockerfile    string
+}
+
+func newScanCmd() *cobra.Command {
+	opts := &scanOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "scan IMAGE...",
+		Short: "Scan container images for known vulnerabilities",
+		Long: `Scan container images with Trivy or Grype and fail when a finding
+reaches the --fail-on severity.
+
+Findings of both scanners are normalized to one format: duplicates reported
+for several layers are merged and severities such as Negligible are mapped
+to low. Multi-arch images are scanned once per --platform, so a build can
+gate every architecture it publishes.
+
+With --server, Trivy runs in client mode against a Trivy server and no
+vulnerability database is downloaded locally.
+
+The report can be written as JSON or as SARIF for code scanning uploads;
+SARIF results are attached to --dockerfile.`,
+		Example: `  # Scan a local image and fail on critical vulnerabilities
+  gz docker scan myapp:latest
+
+  # Gate every platform of a multi-arch build on high and critical findings
+  gz docker scan ghcr.io/myorg/app:1.4.0 --platform linux/amd64 --platform linux/arm64 --fail-on high
+
+  # Upload results to GitHub code scanning
+  gz docker scan myapp:latest --output results.sarif --fail-on none
+
+  # Use a shared Trivy server and ignore a vetted CVE
+  gz docker scan myapp:latest --server http://trivy.internal:4954 --ignore CVE-2023-12345`,
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScan(cmd, opts, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.scanner, "scanner", imagescan.ScannerAuto, "Scanner to run: auto, trivy or grype")
+	cmd.Flags().StringVar(&opts.server, "server", "", "Trivy server URL (client mode)")
+	cmd.Flags().StringSliceVar(&opts.platforms, "platform", nil, "Platforms of a multi-arch image to scan (e.g. linux/arm64)")
+	cmd.Flags().StringVar(&opts.failOn, "fail-on", "critical", "Lowest severity that fails the scan: low, medium, high, critical or none")
+	cmd.Flags().BoolVar(&opts.ignoreUnfixed, "ignore-unfixed", false, "Leave out vulnerabilities without a fix")
+	cmd.Flags().BoolVar(&opts.onlyFixable, "fail-on-fixable", false, "Only fail on vulnerabilities that have a fix")
+	cmd.Flags().StringSliceVar(&opts.ignore, "ignore", nil, "Vulnerability IDs that never fail the scan")
+	cmd.Flags().StringVarP(&opts.format, "format", "f", "table", "Output format: table, json or sarif")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Also write the report to this file (.sarif for SARIF, otherwise JSON)")
+	cmd.Flags().StringVar(&opts.dockerfile, "dockerfile", "Dockerfile", "File SARIF results are attached to")
+
+	return cmd
+}
+
+func runScan(cmd *cobra.Command, opts *scanOptions, images []string) error {
+	var gate *imagescan.Gate
+	if !strings.EqualFold(opts.failOn, "none") {
+		severity, err := imagescan.ParseSeverity(opts.failOn)
+		if err != nil {
+			return err
+		}
+		gate = &imagescan.Gate{FailOn: severity, OnlyFixable: opts.onlyFixable, Ignore: opts.ignore}
+	}
+	switch opts.format {
+	case "table", "json", "sarif":
+	default:
+		return fmt.Errorf("unsupported format %q: use table, json or sarif", opts.format)
+	}
+
+	scanner, err := newScanner(opts.scanner, imagescan.Options{Server: opts.server, IgnoreUnfixed: opts.ignoreUnfixed})
+	if err != nil {
+		return err
+	}
+
+	var targets []imagescan.Target
+	for _, image := range images {
+		if len(opts.platforms) == 0 {
+			targets = append(targets, imagescan.Target{Image: image})
+			continue
+		}
+		for _, platform := range opts.platforms {
+			targets = append(targets, imagescan.Target{Image: image, Platform: platform})
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if opts.format == "table" {
+		fmt.Fprintf(out, "🔍 Scanning %d image(s) with %s\n", len(targets), scanner.Name())
+	}
+	report := imagescan.Scan(cmd.Context(), scanner, targets, gate)
+
+	switch opts.format {
+	case "json":
+		err = report.WriteJSON(out)
+	case "sarif":
+		err = report.WriteSARIF(out, opts.dockerfile)
+	default:
+		report.PrintSummary(out)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.output != "" {
+		format := "json"
+		if strings.EqualFold(filepath.Ext(opts.output), ".sarif") {
+			format = "sarif"
+		}
+		if err := report.WriteFile(opts.output, format, opts.dockerfile); err != nil {
+			return err
+		}
+		if opts.format == "table" {
+			fmt.Fprintf(out, "📝 Report written to %s\n", opts.output)
+		}
+	}
+
+	if failed := report.Failed(); len(failed) > 0 {
+		return fmt.Errorf("%d image(s) could not be scanned", len(failed))
+	}
+	if n := report.Violations(); n > 0 {
+		return fmt.Errorf("%d vulnerability finding(s) at or above %s", n, report.FailOn)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package docker
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/security/imagescan"
+)
+
+type stubScanner struct {
+	targets []imagescan.Target
+}
+
+func (s *stubScanner) Name() string { return "stub" }
+
+func (s *stubScanner) Scan(_ context.Context, target imagescan.Target) ([]imagescan.Finding, error) {
+	s.targets = append(s.targets, target)
+	return []imagescan.Finding{
+		{ID: "CVE-2024-0001", Package: "openssl", InstalledVersion: "3.0.0", FixedVersion: "3.0.1", Severity: imagescan.SeverityHigh},
+		{ID: "CVE-2024-0002", Package: "zlib", InstalledVersion: "1.2", Severity: imagescan.SeverityLow},
+	}, nil
+}
+
+func runScanCmd(t *testing.T, args ...string) (*stubScanner, string, error) {
+	t.Helper()
+	stub := &stubScanner{}
+	orig := newScanner
+	newScanner = func(string, imagescan.Options) (imagescan.Scanner, error) { return stub, nil }
+	t.Cleanup(func() { newScanner = orig })
+
+	cmd := newScanCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	cmd.SetContext(context.Background())
+	err := cmd.Execute()
+	return stub, out.String(), err
+}
+
+func TestScanCmd_Platforms(t *testing.T) {
+	stub, out, err := runScanCmd(t, "app:1.0", "--platform", "linux/amd64,linux/arm64")
+	require.NoError(t, err)
+
+	assert.Equal(t, []imagescan.Target{
+		{Image: "app:1.0", Platform: "linux/amd64"},
+		{Image: "app:1.0", Platform: "linux/arm64"},
+	}, stub.targets)
+	assert.Contains(t, out, "✅ No findings at or above critical")
+}
+
+func TestScanCmd_FailOn(t *testing.T) {
+	_, out, err := runScanCmd(t, "app:1.0", "--fail-on", "high")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 vulnerability finding(s) at or above high")
+	assert.Contains(t, out, "CVE-2024-0001")
+
+	_, _, err = runScanCmd(t, "app:1.0", "--fail-on", "low", "--ignore", "CVE-2024-0001", "--fail-on-fixable")
+	assert.NoError(t, err)
+
+	_, _, err = runScanCmd(t, "app:1.0", "--fail-on", "none")
+	assert.NoError(t, err)
+
+	_, _, err = runScanCmd(t, "app:1.0", "--fail-on", "severe")
+	assert.ErrorContains(t, err, "unknown severity")
+}
+
+func TestScanCmd_OutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.sarif")
+	_, out, err := runScanCmd(t, "app:1.0", "--fail-on", "none", "--output", path, "--dockerfile", "docker/Dockerfile")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Report written to")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"version": "2.1.0"`)
+	assert.Contains(t, string(data), "docker/Dockerfile")
+}
+
+func TestScanCmd_JSONFormat(t *testing.T) {
+	_, out, err := runScanCmd(t, "app:1.0", "--format", "json", "--fail-on", "none")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "Scanning")
+	assert.Contains(t, out, `"scanner": "stub"`)
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/internal/testlib"
+)
+
+// BenchReport is the output of `gz doctor bench`.
+type BenchReport struct {
+	Run              *testlib.BenchRun         `json:"run"`
+	BaselineRevision string                    `json:"baselineRevision,omitempty"`
+	Comparisons      []testlib.BenchComparison `json:"comparisons,omitempty"`
+	Regressions      []testlib.BenchComparison `json:"regressions,omitempty"`
+}
+
+func newBenchCmd() *cobra.Command {
+	var (
+		filter           string
+		bench            testlib.BenchOptions
+		compare          testlib.CompareOptions
+		baseline         string
+		storeDir         string
+		noSave           bool
+		list             bool
+		format           string
+		failOnRegression bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Run the built-in benchmarks and detect performance regressions",
+		Long: `Run the registered micro and macro benchmarks of gz, store the results
+under the current git revision and compare them with a baseline run.
+
+Built-in benchmarks:
+  config/parse-yaml     parsing and validating gzh.yaml
+  cache/memory-set-get  HTTP cache operations in memory
+  cache/file-set-get    HTTP cache operations on disk
+  clone/http-mock       cloning from a local smart HTTP git server
+
+Each benchmark is sampled --samples times. The baseline is the run stored
+for --baseline, or the most recent stored run of another revision. A
+benchmark regresses when its time per operation grew by more than
+--threshold percent and a Mann-Whitney U test on the samples shows the
+difference at --confidence. Runs of a working tree with uncommitted
+changes are stored separately from the committed revision.
+
+Examples:
+  gz doctor bench                              # Run, store and compare with the last run
+  gz doctor bench --filter '^cache/'           # Only the cache benchmarks
+  gz doctor bench --baseline main --threshold 10
+  gz doctor bench --list`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			out := cmd.OutOrStdout()
+			if format != "table" && format != "json" {
+				return fmt.Errorf("unsupported format %q: use table or json", format)
+			}
+
+			benches, err := testlib.DefaultBenchmarks().Select(filter)
+			if err != nil {
+				return err
+			}
+			if len(benches) == 0 {
+				return fmt.Errorf("no benchmark matches %q", filter)
+			}
+			if list {
+				tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(tw, "NAME\tKIND\tDESCRIPTION")
+				for _, b := range benches {
+					fmt.Fprintf(tw, "%s\t%s\t%s\n", b.Name, b.Kind, b.Description)
+				}
+				return tw.Flush()
+			}
+
+			store := testlib.NewBenchStore(storeDir)
+			revision, dirty, err := testlib.GitRevision(ctx, ".")
+			if err != nil {
+				logger.SimpleWarn("Results will not be stored without a git revision", "error", err)
+			}
+
+			if format == "table" {
+				bench.Progress = func(b testlib.Benchmark) {
+					fmt.Fprintf(cmd.ErrOrStderr(), "⏱️  %s\n", b.Name)
+				}
+			}
+			run, err := testlib.RunBenchmarks(ctx, benches, bench)
+			if err != nil {
+				return err
+			}
+			run.Revision, run.Dirty = revision, dirty
+
+			base, err := loadBenchBaseline(cmd, store, baseline, run)
+			if err != nil {
+				return err
+			}
+			if run.Revision != "" && !noSave {
+				if err := store.Save(run); err != nil {
+					return fmt.Errorf("failed to store benchmark results: %w", err)
+				}
+			}
+
+			report := &BenchReport{Run: run}
+			report.Comparisons = testlib.CompareRuns(base, run, compare)
+			report.Regressions = testlib.Regressions(report.Comparisons)
+			if base != nil {
+				report.BaselineRevision = base.Key()
+			}
+
+			if format == "json" {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(report); err != nil {
+					return fmt.Errorf("failed to encode report: %w", err)
+				}
+			} else {
+				printBenchReport(out, report, compare)
+			}
+
+			if failOnRegression && len(report.Regressions) > 0 {
+				return fmt.Errorf("%d benchmark(s) regressed", len(report.Regressions))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&filter, "filter", "", "Regular expression selecting benchmarks by name")
+	cmd.Flags().IntVar(&bench.Samples, "samples", 10, "Timed samples per benchmark")
+	cmd.Flags().DurationVar(&bench.MinSampleTime, "min-time", 100*time.Millisecond, "Minimum duration of one sample")
+	cmd.Flags().Float64Var(&compare.Threshold, "threshold", 5, "Slowdown in percent that counts as a regression")
+	cmd.Flags().Float64Var(&compare.Confidence, "confidence", 0.95, "Required statistical confidence (0-1)")
+	cmd.Flags().StringVar(&baseline, "baseline", "", "Git revision of the stored run to compare with (default: latest other run)")
+	cmd.Flags().StringVar(&storeDir, "store-dir", testlib.DefaultBenchDir(), "Directory holding stored benchmark runs")
+	cmd.Flags().BoolVar(&noSave, "no-save", false, "Do not store the results of this run")
+	cmd.Flags().BoolVar(&list, "list", false, "List the benchmarks instead of running them")
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "Output format: table or json")
+	cmd.Flags().BoolVar(&failOnRegression, "fail-on-regression", true, "Exit non-zero when a benchmark regressed")
+
+	return cmd
+}
+
+// loadBenchBaseline returns the run to compare with, or nil when nothing is
+// stored yet. A revision given by the user is resolved through git first so
+// that branch names and short SHAs work.
+func loadBenchBaseline(cmd *cobra.Command, store *testlib.BenchStore, baseline string, run *testlib.BenchRun) (*testlib.BenchRun, error) {
+	if baseline == "" {
+		return store.Latest(run.Key())
+	}
+	out, err := exec.CommandContext(cmd.Context(), "git", "rev-parse", "--verify", "--quiet", baseline+"^{commit}").Output()
+	if err == nil {
+		baseline = strings.TrimSpace(string(out))
+	}
+	return store.Load(baseline)
+}
+
+func printBenchReport(w io.Writer, report *BenchReport, opts testlib.CompareOptions) {
+	run := report.Run
+	revision := run.Key()
+	if revision == "" {
+		revision = "(no git revision)"
+	}
+	fmt.Fprintf(w, "\n📊 Benchmarks at %s (%s, %s, %d CPUs)\n", revision, run.GoVersion, run.Platform, run.NumCPU)
+	if report.BaselineRevision != "" {
+		fmt.Fprintf(w, "   compared with %s\n", report.BaselineRevision)
+	}
+	fmt.Fprintln(w)
+
+	comparisons := make(map[string]testlib.BenchComparison, len(report.Comparisons))
+	for _, c := range report.Comparisons {
+		comparisons[c.Name] = c
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "BENCHMARK\tKIND\tTIME/OP\t±\tTHROUGHPUT\tBASELINE\tDELTA\tP\tVERDICT")
+	for _, res := range run.Results {
+		if res.Skipped != "" || res.Error != "" {
+			reason := res.Skipped
+			if res.Error != "" {
+				reason = "error: " + res.Error
+			}
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\t-\t-\t-\t%s\n", res.Name, res.Kind, reason)
+			continue
+		}
+
+		throughput := "-"
+		if res.Throughput > 0 {
+			throughput = formatBenchBytes(res.Throughput) + "/s"
+		}
+		spread := "-"
+		if res.MeanNs > 0 {
+			spread = fmt.Sprintf("%.1f%%", res.StdDevNs/res.MeanNs*100)
+		}
+		baselineNs, delta, p := "-", "-", "-"
+		c := comparisons[res.Name]
+		if c.BaselineNs > 0 {
+			baselineNs = formatBenchNs(c.BaselineNs)
+		}
+		if c.Verdict != testlib.VerdictNew && c.Verdict != testlib.VerdictIncomplete {
+			delta = fmt.Sprintf("%+.1f%%", c.DeltaPercent)
+			p = fmt.Sprintf("%.3f", c.PValue)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", res.Name, res.Kind,
+			formatBenchNs(res.MeanNs), spread, throughput, baselineNs, delta, p, benchVerdictLabel(c.Verdict))
+	}
+	_ = tw.Flush()
+
+	if len(report.Regressions) > 0 {
+		fmt.Fprintf(w, "\n🔴 %d regression(s) slower by more than %.0f%% at %.0f%% confidence\n",
+			len(report.Regressions), opts.Threshold, opts.Confidence*100)
+	} else if report.BaselineRevision != "" {
+		fmt.Fprintln(w, "\n✅ No significant regressions")
+	} else {
+		fmt.Fprintln(w, "\n💡 No baseline stored yet; this run becomes the baseline for the next one")
+	}
+}
+
+func benchVerdictLabel(verdict string) string {
+	switch verdict {
+	case testlib.VerdictRegression:
+		return "🔴 regression"
+	case testlib.VerdictImprovement:
+		return "🟢 improvement"
+	default:
+		return verdict
+	}
+}
+
+func formatBenchNs(ns float64) string {
+	switch {
+	case ns >= 1e9:
+		return fmt.Sprintf("%.2fs", ns/1e9)
+	case ns >= 1e6:
+		return fmt.Sprintf("%.2fms", ns/1e6)
+	case ns >= 1e3:
+		return fmt.Sprintf("%.2fµs", ns/1e3)
+	default:
+		return fmt.Sprintf("%.0fns", ns)
+	}
+}
+
+func formatBenchBytes(n float64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%.0f B", n)
+	}
+	div, exp := float64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", n/div, "KMGTPE"[exp])
+}
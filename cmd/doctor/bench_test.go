@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/testlib"
+)
+
+func runBenchCmd(t *testing.T, args ...string) (*bytes.Buffer, error) {
+	t.Helper()
+	cmd := newBenchCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	return &out, cmd.Execute()
+}
+
+func TestBenchCmd_List(t *testing.T) {
+	out, err := runBenchCmd(t, "--list")
+	require.NoError(t, err)
+	for _, name := range []string{"config/parse-yaml", "cache/memory-set-get", "cache/file-set-get", "clone/http-mock"} {
+		assert.Contains(t, out.String(), name)
+	}
+
+	_, err = runBenchCmd(t, "--list", "--filter", "^nothing$")
+	assert.Error(t, err)
+}
+
+func TestBenchCmd_ComparesWithStoredBaseline(t *testing.T) {
+	baseline := &testlib.BenchRun{
+		Revision:  "0000000000000000000000000000000000000000",
+		Timestamp: time.Now().Add(-time.Hour),
+		Results: []testlib.BenchResult{{
+			Name:    "cache/memory-set-get",
+			Kind:    testlib.BenchMicro,
+			Samples: []float64{1, 1, 1, 1},
+			MeanNs:  1,
+		}},
+	}
+	storeDir := t.TempDir()
+	require.NoError(t, testlib.NewBenchStore(storeDir).Save(baseline))
+	out, err := runBenchCmd(t, "--filter", "^cache/memory", "--samples", "4", "--min-time", "1ms",
+		"--store-dir", storeDir, "--no-save", "--format", "json")
+	require.Error(t, err, "a 1ns/op baseline must be reported as regressed")
+
+	var report BenchReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, baseline.Revision, report.BaselineRevision)
+	require.Len(t, report.Regressions, 1)
+	assert.Equal(t, "cache/memory-set-get", report.Regressions[0].Name)
+
+	_, err = runBenchCmd(t, "--filter", "^cache/memory", "--samples", "4", "--min-time", "1ms",
+		"--store-dir", storeDir, "--no-save", "--format", "json", "--fail-on-regression=false")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,860 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/internal/errors"
+	"github.com/gizzahub/gzh-cli/internal/sshconfig"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+	"github.com/gizzahub/gzh-cli/pkg/github"
+	synclone "github.com/gizzahub/gzh-cli/pkg/synclone"
+)
+
+// Cross-file configuration checks, in report order.
+const (
+	cfgCheckLoad      = "load"
+	cfgCheckProviders = "providers"
+	cfgCheckPaths     = "paths"
+	cfgCheckScopes    = "scopes"
+	cfgCheckHosts     = "hosts"
+)
+
+var cfgCheckOrder = []string{cfgCheckLoad, cfgCheckProviders, cfgCheckPaths, cfgCheckScopes, cfgCheckHosts}
+
+// providerGitHosts are the SSH/HTTPS git hosts of providers without an api_url.
+var providerGitHosts = map[string]string{
+	config.ProviderGitHub: "github.com",
+	config.ProviderGitLab: "gitlab.com",
+	config.ProviderGitea:  "gitea.com",
+}
+
+// impliedScopes lists the GitHub OAuth scopes granted by a broader scope.
+var impliedScopes = map[string][]string{
+	"repo":      {"public_repo", "repo:status", "repo_deployment"},
+	"admin:org": {"write:org", "read:org"},
+	"write:org": {"read:org"},
+}
+
+// ConfigFiles are the gz configuration files checked against each other.
+// Empty paths are skipped.
+type ConfigFiles struct {
+	GZH       string   `json:"gzh,omitempty"`       // gzh.yaml: providers, organizations, gz serve
+	Synclone  string   `json:"synclone,omitempty"`  // bulk-clone.yaml
+	Schedules string   `json:"schedules,omitempty"` // gz serve schedules.yaml
+	SSHConfig string   `json:"sshConfig,omitempty"` // OpenSSH client config
+	Policies  []string `json:"policies,omitempty"`  // gz actions-policy policy files
+}
+
+// ConfigFinding is one inconsistency between configuration files.
+type ConfigFinding struct {
+	Check   string   `json:"check"`
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Files   []string `json:"files,omitempty"`
+	Error   string   `json:"error,omitempty"`
+	ErrCode string   `json:"errorCode,omitempty"`
+	Hints   []string `json:"hints,omitempty"`
+}
+
+// ConfigCheckReport is the result of a cross-file configuration check.
+type ConfigCheckReport struct {
+	Files    ConfigFiles     `json:"files"`
+	Findings []ConfigFinding `json:"findings"`
+}
+
+// Failed returns the number of failing findings.
+func (r *ConfigCheckReport) Failed() int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Status == statusFail {
+			n++
+		}
+	}
+	return n
+}
+
+// ConfigChecker loads every gz configuration file and reports settings that
+// contradict each other: providers used but not configured, target paths
+// shared by several organizations, tokens missing scopes the configured
+// operations need and hosts that cannot be reached.
+type ConfigChecker struct {
+	Files ConfigFiles
+	// Offline skips the scope and reachability checks.
+	Offline bool
+	// Timeout bounds each network request.
+	Timeout time.Duration
+	// Scopes returns the OAuth scopes of a GitHub token, or nil when the
+	// token does not report scopes (fine-grained tokens, GitHub Apps).
+	Scopes func(ctx context.Context, apiURL, token string) ([]string, error)
+	// Dial opens a TCP connection; defaults to net.Dialer.
+	Dial func(ctx context.Context, network, addr string) (net.Conn, error)
+	// Getenv reads token environment variables; defaults to os.Getenv.
+	Getenv func(string) string
+}
+
+// configSet holds the loaded files.
+type configSet struct {
+	gzh       *config.UnifiedConfig
+	synclone  *synclone.BulkCloneConfig
+	schedules []scheduleRef
+	ssh       *sshconfig.Config
+	policies  map[string]*github.ActionsPolicy
+}
+
+// scheduleRef is the part of a gz serve schedule the checks need.
+type scheduleRef struct {
+	Name   string         `yaml:"name"`
+	Type   string         `yaml:"type"`
+	Params map[string]any `yaml:"params"`
+}
+
+// providerRef is a use of a provider by one of the files.
+type providerRef struct {
+	provider string
+	file     string
+	where    string
+	// scopes are the GitHub scopes the use needs.
+	scopes []string
+}
+
+// Run loads the files and runs every check.
+func (c *ConfigChecker) Run(ctx context.Context) *ConfigCheckReport {
+	report := &ConfigCheckReport{Files: c.Files}
+	set := c.load(report)
+
+	refs := c.references(set)
+	c.checkProviders(set, refs, report)
+	c.checkPaths(set, report)
+	if !c.Offline {
+		c.checkScopes(ctx, set, refs, report)
+		c.checkHosts(ctx, set, refs, report)
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return cfgCheckIndex(report.Findings[i].Check) < cfgCheckIndex(report.Findings[j].Check)
+	})
+	return report
+}
+
+func cfgCheckIndex(check string) int {
+	for i, c := range cfgCheckOrder {
+		if c == check {
+			return i
+		}
+	}
+	return len(cfgCheckOrder)
+}
+
+func (c *ConfigChecker) getenv(key string) string {
+	if c.Getenv != nil {
+		return c.Getenv(key)
+	}
+	return os.Getenv(key)
+}
+
+func (c *ConfigChecker) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 5 * time.Second
+	}
+	return c.Timeout
+}
+
+func (c *ConfigChecker) load(report *ConfigCheckReport) *configSet {
+	set := &configSet{policies: map[string]*github.ActionsPolicy{}}
+	fail := func(path string, err error) {
+		report.add(cfgCheckLoad, statusFail, "cannot load "+path, []string{path},
+			errors.NewConfigError("failed to load configuration file", err))
+	}
+
+	if path := c.Files.GZH; path != "" {
+		if result, err := config.NewUnifiedLoader().LoadConfigFromPath(path); err != nil {
+			fail(path, err)
+		} else {
+			set.gzh = result.Config
+		}
+	}
+	if path := c.Files.Synclone; path != "" {
+		var cfg synclone.BulkCloneConfig
+		if err := readYAML(path, &cfg); err != nil {
+			fail(path, err)
+		} else {
+			set.synclone = &cfg
+		}
+	}
+	if path := c.Files.Schedules; path != "" {
+		var file struct {
+			Schedules []scheduleRef `yaml:"schedules"`
+		}
+		if err := readYAML(path, &file); err != nil {
+			fail(path, err)
+		} else {
+			set.schedules = file.Schedules
+		}
+	}
+	if path := c.Files.SSHConfig; path != "" {
+		if cfg, err := sshconfig.Load(path); err != nil {
+			fail(path, err)
+		} else {
+			set.ssh = cfg
+		}
+	}
+	for _, path := range c.Files.Policies {
+		policy := github.GetDefaultActionsPolicy()
+		if err := readYAML(path, policy); err != nil {
+			fail(path, err)
+		} else {
+			set.policies[path] = policy
+		}
+	}
+	return set
+}
+
+func readYAML(path string, v any) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, v)
+}
+
+// references collects every provider use outside the providers section of
+// gzh.yaml, which is what configures them.
+func (c *ConfigChecker) references(set *configSet) []providerRef {
+	var refs []providerRef
+
+	if set.gzh != nil {
+		for name, p := range set.gzh.Providers {
+			if p == nil {
+				continue
+			}
+			for _, org := range p.Organizations {
+				if org == nil {
+					continue
+				}
+				ref := providerRef{provider: name, file: c.Files.GZH, where: "organization " + org.Name}
+				if org.Visibility == "private" || org.Visibility == "all" {
+					ref.scopes = append(ref.scopes, "repo")
+				}
+				if org.RepoManagement != nil {
+					ref.scopes = append(ref.scopes, "admin:org")
+				}
+				refs = append(refs, ref)
+			}
+		}
+		if set.gzh.DefaultProvider != "" {
+			refs = append(refs, providerRef{provider: set.gzh.DefaultProvider, file: c.Files.GZH, where: "defaultProvider"})
+		}
+		if s := set.gzh.SSHConfig; s != nil {
+			for name := range s.ProviderConfigs {
+				refs = append(refs, providerRef{provider: name, file: c.Files.GZH, where: "sshConfig.provider_configs"})
+			}
+		}
+	}
+
+	if set.synclone != nil {
+		for _, root := range set.synclone.RepoRoots {
+			refs = append(refs, providerRef{provider: root.Provider, file: c.Files.Synclone, where: "repoRoots " + root.OrgName})
+		}
+	}
+
+	for _, s := range set.schedules {
+		provider, _ := s.Params["provider"].(string)
+		ref := providerRef{provider: provider, file: c.Files.Schedules, where: "schedule " + s.Name}
+		switch s.Type {
+		case "compliance":
+			ref.provider, ref.scopes = config.ProviderGitHub, []string{"admin:org"}
+		case "sync":
+			ref.scopes = []string{"repo"}
+		case "bulk-clone":
+			if v, _ := s.Params["visibility"].(string); v == "private" || v == "all" {
+				ref.scopes = []string{"repo"}
+			}
+		}
+		if ref.provider != "" {
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, path := range sortedPolicyPaths(set.policies) {
+		policy := set.policies[path]
+		ref := providerRef{provider: config.ProviderGitHub, file: path, where: "policy " + policy.Name, scopes: []string{"admin:org"}}
+		if policy.Repository != "" {
+			ref.scopes = []string{"repo"}
+		}
+		refs = append(refs, ref)
+	}
+
+	for i := range refs {
+		refs[i].provider = strings.ToLower(refs[i].provider)
+	}
+	return refs
+}
+
+func sortedPolicyPaths(policies map[string]*github.ActionsPolicy) []string {
+	paths := make([]string, 0, len(policies))
+	for path := range policies {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// providerToken returns the token of a provider from gzh.yaml or its
+// <PROVIDER>_TOKEN variable, and whether gzh.yaml configures the provider.
+func (c *ConfigChecker) providerToken(set *configSet, provider string) (token string, configured bool) {
+	if set.gzh != nil {
+		if p, ok := set.gzh.Providers[provider]; ok && p != nil {
+			configured = true
+			token = config.ExpandEnvironmentVariables(p.Token)
+			if strings.HasPrefix(token, "$") {
+				token = ""
+			}
+		}
+	}
+	if token == "" {
+		token = c.getenv(strings.ToUpper(provider) + "_TOKEN")
+	}
+	return token, configured
+}
+
+func (c *ConfigChecker) checkProviders(set *configSet, refs []providerRef, report *ConfigCheckReport) {
+	reported := map[string]bool{}
+	for _, ref := range refs {
+		key := ref.provider + "|" + ref.file
+		if reported[key] {
+			continue
+		}
+		token, configured := c.providerToken(set, ref.provider)
+		switch {
+		case !configured && token == "":
+			reported[key] = true
+			report.add(cfgCheckProviders, statusFail,
+				fmt.Sprintf("%s uses provider %q, which is not configured", ref.where, ref.provider),
+				nonEmpty(ref.file, c.Files.GZH),
+				errors.NewStandardError(errors.ErrorCodeMissingConfig, "provider referenced but not configured", errors.SeverityHigh).
+					WithSuggestion(fmt.Sprintf("Add a providers.%s section to gzh.yaml or set %s_TOKEN", ref.provider, strings.ToUpper(ref.provider))))
+		case token == "":
+			reported[key] = true
+			report.add(cfgCheckProviders, statusWarn,
+				fmt.Sprintf("provider %q used by %s has no token", ref.provider, ref.where),
+				nonEmpty(ref.file, c.Files.GZH),
+				errors.NewStandardError(errors.ErrorCodeInvalidToken, "provider token missing", errors.SeverityMedium).
+					WithSuggestion(fmt.Sprintf("Set providers.%s.token in gzh.yaml or export %s_TOKEN", ref.provider, strings.ToUpper(ref.provider))))
+		}
+	}
+
+	// 정책 파일의 조직이 gzh.yaml에 없으면 정책이 적용될 저장소를 알 수 없음
+	if set.gzh == nil {
+		return
+	}
+	orgs := map[string]bool{}
+	if p := set.gzh.Providers[config.ProviderGitHub]; p != nil {
+		for _, org := range p.Organizations {
+			if org != nil {
+				orgs[strings.ToLower(org.Name)] = true
+			}
+		}
+	}
+	for _, path := range sortedPolicyPaths(set.policies) {
+		org := set.policies[path].Organization
+		if org == "" || orgs[strings.ToLower(org)] {
+			continue
+		}
+		report.add(cfgCheckProviders, statusWarn,
+			fmt.Sprintf("actions policy targets organization %q, which gzh.yaml does not list", org),
+			[]string{path, c.Files.GZH},
+			errors.NewStandardError(errors.ErrorCodeMissingConfig, "policy organization not configured", errors.SeverityLow).
+				WithSuggestion(fmt.Sprintf("Add %s to providers.github.organizations or fix the policy's organization", org)))
+	}
+}
+
+// target is an organization cloned into a directory.
+type target struct {
+	key  string
+	file string
+}
+
+func (c *ConfigChecker) checkPaths(set *configSet, report *ConfigCheckReport) {
+	byPath := map[string][]target{}
+	add := func(dir, provider, org, file string) {
+		if dir == "" {
+			return
+		}
+		path := normalizeTargetPath(dir)
+		t := target{key: strings.ToLower(provider + "/" + org), file: file}
+		for _, existing := range byPath[path] {
+			if existing.key == t.key {
+				return
+			}
+		}
+		byPath[path] = append(byPath[path], t)
+	}
+
+	if set.gzh != nil {
+		for _, name := range sortedProviderNames(set.gzh.Providers) {
+			for _, org := range set.gzh.Providers[name].Organizations {
+				if org != nil && !org.Flatten {
+					add(org.CloneDir, name, org.Name, c.Files.GZH)
+				}
+			}
+		}
+	}
+	if set.synclone != nil {
+		for _, root := range set.synclone.RepoRoots {
+			add(root.RootPath, root.Provider, root.OrgName, c.Files.Synclone)
+		}
+	}
+
+	paths := make([]string, 0, len(byPath))
+	for path := range byPath {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		targets := byPath[path]
+		if len(targets) < 2 {
+			continue
+		}
+		keys := make([]string, 0, len(targets))
+		var files []string
+		for _, t := range targets {
+			keys = append(keys, t.key)
+			if !containsString(files, t.file) {
+				files = append(files, t.file)
+			}
+		}
+		report.add(cfgCheckPaths, statusFail,
+			fmt.Sprintf("%s is the target path of %s", path, strings.Join(keys, ", ")),
+			files,
+			errors.NewStandardError(errors.ErrorCodeInvalidConfig, "duplicate target path", errors.SeverityHigh).
+				WithSuggestion("Give each organization its own clone_dir/rootPath so clones do not overwrite each other"))
+	}
+}
+
+func normalizeTargetPath(dir string) string {
+	dir = sshconfig.ExpandHome(os.ExpandEnv(dir))
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return filepath.Clean(dir)
+}
+
+func sortedProviderNames(providers map[string]*config.ProviderConfig) []string {
+	names := make([]string, 0, len(providers))
+	for name, p := range providers {
+		if p != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *ConfigChecker) checkScopes(ctx context.Context, set *configSet, refs []providerRef, report *ConfigCheckReport) {
+	// 범위(scope)는 GitHub classic 토큰만 헤더로 알려줌
+	need := map[string][]providerRef{}
+	var order []string
+	for _, ref := range refs {
+		if ref.provider != config.ProviderGitHub {
+			continue
+		}
+		for _, scope := range ref.scopes {
+			if _, ok := need[scope]; !ok {
+				order = append(order, scope)
+			}
+			need[scope] = append(need[scope], ref)
+		}
+	}
+	if len(order) == 0 {
+		return
+	}
+	token, _ := c.providerToken(set, config.ProviderGitHub)
+	if token == "" {
+		return
+	}
+
+	scopesFunc := c.Scopes
+	if scopesFunc == nil {
+		scopesFunc = c.githubScopes
+	}
+	granted, err := scopesFunc(ctx, c.apiURL(set, config.ProviderGitHub), token)
+	if err != nil {
+		report.add(cfgCheckScopes, statusWarn, "cannot read the GitHub token scopes", []string{c.Files.GZH},
+			errors.WrapError(err, errors.ErrorCodeConnectionFailed, "token scope lookup failed", errors.SeverityMedium))
+		return
+	}
+	if granted == nil {
+		return
+	}
+
+	for _, scope := range order {
+		if hasScope(granted, scope) {
+			continue
+		}
+		var uses, files []string
+		for _, ref := range need[scope] {
+			uses = append(uses, ref.where)
+			if !containsString(files, ref.file) {
+				files = append(files, ref.file)
+			}
+		}
+		report.add(cfgCheckScopes, statusFail,
+			fmt.Sprintf("GitHub token lacks the %s scope needed by %s", scope, strings.Join(uses, ", ")),
+			files,
+			errors.NewStandardError(errors.ErrorCodeInsufficientPerms, "token scope missing", errors.SeverityHigh).
+				WithSuggestion(fmt.Sprintf("Regenerate the GitHub token with the %s scope (granted: %s)", scope, strings.Join(granted, ", "))))
+	}
+}
+
+func hasScope(granted []string, scope string) bool {
+	for _, g := range granted {
+		if g == scope || containsString(impliedScopes[g], scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// githubScopes reads X-OAuth-Scopes from the /user endpoint.
+func (c *ConfigChecker) githubScopes(ctx context.Context, apiURL, token string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(apiURL, "/")+"/user", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "token "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub returned HTTP %d", resp.StatusCode)
+	}
+
+	header, ok := resp.Header["X-Oauth-Scopes"]
+	if !ok {
+		return nil, nil
+	}
+	scopes := []string{}
+	for _, s := range strings.Split(strings.Join(header, ","), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes, nil
+}
+
+// apiURL returns the configured or default API URL of a provider.
+func (c *ConfigChecker) apiURL(set *configSet, provider string) string {
+	if set.gzh != nil {
+		if p := set.gzh.Providers[provider]; p != nil && p.APIURL != "" {
+			return p.APIURL
+		}
+	}
+	return defaultProviderAPIs[provider]
+}
+
+// hostRef is a host:port some file expects to reach.
+type hostRef struct {
+	addr  string
+	files []string
+	what  string
+}
+
+func (c *ConfigChecker) hosts(set *configSet, refs []providerRef) []hostRef {
+	byAddr := map[string]*hostRef{}
+	var order []string
+	add := func(host, port, file, what string) {
+		if host == "" {
+			return
+		}
+		addr := net.JoinHostPort(strings.ToLower(host), port)
+		h, ok := byAddr[addr]
+		if !ok {
+			h = &hostRef{addr: addr, what: what}
+			byAddr[addr] = h
+			order = append(order, addr)
+		}
+		if file != "" && !containsString(h.files, file) {
+			h.files = append(h.files, file)
+		}
+	}
+
+	gitHosts := map[string]bool{}
+	for _, ref := range refs {
+		api := c.apiURL(set, ref.provider)
+		if u, err := url.Parse(api); err == nil && u.Hostname() != "" {
+			port := u.Port()
+			if port == "" {
+				port = "443"
+			}
+			add(u.Hostname(), port, ref.file, ref.provider+" API")
+			gitHosts[u.Hostname()] = true
+		}
+		if host := providerGitHosts[ref.provider]; host != "" {
+			gitHosts[host] = true
+		}
+	}
+	if set.synclone != nil {
+		if u, err := url.Parse(set.synclone.Default.Gitlab.URL); err == nil && u.Hostname() != "" {
+			add(u.Hostname(), "443", c.Files.Synclone, "gitlab server")
+			gitHosts[u.Hostname()] = true
+		}
+	}
+
+	// SSH 별칭이 provider 호스트를 가리키면 실제 HostName:Port로 접속 가능해야 함
+	if set.ssh != nil {
+		for _, f := range set.ssh.Files {
+			for _, b := range f.Hosts() {
+				hostname, ok := b.Get("hostname")
+				if !ok || !gitHosts[strings.ToLower(hostname)] {
+					continue
+				}
+				port, ok := b.Get("port")
+				if !ok {
+					port = "22"
+				}
+				add(hostname, port, f.Path, "ssh alias "+b.Name())
+			}
+		}
+	}
+
+	hosts := make([]hostRef, 0, len(order))
+	for _, addr := range order {
+		hosts = append(hosts, *byAddr[addr])
+	}
+	return hosts
+}
+
+func (c *ConfigChecker) checkHosts(ctx context.Context, set *configSet, refs []providerRef, report *ConfigCheckReport) {
+	dial := c.Dial
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	for _, h := range c.hosts(set, refs) {
+		dctx, cancel := context.WithTimeout(ctx, c.timeout())
+		conn, err := dial(dctx, "tcp", h.addr)
+		cancel()
+		if err == nil {
+			_ = conn.Close()
+			continue
+		}
+		report.add(cfgCheckHosts, statusFail,
+			fmt.Sprintf("%s (%s) is unreachable", h.addr, h.what),
+			h.files,
+			timeoutAware(err, "host unreachable").
+				WithSuggestion("Check the host name in the configuration or run 'gz doctor network'"))
+	}
+}
+
+// add appends a finding carrying the code and suggestions of err.
+func (r *ConfigCheckReport) add(check, status, message string, files []string, err *errors.StandardError) {
+	hints := append([]string{}, err.Suggestions...)
+	for _, action := range errors.LookupCode(err.Code).Actions {
+		if !containsString(hints, action) {
+			hints = append(hints, action)
+		}
+	}
+	var clean []string
+	for _, f := range files {
+		if f != "" && !containsString(clean, f) {
+			clean = append(clean, f)
+		}
+	}
+	r.Findings = append(r.Findings, ConfigFinding{
+		Check:   check,
+		Status:  status,
+		Message: message,
+		Files:   clean,
+		Error:   err.Error(),
+		ErrCode: err.ID(),
+		Hints:   hints,
+	})
+}
+
+func nonEmpty(values ...string) []string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultConfigFiles finds the configuration files in their standard
+// locations.
+func defaultConfigFiles() ConfigFiles {
+	var files ConfigFiles
+	if path, err := config.FindConfigFile(); err == nil {
+		files.GZH = path
+	}
+	if path, err := synclone.FindConfigFile(); err == nil {
+		files.Synclone = path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return files
+	}
+	exists := func(path string) string {
+		if _, err := os.Stat(path); err != nil {
+			return ""
+		}
+		return path
+	}
+	files.Schedules = exists(filepath.Join(home, ".config", "gzh-manager", "schedules.yaml"))
+	files.SSHConfig = exists(filepath.Join(home, ".ssh", "config"))
+	if path := exists(filepath.Join(home, ".config", "gzh-manager", "actions-policy.yaml")); path != "" {
+		files.Policies = []string{path}
+	}
+	return files
+}
+
+func newConfigCheckCmd() *cobra.Command {
+	var (
+		files      ConfigFiles
+		offline    bool
+		timeout    time.Duration
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Check gz configuration files for cross-file inconsistencies",
+		Long: `Load every gz configuration file and report settings that contradict each other.
+
+Checks:
+  load       every file parses
+  providers  providers used by synclone, schedules, SSH settings or actions
+             policies are configured in gzh.yaml or have a <PROVIDER>_TOKEN
+  paths      no two organizations clone into the same target path
+  scopes     the GitHub token has the scopes the configured operations need
+  hosts      provider API hosts and SSH aliases of provider hosts are reachable
+
+Files are found in their standard locations unless given with flags.
+--offline skips the scope and reachability checks. Every finding carries an
+error code and the suggested fix.
+
+Examples:
+  gz doctor config                              # Check the default files
+  gz doctor config --offline                    # Without network access
+  gz doctor config --gzh ./gzh.yaml --synclone ./bulk-clone.yaml
+  gz doctor config --policy-file strict.yaml --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defaults := defaultConfigFiles()
+			if !cmd.Flags().Changed("gzh") {
+				files.GZH = defaults.GZH
+			}
+			if !cmd.Flags().Changed("synclone") {
+				files.Synclone = defaults.Synclone
+			}
+			if !cmd.Flags().Changed("schedules") {
+				files.Schedules = defaults.Schedules
+			}
+			if !cmd.Flags().Changed("ssh-config") {
+				files.SSHConfig = defaults.SSHConfig
+			}
+			if !cmd.Flags().Changed("policy-file") {
+				files.Policies = defaults.Policies
+			}
+
+			checker := &ConfigChecker{Files: files, Offline: offline, Timeout: timeout}
+			report := checker.Run(cmd.Context())
+
+			out := cmd.OutOrStdout()
+			if jsonOutput {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(report); err != nil {
+					return fmt.Errorf("failed to encode results: %w", err)
+				}
+			} else {
+				printConfigCheck(out, report)
+			}
+
+			if failed := report.Failed(); failed > 0 {
+				return fmt.Errorf("configuration check found %d problem(s)", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&files.GZH, "gzh", "", "gzh.yaml path (default: standard locations)")
+	cmd.Flags().StringVar(&files.Synclone, "synclone", "", "bulk-clone.yaml path (default: standard locations)")
+	cmd.Flags().StringVar(&files.Schedules, "schedules", "", "gz serve schedule file (default: ~/.config/gzh-manager/schedules.yaml)")
+	cmd.Flags().StringVar(&files.SSHConfig, "ssh-config", "", "SSH client config (default: ~/.ssh/config)")
+	cmd.Flags().StringSliceVar(&files.Policies, "policy-file", nil, "Actions policy files (default: ~/.config/gzh-manager/actions-policy.yaml)")
+	cmd.Flags().BoolVar(&offline, "offline", false, "Skip the token scope and host reachability checks")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Second, "Timeout for each network check")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the results as JSON")
+
+	return cmd
+}
+
+// printConfigCheck lists the checked files followed by the findings and
+// their fixes.
+func printConfigCheck(w io.Writer, report *ConfigCheckReport) {
+	symbols := map[string]string{statusPass: "✅", statusWarn: "⚠️ ", statusFail: "❌"}
+
+	fmt.Fprintln(w, "🧩 Configuration consistency")
+	fmt.Fprintln(w)
+	files := report.Files
+	for _, f := range []struct{ label, path string }{
+		{"gzh.yaml", files.GZH},
+		{"synclone", files.Synclone},
+		{"schedules", files.Schedules},
+		{"ssh config", files.SSHConfig},
+		{"policies", strings.Join(files.Policies, ", ")},
+	} {
+		path := f.path
+		if path == "" {
+			path = "-"
+		}
+		fmt.Fprintf(w, "   %-11s %s\n", f.label, path)
+	}
+
+	if len(report.Findings) == 0 {
+		fmt.Fprintf(w, "\n%s No inconsistencies found\n", symbols[statusPass])
+		return
+	}
+	for _, f := range report.Findings {
+		fmt.Fprintf(w, "\n%s %s: %s [%s]\n", symbols[f.Status], f.Check, f.Message, f.ErrCode)
+		if len(f.Files) > 0 {
+			fmt.Fprintf(w, "   files: %s\n", strings.Join(f.Files, ", "))
+		}
+		for _, hint := range f.Hints {
+			fmt.Fprintf(w, "   💡 %s\n", hint)
+		}
+	}
+}
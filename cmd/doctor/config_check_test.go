@@ -0,0 +1,145 @@
+//nolint:testpackage // White-box testing needed for internal function access
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func findingsByCheck(r *ConfigCheckReport) map[string][]ConfigFinding {
+	m := map[string][]ConfigFinding{}
+	for _, f := range r.Findings {
+		m[f.Check] = append(m[f.Check], f)
+	}
+	return m
+}
+
+func newTestConfigChecker(t *testing.T) (*ConfigChecker, string) {
+	t.Helper()
+	dir := t.TempDir()
+	clones := filepath.Join(dir, "src")
+
+	files := ConfigFiles{
+		GZH: writeConfigFile(t, dir, "gzh.yaml", `version: "1.0.0"
+providers:
+  github:
+    token: ghp_test
+    organizations:
+      - name: acme
+        clone_dir: `+filepath.Join(clones, "acme")+`
+        visibility: private
+`),
+		Synclone: writeConfigFile(t, dir, "bulk-clone.yaml", `version: "0.1"
+repoRoots:
+  - rootPath: `+filepath.Join(clones, "acme")+`
+    provider: github
+    protocol: ssh
+    orgName: acme-labs
+  - rootPath: `+filepath.Join(clones, "platform")+`
+    provider: gitlab
+    protocol: https
+    orgName: platform
+`),
+		Schedules: writeConfigFile(t, dir, "schedules.yaml", `schedules:
+  - name: nightly-audit
+    cron: "0 3 * * *"
+    type: compliance
+`),
+		SSHConfig: writeConfigFile(t, dir, "ssh_config", "Host gh-work\n  HostName github.com\n  Port 2222\n"),
+		Policies: []string{writeConfigFile(t, dir, "policy.yaml", `name: strict
+organization: ghost-org
+`)},
+	}
+
+	return &ConfigChecker{
+		Files:  files,
+		Getenv: func(string) string { return "" },
+		Scopes: func(context.Context, string, string) ([]string, error) {
+			return []string{"repo", "read:org"}, nil
+		},
+		Dial: func(_ context.Context, _, addr string) (net.Conn, error) {
+			if addr == "github.com:2222" {
+				return nil, errors.New("connection refused")
+			}
+			client, server := net.Pipe()
+			_ = server.Close()
+			return client, nil
+		},
+	}, clones
+}
+
+func TestConfigChecker_CrossFileFindings(t *testing.T) {
+	checker, clones := newTestConfigChecker(t)
+	report := checker.Run(context.Background())
+	checks := findingsByCheck(report)
+
+	assert.Empty(t, checks[cfgCheckLoad])
+
+	providers := checks[cfgCheckProviders]
+	require.Len(t, providers, 2)
+	assert.Equal(t, statusFail, providers[0].Status)
+	assert.Contains(t, providers[0].Message, `provider "gitlab"`)
+	assert.Equal(t, "GZ-CONFIG-002", providers[0].ErrCode)
+	assert.Contains(t, providers[0].Hints, "Add a providers.gitlab section to gzh.yaml or set GITLAB_TOKEN")
+	assert.Equal(t, statusWarn, providers[1].Status)
+	assert.Contains(t, providers[1].Message, "ghost-org")
+
+	paths := checks[cfgCheckPaths]
+	require.Len(t, paths, 1)
+	assert.Contains(t, paths[0].Message, filepath.Join(clones, "acme"))
+	assert.Contains(t, paths[0].Message, "github/acme, github/acme-labs")
+	assert.Equal(t, []string{checker.Files.GZH, checker.Files.Synclone}, paths[0].Files)
+
+	scopes := checks[cfgCheckScopes]
+	require.Len(t, scopes, 1)
+	assert.Contains(t, scopes[0].Message, "admin:org")
+	assert.Contains(t, scopes[0].Message, "schedule nightly-audit")
+	assert.Equal(t, "GZ-AUTH-003", scopes[0].ErrCode)
+
+	hosts := checks[cfgCheckHosts]
+	require.Len(t, hosts, 1)
+	assert.Contains(t, hosts[0].Message, "github.com:2222 (ssh alias gh-work)")
+	assert.Equal(t, []string{checker.Files.SSHConfig}, hosts[0].Files)
+
+	assert.Equal(t, 4, report.Failed())
+}
+
+func TestConfigChecker_OfflineAndLoadErrors(t *testing.T) {
+	checker, _ := newTestConfigChecker(t)
+	checker.Offline = true
+	checker.Files.Schedules = writeConfigFile(t, t.TempDir(), "schedules.yaml", "schedules: [")
+
+	report := checker.Run(context.Background())
+	checks := findingsByCheck(report)
+
+	require.Len(t, checks[cfgCheckLoad], 1)
+	assert.Equal(t, []string{checker.Files.Schedules}, checks[cfgCheckLoad][0].Files)
+	assert.Empty(t, checks[cfgCheckScopes])
+	assert.Empty(t, checks[cfgCheckHosts])
+
+	var buf bytes.Buffer
+	printConfigCheck(&buf, report)
+	assert.Contains(t, buf.String(), "paths: ")
+	assert.Contains(t, buf.String(), "💡")
+}
+
+func TestHasScope(t *testing.T) {
+	assert.True(t, hasScope([]string{"admin:org"}, "read:org"))
+	assert.True(t, hasScope([]string{"repo"}, "repo"))
+	assert.False(t, hasScope([]string{"write:org"}, "admin:org"))
+}
@@ -0,0 +1,909 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+)
+
+// defaultOSVURL is the OSV API used for vulnerability lookups.
+const defaultOSVURL = "https://api.osv.dev"
+
+// osvBatchSize is the maximum number of queries per OSV batch request.
+const osvBatchSize = 1000
+
+// Vulnerability severities, lowest first. Vulnerabilities without a rating
+// (most Go vulnerability database entries) are treated as high so that a
+// threshold never lets them pass unnoticed.
+const (
+	severityLow      = "low"
+	severityModerate = "moderate"
+	severityHigh     = "high"
+	severityCritical = "critical"
+	severityUnknown  = "unknown"
+)
+
+// licenseUnknown is reported when no license file is recognized.
+const licenseUnknown = "unknown"
+
+var severityRank = map[string]int{
+	severityLow:      1,
+	severityModerate: 2,
+	severityHigh:     3,
+	severityUnknown:  3,
+	severityCritical: 4,
+}
+
+// DepVulnerability is a known vulnerability affecting a dependency.
+type DepVulnerability struct {
+	ID       string   `json:"id"`
+	Aliases  []string `json:"aliases,omitempty"`
+	Summary  string   `json:"summary,omitempty"`
+	Severity string   `json:"severity"`
+	Fixed    []string `json:"fixed,omitempty"`
+	URL      string   `json:"url"`
+}
+
+// DepModule is the health of one module in the build list.
+type DepModule struct {
+	Path            string             `json:"path"`
+	Version         string             `json:"version"`
+	Indirect        bool               `json:"indirect,omitempty"`
+	Replace         string             `json:"replace,omitempty"`
+	Update          string             `json:"update,omitempty"`
+	Deprecated      string             `json:"deprecated,omitempty"`
+	Retracted       []string           `json:"retracted,omitempty"`
+	License         string             `json:"license"`
+	MissingGoSum    bool               `json:"missingGoSum,omitempty"`
+	Vulnerabilities []DepVulnerability `json:"vulnerabilities,omitempty"`
+
+	dir string
+}
+
+// hasIssues reports whether the module needs attention.
+func (m DepModule) hasIssues() bool {
+	return m.Update != "" || m.Deprecated != "" || len(m.Retracted) > 0 || m.MissingGoSum || len(m.Vulnerabilities) > 0
+}
+
+// DepsSummary counts the findings of a report.
+type DepsSummary struct {
+	Modules         int            `json:"modules"`
+	Direct          int            `json:"direct"`
+	Outdated        int            `json:"outdated"`
+	OutdatedDirect  int            `json:"outdatedDirect"`
+	Retracted       int            `json:"retracted"`
+	Deprecated      int            `json:"deprecated"`
+	MissingGoSum    int            `json:"missingGoSum"`
+	Vulnerable      int            `json:"vulnerable"`
+	Vulnerabilities int            `json:"vulnerabilities"`
+	Licenses        map[string]int `json:"licenses"`
+}
+
+// DepsReport is the result of analyzing a Go module.
+type DepsReport struct {
+	Module       string      `json:"module"`
+	GoVersion    string      `json:"goVersion,omitempty"`
+	GoMod        string      `json:"goMod"`
+	Offline      bool        `json:"offline,omitempty"`
+	Dependencies []DepModule `json:"dependencies"`
+	Summary      DepsSummary `json:"summary"`
+}
+
+// goListModule is the subset of `go list -m -json` output used here.
+type goListModule struct {
+	Path       string
+	Version    string
+	Main       bool
+	Indirect   bool
+	Dir        string
+	GoMod      string
+	GoVersion  string
+	Deprecated string
+	Retracted  []string
+	Update     *goListModule
+	Replace    *goListModule
+}
+
+// DepsAnalyzer inspects the dependencies of a Go module.
+type DepsAnalyzer struct {
+	// Dir is the module root; it must contain go.mod.
+	Dir string
+	// Offline skips the module proxy and OSV lookups.
+	Offline bool
+	// SkipVulns skips only the OSV lookup.
+	SkipVulns bool
+	// OSVURL overrides the OSV API endpoint (used in tests).
+	OSVURL string
+	// HTTPClient is used for OSV requests.
+	HTTPClient *http.Client
+	// ListModules returns `go list -m -json all` output; defaults to running go.
+	ListModules func(ctx context.Context, dir string, online bool) ([]byte, error)
+}
+
+// goListModules runs go list in dir. Online lookups add available updates,
+// retractions and deprecations from the module proxy.
+func goListModules(ctx context.Context, dir string, online bool) ([]byte, error) {
+	args := []string{"list", "-m", "-json"}
+	if online {
+		args = append(args, "-u", "-retracted")
+	}
+	args = append(args, "all")
+
+	cmd := exec.CommandContext(ctx, "go", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Analyze builds the dependency report.
+func (a *DepsAnalyzer) Analyze(ctx context.Context) (*DepsReport, error) {
+	dir := a.Dir
+	if dir == "" {
+		dir = "."
+	}
+	goModPath := filepath.Join(dir, "go.mod")
+	if _, err := os.Stat(goModPath); err != nil {
+		return nil, fmt.Errorf("no go.mod in %s: %w", dir, err)
+	}
+
+	list := a.ListModules
+	if list == nil {
+		list = goListModules
+	}
+	out, err := list(ctx, dir, !a.Offline)
+	if err != nil {
+		return nil, err
+	}
+	modules, err := parseGoList(out)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DepsReport{GoMod: goModPath, Offline: a.Offline}
+	sums := readGoSum(filepath.Join(dir, "go.sum"))
+	for _, m := range modules {
+		if m.Main {
+			report.Module = m.Path
+			report.GoVersion = m.GoVersion
+			continue
+		}
+		report.Dependencies = append(report.Dependencies, newDepModule(m, sums))
+	}
+
+	if !a.Offline && !a.SkipVulns {
+		if err := a.lookupVulnerabilities(ctx, report.Dependencies); err != nil {
+			return nil, err
+		}
+	}
+
+	report.Summary = summarizeDeps(report.Dependencies)
+	return report, nil
+}
+
+func parseGoList(out []byte) ([]goListModule, error) {
+	var modules []goListModule
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var m goListModule
+		err := dec.Decode(&m)
+		if err == io.EOF {
+			return modules, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse go list output: %w", err)
+		}
+		modules = append(modules, m)
+	}
+}
+
+func newDepModule(m goListModule, sums map[string]bool) DepModule {
+	dep := DepModule{
+		Path:       m.Path,
+		Version:    m.Version,
+		Indirect:   m.Indirect,
+		Deprecated: m.Deprecated,
+		Retracted:  m.Retracted,
+		dir:        m.Dir,
+	}
+	if m.Update != nil {
+		dep.Update = m.Update.Version
+	}
+	if m.Replace != nil {
+		dep.Replace = strings.TrimSpace(m.Replace.Path + " " + m.Replace.Version)
+		if dep.dir == "" {
+			dep.dir = m.Replace.Dir
+		}
+	}
+	// 모든 빌드 목록 모듈은 최소한 go.mod 해시가 go.sum에 있어야 한다
+	if sums != nil && m.Version != "" && (m.Replace == nil || m.Replace.Version != "") {
+		path, version := m.Path, m.Version
+		if m.Replace != nil {
+			path, version = m.Replace.Path, m.Replace.Version
+		}
+		dep.MissingGoSum = !sums[path+" "+version]
+	}
+	dep.License = detectLicense(dep.dir)
+	return dep
+}
+
+// readGoSum returns the "path version" pairs that have a go.mod hash, or
+// nil when go.sum cannot be read.
+func readGoSum(path string) map[string]bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	sums := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+		sums[fields[0]+" "+strings.TrimSuffix(fields[1], "/go.mod")] = true
+	}
+	return sums
+}
+
+// licenseFiles are the file name prefixes searched for license text.
+var licenseFiles = []string{"LICENSE", "LICENCE", "COPYING"}
+
+// detectLicense classifies the license file in a module directory.
+func detectLicense(dir string) string {
+	if dir == "" {
+		return licenseUnknown
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return licenseUnknown
+	}
+	for _, entry := range entries {
+		name := strings.ToUpper(entry.Name())
+		for _, prefix := range licenseFiles {
+			if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				continue
+			}
+			if len(data) > 64<<10 {
+				data = data[:64<<10]
+			}
+			return classifyLicense(string(data))
+		}
+	}
+	return licenseUnknown
+}
+
+// classifyLicense recognizes common open source licenses by their text.
+func classifyLicense(text string) string {
+	upper := strings.ToUpper(strings.Join(strings.Fields(text), " "))
+	switch {
+	case strings.Contains(upper, "APACHE LICENSE") && strings.Contains(upper, "VERSION 2.0"):
+		return "Apache-2.0"
+	case strings.Contains(upper, "MOZILLA PUBLIC LICENSE") && strings.Contains(upper, "2.0"):
+		return "MPL-2.0"
+	case strings.Contains(upper, "GNU AFFERO GENERAL PUBLIC LICENSE"):
+		return "AGPL-3.0"
+	case strings.Contains(upper, "GNU LESSER GENERAL PUBLIC LICENSE"):
+		if strings.Contains(upper, "VERSION 3") {
+			return "LGPL-3.0"
+		}
+		return "LGPL-2.1"
+	case strings.Contains(upper, "GNU GENERAL PUBLIC LICENSE"):
+		if strings.Contains(upper, "VERSION 3") {
+			return "GPL-3.0"
+		}
+		return "GPL-2.0"
+	case strings.Contains(upper, "PERMISSION IS HEREBY GRANTED, FREE OF CHARGE"):
+		return "MIT"
+	case strings.Contains(upper, "PERMISSION TO USE, COPY, MODIFY, AND/OR DISTRIBUTE"):
+		return "ISC"
+	case strings.Contains(upper, "REDISTRIBUTION AND USE IN SOURCE AND BINARY FORMS"):
+		if strings.Contains(upper, "NEITHER THE NAME") || strings.Contains(upper, "NAMES OF ITS CONTRIBUTORS") {
+			return "BSD-3-Clause"
+		}
+		return "BSD-2-Clause"
+	case strings.Contains(upper, "THIS IS FREE AND UNENCUMBERED SOFTWARE"):
+		return "Unlicense"
+	default:
+		return licenseUnknown
+	}
+}
+
+// osvVuln is the subset of an OSV record used here.
+type osvVuln struct {
+	ID               string   `json:"id"`
+	Aliases          []string `json:"aliases"`
+	Summary          string   `json:"summary"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"` //nolint:tagliatelle // OSV schema field
+	Affected []struct {
+		Package struct {
+			Name      string `json:"name"`
+			Ecosystem string `json:"ecosystem"`
+		} `json:"package"`
+		Ranges []struct {
+			Events []struct {
+				Fixed string `json:"fixed"`
+			} `json:"events"`
+		} `json:"ranges"`
+	} `json:"affected"`
+}
+
+// lookupVulnerabilities queries OSV for every module version in deps.
+func (a *DepsAnalyzer) lookupVulnerabilities(ctx context.Context, deps []DepModule) error {
+	baseURL := strings.TrimSuffix(a.OSVURL, "/")
+	if baseURL == "" {
+		baseURL = defaultOSVURL
+	}
+	client := a.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second, Transport: metrics.InstrumentTransport("osv", nil)}
+	}
+	osv := &osvClient{baseURL: baseURL, http: client, cache: make(map[string]*osvVuln)}
+
+	for start := 0; start < len(deps); start += osvBatchSize {
+		end := min(start+osvBatchSize, len(deps))
+		ids, err := osv.queryBatch(ctx, deps[start:end])
+		if err != nil {
+			return err
+		}
+		for i, batch := range ids {
+			dep := &deps[start+i]
+			for _, id := range batch {
+				vuln, err := osv.vuln(ctx, id)
+				if err != nil {
+					return err
+				}
+				dep.Vulnerabilities = mergeVulnerability(dep.Vulnerabilities, toDepVulnerability(vuln, dep.Path))
+			}
+		}
+	}
+	return nil
+}
+
+type osvClient struct {
+	baseURL string
+	http    *http.Client
+	cache   map[string]*osvVuln
+}
+
+// queryBatch returns the vulnerability IDs affecting each module, in order.
+func (c *osvClient) queryBatch(ctx context.Context, deps []DepModule) ([][]string, error) {
+	type query struct {
+		Package struct {
+			Name      string `json:"name"`
+			Ecosystem string `json:"ecosystem"`
+		} `json:"package"`
+		Version string `json:"version"`
+	}
+	queries := make([]query, len(deps))
+	for i, dep := range deps {
+		queries[i].Package.Name = dep.Path
+		queries[i].Package.Ecosystem = "Go"
+		// OSV의 Go 생태계 버전은 "v" 접두사를 쓰지 않는다
+		queries[i].Version = strings.TrimPrefix(dep.Version, "v")
+	}
+
+	var response struct {
+		Results []struct {
+			Vulns []struct {
+				ID string `json:"id"`
+			} `json:"vulns"`
+		} `json:"results"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/v1/querybatch", map[string]any{"queries": queries}, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Results) != len(deps) {
+		return nil, fmt.Errorf("OSV returned %d results for %d queries", len(response.Results), len(deps))
+	}
+
+	ids := make([][]string, len(deps))
+	for i, result := range response.Results {
+		for _, v := range result.Vulns {
+			ids[i] = append(ids[i], v.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (c *osvClient) vuln(ctx context.Context, id string) (*osvVuln, error) {
+	if v, ok := c.cache[id]; ok {
+		return v, nil
+	}
+	var v osvVuln
+	if err := c.do(ctx, http.MethodGet, "/v1/vulns/"+id, nil, &v); err != nil {
+		return nil, err
+	}
+	c.cache[id] = &v
+	return &v, nil
+}
+
+func (c *osvClient) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("OSV request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("OSV %s %s returned %s", method, path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode OSV response: %w", err)
+	}
+	return nil
+}
+
+func toDepVulnerability(v *osvVuln, modulePath string) DepVulnerability {
+	dv := DepVulnerability{
+		ID:       v.ID,
+		Aliases:  v.Aliases,
+		Summary:  v.Summary,
+		Severity: normalizeSeverity(v.DatabaseSpecific.Severity),
+		URL:      "https://osv.dev/vulnerability/" + v.ID,
+	}
+	for _, affected := range v.Affected {
+		if affected.Package.Name != modulePath {
+			continue
+		}
+		for _, r := range affected.Ranges {
+			for _, event := range r.Events {
+				if event.Fixed != "" {
+					dv.Fixed = append(dv.Fixed, "v"+strings.TrimPrefix(event.Fixed, "v"))
+				}
+			}
+		}
+	}
+	return dv
+}
+
+func normalizeSeverity(s string) string {
+	switch strings.ToLower(s) {
+	case "low":
+		return severityLow
+	case "moderate", "medium":
+		return severityModerate
+	case "high":
+		return severityHigh
+	case "critical":
+		return severityCritical
+	default:
+		return severityUnknown
+	}
+}
+
+// mergeVulnerability adds v unless it is an alias of a vulnerability already
+// listed, which OSV returns once per database (GO-… and GHSA-…). The rated
+// severity wins when merging.
+func mergeVulnerability(list []DepVulnerability, v DepVulnerability) []DepVulnerability {
+	for i, existing := range list {
+		if existing.ID == v.ID || contains(existing.Aliases, v.ID) || contains(v.Aliases, existing.ID) {
+			if existing.Severity == severityUnknown {
+				list[i].Severity = v.Severity
+			}
+			if len(existing.Fixed) == 0 {
+				list[i].Fixed = v.Fixed
+			}
+			return list
+		}
+	}
+	return append(list, v)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func summarizeDeps(deps []DepModule) DepsSummary {
+	s := DepsSummary{Modules: len(deps), Licenses: make(map[string]int)}
+	for _, d := range deps {
+		if !d.Indirect {
+			s.Direct++
+		}
+		if d.Update != "" {
+			s.Outdated++
+			if !d.Indirect {
+				s.OutdatedDirect++
+			}
+		}
+		if len(d.Retracted) > 0 {
+			s.Retracted++
+		}
+		if d.Deprecated != "" {
+			s.Deprecated++
+		}
+		if d.MissingGoSum {
+			s.MissingGoSum++
+		}
+		if len(d.Vulnerabilities) > 0 {
+			s.Vulnerable++
+			s.Vulnerabilities += len(d.Vulnerabilities)
+		}
+		s.Licenses[d.License]++
+	}
+	return s
+}
+
+// DepsThresholds decide which findings fail the command.
+type DepsThresholds struct {
+	// FailOnSeverity fails on vulnerabilities at or above this severity;
+	// "none" disables the check.
+	FailOnSeverity string
+	// MaxOutdated fails when more direct dependencies are outdated; a
+	// negative value disables the check.
+	MaxOutdated      int
+	FailOnRetracted  bool
+	FailOnDeprecated bool
+	// DenyLicenses fails when a dependency uses one of these licenses.
+	DenyLicenses []string
+}
+
+// Violations returns a description of every threshold the report crosses.
+func (t DepsThresholds) Violations(report *DepsReport) []string {
+	var out []string
+
+	if rank, ok := severityRank[t.FailOnSeverity]; ok && t.FailOnSeverity != severityUnknown {
+		count := 0
+		for _, d := range report.Dependencies {
+			for _, v := range d.Vulnerabilities {
+				if severityRank[v.Severity] >= rank {
+					count++
+				}
+			}
+		}
+		if count > 0 {
+			out = append(out, fmt.Sprintf("%d vulnerabilities at or above %s severity", count, t.FailOnSeverity))
+		}
+	}
+	if t.MaxOutdated >= 0 && report.Summary.OutdatedDirect > t.MaxOutdated {
+		out = append(out, fmt.Sprintf("%d outdated direct dependencies (maximum %d)", report.Summary.OutdatedDirect, t.MaxOutdated))
+	}
+	if t.FailOnRetracted && report.Summary.Retracted > 0 {
+		out = append(out, fmt.Sprintf("%d retracted module versions", report.Summary.Retracted))
+	}
+	if t.FailOnDeprecated && report.Summary.Deprecated > 0 {
+		out = append(out, fmt.Sprintf("%d deprecated modules", report.Summary.Deprecated))
+	}
+	for _, license := range t.DenyLicenses {
+		for name, count := range report.Summary.Licenses {
+			if strings.EqualFold(name, license) {
+				out = append(out, fmt.Sprintf("%d modules licensed under %s", count, name))
+			}
+		}
+	}
+	return out
+}
+
+func newDepsCmd() *cobra.Command {
+	var (
+		analyzer   DepsAnalyzer
+		thresholds DepsThresholds
+		format     string
+		showAll    bool
+		table      cli.TableOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "deps",
+		Short: "Analyze the dependency health of the current Go module",
+		Long: `Inspect go.mod and go.sum of the Go module in --dir and report:
+
+  - outdated dependencies (newer versions on the module proxy)
+  - retracted versions and deprecated modules
+  - build list modules without a go.sum entry
+  - a license summary detected from the module cache
+  - known vulnerabilities from the OSV database (https://osv.dev)
+
+Vulnerabilities without a severity rating are treated as high. The command
+exits non-zero when a threshold is crossed, which makes it usable as a CI
+gate. --offline skips the module proxy and OSV and only reports local
+information.
+
+Examples:
+  gz doctor deps                                  # Table report, fail on high vulnerabilities
+  gz doctor deps --format json > deps.json
+  gz doctor deps --format sarif > deps.sarif      # Upload to code scanning
+  gz doctor deps --fail-on-severity critical --max-outdated 10
+  gz doctor deps --deny-license GPL-3.0,AGPL-3.0`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := table.Validate(depsColumns...); err != nil {
+				return err
+			}
+			if _, ok := severityRank[thresholds.FailOnSeverity]; !ok && thresholds.FailOnSeverity != "none" {
+				return fmt.Errorf("invalid --fail-on-severity %q: use none, low, moderate, high or critical", thresholds.FailOnSeverity)
+			}
+
+			report, err := analyzer.Analyze(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			switch format {
+			case "json":
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(report); err != nil {
+					return fmt.Errorf("failed to encode report: %w", err)
+				}
+			case "sarif":
+				if err := writeDepsSARIF(out, report); err != nil {
+					return err
+				}
+			case "table":
+				if err := printDepsReport(out, report, showAll, table); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("unsupported format %q: use table, json or sarif", format)
+			}
+
+			if violations := thresholds.Violations(report); len(violations) > 0 {
+				return fmt.Errorf("dependency check failed: %s", strings.Join(violations, "; "))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&analyzer.Dir, "dir", ".", "Directory of the Go module to analyze")
+	cmd.Flags().BoolVar(&analyzer.Offline, "offline", false, "Skip the module proxy and OSV lookups")
+	cmd.Flags().BoolVar(&analyzer.SkipVulns, "skip-vulns", false, "Skip the OSV vulnerability lookup")
+	cmd.Flags().StringVar(&analyzer.OSVURL, "osv-url", defaultOSVURL, "OSV API endpoint")
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "Output format: table, json or sarif")
+	cmd.Flags().BoolVar(&showAll, "all", false, "List every module instead of direct dependencies and modules with findings")
+	cli.AddTableFlags(cmd, &table, depsColumns...)
+	cmd.Flags().StringVar(&thresholds.FailOnSeverity, "fail-on-severity", severityHigh, "Fail on vulnerabilities at or above this severity (none, low, moderate, high, critical)")
+	cmd.Flags().IntVar(&thresholds.MaxOutdated, "max-outdated", -1, "Fail when more direct dependencies are outdated (-1 disables)")
+	cmd.Flags().BoolVar(&thresholds.FailOnRetracted, "fail-on-retracted", true, "Fail when a retracted version is used")
+	cmd.Flags().BoolVar(&thresholds.FailOnDeprecated, "fail-on-deprecated", false, "Fail when a deprecated module is used")
+	cmd.Flags().StringSliceVar(&thresholds.DenyLicenses, "deny-license", nil, "Fail when a dependency uses one of these licenses")
+
+	return cmd
+}
+
+// depIssues lists the findings of a module for the table output.
+func depIssues(d DepModule) []string {
+	var issues []string
+	for _, v := range d.Vulnerabilities {
+		issues = append(issues, fmt.Sprintf("%s (%s)", v.ID, v.Severity))
+	}
+	if len(d.Retracted) > 0 {
+		issues = append(issues, "retracted")
+	}
+	if d.Deprecated != "" {
+		issues = append(issues, "deprecated")
+	}
+	if d.MissingGoSum {
+		issues = append(issues, "missing go.sum entry")
+	}
+	return issues
+}
+
+// depsColumns are the columns of the `gz doctor deps` table.
+var depsColumns = []cli.Column{
+	{Name: "module"},
+	{Name: "version", NoTruncate: true},
+	{Name: "latest", NoTruncate: true},
+	{Name: "license"},
+	{Name: "issues"},
+}
+
+func printDepsReport(w io.Writer, report *DepsReport, showAll bool, opts cli.TableOptions) error {
+	fmt.Fprintf(w, "📦 Dependency health: %s", report.Module)
+	if report.GoVersion != "" {
+		fmt.Fprintf(w, " (go %s)", report.GoVersion)
+	}
+	fmt.Fprintln(w)
+	if report.Offline {
+		fmt.Fprintln(w, "   offline: updates, retractions and vulnerabilities were not checked")
+	}
+	fmt.Fprintln(w)
+
+	table := cli.NewTable(depsColumns...)
+	for _, d := range report.Dependencies {
+		if !showAll && d.Indirect && !d.hasIssues() {
+			continue
+		}
+		latest := "-"
+		if d.Update != "" {
+			latest = d.Update
+		}
+		path := cli.Hyperlink(d.Path, "https://pkg.go.dev/"+d.Path)
+		if d.Indirect {
+			path += " (indirect)"
+		}
+		issues := strings.Join(depIssues(d), ", ")
+		if issues == "" {
+			issues = "-"
+		}
+		table.AddRow(path, d.Version, latest, d.License, issues)
+	}
+	if err := table.Render(w, opts); err != nil {
+		return err
+	}
+
+	linker := cli.NewLinker(w)
+	for _, d := range report.Dependencies {
+		for _, v := range d.Vulnerabilities {
+			fmt.Fprintf(w, "\n🚨 %s %s@%s: %s\n", linker.Link(v.ID, v.URL), d.Path, d.Version, v.Summary)
+			if len(v.Fixed) > 0 {
+				fmt.Fprintf(w, "   💡 upgrade to %s\n", strings.Join(v.Fixed, " or "))
+			}
+			if !linker.Enabled() {
+				fmt.Fprintf(w, "   %s\n", v.URL)
+			}
+		}
+		if d.Deprecated != "" {
+			fmt.Fprintf(w, "\n⚠️  %s is deprecated: %s\n", d.Path, d.Deprecated)
+		}
+		if len(d.Retracted) > 0 {
+			fmt.Fprintf(w, "\n⚠️  %s@%s is retracted: %s\n", d.Path, d.Version, strings.Join(d.Retracted, "; "))
+		}
+	}
+
+	licenses := make([]string, 0, len(report.Summary.Licenses))
+	for name := range report.Summary.Licenses {
+		licenses = append(licenses, name)
+	}
+	sort.Slice(licenses, func(i, j int) bool {
+		ci, cj := report.Summary.Licenses[licenses[i]], report.Summary.Licenses[licenses[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return licenses[i] < licenses[j]
+	})
+	parts := make([]string, 0, len(licenses))
+	for _, name := range licenses {
+		parts = append(parts, fmt.Sprintf("%s %d", name, report.Summary.Licenses[name]))
+	}
+
+	s := report.Summary
+	fmt.Fprintf(w, "\n📜 Licenses: %s\n", strings.Join(parts, ", "))
+	fmt.Fprintf(w, "📊 %d modules (%d direct): %d outdated, %d retracted, %d deprecated, %d vulnerable (%d vulnerabilities)\n",
+		s.Modules, s.Direct, s.Outdated, s.Retracted, s.Deprecated, s.Vulnerable, s.Vulnerabilities)
+	return nil
+}
+
+// sarifLevels maps findings to SARIF result levels.
+var sarifLevels = map[string]string{
+	severityLow:      "note",
+	severityModerate: "warning",
+	severityHigh:     "error",
+	severityCritical: "error",
+	severityUnknown:  "error",
+}
+
+// writeDepsSARIF writes the findings as a SARIF 2.1.0 log located in go.mod.
+func writeDepsSARIF(w io.Writer, report *DepsReport) error {
+	type result struct {
+		RuleID    string         `json:"ruleId"`
+		Level     string         `json:"level"`
+		Message   map[string]any `json:"message"`
+		Locations []any          `json:"locations"`
+	}
+
+	goMod, _ := os.ReadFile(report.GoMod)
+	location := func(modulePath string) []any {
+		line := 1
+		for i, text := range strings.Split(string(goMod), "\n") {
+			fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(text), "require "))
+			if len(fields) > 0 && fields[0] == modulePath {
+				line = i + 1
+				break
+			}
+		}
+		return []any{map[string]any{
+			"physicalLocation": map[string]any{
+				"artifactLocation": map[string]any{"uri": "go.mod"},
+				"region":           map[string]any{"startLine": line},
+			},
+		}}
+	}
+	newResult := func(rule, level, text, modulePath string) result {
+		return result{RuleID: rule, Level: level, Message: map[string]any{"text": text}, Locations: location(modulePath)}
+	}
+
+	results := []result{}
+	for _, d := range report.Dependencies {
+		for _, v := range d.Vulnerabilities {
+			text := fmt.Sprintf("%s@%s is affected by %s: %s", d.Path, d.Version, v.ID, v.Summary)
+			if len(v.Fixed) > 0 {
+				text += fmt.Sprintf(" (fixed in %s)", strings.Join(v.Fixed, ", "))
+			}
+			results = append(results, newResult("deps/vulnerability", sarifLevels[v.Severity], text, d.Path))
+		}
+		if len(d.Retracted) > 0 {
+			results = append(results, newResult("deps/retracted", "error",
+				fmt.Sprintf("%s@%s is retracted: %s", d.Path, d.Version, strings.Join(d.Retracted, "; ")), d.Path))
+		}
+		if d.Deprecated != "" {
+			results = append(results, newResult("deps/deprecated", "warning",
+				fmt.Sprintf("%s is deprecated: %s", d.Path, d.Deprecated), d.Path))
+		}
+		if d.Update != "" && !d.Indirect {
+			results = append(results, newResult("deps/outdated", "note",
+				fmt.Sprintf("%s %s can be upgraded to %s", d.Path, d.Version, d.Update), d.Path))
+		}
+		if d.MissingGoSum {
+			results = append(results, newResult("deps/go-sum", "warning",
+				fmt.Sprintf("go.sum has no entry for %s@%s; run go mod tidy", d.Path, d.Version), d.Path))
+		}
+	}
+
+	rule := func(id, description string) map[string]any {
+		return map[string]any{"id": id, "shortDescription": map[string]any{"text": description}}
+	}
+	log := map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []any{map[string]any{
+			"tool": map[string]any{"driver": map[string]any{
+				"name":           "gz doctor deps",
+				"informationUri": "https://github.com/gizzahub/gzh-cli",
+				"rules": []any{
+					rule("deps/vulnerability", "Dependency has a known vulnerability"),
+					rule("deps/retracted", "Dependency version is retracted"),
+					rule("deps/deprecated", "Dependency module is deprecated"),
+					rule("deps/outdated", "Direct dependency has a newer version"),
+					rule("deps/go-sum", "go.sum is missing an entry"),
+				},
+			}},
+			"results": results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("failed to encode SARIF: %w", err)
+	}
+	return nil
+}
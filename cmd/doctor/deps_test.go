@@ -0,0 +1,241 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+)
+
+const mitLicense = `MIT License
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software")...`
+
+// newDepsFixture writes a module with go.mod and go.sum and returns the
+// analyzer with canned go list output.
+func newDepsFixture(t *testing.T, osvURL string) *DepsAnalyzer {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(`module example.com/app
+
+go 1.22
+
+require (
+	example.com/good v1.0.0
+	example.com/vuln v1.2.0
+)
+
+require example.com/old v0.1.0 // indirect
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.sum"), []byte(
+		"example.com/good v1.0.0 h1:abc=\nexample.com/good v1.0.0/go.mod h1:def=\n"+
+			"example.com/vuln v1.2.0/go.mod h1:ghi=\n"), 0o600))
+
+	goodDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(goodDir, "LICENSE"), []byte(mitLicense), 0o600))
+
+	modules := []map[string]any{
+		{"Path": "example.com/app", "Main": true, "GoVersion": "1.22"},
+		{"Path": "example.com/good", "Version": "v1.0.0", "Dir": goodDir},
+		{"Path": "example.com/vuln", "Version": "v1.2.0", "Update": map[string]any{"Path": "example.com/vuln", "Version": "v1.3.0"}},
+		{"Path": "example.com/old", "Version": "v0.1.0", "Indirect": true, "Deprecated": "use example.com/new",
+			"Retracted": []string{"contains a data race"}},
+	}
+	var out bytes.Buffer
+	for _, m := range modules {
+		data, err := json.MarshalIndent(m, "", "\t")
+		require.NoError(t, err)
+		out.Write(data)
+		out.WriteString("\n")
+	}
+
+	return &DepsAnalyzer{
+		Dir:    dir,
+		OSVURL: osvURL,
+		ListModules: func(_ context.Context, _ string, online bool) ([]byte, error) {
+			assert.Equal(t, osvURL != "", online)
+			return out.Bytes(), nil
+		},
+	}
+}
+
+func newOSVServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/querybatch":
+			var req struct {
+				Queries []struct {
+					Package struct {
+						Name string `json:"name"`
+					} `json:"package"`
+					Version string `json:"version"`
+				} `json:"queries"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			results := make([]map[string]any, len(req.Queries))
+			for i, q := range req.Queries {
+				results[i] = map[string]any{}
+				if q.Package.Name == "example.com/vuln" && q.Version == "1.2.0" {
+					results[i]["vulns"] = []map[string]string{{"id": "GO-2024-0001"}, {"id": "GHSA-xxxx"}}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"results": results})
+		case "/v1/vulns/GO-2024-0001":
+			fmt.Fprint(w, `{"id":"GO-2024-0001","aliases":["GHSA-xxxx"],"summary":"Remote code execution",
+				"affected":[{"package":{"name":"example.com/vuln","ecosystem":"Go"},"ranges":[{"events":[{"introduced":"0"},{"fixed":"1.2.5"}]}]}]}`)
+		case "/v1/vulns/GHSA-xxxx":
+			fmt.Fprint(w, `{"id":"GHSA-xxxx","aliases":["GO-2024-0001"],"summary":"RCE","database_specific":{"severity":"MODERATE"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDepsAnalyzer(t *testing.T) {
+	osv := newOSVServer(t)
+	report, err := newDepsFixture(t, osv.URL).Analyze(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com/app", report.Module)
+	assert.Equal(t, "1.22", report.GoVersion)
+	require.Len(t, report.Dependencies, 3)
+
+	good, vuln, old := report.Dependencies[0], report.Dependencies[1], report.Dependencies[2]
+	assert.Equal(t, "MIT", good.License)
+	assert.False(t, good.hasIssues())
+
+	assert.Equal(t, "v1.3.0", vuln.Update)
+	assert.False(t, vuln.MissingGoSum)
+	require.Len(t, vuln.Vulnerabilities, 1, "aliases are merged")
+	assert.Equal(t, "GO-2024-0001", vuln.Vulnerabilities[0].ID)
+	assert.Equal(t, severityModerate, vuln.Vulnerabilities[0].Severity)
+	assert.Equal(t, []string{"v1.2.5"}, vuln.Vulnerabilities[0].Fixed)
+
+	assert.True(t, old.MissingGoSum)
+	assert.Equal(t, licenseUnknown, old.License)
+
+	assert.Equal(t, DepsSummary{
+		Modules: 3, Direct: 2, Outdated: 1, OutdatedDirect: 1, Retracted: 1, Deprecated: 1,
+		MissingGoSum: 1, Vulnerable: 1, Vulnerabilities: 1,
+		Licenses: map[string]int{"MIT": 1, licenseUnknown: 2},
+	}, report.Summary)
+}
+
+func TestDepsAnalyzerOffline(t *testing.T) {
+	a := newDepsFixture(t, "")
+	a.Offline = true
+	report, err := a.Analyze(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.Offline)
+	assert.Zero(t, report.Summary.Vulnerabilities)
+
+	_, err = (&DepsAnalyzer{Dir: t.TempDir()}).Analyze(context.Background())
+	assert.ErrorContains(t, err, "no go.mod")
+}
+
+func TestDepsThresholds(t *testing.T) {
+	report, err := newDepsFixture(t, newOSVServer(t).URL).Analyze(context.Background())
+	require.NoError(t, err)
+
+	assert.Empty(t, DepsThresholds{FailOnSeverity: severityHigh, MaxOutdated: -1}.Violations(report))
+	assert.Equal(t, []string{"1 vulnerabilities at or above moderate severity"},
+		DepsThresholds{FailOnSeverity: severityModerate, MaxOutdated: -1}.Violations(report))
+	assert.Equal(t, []string{
+		"1 outdated direct dependencies (maximum 0)",
+		"1 retracted module versions",
+		"1 deprecated modules",
+		"1 modules licensed under MIT",
+	}, DepsThresholds{FailOnSeverity: "none", FailOnRetracted: true, FailOnDeprecated: true, DenyLicenses: []string{"mit"}}.Violations(report))
+}
+
+func TestDepsSARIF(t *testing.T) {
+	report, err := newDepsFixture(t, newOSVServer(t).URL).Analyze(context.Background())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeDepsSARIF(&buf, report))
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+
+	var rules []string
+	for _, r := range log.Runs[0].Results {
+		rules = append(rules, r.RuleID+":"+r.Level)
+	}
+	assert.Equal(t, []string{
+		"deps/vulnerability:warning", "deps/outdated:note",
+		"deps/retracted:error", "deps/deprecated:warning", "deps/go-sum:warning",
+	}, rules)
+	assert.Equal(t, 7, log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, 10, log.Runs[0].Results[2].Locations[0].PhysicalLocation.Region.StartLine)
+}
+
+func TestDepsTableOutput(t *testing.T) {
+	a := newDepsFixture(t, "")
+	a.Offline = true
+	report, err := a.Analyze(context.Background())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, printDepsReport(&buf, report, false, cli.TableOptions{}))
+	out := buf.String()
+	assert.Contains(t, out, "example.com/app (go 1.22)")
+	assert.Contains(t, out, "example.com/old (indirect)")
+	assert.Contains(t, out, "retracted, deprecated, missing go.sum entry")
+	assert.Contains(t, out, "📜 Licenses: unknown 2, MIT 1")
+	assert.True(t, strings.Contains(out, "offline"))
+
+	buf.Reset()
+	require.NoError(t, printDepsReport(&buf, report, false, cli.TableOptions{Columns: []string{"module", "license"}, NoHeader: true}))
+	assert.NotContains(t, buf.String(), "MODULE")
+	assert.NotContains(t, buf.String(), "retracted, deprecated")
+}
+
+func TestClassifyLicense(t *testing.T) {
+	tests := map[string]string{
+		"Apache License\nVersion 2.0, January 2004":                                "Apache-2.0",
+		"GNU LESSER GENERAL PUBLIC LICENSE Version 3":                              "LGPL-3.0",
+		"GNU GENERAL PUBLIC LICENSE\n Version 2, June 1991":                        "GPL-2.0",
+		"Redistribution and use in source and binary forms... Neither the name of": "BSD-3-Clause",
+		"Redistribution and use in source and binary forms, with or without":       "BSD-2-Clause",
+		"Permission to use, copy, modify, and/or distribute this software":         "ISC",
+		"All rights reserved.": licenseUnknown,
+	}
+	for text, want := range tests {
+		assert.Equal(t, want, classifyLicense(text), text)
+	}
+}
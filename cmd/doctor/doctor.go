@@ -18,8 +18,11 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/gizzahub/gzh-cli/internal/cli"
 	"github.com/gizzahub/gzh-cli/internal/errors"
+	"github.com/gizzahub/gzh-cli/internal/htmlreport"
 	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/pkg/i18n"
 )
 
 const (
@@ -53,40 +56,61 @@ Available subcommands:
   metrics   # Analyze code quality metrics and generate dashboard
   health    # Monitor comprehensive system health metrics
   container # Monitor and diagnose Docker containers
+  env       # Capture and diff environment snapshots for bug reports
+  network   # Diagnose connectivity to provider APIs
+  deps      # Analyze dependency health of the current Go module
+  quality   # Measure test effectiveness with mutation testing
 
 Examples:
   gz doctor                    # Run full diagnostic
   gz doctor --report report.json  # Save detailed report
+  gz doctor --html doctor.html    # Save a shareable HTML report
   gz doctor --quick            # Run quick checks only
   gz doctor --fix              # Attempt automatic fixes
   gz doctor godoc --package ./internal/logger  # Analyze package documentation
   gz doctor dev-env --fix          # Check and fix development environment
   gz doctor setup dev              # Automated development environment setup
-  gz doctor benchmark --package ./internal/synclone --ci  # Run CI benchmarks`,
+  gz doctor benchmark --package ./internal/synclone --ci  # Run CI benchmarks
+  gz doctor env -o env.json    # Capture environment for a bug report
+  gz doctor network            # Diagnose connectivity to provider APIs
+  gz doctor deps --format sarif   # Dependency health report for CI
+  gz doctor quality ./pkg/...     # Mutation score and coverage per package`,
 	Run: runDoctor,
 }
 
 var (
-	reportFile string
-	quickMode  bool
-	attemptFix bool
-	verbose    bool
+	reportFile   string
+	htmlFile     string
+	noHistory    bool
+	quickMode    bool
+	attemptFix   bool
+	verbose      bool
+	progressMode string
 )
 
 func init() {
 	DoctorCmd.Flags().StringVar(&reportFile, "report", "", "Output detailed report to file")
+	DoctorCmd.Flags().StringVar(&htmlFile, "html", "", "Output a self-contained HTML report to file")
+	DoctorCmd.Flags().BoolVar(&noHistory, "no-history", false, "Do not record this run in the report history")
 	DoctorCmd.Flags().BoolVar(&quickMode, "quick", false, "Run quick checks only")
 	DoctorCmd.Flags().BoolVar(&attemptFix, "fix", false, "Attempt to fix detected issues")
 	DoctorCmd.Flags().BoolVar(&verbose, "verbose", false, "Show verbose output")
+	cli.AddProgressFlag(DoctorCmd, &progressMode)
 
 	// Add subcommands
 	DoctorCmd.AddCommand(newGodocCmd())
 	DoctorCmd.AddCommand(newDevEnvCmd())
 	DoctorCmd.AddCommand(newSetupCmd())
 	DoctorCmd.AddCommand(newBenchmarkCmd())
+	DoctorCmd.AddCommand(newBenchCmd())
 	DoctorCmd.AddCommand(newMetricsCmd())
 	DoctorCmd.AddCommand(newHealthCmd())
 	DoctorCmd.AddCommand(newContainerCmd())
+	DoctorCmd.AddCommand(newEnvCmd())
+	DoctorCmd.AddCommand(newNetworkCmd())
+	DoctorCmd.AddCommand(newDepsCmd())
+	DoctorCmd.AddCommand(newConfigCheckCmd())
+	DoctorCmd.AddCommand(newQualityCmd())
 }
 
 // DiagnosticResult represents the result of a diagnostic check.
@@ -181,7 +205,13 @@ func runDoctor(cmd *cobra.Command, args []string) {
 		Timeout: 2 * time.Second,
 	})
 
-	fmt.Println("🩺 Starting GZH Manager diagnostic...")
+	progress, err := cli.ProgressForCommand(cmd, progressMode)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("🩺 " + i18n.T("Starting GZH Manager diagnostic..."))
 	simpleLogger.Info("Starting GZH Manager diagnostic session")
 
 	startTime := time.Now()
@@ -206,47 +236,36 @@ func runDoctor(cmd *cobra.Command, args []string) {
 		}
 
 		// Run diagnostic checks
-		if verbose {
-			fmt.Println("🔍 Running diagnostic checks...")
-		}
-
-		simpleLogger.Info("Running diagnostic checks", "total_categories", 7)
-
-		// System checks
-		simpleLogger.Debug("Running system checks")
-		runSystemChecks(report, simpleLogger, errorRecovery)
-
-		// Configuration checks
-		simpleLogger.Debug("Running configuration checks")
-		runConfigChecks(report, simpleLogger, errorRecovery)
-
-		// Network checks
-		if !quickMode {
-			simpleLogger.Debug("Running network checks")
-			runNetworkChecks(cmd.Context(), report, simpleLogger, errorRecovery)
-		} else {
-			simpleLogger.Debug("Skipping network checks (quick mode)")
+		ctx := cmd.Context()
+		categories := []struct {
+			name  string
+			quick bool
+			run   func()
+		}{
+			{"system", true, func() { runSystemChecks(report, simpleLogger, errorRecovery) }},
+			{"configuration", true, func() { runConfigChecks(report, simpleLogger, errorRecovery) }},
+			{"network", false, func() { runNetworkChecks(ctx, report, simpleLogger, errorRecovery) }},
+			{"git", true, func() { runGitChecks(ctx, report, simpleLogger, errorRecovery) }},
+			{"permission", true, func() { runPermissionChecks(report, simpleLogger, errorRecovery) }},
+			{"performance", false, func() { runPerformanceChecks(report, simpleLogger, errorRecovery) }},
+			{"security", true, func() { runSecurityChecks(report, simpleLogger, errorRecovery) }},
 		}
 
-		// Git checks
-		simpleLogger.Debug("Running git checks")
-		runGitChecks(cmd.Context(), report, simpleLogger, errorRecovery)
-
-		// Permission checks
-		simpleLogger.Debug("Running permission checks")
-		runPermissionChecks(report, simpleLogger, errorRecovery)
+		simpleLogger.Info("Running diagnostic checks", "total_categories", len(categories))
+		progress.Phase("Running diagnostic checks", len(categories))
+		for _, category := range categories {
+			if quickMode && !category.quick {
+				simpleLogger.Debug("Skipping " + category.name + " checks (quick mode)")
+				progress.Item(category.name, cli.ItemSkipped, nil)
+				continue
+			}
 
-		// Performance checks
-		if !quickMode {
-			simpleLogger.Debug("Running performance checks")
-			runPerformanceChecks(report, simpleLogger, errorRecovery)
-		} else {
-			simpleLogger.Debug("Skipping performance checks (quick mode)")
+			simpleLogger.Debug("Running " + category.name + " checks")
+			before := len(report.Results)
+			category.run()
+			progress.Item(category.name, categoryStatus(report.Results[before:]), nil)
 		}
-
-		// Security checks
-		simpleLogger.Debug("Running security checks")
-		runSecurityChecks(report, simpleLogger, errorRecovery)
+		progress.Done()
 
 		return nil
 	})
@@ -286,10 +305,22 @@ func runDoctor(cmd *cobra.Command, args []string) {
 	if reportFile != "" {
 		if err := saveReport(report, reportFile); err != nil {
 			simpleLogger.ErrorWithStack(err, "Failed to save diagnostic report")
-			fmt.Printf("❌ Failed to save report: %v\n", err)
+			fmt.Printf("❌ %s\n", i18n.T("Failed to save report: %v", err))
 		} else {
 			simpleLogger.Info("Diagnostic report saved", "file", reportFile)
-			fmt.Printf("💾 Report saved to: %s\n", reportFile)
+			fmt.Printf("💾 %s\n", i18n.T("Report saved to: %s", reportFile))
+		}
+	}
+
+	if htmlFile != "" {
+		err := writeHTMLReport(htmlFile, "doctor", noHistory, report.Timestamp, doctorHistoryValues(report),
+			func(history []htmlreport.Entry) *htmlreport.Report { return doctorHTMLReport(report, history) })
+		if err != nil {
+			simpleLogger.ErrorWithStack(err, "Failed to save HTML report")
+			fmt.Printf("❌ %s\n", i18n.T("Failed to save report: %v", err))
+		} else {
+			simpleLogger.Info("HTML report saved", "file", htmlFile)
+			fmt.Printf("💾 %s\n", i18n.T("Report saved to: %s", htmlFile))
 		}
 	}
 
@@ -311,6 +342,17 @@ func runDoctor(cmd *cobra.Command, args []string) {
 	simpleLogger.Info("Doctor diagnostic completed successfully")
 }
 
+// categoryStatus reports a check category as failed when one of its
+// checks failed.
+func categoryStatus(results []DiagnosticResult) cli.ItemStatus {
+	for _, r := range results {
+		if r.Status == statusFail {
+			return cli.ItemFailed
+		}
+	}
+	return cli.ItemDone
+}
+
 func runSystemChecks(report *DiagnosticReport, _ logger.CommonLogger, _ *errors.ErrorRecovery) {
 	if verbose {
 		fmt.Println("💻 Checking system information...")
@@ -1046,22 +1088,24 @@ func generateSummaryAndRecommendations(report *DiagnosticReport) {
 
 	if len(report.Recommendations) == 0 {
 		report.Recommendations = append(report.Recommendations,
-			"System appears healthy - no immediate action required")
+			i18n.T("System appears healthy - no immediate action required"))
 	}
 }
 
 func printResults(report *DiagnosticReport) {
-	fmt.Printf("\n📄 Diagnostic Report\n")
+	fmt.Printf("\n📄 %s\n", i18n.T("Diagnostic Report"))
 	fmt.Printf("===================\n")
-	fmt.Printf("Platform: %s\n", report.Platform)
-	fmt.Printf("Duration: %v\n", report.Duration)
-	fmt.Printf("Total Checks: %d\n", report.TotalChecks)
-	fmt.Printf("Passed: %d, Warnings: %d, Failed: %d, Skipped: %d\n",
-		report.PassedChecks, report.WarnChecks, report.FailedChecks, report.SkippedChecks)
+	fmt.Println(i18n.T("Platform: %s", report.Platform))
+	fmt.Println(i18n.T("Duration: %v", report.Duration))
+	fmt.Println(i18n.T("Total Checks: %d", report.TotalChecks))
+	fmt.Println(i18n.T("Passed: %d, Warnings: %d, Failed: %d, Skipped: %d",
+		report.PassedChecks, report.WarnChecks, report.FailedChecks, report.SkippedChecks))
 
-	fmt.Printf("\n📅 Check Results:\n")
+	fmt.Printf("\n📅 %s\n", i18n.T("Check Results:"))
 	fmt.Printf("=================\n")
 
+	linker := cli.NewLinker(os.Stdout)
+
 	for _, result := range report.Results {
 		icon := "✅" // pass
 
@@ -1077,19 +1121,19 @@ func printResults(report *DiagnosticReport) {
 		fmt.Printf("  %s [%s] %s: %s\n", icon, strings.ToUpper(result.Category), result.Name, result.Message)
 
 		if verbose && result.FixSuggestion != "" {
-			fmt.Printf("    💡 Fix: %s\n", result.FixSuggestion)
+			fmt.Printf("    💡 %s\n", linker.Linkify(i18n.T("Fix: %s", result.FixSuggestion)))
 		}
 	}
 
-	fmt.Printf("\n🎯 Summary:\n")
+	fmt.Printf("\n🎯 %s\n", i18n.T("Summary:"))
 	fmt.Printf("=========\n")
 	fmt.Println(report.Summary)
 
-	fmt.Printf("\n💡 Recommendations:\n")
+	fmt.Printf("\n💡 %s\n", i18n.T("Recommendations:"))
 	fmt.Printf("==================\n")
 
 	for i, rec := range report.Recommendations {
-		fmt.Printf("  %d. %s\n", i+1, rec)
+		fmt.Printf("  %d. %s\n", i+1, linker.Linkify(rec))
 	}
 }
 
@@ -1109,7 +1153,7 @@ func saveReport(report *DiagnosticReport, filename string) error {
 }
 
 func attemptAutomaticFixes(ctx context.Context, report *DiagnosticReport, simpleLogger logger.CommonLogger, errorRecovery *errors.ErrorRecovery) {
-	fmt.Printf("\n🔧 Attempting automatic fixes...\n")
+	fmt.Printf("\n🔧 %s\n", i18n.T("Attempting automatic fixes..."))
 	simpleLogger.Info("Starting automatic fixes", "total_issues", report.FailedChecks+report.WarnChecks) //nolint:contextcheck // Logger has its own context management
 
 	fixed := 0
@@ -1121,12 +1165,12 @@ func attemptAutomaticFixes(ctx context.Context, report *DiagnosticReport, simple
 
 				if attemptFix := tryAutoFix(result); attemptFix {
 					simpleLogger.Info("Successfully applied automatic fix", "check_name", result.Name)
-					fmt.Printf("  ✅ Fixed: %s\n", result.Name)
+					fmt.Printf("  ✅ %s\n", i18n.T("Fixed: %s", result.Name))
 
 					fixed++
 				} else {
 					simpleLogger.Warn("Cannot auto-fix issue", "check_name", result.Name, "reason", "no_fix_available")
-					fmt.Printf("  ❌ Cannot auto-fix: %s\n", result.Name)
+					fmt.Printf("  ❌ %s\n", i18n.T("Cannot auto-fix: %s", result.Name))
 				}
 
 				return nil
@@ -1138,11 +1182,11 @@ func attemptAutomaticFixes(ctx context.Context, report *DiagnosticReport, simple
 	}
 
 	simpleLogger.Info("Auto-fix completed", "fixed_count", fixed, "total_attempted", report.FailedChecks+report.WarnChecks) //nolint:contextcheck // Logger has its own context management
-	fmt.Printf("\n🎯 Auto-fix summary: %d issues resolved\n", fixed)
+	fmt.Printf("\n🎯 %s\n", i18n.T("Auto-fix summary: %d issues resolved", fixed))
 
 	if fixed > 0 {
 		simpleLogger.Info("Recommend re-running diagnostic to verify fixes") //nolint:contextcheck // Logger has its own context management
-		fmt.Println("🔄 Re-run 'gz doctor' to verify fixes")
+		fmt.Println("🔄 " + i18n.T("Re-run 'gz doctor' to verify fixes"))
 	}
 }
 
@@ -43,13 +43,13 @@ func TestDoctorCmdSubcommands(t *testing.T) {
 	subcommands := DoctorCmd.Commands()
 
 	// Should have expected subcommands based on init()
-	expectedSubcommands := []string{"godoc", "dev-env", "setup", "benchmark", "metrics", "health", "container"}
+	expectedSubcommands := []string{"godoc", "dev-env", "setup", "benchmark", "bench", "metrics", "health", "container", "env", "network", "deps", "config", "quality"}
 	assert.Len(t, subcommands, len(expectedSubcommands))
 
 	// Verify subcommands exist
 	subcommandNames := make(map[string]bool)
 	for _, subcmd := range subcommands {
-		subcommandNames[subcmd.Use] = true
+		subcommandNames[subcmd.Name()] = true
 	}
 
 	for _, expected := range expectedSubcommands {
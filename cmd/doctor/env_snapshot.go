@@ -0,0 +1,443 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// envSnapshotVersion is bumped whenever the snapshot layout changes incompatibly.
+const envSnapshotVersion = 1
+
+// EnvSnapshot is a reproducible capture of the local environment for bug reports.
+type EnvSnapshot struct {
+	Version     int                        `json:"version"`
+	CreatedAt   time.Time                  `json:"createdAt"`
+	Host        EnvHostInfo                `json:"host"`
+	Git         EnvToolInfo                `json:"git"`
+	SSHAgent    EnvSSHAgentInfo            `json:"sshAgent"`
+	Tools       map[string]EnvToolInfo     `json:"tools"`
+	Network     map[string]EnvReachability `json:"network"`
+	ConfigFiles map[string]EnvFileInfo     `json:"configFiles"`
+}
+
+// EnvHostInfo describes the host and runtime.
+type EnvHostInfo struct {
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	GoVersion string `json:"goVersion"`
+	Shell     string `json:"shell,omitempty"`
+}
+
+// EnvToolInfo describes a tool found (or not) on PATH.
+type EnvToolInfo struct {
+	Found   bool   `json:"found"`
+	Path    string `json:"path,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// EnvSSHAgentInfo describes the ssh-agent state.
+type EnvSSHAgentInfo struct {
+	SocketSet bool   `json:"socketSet"`
+	Reachable bool   `json:"reachable"`
+	KeyCount  int    `json:"keyCount"`
+	Error     string `json:"error,omitempty"`
+}
+
+// EnvReachability is the result of a TCP reachability probe.
+type EnvReachability struct {
+	Reachable bool   `json:"reachable"`
+	LatencyMs int64  `json:"latencyMs,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// EnvFileInfo records the presence and content hash of a config file.
+type EnvFileInfo struct {
+	Exists bool   `json:"exists"`
+	Size   int64  `json:"size,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// EnvDiffEntry is a single difference between two snapshots.
+type EnvDiffEntry struct {
+	Key    string `json:"key"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// snapshotTools lists the PATH tools recorded in a snapshot, with their version arguments.
+var snapshotTools = map[string][]string{
+	"go":     {"version"},
+	"gh":     {"--version"},
+	"glab":   {"--version"},
+	"tea":    {"--version"},
+	"ssh":    {"-V"},
+	"docker": {"--version"},
+	"make":   {"--version"},
+	"gz":     {"--version"},
+}
+
+// snapshotEndpoints lists the provider endpoints probed for reachability.
+var snapshotEndpoints = []string{
+	"github.com:443",
+	"api.github.com:443",
+	"gitlab.com:443",
+	"gitea.com:443",
+	"proxy.golang.org:443",
+}
+
+// snapshotConfigFiles returns the config files hashed in a snapshot, keyed by a
+// home-relative display name so snapshots from different users are comparable.
+func snapshotConfigFiles() map[string]string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return map[string]string{}
+	}
+
+	return map[string]string{
+		"~/.gitconfig":                                 filepath.Join(home, ".gitconfig"),
+		"~/.ssh/config":                                filepath.Join(home, ".ssh", "config"),
+		"~/.config/gzh-manager/gzh.yaml":               filepath.Join(home, ".config", "gzh-manager", "gzh.yaml"),
+		"~/.config/gzh-manager/synclone.yaml":          filepath.Join(home, ".config", "gzh-manager", "synclone.yaml"),
+		"~/.config/gzh-manager/extensions.yaml":        filepath.Join(home, ".config", "gzh-manager", "extensions.yaml"),
+		"~/.config/gzh-manager/plugins/installed.json": filepath.Join(home, ".config", "gzh-manager", "plugins", "installed.json"),
+	}
+}
+
+func newEnvCmd() *cobra.Command {
+	var (
+		output    string
+		noNetwork bool
+		timeout   time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Capture and compare environment snapshots for bug reports",
+		Long: `Capture a reproducible environment snapshot and compare snapshots.
+
+A snapshot records the git version, ssh-agent state, tools found on PATH,
+network reachability of the supported providers and hashes of the relevant
+configuration files. File contents and environment variable values are never
+recorded, so snapshots are safe to attach to bug reports.
+
+Examples:
+  gz doctor env --output env.json           # Save a snapshot bundle
+  gz doctor env --no-network                # Skip provider reachability probes
+  gz doctor env diff before.json after.json # Show what changed between snapshots`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snapshot := CaptureEnvSnapshot(cmd.Context(), EnvSnapshotOptions{
+				SkipNetwork:  noNetwork,
+				ProbeTimeout: timeout,
+			})
+
+			data, err := json.MarshalIndent(snapshot, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode snapshot: %w", err)
+			}
+			data = append(data, '\n')
+
+			if output == "" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
+			}
+			if err := os.WriteFile(output, data, 0o600); err != nil {
+				return fmt.Errorf("failed to write snapshot: %w", err)
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "📦 Environment snapshot saved to %s\n", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the snapshot to a file instead of stdout")
+	cmd.Flags().BoolVar(&noNetwork, "no-network", false, "Skip provider reachability probes")
+	cmd.Flags().DurationVar(&timeout, "timeout", 3*time.Second, "Timeout for each network probe")
+
+	cmd.AddCommand(newEnvDiffCmd())
+
+	return cmd
+}
+
+func newEnvDiffCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "diff <before.json> <after.json>",
+		Short: "Show differences between two environment snapshots",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			before, err := LoadEnvSnapshot(args[0])
+			if err != nil {
+				return err
+			}
+			after, err := LoadEnvSnapshot(args[1])
+			if err != nil {
+				return err
+			}
+
+			diff := DiffEnvSnapshots(before, after)
+			out := cmd.OutOrStdout()
+
+			if jsonOutput {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(diff)
+			}
+
+			if len(diff) == 0 {
+				fmt.Fprintln(out, "✅ No differences")
+				return nil
+			}
+			for _, d := range diff {
+				fmt.Fprintf(out, "~ %s\n    - %s\n    + %s\n", d.Key, displayValue(d.Before), displayValue(d.After))
+			}
+			fmt.Fprintf(out, "\n%d difference(s)\n", len(diff))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output differences as JSON")
+
+	return cmd
+}
+
+// EnvSnapshotOptions controls what CaptureEnvSnapshot collects.
+type EnvSnapshotOptions struct {
+	SkipNetwork  bool
+	ProbeTimeout time.Duration
+}
+
+// CaptureEnvSnapshot collects an environment snapshot. Individual probe
+// failures are recorded in the snapshot rather than returned.
+func CaptureEnvSnapshot(ctx context.Context, opts EnvSnapshotOptions) *EnvSnapshot {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.ProbeTimeout <= 0 {
+		opts.ProbeTimeout = 3 * time.Second
+	}
+
+	snapshot := &EnvSnapshot{
+		Version:   envSnapshotVersion,
+		CreatedAt: time.Now().UTC(),
+		Host: EnvHostInfo{
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+			GoVersion: runtime.Version(),
+			Shell:     filepath.Base(os.Getenv("SHELL")),
+		},
+		Git:         probeTool(ctx, "git", []string{"--version"}),
+		SSHAgent:    probeSSHAgent(ctx),
+		Tools:       make(map[string]EnvToolInfo, len(snapshotTools)),
+		Network:     make(map[string]EnvReachability, len(snapshotEndpoints)),
+		ConfigFiles: make(map[string]EnvFileInfo),
+	}
+
+	for name, args := range snapshotTools {
+		snapshot.Tools[name] = probeTool(ctx, name, args)
+	}
+
+	if !opts.SkipNetwork {
+		for _, endpoint := range snapshotEndpoints {
+			snapshot.Network[endpoint] = probeEndpoint(ctx, endpoint, opts.ProbeTimeout)
+		}
+	}
+
+	for display, path := range snapshotConfigFiles() {
+		snapshot.ConfigFiles[display] = hashFile(path)
+	}
+
+	return snapshot
+}
+
+// LoadEnvSnapshot reads a snapshot bundle from disk.
+func LoadEnvSnapshot(path string) (*EnvSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", path, err)
+	}
+
+	var snapshot EnvSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	if snapshot.Version > envSnapshotVersion {
+		return nil, fmt.Errorf("snapshot %s has unsupported version %d", path, snapshot.Version)
+	}
+
+	return &snapshot, nil
+}
+
+// DiffEnvSnapshots returns the differences between two snapshots, sorted by
+// key. Volatile fields such as the capture time and probe latency are ignored.
+func DiffEnvSnapshots(before, after *EnvSnapshot) []EnvDiffEntry {
+	a := flattenEnvSnapshot(before)
+	b := flattenEnvSnapshot(after)
+
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+
+	var diff []EnvDiffEntry
+	for k := range keys {
+		if a[k] != b[k] {
+			diff = append(diff, EnvDiffEntry{Key: k, Before: a[k], After: b[k]})
+		}
+	}
+	sort.Slice(diff, func(i, j int) bool { return diff[i].Key < diff[j].Key })
+
+	return diff
+}
+
+// flattenEnvSnapshot turns a snapshot into comparable dotted key/value pairs.
+func flattenEnvSnapshot(s *EnvSnapshot) map[string]string {
+	out := map[string]string{
+		"host.os":            s.Host.OS,
+		"host.arch":          s.Host.Arch,
+		"host.goVersion":     s.Host.GoVersion,
+		"host.shell":         s.Host.Shell,
+		"sshAgent.socketSet": fmt.Sprint(s.SSHAgent.SocketSet),
+		"sshAgent.reachable": fmt.Sprint(s.SSHAgent.Reachable),
+		"sshAgent.keyCount":  fmt.Sprint(s.SSHAgent.KeyCount),
+	}
+
+	addTool := func(prefix string, t EnvToolInfo) {
+		out[prefix+".found"] = fmt.Sprint(t.Found)
+		if t.Found {
+			out[prefix+".path"] = t.Path
+			out[prefix+".version"] = t.Version
+		}
+	}
+	addTool("git", s.Git)
+	for name, t := range s.Tools {
+		addTool("tools."+name, t)
+	}
+	for endpoint, r := range s.Network {
+		out["network."+endpoint+".reachable"] = fmt.Sprint(r.Reachable)
+	}
+	for name, f := range s.ConfigFiles {
+		out["configFiles."+name+".exists"] = fmt.Sprint(f.Exists)
+		if f.Exists {
+			out["configFiles."+name+".sha256"] = f.SHA256
+		}
+	}
+
+	return out
+}
+
+func probeTool(ctx context.Context, name string, versionArgs []string) EnvToolInfo {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return EnvToolInfo{}
+	}
+
+	info := EnvToolInfo{Found: true, Path: path}
+	probeCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Some tools (ssh -V) print their version to stderr.
+	output, err := exec.CommandContext(probeCtx, path, versionArgs...).CombinedOutput()
+	if err == nil {
+		info.Version = firstLine(string(output))
+	}
+
+	return info
+}
+
+func probeSSHAgent(ctx context.Context) EnvSSHAgentInfo {
+	info := EnvSSHAgentInfo{SocketSet: os.Getenv("SSH_AUTH_SOCK") != ""}
+	if !info.SocketSet {
+		return info
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// ssh-add -l exits 1 when the agent holds no keys and 2 when it is unreachable.
+	output, err := exec.CommandContext(probeCtx, "ssh-add", "-l").CombinedOutput()
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+		info.Reachable = true
+		return info
+	}
+	if err != nil {
+		info.Error = firstLine(string(output))
+		if info.Error == "" {
+			info.Error = err.Error()
+		}
+		return info
+	}
+
+	info.Reachable = true
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if strings.TrimSpace(line) != "" {
+			info.KeyCount++
+		}
+	}
+
+	return info
+}
+
+func probeEndpoint(ctx context.Context, address string, timeout time.Duration) EnvReachability {
+	dialer := net.Dialer{Timeout: timeout}
+	start := time.Now()
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return EnvReachability{Error: err.Error()}
+	}
+	_ = conn.Close()
+
+	return EnvReachability{Reachable: true, LatencyMs: time.Since(start).Milliseconds()}
+}
+
+func hashFile(path string) EnvFileInfo {
+	f, err := os.Open(path)
+	if err != nil {
+		return EnvFileInfo{}
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return EnvFileInfo{Exists: true}
+	}
+
+	return EnvFileInfo{Exists: true, Size: n, SHA256: hex.EncodeToString(h.Sum(nil))}
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+func displayValue(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
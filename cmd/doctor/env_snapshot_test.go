@@ -0,0 +1,87 @@
+//nolint:testpackage // White-box testing needed for internal function access
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureEnvSnapshot_SkipNetwork(t *testing.T) {
+	snapshot := CaptureEnvSnapshot(context.Background(), EnvSnapshotOptions{SkipNetwork: true})
+
+	assert.Equal(t, envSnapshotVersion, snapshot.Version)
+	assert.NotEmpty(t, snapshot.Host.OS)
+	assert.Empty(t, snapshot.Network)
+	assert.Len(t, snapshot.Tools, len(snapshotTools))
+}
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+
+	info := hashFile(path)
+	assert.True(t, info.Exists)
+	assert.Equal(t, int64(5), info.Size)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", info.SHA256)
+
+	assert.False(t, hashFile(filepath.Join(t.TempDir(), "missing")).Exists)
+}
+
+func TestDiffEnvSnapshots(t *testing.T) {
+	before := &EnvSnapshot{
+		Version: envSnapshotVersion,
+		Git:     EnvToolInfo{Found: true, Path: "/usr/bin/git", Version: "git version 2.40.0"},
+		Tools:   map[string]EnvToolInfo{"gh": {Found: true, Path: "/usr/bin/gh"}},
+		Network: map[string]EnvReachability{"github.com:443": {Reachable: true, LatencyMs: 10}},
+		ConfigFiles: map[string]EnvFileInfo{
+			"~/.gitconfig": {Exists: true, SHA256: "aaa"},
+		},
+	}
+	after := &EnvSnapshot{
+		Version: envSnapshotVersion,
+		Git:     EnvToolInfo{Found: true, Path: "/usr/bin/git", Version: "git version 2.45.0"},
+		Tools:   map[string]EnvToolInfo{"gh": {}},
+		Network: map[string]EnvReachability{"github.com:443": {Reachable: true, LatencyMs: 99}},
+		ConfigFiles: map[string]EnvFileInfo{
+			"~/.gitconfig": {Exists: true, SHA256: "bbb"},
+		},
+	}
+
+	diff := DiffEnvSnapshots(before, after)
+	keys := make([]string, 0, len(diff))
+	for _, d := range diff {
+		keys = append(keys, d.Key)
+	}
+
+	assert.Equal(t, []string{
+		"configFiles.~/.gitconfig.sha256",
+		"git.version",
+		"tools.gh.found",
+		"tools.gh.path",
+	}, keys)
+	assert.Empty(t, DiffEnvSnapshots(before, before))
+}
+
+func TestLoadEnvSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "env.json")
+
+	data, err := json.Marshal(&EnvSnapshot{Version: envSnapshotVersion})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	snapshot, err := LoadEnvSnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, envSnapshotVersion, snapshot.Version)
+
+	future := filepath.Join(dir, "future.json")
+	require.NoError(t, os.WriteFile(future, []byte(`{"version": 99}`), 0o600))
+	_, err = LoadEnvSnapshot(future)
+	assert.Error(t, err)
+}
@@ -5,16 +5,19 @@ package doctor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/gizzahub/gzh-cli/internal/analysis/godoc"
 	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/htmlreport"
 	"github.com/gizzahub/gzh-cli/internal/logger"
 )
 
@@ -31,6 +34,11 @@ func newGodocCmd() *cobra.Command {
 		threshold       float64
 		recommendations bool
 		exportOnly      bool
+		baselinePath    string
+		updateBaseline  bool
+		failOnRegress   bool
+		htmlPath        string
+		noHistory       bool
 	)
 
 	cmd := cli.NewCommandBuilder(ctx, "godoc", "Analyze API documentation coverage and quality").
@@ -47,7 +55,22 @@ Examples:
   gz doctor godoc --package ./internal/logger
   gz doctor godoc --coverage --missing
   gz doctor godoc --all-packages --format json
-  gz doctor godoc --threshold 80 --recommendations`).
+  gz doctor godoc --threshold 80 --recommendations
+
+Baseline mode lets large codebases ratchet documentation quality. Record
+the current findings once, commit the file, and fail only on new issues or
+coverage drops:
+  gz doctor godoc --all-packages --baseline .godoc-baseline.json --update-baseline
+  gz doctor godoc --all-packages --baseline .godoc-baseline.json --fail-on-regression
+
+Issues are matched by type and symbol, not line, so moving code does not
+invalidate the baseline. Re-run with --update-baseline after fixing issues
+to lock the improvement in.
+
+--html writes a self-contained report for sharing. Every run with --html
+is recorded in ~/.gzh/history, per project, and the report charts
+coverage and quality issues over the recorded runs:
+  gz doctor godoc --all-packages --html godoc.html`).
 		WithExample("gz doctor godoc --package ./internal/logger --coverage").
 		WithFormatFlag("table", []string{"table", "json", "yaml"}).
 		WithRunFuncE(func(ctx context.Context, flags *cli.CommonFlags, args []string) error {
@@ -60,6 +83,11 @@ Examples:
 				threshold:       threshold,
 				recommendations: recommendations,
 				exportOnly:      exportOnly,
+				baselinePath:    baselinePath,
+				updateBaseline:  updateBaseline,
+				failOnRegress:   failOnRegress,
+				htmlPath:        htmlPath,
+				noHistory:       noHistory,
 			})
 		}).
 		Build()
@@ -72,6 +100,11 @@ Examples:
 	cmd.Flags().Float64Var(&threshold, "threshold", 0, "Minimum coverage threshold (0-100)")
 	cmd.Flags().BoolVar(&recommendations, "recommendations", false, "Show improvement recommendations")
 	cmd.Flags().BoolVar(&exportOnly, "export-only", true, "Only analyze exported symbols")
+	cmd.Flags().StringVar(&baselinePath, "baseline", "", "Baseline file of accepted findings (e.g. .godoc-baseline.json)")
+	cmd.Flags().BoolVar(&updateBaseline, "update-baseline", false, "Write the current findings to the baseline file")
+	cmd.Flags().BoolVar(&failOnRegress, "fail-on-regression", false, "Fail when findings are worse than the baseline")
+	cmd.Flags().StringVar(&htmlPath, "html", "", "Write a self-contained HTML report to file")
+	cmd.Flags().BoolVar(&noHistory, "no-history", false, "Do not record this run in the report history")
 
 	return cmd
 }
@@ -85,11 +118,23 @@ type godocOptions struct {
 	threshold       float64
 	recommendations bool
 	exportOnly      bool
+	baselinePath    string
+	updateBaseline  bool
+	failOnRegress   bool
+	htmlPath        string
+	noHistory       bool
 }
 
 func runGodocAnalysis(ctx context.Context, flags *cli.CommonFlags, opts godocOptions) error {
 	logger := logger.NewSimpleLogger("doctor-godoc")
 
+	if opts.baselinePath == "" && (opts.updateBaseline || opts.failOnRegress) {
+		return fmt.Errorf("--update-baseline and --fail-on-regression require --baseline")
+	}
+	if opts.updateBaseline && opts.failOnRegress {
+		return fmt.Errorf("--update-baseline and --fail-on-regression cannot be combined")
+	}
+
 	// Determine working directory
 	workingDir, err := os.Getwd()
 	if err != nil {
@@ -147,6 +192,22 @@ func runGodocAnalysis(ctx context.Context, flags *cli.CommonFlags, opts godocOpt
 		return results[i].CoverageStats.CoveragePercentage < results[j].CoverageStats.CoveragePercentage
 	})
 
+	if opts.updateBaseline {
+		return updateGodocBaseline(opts.baselinePath, results)
+	}
+
+	var diff *godoc.BaselineDiff
+	if opts.baselinePath != "" {
+		baseline, err := godoc.LoadBaseline(opts.baselinePath)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("%w\ncreate it with --update-baseline", err)
+			}
+			return err
+		}
+		diff = baseline.Compare(results, godoc.DefaultCoverageTolerance)
+	}
+
 	// Generate output based on format
 	formatter := cli.NewOutputFormatter(flags.Format)
 
@@ -159,11 +220,85 @@ func runGodocAnalysis(ctx context.Context, flags *cli.CommonFlags, opts godocOpt
 			"failed_packages":  failedPackages,
 			"analysis_options": opts,
 		}
-		return formatter.FormatOutput(output)
+		if diff != nil {
+			output["baseline"] = diff
+		}
+		if err := formatter.FormatOutput(output); err != nil {
+			return err
+		}
 
 	default:
 		// For table format, create custom output
-		return displayGodocResults(results, failedPackages, opts)
+		if err := displayGodocResults(results, failedPackages, opts); err != nil {
+			return err
+		}
+		if diff != nil {
+			displayBaselineDiff(diff)
+		}
+	}
+
+	if opts.htmlPath != "" {
+		coverage, issues := godocTotals(results)
+		values := map[string]float64{"coverage": coverage, "issues": float64(issues)}
+		err := writeHTMLReport(opts.htmlPath, godocHistoryName(workingDir), opts.noHistory, time.Now(), values,
+			func(history []htmlreport.Entry) *htmlreport.Report {
+				return godocHTMLReport(workingDir, results, failedPackages, opts.threshold, history)
+			})
+		if err != nil {
+			return err
+		}
+		logger.Info("HTML report saved", "file", opts.htmlPath)
+	}
+
+	if opts.failOnRegress && diff.Regressed() {
+		return fmt.Errorf("documentation regressed against %s: %d new issue(s), %d package(s) with lower coverage",
+			opts.baselinePath, len(diff.NewIssues), len(diff.CoverageDrops))
+	}
+	return nil
+}
+
+// updateGodocBaseline writes results to the baseline file. Packages that
+// were not analyzed in this run keep their recorded state.
+func updateGodocBaseline(path string, results []*godoc.PackageInfo) error {
+	baseline := godoc.NewBaseline(results)
+	if existing, err := godoc.LoadBaseline(path); err == nil {
+		baseline = existing.Merge(results)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := baseline.Save(path); err != nil {
+		return err
+	}
+
+	issues := 0
+	for _, r := range results {
+		issues += len(r.QualityIssues)
+	}
+	logger.SimpleInfo("📌 GoDoc baseline updated", "file", path, "packages", len(baseline.Packages), "issues", issues)
+	return nil
+}
+
+func displayBaselineDiff(diff *godoc.BaselineDiff) {
+	if !diff.Regressed() {
+		logger.SimpleInfo("✅ No documentation regressions against the baseline",
+			"fixed_issues", diff.FixedIssues)
+		if diff.FixedIssues > 0 {
+			logger.SimpleInfo("  💡 Run with --update-baseline to lock in the fixed issues")
+		}
+		return
+	}
+
+	logger.SimpleWarn("❌ Documentation regressed against the baseline",
+		"new_issues", len(diff.NewIssues),
+		"coverage_drops", len(diff.CoverageDrops),
+		"fixed_issues", diff.FixedIssues)
+	for _, drop := range diff.CoverageDrops {
+		logger.SimpleWarn("  📉 Coverage dropped", "package", drop.Package,
+			"baseline", fmt.Sprintf("%.1f%%", drop.Baseline),
+			"current", fmt.Sprintf("%.1f%%", drop.Current))
+	}
+	for _, issue := range diff.NewIssues {
+		logger.SimpleWarn("  🆕 "+issue.Issue.Message, "package", issue.Package, "line", issue.Issue.Line)
 	}
 }
 
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runGodocCmd(t *testing.T, args ...string) error {
+	t.Helper()
+	cmd := newGodocCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func TestGodocCmd_Baseline(t *testing.T) {
+	root := t.TempDir()
+	t.Chdir(root)
+	write := func(src string) {
+		require.NoError(t, os.WriteFile(filepath.Join(root, "lib.go"), []byte(src), 0o600))
+	}
+	write("// Package lib is a test package.\npackage lib\n\nfunc Legacy() {}\n")
+
+	baseline := filepath.Join(root, ".godoc-baseline.json")
+	require.Error(t, runGodocCmd(t, "--baseline", baseline, "--fail-on-regression"), "the baseline must exist")
+	require.NoError(t, runGodocCmd(t, "--baseline", baseline, "--update-baseline"))
+	assert.FileExists(t, baseline)
+
+	assert.NoError(t, runGodocCmd(t, "--baseline", baseline, "--fail-on-regression"), "legacy findings are accepted")
+
+	write("// Package lib is a test package.\npackage lib\n\nfunc Legacy() {}\n\nfunc Fresh() {}\n")
+	assert.Error(t, runGodocCmd(t, "--baseline", baseline, "--fail-on-regression"))
+	assert.NoError(t, runGodocCmd(t, "--baseline", baseline), "without --fail-on-regression only a report is shown")
+
+	assert.Error(t, runGodocCmd(t, "--fail-on-regression"))
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/analysis/godoc"
+	"github.com/gizzahub/gzh-cli/internal/htmlreport"
+)
+
+// writeHTMLReport records values in the named history, unless noHistory
+// is set, and renders the report built from the recorded runs to path.
+func writeHTMLReport(path, historyName string, noHistory bool, at time.Time, values map[string]float64,
+	build func(history []htmlreport.Entry) *htmlreport.Report,
+) error {
+	var entries []htmlreport.Entry
+	if !noHistory {
+		history, err := htmlreport.OpenHistory(historyName)
+		if err != nil {
+			return err
+		}
+		if err := history.Append(htmlreport.Entry{Time: at, Values: values}); err != nil {
+			return err
+		}
+		if entries, err = history.Load(); err != nil {
+			return err
+		}
+	}
+	return htmlreport.WriteFile(path, build(entries))
+}
+
+// doctorHistoryValues are the metrics of a diagnostic run kept for trends.
+func doctorHistoryValues(report *DiagnosticReport) map[string]float64 {
+	return map[string]float64{
+		"passed":   float64(report.PassedChecks),
+		"warnings": float64(report.WarnChecks),
+		"failed":   float64(report.FailedChecks),
+	}
+}
+
+// doctorHTMLReport converts a diagnostic report to an HTML report.
+func doctorHTMLReport(report *DiagnosticReport, history []htmlreport.Entry) *htmlreport.Report {
+	out := &htmlreport.Report{
+		Title:       "GZH Manager Diagnostic Report",
+		Subtitle:    fmt.Sprintf("%s · version %s · %s", report.Platform, report.Version, report.Duration.Round(time.Millisecond)),
+		GeneratedAt: report.Timestamp,
+		Stats: []htmlreport.Stat{
+			{Label: "Checks", Value: fmt.Sprint(report.TotalChecks)},
+			{Label: "Passed", Value: fmt.Sprint(report.PassedChecks), Status: htmlreport.StatusPass},
+			{Label: "Warnings", Value: fmt.Sprint(report.WarnChecks), Status: htmlreport.StatusWarn},
+			{Label: "Failed", Value: fmt.Sprint(report.FailedChecks), Status: htmlreport.StatusFail},
+			{Label: "Skipped", Value: fmt.Sprint(report.SkippedChecks), Status: htmlreport.StatusSkip},
+		},
+		Charts: []*htmlreport.Chart{
+			htmlreport.TrendChart("Check results over time", "", history, "passed", "warnings", "failed"),
+		},
+	}
+
+	// One section per category, in the order the categories ran.
+	var categories []string
+	byCategory := map[string][]htmlreport.Row{}
+	for _, r := range report.Results {
+		if _, ok := byCategory[r.Category]; !ok {
+			categories = append(categories, r.Category)
+		}
+		byCategory[r.Category] = append(byCategory[r.Category], htmlreport.Row{
+			Status: r.Status,
+			Cells:  []string{r.Name, strings.ToUpper(r.Status), r.Message},
+			Detail: r.FixSuggestion,
+		})
+	}
+	for _, category := range categories {
+		title := category
+		if title != "" {
+			title = strings.ToUpper(title[:1]) + title[1:]
+		}
+		out.Sections = append(out.Sections, htmlreport.Section{
+			Title:   title,
+			Columns: []string{"Check", "Status", "Message"},
+			Rows:    byCategory[category],
+		})
+	}
+
+	if len(report.Recommendations) > 0 {
+		section := htmlreport.Section{Title: "Recommendations", Description: report.Summary, Columns: []string{"Recommendation"}}
+		for _, rec := range report.Recommendations {
+			section.Rows = append(section.Rows, htmlreport.Row{Cells: []string{rec}})
+		}
+		out.Sections = append(out.Sections, section)
+	}
+
+	return out
+}
+
+// godocHistoryName keys the coverage history by project directory, so
+// trends of different projects do not mix.
+func godocHistoryName(dir string) string {
+	sum := sha256.Sum256([]byte(dir))
+	return "godoc-" + filepath.Base(dir) + "-" + hex.EncodeToString(sum[:4])
+}
+
+// godocTotals returns the overall coverage and the number of quality
+// issues of an analysis.
+func godocTotals(results []*godoc.PackageInfo) (coverage float64, issues int) {
+	symbols, documented := 0, 0
+	for _, r := range results {
+		symbols += r.CoverageStats.TotalPublicSymbols
+		documented += r.CoverageStats.DocumentedSymbols
+		issues += len(r.QualityIssues)
+	}
+	if symbols > 0 {
+		coverage = float64(documented) * 100.0 / float64(symbols)
+	}
+	return coverage, issues
+}
+
+// godocHTMLReport converts a documentation analysis to an HTML report.
+func godocHTMLReport(dir string, results []*godoc.PackageInfo, failedPackages []string, threshold float64,
+	history []htmlreport.Entry,
+) *htmlreport.Report {
+	coverage, issues := godocTotals(results)
+	out := &htmlreport.Report{
+		Title:       "API Documentation Report",
+		Subtitle:    dir,
+		GeneratedAt: time.Now(),
+		Stats: []htmlreport.Stat{
+			{Label: "Packages", Value: fmt.Sprint(len(results))},
+			{Label: "Coverage", Value: fmt.Sprintf("%.1f%%", coverage), Status: coverageStatus(coverage, threshold)},
+			{Label: "Quality issues", Value: fmt.Sprint(issues), Status: countStatus(issues, htmlreport.StatusWarn)},
+			{Label: "Failed packages", Value: fmt.Sprint(len(failedPackages)), Status: countStatus(len(failedPackages), htmlreport.StatusFail)},
+		},
+		Charts: []*htmlreport.Chart{
+			htmlreport.TrendChart("Documentation coverage over time", "%", history, "coverage"),
+			htmlreport.TrendChart("Quality issues over time", "", history, "issues"),
+		},
+	}
+
+	packages := htmlreport.Section{
+		Title:   "Packages",
+		Columns: []string{"Package", "Coverage", "Documented", "Issues", "Package doc"},
+	}
+	findings := htmlreport.Section{
+		Title:   "Quality issues",
+		Columns: []string{"Package", "Symbol", "Type", "Message"},
+	}
+	for _, r := range results {
+		stats := r.CoverageStats
+		pkgDoc := "yes"
+		if !stats.PackageDocumented {
+			pkgDoc = "no"
+		}
+		packages.Rows = append(packages.Rows, htmlreport.Row{
+			Status: coverageStatus(stats.CoveragePercentage, threshold),
+			Cells: []string{
+				r.ImportPath,
+				fmt.Sprintf("%.1f%%", stats.CoveragePercentage),
+				fmt.Sprintf("%d/%d", stats.DocumentedSymbols, stats.TotalPublicSymbols),
+				fmt.Sprint(len(r.QualityIssues)),
+				pkgDoc,
+			},
+		})
+		for _, issue := range r.QualityIssues {
+			findings.Rows = append(findings.Rows, htmlreport.Row{
+				Status: issueStatus(issue.Severity),
+				Cells:  []string{r.ImportPath, issue.Symbol, issue.Type, issue.Message},
+				Detail: issue.Suggestion,
+			})
+		}
+	}
+	out.Sections = append(out.Sections, packages, findings)
+
+	if len(failedPackages) > 0 {
+		failed := htmlreport.Section{Title: "Packages that could not be analyzed", Columns: []string{"Package"}}
+		for _, p := range failedPackages {
+			failed.Rows = append(failed.Rows, htmlreport.Row{Status: htmlreport.StatusFail, Cells: []string{p}})
+		}
+		out.Sections = append(out.Sections, failed)
+	}
+
+	return out
+}
+
+// coverageStatus grades a coverage percentage against the threshold, or
+// against 80% and 50% without one.
+func coverageStatus(coverage, threshold float64) string {
+	if threshold > 0 {
+		if coverage < threshold {
+			return htmlreport.StatusFail
+		}
+		return htmlreport.StatusPass
+	}
+	switch {
+	case coverage >= 80:
+		return htmlreport.StatusPass
+	case coverage >= 50:
+		return htmlreport.StatusWarn
+	default:
+		return htmlreport.StatusFail
+	}
+}
+
+func countStatus(n int, status string) string {
+	if n > 0 {
+		return status
+	}
+	return htmlreport.StatusPass
+}
+
+func issueStatus(severity string) string {
+	switch severity {
+	case "high":
+		return htmlreport.StatusFail
+	case "medium":
+		return htmlreport.StatusWarn
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/analysis/godoc"
+	"github.com/gizzahub/gzh-cli/internal/htmlreport"
+)
+
+func TestDoctorHTMLReportGroupsByCategory(t *testing.T) {
+	report := &DiagnosticReport{
+		Timestamp:   time.Now(),
+		TotalChecks: 3, PassedChecks: 1, WarnChecks: 1, FailedChecks: 1,
+		Results: []DiagnosticResult{
+			{Name: "Go", Category: "system", Status: statusPass, Message: "ok"},
+			{Name: "Git", Category: "git", Status: statusFail, Message: "missing", FixSuggestion: "install git"},
+			{Name: "Memory", Category: "system", Status: statusWarn, Message: "high"},
+		},
+		Recommendations: []string{"install git"},
+	}
+
+	out := doctorHTMLReport(report, nil)
+
+	require.Len(t, out.Sections, 3)
+	assert.Equal(t, "System", out.Sections[0].Title)
+	assert.Len(t, out.Sections[0].Rows, 2)
+	assert.Equal(t, "Git", out.Sections[1].Title)
+	assert.Equal(t, "install git", out.Sections[1].Rows[0].Detail)
+	assert.Equal(t, "Recommendations", out.Sections[2].Title)
+}
+
+func TestWriteHTMLReportRecordsHistory(t *testing.T) {
+	t.Setenv("GZH_HISTORY_DIR", t.TempDir())
+	path := filepath.Join(t.TempDir(), "godoc.html")
+	results := []*godoc.PackageInfo{{
+		ImportPath:    "example.com/pkg",
+		CoverageStats: godoc.CoverageStats{TotalPublicSymbols: 4, DocumentedSymbols: 3, CoveragePercentage: 75},
+	}}
+
+	var charted int
+	for i := range 2 {
+		err := writeHTMLReport(path, godocHistoryName("/src/project"), false, time.Now().Add(time.Duration(i)*time.Hour),
+			map[string]float64{"coverage": 75, "issues": 0},
+			func(history []htmlreport.Entry) *htmlreport.Report {
+				charted = len(history)
+				return godocHTMLReport("/src/project", results, nil, 0, history)
+			})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, charted)
+
+	html, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "Documentation coverage over time")
+	assert.Contains(t, string(html), "example.com/pkg")
+}
+
+func TestCoverageStatus(t *testing.T) {
+	assert.Equal(t, htmlreport.StatusPass, coverageStatus(85, 0))
+	assert.Equal(t, htmlreport.StatusWarn, coverageStatus(60, 0))
+	assert.Equal(t, htmlreport.StatusFail, coverageStatus(60, 70))
+	assert.Equal(t, htmlreport.StatusPass, coverageStatus(70, 70))
+}
@@ -0,0 +1,683 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/errors"
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+const statusSkip = "skip"
+
+// Network diagnostic stages, in the order they are run for each provider.
+const (
+	netCheckVPN   = "vpn"
+	netCheckDNS   = "dns"
+	netCheckTCP   = "tcp"
+	netCheckTLS   = "tls"
+	netCheckProxy = "proxy"
+	netCheckAPI   = "api"
+)
+
+var netCheckOrder = []string{netCheckVPN, netCheckDNS, netCheckTCP, netCheckTLS, netCheckProxy, netCheckAPI}
+
+// defaultProviderAPIs are the API endpoints probed for providers without an
+// explicit --endpoint.
+var defaultProviderAPIs = map[string]string{
+	config.ProviderGitHub: "https://api.github.com",
+	config.ProviderGitLab: "https://gitlab.com/api/v4",
+	config.ProviderGitea:  "https://gitea.com/api/v1",
+}
+
+// rateLimitPaths are requested relative to the API endpoint to read the rate limit.
+var rateLimitPaths = map[string]string{
+	config.ProviderGitHub: "/rate_limit",
+	config.ProviderGitLab: "/version",
+	config.ProviderGitea:  "/version",
+}
+
+// NetworkTarget is a provider API endpoint to diagnose.
+type NetworkTarget struct {
+	Provider string `json:"provider"`
+	APIURL   string `json:"apiUrl"`
+	Token    string `json:"-"`
+}
+
+// NetworkCheck is the outcome of one diagnostic stage for one target.
+type NetworkCheck struct {
+	Check   string         `json:"check"`
+	Status  string         `json:"status"`
+	Message string         `json:"message"`
+	Latency time.Duration  `json:"latency"`
+	Details map[string]any `json:"details,omitempty"`
+	Error   string         `json:"error,omitempty"`
+	Hints   []string       `json:"hints,omitempty"`
+	ErrCode string         `json:"errorCode,omitempty"`
+}
+
+// NetworkTargetReport collects the checks run against one target.
+type NetworkTargetReport struct {
+	Target NetworkTarget  `json:"target"`
+	Checks []NetworkCheck `json:"checks"`
+}
+
+// Status returns the worst status among the checks.
+func (r NetworkTargetReport) Status() string {
+	status := statusPass
+	for _, c := range r.Checks {
+		switch c.Status {
+		case statusFail:
+			return statusFail
+		case statusWarn:
+			status = statusWarn
+		}
+	}
+	return status
+}
+
+// NetworkDiagnoser runs connectivity diagnostics against provider APIs.
+type NetworkDiagnoser struct {
+	// Timeout bounds each individual stage.
+	Timeout time.Duration
+	// CertWarnDays warns when a certificate expires within this many days.
+	CertWarnDays int
+	// RootCAs overrides the system trust store (used in tests).
+	RootCAs *x509.CertPool
+	// Proxy resolves the proxy for a request; defaults to http.ProxyFromEnvironment.
+	Proxy func(*http.Request) (*url.URL, error)
+	// Network returns the host overrides, DNS servers and VPN settings of a
+	// provider; defaults to httpclient.ProviderNetwork.
+	Network func(provider string) httpclient.NetworkConfig
+}
+
+func (d *NetworkDiagnoser) timeout() time.Duration {
+	if d.Timeout <= 0 {
+		return 5 * time.Second
+	}
+	return d.Timeout
+}
+
+func (d *NetworkDiagnoser) network(provider string) httpclient.NetworkConfig {
+	if d.Network == nil {
+		return httpclient.ProviderNetwork(provider)
+	}
+	return d.Network(provider)
+}
+
+// Diagnose runs all stages against target. Stages that depend on a failed
+// stage are skipped.
+func (d *NetworkDiagnoser) Diagnose(ctx context.Context, target NetworkTarget) NetworkTargetReport {
+	report := NetworkTargetReport{Target: target}
+	add := func(c NetworkCheck) NetworkCheck {
+		report.Checks = append(report.Checks, c)
+		return c
+	}
+
+	u, err := url.Parse(target.APIURL)
+	if err != nil || u.Host == "" {
+		add(failCheck(netCheckDNS, "invalid API URL "+target.APIURL,
+			errors.NewConfigError("invalid provider API URL", err)))
+		return report
+	}
+	host := u.Hostname()
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	addr := net.JoinHostPort(host, port)
+	network := d.network(target.Provider)
+
+	add(d.checkVPN(network, host))
+	proxyCheck := add(d.checkProxy(ctx, u))
+	viaProxy := proxyCheck.Details["proxy"] != nil
+
+	dns := add(d.checkDNS(ctx, network, host))
+	if dns.Status == statusFail && !viaProxy {
+		add(skipCheck(netCheckTCP, "DNS resolution failed"))
+		add(skipCheck(netCheckTLS, "DNS resolution failed"))
+		add(skipCheck(netCheckAPI, "DNS resolution failed"))
+		return report.sorted()
+	}
+
+	if viaProxy {
+		// Direct connections are not how requests travel; the proxy stage
+		// already dialed the proxy.
+		add(skipCheck(netCheckTCP, "requests go through a proxy"))
+		add(skipCheck(netCheckTLS, "requests go through a proxy"))
+	} else {
+		tcp := add(d.checkTCP(ctx, network, addr))
+		switch {
+		case tcp.Status == statusFail:
+			add(skipCheck(netCheckTLS, "TCP connection failed"))
+			add(skipCheck(netCheckAPI, "TCP connection failed"))
+			return report.sorted()
+		case u.Scheme != "https":
+			add(skipCheck(netCheckTLS, "endpoint does not use TLS"))
+		default:
+			if tlsCheck := add(d.checkTLS(ctx, network, addr, host)); tlsCheck.Status == statusFail {
+				add(skipCheck(netCheckAPI, "TLS handshake failed"))
+				return report.sorted()
+			}
+		}
+	}
+
+	add(d.checkAPI(ctx, network, target))
+	return report.sorted()
+}
+
+// sorted orders the checks by stage so that the matrix columns line up.
+func (r NetworkTargetReport) sorted() NetworkTargetReport {
+	rank := make(map[string]int, len(netCheckOrder))
+	for i, c := range netCheckOrder {
+		rank[c] = i
+	}
+	sort.SliceStable(r.Checks, func(i, j int) bool { return rank[r.Checks[i].Check] < rank[r.Checks[j].Check] })
+	return r
+}
+
+// checkVPN reports whether a host that is only reachable through a VPN has
+// an active tunnel interface.
+func (d *NetworkDiagnoser) checkVPN(network httpclient.NetworkConfig, host string) NetworkCheck {
+	if !network.RequiresVPN(host) {
+		return NetworkCheck{Check: netCheckVPN, Status: statusPass, Message: "not required"}
+	}
+	tunnels, err := httpclient.ActiveTunnels(network.VPNInterfaces)
+	if err != nil {
+		return failCheck(netCheckVPN, "cannot detect VPN interfaces", errors.NewNetworkError("interface listing failed", err))
+	}
+	if len(tunnels) == 0 {
+		vpnErr := network.CheckVPN(host)
+		if vpnErr == nil {
+			vpnErr = stderrors.New("no active tunnel interface")
+		}
+		return failCheck(netCheckVPN, vpnErr.Error(),
+			errors.NewNetworkError("VPN not connected", vpnErr).
+				WithSuggestion("Connect to the VPN, or set network.vpn_interfaces to the interface names of your VPN client"))
+	}
+	return NetworkCheck{
+		Check:   netCheckVPN,
+		Status:  statusPass,
+		Message: "tunnel up: " + strings.Join(tunnels, ", "),
+		Details: map[string]any{"tunnels": tunnels},
+	}
+}
+
+func (d *NetworkDiagnoser) checkDNS(ctx context.Context, network httpclient.NetworkConfig, host string) NetworkCheck {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout())
+	defer cancel()
+
+	start := time.Now()
+	addrs, err := network.LookupHost(ctx, host)
+	latency := time.Since(start)
+	if err != nil {
+		c := failCheck(netCheckDNS, "cannot resolve "+host,
+			errors.NewNetworkError("DNS lookup failed", err).
+				WithSuggestion("Check the DNS servers in /etc/resolv.conf or your VPN settings"))
+		c.Latency = latency
+		return c
+	}
+	return NetworkCheck{
+		Check:   netCheckDNS,
+		Status:  statusPass,
+		Message: fmt.Sprintf("%s → %s", host, strings.Join(addrs, ", ")),
+		Latency: latency,
+		Details: map[string]any{"addresses": addrs},
+	}
+}
+
+func (d *NetworkDiagnoser) checkTCP(ctx context.Context, network httpclient.NetworkConfig, addr string) NetworkCheck {
+	dial := network.DialFunc(&net.Dialer{Timeout: d.timeout()})
+	start := time.Now()
+	conn, err := dial(ctx, "tcp", addr)
+	latency := time.Since(start)
+	if err != nil {
+		c := failCheck(netCheckTCP, "cannot connect to "+addr, timeoutAware(err, "TCP connection failed"))
+		c.Latency = latency
+		return c
+	}
+	conn.Close()
+	return NetworkCheck{
+		Check:   netCheckTCP,
+		Status:  statusPass,
+		Message: "connected to " + addr,
+		Latency: latency,
+	}
+}
+
+func (d *NetworkDiagnoser) checkTLS(ctx context.Context, network httpclient.NetworkConfig, addr, serverName string) NetworkCheck {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout())
+	defer cancel()
+
+	dial := network.DialFunc(&net.Dialer{Timeout: d.timeout()})
+	start := time.Now()
+	raw, err := dial(ctx, "tcp", addr)
+	var conn *tls.Conn
+	if err == nil {
+		conn = tls.Client(raw, &tls.Config{ServerName: serverName, RootCAs: d.RootCAs, MinVersion: tls.VersionTLS12})
+		if err = conn.HandshakeContext(ctx); err != nil {
+			raw.Close()
+		}
+	}
+	latency := time.Since(start)
+	if err != nil {
+		c := failCheck(netCheckTLS, "TLS handshake with "+addr+" failed", tlsError(err))
+		c.Latency = latency
+		return c
+	}
+	defer conn.Close()
+
+	state := conn.ConnectionState()
+	leaf := state.PeerCertificates[0]
+	daysLeft := int(time.Until(leaf.NotAfter).Hours() / 24)
+	chain := make([]string, 0, len(state.PeerCertificates))
+	for _, cert := range state.PeerCertificates {
+		chain = append(chain, cert.Subject.CommonName)
+	}
+
+	c := NetworkCheck{
+		Check:   netCheckTLS,
+		Status:  statusPass,
+		Message: fmt.Sprintf("%s, certificate valid for %d days", tls.VersionName(state.Version), daysLeft),
+		Latency: latency,
+		Details: map[string]any{
+			"version":  tls.VersionName(state.Version),
+			"issuer":   leaf.Issuer.CommonName,
+			"notAfter": leaf.NotAfter,
+			"chain":    chain,
+		},
+	}
+	if d.CertWarnDays > 0 && daysLeft < d.CertWarnDays {
+		c.Status = statusWarn
+		c.Message = fmt.Sprintf("certificate of %s expires in %d days (%s)", serverName, daysLeft, leaf.NotAfter.Format("2006-01-02"))
+		c.Hints = []string{"Ask the server administrator to renew the certificate"}
+	}
+	return c
+}
+
+func (d *NetworkDiagnoser) checkProxy(ctx context.Context, u *url.URL) NetworkCheck {
+	proxyFunc := d.Proxy
+	if proxyFunc == nil {
+		proxyFunc = http.ProxyFromEnvironment
+	}
+	req := (&http.Request{Method: http.MethodGet, URL: u, Header: http.Header{}}).WithContext(ctx)
+	proxyURL, err := proxyFunc(req)
+	if err != nil {
+		return failCheck(netCheckProxy, "invalid proxy configuration",
+			errors.NewConfigError("invalid proxy URL", err).
+				WithSuggestion("Check HTTPS_PROXY, HTTP_PROXY and NO_PROXY"))
+	}
+	if proxyURL == nil {
+		return NetworkCheck{Check: netCheckProxy, Status: statusPass, Message: "direct connection (no proxy)"}
+	}
+
+	proxyAddr := proxyURL.Host
+	if proxyURL.Port() == "" {
+		proxyAddr = net.JoinHostPort(proxyURL.Hostname(), "80")
+	}
+	redacted := proxyURL.Redacted()
+
+	dialer := &net.Dialer{Timeout: d.timeout()}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", proxyAddr)
+	latency := time.Since(start)
+	if err != nil {
+		c := failCheck(netCheckProxy, "proxy "+redacted+" is unreachable",
+			errors.NewNetworkError("proxy connection failed", err).
+				WithSuggestion("Add the host to NO_PROXY if it should be reached directly"))
+		c.Latency = latency
+		c.Details = map[string]any{"proxy": redacted}
+		return c
+	}
+	conn.Close()
+	return NetworkCheck{
+		Check:   netCheckProxy,
+		Status:  statusPass,
+		Message: "via proxy " + redacted,
+		Latency: latency,
+		Details: map[string]any{"proxy": redacted},
+	}
+}
+
+func (d *NetworkDiagnoser) checkAPI(ctx context.Context, network httpclient.NetworkConfig, target NetworkTarget) NetworkCheck {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout())
+	defer cancel()
+
+	endpoint := strings.TrimRight(target.APIURL, "/") + rateLimitPaths[target.Provider]
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return failCheck(netCheckAPI, "invalid API URL", errors.NewConfigError("invalid provider API URL", err))
+	}
+	if target.Token != "" {
+		if target.Provider == config.ProviderGitLab {
+			req.Header.Set("PRIVATE-TOKEN", target.Token)
+		} else {
+			req.Header.Set("Authorization", "token "+target.Token)
+		}
+	}
+
+	proxy := d.Proxy
+	if proxy == nil {
+		proxy = http.ProxyFromEnvironment
+	}
+	client := &http.Client{
+		Timeout: d.timeout(),
+		Transport: &http.Transport{
+			Proxy:           proxy,
+			DialContext:     network.DialFunc(&net.Dialer{Timeout: d.timeout()}),
+			TLSClientConfig: &tls.Config{RootCAs: d.RootCAs, MinVersion: tls.VersionTLS12},
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		c := failCheck(netCheckAPI, "request to "+endpoint+" failed", timeoutAware(err, "API request failed"))
+		c.Latency = latency
+		return c
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	c := NetworkCheck{
+		Check:   netCheckAPI,
+		Status:  statusPass,
+		Message: fmt.Sprintf("HTTP %d in %s", resp.StatusCode, latency.Round(time.Millisecond)),
+		Latency: latency,
+		Details: map[string]any{"statusCode": resp.StatusCode, "authenticated": target.Token != ""},
+	}
+
+	remaining, limit, ok := parseRateLimit(resp.Header)
+	if ok {
+		c.Details["rateLimitRemaining"] = remaining
+		c.Details["rateLimitLimit"] = limit
+		c.Message += fmt.Sprintf(", rate limit %d/%d", remaining, limit)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return withError(c, statusFail, errors.NewAuthError("provider rejected the token", nil).
+			WithSuggestion(fmt.Sprintf("Refresh %s_TOKEN or the token in gzh.yaml", strings.ToUpper(target.Provider))))
+	case resp.StatusCode == http.StatusTooManyRequests || (resp.StatusCode == http.StatusForbidden && ok && remaining == 0):
+		return withError(c, statusFail, rateLimitError(target))
+	case resp.StatusCode >= 500:
+		return withError(c, statusFail, errors.NewStandardError(errors.ErrorCodeAPIUnavailable,
+			"provider API is unavailable", errors.SeverityHigh).
+			WithSuggestion("Check the provider status page and retry later"))
+	case resp.StatusCode >= 400:
+		c.Status = statusWarn
+	case ok && limit > 0 && remaining*10 < limit:
+		return withError(c, statusWarn, rateLimitError(target))
+	}
+	if target.Token == "" && c.Status == statusPass {
+		c.Hints = append(c.Hints, fmt.Sprintf("Set %s_TOKEN for higher rate limits", strings.ToUpper(target.Provider)))
+	}
+	return c
+}
+
+// parseRateLimit reads GitHub/Gitea (X-RateLimit-*) or GitLab (RateLimit-*) headers.
+func parseRateLimit(h http.Header) (remaining, limit int, ok bool) {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		r, rerr := strconv.Atoi(h.Get(prefix + "Remaining"))
+		l, lerr := strconv.Atoi(h.Get(prefix + "Limit"))
+		if rerr == nil && lerr == nil {
+			return r, l, true
+		}
+	}
+	return 0, 0, false
+}
+
+func rateLimitError(target NetworkTarget) *errors.StandardError {
+	err := errors.NewStandardError(errors.ErrorCodeRateLimitExceeded,
+		"provider API rate limit is nearly exhausted", errors.SeverityMedium).
+		WithSuggestion("Wait for the rate limit window to reset")
+	if target.Token == "" {
+		err.WithSuggestion(fmt.Sprintf("Set %s_TOKEN; authenticated requests have a much higher limit",
+			strings.ToUpper(target.Provider)))
+	}
+	return err
+}
+
+// timeoutAware classifies err as a timeout or a connection failure.
+func timeoutAware(err error, message string) *errors.StandardError {
+	var netErr net.Error
+	if stderrors.As(err, &netErr) && netErr.Timeout() {
+		return errors.WrapError(err, errors.ErrorCodeNetworkTimeout, message, errors.SeverityMedium).
+			WithSuggestion("Check whether a firewall drops outgoing connections").
+			WithSuggestion("Retry with a larger --timeout on slow networks")
+	}
+	return errors.NewNetworkError(message, err)
+}
+
+// tlsError maps handshake errors to remediation hints.
+func tlsError(err error) *errors.StandardError {
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		invalid          x509.CertificateInvalidError
+		hostname         x509.HostnameError
+	)
+	se := errors.WrapError(err, errors.ErrorCodeConnectionFailed, "TLS handshake failed", errors.SeverityHigh)
+	switch {
+	case stderrors.As(err, &unknownAuthority):
+		se.WithSuggestion("The certificate chain is not trusted; a TLS-inspecting proxy may be in the path").
+			WithSuggestion("Install the corporate root CA or set SSL_CERT_FILE")
+	case stderrors.As(err, &invalid) && invalid.Reason == x509.Expired:
+		se.WithSuggestion("The server certificate has expired or the system clock is wrong")
+	case stderrors.As(err, &hostname):
+		se.WithSuggestion("The certificate does not match the host; check the configured API URL")
+	default:
+		se.WithSuggestion("Verify firewall and proxy settings")
+	}
+	return se
+}
+
+func failCheck(check, message string, err *errors.StandardError) NetworkCheck {
+	return withError(NetworkCheck{Check: check, Message: message}, statusFail, err)
+}
+
+func withError(c NetworkCheck, status string, err *errors.StandardError) NetworkCheck {
+	c.Status = status
+	c.ErrCode = string(err.Code)
+	c.Error = err.Error()
+	c.Hints = append(c.Hints, err.Suggestions...)
+	return c
+}
+
+func skipCheck(check, reason string) NetworkCheck {
+	return NetworkCheck{Check: check, Status: statusSkip, Message: reason}
+}
+
+// networkTargets returns the providers to probe: those configured in gzh.yaml,
+// or all supported providers without a configuration. endpoints overrides API
+// URLs as provider=url for self-hosted instances.
+func networkTargets(only []string, endpoints map[string]string) ([]NetworkTarget, error) {
+	tokens := map[string]string{}
+	providers := map[string]bool{}
+	if cfg, err := config.LoadConfig(); err == nil && cfg != nil {
+		for name, p := range cfg.Providers {
+			providers[name] = true
+			tokens[name] = p.Token
+		}
+	}
+	if len(providers) == 0 {
+		for name := range defaultProviderAPIs {
+			providers[name] = true
+		}
+	}
+	for name := range endpoints {
+		providers[name] = true
+	}
+	if len(only) > 0 {
+		providers = map[string]bool{}
+		for _, name := range only {
+			providers[strings.ToLower(name)] = true
+		}
+	}
+
+	targets := make([]NetworkTarget, 0, len(providers))
+	for name := range providers {
+		apiURL := endpoints[name]
+		if apiURL == "" {
+			apiURL = defaultProviderAPIs[name]
+		}
+		if apiURL == "" {
+			return nil, fmt.Errorf("no API endpoint known for provider %q; use --endpoint %s=<url>", name, name)
+		}
+		token := tokens[name]
+		if token == "" || strings.HasPrefix(token, "$") {
+			token = os.Getenv(strings.ToUpper(name) + "_TOKEN")
+		}
+		targets = append(targets, NetworkTarget{Provider: name, APIURL: apiURL, Token: token})
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].Provider < targets[j].Provider })
+	return targets, nil
+}
+
+func newNetworkCmd() *cobra.Command {
+	var (
+		providers    []string
+		endpoints    map[string]string
+		timeout      time.Duration
+		certWarnDays int
+		jsonOutput   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "network",
+		Short: "Diagnose connectivity to Git provider APIs",
+		Long: `Run deep connectivity diagnostics against the configured Git providers.
+
+For each provider API the following stages are checked:
+  vpn    active tunnel interface for hosts listed in network.vpn_hosts
+  dns    host name resolution, honoring network.hosts and dns_servers
+  tcp    TCP connection to the API port
+  tls    handshake, certificate chain validation and expiry
+  proxy  proxy selection from HTTPS_PROXY/NO_PROXY and proxy reachability
+  api    API latency, authentication and rate limit status
+
+The result is a pass/fail matrix followed by remediation hints for every
+stage that did not pass. Providers come from gzh.yaml, or all supported
+providers without a configuration. Tokens are taken from the configuration or
+the <PROVIDER>_TOKEN environment variables.
+
+Examples:
+  gz doctor network                                   # Check all configured providers
+  gz doctor network --provider github                 # Only GitHub
+  gz doctor network --endpoint gitlab=https://git.example.com/api/v4
+  gz doctor network --json                            # Machine-readable output`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targets, err := networkTargets(providers, endpoints)
+			if err != nil {
+				return err
+			}
+
+			d := &NetworkDiagnoser{Timeout: timeout, CertWarnDays: certWarnDays}
+			reports := make([]NetworkTargetReport, 0, len(targets))
+			for _, target := range targets {
+				reports = append(reports, d.Diagnose(cmd.Context(), target))
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonOutput {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(reports); err != nil {
+					return fmt.Errorf("failed to encode results: %w", err)
+				}
+			} else {
+				printNetworkMatrix(out, reports)
+			}
+
+			failed := 0
+			for _, r := range reports {
+				if r.Status() == statusFail {
+					failed++
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("network diagnostics failed for %d of %d providers", failed, len(reports))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&providers, "provider", nil, "Only check these providers (github, gitlab, gitea)")
+	cmd.Flags().StringToStringVar(&endpoints, "endpoint", nil, "API URL per provider, e.g. gitlab=https://git.example.com/api/v4")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Second, "Timeout for each diagnostic stage")
+	cmd.Flags().IntVar(&certWarnDays, "cert-warn-days", 14, "Warn when a certificate expires within this many days")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the results as JSON")
+
+	return cmd
+}
+
+// printNetworkMatrix writes one row per provider and one column per stage,
+// followed by the hints of every stage that did not pass.
+func printNetworkMatrix(w io.Writer, reports []NetworkTargetReport) {
+	symbols := map[string]string{statusPass: "✅", statusWarn: "⚠️ ", statusFail: "❌", statusSkip: "➖"}
+
+	fmt.Fprintln(w, "🌐 Network diagnostics")
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprint(tw, "PROVIDER")
+	for _, check := range netCheckOrder {
+		fmt.Fprintf(tw, "\t%s", strings.ToUpper(check))
+	}
+	fmt.Fprintln(tw, "\tENDPOINT")
+	for _, r := range reports {
+		byCheck := make(map[string]string, len(r.Checks))
+		for _, c := range r.Checks {
+			byCheck[c.Check] = c.Status
+		}
+		fmt.Fprint(tw, r.Target.Provider)
+		for _, check := range netCheckOrder {
+			symbol, ok := symbols[byCheck[check]]
+			if !ok {
+				symbol = symbols[statusSkip]
+			}
+			fmt.Fprintf(tw, "\t%s", symbol)
+		}
+		fmt.Fprintf(tw, "\t%s\n", r.Target.APIURL)
+	}
+	tw.Flush()
+
+	for _, r := range reports {
+		for _, c := range r.Checks {
+			if c.Status != statusFail && c.Status != statusWarn {
+				continue
+			}
+			fmt.Fprintf(w, "\n%s %s/%s: %s\n", symbols[c.Status], r.Target.Provider, c.Check, c.Message)
+			if c.Error != "" {
+				fmt.Fprintf(w, "   error: %s\n", c.Error)
+			}
+			for _, hint := range c.Hints {
+				fmt.Fprintf(w, "   💡 %s\n", hint)
+			}
+		}
+	}
+}
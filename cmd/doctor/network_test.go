@@ -0,0 +1,193 @@
+//nolint:testpackage // White-box testing needed for internal function access
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+func checksByName(r NetworkTargetReport) map[string]NetworkCheck {
+	m := make(map[string]NetworkCheck, len(r.Checks))
+	for _, c := range r.Checks {
+		m[c.Check] = c
+	}
+	return m
+}
+
+func noProxy(*http.Request) (*url.URL, error) { return nil, nil }
+
+func TestNetworkDiagnoser_AllStagesPass(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rate_limit", r.URL.Path)
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		w.Header().Set("X-RateLimit-Remaining", "4990")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	d := &NetworkDiagnoser{Timeout: 2 * time.Second, RootCAs: pool, Proxy: noProxy}
+
+	report := d.Diagnose(context.Background(), NetworkTarget{Provider: "github", APIURL: srv.URL, Token: "secret"})
+	checks := checksByName(report)
+
+	for _, name := range netCheckOrder {
+		assert.Equal(t, statusPass, checks[name].Status, "%s: %s", name, checks[name].Message)
+	}
+	assert.Equal(t, 4990, checks[netCheckAPI].Details["rateLimitRemaining"])
+	assert.Equal(t, statusPass, report.Status())
+}
+
+func TestNetworkDiagnoser_UntrustedCertificate(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	d := &NetworkDiagnoser{Timeout: 2 * time.Second, RootCAs: x509.NewCertPool(), Proxy: noProxy}
+	report := d.Diagnose(context.Background(), NetworkTarget{Provider: "gitea", APIURL: srv.URL})
+	checks := checksByName(report)
+
+	assert.Equal(t, statusFail, checks[netCheckTLS].Status)
+	assert.Contains(t, checks[netCheckTLS].Hints[0], "not trusted")
+	assert.Equal(t, statusSkip, checks[netCheckAPI].Status)
+	assert.Equal(t, statusFail, report.Status())
+}
+
+func TestNetworkDiagnoser_CertificateExpiryWarning(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	// The test certificate is valid for decades; a larger threshold must warn.
+	d := &NetworkDiagnoser{Timeout: 2 * time.Second, RootCAs: pool, Proxy: noProxy, CertWarnDays: 1 << 20}
+
+	checks := checksByName(d.Diagnose(context.Background(), NetworkTarget{Provider: "gitea", APIURL: srv.URL}))
+	assert.Equal(t, statusWarn, checks[netCheckTLS].Status)
+	assert.Contains(t, checks[netCheckTLS].Message, "expires in")
+}
+
+func TestNetworkDiagnoser_APIStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     int
+		header   map[string]string
+		status   string
+		errCode  string
+		hintPart string
+	}{
+		{name: "unauthorized", code: http.StatusUnauthorized, status: statusFail, errCode: "AUTHENTICATION_FAILED", hintPart: "GITLAB_TOKEN"},
+		{name: "server error", code: http.StatusBadGateway, status: statusFail, errCode: "API_UNAVAILABLE"},
+		{
+			name:   "rate limit low",
+			code:   http.StatusOK,
+			header: map[string]string{"RateLimit-Remaining": "3", "RateLimit-Limit": "60"},
+			status: statusWarn, errCode: "RATE_LIMIT_EXCEEDED", hintPart: "GITLAB_TOKEN",
+		},
+		{name: "anonymous ok", code: http.StatusOK, status: statusPass, hintPart: "higher rate limits"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.code)
+			}))
+			defer srv.Close()
+
+			d := &NetworkDiagnoser{Timeout: 2 * time.Second, Proxy: noProxy}
+			checks := checksByName(d.Diagnose(context.Background(), NetworkTarget{Provider: "gitlab", APIURL: srv.URL}))
+
+			api := checks[netCheckAPI]
+			assert.Equal(t, tt.status, api.Status, api.Message)
+			assert.Equal(t, tt.errCode, api.ErrCode)
+			assert.Equal(t, statusSkip, checks[netCheckTLS].Status)
+			if tt.hintPart != "" {
+				assert.Contains(t, api.Hints[len(api.Hints)-1], tt.hintPart)
+			}
+		})
+	}
+}
+
+func TestNetworkDiagnoser_UnreachableProxy(t *testing.T) {
+	proxyURL, err := url.Parse("http://127.0.0.1:1")
+	require.NoError(t, err)
+	d := &NetworkDiagnoser{
+		Timeout: time.Second,
+		Proxy:   func(*http.Request) (*url.URL, error) { return proxyURL, nil },
+	}
+
+	checks := checksByName(d.Diagnose(context.Background(), NetworkTarget{Provider: "github", APIURL: "https://127.0.0.1:9"}))
+	assert.Equal(t, statusFail, checks[netCheckProxy].Status)
+	assert.NotEmpty(t, checks[netCheckProxy].Hints)
+	assert.Equal(t, statusSkip, checks[netCheckTCP].Status)
+}
+
+func TestNetworkDiagnoser_HostOverrideAndVPN(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	// The test certificate is issued for example.com, which is pinned to
+	// the local server.
+	network := httpclient.NetworkConfig{
+		Hosts:         map[string]string{"example.com": "127.0.0.1"},
+		VPNHosts:      []string{"example.com"},
+		VPNInterfaces: []string{"gz-test-vpn*"},
+	}
+	d := &NetworkDiagnoser{
+		Timeout: 2 * time.Second,
+		RootCAs: pool,
+		Proxy:   noProxy,
+		Network: func(string) httpclient.NetworkConfig { return network },
+	}
+
+	report := d.Diagnose(context.Background(), NetworkTarget{Provider: "gitea", APIURL: "https://example.com:" + port})
+	checks := checksByName(report)
+	assert.Equal(t, statusFail, checks[netCheckVPN].Status)
+	assert.Contains(t, checks[netCheckVPN].Message, "host example.com requires VPN")
+	assert.NotEmpty(t, checks[netCheckVPN].Hints)
+	for _, name := range []string{netCheckDNS, netCheckTCP, netCheckTLS, netCheckAPI} {
+		assert.Equal(t, statusPass, checks[name].Status, "%s: %s", name, checks[name].Message)
+	}
+	assert.Equal(t, "example.com → 127.0.0.1", checks[netCheckDNS].Message)
+}
+
+func TestPrintNetworkMatrix(t *testing.T) {
+	reports := []NetworkTargetReport{{
+		Target: NetworkTarget{Provider: "github", APIURL: "https://api.github.com"},
+		Checks: []NetworkCheck{
+			{Check: netCheckDNS, Status: statusPass},
+			{Check: netCheckTCP, Status: statusFail, Message: "cannot connect", Hints: []string{"Check network connectivity"}},
+		},
+	}}
+
+	var buf bytes.Buffer
+	printNetworkMatrix(&buf, reports)
+	out := buf.String()
+
+	assert.Contains(t, out, "PROVIDER")
+	assert.Contains(t, out, "github")
+	assert.Contains(t, out, "github/tcp: cannot connect")
+	assert.Contains(t, out, "💡 Check network connectivity")
+}
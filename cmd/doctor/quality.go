@@ -0,0 +1,218 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/analysis/mutation"
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/internal/testlib"
+)
+
+// QualityReport is the output of `gz doctor quality`.
+type QualityReport struct {
+	Revision string                         `json:"revision,omitempty"`
+	Report   *mutation.Report               `json:"report"`
+	Previous map[string]mutation.TrendPoint `json:"previous,omitempty"`
+}
+
+func newQualityCmd() *cobra.Command {
+	var (
+		opts      mutation.Options
+		trendFile string
+		noSave    bool
+		format    string
+		minScore  float64
+		survivors int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "quality [packages]",
+		Short: "Measure test effectiveness with mutation testing",
+		Long: `Run mutation testing on Go packages and report the mutation score next
+to statement coverage.
+
+Every mutant is a small change to the source, such as a flipped comparison
+or a negated if condition. The package tests run once per mutant through a
+go test -overlay file, so the working tree is never modified. A mutant is
+killed when the tests fail and survives when they pass; the mutation score
+is the share of mutants killed. High coverage with a low score means the
+tests execute code without asserting its behavior.
+
+Mutators: arithmetic, boundary, negation, logical, boolean, branch
+
+Scores are stored per package under the current git revision so that each
+run shows the change since the previous one.
+
+Examples:
+  gz doctor quality                              # All packages of the module
+  gz doctor quality ./internal/cache/...         # Selected packages
+  gz doctor quality --mutators boundary,branch --max-mutants 20
+  gz doctor quality ./pkg/... --min-score 60     # Fail CI below 60%`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			out := cmd.OutOrStdout()
+			if format != "table" && format != "json" {
+				return fmt.Errorf("unsupported format %q: use table or json", format)
+			}
+			if err := mutation.ValidateMutators(opts.Mutators); err != nil {
+				return err
+			}
+
+			pkgs, err := mutation.ListPackages(ctx, ".", args)
+			if err != nil {
+				return err
+			}
+			if format == "table" {
+				opts.Progress = func(pkg string, done, total int) {
+					fmt.Fprintf(cmd.ErrOrStderr(), "\r🧬 %s %d/%d", pkg, done, total)
+					if done == total {
+						fmt.Fprintln(cmd.ErrOrStderr())
+					}
+				}
+			}
+			report, err := mutation.Run(ctx, pkgs, opts)
+			if err != nil {
+				return err
+			}
+
+			store := mutation.NewTrendStore(trendFile)
+			history, err := store.Load()
+			if err != nil {
+				logger.SimpleWarn("Ignoring mutation score history", "error", err)
+				history = nil
+			}
+			result := &QualityReport{Report: report, Previous: map[string]mutation.TrendPoint{}}
+			for _, p := range report.Packages {
+				if prev, ok := mutation.Previous(history, p.ImportPath); ok {
+					result.Previous[p.ImportPath] = prev
+				}
+			}
+
+			revision, dirty, err := testlib.GitRevision(ctx, ".")
+			if err != nil {
+				logger.SimpleWarn("Scores are recorded without a git revision", "error", err)
+			}
+			if dirty {
+				revision += "-dirty"
+			}
+			result.Revision = revision
+			if !noSave && history != nil {
+				if err := store.Record(report, revision, time.Now()); err != nil {
+					return fmt.Errorf("failed to record mutation scores: %w", err)
+				}
+			}
+
+			if format == "json" {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(result); err != nil {
+					return fmt.Errorf("failed to encode report: %w", err)
+				}
+			} else {
+				printQualityReport(out, result, survivors)
+			}
+
+			if minScore > 0 {
+				var below []string
+				for _, p := range report.Packages {
+					if p.Tested() > 0 && p.Score < minScore {
+						below = append(below, p.ImportPath)
+					}
+				}
+				if len(below) > 0 {
+					return fmt.Errorf("%d package(s) below mutation score %.0f%%: %s", len(below), minScore, strings.Join(below, ", "))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Mutators, "mutators", nil, "Mutators to apply (default: all)")
+	cmd.Flags().IntVar(&opts.MaxMutants, "max-mutants", 50, "Maximum mutants tested per package, sampled evenly (0 = all)")
+	cmd.Flags().IntVar(&opts.Workers, "workers", 0, "Mutants tested in parallel (default: half the CPUs)")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 0, "Test timeout per mutant (default: 10x the unmutated run, at least 10s)")
+	cmd.Flags().Float64Var(&minScore, "min-score", 0, "Exit non-zero when a package scores below this percentage")
+	cmd.Flags().StringVar(&trendFile, "trend-file", mutation.DefaultTrendPath(), "File holding per-package score history")
+	cmd.Flags().BoolVar(&noSave, "no-save", false, "Do not record the scores of this run")
+	cmd.Flags().IntVar(&survivors, "survivors", 10, "Surviving mutants listed per package (0 = none)")
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func printQualityReport(w io.Writer, result *QualityReport, survivors int) {
+	report := result.Report
+	revision := result.Revision
+	if revision == "" {
+		revision = "(no git revision)"
+	}
+	fmt.Fprintf(w, "\n🧬 Mutation testing at %s\n\n", revision)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PACKAGE\tCOVERAGE\tMUTANTS\tKILLED\tSURVIVED\tTIMEOUT\tINVALID\tSCORE\tTREND")
+	for _, p := range report.Packages {
+		coverage := "-"
+		if p.Coverage >= 0 {
+			coverage = fmt.Sprintf("%.1f%%", p.Coverage)
+		}
+		if p.Skipped != "" {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\t-\t-\t-\t-\t%s\n", p.ImportPath, coverage, p.Skipped)
+			continue
+		}
+		mutants := fmt.Sprintf("%d", p.Tested()+p.Invalid)
+		if p.Found > p.Tested()+p.Invalid {
+			mutants = fmt.Sprintf("%d/%d", p.Tested()+p.Invalid, p.Found)
+		}
+		score, trend := "-", "new"
+		if p.Tested() > 0 {
+			score = fmt.Sprintf("%.1f%%", p.Score)
+		}
+		if prev, ok := result.Previous[p.ImportPath]; ok {
+			trend = fmt.Sprintf("%+.1f", p.Score-prev.Score)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\n", p.ImportPath, coverage, mutants,
+			p.Killed, p.Survived, p.TimedOut, p.Invalid, score, trend)
+	}
+	_ = tw.Flush()
+
+	cwd, _ := os.Getwd()
+	listed := false
+	for _, p := range report.Packages {
+		if survivors <= 0 || len(p.Survivors) == 0 {
+			continue
+		}
+		if !listed {
+			fmt.Fprintln(w, "\n⚠️  Surviving mutants:")
+			listed = true
+		}
+		for i, r := range p.Survivors {
+			if i == survivors {
+				fmt.Fprintf(w, "   ... and %d more in %s\n", len(p.Survivors)-survivors, p.ImportPath)
+				break
+			}
+			file := r.File
+			if rel, err := filepath.Rel(cwd, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+			fmt.Fprintf(w, "   %s:%d:%d  %-10s %s → %s\n", file, r.Line, r.Column, r.Mutator, r.Original, r.Replacement)
+		}
+	}
+
+	fmt.Fprintf(w, "\n📊 Overall mutation score: %.1f%%\n", report.Score)
+	if listed {
+		fmt.Fprintln(w, "💡 Tests still pass with these changes; add assertions for the behavior they alter")
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package doctor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/analysis/mutation"
+)
+
+func TestQualityCmd_RejectsBadFlags(t *testing.T) {
+	for _, args := range [][]string{{"--format", "xml"}, {"--mutators", "nope"}} {
+		cmd := newQualityCmd()
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		assert.Error(t, cmd.Execute(), args)
+	}
+}
+
+func TestPrintQualityReport(t *testing.T) {
+	survivor := func(line int) mutation.Result {
+		return mutation.Result{Status: mutation.StatusSurvived, Mutant: mutation.Mutant{
+			Mutator: mutation.MutatorBoundary, File: "/src/clamp.go", Line: line, Column: 7, Original: "<", Replacement: "<=",
+		}}
+	}
+	result := &QualityReport{
+		Revision: "abc123",
+		Report: &mutation.Report{Score: 60, Packages: []*mutation.PackageReport{
+			{
+				ImportPath: "example.com/clamp", Coverage: 92.5, Found: 8, Killed: 3, Survived: 2, Invalid: 1, Score: 60,
+				Survivors: []mutation.Result{survivor(4), survivor(9)},
+			},
+			{ImportPath: "example.com/empty", Coverage: -1, Skipped: "no test files"},
+		}},
+		Previous: map[string]mutation.TrendPoint{"example.com/clamp": {Score: 50}},
+	}
+
+	var out bytes.Buffer
+	printQualityReport(&out, result, 1)
+	text := out.String()
+	require.Contains(t, text, "Mutation testing at abc123")
+	assert.Regexp(t, `example.com/clamp\s+92.5%\s+6/8\s+3\s+2\s+0\s+1\s+60.0%\s+\+10.0`, text)
+	assert.Regexp(t, `example.com/empty\s+-\s+.*no test files`, text)
+	assert.Contains(t, text, "/src/clamp.go:4:7  boundary   < → <=")
+	assert.NotContains(t, text, "clamp.go:9:7")
+	assert.Contains(t, text, "... and 1 more in example.com/clamp")
+	assert.Contains(t, text, "Overall mutation score: 60.0%")
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package cache implements the object cache maintenance commands under
+// gz git cache.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/git/objcache"
+)
+
+// NewGitCacheCmd creates the git cache command.
+func NewGitCacheCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Shared object cache for deduplicated clones",
+		Long: `Maintain the shared Git object cache used by
+'gz git repo clone --object-cache' and 'gz workspace sync --object-cache'.
+
+The cache keeps one bare store per repository family: a repository and its
+forks on the same host share a store, and clones borrow objects from it
+through Git alternates instead of downloading and storing them again.
+
+Available Commands:
+  list   Show the stores, their size and the clones using them
+  gc     Repack the stores and remove the ones no clone uses
+
+Examples:
+  gz git cache list
+  gz git cache gc --prune-unused --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&dir, "dir", "", "Object cache directory (default ~/.gzh/cache/git-objects)")
+
+	cmd.AddCommand(newListCmd(&dir))
+	cmd.AddCommand(newGCCmd(&dir))
+
+	return cmd
+}
+
+// listColumns are the columns of `gz git cache list`.
+var listColumns = []cli.Column{
+	{Name: "store"},
+	{Name: "size", NoTruncate: true},
+	{Name: "clones", NoTruncate: true},
+	{Name: "stale", NoTruncate: true},
+	{Name: "path", Hidden: true},
+}
+
+func newListCmd(dir *string) *cobra.Command {
+	var (
+		table      cli.TableOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List object cache stores",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := table.Validate(listColumns...); err != nil {
+				return err
+			}
+
+			stores, err := objcache.New(*dir).List()
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				if stores == nil {
+					stores = []objcache.Store{}
+				}
+				return writeJSON(cmd.OutOrStdout(), stores)
+			}
+
+			if len(stores) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Object cache is empty")
+				return nil
+			}
+
+			t := cli.NewTable(listColumns...)
+			for _, s := range stores {
+				t.AddRow(s.Key, formatBytes(s.SizeBytes),
+					strconv.Itoa(len(s.LiveUsers)), strconv.Itoa(len(s.Users)-len(s.LiveUsers)), s.Path)
+			}
+			return t.Render(cmd.OutOrStdout(), table)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cli.AddTableFlags(cmd, &table, listColumns...)
+
+	return cmd
+}
+
+func newGCCmd(dir *string) *cobra.Command {
+	var (
+		opts       objcache.GCOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Repack object cache stores and remove unused ones",
+		Long: `Forget clones that were deleted or no longer reference a store, then
+repack every store. With --prune-unused, stores that no clone uses any
+more are removed instead.
+
+Unreachable objects are never pruned from a store: a clone may still need
+an object after the branch that brought it was force-pushed or deleted.`,
+		Example: `  # Preview which stores would be removed
+  gz git cache gc --prune-unused --dry-run
+
+  # Repack all stores and report the result as JSON
+  gz git cache gc --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			results, err := objcache.New(*dir).GC(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				return writeJSON(cmd.OutOrStdout(), results)
+			}
+			return printGCResults(cmd.OutOrStdout(), results, opts.DryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be done without changing anything")
+	cmd.Flags().BoolVar(&opts.PruneUnused, "prune-unused", false, "Remove stores that no clone uses")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func printGCResults(w io.Writer, results []objcache.GCResult, dryRun bool) error {
+	if len(results) == 0 {
+		_, err := fmt.Fprintln(w, "Object cache is empty")
+		return err
+	}
+
+	var reclaimed int64
+	failed := 0
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			failed++
+			fmt.Fprintf(w, "✗ %s: %s\n", r.Key, r.Error)
+		case r.Action == "removed":
+			fmt.Fprintf(w, "🗑  %s removed (%s)\n", r.Key, formatBytes(r.SizeBefore))
+		default:
+			fmt.Fprintf(w, "✓ %s %s, %d clone(s), %s\n", r.Key, r.Action, r.LiveUsers, formatBytes(r.SizeAfter))
+		}
+		if r.StaleUsers > 0 {
+			fmt.Fprintf(w, "   forgot %d deleted clone(s)\n", r.StaleUsers)
+		}
+		reclaimed += r.SizeBefore - r.SizeAfter
+	}
+
+	prefix := ""
+	if dryRun {
+		prefix = "(dry run) "
+	}
+	fmt.Fprintf(w, "\n%s%d store(s), %s reclaimed\n", prefix, len(results), formatBytes(max(reclaimed, 0)))
+	if failed > 0 {
+		return fmt.Errorf("%d store(s) failed", failed)
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
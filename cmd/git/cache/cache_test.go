@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/git/objcache"
+)
+
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewGitCacheCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestCacheCmd_Empty(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+
+	out, err := run(t, "list", "--dir", dir)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Object cache is empty")
+
+	out, err = run(t, "gc", "--dir", dir)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Object cache is empty")
+
+	out, err = run(t, "list", "--dir", dir, "--json")
+	require.NoError(t, err)
+	assert.JSONEq(t, "[]", out)
+}
+
+func TestCacheCmd_ListAndGC(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	tmp := t.TempDir()
+	upstream := filepath.Join(tmp, "acme", "api")
+	require.NoError(t, os.MkdirAll(upstream, 0o755))
+	for _, args := range [][]string{{"init", "-q"}, {"commit", "-q", "--allow-empty", "-m", "init"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = upstream
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	dir := filepath.Join(tmp, "cache")
+	store, err := objcache.New(dir).Prepare(context.Background(), upstream)
+	require.NoError(t, err)
+
+	out, err := run(t, "list", "--dir", dir)
+	require.NoError(t, err)
+	assert.Contains(t, out, "local/api")
+
+	out, err = run(t, "gc", "--dir", dir, "--prune-unused", "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, out, "local/api removed")
+	assert.Contains(t, out, "(dry run) 1 store(s)")
+	assert.DirExists(t, store)
+
+	out, err = run(t, "gc", "--dir", dir, "--prune-unused", "--json")
+	require.NoError(t, err)
+	var results []objcache.GCResult
+	require.NoError(t, json.Unmarshal([]byte(out), &results))
+	require.Len(t, results, 1)
+	assert.Equal(t, "removed", results[0].Action)
+	assert.NoDirExists(t, store)
+}
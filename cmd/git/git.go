@@ -6,9 +6,13 @@ package git
 import (
 	"github.com/spf13/cobra"
 
+	cachepkg "github.com/gizzahub/gzh-cli/cmd/git/cache"
 	eventpkg "github.com/gizzahub/gzh-cli/cmd/git/event"
+	labelspkg "github.com/gizzahub/gzh-cli/cmd/git/labels"
+	orgpkg "github.com/gizzahub/gzh-cli/cmd/git/org"
 	repopkg "github.com/gizzahub/gzh-cli/cmd/git/repo"
 	webhookpkg "github.com/gizzahub/gzh-cli/cmd/git/webhook"
+	worktreepkg "github.com/gizzahub/gzh-cli/cmd/git/worktree"
 	repoconfig "github.com/gizzahub/gzh-cli/cmd/repo-config"
 	"github.com/gizzahub/gzh-cli/internal/app"
 )
@@ -29,12 +33,20 @@ This command provides comprehensive Git platform management capabilities includi
 
 Available Resources:
   repo       Repository lifecycle management (clone, create, sync, etc.)
+  org        Organization team and membership management
+  labels     Canonical issue labels and milestones across an organization
+  cache      Shared object cache for deduplicated clones
+  worktree   Worktrees of one branch across many clones
   config     Repository configuration management
   webhook    Webhook management and automation
   event      Event processing and monitoring
 
 Examples:
   gz git repo clone --provider github --org myorg --target ./repos
+  gz git org sync-teams --org myorg --spec teams.yaml --dry-run
+  gz git labels sync --org myorg --spec labels.yaml --dry-run
+  gz git cache gc --prune-unused
+  gz git worktree create release/2.4 ~/src/acme --fetch
   gz git config audit --org myorg --framework SOC2
   gz git webhook create --org myorg --repo myrepo --url https://example.com/webhook
   gz git event server --port 8080 --secret mysecret`,
@@ -45,6 +57,10 @@ Examples:
 
 	// Add subcommands for each resource
 	cmd.AddCommand(repopkg.NewGitRepoCmd())
+	cmd.AddCommand(orgpkg.NewGitOrgCmd())
+	cmd.AddCommand(labelspkg.NewGitLabelsCmd())
+	cmd.AddCommand(cachepkg.NewGitCacheCmd())
+	cmd.AddCommand(worktreepkg.NewGitWorktreeCmd())
 	cmd.AddCommand(newGitConfigCmd(appCtx))
 	cmd.AddCommand(newGitWebhookCmd())
 	cmd.AddCommand(newGitEventCmd())
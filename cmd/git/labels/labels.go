@@ -0,0 +1,261 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package labels implements the issue label and milestone commands under
+// gz git labels.
+package labels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/git/archival"
+	"github.com/gizzahub/gzh-cli/internal/git/labels"
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+// NewGitLabelsCmd creates the git labels command.
+func NewGitLabelsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "labels",
+		Short: "Canonical issue labels and milestones across an organization",
+		Long: `Manage the issue labels and milestones of every repository of a GitHub
+or Gitea organization or a GitLab group.
+
+Available Commands:
+  sync   Enforce a canonical label and milestone set
+
+Examples:
+  gz git labels sync --provider github --org myorg --spec labels.yaml --dry-run
+  gz git labels sync --provider gitlab --org platform --spec labels.yaml --prune-labels`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newSyncCmd())
+
+	return cmd
+}
+
+// syncOptions contains options for label synchronization.
+type syncOptions struct {
+	provider     string
+	org          string
+	spec         string
+	baseURL      string
+	repositories []string
+	concurrency  int
+	dryRun       bool
+	report       string
+	json         bool
+	failOnDrift  bool
+	auditLog     string
+}
+
+func newSyncCmd() *cobra.Command {
+	opts := &syncOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Enforce a canonical label and milestone set",
+		Long: `Bring the labels and milestones of every non-archived repository of an
+organization in line with a YAML spec: create missing ones, fix colors,
+descriptions, due dates and states, and rename or remove old ones.
+
+  prune_labels: true           # delete labels not listed
+  prune_milestones: false      # delete milestones not listed
+  labels:
+    - name: bug
+      color: d73a4a
+      description: Something isn't working
+    - name: feature
+      color: a2eeef
+  renames:
+    labels:
+      enhancement: feature     # old name: new name
+  milestones:
+    - title: v2.0
+      due_on: 2025-09-30
+      state: open
+
+Names are compared case-insensitively. A renamed label or milestone keeps
+its issues when the repository does not have the new name yet; otherwise
+the old one is deleted. Labels and milestones that any issue or pull
+request still uses are never deleted: they are reported as kept instead.
+
+Every applied change is appended to a local audit log and shipped to the
+audit sinks of gzh.yaml when enabled. Tokens are read from GITHUB_TOKEN,
+GITLAB_TOKEN and GITEA_TOKEN.`,
+		Example: `  # Show the changes without making them
+  gz git labels sync --provider github --org myorg --spec labels.yaml --dry-run
+
+  # Apply to a few repositories, removing labels not in the spec
+  gz git labels sync --provider github --org myorg --spec labels.yaml \
+    --repo 'api-*' --repo web --prune-labels
+
+  # Check a self-hosted GitLab group in CI
+  gz git labels sync --provider gitlab --org platform --spec labels.yaml \
+    --base-url https://gitlab.example.com/api/v4 --dry-run --fail-on-drift`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := labels.LoadSpec(opts.spec)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("prune-labels") {
+				spec.PruneLabels, _ = cmd.Flags().GetBool("prune-labels")
+			}
+			if cmd.Flags().Changed("prune-milestones") {
+				spec.PruneMilestones, _ = cmd.Flags().GetBool("prune-milestones")
+			}
+			return runSync(cmd.Context(), cmd.OutOrStdout(), opts, spec)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.provider, "provider", "github", "Git platform (github, gitlab, gitea)")
+	cmd.Flags().StringVar(&opts.org, "org", "", "Organization or group")
+	cmd.Flags().StringVar(&opts.spec, "spec", "", "Label and milestone spec (YAML)")
+	cmd.Flags().StringVar(&opts.baseURL, "base-url", "", "API base URL (for self-hosted instances)")
+	cmd.Flags().StringSliceVar(&opts.repositories, "repo", nil, "Only sync repositories matching these patterns")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 4, "Number of repositories processed at once")
+	cmd.Flags().Bool("prune-labels", false, "Delete labels not listed in the spec (overrides the spec)")
+	cmd.Flags().Bool("prune-milestones", false, "Delete milestones not listed in the spec (overrides the spec)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show the changes without making them")
+	cmd.Flags().StringVar(&opts.report, "report", "", "Write the sync report (JSON) to this file")
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Print the report as JSON")
+	cmd.Flags().BoolVar(&opts.failOnDrift, "fail-on-drift", false, "Exit with an error when any repository differs from the spec")
+	cmd.Flags().StringVar(&opts.auditLog, "audit-log", "", "Local audit log (default: ~/.config/gzh-manager/labels/audit.jsonl)")
+
+	cmd.MarkFlagRequired("org")
+	cmd.MarkFlagRequired("spec")
+
+	return cmd
+}
+
+// runSync reconciles the organization's labels and milestones with spec.
+func runSync(ctx context.Context, out io.Writer, opts *syncOptions, spec *labels.Spec) error {
+	envVar := strings.ToUpper(opts.provider) + "_TOKEN"
+	token := getTokenFromEnv(envVar)
+	if token == "" {
+		return fmt.Errorf("%s is not set", envVar)
+	}
+	backend, err := labels.NewBackend(opts.provider, opts.baseURL, token)
+	if err != nil {
+		return err
+	}
+
+	syncOpts := labels.Options{
+		DryRun:       opts.dryRun,
+		Repositories: opts.repositories,
+		Concurrency:  opts.concurrency,
+		Actor:        currentUsername(),
+	}
+	if !opts.dryRun {
+		recorder, shipper := labelsRecorders(opts.auditLog)
+		defer func() {
+			closeCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := shipper.Close(closeCtx); err != nil {
+				logger.SimpleWarn("Failed to deliver label sync audit events; they stay spooled for the next run", "error", err)
+			}
+		}()
+		syncOpts.Recorder = recorder
+	}
+
+	if !opts.json {
+		fmt.Fprintf(out, "🏷️  Syncing labels from %s to %s:%s\n", opts.spec, opts.provider, opts.org)
+	}
+	report, runErr := labels.NewSyncer(backend, spec, syncOpts).Run(ctx, opts.org)
+	if report == nil {
+		return runErr
+	}
+
+	if opts.json {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	} else {
+		if opts.dryRun {
+			report.PrintPlan(out)
+		} else {
+			report.PrintDiff(out)
+		}
+		report.PrintSummary(out)
+	}
+
+	if opts.report != "" {
+		if err := report.WriteJSON(opts.report); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		if !opts.json {
+			fmt.Fprintf(out, "Sync report written to %s\n", opts.report)
+		}
+	}
+	if runErr != nil {
+		return runErr
+	}
+
+	if failed := report.Counts()[labels.StatusFailed]; failed > 0 {
+		return fmt.Errorf("%d repository(ies) could not be synced", failed)
+	}
+	if opts.failOnDrift && opts.dryRun && report.HasDrift() {
+		return fmt.Errorf("%d repository(ies) differ from %s", report.Counts()[labels.StatusDrift], opts.spec)
+	}
+	return nil
+}
+
+// labelsRecorders returns the local audit log, followed by the audit
+// shipper of gzh.yaml when shipping is enabled there. The shipper is nil
+// otherwise; closing a nil shipper is a no-op.
+func labelsRecorders(auditLog string) (audit.Recorder, *audit.Shipper) {
+	if auditLog == "" {
+		auditLog = labels.DefaultAuditLogPath()
+	}
+	recorders := audit.Recorders{archival.NewFileRecorder(auditLog)}
+
+	facade := pkgconfig.NewUnifiedConfigFacade()
+	if err := facade.LoadConfiguration(); err != nil {
+		return recorders, nil
+	}
+	shipper, err := audit.NewShipperFromConfig(facade.GetAuditConfig())
+	if err != nil {
+		logger.SimpleWarn("Audit shipping disabled for label sync", "error", err)
+		return recorders, nil
+	}
+	if shipper != nil {
+		recorders = append(recorders, shipper)
+	}
+	return recorders, shipper
+}
+
+// getTokenFromEnv reads a provider token, falling back to the GitHub CLI's
+// GH_TOKEN for GitHub.
+func getTokenFromEnv(envVar string) string {
+	if token := os.Getenv(envVar); token != "" {
+		return token
+	}
+	if envVar == "GITHUB_TOKEN" {
+		return os.Getenv("GH_TOKEN")
+	}
+	return ""
+}
+
+func currentUsername() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
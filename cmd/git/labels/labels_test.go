@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package labels
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGitLabelsCmd(t *testing.T) {
+	cmd := NewGitLabelsCmd()
+	assert.Equal(t, "labels", cmd.Use)
+
+	syncCmd, _, err := cmd.Find([]string{"sync"})
+	require.NoError(t, err)
+	for _, flag := range []string{"org", "spec", "repo", "dry-run", "prune-labels", "prune-milestones", "audit-log", "fail-on-drift"} {
+		assert.NotNil(t, syncCmd.Flags().Lookup(flag), flag)
+	}
+}
+
+func TestRunSyncDryRun(t *testing.T) {
+	var mutations int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			mutations++
+		}
+		switch r.URL.Path {
+		case "/orgs/acme/repos":
+			fmt.Fprint(w, `[{"name":"api"},{"name":"legacy","archived":true}]`)
+		case "/repos/acme/api/labels":
+			fmt.Fprint(w, `[{"id":1,"name":"enhancement","color":"a2eeef"},{"id":2,"name":"wontfix","color":"ffffff"}]`)
+		case "/repos/acme/api/milestones":
+			fmt.Fprint(w, `[]`)
+		case "/repos/acme/api/issues":
+			fmt.Fprint(w, `[{"number":1}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "token")
+
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "labels.yaml")
+	spec := "labels:\n  - {name: feature, color: a2eeef}\nrenames:\n  labels: {enhancement: feature}\n"
+	require.NoError(t, os.WriteFile(specPath, []byte(spec), 0o600))
+
+	cmd := newSyncCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"--org", "acme", "--spec", specPath, "--base-url", server.URL, "--prune-labels",
+		"--dry-run", "--fail-on-drift", "--report", filepath.Join(dir, "report.json"),
+	})
+	err := cmd.ExecuteContext(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 repository(ies) differ")
+
+	assert.Zero(t, mutations, "a dry run must not change anything")
+	assert.Contains(t, out.String(), `~ label "api/feature"`)
+	assert.Contains(t, out.String(), "! api: keep label wontfix (in use)")
+	assert.NotContains(t, out.String(), "legacy", "archived repositories are skipped")
+	assert.FileExists(t, filepath.Join(dir, "report.json"))
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package org implements organization-level commands under gz git org.
+package org
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// NewGitOrgCmd creates the git org command.
+func NewGitOrgCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "org",
+		Short: "Organization management (teams, membership)",
+		Long: `Manage GitHub and Gitea organizations and GitLab groups.
+
+Available Commands:
+  sync-teams   Reconcile team membership and repository permissions with a spec
+
+Examples:
+  gz git org sync-teams --provider github --org myorg --spec teams.yaml --dry-run
+  gz git org sync-teams --provider gitlab --org platform --spec roster.csv
+  gz git org sync-teams --provider gitea --org infra --spec teams.yaml \
+    --base-url https://gitea.example.com/api/v1`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newOrgSyncTeamsCmd())
+
+	return cmd
+}
+
+// getTokenFromEnv reads a provider token, falling back to the GitHub CLI's
+// GH_TOKEN for GitHub.
+func getTokenFromEnv(envVar string) string {
+	if token := os.Getenv(envVar); token != "" {
+		return token
+	}
+	if envVar == "GITHUB_TOKEN" {
+		return os.Getenv("GH_TOKEN")
+	}
+	return ""
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/git/archival"
+	"github.com/gizzahub/gzh-cli/internal/git/teams"
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+// SyncTeamsOptions contains options for team synchronization.
+type SyncTeamsOptions struct {
+	Provider    string
+	Org         string
+	Spec        string
+	Format      string
+	BaseURL     string
+	Teams       []string
+	Concurrency int
+	DryRun      bool
+	Report      string
+	JSON        bool
+	FailOnDrift bool
+	AuditLog    string
+}
+
+// newOrgSyncTeamsCmd creates the org sync-teams command.
+func newOrgSyncTeamsCmd() *cobra.Command {
+	opts := &SyncTeamsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "sync-teams",
+		Short: "Reconcile team membership and repository permissions with a spec",
+		Long: `Reconcile the teams of a GitHub or Gitea organization or a GitLab
+group with a declarative membership source: create missing teams, add
+members and change their roles, and grant repository permissions.
+
+The source is a YAML spec, a CSV roster or an LDAP export (LDIF):
+
+  prune_members: true          # remove members not listed
+  prune_repositories: false    # revoke repositories not listed
+  teams:
+    - name: platform
+      maintainers: [alice]
+      members: [bob, carol]
+      repositories:
+        infra: admin
+        api: push
+
+  team,user,role,repository,permission
+  platform,alice,maintainer,infra,admin
+  platform,bob,member,,
+
+Teams missing from the source are never deleted. Members and repository
+grants are only removed when pruning is enabled, which CSV and LDIF sources
+do with --prune-members and --prune-repositories. On GitLab, teams are the
+group's direct subgroups and repository permissions are project shares.
+Gitea teams have no maintainer role and one permission for all of their
+repositories: maintainers join as members and the team is raised to the
+highest permission it is granted.
+
+Every applied change is appended to a local audit log and shipped to the
+audit sinks of gzh.yaml when enabled. Tokens are read from GITHUB_TOKEN,
+GITLAB_TOKEN and GITEA_TOKEN.`,
+		Example: `  # Show the changes without making them
+  gz git org sync-teams --provider github --org myorg --spec teams.yaml --dry-run
+
+  # Apply an LDAP export, removing members who left
+  gz git org sync-teams --provider github --org myorg --spec groups.ldif --prune-members
+
+  # Sync a self-hosted Gitea organization
+  gz git org sync-teams --provider gitea --org infra --spec teams.yaml \
+    --base-url https://gitea.example.com/api/v1
+
+  # Check a self-hosted GitLab group in CI
+  gz git org sync-teams --provider gitlab --org platform --spec roster.csv \
+    --base-url https://gitlab.example.com/api/v4 --dry-run --fail-on-drift`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := teams.LoadSpec(opts.Spec, opts.Format)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("prune-members") {
+				spec.PruneMembers, _ = cmd.Flags().GetBool("prune-members")
+			}
+			if cmd.Flags().Changed("prune-repositories") {
+				spec.PruneRepositories, _ = cmd.Flags().GetBool("prune-repositories")
+			}
+			return runOrgSyncTeams(cmd.Context(), cmd.OutOrStdout(), opts, spec)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Provider, "provider", "github", "Git platform (github, gitlab, gitea)")
+	cmd.Flags().StringVar(&opts.Org, "org", "", "Organization or group")
+	cmd.Flags().StringVar(&opts.Spec, "spec", "", "Membership source (YAML, CSV or LDIF)")
+	cmd.Flags().StringVar(&opts.Format, "format", "", "Source format: yaml, csv, ldif (default: from the file extension)")
+	cmd.Flags().StringVar(&opts.BaseURL, "base-url", "", "API base URL (for self-hosted instances)")
+	cmd.Flags().StringSliceVar(&opts.Teams, "team", nil, "Only sync teams matching these patterns")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 4, "Number of teams processed at once")
+	cmd.Flags().Bool("prune-members", false, "Remove members not listed in the source (overrides the spec)")
+	cmd.Flags().Bool("prune-repositories", false, "Revoke repositories not listed in the source (overrides the spec)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the changes without making them")
+	cmd.Flags().StringVar(&opts.Report, "report", "", "Write the sync report (JSON) to this file")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print the report as JSON")
+	cmd.Flags().BoolVar(&opts.FailOnDrift, "fail-on-drift", false, "Exit with an error when any team differs from the source")
+	cmd.Flags().StringVar(&opts.AuditLog, "audit-log", "", "Local audit log (default: ~/.config/gzh-manager/teams/audit.jsonl)")
+
+	cmd.MarkFlagRequired("org")
+	cmd.MarkFlagRequired("spec")
+
+	return cmd
+}
+
+// runOrgSyncTeams reconciles the organization's teams with spec.
+func runOrgSyncTeams(ctx context.Context, out io.Writer, opts *SyncTeamsOptions, spec *teams.Spec) error {
+	envVar := strings.ToUpper(opts.Provider) + "_TOKEN"
+	token := getTokenFromEnv(envVar)
+	if token == "" {
+		return fmt.Errorf("%s is not set", envVar)
+	}
+	backend, err := teams.NewBackend(opts.Provider, opts.BaseURL, token)
+	if err != nil {
+		return err
+	}
+
+	syncOpts := teams.Options{
+		DryRun:      opts.DryRun,
+		Teams:       opts.Teams,
+		Concurrency: opts.Concurrency,
+		Actor:       currentUsername(),
+	}
+	if !opts.DryRun {
+		recorder, shipper := teamsRecorders(opts.AuditLog)
+		defer func() {
+			closeCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := shipper.Close(closeCtx); err != nil {
+				logger.SimpleWarn("Failed to deliver team sync audit events; they stay spooled for the next run", "error", err)
+			}
+		}()
+		syncOpts.Recorder = recorder
+	}
+
+	if !opts.JSON {
+		fmt.Fprintf(out, "👥 Syncing teams from %s to %s:%s\n", opts.Spec, opts.Provider, opts.Org)
+	}
+	report, runErr := teams.NewSyncer(backend, spec, syncOpts).Run(ctx, opts.Org)
+	if report == nil {
+		return runErr
+	}
+
+	if opts.JSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	} else {
+		if opts.DryRun {
+			report.PrintPlan(out)
+		} else {
+			report.PrintDiff(out)
+		}
+		report.PrintSummary(out)
+	}
+
+	if opts.Report != "" {
+		if err := report.WriteJSON(opts.Report); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		if !opts.JSON {
+			fmt.Fprintf(out, "Sync report written to %s\n", opts.Report)
+		}
+	}
+	if runErr != nil {
+		return runErr
+	}
+
+	if failed := report.Counts()[teams.StatusFailed]; failed > 0 {
+		return fmt.Errorf("%d team(s) could not be synced", failed)
+	}
+	if opts.FailOnDrift && opts.DryRun && report.HasDrift() {
+		return fmt.Errorf("%d team(s) differ from %s", report.Counts()[teams.StatusDrift], opts.Spec)
+	}
+	return nil
+}
+
+// teamsRecorders returns the local audit log, followed by the audit shipper
+// of gzh.yaml when shipping is enabled there. The shipper is nil otherwise;
+// closing a nil shipper is a no-op.
+func teamsRecorders(auditLog string) (audit.Recorder, *audit.Shipper) {
+	if auditLog == "" {
+		auditLog = teams.DefaultAuditLogPath()
+	}
+	recorders := audit.Recorders{archival.NewFileRecorder(auditLog)}
+
+	facade := pkgconfig.NewUnifiedConfigFacade()
+	if err := facade.LoadConfiguration(); err != nil {
+		return recorders, nil
+	}
+	shipper, err := audit.NewShipperFromConfig(facade.GetAuditConfig())
+	if err != nil {
+		logger.SimpleWarn("Audit shipping disabled for team sync", "error", err)
+		return recorders, nil
+	}
+	if shipper != nil {
+		recorders = append(recorders, shipper)
+	}
+	return recorders, shipper
+}
+
+func currentUsername() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
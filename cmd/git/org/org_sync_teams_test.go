@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGitOrgCmd(t *testing.T) {
+	cmd := NewGitOrgCmd()
+	assert.Equal(t, "org", cmd.Use)
+
+	syncCmd, _, err := cmd.Find([]string{"sync-teams"})
+	require.NoError(t, err)
+	for _, flag := range []string{"org", "spec", "format", "dry-run", "prune-members", "prune-repositories", "audit-log", "fail-on-drift"} {
+		assert.NotNil(t, syncCmd.Flags().Lookup(flag), flag)
+	}
+}
+
+func TestRunOrgSyncTeamsDryRun(t *testing.T) {
+	var mutations int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			mutations++
+		}
+		switch r.URL.Path {
+		case "/orgs/acme/teams":
+			fmt.Fprint(w, `[{"id":1,"name":"platform","slug":"platform"}]`)
+		case "/orgs/acme/teams/platform/members":
+			if r.URL.Query().Get("role") == "maintainer" {
+				fmt.Fprint(w, `[]`)
+				return
+			}
+			fmt.Fprint(w, `[{"login":"alice"}]`)
+		case "/orgs/acme/teams/platform/repos":
+			fmt.Fprint(w, `[]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("GITHUB_TOKEN", "token")
+
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "roster.csv")
+	require.NoError(t, os.WriteFile(specPath, []byte("team,user,role\nplatform,alice,maintainer\nplatform,bob,member\n"), 0o600))
+
+	cmd := newOrgSyncTeamsCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"--org", "acme", "--spec", specPath, "--base-url", server.URL,
+		"--dry-run", "--fail-on-drift", "--report", filepath.Join(dir, "report.json"),
+	})
+	err := cmd.ExecuteContext(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 team(s) differ")
+
+	assert.Zero(t, mutations, "a dry run must not change anything")
+	assert.Contains(t, out.String(), `~ team_member "platform/alice"`)
+	assert.Contains(t, out.String(), `+ team_member "platform/bob"`)
+	assert.FileExists(t, filepath.Join(dir, "report.json"))
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/gizzahub/gzh-cli/internal/journal"
 	"github.com/gizzahub/gzh-cli/pkg/git/provider"
 )
 
@@ -27,6 +28,13 @@ type ArchiveOptions struct {
 	// Operation mode
 	Unarchive bool
 
+	// Policy-driven lifecycle
+	Policy   string
+	BaseURL  string
+	State    string
+	AuditLog string
+	Report   string
+
 	// Safety options
 	DryRun bool
 	Force  bool
@@ -52,9 +60,33 @@ This command provides repository archival management including:
 - Unarchive operations to restore repository activity
 - Pattern matching for bulk operations
 - Dry run capability to preview changes
+- Policy-driven archival of inactive repositories (--policy)
 
 Archived repositories are read-only but preserve all git history, issues,
-pull requests, and other repository data.`,
+pull requests, and other repository data.
+
+With --policy, every repository of --org is moved one step through the
+archival lifecycle per run, so the command is meant to run on a schedule:
+
+  repositories:
+    exclude: ["infra-*"]
+    exempt_topics: [keep]        # repositories with these topics are never archived
+  inactive_months: 12            # no commits or pull requests for this long
+  grace_days: 30                 # time between the notice and the archival
+  notify:
+    method: issue                # issue, email or none
+    labels: [archival]
+    # email: {smtp: smtp.example.com:587, from: platform@example.com,
+    #         username: bot, password: ${SMTP_PASSWORD}, fallback: [team@example.com]}
+  backup:
+    url: s3://repo-archive/github  # optional bundle backup before archiving
+
+Owners of inactive repositories are notified first. A repository with new
+activity during the grace period has its notice withdrawn; otherwise it is
+backed up (when configured) and archived once the grace period ends. Notices
+are tracked in ~/.config/gzh-manager/archival, and every step is appended to
+an audit log there and shipped to the audit sinks of gzh.yaml when enabled.
+Policy mode supports GitHub and GitLab and reads GITHUB_TOKEN or GITLAB_TOKEN.`,
 		Example: `  # Archive a single repository
   gz git repo archive --provider github --repo myorg/oldproject
 
@@ -68,8 +100,15 @@ pull requests, and other repository data.`,
   gz git repo archive --provider github --repo myorg/project --unarchive
 
   # Dry run to preview archival
-  gz git repo archive --provider github --org myorg --match "old-*" --dry-run`,
+  gz git repo archive --provider github --org myorg --match "old-*" --dry-run
+
+  # Preview, then apply an archival policy
+  gz git repo archive --provider github --org myorg --policy archival.yaml --dry-run
+  gz git repo archive --provider github --org myorg --policy archival.yaml --report archival.json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Policy != "" {
+				return runRepoArchivePolicy(cmd.Context(), cmd.OutOrStdout(), opts)
+			}
 			return runRepoArchive(cmd.Context(), opts)
 		},
 	}
@@ -85,6 +124,13 @@ pull requests, and other repository data.`,
 	// Operation mode
 	cmd.Flags().BoolVar(&opts.Unarchive, "unarchive", false, "Unarchive instead of archive")
 
+	// Policy-driven lifecycle
+	cmd.Flags().StringVar(&opts.Policy, "policy", "", "Archive inactive repositories of --org according to this policy file (YAML)")
+	cmd.Flags().StringVar(&opts.BaseURL, "base-url", "", "API base URL for self-hosted instances (with --policy)")
+	cmd.Flags().StringVar(&opts.State, "state", "", "Notice state file (with --policy, default: ~/.config/gzh-manager/archival/<provider>-<org>.json)")
+	cmd.Flags().StringVar(&opts.AuditLog, "audit-log", "", "Local audit log (with --policy, default: ~/.config/gzh-manager/archival/audit.jsonl)")
+	cmd.Flags().StringVar(&opts.Report, "report", "", "Write the archival report (JSON) to this file (with --policy)")
+
 	// Safety options
 	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Preview without archiving")
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Skip confirmation prompts")
@@ -98,7 +144,16 @@ pull requests, and other repository data.`,
 
 	// Validation rules
 	cmd.MarkFlagsMutuallyExclusive("repo", "match")
+	cmd.MarkFlagsMutuallyExclusive("policy", "repo")
+	cmd.MarkFlagsMutuallyExclusive("policy", "match")
+	cmd.MarkFlagsMutuallyExclusive("policy", "unarchive")
 	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if opts.Policy != "" {
+			if opts.Org == "" {
+				return fmt.Errorf("--org is required when using --policy")
+			}
+			return nil
+		}
 		if len(opts.Repos) == 0 && opts.Match == "" {
 			return fmt.Errorf("either --repo or --match must be specified")
 		}
@@ -334,10 +389,13 @@ func (opts *ArchiveOptions) executeOperation(ctx context.Context, gitProvider pr
 
 	operation := "Archiving"
 	emoji := "📦"
+	kind := journal.KindRepoArchive
 	if opts.Unarchive {
 		operation = "Unarchiving"
 		emoji = "🔓"
+		kind = journal.KindRepoUnarchive
 	}
+	op := startJournal("gz git repo archive")
 
 	for i, repo := range repos {
 		if !opts.Quiet {
@@ -360,6 +418,9 @@ func (opts *ArchiveOptions) executeOperation(ctx context.Context, gitProvider pr
 			continue
 		}
 
+		journalRecord(op, kind, opts.Provider, repo.FullName,
+			repoJournalState{Org: repoOrg(opts.Org, repo), Repository: repo}, "")
+
 		successCount++
 		if !opts.Quiet {
 			fmt.Printf(" %s success\n", emoji)
@@ -378,6 +439,7 @@ func (opts *ArchiveOptions) executeOperation(ctx context.Context, gitProvider pr
 				fmt.Printf("  - %v\n", err)
 			}
 		}
+		printUndoHint(op)
 	}
 
 	if len(errors) > 0 {
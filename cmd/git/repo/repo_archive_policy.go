@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/archival"
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+	"github.com/gizzahub/gzh-cli/pkg/cloud"
+	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+// runRepoArchivePolicy moves the repositories of an organization one step
+// through the archival lifecycle of a policy.
+func runRepoArchivePolicy(ctx context.Context, out io.Writer, opts *ArchiveOptions) error {
+	if opts.Format != "table" && opts.Format != "json" {
+		return fmt.Errorf("invalid output format for --policy: %s (valid: table, json)", opts.Format)
+	}
+	policy, err := archival.LoadPolicy(opts.Policy)
+	if err != nil {
+		return err
+	}
+
+	envVar := strings.ToUpper(opts.Provider) + "_TOKEN"
+	token := getTokenFromEnv(envVar)
+	if token == "" {
+		return fmt.Errorf("%s is not set", envVar)
+	}
+	backend, err := archival.NewBackend(opts.Provider, opts.BaseURL, token)
+	if err != nil {
+		return err
+	}
+
+	statePath := opts.State
+	if statePath == "" {
+		statePath = archival.DefaultStatePath(opts.Provider, opts.Org)
+	}
+	state, err := archival.LoadState(statePath, opts.Provider, opts.Org)
+	if err != nil {
+		return err
+	}
+
+	recorder, shipper := archivalRecorders(opts.AuditLog)
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := shipper.Close(closeCtx); err != nil {
+			logger.SimpleWarn("Failed to deliver archival audit events; they stay spooled for the next run", "error", err)
+		}
+	}()
+
+	managerOpts := archival.Options{
+		DryRun:   opts.DryRun,
+		Recorder: recorder,
+		Actor:    currentUsername(),
+	}
+	if policy.Backup.URL != "" {
+		store, err := cloud.OpenObjectStore(ctx, policy.Backup.URL)
+		if err != nil {
+			return fmt.Errorf("failed to open backup store: %w", err)
+		}
+		username, password := backend.GitAuth()
+		managerOpts.Backuper = archival.NewBundleBackup(store, opts.Provider, username, password)
+	}
+
+	if opts.Format == "table" && !opts.Quiet {
+		fmt.Fprintf(out, "📦 Applying %s to %s:%s\n", opts.Policy, opts.Provider, opts.Org)
+	}
+	report, runErr := archival.NewManager(backend, policy, state, managerOpts).Run(ctx, opts.Org)
+	if report == nil {
+		return runErr
+	}
+
+	switch {
+	case opts.Format == "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	case !opts.Quiet:
+		report.PrintTable(out)
+		report.PrintSummary(out)
+	}
+
+	if opts.Report != "" {
+		if err := report.WriteJSON(opts.Report); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+	if runErr != nil {
+		return runErr
+	}
+	if failed := report.Counts()[archival.StatusFailed]; failed > 0 {
+		return fmt.Errorf("%d repositor(y/ies) could not be processed", failed)
+	}
+	return nil
+}
+
+// archivalRecorders returns the local audit log, followed by the audit
+// shipper of gzh.yaml when shipping is enabled there. The shipper is nil
+// otherwise; closing a nil shipper is a no-op.
+func archivalRecorders(auditLog string) (audit.Recorder, *audit.Shipper) {
+	if auditLog == "" {
+		auditLog = archival.DefaultAuditLogPath()
+	}
+	recorders := audit.Recorders{archival.NewFileRecorder(auditLog)}
+
+	facade := pkgconfig.NewUnifiedConfigFacade()
+	if err := facade.LoadConfiguration(); err != nil {
+		return recorders, nil
+	}
+	shipper, err := audit.NewShipperFromConfig(facade.GetAuditConfig())
+	if err != nil {
+		logger.SimpleWarn("Audit shipping disabled for archival", "error", err)
+		return recorders, nil
+	}
+	if shipper != nil {
+		recorders = append(recorders, shipper)
+	}
+	return recorders, shipper
+}
+
+func currentUsername() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
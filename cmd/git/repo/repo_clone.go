@@ -5,13 +5,20 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/gizzahub/gzh-cli/internal/git/clone"
+	"github.com/gizzahub/gzh-cli/internal/git/submodule"
+	"github.com/gizzahub/gzh-cli/internal/tui/repopicker"
+	"github.com/gizzahub/gzh-cli/internal/workerpool"
+	"github.com/gizzahub/gzh-cli/pkg/gerrit"
 	"github.com/gizzahub/gzh-cli/pkg/git/provider"
 	"github.com/gizzahub/gzh-cli/pkg/github"
 	"github.com/gizzahub/gzh-cli/pkg/gitlab"
@@ -20,6 +27,12 @@ import (
 // newRepoCloneCmd creates the git repo clone command.
 func newRepoCloneCmd() *cobra.Command {
 	opts := clone.DefaultCloneOptions()
+	var (
+		mirror       bool
+		includeLFS   bool
+		skipLFS      bool
+		lfsRateLimit string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "clone",
@@ -29,12 +42,18 @@ func newRepoCloneCmd() *cobra.Command {
 - Bulk operations for entire organizations/groups
 - Parallel execution with configurable workers
 - Resume capability for interrupted operations
-- Multiple clone strategies (reset, pull, fetch)
+- Multiple clone strategies (reset, pull, fetch, mirror)
+- Bare mirror backups with date-stamped archives and retention
 - Advanced filtering and matching
+- Interactive, fuzzy-searchable repository picker (--interactive)
 - Multiple output formats
+- Optional secret scanning of cloned repositories
+- Git LFS detection, with resumable and rate-limited object downloads
 
 This command uses the modern provider abstraction layer to support
-GitHub and GitLab platforms through a unified interface.
+GitHub, GitLab and Gerrit through a unified interface. For Gerrit,
+--org is a project name prefix, --base-url is the server and the
+commit-msg hook is installed into every clone.
 Gitea and Gogs providers are planned for future implementation.
 
 Note: This is an experimental command. For production use, consider
@@ -45,25 +64,80 @@ using the stable 'gz synclone' command.`,
   # Clone with filters and custom target
   gz git repo clone --provider gitlab --org mygroup --match "api-*" --target ./projects
 
+  # Clone active Go services that are not forks
+  gz git repo clone --provider github --org myorg \
+    --filter 'language == go and not fork and pushed < 180d and topic in [service, api]'
+
   # Clone with parallel workers and specific strategy
   gz git repo clone --provider github --org myorg --parallel 10 --strategy pull
 
+  # Clone every Gerrit project below platform/ with the commit-msg hook
+  gz git repo clone --provider gerrit --base-url https://review.example.com --org platform/ \
+    --username alice --token "$GERRIT_HTTP_PASSWORD"
+
+  # Attempt repositories quarantined for failing repeatedly now
+  gz git repo clone --provider github --org myorg --retry-quarantined
+
   # Resume interrupted operation
   gz git repo clone --resume abc12345
 
+  # Pick repositories from a searchable list instead of writing filters
+  gz git repo clone --provider github --org myorg --interactive
+
   # Dry run to preview what would be cloned
   gz git repo clone --provider github --org myorg --dry-run
 
   # Clone private repos only with SSH protocol
-  gz git repo clone --provider github --org myorg --visibility private --protocol ssh`,
+  gz git repo clone --provider github --org myorg --visibility private --protocol ssh
+
+  # Scan clones for credentials and fail on high-severity hits
+  gz git repo clone --provider github --org myorg --scan-secrets --fail-on-secrets high --secrets-report secrets.json
+
+  # Keep bare mirrors and write daily archives, keeping 30 per repository
+  gz git repo clone --provider github --org myorg --mirror --target /backup/mirrors \
+    --archive-root /backup/archives --keep-archives 30
+
+  # Download Git LFS objects, capped at 20 MB/s across all workers
+  gz git repo clone --provider github --org myorg --include-lfs --lfs-rate-limit 20MB
+
+  # Shallow submodules, without the docs submodule and with vendor/lib pinned to a tag
+  gz git repo clone --provider github --org myorg --submodules shallow \
+    --skip-submodule docs --pin-submodule vendor/lib=v1.4.0
+
+  # Share objects with other clones of the same repositories and their forks
+  gz git repo clone --provider github --org myorg --include-forks --object-cache
+
+  # Stream one JSON event per repository into jq
+  gz git repo clone --provider github --org myorg --format ndjson | jq 'select(.type == "fail")'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if mirror {
+				opts.Strategy = clone.StrategyMirror
+			}
+			// A filter expression decides about archived repositories itself
+			if opts.Filter != "" && !cmd.Flags().Changed("include-archived") {
+				opts.IncludeArchived = true
+			}
+			switch {
+			case includeLFS:
+				opts.LFS = clone.LFSInclude
+			case skipLFS:
+				opts.LFS = clone.LFSSkip
+			}
+			if lfsRateLimit != "" {
+				limit, err := workerpool.ParseByteSize(lfsRateLimit)
+				if err != nil {
+					return fmt.Errorf("invalid --lfs-rate-limit: %w", err)
+				}
+				opts.LFSRateLimit = limit
+			}
 			return runRepoClone(cmd.Context(), opts)
 		},
 	}
 
 	// Required flags
-	cmd.Flags().StringVar(&opts.Provider, "provider", "", "Git provider (github, gitlab, gitea, gogs)")
-	cmd.Flags().StringVar(&opts.Org, "org", "", "Organization/Group name")
+	cmd.Flags().StringVar(&opts.Provider, "provider", "", "Git provider (github, gitlab, gitea, gogs, gerrit)")
+	cmd.Flags().StringVar(&opts.Org, "org", "", "Organization/Group name (project prefix for Gerrit)")
+	cmd.Flags().StringVar(&opts.BaseURL, "base-url", "", "Server URL for self-hosted instances (required for Gerrit)")
 
 	// Target and configuration
 	cmd.Flags().StringVar(&opts.Target, "target", ".", "Target directory for cloned repositories")
@@ -81,6 +155,7 @@ using the stable 'gz synclone' command.`,
 	// Filtering options
 	cmd.Flags().StringVar(&opts.Match, "match", "", "Repository name pattern (regex)")
 	cmd.Flags().StringVar(&opts.Exclude, "exclude", "", "Repository exclusion pattern (regex)")
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter expression, e.g. 'language == go and not archived' (see 'gz git repo explain-filter')")
 	cmd.Flags().StringVar(&opts.Visibility, "visibility", "all", "Repository visibility (all, public, private)")
 	cmd.Flags().BoolVar(&opts.IncludeArchived, "include-archived", false, "Include archived repositories")
 	cmd.Flags().BoolVar(&opts.IncludeForks, "include-forks", true, "Include forked repositories")
@@ -89,6 +164,7 @@ using the stable 'gz synclone' command.`,
 	cmd.Flags().IntVar(&opts.MinStars, "min-stars", 0, "Minimum star count")
 	cmd.Flags().IntVar(&opts.MaxStars, "max-stars", 0, "Maximum star count (0 = unlimited)")
 	cmd.Flags().StringVar(&opts.UpdatedSince, "updated-since", "", "Only repos updated since date (YYYY-MM-DD)")
+	cmd.Flags().BoolVarP(&opts.Interactive, "interactive", "i", false, "Pick repositories from a searchable list after filtering")
 
 	// Output and behavior
 	cmd.Flags().StringVar(&opts.Format, "format", string(clone.FormatProgress),
@@ -98,6 +174,8 @@ using the stable 'gz synclone' command.`,
 	cmd.Flags().BoolVar(&opts.Verbose, "verbose", false, "Verbose output")
 	cmd.Flags().BoolVar(&opts.CleanupOrphans, "cleanup-orphans", false, "Remove directories not in organization")
 	cmd.Flags().BoolVar(&opts.CreateGZHFile, "create-gzh-file", true, "Create .gzh metadata files")
+	cmd.Flags().BoolVar(&opts.Quarantine, "quarantine", true, "Skip repositories that keep failing (see 'gz git repo quarantine')")
+	cmd.Flags().BoolVar(&opts.RetryQuarantined, "retry-quarantined", false, "Attempt quarantined repositories in this run")
 
 	// Authentication
 	cmd.Flags().StringVar(&opts.Token, "token", "", "Authentication token")
@@ -109,13 +187,43 @@ using the stable 'gz synclone' command.`,
 	cmd.Flags().IntVar(&opts.Depth, "depth", 0, "Clone depth (0 = full clone)")
 	cmd.Flags().BoolVar(&opts.SingleBranch, "single-branch", false, "Clone single branch only")
 	cmd.Flags().StringVar(&opts.Branch, "branch", "", "Specific branch to clone")
+	cmd.Flags().BoolVar(&opts.ObjectCache, "object-cache", false, "Share objects between clones of a repository and its forks (see 'gz git cache')")
+	cmd.Flags().StringVar(&opts.ObjectCacheDir, "object-cache-dir", "", "Object cache directory (default ~/.gzh/cache/git-objects)")
+
+	// Secret scanning
+	cmd.Flags().BoolVar(&opts.ScanSecrets, "scan-secrets", false, "Scan cloned repositories for leaked credentials")
+	cmd.Flags().StringVar(&opts.SecretsConfig, "secrets-config", "", "Secret scanning rules and allowlist (YAML)")
+	cmd.Flags().StringVar(&opts.SecretsReport, "secrets-report", "", "Write secret scan findings (JSON) to this file")
+	cmd.Flags().StringVar(&opts.FailOnSecrets, "fail-on-secrets", "", "Fail when findings reach this severity (low, medium, high, critical)")
+
+	// Mirror backups
+	cmd.Flags().BoolVar(&mirror, "mirror", false, "Keep bare mirror clones with all refs (same as --strategy mirror)")
+	cmd.Flags().StringVar(&opts.ArchiveRoot, "archive-root", "", "Write date-stamped archives of each mirror below this directory")
+	cmd.Flags().StringVar(&opts.ArchiveFormat, "archive-format", "", "Archive compression (zst, gz, lz4; default from global.compression.archive, else zst)")
+	cmd.Flags().IntVar(&opts.KeepArchives, "keep-archives", 0, "Keep only the N newest archives per repository (0 = all)")
+	cmd.Flags().DurationVar(&opts.MaxArchiveAge, "max-archive-age", 0, "Remove archives older than this, e.g. 2160h (0 = never)")
+
+	// Git LFS
+	cmd.Flags().BoolVar(&includeLFS, "include-lfs", false, "Download Git LFS objects after cloning or updating")
+	cmd.Flags().BoolVar(&skipLFS, "skip-lfs", false, "Skip Git LFS detection and downloads")
+	cmd.Flags().StringVar(&lfsRateLimit, "lfs-rate-limit", "", "Cap combined LFS download rate per second, e.g. 20MB (default unlimited)")
+
+	// Submodules
+	cmd.Flags().StringVar(&opts.Submodules, "submodules", string(submodule.StrategyRecursive),
+		fmt.Sprintf("Submodule strategy (%s)", strings.Join(submodule.Strategies(), ", ")))
+	cmd.Flags().IntVar(&opts.SubmoduleJobs, "submodule-jobs", submodule.DefaultJobs, "Submodules updated at once across all workers")
+	cmd.Flags().StringSliceVar(&opts.SkipSubmodules, "skip-submodule", nil, "Leave a submodule uninitialized, by path, name or glob (repeatable)")
+	cmd.Flags().StringToStringVar(&opts.PinSubmodules, "pin-submodule", nil, "Check out PATH=REF instead of the recorded commit (repeatable)")
 
 	// Flag validations and relationships
 	cmd.MarkFlagRequired("provider")
 	cmd.MarkFlagRequired("org")
 	cmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+	cmd.MarkFlagsMutuallyExclusive("mirror", "strategy")
+	cmd.MarkFlagsMutuallyExclusive("include-lfs", "skip-lfs")
 	cmd.MarkFlagsMutuallyExclusive("resume", "provider")
 	cmd.MarkFlagsMutuallyExclusive("resume", "org")
+	cmd.MarkFlagsMutuallyExclusive("resume", "interactive")
 
 	return cmd
 }
@@ -127,18 +235,49 @@ func runRepoClone(ctx context.Context, opts *clone.CloneOptions) error {
 		return runResumeClone(ctx, opts)
 	}
 
+	gitProvider, err := newCloneProvider(opts)
+	if err != nil {
+		return err
+	}
+
+	// Create clone executor
+	executor, err := clone.NewCloneExecutor(gitProvider, opts)
+	if err != nil {
+		return fmt.Errorf("failed to create clone executor: %w", err)
+	}
+
+	if opts.Interactive {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("--interactive requires a terminal")
+		}
+		title := fmt.Sprintf("Select repositories to clone from %s/%s", opts.Provider, opts.Org)
+		executor.SetSelector(clone.InteractiveSelector(title, os.Stdin, os.Stderr))
+	}
+
+	// Execute clone operation
+	err = executor.Execute(ctx)
+	if errors.Is(err, repopicker.ErrCancelled) {
+		fmt.Fprintln(os.Stderr, "Nothing cloned: selection cancelled")
+		return nil
+	}
+	return err
+}
+
+// newCloneProvider creates the provider the clone options point at.
+func newCloneProvider(opts *clone.CloneOptions) (provider.GitProvider, error) {
 	// Create provider factory
 	factory := provider.NewProviderFactory()
 
 	// Register provider constructors
 	if err := registerProviderConstructors(factory); err != nil {
-		return fmt.Errorf("failed to register providers: %w\n\nNote: For production use, consider using the 'gz synclone' command which is fully stable:\n  gz synclone --config examples/synclone/synclone-example.yaml", err)
+		return nil, fmt.Errorf("failed to register providers: %w\n\nNote: For production use, consider using the 'gz synclone' command which is fully stable:\n  gz synclone --config examples/synclone/synclone-example.yaml", err)
 	}
 
 	// Create provider configuration
 	providerConfig := &provider.ProviderConfig{
 		Type:     opts.Provider,
 		Name:     fmt.Sprintf("%s-clone", opts.Provider),
+		BaseURL:  opts.BaseURL,
 		Token:    opts.Token,
 		Username: opts.Username,
 		Password: opts.Password,
@@ -148,7 +287,7 @@ func runRepoClone(ctx context.Context, opts *clone.CloneOptions) error {
 
 	// Register configuration
 	if err := factory.RegisterConfig(providerConfig.Name, providerConfig); err != nil {
-		return fmt.Errorf("failed to register provider config: %w", err)
+		return nil, fmt.Errorf("failed to register provider config: %w", err)
 	}
 
 	// Create provider registry
@@ -161,17 +300,10 @@ func runRepoClone(ctx context.Context, opts *clone.CloneOptions) error {
 	// Get provider instance
 	gitProvider, err := registry.GetProvider(providerConfig.Name)
 	if err != nil {
-		return fmt.Errorf("failed to get provider: %w", err)
-	}
-
-	// Create clone executor
-	executor, err := clone.NewCloneExecutor(gitProvider, opts)
-	if err != nil {
-		return fmt.Errorf("failed to create clone executor: %w", err)
+		return nil, fmt.Errorf("failed to get provider: %w", err)
 	}
 
-	// Execute clone operation
-	return executor.Execute(ctx)
+	return gitProvider, nil
 }
 
 // runResumeClone handles resuming a clone operation.
@@ -201,6 +333,9 @@ func runResumeClone(ctx context.Context, opts *clone.CloneOptions) error {
 	if opts.Verbose {
 		originalOpts.Verbose = opts.Verbose
 	}
+	if opts.RetryQuarantined {
+		originalOpts.RetryQuarantined = opts.RetryQuarantined
+	}
 
 	// Set resume flag
 	originalOpts.Resume = opts.Resume
@@ -234,6 +369,11 @@ func registerProviderConstructors(factory *provider.ProviderFactory) error {
 		return fmt.Errorf("failed to register Gitea provider: %w", err)
 	}
 
+	// Register Gerrit provider
+	if err := gerrit.RegisterGerritProvider(factory); err != nil {
+		return fmt.Errorf("failed to register Gerrit provider: %w", err)
+	}
+
 	// Register Gogs provider
 	if err := factory.RegisterProvider("gogs", func(config *provider.ProviderConfig) (provider.GitProvider, error) {
 		// This would call gogs.CreateGogsProvider(config) when implemented
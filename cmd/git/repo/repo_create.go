@@ -15,6 +15,7 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/gizzahub/gzh-cli/internal/git/repotemplate"
 	"github.com/gizzahub/gzh-cli/pkg/git/provider"
 )
 
@@ -30,6 +31,13 @@ type CreateOptions struct {
 	Private     bool
 	Template    string
 
+	// Declarative template options (see internal/git/repotemplate)
+	FromTemplate string
+	TemplateDir  string
+	Vars         map[string]string
+	BaseURL      string
+	DryRun       bool
+
 	// Initialization options
 	AutoInit          bool
 	GitignoreTemplate string
@@ -73,6 +81,8 @@ customizable settings including visibility, templates, and initialization option
 
 This command provides comprehensive repository creation capabilities including:
 - Template-based repository creation
+- Declarative templates (--from-template) that bootstrap files, CODEOWNERS,
+  labels, webhooks, topics and branch protection on any supported platform
 - Advanced repository settings and permissions
 - Automatic initialization with README, gitignore, and license
 - Support for all major Git platforms`,
@@ -82,10 +92,21 @@ This command provides comprehensive repository creation capabilities including:
   # Create from template
   gz git repo create --provider github --template myorg/template-repo --name myapp
 
+  # Create from a declarative template in ~/.config/gzh-manager/templates/go-service
+  gz git repo create --provider github --org myorg --name billing \
+    --from-template go-service --var team=payments --dry-run
+
+  # Use a template kept in a git repository
+  gz git repo create --provider gitlab --org mygroup --name api \
+    --from-template https://github.com/myorg/repo-templates.git#go-service
+
   # Create with full options
   gz git repo create --provider gitlab --org mygroup --name api \
     --private --description "API service" --auto-init --license MIT
 
+  # Preview the settings a repository would be created with
+  gz git repo create --provider github --org myorg --name newrepo --private --dry-run
+
   # Create with topics and homepage
   gz git repo create --provider github --org myorg --name webapp \
     --description "Web application" --homepage "https://example.com" \
@@ -104,6 +125,11 @@ This command provides comprehensive repository creation capabilities including:
 	cmd.Flags().StringVar(&opts.Description, "description", "", "Repository description")
 	cmd.Flags().BoolVar(&opts.Private, "private", false, "Create as private repository")
 	cmd.Flags().StringVar(&opts.Template, "template", "", "Template repository (org/repo)")
+	cmd.Flags().StringVar(&opts.FromTemplate, "from-template", "", "Declarative template: name, directory or git URL (url#subdir)")
+	cmd.Flags().StringVar(&opts.TemplateDir, "template-dir", repotemplate.DefaultDir(), "Directory containing named templates")
+	cmd.Flags().StringToStringVar(&opts.Vars, "var", nil, "Template variable (name=value, repeatable)")
+	cmd.Flags().StringVar(&opts.BaseURL, "base-url", "", "API base URL for self-hosted instances (with --from-template)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be created without creating anything")
 
 	// Initialization options
 	cmd.Flags().BoolVar(&opts.AutoInit, "auto-init", true, "Initialize with README")
@@ -142,6 +168,10 @@ func runRepoCreate(ctx context.Context, opts *CreateOptions) error {
 		return fmt.Errorf("invalid options: %w", err)
 	}
 
+	if opts.FromTemplate != "" {
+		return runRepoCreateFromTemplate(ctx, opts)
+	}
+
 	// Get provider
 	gitProvider, err := getGitProvider(opts.Provider, opts.Org)
 	if err != nil {
@@ -151,6 +181,17 @@ func runRepoCreate(ctx context.Context, opts *CreateOptions) error {
 	// Create repository request
 	request := opts.toCreateRequest()
 
+	if opts.DryRun {
+		plan := provider.NewPlan()
+		if _, err := provider.NewDryRunProvider(gitProvider, plan).CreateRepository(ctx, request); err != nil {
+			return fmt.Errorf("failed to plan repository creation: %w", err)
+		}
+		if opts.Quiet {
+			return nil
+		}
+		return outputPlan(os.Stdout, plan, fmt.Sprintf("create %s/%s on %s", opts.Org, opts.Name, opts.Provider), opts.Format)
+	}
+
 	// Execute creation
 	repo, err := gitProvider.CreateRepository(ctx, request)
 	if err != nil {
@@ -184,6 +225,10 @@ func (opts *CreateOptions) Validate() error {
 		return fmt.Errorf("invalid repository name: %w", err)
 	}
 
+	if opts.Template != "" && opts.FromTemplate != "" {
+		return fmt.Errorf("--template and --from-template cannot be combined")
+	}
+
 	// Validate template format if provided
 	if opts.Template != "" {
 		if !strings.Contains(opts.Template, "/") {
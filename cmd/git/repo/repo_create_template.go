@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/internal/git/protect"
+	"github.com/gizzahub/gzh-cli/internal/git/repotemplate"
+)
+
+// runRepoCreateFromTemplate creates a repository from a declarative template.
+func runRepoCreateFromTemplate(ctx context.Context, opts *CreateOptions) error {
+	tmpl, cleanup, err := repotemplate.Resolve(ctx, opts.FromTemplate, opts.TemplateDir)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	plan, err := tmpl.Render(repotemplate.Target{Provider: opts.Provider, Org: opts.Org, Name: opts.Name}, opts.Vars)
+	if err != nil {
+		return fmt.Errorf("failed to render template %s: %w", tmpl.Name, err)
+	}
+
+	token := getTokenFromEnv(strings.ToUpper(opts.Provider) + "_TOKEN")
+	if token == "" && !opts.DryRun {
+		return fmt.Errorf("authentication token required for %s (set %s_TOKEN environment variable)",
+			opts.Provider, strings.ToUpper(opts.Provider))
+	}
+
+	backend, err := repotemplate.NewBackend(opts.Provider, opts.BaseURL, token)
+	if err != nil {
+		return err
+	}
+	// Gitea has no protection backend; the applier reports those rules as skipped.
+	protection, _ := protect.NewBackend(opts.Provider, opts.BaseURL, token)
+
+	applier := repotemplate.NewApplier(backend, protection, repotemplate.Options{
+		DryRun:        opts.DryRun,
+		CommitMessage: fmt.Sprintf("Apply repository template %s", tmpl.Name),
+	})
+	result, err := applier.Apply(ctx, plan)
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+
+	if !opts.Quiet {
+		if err := outputTemplateResult(result, opts); err != nil {
+			return err
+		}
+	}
+	if result.Failed() {
+		return fmt.Errorf("repository %s was created but some template steps failed", result.Repository.FullName)
+	}
+	return nil
+}
+
+// outputTemplateResult prints the applied or planned template steps.
+func outputTemplateResult(result *repotemplate.Result, opts *CreateOptions) error {
+	switch opts.Format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case "yaml":
+		data, err := yaml.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal result as YAML: %w", err)
+		}
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if opts.DryRun {
+		fmt.Printf("\n🔍 Dry run: template %s for %s\n\n", opts.FromTemplate, result.Repository.FullName)
+	} else {
+		fmt.Printf("\n✅ Repository %s created from template %s\n\n", result.Repository.FullName, opts.FromTemplate)
+	}
+	result.Print(os.Stdout)
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/gizzahub/gzh-cli/internal/journal"
 	"github.com/gizzahub/gzh-cli/pkg/git/provider"
 )
 
@@ -124,7 +125,7 @@ func runRepoDelete(ctx context.Context, opts *DeleteOptions) error {
 	}
 
 	// Safety check for pattern matching
-	if opts.Match != "" && !opts.Force {
+	if opts.Match != "" && !opts.Force && !opts.DryRun {
 		return fmt.Errorf("pattern matching requires --force flag for safety")
 	}
 
@@ -149,7 +150,7 @@ func runRepoDelete(ctx context.Context, opts *DeleteOptions) error {
 
 	// Dry run
 	if opts.DryRun {
-		return opts.showDryRun(repos)
+		return opts.showDryRun(ctx, gitProvider, repos)
 	}
 
 	// Confirmation prompt
@@ -241,25 +242,27 @@ func (opts *DeleteOptions) getTargetRepositories(ctx context.Context, gitProvide
 	return repos, nil
 }
 
-// showDryRun displays what would be deleted without actually deleting.
-func (opts *DeleteOptions) showDryRun(repos []provider.Repository) error {
-	if !opts.Quiet {
-		fmt.Printf("Dry run - would delete %d repositories:\n\n", len(repos))
-
-		for _, repo := range repos {
-			fmt.Printf("  ❌ %s\n", repo.FullName)
-			if repo.Description != "" {
-				fmt.Printf("     Description: %s\n", repo.Description)
-			}
-			fmt.Printf("     Private: %v, Archived: %v\n", repo.Private, repo.Archived)
-			fmt.Printf("     Last updated: %s\n", repo.UpdatedAt.Format("2006-01-02 15:04:05"))
-			fmt.Println()
+// showDryRun runs the deletion against a dry-run provider and prints the
+// resulting plan.
+func (opts *DeleteOptions) showDryRun(ctx context.Context, gitProvider provider.GitProvider, repos []provider.Repository) error {
+	plan := provider.NewPlan()
+	dryRun := provider.NewDryRunProvider(gitProvider, plan)
+	for _, repo := range repos {
+		if err := dryRun.DeleteRepository(ctx, repo.ID); err != nil {
+			return fmt.Errorf("failed to plan deletion of %s: %w", repo.FullName, err)
 		}
-
-		fmt.Printf("Total repositories to delete: %d\n", len(repos))
-		fmt.Println("\nUse --force to skip confirmation when ready to proceed.")
+	}
+	if opts.Quiet {
+		return nil
 	}
 
+	title := fmt.Sprintf("delete %d repositories on %s", len(repos), opts.Provider)
+	if err := outputPlan(os.Stdout, plan, title, opts.Format); err != nil {
+		return err
+	}
+	if opts.Format == "table" {
+		fmt.Println("\nRun again without --dry-run to delete them.")
+	}
 	return nil
 }
 
@@ -267,6 +270,7 @@ func (opts *DeleteOptions) showDryRun(repos []provider.Repository) error {
 func (opts *DeleteOptions) deleteRepositories(ctx context.Context, gitProvider provider.GitProvider, repos []provider.Repository) error {
 	var errors []error
 	successCount := 0
+	op := startJournal("gz git repo delete")
 
 	for i, repo := range repos {
 		if !opts.Quiet {
@@ -274,14 +278,19 @@ func (opts *DeleteOptions) deleteRepositories(ctx context.Context, gitProvider p
 		}
 
 		// Create backup if requested
+		var backupPath string
 		if opts.Backup {
-			if err := opts.createBackup(ctx, gitProvider, &repo); err != nil {
+			var err error
+			if backupPath, err = opts.createBackup(ctx, gitProvider, &repo); err != nil {
 				if !opts.Quiet {
 					fmt.Printf(" backup failed: %v\n", err)
 				}
 				errors = append(errors, fmt.Errorf("backup failed for %s: %w", repo.FullName, err))
 				continue
 			}
+			if abs, err := filepath.Abs(backupPath); err == nil {
+				backupPath = abs
+			}
 			if !opts.Quiet {
 				fmt.Print(" backed up...")
 			}
@@ -296,6 +305,9 @@ func (opts *DeleteOptions) deleteRepositories(ctx context.Context, gitProvider p
 			continue
 		}
 
+		journalRecord(op, journal.KindRepoDelete, opts.Provider, repo.FullName,
+			repoJournalState{Org: repoOrg(opts.Org, repo), Repository: repo}, backupPath)
+
 		successCount++
 		if !opts.Quiet {
 			fmt.Printf(" ✅ deleted\n")
@@ -314,6 +326,7 @@ func (opts *DeleteOptions) deleteRepositories(ctx context.Context, gitProvider p
 				fmt.Printf("  - %v\n", err)
 			}
 		}
+		printUndoHint(op)
 	}
 
 	if len(errors) > 0 {
@@ -323,15 +336,16 @@ func (opts *DeleteOptions) deleteRepositories(ctx context.Context, gitProvider p
 	return nil
 }
 
-// createBackup creates a backup of the repository before deletion.
-func (opts *DeleteOptions) createBackup(ctx context.Context, gitProvider provider.GitProvider, repo *provider.Repository) error {
+// createBackup creates a backup of the repository before deletion and
+// returns its path.
+func (opts *DeleteOptions) createBackup(ctx context.Context, gitProvider provider.GitProvider, repo *provider.Repository) (string, error) {
 	if opts.BackupPath == "" {
-		return fmt.Errorf("backup path is required when backup is enabled")
+		return "", fmt.Errorf("backup path is required when backup is enabled")
 	}
 
 	// Create backup directory if it doesn't exist
 	if err := os.MkdirAll(opts.BackupPath, 0o755); err != nil {
-		return fmt.Errorf("failed to create backup directory %s: %w", opts.BackupPath, err)
+		return "", fmt.Errorf("failed to create backup directory %s: %w", opts.BackupPath, err)
 	}
 
 	// Generate backup filename with timestamp
@@ -352,7 +366,7 @@ func (opts *DeleteOptions) createBackup(ctx context.Context, gitProvider provide
 }
 
 // createCloneBackup creates a backup by cloning the repository
-func (opts *DeleteOptions) createCloneBackup(ctx context.Context, repo *provider.Repository, backupName string) error {
+func (opts *DeleteOptions) createCloneBackup(ctx context.Context, repo *provider.Repository, backupName string) (string, error) {
 	backupPath := filepath.Join(opts.BackupPath, backupName)
 
 	fmt.Printf("📦 Creating clone backup: %s\n", backupPath)
@@ -360,7 +374,7 @@ func (opts *DeleteOptions) createCloneBackup(ctx context.Context, repo *provider
 	// Use git clone command to create backup
 	cmd := exec.CommandContext(ctx, "git", "clone", "--mirror", repo.CloneURL, backupPath)
 	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to clone repository for backup: %w\nOutput: %s", err, string(output))
+		return "", fmt.Errorf("failed to clone repository for backup: %w\nOutput: %s", err, string(output))
 	}
 
 	// Create metadata file
@@ -378,22 +392,22 @@ func (opts *DeleteOptions) createCloneBackup(ctx context.Context, repo *provider
 	}
 
 	fmt.Printf("✅ Clone backup completed: %s\n", backupPath)
-	return nil
+	return backupPath, nil
 }
 
 // createArchiveBackup creates a backup using git archive
-func (opts *DeleteOptions) createArchiveBackup(ctx context.Context, gitProvider provider.GitProvider, repo *provider.Repository, backupName string) error {
+func (opts *DeleteOptions) createArchiveBackup(ctx context.Context, gitProvider provider.GitProvider, repo *provider.Repository, backupName string) (string, error) {
 	// First clone to a temporary location
 	tempDir, err := os.MkdirTemp("", "git-archive-backup-")
 	if err != nil {
-		return fmt.Errorf("failed to create temporary directory: %w", err)
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
 	}
 	defer os.RemoveAll(tempDir)
 
 	// Clone repository
 	cloneCmd := exec.CommandContext(ctx, "git", "clone", repo.CloneURL, tempDir)
 	if output, err := cloneCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to clone repository for archive: %w\nOutput: %s", err, string(output))
+		return "", fmt.Errorf("failed to clone repository for archive: %w\nOutput: %s", err, string(output))
 	}
 
 	// Create archive
@@ -402,7 +416,7 @@ func (opts *DeleteOptions) createArchiveBackup(ctx context.Context, gitProvider
 
 	archiveCmd := exec.CommandContext(ctx, "git", "-C", tempDir, "archive", "--format=tar.gz", "--output", archivePath, "HEAD")
 	if output, err := archiveCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to create git archive: %w\nOutput: %s", err, string(output))
+		return "", fmt.Errorf("failed to create git archive: %w\nOutput: %s", err, string(output))
 	}
 
 	// Create metadata file
@@ -420,22 +434,22 @@ func (opts *DeleteOptions) createArchiveBackup(ctx context.Context, gitProvider
 	}
 
 	fmt.Printf("✅ Archive backup completed: %s\n", archivePath)
-	return nil
+	return archivePath, nil
 }
 
 // createBundleBackup creates a backup using git bundle
-func (opts *DeleteOptions) createBundleBackup(ctx context.Context, repo *provider.Repository, backupName string) error {
+func (opts *DeleteOptions) createBundleBackup(ctx context.Context, repo *provider.Repository, backupName string) (string, error) {
 	// First clone to a temporary location
 	tempDir, err := os.MkdirTemp("", "git-bundle-backup-")
 	if err != nil {
-		return fmt.Errorf("failed to create temporary directory: %w", err)
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
 	}
 	defer os.RemoveAll(tempDir)
 
 	// Clone repository
 	cloneCmd := exec.CommandContext(ctx, "git", "clone", repo.CloneURL, tempDir)
 	if output, err := cloneCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to clone repository for bundle: %w\nOutput: %s", err, string(output))
+		return "", fmt.Errorf("failed to clone repository for bundle: %w\nOutput: %s", err, string(output))
 	}
 
 	// Create bundle
@@ -444,7 +458,7 @@ func (opts *DeleteOptions) createBundleBackup(ctx context.Context, repo *provide
 
 	bundleCmd := exec.CommandContext(ctx, "git", "-C", tempDir, "bundle", "create", bundlePath, "--all")
 	if output, err := bundleCmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("failed to create git bundle: %w\nOutput: %s", err, string(output))
+		return "", fmt.Errorf("failed to create git bundle: %w\nOutput: %s", err, string(output))
 	}
 
 	// Create metadata file
@@ -462,7 +476,7 @@ func (opts *DeleteOptions) createBundleBackup(ctx context.Context, repo *provide
 	}
 
 	fmt.Printf("✅ Bundle backup completed: %s\n", bundlePath)
-	return nil
+	return bundlePath, nil
 }
 
 // BackupMetadata contains metadata about a repository backup
@@ -0,0 +1,187 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+)
+
+// DepsOptions contains options for dependency update automation.
+type DepsOptions struct {
+	Root        string
+	Policy      string
+	MaxSeverity string
+	Ecosystems  []string
+	OpenPRs     bool
+	Provider    string
+	BaseURL     string
+	Concurrency int
+	JSON        bool
+}
+
+// newRepoDepsCmd creates the repo deps command.
+func newRepoDepsCmd() *cobra.Command {
+	opts := &DepsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "deps [root]",
+		Short: "Find and bump outdated dependencies across cloned repositories",
+		Long: `Scan cloned repositories for outdated dependencies and optionally open
+pull requests that bump them.
+
+Every clone below the root directory (default: the current directory, in the
+<root>/<org>/<repo> layout of bulk clones) is checked for go.mod,
+package.json and requirements.txt. Latest versions come from the Go module
+proxy, the npm registry and PyPI. Updates are classified as patch, minor or
+major.
+
+With --open-prs, each repository gets one pull request per severity, on the
+branch gz/deps-<severity>, against its current branch. Clones must have no
+uncommitted changes. Only manifests are changed; lock files are left to CI.
+
+A policy file limits updates per organization:
+
+  default:
+    max_severity: minor
+    deny: ["github.com/legacy/*"]
+  orgs:
+    platform:
+      allow: ["github.com/platform/*", "react"]
+      max_severity: major
+
+Tokens are read from GITHUB_TOKEN, GITLAB_TOKEN and GITEA_TOKEN.`,
+		Example: `  # Report available updates below ./repos
+  gz git repo deps ./repos
+
+  # Only patch and minor updates of Go modules, as JSON
+  gz git repo deps ./repos --ecosystem go --max-severity minor --json
+
+  # Open pull requests following a per-organization policy
+  gz git repo deps ./repos --policy deps-policy.yaml --open-prs --provider github`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Root = "."
+			if len(args) > 0 {
+				opts.Root = args[0]
+			}
+			return runRepoDeps(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Policy, "policy", "", "Update policy file (YAML)")
+	cmd.Flags().StringVar(&opts.MaxSeverity, "max-severity", "major", "Most disruptive update to propose (patch, minor, major)")
+	cmd.Flags().StringSliceVar(&opts.Ecosystems, "ecosystem", nil, "Only check these ecosystems (go, npm, pip)")
+	cmd.Flags().BoolVar(&opts.OpenPRs, "open-prs", false, "Push update branches and open pull requests")
+	cmd.Flags().StringVar(&opts.Provider, "provider", "github", "Git platform for pull requests (github, gitlab, gitea)")
+	cmd.Flags().StringVar(&opts.BaseURL, "base-url", "", "API base URL (for self-hosted instances)")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 4, "Number of repositories processed at once")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print the results as JSON")
+
+	return cmd
+}
+
+// runRepoDeps plans the updates and opens pull requests when requested.
+func runRepoDeps(ctx context.Context, out io.Writer, opts *DepsOptions) error {
+	maxSeverity, err := depupdate.ParseSeverity(opts.MaxSeverity)
+	if err != nil {
+		return err
+	}
+	ecosystems := make([]depupdate.Ecosystem, 0, len(opts.Ecosystems))
+	for _, e := range opts.Ecosystems {
+		eco := depupdate.Ecosystem(strings.ToLower(e))
+		if eco != depupdate.EcosystemGo && eco != depupdate.EcosystemNPM && eco != depupdate.EcosystemPip {
+			return fmt.Errorf("unsupported ecosystem: %s (supported: go, npm, pip)", e)
+		}
+		ecosystems = append(ecosystems, eco)
+	}
+
+	policy := &depupdate.Policy{}
+	if opts.Policy != "" {
+		if policy, err = depupdate.LoadPolicy(opts.Policy); err != nil {
+			return err
+		}
+	}
+
+	var opener depupdate.PullRequestOpener
+	if opts.OpenPRs {
+		tokenVar := strings.ToUpper(opts.Provider) + "_TOKEN"
+		token := getTokenFromEnv(tokenVar)
+		if token == "" {
+			return fmt.Errorf("%s is not set", tokenVar)
+		}
+		if opener, err = depupdate.NewPullRequestOpener(opts.Provider, opts.BaseURL, token); err != nil {
+			return err
+		}
+	}
+
+	repos, err := depupdate.DiscoverRepositories(opts.Root)
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		return fmt.Errorf("no cloned repositories found below %s", opts.Root)
+	}
+	if !opts.JSON {
+		fmt.Fprintf(out, "🔍 Checking dependencies of %d repositories below %s\n", len(repos), opts.Root)
+	}
+
+	planner := &depupdate.Planner{
+		Resolver:    depupdate.NewRegistryResolver(),
+		Policy:      policy,
+		Ecosystems:  ecosystems,
+		Concurrency: opts.Concurrency,
+	}
+	results := planner.Plan(ctx, repos)
+
+	failed := 0
+	for i := range results {
+		r := &results[i]
+		r.Updates = filterSeverity(r.Updates, maxSeverity)
+		if opener == nil || len(r.Updates) == 0 {
+			continue
+		}
+		opened, err := depupdate.OpenPullRequests(ctx, r.Repository, r.Updates, opener)
+		r.PullRequests = opened
+		if err != nil {
+			r.Errors = append(r.Errors, err.Error())
+			failed++
+		}
+	}
+
+	if opts.JSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+	} else {
+		depupdate.PrintTable(out, results)
+		depupdate.PrintSummary(out, results)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("pull requests could not be opened for %d repositories", failed)
+	}
+	return nil
+}
+
+// filterSeverity drops updates more disruptive than limit.
+func filterSeverity(updates []depupdate.Update, limit depupdate.Severity) []depupdate.Update {
+	kept := updates[:0]
+	for _, u := range updates {
+		if u.Severity.AtMost(limit) {
+			kept = append(kept, u)
+		}
+	}
+	return kept
+}
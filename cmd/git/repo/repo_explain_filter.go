@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/git/clone"
+)
+
+// explainFilterColumns are the columns of `gz git repo explain-filter`.
+var explainFilterColumns = []cli.Column{
+	{Name: "repository"},
+	{Name: "result", NoTruncate: true},
+	{Name: "reason"},
+}
+
+// newRepoExplainFilterCmd creates the git repo explain-filter command.
+func newRepoExplainFilterCmd() *cobra.Command {
+	opts := clone.DefaultCloneOptions()
+	var (
+		table       cli.TableOptions
+		jsonOutput  bool
+		matchedOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "explain-filter",
+		Short: "Show which repositories a clone filter selects and why",
+		Long: `List the repositories of an organization and show, for each, whether
+'gz git repo clone' with the same filters would clone it and which
+condition decided.
+
+Filter expressions combine conditions with and, or, not and parentheses:
+
+  name, full_name, language, visibility   ==  !=  ~ (glob)  !~  =~ (regex)  in [..]
+  topic                                   same operators, true when any topic matches
+  archived, fork, private                 bare, or == true / == false
+  size                                    <  <=  >  >=  ==  != with B, KB, MB, GB units
+  stars                                   <  <=  >  >=  ==  !=
+  pushed                                  time since the last push: 12h, 90d, 6mo, 1y
+
+The same expression is accepted by 'gz git repo clone --filter', by the
+filter field of organizations and groups in gzh.yaml and by the filter
+parameter of bulk-clone jobs.`,
+		Example: `  # Why is a repository skipped?
+  gz git repo explain-filter --provider github --org myorg \
+    --filter 'language == go and not archived and pushed < 90d'
+
+  # Only list what would be cloned, as JSON
+  gz git repo explain-filter --provider gitlab --org mygroup \
+    --filter 'topic in [service, api] or name ~ "svc-*"' --matched --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := table.Validate(explainFilterColumns...); err != nil {
+				return err
+			}
+			if opts.Filter != "" && !cmd.Flags().Changed("include-archived") {
+				opts.IncludeArchived = true
+			}
+
+			gitProvider, err := newCloneProvider(opts)
+			if err != nil {
+				return err
+			}
+			executor, err := clone.NewCloneExecutor(gitProvider, opts)
+			if err != nil {
+				return fmt.Errorf("failed to create clone executor: %w", err)
+			}
+			matches, err := executor.ExplainFilters(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if matchedOnly {
+				kept := matches[:0]
+				for _, m := range matches {
+					if m.Matched {
+						kept = append(kept, m)
+					}
+				}
+				matches = kept
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(matches)
+			}
+			return printFilterMatches(cmd.OutOrStdout(), matches, table)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Provider, "provider", "", "Git provider (github, gitlab, gitea, gogs, gerrit)")
+	cmd.Flags().StringVar(&opts.Org, "org", "", "Organization/Group name (project prefix for Gerrit)")
+	cmd.Flags().StringVar(&opts.BaseURL, "base-url", "", "Server URL for self-hosted instances (required for Gerrit)")
+	cmd.Flags().StringVar(&opts.Token, "token", "", "Authentication token")
+	cmd.Flags().StringVar(&opts.Username, "username", "", "Username for authentication")
+	cmd.Flags().StringVar(&opts.Password, "password", "", "Password for authentication")
+
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter expression to explain")
+	cmd.Flags().StringVar(&opts.Match, "match", "", "Repository name pattern (regex)")
+	cmd.Flags().StringVar(&opts.Exclude, "exclude", "", "Repository exclusion pattern (regex)")
+	cmd.Flags().StringVar(&opts.Visibility, "visibility", "all", "Repository visibility (all, public, private)")
+	cmd.Flags().BoolVar(&opts.IncludeArchived, "include-archived", false, "Include archived repositories")
+	cmd.Flags().BoolVar(&opts.IncludeForks, "include-forks", true, "Include forked repositories")
+	cmd.Flags().StringVar(&opts.Language, "language", "", "Filter by primary language")
+	cmd.Flags().StringSliceVar(&opts.Topics, "topics", nil, "Filter by topics (comma-separated)")
+
+	cmd.Flags().BoolVar(&matchedOnly, "matched", false, "Only show repositories that would be cloned")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cli.AddTableFlags(cmd, &table, explainFilterColumns...)
+
+	cmd.MarkFlagRequired("provider")
+	cmd.MarkFlagRequired("org")
+
+	return cmd
+}
+
+// printFilterMatches writes one row per repository. The reason is the
+// option that rejected it, or the filter conditions that decided.
+func printFilterMatches(w io.Writer, matches []clone.FilterMatch, opts cli.TableOptions) error {
+	if len(matches) == 0 {
+		_, err := fmt.Fprintln(w, "No repositories found")
+		return err
+	}
+
+	t := cli.NewTable(explainFilterColumns...)
+	selected := 0
+	for _, m := range matches {
+		result := "✗ skip"
+		if m.Matched {
+			result = "✓ clone"
+			selected++
+		}
+		t.AddRow(m.Repository, result, filterReason(m))
+	}
+	if err := t.Render(w, opts); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d of %d repositories match\n", selected, len(matches))
+	return err
+}
+
+func filterReason(m clone.FilterMatch) string {
+	if m.RejectedBy != "" {
+		return "rejected by " + m.RejectedBy
+	}
+	if len(m.Steps) == 0 {
+		return "no filter"
+	}
+	parts := make([]string, len(m.Steps))
+	for i, s := range m.Steps {
+		mark := "✓"
+		if !s.Matched {
+			mark = "✗"
+		}
+		parts[i] = fmt.Sprintf("%s %s (%s)", mark, s.Condition, s.Value)
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/git/clone"
+	"github.com/gizzahub/gzh-cli/internal/git/repofilter"
+)
+
+func TestPrintFilterMatches(t *testing.T) {
+	matches := []clone.FilterMatch{
+		{Repository: "acme/api", Matched: true, Steps: []repofilter.Step{
+			{Condition: "language == go", Value: "Go", Matched: true},
+		}},
+		{Repository: "acme/web", Steps: []repofilter.Step{
+			{Condition: "language == go", Value: "TypeScript", Matched: false},
+		}},
+		{Repository: "acme/old", RejectedBy: "archived (--include-archived)"},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, printFilterMatches(&out, matches, cli.TableOptions{Width: 200}))
+	assert.Contains(t, out.String(), "✓ language == go (Go)")
+	assert.Contains(t, out.String(), "✗ language == go (TypeScript)")
+	assert.Contains(t, out.String(), "rejected by archived (--include-archived)")
+	assert.Contains(t, out.String(), "1 of 3 repositories match")
+
+	out.Reset()
+	require.NoError(t, printFilterMatches(&out, nil, cli.TableOptions{}))
+	assert.Equal(t, "No repositories found\n", out.String())
+}
@@ -4,16 +4,19 @@
 package repo
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 
+	"github.com/gizzahub/gzh-cli/internal/cli"
 	"github.com/gizzahub/gzh-cli/internal/git/sync"
+	"github.com/gizzahub/gzh-cli/internal/metrics"
 )
 
 // newRepoSyncCmd defines the sync command under repo.
 func newRepoSyncCmd() *cobra.Command {
-	var opts sync.SyncOptions
+	var (
+		opts         sync.SyncOptions
+		progressMode string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "sync",
@@ -36,8 +39,34 @@ func newRepoSyncCmd() *cobra.Command {
     --include-issues --include-wiki --include-releases
 
   # Dry run to preview changes
-  gz git repo sync --from github:org/repo --to gitlab:group/repo --dry-run`,
+  gz git repo sync --from github:org/repo --to gitlab:group/repo --dry-run
+
+  # Bidirectional sync, GitHub main <-> GitLab master, source wins on conflicts
+  gz git repo sync --from github:org/repo --to gitlab:group/repo \
+    --bidirectional --branch-map main:master --conflict-policy ours
+
+  # Merge diverged branches, resolving lockfiles with a custom merge driver
+  gz git repo sync --from github:org/repo --to gitlab:group/repo \
+    --bidirectional --conflict-policy merge --merge-drivers merge-drivers.yaml
+
+  # Refuse to mirror repositories that contain high-severity secrets
+  gz git repo sync --from github:org --to gitea:org --scan-secrets --fail-on-secrets high
+
+  # Queue diverged branches for manual resolution and preview the branch diff
+  gz git repo sync --from github:org --to gitlab:group --bidirectional --dry-run
+
+  # Air-gapped sync through incremental bundles in object storage
+  gz git repo sync export-bundles --from github:org --store s3://bucket/mirror
+  gz git repo sync apply-bundles --store s3://bucket/mirror --to gitea:org
+
+  # Push local working trees to a mirror remote as they change
+  gz git repo sync watch ~/src --remote mirror`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			progress, err := cli.ProgressForCommand(cmd, progressMode)
+			if err != nil {
+				return err
+			}
+			opts.Progress = progress
 			return runSync(cmd.Context(), opts)
 		},
 	}
@@ -51,6 +80,13 @@ func newRepoSyncCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&opts.UpdateExisting, "update-existing", true, "Update existing repositories")
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Force push (destructive)")
 
+	// Bidirectional options
+	cmd.Flags().BoolVar(&opts.Bidirectional, "bidirectional", false, "Sync branches in both directions for existing repositories")
+	cmd.Flags().StringVar(&opts.ConflictPolicy, "conflict-policy", "manual", "Resolution for diverged branches: ours, theirs, manual, merge")
+	cmd.Flags().StringSliceVar(&opts.BranchMappings, "branch-map", nil, "Branch mapping source:destination (supports trailing *), repeatable")
+	cmd.Flags().StringVar(&opts.ConflictQueue, "conflict-queue", "", "Manual conflict queue file (default ~/.config/gzh-manager/sync/conflicts.json)")
+	cmd.Flags().StringVar(&opts.MergeDrivers, "merge-drivers", "", "Merge driver configuration (YAML) for --conflict-policy merge")
+
 	// Include options
 	cmd.Flags().BoolVar(&opts.IncludeCode, "include-code", true, "Sync repository code")
 	cmd.Flags().BoolVar(&opts.IncludeIssues, "include-issues", false, "Sync issues")
@@ -67,29 +103,22 @@ func newRepoSyncCmd() *cobra.Command {
 	cmd.Flags().IntVar(&opts.Parallel, "parallel", 1, "Parallel sync workers")
 	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Preview without making changes")
 	cmd.Flags().BoolVar(&opts.Verbose, "verbose", false, "Verbose output")
+	cli.AddProgressFlag(cmd, &progressMode)
+
+	// Secret scanning
+	cmd.Flags().BoolVar(&opts.ScanSecrets, "scan-secrets", false, "Scan source code for leaked credentials before pushing")
+	cmd.Flags().StringVar(&opts.SecretsConfig, "secrets-config", "", "Secret scanning rules and allowlist (YAML)")
+	cmd.Flags().StringVar(&opts.SecretsReport, "secrets-report", "", "Write secret scan findings (JSON) to this file")
+	cmd.Flags().StringVar(&opts.FailOnSecrets, "fail-on-secrets", "", "Block pushes with findings of this severity or higher (low, medium, high, critical)")
 
 	cmd.MarkFlagRequired("from")
 	cmd.MarkFlagRequired("to")
 
-	return cmd
-}
+	metrics.AddFlag(cmd)
 
-func newRepoMigrateCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "migrate",
-		Short: "Migrate repositories between platforms",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return fmt.Errorf("migrate command not yet implemented")
-		},
-	}
-}
+	cmd.AddCommand(newRepoSyncExportCmd())
+	cmd.AddCommand(newRepoSyncApplyCmd())
+	cmd.AddCommand(newRepoSyncWatchCmd())
 
-func newRepoSearchCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "search",
-		Short: "Search repositories with advanced filtering",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			return fmt.Errorf("search command not yet implemented")
-		},
-	}
+	return cmd
 }
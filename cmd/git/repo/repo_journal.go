@@ -0,0 +1,240 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/git/protect"
+	"github.com/gizzahub/gzh-cli/internal/journal"
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+func init() {
+	journal.RegisterUndoer(journal.KindRepoDelete, undoRepoDelete)
+	journal.RegisterUndoer(journal.KindRepoArchive, undoRepoArchive)
+	journal.RegisterUndoer(journal.KindRepoUnarchive, undoRepoArchive)
+	journal.RegisterUndoer(journal.KindProtection, undoProtectionChange)
+}
+
+// repoJournalState is the journal state of a deleted, archived or
+// unarchived repository.
+type repoJournalState struct {
+	Org        string              `json:"org"`
+	Repository provider.Repository `json:"repository"`
+}
+
+// protectionJournalState is the journal state of a branch protection change.
+type protectionJournalState struct {
+	BaseURL    string             `json:"baseUrl,omitempty"`
+	Repository protect.Repository `json:"repository"`
+	Branch     string             `json:"branch"`
+	Before     protect.Protection `json:"before"`
+	After      protect.Protection `json:"after"`
+}
+
+// startJournal begins a journaled operation. The journal is a safety net, so
+// when it is unavailable the command still runs, without one.
+func startJournal(command string) *journal.Operation {
+	op, err := journal.Start(command)
+	if err != nil {
+		logger.SimpleWarn("Operation journal disabled; this run cannot be undone", "error", err)
+	}
+	return op
+}
+
+// journalRecord adds a record to op, warning when it cannot be saved.
+func journalRecord(op *journal.Operation, kind, providerName, target string, state any, backup string) {
+	if err := op.Add(kind, providerName, target, state, backup); err != nil {
+		logger.SimpleWarn("Failed to journal change; it cannot be undone", "target", target, "error", err)
+	}
+}
+
+// printUndoHint tells the user how to undo op, if it changed anything.
+func printUndoHint(op *journal.Operation) {
+	if op.Len() > 0 {
+		fmt.Printf("\n↩️  Undo with: gz undo %s\n", op.ID)
+	}
+}
+
+// repoOrg returns the organization a repository belongs to.
+func repoOrg(org string, repo provider.Repository) string {
+	if org != "" {
+		return org
+	}
+	if owner, _, ok := strings.Cut(repo.FullName, "/"); ok {
+		return owner
+	}
+	return repo.Owner.Login
+}
+
+// undoRepoDelete recreates a deleted repository with its recorded settings
+// and pushes the backup taken before the deletion back into it.
+func undoRepoDelete(ctx context.Context, rec journal.Record, opts journal.UndoOptions) (string, error) {
+	var state repoJournalState
+	if err := rec.Decode(&state); err != nil {
+		return "", err
+	}
+	repo := state.Repository
+	if opts.DryRun {
+		if rec.Backup == "" {
+			return "would recreate the repository (empty: no backup was taken)", nil
+		}
+		return "would recreate the repository from " + rec.Backup, nil
+	}
+
+	gitProvider, err := getGitProvider(rec.Provider, state.Org)
+	if err != nil {
+		return "", fmt.Errorf("failed to get provider: %w", err)
+	}
+	created, err := gitProvider.CreateRepository(ctx, provider.CreateRepoRequest{
+		Name:          repo.Name,
+		Description:   repo.Description,
+		Private:       repo.Private,
+		Visibility:    repo.Visibility,
+		DefaultBranch: repo.DefaultBranch,
+		Topics:        repo.Topics,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to recreate repository: %w", err)
+	}
+
+	var note string
+	switch {
+	case rec.Backup == "":
+		note = "recreated empty: no backup was taken, push the contents from a clone"
+	case strings.HasSuffix(rec.Backup, ".tar.gz"):
+		note = "recreated empty: archive backups hold no history, restore the files from " + rec.Backup
+	default:
+		if err := pushBackup(ctx, rec.Backup, created.CloneURL); err != nil {
+			return "", fmt.Errorf("repository recreated, but restoring %s failed: %w", rec.Backup, err)
+		}
+		note = "restored from " + rec.Backup
+	}
+
+	if repo.Archived {
+		if err := gitProvider.ArchiveRepository(ctx, created.ID); err != nil {
+			return "", fmt.Errorf("repository restored, but archiving it again failed: %w", err)
+		}
+	}
+	return note + "; issues, pull requests, webhooks and settings beyond the basics are not restored", nil
+}
+
+// pushBackup pushes every branch and tag of a mirror clone or bundle backup
+// to url. Pull request refs of mirror clones are left out, as platforms
+// reject pushes to them.
+func pushBackup(ctx context.Context, backup, url string) error {
+	source := backup
+	if strings.HasSuffix(backup, ".bundle") {
+		tempDir, err := os.MkdirTemp("", "gz-undo-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer os.RemoveAll(tempDir)
+
+		cmd := exec.CommandContext(ctx, "git", "clone", "--mirror", backup, tempDir)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to read bundle: %w\nOutput: %s", err, string(output))
+		}
+		source = tempDir
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "-C", source, "push", url,
+		"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to push backup: %w\nOutput: %s", err, string(output))
+	}
+	return nil
+}
+
+// undoRepoArchive unarchives an archived repository, or archives an
+// unarchived one again.
+func undoRepoArchive(ctx context.Context, rec journal.Record, opts journal.UndoOptions) (string, error) {
+	var state repoJournalState
+	if err := rec.Decode(&state); err != nil {
+		return "", err
+	}
+	unarchive := rec.Kind == journal.KindRepoArchive
+	action := "archive"
+	if unarchive {
+		action = "unarchive"
+	}
+	if opts.DryRun {
+		return "would " + action + " the repository", nil
+	}
+
+	gitProvider, err := getGitProvider(rec.Provider, state.Org)
+	if err != nil {
+		return "", fmt.Errorf("failed to get provider: %w", err)
+	}
+	current, err := gitProvider.GetRepository(ctx, state.Repository.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository: %w", err)
+	}
+	if current.Archived != unarchive {
+		return "already " + action + "d", nil
+	}
+
+	if unarchive {
+		err = gitProvider.UnarchiveRepository(ctx, state.Repository.ID)
+	} else {
+		err = gitProvider.ArchiveRepository(ctx, state.Repository.ID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to %s repository: %w", action, err)
+	}
+	return action + "d", nil
+}
+
+// undoProtectionChange puts back the branch protection from before a change.
+// A branch whose protection has changed again since is left alone unless
+// the undo is forced.
+func undoProtectionChange(ctx context.Context, rec journal.Record, opts journal.UndoOptions) (string, error) {
+	var state protectionJournalState
+	if err := rec.Decode(&state); err != nil {
+		return "", err
+	}
+
+	tokenVar := strings.ToUpper(rec.Provider) + "_TOKEN"
+	token := getTokenFromEnv(tokenVar)
+	if token == "" {
+		return "", fmt.Errorf("%s is not set", tokenVar)
+	}
+	backend, err := protect.NewBackend(rec.Provider, state.BaseURL, token)
+	if err != nil {
+		return "", err
+	}
+
+	current, err := backend.GetProtection(ctx, state.Repository, state.Branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to get protection: %w", err)
+	}
+	var drift []string
+	for _, c := range protect.Diff(current, state.After) {
+		if !slices.Contains(backend.Unsupported(), c.Setting) {
+			drift = append(drift, c.Setting)
+		}
+	}
+	if len(drift) > 0 && !opts.Force {
+		return "", fmt.Errorf("protection has changed since (%s); use --force to restore it anyway", strings.Join(drift, ", "))
+	}
+
+	changes := protect.Diff(current, state.Before)
+	if len(changes) == 0 {
+		return "already restored", nil
+	}
+	if opts.DryRun {
+		return fmt.Sprintf("would restore %d setting(s)", len(changes)), nil
+	}
+	if err := backend.ApplyProtection(ctx, state.Repository, state.Branch, current, state.Before); err != nil {
+		return "", fmt.Errorf("failed to restore protection: %w", err)
+	}
+	return fmt.Sprintf("restored %d setting(s)", len(changes)), nil
+}
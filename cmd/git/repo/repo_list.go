@@ -12,6 +12,7 @@ import (
 	"regexp"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -19,6 +20,8 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/git/repostats"
 	"github.com/gizzahub/gzh-cli/pkg/config"
 	"github.com/gizzahub/gzh-cli/pkg/git/provider"
 )
@@ -57,6 +60,11 @@ type ListOptions struct {
 	MaxStars     int
 	UpdatedSince string
 
+	// Stats options
+	Stats         bool
+	Filter        string
+	StatsCacheTTL time.Duration
+
 	// Sorting options
 	Sort  string
 	Order string
@@ -66,6 +74,10 @@ type ListOptions struct {
 	Limit   int
 	Quiet   bool
 	Verbose bool
+	Table   cli.TableOptions
+
+	// stats holds computed statistics keyed by repostats.Key once collected.
+	stats map[string]*repostats.Stats
 }
 
 // newRepoListCmd creates the repo list command.
@@ -75,6 +87,8 @@ func newRepoListCmd() *cobra.Command {
 		Sort:       "name",
 		Order:      "asc",
 		Format:     "table",
+
+		StatsCacheTTL: repostats.DefaultCacheTTL,
 	}
 
 	cmd := &cobra.Command{
@@ -87,7 +101,19 @@ This command provides comprehensive repository listing capabilities including:
 - Advanced filtering by various criteria
 - Multiple output formats (table, json, yaml, csv)
 - Aggregation across multiple providers
-- Real-time repository statistics`,
+- Real-time repository statistics
+
+With --stats, computed columns are added: language breakdown, last commit
+age, open pull request and issue counts, size and archived status. They are
+fetched concurrently and cached under ~/.gzh/cache for --stats-cache-ttl.
+
+--filter takes comma separated conditions on the computed statistics:
+  language=Go      primary language (language!=X excludes any use of X)
+  stale>90d        time since the last commit (d, w, mo, y)
+  open-prs>0       open pull/merge requests
+  issues>=10       open issues
+  size<500M        repository size (K, M, G)
+  archived=false   archived status`,
 		Example: `  # List repositories from a GitHub organization
   gz git repo list --provider github --org myorg
 
@@ -104,7 +130,13 @@ This command provides comprehensive repository listing capabilities including:
   gz git repo list --provider github --org myorg --archived-only
 
   # List with sorting and limits
-  gz git repo list --provider github --org myorg --sort stars --order desc --limit 10`,
+  gz git repo list --provider github --org myorg --sort stars --order desc --limit 10
+
+  # Script-friendly output: chosen columns, no header, second page of 50
+  gz git repo list --provider github --org myorg --columns name,language,updated --no-header --page 2 --page-size 50
+
+  # Show computed statistics for stale Go repositories
+  gz git repo list --provider github --org myorg --stats --sort last-activity --filter 'language=Go,stale>90d'`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runRepoList(cmd.Context(), opts)
 		},
@@ -125,8 +157,13 @@ This command provides comprehensive repository listing capabilities including:
 	cmd.Flags().IntVar(&opts.MaxStars, "max-stars", 0, "Maximum star count (0 = no limit)")
 	cmd.Flags().StringVar(&opts.UpdatedSince, "updated-since", "", "Filter by last update date (YYYY-MM-DD)")
 
+	// Stats options
+	cmd.Flags().BoolVar(&opts.Stats, "stats", false, "Show computed statistics (languages, last commit, open PRs/issues, size)")
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter by statistics, e.g. 'language=Go,stale>90d,open-prs>0'")
+	cmd.Flags().DurationVar(&opts.StatsCacheTTL, "stats-cache-ttl", repostats.DefaultCacheTTL, "How long fetched statistics are reused (0 = no cache)")
+
 	// Sorting options
-	cmd.Flags().StringVar(&opts.Sort, "sort", "name", "Sort by field (name, created, updated, stars, forks)")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "name", "Sort by field (name, created, updated, stars, forks, last-activity, size, open-prs, issues)")
 	cmd.Flags().StringVar(&opts.Order, "order", "asc", "Sort order (asc, desc)")
 
 	// Output options
@@ -134,6 +171,7 @@ This command provides comprehensive repository listing capabilities including:
 	cmd.Flags().IntVar(&opts.Limit, "limit", 0, "Limit number of results (0 = no limit)")
 	cmd.Flags().BoolVar(&opts.Quiet, "quiet", false, "Suppress headers and extra output")
 	cmd.Flags().BoolVar(&opts.Verbose, "verbose", false, "Include additional repository details")
+	cli.AddTableFlags(cmd, &opts.Table, repoListColumns...)
 
 	// Validation rules
 	cmd.MarkFlagsMutuallyExclusive("archived-only", "no-archived")
@@ -180,6 +218,13 @@ func runRepoList(ctx context.Context, opts *ListOptions) error {
 	// Apply filtering
 	filtered := opts.applyFilters(allRepos)
 
+	// 통계 기반 필터/정렬은 전체 목록의 통계가 필요하고, 표시만 할 때는
+	// limit 적용 후 남은 저장소만 조회한다.
+	if opts.Filter != "" || isStatsSortField(opts.Sort) {
+		opts.collectStats(ctx, filtered)
+		filtered = opts.applyStatsFilter(filtered)
+	}
+
 	// Apply sorting
 	sorted := opts.applySorting(filtered)
 
@@ -188,6 +233,10 @@ func runRepoList(ctx context.Context, opts *ListOptions) error {
 		sorted = sorted[:opts.Limit]
 	}
 
+	if opts.Stats && opts.stats == nil {
+		opts.collectStats(ctx, sorted)
+	}
+
 	// Output results
 	return opts.outputRepositories(sorted)
 }
@@ -208,7 +257,7 @@ func (opts *ListOptions) Validate() error {
 	}
 
 	// Validate sort field
-	validSortFields := []string{"name", "created", "updated", "stars", "forks"}
+	validSortFields := []string{"name", "created", "updated", "stars", "forks", "last-activity", "size", "open-prs", "issues"}
 	if !contains(validSortFields, opts.Sort) {
 		return fmt.Errorf("invalid sort field: %s (valid: %s)", opts.Sort, strings.Join(validSortFields, ", "))
 	}
@@ -232,6 +281,23 @@ func (opts *ListOptions) Validate() error {
 		return fmt.Errorf("max-stars must be greater than min-stars")
 	}
 
+	// Validate stats filter if provided
+	if _, err := repostats.ParseFilter(opts.Filter); err != nil {
+		return err
+	}
+	if opts.StatsCacheTTL < 0 {
+		return fmt.Errorf("stats-cache-ttl cannot be negative")
+	}
+
+	// Validate table options against the columns of the table to be shown
+	columns := repoListColumns
+	if opts.Stats {
+		columns = repoStatsColumns
+	}
+	if err := opts.Table.Validate(columns...); err != nil {
+		return err
+	}
+
 	// Validate updated-since date format if provided
 	if opts.UpdatedSince != "" {
 		if _, err := parseDate(opts.UpdatedSince); err != nil {
@@ -245,18 +311,6 @@ func (opts *ListOptions) Validate() error {
 // maxConcurrentRequests limits concurrent API requests to avoid rate limiting.
 const maxConcurrentRequests = 5
 
-// Table column widths for consistent formatting.
-const (
-	colWidthName     = 40
-	colWidthPrivate  = 10
-	colWidthLanguage = 15
-	colWidthStars    = 8
-	colWidthForks    = 8
-	colWidthIssues   = 8
-	colWidthUpdated  = 12
-	colWidthStarsV   = 10 // Verbose mode uses wider column
-)
-
 // listFromAllProviders gets repositories from all configured providers.
 func (opts *ListOptions) listFromAllProviders(ctx context.Context) ([]provider.Repository, error) {
 	// Load configuration
@@ -384,12 +438,24 @@ func (opts *ListOptions) listFromProvider(ctx context.Context, providerType, org
 		listOpts.Language = opts.Language
 	}
 
+	// Sorting by computed fields happens client-side
+	if isStatsSortField(opts.Sort) {
+		listOpts.Sort = ""
+	}
+
 	// Get repositories
 	repoList, err := gitProvider.ListRepositories(ctx, listOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list repositories: %w", err)
 	}
 
+	// Stats and cache keys need the provider of each repository
+	for i := range repoList.Repositories {
+		if repoList.Repositories[i].ProviderType == "" {
+			repoList.Repositories[i].ProviderType = providerType
+		}
+	}
+
 	return repoList.Repositories, nil
 }
 
@@ -453,6 +519,14 @@ func (opts *ListOptions) applySorting(repos []provider.Repository) []provider.Re
 			less = sorted[i].Stars < sorted[j].Stars
 		case "forks":
 			less = sorted[i].Forks < sorted[j].Forks
+		case "last-activity":
+			less = opts.statsFor(sorted[i]).LastCommitAt.Before(opts.statsFor(sorted[j]).LastCommitAt)
+		case "size":
+			less = opts.statsFor(sorted[i]).SizeKB < opts.statsFor(sorted[j]).SizeKB
+		case "open-prs":
+			less = opts.statsFor(sorted[i]).OpenPRs < opts.statsFor(sorted[j]).OpenPRs
+		case "issues":
+			less = opts.statsFor(sorted[i]).OpenIssues < opts.statsFor(sorted[j]).OpenIssues
 		default:
 			less = sorted[i].Name < sorted[j].Name
 		}
@@ -468,6 +542,10 @@ func (opts *ListOptions) applySorting(repos []provider.Repository) []provider.Re
 
 // outputRepositories outputs repositories in the specified format.
 func (opts *ListOptions) outputRepositories(repos []provider.Repository) error {
+	if opts.Stats {
+		return opts.outputRepositoriesWithStats(repos)
+	}
+
 	switch opts.Format {
 	case "table":
 		return opts.outputTable(repos)
@@ -482,6 +560,32 @@ func (opts *ListOptions) outputRepositories(repos []provider.Repository) error {
 	}
 }
 
+// repoListColumns are the columns of the repository table. Forks and
+// issues are shown by default with --verbose.
+var repoListColumns = []cli.Column{
+	{Name: "name"},
+	{Name: "visibility", Header: "PRIVATE"},
+	{Name: "language"},
+	{Name: "stars"},
+	{Name: "forks", Hidden: true},
+	{Name: "issues", Hidden: true},
+	{Name: "updated", NoTruncate: true},
+	{Name: "branch", Hidden: true},
+	{Name: "description", Hidden: true},
+	{Name: "url", Hidden: true},
+}
+
+// tableOptions returns the table options with --verbose and --quiet
+// applied.
+func (opts *ListOptions) tableOptions() cli.TableOptions {
+	table := opts.Table
+	if len(table.Columns) == 0 && opts.Verbose && !opts.Stats {
+		table.Columns = []string{"name", "visibility", "language", "stars", "forks", "issues", "updated"}
+	}
+	table.NoHeader = table.NoHeader || opts.Quiet
+	return table
+}
+
 // outputTable outputs repositories in table format.
 func (opts *ListOptions) outputTable(repos []provider.Repository) error {
 	if len(repos) == 0 {
@@ -491,42 +595,7 @@ func (opts *ListOptions) outputTable(repos []provider.Repository) error {
 		return nil
 	}
 
-	// Header
-	if !opts.Quiet {
-		if opts.Verbose {
-			fmt.Printf("%-*s %-*s %-*s %-*s %-*s %-*s %-*s\n",
-				colWidthName, "NAME",
-				colWidthPrivate, "PRIVATE",
-				colWidthLanguage, "LANGUAGE",
-				colWidthStarsV, "STARS",
-				colWidthForks, "FORKS",
-				colWidthIssues, "ISSUES",
-				colWidthUpdated, "UPDATED")
-			fmt.Printf("%-*s %-*s %-*s %-*s %-*s %-*s %-*s\n",
-				colWidthName, strings.Repeat("-", colWidthName),
-				colWidthPrivate, strings.Repeat("-", colWidthPrivate),
-				colWidthLanguage, strings.Repeat("-", colWidthLanguage),
-				colWidthStarsV, strings.Repeat("-", colWidthStarsV),
-				colWidthForks, strings.Repeat("-", colWidthForks),
-				colWidthIssues, strings.Repeat("-", colWidthIssues),
-				colWidthUpdated, strings.Repeat("-", colWidthUpdated))
-		} else {
-			fmt.Printf("%-*s %-*s %-*s %-*s %-*s\n",
-				colWidthName, "NAME",
-				colWidthPrivate, "PRIVATE",
-				colWidthLanguage, "LANGUAGE",
-				colWidthStars, "STARS",
-				colWidthUpdated, "UPDATED")
-			fmt.Printf("%-*s %-*s %-*s %-*s %-*s\n",
-				colWidthName, strings.Repeat("-", colWidthName),
-				colWidthPrivate, strings.Repeat("-", colWidthPrivate),
-				colWidthLanguage, strings.Repeat("-", colWidthLanguage),
-				colWidthStars, strings.Repeat("-", colWidthStars),
-				colWidthUpdated, strings.Repeat("-", colWidthUpdated))
-		}
-	}
-
-	// Rows
+	table := cli.NewTable(repoListColumns...)
 	for _, repo := range repos {
 		private := "public"
 		if repo.Private {
@@ -538,29 +607,25 @@ func (opts *ListOptions) outputTable(repos []provider.Repository) error {
 			language = "n/a"
 		}
 
-		updated := repo.UpdatedAt.Format("2006-01-02")
-
-		if opts.Verbose {
-			fmt.Printf("%-*s %-*s %-*s %-*d %-*d %-*d %-*s\n",
-				colWidthName, truncateString(repo.FullName, colWidthName),
-				colWidthPrivate, private,
-				colWidthLanguage, truncateString(language, colWidthLanguage),
-				colWidthStarsV, repo.Stars,
-				colWidthForks, repo.Forks,
-				colWidthIssues, repo.Issues,
-				colWidthUpdated, updated)
-		} else {
-			fmt.Printf("%-*s %-*s %-*s %-*d %-*s\n",
-				colWidthName, truncateString(repo.FullName, colWidthName),
-				colWidthPrivate, private,
-				colWidthLanguage, truncateString(language, colWidthLanguage),
-				colWidthStars, repo.Stars,
-				colWidthUpdated, updated)
-		}
+		table.AddRow(
+			cli.Hyperlink(repo.FullName, repo.HTMLURL),
+			private,
+			language,
+			strconv.Itoa(repo.Stars),
+			strconv.Itoa(repo.Forks),
+			strconv.Itoa(repo.Issues),
+			repo.UpdatedAt.Format("2006-01-02"),
+			repo.DefaultBranch,
+			repo.Description,
+			cli.Hyperlink(repo.HTMLURL, repo.HTMLURL),
+		)
+	}
+	if err := table.Render(os.Stdout, opts.tableOptions()); err != nil {
+		return err
 	}
 
 	// Summary
-	if !opts.Quiet {
+	if !opts.Quiet && !opts.Table.NoHeader {
 		fmt.Printf("\nTotal: %d repositories\n", len(repos))
 	}
 
@@ -0,0 +1,225 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/git/repostats"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// statsSortFields are sort fields that need computed statistics.
+var statsSortFields = []string{"last-activity", "size", "open-prs", "issues"}
+
+// isStatsSortField reports whether sorting by field needs statistics.
+func isStatsSortField(field string) bool {
+	return contains(statsSortFields, field)
+}
+
+// repositoryWithStats is a repository with its statistics for structured
+// output formats.
+type repositoryWithStats struct {
+	provider.Repository `yaml:",inline"`
+	Stats               *repostats.Stats `json:"stats" yaml:"stats"`
+}
+
+// collectStats fetches statistics for repos and keeps them in opts.stats.
+// Failures are reported as warnings; affected repositories fall back to the
+// statistics of the listing.
+func (opts *ListOptions) collectStats(ctx context.Context, repos []provider.Repository) {
+	collector := &repostats.Collector{
+		Fetchers:    make(map[string]repostats.Fetcher),
+		Concurrency: maxConcurrentRequests,
+	}
+	for _, repo := range repos {
+		if _, ok := collector.Fetchers[repo.ProviderType]; ok {
+			continue
+		}
+		token := getTokenFromEnv(strings.ToUpper(repo.ProviderType) + "_TOKEN")
+		if fetcher, err := repostats.NewFetcher(repo.ProviderType, "", token); err == nil {
+			collector.Fetchers[repo.ProviderType] = fetcher
+		}
+	}
+	if opts.StatsCacheTTL > 0 {
+		collector.Cache = repostats.LoadCache(repostats.DefaultCachePath(), opts.StatsCacheTTL)
+	}
+
+	stats, errs := collector.Collect(ctx, repos)
+	opts.stats = stats
+
+	if len(errs) > 0 {
+		msgs := make([]string, 0, len(errs))
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		fmt.Fprintf(os.Stderr, "Warning: statistics incomplete for %d repositories: %s\n", len(errs), strings.Join(msgs, "; "))
+	}
+	if collector.Cache != nil {
+		if err := collector.Cache.Save(time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
+
+// statsFor returns the collected statistics of repo, or those derived from
+// the listing when none were collected.
+func (opts *ListOptions) statsFor(repo provider.Repository) *repostats.Stats {
+	if s, ok := opts.stats[repostats.Key(repo)]; ok {
+		return s
+	}
+	return repostats.FromRepository(repo)
+}
+
+// applyStatsFilter applies the --filter conditions (already validated in
+// Validate()).
+func (opts *ListOptions) applyStatsFilter(repos []provider.Repository) []provider.Repository {
+	filter, _ := repostats.ParseFilter(opts.Filter)
+	if len(filter) == 0 {
+		return repos
+	}
+
+	now := time.Now()
+	var filtered []provider.Repository
+	for _, repo := range repos {
+		if filter.Match(opts.statsFor(repo), now) {
+			filtered = append(filtered, repo)
+		}
+	}
+	return filtered
+}
+
+// outputRepositoriesWithStats outputs repositories and their statistics in
+// the specified format.
+func (opts *ListOptions) outputRepositoriesWithStats(repos []provider.Repository) error {
+	switch opts.Format {
+	case "table":
+		return opts.outputStatsTable(repos)
+	case "json", "yaml":
+		items := make([]repositoryWithStats, 0, len(repos))
+		for _, repo := range repos {
+			items = append(items, repositoryWithStats{Repository: repo, Stats: opts.statsFor(repo)})
+		}
+		if opts.Format == "yaml" {
+			yamlData, err := yaml.Marshal(items)
+			if err != nil {
+				return fmt.Errorf("failed to marshal repositories as YAML: %w", err)
+			}
+			fmt.Print(string(yamlData))
+			return nil
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(items); err != nil {
+			return fmt.Errorf("failed to encode repositories as JSON: %w", err)
+		}
+		return nil
+	case "csv":
+		return opts.outputStatsCSV(repos)
+	default:
+		return fmt.Errorf("unsupported output format: %s", opts.Format)
+	}
+}
+
+// repoStatsColumns are the columns of the repository table with --stats.
+var repoStatsColumns = []cli.Column{
+	{Name: "name"},
+	{Name: "languages"},
+	{Name: "last-commit", Header: "LAST COMMIT", NoTruncate: true},
+	{Name: "prs"},
+	{Name: "issues"},
+	{Name: "size"},
+	{Name: "archived"},
+}
+
+// outputStatsTable outputs repositories with statistics in table format.
+func (opts *ListOptions) outputStatsTable(repos []provider.Repository) error {
+	if len(repos) == 0 {
+		if !opts.Quiet {
+			fmt.Println("No repositories found")
+		}
+		return nil
+	}
+
+	table := cli.NewTable(repoStatsColumns...)
+	now := time.Now()
+	partial := 0
+	for _, repo := range repos {
+		s := opts.statsFor(repo)
+
+		// Partial stats have no PR count; the issue count may include PRs.
+		prs := strconv.Itoa(s.OpenPRs)
+		if s.Partial {
+			prs = "?"
+			partial++
+		}
+		archived := "no"
+		if s.Archived {
+			archived = "yes"
+		}
+
+		table.AddRow(
+			repo.FullName,
+			repostats.FormatLanguages(s.Languages, 2),
+			repostats.FormatAge(s.Age(now)),
+			prs,
+			strconv.Itoa(s.OpenIssues),
+			repostats.FormatSize(s.SizeKB),
+			archived,
+		)
+	}
+	if err := table.Render(os.Stdout, opts.tableOptions()); err != nil {
+		return err
+	}
+
+	if !opts.Quiet && !opts.Table.NoHeader {
+		fmt.Printf("\nTotal: %d repositories\n", len(repos))
+		if partial > 0 {
+			fmt.Printf("Statistics incomplete for %d repositories (marked ?)\n", partial)
+		}
+	}
+
+	return nil
+}
+
+// outputStatsCSV outputs repositories with statistics in CSV format.
+func (opts *ListOptions) outputStatsCSV(repos []provider.Repository) error {
+	writer := csv.NewWriter(os.Stdout)
+	defer writer.Flush()
+
+	header := []string{"Full Name", "Primary Language", "Languages", "Last Commit At", "Open PRs", "Open Issues", "Size KB", "Archived", "Partial"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, repo := range repos {
+		s := opts.statsFor(repo)
+		record := []string{
+			repo.FullName,
+			s.PrimaryLanguage(),
+			repostats.FormatLanguages(s.Languages, len(s.Languages)),
+			formatTime(s.LastCommitAt),
+			strconv.Itoa(s.OpenPRs),
+			strconv.Itoa(s.OpenIssues),
+			strconv.FormatInt(s.SizeKB, 10),
+			strconv.FormatBool(s.Archived),
+			strconv.FormatBool(s.Partial),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+
+	return nil
+}
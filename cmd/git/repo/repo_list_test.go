@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gizzahub/gzh-cli/internal/git/repostats"
 	"github.com/gizzahub/gzh-cli/pkg/git/provider"
 )
 
@@ -313,6 +314,55 @@ func TestListOptions_applySorting(t *testing.T) {
 	})
 }
 
+func TestListOptions_statsSortingAndFilter(t *testing.T) {
+	now := time.Now()
+	repos := []provider.Repository{
+		{Name: "api", FullName: "acme/api", ProviderType: "github", Language: "Go"},
+		{Name: "web", FullName: "acme/web", ProviderType: "github", Language: "TypeScript"},
+		{Name: "tool", FullName: "acme/tool", ProviderType: "github", Language: "Go"},
+	}
+	opts := &ListOptions{
+		Sort:   "last-activity",
+		Order:  "asc",
+		Filter: "language=Go,stale>90d",
+		stats: map[string]*repostats.Stats{
+			"github:acme/api": {
+				Languages:    []repostats.LanguageShare{{Name: "Go", Percent: 100}},
+				LastCommitAt: now.AddDate(0, 0, -200),
+			},
+			"github:acme/web": {
+				Languages:    []repostats.LanguageShare{{Name: "TypeScript", Percent: 100}},
+				LastCommitAt: now.AddDate(0, 0, -300),
+			},
+			"github:acme/tool": {
+				Languages:    []repostats.LanguageShare{{Name: "Go", Percent: 100}},
+				LastCommitAt: now.AddDate(0, 0, -400),
+				OpenPRs:      4,
+			},
+		},
+	}
+
+	filtered := opts.applyStatsFilter(repos)
+	require.Len(t, filtered, 2)
+
+	sorted := opts.applySorting(filtered)
+	assert.Equal(t, "tool", sorted[0].Name)
+	assert.Equal(t, "api", sorted[1].Name)
+
+	opts.Sort = "open-prs"
+	opts.Order = "desc"
+	sorted = opts.applySorting(repos)
+	assert.Equal(t, "tool", sorted[0].Name)
+
+	// Repositories without collected stats fall back to the listing.
+	s := opts.statsFor(provider.Repository{FullName: "acme/new", Language: "Rust", Size: 10})
+	assert.Equal(t, "Rust", s.PrimaryLanguage())
+	assert.True(t, s.Partial)
+
+	invalid := &ListOptions{Visibility: "all", Sort: "name", Order: "asc", Format: "table", Filter: "stars>10"}
+	assert.ErrorContains(t, invalid.Validate(), "unknown field")
+}
+
 func TestHelperFunctions(t *testing.T) {
 	t.Run("contains", func(t *testing.T) {
 		slice := []string{"apple", "banana", "cherry"}
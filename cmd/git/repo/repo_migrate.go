@@ -0,0 +1,235 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/git/migrate"
+	"github.com/gizzahub/gzh-cli/internal/git/sync"
+)
+
+// MigrateOptions contains options for repository migration.
+type MigrateOptions struct {
+	// Source and destination (provider:org/repo)
+	From      string
+	To        string
+	SourceURL string
+	DestURL   string
+
+	// Content selection
+	SkipGit           bool
+	IncludeLabels     bool
+	IncludeMilestones bool
+	IncludeIssues     bool
+	IncludePRs        bool
+	IncludeReleases   bool
+	IncludeComments   bool
+	All               bool
+
+	// Destination repository
+	CreateMissing bool
+	Private       bool
+
+	// Checkpoint and reporting
+	Resume     bool
+	Checkpoint string
+	Report     string
+
+	DryRun   bool
+	Progress string
+}
+
+// newRepoMigrateCmd creates the repo migrate command.
+func newRepoMigrateCmd() *cobra.Command {
+	opts := &MigrateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate repositories between platforms",
+		Long: `Migrate a repository between GitHub, GitLab and Gitea.
+
+Git data (all branches and tags) is always transferred unless --skip-git is
+set. Tracker content can be transferred as well:
+- Labels and milestones
+- Issues with their comments and open/closed state
+- Pull/merge requests, recreated as closed issues that keep the description,
+  branches, merge state and discussion
+- Releases (tags must exist in the destination, so keep git data enabled)
+
+Each migrated item carries a note linking back to the original. Progress is
+checkpointed after every item; rerun with --resume to continue an interrupted
+migration without duplicating what was already transferred.
+
+Tokens are read from GITHUB_TOKEN, GITLAB_TOKEN and GITEA_TOKEN.`,
+		Example: `  # Move code only
+  gz git repo migrate --from github:myorg/app --to gitlab:mygroup/app
+
+  # Move everything, writing a mapping report
+  gz git repo migrate --from github:myorg/app --to gitea:myorg/app --all --report mapping.json
+
+  # Self-hosted destination, issues and pull requests only
+  gz git repo migrate --from gitlab:group/app --to gitea:org/app \
+    --dest-url https://gitea.example.com/api/v1 --include-issues --include-prs
+
+  # Continue after an interruption
+  gz git repo migrate --from github:myorg/app --to gitlab:mygroup/app --all --resume
+
+  # Preview what would be transferred
+  gz git repo migrate --from github:myorg/app --to gitlab:mygroup/app --all --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			progress, err := cli.ProgressForCommand(cmd, opts.Progress)
+			if err != nil {
+				return err
+			}
+			return runRepoMigrate(cmd.Context(), opts, progress)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.From, "from", "", "Source repository (provider:org/repo)")
+	cmd.Flags().StringVar(&opts.To, "to", "", "Destination repository (provider:org/repo)")
+	cmd.Flags().StringVar(&opts.SourceURL, "source-url", "", "Source API base URL (for self-hosted instances)")
+	cmd.Flags().StringVar(&opts.DestURL, "dest-url", "", "Destination API base URL (for self-hosted instances)")
+
+	cmd.Flags().BoolVar(&opts.SkipGit, "skip-git", false, "Do not transfer branches and tags")
+	cmd.Flags().BoolVar(&opts.IncludeLabels, "include-labels", false, "Transfer labels")
+	cmd.Flags().BoolVar(&opts.IncludeMilestones, "include-milestones", false, "Transfer milestones")
+	cmd.Flags().BoolVar(&opts.IncludeIssues, "include-issues", false, "Transfer issues")
+	cmd.Flags().BoolVar(&opts.IncludePRs, "include-prs", false, "Transfer pull/merge requests as closed issues")
+	cmd.Flags().BoolVar(&opts.IncludeReleases, "include-releases", false, "Transfer releases")
+	cmd.Flags().BoolVar(&opts.IncludeComments, "include-comments", true, "Copy comments on issues and pull requests")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Transfer labels, milestones, issues, pull requests and releases")
+
+	cmd.Flags().BoolVar(&opts.CreateMissing, "create-missing", true, "Create the destination repository if it does not exist")
+	cmd.Flags().BoolVar(&opts.Private, "private", true, "Create the destination repository as private")
+
+	cmd.Flags().BoolVar(&opts.Resume, "resume", false, "Continue from the existing checkpoint")
+	cmd.Flags().StringVar(&opts.Checkpoint, "checkpoint", "", "Checkpoint file (default ~/.config/gzh-manager/migrate/<source>__<destination>.json)")
+	cmd.Flags().StringVar(&opts.Report, "report", "", "Write the source-to-destination mapping report (JSON) to this file")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be migrated without making changes")
+	cli.AddProgressFlag(cmd, &opts.Progress)
+
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// runRepoMigrate executes the repository migration.
+func runRepoMigrate(ctx context.Context, opts *MigrateOptions, progress cli.Progress) error {
+	src, err := migrateEndpoint(opts.From, opts.SourceURL)
+	if err != nil {
+		return fmt.Errorf("invalid source: %w", err)
+	}
+	dst, err := migrateEndpoint(opts.To, opts.DestURL)
+	if err != nil {
+		return fmt.Errorf("invalid destination: %w", err)
+	}
+
+	srcTracker, err := migrate.NewTracker(src)
+	if err != nil {
+		return err
+	}
+	dstTracker, err := migrate.NewTracker(dst)
+	if err != nil {
+		return err
+	}
+
+	srcName := src.Provider + ":" + src.Repo
+	dstName := dst.Provider + ":" + dst.Repo
+
+	checkpointPath := opts.Checkpoint
+	if checkpointPath == "" {
+		checkpointPath = migrate.DefaultCheckpointPath(srcName, dstName)
+	}
+
+	var checkpoint *migrate.Checkpoint
+	switch {
+	case opts.DryRun:
+		// Dry runs read the checkpoint to show progress but never write it.
+		checkpoint, err = migrate.LoadCheckpoint(checkpointPath, srcName, dstName)
+		if err != nil {
+			checkpoint = nil
+		}
+	case opts.Resume:
+		checkpoint, err = migrate.LoadCheckpoint(checkpointPath, srcName, dstName)
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("no checkpoint to resume at %s", checkpointPath)
+		}
+		if err != nil {
+			return err
+		}
+	default:
+		if _, err := os.Stat(checkpointPath); err == nil {
+			return fmt.Errorf("a checkpoint already exists at %s; use --resume to continue or remove it to start over", checkpointPath)
+		}
+		checkpoint = migrate.NewCheckpoint(checkpointPath, srcName, dstName)
+	}
+
+	migrator := migrate.NewMigrator(srcTracker, dstTracker, checkpoint, migrate.Options{
+		Git:           !opts.SkipGit,
+		Labels:        opts.All || opts.IncludeLabels,
+		Milestones:    opts.All || opts.IncludeMilestones,
+		Issues:        opts.All || opts.IncludeIssues,
+		PullRequests:  opts.All || opts.IncludePRs,
+		Releases:      opts.All || opts.IncludeReleases,
+		Comments:      opts.IncludeComments,
+		CreateMissing: opts.CreateMissing,
+		Private:       opts.Private,
+		DryRun:        opts.DryRun,
+		Out:           os.Stdout,
+		Progress:      progress,
+	})
+
+	fmt.Printf("🚚 Migrating %s → %s\n", srcName, dstName)
+	report, runErr := migrator.Run(ctx)
+	if report != nil {
+		if report.DryRun {
+			fmt.Println()
+			report.Plan().Render(os.Stdout)
+		}
+		report.PrintSummary(os.Stdout)
+		if opts.Report != "" {
+			if err := report.WriteJSON(opts.Report); err != nil {
+				return fmt.Errorf("failed to write report: %w", err)
+			}
+			fmt.Printf("Mapping report written to %s\n", opts.Report)
+		}
+	}
+	if runErr != nil {
+		return runErr
+	}
+
+	if report != nil && len(report.Failed()) > 0 {
+		return fmt.Errorf("%d item(s) were not migrated; rerun with --resume to retry", len(report.Failed()))
+	}
+	return nil
+}
+
+// migrateEndpoint parses a provider:org/repo target and attaches the
+// provider token from the environment.
+func migrateEndpoint(target, baseURL string) (migrate.Endpoint, error) {
+	t, err := sync.ParseTarget(target)
+	if err != nil {
+		return migrate.Endpoint{}, err
+	}
+	if !t.IsRepository() {
+		return migrate.Endpoint{}, fmt.Errorf("%s must name a repository (provider:org/repo)", target)
+	}
+
+	return migrate.Endpoint{
+		Provider: t.Provider,
+		BaseURL:  baseURL,
+		Token:    getTokenFromEnv(strings.ToUpper(t.Provider) + "_TOKEN"),
+		Repo:     t.FullName(),
+	}, nil
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// outputPlan prints the changes a dry run captured. Table output renders
+// the plan as a diff under title; json and yaml print the changes.
+func outputPlan(w io.Writer, plan *provider.Plan, title, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(plan); err != nil {
+			return fmt.Errorf("failed to encode plan as JSON: %w", err)
+		}
+		return nil
+	case "yaml":
+		data, err := yaml.Marshal(plan.Changes())
+		if err != nil {
+			return fmt.Errorf("failed to marshal plan as YAML: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	fmt.Fprintf(w, "\n🔍 Dry run: %s\n\n", title)
+	plan.Render(w)
+	return nil
+}
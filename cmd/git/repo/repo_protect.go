@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/git/protect"
+	"github.com/gizzahub/gzh-cli/internal/journal"
+)
+
+// ProtectOptions contains options for branch protection enforcement.
+type ProtectOptions struct {
+	Provider     string
+	Org          string
+	Policy       string
+	BaseURL      string
+	Repositories []string
+	Concurrency  int
+	DryRun       bool
+	Report       string
+	JSON         bool
+	FailOnDrift  bool
+}
+
+// newRepoProtectCmd creates the repo protect command.
+func newRepoProtectCmd() *cobra.Command {
+	opts := &ProtectOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "protect",
+		Short: "Apply a branch protection policy across an organization",
+		Long: `Audit and apply a declarative branch protection policy to every
+repository of a GitHub organization or GitLab group.
+
+The policy is a YAML file listing the protection each branch must have:
+
+  repositories:
+    exclude: ["sandbox-*"]
+  branches:
+    - required_reviews: 2          # no branch: each repository's default branch
+      dismiss_stale_reviews: true
+      require_code_owner_reviews: true
+      status_checks: [ci/build, ci/test]
+      strict_status_checks: true
+      require_signed_commits: true
+      allow_force_pushes: false
+      allow_deletions: false
+      enforce_admins: true
+
+Settings left out of the policy are not touched. With --dry-run the drift
+is printed as a plan of the protection updates that would be made. On GitLab, review, pipeline and signed commit settings are
+project-wide; status check names, strict checks, allow_deletions and
+enforce_admins have no GitLab equivalent and are reported as unsupported.
+
+Tokens are read from GITHUB_TOKEN and GITLAB_TOKEN.`,
+		Example: `  # Show drift without changing anything
+  gz git repo protect --provider github --org myorg --policy protection.yaml --dry-run
+
+  # Apply the policy and keep a JSON report
+  gz git repo protect --provider github --org myorg --policy protection.yaml --report drift.json
+
+  # Audit a self-hosted GitLab group in CI
+  gz git repo protect --provider gitlab --org platform --policy protection.yaml \
+    --base-url https://gitlab.example.com/api/v4 --dry-run --fail-on-drift`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRepoProtect(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Provider, "provider", "github", "Git platform (github, gitlab)")
+	cmd.Flags().StringVar(&opts.Org, "org", "", "Organization or group")
+	cmd.Flags().StringVar(&opts.Policy, "policy", "", "Branch protection policy file (YAML)")
+	cmd.Flags().StringVar(&opts.BaseURL, "base-url", "", "API base URL (for self-hosted instances)")
+	cmd.Flags().StringSliceVar(&opts.Repositories, "repo", nil, "Only process repositories matching these patterns")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 4, "Number of repositories processed at once")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Report drift without changing anything")
+	cmd.Flags().StringVar(&opts.Report, "report", "", "Write the drift report (JSON) to this file")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print the report as JSON")
+	cmd.Flags().BoolVar(&opts.FailOnDrift, "fail-on-drift", false, "Exit with an error when any branch drifts from the policy")
+
+	cmd.MarkFlagRequired("org")
+	cmd.MarkFlagRequired("policy")
+
+	return cmd
+}
+
+// runRepoProtect audits and applies the policy.
+func runRepoProtect(ctx context.Context, out io.Writer, opts *ProtectOptions) error {
+	policy, err := protect.LoadPolicy(opts.Policy)
+	if err != nil {
+		return err
+	}
+
+	token := getTokenFromEnv(strings.ToUpper(opts.Provider) + "_TOKEN")
+	if token == "" {
+		return fmt.Errorf("%s_TOKEN is not set", strings.ToUpper(opts.Provider))
+	}
+	backend, err := protect.NewBackend(opts.Provider, opts.BaseURL, token)
+	if err != nil {
+		return err
+	}
+
+	enforcerOpts := protect.Options{
+		DryRun:       opts.DryRun,
+		Repositories: opts.Repositories,
+		Concurrency:  opts.Concurrency,
+	}
+	var op *journal.Operation
+	if !opts.DryRun {
+		op = startJournal("gz git repo protect")
+		enforcerOpts.Applied = func(repo protect.Repository, branch string, before, after protect.Protection) {
+			journalRecord(op, journal.KindProtection, opts.Provider, repo.FullName+"@"+branch, protectionJournalState{
+				BaseURL:    opts.BaseURL,
+				Repository: repo,
+				Branch:     branch,
+				Before:     before,
+				After:      after,
+			}, "")
+		}
+	}
+	enforcer := protect.NewEnforcer(backend, policy, enforcerOpts)
+
+	if !opts.JSON {
+		fmt.Fprintf(out, "🔒 Enforcing %s on %s:%s\n", opts.Policy, opts.Provider, opts.Org)
+	}
+	report, runErr := enforcer.Run(ctx, opts.Org)
+	if report == nil {
+		return runErr
+	}
+
+	if opts.JSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+	} else {
+		if opts.DryRun {
+			report.PrintPlan(out)
+		} else {
+			report.PrintDiff(out)
+		}
+		report.PrintSummary(out)
+		if op.Len() > 0 {
+			fmt.Fprintf(out, "\n↩️  Undo with: gz undo %s\n", op.ID)
+		}
+	}
+
+	if opts.Report != "" {
+		if err := report.WriteJSON(opts.Report); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		if !opts.JSON {
+			fmt.Fprintf(out, "Drift report written to %s\n", opts.Report)
+		}
+	}
+	if runErr != nil {
+		return runErr
+	}
+
+	if failed := report.Counts()[protect.StatusFailed]; failed > 0 {
+		return fmt.Errorf("%d branch(es) could not be checked or updated", failed)
+	}
+	if opts.FailOnDrift && opts.DryRun && report.HasDrift() {
+		return fmt.Errorf("%d branch(es) drift from the policy", report.Counts()[protect.StatusDrift])
+	}
+	return nil
+}
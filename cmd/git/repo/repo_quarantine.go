@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/git/quarantine"
+)
+
+// newRepoQuarantineCmd creates the git repo quarantine command.
+func newRepoQuarantineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quarantine",
+		Short: "Manage repositories skipped for failing repeatedly",
+		Long: `Manage the quarantine of repositories that fail every bulk run.
+
+'gz git repo clone' quarantines a repository after it failed 3 runs in a row
+with an error that is not transient: permission denied, not found, corrupted
+or too large. Network errors, rate limits and runs in which every repository
+failed do not count.
+
+Quarantined repositories are skipped for a day, then two, four and up to
+seven days after each further failure. They get another attempt earlier
+when they change on the provider side, for example after a push or a
+visibility change. A repository that succeeds again is released.
+
+The list is kept in ~/.gzh/quarantine.json (GZH_QUARANTINE_FILE overrides
+it). Use --retry-quarantined with clone to attempt every repository now.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newRepoQuarantineListCmd())
+	cmd.AddCommand(newRepoQuarantineClearCmd())
+	return cmd
+}
+
+func newRepoQuarantineListCmd() *cobra.Command {
+	var (
+		providerName string
+		format       string
+		all          bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List quarantined repositories",
+		Example: `  # Quarantined repositories
+  gz git repo quarantine list
+
+  # Include repositories that failed but are not quarantined yet
+  gz git repo quarantine list --all --format json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := quarantine.Default()
+			if err != nil {
+				return err
+			}
+
+			var entries []quarantine.Entry
+			for _, e := range store.List() {
+				if (providerName == "" || e.Provider == providerName) && (all || e.Quarantined()) {
+					entries = append(entries, e)
+				}
+			}
+
+			switch format {
+			case "table":
+				return outputQuarantineTable(cmd.OutOrStdout(), entries, time.Now())
+			case "json":
+				if entries == nil {
+					entries = []quarantine.Entry{}
+				}
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(entries)
+			default:
+				return fmt.Errorf("invalid output format: %s (valid: table, json)", format)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&providerName, "provider", "", "Only list repositories of this provider")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format (table, json)")
+	cmd.Flags().BoolVar(&all, "all", false, "Include failing repositories that are not quarantined yet")
+
+	return cmd
+}
+
+func outputQuarantineTable(out io.Writer, entries []quarantine.Entry, now time.Time) error {
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "No quarantined repositories")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPOSITORY\tSTATUS\tREASON\tFAILURES\tRETRY\tLAST ERROR")
+	for _, e := range entries {
+		status, retry := "failing", "next run"
+		if e.Quarantined() {
+			status = "quarantined"
+			if e.RetryAfter.After(now) {
+				retry = e.RetryAfter.Format("2006-01-02 15:04")
+			}
+		}
+		lastError := strings.Join(strings.Fields(e.LastError), " ")
+		fmt.Fprintf(w, "%s/%s\t%s\t%s\t%d\t%s\t%s\n",
+			e.Provider, e.Repository, status, e.Reason, e.Failures, retry, truncateString(lastError, 60))
+	}
+	return w.Flush()
+}
+
+func newRepoQuarantineClearCmd() *cobra.Command {
+	var (
+		all          bool
+		providerName string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clear [provider/owner/repo...]",
+		Short: "Release repositories from quarantine",
+		Long: `Release repositories from quarantine so that the next bulk run attempts
+them again, and forget their failures.`,
+		Example: `  # Release one repository after fixing its permissions
+  gz git repo quarantine clear github/myorg/huge-monorepo
+
+  # Release every GitLab repository
+  gz git repo quarantine clear --all --provider gitlab`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (len(args) > 0) {
+				return fmt.Errorf("specify repositories or --all")
+			}
+			if providerName != "" && !all {
+				return fmt.Errorf("--provider requires --all")
+			}
+
+			store, err := quarantine.Default()
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+
+			if all {
+				removed := store.ClearAll(providerName)
+				if err := store.Save(); err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "✅ Released %d repositories\n", removed)
+				return nil
+			}
+
+			for _, arg := range args {
+				providerPart, name, ok := strings.Cut(arg, "/")
+				if !ok || name == "" {
+					return fmt.Errorf("invalid repository %q: expected provider/owner/repo", arg)
+				}
+				if err := store.Clear(providerPart, name); err != nil {
+					return err
+				}
+			}
+			if err := store.Save(); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "✅ Released %d repositories\n", len(args))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Release every repository")
+	cmd.Flags().StringVar(&providerName, "provider", "", "With --all, only release repositories of this provider")
+
+	return cmd
+}
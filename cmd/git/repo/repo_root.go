@@ -20,15 +20,20 @@ func NewCmd() *cobra.Command {
 
 	// 하위 커맨드는 기존 파일들에서 등록한다
 	cmd.AddCommand(newRepoCloneCmd())
+	cmd.AddCommand(newRepoExplainFilterCmd())
 	cmd.AddCommand(newRepoCloneOrUpdateCmd())
 	cmd.AddCommand(newRepoListCmd())
 	cmd.AddCommand(newRepoCreateCmd())
 	cmd.AddCommand(newRepoDeleteCmd())
 	cmd.AddCommand(newRepoArchiveCmd())
 	cmd.AddCommand(newRepoSyncCmd())
+	cmd.AddCommand(newRepoSyncForksCmd())
 	cmd.AddCommand(newRepoMigrateCmd())
+	cmd.AddCommand(newRepoProtectCmd())
+	cmd.AddCommand(newRepoDepsCmd())
 	cmd.AddCommand(newRepoSearchCmd())
 	cmd.AddCommand(newRepoBulkUpdateCmd())
+	cmd.AddCommand(newRepoQuarantineCmd())
 
 	return cmd
 }
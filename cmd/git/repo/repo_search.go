@@ -0,0 +1,298 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/internal/git/reposearch"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+// SearchOptions contains options for repository search.
+type SearchOptions struct {
+	Query           string
+	Providers       []string
+	AllProviders    bool
+	BaseURLs        map[string]string
+	Owner           string
+	Language        string
+	Topic           string
+	IncludeArchived bool
+	KeepDuplicates  bool
+	Sort            string
+	Page            int
+	PerPage         int
+	Timeout         time.Duration
+	Format          string
+}
+
+// defaultSearchProviders are searched with --all-providers when no provider
+// is configured; both allow anonymous search of public repositories.
+var defaultSearchProviders = []string{"github", "gitlab"}
+
+// newRepoSearchCmd creates the repo search command.
+func newRepoSearchCmd() *cobra.Command {
+	opts := &SearchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "search [QUERY...]",
+		Short: "Search repositories across Git platforms",
+		Long: `Search repositories on one or more Git platforms and merge the results.
+
+With --all-providers the query is sent to every provider in the gz
+configuration (GitHub and GitLab when none is configured) at once. Results
+are normalized into one schema, forks and mirrors are folded into the
+repository they came from, and the merged list is ranked by relevance,
+stars and recent activity before it is paginated.
+
+A provider that fails is reported as a warning while the others' results are
+still shown. Tokens are taken from the configuration or from GITHUB_TOKEN,
+GITLAB_TOKEN and GITEA_TOKEN; without a token only public repositories are
+found.`,
+		Example: `  # Search every configured provider
+  gz git repo search --all-providers kubernetes operator
+
+  # Go repositories of one organization, most starred first
+  gz git repo search --all-providers --owner acme --language go --sort stars
+
+  # Second page as JSON, including a self-hosted Gitea
+  gz git repo search --provider github --provider gitea \
+    --base-url gitea=https://git.example.com/api/v1 --page 2 --format json cli`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			text := strings.TrimSpace(strings.Join(append([]string{opts.Query}, args...), " "))
+			return runRepoSearch(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), text, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Query, "query", "", "Search text (may also be given as arguments)")
+	cmd.Flags().StringSliceVar(&opts.Providers, "provider", nil, "Providers to search (github, gitlab, gitea)")
+	cmd.Flags().BoolVar(&opts.AllProviders, "all-providers", false, "Search every configured provider")
+	cmd.Flags().StringToStringVar(&opts.BaseURLs, "base-url", nil, "API base URL per provider for self-hosted instances (provider=url)")
+	cmd.Flags().StringVar(&opts.Owner, "owner", "", "Only repositories of this organization, group or user")
+	cmd.Flags().StringVar(&opts.Language, "language", "", "Only repositories in this language")
+	cmd.Flags().StringVar(&opts.Topic, "topic", "", "Only repositories with this topic")
+	cmd.Flags().BoolVar(&opts.IncludeArchived, "include-archived", false, "Include archived repositories")
+	cmd.Flags().BoolVar(&opts.KeepDuplicates, "keep-duplicates", false, "List forks and mirrors separately instead of folding them")
+	cmd.Flags().StringVar(&opts.Sort, "sort", reposearch.SortRelevance, "Sort order (relevance, stars, updated, name)")
+	cmd.Flags().IntVar(&opts.Page, "page", 1, "Page of the merged results")
+	cmd.Flags().IntVar(&opts.PerPage, "per-page", 20, "Results per page")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 30*time.Second, "Overall search timeout")
+	cmd.Flags().StringVar(&opts.Format, "format", "table", "Output format (table, json, yaml, csv)")
+
+	return cmd
+}
+
+// runRepoSearch executes the federated search.
+func runRepoSearch(ctx context.Context, out, errOut io.Writer, text string, opts *SearchOptions) error {
+	if err := opts.Validate(text); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+
+	backends, err := opts.backends()
+	if err != nil {
+		return err
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	query := reposearch.Query{
+		Text:            text,
+		Owner:           opts.Owner,
+		Language:        opts.Language,
+		Topic:           opts.Topic,
+		IncludeArchived: opts.IncludeArchived,
+	}
+	results, err := reposearch.Search(ctx, backends, query, reposearch.Options{
+		Page:           opts.Page,
+		PerPage:        opts.PerPage,
+		Sort:           opts.Sort,
+		KeepDuplicates: opts.KeepDuplicates,
+	})
+	if err != nil {
+		return err
+	}
+
+	providers := make([]string, 0, len(results.Errors))
+	for p := range results.Errors {
+		providers = append(providers, p)
+	}
+	sort.Strings(providers)
+	for _, p := range providers {
+		fmt.Fprintf(errOut, "Warning: %s search failed: %s\n", p, results.Errors[p])
+	}
+
+	switch opts.Format {
+	case "json":
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	case "yaml":
+		data, err := yaml.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("failed to marshal results as YAML: %w", err)
+		}
+		_, err = out.Write(data)
+		return err
+	case "csv":
+		return outputSearchCSV(out, results)
+	default:
+		return outputSearchTable(out, results)
+	}
+}
+
+// Validate validates the search options.
+func (opts *SearchOptions) Validate(text string) error {
+	if text == "" && opts.Owner == "" && opts.Topic == "" && opts.Language == "" {
+		return fmt.Errorf("a query or at least one of --owner, --topic or --language is required")
+	}
+	if opts.Page < 1 || opts.PerPage < 1 || opts.PerPage > 100 {
+		return fmt.Errorf("--page must be at least 1 and --per-page between 1 and 100")
+	}
+	switch opts.Format {
+	case "table", "json", "yaml", "csv":
+	default:
+		return fmt.Errorf("invalid output format: %s (valid: table, json, yaml, csv)", opts.Format)
+	}
+	return nil
+}
+
+// backends creates a search backend for every selected provider.
+func (opts *SearchOptions) backends() ([]reposearch.Backend, error) {
+	tokens := map[string]string{}
+	var configured []string
+	if cfg, err := config.LoadConfig(); err == nil && cfg != nil {
+		for name, p := range cfg.Providers {
+			configured = append(configured, name)
+			if !strings.HasPrefix(p.Token, "$") {
+				tokens[name] = p.Token
+			}
+		}
+	}
+
+	names := opts.Providers
+	switch {
+	case len(names) > 0:
+	case opts.AllProviders:
+		names = configured
+		for name := range opts.BaseURLs {
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			names = defaultSearchProviders
+		}
+	default:
+		names = []string{"github"}
+	}
+
+	seen := map[string]bool{}
+	backends := make([]reposearch.Backend, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		token := tokens[name]
+		if token == "" {
+			token = getTokenFromEnv(strings.ToUpper(name) + "_TOKEN")
+		}
+		b, err := reposearch.NewBackend(name, opts.BaseURLs[name], token)
+		if err != nil {
+			return nil, err
+		}
+		backends = append(backends, b)
+	}
+	sort.Slice(backends, func(i, j int) bool { return backends[i].Provider() < backends[j].Provider() })
+	return backends, nil
+}
+
+func outputSearchTable(out io.Writer, results *reposearch.Results) error {
+	if len(results.Hits) == 0 {
+		fmt.Fprintln(out, "No repositories found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tREPOSITORY\tSTARS\tLANGUAGE\tUPDATED\tSCORE\tDESCRIPTION")
+	for _, h := range results.Hits {
+		name := h.FullName
+		if n := len(h.Duplicates); n > 0 {
+			name += fmt.Sprintf(" (+%d)", n)
+		}
+		language := h.Language
+		if language == "" {
+			language = "-"
+		}
+		updated := "-"
+		if !h.UpdatedAt.IsZero() {
+			updated = h.UpdatedAt.Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%.2f\t%s\n",
+			h.ProviderType, name, h.Stars, language, updated, h.Score, truncateString(h.Description, 50))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	first := (results.Page-1)*results.PerPage + 1
+	fmt.Fprintf(out, "\nShowing %d-%d of %d", first, first+len(results.Hits)-1, results.Total)
+	if results.Truncated {
+		fmt.Fprint(out, "+")
+	}
+	fmt.Fprint(out, " results")
+	if results.HasNext {
+		fmt.Fprintf(out, " (next: --page %d)", results.Page+1)
+	}
+	fmt.Fprintln(out)
+	return nil
+}
+
+func outputSearchCSV(out io.Writer, results *reposearch.Results) error {
+	writer := csv.NewWriter(out)
+	header := []string{"Provider", "Full Name", "Score", "Stars", "Forks", "Language", "Fork", "Archived", "Updated At", "HTML URL", "Clone URL", "Duplicates", "Description"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, h := range results.Hits {
+		record := []string{
+			h.ProviderType,
+			h.FullName,
+			strconv.FormatFloat(h.Score, 'f', 3, 64),
+			strconv.Itoa(h.Stars),
+			strconv.Itoa(h.Forks),
+			h.Language,
+			strconv.FormatBool(h.Fork),
+			strconv.FormatBool(h.Archived),
+			formatTime(h.UpdatedAt),
+			h.HTMLURL,
+			h.CloneURL,
+			strings.Join(h.Duplicates, " "),
+			h.Description,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV record: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,328 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/git/sync"
+	"github.com/gizzahub/gzh-cli/pkg/cloud"
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// bundleSource is a repository to export, identified by its store name.
+type bundleSource struct {
+	Name string
+	URL  string
+}
+
+// newRepoSyncExportCmd exports incremental git bundles to object storage.
+func newRepoSyncExportCmd() *cobra.Command {
+	var (
+		from    string
+		store   string
+		match   string
+		workDir string
+		codec   string
+		full    bool
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export-bundles",
+		Short: "Export incremental git bundles to object storage for air-gapped sync",
+		Long: `Export the branches and tags of repositories as git bundles to object
+storage (S3, GCS, Azure Blob) or a directory, e.g. removable media.
+
+Each run uploads only the objects added since the previous export together
+with a manifest of the complete ref state. Use 'apply-bundles' on the
+receiving network to replay them.
+
+Bundles are stored uncompressed unless --compression or
+global.compression.bundle selects a codec (gzip, zstd, lz4); pack data in
+bundles is already compressed, so the gain is mostly in large text files.
+
+Store URLs:
+  s3://bucket/prefix[?region=...&endpoint=...]
+  gs://bucket/prefix
+  azblob://account/container/prefix
+  file:///mnt/usb/gz-bundles (or a plain path)`,
+		Example: `
+  # Export one repository to S3
+  gz git repo sync export-bundles --from github:myorg/repo --store s3://mirror-bucket/git
+
+  # Export an organization to a USB drive
+  gz git repo sync export-bundles --from github:myorg --store /media/usb/bundles
+
+  # Start over with self-contained bundles
+  gz git repo sync export-bundles --from github:myorg --store gs://bucket/git --full`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			objectStore, err := cloud.OpenObjectStore(ctx, store)
+			if err != nil {
+				return err
+			}
+
+			codecOverride, err := bundleCodec(codec)
+			if err != nil {
+				return err
+			}
+
+			sources, err := resolveBundleSources(ctx, from, match)
+			if err != nil {
+				return err
+			}
+
+			bs := sync.NewBundleSync(objectStore, sync.BundleSyncOptions{
+				WorkDir: workDir,
+				Codec:   codecOverride,
+				DryRun:  dryRun,
+				Output:  cmd.OutOrStdout(),
+			})
+
+			var failed int
+			for _, src := range sources {
+				if _, err := bs.Export(ctx, src.Name, src.URL, full); err != nil {
+					failed++
+					fmt.Fprintf(cmd.ErrOrStderr(), "❌ %s: %v\n", src.Name, err)
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d repositories failed to export", failed, len(sources))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Source (provider:org/repo, provider:org, git URL or local path)")
+	cmd.Flags().StringVar(&store, "store", "", "Object store URL (s3://, gs://, azblob://, file:// or a directory)")
+	cmd.Flags().StringVar(&match, "match", "", "Repository name pattern when exporting an organization")
+	cmd.Flags().StringVar(&workDir, "work-dir", sync.DefaultBundleWorkDir(), "Directory for local export mirrors")
+	cmd.Flags().StringVar(&codec, "compression", "", "Bundle compression (none, gzip, zstd, lz4; default from global.compression.bundle)")
+	cmd.Flags().BoolVar(&full, "full", false, "Export self-contained bundles instead of increments")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be exported without uploading")
+
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("store")
+
+	return cmd
+}
+
+// bundleCodec returns the codec named by --compression, or nil to use the
+// configured one.
+func bundleCodec(name string) (compress.Codec, error) {
+	if name == "" {
+		return nil, nil
+	}
+	return compress.Lookup(name)
+}
+
+// newRepoSyncApplyCmd applies exported git bundles on the receiving side.
+func newRepoSyncApplyCmd() *cobra.Command {
+	var (
+		store  string
+		repos  []string
+		dir    string
+		to     string
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "apply-bundles",
+		Short: "Apply git bundles from object storage to local mirrors",
+		Long: `Apply bundles written by 'export-bundles' to bare mirror repositories
+under --dir, in order and only once each. Mirrors are created on first use
+from the latest full bundle.
+
+With --to the updated branches and tags are pushed to a Git platform
+(provider:org) or, for a single repository, to a git URL.`,
+		Example: `
+  # Apply every repository found in the store
+  gz git repo sync apply-bundles --store /media/usb/bundles --dir /srv/mirrors
+
+  # Apply and push to an internal Gitea organization
+  gz git repo sync apply-bundles --store s3://mirror-bucket/git --to gitea:mirrors`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			objectStore, err := cloud.OpenObjectStore(ctx, store)
+			if err != nil {
+				return err
+			}
+
+			if len(repos) == 0 {
+				if repos, err = listBundleRepositories(ctx, objectStore); err != nil {
+					return err
+				}
+				if len(repos) == 0 {
+					return fmt.Errorf("no exported repositories found in %s", objectStore)
+				}
+			}
+
+			bs := sync.NewBundleSync(objectStore, sync.BundleSyncOptions{DryRun: dryRun, Output: cmd.OutOrStdout()})
+
+			var failed int
+			for _, name := range repos {
+				pushURL, err := resolveBundleDestination(ctx, to, name, len(repos))
+				if err == nil {
+					repoDir := filepath.Join(dir, filepath.FromSlash(name)+".git")
+					_, err = bs.Apply(ctx, name, repoDir, pushURL)
+				}
+				if err != nil {
+					failed++
+					fmt.Fprintf(cmd.ErrOrStderr(), "❌ %s: %v\n", name, err)
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d repositories failed to apply", failed, len(repos))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&store, "store", "", "Object store URL (s3://, gs://, azblob://, file:// or a directory)")
+	cmd.Flags().StringSliceVar(&repos, "repo", nil, "Repository (org/repo) to apply, repeatable (default: all in the store)")
+	cmd.Flags().StringVar(&dir, "dir", defaultBundleReceiveDir(), "Directory holding the received bare mirrors")
+	cmd.Flags().StringVar(&to, "to", "", "Push target after applying (provider:org or git URL)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show which bundles would be applied")
+
+	cmd.MarkFlagRequired("store")
+
+	return cmd
+}
+
+// defaultBundleReceiveDir returns the default directory for received mirrors.
+func defaultBundleReceiveDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "gzh-sync-received")
+	}
+	return filepath.Join(homeDir, ".config", "gzh-manager", "sync", "received")
+}
+
+// isGitLocation reports whether s is a git URL or local path rather than a provider target.
+func isGitLocation(s string) bool {
+	if strings.Contains(s, "://") || strings.HasPrefix(s, "git@") {
+		return true
+	}
+	_, err := os.Stat(s)
+	return err == nil
+}
+
+// resolveBundleSources turns --from into repositories with clone URLs.
+func resolveBundleSources(ctx context.Context, from, match string) ([]bundleSource, error) {
+	if isGitLocation(from) {
+		name := strings.TrimSuffix(path.Base(strings.TrimRight(filepath.ToSlash(from), "/")), ".git")
+		return []bundleSource{{Name: name, URL: from}}, nil
+	}
+
+	target, err := sync.ParseTarget(from)
+	if err != nil {
+		return nil, err
+	}
+
+	gitProvider, err := getGitProvider(target.Provider, target.Org)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source provider: %w", err)
+	}
+
+	if target.IsRepository() {
+		repo, err := gitProvider.GetRepository(ctx, target.FullName())
+		if err != nil {
+			return nil, fmt.Errorf("get %s: %w", target.FullName(), err)
+		}
+		return []bundleSource{{Name: target.FullName(), URL: repo.CloneURL}}, nil
+	}
+
+	var sources []bundleSource
+	for page := 1; ; page++ {
+		list, err := gitProvider.ListRepositories(ctx, provider.ListOptions{
+			Organization: target.Org,
+			Type:         "all",
+			Sort:         "full_name",
+			Direction:    "asc",
+			Page:         page,
+			PerPage:      100,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list repositories in %s: %w", target.Org, err)
+		}
+
+		for _, repo := range list.Repositories {
+			if match != "" {
+				if ok, _ := filepath.Match(match, repo.Name); !ok {
+					continue
+				}
+			}
+			sources = append(sources, bundleSource{Name: target.Org + "/" + repo.Name, URL: repo.CloneURL})
+		}
+
+		if !list.HasNext {
+			return sources, nil
+		}
+	}
+}
+
+// listBundleRepositories finds repositories with a manifest in the store.
+func listBundleRepositories(ctx context.Context, store cloud.ObjectStore) ([]string, error) {
+	objects, err := store.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var repos []string
+	for _, obj := range objects {
+		if name, ok := strings.CutSuffix(obj.Key, "/manifest.json"); ok {
+			repos = append(repos, name)
+		}
+	}
+
+	return repos, nil
+}
+
+// resolveBundleDestination returns the push URL for a received repository.
+func resolveBundleDestination(ctx context.Context, to, name string, total int) (string, error) {
+	if to == "" {
+		return "", nil
+	}
+	if isGitLocation(to) {
+		if total > 1 {
+			return "", fmt.Errorf("--to %s is a single repository but %d repositories are being applied", to, total)
+		}
+		return to, nil
+	}
+
+	target, err := sync.ParseTarget(to)
+	if err != nil {
+		return "", err
+	}
+
+	repoName := target.Repo
+	if repoName == "" {
+		repoName = path.Base(name)
+	}
+
+	gitProvider, err := getGitProvider(target.Provider, target.Org)
+	if err != nil {
+		return "", fmt.Errorf("failed to get destination provider: %w", err)
+	}
+
+	repo, err := gitProvider.GetRepository(ctx, target.Org+"/"+repoName)
+	if err != nil {
+		return "", fmt.Errorf("destination %s/%s: %w", target.Org, repoName, err)
+	}
+
+	return repo.CloneURL, nil
+}
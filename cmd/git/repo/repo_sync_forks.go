@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+	"github.com/gizzahub/gzh-cli/internal/git/forksync"
+)
+
+// SyncForksOptions contains options for syncing forks with their upstream.
+type SyncForksOptions struct {
+	Root        string
+	Policy      string
+	Provider    string
+	BaseURL     string
+	Detect      bool
+	OpenMRs     bool
+	DryRun      bool
+	Concurrency int
+	JSON        bool
+}
+
+// newRepoSyncForksCmd creates the repo sync-forks command.
+func newRepoSyncForksCmd() *cobra.Command {
+	opts := &SyncForksOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "sync-forks [root]",
+		Short: "Bring forks up to date with their upstream repositories",
+		Long: `Fetch upstream changes into forks and fast-forward them or open merge
+requests, also when fork and upstream live on different platforms (for
+example internal GitLab forks of GitHub projects).
+
+Every clone below the root directory (default: the current directory, in the
+<root>/<org>/<repo> layout of bulk clones) is treated as a clone of a fork,
+its origin remote. The upstream is taken from, in order:
+
+  1. the upstream setting of the repository in the policy file
+  2. the clone's "upstream" remote
+  3. with --detect, the fork metadata of the fork's platform: the parent of
+     GitHub and Gitea forks, the source project of GitLab forks, and the
+     import URL of projects imported or mirrored from another platform
+
+A fork branch that is only behind is fast-forwarded by pushing the upstream
+commits to it. A diverged branch gets a merge request from the branch
+gz/upstream-<branch> with --open-mrs; files the merge conflicts in are
+reported. Branches are updated on the fork; local working trees are left
+untouched.
+
+A policy file sets the branches and sync mode per repository:
+
+  default:
+    mode: auto            # auto, fast-forward, merge-request, skip
+  repos:
+    oss/kubernetes:
+      upstream: https://github.com/kubernetes/kubernetes.git
+      upstream_branch: master
+      branch: main
+      mode: merge-request
+
+Tokens are read from GITHUB_TOKEN, GITLAB_TOKEN and GITEA_TOKEN.`,
+		Example: `  # Report which forks below ./forks are behind their upstream
+  gz git repo sync-forks ./forks --dry-run
+
+  # Detect GitHub upstreams of GitLab forks and open merge requests
+  gz git repo sync-forks ./forks --provider gitlab --base-url https://gitlab.company.com/api/v4 --detect --open-mrs
+
+  # Follow a per-repository policy, as JSON
+  gz git repo sync-forks ./forks --policy forks.yaml --json`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Root = "."
+			if len(args) > 0 {
+				opts.Root = args[0]
+			}
+			return runRepoSyncForks(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Policy, "policy", "", "Fork sync policy file (YAML)")
+	cmd.Flags().StringVar(&opts.Provider, "provider", "github", "Git platform hosting the forks (github, gitlab, gitea)")
+	cmd.Flags().StringVar(&opts.BaseURL, "base-url", "", "API base URL (for self-hosted instances)")
+	cmd.Flags().BoolVar(&opts.Detect, "detect", false, "Detect upstreams from the platform's fork metadata")
+	cmd.Flags().BoolVar(&opts.OpenMRs, "open-mrs", false, "Open merge requests for forks that cannot be fast-forwarded")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Fetch and compare without changing the forks")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", 4, "Number of repositories processed at once")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Print the results as JSON")
+
+	return cmd
+}
+
+// runRepoSyncForks syncs the forks below the root directory.
+func runRepoSyncForks(ctx context.Context, out io.Writer, opts *SyncForksOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	policy := &forksync.Policy{}
+	if opts.Policy != "" {
+		var err error
+		if policy, err = forksync.LoadPolicy(opts.Policy); err != nil {
+			return err
+		}
+	}
+
+	syncer := &forksync.Syncer{Policy: policy, DryRun: opts.DryRun, Concurrency: opts.Concurrency}
+	tokenVar := strings.ToUpper(opts.Provider) + "_TOKEN"
+	token := getTokenFromEnv(tokenVar)
+	if opts.Detect {
+		// 공개 저장소는 토큰 없이도 조회할 수 있다
+		detector, err := forksync.NewDetector(opts.Provider, opts.BaseURL, token)
+		if err != nil {
+			return err
+		}
+		syncer.Detector = detector
+	}
+	if opts.OpenMRs && !opts.DryRun {
+		if token == "" {
+			return fmt.Errorf("%s is not set", tokenVar)
+		}
+		opener, err := depupdate.NewPullRequestOpener(opts.Provider, opts.BaseURL, token)
+		if err != nil {
+			return err
+		}
+		syncer.Opener = opener
+	}
+
+	repos, err := depupdate.DiscoverRepositories(opts.Root)
+	if err != nil {
+		return err
+	}
+	if len(repos) == 0 {
+		return fmt.Errorf("no cloned repositories found below %s", opts.Root)
+	}
+	if !opts.JSON {
+		fmt.Fprintf(out, "🔍 Checking forks of %d repositories below %s\n\n", len(repos), opts.Root)
+	}
+
+	results := syncer.Sync(ctx, repos)
+
+	if opts.JSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+	} else {
+		forksync.PrintTable(out, results)
+		forksync.PrintSummary(out, results)
+	}
+
+	if failed := forksync.Counts(results)[forksync.StatusFailed]; failed > 0 {
+		return fmt.Errorf("%d forks could not be synced", failed)
+	}
+	return nil
+}
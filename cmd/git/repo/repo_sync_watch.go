@@ -0,0 +1,153 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+	"github.com/gizzahub/gzh-cli/internal/git/sync"
+	"github.com/gizzahub/gzh-cli/internal/tui/syncstatus"
+)
+
+// newRepoSyncWatchCmd mirrors local working trees to a remote as they change.
+func newRepoSyncWatchCmd() *cobra.Command {
+	var (
+		opts  sync.WatchOptions
+		noTUI bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "watch [path...]",
+		Short: "Watch working trees and push changes to a mirror in real time",
+		Long: `Watch local working trees and mirror them, including uncommitted changes,
+to a sync branch on a mirror remote.
+
+Bursts of changes are debounced; once the tree has been quiet for --debounce
+(or changes kept coming for --max-delay) a snapshot is committed to the sync
+branch and pushed. The checked-out branch, its index and the working tree are
+never modified, and new commits on HEAD are merged into the sync branch so
+that it always fast-forwards.
+
+A path that is not a working tree is scanned for clones; clones without the
+mirror remote are skipped. Paths matching --ignore are neither watched nor
+committed; files ignored by .gitignore are never committed.
+
+A repository enters the conflict state when the mirror's sync branch has
+commits the local one lacks (e.g. pushed from another machine) or when the
+working tree has unmerged paths. It is retried on the next change.`,
+		Example: `
+  # Watch the current repository, pushing to the "mirror" remote
+  gz git repo sync watch
+
+  # Watch every clone below ~/src with a custom branch and remote
+  gz git repo sync watch ~/src --branch wip/laptop --remote backup
+
+  # Ignore build output and log lines instead of showing the status view
+  gz git repo sync watch --ignore 'dist/' --ignore '*.log' --no-tui`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			if len(args) == 0 {
+				args = []string{"."}
+			}
+
+			paths, err := watchPaths(ctx, args, opts.Remote, cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			useTUI := !noTUI && isTerminal(os.Stdin) && isTerminal(os.Stdout)
+			if !useTUI {
+				opts.OnUpdate = func(s sync.WatchStatus) { logWatchStatus(out, s) }
+			}
+			watcher, err := sync.NewWorkTreeWatcher(paths, opts)
+			if err != nil {
+				return err
+			}
+
+			if !useTUI {
+				fmt.Fprintf(out, "👀 Watching %d repositories (branch %s → %s), press Ctrl+C to stop\n",
+					len(paths), opts.Branch, opts.Remote)
+				return watcher.Run(ctx)
+			}
+
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			done := make(chan error, 1)
+			go func() { done <- watcher.Run(ctx) }()
+
+			title := fmt.Sprintf("gz sync watch · %s → %s", opts.Branch, opts.Remote)
+			syncNow := func(path string) { watcher.Sync(ctx, path) }
+			if err := syncstatus.Run(ctx, title, watcher.Status, syncNow, cmd.InOrStdin(), out); err != nil {
+				return err
+			}
+			cancel()
+			return <-done
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Branch, "branch", sync.DefaultWatchBranch, "Branch receiving the auto-commits")
+	cmd.Flags().StringVar(&opts.Remote, "remote", sync.DefaultWatchRemote, "Mirror remote the sync branch is pushed to")
+	cmd.Flags().DurationVar(&opts.Debounce, "debounce", sync.DefaultWatchDebounce, "Quiet period after the last change before syncing")
+	cmd.Flags().DurationVar(&opts.MaxDelay, "max-delay", sync.DefaultWatchMaxDelay, "Sync at least this often while changes keep coming")
+	cmd.Flags().StringArrayVar(&opts.Ignore, "ignore", nil, "Glob pattern of paths to ignore (repeatable; no slash matches at any depth)")
+	cmd.Flags().BoolVar(&noTUI, "no-tui", false, "Log sync events instead of showing the status view")
+
+	return cmd
+}
+
+// watchPaths expands the arguments to working trees, scanning directories
+// that are not clones themselves.
+func watchPaths(ctx context.Context, args []string, remote string, warn io.Writer) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		// 저장소 내부 경로는 그대로 넘기고, 원격이 없으면 NewWorkTreeWatcher가 오류를 보고한다
+		if exec.CommandContext(ctx, "git", "-C", arg, "rev-parse", "--show-toplevel").Run() == nil {
+			paths = append(paths, arg)
+			continue
+		}
+		repos, err := depupdate.DiscoverRepositories(arg)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos {
+			if err := exec.CommandContext(ctx, "git", "-C", repo.Path, "remote", "get-url", remote).Run(); err != nil {
+				fmt.Fprintf(warn, "⚠️  skipping %s: no %q remote\n", repo.Path, remote)
+				continue
+			}
+			paths = append(paths, repo.Path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no working trees with a %q remote found", remote)
+	}
+	return paths, nil
+}
+
+func logWatchStatus(out io.Writer, s sync.WatchStatus) {
+	stamp := time.Now().Format("15:04:05")
+	switch s.State {
+	case sync.WatchIdle:
+		if s.Commit != "" {
+			fmt.Fprintf(out, "%s ✅ %s synced %.8s\n", stamp, s.Path, s.Commit)
+		}
+	case sync.WatchConflict:
+		fmt.Fprintf(out, "%s ⚠️  %s %s\n", stamp, s.Path, s.Error)
+	case sync.WatchError:
+		fmt.Fprintf(out, "%s ❌ %s %s\n", stamp, s.Path, s.Error)
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,433 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package worktree implements the multi-repository worktree commands under
+// gz git worktree.
+package worktree
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+	"github.com/gizzahub/gzh-cli/internal/git/repostats"
+	"github.com/gizzahub/gzh-cli/internal/git/worktree"
+)
+
+// selectOptions choose the clones a command works on.
+type selectOptions struct {
+	match       []string
+	exclude     []string
+	concurrency int
+}
+
+func (o *selectOptions) repositories(args []string) (string, []depupdate.Repository, error) {
+	root := "."
+	if len(args) > 0 {
+		root = args[0]
+	}
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return "", nil, err
+	}
+	repos, err := worktree.Repositories(root, o.match, o.exclude)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(repos) == 0 {
+		return "", nil, fmt.Errorf("no matching clones found below %s", root)
+	}
+	return root, repos, nil
+}
+
+// NewGitWorktreeCmd creates the git worktree command.
+func NewGitWorktreeCmd() *cobra.Command {
+	sel := &selectOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "worktree",
+		Short: "Manage worktrees of one branch across many clones",
+		Long: `Manage linked Git worktrees across all clones below a directory, in the
+<root>/<org>/<repo> layout of bulk clones.
+
+'create' checks out a branch of every clone into a parallel tree, by
+default <root>@<branch>/<org>/<repo>, so that a release branch of dozens of
+repositories can be worked on next to their main checkouts. 'list' shows
+the worktrees and which of them are stale, and 'prune' removes those.
+
+A worktree is stale when its directory was deleted, its branch is merged
+into the default branch of origin (or --base), the upstream branch was
+deleted, or its last commit is older than --older-than. Locked worktrees
+and worktrees with uncommitted changes are never removed without --force.
+
+Available Commands:
+  create   Create a worktree for a branch in every clone
+  list     List worktrees and whether they are stale
+  prune    Remove stale worktrees
+
+Examples:
+  gz git worktree create release/2.4 ~/src/acme --fetch
+  gz git worktree list ~/src/acme --stale
+  gz git worktree prune ~/src/acme --delete-branch --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&sel.match, "match", nil, "Only clones whose org/name or name matches these globs")
+	cmd.PersistentFlags().StringSliceVar(&sel.exclude, "exclude", nil, "Skip clones whose org/name or name matches these globs")
+	cmd.PersistentFlags().IntVar(&sel.concurrency, "concurrency", 4, "Number of clones processed at once")
+
+	cmd.AddCommand(newCreateCmd(sel))
+	cmd.AddCommand(newListCmd(sel))
+	cmd.AddCommand(newPruneCmd(sel))
+
+	return cmd
+}
+
+func newCreateCmd(sel *selectOptions) *cobra.Command {
+	var (
+		opts       worktree.CreateOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create <branch> [root]",
+		Short: "Create a worktree for a branch in every clone",
+		Long: `Create a worktree for the branch in every clone below root (default: the
+current directory).
+
+The worktree checks out the local branch, or else a new local branch
+tracking origin/<branch>. Clones that have neither are skipped, unless
+--create-branch creates the branch from --base (default: the default branch
+of origin). Clones that have the branch checked out in a worktree already
+are left as they are.`,
+		Example: `  # Check out release/2.4 of every clone into ~/src/acme@release-2.4
+  gz git worktree create release/2.4 ~/src/acme --fetch
+
+  # Start a hotfix branch in the api-* repositories only
+  gz git worktree create hotfix/cve-1234 --match 'api-*' --create-branch --base origin/release/2.4`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, repos, err := sel.repositories(args[1:])
+			if err != nil {
+				return err
+			}
+			opts.Branch = args[0]
+			opts.Root = root
+			opts.Concurrency = sel.concurrency
+			if opts.Into == "" {
+				opts.Into = root + "@" + worktree.BranchSlug(opts.Branch)
+			} else if opts.Into, err = filepath.Abs(opts.Into); err != nil {
+				return err
+			}
+
+			results := worktree.Create(cmd.Context(), repos, opts)
+			if jsonOutput {
+				if err := writeJSON(cmd.OutOrStdout(), results); err != nil {
+					return err
+				}
+			} else {
+				printResults(cmd.OutOrStdout(), results)
+				printSummary(cmd.OutOrStdout(), results, opts.DryRun)
+			}
+			return failures(results)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Into, "into", "", "Directory to create the worktrees in (default <root>@<branch>)")
+	cmd.Flags().BoolVar(&opts.Fetch, "fetch", false, "Fetch origin before looking for the branch")
+	cmd.Flags().BoolVar(&opts.CreateBranch, "create-branch", false, "Create the branch in clones that do not have it")
+	cmd.Flags().StringVar(&opts.Base, "base", "", "Start point of created branches (default: the default branch of origin)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be created without changing anything")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// listColumns are the columns of `gz git worktree list`.
+var listColumns = []cli.Column{
+	{Name: "repository"},
+	{Name: "branch"},
+	{Name: "path"},
+	{Name: "last-commit", NoTruncate: true},
+	{Name: "state", NoTruncate: true},
+	{Name: "head", Hidden: true},
+}
+
+// staleFlags are the flags deciding which worktrees are stale.
+type staleFlags struct {
+	base      string
+	olderThan string
+}
+
+func (f *staleFlags) add(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.base, "base", "", "Branch that merged worktree branches are merged into (default: the default branch of origin)")
+	cmd.Flags().StringVar(&f.olderThan, "older-than", "30d", "Worktrees whose last commit is older are stale (e.g. 30d, 2w, 6mo; 0 disables)")
+}
+
+func (f *staleFlags) options() (worktree.StaleOptions, error) {
+	opts := worktree.StaleOptions{Base: f.base}
+	if f.olderThan != "" && f.olderThan != "0" {
+		age, err := repostats.ParseAge(f.olderThan)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --older-than: %w", err)
+		}
+		opts.MaxAge = age
+	}
+	return opts, nil
+}
+
+func newListCmd(sel *selectOptions) *cobra.Command {
+	var (
+		stale      staleFlags
+		staleOnly  bool
+		table      cli.TableOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list [root]",
+		Short: "List worktrees and whether they are stale",
+		Example: `  # All worktrees of the clones below the current directory
+  gz git worktree list
+
+  # Only stale worktrees, counting a week without commits as stale
+  gz git worktree list ~/src/acme --stale --older-than 1w`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := table.Validate(listColumns...); err != nil {
+				return err
+			}
+			staleOpts, err := stale.options()
+			if err != nil {
+				return err
+			}
+			_, repos, err := sel.repositories(args)
+			if err != nil {
+				return err
+			}
+
+			worktrees, listErr := worktree.List(cmd.Context(), repos, staleOpts, sel.concurrency)
+			if staleOnly {
+				kept := worktrees[:0]
+				for _, w := range worktrees {
+					if w.Stale() {
+						kept = append(kept, w)
+					}
+				}
+				worktrees = kept
+			}
+
+			if jsonOutput {
+				if worktrees == nil {
+					worktrees = []worktree.Worktree{}
+				}
+				if err := writeJSON(cmd.OutOrStdout(), worktrees); err != nil {
+					return err
+				}
+				return listErr
+			}
+
+			if len(worktrees) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No worktrees found")
+				return listErr
+			}
+			t := cli.NewTable(listColumns...)
+			for _, w := range worktrees {
+				branch := w.Branch
+				if branch == "" {
+					branch = "(detached)"
+				}
+				t.AddRow(w.Repository.FullName(), branch, w.Path, formatAge(w.LastCommit), state(w), shortHead(w.Head))
+			}
+			if err := t.Render(cmd.OutOrStdout(), table); err != nil {
+				return err
+			}
+			return listErr
+		},
+	}
+
+	stale.add(cmd)
+	cmd.Flags().BoolVar(&staleOnly, "stale", false, "Only list stale worktrees")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cli.AddTableFlags(cmd, &table, listColumns...)
+
+	return cmd
+}
+
+func newPruneCmd(sel *selectOptions) *cobra.Command {
+	var (
+		stale      staleFlags
+		opts       worktree.PruneOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prune [root]",
+		Short: "Remove stale worktrees",
+		Long: `Remove the stale worktrees of the clones below root (default: the current
+directory). Locked worktrees are kept, and so are worktrees with
+uncommitted changes unless --force is given. With --delete-branch, the
+branches of removed worktrees are deleted as well when they are merged.`,
+		Example: `  # Preview what would be removed
+  gz git worktree prune ~/src/acme --dry-run
+
+  # Remove worktrees of merged release branches and delete the branches
+  gz git worktree prune ~/src/acme --older-than 0 --delete-branch`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			staleOpts, err := stale.options()
+			if err != nil {
+				return err
+			}
+			_, repos, err := sel.repositories(args)
+			if err != nil {
+				return err
+			}
+			opts.StaleOptions = staleOpts
+			opts.Concurrency = sel.concurrency
+
+			results := worktree.Prune(cmd.Context(), repos, opts)
+			if jsonOutput {
+				if results == nil {
+					results = []worktree.Result{}
+				}
+				if err := writeJSON(cmd.OutOrStdout(), results); err != nil {
+					return err
+				}
+				return failures(results)
+			}
+			if len(results) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No stale worktrees")
+				return nil
+			}
+			printResults(cmd.OutOrStdout(), results)
+			printSummary(cmd.OutOrStdout(), results, opts.DryRun)
+			return failures(results)
+		},
+	}
+
+	stale.add(cmd)
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Also remove worktrees with uncommitted changes")
+	cmd.Flags().BoolVar(&opts.DeleteBranch, "delete-branch", false, "Delete merged branches of removed worktrees")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be removed without changing anything")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+var statusIcons = map[worktree.Status]string{
+	worktree.StatusCreated:     "✓",
+	worktree.StatusWouldCreate: "+",
+	worktree.StatusExists:      "=",
+	worktree.StatusRemoved:     "🗑 ",
+	worktree.StatusWouldRemove: "-",
+	worktree.StatusKept:        "⚠️",
+	worktree.StatusSkipped:     "·",
+	worktree.StatusFailed:      "✗",
+}
+
+func printResults(w io.Writer, results []worktree.Result) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, r := range results {
+		detail := r.Path
+		switch {
+		case r.Error != "":
+			detail = r.Error
+		case r.Detail != "" && r.Path != "":
+			detail += " (" + r.Detail + ")"
+		case r.Detail != "":
+			detail = r.Detail
+		}
+		fmt.Fprintf(tw, "%s %s\t%s\t%s\n", statusIcons[r.Status], r.Repository.FullName(), r.Status, detail)
+	}
+	tw.Flush()
+}
+
+func printSummary(w io.Writer, results []worktree.Result, dryRun bool) {
+	counts := make(map[worktree.Status]int)
+	for _, r := range results {
+		counts[r.Status]++
+	}
+	var parts []string
+	for _, s := range []worktree.Status{
+		worktree.StatusCreated, worktree.StatusWouldCreate, worktree.StatusExists, worktree.StatusRemoved,
+		worktree.StatusWouldRemove, worktree.StatusKept, worktree.StatusSkipped, worktree.StatusFailed,
+	} {
+		if counts[s] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[s], s))
+		}
+	}
+	prefix := ""
+	if dryRun {
+		prefix = "(dry run) "
+	}
+	fmt.Fprintf(w, "\n%s%s\n", prefix, strings.Join(parts, ", "))
+}
+
+func failures(results []worktree.Result) error {
+	failed := 0
+	for _, r := range results {
+		if r.Status == worktree.StatusFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d worktree operation(s) failed", failed)
+	}
+	return nil
+}
+
+func state(w worktree.Worktree) string {
+	var flags []string
+	if w.Locked {
+		flags = append(flags, "locked")
+	}
+	if w.Dirty {
+		flags = append(flags, "dirty")
+	}
+	if w.Stale() {
+		flags = append(flags, "stale: "+strings.Join(w.Reasons, ", "))
+	}
+	if len(flags) == 0 {
+		return "in use"
+	}
+	return strings.Join(flags, "; ")
+}
+
+func shortHead(head string) string {
+	if len(head) > 12 {
+		return head[:12]
+	}
+	return head
+}
+
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
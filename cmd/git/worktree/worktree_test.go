@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package worktree
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/git/worktree"
+)
+
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewGitWorktreeCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestWorktreeCmd(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root := filepath.Join(t.TempDir(), "src")
+	for _, name := range []string{"api", "web"} {
+		repo := filepath.Join(root, "acme", name)
+		require.NoError(t, os.MkdirAll(repo, 0o755))
+		for _, args := range [][]string{{"init", "-q", "--initial-branch=main"}, {"commit", "-q", "--allow-empty", "-m", "init"}} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repo
+			cmd.Env = append(os.Environ(),
+				"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+				"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+			out, err := cmd.CombinedOutput()
+			require.NoError(t, err, string(out))
+		}
+	}
+
+	out, err := run(t, "create", "release/1.0", root)
+	require.NoError(t, err, out)
+	assert.Contains(t, out, "2 skipped")
+
+	out, err = run(t, "create", "release/1.0", root, "--create-branch", "--match", "api")
+	require.NoError(t, err, out)
+	assert.Contains(t, out, "acme/api  created")
+	tree := filepath.Join(root+"@release-1.0", "acme", "api")
+	assert.DirExists(t, tree)
+
+	out, err = run(t, "list", root, "--json")
+	require.NoError(t, err, out)
+	var worktrees []worktree.Worktree
+	require.NoError(t, json.Unmarshal([]byte(out), &worktrees))
+	require.Len(t, worktrees, 1)
+	assert.Equal(t, "release/1.0", worktrees[0].Branch)
+	assert.False(t, worktrees[0].Stale())
+
+	out, err = run(t, "list", root, "--stale")
+	require.NoError(t, err, out)
+	assert.Contains(t, out, "No worktrees found")
+
+	require.NoError(t, os.RemoveAll(tree))
+	out, err = run(t, "prune", root, "--dry-run")
+	require.NoError(t, err, out)
+	assert.Contains(t, out, "would-remove")
+	assert.Contains(t, out, "(dry run) 1 would-remove")
+
+	out, err = run(t, "prune", root)
+	require.NoError(t, err, out)
+	assert.Contains(t, out, "1 removed")
+
+	out, err = run(t, "prune", root)
+	require.NoError(t, err, out)
+	assert.Contains(t, out, "No stale worktrees")
+
+	_, err = run(t, "list", root, "--older-than", "soon")
+	assert.ErrorContains(t, err, "invalid --older-than")
+}
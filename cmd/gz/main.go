@@ -8,15 +8,31 @@ import (
 	"os"
 
 	apprunner "github.com/gizzahub/gzh-cli/internal/apprunner"
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	gzerrors "github.com/gizzahub/gzh-cli/internal/errors"
 	"github.com/gizzahub/gzh-cli/internal/version"
+	"github.com/gizzahub/gzh-cli/pkg/plugins"
 )
 
 func main() {
+	// Sandboxed plugin launches re-execute gz as a helper before anything else runs
+	plugins.SandboxMain()
+
 	// Create and run the application
 	runner := apprunner.NewRunner(version.Version)
 
 	if err := runner.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", err)
-		os.Exit(1)
+		// --format json 에러는 이미 JSON 봉투로 출력됨
+		if !gzerrors.IsReported(err) {
+			linker := cli.NewLinker(os.Stderr)
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			for _, hint := range gzerrors.Hints(err) {
+				fmt.Fprintf(os.Stderr, "  💡 %s\n", linker.Linkify(hint))
+			}
+			if code := gzerrors.Code(err); code != "" {
+				fmt.Fprintf(os.Stderr, "  📖 %s\n", linker.LinkWithURL(code, gzerrors.DocsURL))
+			}
+		}
+		os.Exit(gzerrors.ExitCode(err))
 	}
 }
@@ -0,0 +1,328 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/alerting"
+	"github.com/gizzahub/gzh-cli/pkg/debug"
+	"github.com/gizzahub/gzh-cli/pkg/memory"
+)
+
+// alertsOptions holds flags shared by the alerts subcommands.
+type alertsOptions struct {
+	config string
+	state  string
+}
+
+func newAlertsCmd() *cobra.Command {
+	opts := &alertsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "alerts",
+		Short: "Evaluate alert rules with silence windows and escalation",
+		Long: `Evaluate threshold and rate-of-change rules over metrics scraped from
+the sources in the alert configuration (default
+~/.config/gzh-manager/alerts.yaml).
+
+A rule fires once it matched for its 'for' duration and is announced on
+its channels. Silences hold back notifications during one-off or weekly
+maintenance windows. Firing alerts that stay unacknowledged for the
+rule's escalation delay are sent to the escalation channels as well.
+
+Example configuration:
+  sources:
+    - name: sync-server
+      url: http://localhost:9090/metrics
+  channels:
+    ops:
+      type: slack
+      webhook_url: ${SLACK_WEBHOOK_URL}
+    oncall:
+      type: email
+      smtp: smtp.example.com:587
+      from: gz@example.com
+      to: [oncall@example.com]
+  rules:
+    - name: github-rate-limit
+      metric: gz_api_rate_limit_remaining
+      labels: {provider: github}
+      op: "<"
+      value: 200
+      for: 5m
+      severity: critical
+      channels: [ops]
+      escalation:
+        after: 30m
+        channels: [oncall]
+  silences:
+    - name: weekend-maintenance
+      weekly: {days: [sat], from: "22:00", to: "02:00", timezone: Europe/Berlin}
+
+Examples:
+  gz monitoring alerts validate
+  gz monitoring alerts check
+  gz monitoring alerts watch --interval 1m
+  gz monitoring alerts list
+  gz monitoring alerts ack 3f9c2a`,
+		SilenceUsage: true,
+	}
+
+	cmd.PersistentFlags().StringVar(&opts.config, "config", alerting.DefaultConfigPath(), "Alert configuration file")
+	cmd.PersistentFlags().StringVar(&opts.state, "state", alerting.DefaultStatePath(), "Alert state file")
+
+	cmd.AddCommand(newAlertsCheckCmd(opts))
+	cmd.AddCommand(newAlertsWatchCmd(opts))
+	cmd.AddCommand(newAlertsListCmd(opts))
+	cmd.AddCommand(newAlertsAckCmd(opts))
+	cmd.AddCommand(newAlertsValidateCmd(opts))
+
+	return cmd
+}
+
+func newAlertsCheckCmd(opts *alertsOptions) *cobra.Command {
+	var metricsFile string
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Scrape the sources once and evaluate all rules",
+		Long: `Scrape the sources once, evaluate all rules and send notifications.
+Run it from cron or a systemd timer, or use 'watch' instead.
+
+With --metrics-file the samples are read from a Prometheus text file
+instead of the configured sources.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := alerting.LoadConfig(opts.config)
+			if err != nil {
+				return err
+			}
+			state, err := alerting.LoadState(opts.state)
+			if err != nil {
+				return err
+			}
+
+			engine := alerting.NewEngine(cfg, state, nil)
+			return runCheck(cmd.Context(), cmd.OutOrStdout(), engine, state, metricsFile)
+		},
+	}
+
+	cmd.Flags().StringVar(&metricsFile, "metrics-file", "", "Read samples from a Prometheus text file instead of scraping")
+
+	return cmd
+}
+
+func newAlertsWatchCmd(opts *alertsOptions) *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Evaluate the rules periodically until interrupted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+
+			cfg, err := alerting.LoadConfig(opts.config)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "👀 Evaluating %d rules every %s (Ctrl+C to stop)\n", len(cfg.Rules), interval)
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				// ack 명령이 남긴 변경을 반영하도록 매번 상태를 다시 읽는다
+				state, err := alerting.LoadState(opts.state)
+				if err != nil {
+					return err
+				}
+				engine := alerting.NewEngine(cfg, state, nil)
+				if err := runCheck(ctx, out, engine, state, ""); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  %v\n", err)
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "Evaluation interval")
+	memory.AddLeakFlags(cmd)
+	debug.AddTriggerFlags(cmd)
+
+	return cmd
+}
+
+// runCheck collects samples, evaluates them and saves the state. Scrape
+// and delivery failures are reported after the state has been saved.
+func runCheck(ctx context.Context, out io.Writer, engine *alerting.Engine, state *alerting.State, metricsFile string) error {
+	var samples []alerting.Sample
+	var collectErr error
+	if metricsFile != "" {
+		f, err := os.Open(metricsFile)
+		if err != nil {
+			return fmt.Errorf("failed to open metrics file: %w", err)
+		}
+		samples, err = alerting.ParseSamples(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", metricsFile, err)
+		}
+	} else {
+		samples, collectErr = engine.Collect(ctx, nil)
+	}
+
+	events, evalErr := engine.Evaluate(ctx, samples)
+	if err := state.Save(); err != nil {
+		return err
+	}
+
+	now := time.Now().Format("15:04:05")
+	for _, ev := range events {
+		fmt.Fprintf(out, "%s %s %s %s → %v\n", now, eventIcon(ev.Kind), ev.Alert.Rule, ev.Alert.Series, ev.Channels)
+	}
+
+	firing := 0
+	for _, a := range state.List() {
+		if a.Status == alerting.StatusFiring {
+			firing++
+		}
+	}
+	fmt.Fprintf(out, "%s Evaluated %d samples: %d firing, %d notifications\n", now, len(samples), firing, len(events))
+
+	if collectErr != nil {
+		return collectErr
+	}
+	return evalErr
+}
+
+func eventIcon(kind alerting.EventKind) string {
+	switch kind {
+	case alerting.EventFiring:
+		return "🔥"
+	case alerting.EventEscalated:
+		return "🚨"
+	default:
+		return "✅"
+	}
+}
+
+func newAlertsListCmd(opts *alertsOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List pending, firing and recently resolved alerts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			state, err := alerting.LoadState(opts.state)
+			if err != nil {
+				return err
+			}
+
+			alerts := state.List()
+			if len(alerts) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No alerts")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tRULE\tSTATUS\tSEVERITY\tVALUE\tSINCE\tSERIES\tNOTE")
+			for _, a := range alerts {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", a.ID, a.Rule, a.Status, valueOr(a.Severity, "-"),
+					strconv.FormatFloat(a.Value, 'g', 6, 64), a.Since.Local().Format("2006-01-02 15:04"), a.Series, alertNote(a))
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func alertNote(a alerting.Alert) string {
+	switch {
+	case !a.AckedAt.IsZero():
+		return "acked by " + valueOr(a.AckedBy, "unknown")
+	case a.Silenced:
+		return "silenced"
+	case !a.EscalatedAt.IsZero():
+		return "escalated"
+	default:
+		return ""
+	}
+}
+
+func newAlertsAckCmd(opts *alertsOptions) *cobra.Command {
+	var by string
+
+	cmd := &cobra.Command{
+		Use:   "ack <id>",
+		Short: "Acknowledge a firing alert to stop its escalation",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			state, err := alerting.LoadState(opts.state)
+			if err != nil {
+				return err
+			}
+
+			a, err := state.Ack(args[0], by, time.Now())
+			if err != nil {
+				return err
+			}
+			if err := state.Save(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✅ Acknowledged %s (%s %s)\n", a.ID, a.Rule, a.Series)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&by, "by", os.Getenv("USER"), "Name recorded with the acknowledgement")
+
+	return cmd
+}
+
+func newAlertsValidateCmd(opts *alertsOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check the alert configuration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := alerting.LoadConfig(opts.config)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✅ %s: %d sources, %d rules, %d channels, %d silences\n",
+				opts.config, len(cfg.Sources), len(cfg.Rules), len(cfg.Channels), len(cfg.Silences))
+			return nil
+		},
+	}
+}
+
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package monitoring
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runAlerts(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := newAlertsCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestAlertsCheckListAck(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "alerts.yaml")
+	require.NoError(t, os.WriteFile(config, []byte(`
+rules:
+  - name: low-budget
+    metric: gz_api_rate_limit_remaining
+    op: "<"
+    value: 100
+`), 0o600))
+	metrics := filepath.Join(dir, "metrics.txt")
+	require.NoError(t, os.WriteFile(metrics, []byte("gz_api_rate_limit_remaining{provider=\"github\"} 12\n"), 0o600))
+	flags := []string{"--config", config, "--state", filepath.Join(dir, "state.json")}
+
+	out, err := runAlerts(t, append([]string{"validate"}, flags...)...)
+	require.NoError(t, err)
+	assert.Contains(t, out, "1 rules")
+
+	out, err = runAlerts(t, append([]string{"check", "--metrics-file", metrics}, flags...)...)
+	require.NoError(t, err)
+	assert.Contains(t, out, "🔥 low-budget")
+	assert.Contains(t, out, "1 firing")
+
+	out, err = runAlerts(t, append([]string{"list"}, flags...)...)
+	require.NoError(t, err)
+	assert.Contains(t, out, "firing")
+	lines := strings.Split(out, "\n")
+	require.GreaterOrEqual(t, len(lines), 2)
+	id := strings.Fields(lines[1])[0]
+
+	_, err = runAlerts(t, append([]string{"ack", "zzz"}, flags...)...)
+	assert.Error(t, err)
+
+	out, err = runAlerts(t, append([]string{"ack", id, "--by", "alice"}, flags...)...)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Acknowledged")
+
+	out, err = runAlerts(t, append([]string{"list"}, flags...)...)
+	require.NoError(t, err)
+	assert.Contains(t, out, "acked by alice")
+}
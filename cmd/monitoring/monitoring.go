@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package monitoring implements the `gz monitoring` command.
+package monitoring
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+// NewMonitoringCmd creates the monitoring command.
+func NewMonitoringCmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+
+	cmd := &cobra.Command{
+		Use:   "monitoring",
+		Short: "Alert on metrics collected from gz processes",
+		Long: `Evaluate alert rules against the metrics that gz processes expose
+with --metrics-addr and route alerts to Slack, Discord, Teams or email.`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newAlertsCmd())
+
+	return cmd
+}
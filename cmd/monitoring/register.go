@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package monitoring
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type monitoringCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p monitoringCmdProvider) Command() *cobra.Command {
+	return NewMonitoringCmd(p.appCtx)
+}
+
+func (p monitoringCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "monitoring",
+		Category:     registry.CategoryUtility,
+		Version:      "1.0.0",
+		Priority:     80,
+		Experimental: false,
+		Dependencies: []string{},
+		Tags:         []string{"monitoring", "alerts", "metrics", "notifications"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterMonitoringCmd registers the monitoring command with the command registry.
+func RegisterMonitoringCmd(appCtx *app.AppContext) {
+	registry.Register(monitoringCmdProvider{appCtx: appCtx})
+}
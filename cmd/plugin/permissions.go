@@ -0,0 +1,256 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/gizzahub/gzh-cli/pkg/plugins"
+)
+
+// isInteractive reports whether permission prompts can be answered on in.
+// Tests replace it to drive the prompt without a terminal.
+var isInteractive = func(in io.Reader) bool {
+	f, ok := in.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// terminalPrompter asks for permissions on the command's input.
+type terminalPrompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func newTerminalPrompter(in io.Reader, out io.Writer) *terminalPrompter {
+	return &terminalPrompter{in: bufio.NewReader(in), out: out}
+}
+
+// Ask implements plugins.Prompter. Anything but an explicit allow denies.
+func (p *terminalPrompter) Ask(plugin string, perm plugins.Permission) (plugins.Answer, error) {
+	fmt.Fprintf(p.out, "🔐 Plugin %s requests %s\n", plugin, describePermission(perm))
+	fmt.Fprint(p.out, "   Allow? [y] once, [a] always, [n] no (default), [d] never: ")
+
+	line, err := p.in.ReadString('\n')
+	if err != nil && line == "" {
+		if err == io.EOF {
+			fmt.Fprintln(p.out)
+			return plugins.AnswerDeny, nil
+		}
+		return plugins.AnswerDeny, fmt.Errorf("read answer: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return plugins.AnswerAllowOnce, nil
+	case "a", "always":
+		return plugins.AnswerAllowAlways, nil
+	case "d", "never":
+		return plugins.AnswerDenyAlways, nil
+	default:
+		return plugins.AnswerDeny, nil
+	}
+}
+
+func describePermission(perm plugins.Permission) string {
+	switch perm.Kind() {
+	case "network":
+		return "network access"
+	case "read":
+		return "read access to " + perm.Path()
+	case "env":
+		return "the environment variable " + perm.Path()
+	default:
+		return "write access to " + perm.Path()
+	}
+}
+
+func newPermissionsCmd(opts *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "permissions [name]",
+		Short: "List, grant and revoke plugin permissions",
+		Long: `Show the permission decisions recorded for plugins.
+
+Plugins declare the network and filesystem access they need in their
+registry manifest. The first time 'gz plugin run' meets a permission
+without a recorded decision it asks whether to allow it once, always, or
+not at all. Without a terminal the permission is denied; grant it ahead of
+time with 'gz plugin permissions grant'.
+
+Permissions are written as network, read:<path>, write:<path> or
+env:<name>. Plugins see only PATH, HOME, locale and terminal variables
+unless an env permission forwards another variable, such as a token.
+
+Examples:
+  gz plugin permissions
+  gz plugin permissions grant lint network
+  gz plugin permissions grant lint env:GITHUB_TOKEN
+  gz plugin permissions revoke lint write:./out
+  gz plugin permissions revoke lint
+  gz plugin permissions log`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			grants, err := plugins.NewConsentStore(opts.dir).Grants()
+			if err != nil {
+				return err
+			}
+
+			names := make([]string, 0, len(grants))
+			for name := range grants {
+				if len(args) == 0 || args[0] == name {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+
+			if len(names) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No plugin permissions recorded")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "PLUGIN\tPERMISSION\tDECISION\tVERSION\tDECIDED")
+			for _, name := range names {
+				for _, g := range grants[name] {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, g.Permission, g.Decision, valueOr(g.Version, "-"), g.DecidedAt.Local().Format("2006-01-02 15:04"))
+				}
+			}
+
+			return w.Flush()
+		},
+	}
+
+	cmd.AddCommand(newPermissionsGrantCmd(opts))
+	cmd.AddCommand(newPermissionsRevokeCmd(opts))
+	cmd.AddCommand(newPermissionsLogCmd(opts))
+
+	return cmd
+}
+
+func newPermissionsGrantCmd(opts *options) *cobra.Command {
+	var deny bool
+
+	cmd := &cobra.Command{
+		Use:   "grant <name> <permission>...",
+		Short: "Record permissions for a plugin without prompting",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			perms, err := parsePermissions(args[1:])
+			if err != nil {
+				return err
+			}
+
+			version := ""
+			if inst, err := plugins.NewInstaller(plugins.InstallerConfig{Dir: opts.dir}); err == nil {
+				if installed, err := inst.Get(args[0]); err == nil {
+					version = installed.Version
+				}
+			}
+
+			decision := plugins.DecisionAllow
+			if deny {
+				decision = plugins.DecisionDeny
+			}
+
+			store := plugins.NewConsentStore(opts.dir)
+			for _, perm := range perms {
+				if err := store.Record(args[0], version, perm, decision, "command"); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "✅ %s: %s %s\n", args[0], decision, perm)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&deny, "deny", false, "Record a permanent denial instead of a grant")
+
+	return cmd
+}
+
+func newPermissionsRevokeCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <name> [permission...]",
+		Short: "Forget recorded permission decisions of a plugin",
+		Long: `Forget recorded permission decisions of a plugin, so the next run
+asks again. Without permissions all decisions of the plugin are revoked.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			perms, err := parsePermissions(args[1:])
+			if err != nil {
+				return err
+			}
+
+			revoked, err := plugins.NewConsentStore(opts.dir).Revoke(args[0], perms...)
+			if err != nil {
+				return err
+			}
+
+			if len(revoked) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No matching permissions recorded for %s\n", args[0])
+				return nil
+			}
+			for _, perm := range revoked {
+				fmt.Fprintf(cmd.OutOrStdout(), "🗑️  Revoked %s for %s\n", perm, args[0])
+			}
+
+			return nil
+		},
+	}
+}
+
+func newPermissionsLogCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "log [name]",
+		Short: "Show the history of permission decisions",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			events, err := plugins.NewConsentStore(opts.dir).Log()
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TIME\tPLUGIN\tACTION\tPERMISSION\tSOURCE")
+			for _, e := range events {
+				if len(args) > 0 && e.Plugin != args[0] {
+					continue
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Time.Local().Format("2006-01-02 15:04:05"), e.Plugin, e.Action, e.Permission, e.Source)
+			}
+
+			return w.Flush()
+		},
+	}
+}
+
+func parsePermissions(args []string) ([]plugins.Permission, error) {
+	perms := make([]plugins.Permission, 0, len(args))
+	for _, arg := range args {
+		perm, err := plugins.ParsePermission(arg)
+		if err != nil {
+			return nil, err
+		}
+		perms = append(perms, perm)
+	}
+
+	return perms, nil
+}
+
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+
+	return s
+}
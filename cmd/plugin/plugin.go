@@ -0,0 +1,361 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package plugin implements the `gz plugin` command for managing registry plugins.
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/pkg/plugins"
+)
+
+// options holds flags shared by plugin subcommands.
+type options struct {
+	registry       string
+	dir            string
+	trustedKeys    []string
+	skipSignatures bool
+}
+
+// NewPluginCmd creates the plugin command.
+func NewPluginCmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Search, install and update gz plugins",
+		Long: `Manage gz plugins distributed through an HTTP or OCI plugin registry.
+
+Plugins are verified before installation:
+  - The SHA-256 checksum published by the registry must match
+  - A minisign or cosign signature must verify against a trusted key
+
+Installed plugins run out of process in an OS-level sandbox on Linux
+(see 'gz plugin sandbox'). Network and filesystem access beyond the
+sandbox needs the user's consent (see 'gz plugin permissions').
+
+Trusted public keys are read from --trusted-key or from
+~/.config/gzh-manager/plugins/trusted-keys/.
+
+Examples:
+  gz plugin search lint
+  gz plugin install hello
+  gz plugin install hello@1.2.0 --registry oci://ghcr.io/gizzahub/gz-plugins
+  gz plugin update --all
+  gz plugin list
+  gz plugin run hello -- --name world`,
+		SilenceUsage: true,
+	}
+
+	defaultRegistry := os.Getenv("GZH_PLUGIN_REGISTRY")
+	if defaultRegistry == "" {
+		defaultRegistry = plugins.DefaultRegistryURL
+	}
+
+	cmd.PersistentFlags().StringVar(&opts.registry, "registry", defaultRegistry, "Plugin registry (https:// index URL or oci:// reference)")
+	cmd.PersistentFlags().StringVar(&opts.dir, "plugin-dir", plugins.DefaultPluginDir(), "Plugin installation directory")
+	cmd.PersistentFlags().StringSliceVar(&opts.trustedKeys, "trusted-key", nil, "Trusted public key file (minisign or cosign PEM), repeatable")
+	cmd.PersistentFlags().BoolVar(&opts.skipSignatures, "insecure-skip-signature", false, "Install plugins without signature verification (checksums are still verified)")
+
+	cmd.AddCommand(newSearchCmd(opts))
+	cmd.AddCommand(newInstallCmd(opts))
+	cmd.AddCommand(newUpdateCmd(opts))
+	cmd.AddCommand(newListCmd(opts))
+	cmd.AddCommand(newRemoveCmd(opts))
+	cmd.AddCommand(newRunCmd(opts))
+	cmd.AddCommand(newSandboxCmd())
+	cmd.AddCommand(newPermissionsCmd(opts))
+
+	return cmd
+}
+
+func newSearchCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "search [query]",
+		Short: "Search the plugin registry",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reg, err := plugins.NewRegistry(opts.registry, nil)
+			if err != nil {
+				return err
+			}
+
+			query := ""
+			if len(args) > 0 {
+				query = args[0]
+			}
+
+			found, err := reg.Search(cmd.Context(), query)
+			if err != nil {
+				return err
+			}
+
+			if len(found) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No plugins found in %s\n", reg.Name())
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tLATEST\tDESCRIPTION")
+			for i := range found {
+				latest := "-"
+				if rel, ok := found[i].Latest(); ok {
+					latest = rel.Version
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", found[i].Name, latest, found[i].Description)
+			}
+
+			return w.Flush()
+		},
+	}
+}
+
+func newInstallCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "install <name>[@version]...",
+		Short: "Install plugins from the registry",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inst, err := newInstaller(opts)
+			if err != nil {
+				return err
+			}
+
+			for _, arg := range args {
+				name, version, _ := strings.Cut(arg, "@")
+
+				installed, err := inst.Install(cmd.Context(), name, version)
+				if err != nil {
+					return fmt.Errorf("install %s: %w", arg, err)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "✅ Installed %s %s%s\n", installed.Name, installed.Version, verifiedSuffix(installed))
+				printDeclaredPermissions(cmd.OutOrStdout(), installed)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newUpdateCmd(opts *options) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "update [name...]",
+		Short: "Update installed plugins to their latest version",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inst, err := newInstaller(opts)
+			if err != nil {
+				return err
+			}
+
+			names := args
+			if all {
+				installed, err := inst.List()
+				if err != nil {
+					return err
+				}
+				names = names[:0]
+				for _, p := range installed {
+					names = append(names, p.Name)
+				}
+			}
+
+			if len(names) == 0 {
+				return fmt.Errorf("specify plugin names or --all")
+			}
+
+			for _, name := range names {
+				installed, updated, err := inst.Update(cmd.Context(), name)
+				if err != nil {
+					return fmt.Errorf("update %s: %w", name, err)
+				}
+
+				if updated {
+					fmt.Fprintf(cmd.OutOrStdout(), "⬆️  Updated %s to %s%s\n", installed.Name, installed.Version, verifiedSuffix(installed))
+					printDeclaredPermissions(cmd.OutOrStdout(), installed)
+				} else {
+					fmt.Fprintf(cmd.OutOrStdout(), "✓ %s %s is up to date\n", installed.Name, installed.Version)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Update all installed plugins")
+
+	return cmd
+}
+
+// pluginListColumns are the columns of `gz plugin list`.
+var pluginListColumns = []cli.Column{
+	{Name: "name"},
+	{Name: "version", NoTruncate: true},
+	{Name: "verified"},
+	{Name: "source"},
+}
+
+func newListCmd(opts *options) *cobra.Command {
+	var table cli.TableOptions
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List installed plugins",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := table.Validate(pluginListColumns...); err != nil {
+				return err
+			}
+
+			inst, err := plugins.NewInstaller(plugins.InstallerConfig{Dir: opts.dir})
+			if err != nil {
+				return err
+			}
+
+			installed, err := inst.List()
+			if err != nil {
+				return err
+			}
+
+			if len(installed) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No plugins installed")
+				return nil
+			}
+
+			t := cli.NewTable(pluginListColumns...)
+			for _, p := range installed {
+				verified := p.Verified
+				if verified == "" {
+					verified = "checksum-only"
+				}
+				t.AddRow(p.Name, p.Version, verified, p.Source)
+			}
+
+			return t.Render(cmd.OutOrStdout(), table)
+		},
+	}
+
+	cli.AddTableFlags(cmd, &table, pluginListColumns...)
+
+	return cmd
+}
+
+func newRemoveCmd(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <name>...",
+		Short: "Remove installed plugins",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inst, err := plugins.NewInstaller(plugins.InstallerConfig{Dir: opts.dir})
+			if err != nil {
+				return err
+			}
+
+			store := plugins.NewConsentStore(opts.dir)
+			for _, name := range args {
+				if err := inst.Remove(name); err != nil {
+					return err
+				}
+				// 다시 설치하면 권한을 새로 묻는다
+				if _, err := store.Revoke(name); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "🗑️  Removed %s\n", name)
+			}
+
+			return nil
+		},
+	}
+}
+
+// newInstaller builds an installer from command options and trusted keys.
+func newInstaller(opts *options) (*plugins.Installer, error) {
+	reg, err := plugins.NewRegistry(opts.registry, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	verifiers, err := loadVerifiers(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	inst, err := plugins.NewInstaller(plugins.InstallerConfig{
+		Dir:              opts.dir,
+		Registry:         reg,
+		Verifiers:        verifiers,
+		RequireSignature: !opts.skipSignatures,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w (add keys with --trusted-key or use --insecure-skip-signature)", err)
+	}
+
+	return inst, nil
+}
+
+// loadVerifiers loads trusted keys from flags and the default key directory.
+func loadVerifiers(opts *options) ([]plugins.Verifier, error) {
+	paths := append([]string{}, opts.trustedKeys...)
+
+	// 기본 신뢰 키 디렉토리의 모든 키 파일 포함
+	keyDir := filepath.Join(opts.dir, "trusted-keys")
+	if entries, err := os.ReadDir(keyDir); err == nil {
+		for _, e := range entries {
+			if !e.IsDir() {
+				paths = append(paths, filepath.Join(keyDir, e.Name()))
+			}
+		}
+	}
+
+	verifiers := make([]plugins.Verifier, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read trusted key %s: %w", path, err)
+		}
+
+		v, err := plugins.ParseVerifier(data)
+		if err != nil {
+			return nil, fmt.Errorf("load trusted key %s: %w", path, err)
+		}
+		verifiers = append(verifiers, v)
+	}
+
+	return verifiers, nil
+}
+
+func verifiedSuffix(p *plugins.Installed) string {
+	if p.Verified == "" {
+		return " (signature not verified)"
+	}
+
+	return fmt.Sprintf(" (verified with %s)", p.Verified)
+}
+
+// printDeclaredPermissions lists the permissions an installed release asks
+// for, which 'gz plugin run' will prompt for.
+func printDeclaredPermissions(w io.Writer, p *plugins.Installed) {
+	perms, err := p.Permissions.List()
+	if err != nil || len(perms) == 0 {
+		return
+	}
+
+	names := make([]string, len(perms))
+	for i, perm := range perms {
+		names[i] = string(perm)
+	}
+	fmt.Fprintf(w, "   Requests: %s\n", strings.Join(names, ", "))
+}
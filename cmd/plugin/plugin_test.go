@@ -0,0 +1,175 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/plugins"
+)
+
+// TestMain lets sandboxed plugin runs re-execute the test binary as helper.
+func TestMain(m *testing.M) {
+	plugins.SandboxMain()
+	os.Exit(m.Run())
+}
+
+func TestNewPluginCmd_Subcommands(t *testing.T) {
+	cmd := NewPluginCmd(nil)
+
+	names := make([]string, 0, len(cmd.Commands()))
+	for _, sub := range cmd.Commands() {
+		names = append(names, sub.Name())
+	}
+
+	assert.ElementsMatch(t, []string{"search", "install", "update", "list", "remove", "run", "sandbox", "permissions"}, names)
+}
+
+func TestInstallRequiresTrustedKey(t *testing.T) {
+	cmd := NewPluginCmd(nil)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"install", "hello", "--plugin-dir", t.TempDir(), "--registry", "https://example.invalid/index.json"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--trusted-key")
+}
+
+func TestListEmpty(t *testing.T) {
+	var out bytes.Buffer
+
+	cmd := NewPluginCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"list", "--plugin-dir", t.TempDir()})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "No plugins installed")
+}
+
+func TestRunPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugin")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "hello")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho \"hello $1\"\nexit 3\n"), 0o755)) //nolint:gosec // test plugin must be executable
+	state, err := json.Marshal(map[string]plugins.Installed{"hello": {Name: "hello", Version: "1.0.0", Path: script}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "installed.json"), state, 0o600))
+
+	for _, extra := range [][]string{nil, {"--no-sandbox"}} {
+		var out bytes.Buffer
+
+		cmd := NewPluginCmd(nil)
+		cmd.SetOut(&out)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append(append([]string{"run", "--plugin-dir", dir}, extra...), "hello", "world"))
+
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exited with code 3")
+		assert.Equal(t, "hello world\n", out.String())
+		assert.DirExists(t, filepath.Join(dir, "data", "hello"))
+	}
+}
+
+func TestRunPluginNotInstalled(t *testing.T) {
+	cmd := NewPluginCmd(nil)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"run", "--plugin-dir", t.TempDir(), "missing"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, plugins.ErrPluginNotFound)
+}
+
+func TestSandboxCmd(t *testing.T) {
+	var out bytes.Buffer
+
+	cmd := NewPluginCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"sandbox"})
+
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "MECHANISM")
+	assert.Contains(t, out.String(), "landlock")
+	assert.Contains(t, out.String(), "Default limits")
+}
+
+func TestRunPluginPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugin")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "fetch")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nexit 0\n"), 0o755)) //nolint:gosec // test plugin must be executable
+	state, err := json.Marshal(map[string]plugins.Installed{"fetch": {
+		Name: "fetch", Version: "1.0.0", Path: script,
+		Permissions: plugins.Permissions{Network: true},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "installed.json"), state, 0o600))
+
+	execute := func(stdin string, args ...string) (string, string, error) {
+		var out, errOut bytes.Buffer
+		cmd := NewPluginCmd(nil)
+		cmd.SetIn(bytes.NewBufferString(stdin))
+		cmd.SetOut(&out)
+		cmd.SetErr(&errOut)
+		cmd.SetArgs(append([]string{"--plugin-dir", dir}, args...))
+		err := cmd.Execute()
+		return out.String(), errOut.String(), err
+	}
+
+	_, stderr, err := execute("", "run", "fetch")
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "Denied network access to fetch", "non-interactive runs deny")
+
+	interactive := isInteractive
+	t.Cleanup(func() { isInteractive = interactive })
+	isInteractive = func(io.Reader) bool { return true }
+
+	_, stderr, err = execute("a\n", "run", "fetch")
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "requests network access")
+	assert.NotContains(t, stderr, "Denied")
+
+	out, _, err := execute("", "permissions")
+	require.NoError(t, err)
+	assert.Contains(t, out, "fetch")
+	assert.Contains(t, out, "network")
+	assert.Contains(t, out, "allow")
+
+	_, stderr, err = execute("", "run", "fetch")
+	require.NoError(t, err)
+	assert.NotContains(t, stderr, "requests network access", "recorded grants are not asked again")
+
+	out, _, err = execute("", "permissions", "revoke", "fetch")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Revoked network for fetch")
+
+	_, _, err = execute("", "permissions", "grant", "fetch", "write:out", "--deny")
+	require.NoError(t, err)
+	out, _, err = execute("", "permissions", "log", "fetch")
+	require.NoError(t, err)
+	assert.Contains(t, out, "allow")
+	assert.Contains(t, out, "revoke")
+	assert.Contains(t, out, "deny")
+
+	_, _, err = execute("", "permissions", "grant", "fetch", "exec:/bin/sh")
+	assert.Error(t, err)
+}
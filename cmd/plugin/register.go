@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugin
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type pluginCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p pluginCmdProvider) Command() *cobra.Command {
+	return NewPluginCmd(p.appCtx)
+}
+
+func (p pluginCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "plugin",
+		Category:     registry.CategoryUtility,
+		Version:      "1.0.0",
+		Priority:     85,
+		Experimental: false,
+		Dependencies: []string{},
+		Tags:         []string{"plugin", "registry", "install", "extension"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterPluginCmd registers the plugin command with the command registry.
+func RegisterPluginCmd(appCtx *app.AppContext) {
+	registry.Register(pluginCmdProvider{appCtx: appCtx})
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/trace"
+	"github.com/gizzahub/gzh-cli/pkg/plugins"
+)
+
+// runOptions holds flags for `gz plugin run`.
+type runOptions struct {
+	noSandbox    bool
+	strict       bool
+	allowNetwork bool
+	allowRead    []string
+	allowWrite   []string
+	limits       plugins.ResourceLimits
+}
+
+func newRunCmd(opts *options) *cobra.Command {
+	ro := &runOptions{limits: plugins.DefaultSandboxPolicy().Limits}
+
+	cmd := &cobra.Command{
+		Use:   "run <name> [args...]",
+		Short: "Run an installed plugin inside the sandbox",
+		Long: `Run an installed plugin as a separate process.
+
+On Linux the plugin is confined before it starts:
+  - landlock restricts filesystem access to system libraries, the current
+    directory (read-only) and the plugin's data directory
+  - a seccomp filter blocks privileged syscalls and, unless --allow-network
+    is given, IPv4/IPv6 sockets
+  - a cgroup (or rlimits when cgroups are not delegated) bounds memory,
+    CPU, processes and open files
+
+Mechanisms the system lacks are skipped with a warning; use --strict to
+refuse to run instead. See 'gz plugin sandbox' for what is available.
+
+Network and filesystem access declared in the plugin's manifest is asked
+for on first use and remembered if allowed or denied permanently; without a
+terminal undecided permissions are denied. The --allow-* flags grant access
+for a single run. See 'gz plugin permissions'.
+
+Examples:
+  gz plugin run hello
+  gz plugin run lint --allow-network -- --fix ./...
+  gz plugin run report --allow-write ./out --memory 1024`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlugin(cmd, opts, ro, args[0], args[1:])
+		},
+	}
+
+	// 플러그인 인자가 gz 플래그로 해석되지 않도록 한다
+	cmd.Flags().SetInterspersed(false)
+
+	cmd.Flags().BoolVar(&ro.noSandbox, "no-sandbox", false, "Run the plugin without OS-level isolation")
+	cmd.Flags().BoolVar(&ro.strict, "strict", false, "Fail if any sandbox mechanism is unavailable")
+	cmd.Flags().BoolVar(&ro.allowNetwork, "allow-network", false, "Allow the plugin to open network connections")
+	cmd.Flags().StringSliceVar(&ro.allowRead, "allow-read", nil, "Additional path the plugin may read, repeatable")
+	cmd.Flags().StringSliceVar(&ro.allowWrite, "allow-write", nil, "Additional path the plugin may write, repeatable")
+	cmd.Flags().IntVar(&ro.limits.MaxMemoryMB, "memory", ro.limits.MaxMemoryMB, "Memory limit in MB (0 = unlimited)")
+	cmd.Flags().Float64Var(&ro.limits.MaxCPUPercent, "cpu", ro.limits.MaxCPUPercent, "CPU limit in percent of one core (0 = unlimited)")
+	cmd.Flags().DurationVar(&ro.limits.MaxExecutionTime, "timeout", ro.limits.MaxExecutionTime, "Maximum execution time (0 = unlimited)")
+	cmd.Flags().IntVar(&ro.limits.MaxFileHandles, "max-files", ro.limits.MaxFileHandles, "Maximum open files (0 = unlimited)")
+	cmd.Flags().IntVar(&ro.limits.MaxProcesses, "max-procs", ro.limits.MaxProcesses, "Maximum processes and threads (0 = unlimited)")
+
+	return cmd
+}
+
+func runPlugin(cmd *cobra.Command, opts *options, ro *runOptions, name string, args []string) error {
+	inst, err := plugins.NewInstaller(plugins.InstallerConfig{Dir: opts.dir})
+	if err != nil {
+		return err
+	}
+
+	installed, err := inst.Get(name)
+	if err != nil {
+		return err
+	}
+
+	dataDir := filepath.Join(inst.Dir(), "data", name)
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return fmt.Errorf("create plugin data directory: %w", err)
+	}
+
+	var pluginCmd *exec.Cmd
+	if ro.noSandbox {
+		pluginCmd = exec.CommandContext(cmd.Context(), installed.Path, args...)
+		pluginCmd.Env = os.Environ()
+	} else {
+		granted, err := resolvePermissions(cmd, opts, installed)
+		if err != nil {
+			return err
+		}
+
+		sandbox, err := plugins.NewSandbox(ro.policy(dataDir, granted))
+		if err != nil {
+			return fmt.Errorf("%w (use --no-sandbox to run without isolation)", err)
+		}
+
+		if missing := sandbox.Missing(); len(missing) > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  Running %s with reduced isolation: %s unavailable\n", name, strings.Join(missing, ", "))
+		}
+
+		var cleanup func()
+		pluginCmd, cleanup, err = sandbox.Command(cmd.Context(), installed.Path, args...)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+	}
+
+	// 플러그인 로그와 API 호출을 이 실행의 트레이스에 잇는다
+	pluginCmd.Env = append(trace.AppendEnv(cmd.Context(), pluginCmd.Env), "GZ_PLUGIN_DATA_DIR="+dataDir)
+	pluginCmd.Stdin = cmd.InOrStdin()
+	pluginCmd.Stdout = cmd.OutOrStdout()
+	pluginCmd.Stderr = cmd.ErrOrStderr()
+
+	if err := pluginCmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("plugin %s exited with code %d", name, exitErr.ExitCode())
+		}
+		return fmt.Errorf("run plugin %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// resolvePermissions decides the permissions declared by the plugin,
+// prompting for those without a recorded decision when running on a
+// terminal and denying them otherwise.
+func resolvePermissions(cmd *cobra.Command, opts *options, installed *plugins.Installed) ([]plugins.Permission, error) {
+	requested, err := installed.Permissions.List()
+	if err != nil {
+		return nil, fmt.Errorf("invalid permissions in the manifest of %s: %w", installed.Name, err)
+	}
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	var prompter plugins.Prompter
+	if isInteractive(cmd.InOrStdin()) {
+		prompter = newTerminalPrompter(cmd.InOrStdin(), cmd.ErrOrStderr())
+	}
+
+	granted, denied, err := plugins.ResolvePermissions(plugins.NewConsentStore(opts.dir), prompter, installed.Name, installed.Version, requested)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, perm := range denied {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  Denied %s to %s (grant it with 'gz plugin permissions grant %s %s')\n",
+			describePermission(perm), installed.Name, installed.Name, perm)
+	}
+
+	return granted, nil
+}
+
+// policy builds the sandbox policy for a plugin run from the flags and the
+// permissions granted to the plugin.
+func (ro *runOptions) policy(dataDir string, granted []plugins.Permission) plugins.SandboxPolicy {
+	policy := plugins.DefaultSandboxPolicy()
+	policy.Limits = ro.limits
+	policy.AllowNetwork = ro.allowNetwork
+	policy.Strict = ro.strict
+	policy.Apply(granted)
+
+	if wd, err := os.Getwd(); err == nil {
+		policy.ReadOnlyPaths = append(policy.ReadOnlyPaths, wd)
+	}
+	if policy.AllowNetwork {
+		// /etc/resolv.conf는 흔히 systemd-resolved 경로를 가리킨다
+		policy.ReadOnlyPaths = append(policy.ReadOnlyPaths, "/run/systemd/resolve")
+	}
+
+	policy.ReadOnlyPaths = append(policy.ReadOnlyPaths, absPaths(ro.allowRead)...)
+	policy.ReadWritePaths = append(policy.ReadWritePaths, dataDir)
+	policy.ReadWritePaths = append(policy.ReadWritePaths, absPaths(ro.allowWrite)...)
+
+	return policy
+}
+
+func absPaths(paths []string) []string {
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if abs, err := filepath.Abs(p); err == nil {
+			out = append(out, abs)
+		}
+	}
+
+	return out
+}
+
+func newSandboxCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sandbox",
+		Short: "Show which plugin sandbox mechanisms this system supports",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			caps := plugins.ProbeSandbox()
+			out := cmd.OutOrStdout()
+
+			fmt.Fprintf(out, "Plugin sandbox on %s:\n\n", caps.OS)
+
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "MECHANISM\tSTATUS\tDETAIL")
+			for _, m := range caps.Mechanisms {
+				status := "✅ available"
+				if !m.Available {
+					status = "❌ unavailable"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", m.Mechanism, status, m.Detail)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+
+			limits := plugins.DefaultSandboxPolicy().Limits
+			fmt.Fprintf(out, "\nDefault limits: %d MB memory, %.0f%% CPU, %d processes, %d open files, %s timeout\n",
+				limits.MaxMemoryMB, limits.MaxCPUPercent, limits.MaxProcesses, limits.MaxFileHandles,
+				limits.MaxExecutionTime.Round(time.Second))
+
+			return nil
+		},
+	}
+}
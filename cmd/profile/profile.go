@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/gizzahub/gzh-cli/internal/app"
+	"github.com/gizzahub/gzh-cli/internal/errors"
 	"github.com/gizzahub/gzh-cli/internal/simpleprof"
 )
 
@@ -71,9 +72,13 @@ The server provides the following endpoints:
   /debug/pprof/heap       - Memory profile
   /debug/pprof/goroutine  - Goroutine profile
   /debug/pprof/block      - Block profile
-  /debug/pprof/mutex      - Mutex profile`,
+  /debug/pprof/mutex      - Mutex profile
+  /debug/breakers/        - Circuit breaker states (POST {name}/open|close|reset to control)
+  /debug/recovery/        - Progress of the current or last dependency-ordered recovery run`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			profiler := simpleprof.NewSimpleProfiler("tmp/profiles")
+			profiler.Handle(errors.BreakerHandlerPath, errors.NewBreakerHandler(errors.DefaultBreakers))
+			profiler.Handle(errors.RecoveryHandlerPath, errors.NewRecoveryHandler(errors.DefaultRecovery))
 
 			if err := profiler.StartHTTPServer(port); err != nil {
 				return fmt.Errorf("failed to start pprof server: %w", err)
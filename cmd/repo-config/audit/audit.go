@@ -66,6 +66,7 @@ func NewCmd() *cobra.Command {
 		flags      GlobalFlags
 		format     string
 		outputFile string
+		noHistory  bool
 	)
 
 	cmd := &cobra.Command{
@@ -76,14 +77,26 @@ func NewCmd() *cobra.Command {
 This command analyzes repository configurations against defined policies
 and generates simple compliance reports.
 
+The html format runs the audit against the policies in the configuration
+file and writes a self-contained report for attaching to audits. Every
+html run is recorded in ~/.gzh/history, per organization, and the report
+charts compliance and violations over the recorded runs.
+
 Examples:
   # Basic audit report
-  gz repo-config audit --org myorg`,
+  gz repo-config audit --org myorg
+
+  # Shareable HTML report with compliance trends
+  gz repo-config audit --org myorg --config repo-config.yaml --format html --output audit.html`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if flags.Organization == "" {
 				return fmt.Errorf("organization is required (use --org flag)")
 			}
 
+			if format == "html" {
+				return runHTMLAudit(cmd.Context(), cmd.OutOrStdout(), &flags, outputFile, noHistory)
+			}
+
 			fmt.Printf("📊 Basic compliance audit for organization: %s\n", flags.Organization)
 			fmt.Printf("⚠️  This is a simplified audit command. Full audit features have been removed.\n")
 			fmt.Printf("Format: %s\n", format)
@@ -99,8 +112,9 @@ Examples:
 	addGlobalFlags(cmd, &flags)
 
 	// Add basic flags
-	cmd.Flags().StringVar(&format, "format", "table", "Output format (table, json)")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format (table, json, html)")
 	cmd.Flags().StringVar(&outputFile, "output", "", "Output file path")
+	cmd.Flags().BoolVar(&noHistory, "no-history", false, "Do not record this run in the report history")
 
 	return cmd
 }
@@ -0,0 +1,157 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/gizzahub/gzh-cli/internal/htmlreport"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+	"github.com/gizzahub/gzh-cli/pkg/github"
+)
+
+// runHTMLAudit audits the organization against the configured policies
+// and writes the result as an HTML report.
+func runHTMLAudit(ctx context.Context, out io.Writer, flags *GlobalFlags, outputFile string, noHistory bool) error {
+	if outputFile == "" {
+		return fmt.Errorf("--output is required for html format")
+	}
+	if flags.ConfigFile == "" {
+		return fmt.Errorf("configuration file is required for html format (use --config flag)")
+	}
+	token := flags.Token
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		return fmt.Errorf("GitHub token not provided and GITHUB_TOKEN environment variable not set")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	adapter := config.NewGitHubAuditAdapter(github.NewRepoConfigClient(token))
+	report, err := adapter.RunComplianceAudit(ctx, flags.ConfigFile, flags.Organization)
+	if err != nil {
+		return err
+	}
+
+	var history []htmlreport.Entry
+	if !noHistory {
+		store, err := htmlreport.OpenHistory("compliance-" + flags.Organization)
+		if err != nil {
+			return err
+		}
+		entry := htmlreport.Entry{Time: report.GeneratedAt, Values: map[string]float64{
+			"compliance": report.Summary.CompliancePercentage,
+			"violations": float64(report.Summary.TotalViolations),
+		}}
+		if err := store.Append(entry); err != nil {
+			return err
+		}
+		if history, err = store.Load(); err != nil {
+			return err
+		}
+	}
+
+	if err := htmlreport.WriteFile(outputFile, auditHTMLReport(report, history)); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "📊 %d of %d repositories compliant (%.1f%%), %d violation(s)\n",
+		report.Summary.CompliantRepositories, report.Summary.AuditedRepositories,
+		report.Summary.CompliancePercentage, report.Summary.TotalViolations)
+	fmt.Fprintf(out, "💾 Report saved to: %s\n", outputFile)
+	return nil
+}
+
+// auditHTMLReport converts a compliance audit report to an HTML report.
+func auditHTMLReport(report *config.AuditReport, history []htmlreport.Entry) *htmlreport.Report {
+	summary := report.Summary
+	out := &htmlreport.Report{
+		Title:       "Compliance Audit: " + report.Organization,
+		Subtitle:    report.PolicyFile,
+		GeneratedAt: report.GeneratedAt,
+		Stats: []htmlreport.Stat{
+			{Label: "Repositories", Value: fmt.Sprint(summary.AuditedRepositories)},
+			{Label: "Compliant", Value: fmt.Sprintf("%.1f%%", summary.CompliancePercentage), Status: complianceStatus(summary.CompliancePercentage)},
+			{Label: "Violations", Value: fmt.Sprint(summary.TotalViolations), Status: countStatus(summary.TotalViolations)},
+			{Label: "Policies", Value: fmt.Sprint(summary.TotalPolicies)},
+			{Label: "Active exceptions", Value: fmt.Sprint(summary.ActiveExceptions)},
+		},
+		Charts: []*htmlreport.Chart{
+			htmlreport.TrendChart("Compliance over time", "%", history, "compliance"),
+			htmlreport.TrendChart("Violations over time", "", history, "violations"),
+		},
+	}
+
+	policies := htmlreport.Section{
+		Title:   "Policies",
+		Columns: []string{"Policy", "Compliance", "Compliant", "Violating", "Exempted"},
+	}
+	for _, p := range report.Policies {
+		policies.Rows = append(policies.Rows, htmlreport.Row{
+			Status: complianceStatus(p.CompliancePercentage),
+			Cells: []string{
+				p.PolicyName,
+				fmt.Sprintf("%.1f%%", p.CompliancePercentage),
+				fmt.Sprint(p.CompliantRepos),
+				fmt.Sprint(p.ViolatingRepos),
+				fmt.Sprint(p.ExemptedRepos),
+			},
+			Detail: p.Description,
+		})
+	}
+
+	violations := htmlreport.Section{
+		Title:   "Violations",
+		Columns: []string{"Repository", "Policy", "Rule", "Severity", "Message"},
+	}
+	repos := append([]config.RepoAuditResult(nil), report.Repositories...)
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Repository < repos[j].Repository })
+	for _, repo := range repos {
+		for _, v := range repo.Violations {
+			violations.Rows = append(violations.Rows, htmlreport.Row{
+				Status: severityStatus(v.Severity),
+				Cells:  []string{repo.Repository, v.PolicyName, v.RuleName, v.Severity, v.Message},
+				Detail: v.Remediation,
+			})
+		}
+	}
+
+	out.Sections = append(out.Sections, policies, violations)
+	return out
+}
+
+func complianceStatus(percentage float64) string {
+	switch {
+	case percentage >= 100:
+		return htmlreport.StatusPass
+	case percentage >= 80:
+		return htmlreport.StatusWarn
+	default:
+		return htmlreport.StatusFail
+	}
+}
+
+func countStatus(n int) string {
+	if n > 0 {
+		return htmlreport.StatusFail
+	}
+	return htmlreport.StatusPass
+}
+
+func severityStatus(severity string) string {
+	switch severity {
+	case "critical", "high":
+		return htmlreport.StatusFail
+	case "medium":
+		return htmlreport.StatusWarn
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/htmlreport"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+func TestAuditHTMLReport(t *testing.T) {
+	report := &config.AuditReport{
+		Organization: "myorg",
+		GeneratedAt:  time.Now(),
+		Summary:      config.AuditSummary{AuditedRepositories: 2, CompliantRepositories: 1, CompliancePercentage: 50, TotalViolations: 1},
+		Policies:     []config.PolicyAuditResult{{PolicyName: "security", CompliancePercentage: 50}},
+		Repositories: []config.RepoAuditResult{
+			{Repository: "b", Compliant: true},
+			{Repository: "a", Violations: []config.PolicyViolation{{
+				PolicyName: "security", RuleName: "branch_protection", Severity: "critical",
+				Message: "not protected", Remediation: "enable branch protection",
+			}}},
+		},
+	}
+
+	out := auditHTMLReport(report, nil)
+
+	assert.Equal(t, "Compliance Audit: myorg", out.Title)
+	assert.Equal(t, htmlreport.StatusFail, out.Stats[1].Status)
+	require.Len(t, out.Sections, 2)
+	require.Len(t, out.Sections[1].Rows, 1)
+	row := out.Sections[1].Rows[0]
+	assert.Equal(t, htmlreport.StatusFail, row.Status)
+	assert.Equal(t, "a", row.Cells[0])
+	assert.Equal(t, "enable branch protection", row.Detail)
+}
+
+func TestHTMLFormatRequiresOutputAndConfig(t *testing.T) {
+	cmd := NewCmd()
+	cmd.SetArgs([]string{"--org", "myorg", "--format", "html"})
+	assert.ErrorContains(t, cmd.Execute(), "--output")
+
+	cmd = NewCmd()
+	cmd.SetArgs([]string{"--org", "myorg", "--format", "html", "--output", "audit.html"})
+	assert.ErrorContains(t, cmd.Execute(), "--config")
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package evidence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/pkg/compliance"
+	"github.com/gizzahub/gzh-cli/pkg/github"
+)
+
+// NewCmd creates the evidence subcommand.
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "evidence",
+		Short: "Collect and verify tamper-evident compliance evidence",
+		Long: `Collect compliance evidence into a tamper-evident archive and verify it.
+
+An evidence archive is a directory with the collected artifacts stored by
+their SHA-256 digest and a manifest.json that chains every entry to the one
+before it. Collecting into an existing archive extends the chain. Signing
+the manifest with cosign lets auditors check that nothing was changed,
+added or removed since it was signed.
+
+Examples:
+  gz repo-config evidence collect --org myorg --archive ./evidence --branch-protection
+  gz repo-config evidence collect --archive ./evidence --audit-log ./logs --scan-result trivy.json --sign-key cosign.key
+  gz repo-config evidence verify ./evidence --key cosign.pub`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newCollectCmd())
+	cmd.AddCommand(newVerifyCmd())
+
+	return cmd
+}
+
+type collectOptions struct {
+	organization     string
+	token            string
+	archive          string
+	branchProtection bool
+	repositories     []string
+	auditLogs        []string
+	scanResults      []string
+	files            []string
+	signKey          string
+}
+
+func newCollectCmd() *cobra.Command {
+	opts := &collectOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "collect",
+		Short: "Collect evidence artifacts into an archive",
+		Long: `Collect evidence artifacts into an archive, creating it if needed.
+
+Branch protection of every repository's default branch is dumped from the
+GitHub API with --branch-protection. Audit logs, scan results and other
+files are added from paths; directories are added recursively.
+
+With --sign-key the manifest is signed with 'cosign sign-blob'. The key can
+be a cosign key file or a KMS reference; cosign reads its password from
+COSIGN_PASSWORD.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCollect(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.organization, "org", "o", "", "GitHub organization name")
+	cmd.Flags().StringVarP(&opts.token, "token", "t", "", "GitHub personal access token (defaults to GITHUB_TOKEN)")
+	cmd.Flags().StringVarP(&opts.archive, "archive", "a", "", "Evidence archive directory")
+	cmd.Flags().BoolVar(&opts.branchProtection, "branch-protection", false, "Dump default branch protection of the organization's repositories")
+	cmd.Flags().StringSliceVar(&opts.repositories, "repo", nil, "Limit branch protection to these repositories")
+	cmd.Flags().StringSliceVar(&opts.auditLogs, "audit-log", nil, "Audit log file or directory to add")
+	cmd.Flags().StringSliceVar(&opts.scanResults, "scan-result", nil, "Scan result file or directory to add")
+	cmd.Flags().StringSliceVar(&opts.files, "file", nil, "Other evidence file or directory to add")
+	cmd.Flags().StringVar(&opts.signKey, "sign-key", "", "cosign key used to sign the manifest")
+	_ = cmd.MarkFlagRequired("archive")
+
+	return cmd
+}
+
+func runCollect(ctx context.Context, out io.Writer, opts *collectOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var sources []compliance.Source
+	if opts.branchProtection {
+		if opts.organization == "" {
+			return fmt.Errorf("organization is required for --branch-protection (use --org flag)")
+		}
+		token := opts.token
+		if token == "" {
+			token = os.Getenv("GITHUB_TOKEN")
+		}
+		if token == "" {
+			return fmt.Errorf("GitHub token not provided and GITHUB_TOKEN environment variable not set")
+		}
+		sources = append(sources, compliance.BranchProtectionSource{
+			Client:       github.NewRepoConfigClient(token),
+			Organization: opts.organization,
+			Repositories: opts.repositories,
+		})
+	}
+	for _, group := range []struct {
+		kind  string
+		paths []string
+	}{
+		{compliance.KindAuditLog, opts.auditLogs},
+		{compliance.KindScanResult, opts.scanResults},
+		{compliance.KindFile, opts.files},
+	} {
+		for _, path := range group.paths {
+			sources = append(sources, compliance.FileSource{Kind: group.kind, Path: path})
+		}
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("nothing to collect: use --branch-protection, --audit-log, --scan-result or --file")
+	}
+
+	archive, err := compliance.Open(opts.archive)
+	if err != nil {
+		return err
+	}
+	entries, err := compliance.Collect(ctx, archive, sources...)
+	if err != nil {
+		return err
+	}
+
+	manifest := archive.Manifest()
+	fmt.Fprintf(out, "📦 Added %d artifact(s) to %s (%d total)\n", len(entries), archive.Dir(), len(manifest.Entries))
+	fmt.Fprintf(out, "🔗 Chain head: %s\n", manifest.Head)
+
+	if opts.signKey != "" {
+		if err := compliance.Sign(ctx, archive.Dir(), compliance.NewCosignSigner(opts.signKey)); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "🔏 Signed %s\n", compliance.ManifestFile)
+	} else {
+		fmt.Fprintln(out, "⚠️  The manifest is not signed; record the chain head or use --sign-key.")
+	}
+
+	return nil
+}
+
+type verifyOptions struct {
+	key              string
+	requireSignature bool
+	jsonOutput       bool
+}
+
+func newVerifyCmd() *cobra.Command {
+	opts := &verifyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "verify <archive>",
+		Short: "Verify the integrity of an evidence archive",
+		Long: `Verify an evidence archive.
+
+Every artifact is checked against its digest and every manifest entry
+against the hash chain. With --key the manifest signature is verified with
+the cosign public key. The command exits with an error when any check fails.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerify(cmd.OutOrStdout(), args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.key, "key", "", "cosign public key (PEM) to verify the manifest signature")
+	cmd.Flags().BoolVar(&opts.requireSignature, "require-signature", false, "Fail if the manifest is not signed")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runVerify(out io.Writer, dir string, opts *verifyOptions) error {
+	verifyOpts := compliance.VerifyOptions{RequireSignature: opts.requireSignature}
+	if opts.key != "" {
+		key, err := os.ReadFile(opts.key)
+		if err != nil {
+			return fmt.Errorf("failed to read public key: %w", err)
+		}
+		verifyOpts.PublicKey = key
+	}
+
+	result, err := compliance.Verify(dir, verifyOpts)
+	if result == nil {
+		return err
+	}
+
+	if opts.jsonOutput {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if encErr := enc.Encode(result); encErr != nil {
+			return encErr
+		}
+		return err
+	}
+
+	for _, p := range result.Problems {
+		if p.Seq > 0 {
+			fmt.Fprintf(out, "❌ #%d %s: %s\n", p.Seq, p.Name, p.Reason)
+		} else {
+			fmt.Fprintf(out, "❌ %s\n", p.Reason)
+		}
+	}
+	if errors.Is(err, compliance.ErrVerificationFailed) {
+		return err
+	}
+
+	fmt.Fprintf(out, "✅ %d artifact(s) verified, chain head %s\n", result.Entries, result.Head)
+	switch {
+	case result.SignatureVerified:
+		fmt.Fprintln(out, "🔏 Manifest signature is valid")
+	case result.Signed:
+		fmt.Fprintln(out, "⚠️  Manifest is signed but the signature was not checked; pass --key")
+	default:
+		fmt.Fprintln(out, "⚠️  Manifest is not signed")
+	}
+	return err
+}
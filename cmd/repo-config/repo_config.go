@@ -10,6 +10,7 @@ import (
 	"github.com/gizzahub/gzh-cli/cmd/repo-config/audit"
 	"github.com/gizzahub/gzh-cli/cmd/repo-config/dashboard"
 	"github.com/gizzahub/gzh-cli/cmd/repo-config/diff"
+	"github.com/gizzahub/gzh-cli/cmd/repo-config/evidence"
 	"github.com/gizzahub/gzh-cli/cmd/repo-config/list"
 	"github.com/gizzahub/gzh-cli/cmd/repo-config/risk"
 	"github.com/gizzahub/gzh-cli/cmd/repo-config/template"
@@ -43,6 +44,7 @@ Examples:
   gz repo-config validate               # Validate configuration files
   gz repo-config diff                   # Show differences between current and target
   gz repo-config audit                  # Generate compliance audit report
+  gz repo-config evidence collect       # Collect signed compliance evidence
   gz repo-config webhook                # Manage repository webhooks
   gz repo-config dashboard              # Start real-time compliance dashboard
   gz repo-config risk-assessment        # Perform CVSS-based risk assessment`,
@@ -57,6 +59,7 @@ Examples:
 	cmd.AddCommand(validate.NewCmd())
 	cmd.AddCommand(diff.NewCmd())
 	cmd.AddCommand(audit.NewCmd())
+	cmd.AddCommand(evidence.NewCmd())
 	cmd.AddCommand(template.NewCmd())
 	cmd.AddCommand(webhook.NewCmd())
 	cmd.AddCommand(dashboard.NewCmd())
@@ -17,7 +17,7 @@ func TestNewRepoConfigCmd(t *testing.T) {
 	assert.Equal(t, "GitHub repository configuration management", cmd.Short)
 	assert.Contains(t, cmd.Long, "infrastructure-as-code")
 
-	expectedSubcommands := []string{"list", "apply", "validate", "diff", "audit", "template"}
+	expectedSubcommands := []string{"list", "apply", "validate", "diff", "audit", "evidence", "template"}
 	actualSubcommands := make([]string, 0, len(cmd.Commands()))
 	for _, subcmd := range cmd.Commands() {
 		actualSubcommands = append(actualSubcommands, subcmd.Use)
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/go-github/v66/github"
+
+	"github.com/gizzahub/gzh-cli/internal/journal"
+)
+
+func init() {
+	journal.RegisterUndoer(journal.KindWebhookDelete, undoWebhookDelete)
+}
+
+// webhookJournalState is the journal state of a deleted webhook.
+type webhookJournalState struct {
+	Organization string       `json:"organization"`
+	Repository   string       `json:"repository"`
+	Hook         *github.Hook `json:"hook"`
+}
+
+// undoWebhookDelete recreates a deleted webhook with its recorded URL,
+// events and settings. GitHub never returns webhook secrets, so a secret
+// cannot be restored.
+func undoWebhookDelete(ctx context.Context, rec journal.Record, opts journal.UndoOptions) (string, error) {
+	var state webhookJournalState
+	if err := rec.Decode(&state); err != nil {
+		return "", err
+	}
+	if state.Hook == nil || state.Hook.Config == nil {
+		return "", fmt.Errorf("no webhook configuration recorded for %s", rec.Target)
+	}
+	if opts.DryRun {
+		return "would recreate the webhook for " + state.Hook.Config.GetURL(), nil
+	}
+
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GH_TOKEN")
+	}
+	if token == "" {
+		return "", fmt.Errorf("GITHUB_TOKEN is not set")
+	}
+
+	config := *state.Hook.Config
+	hadSecret := config.Secret != nil
+	config.Secret = nil
+	hook := &github.Hook{
+		Events: state.Hook.Events,
+		Active: state.Hook.Active,
+		Config: &config,
+	}
+
+	created, _, err := createGitHubClient(token).Repositories.CreateHook(ctx, state.Organization, state.Repository, hook)
+	if err != nil {
+		return "", fmt.Errorf("failed to recreate webhook: %w", err)
+	}
+
+	note := fmt.Sprintf("recreated as webhook %d", created.GetID())
+	if hadSecret {
+		note += "; the secret could not be restored, set it with 'gz repo-config webhook update'"
+	}
+	return note, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/oauth2"
 
+	"github.com/gizzahub/gzh-cli/internal/journal"
 	"github.com/gizzahub/gzh-cli/pkg/types/repoconfig"
 )
 
@@ -385,13 +386,30 @@ func runWebhookDelete(flags *WebhookFlags) error {
 		return nil
 	}
 
-	_, err := client.Repositories.DeleteHook(ctx, flags.Organization, flags.Repository, flags.ID)
+	// 되돌릴 수 있도록 삭제 전 설정을 저널에 남긴다
+	hook, _, err := client.Repositories.GetHook(ctx, flags.Organization, flags.Repository, flags.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	_, err = client.Repositories.DeleteHook(ctx, flags.Organization, flags.Repository, flags.ID)
 	if err != nil {
 		return fmt.Errorf("failed to delete webhook: %w", err)
 	}
 
 	fmt.Printf("Successfully deleted webhook with ID: %d\n", flags.ID)
 
+	op, err := journal.Start("gz repo-config webhook delete")
+	if err == nil {
+		err = op.Add(journal.KindWebhookDelete, "github", fmt.Sprintf("%s/%s#%d", flags.Organization, flags.Repository, flags.ID),
+			webhookJournalState{Organization: flags.Organization, Repository: flags.Repository, Hook: hook}, "")
+	}
+	if err != nil {
+		fmt.Printf("⚠️  Warning: failed to journal the deletion; it cannot be undone: %v\n", err)
+	} else {
+		fmt.Printf("↩️  Undo with: gz undo %s\n", op.ID)
+	}
+
 	return nil
 }
 
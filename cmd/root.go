@@ -7,28 +7,48 @@ import (
 	"context"
 	"fmt"
 	"os"
+	rtdebug "runtime/debug"
 	"slices"
 
 	"github.com/spf13/cobra"
 
+	apicmd "github.com/gizzahub/gzh-cli/cmd/api"
+	backupcmd "github.com/gizzahub/gzh-cli/cmd/backup"
+	cloudcmd "github.com/gizzahub/gzh-cli/cmd/cloud"
+	configcmd "github.com/gizzahub/gzh-cli/cmd/config"
+	debugcmd "github.com/gizzahub/gzh-cli/cmd/debug"
 	devenv "github.com/gizzahub/gzh-cli/cmd/dev-env"
+	"github.com/gizzahub/gzh-cli/cmd/docker"
 	_ "github.com/gizzahub/gzh-cli/cmd/doctor"
 	"github.com/gizzahub/gzh-cli/cmd/git"
 	gitsync "github.com/gizzahub/gzh-cli/cmd/git-sync"
 	"github.com/gizzahub/gzh-cli/cmd/ide"
+	"github.com/gizzahub/gzh-cli/cmd/monitoring"
 	netenv "github.com/gizzahub/gzh-cli/cmd/net-env"
+	"github.com/gizzahub/gzh-cli/cmd/plugin"
 	"github.com/gizzahub/gzh-cli/cmd/profile"
 	repoconfig "github.com/gizzahub/gzh-cli/cmd/repo-config"
 	"github.com/gizzahub/gzh-cli/cmd/selfupdate"
+	"github.com/gizzahub/gzh-cli/cmd/serve"
+	sshconfig "github.com/gizzahub/gzh-cli/cmd/ssh-config"
 	"github.com/gizzahub/gzh-cli/cmd/synclone"
+	"github.com/gizzahub/gzh-cli/cmd/undo"
+	"github.com/gizzahub/gzh-cli/cmd/workspace"
 
 	"github.com/gizzahub/gzh-cli/cmd/registry"
 	"github.com/gizzahub/gzh-cli/cmd/shell"
 	versioncmd "github.com/gizzahub/gzh-cli/cmd/version"
 	"github.com/gizzahub/gzh-cli/internal/app"
+	"github.com/gizzahub/gzh-cli/internal/cli"
 	"github.com/gizzahub/gzh-cli/internal/config"
+	gzerrors "github.com/gizzahub/gzh-cli/internal/errors"
 	"github.com/gizzahub/gzh-cli/internal/extensions"
 	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/internal/trace"
+	"github.com/gizzahub/gzh-cli/pkg/api"
+	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
+	pkgdebug "github.com/gizzahub/gzh-cli/pkg/debug"
+	"github.com/gizzahub/gzh-cli/pkg/i18n"
 )
 
 var (
@@ -37,6 +57,9 @@ var (
 	quiet        bool
 	debugShell   bool
 	experimental bool
+	errorFormat  string
+	profileName  string
+	traceID      string
 )
 
 // NewRootCmd creates the root command and wires up subcommands with shared context.
@@ -53,12 +76,30 @@ Utility Commands: doctor, version`,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			// Set global logging configuration based on flags
 			logger.SetGlobalLoggingFlags(verbose, debug, quiet)
+			// One trace ID per invocation correlates logs, API calls, plugins and audit events
+			trace.Start(traceID)
 			// Propagate verbose to env for deep packages that can't import logger
 			if verbose {
 				_ = os.Setenv("GZH_VERBOSE", "1")
 			} else {
 				_ = os.Unsetenv("GZH_VERBOSE")
 			}
+			// --profile overrides GZH_PROFILE, which the config loader reads
+			if profileName != "" {
+				_ = os.Setenv(pkgconfig.ProfileEnvVar, profileName)
+			}
+			// Route every HTTP client through the configured proxy and CA bundle
+			if err := pkgconfig.ConfigureNetwork(); err != nil {
+				logger.SimpleWarn("Network settings ignored", "error", err)
+			}
+			// Codecs of caches, bundles and archives from global.compression
+			if err := pkgconfig.ConfigureCompression(); err != nil {
+				logger.SimpleWarn("Compression settings ignored", "error", err)
+			}
+			// Attribute recorded provider API calls to this command
+			if tracker := api.Default(); tracker != nil {
+				tracker.SetCommand(cmd.CommandPath())
+			}
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
@@ -78,6 +119,18 @@ Utility Commands: doctor, version`,
 	profile.RegisterProfileCmd(appCtx)
 	git.RegisterGitCmd(appCtx)
 	selfupdate.RegisterSelfUpdateCmd(appCtx)
+	plugin.RegisterPluginCmd(appCtx)
+	debugcmd.RegisterDebugCmd(appCtx)
+	sshconfig.RegisterSSHConfigCmd(appCtx)
+	serve.RegisterServeCmd(appCtx)
+	monitoring.RegisterMonitoringCmd(appCtx)
+	cloudcmd.RegisterCloudCmd(appCtx)
+	workspace.RegisterWorkspaceCmd(appCtx)
+	configcmd.RegisterConfigCmd(appCtx)
+	docker.RegisterDockerCmd(appCtx)
+	undo.RegisterUndoCmd(appCtx)
+	apicmd.RegisterAPICmd(appCtx)
+	backupcmd.RegisterBackupCmd(appCtx)
 
 	// Initialize lifecycle manager and filter commands
 	lifecycleManager := registry.NewLifecycleManager()
@@ -147,16 +200,32 @@ Utility Commands: doctor, version`,
 	cmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug logging (shows all log levels)")
 	cmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress all logs except critical errors")
 	cmd.PersistentFlags().BoolVar(&experimental, "experimental", false, "Enable experimental features")
+	cmd.PersistentFlags().StringVar(&errorFormat, "format", "text", "Output format (text, json); json also reports errors as a JSON envelope")
+	cmd.PersistentFlags().StringVar(&profileName, "profile", "", "Configuration profile to apply (default: $GZH_PROFILE)")
+	cmd.PersistentFlags().StringVar(&traceID, "trace-id", "", "Trace ID correlating this run's logs, API calls and audit events (32 hex characters; default: $GZ_TRACE_ID or generated)")
 
 	// Hidden debug shell flag
 	cmd.PersistentFlags().BoolVar(&debugShell, "debug-shell", false, "")
 	cmd.PersistentFlags().MarkHidden("debug-shell")
 
+	// 모든 명령어에서 --debug-addr로 실시간 로그 스트리밍 사용 가능
+	pkgdebug.AddServerFlag(cmd)
+
 	return cmd
 }
 
-// Execute invokes the command.
-func Execute(ctx context.Context, version string) error {
+// Execute invokes the command. A panic in any command is turned into a
+// crash report under ~/.gzh/crash and a reported error.
+func Execute(ctx context.Context, version string) (err error) {
+	if pkgdebug.CrashReportsEnabled() {
+		logger.DefaultLogStream().KeepRecent(pkgdebug.CrashLogEvents)
+		defer func() {
+			if r := recover(); r != nil {
+				err = gzerrors.Reported(handleCrash(r, rtdebug.Stack(), version))
+			}
+		}()
+	}
+
 	// Check if debug shell should be started immediately
 	if os.Getenv("GZH_DEBUG_SHELL") == "1" {
 		// Run shell directly
@@ -175,7 +244,13 @@ func Execute(ctx context.Context, version string) error {
 		Config: cfg,
 	}
 
+	// 도움말과 메시지가 출력되기 전에 언어를 정한다 ($GZH_LOCALE > global.locale > LANG)
+	if l, err := i18n.New(i18n.Resolve(pkgconfig.ConfiguredLocale(), os.Getenv)); err == nil {
+		i18n.SetDefault(l)
+	}
+
 	rootCmd := NewRootCmd(ctx, version, appCtx)
+	cli.LocalizeCommand(rootCmd, i18n.Default())
 
 	// Check if --debug-shell flag is present
 	if slices.Contains(os.Args[1:], "--debug-shell") {
@@ -184,9 +259,131 @@ func Execute(ctx context.Context, version string) error {
 		return nil
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	// JSON 에러 출력 시 cobra의 텍스트 에러/사용법 출력을 막아 stderr를 파싱 가능하게 유지
+	jsonErrors := jsonErrorsRequested(os.Args[1:])
+	if jsonErrors {
+		rootCmd.SilenceErrors = true
+		rootCmd.SilenceUsage = true
+	}
+
+	recorder := newHistoryRecorder(version)
+	tracker := newAPIUsageTracker()
+	api.SetDefault(tracker)
+	rates := loadRateBook()
+	api.SetDefaultRates(rates)
+	executed, err := rootCmd.ExecuteC()
+	if tracker != nil {
+		if flushErr := tracker.Flush(); flushErr != nil {
+			logger.Debug("failed to record api usage", "error", flushErr)
+		}
+	}
+	if rates != nil {
+		if saveErr := rates.Save(); saveErr != nil {
+			logger.Debug("failed to save rate state", "error", saveErr)
+		}
+	}
+	if executed != nil && !pkgdebug.IsHistoryCommand(executed) {
+		// 실행 이력 기록 실패는 명령 결과에 영향을 주지 않음
+		if recErr := recorder.Finish(executed, err); recErr != nil {
+			logger.Debug("failed to record execution history", "error", recErr)
+		}
+	}
+	if err != nil && jsonErrors {
+		if writeErr := gzerrors.WriteJSON(rootCmd.ErrOrStderr(), err); writeErr == nil {
+			return gzerrors.Reported(err)
+		}
+	}
+	if err != nil {
 		return fmt.Errorf("error executing root command: %w", err)
 	}
 
 	return nil
 }
+
+// handleCrash writes the crash report for a panic recovered by Execute and
+// tells the user about it on stderr.
+func handleCrash(value any, stack []byte, version string) error {
+	return pkgdebug.HandleCrash(os.Stderr, value, stack, pkgdebug.CrashOptions{
+		Version:    version,
+		Args:       os.Args[1:],
+		ConfigPath: crashConfigPath(),
+		OpenIssue:  pkgdebug.CrashOpenIssueRequested(),
+	})
+}
+
+// crashConfigPath returns the configuration file gz loads, or "" when it
+// cannot be determined; a broken configuration must not hide the crash.
+func crashConfigPath() (path string) {
+	defer func() {
+		if recover() != nil {
+			path = ""
+		}
+	}()
+	facade := pkgconfig.NewUnifiedConfigFacade()
+	if err := facade.LoadConfiguration(); err != nil || facade.GetLoadResult() == nil {
+		return ""
+	}
+	return facade.GetLoadResult().ConfigPath
+}
+
+// jsonErrorsRequested reports whether errors should be written as JSON envelopes,
+// either through --format json (global or a command's own flag) or GZ_ERROR_FORMAT=json.
+// 플래그 파싱 전에 결정해야 하므로 인자를 직접 검사한다.
+func jsonErrorsRequested(args []string) bool {
+	if os.Getenv("GZ_ERROR_FORMAT") == "json" {
+		return true
+	}
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg == "--format=json" || (arg == "--format" && i+1 < len(args) && args[i+1] == "json") {
+			return true
+		}
+	}
+	return false
+}
+
+// newHistoryRecorder returns a recorder for ~/.gzh/history.db, or nil when
+// recording is disabled or the home directory cannot be resolved.
+func newHistoryRecorder(version string) *pkgdebug.Recorder {
+	if !pkgdebug.Enabled() {
+		return nil
+	}
+	path, err := pkgdebug.DefaultPath()
+	if err != nil {
+		return nil
+	}
+	return pkgdebug.NewRecorder(pkgdebug.NewHistory(path), version)
+}
+
+// newAPIUsageTracker returns a tracker for ~/.gzh/api-usage.db, or nil when
+// recording is disabled or the home directory cannot be resolved.
+func newAPIUsageTracker() *api.Tracker {
+	if !api.Enabled() {
+		return nil
+	}
+	path, err := api.DefaultPath()
+	if err != nil {
+		return nil
+	}
+	return api.NewTracker(api.NewStore(path))
+}
+
+// loadRateBook restores the rate state saved in ~/.gzh/rate-state.json, or
+// returns nil when persistence is disabled or the home directory cannot be
+// resolved. Unreadable state starts an empty book.
+func loadRateBook() *api.RateBook {
+	if !api.RateStateEnabled() {
+		return nil
+	}
+	path, err := api.DefaultRateStatePath()
+	if err != nil {
+		return nil
+	}
+	rates, err := api.LoadRateBook(path)
+	if err != nil {
+		logger.Debug("ignoring saved rate state", "error", err)
+	}
+	return rates
+}
@@ -0,0 +1,305 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/jobs"
+	"github.com/gizzahub/gzh-cli/internal/websocket"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+	"github.com/gizzahub/gzh-cli/pkg/plugins"
+)
+
+// maxRequestBody bounds job submission bodies.
+const maxRequestBody = 1 << 20
+
+// api serves the job and schedule endpoints.
+type api struct {
+	jobs      *jobs.Manager
+	schedules *jobs.Scheduler
+	// plugins is nil unless the server runs with --plugins.
+	plugins *plugins.Manager
+	// eventLog is the log webhook events are appended to.
+	eventLog *provider.EventLog
+	token    string
+	// loopback restricts requests to loopback Host names, so that a web
+	// page cannot reach a server bound to the loopback interface through
+	// a DNS name rebound to 127.0.0.1.
+	loopback bool
+}
+
+// handler returns the routed and authenticated API handler.
+func (a *api) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/health", a.health)
+	mux.HandleFunc("POST /api/v1/jobs/{type}", a.submit)
+	mux.HandleFunc("GET /api/v1/jobs", a.list)
+	mux.HandleFunc("GET /api/v1/jobs/{id}", a.get)
+	mux.HandleFunc("DELETE /api/v1/jobs/{id}", a.cancel)
+	mux.HandleFunc("GET /api/v1/jobs/{id}/events", a.events)
+	mux.HandleFunc("GET /api/v1/schedules", a.listSchedules)
+	mux.HandleFunc("GET /api/v1/schedules/{name}", a.getSchedule)
+	mux.HandleFunc("POST /api/v1/schedules/{name}/pause", a.pauseSchedule)
+	mux.HandleFunc("POST /api/v1/schedules/{name}/resume", a.resumeSchedule)
+	mux.HandleFunc("POST /api/v1/schedules/{name}/trigger", a.triggerSchedule)
+	mux.HandleFunc("GET /api/v1/plugins", a.listPlugins)
+	mux.HandleFunc("GET /api/v1/events", a.replayEvents)
+	return a.authenticate(mux)
+}
+
+// authenticate requires the bearer token when one is configured. Browsers
+// cannot set headers on WebSocket requests, so the token may also be passed
+// as the access_token query parameter. Requests from another origin, and
+// on a loopback server requests for a name other than a loopback one, are
+// refused before the token is checked.
+func (a *api) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.loopback && !isLoopbackHost(r.Host) {
+			writeError(w, http.StatusForbidden, "unexpected host "+r.Host)
+			return
+		}
+		if !websocket.SameOrigin(r) {
+			writeError(w, http.StatusForbidden, "cross-origin requests are not allowed")
+			return
+		}
+		if a.token != "" {
+			got := r.URL.Query().Get("access_token")
+			if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+				got = strings.TrimPrefix(header, "Bearer ")
+			}
+			if subtle.ConstantTimeCompare([]byte(got), []byte(a.token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="gz serve"`)
+				writeError(w, http.StatusUnauthorized, "missing or invalid token")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a *api) health(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ok", "jobTypes": a.jobs.Types()})
+}
+
+func (a *api) submit(w http.ResponseWriter, r *http.Request) {
+	// 브라우저가 사전 요청 없이 보낼 수 있는 폼과 text/plain 본문은 받지 않는다
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+		return
+	}
+	if !json.Valid(body) {
+		writeError(w, http.StatusBadRequest, "request body must be a JSON object")
+		return
+	}
+
+	job, err := a.jobs.Submit(r.PathValue("type"), body)
+	switch {
+	case errors.Is(err, jobs.ErrUnknownType):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, jobs.ErrQueueFull):
+		w.Header().Set("Retry-After", "30")
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		w.Header().Set("Location", "/api/v1/jobs/"+job.ID)
+		writeJSON(w, http.StatusAccepted, job)
+	}
+}
+
+func (a *api) list(w http.ResponseWriter, r *http.Request) {
+	status := jobs.Status(r.URL.Query().Get("status"))
+	out := []jobs.Job{}
+	for _, job := range a.jobs.List() {
+		if status == "" || job.Status == status {
+			out = append(out, job)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"jobs": out})
+}
+
+func (a *api) get(w http.ResponseWriter, r *http.Request) {
+	job, err := a.jobs.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (a *api) cancel(w http.ResponseWriter, r *http.Request) {
+	job, err := a.jobs.Cancel(r.PathValue("id"))
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, jobs.ErrFinished):
+		writeError(w, http.StatusConflict, err.Error())
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	default:
+		writeJSON(w, http.StatusAccepted, job)
+	}
+}
+
+// events streams the job's events: recorded events first, then live ones
+// until the job finishes. WebSocket clients receive one text frame per
+// event; plain HTTP clients receive NDJSON.
+func (a *api) events(w http.ResponseWriter, r *http.Request) {
+	past, live, unsubscribe, err := a.jobs.Subscribe(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	defer unsubscribe()
+
+	if websocket.IsUpgrade(r) {
+		a.streamWebSocket(w, r, past, live)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	send := func(event cli.Event) bool {
+		if err := enc.Encode(event); err != nil {
+			return false
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return true
+	}
+
+	for _, event := range past {
+		if !send(event) {
+			return
+		}
+	}
+	for {
+		select {
+		case event, ok := <-live:
+			if !ok || !send(event) {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (a *api) streamWebSocket(w http.ResponseWriter, r *http.Request, past []cli.Event, live <-chan cli.Event) {
+	ws, err := websocket.Upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+
+	clientGone := make(chan struct{})
+	go func() {
+		_ = ws.ReadLoop(nil)
+		close(clientGone)
+	}()
+
+	send := func(event cli.Event) bool {
+		data, err := json.Marshal(event)
+		return err == nil && ws.WriteText(data) == nil
+	}
+	for _, event := range past {
+		if !send(event) {
+			return
+		}
+	}
+	for {
+		select {
+		case event, ok := <-live:
+			if !ok || !send(event) {
+				return
+			}
+		case <-clientGone:
+			return
+		}
+	}
+}
+
+func (a *api) listSchedules(w http.ResponseWriter, _ *http.Request) {
+	out := []jobs.ScheduleStatus{}
+	if a.schedules != nil {
+		out = a.schedules.List()
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"schedules": out})
+}
+
+func (a *api) getSchedule(w http.ResponseWriter, r *http.Request) {
+	a.scheduleAction(w, r, func(s *jobs.Scheduler, name string) (jobs.ScheduleStatus, error) {
+		return s.Get(name)
+	})
+}
+
+func (a *api) pauseSchedule(w http.ResponseWriter, r *http.Request) {
+	a.scheduleAction(w, r, (*jobs.Scheduler).Pause)
+}
+
+func (a *api) resumeSchedule(w http.ResponseWriter, r *http.Request) {
+	a.scheduleAction(w, r, (*jobs.Scheduler).Resume)
+}
+
+func (a *api) scheduleAction(w http.ResponseWriter, r *http.Request, action func(*jobs.Scheduler, string) (jobs.ScheduleStatus, error)) {
+	if a.schedules == nil {
+		writeError(w, http.StatusNotFound, jobs.ErrScheduleNotFound.Error())
+		return
+	}
+	status, err := action(a.schedules, r.PathValue("name"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (a *api) triggerSchedule(w http.ResponseWriter, r *http.Request) {
+	if a.schedules == nil {
+		writeError(w, http.StatusNotFound, jobs.ErrScheduleNotFound.Error())
+		return
+	}
+	job, err := a.schedules.Trigger(r.PathValue("name"))
+	switch {
+	case errors.Is(err, jobs.ErrScheduleNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, jobs.ErrOverlap):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, jobs.ErrQueueFull):
+		w.Header().Set("Retry-After", "30")
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	default:
+		w.Header().Set("Location", "/api/v1/jobs/"+job.ID)
+		writeJSON(w, http.StatusAccepted, job)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
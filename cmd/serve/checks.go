@@ -0,0 +1,198 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+	"github.com/gizzahub/gzh-cli/pkg/github"
+)
+
+// Job types used by scheduled checks.
+const (
+	jobCompliance = "compliance"
+	jobTokenCheck = "token-check"
+)
+
+// tokenExpiryWarning is how far ahead an expiring token is reported.
+const tokenExpiryWarning = 7 * 24 * time.Hour
+
+// tokenCheckParams is the body of POST /api/v1/jobs/token-check.
+type tokenCheckParams struct {
+	// Providers to check; empty checks every provider with a token.
+	Providers []string `json:"providers,omitempty"`
+}
+
+// tokenCheckRunner validates the server's provider tokens.
+type tokenCheckRunner struct {
+	newProvider providerFunc
+	settings    *settings
+}
+
+func (r *tokenCheckRunner) params(raw json.RawMessage) (tokenCheckParams, error) {
+	var p tokenCheckParams
+	if err := decodeParams(raw, &p); err != nil {
+		return p, err
+	}
+	for _, name := range p.Providers {
+		if _, ok := providerTokenEnv[name]; !ok {
+			return p, fmt.Errorf("unsupported provider %q", name)
+		}
+	}
+	return p, nil
+}
+
+func (r *tokenCheckRunner) Validate(raw json.RawMessage) error {
+	_, err := r.params(raw)
+	return err
+}
+
+func (r *tokenCheckRunner) Run(ctx context.Context, raw json.RawMessage, emit func(cli.Event)) error {
+	p, err := r.params(raw)
+	if err != nil {
+		return err
+	}
+	providers := p.Providers
+	if len(providers) == 0 {
+		for name := range providerTokenEnv {
+			if r.settings.token(name) != "" {
+				providers = append(providers, name)
+			}
+		}
+		sort.Strings(providers)
+	}
+	if len(providers) == 0 {
+		return errors.New("no provider tokens are configured")
+	}
+
+	emit(cli.Event{Type: "start", Data: map[string]any{"total": len(providers)}})
+	failed := 0
+	for _, name := range providers {
+		if err := r.check(ctx, name, emit); err != nil {
+			failed++
+			emit(cli.Event{Type: "fail", Target: name, Error: err.Error()})
+			continue
+		}
+		emit(cli.Event{Type: "success", Target: name})
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d provider tokens are invalid", failed, len(providers))
+	}
+	return nil
+}
+
+func (r *tokenCheckRunner) check(ctx context.Context, name string, emit func(cli.Event)) error {
+	token := r.settings.token(name)
+	if token == "" {
+		return fmt.Errorf("no token configured (%s)", providerTokenEnv[name])
+	}
+	p, err := r.newProvider(name, "", token)
+	if err != nil {
+		return err
+	}
+	info, err := p.ValidateToken(ctx)
+	if err != nil {
+		return err
+	}
+	if info == nil || !info.Valid {
+		return errors.New("token was rejected")
+	}
+	if !info.ExpiresAt.IsZero() && time.Until(info.ExpiresAt) < tokenExpiryWarning {
+		emit(cli.Event{
+			Type:    "info",
+			Target:  name,
+			Message: fmt.Sprintf("token expires %s", info.ExpiresAt.Format(time.RFC3339)),
+		})
+	}
+	return nil
+}
+
+// complianceParams is the body of POST /api/v1/jobs/compliance.
+type complianceParams struct {
+	Org string `json:"org"`
+	// Config is the repo-config policy file, relative to the server
+	// workspace.
+	Config string `json:"config"`
+}
+
+// auditFunc audits an organization against a policy file.
+type auditFunc func(ctx context.Context, token, configPath, org string) (*config.AuditReport, error)
+
+// githubAudit audits a GitHub organization with the repo-config policies.
+func githubAudit(ctx context.Context, token, configPath, org string) (*config.AuditReport, error) {
+	adapter := config.NewGitHubAuditAdapter(github.NewRepoConfigClient(token))
+	return adapter.RunComplianceAudit(ctx, configPath, org)
+}
+
+// complianceRunner audits a GitHub organization against repo-config
+// policies. The job fails when any repository violates a policy, so the
+// last run of a scheduled check shows whether the organization complies.
+type complianceRunner struct {
+	workspace string
+	audit     auditFunc
+	settings  *settings
+}
+
+func (r *complianceRunner) options(raw json.RawMessage) (complianceParams, string, error) {
+	var p complianceParams
+	if err := decodeParams(raw, &p); err != nil {
+		return p, "", err
+	}
+	if p.Org == "" {
+		return p, "", errors.New("org is required")
+	}
+	path, err := workspacePath(r.workspace, p.Config)
+	if err != nil {
+		return p, "", fmt.Errorf("config: %w", err)
+	}
+	return p, path, nil
+}
+
+func (r *complianceRunner) Validate(raw json.RawMessage) error {
+	_, _, err := r.options(raw)
+	return err
+}
+
+func (r *complianceRunner) Run(ctx context.Context, raw json.RawMessage, emit func(cli.Event)) error {
+	p, path, err := r.options(raw)
+	if err != nil {
+		return err
+	}
+
+	report, err := r.audit(ctx, r.settings.token("github"), path, p.Org)
+	if err != nil {
+		return fmt.Errorf("compliance audit failed: %w", err)
+	}
+
+	emit(cli.Event{Type: "start", Data: map[string]any{"total": len(report.Repositories)}})
+	for _, repo := range report.Repositories {
+		if repo.Compliant {
+			emit(cli.Event{Type: "success", Target: repo.Repository})
+			continue
+		}
+		messages := make([]string, 0, len(repo.Violations))
+		for _, v := range repo.Violations {
+			messages = append(messages, v.Message)
+		}
+		emit(cli.Event{Type: "fail", Target: repo.Repository, Error: strings.Join(messages, "; ")})
+	}
+	emit(cli.Event{Type: "info", Message: "Compliance audit completed", Data: map[string]any{
+		"compliant":  report.Summary.CompliantRepositories,
+		"violations": report.Summary.TotalViolations,
+		"percentage": report.Summary.CompliancePercentage,
+	}})
+
+	if report.Summary.TotalViolations > 0 {
+		return fmt.Errorf("%d policy violations in %s", report.Summary.TotalViolations, p.Org)
+	}
+	return nil
+}
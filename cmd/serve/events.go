@@ -0,0 +1,249 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/async"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+	"github.com/gizzahub/gzh-cli/pkg/gitea"
+	"github.com/gizzahub/gzh-cli/pkg/github"
+	"github.com/gizzahub/gzh-cli/pkg/gitlab"
+	"github.com/gizzahub/gzh-cli/pkg/plugins"
+)
+
+// webhookSecretEnv holds the webhook secret when --webhook-secret is not
+// given.
+const webhookSecretEnv = "GZH_WEBHOOK_SECRET"
+
+// maxWebhookBody bounds webhook deliveries; GitHub caps payloads at 25 MB.
+const maxWebhookBody = 25 << 20
+
+// eventRetryInterval is how often plugin deliveries that failed are retried
+// when no new events arrive.
+const eventRetryInterval = time.Minute
+
+func defaultEventLogPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".gzh", "serve", "events.log")
+	}
+	return filepath.Join(home, ".gzh", "serve", "events.log")
+}
+
+// webhooks receives signed provider webhooks and appends their events to
+// the event log. GitHub repository events and GitLab system hooks also
+// publish cache invalidations on the event bus, to which the repository
+// caches that gz processes share in ~/.gzh/cache are subscribed. The
+// endpoints are authenticated by the webhook signature instead of the API
+// token, since providers cannot send one.
+type webhooks struct {
+	secret string
+	// githubMu serializes the GitHub processor, which is not safe for
+	// concurrent use.
+	githubMu    sync.Mutex
+	github      github.EventProcessor
+	githubCache *github.CachedGitHubClient
+	gitea       *gitea.HookManager
+	gitlabCache *gitlab.CachedGitLabClient
+	bus         *async.Bus
+	// notify is called after an event was logged.
+	notify func()
+}
+
+func newWebhooks(log *provider.EventLog, secret string, notify func()) (*webhooks, error) {
+	hooks := gitea.NewHookManager(gitea.FlavorGitea, "", "")
+	hooks.SetEventLog(log)
+
+	h := &webhooks{
+		secret:      secret,
+		github:      github.NewEventProcessor(github.NewEventLogStorage(log), serveLogger{}),
+		githubCache: github.NewCachedGitHubClient(""),
+		gitea:       hooks,
+		gitlabCache: gitlab.NewCachedGitLabClient("", ""),
+		bus:         async.NewBus(),
+		notify:      notify,
+	}
+	h.githubCache.SubscribeInvalidations(h.bus)
+	h.gitlabCache.SubscribeInvalidations(h.bus)
+	if err := github.RegisterCacheInvalidation(h.github, h.bus, serveLogger{}); err != nil {
+		_ = h.Close()
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *webhooks) register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /webhooks/github", h.handleGitHub)
+	mux.HandleFunc("POST /webhooks/gitea", h.handleGitea)
+	mux.HandleFunc("POST /webhooks/gitlab", h.handleGitLab)
+}
+
+// Close releases the repository caches.
+func (h *webhooks) Close() error {
+	return errors.Join(h.githubCache.Close(), h.gitlabCache.Close())
+}
+
+func (h *webhooks) handleGitHub(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "webhook payload too large")
+		return
+	}
+	if !h.github.ValidateSignature(body, r.Header.Get("X-Hub-Signature-256"), h.secret) {
+		writeError(w, http.StatusUnauthorized, "invalid webhook signature")
+		return
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	event, err := h.github.ParseWebhookEvent(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.githubMu.Lock()
+	err = h.github.ProcessEvent(r.Context(), event)
+	h.githubMu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.notify()
+	writeJSON(w, http.StatusAccepted, map[string]string{"id": event.ID})
+}
+
+func (h *webhooks) handleGitea(w http.ResponseWriter, r *http.Request) {
+	event, err := h.gitea.ParseWebhook(r, h.secret)
+	switch {
+	case errors.Is(err, gitea.ErrInvalidSignature):
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.gitea.ProcessEvent(r.Context(), *event); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.notify()
+	writeJSON(w, http.StatusAccepted, map[string]string{"id": event.ID})
+}
+
+// handleGitLab invalidates cached project listings affected by a GitLab
+// system hook. GitLab sends the secret as X-Gitlab-Token instead of a
+// signature. System hooks are not logged as events.
+func (h *webhooks) handleGitLab(w http.ResponseWriter, r *http.Request) {
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Gitlab-Token")), []byte(h.secret)) != 1 {
+		writeError(w, http.StatusUnauthorized, "invalid webhook token")
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "webhook payload too large")
+		return
+	}
+
+	inv, err := h.gitlabCache.HookInvalidation(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.bus.Publish(r.Context(), inv); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"invalidated": len(inv.Keys)})
+}
+
+// replayEvents streams logged events as NDJSON records with their sequence
+// numbers, filtered by ?from=<seq>, ?since=<RFC 3339 time> and ?type=.
+func (a *api) replayEvents(w http.ResponseWriter, r *http.Request) {
+	if a.eventLog == nil {
+		writeError(w, http.StatusNotFound, "the event log is disabled")
+		return
+	}
+
+	var opts provider.ReplayOptions
+	query := r.URL.Query()
+	if from := query.Get("from"); from != "" {
+		seq, err := strconv.ParseUint(from, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "from must be a sequence number")
+			return
+		}
+		opts.FromSeq = seq
+	}
+	if since := query.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "since must be an RFC 3339 time")
+			return
+		}
+		opts.Since = t
+	}
+	for _, types := range query["type"] {
+		opts.EventTypes = append(opts.EventTypes, strings.Split(types, ",")...)
+	}
+	// 응답 도중 추가되는 이벤트는 다음 요청에서 from으로 이어 받는다
+	opts.ToSeq = a.eventLog.LastSeq()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(w)
+	if opts.ToSeq == 0 {
+		return
+	}
+	_ = a.eventLog.Replay(r.Context(), opts, func(rec provider.EventRecord) error {
+		return enc.Encode(rec)
+	})
+}
+
+// deliverPluginEvents passes logged events to subscribed plugins whenever
+// notify fires, and periodically to retry failed deliveries, until ctx is
+// done.
+func deliverPluginEvents(ctx context.Context, manager *plugins.Manager, log *provider.EventLog, notify <-chan struct{}) {
+	ticker := time.NewTicker(eventRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := manager.DeliverEvents(ctx, log); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "⚠️  plugin event delivery: %v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+// serveLogger adapts the provider event processors' logging to the
+// server's output: warnings and errors go to stderr, the rest is dropped.
+type serveLogger struct{}
+
+func (serveLogger) Debug(string, ...any) {}
+func (serveLogger) Info(string, ...any)  {}
+
+func (serveLogger) Warn(msg string, args ...any) {
+	fmt.Fprintf(os.Stderr, "⚠️  %s %v\n", msg, args)
+}
+
+func (serveLogger) Error(msg string, args ...any) {
+	fmt.Fprintf(os.Stderr, "⚠️  %s %v\n", msg, args)
+}
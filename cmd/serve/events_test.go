@@ -0,0 +1,172 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+	"github.com/gizzahub/gzh-cli/pkg/github"
+	"github.com/gizzahub/gzh-cli/pkg/gitlab"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhooks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	log, err := provider.OpenEventLog(filepath.Join(t.TempDir(), "events.log"))
+	require.NoError(t, err)
+	defer log.Close()
+
+	notified := 0
+	hooks, err := newWebhooks(log, "s3cret", func() { notified++ })
+	require.NoError(t, err)
+	defer hooks.Close()
+	mux := http.NewServeMux()
+	hooks.register(mux)
+	mux.Handle("/", (&api{eventLog: log, token: "tok"}).handler())
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	post := func(path, body string, headers map[string]string) int {
+		req, err := http.NewRequest(http.MethodPost, srv.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	push := `{"action":"opened","repository":{"name":"repo"},"organization":{"login":"org"},"sender":{"login":"alice"}}`
+	assert.Equal(t, http.StatusAccepted, post("/webhooks/github", push, map[string]string{
+		"X-GitHub-Event":      "push",
+		"X-GitHub-Delivery":   "gh-1",
+		"X-Hub-Signature-256": sign("s3cret", push),
+	}))
+	assert.Equal(t, http.StatusUnauthorized, post("/webhooks/github", push, map[string]string{
+		"X-GitHub-Event":      "push",
+		"X-GitHub-Delivery":   "gh-2",
+		"X-Hub-Signature-256": sign("wrong", push),
+	}))
+
+	issue := `{"action":"opened","repository":{"name":"repo","full_name":"org/repo"},"sender":{"login":"bob"}}`
+	assert.Equal(t, http.StatusUnauthorized, post("/webhooks/gitea", issue, map[string]string{
+		"X-Gitea-Event":     "issues",
+		"X-Gitea-Delivery":  "gt-1",
+		"X-Gitea-Signature": hex.EncodeToString([]byte("forged")),
+	}))
+	assert.Equal(t, http.StatusAccepted, post("/webhooks/gitea", issue, map[string]string{
+		"X-Gitea-Event":     "issues",
+		"X-Gitea-Delivery":  "gt-1",
+		"X-Gitea-Signature": strings.TrimPrefix(sign("s3cret", issue), "sha256="),
+	}))
+	assert.Equal(t, 2, notified)
+
+	// 재생 API는 토큰으로 보호된다
+	resp, err := http.Get(srv.URL + "/api/v1/events")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/events?from=2", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer tok")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var records []provider.EventRecord
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var rec provider.EventRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &rec))
+		records = append(records, rec)
+	}
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(2), records[0].Seq)
+	assert.Equal(t, "gt-1", records[0].Event.ID)
+	assert.Equal(t, uint64(2), log.LastSeq())
+}
+
+func TestWebhooksInvalidateCaches(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GITLAB_BASE_URL", "https://gitlab.example.com")
+	ctx := context.Background()
+
+	// 다른 gz 프로세스가 디스크 캐시에 남긴 목록
+	githubCache := github.OpenRepositoryCache()
+	require.NoError(t, githubCache.SetJSON(ctx, "repos:acme", []string{"a"}))
+	require.NoError(t, githubCache.Close())
+	gitlabCache := gitlab.OpenProjectCache()
+	require.NoError(t, gitlabCache.SetJSON(ctx, "projects:gitlab.example.com:acme", []string{"a"}))
+	require.NoError(t, gitlabCache.Close())
+
+	log, err := provider.OpenEventLog(filepath.Join(t.TempDir(), "events.log"))
+	require.NoError(t, err)
+	defer log.Close()
+	hooks, err := newWebhooks(log, "s3cret", func() {})
+	require.NoError(t, err)
+	defer hooks.Close()
+	mux := http.NewServeMux()
+	hooks.register(mux)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	created := `{"action":"created","repository":{"name":"b"},"organization":{"login":"acme"}}`
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/webhooks/github", strings.NewReader(created))
+	require.NoError(t, err)
+	req.Header.Set("X-GitHub-Event", "repository")
+	req.Header.Set("X-GitHub-Delivery", "gh-1")
+	req.Header.Set("X-Hub-Signature-256", sign("s3cret", created))
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	githubCache = github.OpenRepositoryCache()
+	defer githubCache.Close()
+	_, found := githubCache.Get(ctx, "repos:acme")
+	assert.False(t, found)
+
+	hook := `{"event_name":"project_create","path_with_namespace":"acme/b","project_id":7}`
+	for _, tc := range []struct {
+		token string
+		want  int
+	}{{"wrong", http.StatusUnauthorized}, {"s3cret", http.StatusOK}} {
+		req, err := http.NewRequest(http.MethodPost, srv.URL+"/webhooks/gitlab", strings.NewReader(hook))
+		require.NoError(t, err)
+		req.Header.Set("X-Gitlab-Token", tc.token)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, tc.want, resp.StatusCode)
+	}
+
+	gitlabCache = gitlab.OpenProjectCache()
+	defer gitlabCache.Close()
+	_, found = gitlabCache.Get(ctx, "projects:gitlab.example.com:acme")
+	assert.False(t, found)
+	assert.Equal(t, uint64(1), log.LastSeq())
+}
@@ -0,0 +1,426 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/jobs"
+	servev1 "github.com/gizzahub/gzh-cli/pkg/api/serve/v1"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// defaultHealthWatchInterval is how often WatchHealth runs the checks when
+// the client does not ask for an interval.
+const defaultHealthWatchInterval = 10 * time.Second
+
+// grpcAPI serves the gRPC services of pkg/api/serve/v1 on the same job
+// manager, scheduler and health checks as the REST API.
+type grpcAPI struct {
+	jobs        *jobs.Manager
+	schedules   *jobs.Scheduler
+	health      *health
+	settings    *settings
+	newProvider providerFunc
+	token       string
+}
+
+// server returns a gRPC server with every service and reflection
+// registered behind the token check.
+func (g *grpcAPI) server() *grpc.Server {
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+			if err := g.authorize(ctx); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, next grpc.StreamHandler) error {
+			if err := g.authorize(ss.Context()); err != nil {
+				return err
+			}
+			return next(srv, ss)
+		}),
+	)
+	servev1.RegisterJobServiceServer(s, &jobService{api: g})
+	servev1.RegisterRepositoryServiceServer(s, &repositoryService{api: g})
+	servev1.RegisterMonitoringServiceServer(s, &monitoringService{api: g})
+	reflection.Register(s)
+	return s
+}
+
+// authorize requires "authorization: Bearer <token>" metadata when a token
+// is configured, like the REST API.
+func (g *grpcAPI) authorize(ctx context.Context) error {
+	if g.token == "" {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, header := range md.Get("authorization") {
+		got, ok := strings.CutPrefix(header, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(got), []byte(g.token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid token")
+}
+
+// stopGRPC lets in-flight calls finish for up to timeout. Streams that are
+// still open after that, such as WatchHealth, are closed.
+func stopGRPC(s *grpc.Server, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		s.Stop()
+	}
+}
+
+// jobError maps job manager and scheduler errors to gRPC status codes;
+// other errors get fallback.
+func jobError(err error, fallback codes.Code) error {
+	code := fallback
+	switch {
+	case errors.Is(err, jobs.ErrNotFound), errors.Is(err, jobs.ErrUnknownType), errors.Is(err, jobs.ErrScheduleNotFound):
+		code = codes.NotFound
+	case errors.Is(err, jobs.ErrQueueFull):
+		code = codes.ResourceExhausted
+	case errors.Is(err, jobs.ErrFinished):
+		code = codes.FailedPrecondition
+	case errors.Is(err, jobs.ErrOverlap):
+		code = codes.AlreadyExists
+	}
+	return status.Error(code, err.Error())
+}
+
+type jobService struct {
+	servev1.UnimplementedJobServiceServer
+	api *grpcAPI
+}
+
+func (s *jobService) SubmitJob(_ context.Context, req *servev1.SubmitJobRequest) (*servev1.Job, error) {
+	params := []byte("{}")
+	if req.GetParams() != nil {
+		var err error
+		if params, err = protojson.Marshal(req.GetParams()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid job parameters: %v", err)
+		}
+	}
+	job, err := s.api.jobs.Submit(req.GetType(), params)
+	if err != nil {
+		return nil, jobError(err, codes.InvalidArgument)
+	}
+	return jobToProto(job), nil
+}
+
+func (s *jobService) GetJob(_ context.Context, req *servev1.GetJobRequest) (*servev1.Job, error) {
+	job, err := s.api.jobs.Get(req.GetId())
+	if err != nil {
+		return nil, jobError(err, codes.NotFound)
+	}
+	return jobToProto(job), nil
+}
+
+func (s *jobService) ListJobs(_ context.Context, req *servev1.ListJobsRequest) (*servev1.ListJobsResponse, error) {
+	resp := &servev1.ListJobsResponse{}
+	for _, job := range s.api.jobs.List() {
+		if req.GetStatus() == "" || string(job.Status) == req.GetStatus() {
+			resp.Jobs = append(resp.Jobs, jobToProto(job))
+		}
+	}
+	return resp, nil
+}
+
+func (s *jobService) CancelJob(_ context.Context, req *servev1.CancelJobRequest) (*servev1.Job, error) {
+	job, err := s.api.jobs.Cancel(req.GetId())
+	if err != nil {
+		return nil, jobError(err, codes.Internal)
+	}
+	return jobToProto(job), nil
+}
+
+// WatchJob sends the recorded events, then live ones until the job
+// finishes or the client goes away.
+func (s *jobService) WatchJob(req *servev1.WatchJobRequest, stream grpc.ServerStreamingServer[servev1.JobEvent]) error {
+	past, live, unsubscribe, err := s.api.jobs.Subscribe(req.GetId())
+	if err != nil {
+		return jobError(err, codes.NotFound)
+	}
+	defer unsubscribe()
+
+	for _, event := range past {
+		if err := stream.Send(eventToProto(event)); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case event, ok := <-live:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(eventToProto(event)); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
+
+func (s *jobService) ListSchedules(context.Context, *servev1.ListSchedulesRequest) (*servev1.ListSchedulesResponse, error) {
+	resp := &servev1.ListSchedulesResponse{}
+	if s.api.schedules != nil {
+		for _, sched := range s.api.schedules.List() {
+			resp.Schedules = append(resp.Schedules, scheduleToProto(sched))
+		}
+	}
+	return resp, nil
+}
+
+func (s *jobService) PauseSchedule(_ context.Context, req *servev1.ScheduleRequest) (*servev1.Schedule, error) {
+	return s.scheduleAction(req, (*jobs.Scheduler).Pause)
+}
+
+func (s *jobService) ResumeSchedule(_ context.Context, req *servev1.ScheduleRequest) (*servev1.Schedule, error) {
+	return s.scheduleAction(req, (*jobs.Scheduler).Resume)
+}
+
+func (s *jobService) scheduleAction(req *servev1.ScheduleRequest, action func(*jobs.Scheduler, string) (jobs.ScheduleStatus, error)) (*servev1.Schedule, error) {
+	if s.api.schedules == nil {
+		return nil, jobError(jobs.ErrScheduleNotFound, codes.NotFound)
+	}
+	sched, err := action(s.api.schedules, req.GetName())
+	if err != nil {
+		return nil, jobError(err, codes.NotFound)
+	}
+	return scheduleToProto(sched), nil
+}
+
+func (s *jobService) TriggerSchedule(_ context.Context, req *servev1.ScheduleRequest) (*servev1.Job, error) {
+	if s.api.schedules == nil {
+		return nil, jobError(jobs.ErrScheduleNotFound, codes.NotFound)
+	}
+	job, err := s.api.schedules.Trigger(req.GetName())
+	if err != nil {
+		return nil, jobError(err, codes.Internal)
+	}
+	return jobToProto(job), nil
+}
+
+type repositoryService struct {
+	servev1.UnimplementedRepositoryServiceServer
+	api *grpcAPI
+}
+
+// ListRepositories lists one page of an organization's repositories with
+// the server's token for the provider.
+func (s *repositoryService) ListRepositories(ctx context.Context, req *servev1.ListRepositoriesRequest) (*servev1.ListRepositoriesResponse, error) {
+	providerType := req.GetProvider()
+	if _, ok := providerTokenEnv[providerType]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported provider %q", providerType)
+	}
+	if req.GetOrg() == "" {
+		return nil, status.Error(codes.InvalidArgument, "org is required")
+	}
+
+	client, err := s.api.newProvider(providerType, req.GetOrg(), s.api.settings.token(providerType))
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to create %s provider: %v", providerType, err)
+	}
+	list, err := client.ListRepositories(ctx, provider.ListOptions{
+		Organization: req.GetOrg(),
+		Page:         int(req.GetPage()),
+		PerPage:      int(req.GetPerPage()),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to list repositories: %v", err)
+	}
+
+	resp := &servev1.ListRepositoriesResponse{TotalCount: int32(list.TotalCount), HasNext: list.HasNext}
+	for _, repo := range list.Repositories {
+		resp.Repositories = append(resp.Repositories, repositoryToProto(repo))
+	}
+	return resp, nil
+}
+
+type monitoringService struct {
+	servev1.UnimplementedMonitoringServiceServer
+	api *grpcAPI
+}
+
+func (s *monitoringService) GetHealth(ctx context.Context, req *servev1.HealthRequest) (*servev1.HealthReport, error) {
+	return s.report(ctx, req), nil
+}
+
+// WatchHealth runs the checks every interval and sends the report whenever
+// it differs from the last one sent.
+func (s *monitoringService) WatchHealth(req *servev1.WatchHealthRequest, stream grpc.ServerStreamingServer[servev1.HealthReport]) error {
+	interval := time.Duration(req.GetIntervalSeconds()) * time.Second
+	if interval <= 0 {
+		interval = defaultHealthWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *servev1.HealthReport
+	for {
+		report := s.report(stream.Context(), req.GetRequest())
+		if !proto.Equal(report, last) {
+			if err := stream.Send(report); err != nil {
+				return err
+			}
+			last = report
+		}
+		select {
+		case <-ticker.C:
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
+
+// report runs the checks like the probe endpoints: liveness_only like
+// /livez, otherwise the /healthz status with readiness as in /readyz.
+func (s *monitoringService) report(ctx context.Context, req *servev1.HealthRequest) *servev1.HealthReport {
+	h := s.api.health
+	results, ok := h.run(ctx, req.GetExclude(), req.GetLivenessOnly())
+	report := &servev1.HealthReport{Ready: h.ready.Load() && ok}
+	switch {
+	case req.GetLivenessOnly():
+		report.Status = okStatus(ok, "ok", "failed")
+	case !h.ready.Load():
+		report.Status = "not ready"
+	default:
+		report.Status, _ = h.summarize(results)
+	}
+	for _, res := range results {
+		report.Checks = append(report.Checks, &servev1.HealthCheck{Name: res.Name, Status: res.Status, Error: res.Error})
+	}
+	return report
+}
+
+func jobToProto(job jobs.Job) *servev1.Job {
+	return &servev1.Job{
+		Id:       job.ID,
+		Type:     job.Type,
+		Status:   string(job.Status),
+		Params:   jsonStruct(job.Params),
+		Priority: job.Priority,
+		Progress: &servev1.Progress{
+			Total:     int32(job.Progress.Total),
+			Completed: int32(job.Progress.Completed),
+			Failed:    int32(job.Progress.Failed),
+			Skipped:   int32(job.Progress.Skipped),
+		},
+		Error:      job.Error,
+		CreatedAt:  timestamppb.New(job.CreatedAt),
+		StartedAt:  timestampOrNil(job.StartedAt),
+		FinishedAt: timestampOrNil(job.FinishedAt),
+	}
+}
+
+func eventToProto(event cli.Event) *servev1.JobEvent {
+	out := &servev1.JobEvent{
+		Time:    timestamppb.New(event.Time),
+		Type:    event.Type,
+		Target:  event.Target,
+		Message: event.Message,
+		Error:   event.Error,
+		Attempt: int32(event.Attempt),
+	}
+	if len(event.Data) > 0 {
+		// JSON keeps the values the REST stream would send, e.g. slices
+		// of strings that structpb.NewStruct rejects.
+		if data, err := json.Marshal(event.Data); err == nil {
+			out.Data = jsonStruct(data)
+		}
+	}
+	return out
+}
+
+func scheduleToProto(s jobs.ScheduleStatus) *servev1.Schedule {
+	out := &servev1.Schedule{
+		Name:          s.Name,
+		Cron:          s.Cron,
+		Type:          s.Type,
+		Jitter:        s.Jitter,
+		Paused:        s.Paused,
+		NextRun:       timestampOrNil(s.NextRun),
+		SkippedRuns:   int32(s.SkippedRuns),
+		LastSkippedAt: timestampOrNil(s.LastSkippedAt),
+	}
+	if run := s.LastRun; run != nil {
+		out.LastRun = &servev1.ScheduleRun{
+			JobId:       run.JobID,
+			Trigger:     run.Trigger,
+			Status:      string(run.Status),
+			Error:       run.Error,
+			SubmittedAt: timestamppb.New(run.SubmittedAt),
+			FinishedAt:  timestampOrNil(run.FinishedAt),
+		}
+	}
+	return out
+}
+
+func repositoryToProto(repo provider.Repository) *servev1.Repository {
+	out := &servev1.Repository{
+		Name:          repo.Name,
+		FullName:      repo.FullName,
+		Description:   repo.Description,
+		CloneUrl:      repo.CloneURL,
+		SshUrl:        repo.SSHURL,
+		HtmlUrl:       repo.HTMLURL,
+		DefaultBranch: repo.DefaultBranch,
+		Language:      repo.Language,
+		Visibility:    string(repo.Visibility),
+		Private:       repo.Private,
+		Archived:      repo.Archived,
+		Fork:          repo.Fork,
+		Topics:        repo.Topics,
+	}
+	if !repo.UpdatedAt.IsZero() {
+		out.UpdatedAt = timestamppb.New(repo.UpdatedAt)
+	}
+	return out
+}
+
+// jsonStruct converts a JSON object; anything else yields nil.
+func jsonStruct(data []byte) *structpb.Struct {
+	if len(data) == 0 {
+		return nil
+	}
+	s := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, s); err != nil {
+		return nil
+	}
+	return s
+}
+
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
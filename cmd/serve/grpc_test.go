@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/gizzahub/gzh-cli/internal/jobs"
+	servev1 "github.com/gizzahub/gzh-cli/pkg/api/serve/v1"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// repoProvider lists a fixed set of repositories.
+type repoProvider struct {
+	provider.GitProvider
+	opts *provider.ListOptions
+}
+
+func (p repoProvider) ListRepositories(_ context.Context, opts provider.ListOptions) (*provider.RepositoryList, error) {
+	*p.opts = opts
+	return &provider.RepositoryList{
+		Repositories: []provider.Repository{{Name: "api", FullName: "acme/api", Visibility: provider.VisibilityPrivate, Topics: []string{"go"}}},
+		TotalCount:   3,
+		HasNext:      true,
+	}, nil
+}
+
+type grpcClients struct {
+	jobs       servev1.JobServiceClient
+	repos      servev1.RepositoryServiceClient
+	monitoring servev1.MonitoringServiceClient
+}
+
+func newTestGRPC(t *testing.T, token string, newProvider providerFunc) (grpcClients, *stubRunner, *health) {
+	t.Helper()
+	manager, err := jobs.NewManager(jobs.Config{Dir: t.TempDir(), Workers: 1, QueueSize: 4})
+	require.NoError(t, err)
+	runner := &stubRunner{release: make(chan struct{})}
+	manager.Register(jobBulkClone, runner)
+	scheduler, err := jobs.NewScheduler(manager, []jobs.ScheduledJob{{
+		Name:   "nightly",
+		Cron:   "0 2 * * *",
+		Type:   jobBulkClone,
+		Params: json.RawMessage(`{"org":"acme"}`),
+	}}, jobs.SchedulerConfig{})
+	require.NoError(t, err)
+	require.NoError(t, manager.Start())
+	probes := newHealth([]string{checkWorkers, checkQueue}, healthDeps{jobs: manager, schedules: scheduler})
+
+	listener := bufconn.Listen(1 << 20)
+	server := (&grpcAPI{
+		jobs:        manager,
+		schedules:   scheduler,
+		health:      probes,
+		settings:    &settings{tokens: map[string]string{"github": "ghp_x"}},
+		newProvider: newProvider,
+		token:       token,
+	}).server()
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+		server.Stop()
+		_ = manager.Stop(5 * time.Second)
+	})
+	return grpcClients{
+		jobs:       servev1.NewJobServiceClient(conn),
+		repos:      servev1.NewRepositoryServiceClient(conn),
+		monitoring: servev1.NewMonitoringServiceClient(conn),
+	}, runner, probes
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestGRPCJobService(t *testing.T) {
+	clients, runner, _ := newTestGRPC(t, "secret", nil)
+	ctx := withToken("secret")
+
+	_, err := clients.jobs.ListJobs(context.Background(), &servev1.ListJobsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = clients.jobs.SubmitJob(ctx, &servev1.SubmitJobRequest{Type: jobBulkClone})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = clients.jobs.SubmitJob(ctx, &servev1.SubmitJobRequest{Type: "mirror"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	params, err := structpb.NewStruct(map[string]any{"org": "acme"})
+	require.NoError(t, err)
+	job, err := clients.jobs.SubmitJob(ctx, &servev1.SubmitJobRequest{Type: jobBulkClone, Params: params})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", job.GetParams().GetFields()["org"].GetStringValue())
+
+	stream, err := clients.jobs.WatchJob(ctx, &servev1.WatchJobRequest{Id: job.GetId()})
+	require.NoError(t, err)
+	first, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "start", first.GetType())
+	assert.Equal(t, 2.0, first.GetData().GetFields()["total"].GetNumberValue())
+
+	close(runner.release)
+	types := []string{first.GetType()}
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		types = append(types, event.GetType())
+	}
+	assert.Equal(t, []string{"start", "success", "success", "job"}, types)
+
+	got, err := clients.jobs.GetJob(ctx, &servev1.GetJobRequest{Id: job.GetId()})
+	require.NoError(t, err)
+	assert.Equal(t, string(jobs.StatusSucceeded), got.GetStatus())
+	assert.Equal(t, int32(2), got.GetProgress().GetCompleted())
+	assert.NotNil(t, got.GetFinishedAt())
+
+	list, err := clients.jobs.ListJobs(ctx, &servev1.ListJobsRequest{Status: string(jobs.StatusRunning)})
+	require.NoError(t, err)
+	assert.Empty(t, list.GetJobs())
+
+	_, err = clients.jobs.CancelJob(ctx, &servev1.CancelJobRequest{Id: job.GetId()})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = clients.jobs.GetJob(ctx, &servev1.GetJobRequest{Id: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCSchedules(t *testing.T) {
+	clients, runner, _ := newTestGRPC(t, "", nil)
+	ctx := context.Background()
+
+	list, err := clients.jobs.ListSchedules(ctx, &servev1.ListSchedulesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.GetSchedules(), 1)
+	assert.NotNil(t, list.GetSchedules()[0].GetNextRun())
+
+	paused, err := clients.jobs.PauseSchedule(ctx, &servev1.ScheduleRequest{Name: "nightly"})
+	require.NoError(t, err)
+	assert.True(t, paused.GetPaused())
+
+	_, err = clients.jobs.TriggerSchedule(ctx, &servev1.ScheduleRequest{Name: "nightly"})
+	require.NoError(t, err)
+	_, err = clients.jobs.TriggerSchedule(ctx, &servev1.ScheduleRequest{Name: "nightly"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	close(runner.release)
+
+	_, err = clients.jobs.ResumeSchedule(ctx, &servev1.ScheduleRequest{Name: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCRepositoryService(t *testing.T) {
+	var opts provider.ListOptions
+	var gotToken string
+	clients, _, _ := newTestGRPC(t, "", func(_, _, token string) (provider.GitProvider, error) {
+		gotToken = token
+		return repoProvider{opts: &opts}, nil
+	})
+	ctx := context.Background()
+
+	_, err := clients.repos.ListRepositories(ctx, &servev1.ListRepositoriesRequest{Provider: "svn", Org: "acme"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = clients.repos.ListRepositories(ctx, &servev1.ListRepositoriesRequest{Provider: "github"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := clients.repos.ListRepositories(ctx, &servev1.ListRepositoriesRequest{Provider: "github", Org: "acme", Page: 2, PerPage: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_x", gotToken)
+	assert.Equal(t, provider.ListOptions{Organization: "acme", Page: 2, PerPage: 1}, opts)
+	assert.Equal(t, int32(3), resp.GetTotalCount())
+	assert.True(t, resp.GetHasNext())
+	require.Len(t, resp.GetRepositories(), 1)
+	assert.Equal(t, "acme/api", resp.GetRepositories()[0].GetFullName())
+	assert.Equal(t, "private", resp.GetRepositories()[0].GetVisibility())
+	assert.Equal(t, []string{"go"}, resp.GetRepositories()[0].GetTopics())
+}
+
+func TestGRPCMonitoringService(t *testing.T) {
+	clients, _, probes := newTestGRPC(t, "", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	report, err := clients.monitoring.GetHealth(ctx, &servev1.HealthRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "not ready", report.GetStatus())
+	assert.False(t, report.GetReady())
+	assert.Len(t, report.GetChecks(), 2)
+
+	live, err := clients.monitoring.GetHealth(ctx, &servev1.HealthRequest{LivenessOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", live.GetStatus())
+	assert.Len(t, live.GetChecks(), 1)
+
+	stream, err := clients.monitoring.WatchHealth(ctx, &servev1.WatchHealthRequest{
+		Request:         &servev1.HealthRequest{Exclude: []string{checkQueue}},
+		IntervalSeconds: 1,
+	})
+	require.NoError(t, err)
+	first, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "not ready", first.GetStatus())
+
+	probes.ready.Store(true)
+	next, err := stream.Recv()
+	require.NoError(t, err, "a change is sent on the next check")
+	assert.Equal(t, "ok", next.GetStatus())
+	assert.True(t, next.GetReady())
+	assert.Len(t, next.GetChecks(), 1)
+}
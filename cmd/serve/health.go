@@ -0,0 +1,331 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpcache"
+	"github.com/gizzahub/gzh-cli/internal/jobs"
+)
+
+// Health check names accepted by --health-checks.
+const (
+	checkWorkers   = "workers"
+	checkScheduler = "scheduler"
+	checkQueue     = "queue"
+	checkStorage   = "storage"
+	checkCache     = "cache"
+	checkEvents    = "events"
+	checkProviders = "providers"
+)
+
+// healthCheckNames lists every check in report order.
+var healthCheckNames = []string{
+	checkWorkers, checkScheduler, checkQueue, checkStorage, checkCache, checkEvents, checkProviders,
+}
+
+// healthCheckTimeout bounds a single check so a hanging provider cannot
+// stall a probe past the kubelet's own timeout.
+const healthCheckTimeout = 5 * time.Second
+
+// healthCheck is one subsystem probe. Liveness checks only fail when the
+// process cannot recover without a restart; the others gate readiness.
+type healthCheck struct {
+	name     string
+	liveness bool
+	check    func(ctx context.Context) error
+}
+
+// checkResult is one entry of a probe response.
+type checkResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// health serves /livez, /readyz and /healthz. The probes are not
+// authenticated so that kubelets and load balancers can reach them.
+type health struct {
+	checks []healthCheck
+	// ready is false until the workers and scheduler run and again once
+	// the server starts draining.
+	ready atomic.Bool
+}
+
+// parseHealthChecks validates --health-checks. Empty selects every check.
+func parseHealthChecks(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return healthCheckNames, nil
+	}
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if !slices.Contains(healthCheckNames, name) {
+			return nil, fmt.Errorf("unknown health check %q (valid: %s)", name, strings.Join(healthCheckNames, ", "))
+		}
+		if !slices.Contains(out, name) {
+			out = append(out, name)
+		}
+	}
+	return out, nil
+}
+
+// healthDeps are the subsystems the checks probe.
+type healthDeps struct {
+	jobs      *jobs.Manager
+	schedules *jobs.Scheduler
+	dataDir   string
+	cacheDir  string
+	settings  *settings
+	providers *providerProbe
+}
+
+// newHealth builds the enabled checks in report order.
+func newHealth(enabled []string, deps healthDeps) *health {
+	all := map[string]healthCheck{
+		checkWorkers: {name: checkWorkers, liveness: true, check: func(context.Context) error {
+			if !deps.jobs.Running() {
+				return jobs.ErrNotRunning
+			}
+			return nil
+		}},
+		checkScheduler: {name: checkScheduler, liveness: true, check: func(context.Context) error {
+			return deps.schedules.Check()
+		}},
+		checkQueue: {name: checkQueue, check: func(context.Context) error {
+			return deps.jobs.Check()
+		}},
+		checkStorage: {name: checkStorage, check: func(context.Context) error {
+			return writable(deps.dataDir)
+		}},
+		checkCache: {name: checkCache, check: func(context.Context) error {
+			if deps.cacheDir == "" {
+				return nil
+			}
+			return writable(deps.cacheDir)
+		}},
+		checkEvents: {name: checkEvents, check: func(context.Context) error {
+			return deps.settings.watchError()
+		}},
+		checkProviders: {name: checkProviders, check: deps.providers.check},
+	}
+
+	h := &health{}
+	for _, name := range healthCheckNames {
+		if slices.Contains(enabled, name) {
+			h.checks = append(h.checks, all[name])
+		}
+	}
+	return h
+}
+
+// register adds the probe endpoints to mux.
+func (h *health) register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /livez", h.livez)
+	mux.HandleFunc("GET /readyz", h.readyz)
+	mux.HandleFunc("GET /healthz", h.healthz)
+}
+
+// livez fails only when a liveness check fails, so that provider outages
+// or a full queue never make Kubernetes restart the server.
+func (h *health) livez(w http.ResponseWriter, r *http.Request) {
+	results, ok := h.run(r.Context(), r.URL.Query()["exclude"], true)
+	writeProbe(w, ok, okStatus(ok, "ok", "failed"), results)
+}
+
+// readyz fails while the server starts or drains and when any enabled check
+// fails.
+func (h *health) readyz(w http.ResponseWriter, r *http.Request) {
+	if !h.ready.Load() {
+		writeProbe(w, false, "not ready", nil)
+		return
+	}
+	results, ok := h.run(r.Context(), r.URL.Query()["exclude"], false)
+	writeProbe(w, ok, okStatus(ok, "ok", "not ready"), results)
+}
+
+// healthz reports every enabled check. Failing readiness checks only make
+// it degraded; it returns 503 when a liveness check fails.
+func (h *health) healthz(w http.ResponseWriter, r *http.Request) {
+	results, _ := h.run(r.Context(), r.URL.Query()["exclude"], false)
+	status, live := h.summarize(results)
+	writeProbe(w, live, status, results)
+}
+
+// summarize returns the /healthz status of results and whether every
+// liveness check passed.
+func (h *health) summarize(results []checkResult) (string, bool) {
+	status, live := "ok", true
+	for _, res := range results {
+		if res.Status == "ok" {
+			continue
+		}
+		status = "degraded"
+		if h.lookup(res.Name).liveness {
+			live = false
+		}
+	}
+	if !live {
+		status = "failed"
+	}
+	return status, live
+}
+
+func (h *health) lookup(name string) healthCheck {
+	for _, c := range h.checks {
+		if c.name == name {
+			return c
+		}
+	}
+	return healthCheck{}
+}
+
+// run executes the checks concurrently. livenessOnly limits it to
+// liveness checks; exclude skips checks by name, like ?exclude=name on the
+// Kubernetes API server probes.
+func (h *health) run(ctx context.Context, exclude []string, livenessOnly bool) ([]checkResult, bool) {
+	var selected []healthCheck
+	for _, c := range h.checks {
+		if (livenessOnly && !c.liveness) || slices.Contains(exclude, c.name) {
+			continue
+		}
+		selected = append(selected, c)
+	}
+
+	results := make([]checkResult, len(selected))
+	var wg sync.WaitGroup
+	for i, c := range selected {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			results[i] = checkResult{Name: c.name, Status: "ok"}
+			if err := c.check(ctx); err != nil {
+				results[i].Status, results[i].Error = "failed", err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+
+	ok := true
+	for _, res := range results {
+		ok = ok && res.Status == "ok"
+	}
+	return results, ok
+}
+
+func okStatus(ok bool, pass, fail string) string {
+	if ok {
+		return pass
+	}
+	return fail
+}
+
+func writeProbe(w http.ResponseWriter, ok bool, status string, results []checkResult) {
+	w.Header().Set("Cache-Control", "no-store")
+	code := http.StatusOK
+	if !ok {
+		code = http.StatusServiceUnavailable
+	}
+	body := map[string]any{"status": status}
+	if results != nil {
+		body["checks"] = results
+	}
+	writeJSON(w, code, body)
+}
+
+// writable creates and removes a file in dir.
+func writable(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".health-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	err = f.Close()
+	if rmErr := os.Remove(name); err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// defaultCacheDir is the parent of the HTTP response and object caches, or
+// empty when there is no home directory and the caches stay in memory.
+func defaultCacheDir() string {
+	dir, err := httpcache.DefaultCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Dir(dir)
+}
+
+// providerProbe validates the configured provider tokens. Results are kept
+// for interval so that frequent probes do not spend API rate limit.
+type providerProbe struct {
+	newProvider providerFunc
+	settings    *settings
+	interval    time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	checked time.Time
+	last    error
+}
+
+func (p *providerProbe) check(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if !p.checked.IsZero() && now.Sub(p.checked) < p.interval {
+		return p.last
+	}
+
+	var names []string
+	for name := range providerTokenEnv {
+		if p.settings.token(name) != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if err := p.validate(ctx, name); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	p.checked, p.last = now, errors.Join(errs...)
+	return p.last
+}
+
+func (p *providerProbe) validate(ctx context.Context, name string) error {
+	client, err := p.newProvider(name, "", p.settings.token(name))
+	if err != nil {
+		return err
+	}
+	info, err := client.ValidateToken(ctx)
+	if err != nil {
+		return err
+	}
+	if info == nil || !info.Valid {
+		return errors.New("token was rejected")
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/pkg/plugins"
+)
+
+// jobPlugin runs an installed plugin.
+const jobPlugin = "plugin"
+
+// pluginParams is the body of POST /api/v1/jobs/plugin.
+type pluginParams struct {
+	Plugin string   `json:"plugin"`
+	Args   []string `json:"args,omitempty"`
+}
+
+// pluginExecutor runs the active version of a plugin.
+type pluginExecutor interface {
+	Run(ctx context.Context, name string, args []string, stdout, stderr io.Writer) (string, error)
+}
+
+// pluginRunner runs plugin jobs on the hot-reloaded plugin versions.
+type pluginRunner struct {
+	plugins pluginExecutor
+}
+
+func (r *pluginRunner) params(raw json.RawMessage) (pluginParams, error) {
+	var p pluginParams
+	if err := decodeParams(raw, &p); err != nil {
+		return p, err
+	}
+	if p.Plugin == "" {
+		return p, errors.New("plugin is required")
+	}
+	return p, nil
+}
+
+func (r *pluginRunner) Validate(raw json.RawMessage) error {
+	_, err := r.params(raw)
+	return err
+}
+
+// Run streams the plugin's output lines as events, stdout as "info" and
+// stderr as "warning".
+func (r *pluginRunner) Run(ctx context.Context, raw json.RawMessage, emit func(cli.Event)) error {
+	p, err := r.params(raw)
+	if err != nil {
+		return err
+	}
+
+	stdout := &lineEmitter{emit: emit, target: p.Plugin, eventType: "info"}
+	stderr := &lineEmitter{emit: emit, target: p.Plugin, eventType: "warning"}
+	version, err := r.plugins.Run(ctx, p.Plugin, p.Args, stdout, stderr)
+	stdout.flush()
+	stderr.flush()
+	if err != nil {
+		return err
+	}
+
+	emit(cli.Event{Type: "success", Target: p.Plugin, Message: "Plugin completed", Data: map[string]any{"version": version}})
+	return nil
+}
+
+// lineEmitter turns written output into one event per line.
+type lineEmitter struct {
+	emit      func(cli.Event)
+	target    string
+	eventType string
+	mu        sync.Mutex
+	buf       bytes.Buffer
+}
+
+func (w *lineEmitter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// 개행이 없는 나머지는 다음 쓰기까지 보관
+			w.buf.Reset()
+			w.buf.WriteString(line)
+			return len(p), nil
+		}
+		w.send(line[:len(line)-1])
+	}
+}
+
+func (w *lineEmitter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.buf.Len() > 0 {
+		w.send(w.buf.String())
+		w.buf.Reset()
+	}
+}
+
+func (w *lineEmitter) send(line string) {
+	w.emit(cli.Event{Type: w.eventType, Target: w.target, Message: line})
+}
+
+// startPlugins loads the installed plugins and reloads them on change.
+// Plugins run in the sandbox with the permissions granted through
+// 'gz plugin permissions'; nothing is prompted for.
+func startPlugins(ctx context.Context, opts *serveOptions) *plugins.Manager {
+	manager := plugins.NewManager(plugins.ManagerConfig{
+		Dir:       opts.pluginDir,
+		Command:   plugins.SandboxCommand(opts.pluginDir, plugins.DefaultSandboxPolicy()),
+		Probation: opts.pluginProbation,
+		OnEvent: func(e plugins.ManagerEvent) {
+			if e.Err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  plugin %s\n", e)
+				return
+			}
+			fmt.Printf("🔌 plugin %s\n", e)
+		},
+	})
+
+	// 초기 로드 실패는 해당 플러그인만 제외하고 계속 진행
+	if err := manager.Reload(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", err)
+	}
+	go func() {
+		if err := manager.Watch(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  plugin reload disabled: %v\n", err)
+		}
+	}()
+
+	return manager
+}
+
+// stopPlugins waits for in-flight plugin executions.
+func stopPlugins(manager *plugins.Manager, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := manager.Close(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  plugin executions cancelled: %v\n", err)
+	}
+}
+
+func (a *api) listPlugins(w http.ResponseWriter, _ *http.Request) {
+	if a.plugins == nil {
+		writeError(w, http.StatusNotFound, "plugins are not enabled; start the server with --plugins")
+		return
+	}
+	loaded := a.plugins.Loaded()
+	if loaded == nil {
+		loaded = []plugins.LoadedVersion{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"plugins": loaded})
+}
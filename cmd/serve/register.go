@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type serveCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p serveCmdProvider) Command() *cobra.Command {
+	return NewServeCmd(p.appCtx)
+}
+
+func (p serveCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "serve",
+		Category:     registry.CategoryGit,
+		Version:      "1.0.0",
+		Priority:     60,
+		Experimental: false,
+		Dependencies: []string{"git"},
+		Tags:         []string{"server", "api", "jobs", "clone", "sync"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterServeCmd registers the serve command with the command registry.
+func RegisterServeCmd(appCtx *app.AppContext) {
+	registry.Register(serveCmdProvider{appCtx: appCtx})
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/git/clone"
+	gitsync "github.com/gizzahub/gzh-cli/internal/git/sync"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+	"github.com/gizzahub/gzh-cli/pkg/gitea"
+	"github.com/gizzahub/gzh-cli/pkg/github"
+	"github.com/gizzahub/gzh-cli/pkg/gitlab"
+)
+
+// Job types accepted by the API.
+const (
+	jobBulkClone = "bulk-clone"
+	jobSync      = "sync"
+)
+
+// providerTokenEnv maps providers to the environment variable holding the
+// server's token. Tokens are never accepted in job parameters, which are
+// persisted and returned by the API.
+var providerTokenEnv = map[string]string{
+	"github": "GITHUB_TOKEN",
+	"gitlab": "GITLAB_TOKEN",
+	"gitea":  "GITEA_TOKEN",
+}
+
+// providerFunc creates a provider client for a provider type and
+// organization.
+type providerFunc func(providerType, org, token string) (provider.GitProvider, error)
+
+// newProvider creates a provider client authenticated with the server's
+// token for that provider.
+func newProvider(providerType, org, token string) (provider.GitProvider, error) {
+	if _, ok := providerTokenEnv[providerType]; !ok {
+		return nil, fmt.Errorf("unsupported provider: %s", providerType)
+	}
+
+	factory := provider.NewProviderFactory()
+	constructors := map[string]provider.ProviderConstructor{
+		"github": github.CreateGitHubProvider,
+		"gitlab": gitlab.CreateGitLabProvider,
+		"gitea":  gitea.CreateGiteaProvider,
+	}
+	if err := factory.RegisterProvider(providerType, constructors[providerType]); err != nil {
+		return nil, err
+	}
+
+	return factory.CreateProviderByType(providerType, &provider.ProviderConfig{
+		Type:    providerType,
+		Name:    fmt.Sprintf("%s-%s", providerType, org),
+		Token:   token,
+		Enabled: true,
+		Extra:   make(map[string]any),
+	})
+}
+
+// bulkCloneParams is the body of POST /api/v1/jobs/bulk-clone.
+type bulkCloneParams struct {
+	Provider string `json:"provider"`
+	Org      string `json:"org"`
+	// Target is a directory relative to the server workspace; it defaults
+	// to the organization name.
+	Target   string `json:"target,omitempty"`
+	Strategy string `json:"strategy,omitempty"`
+	Parallel int    `json:"parallel,omitempty"`
+	Match    string `json:"match,omitempty"`
+	Exclude  string `json:"exclude,omitempty"`
+	// Filter is a repofilter expression; archived repositories are left
+	// to it unless includeArchived is set.
+	Filter          string   `json:"filter,omitempty"`
+	Visibility      string   `json:"visibility,omitempty"`
+	IncludeArchived bool     `json:"includeArchived,omitempty"`
+	IncludeForks    *bool    `json:"includeForks,omitempty"`
+	Language        string   `json:"language,omitempty"`
+	Topics          []string `json:"topics,omitempty"`
+	Protocol        string   `json:"protocol,omitempty"`
+	Depth           int      `json:"depth,omitempty"`
+	SingleBranch    bool     `json:"singleBranch,omitempty"`
+	Branch          string   `json:"branch,omitempty"`
+	DryRun          bool     `json:"dryRun,omitempty"`
+	ScanSecrets     bool     `json:"scanSecrets,omitempty"`
+	FailOnSecrets   string   `json:"failOnSecrets,omitempty"`
+}
+
+// cloneRunner runs bulk-clone jobs into the server workspace.
+type cloneRunner struct {
+	workspace   string
+	newProvider providerFunc
+	settings    *settings
+}
+
+func (r *cloneRunner) options(raw json.RawMessage) (*clone.CloneOptions, error) {
+	var p bulkCloneParams
+	if err := decodeParams(raw, &p); err != nil {
+		return nil, err
+	}
+	if _, ok := providerTokenEnv[p.Provider]; !ok {
+		return nil, fmt.Errorf("unsupported provider %q", p.Provider)
+	}
+
+	target := p.Target
+	if target == "" {
+		target = p.Org
+	}
+	dir, err := workspacePath(r.workspace, target)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := clone.DefaultCloneOptions()
+	opts.Provider = p.Provider
+	opts.Org = p.Org
+	opts.Target = dir
+	opts.Strategy = clone.CloneStrategy(p.Strategy)
+	opts.Parallel = p.Parallel
+	if opts.Parallel == 0 {
+		opts.Parallel = r.settings.parallel(jobBulkClone)
+	}
+	opts.Match = p.Match
+	opts.Exclude = p.Exclude
+	opts.Filter = p.Filter
+	opts.Visibility = p.Visibility
+	opts.IncludeArchived = p.IncludeArchived || p.Filter != ""
+	if p.IncludeForks != nil {
+		opts.IncludeForks = *p.IncludeForks
+	}
+	opts.Language = p.Language
+	opts.Topics = p.Topics
+	opts.Protocol = p.Protocol
+	opts.Depth = p.Depth
+	opts.SingleBranch = p.SingleBranch
+	opts.Branch = p.Branch
+	opts.DryRun = p.DryRun
+	opts.ScanSecrets = p.ScanSecrets
+	opts.FailOnSecrets = p.FailOnSecrets
+	opts.Format = string(clone.FormatNDJSON)
+
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+func (r *cloneRunner) Validate(raw json.RawMessage) error {
+	_, err := r.options(raw)
+	return err
+}
+
+func (r *cloneRunner) Run(ctx context.Context, raw json.RawMessage, emit func(cli.Event)) error {
+	opts, err := r.options(raw)
+	if err != nil {
+		return err
+	}
+	opts.Token = r.settings.token(opts.Provider)
+
+	p, err := r.newProvider(opts.Provider, opts.Org, opts.Token)
+	if err != nil {
+		return fmt.Errorf("failed to create %s provider: %w", opts.Provider, err)
+	}
+	executor, err := clone.NewCloneExecutor(p, opts)
+	if err != nil {
+		return err
+	}
+	executor.SetEventSink(emit)
+	return executor.Execute(ctx)
+}
+
+// syncParams is the body of POST /api/v1/jobs/sync.
+type syncParams struct {
+	From            string `json:"from"`
+	To              string `json:"to"`
+	CreateMissing   bool   `json:"createMissing,omitempty"`
+	UpdateExisting  bool   `json:"updateExisting,omitempty"`
+	Force           bool   `json:"force,omitempty"`
+	IncludeCode     bool   `json:"includeCode,omitempty"`
+	IncludeIssues   bool   `json:"includeIssues,omitempty"`
+	IncludePRs      bool   `json:"includePRs,omitempty"`
+	IncludeWiki     bool   `json:"includeWiki,omitempty"`
+	IncludeReleases bool   `json:"includeReleases,omitempty"`
+	IncludeSettings bool   `json:"includeSettings,omitempty"`
+	Match           string `json:"match,omitempty"`
+	Exclude         string `json:"exclude,omitempty"`
+	Parallel        int    `json:"parallel,omitempty"`
+	DryRun          bool   `json:"dryRun,omitempty"`
+	ScanSecrets     bool   `json:"scanSecrets,omitempty"`
+	FailOnSecrets   string `json:"failOnSecrets,omitempty"`
+}
+
+// syncRunner runs cross-provider sync jobs. The sync engine has no
+// per-repository progress hooks, so only start and completion are reported.
+type syncRunner struct {
+	newProvider providerFunc
+	settings    *settings
+}
+
+func (r *syncRunner) options(raw json.RawMessage) (gitsync.SyncOptions, error) {
+	var p syncParams
+	if err := decodeParams(raw, &p); err != nil {
+		return gitsync.SyncOptions{}, err
+	}
+	opts := gitsync.SyncOptions{
+		From:            p.From,
+		To:              p.To,
+		CreateMissing:   p.CreateMissing,
+		UpdateExisting:  p.UpdateExisting,
+		Force:           p.Force,
+		IncludeCode:     p.IncludeCode,
+		IncludeIssues:   p.IncludeIssues,
+		IncludePRs:      p.IncludePRs,
+		IncludeWiki:     p.IncludeWiki,
+		IncludeReleases: p.IncludeReleases,
+		IncludeSettings: p.IncludeSettings,
+		Match:           p.Match,
+		Exclude:         p.Exclude,
+		Parallel:        p.Parallel,
+		DryRun:          p.DryRun,
+		ScanSecrets:     p.ScanSecrets,
+		FailOnSecrets:   p.FailOnSecrets,
+	}
+	if opts.Parallel == 0 {
+		opts.Parallel = r.settings.parallel(jobSync)
+	}
+	if err := opts.Validate(); err != nil {
+		return gitsync.SyncOptions{}, err
+	}
+	return opts, nil
+}
+
+func (r *syncRunner) Validate(raw json.RawMessage) error {
+	_, err := r.options(raw)
+	return err
+}
+
+func (r *syncRunner) Run(ctx context.Context, raw json.RawMessage, emit func(cli.Event)) error {
+	opts, err := r.options(raw)
+	if err != nil {
+		return err
+	}
+	src, err := opts.GetSourceTarget()
+	if err != nil {
+		return err
+	}
+	dst, err := opts.GetDestinationTarget()
+	if err != nil {
+		return err
+	}
+
+	srcProvider, err := r.newProvider(src.Provider, src.Org, r.settings.token(src.Provider))
+	if err != nil {
+		return fmt.Errorf("failed to create source provider: %w", err)
+	}
+	dstProvider, err := r.newProvider(dst.Provider, dst.Org, r.settings.token(dst.Provider))
+	if err != nil {
+		return fmt.Errorf("failed to create destination provider: %w", err)
+	}
+
+	emit(cli.Event{Type: "info", Message: fmt.Sprintf("Syncing %s to %s", opts.From, opts.To)})
+	if err := gitsync.NewSyncEngine(srcProvider, dstProvider, opts).Sync(ctx); err != nil {
+		return fmt.Errorf("synchronization failed: %w", err)
+	}
+	emit(cli.Event{Type: "info", Message: "Synchronization completed"})
+	return nil
+}
+
+// decodeParams decodes job parameters, rejecting unknown fields so that
+// typos do not silently fall back to defaults.
+func decodeParams(raw json.RawMessage, v any) error {
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid job parameters: %w", err)
+	}
+	return nil
+}
+
+// workspacePath resolves a client supplied directory inside the workspace.
+func workspacePath(workspace, target string) (string, error) {
+	if target == "" || filepath.IsAbs(target) {
+		return "", errors.New("target must be a path relative to the server workspace")
+	}
+	clean := filepath.Clean(target)
+	if clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("target %q escapes the server workspace", target)
+	}
+	return filepath.Join(workspace, clean), nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/internal/jobs"
+)
+
+// scheduleFile is the format of --schedule:
+//
+//	schedules:
+//	  - name: nightly-clone
+//	    cron: "0 2 * * *"
+//	    type: bulk-clone
+//	    jitter: 10m
+//	    timezone: Asia/Seoul
+//	    params:
+//	      provider: github
+//	      org: myorg
+//	      strategy: pull
+//	      filter: language == go and not archived and pushed < 180d
+type scheduleFile struct {
+	Schedules []scheduleConfig `yaml:"schedules"`
+}
+
+type scheduleConfig struct {
+	Name     string         `yaml:"name"`
+	Cron     string         `yaml:"cron"`
+	Type     string         `yaml:"type"`
+	Jitter   time.Duration  `yaml:"jitter"`
+	Timezone string         `yaml:"timezone"`
+	Paused   bool           `yaml:"paused"`
+	Params   map[string]any `yaml:"params"`
+}
+
+// defaultSchedulePath returns ~/.config/gzh-manager/schedules.yaml when it
+// exists.
+func defaultSchedulePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	path := filepath.Join(home, ".config", "gzh-manager", "schedules.yaml")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// loadSchedules reads the scheduled jobs from path. An empty path yields
+// no schedules.
+func loadSchedules(path string) ([]jobs.ScheduledJob, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedules: %w", err)
+	}
+
+	var file scheduleFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid schedule file %s: %w", path, err)
+	}
+
+	scheduled := make([]jobs.ScheduledJob, 0, len(file.Schedules))
+	for _, sc := range file.Schedules {
+		job := jobs.ScheduledJob{
+			Name:   sc.Name,
+			Cron:   sc.Cron,
+			Type:   sc.Type,
+			Jitter: sc.Jitter,
+			Paused: sc.Paused,
+		}
+		if sc.Timezone != "" {
+			loc, err := time.LoadLocation(sc.Timezone)
+			if err != nil {
+				return nil, fmt.Errorf("schedule %q: invalid timezone: %w", sc.Name, err)
+			}
+			job.Location = loc
+		}
+		if sc.Params == nil {
+			sc.Params = map[string]any{}
+		}
+		if job.Params, err = json.Marshal(sc.Params); err != nil {
+			return nil, fmt.Errorf("schedule %q: invalid params: %w", sc.Name, err)
+		}
+		scheduled = append(scheduled, job)
+	}
+	return scheduled, nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/jobs"
+)
+
+// scheduleClient talks to the schedule endpoints of a running server.
+type scheduleClient struct {
+	server string
+	token  string
+	http   *http.Client
+}
+
+func (c *scheduleClient) do(method, path string, out any) error {
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.server, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", c.server, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("%s", apiErr.Error)
+		}
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// newSchedulesCmd creates `gz serve schedules`, the client for a running
+// server's scheduled jobs.
+func newSchedulesCmd() *cobra.Command {
+	client := &scheduleClient{http: &http.Client{Timeout: 30 * time.Second}}
+
+	cmd := &cobra.Command{
+		Use:   "schedules",
+		Short: "List, pause, resume and trigger scheduled jobs of a running server",
+		Long: `Manage the scheduled jobs of a running gz serve instance.
+
+Examples:
+  gz serve schedules list
+  gz serve schedules pause nightly-clone
+  gz serve schedules trigger token-check --server http://build:8080`,
+		SilenceUsage: true,
+		PersistentPreRun: func(*cobra.Command, []string) {
+			if client.token == "" {
+				client.token = os.Getenv(tokenEnv)
+			}
+		},
+	}
+	cmd.PersistentFlags().StringVar(&client.server, "server", "http://127.0.0.1:8080", "URL of the gz serve instance")
+	cmd.PersistentFlags().StringVar(&client.token, "token", "", "API token (default $"+tokenEnv+")")
+
+	var table cli.TableOptions
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List scheduled jobs with their next and last run",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := table.Validate(scheduleColumns...); err != nil {
+				return err
+			}
+			var out struct {
+				Schedules []jobs.ScheduleStatus `json:"schedules"`
+			}
+			if err := client.do(http.MethodGet, "/api/v1/schedules", &out); err != nil {
+				return err
+			}
+			return printSchedules(cmd.OutOrStdout(), out.Schedules, table)
+		},
+	}
+	cli.AddTableFlags(listCmd, &table, scheduleColumns...)
+	cmd.AddCommand(listCmd)
+
+	for _, action := range []struct{ name, short string }{
+		{"pause", "Stop a schedule from firing"},
+		{"resume", "Resume a paused schedule"},
+	} {
+		cmd.AddCommand(&cobra.Command{
+			Use:   action.name + " <name>",
+			Short: action.short,
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				var st jobs.ScheduleStatus
+				path := "/api/v1/schedules/" + url.PathEscape(args[0]) + "/" + action.name
+				if err := client.do(http.MethodPost, path, &st); err != nil {
+					return err
+				}
+				return printSchedules(cmd.OutOrStdout(), []jobs.ScheduleStatus{st}, cli.TableOptions{})
+			},
+		})
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "trigger <name>",
+		Short: "Run a scheduled job now",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var job jobs.Job
+			path := "/api/v1/schedules/" + url.PathEscape(args[0]) + "/trigger"
+			if err := client.do(http.MethodPost, path, &job); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "▶️  %s queued as job %s\n", args[0], job.ID)
+			return nil
+		},
+	})
+
+	return cmd
+}
+
+// scheduleColumns are the columns of `gz serve schedules list`.
+var scheduleColumns = []cli.Column{
+	{Name: "name"},
+	{Name: "type"},
+	{Name: "cron", NoTruncate: true},
+	{Name: "next-run", Header: "NEXT RUN", NoTruncate: true},
+	{Name: "last-run", Header: "LAST RUN", NoTruncate: true},
+	{Name: "status"},
+}
+
+func printSchedules(w io.Writer, schedules []jobs.ScheduleStatus, opts cli.TableOptions) error {
+	if len(schedules) == 0 {
+		fmt.Fprintln(w, "No scheduled jobs configured")
+		return nil
+	}
+
+	table := cli.NewTable(scheduleColumns...)
+	for _, s := range schedules {
+		next := "paused"
+		if !s.Paused {
+			next = "-"
+			if s.NextRun != nil {
+				next = s.NextRun.Local().Format("2006-01-02 15:04")
+			}
+		}
+		last, status := "-", "-"
+		if s.LastRun != nil {
+			last = s.LastRun.SubmittedAt.Local().Format("2006-01-02 15:04")
+			status = string(s.LastRun.Status)
+		}
+		table.AddRow(s.Name, s.Type, s.Cron, next, last, status)
+	}
+	return table.Render(w, opts)
+}
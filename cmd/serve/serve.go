@@ -0,0 +1,490 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package serve implements the `gz serve` command, an HTTP API for running
+// bulk-clone, sync and check jobs in the background and on cron schedules.
+package serve
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+	"github.com/gizzahub/gzh-cli/internal/jobs"
+	"github.com/gizzahub/gzh-cli/pkg/debug"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+	"github.com/gizzahub/gzh-cli/pkg/memory"
+	"github.com/gizzahub/gzh-cli/pkg/plugins"
+)
+
+// tokenEnv holds the API token when --token is not given.
+const tokenEnv = "GZH_SERVE_TOKEN"
+
+// tokenBytes is the size of generated API tokens.
+const tokenBytes = 32
+
+type serveOptions struct {
+	addr         string
+	grpcAddr     string
+	token        string
+	tokenFile    string
+	workers      int
+	queueSize    int
+	jobTimeout   time.Duration
+	dataDir      string
+	workspace    string
+	configPath   string
+	schedulePath string
+	// healthChecks are the checks behind /readyz and /healthz; empty
+	// enables all of them.
+	healthChecks     []string
+	providerInterval time.Duration
+	// plugins enables plugin jobs on hot-reloaded plugins from pluginDir.
+	plugins         bool
+	pluginDir       string
+	pluginProbation time.Duration
+	// eventLog is where webhook events are persisted; webhookSecret enables
+	// the webhook endpoints.
+	eventLog      string
+	webhookSecret string
+}
+
+// NewServeCmd creates the serve command.
+func NewServeCmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+
+	opts := &serveOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run the job API for bulk-clone and sync operations",
+		Long: `Start an HTTP server that runs bulk-clone and sync jobs in the background.
+
+Jobs are queued and executed by a fixed number of workers. Their state is
+persisted in --data-dir: queued jobs resume after a restart and jobs that
+were running are marked failed.
+
+Provider tokens are read from the server environment (GITHUB_TOKEN,
+GITLAB_TOKEN, GITEA_TOKEN) and are never part of a job request. Clones are
+written below --workspace.
+
+With --config (default ~/.config/gzh-manager/gzh.yaml when present) the
+server reloads provider tokens, global.concurrency and global.log_level when
+the file changes, without a restart. Invalid edits are rejected and the
+previous values stay active. New values apply to jobs started afterwards.
+
+With --schedule (default ~/.config/gzh-manager/schedules.yaml when present)
+jobs are also submitted on cron schedules:
+
+  schedules:
+    - name: nightly-clone
+      cron: "0 2 * * *"        # minute hour day-of-month month day-of-week
+      type: bulk-clone          # bulk-clone, sync, compliance or token-check
+      jitter: 10m               # random delay added to each run
+      timezone: Asia/Seoul      # default: server local time
+      params: {provider: github, org: myorg, strategy: pull}
+
+A run that comes due while the previous one is still queued or running is
+skipped. Pause state and the last run of each schedule are kept in
+--data-dir across restarts.
+
+Endpoints:
+  POST   /api/v1/jobs/bulk-clone   queue an organization clone
+  POST   /api/v1/jobs/sync         queue a cross-provider sync
+  POST   /api/v1/jobs/compliance   audit an organization ({"org","config"})
+  POST   /api/v1/jobs/token-check  validate provider tokens
+  GET    /api/v1/jobs              list jobs (?status=running)
+  GET    /api/v1/jobs/{id}         job status and progress
+  DELETE /api/v1/jobs/{id}         cancel a queued or running job
+  GET    /api/v1/jobs/{id}/events  event stream (WebSocket or NDJSON)
+  GET    /api/v1/schedules         scheduled jobs with next and last run
+  POST   /api/v1/schedules/{name}/pause|resume|trigger
+  POST   /api/v1/jobs/plugin       run a plugin ({"plugin","args"}, with --plugins)
+  GET    /api/v1/plugins           loaded plugin versions and in-flight runs
+  GET    /api/v1/events            logged webhook events as NDJSON
+                                   (?from=<seq>&since=<RFC 3339>&type=push)
+
+With --grpc-addr the same API is also served over gRPC, with server
+reflection enabled, for tools that integrate programmatically
+(pkg/api/serve/v1/serve.proto):
+  JobService          jobs and schedules; WatchJob streams job events
+  RepositoryService   ListRepositories of an organization
+  MonitoringService   health checks; WatchHealth streams status changes
+The token is passed as "authorization: Bearer <token>" metadata.
+
+With --plugins the installed plugins are loaded and reloaded when
+'gz plugin install' or 'gz plugin update' changes them. A new version is
+health checked (plugin --version) and loaded alongside the running one; new
+runs switch to it atomically while runs already started finish on the old
+version. If the new version fails its check, or fails it again after
+--plugin-probation, the previous version stays or becomes active again.
+Plugins run in the sandbox with the permissions granted through
+'gz plugin permissions grant'.
+
+With --webhook-secret or ` + webhookSecretEnv + ` the server receives provider
+webhooks, authenticated by their signature instead of the token:
+  POST   /webhooks/github   X-Hub-Signature-256
+  POST   /webhooks/gitea    X-Gitea-Signature
+  POST   /webhooks/gitlab   X-Gitlab-Token (system hooks)
+GitHub repository events and GitLab project and group system hooks evict
+the cached organization and group listings in ~/.gzh/cache, so the next
+clone or sync on this machine sees the change. Caches on other machines
+are not notified and keep their listings until they expire. GitHub and Gitea events are appended to
+--event-log and kept across restarts. With --plugins each event is also
+passed to the installed plugins that subscribe to its type (the "events"
+list of their release) as JSON on the stdin of '<plugin> event'. A
+plugin's position in the log is committed after it succeeds, so it gets
+every event once, including those logged while the server was down;
+failed deliveries are retried.
+
+Probes, not authenticated, for Kubernetes and load balancers:
+  GET    /livez     job workers and scheduler loop
+  GET    /readyz    503 while starting, draining or when a check fails
+  GET    /healthz   every check; "degraded" unless a liveness check fails
+
+Checks (--health-checks): workers, scheduler, queue (free slots), storage
+(--data-dir writable), cache (~/.gzh/cache writable), events (config
+reload running) and providers (tokens validated at most every
+--health-provider-interval). Add ?exclude=<check> to skip one.
+
+Requests must carry "Authorization: Bearer <token>". Without --token or
+` + tokenEnv + ` a token is generated and kept in --token-file, readable only
+by the user, and reused on the next start. Binding to a non-loopback
+address requires an explicit token. Job submissions must be sent with
+"Content-Type: application/json", requests from web pages of another
+origin are refused, and a server bound to a loopback address only answers
+requests for localhost or a loopback IP.
+
+Examples:
+  gz serve --workspace ~/repos
+  gz serve --addr 0.0.0.0:8080 --token "$(cat token)" --workers 4
+  gz serve --schedule schedules.yaml
+  gz serve --grpc-addr 127.0.0.1:9090
+  gz serve --plugins --plugin-probation 1m
+  gz serve --leak-detect --leak-dump-dir /tmp/gz-profiles
+  gz serve --capture-cpu 150 --capture-heap-growth 512 --capture-goroutines 5000
+  gz serve schedules list
+
+  curl -X POST localhost:8080/api/v1/jobs/bulk-clone \
+    -H "Authorization: Bearer $(cat ~/.gzh/serve/token)" -H 'Content-Type: application/json' \
+    -d '{"provider":"github","org":"myorg","parallel":10}'
+  grpcurl -plaintext -d '{"id":"<job id>"}' localhost:9090 gz.serve.v1.JobService/WatchJob`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.token == "" {
+				opts.token = os.Getenv(tokenEnv)
+			}
+			if opts.webhookSecret == "" {
+				opts.webhookSecret = os.Getenv(webhookSecretEnv)
+			}
+			return runServe(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.addr, "addr", "127.0.0.1:8080", "Address to listen on")
+	cmd.Flags().StringVar(&opts.grpcAddr, "grpc-addr", "", "Address to serve the gRPC API on (disabled when empty)")
+	cmd.Flags().StringVar(&opts.token, "token", "", "Bearer token required by the API (default $"+tokenEnv+")")
+	cmd.Flags().StringVar(&opts.tokenFile, "token-file", defaultTokenPath(), "File holding the generated token when no token is given")
+	cmd.Flags().IntVar(&opts.workers, "workers", 2, "Number of jobs run concurrently")
+	cmd.Flags().IntVar(&opts.queueSize, "queue-size", 100, "Number of jobs that may wait for a worker")
+	cmd.Flags().DurationVar(&opts.jobTimeout, "job-timeout", 2*time.Hour, "Maximum run time of a single job")
+	cmd.Flags().StringVar(&opts.dataDir, "data-dir", defaultDataDir(), "Directory where job state is persisted")
+	cmd.Flags().StringVar(&opts.workspace, "workspace", ".", "Directory that clone targets are relative to")
+	cmd.Flags().StringVar(&opts.configPath, "config", "", "Configuration file reloaded on change (tokens, concurrency, log level)")
+	cmd.Flags().StringVar(&opts.schedulePath, "schedule", "", "YAML file with jobs to run on cron schedules")
+	cmd.Flags().StringSliceVar(&opts.healthChecks, "health-checks", nil, "Checks behind /readyz and /healthz (default all)")
+	cmd.Flags().DurationVar(&opts.providerInterval, "health-provider-interval", 5*time.Minute, "How often the providers check validates tokens")
+	cmd.Flags().BoolVar(&opts.plugins, "plugins", false, "Run plugin jobs and hot-reload installed plugins")
+	cmd.Flags().StringVar(&opts.pluginDir, "plugin-dir", plugins.DefaultPluginDir(), "Plugin installation directory")
+	cmd.Flags().StringVar(&opts.eventLog, "event-log", defaultEventLogPath(), "File where webhook events are persisted")
+	cmd.Flags().StringVar(&opts.webhookSecret, "webhook-secret", "", "Secret that provider webhooks are signed with (default $"+webhookSecretEnv+")")
+	cmd.Flags().DurationVar(&opts.pluginProbation, "plugin-probation", 30*time.Second, "Recheck a new plugin version after this long and roll back if it fails (0 = off)")
+
+	cmd.AddCommand(newSchedulesCmd())
+	memory.AddLeakFlags(cmd)
+	debug.AddTriggerFlags(cmd)
+
+	return cmd
+}
+
+func defaultDataDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".gzh", "serve", "jobs")
+	}
+	return filepath.Join(home, ".gzh", "serve", "jobs")
+}
+
+// defaultTokenPath returns ~/.gzh/serve/token.
+func defaultTokenPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".gzh", "serve", "token")
+	}
+	return filepath.Join(home, ".gzh", "serve", "token")
+}
+
+// loadOrCreateToken returns the token kept in path, generating and storing
+// one when the file does not exist yet.
+func loadOrCreateToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if token := strings.TrimSpace(string(data)); token != "" {
+			return token, nil
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", fmt.Errorf("failed to create token directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write token file: %w", err)
+	}
+	return token, nil
+}
+
+func runServe(ctx context.Context, opts *serveOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, addr := range []string{opts.addr, opts.grpcAddr} {
+		if addr != "" && opts.token == "" && !isLoopback(addr) {
+			return fmt.Errorf("refusing to listen on %s without a token; set --token or %s", addr, tokenEnv)
+		}
+	}
+	if opts.token == "" {
+		// 루프백이라도 같은 기기의 웹 페이지가 API를 호출할 수 있으므로 토큰 없이는 열지 않는다
+		token, err := loadOrCreateToken(opts.tokenFile)
+		if err != nil {
+			return err
+		}
+		opts.token = token
+		fmt.Printf("🔑 API token in %s\n", opts.tokenFile)
+	}
+	if opts.workers < 1 {
+		return fmt.Errorf("--workers must be at least 1")
+	}
+	enabledChecks, err := parseHealthChecks(opts.healthChecks)
+	if err != nil {
+		return err
+	}
+
+	workspace, err := filepath.Abs(opts.workspace)
+	if err != nil {
+		return fmt.Errorf("invalid workspace: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	runtime := &settings{}
+	if w, err := watchConfig(ctx, opts.configPath, runtime); err != nil {
+		return err
+	} else if w != nil {
+		fmt.Printf("🔄 Reloading %s on change\n", w.Path())
+	}
+
+	manager, err := jobs.NewManager(jobs.Config{
+		Dir:        opts.dataDir,
+		Workers:    opts.workers,
+		QueueSize:  opts.queueSize,
+		JobTimeout: opts.jobTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	manager.Register(jobBulkClone, &cloneRunner{workspace: workspace, newProvider: newProvider, settings: runtime})
+	manager.Register(jobSync, &syncRunner{newProvider: newProvider, settings: runtime})
+	manager.Register(jobCompliance, &complianceRunner{workspace: workspace, audit: githubAudit, settings: runtime})
+	manager.Register(jobTokenCheck, &tokenCheckRunner{newProvider: newProvider, settings: runtime})
+	var pluginManager *plugins.Manager
+	if opts.plugins {
+		pluginManager = startPlugins(ctx, opts)
+		manager.Register(jobPlugin, &pluginRunner{plugins: pluginManager})
+	}
+
+	schedulePath := opts.schedulePath
+	if schedulePath == "" {
+		schedulePath = defaultSchedulePath()
+	}
+	scheduled, err := loadSchedules(schedulePath)
+	if err != nil {
+		return err
+	}
+	scheduler, err := jobs.NewScheduler(manager, scheduled, jobs.SchedulerConfig{
+		StatePath: filepath.Join(opts.dataDir, "schedules", "state.json"),
+	})
+	if err != nil {
+		return err
+	}
+
+	probes := newHealth(enabledChecks, healthDeps{
+		jobs:      manager,
+		schedules: scheduler,
+		dataDir:   opts.dataDir,
+		cacheDir:  defaultCacheDir(),
+		settings:  runtime,
+		providers: &providerProbe{newProvider: newProvider, settings: runtime, interval: opts.providerInterval, now: time.Now},
+	})
+
+	if err := manager.Start(); err != nil {
+		return fmt.Errorf("failed to start job workers: %w", err)
+	}
+	go scheduler.Run(ctx)
+	if len(scheduled) > 0 {
+		fmt.Printf("⏰ %d scheduled jobs loaded from %s\n", len(scheduled), schedulePath)
+	}
+
+	events, err := provider.OpenEventLog(opts.eventLog)
+	if err != nil {
+		_ = manager.Stop(5 * time.Second)
+		return err
+	}
+	defer events.Close()
+
+	listener, err := net.Listen("tcp", opts.addr)
+	if err != nil {
+		_ = manager.Stop(5 * time.Second)
+		return fmt.Errorf("failed to listen on %s: %w", opts.addr, err)
+	}
+
+	// 버퍼 1: 전달 중에 쌓인 알림은 한 번의 재전달로 합쳐진다
+	logged := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case logged <- struct{}{}:
+		default:
+		}
+	}
+	if pluginManager != nil {
+		go deliverPluginEvents(ctx, pluginManager, events, logged)
+	}
+
+	mux := http.NewServeMux()
+	probes.register(mux)
+	if opts.webhookSecret != "" {
+		hooks, err := newWebhooks(events, opts.webhookSecret, notify)
+		if err != nil {
+			_ = listener.Close()
+			_ = manager.Stop(5 * time.Second)
+			return err
+		}
+		defer hooks.Close()
+		hooks.register(mux)
+		fmt.Printf("🪝 Receiving webhooks, events logged to %s\n", opts.eventLog)
+	}
+	mux.Handle("/", (&api{
+		jobs:      manager,
+		schedules: scheduler,
+		plugins:   pluginManager,
+		eventLog:  events,
+		token:     opts.token,
+		loopback:  isLoopback(opts.addr),
+	}).handler())
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 2)
+	var grpcServer *grpc.Server
+	if opts.grpcAddr != "" {
+		grpcListener, err := net.Listen("tcp", opts.grpcAddr)
+		if err != nil {
+			_ = listener.Close()
+			_ = manager.Stop(5 * time.Second)
+			return fmt.Errorf("failed to listen on %s: %w", opts.grpcAddr, err)
+		}
+		grpcServer = (&grpcAPI{
+			jobs:        manager,
+			schedules:   scheduler,
+			health:      probes,
+			settings:    runtime,
+			newProvider: newProvider,
+			token:       opts.token,
+		}).server()
+		go func() {
+			errCh <- grpcServer.Serve(grpcListener)
+		}()
+		fmt.Printf("🔌 gRPC API listening on %s\n", grpcListener.Addr())
+	}
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+	probes.ready.Store(true)
+	fmt.Printf("🚀 gz serve listening on http://%s (workers: %d, workspace: %s)\n", listener.Addr(), opts.workers, workspace)
+
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		fmt.Println("Shutting down...")
+	}
+	probes.ready.Store(false)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = server.Shutdown(shutdownCtx)
+	if grpcServer != nil {
+		stopGRPC(grpcServer, 10*time.Second)
+	}
+	if stopErr := manager.Stop(30 * time.Second); stopErr != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  %v\n", stopErr)
+	}
+	if pluginManager != nil {
+		stopPlugins(pluginManager, 30*time.Second)
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// isLoopbackHost reports whether host, the Host of a request with an
+// optional port, names the local machine.
+func isLoopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// isLoopback reports whether addr only accepts local connections.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
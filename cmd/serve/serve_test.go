@@ -0,0 +1,593 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/jobs"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// stubRunner emits a fixed set of events and waits for release.
+type stubRunner struct {
+	release chan struct{}
+}
+
+func (r *stubRunner) Validate(params json.RawMessage) error {
+	var p struct {
+		Org string `json:"org"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return err
+	}
+	if p.Org == "" {
+		return errors.New("org is required")
+	}
+	return nil
+}
+
+func (r *stubRunner) Run(ctx context.Context, _ json.RawMessage, emit func(cli.Event)) error {
+	emit(cli.Event{Type: "start", Data: map[string]any{"total": 2}})
+	emit(cli.Event{Type: "success", Target: "acme/api"})
+	select {
+	case <-r.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	emit(cli.Event{Type: "success", Target: "acme/web"})
+	return nil
+}
+
+func newTestServer(t *testing.T, token string) (*httptest.Server, *stubRunner) {
+	t.Helper()
+	manager, err := jobs.NewManager(jobs.Config{Dir: t.TempDir(), Workers: 1, QueueSize: 4})
+	require.NoError(t, err)
+	runner := &stubRunner{release: make(chan struct{})}
+	manager.Register(jobBulkClone, runner)
+	scheduler, err := jobs.NewScheduler(manager, []jobs.ScheduledJob{{
+		Name:   "nightly",
+		Cron:   "0 2 * * *",
+		Type:   jobBulkClone,
+		Params: json.RawMessage(`{"org":"acme"}`),
+	}}, jobs.SchedulerConfig{})
+	require.NoError(t, err)
+	require.NoError(t, manager.Start())
+
+	srv := httptest.NewServer((&api{jobs: manager, schedules: scheduler, token: token}).handler())
+	t.Cleanup(func() {
+		srv.Close()
+		_ = manager.Stop(5 * time.Second)
+	})
+	return srv, runner
+}
+
+func doRequest(t *testing.T, method, url, token, body string) (*http.Response, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var decoded map[string]any
+	_ = json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp, decoded
+}
+
+func TestJobAPI(t *testing.T) {
+	srv, runner := newTestServer(t, "secret")
+	base := srv.URL + "/api/v1/jobs"
+
+	resp, _ := doRequest(t, http.MethodGet, base, "", "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, body := doRequest(t, http.MethodPost, base+"/bulk-clone", "secret", `{"org":""}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "org is required", body["error"])
+
+	resp, _ = doRequest(t, http.MethodPost, base+"/mirror", "secret", `{"org":"acme"}`)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, body = doRequest(t, http.MethodPost, base+"/bulk-clone", "secret", `{"org":"acme"}`)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	id := body["id"].(string)
+	assert.Equal(t, "/api/v1/jobs/"+id, resp.Header.Get("Location"))
+
+	require.Eventually(t, func() bool {
+		_, body := doRequest(t, http.MethodGet, base+"/"+id, "secret", "")
+		return body["status"] == "running"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	close(runner.release)
+	require.Eventually(t, func() bool {
+		_, body := doRequest(t, http.MethodGet, base+"/"+id, "secret", "")
+		return body["status"] == "succeeded"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, body = doRequest(t, http.MethodGet, base+"/"+id, "secret", "")
+	assert.Equal(t, map[string]any{"total": 2.0, "completed": 2.0, "failed": 0.0, "skipped": 0.0}, body["progress"])
+
+	resp, _ = doRequest(t, http.MethodDelete, base+"/"+id, "secret", "")
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	resp, _ = doRequest(t, http.MethodGet, base+"/missing", "secret", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	_, body = doRequest(t, http.MethodGet, base+"?status=succeeded", "secret", "")
+	assert.Len(t, body["jobs"], 1)
+}
+
+func TestJobAPICancel(t *testing.T) {
+	srv, _ := newTestServer(t, "")
+	base := srv.URL + "/api/v1/jobs"
+
+	_, body := doRequest(t, http.MethodPost, base+"/bulk-clone", "", `{"org":"acme"}`)
+	id := body["id"].(string)
+
+	resp, _ := doRequest(t, http.MethodDelete, base+"/"+id, "", "")
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	require.Eventually(t, func() bool {
+		_, body := doRequest(t, http.MethodGet, base+"/"+id, "", "")
+		return body["status"] == "canceled"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestJobEventsNDJSON(t *testing.T) {
+	srv, runner := newTestServer(t, "")
+	_, body := doRequest(t, http.MethodPost, srv.URL+"/api/v1/jobs/bulk-clone", "", `{"org":"acme"}`)
+	id := body["id"].(string)
+
+	resp, err := http.Get(srv.URL + "/api/v1/jobs/" + id + "/events")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	close(runner.release)
+	var types []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var event cli.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []string{"start", "success", "success", "job"}, types)
+}
+
+func TestJobEventsWebSocket(t *testing.T) {
+	srv, runner := newTestServer(t, "secret")
+	_, body := doRequest(t, http.MethodPost, srv.URL+"/api/v1/jobs/bulk-clone", "secret", `{"org":"acme"}`)
+	id := body["id"].(string)
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	require.NoError(t, err)
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	key := "dGhlIHNhbXBsZSBub25jZQ=="
+	_, err = io.WriteString(conn, "GET /api/v1/jobs/"+id+"/events?access_token=secret HTTP/1.1\r\n"+
+		"Host: test\r\nUpgrade: websocket\r\nConnection: keep-alive, Upgrade\r\n"+
+		"Sec-WebSocket-Key: "+key+"\r\nSec-WebSocket-Version: 13\r\n\r\n")
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+
+	close(runner.release)
+	var types []string
+	for {
+		var head [2]byte
+		_, err := io.ReadFull(reader, head[:])
+		require.NoError(t, err)
+		opcode := head[0] & 0x0F
+		length := int(head[1] & 0x7F)
+		if length == 126 {
+			var ext [2]byte
+			_, err = io.ReadFull(reader, ext[:])
+			require.NoError(t, err)
+			length = int(binary.BigEndian.Uint16(ext[:]))
+		}
+		payload := make([]byte, length)
+		_, err = io.ReadFull(reader, payload)
+		require.NoError(t, err)
+		if opcode == 0x8 { // close
+			break
+		}
+		require.Equal(t, byte(0x1), opcode) // text
+		var event cli.Event
+		require.NoError(t, json.Unmarshal(payload, &event))
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []string{"start", "success", "success", "job"}, types)
+}
+
+func TestScheduleAPI(t *testing.T) {
+	srv, runner := newTestServer(t, "")
+	base := srv.URL + "/api/v1/schedules"
+
+	_, body := doRequest(t, http.MethodGet, base, "", "")
+	require.Len(t, body["schedules"], 1)
+	nightly := body["schedules"].([]any)[0].(map[string]any)
+	assert.Equal(t, "nightly", nightly["name"])
+	assert.NotEmpty(t, nightly["nextRun"])
+
+	resp, body := doRequest(t, http.MethodPost, base+"/nightly/pause", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, true, body["paused"])
+	assert.Nil(t, body["nextRun"])
+
+	resp, body = doRequest(t, http.MethodPost, base+"/nightly/trigger", "", "")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "/api/v1/jobs/"+body["id"].(string), resp.Header.Get("Location"))
+
+	resp, _ = doRequest(t, http.MethodPost, base+"/nightly/trigger", "", "")
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	close(runner.release)
+	require.Eventually(t, func() bool {
+		_, body := doRequest(t, http.MethodGet, base+"/nightly", "", "")
+		last, _ := body["lastRun"].(map[string]any)
+		return last["status"] == "succeeded" && last["trigger"] == "manual"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	resp, body = doRequest(t, http.MethodPost, base+"/nightly/resume", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, false, body["paused"])
+
+	resp, _ = doRequest(t, http.MethodPost, base+"/missing/trigger", "", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestLoadSchedules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`schedules:
+  - name: nightly-clone
+    cron: "0 2 * * *"
+    type: bulk-clone
+    jitter: 10m
+    timezone: Asia/Seoul
+    params:
+      provider: github
+      org: acme
+      topics: [go]
+  - name: tokens
+    cron: "@daily"
+    type: token-check
+`), 0o600))
+
+	scheduled, err := loadSchedules(path)
+	require.NoError(t, err)
+	require.Len(t, scheduled, 2)
+	assert.Equal(t, 10*time.Minute, scheduled[0].Jitter)
+	assert.Equal(t, "Asia/Seoul", scheduled[0].Location.String())
+	assert.JSONEq(t, `{"provider":"github","org":"acme","topics":["go"]}`, string(scheduled[0].Params))
+	assert.JSONEq(t, `{}`, string(scheduled[1].Params))
+	assert.Nil(t, scheduled[1].Location)
+
+	none, err := loadSchedules("")
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestComplianceRunner(t *testing.T) {
+	workspace := t.TempDir()
+	var gotPath, gotOrg string
+	r := &complianceRunner{
+		workspace: workspace,
+		audit: func(_ context.Context, _, configPath, org string) (*config.AuditReport, error) {
+			gotPath, gotOrg = configPath, org
+			return &config.AuditReport{
+				Summary: config.AuditSummary{CompliantRepositories: 1, TotalViolations: 1},
+				Repositories: []config.RepoAuditResult{
+					{Repository: "api", Compliant: true},
+					{Repository: "web", Violations: []config.PolicyViolation{{Message: "branch protection disabled"}}},
+				},
+			}, nil
+		},
+	}
+
+	assert.Error(t, r.Validate(json.RawMessage(`{"config":"policy.yaml"}`)))
+	assert.Error(t, r.Validate(json.RawMessage(`{"org":"acme","config":"../policy.yaml"}`)))
+
+	var events []cli.Event
+	err := r.Run(context.Background(), json.RawMessage(`{"org":"acme","config":"policy.yaml"}`), func(e cli.Event) {
+		events = append(events, e)
+	})
+	assert.EqualError(t, err, "1 policy violations in acme")
+	assert.Equal(t, filepath.Join(workspace, "policy.yaml"), gotPath)
+	assert.Equal(t, "acme", gotOrg)
+	require.Len(t, events, 4)
+	assert.Equal(t, "fail", events[2].Type)
+	assert.Equal(t, "branch protection disabled", events[2].Error)
+}
+
+// tokenProvider answers token validation with a fixed result.
+type tokenProvider struct {
+	provider.GitProvider
+	err   error
+	calls *int
+}
+
+func (p tokenProvider) ValidateToken(context.Context) (*provider.TokenInfo, error) {
+	*p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &provider.TokenInfo{Valid: true}, nil
+}
+
+func TestHealthProbes(t *testing.T) {
+	manager, err := jobs.NewManager(jobs.Config{Dir: t.TempDir(), Workers: 1, QueueSize: 1})
+	require.NoError(t, err)
+	runner := &stubRunner{release: make(chan struct{})}
+	manager.Register(jobBulkClone, runner)
+	scheduler, err := jobs.NewScheduler(manager, nil, jobs.SchedulerConfig{})
+	require.NoError(t, err)
+
+	for _, env := range providerTokenEnv {
+		t.Setenv(env, "")
+	}
+	var calls int
+	providerErr := errors.New("401 Bad credentials")
+	runtime := &settings{tokens: map[string]string{"github": "ghp_x"}}
+	probes := newHealth(healthCheckNames, healthDeps{
+		jobs:      manager,
+		schedules: scheduler,
+		dataDir:   t.TempDir(),
+		cacheDir:  t.TempDir(),
+		settings:  runtime,
+		providers: &providerProbe{
+			newProvider: func(string, string, string) (provider.GitProvider, error) {
+				return tokenProvider{err: providerErr, calls: &calls}, nil
+			},
+			settings: runtime,
+			interval: time.Hour,
+			now:      time.Now,
+		},
+	})
+	mux := http.NewServeMux()
+	probes.register(mux)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	resp, body := doRequest(t, http.MethodGet, srv.URL+"/readyz", "", "")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "not ready", body["status"])
+	resp, _ = doRequest(t, http.MethodGet, srv.URL+"/livez", "", "")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode, "workers and scheduler are not running")
+
+	require.NoError(t, manager.Start())
+	t.Cleanup(func() { _ = manager.Stop(5 * time.Second) })
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go scheduler.Run(ctx)
+	require.Eventually(t, func() bool { return scheduler.Check() == nil }, 5*time.Second, 10*time.Millisecond)
+	probes.ready.Store(true)
+
+	resp, body = doRequest(t, http.MethodGet, srv.URL+"/livez", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, body["checks"], 2)
+
+	// The provider check fails readiness but not liveness.
+	resp, body = doRequest(t, http.MethodGet, srv.URL+"/readyz", "", "")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	checks := body["checks"].([]any)
+	require.Len(t, checks, len(healthCheckNames))
+	assert.Equal(t, map[string]any{"name": "providers", "status": "failed", "error": "github: 401 Bad credentials"}, checks[6])
+
+	resp, body = doRequest(t, http.MethodGet, srv.URL+"/healthz", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "degraded", body["status"])
+	assert.Equal(t, 1, calls, "provider results are reused within the interval")
+
+	resp, body = doRequest(t, http.MethodGet, srv.URL+"/readyz?exclude=providers", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, body["checks"], len(healthCheckNames)-1)
+
+	// A full queue takes the server out of rotation.
+	for range 2 {
+		_, err := manager.Submit(jobBulkClone, json.RawMessage(`{"org":"acme"}`))
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool { return manager.Check() != nil }, 5*time.Second, 10*time.Millisecond)
+	resp, _ = doRequest(t, http.MethodGet, srv.URL+"/readyz?exclude=providers", "", "")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	close(runner.release)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		resp, _ := doRequest(t, http.MethodGet, srv.URL+"/healthz", "", "")
+		return resp.StatusCode == http.StatusServiceUnavailable
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestParseHealthChecks(t *testing.T) {
+	all, err := parseHealthChecks(nil)
+	require.NoError(t, err)
+	assert.Equal(t, healthCheckNames, all)
+
+	got, err := parseHealthChecks([]string{"queue", " workers", "queue"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"queue", "workers"}, got)
+
+	_, err = parseHealthChecks([]string{"database"})
+	assert.ErrorContains(t, err, `unknown health check "database"`)
+}
+
+func TestTokenCheckRunnerValidate(t *testing.T) {
+	r := &tokenCheckRunner{}
+	assert.NoError(t, r.Validate(json.RawMessage(`{}`)))
+	assert.NoError(t, r.Validate(json.RawMessage(`{"providers":["github","gitea"]}`)))
+	assert.Error(t, r.Validate(json.RawMessage(`{"providers":["bitbucket"]}`)))
+}
+
+func TestWorkspacePath(t *testing.T) {
+	ws := filepath.Join(string(filepath.Separator), "srv", "repos")
+
+	got, err := workspacePath(ws, "acme/backend")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(ws, "acme", "backend"), got)
+
+	for _, bad := range []string{"", "../etc", "a/../../b", "/abs"} {
+		_, err := workspacePath(ws, bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestCloneRunnerValidate(t *testing.T) {
+	r := &cloneRunner{workspace: t.TempDir()}
+	assert.NoError(t, r.Validate(json.RawMessage(`{"provider":"github","org":"acme","strategy":"pull"}`)))
+	assert.Error(t, r.Validate(json.RawMessage(`{"provider":"bitbucket","org":"acme"}`)))
+	assert.Error(t, r.Validate(json.RawMessage(`{"provider":"github","org":"acme","token":"x"}`)))
+	assert.Error(t, r.Validate(json.RawMessage(`{"provider":"github","org":"acme","target":"../x"}`)))
+	assert.NoError(t, r.Validate(json.RawMessage(`{"provider":"github","org":"acme","filter":"language == go and not archived"}`)))
+	assert.ErrorContains(t, r.Validate(json.RawMessage(`{"provider":"github","org":"acme","filter":"stars ~ 1"}`)), "stars does not support ~")
+
+	s := &syncRunner{}
+	assert.NoError(t, s.Validate(json.RawMessage(`{"from":"github:acme","to":"gitea:acme","includeCode":true}`)))
+	assert.Error(t, s.Validate(json.RawMessage(`{"from":"github:acme","to":"gitea:acme"}`)))
+}
+
+func TestIsLoopback(t *testing.T) {
+	assert.True(t, isLoopback("127.0.0.1:8080"))
+	assert.True(t, isLoopback("localhost:8080"))
+	assert.True(t, isLoopback("[::1]:8080"))
+	assert.False(t, isLoopback("0.0.0.0:8080"))
+	assert.False(t, isLoopback(":8080"))
+
+	assert.True(t, isLoopbackHost("localhost:8080"))
+	assert.True(t, isLoopbackHost("127.0.0.1"))
+	assert.True(t, isLoopbackHost("[::1]:8080"))
+	assert.False(t, isLoopbackHost("evil.example.com:8080"))
+	assert.False(t, isLoopbackHost("localhost.evil.example.com"))
+}
+
+func TestAPIRejectsBrowserRequests(t *testing.T) {
+	srv, _ := newTestServer(t, "secret")
+	url := srv.URL + "/api/v1/jobs/" + jobBulkClone
+
+	// 폼이나 text/plain으로 보낸 작업은 받지 않는다
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"org":"acme"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "text/plain")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+	// 다른 출처의 웹 페이지는 토큰이 있어도 거부된다
+	req, err = http.NewRequest(http.MethodGet, srv.URL+"/api/v1/jobs", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Origin", "https://evil.example.com")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	req.Header.Set("Origin", srv.URL)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestAPIRejectsRebindingHost(t *testing.T) {
+	handler := (&api{token: "secret", loopback: true}).handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/schedules", nil)
+	req.Host = "rebind.evil.example.com:8080"
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req.Host = "localhost:8080"
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestLoadOrCreateToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "serve", "token")
+
+	token, err := loadOrCreateToken(path)
+	require.NoError(t, err)
+	assert.Len(t, token, 2*tokenBytes)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// 다음 실행에서도 같은 토큰을 쓴다
+	again, err := loadOrCreateToken(path)
+	require.NoError(t, err)
+	assert.Equal(t, token, again)
+}
+
+// fakePlugins writes fixed output and reports a version.
+type fakePlugins struct {
+	gotArgs []string
+}
+
+func (f *fakePlugins) Run(_ context.Context, name string, args []string, stdout, stderr io.Writer) (string, error) {
+	f.gotArgs = args
+	_, _ = io.WriteString(stdout, "line one\nline ")
+	_, _ = io.WriteString(stdout, "two\npartial")
+	_, _ = io.WriteString(stderr, "careful\n")
+	if name == "broken" {
+		return "0.9.0", errors.New("plugin broken 0.9.0 exited with code 1")
+	}
+	return "1.2.0", nil
+}
+
+func TestPluginRunner(t *testing.T) {
+	fake := &fakePlugins{}
+	r := &pluginRunner{plugins: fake}
+
+	assert.Error(t, r.Validate(json.RawMessage(`{}`)))
+	assert.Error(t, r.Validate(json.RawMessage(`{"plugin":"hello","env":{}}`)))
+
+	var events []cli.Event
+	err := r.Run(context.Background(), json.RawMessage(`{"plugin":"hello","args":["--name","world"]}`), func(e cli.Event) {
+		events = append(events, e)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--name", "world"}, fake.gotArgs)
+
+	var messages []string
+	for _, e := range events {
+		messages = append(messages, e.Type+":"+e.Message)
+	}
+	assert.Equal(t, []string{"info:line one", "info:line two", "warning:careful", "info:partial", "success:Plugin completed"}, messages)
+	assert.Equal(t, "1.2.0", events[4].Data["version"])
+
+	err = r.Run(context.Background(), json.RawMessage(`{"plugin":"broken"}`), func(cli.Event) {})
+	assert.EqualError(t, err, "plugin broken 0.9.0 exited with code 1")
+}
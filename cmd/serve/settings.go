@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package serve
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+// settings holds the values that --config may change while the server runs.
+// The zero value uses the environment tokens and the runner defaults.
+type settings struct {
+	mu            sync.RWMutex
+	tokens        map[string]string
+	cloneParallel int
+	syncParallel  int
+	// watchErr is why config reloading stopped, if it did.
+	watchErr error
+}
+
+// token returns the configured token for a provider, falling back to the
+// server environment.
+func (s *settings) token(providerType string) string {
+	if s != nil {
+		s.mu.RLock()
+		token := s.tokens[providerType]
+		s.mu.RUnlock()
+		if token != "" {
+			return token
+		}
+	}
+	return os.Getenv(providerTokenEnv[providerType])
+}
+
+// parallel returns the default parallelism for clone or sync jobs that do
+// not set one.
+func (s *settings) parallel(jobType string) int {
+	if s == nil {
+		return 0
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if jobType == jobSync {
+		return s.syncParallel
+	}
+	return s.cloneParallel
+}
+
+// watchError returns the error that stopped config reloading. It is nil
+// while the watcher runs and when no config is watched.
+func (s *settings) watchError() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.watchErr != nil {
+		return fmt.Errorf("config reload stopped: %w", s.watchErr)
+	}
+	return nil
+}
+
+// subscribe keeps the settings in sync with a config watcher. New values
+// apply to jobs started after the reload; running jobs are not affected.
+func (s *settings) subscribe(w *config.ConfigWatcher) {
+	s.load(config.DiffReloadable(nil, w.Current()))
+
+	config.Subscribe(w, func(e config.ProviderTokenChanged) error {
+		s.mu.Lock()
+		if s.tokens == nil {
+			s.tokens = make(map[string]string)
+		}
+		s.tokens[e.Provider] = e.New
+		s.mu.Unlock()
+		fmt.Printf("🔑 %s token reloaded\n", e.Provider)
+		return nil
+	})
+	config.Subscribe(w, func(e config.ConcurrencyChanged) error {
+		s.mu.Lock()
+		s.cloneParallel, s.syncParallel = e.New.CloneWorkers, e.New.UpdateWorkers
+		s.mu.Unlock()
+		fmt.Printf("⚙️  job parallelism reloaded (clone: %d, sync: %d)\n", e.New.CloneWorkers, e.New.UpdateWorkers)
+		return nil
+	})
+	config.Subscribe(w, func(e config.LogLevelChanged) error {
+		logger.SetGlobalLevel(e.New)
+		fmt.Printf("📝 log level reloaded (%s)\n", displayLevel(e.New))
+		return nil
+	})
+}
+
+// load applies the initial configuration without announcing it.
+func (s *settings) load(events []config.ReloadEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ev := range events {
+		switch e := ev.(type) {
+		case config.ProviderTokenChanged:
+			if s.tokens == nil {
+				s.tokens = make(map[string]string)
+			}
+			s.tokens[e.Provider] = e.New
+		case config.ConcurrencyChanged:
+			s.cloneParallel, s.syncParallel = e.New.CloneWorkers, e.New.UpdateWorkers
+		case config.LogLevelChanged:
+			logger.SetGlobalLevel(e.New)
+		}
+	}
+}
+
+func displayLevel(level string) string {
+	if level == "" {
+		return "from logging config"
+	}
+	return level
+}
+
+// watchConfig starts reloading --config in the background. An empty path
+// uses ~/.config/gzh-manager/gzh.yaml when it exists.
+func watchConfig(ctx context.Context, path string, s *settings) (*config.ConfigWatcher, error) {
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil //nolint:nilnil // no default config location
+		}
+		path = filepath.Join(home, ".config", "gzh-manager", "gzh.yaml")
+		if _, err := os.Stat(path); err != nil {
+			return nil, nil //nolint:nilnil // live reload is optional without --config
+		}
+	}
+
+	w, err := config.NewConfigWatcher(path, config.ConfigWatcherOptions{
+		OnError: func(err error) {
+			fmt.Fprintf(os.Stderr, "⚠️  config reload: %v\n", err)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	s.subscribe(w)
+
+	go func() {
+		if err := w.Watch(ctx); err != nil {
+			s.mu.Lock()
+			s.watchErr = err
+			s.mu.Unlock()
+			fmt.Fprintf(os.Stderr, "⚠️  config reload disabled: %v\n", err)
+		}
+	}()
+
+	return w, nil
+}
@@ -0,0 +1,397 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sshconfig
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/alerting"
+	sshcfg "github.com/gizzahub/gzh-cli/internal/sshconfig"
+)
+
+// knownHostsOptions holds flags shared by the known-hosts subcommands.
+type knownHostsOptions struct {
+	configPath string
+	knownHosts string
+	pinsPath   string
+	providers  []string
+	timeout    time.Duration
+}
+
+func newKnownHostsCmd() *cobra.Command {
+	opts := &knownHostsOptions{}
+	sshDir := defaultSSHDir()
+
+	cmd := &cobra.Command{
+		Use:   "known-hosts",
+		Short: "Populate known_hosts with verified provider host keys",
+		Long: `Manage known_hosts entries for Git provider hosts.
+
+Host keys are fetched with ssh-keyscan and verified before they are
+trusted: against the fingerprints pinned in the pins file (default
+~/.config/gzh-manager/ssh-host-keys.yaml), otherwise against the
+fingerprints published by github.com and gitlab.com, otherwise against
+keys already in known_hosts. Keys of other hosts must be confirmed.
+
+A key that does not match is never written and is reported as changed:
+either the provider rotated its key or the connection is intercepted.
+
+Without host arguments, the hosts of the SSH config that resolve to a
+provider are used, plus github.com and gitlab.com.
+
+Example pins file:
+  pins:
+    git.example.com:
+      - SHA256:Jf9E2n2m1Xq...`,
+		SilenceUsage: true,
+	}
+
+	cmd.PersistentFlags().StringVar(&opts.configPath, "config", filepath.Join(sshDir, "config"), "SSH client config file")
+	cmd.PersistentFlags().StringVar(&opts.knownHosts, "known-hosts", filepath.Join(sshDir, "known_hosts"), "known_hosts file")
+	cmd.PersistentFlags().StringVar(&opts.pinsPath, "pins", sshcfg.DefaultPinsPath(), "Host key pins file")
+	cmd.PersistentFlags().StringSliceVar(&opts.providers, "provider", sshcfg.DefaultProviders, "Git provider hostnames")
+	cmd.PersistentFlags().DurationVar(&opts.timeout, "timeout", 10*time.Second, "ssh-keyscan timeout")
+
+	cmd.AddCommand(newKnownHostsSyncCmd(opts))
+	cmd.AddCommand(newKnownHostsCheckCmd(opts))
+	cmd.AddCommand(newKnownHostsPinCmd(opts))
+	cmd.AddCommand(newKnownHostsUnpinCmd(opts))
+
+	return cmd
+}
+
+// targets returns the hosts given as arguments or the configured ones.
+func (o *knownHostsOptions) targets(args []string) ([]sshcfg.HostKeyTarget, error) {
+	if len(args) == 0 {
+		return sshcfg.ConfiguredTargets(o.configPath, o.providers)
+	}
+	targets := make([]sshcfg.HostKeyTarget, 0, len(args))
+	for _, arg := range args {
+		targets = append(targets, sshcfg.ParseHostKeyTarget(arg))
+	}
+	return targets, nil
+}
+
+func newKnownHostsSyncCmd(opts *knownHostsOptions) *cobra.Command {
+	var (
+		yes        bool
+		pin        bool
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync [host[:port]...]",
+		Short: "Add verified host keys that are missing from known_hosts",
+		Long: `Fetch the host keys of each host, verify them and append the ones
+missing from known_hosts. The file is backed up first.
+
+Hosts without pinned or published fingerprints and without keys in
+known_hosts are shown with their fingerprints for confirmation; compare
+them with the ones your provider publishes. --yes trusts them without
+asking and --pin records the confirmed fingerprints in the pins file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targets, err := opts.targets(args)
+			if err != nil {
+				return err
+			}
+			pins, err := sshcfg.LoadPins(opts.pinsPath)
+			if err != nil {
+				return err
+			}
+
+			m := sshcfg.NewHostKeyManager(opts.knownHosts, pins)
+			m.Timeout = opts.timeout
+			m.PinConfirmed = pin
+			m.Confirm = confirmKeys(cmd.InOrStdin(), cmd.OutOrStdout(), yes)
+
+			results := m.Sync(cmd.Context(), targets)
+			if pin {
+				if err := pins.Save(opts.pinsPath); err != nil {
+					return err
+				}
+			}
+			return reportHostKeys(cmd.OutOrStdout(), results, jsonOutput)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Trust unverifiable keys without asking")
+	cmd.Flags().BoolVar(&pin, "pin", false, "Pin the fingerprints of confirmed keys")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// confirmKeys asks on out whether to trust keys, reading the answer from in.
+func confirmKeys(in io.Reader, out io.Writer, yes bool) func(string, []sshcfg.HostKey) bool {
+	reader := bufio.NewReader(in)
+	return func(host string, keys []sshcfg.HostKey) bool {
+		fmt.Fprintf(out, "\n🔑 %s has no pinned or published fingerprints. It presents:\n", host)
+		for _, k := range keys {
+			fmt.Fprintf(out, "   %s %s\n", k.Type, k.Fingerprint())
+		}
+		if yes {
+			return true
+		}
+		fmt.Fprint(out, "Trust these keys? [y/N]: ")
+		answer, _ := reader.ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		return answer == "y" || answer == "yes"
+	}
+}
+
+func newKnownHostsCheckCmd(opts *knownHostsOptions) *cobra.Command {
+	var (
+		notify      []string
+		alertConfig string
+		jsonOutput  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "check [host[:port]...]",
+		Short: "Alert when a host key changed unexpectedly",
+		Long: `Fetch the host keys of each host and compare them with the pinned or
+published fingerprints and with known_hosts, without changing anything.
+
+The command fails when a host key changed, so it can run from cron. With
+--notify, every change is also sent to the named channels of the alert
+configuration used by 'gz monitoring alerts'.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			targets, err := opts.targets(args)
+			if err != nil {
+				return err
+			}
+			pins, err := sshcfg.LoadPins(opts.pinsPath)
+			if err != nil {
+				return err
+			}
+			var notifiers []alerting.Notifier
+			if len(notify) > 0 {
+				if notifiers, err = channelNotifiers(alertConfig, notify); err != nil {
+					return err
+				}
+			}
+
+			m := sshcfg.NewHostKeyManager(opts.knownHosts, pins)
+			m.Timeout = opts.timeout
+			m.DryRun = true
+
+			results := m.Sync(cmd.Context(), targets)
+			notifyErr := notifyChanges(cmd.Context(), notifiers, results)
+			if err := reportHostKeys(cmd.OutOrStdout(), results, jsonOutput); err != nil {
+				return err
+			}
+			return notifyErr
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&notify, "notify", nil, "Alert channels to notify about changed host keys")
+	cmd.Flags().StringVar(&alertConfig, "alert-config", alerting.DefaultConfigPath(), "Alert configuration that defines the channels")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func channelNotifiers(path string, names []string) ([]alerting.Notifier, error) {
+	cfg, err := alerting.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	notifiers := make([]alerting.Notifier, 0, len(names))
+	for _, name := range names {
+		ch, ok := cfg.Channels[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown alert channel %s in %s", name, path)
+		}
+		notifiers = append(notifiers, alerting.NewNotifier(ch, nil))
+	}
+	return notifiers, nil
+}
+
+// notifyChanges sends a critical alert for every changed host key.
+func notifyChanges(ctx context.Context, notifiers []alerting.Notifier, results []sshcfg.HostKeyResult) error {
+	var firstErr error
+	for _, r := range results {
+		if r.Status != sshcfg.HostKeyChanged {
+			continue
+		}
+		n := alerting.Notification{
+			Kind: alerting.EventFiring,
+			Alert: alerting.Alert{
+				ID:       "ssh-host-key",
+				Rule:     "ssh-host-key-changed",
+				Series:   r.Host,
+				Severity: "critical",
+				Summary:  r.Message,
+				Value:    1,
+				Status:   alerting.StatusFiring,
+				FiredAt:  time.Now(),
+			},
+		}
+		for _, notifier := range notifiers {
+			if err := notifier.Notify(ctx, n); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("failed to send host key alert: %w", err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// reportHostKeys prints results and fails when a key changed or could not
+// be synced.
+func reportHostKeys(out io.Writer, results []sshcfg.HostKeyResult, jsonOutput bool) error {
+	if jsonOutput {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		printHostKeys(out, results)
+	}
+
+	changed, failed := 0, 0
+	for _, r := range results {
+		switch r.Status {
+		case sshcfg.HostKeyChanged:
+			changed++
+		case sshcfg.HostKeyFailed:
+			failed++
+		}
+	}
+	switch {
+	case changed > 0:
+		return fmt.Errorf("host key changed for %d host(s)", changed)
+	case failed > 0:
+		return fmt.Errorf("failed to verify host keys of %d host(s)", failed)
+	}
+	return nil
+}
+
+func printHostKeys(out io.Writer, results []sshcfg.HostKeyResult) {
+	if len(results) == 0 {
+		fmt.Fprintln(out, "No hosts to check")
+		return
+	}
+
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "HOST\tSTATUS\tVERIFIED BY\tMESSAGE")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s %s\t%s\t%s\n", r.Host, hostKeyIcon(r.Status), r.Status, valueOr(r.Source, "-"), r.Message)
+	}
+	_ = w.Flush()
+
+	for _, r := range results {
+		if r.Status == sshcfg.HostKeyChanged {
+			fmt.Fprintf(out, "\n🚨 HOST KEY CHANGED for %s\n   %s\n   Do not connect until the new key is confirmed with the provider.\n", r.Host, r.Message)
+		}
+	}
+	for _, r := range results {
+		if r.Backup != "" {
+			fmt.Fprintf(out, "\nbackup: %s\n", r.Backup)
+			break
+		}
+	}
+}
+
+func hostKeyIcon(status sshcfg.HostKeyStatus) string {
+	switch status {
+	case sshcfg.HostKeyOK, sshcfg.HostKeyAdded:
+		return "✅"
+	case sshcfg.HostKeyChanged:
+		return "🚨"
+	case sshcfg.HostKeyFailed:
+		return "❌"
+	default:
+		return "⚠️"
+	}
+}
+
+func newKnownHostsPinCmd(opts *knownHostsOptions) *cobra.Command {
+	var fps []string
+
+	cmd := &cobra.Command{
+		Use:   "pin <host[:port]>",
+		Short: "Pin the host key fingerprints of a host",
+		Long: `Record the fingerprints a host must present. Without --fingerprint,
+the keys currently in known_hosts are pinned.
+
+Pinned fingerprints replace the published ones for github.com and
+gitlab.com, so a provider key rotation can be accepted by pinning the
+newly published fingerprints.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := sshcfg.ParseHostKeyTarget(args[0]).Name()
+			pins, err := sshcfg.LoadPins(opts.pinsPath)
+			if err != nil {
+				return err
+			}
+
+			if len(fps) == 0 {
+				for _, k := range sshcfg.LoadKnownHosts(opts.knownHosts).Keys(name) {
+					fps = append(fps, k.Fingerprint())
+				}
+				if len(fps) == 0 {
+					return fmt.Errorf("no keys for %s in %s; run 'gz ssh-config known-hosts sync %s' or pass --fingerprint", name, opts.knownHosts, args[0])
+				}
+			}
+			for _, fp := range fps {
+				if !strings.HasPrefix(fp, "SHA256:") {
+					return fmt.Errorf("fingerprint %q is not in SHA256:... form (see 'ssh-keygen -lf')", fp)
+				}
+			}
+
+			pins.Pin(name, fps...)
+			if err := pins.Save(opts.pinsPath); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "📌 Pinned %d fingerprint(s) for %s in %s\n", len(fps), name, opts.pinsPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&fps, "fingerprint", nil, "SHA256 fingerprint to pin (repeatable)")
+
+	return cmd
+}
+
+func newKnownHostsUnpinCmd(opts *knownHostsOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unpin <host[:port]>",
+		Short: "Remove the pinned fingerprints of a host",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := sshcfg.ParseHostKeyTarget(args[0]).Name()
+			pins, err := sshcfg.LoadPins(opts.pinsPath)
+			if err != nil {
+				return err
+			}
+			if !pins.Unpin(name) {
+				return fmt.Errorf("%s has no pinned fingerprints", name)
+			}
+			if err := pins.Save(opts.pinsPath); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "✅ Removed the pins of %s\n", name)
+			return nil
+		},
+	}
+}
+
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sshconfig
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type sshConfigCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p sshConfigCmdProvider) Command() *cobra.Command {
+	return NewSSHConfigCmd(p.appCtx)
+}
+
+func (p sshConfigCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "ssh-config",
+		Category:     registry.CategoryConfig,
+		Version:      "1.0.0",
+		Priority:     40,
+		Experimental: false,
+		Dependencies: []string{"ssh"},
+		Tags:         []string{"ssh", "config", "known_hosts", "doctor"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterSSHConfigCmd registers the ssh-config command with the command registry.
+func RegisterSSHConfigCmd(appCtx *app.AppContext) {
+	registry.Register(sshConfigCmdProvider{appCtx: appCtx})
+}
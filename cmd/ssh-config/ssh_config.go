@@ -0,0 +1,205 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package sshconfig implements the `gz ssh-config` command for diagnosing and
+// repairing the SSH client configuration.
+package sshconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+	sshcfg "github.com/gizzahub/gzh-cli/internal/sshconfig"
+)
+
+// NewSSHConfigCmd creates the ssh-config command.
+func NewSSHConfigCmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+
+	cmd := &cobra.Command{
+		Use:   "ssh-config",
+		Short: "Diagnose and repair the SSH client configuration",
+		Long: `Inspect ~/.ssh/config and the files it includes for drift that breaks
+Git operations over SSH.
+
+Examples:
+  gz ssh-config doctor
+  gz ssh-config doctor --auto-fix
+  gz ssh-config doctor --host github.com --skip-connectivity
+  gz ssh-config known-hosts sync
+  gz ssh-config known-hosts check --notify ops`,
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(newDoctorCmd())
+	cmd.AddCommand(newKnownHostsCmd())
+
+	return cmd
+}
+
+type doctorOptions struct {
+	configPath       string
+	knownHosts       []string
+	pinsPath         string
+	providers        []string
+	hosts            []string
+	skipConnectivity bool
+	autoFix          bool
+	timeout          time.Duration
+	jsonOutput       bool
+}
+
+func newDoctorCmd() *cobra.Command {
+	opts := &doctorOptions{}
+	sshDir := defaultSSHDir()
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Detect SSH config conflicts and verify provider connectivity",
+		Long: `Check the SSH client configuration for common problems:
+
+  duplicate-host        Host blocks with identical patterns (fixable)
+  conflict              Options ignored because an earlier block sets them
+  identity-permissions  IdentityFile readable by group or others (fixable)
+  identity-missing      IdentityFile that does not exist
+  known-hosts           Git provider hosts without a known_hosts entry (fixable)
+  host-key              known_hosts keys that do not match pinned or published
+                        fingerprints
+  connectivity          Git provider hosts where 'ssh -T' does not authenticate
+
+With --auto-fix, duplicate blocks are merged into the first one, key
+permissions are set to 0600 and missing host keys are fetched with
+ssh-keyscan; keys of hosts with pinned or published fingerprints are
+only added when they match. Rewritten files are backed up as <file>.bak-<timestamp>
+and replaced atomically.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDoctor(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.configPath, "config", filepath.Join(sshDir, "config"), "SSH client config file")
+	cmd.Flags().StringSliceVar(&opts.knownHosts, "known-hosts", []string{filepath.Join(sshDir, "known_hosts")}, "known_hosts files to check (the first receives new keys)")
+	cmd.Flags().StringVar(&opts.pinsPath, "pins", sshcfg.DefaultPinsPath(), "Host key pins file")
+	cmd.Flags().StringSliceVar(&opts.providers, "provider", sshcfg.DefaultProviders, "Git provider hostnames to verify")
+	cmd.Flags().StringSliceVar(&opts.hosts, "host", nil, "Only verify these Host aliases")
+	cmd.Flags().BoolVar(&opts.skipConnectivity, "skip-connectivity", false, "Do not run 'ssh -T' against provider hosts")
+	cmd.Flags().BoolVar(&opts.autoFix, "auto-fix", false, "Repair fixable problems (files are backed up first)")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 10*time.Second, "Connection timeout for ssh and ssh-keyscan")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func defaultSSHDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".ssh"
+	}
+	return filepath.Join(home, ".ssh")
+}
+
+type doctorResult struct {
+	*sshcfg.Report
+	Fixes []sshcfg.FixResult `json:"fixes,omitempty"`
+}
+
+func runDoctor(cmd *cobra.Command, opts *doctorOptions) error {
+	ctx := cmd.Context()
+	out := cmd.OutOrStdout()
+
+	pins, err := sshcfg.LoadPins(opts.pinsPath)
+	if err != nil {
+		return err
+	}
+
+	doctor := sshcfg.NewDoctor(opts.configPath)
+	doctor.KnownHosts = opts.knownHosts
+	doctor.Pins = pins
+	doctor.Providers = opts.providers
+	doctor.Hosts = opts.hosts
+	doctor.SkipConnectivity = opts.skipConnectivity
+	doctor.Timeout = opts.timeout
+
+	report, err := doctor.Run(ctx)
+	if err != nil {
+		return err
+	}
+	result := doctorResult{Report: report}
+
+	if opts.autoFix && len(report.Fixable()) > 0 {
+		result.Fixes = doctor.Fix(ctx, report)
+		// Re-check so the output reflects what is still wrong.
+		report, err = doctor.Run(ctx)
+		if err != nil {
+			return err
+		}
+		result.Report = report
+	}
+
+	if opts.jsonOutput {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		printResult(out, result, opts.autoFix)
+	}
+
+	if report.HasErrors() {
+		return fmt.Errorf("SSH config doctor found %d problem(s)", len(report.Issues))
+	}
+	return nil
+}
+
+func printResult(out io.Writer, result doctorResult, autoFix bool) {
+	fmt.Fprintf(out, "🔍 Checked %s", result.ConfigPath)
+	if n := len(result.Files) - 1; n > 0 {
+		fmt.Fprintf(out, " (+%d included file(s))", n)
+	}
+	fmt.Fprintln(out)
+
+	if len(result.Fixes) > 0 {
+		fmt.Fprintln(out, "\n🔧 Fixes:")
+		for _, f := range result.Fixes {
+			if f.Error != "" {
+				fmt.Fprintf(out, "  ❌ [%s] %s: %s\n", f.Check, f.Target, f.Error)
+				continue
+			}
+			fmt.Fprintf(out, "  ✅ [%s] %s: %s\n", f.Check, f.Target, f.Message)
+			if f.Backup != "" {
+				fmt.Fprintf(out, "     backup: %s\n", f.Backup)
+			}
+		}
+	}
+
+	if len(result.Issues) == 0 {
+		fmt.Fprintln(out, "\n✅ No problems found")
+		return
+	}
+
+	fmt.Fprintln(out)
+	for _, issue := range result.Issues {
+		icon := "⚠️ "
+		if issue.Severity == sshcfg.SeverityError {
+			icon = "❌"
+		}
+		location := filepath.Base(issue.File)
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, issue.Line)
+		}
+		fmt.Fprintf(out, "%s [%s] %s (%s): %s\n", icon, issue.Check, issue.Host, location, issue.Message)
+	}
+
+	if fixable := len(result.Fixable()); fixable > 0 && !autoFix {
+		fmt.Fprintf(out, "\n💡 %d problem(s) can be repaired with --auto-fix\n", fixable)
+	}
+}
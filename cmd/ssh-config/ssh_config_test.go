@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sshconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoctorCmd_AutoFixMergesDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config")
+	require.NoError(t, os.WriteFile(config, []byte("Host internal\n  User a\n\nHost internal\n  Port 2200\n"), 0o600))
+
+	var out bytes.Buffer
+	cmd := NewSSHConfigCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"doctor", "--config", config, "--known-hosts", filepath.Join(dir, "known_hosts"), "--skip-connectivity"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "[duplicate-host]")
+	assert.Contains(t, out.String(), "--auto-fix")
+
+	out.Reset()
+	cmd = NewSSHConfigCmd(nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"doctor", "--config", config, "--known-hosts", filepath.Join(dir, "known_hosts"), "--skip-connectivity", "--auto-fix", "--json"})
+	require.NoError(t, cmd.Execute())
+
+	var result struct {
+		Issues []json.RawMessage `json:"issues"`
+		Fixes  []struct {
+			Check  string `json:"check"`
+			Backup string `json:"backup"`
+		} `json:"fixes"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Empty(t, result.Issues)
+	require.Len(t, result.Fixes, 1)
+	assert.NotEmpty(t, result.Fixes[0].Backup)
+
+	data, err := os.ReadFile(config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), "Host internal"))
+	assert.Contains(t, string(data), "Port 2200")
+}
+
+func TestKnownHostsCmd_PinAndCheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as ssh-keyscan")
+	}
+	dir := t.TempDir()
+	knownHosts := filepath.Join(dir, "known_hosts")
+	pins := filepath.Join(dir, "ssh-host-keys.yaml")
+	require.NoError(t, os.WriteFile(knownHosts, []byte("git.example.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5\n"), 0o600))
+
+	// 고정된 키와 다른 키를 제시하는 가짜 ssh-keyscan
+	bin := filepath.Join(dir, "bin")
+	require.NoError(t, os.Mkdir(bin, 0o755))
+	script := "#!/bin/sh\nfor h; do :; done\necho \"$h ssh-ed25519 c3dhcHBlZCBrZXk=\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "ssh-keyscan"), []byte(script), 0o755))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := NewSSHConfigCmd(nil)
+		cmd.SetOut(&out)
+		cmd.SetArgs(append([]string{"known-hosts", "--known-hosts", knownHosts, "--pins", pins}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("pin", "git.example.com")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Pinned 1 fingerprint(s) for git.example.com")
+
+	_, err = run("pin", "git.example.org")
+	assert.ErrorContains(t, err, "no keys for git.example.org")
+
+	out, err = run("check", "git.example.com")
+	require.EqualError(t, err, "host key changed for 1 host(s)")
+	assert.Contains(t, out, "HOST KEY CHANGED for git.example.com")
+	assert.Contains(t, out, "pinned fingerprints")
+
+	out, err = run("unpin", "git.example.com")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Removed the pins of git.example.com")
+}
@@ -7,15 +7,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/gizzahub/gzh-cli/internal/app"
 	"github.com/gizzahub/gzh-cli/internal/config"
 	gerrors "github.com/gizzahub/gzh-cli/internal/errors"
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+	"github.com/gizzahub/gzh-cli/internal/workerpool"
 	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
 	"github.com/gizzahub/gzh-cli/pkg/github"
 	"github.com/gizzahub/gzh-cli/pkg/gitlab"
+	"github.com/gizzahub/gzh-cli/pkg/memory"
+	synclonepkg "github.com/gizzahub/gzh-cli/pkg/synclone"
 )
 
 type syncCloneOptions struct {
@@ -29,6 +34,9 @@ type syncCloneOptions struct {
 	resume         bool
 	progressMode   string
 	cleanupOrphans bool
+	noHooks        bool
+	sparseOwners   []string
+	noSparse       bool
 }
 
 func defaultSyncCloneOptions() *syncCloneOptions {
@@ -66,6 +74,38 @@ configuration file regardless of the provider (GitHub, GitLab, Gitea).
 When targeting an organization, a gzh.yaml file will be created in the target directory
 containing the repository list for future reference and synchronization.
 
+Lifecycle hooks configured under global.hooks or an organization's hooks
+(pre_clone, post_clone, post_update, on_failure) run shell commands or gz
+plugins for every repository. They receive the repository context as GZ_*
+environment variables and as JSON on stdin; their output is captured in the
+run report under <clone_dir>/.gzh/reports. Use --no-hooks to skip them:
+
+  global:
+    hooks:
+      post_clone:
+        - name: install
+          run: test ! -f package.json || npm ci
+          timeout: 10m
+      on_failure:
+        - plugin: notify
+          args: [--channel, ops]
+
+A sparse profile under global.sparse or an organization's sparse checks out
+only the paths a team owns, read from each repository's CODEOWNERS or from
+an explicit mapping. Repositories are cloned without blobs and fetch only
+what the owned paths need, and ownership changes are picked up on every
+update. Repositories with neither are checked out in full. Use
+--sparse-owner to select owners from the command line and --no-sparse to
+clone everything:
+
+  global:
+    sparse:
+      owners: ["@acme/payments"]
+      root_files: true           # also README.md, go.mod, ...
+      paths: [/tools/]           # always checked out
+      repositories:
+        legacy-*: [/billing/]    # used instead of CODEOWNERS
+
 For provider-specific operations, use the subcommands (github, gitlab, etc.).`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return o.run(ctx, cmd, args)
@@ -82,9 +122,13 @@ For provider-specific operations, use the subcommands (github, gitlab, etc.).`,
 	cmd.Flags().BoolVar(&o.resume, "resume", false, "Resume interrupted clone operation from saved state")
 	cmd.Flags().StringVar(&o.progressMode, "progress-mode", o.progressMode, "Progress display mode: bar, dots, spinner, quiet")
 	cmd.Flags().BoolVar(&o.cleanupOrphans, "cleanup-orphans", o.cleanupOrphans, "Remove directories not present in the organization's repositories")
+	cmd.Flags().BoolVar(&o.noHooks, "no-hooks", false, "Do not run lifecycle hooks from the configuration")
+	cmd.Flags().StringSliceVar(&o.sparseOwners, "sparse-owner", nil, "Only check out paths these CODEOWNERS owners own (e.g. @acme/payments)")
+	cmd.Flags().BoolVar(&o.noSparse, "no-sparse", false, "Check out repositories in full, ignoring sparse profiles")
 
 	// Mark flags as mutually exclusive
 	cmd.MarkFlagsMutuallyExclusive("config", "use-config", "use-gzh-config")
+	cmd.MarkFlagsMutuallyExclusive("sparse-owner", "no-sparse")
 
 	cmd.AddCommand(newSyncCloneConfigCmd(appCtx))
 	cmd.AddCommand(newSyncCloneForgeCmd(appCtx))
@@ -94,6 +138,10 @@ For provider-specific operations, use the subcommands (github, gitlab, etc.).`,
 	cmd.AddCommand(newSyncCloneValidateCmd(appCtx))
 	cmd.AddCommand(newSyncCloneStateCmd(appCtx))
 
+	// 모든 하위 명령어에서 --metrics-addr, --memory-auto-tune 사용 가능
+	metrics.AddFlag(cmd)
+	memory.AddFlag(cmd)
+
 	return cmd
 }
 
@@ -190,18 +238,29 @@ func (o *syncCloneOptions) runWithCentralConfigService(ctx context.Context) erro
 
 // executeProviderCloning executes the cloning operation for a specific provider.
 func (o *syncCloneOptions) executeProviderCloning(ctx context.Context, target pkgconfig.BulkCloneTarget, targetPath string) error {
+	hooks := o.hookRunner(target)
+	sparse := o.sparseCheckout(target)
+
 	switch target.Provider {
 	case pkgconfig.ProviderGitHub:
-		// Use resumable clone if requested or if parallel/worker pool is enabled
-		if o.resume || o.parallel > 1 {
-			return github.RefreshAllResumable(ctx, targetPath, target.Name, target.Strategy, o.parallel, o.maxRetries, o.resume, o.progressMode)
+		// Use resumable clone if requested, if parallel/worker pool is enabled
+		// or if hooks or sparse checkouts need to run per repository
+		if o.resume || o.parallel > 1 || hooks != nil || sparse != nil {
+			manager := github.NewResumableCloneManager(github.DefaultBulkOperationsConfig())
+			manager.SetHooks(hooks)
+			manager.SetSparse(sparse)
+			return manager.RefreshAllResumable(ctx, targetPath, target.Name, target.Strategy, o.parallel, o.maxRetries, o.resume, o.progressMode)
 		}
 
 		return github.RefreshAll(ctx, targetPath, target.Name, target.Strategy)
 	case pkgconfig.ProviderGitLab:
-		// Use resumable clone if requested or if parallel/worker pool is enabled
-		if o.resume || o.parallel > 1 {
-			return gitlab.RefreshAllResumable(ctx, targetPath, target.Name, target.Strategy, o.parallel, o.maxRetries, o.resume, o.progressMode)
+		// Use resumable clone if requested, if parallel/worker pool is enabled
+		// or if hooks or sparse checkouts need to run per repository
+		if o.resume || o.parallel > 1 || hooks != nil || sparse != nil {
+			manager := gitlab.NewResumableCloneManager(workerpool.DefaultRepositoryPoolConfig())
+			manager.SetHooks(hooks)
+			manager.SetSparse(sparse)
+			return manager.RefreshAllResumable(ctx, targetPath, target.Name, target.Strategy, o.parallel, o.maxRetries, o.resume, o.progressMode)
 		}
 
 		return gitlab.RefreshAll(ctx, targetPath, target.Name, target.Strategy)
@@ -213,6 +272,43 @@ func (o *syncCloneOptions) executeProviderCloning(ctx context.Context, target pk
 	}
 }
 
+// hookRunner returns the lifecycle hook runner of target, or nil when it has
+// no hooks or --no-hooks is set.
+func (o *syncCloneOptions) hookRunner(target pkgconfig.BulkCloneTarget) *synclonepkg.HookRunner {
+	if target.Hooks.Empty() {
+		return nil
+	}
+	if o.noHooks {
+		fmt.Printf("⏭️  Skipping lifecycle hooks of %s/%s (--no-hooks)\n", target.Provider, target.Name)
+		return nil
+	}
+
+	return synclonepkg.NewHookRunner(target.Hooks)
+}
+
+// sparseCheckout returns the sparse checkout of target, with the owners of
+// --sparse-owner replacing the configured ones, or nil when it has no
+// sparse profile or --no-sparse is set.
+func (o *syncCloneOptions) sparseCheckout(target pkgconfig.BulkCloneTarget) *synclonepkg.SparseCheckout {
+	if o.noSparse {
+		return nil
+	}
+	profile := target.Sparse
+	if len(o.sparseOwners) > 0 {
+		profile = profile.Merge(&synclonepkg.SparseProfile{Owners: o.sparseOwners})
+	}
+	if profile.Empty() {
+		return nil
+	}
+
+	owners := strings.Join(profile.Owners, ", ")
+	if owners == "" {
+		owners = "mapped paths"
+	}
+	fmt.Printf("🌿 Sparse checkout of %s/%s: %s\n", target.Provider, target.Name, owners)
+	return synclonepkg.NewSparseCheckout(profile)
+}
+
 // isConfigNotFoundError checks if the error indicates a configuration file was not found.
 func isConfigNotFoundError(err error) bool {
 	return errors.Is(err, gerrors.ErrConfigNotFound)
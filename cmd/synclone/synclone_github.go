@@ -20,6 +20,7 @@ import (
 	"github.com/gizzahub/gzh-cli/internal/env"
 	"github.com/gizzahub/gzh-cli/internal/errors"
 	"github.com/gizzahub/gzh-cli/internal/validation"
+	"github.com/gizzahub/gzh-cli/internal/workerpool"
 	"github.com/gizzahub/gzh-cli/pkg/config"
 	"github.com/gizzahub/gzh-cli/pkg/github"
 )
@@ -159,6 +160,7 @@ type syncCloneGithubOptions struct {
 	onlyEmpty       bool
 	sizeLimit       int64
 	cleanupOrphans  bool
+	maxDiskUsage    string
 }
 
 func defaultSyncCloneGithubOptions() *syncCloneGithubOptions {
@@ -222,6 +224,7 @@ func newSyncCloneGithubCmd(appCtx *app.AppContext) *cobra.Command {
 	cmd.Flags().BoolVar(&o.onlyEmpty, "only-empty", false, "Include only empty repositories")
 	cmd.Flags().Int64Var(&o.sizeLimit, "size-limit", 0, "Maximum repository size in KB (0 = no limit)")
 	cmd.Flags().BoolVar(&o.cleanupOrphans, "cleanup-orphans", false, "Remove directories not present in the organization's repositories")
+	cmd.Flags().StringVar(&o.maxDiskUsage, "max-disk-usage", "", "Queue or refuse clones that would exceed this disk usage (e.g., 90% of the filesystem, or 50GB under the target)")
 
 	// Aliases for simpler flags
 	cmd.Flags().StringVar(&o.targetPath, "target", o.targetPath, "Target directory; defaults to current directory + org name (e.g., ./ScriptonBasestar) if not set")
@@ -307,6 +310,11 @@ func (o *syncCloneGithubOptions) run(cmd *cobra.Command, args []string, appCtx *
 	o.orgName = sanitized.OrgName
 	o.strategy = sanitized.Strategy
 
+	diskBudget, err := workerpool.ParseDiskBudget(o.maxDiskUsage)
+	if err != nil {
+		return errors.NewRecoverableError(errors.ErrorTypeValidation, "Invalid --max-disk-usage", err, false)
+	}
+
 	// Get GitHub token
 	token := o.token
 	if token == "" {
@@ -322,8 +330,6 @@ func (o *syncCloneGithubOptions) run(cmd *cobra.Command, args []string, appCtx *
 	// Use optimized streaming approach for large-scale operations
 	ctx := cmd.Context()
 
-	var err error
-
 	// New synclone workflow: 1. Get repo list -> 2. Generate gzh.yaml -> 3. Cleanup orphans -> 4. Clone repos
 	log.Info("Starting synclone workflow: fetching repository list from GitHub")
 
@@ -424,10 +430,14 @@ func (o *syncCloneGithubOptions) run(cmd *cobra.Command, args []string, appCtx *
 			fmt.Printf("🧠 Memory limit: %s\n", o.memoryLimit)
 		}
 
-		err = github.RefreshAllOptimizedStreaming(ctx, o.targetPath, o.orgName, o.strategy, token)
-	} else if o.resume || o.parallel > 1 {
+		config := github.DefaultOptimizedCloneConfig()
+		config.WorkerPoolConfig.DiskBudget = diskBudget
+		err = github.RefreshAllOptimizedStreamingWithConfig(ctx, o.targetPath, o.orgName, o.strategy, token, config)
+	} else if o.resume || o.parallel > 1 || diskBudget != nil {
 		log.Info("Using resumable parallel cloning", "resume", o.resume, "progress_mode", o.progressMode)
-		err = github.RefreshAllResumable(ctx, o.targetPath, o.orgName, o.strategy, o.parallel, o.maxRetries, o.resume, o.progressMode)
+		config := github.DefaultBulkOperationsConfig()
+		config.PoolConfig.DiskBudget = diskBudget
+		err = github.NewResumableCloneManager(config).RefreshAllResumable(ctx, o.targetPath, o.orgName, o.strategy, o.parallel, o.maxRetries, o.resume, o.progressMode)
 	} else {
 		log.Info("Using standard cloning approach")
 		fmt.Printf("⚙️ Starting repository synchronization with strategy: %s\n", o.strategy)
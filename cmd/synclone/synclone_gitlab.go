@@ -4,6 +4,7 @@
 package synclone
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/gizzahub/gzh-cli/internal/app"
+	"github.com/gizzahub/gzh-cli/internal/workerpool"
 	gitlabpkg "github.com/gizzahub/gzh-cli/pkg/gitlab"
 	synclonepkg "github.com/gizzahub/gzh-cli/pkg/synclone"
 )
@@ -29,6 +31,18 @@ type syncCloneGitlabOptions struct {
 	progressMode string
 	heartbeatSec int
 	token        string
+
+	// 서브그룹 트리 클론 옵션 (--recursively 와 함께 사용)
+	pathTemplate     string
+	maxDepth         int
+	includes         []string
+	excludes         []string
+	excludeSubgroups []string
+	includeArchived  bool
+
+	maxDiskUsage string
+	autoscale    bool
+	maxParallel  int
 }
 
 func defaultSyncCloneGitlabOptions() *syncCloneGitlabOptions {
@@ -63,7 +77,14 @@ func newSyncCloneGitlabCmd(appCtx *app.AppContext) *cobra.Command {
 	cmd.Flags().MarkDeprecated("groupName", "use --group instead")
 	cmd.Flags().MarkHidden("targetPath")
 	cmd.Flags().MarkHidden("groupName")
-	cmd.Flags().BoolVarP(&o.recursively, "recursively", "r", o.recursively, "recursively")
+	cmd.Flags().BoolVarP(&o.recursively, "recursively", "r", o.recursively, "Clone subgroups recursively using --path-template for the directory layout")
+	cmd.Flags().StringVar(&o.pathTemplate, "path-template", o.pathTemplate,
+		"Local layout for recursive clones: nested, flat, flat-prefixed, or a template using {group}, {subgroup}, {subgroup_flat}, {namespace}, {project}")
+	cmd.Flags().IntVar(&o.maxDepth, "max-depth", 0, "Maximum subgroup depth for recursive clones (0 = unlimited)")
+	cmd.Flags().StringSliceVar(&o.includes, "include", nil, "Only clone projects matching [SUBGROUP=]PATTERN (repeatable)")
+	cmd.Flags().StringSliceVar(&o.excludes, "exclude", nil, "Skip projects matching [SUBGROUP=]PATTERN (repeatable)")
+	cmd.Flags().StringSliceVar(&o.excludeSubgroups, "exclude-subgroup", nil, "Skip subgroups matching PATTERN and everything below them (repeatable)")
+	cmd.Flags().BoolVar(&o.includeArchived, "include-archived", false, "Include archived projects in recursive clones")
 	cmd.Flags().StringVarP(&o.strategy, "strategy", "s", o.strategy, "Sync strategy: reset, pull, or fetch")
 	cmd.Flags().StringVarP(&o.configFile, "config", "c", o.configFile, "Path to config file")
 	cmd.Flags().BoolVar(&o.useConfig, "use-config", false, "Use config file from standard locations")
@@ -78,6 +99,10 @@ func newSyncCloneGitlabCmd(appCtx *app.AppContext) *cobra.Command {
 
 	// 무출력 방지를 위한 하트비트 간격(초). 0이면 비활성화
 	cmd.Flags().IntVar(&o.heartbeatSec, "heartbeat-interval", o.heartbeatSec, "Heartbeat interval in seconds (0 to disable)")
+	cmd.Flags().StringVar(&o.maxDiskUsage, "max-disk-usage", "", "Queue or refuse clones that would exceed this disk usage (e.g., 90% of the filesystem, or 50GB under the target)")
+	// 레이트 리밋 여유, 오류율, 메모리 압박에 따라 워커 수 자동 조절
+	cmd.Flags().BoolVar(&o.autoscale, "autoscale", false, "Adjust parallel workers from rate limit headroom, error rate and memory pressure")
+	cmd.Flags().IntVar(&o.maxParallel, "max-parallel", 0, "Upper bound for --autoscale (default: 4 × --parallel)")
 	// 토큰 플래그
 	cmd.Flags().StringVar(&o.token, "token", "", "GitLab token for API access (or set GITLAB_TOKEN)")
 
@@ -150,6 +175,11 @@ func (o *syncCloneGitlabOptions) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid strategy: %s. Must be one of: reset, pull, fetch", o.strategy)
 	}
 
+	diskBudget, err := workerpool.ParseDiskBudget(o.maxDiskUsage)
+	if err != nil {
+		return fmt.Errorf("invalid --max-disk-usage: %w", err)
+	}
+
 	// 최상위 타깃 디렉터리 보장 생성 (리포별 디렉터리는 작업 단계에서 생성)
 	if o.targetPath != "" {
 		if err := os.MkdirAll(o.targetPath, 0o755); err != nil {
@@ -177,9 +207,22 @@ func (o *syncCloneGitlabOptions) run(cmd *cobra.Command, args []string) error {
 	// Use resumable clone if requested or if parallel/worker pool is enabled
 	ctx := cmd.Context()
 
-	var err error
-	if o.resume || o.parallel > 1 {
-		err = gitlabpkg.RefreshAllResumable(ctx, o.targetPath, o.groupName, o.strategy, o.parallel, o.maxRetries, o.resume, o.progressMode)
+	if o.recursively {
+		err = o.cloneTree(ctx)
+	} else if o.resume || o.parallel > 1 || diskBudget != nil || o.autoscale {
+		config := workerpool.DefaultRepositoryPoolConfig()
+		config.DiskBudget = diskBudget
+		if o.autoscale {
+			maxParallel := o.maxParallel
+			if maxParallel <= 0 {
+				maxParallel = 4 * max(o.parallel, 1)
+			}
+			config.Autoscale = &workerpool.AutoscaleConfig{
+				MaxWorkers:        maxParallel,
+				RateLimitHeadroom: workerpool.ProviderRateLimit("gitlab"),
+			}
+		}
+		err = gitlabpkg.NewResumableCloneManager(config).RefreshAllResumable(ctx, o.targetPath, o.groupName, o.strategy, o.parallel, o.maxRetries, o.resume, o.progressMode)
 	} else {
 		err = gitlabpkg.RefreshAll(ctx, o.targetPath, o.groupName, o.strategy)
 	}
@@ -228,5 +271,70 @@ func (o *syncCloneGitlabOptions) loadFromConfig() error {
 		o.recursively = groupConfig.Recursive
 	}
 
+	if o.pathTemplate == "" && groupConfig.PathTemplate != "" {
+		o.pathTemplate = groupConfig.PathTemplate
+	}
+
 	return nil
 }
+
+// treeOptions builds the subgroup walk options from the command flags.
+func (o *syncCloneGitlabOptions) treeOptions() (gitlabpkg.TreeOptions, error) {
+	opts := gitlabpkg.TreeOptions{
+		PathTemplate:    resolvePathTemplate(o.pathTemplate),
+		MaxDepth:        o.maxDepth,
+		IncludeArchived: o.includeArchived,
+	}
+
+	for _, expr := range o.includes {
+		f, err := gitlabpkg.ParseSubgroupFilter(expr, false)
+		if err != nil {
+			return opts, err
+		}
+		opts.Filters = append(opts.Filters, f)
+	}
+	for _, expr := range o.excludes {
+		f, err := gitlabpkg.ParseSubgroupFilter(expr, true)
+		if err != nil {
+			return opts, err
+		}
+		opts.Filters = append(opts.Filters, f)
+	}
+	for _, pattern := range o.excludeSubgroups {
+		opts.Filters = append(opts.Filters, gitlabpkg.SubgroupFilter{Subgroup: pattern, Skip: true})
+	}
+
+	return opts, nil
+}
+
+// cloneTree clones the whole group tree with the configured path layout.
+func (o *syncCloneGitlabOptions) cloneTree(ctx context.Context) error {
+	opts, err := o.treeOptions()
+	if err != nil {
+		return err
+	}
+
+	stats, err := gitlabpkg.CloneGroupTree(ctx, o.targetPath, o.groupName, o.strategy, o.parallel, opts)
+	fmt.Printf("Walked %d group(s): %d project(s) synced, %d filtered", stats.Groups, stats.Projects, stats.Filtered)
+	if stats.Cycles > 0 {
+		fmt.Printf(", %d repeated group(s) skipped", stats.Cycles)
+	}
+	fmt.Println()
+
+	return err
+}
+
+// resolvePathTemplate maps layout names to their templates; anything else is
+// used as a literal template.
+func resolvePathTemplate(name string) string {
+	switch name {
+	case "", "nested":
+		return gitlabpkg.LayoutNested
+	case "flat":
+		return gitlabpkg.LayoutFlat
+	case "flat-prefixed":
+		return gitlabpkg.LayoutFlatPrefixed
+	default:
+		return name
+	}
+}
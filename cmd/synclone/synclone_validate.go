@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/gizzahub/gzh-cli/internal/app"
+	"github.com/gizzahub/gzh-cli/pkg/config"
 	synclonepkg "github.com/gizzahub/gzh-cli/pkg/synclone"
 )
 
@@ -70,13 +71,26 @@ func (o *syncCloneValidateOptions) run(cmd *cobra.Command, args []string) error
 		configPath = o.configFile
 	}
 
-	// First try to load the config using existing validation
+	// Report every schema issue with its location before loading, so that
+	// typos and invalid values are not reduced to the first loader error.
+	result, err := config.ValidateFileWithSchema(configPath, config.ConfigTypeBulkClone)
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		for _, issue := range result.Issues {
+			fmt.Printf("  %s:%s\n", configPath, issue)
+		}
+		return fmt.Errorf("schema validation failed: %d issue(s)", len(result.Issues))
+	}
+
+	// Then load the config using existing validation
 	cfg, err := synclonepkg.LoadConfig(configPath)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Then validate against schema
+	// Validate the loaded config against the schema
 	if err := synclonepkg.ValidateConfigWithSchema(configPath); err != nil {
 		return fmt.Errorf("schema validation failed: %w", err)
 	}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package undo
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type undoCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p undoCmdProvider) Command() *cobra.Command {
+	return NewUndoCmd(p.appCtx)
+}
+
+func (p undoCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "undo",
+		Category:     registry.CategoryGit,
+		Version:      "1.0.0",
+		Priority:     46,
+		Experimental: false,
+		Dependencies: []string{},
+		Tags:         []string{"undo", "journal", "safety", "repositories"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterUndoCmd registers the undo command with the command registry.
+func RegisterUndoCmd(appCtx *app.AppContext) {
+	registry.Register(undoCmdProvider{appCtx: appCtx})
+}
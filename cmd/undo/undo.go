@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package undo implements the `gz undo` command.
+package undo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+	"github.com/gizzahub/gzh-cli/internal/journal"
+)
+
+type options struct {
+	list   bool
+	dryRun bool
+	force  bool
+}
+
+// NewUndoCmd creates the undo command.
+func NewUndoCmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "undo [operation-id]",
+		Short: "Reverse a journaled repository operation",
+		Long: `Reverse a destructive operation recorded in the operation journal.
+
+These commands journal every change they make, with the state from before
+the change and the path of any backup taken:
+
+  gz git repo delete              repositories are recreated with their
+                                  settings and the --backup clone or bundle
+                                  is pushed back
+  gz git repo archive             repositories are unarchived (or archived
+                                  again after --unarchive)
+  gz git repo protect             branch protection is put back
+  gz repo-config webhook delete   webhooks are recreated (without secret)
+
+Changes are reversed newest first through the platform API, reading the
+same *_TOKEN variables as the original command. Changes already undone are
+skipped, so a partly failed undo can simply be run again. A branch whose
+protection has changed since is left alone unless --force is given.
+
+The journal is kept in ~/.gzh/journal (GZH_JOURNAL_DIR overrides it).`,
+		Example: `  # List journaled operations
+  gz undo --list
+
+  # Show what undoing an operation would do
+  gz undo 20251016-142501-a1b2c3 --dry-run
+
+  # Undo it; a unique prefix of the ID is enough
+  gz undo 20251016-142501`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			j, err := journal.Default()
+			if err != nil {
+				return err
+			}
+			if opts.list || len(args) == 0 {
+				return listOperations(cmd.OutOrStdout(), j)
+			}
+			return runUndo(cmd.Context(), cmd.OutOrStdout(), j, args[0], opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.list, "list", false, "List journaled operations")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show what would be undone without changing anything")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Undo changes even when the target has changed since")
+
+	return cmd
+}
+
+// listOperations prints the journaled operations, newest first.
+func listOperations(out io.Writer, j *journal.Journal) error {
+	ops, err := j.List()
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		fmt.Fprintf(out, "No journaled operations in %s\n", j.Dir())
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTARTED\tCOMMAND\tCHANGES\tSTATUS")
+	for _, op := range ops {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", op.ID, op.StartedAt.Local().Format("2006-01-02 15:04:05"),
+			op.Command, len(op.Records), status(op))
+	}
+	return tw.Flush()
+}
+
+func status(op *journal.Operation) string {
+	if op.UndoneAt != nil {
+		return "undone"
+	}
+	undone := 0
+	for _, rec := range op.Records {
+		if rec.UndoneAt != nil {
+			undone++
+		}
+	}
+	if undone > 0 {
+		return fmt.Sprintf("partly undone (%d/%d)", undone, len(op.Records))
+	}
+	return "active"
+}
+
+// runUndo reverses one operation and prints the outcome of every change.
+func runUndo(ctx context.Context, out io.Writer, j *journal.Journal, id string, opts *options) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	op, err := j.Load(id)
+	if err != nil {
+		return err
+	}
+	if op.UndoneAt != nil && !opts.force {
+		return fmt.Errorf("operation %s was already undone at %s", op.ID, op.UndoneAt.Local().Format("2006-01-02 15:04:05"))
+	}
+
+	verb := "Undoing"
+	if opts.dryRun {
+		verb = "Dry run - would undo"
+	}
+	fmt.Fprintf(out, "↩️  %s %s (%s, %d changes)\n\n", verb, op.ID, op.Command, len(op.Records))
+
+	results, undoErr := op.Undo(ctx, journal.UndoOptions{DryRun: opts.dryRun, Force: opts.force})
+	failed := 0
+	for _, r := range results {
+		icon := "✅"
+		detail := r.Note
+		switch {
+		case r.Err != nil:
+			icon = "❌"
+			detail = r.Err.Error()
+			failed++
+		case r.Skipped:
+			icon = "⏭️ "
+		}
+		fmt.Fprintf(out, "  %s %s %s", icon, r.Record.Kind, r.Record.Target)
+		if detail != "" {
+			fmt.Fprintf(out, ": %s", detail)
+		}
+		fmt.Fprintln(out)
+	}
+
+	if undoErr != nil {
+		fmt.Fprintf(out, "\n%d of %d changes could not be undone; fix the cause and run 'gz undo %s' again\n", failed, len(results), op.ID)
+		return fmt.Errorf("undo of %s incomplete", op.ID)
+	}
+	if !opts.dryRun {
+		fmt.Fprintf(out, "\n✅ Operation %s undone\n", op.ID)
+	}
+	return nil
+}
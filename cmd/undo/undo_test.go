@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package undo
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/journal"
+)
+
+func TestUndoCmd(t *testing.T) {
+	t.Setenv("GZH_JOURNAL_DIR", t.TempDir())
+	journal.RegisterUndoer("test.kind", func(_ context.Context, rec journal.Record, opts journal.UndoOptions) (string, error) {
+		if opts.DryRun {
+			return "would restore " + rec.Target, nil
+		}
+		return "restored", nil
+	})
+
+	j, err := journal.Default()
+	require.NoError(t, err)
+	op := j.Begin("gz git repo archive")
+	require.NoError(t, op.Add("test.kind", "github", "org/api", nil, ""))
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := NewUndoCmd(nil)
+		cmd.SetOut(&out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("--list")
+	require.NoError(t, err)
+	assert.Contains(t, out, op.ID)
+	assert.Contains(t, out, "active")
+
+	out, err = run(op.ID, "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, out, "would restore org/api")
+
+	out, err = run(op.ID[:15])
+	require.NoError(t, err)
+	assert.Contains(t, out, "Operation "+op.ID+" undone")
+
+	_, err = run(op.ID)
+	assert.ErrorContains(t, err, "already undone")
+
+	out, err = run()
+	require.NoError(t, err)
+	assert.Contains(t, out, "undone")
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workspace
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/cmd/registry"
+	"github.com/gizzahub/gzh-cli/internal/app"
+)
+
+type workspaceCmdProvider struct {
+	appCtx *app.AppContext
+}
+
+func (p workspaceCmdProvider) Command() *cobra.Command {
+	return NewWorkspaceCmd(p.appCtx)
+}
+
+func (p workspaceCmdProvider) Metadata() registry.CommandMetadata {
+	return registry.CommandMetadata{
+		Name:         "workspace",
+		Category:     registry.CategoryGit,
+		Version:      "1.0.0",
+		Priority:     45,
+		Experimental: false,
+		Dependencies: []string{},
+		Tags:         []string{"workspace", "repositories", "git", "parallel"},
+		Lifecycle:    registry.LifecycleBeta,
+	}
+}
+
+// RegisterWorkspaceCmd registers the workspace command with the command registry.
+func RegisterWorkspaceCmd(appCtx *app.AppContext) {
+	registry.Register(workspaceCmdProvider{appCtx: appCtx})
+}
@@ -0,0 +1,447 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package workspace implements the `gz workspace` command.
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/app"
+	"github.com/gizzahub/gzh-cli/internal/git/objcache"
+	"github.com/gizzahub/gzh-cli/internal/workspace"
+	pkgconfig "github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+// options holds flags shared by the workspace subcommands.
+type options struct {
+	configPath string
+}
+
+// load reads the workspaces of the config file with the active profile.
+func (o *options) load() (*pkgconfig.UnifiedConfig, error) {
+	path := o.configPath
+	if path == "" {
+		found, err := pkgconfig.FindConfigFile()
+		if err != nil {
+			return nil, fmt.Errorf("no --config given and no gzh.yaml found: %w", err)
+		}
+		path = found
+	}
+	return pkgconfig.ReadWorkspaces(path, pkgconfig.ActiveProfileName("", nil))
+}
+
+// selectOptions select the workspace and its repositories.
+type selectOptions struct {
+	include     []string
+	exclude     []string
+	tags        []string
+	concurrency int
+}
+
+func (s *selectOptions) register(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&s.include, "include", nil, "Only repositories matching these glob patterns")
+	cmd.Flags().StringSliceVar(&s.exclude, "exclude", nil, "Skip repositories matching these glob patterns")
+	cmd.Flags().StringSliceVar(&s.tags, "tag", nil, "Only repositories with one of these tags")
+	cmd.Flags().IntVarP(&s.concurrency, "concurrency", "j", 0, "Repositories processed in parallel (default: workspace setting)")
+}
+
+// resolve resolves the workspace named in args, or the current one when
+// args is empty.
+func (s *selectOptions) resolve(cfg *pkgconfig.UnifiedConfig, args []string) (*pkgconfig.ResolvedWorkspace, error) {
+	name, err := workspaceName(cfg, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var filters *pkgconfig.WorkspaceFilters
+	if len(s.include)+len(s.exclude)+len(s.tags) > 0 {
+		filters = &pkgconfig.WorkspaceFilters{Include: s.include, Exclude: s.exclude, Tags: s.tags}
+	}
+	ws, err := cfg.ResolveWorkspace(name, filters)
+	if err != nil {
+		return nil, err
+	}
+	if s.concurrency > 0 {
+		ws.Concurrency = s.concurrency
+	}
+	if len(ws.Targets) == 0 {
+		return nil, fmt.Errorf("no repositories of workspace %s match the filters", name)
+	}
+	return ws, nil
+}
+
+// workspaceName returns the name given in args. Without one, it picks the
+// workspace whose root contains the working directory, or the only
+// configured workspace.
+func workspaceName(cfg *pkgconfig.UnifiedConfig, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	names := cfg.WorkspaceNames()
+	if len(names) == 0 {
+		return "", fmt.Errorf("no workspaces configured")
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		for _, name := range names {
+			root, err := cfg.WorkspaceRoot(name)
+			if err != nil {
+				continue
+			}
+			if rel, err := filepath.Rel(root, wd); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return name, nil
+			}
+		}
+	}
+	if len(names) == 1 {
+		return names[0], nil
+	}
+	return "", fmt.Errorf("no workspace given and the working directory is outside every workspace (available: %s)", strings.Join(names, ", "))
+}
+
+// NewWorkspaceCmd creates the workspace command.
+func NewWorkspaceCmd(appCtx *app.AppContext) *cobra.Command {
+	_ = appCtx
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Operate on named groups of repositories",
+		Long: `Clone, sync, inspect and run commands across a named group of
+repositories defined in the workspaces section of gzh.yaml. Repositories
+may come from different providers and are processed in parallel.
+
+Commands that take an optional workspace name fall back to the workspace
+containing the working directory, or to the only configured workspace.`,
+		Example: `  # workspaces:
+  #   teamA:
+  #     description: Team A services
+  #     root: ~/work/team-a
+  #     strategy: pull
+  #     repos:
+  #       - {provider: github, name: acme/api, tags: [backend]}
+  #       - {provider: gitlab, name: acme/web, tags: [frontend]}
+  #       - {url: https://git.example.com/tools/ci.git, path: tools/ci}
+  #     filters:
+  #       exclude: ["*-archive"]
+
+  gz workspace list
+  gz workspace sync teamA
+  gz workspace status teamA --tag backend
+  gz workspace exec teamA -- git log -1 --oneline`,
+		SilenceUsage: true,
+	}
+
+	cmd.PersistentFlags().StringVar(&opts.configPath, "config", "", "Config file (default: the gzh.yaml found in the standard locations)")
+
+	cmd.AddCommand(newListCmd(opts))
+	cmd.AddCommand(newSyncCmd(opts))
+	cmd.AddCommand(newStatusCmd(opts))
+	cmd.AddCommand(newExecCmd(opts))
+
+	return cmd
+}
+
+type workspaceSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Root        string `json:"root"`
+	Strategy    string `json:"strategy"`
+	Repos       int    `json:"repos"`
+}
+
+func newListCmd(opts *options) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List configured workspaces",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := opts.load()
+			if err != nil {
+				return err
+			}
+
+			summaries := make([]workspaceSummary, 0, len(cfg.Workspaces))
+			for _, name := range cfg.WorkspaceNames() {
+				ws, err := cfg.ResolveWorkspace(name, nil)
+				if err != nil {
+					return err
+				}
+				summaries = append(summaries, workspaceSummary{
+					Name:        name,
+					Description: cfg.Workspaces[name].Description,
+					Root:        ws.Root,
+					Strategy:    ws.Strategy,
+					Repos:       len(ws.Targets),
+				})
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonOutput {
+				return encodeJSON(out, summaries)
+			}
+			if len(summaries) == 0 {
+				fmt.Fprintln(out, "No workspaces configured")
+				return nil
+			}
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tREPOS\tSTRATEGY\tROOT\tDESCRIPTION")
+			for _, s := range summaries {
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", s.Name, s.Repos, s.Strategy, s.Root, s.Description)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newSyncCmd(opts *options) *cobra.Command {
+	var (
+		sel         selectOptions
+		strategy    string
+		timeout     time.Duration
+		jsonOutput  bool
+		objectCache bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync [workspace]",
+		Short: "Clone missing repositories and update the others",
+		Long: `Clone the repositories of a workspace that are missing and update the
+others with the workspace strategy:
+
+  pull   git pull --ff-only (default)
+  fetch  git fetch --prune origin
+  reset  fetch, then git reset --hard to the upstream branch
+
+With --object-cache, new clones borrow objects from a shared store per
+repository and its forks, so workspaces cloning the same repositories keep
+one copy of their history. 'gz git cache gc' maintains the stores.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := opts.load()
+			if err != nil {
+				return err
+			}
+			ws, err := sel.resolve(cfg, args)
+			if err != nil {
+				return err
+			}
+			if strategy != "" {
+				switch strategy {
+				case pkgconfig.WorkspaceStrategyPull, pkgconfig.WorkspaceStrategyFetch, pkgconfig.WorkspaceStrategyReset:
+					ws.Strategy = strategy
+				default:
+					return fmt.Errorf("invalid strategy: %s (valid: pull, fetch, reset)", strategy)
+				}
+			}
+
+			runner := workspace.NewRunner(ws)
+			runner.Timeout = timeout
+			if objectCache {
+				runner.ObjectCache = objcache.New("")
+			}
+			results, err := runner.Sync(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return reportResults(cmd.OutOrStdout(), ws, results, jsonOutput, false)
+		},
+	}
+
+	sel.register(cmd)
+	cmd.Flags().StringVar(&strategy, "strategy", "", "Override the workspace strategy (pull, fetch, reset)")
+	cmd.Flags().DurationVar(&timeout, "timeout", workspace.DefaultTimeout, "Time limit per repository")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&objectCache, "object-cache", false, "Clone new repositories with the shared object cache")
+
+	return cmd
+}
+
+func newStatusCmd(opts *options) *cobra.Command {
+	var (
+		sel        selectOptions
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "status [workspace]",
+		Short: "Show branch, local changes and upstream drift of every repository",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := opts.load()
+			if err != nil {
+				return err
+			}
+			ws, err := sel.resolve(cfg, args)
+			if err != nil {
+				return err
+			}
+
+			statuses, err := workspace.NewRunner(ws).Status(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonOutput {
+				return encodeJSON(out, statuses)
+			}
+			printStatuses(out, ws, statuses)
+			return nil
+		},
+	}
+
+	sel.register(cmd)
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func printStatuses(out io.Writer, ws *pkgconfig.ResolvedWorkspace, statuses []workspace.Status) {
+	fmt.Fprintf(out, "📂 %s (%s)\n\n", ws.Name, ws.Root)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPO\tBRANCH\tCHANGES\tAHEAD\tBEHIND\tSTATE")
+	clean := 0
+	for _, s := range statuses {
+		switch {
+		case !s.Cloned:
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\tnot cloned\n", s.Repo)
+		case s.Error != "":
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\t❌ %s\n", s.Repo, s.Error)
+		default:
+			state := "✅ clean"
+			switch {
+			case s.Changed > 0:
+				state = "✏️  modified"
+			case s.Upstream == "":
+				state = "⚠️  no upstream"
+			case s.Ahead > 0 || s.Behind > 0:
+				state = "🔀 diverged"
+			}
+			if s.Clean() {
+				clean++
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\n", s.Repo, s.Branch, s.Changed, s.Ahead, s.Behind, state)
+		}
+	}
+	_ = w.Flush()
+	fmt.Fprintf(out, "\n%d of %d repositories clean\n", clean, len(statuses))
+}
+
+func newExecCmd(opts *options) *cobra.Command {
+	var (
+		sel        selectOptions
+		timeout    time.Duration
+		jsonOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "exec [workspace] -- <command> [args...]",
+		Short: "Run a command in every cloned repository",
+		Long: `Run a command in every cloned repository of a workspace in parallel.
+The output of each repository is printed as one block once it finished.
+The command is executed directly, not through a shell.`,
+		Example: `  gz workspace exec teamA -- git pull
+  gz workspace exec teamA --tag backend -- make test
+  gz workspace exec -- git status --short`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dash := cmd.ArgsLenAtDash()
+			if dash < 0 || dash == len(args) {
+				return fmt.Errorf("no command given; put it after --, e.g. gz workspace exec -- git pull")
+			}
+			if dash > 1 {
+				return fmt.Errorf("expected at most one workspace before --, got %d", dash)
+			}
+
+			cfg, err := opts.load()
+			if err != nil {
+				return err
+			}
+			ws, err := sel.resolve(cfg, args[:dash])
+			if err != nil {
+				return err
+			}
+
+			runner := workspace.NewRunner(ws)
+			runner.Timeout = timeout
+			results, err := runner.Exec(cmd.Context(), args[dash:])
+			if err != nil {
+				return err
+			}
+			return reportResults(cmd.OutOrStdout(), ws, results, jsonOutput, true)
+		},
+	}
+
+	sel.register(cmd)
+	cmd.Flags().DurationVar(&timeout, "timeout", workspace.DefaultTimeout, "Time limit per repository")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+// reportResults prints results and fails when an operation failed. With
+// showOutput, the output of every repository is printed, not only that of
+// failed ones.
+func reportResults(out io.Writer, ws *pkgconfig.ResolvedWorkspace, results []workspace.Result, jsonOutput, showOutput bool) error {
+	failed := 0
+	for _, r := range results {
+		if r.Failed() {
+			failed++
+		}
+	}
+
+	if jsonOutput {
+		if err := encodeJSON(out, results); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(out, "📂 %s (%s)\n", ws.Name, ws.Root)
+		for _, r := range results {
+			icon := "✅"
+			switch {
+			case r.Failed():
+				icon = "❌"
+			case r.Action == workspace.ActionSkipped:
+				icon = "⏭️ "
+			}
+			fmt.Fprintf(out, "%s %s: %s (%s)\n", icon, r.Repo, r.Action, r.Duration.Round(time.Millisecond))
+			if r.Failed() {
+				fmt.Fprintf(out, "   %s\n", r.Error)
+			}
+			if r.Output != "" && (showOutput || r.Failed()) {
+				for _, line := range strings.Split(r.Output, "\n") {
+					fmt.Fprintf(out, "   │ %s\n", line)
+				}
+			}
+		}
+		fmt.Fprintf(out, "\n%d repositories, %d failed\n", len(results), failed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d repositories failed", failed, len(results))
+	}
+	return nil
+}
+
+func encodeJSON(out io.Writer, v any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workspace
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceCmd(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	origin := filepath.Join(dir, "origin")
+	require.NoError(t, os.MkdirAll(origin, 0o755))
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = origin
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	config := filepath.Join(dir, "gzh.yaml")
+	require.NoError(t, os.WriteFile(config, []byte(`version: "1.0.0"
+workspaces:
+  teamA:
+    root: `+filepath.Join(dir, "ws")+`
+    repos:
+      - {url: `+origin+`, name: acme/api, tags: [backend]}
+      - {url: `+origin+`, name: acme/web, tags: [frontend]}
+`), 0o600))
+
+	run := func(args ...string) (string, error) {
+		var out bytes.Buffer
+		cmd := NewWorkspaceCmd(nil)
+		cmd.SetOut(&out)
+		cmd.SetArgs(append([]string{"--config", config}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("list")
+	require.NoError(t, err)
+	assert.Contains(t, out, "teamA")
+
+	out, err = run("sync", "--tag", "backend")
+	require.NoError(t, err, "the only workspace is used without a name")
+	assert.Contains(t, out, "acme/api: cloned")
+	assert.NotContains(t, out, "acme/web")
+
+	out, err = run("status", "teamA")
+	require.NoError(t, err)
+	assert.Contains(t, out, "not cloned")
+	assert.Contains(t, out, "1 of 2 repositories clean")
+
+	out, err = run("exec", "teamA", "--", "git", "log", "--format=%s")
+	require.NoError(t, err)
+	assert.Contains(t, out, "│ init")
+	assert.Contains(t, out, "acme/web: skipped")
+
+	_, err = run("exec", "teamA")
+	assert.ErrorContains(t, err, "no command given")
+
+	_, err = run("sync", "teamA", "--include", "nothing")
+	assert.EqualError(t, err, "no repositories of workspace teamA match the filters")
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package actionsfix
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+	"github.com/gizzahub/gzh-cli/pkg/github"
+)
+
+const testWorkflow = `name: CI
+on: [push]
+
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - name: Notify
+        uses: evil/notify@main
+        with:
+          channel: ci
+
+      - uses: docker/build-push-action@0123456789abcdef0123456789abcdef01234567
+      - uses: ./local-action
+      - run: make test
+`
+
+type fakeResolver map[string]string
+
+func (f fakeResolver) ResolveRef(_ context.Context, repo, ref string) (string, error) {
+	return f[repo+"@"+ref], nil
+}
+
+func testPolicy() *github.ActionsPolicy {
+	p := github.GetDefaultActionsPolicy()
+	p.PermissionLevel = github.ActionsPermissionSelectedActions
+	p.SecuritySettings.AllowGitHubOwnedActions = true
+	p.AllowedActions = []string{"docker/build-push-action"}
+	p.WorkflowPermissions = github.WorkflowPermissions{
+		ContentsPermission:     github.TokenPermissionRead,
+		PullRequestsPermission: github.TokenPermissionWrite,
+	}
+	return p
+}
+
+func TestFixWorkflow(t *testing.T) {
+	sha := strings.Repeat("a", 40)
+	rules := RulesFromPolicy(testPolicy(), true, true, true)
+
+	out, changes, err := FixWorkflow(context.Background(), "ci.yml", []byte(testWorkflow), rules,
+		fakeResolver{"actions/checkout@v4": sha})
+	require.NoError(t, err)
+
+	assert.Equal(t, `name: CI
+on: [push]
+
+permissions:
+  contents: read
+  pull-requests: write
+
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@`+sha+` # v4
+
+      - uses: docker/build-push-action@0123456789abcdef0123456789abcdef01234567
+      - uses: ./local-action
+      - run: make test
+`, string(out))
+
+	require.Len(t, changes, 3)
+	assert.Equal(t, Change{File: "ci.yml", Line: 4, Kind: ChangePermissions,
+		Description: "added top-level permissions (contents: read, pull-requests: write)"}, changes[0])
+	assert.Equal(t, Change{File: "ci.yml", Line: 8, Kind: ChangePin,
+		Description: "pinned actions/checkout@v4 to aaaaaaaaaaaa"}, changes[1])
+	assert.Equal(t, Change{File: "ci.yml", Line: 9, Kind: ChangeRemove,
+		Description: "removed step using disallowed action evil/notify@main"}, changes[2])
+
+	// Fixed output is stable.
+	again, changes, err := FixWorkflow(context.Background(), "ci.yml", out, rules, fakeResolver{})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, string(out), string(again))
+}
+
+func TestActionAllowed(t *testing.T) {
+	p := testPolicy()
+	p.AllowedActionsPatterns = []string{"myorg/*"}
+	p.SecuritySettings.RestrictedActionsPatterns = []string{"myorg/legacy*"}
+
+	assert.True(t, actionAllowed(p, "actions/setup-go@v5"))
+	assert.True(t, actionAllowed(p, "myorg/deploy@v1"))
+	assert.False(t, actionAllowed(p, "myorg/legacy-deploy@v1"))
+	assert.False(t, actionAllowed(p, "other/action@v1"))
+
+	p.PermissionLevel = github.ActionsPermissionAll
+	assert.True(t, actionAllowed(p, "other/action@v1"))
+	assert.False(t, actionAllowed(p, "myorg/legacy-deploy@v1"))
+}
+
+// fakeGitHub serves the endpoints the remediator uses.
+type fakeGitHub struct {
+	mu      sync.Mutex
+	files   map[string]string // repo -> ci.yml content
+	updated map[string]string // repo -> committed content
+	pulls   map[string]bool
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/repos/"), "/")
+	repo, rest := parts[0]+"/"+parts[1], strings.Join(parts[2:], "/")
+	content, ok := f.files[repo]
+	writeJSON := func(v any) { _ = json.NewEncoder(w).Encode(v) }
+
+	switch {
+	case r.Method == http.MethodGet && rest == "contents/.github/workflows":
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON([]map[string]string{{"type": "file", "path": ".github/workflows/ci.yml"}})
+	case r.Method == http.MethodGet && rest == "contents/.github/workflows/ci.yml":
+		writeJSON(map[string]string{"sha": "blob", "content": base64.StdEncoding.EncodeToString([]byte(content))})
+	case r.Method == http.MethodGet && strings.HasPrefix(rest, "commits/"):
+		_, _ = w.Write([]byte(strings.Repeat("b", 40)))
+	case r.Method == http.MethodGet && rest == "git/ref/heads/main":
+		writeJSON(map[string]any{"object": map[string]string{"sha": "base"}})
+	case r.Method == http.MethodPatch && rest == "git/refs/heads/"+DefaultBranch:
+		http.NotFound(w, r)
+	case r.Method == http.MethodPost && rest == "git/refs":
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && rest == "contents/.github/workflows/ci.yml":
+		var body struct {
+			Content string `json:"content"`
+			Branch  string `json:"branch"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		data, _ := base64.StdEncoding.DecodeString(body.Content)
+		f.updated[repo] = string(data)
+	case r.Method == http.MethodPost && rest == "pulls":
+		if f.pulls[repo] {
+			http.Error(w, `{"message":"A pull request already exists"}`, http.StatusUnprocessableEntity)
+			return
+		}
+		f.pulls[repo] = true
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(map[string]string{"html_url": "https://github.com/" + repo + "/pull/1"})
+	default:
+		http.Error(w, r.Method+" "+r.URL.Path, http.StatusNotImplemented)
+	}
+}
+
+func TestRemediator(t *testing.T) {
+	fake := &fakeGitHub{
+		files: map[string]string{
+			"org/app":   testWorkflow,
+			"org/clean": "permissions: {}\njobs:\n  a:\n    steps:\n      - run: true\n",
+		},
+		updated: map[string]string{},
+		pulls:   map[string]bool{"org/lib": true},
+	}
+	fake.files["org/lib"] = testWorkflow
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	opener, err := depupdate.NewPullRequestOpener("github", server.URL, "token")
+	require.NoError(t, err)
+	var progress []string
+	var mu sync.Mutex
+	remediator := &Remediator{
+		API:    NewClient(server.URL, "token", 0),
+		Opener: opener,
+		Rules:  RulesFromPolicy(testPolicy(), true, true, true),
+		Progress: func(res RepoResult) {
+			mu.Lock()
+			progress = append(progress, res.Repository)
+			mu.Unlock()
+		},
+	}
+	repos := []Repository{
+		{FullName: "org/app", DefaultBranch: "main"},
+		{FullName: "org/clean", DefaultBranch: "main"},
+		{FullName: "org/lib", DefaultBranch: "main"},
+		{FullName: "org/empty", DefaultBranch: "main"},
+	}
+
+	report, err := remediator.Run(context.Background(), repos, Options{BatchSize: 2, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Planned)
+	assert.Equal(t, 2, report.Clean)
+	assert.Empty(t, fake.updated)
+
+	report, err = remediator.Run(context.Background(), repos, Options{BatchSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Opened)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 2, report.Clean)
+	assert.Zero(t, report.Failed)
+	assert.Equal(t, "https://github.com/org/app/pull/1", report.Repositories[0].PullRequest)
+	assert.Len(t, report.Repositories[0].Changes, 3)
+	assert.Contains(t, fake.updated["org/app"], "actions/checkout@"+strings.Repeat("b", 40)+" # v4")
+	assert.NotContains(t, fake.updated["org/app"], "evil/notify")
+	assert.Len(t, progress, 8)
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package actionsfix
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+)
+
+// workflowsDir is where GitHub looks for workflow files.
+const workflowsDir = ".github/workflows"
+
+// errNotFound is returned for 404 responses.
+var errNotFound = errors.New("not found")
+
+// Repository is a repository to remediate.
+type Repository struct {
+	FullName      string `json:"full_name"`      //nolint:tagliatelle // GitHub API
+	DefaultBranch string `json:"default_branch"` //nolint:tagliatelle // GitHub API
+	Archived      bool   `json:"archived"`
+}
+
+// WorkflowFile is a workflow file on a branch.
+type WorkflowFile struct {
+	Path    string
+	SHA     string
+	Content []byte
+}
+
+// Client talks to the GitHub REST API. Requests are spaced to stay under
+// a requests-per-second budget, shared by every goroutine using the client.
+type Client struct {
+	api    string
+	token  string
+	http   *http.Client
+	every  time.Duration
+	shaMu  sync.Mutex
+	shas   map[string]string
+	tickMu sync.Mutex
+	next   time.Time
+}
+
+// NewClient creates a client. baseURL selects GitHub Enterprise and may be
+// empty; rate <= 0 disables throttling.
+func NewClient(baseURL, token string, rate float64) *Client {
+	c := &Client{
+		api:   depupdate.DefaultGitHubAPI,
+		token: token,
+		http:  &http.Client{Timeout: 60 * time.Second},
+		shas:  make(map[string]string),
+	}
+	if baseURL != "" {
+		c.api = strings.TrimSuffix(baseURL, "/")
+	}
+	if rate > 0 {
+		c.every = time.Duration(float64(time.Second) / rate)
+	}
+	return c
+}
+
+// throttle waits for the next request slot.
+func (c *Client) throttle(ctx context.Context) error {
+	if c.every == 0 {
+		return nil
+	}
+	c.tickMu.Lock()
+	now := time.Now()
+	slot := c.next
+	if slot.Before(now) {
+		slot = now
+	}
+	c.next = slot.Add(c.every)
+	c.tickMu.Unlock()
+
+	timer := time.NewTimer(time.Until(slot))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) do(ctx context.Context, method, path, accept string, body, out any) error {
+	if err := c.throttle(ctx); err != nil {
+		return err
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.api+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if accept == "" {
+		accept = "application/vnd.github+json"
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", "gzh-cli")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%s %s: %w", method, req.URL.Path, errNotFound)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("%s %s: HTTP %d - %s", method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	switch out := out.(type) {
+	case nil:
+		return nil
+	case *string:
+		*out = strings.TrimSpace(string(data))
+		return nil
+	default:
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to decode %s response: %w", req.URL.Path, err)
+		}
+		return nil
+	}
+}
+
+// ListRepositories returns the unarchived repositories of org.
+func (c *Client) ListRepositories(ctx context.Context, org string) ([]Repository, error) {
+	var all []Repository
+	for page := 1; ; page++ {
+		var repos []Repository
+		path := fmt.Sprintf("/orgs/%s/repos?per_page=100&page=%d", url.PathEscape(org), page)
+		if err := c.do(ctx, http.MethodGet, path, "", nil, &repos); err != nil {
+			return nil, err
+		}
+		for _, r := range repos {
+			if !r.Archived {
+				all = append(all, r)
+			}
+		}
+		if len(repos) < 100 {
+			return all, nil
+		}
+	}
+}
+
+// Repository returns one repository.
+func (c *Client) Repository(ctx context.Context, fullName string) (Repository, error) {
+	var repo Repository
+	err := c.do(ctx, http.MethodGet, "/repos/"+fullName, "", nil, &repo)
+	return repo, err
+}
+
+// Workflows returns the workflow files on branch. A repository without a
+// workflows directory has none.
+func (c *Client) Workflows(ctx context.Context, repo, branch string) ([]WorkflowFile, error) {
+	var entries []struct {
+		Type string `json:"type"`
+		Path string `json:"path"`
+	}
+	query := "?ref=" + url.QueryEscape(branch)
+	err := c.do(ctx, http.MethodGet, "/repos/"+repo+"/contents/"+workflowsDir+query, "", nil, &entries)
+	if errors.Is(err, errNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var files []WorkflowFile
+	for _, e := range entries {
+		if e.Type != "file" || !(strings.HasSuffix(e.Path, ".yml") || strings.HasSuffix(e.Path, ".yaml")) {
+			continue
+		}
+		var file struct {
+			SHA      string `json:"sha"`
+			Content  string `json:"content"`
+			Encoding string `json:"encoding"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/repos/"+repo+"/contents/"+e.Path+query, "", nil, &file); err != nil {
+			return nil, err
+		}
+		content, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", e.Path, err)
+		}
+		files = append(files, WorkflowFile{Path: e.Path, SHA: file.SHA, Content: content})
+	}
+	return files, nil
+}
+
+// ResolveRef returns the commit SHA of ref in repo. Results are cached, as
+// the same actions appear in most workflows of an organization.
+func (c *Client) ResolveRef(ctx context.Context, repo, ref string) (string, error) {
+	key := repo + "@" + ref
+	c.shaMu.Lock()
+	sha, ok := c.shas[key]
+	c.shaMu.Unlock()
+	if ok {
+		return sha, nil
+	}
+
+	path := "/repos/" + repo + "/commits/" + url.PathEscape(ref)
+	if err := c.do(ctx, http.MethodGet, path, "application/vnd.github.sha", nil, &sha); err != nil {
+		return "", err
+	}
+	if !shaRe.MatchString(sha) {
+		return "", fmt.Errorf("unexpected commit SHA %q for %s", sha, key)
+	}
+	c.shaMu.Lock()
+	c.shas[key] = sha
+	c.shaMu.Unlock()
+	return sha, nil
+}
+
+// ResetBranch points branch at the head of base, creating it when needed,
+// so a re-run replaces the previous remediation commits.
+func (c *Client) ResetBranch(ctx context.Context, repo, branch, base string) error {
+	var ref struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/repos/"+repo+"/git/ref/heads/"+base, "", nil, &ref); err != nil {
+		return err
+	}
+
+	err := c.do(ctx, http.MethodPatch, "/repos/"+repo+"/git/refs/heads/"+branch, "",
+		map[string]any{"sha": ref.Object.SHA, "force": true}, nil)
+	if err == nil {
+		return nil
+	}
+	return c.do(ctx, http.MethodPost, "/repos/"+repo+"/git/refs", "",
+		map[string]any{"ref": "refs/heads/" + branch, "sha": ref.Object.SHA}, nil)
+}
+
+// UpdateFile commits content to path on branch. sha is the blob being
+// replaced.
+func (c *Client) UpdateFile(ctx context.Context, repo, branch, path, message string, content []byte, sha string) error {
+	return c.do(ctx, http.MethodPut, "/repos/"+repo+"/contents/"+path, "", map[string]any{
+		"message": message,
+		"content": base64.StdEncoding.EncodeToString(content),
+		"sha":     sha,
+		"branch":  branch,
+	}, nil)
+}
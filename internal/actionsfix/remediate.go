@@ -0,0 +1,220 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package actionsfix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+)
+
+// DefaultBranch is the branch remediation commits are pushed to.
+const DefaultBranch = "gz/actions-policy"
+
+// PullRequestTitle is the title of remediation pull requests.
+const PullRequestTitle = "ci: remediate GitHub Actions policy violations"
+
+// Repository statuses.
+const (
+	StatusOpened  = "opened"
+	StatusUpdated = "updated"
+	StatusClean   = "clean"
+	StatusPlanned = "planned"
+	StatusFailed  = "failed"
+)
+
+// API is the part of the GitHub API remediation needs; Client implements it.
+type API interface {
+	Resolver
+	Workflows(ctx context.Context, repo, branch string) ([]WorkflowFile, error)
+	ResetBranch(ctx context.Context, repo, branch, base string) error
+	UpdateFile(ctx context.Context, repo, branch, path, message string, content []byte, sha string) error
+}
+
+// Options tune a remediation run.
+type Options struct {
+	// Branch receives the fix commits. It is reset to the default branch
+	// on every run.
+	Branch string
+	// BatchSize repositories are remediated concurrently; the next batch
+	// starts BatchDelay after the previous one finished.
+	BatchSize  int
+	BatchDelay time.Duration
+	// DryRun computes the changes without pushing or opening anything.
+	DryRun bool
+}
+
+// RepoResult is the outcome for one repository.
+type RepoResult struct {
+	Repository  string   `json:"repository"`
+	Status      string   `json:"status"`
+	Changes     []Change `json:"changes,omitempty"`
+	PullRequest string   `json:"pull_request,omitempty"` //nolint:tagliatelle // matches GitHub naming
+	Error       string   `json:"error,omitempty"`
+}
+
+// Report summarizes a remediation run.
+type Report struct {
+	Repositories []RepoResult `json:"repositories"`
+	Opened       int          `json:"opened"`
+	Updated      int          `json:"updated"`
+	Clean        int          `json:"clean"`
+	Planned      int          `json:"planned"`
+	Failed       int          `json:"failed"`
+}
+
+// Remediator fixes workflows across repositories and proposes the fixes as
+// pull requests.
+type Remediator struct {
+	API    API
+	Opener depupdate.PullRequestOpener
+	Rules  Rules
+	// Progress, when set, is called after each repository.
+	Progress func(RepoResult)
+}
+
+// Run remediates repos in batches. A failing repository does not stop the
+// run; only cancellation does, and the report covers the repositories done
+// so far.
+func (r *Remediator) Run(ctx context.Context, repos []Repository, opts Options) (*Report, error) {
+	if opts.Branch == "" {
+		opts.Branch = DefaultBranch
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = len(repos)
+	}
+
+	report := &Report{}
+	for start := 0; start < len(repos); start += opts.BatchSize {
+		if start > 0 && opts.BatchDelay > 0 {
+			select {
+			case <-time.After(opts.BatchDelay):
+			case <-ctx.Done():
+				return report, ctx.Err()
+			}
+		}
+
+		batch := repos[start:min(start+opts.BatchSize, len(repos))]
+		results := make([]RepoResult, len(batch))
+		var wg sync.WaitGroup
+		for i, repo := range batch {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = r.remediate(ctx, repo, opts)
+				if r.Progress != nil {
+					r.Progress(results[i])
+				}
+			}()
+		}
+		wg.Wait()
+
+		for _, res := range results {
+			report.add(res)
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func (rep *Report) add(res RepoResult) {
+	rep.Repositories = append(rep.Repositories, res)
+	switch res.Status {
+	case StatusOpened:
+		rep.Opened++
+	case StatusUpdated:
+		rep.Updated++
+	case StatusClean:
+		rep.Clean++
+	case StatusPlanned:
+		rep.Planned++
+	default:
+		rep.Failed++
+	}
+}
+
+func (r *Remediator) remediate(ctx context.Context, repo Repository, opts Options) RepoResult {
+	res := RepoResult{Repository: repo.FullName}
+	fail := func(err error) RepoResult {
+		res.Status, res.Error = StatusFailed, err.Error()
+		return res
+	}
+
+	files, err := r.API.Workflows(ctx, repo.FullName, repo.DefaultBranch)
+	if err != nil {
+		return fail(err)
+	}
+
+	type fixed struct {
+		file    WorkflowFile
+		content []byte
+		changes []Change
+	}
+	var fixes []fixed
+	for _, f := range files {
+		content, changes, err := FixWorkflow(ctx, f.Path, f.Content, r.Rules, r.API)
+		if err != nil {
+			return fail(err)
+		}
+		if len(changes) > 0 {
+			fixes = append(fixes, fixed{file: f, content: content, changes: changes})
+			res.Changes = append(res.Changes, changes...)
+		}
+	}
+
+	switch {
+	case len(fixes) == 0:
+		res.Status = StatusClean
+		return res
+	case opts.DryRun:
+		res.Status = StatusPlanned
+		return res
+	}
+
+	if err := r.API.ResetBranch(ctx, repo.FullName, opts.Branch, repo.DefaultBranch); err != nil {
+		return fail(err)
+	}
+	for _, f := range fixes {
+		message := fmt.Sprintf("ci: remediate actions policy in %s", f.file.Path)
+		if err := r.API.UpdateFile(ctx, repo.FullName, opts.Branch, f.file.Path, message, f.content, f.file.SHA); err != nil {
+			return fail(err)
+		}
+	}
+
+	link, err := r.Opener.Open(ctx, depupdate.PullRequest{
+		Repo:  repo.FullName,
+		Head:  opts.Branch,
+		Base:  repo.DefaultBranch,
+		Title: PullRequestTitle,
+		Body:  pullRequestBody(res.Changes),
+	})
+	switch {
+	case errors.Is(err, depupdate.ErrPullRequestExists):
+		res.Status, res.PullRequest = StatusUpdated, opts.Branch
+	case err != nil:
+		return fail(err)
+	default:
+		res.Status, res.PullRequest = StatusOpened, link
+	}
+	return res
+}
+
+func pullRequestBody(changes []Change) string {
+	var b strings.Builder
+	b.WriteString("This pull request brings the GitHub Actions workflows in line with the organization's actions policy.\n\n")
+	b.WriteString("| File | Line | Change |\n|------|------|--------|\n")
+	for _, c := range changes {
+		fmt.Fprintf(&b, "| `%s` | %d | %s |\n", c.File, c.Line, c.Description)
+	}
+	b.WriteString("\nPinned actions keep their original ref as a comment so that update tools can still track them.\n")
+	b.WriteString("\n_Generated by `gz actions-policy remediate`._\n")
+	return b.String()
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package actionsfix rewrites GitHub Actions workflows to satisfy an
+// actions policy and proposes the result as pull requests: actions are
+// pinned to commit SHAs, disallowed actions are removed and a top-level
+// permissions block is added where it is missing.
+package actionsfix
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/pkg/github"
+)
+
+// Change kinds.
+const (
+	ChangePin         = "pin"
+	ChangeRemove      = "remove"
+	ChangePermissions = "permissions"
+)
+
+// Change is one fix applied to a workflow file.
+type Change struct {
+	File string `json:"file"`
+	// Line is the 1-based line in the original file.
+	Line        int    `json:"line"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+}
+
+// Rules selects the fixes applied to workflow files.
+type Rules struct {
+	// PinActions replaces tag and branch refs of remote actions with the
+	// commit SHA they point to.
+	PinActions bool
+	// Allowed reports whether a remote action (owner/repo[/path]@ref) may
+	// stay. Steps using other actions are removed. Nil keeps every action.
+	Allowed func(action string) bool
+	// Permissions is the top-level permissions block added to workflows
+	// that have none. Empty adds nothing.
+	Permissions map[string]string
+}
+
+// Resolver resolves an action ref to a commit SHA.
+type Resolver interface {
+	ResolveRef(ctx context.Context, repo, ref string) (string, error)
+}
+
+// RulesFromPolicy derives the rules from an actions policy. Remote actions
+// are checked against the permission level: "all" allows everything but
+// the restricted patterns; "selected" and "local_only" allow the listed
+// actions and patterns, plus GitHub-owned actions when the policy allows
+// them.
+func RulesFromPolicy(p *github.ActionsPolicy, pin, remove, permissions bool) Rules {
+	rules := Rules{PinActions: pin}
+	if remove {
+		rules.Allowed = func(action string) bool { return actionAllowed(p, action) }
+	}
+	if permissions {
+		rules.Permissions = permissionsBlock(p.WorkflowPermissions)
+	}
+	return rules
+}
+
+func actionAllowed(p *github.ActionsPolicy, action string) bool {
+	if matchesAny(p.SecuritySettings.RestrictedActionsPatterns, action) {
+		return false
+	}
+	switch p.PermissionLevel {
+	case github.ActionsPermissionAll:
+		return true
+	case github.ActionsPermissionDisabled:
+		// Actions cannot run at all; removing every step helps nobody.
+		return true
+	}
+	owner, _, _ := strings.Cut(action, "/")
+	if p.SecuritySettings.AllowGitHubOwnedActions && (owner == "actions" || owner == "github") {
+		return true
+	}
+	return matchesAny(p.AllowedActions, action) || matchesAny(p.AllowedActionsPatterns, action)
+}
+
+// matchesAny matches action against glob patterns. A pattern without @
+// matches every ref.
+func matchesAny(patterns []string, action string) bool {
+	name, _, _ := strings.Cut(action, "@")
+	for _, pattern := range patterns {
+		target := action
+		if !strings.Contains(pattern, "@") {
+			target = name
+		}
+		if ok, _ := path.Match(pattern, target); ok || pattern == target {
+			return true
+		}
+	}
+	return false
+}
+
+// permissionsBlock lists the scopes the policy grants. Scopes set to none
+// are left out, which GitHub treats as no access once any scope is listed.
+func permissionsBlock(wp github.WorkflowPermissions) map[string]string {
+	scopes := map[string]github.ActionsTokenPermission{
+		"actions":         wp.ActionsReadPermission,
+		"contents":        wp.ContentsPermission,
+		"packages":        wp.PackagesPermission,
+		"pull-requests":   wp.PullRequestsPermission,
+		"issues":          wp.IssuesPermission,
+		"deployments":     wp.DeploymentsPermission,
+		"checks":          wp.ChecksPermission,
+		"statuses":        wp.StatusesPermission,
+		"security-events": wp.SecurityEventsPermission,
+		"id-token":        wp.IdTokenPermission,
+		"attestations":    wp.AttestationsPermission,
+	}
+	for scope, perm := range wp.CustomPermissions {
+		scopes[scope] = perm
+	}
+
+	block := make(map[string]string)
+	for scope, perm := range scopes {
+		if perm == github.TokenPermissionRead || perm == github.TokenPermissionWrite {
+			block[scope] = string(perm)
+		}
+	}
+	if len(block) == 0 {
+		block["contents"] = string(github.TokenPermissionRead)
+	}
+	return block
+}
+
+var (
+	usesRe        = regexp.MustCompile(`^(\s*(?:-\s+)?)uses:\s*(["']?)([^"'#\s]+)(["']?)\s*(#.*)?$`)
+	shaRe         = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	permissionsRe = regexp.MustCompile(`^permissions\s*:`)
+	jobsRe        = regexp.MustCompile(`^jobs\s*:`)
+)
+
+// FixWorkflow applies rules to one workflow file. Lines that need no fix
+// are left byte for byte, so the diff only shows the fixes.
+func FixWorkflow(ctx context.Context, file string, content []byte, rules Rules, resolver Resolver) ([]byte, []Change, error) {
+	lines := strings.Split(string(content), "\n")
+	removed := make([]bool, len(lines))
+	var changes []Change
+
+	for i, line := range lines {
+		if removed[i] {
+			continue
+		}
+		m := usesRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		action := m[3]
+		if strings.HasPrefix(action, "./") || strings.HasPrefix(action, "docker://") || !strings.Contains(action, "@") {
+			continue
+		}
+
+		if rules.Allowed != nil && !rules.Allowed(action) {
+			if start, end, ok := stepSpan(lines, i, m[1]); ok {
+				for j := start; j < end; j++ {
+					removed[j] = true
+				}
+				changes = append(changes, Change{File: file, Line: start + 1, Kind: ChangeRemove,
+					Description: fmt.Sprintf("removed step using disallowed action %s", action)})
+				continue
+			}
+		}
+
+		name, ref, _ := strings.Cut(action, "@")
+		if !rules.PinActions || resolver == nil || shaRe.MatchString(ref) {
+			continue
+		}
+		sha, err := resolver.ResolveRef(ctx, actionRepo(name), ref)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s:%d: failed to resolve %s: %w", file, i+1, action, err)
+		}
+		lines[i] = fmt.Sprintf("%suses: %s%s@%s%s # %s", m[1], m[2], name, sha, m[4], ref)
+		changes = append(changes, Change{File: file, Line: i + 1, Kind: ChangePin,
+			Description: fmt.Sprintf("pinned %s to %s", action, sha[:12])})
+	}
+
+	out := make([]string, 0, len(lines))
+	for i, line := range lines {
+		if !removed[i] {
+			out = append(out, line)
+		}
+	}
+
+	if len(rules.Permissions) > 0 && !hasPermissions(out) {
+		for i, line := range out {
+			if !jobsRe.MatchString(line) {
+				continue
+			}
+			block := permissionsLines(rules.Permissions)
+			out = append(out[:i], append(block, out[i:]...)...)
+			scopes := make([]string, 0, len(block)-2)
+			for _, l := range block[1 : len(block)-1] {
+				scopes = append(scopes, strings.TrimSpace(l))
+			}
+			changes = append(changes, Change{File: file, Line: indexOf(lines, line) + 1, Kind: ChangePermissions,
+				Description: "added top-level permissions (" + strings.Join(scopes, ", ") + ")"})
+			break
+		}
+	}
+
+	sort.SliceStable(changes, func(a, b int) bool { return changes[a].Line < changes[b].Line })
+	return []byte(strings.Join(out, "\n")), changes, nil
+}
+
+// stepSpan returns the lines [start, end) of the step containing the uses
+// key at line i. Job-level uses of reusable workflows are not steps.
+func stepSpan(lines []string, i int, prefix string) (int, int, bool) {
+	start, dash := -1, -1
+	if d := strings.Index(prefix, "-"); d >= 0 {
+		start, dash = i, d
+	} else {
+		key := len(prefix)
+		for j := i - 1; j >= 0; j-- {
+			trimmed := strings.TrimLeft(lines[j], " ")
+			indent := len(lines[j]) - len(trimmed)
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			if strings.HasPrefix(trimmed, "- ") && indent+2 == key {
+				start, dash = j, indent
+				break
+			}
+			if indent < key {
+				return 0, 0, false
+			}
+		}
+	}
+	if start < 0 {
+		return 0, 0, false
+	}
+
+	end := i + 1
+	for ; end < len(lines); end++ {
+		trimmed := strings.TrimLeft(lines[end], " ")
+		if trimmed != "" && len(lines[end])-len(trimmed) <= dash {
+			break
+		}
+	}
+	// Trailing blank lines belong to the gap before the next step.
+	for end > i+1 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return start, end, true
+}
+
+// actionRepo returns owner/repo of an action that may live in a
+// subdirectory (owner/repo/path).
+func actionRepo(name string) string {
+	parts := strings.SplitN(name, "/", 3)
+	if len(parts) < 2 {
+		return name
+	}
+	return parts[0] + "/" + parts[1]
+}
+
+func hasPermissions(lines []string) bool {
+	for _, line := range lines {
+		if permissionsRe.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func permissionsLines(perms map[string]string) []string {
+	scopes := make([]string, 0, len(perms))
+	for scope := range perms {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+
+	block := []string{"permissions:"}
+	for _, scope := range scopes {
+		block = append(block, fmt.Sprintf("  %s: %s", scope, perms[scope]))
+	}
+	return append(block, "")
+}
+
+func indexOf(lines []string, line string) int {
+	for i, l := range lines {
+		if l == line {
+			return i
+		}
+	}
+	return 0
+}
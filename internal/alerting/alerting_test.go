@@ -0,0 +1,309 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+sources:
+  - name: gz
+    url: http://localhost:9090/metrics
+channels:
+  ops:
+    type: slack
+    webhook_url: ${SLACK_URL}
+  oncall:
+    type: email
+    smtp: mail.example.com:587
+    from: gz@example.com
+    to: [oncall@example.com]
+rules:
+  - name: api-errors
+    metric: gz_errors_total
+    labels: {component: api}
+    type: rate
+    op: ">"
+    value: 1
+    window: 2m
+    severity: warning
+    channels: [ops]
+  - name: rate-limit-low
+    metric: gz_api_rate_limit_remaining
+    op: "<"
+    value: 100
+    for: 5m
+    severity: critical
+    summary: GitHub API budget is almost exhausted
+    channels: [ops]
+    escalation:
+      after: 15m
+      channels: [oncall]
+silences:
+  - name: nightly-maintenance
+    rules: [api-errors]
+    weekly:
+      days: [sat, sun]
+      from: "22:00"
+      to: "02:00"
+      timezone: UTC
+`
+
+type recorder struct {
+	sent []Notification
+	err  error
+}
+
+func (r *recorder) Notify(_ context.Context, n Notification) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.sent = append(r.sent, n)
+	return nil
+}
+
+type testEngine struct {
+	*Engine
+	clock  time.Time
+	ops    *recorder
+	oncall *recorder
+}
+
+func newTestEngine(t *testing.T, extra ...Silence) *testEngine {
+	t.Helper()
+	cfg, err := ParseConfig([]byte(testConfig))
+	require.NoError(t, err)
+	cfg.Silences = append(cfg.Silences, extra...)
+
+	state, err := LoadState(filepath.Join(t.TempDir(), "state.json"))
+	require.NoError(t, err)
+
+	te := &testEngine{
+		Engine: NewEngine(cfg, state, nil),
+		clock:  time.Date(2025, 3, 5, 12, 0, 0, 0, time.UTC), // 수요일
+		ops:    &recorder{},
+		oncall: &recorder{},
+	}
+	te.notifiers = map[string]Notifier{"ops": te.ops, "oncall": te.oncall}
+	te.now = func() time.Time { return te.clock }
+	return te
+}
+
+func (te *testEngine) eval(t *testing.T, after time.Duration, samples ...Sample) []Event {
+	t.Helper()
+	te.clock = te.clock.Add(after)
+	events, err := te.Evaluate(context.Background(), samples)
+	require.NoError(t, err)
+	return events
+}
+
+func remaining(v float64) Sample {
+	return Sample{Name: "gz_api_rate_limit_remaining", Labels: map[string]string{"provider": "github"}, Value: v}
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(testConfig))
+	require.NoError(t, err)
+	assert.Equal(t, RuleThreshold, cfg.Rules[1].Type, "type defaults to threshold")
+	assert.Equal(t, 2*time.Minute, cfg.Rules[0].Window)
+	assert.Equal(t, 15*time.Minute, cfg.Rules[1].Escalation.After)
+
+	tests := map[string]struct {
+		from, to string
+		want     string
+	}{
+		"unknown field":   {"op: \">\"", "operator: \">\"", "field operator not found"},
+		"unknown op":      {"op: \"<\"", "op: \"=<\"", "unknown op"},
+		"unknown channel": {"channels: [oncall]", "channels: [pager]", "unknown channel pager"},
+		"channel type":    {"type: slack", "type: irc", "unknown type \"irc\""},
+		"weekday":         {"days: [sat, sun]", "days: [caturday]", "unknown weekday"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(strings.Replace(testConfig, tt.from, tt.to, 1)))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestParseSamples(t *testing.T) {
+	text := `# HELP gz_errors_total Errors encountered by gz components.
+# TYPE gz_errors_total counter
+gz_errors_total{component="api:github"} 3
+gz_errors_total{component="say \"hi\", ok"} 1 1700000000000
+gz_workerpool_workers 8
+
+gz_api_cache_hit_ratio{provider="github"} 0.75
+`
+	samples, err := ParseSamples(strings.NewReader(text))
+	require.NoError(t, err)
+	require.Len(t, samples, 4)
+	assert.Equal(t, "gz_errors_total", samples[0].Name)
+	assert.Equal(t, map[string]string{"component": "api:github"}, samples[0].Labels)
+	assert.Equal(t, `say "hi", ok`, samples[1].Labels["component"])
+	assert.Equal(t, 8.0, samples[2].Value)
+	assert.Equal(t, `gz_api_cache_hit_ratio{provider="github"}`, samples[3].seriesKey())
+
+	_, err = ParseSamples(strings.NewReader(`gz_errors_total{component="api" 3`))
+	assert.Error(t, err)
+}
+
+func TestScrapeAddsSourceLabel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "gz_workerpool_workers{pool=\"clone\"} 4\n")
+	}))
+	defer srv.Close()
+
+	samples, err := Scrape(context.Background(), srv.Client(), Source{Name: "laptop", URL: srv.URL})
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Equal(t, map[string]string{"pool": "clone", "source": "laptop"}, samples[0].Labels)
+}
+
+func TestSilenceWindows(t *testing.T) {
+	weekly := Silence{Weekly: &WeeklyWindow{Days: []string{"saturday"}, From: "22:00", To: "02:00", Timezone: "UTC"}}
+	require.NoError(t, weekly.validate())
+
+	sat := time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)
+	assert.False(t, weekly.Active(sat.Add(21*time.Hour)))
+	assert.True(t, weekly.Active(sat.Add(23*time.Hour)))
+	assert.True(t, weekly.Active(sat.Add(25*time.Hour)), "overnight windows continue into sunday")
+	assert.False(t, weekly.Active(sat.Add(-time.Hour)), "friday night is not part of the window")
+
+	once := Silence{Start: sat, End: sat.Add(time.Hour), Labels: map[string]string{"provider": "github"}}
+	assert.True(t, once.Active(sat))
+	assert.False(t, once.Active(sat.Add(time.Hour)))
+	assert.True(t, once.Matches("any", map[string]string{"provider": "github", "source": "gz"}))
+	assert.False(t, once.Matches("any", map[string]string{"provider": "gitlab"}))
+}
+
+func TestEngineThresholdEscalationAndAck(t *testing.T) {
+	te := newTestEngine(t)
+
+	assert.Empty(t, te.eval(t, 0, remaining(50)), "pending until the rule held for 5m")
+	assert.Empty(t, te.eval(t, 4*time.Minute, remaining(40)))
+
+	events := te.eval(t, time.Minute, remaining(30))
+	require.Len(t, events, 1)
+	assert.Equal(t, EventFiring, events[0].Kind)
+	require.Len(t, te.ops.sent, 1)
+	assert.Contains(t, te.ops.sent[0].Title(), "[CRITICAL] rate-limit-low firing")
+
+	assert.Empty(t, te.eval(t, 10*time.Minute, remaining(30)), "notified only once")
+
+	events = te.eval(t, 5*time.Minute, remaining(20))
+	require.Len(t, events, 1)
+	assert.Equal(t, EventEscalated, events[0].Kind)
+	require.Len(t, te.oncall.sent, 1)
+	assert.Contains(t, te.oncall.sent[0].Text(), "gz monitoring alerts ack "+events[0].Alert.ID)
+
+	events = te.eval(t, time.Minute, remaining(500))
+	require.Len(t, events, 1)
+	assert.Equal(t, EventResolved, events[0].Kind)
+	assert.ElementsMatch(t, []string{"ops", "oncall"}, events[0].Channels)
+
+	// 확인된 알림은 에스컬레이션되지 않는다
+	te.eval(t, time.Minute, remaining(10))
+	te.eval(t, 5*time.Minute, remaining(10))
+	list := te.state.List()
+	require.Equal(t, StatusFiring, list[0].Status)
+	_, err := te.state.Ack(list[0].ID[:4], "alice", te.clock)
+	require.NoError(t, err)
+	assert.Empty(t, te.eval(t, time.Hour, remaining(10)))
+	assert.Len(t, te.oncall.sent, 2, "the resolution reached oncall, the acked alert did not escalate")
+}
+
+func TestEngineSilenceHoldsBackNotifications(t *testing.T) {
+	start := time.Date(2025, 3, 5, 12, 0, 0, 0, time.UTC)
+	te := newTestEngine(t, Silence{Name: "upgrade", Start: start, End: start.Add(30 * time.Minute)})
+
+	te.eval(t, 0, remaining(10))
+	assert.Empty(t, te.eval(t, 10*time.Minute, remaining(10)))
+	assert.True(t, te.state.List()[0].Silenced)
+	assert.Empty(t, te.ops.sent)
+
+	events := te.eval(t, 25*time.Minute, remaining(10))
+	require.Len(t, events, 1, "the held back notification is sent when the silence ends")
+	assert.Equal(t, EventFiring, events[0].Kind)
+}
+
+func TestEngineRateRule(t *testing.T) {
+	te := newTestEngine(t)
+	errs := func(v float64) Sample {
+		return Sample{Name: "gz_errors_total", Labels: map[string]string{"component": "api"}, Value: v}
+	}
+
+	assert.Empty(t, te.eval(t, 0, errs(0)), "a rate needs two points")
+	assert.Empty(t, te.eval(t, time.Minute, errs(30)), "0.5/s is below the threshold")
+	events := te.eval(t, time.Minute, errs(300))
+	require.Len(t, events, 1)
+	assert.InDelta(t, 2.5, events[0].Alert.Value, 0.001)
+
+	assert.LessOrEqual(t, len(te.state.History["api-errors|"+errs(0).seriesKey()]), 3)
+}
+
+func TestEngineRetriesFailedDelivery(t *testing.T) {
+	te := newTestEngine(t)
+	te.ops.err = errors.New("webhook down")
+
+	te.eval(t, 0, remaining(10))
+	te.clock = te.clock.Add(5 * time.Minute)
+	_, err := te.Evaluate(context.Background(), []Sample{remaining(10)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "webhook down")
+
+	te.ops.err = nil
+	events := te.eval(t, time.Minute, remaining(10))
+	require.Len(t, events, 1)
+	assert.Equal(t, EventFiring, events[0].Kind)
+}
+
+func TestStatePersistence(t *testing.T) {
+	te := newTestEngine(t)
+	te.eval(t, 0, remaining(10))
+	require.NoError(t, te.state.Save())
+
+	loaded, err := LoadState(te.state.path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Alerts, 1)
+	assert.Equal(t, StatusPending, loaded.List()[0].Status)
+}
+
+func TestNotifiers(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	n := Notification{Kind: EventFiring, Alert: Alert{ID: "abc", Rule: "disk", Severity: "warning", Series: "disk_free"}}
+	t.Setenv("TEAMS_URL", srv.URL)
+	require.NoError(t, NewNotifier(Channel{Type: ChannelTeams, WebhookURL: "${TEAMS_URL}"}, srv.Client()).Notify(context.Background(), n))
+	assert.Equal(t, "MessageCard", got["@type"])
+	assert.Contains(t, got["title"], "disk firing")
+
+	require.NoError(t, NewNotifier(Channel{Type: ChannelDiscord, WebhookURL: srv.URL}, srv.Client()).Notify(context.Background(), n))
+	assert.Contains(t, got["content"], "**🔥 [WARNING] disk firing**")
+
+	var msg string
+	mail := &emailNotifier{cfg: Channel{SMTP: "mail:25", From: "gz@example.com", To: []string{"a@example.com"}},
+		send: func(_ string, _ smtp.Auth, _ string, _ []string, m []byte) error { msg = string(m); return nil }}
+	require.NoError(t, mail.Notify(context.Background(), n))
+	assert.Contains(t, msg, "Subject: 🔥 [WARNING] disk firing\r\n")
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package alerting evaluates alert rules over scraped Prometheus metrics and
+// routes the resulting alerts to chat and email channels.
+package alerting
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RuleType selects how a rule compares samples.
+type RuleType string
+
+// Supported rule types.
+const (
+	// RuleThreshold compares the current sample value.
+	RuleThreshold RuleType = "threshold"
+	// RuleRate compares the per-second rate of change over Window.
+	RuleRate RuleType = "rate"
+)
+
+// ChannelType is the kind of notification channel.
+type ChannelType string
+
+// Supported channel types.
+const (
+	ChannelSlack   ChannelType = "slack"
+	ChannelDiscord ChannelType = "discord"
+	ChannelTeams   ChannelType = "teams"
+	ChannelEmail   ChannelType = "email"
+)
+
+// defaultRateWindow is used by rate rules without a window.
+const defaultRateWindow = 5 * time.Minute
+
+// Config is the alerting configuration, usually alerts.yaml.
+type Config struct {
+	// Sources are Prometheus text endpoints to scrape, e.g. the
+	// --metrics-addr of a long-running gz process.
+	Sources  []Source           `yaml:"sources"`
+	Rules    []Rule             `yaml:"rules"`
+	Channels map[string]Channel `yaml:"channels"`
+	Silences []Silence          `yaml:"silences"`
+}
+
+// Source is a metrics endpoint. Its name is added to every scraped sample
+// as the "source" label.
+type Source struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+}
+
+// Rule raises an alert for every series of Metric matching Labels whose
+// value (threshold) or rate of change (rate) satisfies Op Value for at
+// least For.
+type Rule struct {
+	Name     string            `yaml:"name"`
+	Metric   string            `yaml:"metric"`
+	Labels   map[string]string `yaml:"labels"`
+	Type     RuleType          `yaml:"type"`
+	Op       string            `yaml:"op"`
+	Value    float64           `yaml:"value"`
+	Window   time.Duration     `yaml:"window"`
+	For      time.Duration     `yaml:"for"`
+	Severity string            `yaml:"severity"`
+	Summary  string            `yaml:"summary"`
+	// Channels receive firing and resolved notifications.
+	Channels   []string    `yaml:"channels"`
+	Escalation *Escalation `yaml:"escalation"`
+}
+
+// Escalation notifies additional channels when a firing alert stays
+// unacknowledged for After.
+type Escalation struct {
+	After    time.Duration `yaml:"after"`
+	Channels []string      `yaml:"channels"`
+}
+
+// Channel is a notification target. Webhook URLs and the email password
+// may reference environment variables, e.g. ${SLACK_WEBHOOK_URL}.
+type Channel struct {
+	Type       ChannelType `yaml:"type"`
+	WebhookURL string      `yaml:"webhook_url"`
+
+	// Email settings.
+	SMTP     string   `yaml:"smtp"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+	Username string   `yaml:"username"`
+	Password string   `yaml:"password"`
+}
+
+// DefaultConfigPath returns ~/.config/gzh-manager/alerts.yaml.
+func DefaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "alerts.yaml"
+	}
+	return filepath.Join(home, ".config", "gzh-manager", "alerts.yaml")
+}
+
+// DefaultStatePath returns ~/.config/gzh-manager/alerts/state.json.
+func DefaultStatePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join("alerts", "state.json")
+	}
+	return filepath.Join(home, ".config", "gzh-manager", "alerts", "state.json")
+}
+
+// LoadConfig reads and validates an alerting configuration file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert config %s: %w", path, err)
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig parses and validates an alerting configuration.
+func ParseConfig(data []byte) (*Config, error) {
+	var c Config
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil {
+		return nil, fmt.Errorf("invalid alert config: %w", err)
+	}
+	for i := range c.Rules {
+		if c.Rules[i].Type == "" {
+			c.Rules[i].Type = RuleThreshold
+		}
+		if c.Rules[i].Type == RuleRate && c.Rules[i].Window == 0 {
+			c.Rules[i].Window = defaultRateWindow
+		}
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Validate checks rules, channels and silences for consistency.
+func (c *Config) Validate() error {
+	names := make([]string, 0, len(c.Channels))
+	for name := range c.Channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ch := c.Channels[name]
+		switch ch.Type {
+		case ChannelSlack, ChannelDiscord, ChannelTeams:
+			if ch.WebhookURL == "" {
+				return fmt.Errorf("invalid alert config: channel %s: webhook_url is required", name)
+			}
+		case ChannelEmail:
+			if ch.SMTP == "" || ch.From == "" || len(ch.To) == 0 {
+				return fmt.Errorf("invalid alert config: channel %s: smtp, from and to are required", name)
+			}
+		default:
+			return fmt.Errorf("invalid alert config: channel %s: unknown type %q (want slack, discord, teams or email)", name, ch.Type)
+		}
+	}
+
+	for i, s := range c.Sources {
+		if s.Name == "" || s.URL == "" {
+			return fmt.Errorf("invalid alert config: source %d: name and url are required", i+1)
+		}
+	}
+
+	seen := make(map[string]bool, len(c.Rules))
+	for _, r := range c.Rules {
+		if r.Name == "" || r.Metric == "" {
+			return fmt.Errorf("invalid alert config: every rule needs a name and a metric")
+		}
+		if seen[r.Name] {
+			return fmt.Errorf("invalid alert config: duplicate rule %s", r.Name)
+		}
+		seen[r.Name] = true
+
+		if r.Type != RuleThreshold && r.Type != RuleRate {
+			return fmt.Errorf("invalid alert config: rule %s: unknown type %q (want threshold or rate)", r.Name, r.Type)
+		}
+		if _, err := compare(r.Op, 0, 0); err != nil {
+			return fmt.Errorf("invalid alert config: rule %s: %w", r.Name, err)
+		}
+		if r.For < 0 || r.Window < 0 {
+			return fmt.Errorf("invalid alert config: rule %s: durations must not be negative", r.Name)
+		}
+		if err := c.checkChannels(r.Name, r.Channels); err != nil {
+			return err
+		}
+		if r.Escalation != nil {
+			if r.Escalation.After <= 0 {
+				return fmt.Errorf("invalid alert config: rule %s: escalation.after must be positive", r.Name)
+			}
+			if len(r.Escalation.Channels) == 0 {
+				return fmt.Errorf("invalid alert config: rule %s: escalation needs channels", r.Name)
+			}
+			if err := c.checkChannels(r.Name, r.Escalation.Channels); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range c.Silences {
+		if err := c.Silences[i].validate(); err != nil {
+			return fmt.Errorf("invalid alert config: silence %s: %w", c.Silences[i].label(i), err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) checkChannels(rule string, names []string) error {
+	for _, name := range names {
+		if _, ok := c.Channels[name]; !ok {
+			return fmt.Errorf("invalid alert config: rule %s: unknown channel %s", rule, name)
+		}
+	}
+	return nil
+}
+
+// compare applies op to value and threshold.
+func compare(op string, value, threshold float64) (bool, error) {
+	switch op {
+	case ">":
+		return value > threshold, nil
+	case ">=":
+		return value >= threshold, nil
+	case "<":
+		return value < threshold, nil
+	case "<=":
+		return value <= threshold, nil
+	case "==":
+		return value == threshold, nil
+	case "!=":
+		return value != threshold, nil
+	default:
+		return false, fmt.Errorf("unknown op %q (want >, >=, <, <=, == or !=)", op)
+	}
+}
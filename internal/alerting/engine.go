@@ -0,0 +1,420 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package alerting
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// resolvedRetention is how long resolved alerts stay in the state.
+const resolvedRetention = 24 * time.Hour
+
+// Status is the lifecycle state of an alert.
+type Status string
+
+// Alert states. An alert is pending while its rule matches for less than
+// the rule's For duration.
+const (
+	StatusPending  Status = "pending"
+	StatusFiring   Status = "firing"
+	StatusResolved Status = "resolved"
+)
+
+// EventKind is the kind of notification an evaluation produced.
+type EventKind string
+
+// Event kinds.
+const (
+	EventFiring    EventKind = "firing"
+	EventEscalated EventKind = "escalated"
+	EventResolved  EventKind = "resolved"
+)
+
+// Alert is one series matching a rule.
+type Alert struct {
+	ID       string            `json:"id"`
+	Rule     string            `json:"rule"`
+	Series   string            `json:"series"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Severity string            `json:"severity,omitempty"`
+	Summary  string            `json:"summary,omitempty"`
+	Value    float64           `json:"value"`
+	Status   Status            `json:"status"`
+
+	// Since is when the rule first matched.
+	Since       time.Time `json:"since"`
+	FiredAt     time.Time `json:"firedAt,omitzero"`
+	NotifiedAt  time.Time `json:"notifiedAt,omitzero"`
+	EscalatedAt time.Time `json:"escalatedAt,omitzero"`
+	ResolvedAt  time.Time `json:"resolvedAt,omitzero"`
+	AckedAt     time.Time `json:"ackedAt,omitzero"`
+	AckedBy     string    `json:"ackedBy,omitempty"`
+	// Silenced is true while an active silence holds back notifications.
+	Silenced bool `json:"silenced,omitempty"`
+}
+
+// Event is a notification produced by an evaluation.
+type Event struct {
+	Kind     EventKind
+	Alert    Alert
+	Channels []string
+	// Err holds delivery failures, per channel.
+	Err error
+}
+
+// Point is a sample of a series at a time, kept for rate rules.
+type Point struct {
+	Time  time.Time `json:"t"`
+	Value float64   `json:"v"`
+}
+
+// State is the persisted alert state shared by `check` runs and `watch`.
+type State struct {
+	Alerts map[string]*Alert `json:"alerts"`
+	// History holds recent points per rule and series for rate rules.
+	History map[string][]Point `json:"history,omitempty"`
+
+	path string
+}
+
+// LoadState reads the state file at path; a missing file yields an empty
+// state.
+func LoadState(path string) (*State, error) {
+	s := &State{Alerts: make(map[string]*Alert), History: make(map[string][]Point), path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alert state %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse alert state %s: %w", path, err)
+	}
+	if s.Alerts == nil {
+		s.Alerts = make(map[string]*Alert)
+	}
+	if s.History == nil {
+		s.History = make(map[string][]Point)
+	}
+	return s, nil
+}
+
+// Save writes the state back to its file.
+func (s *State) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create alert state directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode alert state: %w", err)
+	}
+	return os.WriteFile(s.path, data, 0o600)
+}
+
+// List returns the alerts ordered by status (firing first) and rule.
+func (s *State) List() []Alert {
+	order := map[Status]int{StatusFiring: 0, StatusPending: 1, StatusResolved: 2}
+	list := make([]Alert, 0, len(s.Alerts))
+	for _, a := range s.Alerts {
+		list = append(list, *a)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if order[list[i].Status] != order[list[j].Status] {
+			return order[list[i].Status] < order[list[j].Status]
+		}
+		if list[i].Rule != list[j].Rule {
+			return list[i].Rule < list[j].Rule
+		}
+		return list[i].Series < list[j].Series
+	})
+	return list
+}
+
+// Ack acknowledges the firing alert with id (or a unique prefix of it),
+// which stops its escalation.
+func (s *State) Ack(id, by string, at time.Time) (*Alert, error) {
+	if id == "" {
+		return nil, fmt.Errorf("alert id is required")
+	}
+
+	var found *Alert
+	for key, a := range s.Alerts {
+		if !strings.HasPrefix(key, id) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("alert id %s is ambiguous", id)
+		}
+		found = a
+	}
+	if found == nil {
+		return nil, fmt.Errorf("alert %s not found", id)
+	}
+	if found.Status != StatusFiring {
+		return nil, fmt.Errorf("alert %s is %s, only firing alerts can be acknowledged", found.ID, found.Status)
+	}
+	if found.AckedAt.IsZero() {
+		found.AckedAt = at
+		found.AckedBy = by
+	}
+	return found, nil
+}
+
+// Engine evaluates rules against samples and notifies channels.
+type Engine struct {
+	cfg       *Config
+	state     *State
+	notifiers map[string]Notifier
+	now       func() time.Time
+}
+
+// NewEngine creates an engine for cfg that keeps alerts in state.
+func NewEngine(cfg *Config, state *State, client *http.Client) *Engine {
+	notifiers := make(map[string]Notifier, len(cfg.Channels))
+	for name, ch := range cfg.Channels {
+		notifiers[name] = NewNotifier(ch, client)
+	}
+	return &Engine{cfg: cfg, state: state, notifiers: notifiers, now: time.Now}
+}
+
+// Collect scrapes every configured source. Sources that fail are reported
+// in the returned error; samples of the others are still returned.
+func (e *Engine) Collect(ctx context.Context, client *http.Client) ([]Sample, error) {
+	var samples []Sample
+	var errs []error
+	for _, src := range e.cfg.Sources {
+		s, err := Scrape(ctx, client, src)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		samples = append(samples, s...)
+	}
+	return samples, errors.Join(errs...)
+}
+
+// Evaluate applies every rule to samples, advances alert states and sends
+// the resulting notifications. Series missing from samples keep their
+// state, so an unreachable source neither fires nor resolves alerts.
+//
+// Firing alerts are announced once on the rule's channels. While a silence
+// matches, the announcement is held back and sent when the silence ends.
+// Announced alerts that stay unacknowledged for the rule's escalation
+// delay are sent to the escalation channels. Resolutions go to every
+// channel that saw the alert.
+func (e *Engine) Evaluate(ctx context.Context, samples []Sample) ([]Event, error) {
+	now := e.now()
+	var events []Event
+
+	for i := range e.cfg.Rules {
+		rule := &e.cfg.Rules[i]
+		for _, s := range samples {
+			if s.Name != rule.Metric || !matchLabels(rule.Labels, s.Labels) {
+				continue
+			}
+			if ev, ok := e.evaluateSeries(ctx, rule, s, now); ok {
+				events = append(events, ev)
+			}
+		}
+	}
+
+	e.prune(now)
+
+	var errs []error
+	for _, ev := range events {
+		if ev.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", ev.Alert.Rule, ev.Kind, ev.Err))
+		}
+	}
+	return events, errors.Join(errs...)
+}
+
+func (e *Engine) evaluateSeries(ctx context.Context, rule *Rule, s Sample, now time.Time) (Event, bool) {
+	series := s.seriesKey()
+	value := s.Value
+
+	if rule.Type == RuleRate {
+		rate, ok := e.rate(rule, series, s.Value, now)
+		if !ok {
+			return Event{}, false
+		}
+		value = rate
+	}
+
+	breach, _ := compare(rule.Op, value, rule.Value)
+	id := alertID(rule.Name, series)
+	a := e.state.Alerts[id]
+
+	if !breach {
+		if a == nil || a.Status == StatusResolved {
+			return Event{}, false
+		}
+		if a.Status == StatusPending {
+			delete(e.state.Alerts, id)
+			return Event{}, false
+		}
+
+		a.Status = StatusResolved
+		a.ResolvedAt = now
+		a.Value = value
+		a.Silenced = false
+		if a.NotifiedAt.IsZero() {
+			return Event{}, false
+		}
+		channels := rule.Channels
+		if !a.EscalatedAt.IsZero() && rule.Escalation != nil {
+			channels = mergeChannels(channels, rule.Escalation.Channels)
+		}
+		return e.notify(ctx, EventResolved, a, channels), true
+	}
+
+	if a == nil || a.Status == StatusResolved {
+		a = &Alert{
+			ID:       id,
+			Rule:     rule.Name,
+			Series:   series,
+			Labels:   s.Labels,
+			Severity: rule.Severity,
+			Summary:  rule.Summary,
+			Status:   StatusPending,
+			Since:    now,
+		}
+		e.state.Alerts[id] = a
+	}
+	a.Value = value
+
+	if a.Status == StatusPending {
+		if now.Sub(a.Since) < rule.For {
+			return Event{}, false
+		}
+		a.Status = StatusFiring
+		a.FiredAt = now
+	}
+
+	a.Silenced = e.silenced(rule.Name, a.Labels, now)
+	if a.Silenced {
+		return Event{}, false
+	}
+
+	if a.NotifiedAt.IsZero() {
+		ev := e.notify(ctx, EventFiring, a, rule.Channels)
+		if delivered(ev) {
+			a.NotifiedAt = now
+		}
+		return ev, true
+	}
+
+	if esc := rule.Escalation; esc != nil && a.AckedAt.IsZero() && a.EscalatedAt.IsZero() && now.Sub(a.NotifiedAt) >= esc.After {
+		ev := e.notify(ctx, EventEscalated, a, esc.Channels)
+		if delivered(ev) {
+			a.EscalatedAt = now
+		}
+		return ev, true
+	}
+
+	return Event{}, false
+}
+
+// rate records value and returns the per-second change over the rule's
+// window. ok is false until two points span part of the window.
+func (e *Engine) rate(rule *Rule, series string, value float64, now time.Time) (float64, bool) {
+	key := rule.Name + "|" + series
+	points := append(e.state.History[key], Point{Time: now, Value: value})
+
+	// 구간 시작 이전의 점은 가장 최근 것 하나만 남긴다
+	cutoff := now.Add(-rule.Window)
+	first := 0
+	for first+1 < len(points) && !points[first+1].Time.After(cutoff) {
+		first++
+	}
+	points = points[first:]
+	e.state.History[key] = points
+
+	oldest := points[0]
+	elapsed := now.Sub(oldest.Time).Seconds()
+	if len(points) < 2 || elapsed <= 0 {
+		return 0, false
+	}
+	return (value - oldest.Value) / elapsed, true
+}
+
+func (e *Engine) silenced(rule string, labels map[string]string, now time.Time) bool {
+	for i := range e.cfg.Silences {
+		if s := &e.cfg.Silences[i]; s.Active(now) && s.Matches(rule, labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Engine) notify(ctx context.Context, kind EventKind, a *Alert, channels []string) Event {
+	ev := Event{Kind: kind, Alert: *a, Channels: channels}
+	failed := 0
+	var errs []error
+	for _, name := range channels {
+		n, ok := e.notifiers[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown channel %s", name))
+			failed++
+			continue
+		}
+		if err := n.Notify(ctx, Notification{Kind: kind, Alert: *a}); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			failed++
+		}
+	}
+	ev.Err = errors.Join(errs...)
+	if failed == len(channels) && failed > 0 {
+		// 모든 채널이 실패하면 다음 평가에서 다시 보낸다
+		ev.Channels = nil
+	}
+	return ev
+}
+
+// delivered reports whether at least one channel received the event.
+func delivered(ev Event) bool {
+	return len(ev.Channels) > 0 || ev.Err == nil
+}
+
+// prune drops resolved alerts past their retention and stale rate history.
+func (e *Engine) prune(now time.Time) {
+	for id, a := range e.state.Alerts {
+		if a.Status == StatusResolved && now.Sub(a.ResolvedAt) > resolvedRetention {
+			delete(e.state.Alerts, id)
+		}
+	}
+	for key, points := range e.state.History {
+		if len(points) == 0 || now.Sub(points[len(points)-1].Time) > resolvedRetention {
+			delete(e.state.History, key)
+		}
+	}
+}
+
+func alertID(rule, series string) string {
+	sum := sha256.Sum256([]byte(rule + "|" + series))
+	return hex.EncodeToString(sum[:])[:10]
+}
+
+func mergeChannels(a, b []string) []string {
+	out := append([]string(nil), a...)
+	for _, ch := range b {
+		if !contains(out, ch) {
+			out = append(out, ch)
+		}
+	}
+	return out
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Notification is sent to a channel when an alert fires, escalates or
+// resolves.
+type Notification struct {
+	Kind  EventKind
+	Alert Alert
+}
+
+// Title returns a one-line summary of the notification.
+func (n Notification) Title() string {
+	icon := map[EventKind]string{EventFiring: "🔥", EventEscalated: "🚨", EventResolved: "✅"}[n.Kind]
+	severity := n.Alert.Severity
+	if severity == "" {
+		severity = "alert"
+	}
+	return fmt.Sprintf("%s [%s] %s %s", icon, strings.ToUpper(severity), n.Alert.Rule, n.Kind)
+}
+
+// Text returns the notification body.
+func (n Notification) Text() string {
+	a := n.Alert
+	var b strings.Builder
+	if a.Summary != "" {
+		b.WriteString(a.Summary + "\n")
+	}
+	fmt.Fprintf(&b, "Series: %s\n", a.Series)
+	fmt.Fprintf(&b, "Value: %s\n", strconv.FormatFloat(a.Value, 'g', 6, 64))
+	switch n.Kind {
+	case EventResolved:
+		fmt.Fprintf(&b, "Resolved at %s after %s", a.ResolvedAt.Format(time.RFC3339), a.ResolvedAt.Sub(a.FiredAt).Round(time.Second))
+	case EventEscalated:
+		fmt.Fprintf(&b, "Firing since %s without acknowledgement. Acknowledge with: gz monitoring alerts ack %s", a.FiredAt.Format(time.RFC3339), a.ID)
+	default:
+		fmt.Fprintf(&b, "Firing since %s (alert %s)", a.FiredAt.Format(time.RFC3339), a.ID)
+	}
+	return b.String()
+}
+
+// Notifier delivers notifications to one channel.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// NewNotifier creates the notifier for a configured channel.
+func NewNotifier(ch Channel, client *http.Client) Notifier {
+	if ch.Type == ChannelEmail {
+		return &emailNotifier{cfg: ch, send: smtp.SendMail}
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &webhookNotifier{kind: ch.Type, url: os.ExpandEnv(ch.WebhookURL), client: client}
+}
+
+// webhookNotifier posts to a Slack, Discord or Teams incoming webhook.
+type webhookNotifier struct {
+	kind   ChannelType
+	url    string
+	client *http.Client
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(webhookPayload(w.kind, n))
+	if err != nil {
+		return fmt.Errorf("encode %s notification: %w", w.kind, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s webhook: %w", w.kind, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s webhook: %w", w.kind, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s webhook: unexpected status %s", w.kind, resp.Status)
+	}
+	return nil
+}
+
+func webhookPayload(kind ChannelType, n Notification) any {
+	switch kind {
+	case ChannelDiscord:
+		return map[string]string{"content": "**" + n.Title() + "**\n" + n.Text()}
+	case ChannelTeams:
+		color := "D9534F"
+		if n.Kind == EventResolved {
+			color = "5CB85C"
+		}
+		return map[string]string{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    n.Title(),
+			"themeColor": color,
+			"title":      n.Title(),
+			"text":       strings.ReplaceAll(n.Text(), "\n", "<br>"),
+		}
+	default:
+		return map[string]string{"text": "*" + n.Title() + "*\n" + n.Text()}
+	}
+}
+
+// emailNotifier mails the channel's recipients.
+type emailNotifier struct {
+	cfg  Channel
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func (e *emailNotifier) Notify(_ context.Context, n Notification) error {
+	var auth smtp.Auth
+	if e.cfg.Username != "" {
+		host, _, _ := net.SplitHostPort(e.cfg.SMTP)
+		auth = smtp.PlainAuth("", e.cfg.Username, os.ExpandEnv(e.cfg.Password), host)
+	}
+
+	msg := "From: " + e.cfg.From + "\r\n" +
+		"To: " + strings.Join(e.cfg.To, ", ") + "\r\n" +
+		"Subject: " + n.Title() + "\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n\r\n" +
+		strings.ReplaceAll(n.Text(), "\n", "\r\n") + "\r\n"
+	if err := e.send(e.cfg.SMTP, auth, e.cfg.From, e.cfg.To, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send email via %s: %w", e.cfg.SMTP, err)
+	}
+	return nil
+}
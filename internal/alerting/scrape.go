@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package alerting
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Sample is one series value of a scrape.
+type Sample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// seriesKey identifies the series of a sample, e.g. gz_errors_total{component="api"}.
+func (s Sample) seriesKey() string {
+	return s.Name + formatLabels(s.Labels)
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+strconv.Quote(labels[k]))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// Scrape fetches the Prometheus text exposition of src. Every sample gets
+// the source name as "source" label unless it already carries one.
+func Scrape(ctx context.Context, client *http.Client, src Source) ([]Sample, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("scrape %s: %w", src.Name, err)
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scrape %s: %w", src.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scrape %s: unexpected status %s", src.Name, resp.Status)
+	}
+
+	samples, err := ParseSamples(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("scrape %s: %w", src.Name, err)
+	}
+	for _, s := range samples {
+		if _, ok := s.Labels["source"]; !ok {
+			s.Labels["source"] = src.Name
+		}
+	}
+	return samples, nil
+}
+
+// ParseSamples parses the Prometheus text exposition format. Comments,
+// HELP and TYPE lines are skipped; timestamps are ignored.
+func ParseSamples(r io.Reader) ([]Sample, error) {
+	var samples []Sample
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		s, err := parseSampleLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		samples = append(samples, s)
+	}
+	return samples, scanner.Err()
+}
+
+func parseSampleLine(line string) (Sample, error) {
+	s := Sample{Labels: make(map[string]string)}
+
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return s, fmt.Errorf("invalid sample %q", line)
+	}
+	s.Name = line[:end]
+	rest := line[end:]
+
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		rest, err = parseLabels(rest[1:], s.Labels)
+		if err != nil {
+			return s, err
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return s, fmt.Errorf("missing value in %q", line)
+	}
+	v, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return s, fmt.Errorf("invalid value in %q", line)
+	}
+	s.Value = v
+	return s, nil
+}
+
+// parseLabels reads `name="value",...}` into labels and returns the rest of
+// the line after the closing brace.
+func parseLabels(in string, labels map[string]string) (string, error) {
+	for {
+		in = strings.TrimLeft(in, " ,")
+		if strings.HasPrefix(in, "}") {
+			return in[1:], nil
+		}
+
+		eq := strings.IndexByte(in, '=')
+		if eq <= 0 || len(in) < eq+2 || in[eq+1] != '"' {
+			return "", fmt.Errorf("invalid labels near %q", in)
+		}
+		name := strings.TrimSpace(in[:eq])
+
+		var value strings.Builder
+		i := eq + 2
+		for ; i < len(in) && in[i] != '"'; i++ {
+			if in[i] == '\\' && i+1 < len(in) {
+				i++
+				switch in[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(in[i])
+				}
+				continue
+			}
+			value.WriteByte(in[i])
+		}
+		if i >= len(in) {
+			return "", fmt.Errorf("unterminated label value for %s", name)
+		}
+		labels[name] = value.String()
+		in = in[i+1:]
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package alerting
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Silence suppresses notifications for matching alerts while it is
+// active. A silence is either a one-off window (Start, End) or a weekly
+// maintenance window. Alerts keep being evaluated; notifications that were
+// held back are sent when the silence ends and the alert still fires.
+type Silence struct {
+	Name    string `yaml:"name"`
+	Comment string `yaml:"comment"`
+	// Rules limits the silence to these rule names; empty matches all rules.
+	Rules []string `yaml:"rules"`
+	// Labels limits the silence to alerts carrying these labels.
+	Labels map[string]string `yaml:"labels"`
+
+	Start  time.Time     `yaml:"start"`
+	End    time.Time     `yaml:"end"`
+	Weekly *WeeklyWindow `yaml:"weekly"`
+}
+
+// WeeklyWindow repeats on Days between From and To ("15:04"). A window
+// whose To is before From ends on the next day.
+type WeeklyWindow struct {
+	Days     []string `yaml:"days"`
+	From     string   `yaml:"from"`
+	To       string   `yaml:"to"`
+	Timezone string   `yaml:"timezone"`
+}
+
+func (s *Silence) label(i int) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return strconv.Itoa(i + 1)
+}
+
+func (s *Silence) validate() error {
+	if s.Weekly == nil {
+		if s.Start.IsZero() || s.End.IsZero() {
+			return fmt.Errorf("start and end, or weekly, are required")
+		}
+		if !s.End.After(s.Start) {
+			return fmt.Errorf("end must be after start")
+		}
+		return nil
+	}
+
+	w := s.Weekly
+	if len(w.Days) == 0 {
+		return fmt.Errorf("weekly.days is required")
+	}
+	for _, d := range w.Days {
+		if _, ok := parseWeekday(d); !ok {
+			return fmt.Errorf("unknown weekday %q", d)
+		}
+	}
+	if _, err := parseClock(w.From); err != nil {
+		return err
+	}
+	if _, err := parseClock(w.To); err != nil {
+		return err
+	}
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", w.Timezone)
+	}
+	return nil
+}
+
+// Active reports whether the silence is in effect at t.
+func (s *Silence) Active(t time.Time) bool {
+	if s.Weekly == nil {
+		return !t.Before(s.Start) && t.Before(s.End)
+	}
+
+	w := s.Weekly
+	loc, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return false
+	}
+	from, err1 := parseClock(w.From)
+	to, err2 := parseClock(w.To)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if from <= to {
+		return w.hasDay(local.Weekday()) && minute >= from && minute < to
+	}
+	// 자정을 넘기는 구간은 시작한 요일을 기준으로 한다
+	if minute >= from {
+		return w.hasDay(local.Weekday())
+	}
+	return minute < to && w.hasDay(local.AddDate(0, 0, -1).Weekday())
+}
+
+// Matches reports whether the silence applies to an alert of rule with
+// labels.
+func (s *Silence) Matches(rule string, labels map[string]string) bool {
+	if len(s.Rules) > 0 && !contains(s.Rules, rule) {
+		return false
+	}
+	return matchLabels(s.Labels, labels)
+}
+
+func (w *WeeklyWindow) hasDay(day time.Weekday) bool {
+	for _, d := range w.Days {
+		if wd, ok := parseWeekday(d); ok && wd == day {
+			return true
+		}
+	}
+	return false
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	s = strings.ToLower(s)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// parseClock returns the minutes since midnight of a "15:04" time.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (want HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLabels reports whether labels carries every pair of want.
+func matchLabels(want, labels map[string]string) bool {
+	for k, v := range want {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package godoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/filesystem"
+)
+
+// BaselineVersion is the format version written to baseline files.
+const BaselineVersion = 1
+
+// DefaultCoverageTolerance is the coverage drop, in percentage points,
+// that Compare still accepts; it absorbs rounding.
+const DefaultCoverageTolerance = 0.1
+
+// Baseline records the documentation state of a codebase so later runs
+// fail only on new findings. Issues are keyed by type and symbol rather
+// than line, so unrelated edits do not invalidate the baseline.
+type Baseline struct {
+	Version   int                         `json:"version"`
+	CreatedAt time.Time                   `json:"created_at"`
+	Packages  map[string]*PackageBaseline `json:"packages"`
+}
+
+// PackageBaseline is the recorded state of one package.
+type PackageBaseline struct {
+	Coverage float64 `json:"coverage"`
+	// Issues counts the quality issues per "type:symbol" key.
+	Issues map[string]int `json:"issues,omitempty"`
+}
+
+// CoverageDrop is a package whose coverage fell below its baseline.
+type CoverageDrop struct {
+	Package  string  `json:"package"`
+	Baseline float64 `json:"baseline"`
+	Current  float64 `json:"current"`
+}
+
+// BaselineIssue is a quality issue not covered by the baseline.
+type BaselineIssue struct {
+	Package string       `json:"package"`
+	Issue   QualityIssue `json:"issue"`
+}
+
+// BaselineDiff is the result of comparing an analysis with a baseline.
+type BaselineDiff struct {
+	NewIssues     []BaselineIssue `json:"new_issues"`
+	CoverageDrops []CoverageDrop  `json:"coverage_drops"`
+	// FixedIssues counts baseline issues that no longer occur; updating
+	// the baseline locks the improvement in.
+	FixedIssues int `json:"fixed_issues"`
+	// NewPackages are analyzed packages missing from the baseline. All of
+	// their issues are new.
+	NewPackages []string `json:"new_packages,omitempty"`
+}
+
+// Regressed reports whether the analysis is worse than the baseline.
+func (d *BaselineDiff) Regressed() bool {
+	return len(d.NewIssues) > 0 || len(d.CoverageDrops) > 0
+}
+
+// baselineKey normalizes a package path so "./pkg/x" and "pkg/x" match.
+func baselineKey(importPath string) string {
+	return filepath.ToSlash(filepath.Clean(importPath))
+}
+
+func issueKey(issue QualityIssue) string {
+	return issue.Type + ":" + issue.Symbol
+}
+
+// NewBaseline records the state of results.
+func NewBaseline(results []*PackageInfo) *Baseline {
+	b := &Baseline{
+		Version:   BaselineVersion,
+		CreatedAt: time.Now().UTC(),
+		Packages:  make(map[string]*PackageBaseline, len(results)),
+	}
+	for _, r := range results {
+		pkg := &PackageBaseline{Coverage: r.CoverageStats.CoveragePercentage}
+		for _, issue := range r.QualityIssues {
+			if pkg.Issues == nil {
+				pkg.Issues = make(map[string]int)
+			}
+			pkg.Issues[issueKey(issue)]++
+		}
+		b.Packages[baselineKey(r.ImportPath)] = pkg
+	}
+	return b
+}
+
+// LoadBaseline reads a baseline file.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read godoc baseline: %w", err)
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid godoc baseline %s: %w", path, err)
+	}
+	if b.Version > BaselineVersion {
+		return nil, fmt.Errorf("godoc baseline %s has version %d; this gz supports up to %d", path, b.Version, BaselineVersion)
+	}
+	if b.Packages == nil {
+		b.Packages = make(map[string]*PackageBaseline)
+	}
+	return &b, nil
+}
+
+// Save writes the baseline as indented JSON, so it diffs well in review.
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode godoc baseline: %w", err)
+	}
+	if err := filesystem.WriteFileAtomic(filesystem.OS(), path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write godoc baseline: %w", err)
+	}
+	return nil
+}
+
+// Merge returns a baseline with the packages of results replaced by their
+// current state and the other packages kept, so a baseline can be updated
+// from an analysis of a single package.
+func (b *Baseline) Merge(results []*PackageInfo) *Baseline {
+	merged := NewBaseline(results)
+	for key, pkg := range b.Packages {
+		if _, ok := merged.Packages[key]; !ok {
+			merged.Packages[key] = pkg
+		}
+	}
+	return merged
+}
+
+// Compare reports what changed in results relative to the baseline.
+// Packages in the baseline that were not analyzed are ignored. A coverage
+// drop of up to tolerance percentage points is accepted.
+func (b *Baseline) Compare(results []*PackageInfo, tolerance float64) *BaselineDiff {
+	diff := &BaselineDiff{}
+	for _, r := range results {
+		key := baselineKey(r.ImportPath)
+		pkg, ok := b.Packages[key]
+		if !ok {
+			diff.NewPackages = append(diff.NewPackages, key)
+			pkg = &PackageBaseline{}
+		} else if r.CoverageStats.CoveragePercentage < pkg.Coverage-tolerance {
+			diff.CoverageDrops = append(diff.CoverageDrops, CoverageDrop{
+				Package:  key,
+				Baseline: pkg.Coverage,
+				Current:  r.CoverageStats.CoveragePercentage,
+			})
+		}
+
+		// 같은 키의 이슈가 기준선보다 많아진 만큼만 새 이슈로 본다
+		remaining := make(map[string]int, len(pkg.Issues))
+		for k, n := range pkg.Issues {
+			remaining[k] = n
+		}
+		for _, issue := range r.QualityIssues {
+			k := issueKey(issue)
+			if remaining[k] > 0 {
+				remaining[k]--
+				continue
+			}
+			diff.NewIssues = append(diff.NewIssues, BaselineIssue{Package: key, Issue: issue})
+		}
+		for _, n := range remaining {
+			diff.FixedIssues += n
+		}
+	}
+
+	sort.Strings(diff.NewPackages)
+	sort.SliceStable(diff.NewIssues, func(i, j int) bool {
+		if diff.NewIssues[i].Package != diff.NewIssues[j].Package {
+			return diff.NewIssues[i].Package < diff.NewIssues[j].Package
+		}
+		return diff.NewIssues[i].Issue.Line < diff.NewIssues[j].Issue.Line
+	})
+	sort.Slice(diff.CoverageDrops, func(i, j int) bool { return diff.CoverageDrops[i].Package < diff.CoverageDrops[j].Package })
+	return diff
+}
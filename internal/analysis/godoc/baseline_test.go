@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package godoc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePackage(t *testing.T, dir, src string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lib.go"), []byte(src), 0o600))
+}
+
+func analyze(t *testing.T, root, pkg string) *PackageInfo {
+	t.Helper()
+	info, err := NewAnalyzer(root).AnalyzePackage(context.Background(), pkg)
+	require.NoError(t, err)
+	return info
+}
+
+func TestBaselineCompare(t *testing.T) {
+	root := t.TempDir()
+	writePackage(t, filepath.Join(root, "lib"), `// Package lib is a test package.
+package lib
+
+func Undocumented() {}
+
+// Documented does nothing.
+func Documented() {}
+`)
+	before := analyze(t, root, "./lib")
+	baseline := NewBaseline([]*PackageInfo{before})
+	path := filepath.Join(root, ".godoc-baseline.json")
+	require.NoError(t, baseline.Save(path))
+
+	loaded, err := LoadBaseline(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded.Packages["lib"].Issues["missing_function_doc:Undocumented"])
+
+	// 행 번호가 바뀌어도 기존 이슈는 새 이슈로 보지 않는다
+	writePackage(t, filepath.Join(root, "lib"), `// Package lib is a test package.
+package lib
+
+// Documented does nothing.
+func Documented() {}
+
+func Undocumented() {}
+`)
+	diff := loaded.Compare([]*PackageInfo{analyze(t, root, "lib")}, DefaultCoverageTolerance)
+	assert.False(t, diff.Regressed())
+	assert.Empty(t, diff.NewPackages, "./lib and lib are the same package")
+
+	writePackage(t, filepath.Join(root, "lib"), `// Package lib is a test package.
+package lib
+
+func Undocumented() {}
+
+func AlsoUndocumented() {}
+
+// Documented does nothing.
+func Documented() {}
+`)
+	diff = loaded.Compare([]*PackageInfo{analyze(t, root, "./lib")}, DefaultCoverageTolerance)
+	assert.True(t, diff.Regressed())
+	require.Len(t, diff.NewIssues, 1)
+	assert.Equal(t, "AlsoUndocumented", diff.NewIssues[0].Issue.Symbol)
+	require.Len(t, diff.CoverageDrops, 1)
+	assert.InDelta(t, 50.0, diff.CoverageDrops[0].Baseline, 0.01)
+
+	writePackage(t, filepath.Join(root, "lib"), `// Package lib is a test package.
+package lib
+
+// Undocumented is documented now.
+func Undocumented() {}
+`)
+	diff = loaded.Compare([]*PackageInfo{analyze(t, root, "./lib")}, DefaultCoverageTolerance)
+	assert.False(t, diff.Regressed())
+	assert.Equal(t, 1, diff.FixedIssues)
+}
+
+func TestBaselineNewPackageAndMerge(t *testing.T) {
+	root := t.TempDir()
+	writePackage(t, filepath.Join(root, "a"), "// Package a is documented.\npackage a\n\n// A is documented.\nfunc A() {}\n")
+	writePackage(t, filepath.Join(root, "b"), "package b\n\nfunc B() {}\n")
+
+	baseline := NewBaseline([]*PackageInfo{analyze(t, root, "./a")})
+	diff := baseline.Compare([]*PackageInfo{analyze(t, root, "./a"), analyze(t, root, "./b")}, DefaultCoverageTolerance)
+	assert.Equal(t, []string{"b"}, diff.NewPackages)
+	assert.Len(t, diff.NewIssues, 2, "issues of unknown packages are new")
+
+	merged := baseline.Merge([]*PackageInfo{analyze(t, root, "./b")})
+	assert.Contains(t, merged.Packages, "a")
+	assert.Contains(t, merged.Packages, "b")
+	assert.False(t, merged.Compare([]*PackageInfo{analyze(t, root, "./b")}, DefaultCoverageTolerance).Regressed())
+
+	_, err := LoadBaseline(filepath.Join(root, "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,280 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package mutation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Mutant outcomes.
+const (
+	// StatusKilled means the tests failed on the mutant.
+	StatusKilled = "killed"
+	// StatusSurvived means the tests passed on the mutant.
+	StatusSurvived = "survived"
+	// StatusTimeout means the tests hung on the mutant, e.g. an endless
+	// loop; it counts as killed.
+	StatusTimeout = "timeout"
+	// StatusInvalid means the mutant did not compile; it is not scored.
+	StatusInvalid = "invalid"
+)
+
+// Options configures Run.
+type Options struct {
+	// Mutators to apply (default: all).
+	Mutators []string
+	// MaxMutants per package; 0 tests every mutant. A larger set is
+	// sampled evenly across the package.
+	MaxMutants int
+	// Workers running mutants in parallel (default: half the CPUs).
+	Workers int
+	// Timeout per mutant test run (default: ten times the unmutated run,
+	// at least 10s).
+	Timeout time.Duration
+	// Runner runs the tests (default: GoRunner in the package's directory).
+	Runner Runner
+	// Progress is called after each mutant.
+	Progress func(pkg string, done, total int)
+}
+
+// Result is the outcome of one mutant.
+type Result struct {
+	Mutant
+	Status string `json:"status"`
+}
+
+// PackageReport is the mutation testing result of one package.
+type PackageReport struct {
+	ImportPath string `json:"importPath"`
+	// Coverage is the statement coverage in percent, or -1 when unknown.
+	Coverage float64 `json:"coverage"`
+	// Found is the number of mutants before sampling.
+	Found    int     `json:"found"`
+	Killed   int     `json:"killed"`
+	Survived int     `json:"survived"`
+	TimedOut int     `json:"timedOut"`
+	Invalid  int     `json:"invalid"`
+	Score    float64 `json:"score"`
+	// Skipped explains why the package was not mutated.
+	Skipped   string        `json:"skipped,omitempty"`
+	Survivors []Result      `json:"survivors,omitempty"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// Tested returns the number of scored mutants.
+func (p *PackageReport) Tested() int {
+	return p.Killed + p.Survived + p.TimedOut
+}
+
+func (p *PackageReport) add(r Result) {
+	switch r.Status {
+	case StatusKilled:
+		p.Killed++
+	case StatusTimeout:
+		p.TimedOut++
+	case StatusInvalid:
+		p.Invalid++
+	default:
+		p.Survived++
+		p.Survivors = append(p.Survivors, r)
+	}
+}
+
+func (p *PackageReport) score() {
+	if n := p.Tested(); n > 0 {
+		p.Score = float64(p.Killed+p.TimedOut) / float64(n) * 100
+	}
+}
+
+// Report is the result of Run.
+type Report struct {
+	Packages []*PackageReport `json:"packages"`
+	// Score is the mutation score over all packages.
+	Score float64 `json:"score"`
+}
+
+// Run mutation-tests the packages one after another.
+func Run(ctx context.Context, pkgs []Package, opts Options) (*Report, error) {
+	if err := ValidateMutators(opts.Mutators); err != nil {
+		return nil, err
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = max(1, runtime.NumCPU()/2)
+	}
+
+	tmp, err := os.MkdirTemp("", "gz-mutation-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	report := &Report{}
+	var killed, tested int
+	for _, pkg := range pkgs {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		pr, err := runPackage(ctx, pkg, opts, tmp)
+		if err != nil {
+			return report, err
+		}
+		report.Packages = append(report.Packages, pr)
+		killed += pr.Killed + pr.TimedOut
+		tested += pr.Tested()
+	}
+	if tested > 0 {
+		report.Score = float64(killed) / float64(tested) * 100
+	}
+	return report, nil
+}
+
+type fileMutant struct {
+	Mutant
+	src []byte
+}
+
+func runPackage(ctx context.Context, pkg Package, opts Options, tmp string) (*PackageReport, error) {
+	start := time.Now()
+	pr := &PackageReport{ImportPath: pkg.ImportPath, Coverage: -1}
+	defer func() { pr.Duration = time.Since(start) }()
+
+	runner := opts.Runner
+	if runner == nil {
+		runner = GoRunner{Dir: pkg.Dir}
+	}
+	if !pkg.HasTests {
+		pr.Skipped = "no test files"
+		return pr, nil
+	}
+
+	var mutants []fileMutant
+	for _, f := range pkg.GoFiles {
+		ms, src, err := FindFileMutants(f, opts.Mutators)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range ms {
+			mutants = append(mutants, fileMutant{Mutant: m, src: src})
+		}
+	}
+	pr.Found = len(mutants)
+	if len(mutants) == 0 {
+		pr.Skipped = "no mutants"
+		return pr, nil
+	}
+
+	baseline := runner.Test(ctx, pkg, "", true, 0)
+	pr.Coverage = baseline.Coverage
+	if !baseline.Passed {
+		pr.Skipped = "tests fail without mutations"
+		return pr, nil
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = max(10*time.Second, 10*baseline.Duration)
+	}
+
+	mutants = sample(mutants, opts.MaxMutants)
+	results := make([]Result, len(mutants))
+	jobs := make(chan int)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done int
+		werr error
+	)
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				status, err := testMutant(ctx, runner, pkg, mutants[i], filepath.Join(tmp, fmt.Sprintf("m%d-%d", w, i)), timeout)
+				mu.Lock()
+				if err != nil && werr == nil {
+					werr = err
+				}
+				results[i] = Result{Mutant: mutants[i].Mutant, Status: status}
+				done++
+				if opts.Progress != nil {
+					opts.Progress(pkg.ImportPath, done, len(mutants))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range mutants {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if werr != nil {
+		return nil, werr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, r := range results {
+		pr.add(r)
+	}
+	pr.score()
+	return pr, nil
+}
+
+// testMutant writes the mutated file and an overlay replacing the original
+// with it under dir, and runs the tests.
+func testMutant(ctx context.Context, runner Runner, pkg Package, m fileMutant, dir string, timeout time.Duration) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create work directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	mutated := filepath.Join(dir, filepath.Base(m.File))
+	if err := os.WriteFile(mutated, m.Apply(m.src), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write mutant: %w", err)
+	}
+	overlay, err := json.Marshal(map[string]map[string]string{"Replace": {m.File: mutated}})
+	if err != nil {
+		return "", err
+	}
+	overlayPath := filepath.Join(dir, "overlay.json")
+	if err := os.WriteFile(overlayPath, overlay, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write overlay: %w", err)
+	}
+
+	res := runner.Test(ctx, pkg, overlayPath, false, timeout)
+	switch {
+	case res.Passed:
+		return StatusSurvived, nil
+	case res.BuildFailed:
+		return StatusInvalid, nil
+	case res.TimedOut:
+		return StatusTimeout, nil
+	default:
+		return StatusKilled, nil
+	}
+}
+
+// sample returns at most n mutants spread evenly over the list, so every
+// part of the package is represented.
+func sample(mutants []fileMutant, n int) []fileMutant {
+	if n <= 0 || len(mutants) <= n {
+		return mutants
+	}
+	out := make([]fileMutant, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, mutants[i*len(mutants)/n])
+	}
+	return out
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package mutation
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clampSrc = `package clamp
+
+const limit = 10 + 1
+
+// Clamp limits v to [lo, hi].
+func Clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi && true {
+		return hi
+	}
+	return v
+}
+
+func label(n int) string {
+	return "n=" + string(rune('0'+n))
+}
+`
+
+func TestFindMutants(t *testing.T) {
+	mutants, err := FindMutants("clamp.go", []byte(clampSrc), nil)
+	require.NoError(t, err)
+
+	var got []string
+	for _, m := range mutants {
+		got = append(got, m.Mutator+" "+m.Original+" → "+m.Replacement)
+	}
+	assert.Equal(t, []string{
+		"branch if v < lo → if !(v < lo)",
+		"boundary < → <=",
+		"branch if v > hi && true → if !(v > hi && true)",
+		"boundary > → >=",
+		"logical && → ||",
+		"boolean true → false",
+		"arithmetic + → -",
+	}, got, "constants and string concatenation are not mutated")
+
+	mutated := string(mutants[0].Apply([]byte(clampSrc)))
+	assert.Contains(t, mutated, "if !(v < lo) {")
+
+	boundary, err := FindMutants("clamp.go", []byte(clampSrc), []string{MutatorBoundary})
+	require.NoError(t, err)
+	assert.Len(t, boundary, 2)
+
+	generated, err := FindMutants("gen.go", []byte("// Code generated by x. DO NOT EDIT.\n\n"+clampSrc), nil)
+	require.NoError(t, err)
+	assert.Empty(t, generated)
+
+	assert.ErrorContains(t, ValidateMutators([]string{"boundary", "nope"}), `unknown mutator "nope"`)
+}
+
+// fakeRunner kills mutants whose source contains one of kill, fails to
+// build those containing invalid and lets the rest survive.
+type fakeRunner struct {
+	kill    []string
+	invalid string
+}
+
+func (f fakeRunner) Test(_ context.Context, _ Package, overlay string, _ bool, _ time.Duration) TestResult {
+	if overlay == "" {
+		return TestResult{Passed: true, Coverage: 75}
+	}
+	var o struct{ Replace map[string]string }
+	data, _ := os.ReadFile(overlay)
+	_ = json.Unmarshal(data, &o)
+	for _, mutated := range o.Replace {
+		src, _ := os.ReadFile(mutated)
+		if f.invalid != "" && strings.Contains(string(src), f.invalid) {
+			return TestResult{BuildFailed: true}
+		}
+		for _, k := range f.kill {
+			if strings.Contains(string(src), k) {
+				return TestResult{}
+			}
+		}
+	}
+	return TestResult{Passed: true}
+}
+
+func TestRun_ScoresPackage(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "clamp.go")
+	require.NoError(t, os.WriteFile(file, []byte(clampSrc), 0o600))
+	pkg := Package{ImportPath: "example.com/clamp", Dir: dir, GoFiles: []string{file}, HasTests: true}
+
+	report, err := Run(context.Background(), []Package{pkg, {ImportPath: "example.com/empty", HasTests: false}}, Options{
+		Workers: 2,
+		Runner:  fakeRunner{kill: []string{"v <= lo", "!(v < lo)", "'0'-n"}, invalid: "||"},
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Packages, 2)
+
+	p := report.Packages[0]
+	assert.Equal(t, 75.0, p.Coverage)
+	assert.Equal(t, 7, p.Found)
+	assert.Equal(t, 3, p.Killed)
+	assert.Equal(t, 1, p.Invalid)
+	assert.Equal(t, 3, p.Survived)
+	assert.InDelta(t, 50.0, p.Score, 0.01)
+	require.Len(t, p.Survivors, 3)
+	var survivors []string
+	for _, r := range p.Survivors {
+		assert.Equal(t, StatusSurvived, r.Status)
+		assert.Equal(t, 10, r.Line)
+		survivors = append(survivors, r.Mutator)
+	}
+	assert.Equal(t, []string{MutatorBranch, MutatorBoundary, MutatorBoolean}, survivors)
+
+	assert.Equal(t, "no test files", report.Packages[1].Skipped)
+	assert.InDelta(t, 50.0, report.Score, 0.01)
+
+	sampled, err := Run(context.Background(), []Package{pkg}, Options{MaxMutants: 3, Runner: fakeRunner{}})
+	require.NoError(t, err)
+	assert.Equal(t, 3, sampled.Packages[0].Tested())
+	assert.Equal(t, 7, sampled.Packages[0].Found)
+}
+
+func TestTrendStore(t *testing.T) {
+	store := NewTrendStore(filepath.Join(t.TempDir(), "quality", "trends.json"))
+	history, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	report := &Report{Packages: []*PackageReport{
+		{ImportPath: "a", Killed: 3, Survived: 1, Score: 75, Coverage: 80},
+		{ImportPath: "b", Skipped: "no test files"},
+	}}
+	now := time.Date(2025, 10, 16, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < maxTrendPoints+2; i++ {
+		require.NoError(t, store.Record(report, "abc123", now.Add(time.Duration(i)*time.Hour)))
+	}
+
+	history, err = store.Load()
+	require.NoError(t, err)
+	assert.Len(t, history["a"], maxTrendPoints)
+	assert.NotContains(t, history, "b")
+
+	prev, ok := Previous(history, "a")
+	require.True(t, ok)
+	assert.Equal(t, TrendPoint{Time: now.Add(time.Duration(maxTrendPoints+1) * time.Hour), Revision: "abc123", Score: 75, Coverage: 80, Tested: 4, Survived: 1}, prev)
+}
+
+// TestRun_GoTest mutation-tests a small module with the real go command.
+func TestRun_GoTest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test for every mutant")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not available")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/clamp\n\ngo 1.21\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "clamp.go"), []byte(`package clamp
+
+func Clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+`), 0o600))
+	// 경계값을 검사하지 않는 테스트: 경계 변이는 살아남는다
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "clamp_test.go"), []byte(`package clamp
+
+import "testing"
+
+func TestClamp(t *testing.T) {
+	if Clamp(-5, 0, 10) != 0 || Clamp(50, 0, 10) != 10 || Clamp(5, 0, 10) != 5 {
+		t.Fatal("wrong")
+	}
+}
+`), 0o600))
+	t.Setenv("GOFLAGS", "")
+	t.Setenv("GOWORK", "off")
+
+	ctx := context.Background()
+	pkgs, err := ListPackages(ctx, dir, nil)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 1)
+	assert.True(t, pkgs[0].HasTests)
+
+	report, err := Run(ctx, pkgs, Options{Runner: GoRunner{Dir: dir}, Timeout: time.Minute})
+	require.NoError(t, err)
+	p := report.Packages[0]
+	assert.Equal(t, 100.0, p.Coverage)
+	assert.Equal(t, 4, p.Found)
+	assert.Equal(t, 2, p.Killed, "negated branches are caught")
+	assert.Equal(t, 2, p.Survived, "boundary mutants are not")
+	assert.Equal(t, MutatorBoundary, p.Survivors[0].Mutator)
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package mutation implements mutation testing for Go packages.
+//
+// Mutants are small changes to the source, such as `<` becoming `<=` or an
+// if condition being negated, that a meaningful test suite should notice.
+// Each mutant is compiled through a `go test -overlay` file, so the working
+// tree is never modified. A mutant is killed when the tests fail and
+// survives when they pass; the mutation score is the share of mutants
+// killed. Surviving mutants point at code whose behavior no test asserts.
+package mutation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Mutator names.
+const (
+	// MutatorArithmetic swaps + and -, * and /, and their assignment and
+	// increment forms.
+	MutatorArithmetic = "arithmetic"
+	// MutatorBoundary moves comparison boundaries: < and <=, > and >=.
+	MutatorBoundary = "boundary"
+	// MutatorNegation swaps == and !=.
+	MutatorNegation = "negation"
+	// MutatorLogical swaps && and ||.
+	MutatorLogical = "logical"
+	// MutatorBoolean swaps the literals true and false.
+	MutatorBoolean = "boolean"
+	// MutatorBranch negates if conditions.
+	MutatorBranch = "branch"
+)
+
+// Mutators lists every mutator.
+var Mutators = []string{
+	MutatorArithmetic, MutatorBoundary, MutatorNegation,
+	MutatorLogical, MutatorBoolean, MutatorBranch,
+}
+
+var (
+	arithmeticSwaps = map[token.Token]token.Token{
+		token.ADD: token.SUB, token.SUB: token.ADD,
+		token.MUL: token.QUO, token.QUO: token.MUL, token.REM: token.MUL,
+		token.ADD_ASSIGN: token.SUB_ASSIGN, token.SUB_ASSIGN: token.ADD_ASSIGN,
+		token.MUL_ASSIGN: token.QUO_ASSIGN, token.QUO_ASSIGN: token.MUL_ASSIGN,
+		token.INC: token.DEC, token.DEC: token.INC,
+	}
+	boundarySwaps = map[token.Token]token.Token{
+		token.LSS: token.LEQ, token.LEQ: token.LSS,
+		token.GTR: token.GEQ, token.GEQ: token.GTR,
+	}
+	negationSwaps = map[token.Token]token.Token{token.EQL: token.NEQ, token.NEQ: token.EQL}
+	logicalSwaps  = map[token.Token]token.Token{token.LAND: token.LOR, token.LOR: token.LAND}
+)
+
+// ValidateMutators checks mutator names.
+func ValidateMutators(names []string) error {
+	for _, n := range names {
+		found := false
+		for _, m := range Mutators {
+			if n == m {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown mutator %q (valid: %s)", n, strings.Join(Mutators, ", "))
+		}
+	}
+	return nil
+}
+
+// edit replaces src[Offset:End] with Text.
+type edit struct {
+	Offset int
+	End    int
+	Text   string
+}
+
+// Mutant is one mutation of a source file.
+type Mutant struct {
+	Mutator     string `json:"mutator"`
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Column      int    `json:"column"`
+	Original    string `json:"original"`
+	Replacement string `json:"replacement"`
+
+	edits []edit
+}
+
+// String returns "file:line:col mutator original → replacement".
+func (m Mutant) String() string {
+	return fmt.Sprintf("%s:%d:%d %s %s → %s", m.File, m.Line, m.Column, m.Mutator, m.Original, m.Replacement)
+}
+
+// Apply returns src with the mutation applied.
+func (m Mutant) Apply(src []byte) []byte {
+	edits := append([]edit(nil), m.edits...)
+	// 뒤에서부터 적용해 앞쪽 오프셋이 바뀌지 않게 한다
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Offset > edits[j].Offset })
+	out := append([]byte(nil), src...)
+	for _, e := range edits {
+		out = append(out[:e.Offset], append([]byte(e.Text), out[e.End:]...)...)
+	}
+	return out
+}
+
+// FindMutants returns the mutants of one source file for the enabled
+// mutators, in source order. Generated files have none.
+func FindMutants(path string, src []byte, enabled []string) ([]Mutant, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if ast.IsGenerated(file) {
+		return nil, nil
+	}
+
+	on := make(map[string]bool, len(Mutators))
+	if len(enabled) == 0 {
+		enabled = Mutators
+	}
+	for _, m := range enabled {
+		on[m] = true
+	}
+
+	var out []Mutant
+	add := func(mutator string, pos token.Pos, original, replacement string, edits ...edit) {
+		p := fset.Position(pos)
+		out = append(out, Mutant{
+			Mutator:     mutator,
+			File:        path,
+			Line:        p.Line,
+			Column:      p.Column,
+			Original:    original,
+			Replacement: replacement,
+			edits:       edits,
+		})
+	}
+	swapOp := func(pos token.Pos, op token.Token) {
+		for _, s := range []struct {
+			name  string
+			swaps map[token.Token]token.Token
+		}{
+			{MutatorArithmetic, arithmeticSwaps},
+			{MutatorBoundary, boundarySwaps},
+			{MutatorNegation, negationSwaps},
+			{MutatorLogical, logicalSwaps},
+		} {
+			if to, ok := s.swaps[op]; ok && on[s.name] {
+				off := fset.Position(pos).Offset
+				add(s.name, pos, op.String(), to.String(), edit{Offset: off, End: off + len(op.String()), Text: to.String()})
+			}
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GenDecl:
+			// 상수 식은 컴파일 타임에 평가되어 변이가 대부분 무의미하다
+			return n.Tok != token.CONST && n.Tok != token.IMPORT
+		case *ast.BinaryExpr:
+			if n.Op == token.ADD && (isString(n.X) || isString(n.Y)) {
+				return true
+			}
+			swapOp(n.OpPos, n.Op)
+		case *ast.AssignStmt:
+			swapOp(n.TokPos, n.Tok)
+		case *ast.IncDecStmt:
+			swapOp(n.TokPos, n.Tok)
+		case *ast.Ident:
+			if on[MutatorBoolean] && (n.Name == "true" || n.Name == "false") && n.Obj == nil {
+				to := "false"
+				if n.Name == "false" {
+					to = "true"
+				}
+				off := fset.Position(n.Pos()).Offset
+				add(MutatorBoolean, n.Pos(), n.Name, to, edit{Offset: off, End: off + len(n.Name), Text: to})
+			}
+		case *ast.IfStmt:
+			if on[MutatorBranch] {
+				start, end := fset.Position(n.Cond.Pos()).Offset, fset.Position(n.Cond.End()).Offset
+				cond := string(src[start:end])
+				add(MutatorBranch, n.Cond.Pos(), "if "+shorten(cond), "if !("+shorten(cond)+")",
+					edit{Offset: start, End: start, Text: "!("}, edit{Offset: end, End: end, Text: ")"})
+			}
+		}
+		return true
+	})
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Line != out[j].Line {
+			return out[i].Line < out[j].Line
+		}
+		return out[i].Column < out[j].Column
+	})
+	return out, nil
+}
+
+// FindFileMutants reads and mutates a file.
+func FindFileMutants(path string, enabled []string) ([]Mutant, []byte, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	mutants, err := FindMutants(path, src, enabled)
+	return mutants, src, err
+}
+
+func isString(e ast.Expr) bool {
+	lit, ok := e.(*ast.BasicLit)
+	return ok && lit.Kind == token.STRING
+}
+
+func shorten(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) > 40 {
+		return s[:37] + "..."
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package mutation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Package is a Go package to mutate.
+type Package struct {
+	ImportPath string
+	Dir        string
+	// GoFiles are the non-test source files, as absolute paths.
+	GoFiles []string
+	// HasTests reports whether the package has test files.
+	HasTests bool
+}
+
+// TestResult is the outcome of running the tests of a package.
+type TestResult struct {
+	Passed      bool
+	BuildFailed bool
+	TimedOut    bool
+	// Coverage is the statement coverage in percent, or -1 when unknown.
+	Coverage float64
+	Duration time.Duration
+	Output   string
+}
+
+// Runner runs the tests of a package.
+type Runner interface {
+	// Test runs the tests of pkg. overlay is a `go build -overlay` file
+	// replacing sources with mutants, or "" for the unmodified package.
+	// timeout bounds the test binary; zero means no limit.
+	Test(ctx context.Context, pkg Package, overlay string, cover bool, timeout time.Duration) TestResult
+}
+
+// GoRunner runs `go test` in Dir.
+type GoRunner struct {
+	Dir string
+	// Go is the go command (default "go").
+	Go string
+}
+
+var coverageRe = regexp.MustCompile(`coverage: ([0-9.]+)% of statements`)
+
+// Test implements Runner.
+func (r GoRunner) Test(ctx context.Context, pkg Package, overlay string, cover bool, timeout time.Duration) TestResult {
+	goCmd := r.Go
+	if goCmd == "" {
+		goCmd = "go"
+	}
+	args := []string{"test", "-count=1", "-failfast"}
+	if cover {
+		args = append(args, "-cover")
+	}
+	if overlay != "" {
+		args = append(args, "-overlay", overlay)
+	}
+	if timeout > 0 {
+		args = append(args, "-timeout", timeout.String())
+	}
+	args = append(args, pkg.ImportPath)
+
+	cmd := exec.CommandContext(ctx, goCmd, args...)
+	cmd.Dir = r.Dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	start := time.Now()
+	err := cmd.Run()
+	res := TestResult{Passed: err == nil, Duration: time.Since(start), Output: out.String(), Coverage: -1}
+	if m := coverageRe.FindStringSubmatch(res.Output); m != nil {
+		res.Coverage, _ = strconv.ParseFloat(m[1], 64)
+	}
+	if err != nil {
+		res.BuildFailed = strings.Contains(res.Output, "[build failed]") || strings.Contains(res.Output, "[setup failed]")
+		res.TimedOut = strings.Contains(res.Output, "panic: test timed out")
+	}
+	return res
+}
+
+// ListPackages resolves package patterns in dir with `go list`.
+func ListPackages(ctx context.Context, dir string, patterns []string) ([]Package, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	cmd := exec.CommandContext(ctx, "go", append([]string{"list", "-json"}, patterns...)...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packages: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var pkgs []Package
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var p struct {
+			ImportPath   string
+			Dir          string
+			GoFiles      []string
+			TestGoFiles  []string
+			XTestGoFiles []string
+		}
+		if err := dec.Decode(&p); err != nil {
+			return nil, fmt.Errorf("failed to parse go list output: %w", err)
+		}
+		pkg := Package{
+			ImportPath: p.ImportPath,
+			Dir:        p.Dir,
+			HasTests:   len(p.TestGoFiles)+len(p.XTestGoFiles) > 0,
+		}
+		for _, f := range p.GoFiles {
+			pkg.GoFiles = append(pkg.GoFiles, filepath.Join(p.Dir, f))
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	if len(pkgs) == 0 {
+		return nil, errors.New("no packages match")
+	}
+	return pkgs, nil
+}
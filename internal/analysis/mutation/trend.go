@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package mutation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/filesystem"
+)
+
+// maxTrendPoints is the number of runs kept per package.
+const maxTrendPoints = 50
+
+// TrendPoint is the result of one run for a package.
+type TrendPoint struct {
+	Time     time.Time `json:"time"`
+	Revision string    `json:"revision,omitempty"`
+	Score    float64   `json:"score"`
+	Coverage float64   `json:"coverage"`
+	Tested   int       `json:"tested"`
+	Survived int       `json:"survived"`
+}
+
+// TrendStore keeps per-package mutation score history in a JSON file.
+type TrendStore struct {
+	path string
+}
+
+// DefaultTrendPath returns the file holding mutation score history.
+func DefaultTrendPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "gzh-manager", "quality", "mutation-trends.json")
+}
+
+// NewTrendStore creates a store backed by path.
+func NewTrendStore(path string) *TrendStore {
+	return &TrendStore{path: path}
+}
+
+// Load returns the history by import path, oldest point first.
+func (s *TrendStore) Load() (map[string][]TrendPoint, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string][]TrendPoint{}, nil
+		}
+		return nil, fmt.Errorf("failed to read mutation trends: %w", err)
+	}
+	history := map[string][]TrendPoint{}
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+	return history, nil
+}
+
+// Record appends the scored packages of report to the history.
+func (s *TrendStore) Record(report *Report, revision string, now time.Time) error {
+	history, err := s.Load()
+	if err != nil {
+		return err
+	}
+	for _, p := range report.Packages {
+		if p.Tested() == 0 {
+			continue
+		}
+		points := append(history[p.ImportPath], TrendPoint{
+			Time:     now.UTC(),
+			Revision: revision,
+			Score:    p.Score,
+			Coverage: p.Coverage,
+			Tested:   p.Tested(),
+			Survived: p.Survived,
+		})
+		if len(points) > maxTrendPoints {
+			points = points[len(points)-maxTrendPoints:]
+		}
+		history[p.ImportPath] = points
+	}
+
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal mutation trends: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create trend directory: %w", err)
+	}
+	return filesystem.WriteFileAtomic(filesystem.OS(), s.path, data, 0o600)
+}
+
+// Previous returns the last recorded point of a package, if any.
+func Previous(history map[string][]TrendPoint, importPath string) (TrendPoint, bool) {
+	points := history[importPath]
+	if len(points) == 0 {
+		return TrendPoint{}, false
+	}
+	return points[len(points)-1], true
+}
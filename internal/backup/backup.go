@@ -0,0 +1,565 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package backup snapshots gz state and configuration into an encrypted
+// archive and restores it, possibly on another machine.
+//
+// An archive covers a set of roots, by default ~/.gzh (state databases,
+// journal, jobs) and ~/.config/gzh-manager (configuration and the plugin
+// registry). Rules choose per subtree whether files are stored, only listed
+// in the manifest (caches and plugin binaries, which can be rebuilt) or
+// skipped. The tar payload is compressed (zstd unless configured otherwise)
+// and sealed in chunks with AES-256-GCM under a key derived from a
+// passphrase with scrypt, so archives are created and restored as streams.
+package backup
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+)
+
+// FormatVersion is the manifest format written by Create.
+const FormatVersion = 1
+
+// Extension is the file extension of backup archives.
+const Extension = ".gzbk"
+
+const manifestName = "manifest.json"
+
+// Root names.
+const (
+	RootState  = "state"
+	RootConfig = "config"
+)
+
+// Mode selects how files under a rule's prefix are backed up.
+type Mode string
+
+const (
+	// ModeFull stores the file contents.
+	ModeFull Mode = "full"
+	// ModeMetadata lists files in the manifest without their contents.
+	ModeMetadata Mode = "metadata"
+	// ModeSkip leaves files out entirely.
+	ModeSkip Mode = "skip"
+)
+
+// Rule applies a mode to the files under a slash-separated prefix of a
+// root. The longest matching prefix wins.
+type Rule struct {
+	Prefix string
+	Mode   Mode
+}
+
+// Root is a directory tree included in a backup.
+type Root struct {
+	Name  string
+	Path  string
+	Rules []Rule
+}
+
+// mode returns the mode of rel, a slash-separated path inside the root.
+func (r Root) mode(rel string) Mode {
+	best, mode := -1, ModeFull
+	for _, rule := range r.Rules {
+		p := strings.Trim(rule.Prefix, "/")
+		if (rel == p || strings.HasPrefix(rel, p+"/")) && len(p) > best {
+			best, mode = len(p), rule.Mode
+		}
+	}
+	return mode
+}
+
+// DefaultRoots returns the state and configuration roots of the current
+// user. $GZH_CONFIG_DIR overrides the configuration directory.
+func DefaultRoots() ([]Root, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	configDir := os.Getenv("GZH_CONFIG_DIR")
+	if configDir == "" {
+		configDir = filepath.Join(home, ".config", "gzh-manager")
+	}
+
+	return []Root{
+		{
+			Name: RootState,
+			Path: filepath.Join(home, ".gzh"),
+			Rules: []Rule{
+				{Prefix: "backups", Mode: ModeSkip},
+				{Prefix: "tmp", Mode: ModeSkip},
+				// 캐시는 다시 채울 수 있으므로 목록만 남긴다
+				{Prefix: "cache", Mode: ModeMetadata},
+			},
+		},
+		{
+			Name: RootConfig,
+			Path: configDir,
+			Rules: []Rule{
+				// 플러그인 바이너리는 플랫폼마다 다르므로 installed.json으로 다시 설치한다
+				{Prefix: "plugins", Mode: ModeMetadata},
+				{Prefix: "plugins/installed.json", Mode: ModeFull},
+				{Prefix: "plugins/data", Mode: ModeFull},
+			},
+		},
+	}, nil
+}
+
+// DefaultDir returns the directory local backups are written to,
+// ~/.gzh/backups. It is excluded from the state root.
+func DefaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".gzh", "backups"), nil
+}
+
+// DefaultName returns the file name of a backup of host taken at t.
+func DefaultName(host string, t time.Time) string {
+	if host == "" {
+		host = "host"
+	}
+	return "gz-backup-" + host + "-" + t.UTC().Format("20060102-150405") + Extension
+}
+
+// Manifest describes the contents of an archive.
+type Manifest struct {
+	Version   int          `json:"version"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Hostname  string       `json:"hostname,omitempty"`
+	OS        string       `json:"os"`
+	GZVersion string       `json:"gzVersion,omitempty"`
+	Home      string       `json:"home,omitempty"`
+	Roots     []RootInfo   `json:"roots"`
+	Files     []FileEntry  `json:"files"`
+	Skipped   []SkipReason `json:"skipped,omitempty"`
+}
+
+// RootInfo records where a root was on the source machine.
+type RootInfo struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// FileEntry is a file of a root. Files listed for their metadata only have
+// Stored false and no checksum.
+type FileEntry struct {
+	Root    string      `json:"root"`
+	Path    string      `json:"path"`
+	Size    int64       `json:"size"`
+	Mode    fs.FileMode `json:"mode"`
+	ModTime time.Time   `json:"modTime"`
+	Stored  bool        `json:"stored"`
+	SHA256  string      `json:"sha256,omitempty"`
+}
+
+// SkipReason records a file that could not be backed up.
+type SkipReason struct {
+	Root   string `json:"root"`
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// Root returns the recorded root named name.
+func (m *Manifest) Root(name string) (RootInfo, bool) {
+	for _, r := range m.Roots {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return RootInfo{}, false
+}
+
+// CreateOptions configures Create.
+type CreateOptions struct {
+	Roots      []Root
+	Passphrase string
+	// GZVersion is recorded in the manifest.
+	GZVersion string
+	// Now overrides the creation time (for tests).
+	Now func() time.Time
+	// Codec compresses the payload; defaults to the configured backup codec.
+	Codec compress.Codec
+}
+
+// Create writes an encrypted archive of the roots to w and returns its
+// manifest. Missing roots are recorded without files.
+func Create(w io.Writer, opts CreateOptions) (*Manifest, error) {
+	if opts.Passphrase == "" {
+		return nil, ErrNoPassphrase
+	}
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+
+	m := &Manifest{
+		Version:   FormatVersion,
+		CreatedAt: now().UTC(),
+		OS:        runtime.GOOS,
+		GZVersion: opts.GZVersion,
+	}
+	m.Hostname, _ = os.Hostname()
+	m.Home, _ = os.UserHomeDir()
+
+	codec := opts.Codec
+	if codec == nil {
+		codec = compress.ForUse(compress.UseBackup)
+	}
+
+	sw, err := newSealWriter(w, opts.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	cw, err := codec.NewWriter(sw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start archive: %w", err)
+	}
+	tw := tar.NewWriter(cw)
+
+	for _, root := range opts.Roots {
+		m.Roots = append(m.Roots, RootInfo{Name: root.Name, Path: root.Path})
+		if err := addRoot(tw, m, root); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	// 매니페스트는 파일 목록이 확정된 뒤 마지막에 기록한다
+	if err := writeTarFile(tw, manifestName, 0o600, m.CreatedAt, data); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := cw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := sw.Close(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func addRoot(tw *tar.Writer, m *Manifest, root Root) error {
+	if _, err := os.Stat(root.Path); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return filepath.WalkDir(root.Path, func(p string, d fs.DirEntry, walkErr error) error {
+		rel, err := filepath.Rel(root.Path, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if walkErr != nil {
+			m.Skipped = append(m.Skipped, SkipReason{Root: root.Name, Path: rel, Reason: walkErr.Error()})
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if rel == "." {
+			return nil
+		}
+
+		mode := root.mode(rel)
+		if d.IsDir() {
+			if mode == ModeSkip {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if mode == ModeSkip {
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			m.Skipped = append(m.Skipped, SkipReason{Root: root.Name, Path: rel, Reason: "not a regular file"})
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			m.Skipped = append(m.Skipped, SkipReason{Root: root.Name, Path: rel, Reason: err.Error()})
+			return nil
+		}
+		entry := FileEntry{
+			Root:    root.Name,
+			Path:    rel,
+			Size:    info.Size(),
+			Mode:    info.Mode().Perm(),
+			ModTime: info.ModTime().UTC(),
+		}
+		if mode == ModeMetadata {
+			m.Files = append(m.Files, entry)
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			m.Skipped = append(m.Skipped, SkipReason{Root: root.Name, Path: rel, Reason: err.Error()})
+			return nil
+		}
+		defer f.Close()
+		sum, err := copyTarFile(tw, entryName(root.Name, rel), entry, f)
+		if err != nil {
+			return fmt.Errorf("failed to back up %s/%s: %w", root.Name, rel, err)
+		}
+		entry.Stored = true
+		entry.SHA256 = sum
+		m.Files = append(m.Files, entry)
+		return nil
+	})
+}
+
+// copyTarFile adds the entry.Size bytes of r to the archive and returns
+// their SHA-256 checksum. Bytes appended while copying are left out.
+func copyTarFile(tw *tar.Writer, name string, entry FileEntry, r io.Reader) (string, error) {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    int64(entry.Mode.Perm()),
+		Size:    entry.Size,
+		ModTime: entry.ModTime,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	n, err := io.Copy(tw, io.TeeReader(io.LimitReader(r, entry.Size), h))
+	if err != nil {
+		return "", err
+	}
+	if n < entry.Size {
+		return "", errors.New("file shrank while it was backed up")
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func entryName(root, rel string) string {
+	return path.Join("files", root, rel)
+}
+
+func writeTarFile(tw *tar.Writer, name string, mode fs.FileMode, modTime time.Time, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    int64(mode.Perm()),
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	return nil
+}
+
+// Archive is an opened backup. File contents are not held in memory;
+// Files decrypts the archive again and streams them.
+type Archive struct {
+	Manifest   *Manifest
+	src        io.ReadSeeker
+	start      int64
+	passphrase string
+	// closer is set when the archive owns src.
+	closer io.Closer
+}
+
+// Open decrypts and reads an archive from r, which must stay readable
+// until the archive is no longer used. It returns ErrWrongPassphrase when
+// the passphrase does not match and verifies the stored checksums.
+func Open(r io.ReadSeeker, passphrase string) (*Archive, error) {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	a := &Archive{src: r, start: start, passphrase: passphrase}
+
+	// 첫 번째 패스는 체크섬만 모은다; 매니페스트는 아카이브 끝에 있다
+	sums := make(map[string]string)
+	err = a.walk(func(hdr *tar.Header, r io.Reader) error {
+		if hdr.Name == manifestName {
+			var m Manifest
+			if err := json.NewDecoder(r).Decode(&m); err != nil {
+				return fmt.Errorf("invalid backup manifest: %w", err)
+			}
+			a.Manifest = &m
+			return nil
+		}
+		h := sha256.New()
+		if _, err := io.Copy(h, r); err != nil {
+			return fmt.Errorf("failed to read %s from archive: %w", hdr.Name, err)
+		}
+		sums[hdr.Name] = hex.EncodeToString(h.Sum(nil))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if a.Manifest == nil {
+		return nil, errors.New("invalid backup: manifest missing")
+	}
+	if a.Manifest.Version > FormatVersion {
+		return nil, fmt.Errorf("backup format %d is newer than supported (%d); upgrade gz", a.Manifest.Version, FormatVersion)
+	}
+	for _, f := range a.Manifest.Files {
+		if !f.Stored {
+			continue
+		}
+		sum, ok := sums[entryName(f.Root, f.Path)]
+		if !ok {
+			return nil, fmt.Errorf("invalid backup: %s/%s missing", f.Root, f.Path)
+		}
+		if sum != f.SHA256 {
+			return nil, fmt.Errorf("invalid backup: checksum mismatch for %s/%s", f.Root, f.Path)
+		}
+	}
+	return a, nil
+}
+
+// OpenFile opens the archive at path. The archive must be closed.
+func OpenFile(path, passphrase string) (*Archive, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	a, err := Open(f, passphrase)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	a.closer = f
+	return a, nil
+}
+
+// Close closes the file opened by OpenFile.
+func (a *Archive) Close() error {
+	if a.closer == nil {
+		return nil
+	}
+	return a.closer.Close()
+}
+
+// Files calls fn for each stored file in archive order, with a reader of
+// its contents. The reader fails instead of returning io.EOF when the
+// contents do not match the manifest checksum. Contents fn does not read
+// are skipped.
+func (a *Archive) Files(fn func(f FileEntry, r io.Reader) error) error {
+	stored := make(map[string]FileEntry, len(a.Manifest.Files))
+	for _, f := range a.Manifest.Files {
+		if f.Stored {
+			stored[entryName(f.Root, f.Path)] = f
+		}
+	}
+	return a.walk(func(hdr *tar.Header, r io.Reader) error {
+		f, ok := stored[hdr.Name]
+		if !ok {
+			return nil
+		}
+		return fn(f, &verifyingReader{r: r, h: sha256.New(), want: f.SHA256, name: f.Root + "/" + f.Path})
+	})
+}
+
+// walk decrypts the archive from the start and calls fn for each entry.
+func (a *Archive) walk(fn func(hdr *tar.Header, r io.Reader) error) error {
+	if _, err := a.src.Seek(a.start, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	payload, err := newOpenReader(a.src, a.passphrase)
+	if err != nil {
+		return err
+	}
+
+	// 압축 형식은 헤더로 판별하므로 이전의 gzip 백업도 그대로 열린다
+	cr, _, err := compress.NewDetectingReader(payload)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer cr.Close()
+	tr := tar.NewReader(cr)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if err := fn(hdr, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// verifyingReader checks the SHA-256 checksum of r when it is read to the
+// end.
+type verifyingReader struct {
+	r    io.Reader
+	h    hash.Hash
+	want string
+	name string
+}
+
+func (v *verifyingReader) Read(p []byte) (int, error) {
+	n, err := v.r.Read(p)
+	v.h.Write(p[:n])
+	if errors.Is(err, io.EOF) && hex.EncodeToString(v.h.Sum(nil)) != v.want {
+		return n, fmt.Errorf("invalid backup: checksum mismatch for %s", v.name)
+	}
+	return n, err
+}
+
+// Summary groups the manifest files by root: stored files, their size and
+// files listed for metadata only.
+type Summary struct {
+	Root     string
+	Stored   int
+	Size     int64
+	Metadata int
+}
+
+// Summarize returns one Summary per root, in manifest order.
+func (m *Manifest) Summarize() []Summary {
+	index := make(map[string]int, len(m.Roots))
+	out := make([]Summary, 0, len(m.Roots))
+	for _, r := range m.Roots {
+		index[r.Name] = len(out)
+		out = append(out, Summary{Root: r.Name})
+	}
+	for _, f := range m.Files {
+		i, ok := index[f.Root]
+		if !ok {
+			continue
+		}
+		if f.Stored {
+			out[i].Stored++
+			out[i].Size += f.Size
+		} else {
+			out[i].Metadata++
+		}
+	}
+	return out
+}
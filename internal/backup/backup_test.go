@@ -0,0 +1,322 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/cloud"
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+// sourceHome lays out a home directory with gz state and configuration.
+func sourceHome(t *testing.T) (string, []Root) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GZH_CONFIG_DIR", "")
+
+	state := filepath.Join(home, ".gzh")
+	config := filepath.Join(home, ".config", "gzh-manager")
+	writeFile(t, filepath.Join(state, "history.db"), `{"command":"gz synclone"}`+"\n")
+	writeFile(t, filepath.Join(state, "cache", "http", "ab", "abcd.json"), `{"body":"cached"}`)
+	writeFile(t, filepath.Join(state, "backups", "old"+Extension), "old backup")
+	writeFile(t, filepath.Join(config, "synclone.yaml"), "target: "+filepath.Join(home, "src")+"\n")
+	writeFile(t, filepath.Join(config, "plugins", "installed.json"), `{"path":"`+filepath.Join(config, "plugins", "lint", "1.0.0", "lint")+`"}`)
+	writeFile(t, filepath.Join(config, "plugins", "lint", "1.0.0", "lint"), "\x7fELF binary")
+	writeFile(t, filepath.Join(config, "plugins", "data", "lint", "state.json"), `{}`)
+
+	roots, err := DefaultRoots()
+	require.NoError(t, err)
+	return home, roots
+}
+
+func createArchive(t *testing.T, roots []Root, passphrase string) ([]byte, *Manifest) {
+	t.Helper()
+	var buf bytes.Buffer
+	m, err := Create(&buf, CreateOptions{Roots: roots, Passphrase: passphrase, GZVersion: "1.2.3"})
+	require.NoError(t, err)
+	return buf.Bytes(), m
+}
+
+// storedFile returns the contents of a stored file of a.
+func storedFile(t *testing.T, a *Archive, root, rel string) string {
+	t.Helper()
+	var data []byte
+	found := false
+	require.NoError(t, a.Files(func(f FileEntry, r io.Reader) error {
+		if f.Root != root || f.Path != rel {
+			return nil
+		}
+		var err error
+		data, err = io.ReadAll(r)
+		found = true
+		return err
+	}))
+	require.True(t, found, "%s/%s not stored", root, rel)
+	return string(data)
+}
+
+func TestCreateAndOpen(t *testing.T) {
+	_, roots := sourceHome(t)
+	data, m := createArchive(t, roots, "correct horse")
+
+	assert.True(t, IsBackup(data))
+	assert.NotContains(t, string(data), "gz synclone", "archive must be encrypted")
+
+	stored := map[string]bool{}
+	for _, f := range m.Files {
+		stored[f.Root+"/"+f.Path] = f.Stored
+	}
+	assert.Equal(t, map[string]bool{
+		"state/history.db":                    true,
+		"state/cache/http/ab/abcd.json":       false,
+		"config/synclone.yaml":                true,
+		"config/plugins/installed.json":       true,
+		"config/plugins/lint/1.0.0/lint":      false,
+		"config/plugins/data/lint/state.json": true,
+	}, stored)
+	summary := m.Summarize()
+	require.Len(t, summary, 2)
+	assert.Equal(t, Summary{Root: RootState, Stored: 1, Size: int64(len(`{"command":"gz synclone"}`) + 1), Metadata: 1}, summary[0])
+	assert.Equal(t, RootConfig, summary[1].Root)
+	assert.Equal(t, 3, summary[1].Stored)
+	assert.Equal(t, 1, summary[1].Metadata)
+
+	_, err := Open(bytes.NewReader(data), "wrong")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+	_, err = Open(bytes.NewReader([]byte("plain text")), "correct horse")
+	assert.ErrorIs(t, err, ErrNotBackup)
+
+	a, err := Open(bytes.NewReader(data), "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", a.Manifest.GZVersion)
+	assert.Len(t, a.Manifest.Files, len(m.Files))
+
+	_, err = Create(&bytes.Buffer{}, CreateOptions{Roots: roots})
+	assert.ErrorIs(t, err, ErrNoPassphrase)
+}
+
+func TestCreateAndOpen_Codecs(t *testing.T) {
+	_, roots := sourceHome(t)
+
+	// gzip은 이전 버전이 쓰던 형식이다
+	for _, codec := range []compress.Codec{compress.Gzip, compress.LZ4, compress.None} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			var buf bytes.Buffer
+			_, err := Create(&buf, CreateOptions{Roots: roots, Passphrase: "pw", Codec: codec})
+			require.NoError(t, err)
+
+			a, err := Open(bytes.NewReader(buf.Bytes()), "pw")
+			require.NoError(t, err)
+			assert.Equal(t, `{"command":"gz synclone"}`+"\n", storedFile(t, a, RootState, "history.db"))
+		})
+	}
+}
+
+func TestCreateAndOpen_Streams(t *testing.T) {
+	home, roots := sourceHome(t)
+	large := make([]byte, 3*chunkSize+100)
+	_, err := rand.Read(large)
+	require.NoError(t, err)
+	writeFile(t, filepath.Join(home, ".gzh", "state.db"), string(large))
+
+	var buf bytes.Buffer
+	_, err = Create(&buf, CreateOptions{Roots: roots, Passphrase: "pw", Codec: compress.None})
+	require.NoError(t, err)
+	data := buf.Bytes()
+	require.Greater(t, len(data), 3*chunkSize)
+
+	a, err := Open(bytes.NewReader(data), "pw")
+	require.NoError(t, err)
+	assert.Equal(t, string(large), storedFile(t, a, RootState, "state.db"))
+
+	// 잘리거나 바뀐 청크는 암호 오류와 같이 거부된다
+	_, err = Open(bytes.NewReader(data[:len(data)-chunkSize]), "pw")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+	tampered := bytes.Clone(data)
+	tampered[len(tampered)/2] ^= 1
+	_, err = Open(bytes.NewReader(tampered), "pw")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+	_, err = Open(bytes.NewReader(append(bytes.Clone(data), 0)), "pw")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+}
+
+func TestRestore_RemapsPaths(t *testing.T) {
+	oldHome, roots := sourceHome(t)
+	data, _ := createArchive(t, roots, "pw")
+	a, err := Open(bytes.NewReader(data), "pw")
+	require.NoError(t, err)
+
+	newHome := t.TempDir()
+	t.Setenv("HOME", newHome)
+	state := filepath.Join(newHome, ".gzh")
+	config := filepath.Join(newHome, "cfg")
+	writeFile(t, filepath.Join(state, "history.db"), "local history\n")
+
+	opts := RestoreOptions{
+		Targets:  map[string]string{RootState: state, RootConfig: config},
+		Mappings: []Mapping{{From: filepath.Join(oldHome, "src"), To: "/work/src"}},
+		DryRun:   true,
+	}
+	res, err := Restore(a, opts)
+	require.NoError(t, err)
+	assert.Len(t, res.Restored, 3)
+	assert.Equal(t, []string{filepath.Join(state, "history.db")}, res.Existing)
+	assert.Equal(t, 2, res.Metadata)
+	assert.NoFileExists(t, filepath.Join(config, "synclone.yaml"))
+
+	opts.DryRun = false
+	res, err = Restore(a, opts)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(config, "synclone.yaml"),
+		filepath.Join(config, "plugins", "installed.json"),
+	}, res.Remapped)
+
+	assert.Equal(t, "local history\n", readFile(t, filepath.Join(state, "history.db")))
+	assert.Equal(t, "target: /work/src\n", readFile(t, filepath.Join(config, "synclone.yaml")))
+	assert.Equal(t, `{"path":"`+filepath.Join(config, "plugins", "lint", "1.0.0", "lint")+`"}`,
+		readFile(t, filepath.Join(config, "plugins", "installed.json")))
+	assert.NoFileExists(t, filepath.Join(config, "plugins", "lint", "1.0.0", "lint"))
+
+	opts.Overwrite = true
+	_, err = Restore(a, opts)
+	require.NoError(t, err)
+	assert.Equal(t, `{"command":"gz synclone"}`+"\n", readFile(t, filepath.Join(state, "history.db")))
+
+	_, err = Restore(a, RestoreOptions{Targets: map[string]string{"cache": state}})
+	assert.ErrorContains(t, err, `no "cache" root`)
+}
+
+func TestRemap_PathBoundaries(t *testing.T) {
+	mappings := []Mapping{{From: "/home/al", To: "/Users/al"}}
+	for in, want := range map[string]string{
+		"target: /home/al\n":                 "target: /Users/al\n",
+		"target: /home/al/src\n":             "target: /Users/al/src\n",
+		`{"path":"/home/al"}`:                `{"path":"/Users/al"}`,
+		"dir='/home/al' other=/home/al":      "dir='/Users/al' other=/Users/al",
+		"target: /home/alice/src\n":          "target: /home/alice/src\n",
+		"target: /home/al.bak\n":             "target: /home/al.bak\n",
+		"/home/alice /home/al\t/home/al_x\n": "/home/alice /Users/al\t/home/al_x\n",
+		"PATH=/home/al:/usr/bin":             "PATH=/Users/al:/usr/bin",
+		"dirs: [/home/al, /home/alice]":      "dirs: [/Users/al, /home/alice]",
+	} {
+		out, changed := remap([]byte(in), mappings)
+		assert.Equal(t, want, string(out), in)
+		assert.Equal(t, want != in, changed, in)
+	}
+}
+
+func TestRestore_OverwriteIsAtomic(t *testing.T) {
+	_, roots := sourceHome(t)
+	data, _ := createArchive(t, roots, "pw")
+	a, err := Open(bytes.NewReader(data), "pw")
+	require.NoError(t, err)
+
+	state := t.TempDir()
+	existing := filepath.Join(state, "history.db")
+	writeFile(t, existing, "local history\n")
+	// 기존 파일에 연결된 하드 링크는 덮어쓰기 후에도 이전 내용을 가리켜야 한다
+	linked := filepath.Join(t.TempDir(), "linked.db")
+	require.NoError(t, os.Link(existing, linked))
+
+	_, err = Restore(a, RestoreOptions{Targets: map[string]string{RootState: state}, Overwrite: true})
+	require.NoError(t, err)
+	assert.Equal(t, `{"command":"gz synclone"}`+"\n", readFile(t, existing))
+	assert.Equal(t, "local history\n", readFile(t, linked))
+
+	entries, err := os.ReadDir(state)
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.NotContains(t, e.Name(), ".history.db.", "temporary file left behind")
+	}
+}
+
+func TestRestore_RefusesSymlinkedDirectories(t *testing.T) {
+	_, roots := sourceHome(t)
+	data, _ := createArchive(t, roots, "pw")
+	a, err := Open(bytes.NewReader(data), "pw")
+	require.NoError(t, err)
+
+	config := t.TempDir()
+	outside := t.TempDir()
+	require.NoError(t, os.Symlink(outside, filepath.Join(config, "plugins")))
+
+	_, err = Restore(a, RestoreOptions{Targets: map[string]string{RootConfig: config}, Overwrite: true})
+	assert.ErrorContains(t, err, "symlink")
+	assert.NoFileExists(t, filepath.Join(outside, "installed.json"))
+
+	// 대상 디렉터리 자체가 링크인 것은 허용한다
+	linked := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.Symlink(t.TempDir(), linked))
+	_, err = Restore(a, RestoreOptions{Targets: map[string]string{RootConfig: linked}})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(linked, "synclone.yaml"))
+}
+
+func TestParseMapping(t *testing.T) {
+	m, err := ParseMapping("/home/alice=/Users/alice")
+	require.NoError(t, err)
+	assert.Equal(t, Mapping{From: "/home/alice", To: "/Users/alice"}, m)
+
+	_, err = ParseMapping("/home/alice")
+	assert.Error(t, err)
+}
+
+func TestPushPullList(t *testing.T) {
+	_, roots := sourceHome(t)
+	data, _ := createArchive(t, roots, "pw")
+
+	local := t.TempDir()
+	file := filepath.Join(local, DefaultName("laptop", time.Date(2025, 10, 16, 9, 30, 0, 0, time.UTC)))
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+	plain := filepath.Join(local, "notes"+Extension)
+	require.NoError(t, os.WriteFile(plain, []byte("not encrypted"), 0o600))
+
+	store, err := cloud.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	key, err := Push(ctx, store, file)
+	require.NoError(t, err)
+	assert.Equal(t, "gz-backup-laptop-20251016-093000.gzbk", key)
+	_, err = Push(ctx, store, plain)
+	assert.ErrorIs(t, err, ErrNotBackup)
+
+	listed, err := List(ctx, store)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, key, listed[0].Key)
+
+	pulled, err := Pull(ctx, store, key, filepath.Join(t.TempDir(), "restore"))
+	require.NoError(t, err)
+	a, err := OpenFile(pulled, "pw")
+	require.NoError(t, err)
+	defer a.Close()
+	assert.NotEmpty(t, a.Manifest.Files)
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+var (
+	// ErrNoPassphrase is returned when creating a backup without a passphrase.
+	ErrNoPassphrase = errors.New("a passphrase is required to encrypt the backup")
+	// ErrWrongPassphrase is returned when an archive cannot be decrypted,
+	// because the passphrase is wrong or the archive was modified.
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted backup")
+	// ErrNotBackup is returned for files that are not gz backups.
+	ErrNotBackup = errors.New("not a gz backup")
+)
+
+// Sealed archive layout:
+//
+//	magic "GZBK" | version (1) | scrypt log2(N) (1) | salt (16) | nonce prefix (7) | chunks
+//
+// The payload is sealed in chunks of chunkSize bytes with AES-256-GCM, so
+// archives are written and read as streams. Each chunk is followed by its
+// tag. A chunk's nonce is the prefix, the chunk index (4, big endian) and
+// a last-chunk flag (1), so reordered, dropped or truncated chunks fail to
+// open. The header is authenticated as additional data of every chunk.
+var magic = []byte("GZBK")
+
+const (
+	sealVersion = 2
+	scryptLogN  = 15
+	saltSize    = 16
+	// headerSize is the header up to the nonce prefix.
+	headerSize      = 4 + 1 + 1 + saltSize
+	noncePrefixSize = 7
+	chunkSize       = 64 << 10
+)
+
+func deriveKey(passphrase string, salt []byte, logN byte) ([]byte, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<logN, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of chunk index.
+func chunkNonce(prefix []byte, index uint32, last bool) []byte {
+	nonce := make([]byte, 0, noncePrefixSize+5)
+	nonce = binary.BigEndian.AppendUint32(append(nonce, prefix...), index)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// sealWriter encrypts what is written to it chunk by chunk. Close seals
+// the last chunk and must be called for the archive to open.
+type sealWriter struct {
+	w      io.Writer
+	aead   cipher.AEAD
+	header []byte
+	index  uint32
+	buf    []byte
+	out    []byte
+}
+
+func newSealWriter(w io.Writer, passphrase string) (*sealWriter, error) {
+	header := make([]byte, headerSize+noncePrefixSize)
+	copy(header, magic)
+	header[4] = sealVersion
+	header[5] = scryptLogN
+	if _, err := rand.Read(header[6:]); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := deriveKey(passphrase, header[6:headerSize], scryptLogN)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	return &sealWriter{
+		w:      w,
+		aead:   aead,
+		header: header,
+		buf:    make([]byte, 0, chunkSize),
+		out:    make([]byte, 0, chunkSize+aead.Overhead()),
+	}, nil
+}
+
+func (s *sealWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		// 가득 찬 청크는 뒤에 데이터가 더 올 때까지 남겨 두어야 마지막 청크로 봉인할 수 있다
+		if len(s.buf) == chunkSize {
+			if err := s.flush(false); err != nil {
+				return written, err
+			}
+		}
+		n := copy(s.buf[len(s.buf):chunkSize], p)
+		s.buf = s.buf[:len(s.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close seals the buffered data as the last chunk. It does not close the
+// underlying writer.
+func (s *sealWriter) Close() error {
+	return s.flush(true)
+}
+
+func (s *sealWriter) flush(last bool) error {
+	if s.index == math.MaxUint32 {
+		return errors.New("backup is too large")
+	}
+	s.out = s.aead.Seal(s.out[:0], chunkNonce(s.header[headerSize:], s.index, last), s.buf, s.header)
+	if _, err := s.w.Write(s.out); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	s.index++
+	s.buf = s.buf[:0]
+	return nil
+}
+
+// newOpenReader returns a reader of the payload sealed in r. The first
+// chunk is decrypted before it returns, so a wrong passphrase is reported
+// here; later chunks that fail to open make Read return
+// ErrWrongPassphrase.
+func newOpenReader(r io.Reader, passphrase string) (io.Reader, error) {
+	header := make([]byte, headerSize, headerSize+noncePrefixSize)
+	if _, err := io.ReadFull(r, header); err != nil || !bytes.Equal(header[:4], magic) {
+		return nil, ErrNotBackup
+	}
+	version := header[4]
+	if version != sealVersion {
+		return nil, fmt.Errorf("unsupported backup encryption version %d", version)
+	}
+	if header[5] < 10 || header[5] > 22 {
+		return nil, fmt.Errorf("invalid backup key parameters")
+	}
+
+	key, err := deriveKey(passphrase, header[6:headerSize], header[5])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	header = header[:headerSize+noncePrefixSize]
+	if _, err := io.ReadFull(r, header[headerSize:]); err != nil {
+		return nil, ErrNotBackup
+	}
+	o := &openReader{
+		r:      r,
+		aead:   aead,
+		header: header,
+		buf:    make([]byte, chunkSize+aead.Overhead()),
+		plain:  make([]byte, 0, chunkSize),
+	}
+	if err := o.next(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// openReader decrypts a chunked payload.
+type openReader struct {
+	r      io.Reader
+	aead   cipher.AEAD
+	header []byte
+	index  uint32
+	buf    []byte
+	plain  []byte
+	// unread is the part of plain not yet returned by Read.
+	unread []byte
+	last   bool
+	err    error
+}
+
+func (o *openReader) Read(p []byte) (int, error) {
+	for len(o.unread) == 0 {
+		switch {
+		case o.err != nil:
+			return 0, o.err
+		case o.last:
+			return 0, io.EOF
+		}
+		o.err = o.next()
+	}
+	n := copy(p, o.unread)
+	o.unread = o.unread[n:]
+	return n, nil
+}
+
+// next decrypts the following chunk.
+func (o *openReader) next() error {
+	n, err := io.ReadFull(o.r, o.buf)
+	switch {
+	case errors.Is(err, io.EOF):
+		// 마지막 청크 없이 끝난 아카이브는 잘린 것이다
+		return ErrWrongPassphrase
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// 짧은 청크는 마지막 청크뿐이다
+	case err != nil:
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	short := err != nil
+	prefix := o.header[headerSize:]
+
+	plain, err := o.aead.Open(o.plain[:0], chunkNonce(prefix, o.index, short), o.buf[:n], o.header)
+	last := short
+	if err != nil && !short {
+		// 마지막 청크도 가득 차 있을 수 있다
+		plain, err = o.aead.Open(o.plain[:0], chunkNonce(prefix, o.index, true), o.buf[:n], o.header)
+		last = true
+	}
+	if err != nil {
+		return ErrWrongPassphrase
+	}
+	if last {
+		// 마지막 청크 뒤에 덧붙은 데이터도 변조로 본다
+		if m, _ := io.ReadFull(o.r, o.buf[:1]); m > 0 {
+			return ErrWrongPassphrase
+		}
+	} else if o.index == math.MaxUint32 {
+		return ErrWrongPassphrase
+	}
+
+	o.index++
+	o.last = last
+	o.unread = plain
+	return nil
+}
+
+// IsBackup reports whether data starts like a sealed archive.
+func IsBackup(data []byte) bool {
+	return len(data) >= headerSize && bytes.Equal(data[:4], magic)
+}
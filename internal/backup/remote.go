@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/pkg/cloud"
+)
+
+// Push uploads the archive at file to store under its base name and
+// returns the object key.
+func Push(ctx context.Context, store cloud.ObjectStore, file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer f.Close()
+
+	// 암호화되지 않은 파일을 실수로 올리지 않도록 헤더를 확인한다
+	head := make([]byte, headerSize)
+	if _, err := io.ReadFull(f, head); err != nil || !IsBackup(head) {
+		return "", fmt.Errorf("%s: %w", file, ErrNotBackup)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return "", fmt.Errorf("failed to stat backup: %w", err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	key := filepath.Base(file)
+	if err := store.Put(ctx, key, f, info.Size()); err != nil {
+		return "", fmt.Errorf("failed to upload %s to %s: %w", key, store, err)
+	}
+	return key, nil
+}
+
+// Pull downloads the backup named key from store into dir and returns the
+// local path.
+func Pull(ctx context.Context, store cloud.ObjectStore, key, dir string) (string, error) {
+	name := path.Base(key)
+	if name == "." || name == "/" || name == ".." {
+		return "", fmt.Errorf("invalid backup name %q", key)
+	}
+	rc, err := store.Get(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s from %s: %w", key, store, err)
+	}
+	defer rc.Close()
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	dest := filepath.Join(dir, name)
+	tmp, err := os.CreateTemp(dir, "."+name+".*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dest, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, rc); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", dest, err)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", dest, err)
+	}
+	return dest, nil
+}
+
+// List returns the backups in store, newest first.
+func List(ctx context.Context, store cloud.ObjectStore) ([]cloud.ObjectInfo, error) {
+	objects, err := store.List(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups in %s: %w", store, err)
+	}
+	out := objects[:0]
+	for _, o := range objects {
+		if strings.HasSuffix(o.Key, Extension) {
+			out = append(out, o)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Modified.After(out[j].Modified) })
+	return out, nil
+}
+
+// ListDir returns the backups in a local directory, newest first.
+func ListDir(dir string) ([]cloud.ObjectInfo, error) {
+	store, err := cloud.NewFileStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	return List(context.Background(), store)
+}
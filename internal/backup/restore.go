@@ -0,0 +1,285 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxRemapSize is the largest file whose contents are remapped; larger
+// files are restored as they are without being read into memory.
+const maxRemapSize = 8 << 20
+
+// Mapping rewrites a path prefix of the source machine to one of the target.
+type Mapping struct {
+	From string
+	To   string
+}
+
+// ParseMapping parses "OLD=NEW".
+func ParseMapping(s string) (Mapping, error) {
+	from, to, ok := strings.Cut(s, "=")
+	if !ok || from == "" || to == "" {
+		return Mapping{}, fmt.Errorf("invalid path mapping %q (expected OLD=NEW)", s)
+	}
+	return Mapping{From: from, To: to}, nil
+}
+
+// RestoreOptions configures Restore.
+type RestoreOptions struct {
+	// Targets maps root names to the directories they are restored into.
+	// Roots without a target are not restored.
+	Targets map[string]string
+	// Mappings rewrite absolute paths inside restored text files of up to
+	// 8 MiB, such as clone targets in synclone.yaml or plugin paths in
+	// installed.json.
+	// The source home and root directories are mapped to the target ones
+	// automatically.
+	Mappings []Mapping
+	// Overwrite replaces existing files; otherwise they are kept.
+	Overwrite bool
+	// DryRun reports what would be restored without writing.
+	DryRun bool
+}
+
+// RestoreResult reports what Restore did.
+type RestoreResult struct {
+	// Restored lists the written files, as target paths.
+	Restored []string
+	// Existing lists files kept because they already exist.
+	Existing []string
+	// Remapped lists restored files whose contents had paths rewritten.
+	Remapped []string
+	// Metadata counts files listed in the backup without contents.
+	Metadata int
+	// Mappings are the path mappings applied, longest source first.
+	Mappings []Mapping
+}
+
+// Restore writes the stored files of a into their target roots.
+func Restore(a *Archive, opts RestoreOptions) (*RestoreResult, error) {
+	if len(opts.Targets) == 0 {
+		return nil, errors.New("no roots selected to restore")
+	}
+	for name := range opts.Targets {
+		if _, ok := a.Manifest.Root(name); !ok {
+			return nil, fmt.Errorf("backup has no %q root", name)
+		}
+	}
+
+	res := &RestoreResult{Mappings: mappings(a.Manifest, opts)}
+	dests := make(map[string]string)
+	for _, f := range a.Manifest.Files {
+		target, ok := opts.Targets[f.Root]
+		if !ok {
+			continue
+		}
+		if !f.Stored {
+			res.Metadata++
+			continue
+		}
+
+		dest, err := safeJoin(target, f.Path)
+		if err != nil {
+			return res, err
+		}
+		if !opts.Overwrite {
+			if _, err := os.Lstat(dest); err == nil {
+				res.Existing = append(res.Existing, dest)
+				continue
+			}
+		}
+		dests[entryName(f.Root, f.Path)] = dest
+	}
+	if len(dests) == 0 {
+		return res, nil
+	}
+
+	err := a.Files(func(f FileEntry, r io.Reader) error {
+		dest, ok := dests[entryName(f.Root, f.Path)]
+		if !ok {
+			return nil
+		}
+
+		// 경로 치환은 텍스트 설정 파일 대상이므로 큰 파일은 그대로 흘려 보낸다
+		if f.Size <= maxRemapSize {
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return fmt.Errorf("failed to restore %s: %w", dest, err)
+			}
+			if rewritten, changed := remap(data, res.Mappings); changed {
+				data = rewritten
+				res.Remapped = append(res.Remapped, dest)
+			}
+			r = bytes.NewReader(data)
+		}
+		res.Restored = append(res.Restored, dest)
+		if opts.DryRun {
+			return nil
+		}
+		return restoreFile(dest, f, r)
+	})
+	return res, err
+}
+
+// restoreFile writes the contents of f from r to dest, replacing an
+// existing file atomically.
+func restoreFile(dest string, f FileEntry, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", dest, err)
+	}
+	mode := f.Mode.Perm()
+	if mode == 0 {
+		mode = 0o600
+	}
+	// 임시 파일에 쓴 뒤 이름을 바꿔, 실패해도 기존 파일이 반쯤 덮어써지지 않게 한다
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", dest, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to restore %s: %w", dest, err)
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to restore %s: %w", dest, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", dest, err)
+	}
+	_ = os.Chtimes(tmp.Name(), f.ModTime, f.ModTime)
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", dest, err)
+	}
+	return nil
+}
+
+// mappings returns the explicit mappings and the automatic ones for the
+// home and root directories, longest source first so nested paths are
+// rewritten before their parents. Explicit mappings take precedence.
+func mappings(m *Manifest, opts RestoreOptions) []Mapping {
+	out := append([]Mapping(nil), opts.Mappings...)
+	seen := make(map[string]bool, len(out))
+	for _, mp := range out {
+		seen[mp.From] = true
+	}
+	add := func(from, to string) {
+		if from == "" || to == "" || from == to || seen[from] {
+			return
+		}
+		seen[from] = true
+		out = append(out, Mapping{From: from, To: to})
+	}
+
+	for name, target := range opts.Targets {
+		if r, ok := m.Root(name); ok {
+			add(r.Path, target)
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		add(m.Home, home)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if len(out[i].From) != len(out[j].From) {
+			return len(out[i].From) > len(out[j].From)
+		}
+		return out[i].From < out[j].From
+	})
+	return out
+}
+
+// remap rewrites mapped path prefixes in text data. A prefix only matches
+// where it ends at a path boundary, so /home/al does not rewrite
+// /home/alice. Binary data is returned unchanged.
+func remap(data []byte, mappings []Mapping) ([]byte, bool) {
+	if len(mappings) == 0 || bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data) {
+		return data, false
+	}
+
+	// 한 번의 패스로 치환해 이미 바꾼 경로가 다시 바뀌지 않도록 한다
+	text := string(data)
+	var out strings.Builder
+	changed := false
+	for i := 0; i < len(text); {
+		mp, ok := mappingAt(text[i:], mappings)
+		if !ok {
+			out.WriteByte(text[i])
+			i++
+			continue
+		}
+		out.WriteString(mp.To)
+		i += len(mp.From)
+		changed = true
+	}
+	if !changed {
+		return data, false
+	}
+	return []byte(out.String()), true
+}
+
+// mappingAt returns the first mapping whose source starts s and ends at a
+// path boundary. mappings are ordered longest source first.
+func mappingAt(s string, mappings []Mapping) (Mapping, bool) {
+	for _, mp := range mappings {
+		if strings.HasPrefix(s, mp.From) && pathBoundary(s[len(mp.From):]) {
+			return mp, true
+		}
+	}
+	return Mapping{}, false
+}
+
+// pathBoundary reports whether a path prefix followed by rest ends a path
+// component: rest is empty or starts with a separator, a quote, whitespace,
+// or the ':' and ',' separating entries of PATH-style and flow lists.
+func pathBoundary(rest string) bool {
+	if rest == "" {
+		return true
+	}
+	c := rest[0]
+	return c == '/' || c == filepath.Separator || strings.IndexByte("\"'`:,", c) >= 0 || unicode.IsSpace(rune(c))
+}
+
+// safeJoin joins a slash-separated archive path to dir, rejecting paths
+// that escape it, either lexically or through a symlink in one of the
+// existing directories below dir.
+func safeJoin(dir, rel string) (string, error) {
+	clean := filepath.FromSlash(rel)
+	if !filepath.IsLocal(clean) {
+		return "", fmt.Errorf("invalid backup: unsafe path %q", rel)
+	}
+
+	// 대상 디렉터리 자체는 링크여도 되지만 그 아래 디렉터리는 따라가지 않는다
+	parent := dir
+	for _, part := range strings.Split(filepath.Dir(clean), string(filepath.Separator)) {
+		if part == "." {
+			break
+		}
+		parent = filepath.Join(parent, part)
+		info, err := os.Lstat(parent)
+		if errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to check restore path: %w", err)
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return "", fmt.Errorf("refusing to restore %q through symlink %s", rel, parent)
+		}
+	}
+	return filepath.Join(dir, clean), nil
+}
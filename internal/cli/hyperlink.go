@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// OSC 8 escape sequences that open and close a terminal hyperlink.
+const (
+	hyperlinkOpen  = "\x1b]8;;"
+	hyperlinkClose = "\x1b\\"
+)
+
+// hyperlinkPattern matches one OSC 8 hyperlink: its URL and visible text.
+var hyperlinkPattern = regexp.MustCompile("\x1b\\]8;[^;\x1b]*;([^\x1b]*)\x1b\\\\(.*?)\x1b\\]8;;\x1b\\\\")
+
+// urlPattern matches bare http(s) URLs in free text.
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"')\]]+[^\s<>"')\].,;:!?]`)
+
+// Hyperlink returns text as an OSC 8 terminal hyperlink to url. Terminals
+// without hyperlink support print the escape sequences literally, so output
+// that may not reach such a terminal should go through a Linker instead.
+func Hyperlink(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return hyperlinkOpen + url + hyperlinkClose + text + hyperlinkOpen + hyperlinkClose
+}
+
+// StripHyperlinks replaces every hyperlink in s with its visible text.
+func StripHyperlinks(s string) string {
+	if !strings.Contains(s, hyperlinkOpen) {
+		return s
+	}
+	return hyperlinkPattern.ReplaceAllString(s, "$2")
+}
+
+// VisibleWidth returns the number of runes of s a terminal displays,
+// ignoring hyperlink escape sequences.
+func VisibleWidth(s string) int {
+	return utf8.RuneCountInString(StripHyperlinks(s))
+}
+
+// HyperlinksSupported reports whether w is a terminal that renders OSC 8
+// hyperlinks. FORCE_HYPERLINK=1 or FORCE_HYPERLINK=0 overrides detection,
+// for example to keep links when piping into a pager.
+func HyperlinksSupported(w io.Writer) bool {
+	if force, ok := os.LookupEnv("FORCE_HYPERLINK"); ok {
+		enabled, err := strconv.ParseBool(force)
+		return err == nil && enabled
+	}
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return false
+	}
+	return terminalSupportsHyperlinks(os.Getenv)
+}
+
+// terminalSupportsHyperlinks recognizes terminal emulators known to render
+// OSC 8 hyperlinks from their environment. Unknown terminals get plain text.
+func terminalSupportsHyperlinks(getenv func(string) string) bool {
+	if getenv("CI") != "" || getenv("TERM") == "dumb" {
+		return false
+	}
+	if getenv("WT_SESSION") != "" || getenv("KONSOLE_VERSION") != "" || getenv("DOMTERM") != "" {
+		return true
+	}
+	// VTE 0.50 (GNOME Terminal, Tilix, ...) introduced hyperlinks
+	if vte, err := strconv.Atoi(getenv("VTE_VERSION")); err == nil && vte >= 5000 {
+		return true
+	}
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper", "Tabby", "rio":
+		return true
+	}
+	switch getenv("TERM") {
+	case "xterm-kitty", "alacritty", "foot", "xterm-ghostty", "wezterm":
+		return true
+	}
+	return false
+}
+
+// Linker renders hyperlinks when its output supports them and falls back to
+// plain text otherwise.
+type Linker struct {
+	enabled bool
+}
+
+// NewLinker creates a linker for output written to w.
+func NewLinker(w io.Writer) Linker {
+	return Linker{enabled: HyperlinksSupported(w)}
+}
+
+// Enabled reports whether links are rendered as hyperlinks.
+func (l Linker) Enabled() bool {
+	return l.enabled
+}
+
+// Link returns text linked to url, or just text without hyperlink support.
+// Use it where the URL is available elsewhere, such as a repository name.
+func (l Linker) Link(text, url string) string {
+	if !l.enabled || url == "" {
+		return text
+	}
+	return Hyperlink(text, url)
+}
+
+// LinkWithURL returns text linked to url, or "text (url)" without hyperlink
+// support, so the destination is never lost.
+func (l Linker) LinkWithURL(text, url string) string {
+	switch {
+	case url == "" || text == url:
+		return l.Link(url, url)
+	case l.enabled:
+		return Hyperlink(text, url)
+	default:
+		return text + " (" + url + ")"
+	}
+}
+
+// Linkify turns the bare URLs in s into hyperlinks. The text is unchanged
+// without hyperlink support.
+func (l Linker) Linkify(s string) string {
+	if !l.enabled {
+		return s
+	}
+	return urlPattern.ReplaceAllStringFunc(s, func(url string) string {
+		return Hyperlink(url, url)
+	})
+}
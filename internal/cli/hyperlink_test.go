@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHyperlink(t *testing.T) {
+	link := Hyperlink("org/api", "https://github.com/org/api")
+	assert.Equal(t, "\x1b]8;;https://github.com/org/api\x1b\\org/api\x1b]8;;\x1b\\", link)
+	assert.Equal(t, "org/api", Hyperlink("org/api", ""))
+
+	assert.Equal(t, "see org/api and org/web", StripHyperlinks("see "+link+" and "+Hyperlink("org/web", "https://x")))
+	assert.Equal(t, 7, VisibleWidth(link))
+
+	assert.Equal(t, Hyperlink("org/…", "https://github.com/org/api"), Truncate(link, 5), "a linked cell stays linked")
+	assert.Equal(t, "a org…", Truncate("a "+link, 6))
+}
+
+func TestLinker(t *testing.T) {
+	const url = "https://example.com/docs"
+	off := Linker{}
+	assert.Equal(t, "docs", off.Link("docs", url))
+	assert.Equal(t, "docs ("+url+")", off.LinkWithURL("docs", url))
+	assert.Equal(t, "Install from "+url+".", off.Linkify("Install from "+url+"."))
+
+	on := Linker{enabled: true}
+	assert.Equal(t, Hyperlink("docs", url), on.Link("docs", url))
+	assert.Equal(t, Hyperlink("docs", url), on.LinkWithURL("docs", url))
+	assert.Equal(t, Hyperlink(url, url), on.LinkWithURL(url, url))
+	assert.Equal(t, "Install from "+Hyperlink(url, url)+".", on.Linkify("Install from "+url+"."))
+}
+
+func TestHyperlinksSupported(t *testing.T) {
+	t.Setenv("FORCE_HYPERLINK", "1")
+	assert.True(t, HyperlinksSupported(&bytes.Buffer{}))
+	t.Setenv("FORCE_HYPERLINK", "0")
+	assert.False(t, HyperlinksSupported(&bytes.Buffer{}))
+
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	assert.True(t, terminalSupportsHyperlinks(env(map[string]string{"TERM_PROGRAM": "iTerm.app"})))
+	assert.True(t, terminalSupportsHyperlinks(env(map[string]string{"VTE_VERSION": "6800"})))
+	assert.False(t, terminalSupportsHyperlinks(env(map[string]string{"VTE_VERSION": "4601"})))
+	assert.True(t, terminalSupportsHyperlinks(env(map[string]string{"TERM": "xterm-kitty"})))
+	assert.False(t, terminalSupportsHyperlinks(env(map[string]string{"TERM": "xterm-kitty", "CI": "true"})))
+	assert.False(t, terminalSupportsHyperlinks(env(map[string]string{"TERM": "xterm-256color"})))
+}
+
+func TestTable_RenderHyperlinks(t *testing.T) {
+	table := NewTable(Column{Name: "name"}, Column{Name: "language"})
+	table.AddRow(Hyperlink("api", "https://github.com/org/api"), "Go")
+	table.AddRow("web-frontend", "TypeScript")
+
+	t.Setenv("FORCE_HYPERLINK", "0")
+	lines := renderTable(t, table, TableOptions{NoHeader: true})
+	assert.Equal(t, []string{"api           Go", "web-frontend  TypeScript"}, lines)
+
+	t.Setenv("FORCE_HYPERLINK", "1")
+	lines = renderTable(t, table, TableOptions{NoHeader: true})
+	assert.Equal(t, Hyperlink("api", "https://github.com/org/api")+"           Go", lines[0], "links are padded by their visible width")
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/gizzahub/gzh-cli/pkg/i18n"
+)
+
+// usageTemplate is cobra's default usage template with every heading
+// wrapped in %[n]s so it can be translated.
+const usageTemplate = `%[1]s{{if .Runnable}}
+  {{.UseLine}}{{end}}{{if .HasAvailableSubCommands}}
+  {{.CommandPath}} [command]{{end}}{{if gt (len .Aliases) 0}}
+
+%[2]s
+  {{.NameAndAliases}}{{end}}{{if .HasExample}}
+
+%[3]s
+{{.Example}}{{end}}{{if .HasAvailableSubCommands}}{{$cmds := .Commands}}{{if eq (len .Groups) 0}}
+
+%[4]s{{range $cmds}}{{if (or .IsAvailableCommand (eq .Name "help"))}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{else}}{{range $group := .Groups}}
+
+{{.Title}}{{range $cmds}}{{if (and (eq .GroupID $group.ID) (or .IsAvailableCommand (eq .Name "help")))}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}{{if not .AllChildCommandsHaveGroup}}
+
+%[5]s{{range $cmds}}{{if (and (eq .GroupID "") (or .IsAvailableCommand (eq .Name "help")))}}
+  {{rpad .Name .NamePadding }} {{.Short}}{{end}}{{end}}{{end}}{{end}}{{end}}{{if .HasAvailableLocalFlags}}
+
+%[6]s
+{{.LocalFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasAvailableInheritedFlags}}
+
+%[7]s
+{{.InheritedFlags.FlagUsages | trimTrailingWhitespaces}}{{end}}{{if .HasHelpSubCommands}}
+
+%[8]s{{range .Commands}}{{if .IsAdditionalHelpTopicCommand}}
+  {{rpad .CommandPath .CommandPathPadding}} {{.Short}}{{end}}{{end}}{{end}}{{if .HasAvailableSubCommands}}
+
+%[9]s{{end}}
+`
+
+// LocalizeCommand translates the help of cmd and its subcommands into the
+// locale of l: the usage template headings, the short descriptions and the
+// flag usages that have a translation. Nothing changes for the default
+// locale, so English help stays exactly as written.
+func LocalizeCommand(cmd *cobra.Command, l *i18n.Localizer) {
+	if l.Locale() == i18n.DefaultLocale {
+		return
+	}
+
+	// The command path is a template action, so it must survive the
+	// translation's own format verb untouched.
+	moreInfo := strings.Replace(l.T(`Use "%s [command] --help" for more information about a command.`), "%s", "{{.CommandPath}}", 1)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate,
+		l.T("Usage:"),
+		l.T("Aliases:"),
+		l.T("Examples:"),
+		l.T("Available Commands:"),
+		l.T("Additional Commands:"),
+		l.T("Flags:"),
+		l.T("Global Flags:"),
+		l.T("Additional help topics:"),
+		moreInfo,
+	))
+
+	localizeTree(cmd, l)
+}
+
+func localizeTree(cmd *cobra.Command, l *i18n.Localizer) {
+	if short, ok := l.Lookup(cmd.Short); ok {
+		cmd.Short = short
+	}
+	translate := func(f *pflag.Flag) {
+		if usage, ok := l.Lookup(f.Usage); ok {
+			f.Usage = usage
+		}
+	}
+	cmd.LocalFlags().VisitAll(translate)
+	cmd.PersistentFlags().VisitAll(translate)
+
+	for _, sub := range cmd.Commands() {
+		localizeTree(sub, l)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/i18n"
+)
+
+func newLocalizeTestCmd() *cobra.Command {
+	root := &cobra.Command{Use: "gz"}
+	root.PersistentFlags().Bool("verbose", false, "Enable verbose logging")
+	root.AddCommand(&cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose system health and configuration issues",
+		Run:   func(*cobra.Command, []string) {},
+	})
+	root.AddCommand(&cobra.Command{
+		Use:   "custom",
+		Short: "Not in any catalog",
+		Run:   func(*cobra.Command, []string) {},
+	})
+	return root
+}
+
+func TestLocalizeCommand(t *testing.T) {
+	ko, err := i18n.New("ko")
+	require.NoError(t, err)
+
+	root := newLocalizeTestCmd()
+	LocalizeCommand(root, ko)
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+	require.NoError(t, root.Usage())
+
+	help := out.String()
+	assert.Contains(t, help, "사용법:")
+	assert.Contains(t, help, "사용 가능한 명령:")
+	assert.Contains(t, help, "시스템 상태와 설정 문제 진단")
+	assert.Contains(t, help, "Not in any catalog", "untranslated descriptions are kept")
+	assert.Contains(t, help, "상세 로그 출력")
+	assert.Contains(t, help, `"gz [command] --help"`)
+}
+
+func TestLocalizeCommandDefaultLocale(t *testing.T) {
+	en, err := i18n.New("en")
+	require.NoError(t, err)
+
+	localized, plain := newLocalizeTestCmd(), newLocalizeTestCmd()
+	LocalizeCommand(localized, en)
+
+	var a, b bytes.Buffer
+	localized.SetOut(&a)
+	plain.SetOut(&b)
+	require.NoError(t, localized.Usage())
+	require.NoError(t, plain.Usage())
+	assert.Equal(t, b.String(), a.String())
+}
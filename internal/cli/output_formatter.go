@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -20,12 +23,19 @@ const (
 	FormatYAML = "yaml"
 	// FormatTable represents table output format.
 	FormatTable = "table"
+	// FormatNDJSON represents newline-delimited JSON, one compact object per
+	// line, written as results become available.
+	FormatNDJSON = "ndjson"
 )
 
 // OutputFormatter provides consistent output formatting across commands.
 type OutputFormatter struct {
 	writer io.Writer
 	format string
+	linker Linker
+
+	// mu serializes NDJSON lines written from concurrent workers.
+	mu sync.Mutex
 }
 
 // NewOutputFormatter creates a new output formatter.
@@ -33,6 +43,7 @@ func NewOutputFormatter(format string) *OutputFormatter {
 	return &OutputFormatter{
 		writer: os.Stdout,
 		format: format,
+		linker: NewLinker(os.Stdout),
 	}
 }
 
@@ -41,6 +52,7 @@ func NewOutputFormatterWithWriter(format string, writer io.Writer) *OutputFormat
 	return &OutputFormatter{
 		writer: writer,
 		format: format,
+		linker: NewLinker(writer),
 	}
 }
 
@@ -53,6 +65,8 @@ func (f *OutputFormatter) FormatOutput(data any) error {
 		return f.outputYAML(data)
 	case FormatTable:
 		return f.outputTable(data)
+	case FormatNDJSON:
+		return f.outputNDJSON(data)
 	default:
 		return fmt.Errorf("unsupported output format: %s", f.format)
 	}
@@ -65,6 +79,72 @@ func (f *OutputFormatter) outputJSON(data any) error {
 	return encoder.Encode(data)
 }
 
+// outputNDJSON writes each element of a slice or array as its own line, or
+// data itself as a single line otherwise.
+func (f *OutputFormatter) outputNDJSON(data any) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return f.writeLine(data)
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := f.writeLine(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeLine writes one compact JSON line and flushes it when the writer is
+// buffered, so consumers such as jq see it immediately.
+func (f *OutputFormatter) writeLine(data any) error {
+	line, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, err := f.writer.Write(line); err != nil {
+		return err
+	}
+	if flusher, ok := f.writer.(interface{ Flush() error }); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// Event is a single progress event of a long-running operation, such as a
+// repository finishing its clone, a retry or an error.
+type Event struct {
+	Time    time.Time      `json:"time"`
+	Type    string         `json:"type"`
+	Target  string         `json:"target,omitempty"`
+	Message string         `json:"message,omitempty"`
+	Error   string         `json:"error,omitempty"`
+	Attempt int            `json:"attempt,omitempty"`
+	Data    map[string]any `json:"data,omitempty"`
+}
+
+// IsStreaming reports whether the formatter writes events as they happen
+// instead of a single document at the end.
+func (f *OutputFormatter) IsStreaming() bool {
+	return f.format == FormatNDJSON
+}
+
+// EmitEvent writes event as one NDJSON line. It is safe for concurrent use
+// and does nothing unless the format is FormatNDJSON, so callers can emit
+// unconditionally and still render their usual output at the end.
+func (f *OutputFormatter) EmitEvent(event Event) error {
+	if !f.IsStreaming() {
+		return nil
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	return f.writeLine(event)
+}
+
 // outputYAML outputs data in YAML format.
 func (f *OutputFormatter) outputYAML(data any) error {
 	encoder := yaml.NewEncoder(f.writer)
@@ -101,8 +181,8 @@ func (f *OutputFormatter) FormatTable(data TableData) error {
 
 	for _, row := range rows {
 		for i, cell := range row {
-			if i < len(colWidths) && len(cell) > colWidths[i] {
-				colWidths[i] = len(cell)
+			if i < len(colWidths) && VisibleWidth(cell) > colWidths[i] {
+				colWidths[i] = VisibleWidth(cell)
 			}
 		}
 	}
@@ -124,7 +204,10 @@ func (f *OutputFormatter) printRow(cells []string, colWidths []int) {
 	var parts []string
 	for i, cell := range cells {
 		if i < len(colWidths) {
-			parts = append(parts, fmt.Sprintf("%-*s", colWidths[i], cell))
+			if !f.linker.Enabled() {
+				cell = StripHyperlinks(cell)
+			}
+			parts = append(parts, cell+strings.Repeat(" ", max(colWidths[i]-VisibleWidth(cell), 0)))
 		}
 	}
 	fmt.Fprintln(f.writer, strings.Join(parts, "  "))
@@ -139,24 +222,31 @@ func (f *OutputFormatter) printSeparator(colWidths []int) {
 	fmt.Fprintln(f.writer, strings.Join(parts, "  "))
 }
 
+// Link returns text as a hyperlink to url when the output supports it, and
+// text otherwise. See Linker.Link.
+func (f *OutputFormatter) Link(text, url string) string {
+	return f.linker.Link(text, url)
+}
+
 // PrintSuccess prints a success message.
 func (f *OutputFormatter) PrintSuccess(message string) {
 	fmt.Fprintf(f.writer, "✅ %s\n", message)
 }
 
-// PrintError prints an error message.
+// PrintError prints an error message. URLs in messages of this and the
+// other Print methods are hyperlinked where the output supports it.
 func (f *OutputFormatter) PrintError(message string) {
-	fmt.Fprintf(f.writer, "❌ %s\n", message)
+	fmt.Fprintf(f.writer, "❌ %s\n", f.linker.Linkify(message))
 }
 
 // PrintWarning prints a warning message.
 func (f *OutputFormatter) PrintWarning(message string) {
-	fmt.Fprintf(f.writer, "⚠️  %s\n", message)
+	fmt.Fprintf(f.writer, "⚠️  %s\n", f.linker.Linkify(message))
 }
 
 // PrintInfo prints an info message.
 func (f *OutputFormatter) PrintInfo(message string) {
-	fmt.Fprintf(f.writer, "ℹ️  %s\n", message)
+	fmt.Fprintf(f.writer, "ℹ️  %s\n", f.linker.Linkify(message))
 }
 
 // PrintVerbose prints a verbose message if verbose mode is enabled.
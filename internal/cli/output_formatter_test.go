@@ -5,7 +5,9 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -459,3 +461,60 @@ func TestOutputFormatter_Integration(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputFormatter_FormatOutput_NDJSON(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	formatter := NewOutputFormatterWithWriter(FormatNDJSON, buffer)
+
+	items := []map[string]any{{"name": "a"}, {"name": "b"}}
+	require.NoError(t, formatter.FormatOutput(items))
+	assert.Equal(t, "{\"name\":\"a\"}\n{\"name\":\"b\"}\n", buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, formatter.FormatOutput(map[string]any{"name": "single"}))
+	assert.Equal(t, "{\"name\":\"single\"}\n", buffer.String())
+}
+
+// flushRecorder records how many lines were flushed.
+type flushRecorder struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (f *flushRecorder) Flush() error {
+	f.flushes++
+	return nil
+}
+
+func TestOutputFormatter_EmitEvent(t *testing.T) {
+	writer := &flushRecorder{}
+	formatter := NewOutputFormatterWithWriter(FormatNDJSON, writer)
+	assert.True(t, formatter.IsStreaming())
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(attempt int) {
+			defer wg.Done()
+			assert.NoError(t, formatter.EmitEvent(Event{Type: "retry", Target: "org/repo", Attempt: attempt}))
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSpace(writer.String()), "\n")
+	require.Len(t, lines, 20)
+	assert.Equal(t, 20, writer.flushes)
+	for _, line := range lines {
+		var event Event
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		assert.Equal(t, "retry", event.Type)
+		assert.Equal(t, "org/repo", event.Target)
+		assert.False(t, event.Time.IsZero())
+	}
+
+	buffer := &bytes.Buffer{}
+	table := NewOutputFormatterWithWriter(FormatTable, buffer)
+	assert.False(t, table.IsStreaming())
+	require.NoError(t, table.EmitEvent(Event{Type: "retry"}))
+	assert.Empty(t, buffer.String(), "non-streaming formats ignore events")
+}
@@ -0,0 +1,415 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// ItemStatus is the outcome of one item of a long-running operation.
+type ItemStatus string
+
+const (
+	// ItemDone marks an item that was processed successfully.
+	ItemDone ItemStatus = "done"
+	// ItemFailed marks an item that could not be processed.
+	ItemFailed ItemStatus = "failed"
+	// ItemSkipped marks an item that needed no work.
+	ItemSkipped ItemStatus = "skipped"
+)
+
+// ProgressMode selects how progress is rendered.
+type ProgressMode string
+
+const (
+	// ProgressAuto draws a bar on terminals and prints lines otherwise.
+	ProgressAuto ProgressMode = "auto"
+	// ProgressBar redraws a single status line.
+	ProgressBar ProgressMode = "bar"
+	// ProgressLines prints one line per item.
+	ProgressLines ProgressMode = "lines"
+	// ProgressEvents writes NDJSON progress events.
+	ProgressEvents ProgressMode = "events"
+	// ProgressNone prints nothing but still keeps the counters.
+	ProgressNone ProgressMode = "none"
+)
+
+// ProgressModes lists the accepted --progress values.
+var ProgressModes = []ProgressMode{ProgressAuto, ProgressBar, ProgressLines, ProgressEvents, ProgressNone}
+
+// ParseProgressMode validates a --progress value.
+func ParseProgressMode(value string) (ProgressMode, error) {
+	for _, m := range ProgressModes {
+		if string(m) == value {
+			return m, nil
+		}
+	}
+	names := make([]string, len(ProgressModes))
+	for i, m := range ProgressModes {
+		names[i] = string(m)
+	}
+	return "", fmt.Errorf("invalid progress mode %q (use %s)", value, strings.Join(names, ", "))
+}
+
+// Progress reports a long-running operation made of phases, each processing
+// a number of items. Implementations are safe for concurrent use so workers
+// can report their items directly.
+type Progress interface {
+	// Phase starts a phase of total items; total is 0 when not yet known.
+	Phase(name string, total int)
+	// AddTotal grows the total of the current phase as work is discovered.
+	AddTotal(n int)
+	// Item records the outcome of one item of the current phase.
+	Item(name string, status ItemStatus, err error)
+	// Logf prints a message without corrupting a progress bar.
+	Logf(format string, args ...any)
+	// Done ends the operation and prints the final counters.
+	Done()
+	// Snapshot returns the counters and estimates of the current phase.
+	Snapshot() ProgressSnapshot
+}
+
+// ProgressSnapshot is the state of the current phase.
+type ProgressSnapshot struct {
+	Phase   string        `json:"phase"`
+	Total   int           `json:"total"`
+	Done    int           `json:"done"`
+	Failed  int           `json:"failed"`
+	Skipped int           `json:"skipped"`
+	Elapsed time.Duration `json:"elapsed"`
+	// Rate is the moving average throughput in items per second.
+	Rate float64 `json:"rate"`
+	// ETA is the estimated time left; zero when unknown.
+	ETA time.Duration `json:"eta"`
+}
+
+// Processed returns the number of items with an outcome.
+func (s ProgressSnapshot) Processed() int {
+	return s.Done + s.Failed + s.Skipped
+}
+
+// Percent returns the share of processed items, or -1 when the total is
+// unknown.
+func (s ProgressSnapshot) Percent() float64 {
+	if s.Total <= 0 {
+		return -1
+	}
+	return float64(s.Processed()) / float64(s.Total) * 100
+}
+
+// throughputWindow is the number of recent items the rate is averaged over;
+// large enough to smooth out single slow items, small enough to follow
+// changes such as a rate limit kicking in.
+const throughputWindow = 20
+
+// barInterval limits how often a progress bar is redrawn.
+const barInterval = 100 * time.Millisecond
+
+// progress implements Progress for every mode.
+type progress struct {
+	mu     sync.Mutex
+	out    io.Writer
+	mode   ProgressMode
+	events *OutputFormatter
+	now    func() time.Time
+
+	snap       ProgressSnapshot
+	phaseStart time.Time
+	started    time.Time
+	// recent holds the completion times of the last throughputWindow items.
+	recent   []time.Time
+	drawn    bool
+	lastDraw time.Time
+	finished bool
+}
+
+// NewProgress creates a progress reporter writing to out. ProgressAuto picks
+// ProgressBar when out is a terminal.
+func NewProgress(out io.Writer, mode ProgressMode) Progress {
+	if out == nil {
+		out = io.Discard
+	}
+	if mode == ProgressAuto || mode == "" {
+		mode = ProgressLines
+		if f, ok := out.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+			mode = ProgressBar
+		}
+	}
+
+	p := &progress{out: out, mode: mode, now: time.Now}
+	if mode == ProgressEvents {
+		p.events = NewOutputFormatterWithWriter(FormatNDJSON, out)
+	}
+	p.started = p.now()
+	return p
+}
+
+// AddProgressFlag registers the --progress flag on cmd.
+func AddProgressFlag(cmd *cobra.Command, mode *string) {
+	cmd.Flags().StringVar(mode, "progress", string(ProgressAuto),
+		"Progress output: auto, bar, lines, events (NDJSON) or none")
+}
+
+// ProgressForCommand creates the progress reporter of cmd from its
+// --progress value. The global --quiet flag turns progress off.
+func ProgressForCommand(cmd *cobra.Command, mode string) (Progress, error) {
+	m, err := ParseProgressMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	if f := cmd.Flag("quiet"); f != nil && f.Value.String() == "true" && m != ProgressEvents {
+		m = ProgressNone
+	}
+	return NewProgress(cmd.OutOrStdout(), m), nil
+}
+
+func (p *progress) Phase(name string, total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clearBar()
+	p.snap = ProgressSnapshot{Phase: name, Total: total}
+	p.phaseStart = p.now()
+	p.recent = p.recent[:0]
+
+	switch p.mode {
+	case ProgressLines, ProgressBar:
+		if total > 0 {
+			fmt.Fprintf(p.out, "→ %s (%d)\n", name, total)
+		} else {
+			fmt.Fprintf(p.out, "→ %s\n", name)
+		}
+	case ProgressEvents:
+		p.emit(Event{Type: "phase", Target: name, Data: map[string]any{"total": total}})
+	}
+	p.drawBar(true)
+}
+
+func (p *progress) AddTotal(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.snap.Total += n
+	if p.mode == ProgressEvents {
+		p.emit(Event{Type: "total", Target: p.snap.Phase, Data: map[string]any{"total": p.snap.Total}})
+	}
+	p.drawBar(false)
+}
+
+func (p *progress) Item(name string, status ItemStatus, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch status {
+	case ItemFailed:
+		p.snap.Failed++
+	case ItemSkipped:
+		p.snap.Skipped++
+	default:
+		status = ItemDone
+		p.snap.Done++
+	}
+	// Items finishing after the total was reached grow it instead of
+	// showing more than 100%.
+	if p.snap.Total > 0 && p.snap.Processed() > p.snap.Total {
+		p.snap.Total = p.snap.Processed()
+	}
+	p.recent = append(p.recent, p.now())
+	if len(p.recent) > throughputWindow {
+		p.recent = p.recent[len(p.recent)-throughputWindow:]
+	}
+	p.estimate()
+
+	switch p.mode {
+	case ProgressLines:
+		fmt.Fprintln(p.out, p.itemLine(name, status, err))
+	case ProgressBar:
+		// Failures stay visible above the bar; successes only move it.
+		if status == ItemFailed {
+			p.clearBar()
+			fmt.Fprintln(p.out, p.itemLine(name, status, err))
+		}
+		p.drawBar(false)
+	case ProgressEvents:
+		event := Event{Type: "item", Target: name, Message: string(status), Data: p.eventData()}
+		if err != nil {
+			event.Error = err.Error()
+		}
+		p.emit(event)
+	}
+}
+
+func (p *progress) Logf(format string, args ...any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch p.mode {
+	case ProgressLines, ProgressBar:
+		p.clearBar()
+		fmt.Fprintf(p.out, format+"\n", args...)
+		p.drawBar(true)
+	case ProgressEvents:
+		p.emit(Event{Type: "info", Message: fmt.Sprintf(format, args...)})
+	}
+}
+
+func (p *progress) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.finished {
+		return
+	}
+	p.finished = true
+	p.estimate()
+
+	switch p.mode {
+	case ProgressLines, ProgressBar:
+		p.clearBar()
+		s := p.snap
+		fmt.Fprintf(p.out, "✔ %d done, %d failed, %d skipped in %s\n",
+			s.Done, s.Failed, s.Skipped, FormatETA(p.now().Sub(p.started)))
+	case ProgressEvents:
+		data := p.eventData()
+		data["duration"] = p.now().Sub(p.started).Seconds()
+		p.emit(Event{Type: "done", Target: p.snap.Phase, Data: data})
+	}
+}
+
+func (p *progress) Snapshot() ProgressSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.estimate()
+	return p.snap
+}
+
+// estimate updates the elapsed time, rate and ETA. The rate is averaged
+// over the recent items only so the estimate follows slowdowns; it falls
+// back to the phase average until the window has two items.
+func (p *progress) estimate() {
+	now := p.now()
+	p.snap.Elapsed = now.Sub(p.phaseStart)
+	p.snap.Rate, p.snap.ETA = 0, 0
+
+	switch n := len(p.recent); {
+	case n >= 2 && p.recent[n-1].After(p.recent[0]):
+		p.snap.Rate = float64(n-1) / p.recent[n-1].Sub(p.recent[0]).Seconds()
+	case p.snap.Processed() > 0 && p.snap.Elapsed > 0:
+		p.snap.Rate = float64(p.snap.Processed()) / p.snap.Elapsed.Seconds()
+	}
+
+	if remaining := p.snap.Total - p.snap.Processed(); remaining > 0 && p.snap.Rate > 0 {
+		p.snap.ETA = time.Duration(float64(remaining) / p.snap.Rate * float64(time.Second))
+	}
+}
+
+func (p *progress) itemLine(name string, status ItemStatus, err error) string {
+	icon := "✅"
+	switch status {
+	case ItemFailed:
+		icon = "❌"
+	case ItemSkipped:
+		icon = "⏭️"
+	}
+
+	var b strings.Builder
+	if pct := p.snap.Percent(); pct >= 0 {
+		fmt.Fprintf(&b, "[%5.1f%%] ", pct)
+	} else {
+		fmt.Fprintf(&b, "[%d] ", p.snap.Processed())
+	}
+	fmt.Fprintf(&b, "%s %s", icon, name)
+	if err != nil {
+		fmt.Fprintf(&b, ": %v", err)
+	}
+	if p.snap.ETA > 0 {
+		fmt.Fprintf(&b, " (ETA %s)", FormatETA(p.snap.ETA))
+	}
+	return b.String()
+}
+
+// drawBar redraws the status line, at most every barInterval unless force
+// is set.
+func (p *progress) drawBar(force bool) {
+	if p.mode != ProgressBar || p.finished {
+		return
+	}
+	now := p.now()
+	if !force && p.drawn && now.Sub(p.lastDraw) < barInterval && p.snap.Processed() < p.snap.Total {
+		return
+	}
+	p.lastDraw = now
+	p.drawn = true
+
+	s := p.snap
+	var b strings.Builder
+	const width = 24
+	if pct := s.Percent(); pct >= 0 {
+		filled := int(pct / 100 * width)
+		fmt.Fprintf(&b, "%s [%s%s] %d/%d %3.0f%%", s.Phase,
+			strings.Repeat("█", filled), strings.Repeat("░", width-filled), s.Processed(), s.Total, pct)
+	} else {
+		fmt.Fprintf(&b, "%s %d", s.Phase, s.Processed())
+	}
+	if s.Failed > 0 {
+		fmt.Fprintf(&b, " · %d failed", s.Failed)
+	}
+	if s.Rate > 0 {
+		fmt.Fprintf(&b, " · %.1f/s", s.Rate)
+	}
+	if s.ETA > 0 {
+		fmt.Fprintf(&b, " · ETA %s", FormatETA(s.ETA))
+	}
+	fmt.Fprintf(p.out, "\r\033[K%s", b.String())
+}
+
+func (p *progress) clearBar() {
+	if p.mode == ProgressBar && p.drawn {
+		fmt.Fprint(p.out, "\r\033[K")
+		p.drawn = false
+	}
+}
+
+func (p *progress) eventData() map[string]any {
+	s := p.snap
+	return map[string]any{
+		"phase":      s.Phase,
+		"total":      s.Total,
+		"done":       s.Done,
+		"failed":     s.Failed,
+		"skipped":    s.Skipped,
+		"rate":       s.Rate,
+		"etaSeconds": s.ETA.Seconds(),
+	}
+}
+
+// emit writes an event; write errors are ignored so a closed pipe does not
+// abort the operation being reported.
+func (p *progress) emit(event Event) {
+	event.Time = p.now()
+	_ = p.events.EmitEvent(event)
+}
+
+// FormatETA renders a duration the way progress output shows it, e.g. "45s",
+// "3m20s" or "1h05m".
+func FormatETA(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock advances by step on every reading.
+type fakeClock struct {
+	t    time.Time
+	step time.Duration
+}
+
+func (c *fakeClock) now() time.Time {
+	c.t = c.t.Add(c.step)
+	return c.t
+}
+
+func newTestProgress(mode ProgressMode, step time.Duration) (*progress, *bytes.Buffer) {
+	var buf bytes.Buffer
+	p := NewProgress(&buf, mode).(*progress)
+	clock := &fakeClock{t: time.Unix(0, 0), step: step}
+	p.now = clock.now
+	p.started = clock.t
+	return p, &buf
+}
+
+func TestProgressEstimatesETAFromRecentThroughput(t *testing.T) {
+	p, _ := newTestProgress(ProgressNone, 0)
+	clock := time.Unix(0, 0)
+	p.now = func() time.Time { return clock }
+
+	p.Phase("clone", 100)
+	// Ten slow items followed by ten fast ones: the estimate follows the
+	// recent rate rather than the phase average.
+	for range 10 {
+		clock = clock.Add(10 * time.Second)
+		p.Item("slow", ItemDone, nil)
+	}
+	for range throughputWindow {
+		clock = clock.Add(time.Second)
+		p.Item("fast", ItemDone, nil)
+	}
+
+	s := p.Snapshot()
+	assert.Equal(t, 30, s.Done)
+	assert.InDelta(t, 1.0, s.Rate, 0.001)
+	assert.Equal(t, 70*time.Second, s.ETA)
+	assert.Equal(t, 120*time.Second, s.Elapsed)
+}
+
+func TestProgressCountsAndUnknownTotal(t *testing.T) {
+	p, _ := newTestProgress(ProgressNone, time.Second)
+
+	p.Phase("scan", 0)
+	p.Item("a", ItemDone, nil)
+	p.Item("b", ItemFailed, errors.New("boom"))
+	p.Item("c", ItemSkipped, nil)
+
+	s := p.Snapshot()
+	assert.Equal(t, 3, s.Processed())
+	assert.Equal(t, -1.0, s.Percent())
+	assert.Zero(t, s.ETA)
+
+	p.AddTotal(4)
+	assert.InDelta(t, 75.0, p.Snapshot().Percent(), 0.001)
+
+	// More items than announced grow the total instead of passing 100%.
+	p.Item("d", ItemDone, nil)
+	p.Item("e", ItemDone, nil)
+	assert.Equal(t, 5, p.Snapshot().Total)
+}
+
+func TestProgressLines(t *testing.T) {
+	p, buf := newTestProgress(ProgressLines, time.Second)
+
+	p.Phase("sync", 2)
+	p.Item("org/a", ItemDone, nil)
+	p.Item("org/b", ItemFailed, errors.New("denied"))
+	p.Done()
+
+	out := buf.String()
+	assert.Contains(t, out, "→ sync (2)\n")
+	assert.Contains(t, out, "[ 50.0%] ✅ org/a")
+	assert.Contains(t, out, "[100.0%] ❌ org/b: denied")
+	assert.Contains(t, out, "✔ 1 done, 1 failed, 0 skipped in")
+	assert.NotContains(t, out, "\r")
+}
+
+func TestProgressBarKeepsFailuresAboveTheBar(t *testing.T) {
+	p, buf := newTestProgress(ProgressBar, time.Second)
+
+	p.Phase("clone", 2)
+	p.Item("ok", ItemDone, nil)
+	p.Item("bad", ItemFailed, errors.New("timeout"))
+	p.Done()
+
+	out := buf.String()
+	assert.Contains(t, out, "\r\033[K")
+	assert.Contains(t, out, "clone [████████████░░░░░░░░░░░░] 1/2  50%")
+	assert.Contains(t, out, "❌ bad: timeout\n")
+	assert.NotContains(t, out, "✅ ok")
+	assert.Contains(t, out, "✔ 1 done, 1 failed, 0 skipped")
+}
+
+func TestProgressEvents(t *testing.T) {
+	p, buf := newTestProgress(ProgressEvents, time.Second)
+
+	p.Phase("migrate issues", 2)
+	p.Item("1", ItemDone, nil)
+	p.Item("2", ItemFailed, errors.New("rate limited"))
+	p.Logf("note %d", 1)
+	p.Done()
+	p.Done()
+
+	var events []Event
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var e Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		events = append(events, e)
+	}
+	require.Len(t, events, 5)
+
+	assert.Equal(t, "phase", events[0].Type)
+	assert.Equal(t, "item", events[1].Type)
+	assert.Equal(t, "done", events[1].Message)
+	assert.InDelta(t, 1, events[1].Data["done"], 0)
+	assert.Equal(t, "rate limited", events[2].Error)
+	assert.Equal(t, "info", events[3].Type)
+	assert.Equal(t, "done", events[4].Type)
+	assert.Contains(t, events[4].Data, "duration")
+}
+
+func TestProgressConcurrentItems(t *testing.T) {
+	p := NewProgress(nil, ProgressNone)
+	p.Phase("work", 100)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 25 {
+				p.Item("x", ItemDone, nil)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 100, p.Snapshot().Done)
+}
+
+func TestProgressForCommand(t *testing.T) {
+	root := &cobra.Command{Use: "gz"}
+	quiet := root.PersistentFlags().Bool("quiet", false, "")
+	cmd := &cobra.Command{Use: "sync"}
+	root.AddCommand(cmd)
+
+	_, err := ProgressForCommand(cmd, "fancy")
+	require.Error(t, err)
+
+	p, err := ProgressForCommand(cmd, "lines")
+	require.NoError(t, err)
+	assert.Equal(t, ProgressLines, p.(*progress).mode)
+
+	*quiet = true
+	p, err = ProgressForCommand(cmd, "lines")
+	require.NoError(t, err)
+	assert.Equal(t, ProgressNone, p.(*progress).mode)
+
+	p, err = ProgressForCommand(cmd, "events")
+	require.NoError(t, err)
+	assert.Equal(t, ProgressEvents, p.(*progress).mode)
+}
+
+func TestFormatETA(t *testing.T) {
+	assert.Equal(t, "45s", FormatETA(45*time.Second))
+	assert.Equal(t, "3m20s", FormatETA(200*time.Second))
+	assert.Equal(t, "1h05m", FormatETA(65*time.Minute))
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/gizzahub/gzh-cli/pkg/i18n"
+)
+
+// minColumnWidth is the narrowest a column is truncated to when the table
+// does not fit the terminal.
+const minColumnWidth = 8
+
+// columnGap separates table columns.
+const columnGap = "  "
+
+// Column describes one column of a Table.
+type Column struct {
+	// Name selects the column in --columns, such as "language".
+	Name string
+	// Header is printed above the column; empty means Name in upper case.
+	Header string
+	// Hidden columns are only shown when selected with --columns.
+	Hidden bool
+	// NoTruncate keeps the column at full width when the table is shrunk
+	// to the terminal width, for values such as IDs that are useless when
+	// cut.
+	NoTruncate bool
+}
+
+func (c Column) header() string {
+	if c.Header != "" {
+		return c.Header
+	}
+	return strings.ToUpper(c.Name)
+}
+
+// TableOptions control how a Table is rendered. They are usually bound to
+// command flags with AddTableFlags.
+type TableOptions struct {
+	// Columns lists the columns to show, in order; empty shows every
+	// column that is not Hidden.
+	Columns []string
+	// Page is the 1-based page to show when PageSize is set; 0 means the
+	// first page.
+	Page int
+	// PageSize is the number of rows per page; 0 shows every row.
+	PageSize int
+	// NoHeader omits the header, separator and page footer.
+	NoHeader bool
+	// Width is the width to fit the table in; 0 uses the terminal width
+	// when writing to a terminal and never truncates otherwise.
+	Width int
+}
+
+// AddTableFlags registers --columns, --page, --page-size and --no-header on
+// cmd. columns is the list of column names shown in the help text.
+func AddTableFlags(cmd *cobra.Command, opts *TableOptions, columns ...Column) {
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.Name)
+	}
+	usage := "Columns to show, comma separated"
+	if len(names) > 0 {
+		usage += " (" + strings.Join(names, ", ") + ")"
+	}
+	cmd.Flags().StringSliceVar(&opts.Columns, "columns", nil, usage)
+	cmd.Flags().IntVar(&opts.Page, "page", 1, "Page of rows to show (with --page-size)")
+	cmd.Flags().IntVar(&opts.PageSize, "page-size", 0, "Rows per page (0 = all rows)")
+	cmd.Flags().BoolVar(&opts.NoHeader, "no-header", false, "Omit the table header, for scripting")
+}
+
+// Validate checks the options against the columns of a table.
+func (o TableOptions) Validate(columns ...Column) error {
+	if o.Page < 0 {
+		return fmt.Errorf("invalid page: %d (pages start at 1)", o.Page)
+	}
+	if o.PageSize < 0 {
+		return fmt.Errorf("invalid page size: %d", o.PageSize)
+	}
+	_, err := selectColumns(columns, o.Columns)
+	return err
+}
+
+// Table is a plain-text table with selectable columns, pagination and
+// truncation to the terminal width. Cells may be hyperlinks made with
+// Hyperlink; they are reduced to their text on output without link support.
+type Table struct {
+	columns []Column
+	rows    [][]string
+}
+
+// NewTable creates a table with the given columns.
+func NewTable(columns ...Column) *Table {
+	return &Table{columns: columns}
+}
+
+// AddRow appends a row; cells are given in column order. Missing cells are
+// empty and extra cells are ignored.
+func (t *Table) AddRow(cells ...string) {
+	row := make([]string, len(t.columns))
+	copy(row, cells)
+	t.rows = append(t.rows, row)
+}
+
+// Len returns the number of rows.
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// Render writes the selected page of the table to w.
+func (t *Table) Render(w io.Writer, opts TableOptions) error {
+	if err := opts.Validate(t.columns...); err != nil {
+		return err
+	}
+	selected, _ := selectColumns(t.columns, opts.Columns)
+
+	rows := t.rows
+	pages := 1
+	opts.Page = max(opts.Page, 1)
+	if opts.PageSize > 0 {
+		pages = max(1, (len(rows)+opts.PageSize-1)/opts.PageSize)
+		start := min((opts.Page-1)*opts.PageSize, len(rows))
+		end := min(start+opts.PageSize, len(rows))
+		rows = rows[start:end]
+	}
+
+	// 링크를 표시할 수 없는 출력에서는 이스케이프 시퀀스 대신 텍스트만 남긴다
+	links := HyperlinksSupported(w)
+	cell := func(row []string, col int) string {
+		if links {
+			return row[col]
+		}
+		return StripHyperlinks(row[col])
+	}
+
+	widths := make([]int, len(selected))
+	for i, col := range selected {
+		if !opts.NoHeader {
+			widths[i] = utf8.RuneCountInString(t.columns[col].header())
+		}
+		for _, row := range rows {
+			widths[i] = max(widths[i], VisibleWidth(row[col]))
+		}
+	}
+	width := opts.Width
+	if width == 0 {
+		width = terminalWidth(w)
+	}
+	if width > 0 {
+		t.fit(selected, widths, width)
+	}
+
+	if !opts.NoHeader {
+		headers := make([]string, len(selected))
+		separators := make([]string, len(selected))
+		for i, col := range selected {
+			headers[i] = t.columns[col].header()
+			separators[i] = strings.Repeat("-", widths[i])
+		}
+		writeTableRow(w, headers, widths)
+		writeTableRow(w, separators, widths)
+	}
+	cells := make([]string, len(selected))
+	for _, row := range rows {
+		for i, col := range selected {
+			cells[i] = cell(row, col)
+		}
+		writeTableRow(w, cells, widths)
+	}
+
+	if !opts.NoHeader && opts.PageSize > 0 && pages > 1 {
+		fmt.Fprintf(w, "\n%s\n", i18n.T("Page %d of %d (%d rows)", opts.Page, pages, len(t.rows)))
+	}
+	return nil
+}
+
+// fit narrows the widest truncatable columns until the table fits width.
+func (t *Table) fit(selected, widths []int, width int) {
+	total := func() int {
+		sum := len(columnGap) * (len(widths) - 1)
+		for _, w := range widths {
+			sum += w
+		}
+		return sum
+	}
+	for total() > width {
+		widest := -1
+		for i, col := range selected {
+			if t.columns[col].NoTruncate || widths[i] <= minColumnWidth {
+				continue
+			}
+			if widest < 0 || widths[i] > widths[widest] {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest] = max(minColumnWidth, widths[widest]-(total()-width))
+	}
+}
+
+// writeTableRow writes cells padded to widths, truncating longer cells with
+// an ellipsis. The last column is not padded.
+func writeTableRow(w io.Writer, cells []string, widths []int) {
+	var b strings.Builder
+	for i, cell := range cells {
+		cell = Truncate(cell, widths[i])
+		if i > 0 {
+			b.WriteString(columnGap)
+		}
+		b.WriteString(cell)
+		if i < len(cells)-1 {
+			b.WriteString(strings.Repeat(" ", widths[i]-VisibleWidth(cell)))
+		}
+	}
+	fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+}
+
+// Truncate shortens s to at most width visible runes, ending it with "…"
+// when cut. A cell that is a single hyperlink stays linked.
+func Truncate(s string, width int) string {
+	if VisibleWidth(s) <= width {
+		return s
+	}
+	if strings.Contains(s, hyperlinkOpen) {
+		if m := hyperlinkPattern.FindStringSubmatch(s); m != nil && m[0] == s {
+			return Hyperlink(Truncate(m[2], width), m[1])
+		}
+		s = StripHyperlinks(s)
+	}
+	if width <= 1 {
+		return string([]rune(s)[:max(width, 0)])
+	}
+	return string([]rune(s)[:width-1]) + "…"
+}
+
+// selectColumns returns the indexes of the named columns, or of every
+// column that is not hidden when names is empty.
+func selectColumns(columns []Column, names []string) ([]int, error) {
+	if len(names) == 0 {
+		var out []int
+		for i, c := range columns {
+			if !c.Hidden {
+				out = append(out, i)
+			}
+		}
+		return out, nil
+	}
+
+	out := make([]int, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := -1
+		for i, c := range columns {
+			if c.Name == name {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			available := make([]string, 0, len(columns))
+			for _, c := range columns {
+				available = append(available, c.Name)
+			}
+			return nil, fmt.Errorf("unknown column: %s (available: %s)", name, strings.Join(available, ", "))
+		}
+		out = append(out, found)
+	}
+	return out, nil
+}
+
+// terminalWidth returns the width of w when it is a terminal, or 0. The
+// COLUMNS environment variable overrides the detected width.
+func terminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if width, _, err := term.GetSize(int(f.Fd())); err == nil {
+		return width
+	}
+	return 0
+}
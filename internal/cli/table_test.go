@@ -0,0 +1,98 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testColumns = []Column{
+	{Name: "name"},
+	{Name: "language"},
+	{Name: "stars", Hidden: true},
+	{Name: "updated", Header: "LAST UPDATE", NoTruncate: true},
+}
+
+func newTestTable() *Table {
+	table := NewTable(testColumns...)
+	table.AddRow("api", "Go", "12", "2025-01-02")
+	table.AddRow("web-frontend-with-a-long-name", "TypeScript", "3", "2025-01-01")
+	table.AddRow("docs", "", "0", "2024-12-31")
+	return table
+}
+
+func renderTable(t *testing.T, table *Table, opts TableOptions) []string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, table.Render(&buf, opts))
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+}
+
+func TestTable_RenderDefaultColumns(t *testing.T) {
+	lines := renderTable(t, newTestTable(), TableOptions{})
+
+	require.Len(t, lines, 5)
+	assert.Equal(t, "NAME                           LANGUAGE    LAST UPDATE", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "-----"))
+	assert.Equal(t, "api                            Go          2025-01-02", lines[2])
+	assert.NotContains(t, lines[0], "STARS", "hidden columns are only shown on request")
+}
+
+func TestTable_RenderSelectedColumns(t *testing.T) {
+	lines := renderTable(t, newTestTable(), TableOptions{Columns: []string{"stars", "Name"}, NoHeader: true})
+
+	assert.Equal(t, []string{
+		"12  api",
+		"3   web-frontend-with-a-long-name",
+		"0   docs",
+	}, lines)
+
+	err := newTestTable().Render(&bytes.Buffer{}, TableOptions{Columns: []string{"owner"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "available: name, language, stars, updated")
+}
+
+func TestTable_RenderPage(t *testing.T) {
+	lines := renderTable(t, newTestTable(), TableOptions{Columns: []string{"name"}, Page: 2, PageSize: 2})
+	assert.Equal(t, []string{"NAME", "----", "docs", "", "Page 2 of 2 (3 rows)"}, lines)
+
+	lines = renderTable(t, newTestTable(), TableOptions{Columns: []string{"name"}, Page: 5, PageSize: 2, NoHeader: true})
+	assert.Equal(t, []string{""}, lines, "pages past the end are empty")
+
+	assert.Error(t, TableOptions{Page: -1}.Validate(testColumns...))
+	assert.Error(t, TableOptions{Page: 1, PageSize: -1}.Validate(testColumns...))
+}
+
+func TestTable_RenderTruncatesToWidth(t *testing.T) {
+	lines := renderTable(t, newTestTable(), TableOptions{Width: 40})
+
+	for _, line := range lines {
+		assert.LessOrEqual(t, len([]rune(line)), 40, line)
+	}
+	assert.Contains(t, lines[3], "…")
+	assert.Contains(t, lines[3], "2025-01-01", "NoTruncate columns keep their width")
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", Truncate("abc", 3))
+	assert.Equal(t, "ab…", Truncate("abcd", 3))
+	assert.Equal(t, "한글…", Truncate("한글테스트", 3))
+	assert.Equal(t, "a", Truncate("abc", 1))
+}
+
+func TestAddTableFlags(t *testing.T) {
+	cmd := &cobra.Command{Use: "list"}
+	opts := &TableOptions{}
+	AddTableFlags(cmd, opts, testColumns...)
+
+	require.NoError(t, cmd.ParseFlags([]string{"--columns", "name,stars", "--page", "2", "--page-size", "10", "--no-header"}))
+	assert.Equal(t, TableOptions{Columns: []string{"name", "stars"}, Page: 2, PageSize: 10, NoHeader: true}, *opts)
+	assert.Contains(t, cmd.Flags().Lookup("columns").Usage, "name, language, stars, updated")
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BreakerRegistry keeps named circuit breakers so that they can be
+// inspected and controlled at runtime.
+type BreakerRegistry struct {
+	mu       sync.RWMutex
+	breakers map[string]*CircuitBreaker
+}
+
+// DefaultBreakers is the registry served by the debug HTTP server.
+var DefaultBreakers = NewBreakerRegistry()
+
+// NewBreakerRegistry creates an empty registry.
+func NewBreakerRegistry() *BreakerRegistry {
+	return &BreakerRegistry{breakers: make(map[string]*CircuitBreaker)}
+}
+
+// NewBreaker creates a circuit breaker and registers it under name. An
+// existing breaker with the same name is returned instead, so that
+// components created more than once share one breaker.
+func (r *BreakerRegistry) NewBreaker(name string, maxFailures int, resetTime time.Duration) *CircuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cb, ok := r.breakers[name]; ok {
+		return cb
+	}
+	cb := NewCircuitBreaker(maxFailures, resetTime)
+	cb.name = name
+	r.breakers[name] = cb
+	return cb
+}
+
+// Get returns the breaker registered under name.
+func (r *BreakerRegistry) Get(name string) (*CircuitBreaker, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cb, ok := r.breakers[name]
+	return cb, ok
+}
+
+// Remove unregisters the breaker with the given name.
+func (r *BreakerRegistry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.breakers, name)
+}
+
+// Snapshots returns the state of every breaker, sorted by name.
+func (r *BreakerRegistry) Snapshots() []BreakerSnapshot {
+	r.mu.RLock()
+	breakers := make([]*CircuitBreaker, 0, len(r.breakers))
+	for _, cb := range r.breakers {
+		breakers = append(breakers, cb)
+	}
+	r.mu.RUnlock()
+
+	snapshots := make([]BreakerSnapshot, 0, len(breakers))
+	for _, cb := range breakers {
+		snapshots = append(snapshots, cb.Snapshot())
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Name < snapshots[j].Name })
+	return snapshots
+}
+
+// BreakerHandlerPath is where the debug HTTP server mounts the breaker API.
+const BreakerHandlerPath = "/debug/breakers/"
+
+// NewBreakerHandler returns the circuit breaker introspection API:
+//
+//	GET  /debug/breakers/               list every breaker
+//	GET  /debug/breakers/{name}         show one breaker
+//	POST /debug/breakers/{name}/open    force the breaker open
+//	POST /debug/breakers/{name}/close   force the breaker closed
+//	POST /debug/breakers/{name}/reset   return to normal operation
+//
+// Control requests accept an optional ?reason= that is recorded in the
+// breaker's transition history.
+func NewBreakerHandler(r *BreakerRegistry) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+BreakerHandlerPath+"{$}", func(w http.ResponseWriter, _ *http.Request) {
+		writeBreakerJSON(w, http.StatusOK, r.Snapshots())
+	})
+
+	mux.HandleFunc("GET "+BreakerHandlerPath+"{name}", func(w http.ResponseWriter, req *http.Request) {
+		cb, ok := r.Get(req.PathValue("name"))
+		if !ok {
+			writeBreakerError(w, http.StatusNotFound, fmt.Sprintf("circuit breaker %q not found", req.PathValue("name")))
+			return
+		}
+		writeBreakerJSON(w, http.StatusOK, cb.Snapshot())
+	})
+
+	mux.HandleFunc("POST "+BreakerHandlerPath+"{name}/{action}", func(w http.ResponseWriter, req *http.Request) {
+		cb, ok := r.Get(req.PathValue("name"))
+		if !ok {
+			writeBreakerError(w, http.StatusNotFound, fmt.Sprintf("circuit breaker %q not found", req.PathValue("name")))
+			return
+		}
+
+		reason := strings.TrimSpace(req.URL.Query().Get("reason"))
+		switch req.PathValue("action") {
+		case "open":
+			cb.ForceOpen(reason)
+		case "close":
+			cb.ForceClose(reason)
+		case "reset":
+			cb.Reset(reason)
+		default:
+			writeBreakerError(w, http.StatusNotFound, fmt.Sprintf("unknown action %q (valid: open, close, reset)", req.PathValue("action")))
+			return
+		}
+		writeBreakerJSON(w, http.StatusOK, cb.Snapshot())
+	})
+
+	return mux
+}
+
+func writeBreakerJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeBreakerError(w http.ResponseWriter, status int, msg string) {
+	writeBreakerJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errBoom = errors.New("boom")
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Millisecond)
+
+	assert.ErrorIs(t, cb.Execute(func() error { return errBoom }), errBoom)
+	assert.Equal(t, StateClosed, cb.GetState())
+	assert.ErrorIs(t, cb.Execute(func() error { return errBoom }), errBoom)
+	assert.Equal(t, StateOpen, cb.GetState())
+
+	// After the reset time a trial request goes through half-open.
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, cb.Execute(func() error { return nil }))
+	assert.Equal(t, StateClosed, cb.GetState())
+
+	s := cb.Snapshot()
+	assert.Equal(t, int64(2), s.TotalFailures)
+	assert.Equal(t, int64(1), s.TotalSuccesses)
+	require.Len(t, s.Transitions, 3)
+	assert.Equal(t, StateHalfOpen, s.Transitions[1].To)
+	assert.Equal(t, "request succeeded", s.Transitions[2].Reason)
+}
+
+func TestCircuitBreakerForce(t *testing.T) {
+	cb := NewCircuitBreaker(1, time.Millisecond)
+
+	cb.ForceOpen("incident 42")
+	time.Sleep(5 * time.Millisecond)
+	assert.Error(t, cb.Execute(func() error { return nil }), "forced open ignores the reset time")
+	assert.Equal(t, int64(1), cb.Snapshot().Rejected)
+
+	cb.ForceClose("")
+	for range 3 {
+		_ = cb.Execute(func() error { return errBoom })
+	}
+	assert.Equal(t, StateClosed, cb.GetState(), "forced closed does not trip")
+
+	cb.Reset("")
+	assert.False(t, cb.Snapshot().Forced)
+	_ = cb.Execute(func() error { return errBoom })
+	assert.Equal(t, StateOpen, cb.GetState())
+}
+
+func TestBreakerHandler(t *testing.T) {
+	reg := NewBreakerRegistry()
+	cb := reg.NewBreaker("github-api", 3, time.Minute)
+	assert.Same(t, cb, reg.NewBreaker("github-api", 5, time.Hour))
+	reg.NewBreaker("gitlab-api", 3, time.Minute)
+
+	srv := httptest.NewServer(NewBreakerHandler(reg))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + BreakerHandlerPath)
+	require.NoError(t, err)
+	var list []map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	resp.Body.Close()
+	require.Len(t, list, 2)
+	assert.Equal(t, "github-api", list[0]["name"])
+	assert.Equal(t, "closed", list[0]["state"])
+
+	resp, err = http.Post(srv.URL+BreakerHandlerPath+"github-api/open?reason=outage", "", nil)
+	require.NoError(t, err)
+	var snap map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&snap))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "open", snap["state"])
+	assert.Equal(t, true, snap["forced"])
+	assert.Equal(t, StateOpen, cb.GetState())
+	assert.Equal(t, "forced open: outage", cb.Snapshot().Transitions[0].Reason)
+
+	for path, want := range map[string]int{
+		"missing/open":      http.StatusNotFound,
+		"github-api/toggle": http.StatusNotFound,
+	} {
+		resp, err = http.Post(srv.URL+BreakerHandlerPath+path, "", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, want, resp.StatusCode, path)
+	}
+
+	resp, err = http.Get(srv.URL + BreakerHandlerPath + "github-api/reset")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode, "control requires POST")
+}
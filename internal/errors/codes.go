@@ -0,0 +1,191 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// Category groups error codes by the subsystem that failed.
+type Category string
+
+// Error categories. The category name forms the middle part of a stable code.
+const (
+	CategoryConfig     Category = "CONFIG"
+	CategoryAuth       Category = "AUTH"
+	CategoryNetwork    Category = "NET"
+	CategoryGit        Category = "GIT"
+	CategoryInput      Category = "INPUT"
+	CategoryFileSystem Category = "FS"
+	CategorySystem     Category = "SYS"
+)
+
+const (
+	// ErrorCodeUsage indicates invalid command line usage (unknown flag, missing argument).
+	ErrorCodeUsage ErrorCode = "USAGE_ERROR"
+	// ErrorCodeCanceled indicates the operation was canceled by the user.
+	ErrorCodeCanceled ErrorCode = "CANCELED"
+	// ErrorCodeUnknown indicates an error that could not be classified.
+	ErrorCodeUnknown ErrorCode = "UNKNOWN"
+)
+
+// CodeInfo describes the stable, machine-readable identity of an ErrorCode.
+type CodeInfo struct {
+	// ID is the stable identifier, e.g. GZ-GIT-003. IDs are never reused or
+	// renumbered so scripts can match on them across releases.
+	ID        string   `json:"code"`
+	Category  Category `json:"category"`
+	Retryable bool     `json:"retryable"`
+	Actions   []string `json:"actions,omitempty"`
+}
+
+// codeRegistry maps every ErrorCode to its stable identity.
+// 새 코드는 카테고리의 마지막 번호 다음으로만 추가한다 (기존 번호 변경 금지).
+var codeRegistry = map[ErrorCode]CodeInfo{
+	ErrorCodeInvalidConfig:  {ID: "GZ-CONFIG-001", Category: CategoryConfig, Actions: []string{"Run 'gz config validate' to locate the invalid value"}},
+	ErrorCodeMissingConfig:  {ID: "GZ-CONFIG-002", Category: CategoryConfig, Actions: []string{"Run 'gz config init' to create a configuration"}},
+	ErrorCodeConfigNotFound: {ID: "GZ-CONFIG-003", Category: CategoryConfig, Actions: []string{"Check the --config path or run 'gz config init'"}},
+
+	ErrorCodeInvalidToken:      {ID: "GZ-AUTH-001", Category: CategoryAuth, Actions: []string{"Check the provider token environment variable (e.g. GITHUB_TOKEN)"}},
+	ErrorCodeTokenExpired:      {ID: "GZ-AUTH-002", Category: CategoryAuth, Actions: []string{"Generate a new token and update the environment or profile"}},
+	ErrorCodeInsufficientPerms: {ID: "GZ-AUTH-003", Category: CategoryAuth, Actions: []string{"Grant the token the required scopes (repo, admin:org)"}},
+	ErrorCodeAuthFailed:        {ID: "GZ-AUTH-004", Category: CategoryAuth, Actions: []string{"Verify the credentials for the target provider"}},
+
+	ErrorCodeNetworkTimeout:    {ID: "GZ-NET-001", Category: CategoryNetwork, Retryable: true, Actions: []string{"Retry the command", "Increase --timeout if the operation is large"}},
+	ErrorCodeConnectionFailed:  {ID: "GZ-NET-002", Category: CategoryNetwork, Retryable: true, Actions: []string{"Check network connectivity and proxy settings", "Run 'gz doctor' for a network check"}},
+	ErrorCodeRateLimitExceeded: {ID: "GZ-NET-003", Category: CategoryNetwork, Retryable: true, Actions: []string{"Wait for the rate limit window to reset", "Use an authenticated token for a higher limit"}},
+	ErrorCodeAPIUnavailable:    {ID: "GZ-NET-004", Category: CategoryNetwork, Retryable: true, Actions: []string{"Check the provider status page and retry later"}},
+
+	ErrorCodeRepoNotFound:       {ID: "GZ-GIT-001", Category: CategoryGit, Actions: []string{"Verify the repository name and that the token can access it"}},
+	ErrorCodeCloneFailed:        {ID: "GZ-GIT-002", Category: CategoryGit, Actions: []string{"Retry the clone", "Check SSH keys or HTTPS credentials"}},
+	ErrorCodeGitOperationFailed: {ID: "GZ-GIT-003", Category: CategoryGit, Actions: []string{"Inspect the repository with 'git status'"}},
+	ErrorCodePermissionDenied:   {ID: "GZ-GIT-004", Category: CategoryGit, Actions: []string{"Check repository permissions for the authenticated user"}},
+
+	ErrorCodeInvalidInput:     {ID: "GZ-INPUT-001", Category: CategoryInput, Actions: []string{"Check the command arguments"}},
+	ErrorCodeValidationFailed: {ID: "GZ-INPUT-002", Category: CategoryInput, Actions: []string{"Check input format and constraints"}},
+	ErrorCodeInvalidFormat:    {ID: "GZ-INPUT-003", Category: CategoryInput, Actions: []string{"Check the input file syntax"}},
+	ErrorCodeUsage:            {ID: "GZ-INPUT-004", Category: CategoryInput, Actions: []string{"Run the command with --help for usage"}},
+
+	ErrorCodeFileNotFound: {ID: "GZ-FS-001", Category: CategoryFileSystem, Actions: []string{"Verify the path exists"}},
+	ErrorCodeAccessDenied: {ID: "GZ-FS-002", Category: CategoryFileSystem, Actions: []string{"Check file ownership and permissions"}},
+	ErrorCodeDiskFull:     {ID: "GZ-FS-003", Category: CategoryFileSystem, Actions: []string{"Free disk space and retry"}},
+	ErrorCodeIOError:      {ID: "GZ-FS-004", Category: CategoryFileSystem, Retryable: true, Actions: []string{"Check file permissions and disk space"}},
+
+	ErrorCodeUnknown:           {ID: "GZ-SYS-000", Category: CategorySystem, Actions: []string{"Re-run with --debug and report the output"}},
+	ErrorCodeInternalError:     {ID: "GZ-SYS-001", Category: CategorySystem, Actions: []string{"Re-run with --debug and report the output"}},
+	ErrorCodeResourceExhausted: {ID: "GZ-SYS-002", Category: CategorySystem, Retryable: true, Actions: []string{"Reduce --parallel and retry"}},
+	ErrorCodeOperationFailed:   {ID: "GZ-SYS-003", Category: CategorySystem},
+	ErrorCodeTimeout:           {ID: "GZ-SYS-004", Category: CategorySystem, Retryable: true, Actions: []string{"Increase --timeout and retry"}},
+	ErrorCodeCanceled:          {ID: "GZ-SYS-005", Category: CategorySystem},
+}
+
+// LookupCode returns the stable identity of code. Unregistered codes map to GZ-SYS-000.
+func LookupCode(code ErrorCode) CodeInfo {
+	if info, ok := codeRegistry[code]; ok {
+		return info
+	}
+	return codeRegistry[ErrorCodeUnknown]
+}
+
+// Codes returns the registry of all error codes, e.g. for documentation.
+func Codes() map[ErrorCode]CodeInfo {
+	codes := make(map[ErrorCode]CodeInfo, len(codeRegistry))
+	for code, info := range codeRegistry {
+		codes[code] = info
+	}
+	return codes
+}
+
+// ID returns the stable identifier of the error, e.g. GZ-AUTH-004.
+func (e *StandardError) ID() string {
+	return LookupCode(e.Code).ID
+}
+
+// recoverableCodes maps RecoverableError types to error codes.
+var recoverableCodes = map[ErrorType]ErrorCode{
+	ErrorTypeNetwork:    ErrorCodeConnectionFailed,
+	ErrorTypeAuth:       ErrorCodeAuthFailed,
+	ErrorTypeValidation: ErrorCodeValidationFailed,
+	ErrorTypeSystem:     ErrorCodeInternalError,
+	ErrorTypeTimeout:    ErrorCodeTimeout,
+	ErrorTypeRateLimit:  ErrorCodeRateLimitExceeded,
+	ErrorTypeUnknown:    ErrorCodeUnknown,
+}
+
+// usagePrefixes are the messages cobra uses for command line errors.
+var usagePrefixes = []string{
+	"unknown command",
+	"unknown flag",
+	"unknown shorthand flag",
+	"required flag(s)",
+	"invalid argument",
+	"flag needs an argument",
+	"accepts ",
+	"requires at least",
+	"requires at most",
+}
+
+// Classify determines the ErrorCode of any error. Typed errors are matched
+// first; plain errors fall back to well-known standard library sentinels and
+// command line usage messages.
+func Classify(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+
+	var stdErr *StandardError
+	if errors.As(err, &stdErr) {
+		return stdErr.Code
+	}
+
+	var recErr *RecoverableError
+	if errors.As(err, &recErr) {
+		return recoverableCodes[recErr.Type]
+	}
+
+	var netErr net.Error
+	var urlErr *url.Error
+	var opErr *net.OpError
+
+	switch {
+	case errors.Is(err, ErrConfigNotFound):
+		return ErrorCodeConfigNotFound
+	case errors.Is(err, ErrInvalidConfig):
+		return ErrorCodeInvalidConfig
+	case errors.Is(err, ErrConfigNotLoaded):
+		return ErrorCodeMissingConfig
+	case errors.Is(err, context.Canceled):
+		return ErrorCodeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorCodeNetworkTimeout
+	case errors.As(err, &opErr), errors.As(err, &urlErr):
+		return ErrorCodeConnectionFailed
+	case errors.Is(err, syscall.ENOSPC):
+		return ErrorCodeDiskFull
+	case errors.Is(err, os.ErrNotExist):
+		return ErrorCodeFileNotFound
+	case errors.Is(err, os.ErrPermission):
+		return ErrorCodeAccessDenied
+	}
+
+	// 래핑된 에러도 cobra 메시지로 시작하는 단계가 있는지 확인
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		msg := e.Error()
+		for _, prefix := range usagePrefixes {
+			if strings.HasPrefix(msg, prefix) {
+				return ErrorCodeUsage
+			}
+		}
+	}
+
+	return ErrorCodeUnknown
+}
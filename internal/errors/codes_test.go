@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/i18n"
+)
+
+func TestCodeRegistryIsStable(t *testing.T) {
+	pattern := regexp.MustCompile(`^GZ-[A-Z]+-\d{3}$`)
+	seen := map[string]ErrorCode{}
+
+	for code, info := range Codes() {
+		assert.Regexp(t, pattern, info.ID, code)
+		assert.Contains(t, info.ID, "-"+string(info.Category)+"-", code)
+		if other, dup := seen[info.ID]; dup {
+			t.Errorf("%s is used by both %s and %s", info.ID, code, other)
+		}
+		seen[info.ID] = code
+	}
+
+	// 공개된 코드 번호는 바뀌면 안 된다
+	assert.Equal(t, "GZ-GIT-003", LookupCode(ErrorCodeGitOperationFailed).ID)
+	assert.Equal(t, "GZ-AUTH-004", LookupCode(ErrorCodeAuthFailed).ID)
+	assert.Equal(t, "GZ-SYS-000", LookupCode("NOT_REGISTERED").ID)
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{"standard", fmt.Errorf("sync: %w", NewStandardError(ErrorCodeCloneFailed, "clone", SeverityHigh)), ErrorCodeCloneFailed},
+		{"recoverable", NewRecoverableError(ErrorTypeRateLimit, "limited", nil, true), ErrorCodeRateLimitExceeded},
+		{"sentinel", Wrap(os.ErrClosed, ErrConfigNotFound), ErrorCodeConfigNotFound},
+		{"deadline", fmt.Errorf("fetch: %w", context.DeadlineExceeded), ErrorCodeTimeout},
+		{"canceled", context.Canceled, ErrorCodeCanceled},
+		{"not exist", &os.PathError{Op: "open", Path: "x", Err: os.ErrNotExist}, ErrorCodeFileNotFound},
+		{"usage", fmt.Errorf("unknown flag: --nope"), ErrorCodeUsage},
+		{"plain", fmt.Errorf("something broke"), ErrorCodeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Classify(tt.err))
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	err := NewNetworkError("api unreachable", context.DeadlineExceeded).WithContext("host", "api.github.com")
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, fmt.Errorf("list repos: %w", err)))
+
+	var out struct {
+		Error Envelope `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "GZ-NET-002", out.Error.Code)
+	assert.Equal(t, CategoryNetwork, out.Error.Category)
+	assert.True(t, out.Error.Retryable)
+	assert.Contains(t, out.Error.Actions, "Check network connectivity")
+	assert.Equal(t, "api.github.com", out.Error.Context["host"])
+	assert.Contains(t, out.Error.Message, "list repos")
+}
+
+func TestHints(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, i18n.SetLocale(i18n.DefaultLocale)) })
+
+	usage := fmt.Errorf("unknown command \"nope\" for \"gz\"")
+	assert.Equal(t, []string{"Run the command with --help for usage"}, Hints(usage))
+	assert.Empty(t, Hints(fmt.Errorf("boom")), "unclassified errors have no hints")
+	assert.Equal(t, "GZ-INPUT-004", Code(usage))
+	assert.Empty(t, Code(fmt.Errorf("boom")))
+
+	require.NoError(t, i18n.SetLocale("ko"))
+	assert.Equal(t, []string{"--help로 사용법을 확인하세요"}, Hints(usage))
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, ExitCode(nil))
+	assert.Equal(t, 2, ExitCode(fmt.Errorf("execution failed: %w", fmt.Errorf("unknown command \"nope\" for \"gz\""))))
+	assert.Equal(t, 3, ExitCode(NewAuthError("bad token", nil)))
+	assert.Equal(t, 1, ExitCode(NewStandardError(ErrorCodeGitOperationFailed, "push", SeverityHigh)))
+}
+
+func TestReported(t *testing.T) {
+	err := Reported(fmt.Errorf("boom"))
+	assert.True(t, IsReported(fmt.Errorf("wrapped: %w", err)))
+	assert.False(t, IsReported(fmt.Errorf("boom")))
+	assert.NoError(t, Reported(nil))
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/gizzahub/gzh-cli/pkg/i18n"
+)
+
+// Envelope is the machine-readable form of a command failure, written when
+// commands run with --format json.
+type Envelope struct {
+	Code      string         `json:"code"`
+	Name      ErrorCode      `json:"name"`
+	Category  Category       `json:"category"`
+	Message   string         `json:"message"`
+	Retryable bool           `json:"retryable"`
+	Actions   []string       `json:"actions,omitempty"`
+	Context   map[string]any `json:"context,omitempty"`
+}
+
+// NewEnvelope classifies err and builds its envelope. Suggestions and
+// retryability set on a StandardError take precedence over the registry.
+func NewEnvelope(err error) *Envelope {
+	code := Classify(err)
+	info := LookupCode(code)
+
+	env := &Envelope{
+		Code:      info.ID,
+		Name:      code,
+		Category:  info.Category,
+		Message:   err.Error(),
+		Retryable: info.Retryable,
+		Actions:   info.Actions,
+	}
+
+	var stdErr *StandardError
+	if errors.As(err, &stdErr) {
+		env.Retryable = stdErr.Retryable
+		if len(stdErr.Suggestions) > 0 {
+			env.Actions = stdErr.Suggestions
+		}
+		if len(stdErr.Context) > 0 {
+			env.Context = stdErr.Context
+		}
+	}
+
+	var recErr *RecoverableError
+	if errors.As(err, &recErr) {
+		env.Retryable = recErr.Retryable
+	}
+
+	env.Actions = localize(env.Actions)
+
+	return env
+}
+
+// Hints returns the suggested actions for err in the current locale, for
+// printing below a text error. Unclassified errors have no hints.
+func Hints(err error) []string {
+	if err == nil || Classify(err) == ErrorCodeUnknown {
+		return nil
+	}
+	return NewEnvelope(err).Actions
+}
+
+// DocsURL is the reference of the error codes and exit codes.
+const DocsURL = "https://github.com/gizzahub/gzh-cli/blob/main/docs/50-api-reference/50-command-reference.md#machine-readable-errors"
+
+// Code returns the stable identifier of err, e.g. GZ-NET-003, or "" for
+// unclassified errors.
+func Code(err error) string {
+	if err == nil || Classify(err) == ErrorCodeUnknown {
+		return ""
+	}
+	return LookupCode(Classify(err)).ID
+}
+
+func localize(messages []string) []string {
+	if len(messages) == 0 {
+		return messages
+	}
+	out := make([]string, len(messages))
+	for i, msg := range messages {
+		out[i] = i18n.T(msg)
+	}
+	return out
+}
+
+// WriteJSON writes err as {"error": {...}} to w.
+func WriteJSON(w io.Writer, err error) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(struct {
+		Error *Envelope `json:"error"`
+	}{NewEnvelope(err)})
+}
+
+// exitCodes are the documented process exit codes per category.
+var exitCodes = map[Category]int{
+	CategoryInput:      2,
+	CategoryAuth:       3,
+	CategoryNetwork:    4,
+	CategoryConfig:     5,
+	CategoryFileSystem: 6,
+}
+
+// ExitCode returns the process exit code for err: 0 on success, a category
+// specific code where one is defined and 1 otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if code, ok := exitCodes[LookupCode(Classify(err)).Category]; ok {
+		return code
+	}
+	return 1
+}
+
+// reportedError marks an error that has already been written to the user.
+type reportedError struct {
+	err error
+}
+
+func (e *reportedError) Error() string { return e.err.Error() }
+func (e *reportedError) Unwrap() error { return e.err }
+
+// Reported marks err as already written so callers up the stack do not print it again.
+func Reported(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &reportedError{err: err}
+}
+
+// IsReported reports whether err was marked with Reported.
+func IsReported(err error) bool {
+	var r *reportedError
+	return errors.As(err, &r)
+}
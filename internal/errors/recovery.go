@@ -247,14 +247,25 @@ func (er *ErrorRecovery) calculateBackoff(attempt int) time.Duration {
 	return delay
 }
 
+// maxBreakerTransitions is how many state transitions a breaker remembers.
+const maxBreakerTransitions = 10
+
 // CircuitBreaker implements a simple circuit breaker pattern.
 type CircuitBreaker struct {
+	name        string
 	maxFailures int
 	resetTime   time.Duration
 	failures    int
 	lastFailure time.Time
 	state       CircuitState
-	mu          sync.RWMutex
+	forced      bool
+
+	totalFailures  int64
+	totalSuccesses int64
+	rejected       int64
+	transitions    []BreakerTransition
+
+	mu sync.RWMutex
 }
 
 // CircuitState represents the state of a circuit breaker.
@@ -282,6 +293,19 @@ func (s CircuitState) String() string {
 	}
 }
 
+// MarshalText encodes the state by name.
+func (s CircuitState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// BreakerTransition records a circuit breaker state change.
+type BreakerTransition struct {
+	From   CircuitState `json:"from"`
+	To     CircuitState `json:"to"`
+	At     time.Time    `json:"at"`
+	Reason string       `json:"reason"`
+}
+
 // NewCircuitBreaker creates a new circuit breaker.
 func NewCircuitBreaker(maxFailures int, resetTime time.Duration) *CircuitBreaker {
 	return &CircuitBreaker{
@@ -303,21 +327,27 @@ func (cb *CircuitBreaker) Execute(fn func() error) error {
 	return err
 }
 
-// allowRequest checks if a request should be allowed.
+// allowRequest checks if a request should be allowed. An open breaker lets
+// a trial request through once the reset time has passed.
 func (cb *CircuitBreaker) allowRequest() bool {
-	cb.mu.RLock()
-	defer cb.mu.RUnlock()
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
 
+	allowed := false
 	switch cb.state {
-	case StateClosed:
-		return true
+	case StateClosed, StateHalfOpen:
+		allowed = true
 	case StateOpen:
-		return time.Since(cb.lastFailure) > cb.resetTime
-	case StateHalfOpen:
-		return true
-	default:
-		return false
+		if !cb.forced && time.Since(cb.lastFailure) > cb.resetTime {
+			cb.transition(StateHalfOpen, "reset timeout elapsed")
+			allowed = true
+		}
+	}
+
+	if !allowed {
+		cb.rejected++
 	}
+	return allowed
 }
 
 // recordResult records the result of an operation.
@@ -327,17 +357,47 @@ func (cb *CircuitBreaker) recordResult(err error) {
 
 	if err != nil {
 		cb.failures++
+		cb.totalFailures++
 		cb.lastFailure = time.Now()
 
-		if cb.failures >= cb.maxFailures {
-			cb.state = StateOpen
+		if cb.forced {
+			return
+		}
+		switch {
+		case cb.state == StateHalfOpen:
+			cb.transition(StateOpen, "trial request failed")
+		case cb.state == StateClosed && cb.failures >= cb.maxFailures:
+			cb.transition(StateOpen, fmt.Sprintf("%d consecutive failures", cb.failures))
 		}
 	} else {
 		cb.failures = 0
-		cb.state = StateClosed
+		cb.totalSuccesses++
+
+		if !cb.forced && cb.state != StateClosed {
+			cb.transition(StateClosed, "request succeeded")
+		}
 	}
 }
 
+// transition changes the state and records the change. The caller must hold
+// the write lock.
+func (cb *CircuitBreaker) transition(to CircuitState, reason string) {
+	if cb.state == to {
+		return
+	}
+	cb.setState(to, reason)
+}
+
+// setState records a state change even if the state stays the same, so
+// that manual interventions always show up in the transition history.
+func (cb *CircuitBreaker) setState(to CircuitState, reason string) {
+	cb.transitions = append(cb.transitions, BreakerTransition{From: cb.state, To: to, At: time.Now(), Reason: reason})
+	if len(cb.transitions) > maxBreakerTransitions {
+		cb.transitions = cb.transitions[len(cb.transitions)-maxBreakerTransitions:]
+	}
+	cb.state = to
+}
+
 // GetState returns the current state of the circuit breaker.
 func (cb *CircuitBreaker) GetState() CircuitState {
 	cb.mu.RLock()
@@ -346,6 +406,84 @@ func (cb *CircuitBreaker) GetState() CircuitState {
 	return cb.state
 }
 
+// ForceOpen opens the breaker and keeps it open, rejecting every request,
+// until ForceClose or Reset is called.
+func (cb *CircuitBreaker) ForceOpen(reason string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.setState(StateOpen, forcedReason("forced open", reason))
+	cb.forced = true
+}
+
+// ForceClose closes the breaker and keeps it closed, letting every request
+// through regardless of failures, until ForceOpen or Reset is called.
+func (cb *CircuitBreaker) ForceClose(reason string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.setState(StateClosed, forcedReason("forced closed", reason))
+	cb.forced = true
+	cb.failures = 0
+}
+
+// Reset returns the breaker to normal operation in the closed state and
+// clears the consecutive failure count.
+func (cb *CircuitBreaker) Reset(reason string) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.forced = false
+	cb.setState(StateClosed, forcedReason("reset", reason))
+	cb.failures = 0
+}
+
+func forcedReason(action, reason string) string {
+	if reason == "" {
+		return action
+	}
+	return action + ": " + reason
+}
+
+// BreakerSnapshot is a point-in-time view of a circuit breaker.
+type BreakerSnapshot struct {
+	Name           string              `json:"name"`
+	State          CircuitState        `json:"state"`
+	Forced         bool                `json:"forced"`
+	Failures       int                 `json:"failures"`
+	MaxFailures    int                 `json:"maxFailures"`
+	ResetTimeout   string              `json:"resetTimeout"`
+	TotalFailures  int64               `json:"totalFailures"`
+	TotalSuccesses int64               `json:"totalSuccesses"`
+	Rejected       int64               `json:"rejected"`
+	LastFailure    *time.Time          `json:"lastFailure,omitempty"`
+	Transitions    []BreakerTransition `json:"transitions"`
+}
+
+// Snapshot returns the current state and counters of the breaker.
+func (cb *CircuitBreaker) Snapshot() BreakerSnapshot {
+	cb.mu.RLock()
+	defer cb.mu.RUnlock()
+
+	s := BreakerSnapshot{
+		Name:           cb.name,
+		State:          cb.state,
+		Forced:         cb.forced,
+		Failures:       cb.failures,
+		MaxFailures:    cb.maxFailures,
+		ResetTimeout:   cb.resetTime.String(),
+		TotalFailures:  cb.totalFailures,
+		TotalSuccesses: cb.totalSuccesses,
+		Rejected:       cb.rejected,
+		Transitions:    append([]BreakerTransition{}, cb.transitions...),
+	}
+	if !cb.lastFailure.IsZero() {
+		lastFailure := cb.lastFailure
+		s.LastFailure = &lastFailure
+	}
+	return s
+}
+
 // GetMemoryStats returns current memory statistics.
 func GetMemoryStats() map[string]any {
 	var m runtime.MemStats
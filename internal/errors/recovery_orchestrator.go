@@ -0,0 +1,452 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+)
+
+// Components with standard recovery dependencies. The provider client is
+// built on the response cache, and the worker pool runs jobs that call
+// the provider client, so they recover in that order.
+const (
+	ComponentCache          = "cache"
+	ComponentProviderClient = "provider-client"
+	ComponentWorkerPool     = "worker-pool"
+)
+
+// StandardRecoveryDependencies maps each standard component to the
+// components it depends on.
+var StandardRecoveryDependencies = map[string][]string{
+	ComponentCache:          nil,
+	ComponentProviderClient: {ComponentCache},
+	ComponentWorkerPool:     {ComponentProviderClient},
+}
+
+// DefaultRecoveryStepTimeout bounds a step that sets no timeout.
+const DefaultRecoveryStepTimeout = 30 * time.Second
+
+// RecoveryStep recovers one component.
+type RecoveryStep struct {
+	// Component names the component.
+	Component string
+
+	// DependsOn lists the components that must recover first. When a
+	// component fails, the components depending on it are recovered too.
+	DependsOn []string
+
+	// Recover restores the component.
+	Recover func(ctx context.Context) error
+
+	// Verify optionally checks that the component works after Recover.
+	Verify func(ctx context.Context) error
+
+	// Rollback optionally undoes Recover when the run fails, so that
+	// components are not left half-recovered.
+	Rollback func(ctx context.Context) error
+
+	// Timeout bounds Recover and Verify together. Defaults to
+	// DefaultRecoveryStepTimeout.
+	Timeout time.Duration
+}
+
+// RecoveryStatus is the state of a recovery run or one of its steps.
+type RecoveryStatus string
+
+// Recovery states.
+const (
+	RecoveryPending    RecoveryStatus = "pending"
+	RecoveryRunning    RecoveryStatus = "running"
+	RecoveryRecovered  RecoveryStatus = "recovered"
+	RecoveryFailed     RecoveryStatus = "failed"
+	RecoveryRolledBack RecoveryStatus = "rolled_back"
+	RecoverySkipped    RecoveryStatus = "skipped"
+)
+
+// RecoveryStepResult is the outcome of one step of a run.
+type RecoveryStepResult struct {
+	Component     string         `json:"component"`
+	Status        RecoveryStatus `json:"status"`
+	Started       *time.Time     `json:"started,omitempty"`
+	Duration      string         `json:"duration,omitempty"`
+	Error         string         `json:"error,omitempty"`
+	RollbackError string         `json:"rollbackError,omitempty"`
+}
+
+// RecoveryReport describes a recovery run. Steps are in execution order.
+type RecoveryReport struct {
+	Failed   []string             `json:"failed"`
+	Status   RecoveryStatus       `json:"status"`
+	Started  time.Time            `json:"started"`
+	Finished *time.Time           `json:"finished,omitempty"`
+	Done     int                  `json:"done"`
+	Total    int                  `json:"total"`
+	Steps    []RecoveryStepResult `json:"steps"`
+}
+
+func (r *RecoveryReport) clone() *RecoveryReport {
+	c := *r
+	c.Failed = append([]string(nil), r.Failed...)
+	c.Steps = append([]RecoveryStepResult(nil), r.Steps...)
+	return &c
+}
+
+// RecoveryOrchestrator recovers failed components in dependency order.
+// Each step is verified before its dependents run; when a step fails, it
+// and the steps recovered before it are rolled back in reverse order and
+// the remaining steps are skipped. Runs are serialized.
+type RecoveryOrchestrator struct {
+	mu    sync.RWMutex
+	steps map[string]RecoveryStep
+	last  *RecoveryReport
+
+	runMu sync.Mutex
+}
+
+// DefaultRecovery is the orchestrator served by the debug HTTP server.
+var DefaultRecovery = NewRecoveryOrchestrator()
+
+// NewRecoveryOrchestrator creates an orchestrator without steps.
+func NewRecoveryOrchestrator() *RecoveryOrchestrator {
+	return &RecoveryOrchestrator{steps: make(map[string]RecoveryStep)}
+}
+
+// Register adds the recovery step of a component.
+func (o *RecoveryOrchestrator) Register(step RecoveryStep) error {
+	if step.Component == "" {
+		return errors.New("recovery step has no component")
+	}
+	if step.Recover == nil {
+		return fmt.Errorf("recovery step %q has no recover function", step.Component)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.steps[step.Component]; ok {
+		return fmt.Errorf("recovery step %q is already registered", step.Component)
+	}
+	o.steps[step.Component] = step
+	return nil
+}
+
+// Plan returns the components to recover when failed components fail: the
+// failed components and everything depending on them, in topological
+// order. Components without an order between them are sorted by name.
+func (o *RecoveryOrchestrator) Plan(failed ...string) ([]string, error) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	return o.plan(failed)
+}
+
+func (o *RecoveryOrchestrator) plan(failed []string) ([]string, error) {
+	dependents := make(map[string][]string, len(o.steps))
+	for name, step := range o.steps {
+		for _, dep := range step.DependsOn {
+			if _, ok := o.steps[dep]; !ok {
+				return nil, fmt.Errorf("recovery step %q depends on unregistered component %q", name, dep)
+			}
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+
+	// 실패한 컴포넌트에 의존하는 컴포넌트도 함께 복구한다
+	selected := make(map[string]bool)
+	queue := make([]string, 0, len(failed))
+	for _, name := range failed {
+		if _, ok := o.steps[name]; !ok {
+			return nil, fmt.Errorf("no recovery step registered for component %q", name)
+		}
+		queue = append(queue, name)
+	}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if selected[name] {
+			continue
+		}
+		selected[name] = true
+		queue = append(queue, dependents[name]...)
+	}
+
+	indegree := make(map[string]int, len(selected))
+	for name := range selected {
+		for _, dep := range o.steps[name].DependsOn {
+			if selected[dep] {
+				indegree[name]++
+			}
+		}
+	}
+
+	var ready []string
+	for name := range selected {
+		if indegree[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	order := make([]string, 0, len(selected))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+		for _, dependent := range dependents[name] {
+			if !selected[dependent] {
+				continue
+			}
+			indegree[dependent]--
+			if indegree[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(selected) {
+		var cycle []string
+		for name := range selected {
+			if indegree[name] > 0 {
+				cycle = append(cycle, name)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, fmt.Errorf("recovery dependency cycle between %s", strings.Join(cycle, ", "))
+	}
+	return order, nil
+}
+
+// Run recovers the failed components and their dependents. The report is
+// returned even when recovery fails; the error names the first step that
+// failed. Progress can be followed with Snapshot while Run executes.
+func (o *RecoveryOrchestrator) Run(ctx context.Context, failed ...string) (*RecoveryReport, error) {
+	o.runMu.Lock()
+	defer o.runMu.Unlock()
+
+	o.mu.RLock()
+	order, err := o.plan(failed)
+	steps := make([]RecoveryStep, len(order))
+	for i, name := range order {
+		steps[i] = o.steps[name]
+	}
+	o.mu.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan recovery: %w", err)
+	}
+
+	report := &RecoveryReport{
+		Failed:  append([]string(nil), failed...),
+		Status:  RecoveryRunning,
+		Started: time.Now(),
+		Total:   len(steps),
+		Steps:   make([]RecoveryStepResult, len(steps)),
+	}
+	for i, name := range order {
+		report.Steps[i] = RecoveryStepResult{Component: name, Status: RecoveryPending}
+	}
+	o.publish(report)
+
+	var runErr error
+	for i, step := range steps {
+		if runErr = o.runStep(ctx, report, i, step); runErr != nil {
+			o.rollback(report, steps, i)
+			break
+		}
+	}
+
+	finished := time.Now()
+	o.update(report, func() {
+		report.Finished = &finished
+		report.Status = RecoveryRecovered
+		if runErr != nil {
+			report.Status = RecoveryFailed
+		}
+	})
+	return o.Snapshot(), runErr
+}
+
+func (o *RecoveryOrchestrator) runStep(ctx context.Context, report *RecoveryReport, i int, step RecoveryStep) error {
+	started := time.Now()
+	o.update(report, func() {
+		report.Steps[i].Status = RecoveryRunning
+		report.Steps[i].Started = &started
+	})
+
+	timeout := step.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRecoveryStepTimeout
+	}
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := callWithContext(stepCtx, step.Recover)
+	if err != nil {
+		err = fmt.Errorf("failed to recover %s: %w", step.Component, err)
+	} else if step.Verify != nil {
+		if verr := callWithContext(stepCtx, step.Verify); verr != nil {
+			err = fmt.Errorf("failed to verify %s: %w", step.Component, verr)
+		}
+	}
+
+	elapsed := time.Since(started)
+	metrics.RecoverySteps.With(step.Component, metrics.ResultLabel(err)).Inc()
+	metrics.RecoveryStepDuration.With(step.Component).Observe(elapsed.Seconds())
+
+	o.update(report, func() {
+		report.Steps[i].Duration = elapsed.Round(time.Millisecond).String()
+		report.Done++
+		report.Steps[i].Status = RecoveryRecovered
+		if err != nil {
+			report.Steps[i].Status = RecoveryFailed
+			report.Steps[i].Error = err.Error()
+		}
+	})
+	return err
+}
+
+// rollback undoes the failed step and the steps recovered before it, in
+// reverse order, and skips the steps after it. Rollbacks get their own
+// timeout so they also run when the run's context has ended.
+func (o *RecoveryOrchestrator) rollback(report *RecoveryReport, steps []RecoveryStep, failed int) {
+	for i := failed + 1; i < len(steps); i++ {
+		o.update(report, func() { report.Steps[i].Status = RecoverySkipped })
+	}
+
+	for i := failed; i >= 0; i-- {
+		step := steps[i]
+		if step.Rollback == nil {
+			continue
+		}
+
+		timeout := step.Timeout
+		if timeout <= 0 {
+			timeout = DefaultRecoveryStepTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := callWithContext(ctx, step.Rollback)
+		cancel()
+
+		o.update(report, func() {
+			if err != nil {
+				report.Steps[i].RollbackError = err.Error()
+				return
+			}
+			if i != failed {
+				report.Steps[i].Status = RecoveryRolledBack
+			}
+		})
+	}
+}
+
+// callWithContext runs fn and returns early with the context's error when
+// fn does not return before ctx ends.
+func callWithContext(ctx context.Context, fn func(ctx context.Context) error) error {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (o *RecoveryOrchestrator) publish(report *RecoveryReport) {
+	o.update(report, func() {})
+}
+
+// update applies change to report and publishes a copy for Snapshot.
+func (o *RecoveryOrchestrator) update(report *RecoveryReport, change func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	change()
+	o.last = report.clone()
+	if report.Total > 0 {
+		metrics.RecoveryProgress.With().Set(float64(report.Done) / float64(report.Total))
+	}
+}
+
+// Snapshot returns the report of the running or last run, or nil when
+// nothing has run yet.
+func (o *RecoveryOrchestrator) Snapshot() *RecoveryReport {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	if o.last == nil {
+		return nil
+	}
+	return o.last.clone()
+}
+
+// RecoveryStepInfo describes a registered step.
+type RecoveryStepInfo struct {
+	Component string   `json:"component"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+	Timeout   string   `json:"timeout"`
+}
+
+// Steps returns the registered steps, sorted by component.
+func (o *RecoveryOrchestrator) Steps() []RecoveryStepInfo {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	infos := make([]RecoveryStepInfo, 0, len(o.steps))
+	for _, step := range o.steps {
+		timeout := step.Timeout
+		if timeout <= 0 {
+			timeout = DefaultRecoveryStepTimeout
+		}
+		infos = append(infos, RecoveryStepInfo{
+			Component: step.Component,
+			DependsOn: append([]string(nil), step.DependsOn...),
+			Timeout:   timeout.String(),
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Component < infos[j].Component })
+	return infos
+}
+
+// RecoveryHandlerPath is where the debug HTTP server mounts the recovery API.
+const RecoveryHandlerPath = "/debug/recovery/"
+
+// NewRecoveryHandler returns the recovery progress API:
+//
+//	GET /debug/recovery/        report of the running or last run
+//	GET /debug/recovery/steps   registered steps and their dependencies
+func NewRecoveryHandler(o *RecoveryOrchestrator) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+RecoveryHandlerPath+"{$}", func(w http.ResponseWriter, _ *http.Request) {
+		report := o.Snapshot()
+		if report == nil {
+			writeBreakerError(w, http.StatusNotFound, "no recovery has run")
+			return
+		}
+		writeBreakerJSON(w, http.StatusOK, report)
+	})
+
+	mux.HandleFunc("GET "+RecoveryHandlerPath+"steps", func(w http.ResponseWriter, _ *http.Request) {
+		writeBreakerJSON(w, http.StatusOK, o.Steps())
+	})
+
+	return mux
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recoveryLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (l *recoveryLog) add(call string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, call)
+}
+
+func (l *recoveryLog) step(component string, recoverErr error) RecoveryStep {
+	return RecoveryStep{
+		Component: component,
+		DependsOn: StandardRecoveryDependencies[component],
+		Recover: func(context.Context) error {
+			l.add("recover " + component)
+			return recoverErr
+		},
+		Verify: func(context.Context) error {
+			l.add("verify " + component)
+			return nil
+		},
+		Rollback: func(context.Context) error {
+			l.add("rollback " + component)
+			return nil
+		},
+	}
+}
+
+func TestRecoveryOrchestratorPlan(t *testing.T) {
+	var log recoveryLog
+	o := NewRecoveryOrchestrator()
+	require.NoError(t, o.Register(log.step(ComponentWorkerPool, nil)))
+	require.NoError(t, o.Register(log.step(ComponentProviderClient, nil)))
+	require.NoError(t, o.Register(log.step(ComponentCache, nil)))
+	require.NoError(t, o.Register(RecoveryStep{Component: "audit", DependsOn: []string{ComponentCache}, Recover: func(context.Context) error { return nil }}))
+
+	order, err := o.Plan(ComponentWorkerPool, ComponentCache)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ComponentCache, "audit", ComponentProviderClient, ComponentWorkerPool}, order)
+
+	order, err = o.Plan(ComponentProviderClient)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ComponentProviderClient, ComponentWorkerPool}, order, "dependents recover too, dependencies do not")
+
+	assert.ErrorContains(t, o.Register(log.step(ComponentCache, nil)), "already registered")
+	_, err = o.Plan("database")
+	assert.ErrorContains(t, err, "no recovery step registered")
+
+	cyclic := NewRecoveryOrchestrator()
+	noop := func(context.Context) error { return nil }
+	require.NoError(t, cyclic.Register(RecoveryStep{Component: "a", DependsOn: []string{"b"}, Recover: noop}))
+	require.NoError(t, cyclic.Register(RecoveryStep{Component: "b", DependsOn: []string{"a"}, Recover: noop}))
+	_, err = cyclic.Plan("a")
+	assert.ErrorContains(t, err, "cycle between a, b")
+}
+
+func TestRecoveryOrchestratorRun(t *testing.T) {
+	var log recoveryLog
+	o := NewRecoveryOrchestrator()
+	for _, component := range []string{ComponentCache, ComponentProviderClient, ComponentWorkerPool} {
+		require.NoError(t, o.Register(log.step(component, nil)))
+	}
+	assert.Nil(t, o.Snapshot())
+
+	report, err := o.Run(context.Background(), ComponentCache)
+	require.NoError(t, err)
+	assert.Equal(t, RecoveryRecovered, report.Status)
+	assert.Equal(t, 3, report.Done)
+	assert.Equal(t, []string{
+		"recover cache", "verify cache",
+		"recover provider-client", "verify provider-client",
+		"recover worker-pool", "verify worker-pool",
+	}, log.calls)
+	for _, step := range report.Steps {
+		assert.Equal(t, RecoveryRecovered, step.Status)
+	}
+}
+
+func TestRecoveryOrchestratorRollback(t *testing.T) {
+	var log recoveryLog
+	o := NewRecoveryOrchestrator()
+	require.NoError(t, o.Register(log.step(ComponentCache, nil)))
+	require.NoError(t, o.Register(log.step(ComponentProviderClient, errBoom)))
+	require.NoError(t, o.Register(log.step(ComponentWorkerPool, nil)))
+
+	report, err := o.Run(context.Background(), ComponentCache)
+	require.ErrorIs(t, err, errBoom)
+	assert.ErrorContains(t, err, "failed to recover provider-client")
+	assert.Equal(t, RecoveryFailed, report.Status)
+	assert.Equal(t, []string{
+		"recover cache", "verify cache", "recover provider-client",
+		"rollback provider-client", "rollback cache",
+	}, log.calls)
+	assert.Equal(t, RecoveryRolledBack, report.Steps[0].Status)
+	assert.Equal(t, RecoveryFailed, report.Steps[1].Status)
+	assert.Equal(t, RecoverySkipped, report.Steps[2].Status)
+}
+
+func TestRecoveryOrchestratorTimeout(t *testing.T) {
+	o := NewRecoveryOrchestrator()
+	require.NoError(t, o.Register(RecoveryStep{
+		Component: ComponentCache,
+		Recover:   func(context.Context) error { return nil },
+		Verify: func(context.Context) error {
+			// 컨텍스트를 무시하는 검증도 타임아웃으로 끝난다
+			time.Sleep(time.Second)
+			return nil
+		},
+		Timeout: 10 * time.Millisecond,
+	}))
+
+	_, err := o.Run(context.Background(), ComponentCache)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "failed to verify cache")
+}
+
+func TestRecoveryHandler(t *testing.T) {
+	o := NewRecoveryOrchestrator()
+	require.NoError(t, o.Register(RecoveryStep{Component: ComponentCache, Recover: func(context.Context) error { return nil }}))
+	srv := httptest.NewServer(NewRecoveryHandler(o))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + RecoveryHandlerPath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	_, err = o.Run(context.Background(), ComponentCache)
+	require.NoError(t, err)
+
+	resp, err = http.Get(srv.URL + RecoveryHandlerPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var report RecoveryReport
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.Equal(t, RecoveryRecovered, report.Status)
+	assert.Equal(t, 1, report.Total)
+
+	resp2, err := http.Get(srv.URL + RecoveryHandlerPath + "steps")
+	require.NoError(t, err)
+	defer resp2.Body.Close()
+	var steps []RecoveryStepInfo
+	require.NoError(t, json.NewDecoder(resp2.Body).Decode(&steps))
+	require.Len(t, steps, 1)
+	assert.Equal(t, DefaultRecoveryStepTimeout.String(), steps[0].Timeout)
+}
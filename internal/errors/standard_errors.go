@@ -176,16 +176,7 @@ func (e *StandardError) WithRetryable(retryable bool) *StandardError {
 
 // determineRetryability determines if an error should be retryable based on its code.
 func determineRetryability(code ErrorCode) bool {
-	retryableErrors := map[ErrorCode]bool{
-		ErrorCodeNetworkTimeout:    true,
-		ErrorCodeConnectionFailed:  true,
-		ErrorCodeRateLimitExceeded: true,
-		ErrorCodeAPIUnavailable:    true,
-		ErrorCodeTimeout:           true,
-		ErrorCodeResourceExhausted: true,
-		ErrorCodeIOError:           true,
-	}
-	return retryableErrors[code]
+	return LookupCode(code).Retryable
 }
 
 // captureStackTrace captures the current stack trace.
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package filesystem abstracts the file operations used for configuration
+// and clone bookkeeping so that tests can substitute an in-memory
+// implementation with snapshots and injected faults.
+package filesystem
+
+import (
+	"io/fs"
+	"os"
+)
+
+// FileSystem is the set of file operations code under test may depend on.
+type FileSystem interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(path string, perm fs.FileMode) error
+	Stat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	Rename(oldpath, newpath string) error
+	Remove(name string) error
+	RemoveAll(path string) error
+}
+
+// OSFileSystem implements FileSystem with the os package.
+type OSFileSystem struct{}
+
+// Ensure implementations satisfy FileSystem.
+var (
+	_ FileSystem = OSFileSystem{}
+	_ FileSystem = (*MemoryFileSystem)(nil)
+)
+
+// OS returns the FileSystem backed by the operating system.
+func OS() FileSystem {
+	return OSFileSystem{}
+}
+
+// ReadFile reads the named file.
+func (OSFileSystem) ReadFile(name string) ([]byte, error) { return os.ReadFile(name) }
+
+// WriteFile writes data to the named file.
+func (OSFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+// MkdirAll creates a directory and its parents.
+func (OSFileSystem) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+
+// Stat returns file information.
+func (OSFileSystem) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+
+// ReadDir lists a directory sorted by name.
+func (OSFileSystem) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+
+// Rename moves a file or directory.
+func (OSFileSystem) Rename(oldpath, newpath string) error { return os.Rename(oldpath, newpath) }
+
+// Remove deletes a file or empty directory.
+func (OSFileSystem) Remove(name string) error { return os.Remove(name) }
+
+// RemoveAll deletes path and everything below it.
+func (OSFileSystem) RemoveAll(path string) error { return os.RemoveAll(path) }
+
+// Exists reports whether name exists in fsys.
+func Exists(fsys FileSystem, name string) bool {
+	_, err := fsys.Stat(name)
+	return err == nil
+}
+
+// WriteFileAtomic writes data to a temporary file next to name and renames
+// it into place, so readers never observe a partially written file.
+func WriteFileAtomic(fsys FileSystem, name string, data []byte, perm fs.FileMode) error {
+	tmp := name + ".tmp"
+	if err := fsys.WriteFile(tmp, data, perm); err != nil {
+		_ = fsys.Remove(tmp)
+		return err
+	}
+	if err := fsys.Rename(tmp, name); err != nil {
+		_ = fsys.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,422 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package filesystem
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Op identifies a file operation for fault injection and call counting.
+type Op string
+
+// File operations of MemoryFileSystem.
+const (
+	OpRead    Op = "read"
+	OpWrite   Op = "write"
+	OpMkdir   Op = "mkdir"
+	OpStat    Op = "stat"
+	OpReadDir Op = "readdir"
+	OpRename  Op = "rename"
+	OpRemove  Op = "remove"
+)
+
+// FaultRule makes matching operations slow or fail.
+type FaultRule struct {
+	Op      Op            // Operation to match; empty matches every operation
+	Pattern string        // path.Match pattern for the path; empty matches every path
+	After   int           // Number of matching operations that pass before the rule applies
+	Count   int           // Number of times to apply this rule (0 = infinite)
+	Err     error         // Error to return; nil only adds Delay
+	Delay   time.Duration // Latency added before the operation
+	Partial bool          // Writes store the first half of the data before failing
+	matched int           // Internal counter
+	applied int           // Internal counter
+}
+
+// memNode is an immutable file or directory. Writes replace nodes instead
+// of modifying them, so snapshots and branches can share them.
+type memNode struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (n *memNode) isDir() bool { return n.mode.IsDir() }
+
+// MemoryFileSystem is a concurrency-safe in-memory FileSystem for tests.
+// Its state can be snapshotted and restored, branched into independent
+// copy-on-write file systems and made to fail or stall with FaultRules.
+type MemoryFileSystem struct {
+	mu    sync.RWMutex
+	nodes map[string]*memNode
+
+	faultMu sync.Mutex
+	faults  []*FaultRule
+	calls   map[Op]int
+}
+
+// NewMemoryFileSystem creates an empty in-memory file system.
+func NewMemoryFileSystem() *MemoryFileSystem {
+	return &MemoryFileSystem{
+		nodes: make(map[string]*memNode),
+		calls: make(map[Op]int),
+	}
+}
+
+// Snapshot is a point-in-time copy of a MemoryFileSystem.
+type Snapshot struct {
+	nodes map[string]*memNode
+}
+
+// Snapshot captures the current contents. File data is shared, not copied.
+func (m *MemoryFileSystem) Snapshot() *Snapshot {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return &Snapshot{nodes: copyNodes(m.nodes)}
+}
+
+// Restore replaces the contents with a snapshot. A snapshot may be restored
+// any number of times. Fault rules and call counts are kept.
+func (m *MemoryFileSystem) Restore(s *Snapshot) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nodes = copyNodes(s.nodes)
+}
+
+// Branch returns an independent file system that starts with the current
+// contents. Changes to either side are not visible to the other. The branch
+// has no fault rules.
+func (m *MemoryFileSystem) Branch() *MemoryFileSystem {
+	branch := NewMemoryFileSystem()
+	branch.Restore(m.Snapshot())
+	return branch
+}
+
+func copyNodes(nodes map[string]*memNode) map[string]*memNode {
+	out := make(map[string]*memNode, len(nodes))
+	for k, v := range nodes {
+		out[k] = v
+	}
+	return out
+}
+
+// AddFault registers a fault rule. Rules are evaluated in order; the first
+// rule that returns an error decides the result, delays add up.
+func (m *MemoryFileSystem) AddFault(rule FaultRule) {
+	m.faultMu.Lock()
+	defer m.faultMu.Unlock()
+	m.faults = append(m.faults, &rule)
+}
+
+// FailNth makes the nth (1-based) op on paths matching pattern fail with EIO.
+func (m *MemoryFileSystem) FailNth(op Op, pattern string, n int) {
+	m.AddFault(FaultRule{Op: op, Pattern: pattern, After: n - 1, Count: 1, Err: syscall.EIO})
+}
+
+// AddLatency delays every op on paths matching pattern.
+func (m *MemoryFileSystem) AddLatency(op Op, pattern string, d time.Duration) {
+	m.AddFault(FaultRule{Op: op, Pattern: pattern, Delay: d})
+}
+
+// ClearFaults removes all fault rules.
+func (m *MemoryFileSystem) ClearFaults() {
+	m.faultMu.Lock()
+	defer m.faultMu.Unlock()
+	m.faults = nil
+}
+
+// Calls returns how often op was attempted, including failed attempts.
+func (m *MemoryFileSystem) Calls(op Op) int {
+	m.faultMu.Lock()
+	defer m.faultMu.Unlock()
+	return m.calls[op]
+}
+
+// fault applies the fault rules to an operation. It returns the error to
+// report and whether a write should be stored partially.
+func (m *MemoryFileSystem) fault(op Op, name string) (partial bool, err error) {
+	m.faultMu.Lock()
+	m.calls[op]++
+
+	var delay time.Duration
+	for _, rule := range m.faults {
+		if rule.Op != "" && rule.Op != op {
+			continue
+		}
+		if rule.Pattern != "" {
+			if ok, _ := path.Match(rule.Pattern, name); !ok {
+				continue
+			}
+		}
+		rule.matched++
+		if rule.matched <= rule.After || (rule.Count > 0 && rule.applied >= rule.Count) {
+			continue
+		}
+		rule.applied++
+		delay += rule.Delay
+		if err == nil && rule.Err != nil {
+			err = rule.Err
+			partial = rule.Partial
+		}
+	}
+	m.faultMu.Unlock()
+
+	// 지연은 잠금 밖에서 적용해 다른 고루틴의 작업을 막지 않는다
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	if err != nil {
+		return partial, &fs.PathError{Op: string(op), Path: name, Err: err}
+	}
+	return false, nil
+}
+
+// clean normalizes a path. Absolute paths are rooted at "/", relative ones
+// at ".".
+func clean(name string) string {
+	return path.Clean(filepath.ToSlash(name))
+}
+
+func isRoot(p string) bool { return p == "/" || p == "." }
+
+// parentDirLocked reports an error unless the parent of p is a directory.
+func (m *MemoryFileSystem) parentDirLocked(op Op, p string) error {
+	dir := path.Dir(p)
+	if isRoot(dir) {
+		return nil
+	}
+	parent, ok := m.nodes[dir]
+	if !ok {
+		return &fs.PathError{Op: string(op), Path: p, Err: fs.ErrNotExist}
+	}
+	if !parent.isDir() {
+		return &fs.PathError{Op: string(op), Path: p, Err: syscall.ENOTDIR}
+	}
+	return nil
+}
+
+// ReadFile returns a copy of the file contents.
+func (m *MemoryFileSystem) ReadFile(name string) ([]byte, error) {
+	p := clean(name)
+	if _, err := m.fault(OpRead, p); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	node, ok := m.nodes[p]
+	if !ok {
+		return nil, &fs.PathError{Op: string(OpRead), Path: p, Err: fs.ErrNotExist}
+	}
+	if node.isDir() {
+		return nil, &fs.PathError{Op: string(OpRead), Path: p, Err: syscall.EISDIR}
+	}
+	return append([]byte(nil), node.data...), nil
+}
+
+// WriteFile creates or replaces a file. The parent directory must exist.
+func (m *MemoryFileSystem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	p := clean(name)
+	partial, faultErr := m.fault(OpWrite, p)
+	if faultErr != nil && !partial {
+		return faultErr
+	}
+	if partial {
+		data = data[:len(data)/2]
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.parentDirLocked(OpWrite, p); err != nil {
+		return err
+	}
+	if existing, ok := m.nodes[p]; ok && existing.isDir() {
+		return &fs.PathError{Op: string(OpWrite), Path: p, Err: syscall.EISDIR}
+	}
+	m.nodes[p] = &memNode{data: append([]byte(nil), data...), mode: perm.Perm(), modTime: time.Now()}
+
+	return faultErr
+}
+
+// MkdirAll creates a directory and any missing parents.
+func (m *MemoryFileSystem) MkdirAll(name string, perm fs.FileMode) error {
+	p := clean(name)
+	if _, err := m.fault(OpMkdir, p); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var dirs []string
+	for dir := p; !isRoot(dir); dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		node, ok := m.nodes[dirs[i]]
+		if !ok {
+			m.nodes[dirs[i]] = &memNode{mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+			continue
+		}
+		if !node.isDir() {
+			return &fs.PathError{Op: string(OpMkdir), Path: dirs[i], Err: syscall.ENOTDIR}
+		}
+	}
+	return nil
+}
+
+// Stat returns file information.
+func (m *MemoryFileSystem) Stat(name string) (fs.FileInfo, error) {
+	p := clean(name)
+	if _, err := m.fault(OpStat, p); err != nil {
+		return nil, err
+	}
+
+	if isRoot(p) {
+		return &memFileInfo{name: p, node: &memNode{mode: fs.ModeDir | 0o755}}, nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	node, ok := m.nodes[p]
+	if !ok {
+		return nil, &fs.PathError{Op: string(OpStat), Path: p, Err: fs.ErrNotExist}
+	}
+	return &memFileInfo{name: path.Base(p), node: node}, nil
+}
+
+// ReadDir lists a directory sorted by name.
+func (m *MemoryFileSystem) ReadDir(name string) ([]fs.DirEntry, error) {
+	p := clean(name)
+	if _, err := m.fault(OpReadDir, p); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if !isRoot(p) {
+		node, ok := m.nodes[p]
+		if !ok {
+			return nil, &fs.PathError{Op: string(OpReadDir), Path: p, Err: fs.ErrNotExist}
+		}
+		if !node.isDir() {
+			return nil, &fs.PathError{Op: string(OpReadDir), Path: p, Err: syscall.ENOTDIR}
+		}
+	}
+
+	var entries []fs.DirEntry
+	for child, node := range m.nodes {
+		if path.Dir(child) == p && child != p {
+			entries = append(entries, fs.FileInfoToDirEntry(&memFileInfo{name: path.Base(child), node: node}))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return entries, nil
+}
+
+// Rename moves a file or a directory with its contents. An existing file at
+// newpath is replaced.
+func (m *MemoryFileSystem) Rename(oldpath, newpath string) error {
+	from, to := clean(oldpath), clean(newpath)
+	if _, err := m.fault(OpRename, from); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, ok := m.nodes[from]
+	if !ok {
+		return &fs.PathError{Op: string(OpRename), Path: from, Err: fs.ErrNotExist}
+	}
+	if err := m.parentDirLocked(OpRename, to); err != nil {
+		return err
+	}
+	if target, ok := m.nodes[to]; ok && target.isDir() != node.isDir() {
+		return &fs.PathError{Op: string(OpRename), Path: to, Err: syscall.EEXIST}
+	}
+
+	delete(m.nodes, from)
+	m.nodes[to] = node
+	if node.isDir() {
+		prefix := from + "/"
+		for child, n := range m.nodes {
+			if strings.HasPrefix(child, prefix) {
+				delete(m.nodes, child)
+				m.nodes[to+"/"+strings.TrimPrefix(child, prefix)] = n
+			}
+		}
+	}
+	return nil
+}
+
+// Remove deletes a file or an empty directory.
+func (m *MemoryFileSystem) Remove(name string) error {
+	p := clean(name)
+	if _, err := m.fault(OpRemove, p); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	node, ok := m.nodes[p]
+	if !ok {
+		return &fs.PathError{Op: string(OpRemove), Path: p, Err: fs.ErrNotExist}
+	}
+	if node.isDir() {
+		prefix := p + "/"
+		for child := range m.nodes {
+			if strings.HasPrefix(child, prefix) {
+				return &fs.PathError{Op: string(OpRemove), Path: p, Err: syscall.ENOTEMPTY}
+			}
+		}
+	}
+	delete(m.nodes, p)
+	return nil
+}
+
+// RemoveAll deletes path and everything below it. A missing path is not an error.
+func (m *MemoryFileSystem) RemoveAll(name string) error {
+	p := clean(name)
+	if _, err := m.fault(OpRemove, p); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prefix := p + "/"
+	for child := range m.nodes {
+		if child == p || strings.HasPrefix(child, prefix) || isRoot(p) {
+			delete(m.nodes, child)
+		}
+	}
+	return nil
+}
+
+// memFileInfo implements fs.FileInfo for a memNode.
+type memFileInfo struct {
+	name string
+	node *memNode
+}
+
+func (fi *memFileInfo) Name() string       { return fi.name }
+func (fi *memFileInfo) Size() int64        { return int64(len(fi.node.data)) }
+func (fi *memFileInfo) Mode() fs.FileMode  { return fi.node.mode }
+func (fi *memFileInfo) ModTime() time.Time { return fi.node.modTime }
+func (fi *memFileInfo) IsDir() bool        { return fi.node.isDir() }
+func (fi *memFileInfo) Sys() any           { return nil }
@@ -0,0 +1,172 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryFileSystem_Basics(t *testing.T) {
+	m := NewMemoryFileSystem()
+
+	require.NoError(t, m.MkdirAll("/config/gzh-manager", 0o755))
+	require.NoError(t, m.WriteFile("/config/gzh-manager/gzh.yaml", []byte("version: 1"), 0o600))
+
+	data, err := m.ReadFile("/config/gzh-manager/gzh.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "version: 1", string(data))
+
+	info, err := m.Stat("/config/gzh-manager")
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	entries, err := m.ReadDir("/config")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "gzh-manager", entries[0].Name())
+
+	_, err = m.ReadFile("/missing")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.Error(t, m.WriteFile("/nodir/file", nil, 0o600), "parent must exist")
+	assert.ErrorIs(t, m.Remove("/config"), syscall.ENOTEMPTY)
+
+	require.NoError(t, m.Rename("/config", "/etc"))
+	assert.True(t, Exists(m, "/etc/gzh-manager/gzh.yaml"))
+	assert.False(t, Exists(m, "/config/gzh-manager"))
+
+	require.NoError(t, m.RemoveAll("/etc"))
+	assert.False(t, Exists(m, "/etc/gzh-manager/gzh.yaml"))
+}
+
+func TestMemoryFileSystem_SnapshotRestore(t *testing.T) {
+	m := NewMemoryFileSystem()
+	require.NoError(t, m.MkdirAll("/repos", 0o755))
+	require.NoError(t, m.WriteFile("/repos/a", []byte("a1"), 0o644))
+
+	snap := m.Snapshot()
+
+	require.NoError(t, m.WriteFile("/repos/a", []byte("a2"), 0o644))
+	require.NoError(t, m.WriteFile("/repos/b", []byte("b"), 0o644))
+
+	m.Restore(snap)
+	data, err := m.ReadFile("/repos/a")
+	require.NoError(t, err)
+	assert.Equal(t, "a1", string(data))
+	assert.False(t, Exists(m, "/repos/b"))
+
+	// 같은 스냅샷을 여러 번 복원할 수 있어야 한다
+	require.NoError(t, m.Remove("/repos/a"))
+	m.Restore(snap)
+	assert.True(t, Exists(m, "/repos/a"))
+}
+
+func TestMemoryFileSystem_BranchIsIsolated(t *testing.T) {
+	m := NewMemoryFileSystem()
+	require.NoError(t, m.WriteFile("shared", []byte("base"), 0o644))
+
+	branch := m.Branch()
+	require.NoError(t, branch.WriteFile("shared", []byte("branch"), 0o644))
+	require.NoError(t, m.WriteFile("only-parent", nil, 0o644))
+
+	data, err := m.ReadFile("shared")
+	require.NoError(t, err)
+	assert.Equal(t, "base", string(data))
+	assert.False(t, Exists(branch, "only-parent"))
+}
+
+func TestMemoryFileSystem_FailNthWrite(t *testing.T) {
+	m := NewMemoryFileSystem()
+	require.NoError(t, WriteFileAtomic(m, "state.json", []byte("v1"), 0o600))
+
+	m.FailNth(OpWrite, "", 2)
+
+	require.NoError(t, WriteFileAtomic(m, "other.json", []byte("x"), 0o600))
+	err := WriteFileAtomic(m, "state.json", []byte("v2"), 0o600)
+	require.ErrorIs(t, err, syscall.EIO)
+
+	data, err := m.ReadFile("state.json")
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(data), "failed atomic write leaves the original intact")
+	assert.False(t, Exists(m, "state.json.tmp"))
+
+	require.NoError(t, WriteFileAtomic(m, "state.json", []byte("v3"), 0o600), "rule applies once")
+	assert.Equal(t, 4, m.Calls(OpWrite))
+}
+
+func TestMemoryFileSystem_PartialWrite(t *testing.T) {
+	m := NewMemoryFileSystem()
+	m.AddFault(FaultRule{Op: OpWrite, Pattern: "*.log", Err: syscall.ENOSPC, Partial: true})
+
+	err := m.WriteFile("out.log", []byte("12345678"), 0o644)
+	require.ErrorIs(t, err, syscall.ENOSPC)
+
+	data, err := m.ReadFile("out.log")
+	require.NoError(t, err)
+	assert.Equal(t, "1234", string(data))
+
+	require.NoError(t, m.WriteFile("out.txt", []byte("ok"), 0o644), "pattern does not match")
+}
+
+func TestMemoryFileSystem_Latency(t *testing.T) {
+	m := NewMemoryFileSystem()
+	require.NoError(t, m.WriteFile("slow", []byte("x"), 0o644))
+	m.AddLatency(OpRead, "slow", 30*time.Millisecond)
+
+	start := time.Now()
+	_, err := m.ReadFile("slow")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+
+	m.ClearFaults()
+	start = time.Now()
+	_, err = m.ReadFile("slow")
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 30*time.Millisecond)
+}
+
+func TestMemoryFileSystem_Concurrent(t *testing.T) {
+	m := NewMemoryFileSystem()
+	require.NoError(t, m.MkdirAll("/work", 0o755))
+	m.AddFault(FaultRule{Op: OpWrite, After: 50, Count: 10, Err: errors.New("injected")})
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures int
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				name := fmt.Sprintf("/work/%d-%d", i, j)
+				if err := m.WriteFile(name, []byte(name), 0o644); err != nil {
+					mu.Lock()
+					failures++
+					mu.Unlock()
+				}
+				_, _ = m.ReadFile(name)
+				_, _ = m.ReadDir("/work")
+				if j == 5 {
+					_ = m.Branch()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, failures)
+	entries, err := m.ReadDir("/work")
+	require.NoError(t, err)
+	assert.Len(t, entries, 190)
+}
@@ -0,0 +1,346 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package archival
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/testutil/mocks"
+	"github.com/gizzahub/gzh-cli/pkg/cloud"
+)
+
+const testPolicy = `
+repositories:
+  exclude: ["sandbox-*"]
+  exempt_topics: [keep]
+inactive_months: 12
+grace_days: 30
+notify:
+  labels: [archival]
+`
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	assert.Equal(t, NotifyIssue, p.Notify.Method)
+	assert.Equal(t, 30*24*time.Hour, p.GracePeriod())
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC), p.InactiveSince(now))
+
+	for _, bad := range []string{
+		"grace_days: 30\n",
+		"inactive_months: 6\ngrace_day: 30\n",
+		"inactive_months: 6\nnotify: {method: carrier-pigeon}\n",
+		"inactive_months: 6\nnotify: {method: email, email: {smtp: mail:25}}\n",
+		"inactive_months: 6\nnotify: {method: email, email: {smtp: mail:25, from: not-an-address}}\n",
+	} {
+		_, err := ParsePolicy([]byte(bad))
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestSelector(t *testing.T) {
+	s := Selector{Exclude: []string{"sandbox-*"}, ExemptTopics: []string{"keep"}}
+	assert.True(t, s.Matches(Repository{Name: "api"}))
+	assert.False(t, s.Matches(Repository{Name: "sandbox-1"}))
+	assert.False(t, s.Matches(Repository{Name: "old", Archived: true}))
+	assert.False(t, s.Matches(Repository{Name: "fork", Fork: true}))
+	assert.True(t, Selector{IncludeForks: true}.Matches(Repository{Name: "fork", Fork: true}))
+	assert.True(t, s.Exempt(Repository{Topics: []string{"go", "Keep"}}))
+	assert.False(t, s.Exempt(Repository{Topics: []string{"go"}}))
+}
+
+type fakeBackend struct {
+	repos    []Repository
+	activity map[string]Activity
+	issues   []Issue
+	closed   []int
+	archived []string
+}
+
+func (b *fakeBackend) Provider() string { return "github" }
+
+func (b *fakeBackend) GitAuth() (string, string) { return "", "" }
+
+func (b *fakeBackend) ListRepositories(context.Context, string) ([]Repository, error) {
+	return b.repos, nil
+}
+
+func (b *fakeBackend) LastActivity(_ context.Context, repo Repository) (Activity, error) {
+	return b.activity[repo.Name], nil
+}
+
+func (b *fakeBackend) Owners(_ context.Context, repo Repository) ([]Owner, error) {
+	return []Owner{{Login: "owner-of-" + repo.Name}}, nil
+}
+
+func (b *fakeBackend) OpenIssue(_ context.Context, _ Repository, issue Issue) (IssueRef, error) {
+	b.issues = append(b.issues, issue)
+	n := len(b.issues)
+	return IssueRef{Number: n, URL: fmt.Sprintf("https://example.test/issues/%d", n)}, nil
+}
+
+func (b *fakeBackend) CloseIssue(_ context.Context, _ Repository, number int, _ string) error {
+	b.closed = append(b.closed, number)
+	return nil
+}
+
+func (b *fakeBackend) Archive(_ context.Context, repo Repository) error {
+	b.archived = append(b.archived, repo.FullName)
+	return nil
+}
+
+type fakeBackup struct{ repos []string }
+
+func (f *fakeBackup) Backup(_ context.Context, repo Repository) (string, error) {
+	f.repos = append(f.repos, repo.FullName)
+	return "file:///backups/" + repo.FullName + ".bundle", nil
+}
+
+// auditActions returns the action and resource of each recorded event.
+func auditActions(r *mocks.AuditRecorder) []string {
+	var out []string
+	for _, e := range r.Events() {
+		out = append(out, e.Action+" "+e.Resource)
+	}
+	return out
+}
+
+func TestManagerLifecycle(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := start.AddDate(-2, 0, 0)
+	backend := &fakeBackend{
+		repos: []Repository{
+			{Name: "api", FullName: "acme/api", CreatedAt: old},
+			{Name: "legacy", FullName: "acme/legacy", CreatedAt: old},
+			{Name: "tools", FullName: "acme/tools", CreatedAt: old},
+			{Name: "pinned", FullName: "acme/pinned", CreatedAt: old, Topics: []string{"keep"}},
+			{Name: "sandbox-x", FullName: "acme/sandbox-x", CreatedAt: old},
+			{Name: "fresh", FullName: "acme/fresh", CreatedAt: start.AddDate(0, 0, -1)},
+		},
+		activity: map[string]Activity{
+			"api":    {LastCommit: start.AddDate(0, -1, 0)},
+			"legacy": {LastCommit: old, LastPullRequest: start.AddDate(-1, -1, 0)},
+			"tools":  {LastCommit: old},
+		},
+	}
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	recorder := &mocks.AuditRecorder{}
+	backup := &fakeBackup{}
+
+	run := func(now time.Time, dryRun bool) map[string]Result {
+		t.Helper()
+		state, err := LoadState(statePath, "github", "acme")
+		require.NoError(t, err)
+		m := NewManager(backend, policy, state, Options{
+			DryRun:   dryRun,
+			Backuper: backup,
+			Recorder: recorder,
+			Now:      func() time.Time { return now },
+		})
+		report, err := m.Run(context.Background(), "acme")
+		require.NoError(t, err)
+		results := make(map[string]Result)
+		for _, r := range report.Results {
+			results[r.Repository] = r
+		}
+		return results
+	}
+
+	// 미리보기는 아무것도 바꾸지 않는다
+	preview := run(start, true)
+	assert.Equal(t, StatusNotified, preview["acme/legacy"].Status)
+	assert.Empty(t, backend.issues)
+	assert.Empty(t, recorder.Events())
+
+	results := run(start, false)
+	assert.Equal(t, StatusActive, results["acme/api"].Status)
+	assert.Equal(t, StatusActive, results["acme/fresh"].Status)
+	assert.Equal(t, StatusExempt, results["acme/pinned"].Status)
+	assert.NotContains(t, results, "acme/sandbox-x")
+	assert.Equal(t, StatusNotified, results["acme/legacy"].Status)
+	assert.Equal(t, StatusNotified, results["acme/tools"].Status)
+	assert.Equal(t, start.AddDate(0, 0, 30), *results["acme/legacy"].ArchiveAfter)
+	require.Len(t, backend.issues, 2)
+	assert.Equal(t, []string{"archival"}, backend.issues[0].Labels)
+	assert.Equal(t, "owner-of-legacy", backend.issues[0].Assignees[0].Login)
+	assert.Contains(t, backend.issues[0].Body, "since 2024-05-01")
+
+	// 유예 기간 중에는 대기하고, 그 사이 활동이 생기면 통지를 철회한다
+	backend.activity["tools"] = Activity{LastPullRequest: start.AddDate(0, 0, 5)}
+	results = run(start.AddDate(0, 0, 10), false)
+	assert.Equal(t, StatusPending, results["acme/legacy"].Status)
+	assert.Equal(t, StatusReactivated, results["acme/tools"].Status)
+	assert.Equal(t, []int{2}, backend.closed)
+
+	results = run(start.AddDate(0, 0, 31), false)
+	assert.Equal(t, StatusArchived, results["acme/legacy"].Status)
+	assert.Equal(t, "file:///backups/acme/legacy.bundle", results["acme/legacy"].Backup)
+	assert.Equal(t, []string{"acme/legacy"}, backend.archived)
+	assert.Equal(t, []string{"acme/legacy"}, backup.repos)
+	assert.Equal(t, []int{2, 1}, backend.closed)
+
+	state, err := LoadState(statePath, "github", "acme")
+	require.NoError(t, err)
+	assert.Empty(t, state.Notices)
+
+	assert.Equal(t, []string{
+		ActionNotified + " github:acme/legacy",
+		ActionNotified + " github:acme/tools",
+		ActionWithdrawn + " github:acme/tools",
+		ActionBackedUp + " github:acme/legacy",
+		ActionArchived + " github:acme/legacy",
+	}, auditActions(recorder))
+	assert.NotEmpty(t, recorder.Events()[0].ID)
+	assert.Equal(t, "archival", recorder.Events()[0].Category)
+}
+
+func TestLoadStateRejectsOtherOrg(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	state, err := LoadState(path, "github", "acme")
+	require.NoError(t, err)
+	state.Notices["acme/api"] = &Notice{Method: NotifyNone}
+	require.NoError(t, state.Save())
+
+	_, err = LoadState(path, "gitlab", "acme")
+	assert.ErrorContains(t, err, "belongs to github:acme")
+}
+
+func TestEmailNotifier(t *testing.T) {
+	var sentTo []string
+	var sentMsg string
+	n := &emailNotifier{
+		cfg: EmailConfig{SMTP: "mail.example.com:587", From: "platform@example.com", Fallback: []string{"team@example.com"}},
+		send: func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+			assert.Equal(t, "mail.example.com:587", addr)
+			assert.Equal(t, "platform@example.com", from)
+			sentTo, sentMsg = to, string(msg)
+			return nil
+		},
+	}
+	repo := Repository{FullName: "acme/legacy"}
+	deadline := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	notice, err := n.Notify(context.Background(), repo, []Owner{{Login: "a", Email: "a@example.com"}, {Login: "b"}}, time.Time{}, deadline)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@example.com"}, sentTo)
+	assert.Equal(t, []string{"a@example.com"}, notice.Recipients)
+	assert.Contains(t, sentMsg, "Subject: acme/legacy will be archived on 2025-07-01\r\n")
+	assert.Contains(t, sentMsg, "since never")
+
+	_, err = n.Notify(context.Background(), repo, nil, time.Time{}, deadline)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team@example.com"}, sentTo, "owners without email fall back")
+}
+
+func TestGitHubBackend(t *testing.T) {
+	var archived, issue map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/orgs/acme/repos":
+			_, _ = w.Write([]byte(`[{"id":1,"name":"api","full_name":"acme/api","topics":["keep"],"pushed_at":"2024-01-02T00:00:00Z"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/api/pulls":
+			assert.Equal(t, "updated", r.URL.Query().Get("sort"))
+			_, _ = w.Write([]byte(`[{"updated_at":"2024-03-04T00:00:00Z"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/api/collaborators":
+			_, _ = w.Write([]byte(`[{"id":7,"login":"alice"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/users/alice":
+			_, _ = w.Write([]byte(`{"login":"alice","email":"alice@example.com"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/api/issues":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&issue))
+			_, _ = w.Write([]byte(`{"number":12,"html_url":"https://github.com/acme/api/issues/12"}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/repos/acme/api":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&archived))
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	b, err := NewBackend("github", srv.URL, "secret")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	repos, err := b.ListRepositories(ctx, "acme")
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, []string{"keep"}, repos[0].Topics)
+
+	activity, err := b.LastActivity(ctx, repos[0])
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), activity.Last())
+
+	owners, err := b.Owners(ctx, repos[0])
+	require.NoError(t, err)
+	assert.Equal(t, []Owner{{ID: 7, Login: "alice", Email: "alice@example.com"}}, owners)
+
+	ref, err := b.OpenIssue(ctx, repos[0], Issue{Title: "t", Assignees: owners, Labels: []string{"archival"}})
+	require.NoError(t, err)
+	assert.Equal(t, 12, ref.Number)
+	assert.Equal(t, []any{"alice"}, issue["assignees"])
+
+	require.NoError(t, b.Archive(ctx, repos[0]))
+	assert.Equal(t, true, archived["archived"])
+
+	_, err = NewBackend("bitbucket", "", "")
+	assert.Error(t, err)
+}
+
+func TestBundleBackup(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	src := filepath.Join(t.TempDir(), "src")
+	for _, args := range [][]string{
+		{"init", "--quiet", src},
+		{"-C", src, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "--quiet", "--allow-empty", "-m", "init"},
+	} {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	storeDir := t.TempDir()
+	store, err := cloud.NewFileStore(storeDir)
+	require.NoError(t, err)
+
+	location, err := NewBundleBackup(store, "github", "", "").Backup(context.Background(), Repository{FullName: "acme/src", CloneURL: src})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(location, store.String()+"/github/acme/src/"), location)
+	assert.True(t, strings.HasSuffix(location, ".bundle"))
+
+	objects, err := store.List(context.Background(), "github/acme/src/")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	out, err := exec.Command("git", "bundle", "verify", filepath.Join(storeDir, filepath.FromSlash(objects[0].Key))).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	// 커밋이 없는 저장소는 백업할 것이 없다
+	empty := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, exec.Command("git", "init", "--quiet", empty).Run())
+	location, err = NewBundleBackup(store, "github", "", "").Backup(context.Background(), Repository{FullName: "acme/empty", CloneURL: empty})
+	require.NoError(t, err)
+	assert.Empty(t, location)
+	_, err = os.Stat(filepath.Join(storeDir, "github", "acme", "empty"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package archival
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gizzahub/gzh-cli/internal/trace"
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+)
+
+// Audit actions recorded for each archival step.
+const (
+	ActionNotified  = "repository.archival.notified"
+	ActionWithdrawn = "repository.archival.withdrawn"
+	ActionBackedUp  = "repository.archival.backed_up"
+	ActionArchived  = "repository.archival.archived"
+)
+
+// FileRecorder appends audit events as JSON lines to a local file, so the
+// trail is kept even when no SIEM sink is configured.
+type FileRecorder struct {
+	mu   sync.Mutex
+	path string
+}
+
+// DefaultAuditLogPath returns the local archival audit log.
+func DefaultAuditLogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "gzh-manager", "archival", "audit.jsonl")
+}
+
+// NewFileRecorder creates a recorder appending to path.
+func NewFileRecorder(path string) *FileRecorder {
+	return &FileRecorder{path: path}
+}
+
+// Record appends event to the file, tagged with the run's trace ID.
+func (r *FileRecorder) Record(event audit.Event) error {
+	if event.TraceID == "" {
+		event.TraceID = trace.ID()
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package archival
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Repository is a repository the policy may apply to.
+type Repository struct {
+	ID       int64    `json:"-"`
+	Name     string   `json:"name"`
+	FullName string   `json:"fullName"`
+	CloneURL string   `json:"cloneUrl"`
+	Archived bool     `json:"archived,omitempty"`
+	Fork     bool     `json:"fork,omitempty"`
+	Topics   []string `json:"topics,omitempty"`
+	// CreatedAt keeps new, still empty repositories from counting as
+	// inactive.
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	PushedAt  time.Time `json:"pushedAt,omitempty"`
+}
+
+// Activity is the most recent activity of a repository.
+type Activity struct {
+	LastCommit      time.Time `json:"lastCommit,omitempty"`
+	LastPullRequest time.Time `json:"lastPullRequest,omitempty"`
+}
+
+// Last returns the later of the commit and pull request times.
+func (a Activity) Last() time.Time {
+	if a.LastPullRequest.After(a.LastCommit) {
+		return a.LastPullRequest
+	}
+	return a.LastCommit
+}
+
+// Owner is a user responsible for a repository.
+type Owner struct {
+	ID    int64  `json:"-"`
+	Login string `json:"login"`
+	Email string `json:"email,omitempty"`
+}
+
+// Issue is a notice issue to open in a repository.
+type Issue struct {
+	Title     string
+	Body      string
+	Labels    []string
+	Assignees []Owner
+}
+
+// IssueRef identifies an opened issue.
+type IssueRef struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+}
+
+// Backend reads repository activity and performs archival on one platform.
+type Backend interface {
+	// Provider returns the platform name.
+	Provider() string
+	// ListRepositories lists every repository of an organization or group.
+	ListRepositories(ctx context.Context, org string) ([]Repository, error)
+	// LastActivity returns when the repository last saw a commit or a pull
+	// request update.
+	LastActivity(ctx context.Context, repo Repository) (Activity, error)
+	// Owners returns the administrators of a repository.
+	Owners(ctx context.Context, repo Repository) ([]Owner, error)
+	// OpenIssue opens an issue in the repository.
+	OpenIssue(ctx context.Context, repo Repository, issue Issue) (IssueRef, error)
+	// CloseIssue comments on and closes an issue.
+	CloseIssue(ctx context.Context, repo Repository, number int, comment string) error
+	// Archive makes the repository read-only.
+	Archive(ctx context.Context, repo Repository) error
+	// GitAuth returns the credentials for HTTPS git access.
+	GitAuth() (username, token string)
+}
+
+// NewBackend creates the backend for provider. baseURL selects a
+// self-hosted API and may be empty.
+func NewBackend(provider, baseURL, token string) (Backend, error) {
+	switch provider {
+	case "github":
+		return newGitHubBackend(baseURL, token), nil
+	case "gitlab":
+		return newGitLabBackend(baseURL, token), nil
+	default:
+		return nil, fmt.Errorf("unsupported provider for archival: %s (supported: github, gitlab)", provider)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package archival
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// gitHubBackend implements Backend against the GitHub REST API.
+type gitHubBackend struct {
+	client *restapi.Client
+	token  string
+}
+
+func newGitHubBackend(baseURL, token string) *gitHubBackend {
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return &gitHubBackend{
+		client: restapi.New("github", baseURL, func(req *http.Request) {
+			req.Header.Set("Accept", "application/vnd.github+json")
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}),
+		token: token,
+	}
+}
+
+func (b *gitHubBackend) Provider() string { return "github" }
+
+func (b *gitHubBackend) GitAuth() (string, string) { return "x-access-token", b.token }
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghRepo struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	FullName  string    `json:"full_name"`
+	CloneURL  string    `json:"clone_url"`
+	Archived  bool      `json:"archived"`
+	Fork      bool      `json:"fork"`
+	Topics    []string  `json:"topics"`
+	CreatedAt time.Time `json:"created_at"`
+	PushedAt  time.Time `json:"pushed_at"`
+}
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghPull struct {
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Email string `json:"email"`
+}
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghIssue struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+func (b *gitHubBackend) ListRepositories(ctx context.Context, org string) ([]Repository, error) {
+	repos, err := restapi.ListAll[ghRepo](ctx, b.client, "/orgs/"+url.PathEscape(org)+"/repos?type=all", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories of %s: %w", org, err)
+	}
+
+	out := make([]Repository, 0, len(repos))
+	for _, r := range repos {
+		out = append(out, Repository{
+			ID:        r.ID,
+			Name:      r.Name,
+			FullName:  r.FullName,
+			CloneURL:  r.CloneURL,
+			Archived:  r.Archived,
+			Fork:      r.Fork,
+			Topics:    r.Topics,
+			CreatedAt: r.CreatedAt,
+			PushedAt:  r.PushedAt,
+		})
+	}
+	return out, nil
+}
+
+// LastActivity uses pushed_at, which covers pushes to every branch, and the
+// most recently updated pull request in any state.
+func (b *gitHubBackend) LastActivity(ctx context.Context, repo Repository) (Activity, error) {
+	activity := Activity{LastCommit: repo.PushedAt}
+
+	var pulls []ghPull
+	path := "/repos/" + repo.FullName + "/pulls?state=all&sort=updated&direction=desc&per_page=1"
+	if err := b.client.Do(ctx, http.MethodGet, path, nil, &pulls); err != nil {
+		return activity, fmt.Errorf("failed to list pull requests of %s: %w", repo.FullName, err)
+	}
+	if len(pulls) > 0 {
+		activity.LastPullRequest = pulls[0].UpdatedAt
+	}
+	return activity, nil
+}
+
+// Owners returns the direct administrators of a repository with the public
+// email address of their profile.
+func (b *gitHubBackend) Owners(ctx context.Context, repo Repository) ([]Owner, error) {
+	admins, err := restapi.ListAll[ghUser](ctx, b.client, "/repos/"+repo.FullName+"/collaborators?affiliation=direct&permission=admin", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list administrators of %s: %w", repo.FullName, err)
+	}
+
+	owners := make([]Owner, 0, len(admins))
+	for _, a := range admins {
+		var user ghUser
+		if err := b.client.Do(ctx, http.MethodGet, "/users/"+url.PathEscape(a.Login), nil, &user); err != nil && !errors.Is(err, restapi.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get user %s: %w", a.Login, err)
+		}
+		owners = append(owners, Owner{ID: a.ID, Login: a.Login, Email: user.Email})
+	}
+	return owners, nil
+}
+
+func (b *gitHubBackend) OpenIssue(ctx context.Context, repo Repository, issue Issue) (IssueRef, error) {
+	assignees := make([]string, 0, len(issue.Assignees))
+	for _, o := range issue.Assignees {
+		assignees = append(assignees, o.Login)
+	}
+	body := map[string]any{
+		"title":     issue.Title,
+		"body":      issue.Body,
+		"labels":    issue.Labels,
+		"assignees": assignees,
+	}
+
+	var created ghIssue
+	if err := b.client.Do(ctx, http.MethodPost, "/repos/"+repo.FullName+"/issues", body, &created); err != nil {
+		return IssueRef{}, fmt.Errorf("failed to open issue in %s: %w", repo.FullName, err)
+	}
+	return IssueRef{Number: created.Number, URL: created.HTMLURL}, nil
+}
+
+func (b *gitHubBackend) CloseIssue(ctx context.Context, repo Repository, number int, comment string) error {
+	path := "/repos/" + repo.FullName + "/issues/" + strconv.Itoa(number)
+	if err := b.client.Do(ctx, http.MethodPost, path+"/comments", map[string]string{"body": comment}, nil); err != nil {
+		return fmt.Errorf("failed to comment on %s#%d: %w", repo.FullName, number, err)
+	}
+	if err := b.client.Do(ctx, http.MethodPatch, path, map[string]string{"state": "closed"}, nil); err != nil {
+		return fmt.Errorf("failed to close %s#%d: %w", repo.FullName, number, err)
+	}
+	return nil
+}
+
+func (b *gitHubBackend) Archive(ctx context.Context, repo Repository) error {
+	if err := b.client.Do(ctx, http.MethodPatch, "/repos/"+repo.FullName, map[string]bool{"archived": true}, nil); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", repo.FullName, err)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package archival
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// gitLabAccessMaintainer is the lowest access level treated as an owner.
+const gitLabAccessMaintainer = 40
+
+// gitLabBackend implements Backend against the GitLab REST API (v4).
+type gitLabBackend struct {
+	client *restapi.Client
+	token  string
+}
+
+func newGitLabBackend(baseURL, token string) *gitLabBackend {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com/api/v4"
+	}
+	return &gitLabBackend{
+		client: restapi.New("gitlab", baseURL, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("PRIVATE-TOKEN", token)
+			}
+		}),
+		token: token,
+	}
+}
+
+func (b *gitLabBackend) Provider() string { return "gitlab" }
+
+func (b *gitLabBackend) GitAuth() (string, string) { return "oauth2", b.token }
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glProject struct {
+	ID                int64     `json:"id"`
+	Path              string    `json:"path"`
+	PathWithNamespace string    `json:"path_with_namespace"`
+	HTTPURLToRepo     string    `json:"http_url_to_repo"`
+	Archived          bool      `json:"archived"`
+	Topics            []string  `json:"topics"`
+	CreatedAt         time.Time `json:"created_at"`
+	ForkedFromProject *struct {
+		ID int64 `json:"id"`
+	} `json:"forked_from_project"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glCommit struct {
+	CommittedDate time.Time `json:"committed_date"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glMergeRequest struct {
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glMember struct {
+	ID          int64  `json:"id"`
+	Username    string `json:"username"`
+	State       string `json:"state"`
+	AccessLevel int    `json:"access_level"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glUser struct {
+	PublicEmail string `json:"public_email"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glIssue struct {
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+}
+
+func (b *gitLabBackend) ListRepositories(ctx context.Context, org string) ([]Repository, error) {
+	path := "/groups/" + url.PathEscape(org) + "/projects?include_subgroups=true"
+	projects, err := restapi.ListAll[glProject](ctx, b.client, path, "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects of %s: %w", org, err)
+	}
+
+	out := make([]Repository, 0, len(projects))
+	for _, p := range projects {
+		out = append(out, Repository{
+			ID:        p.ID,
+			Name:      p.Path,
+			FullName:  p.PathWithNamespace,
+			CloneURL:  p.HTTPURLToRepo,
+			Archived:  p.Archived,
+			Fork:      p.ForkedFromProject != nil,
+			Topics:    p.Topics,
+			CreatedAt: p.CreatedAt,
+		})
+	}
+	return out, nil
+}
+
+func (b *gitLabBackend) projectPath(repo Repository) string {
+	return "/projects/" + strconv.FormatInt(repo.ID, 10)
+}
+
+// LastActivity uses the latest commit of the default branch and the most
+// recently updated merge request in any state.
+func (b *gitLabBackend) LastActivity(ctx context.Context, repo Repository) (Activity, error) {
+	var activity Activity
+
+	var commits []glCommit
+	err := b.client.Do(ctx, http.MethodGet, b.projectPath(repo)+"/repository/commits?per_page=1", nil, &commits)
+	if err != nil && !errors.Is(err, restapi.ErrNotFound) { // 빈 저장소는 404를 반환한다
+		return activity, fmt.Errorf("failed to list commits of %s: %w", repo.FullName, err)
+	}
+	if len(commits) > 0 {
+		activity.LastCommit = commits[0].CommittedDate
+	}
+
+	var mrs []glMergeRequest
+	path := b.projectPath(repo) + "/merge_requests?state=all&order_by=updated_at&sort=desc&per_page=1"
+	if err := b.client.Do(ctx, http.MethodGet, path, nil, &mrs); err != nil {
+		return activity, fmt.Errorf("failed to list merge requests of %s: %w", repo.FullName, err)
+	}
+	if len(mrs) > 0 {
+		activity.LastPullRequest = mrs[0].UpdatedAt
+	}
+	return activity, nil
+}
+
+// Owners returns the active maintainers and owners of a project, including
+// inherited group members, with the public email address of their profile.
+func (b *gitLabBackend) Owners(ctx context.Context, repo Repository) ([]Owner, error) {
+	members, err := restapi.ListAll[glMember](ctx, b.client, b.projectPath(repo)+"/members/all", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of %s: %w", repo.FullName, err)
+	}
+
+	var owners []Owner
+	for _, m := range members {
+		if m.AccessLevel < gitLabAccessMaintainer || m.State != "active" {
+			continue
+		}
+		var user glUser
+		if err := b.client.Do(ctx, http.MethodGet, "/users/"+strconv.FormatInt(m.ID, 10), nil, &user); err != nil && !errors.Is(err, restapi.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get user %s: %w", m.Username, err)
+		}
+		owners = append(owners, Owner{ID: m.ID, Login: m.Username, Email: user.PublicEmail})
+	}
+	return owners, nil
+}
+
+func (b *gitLabBackend) OpenIssue(ctx context.Context, repo Repository, issue Issue) (IssueRef, error) {
+	assignees := make([]int64, 0, len(issue.Assignees))
+	for _, o := range issue.Assignees {
+		assignees = append(assignees, o.ID)
+	}
+	body := map[string]any{
+		"title":        issue.Title,
+		"description":  issue.Body,
+		"labels":       strings.Join(issue.Labels, ","),
+		"assignee_ids": assignees,
+	}
+
+	var created glIssue
+	if err := b.client.Do(ctx, http.MethodPost, b.projectPath(repo)+"/issues", body, &created); err != nil {
+		return IssueRef{}, fmt.Errorf("failed to open issue in %s: %w", repo.FullName, err)
+	}
+	return IssueRef{Number: created.IID, URL: created.WebURL}, nil
+}
+
+func (b *gitLabBackend) CloseIssue(ctx context.Context, repo Repository, number int, comment string) error {
+	path := b.projectPath(repo) + "/issues/" + strconv.Itoa(number)
+	if err := b.client.Do(ctx, http.MethodPost, path+"/notes", map[string]string{"body": comment}, nil); err != nil {
+		return fmt.Errorf("failed to comment on %s#%d: %w", repo.FullName, number, err)
+	}
+	if err := b.client.Do(ctx, http.MethodPut, path, map[string]string{"state_event": "close"}, nil); err != nil {
+		return fmt.Errorf("failed to close %s#%d: %w", repo.FullName, number, err)
+	}
+	return nil
+}
+
+func (b *gitLabBackend) Archive(ctx context.Context, repo Repository) error {
+	if err := b.client.Do(ctx, http.MethodPost, b.projectPath(repo)+"/archive", nil, nil); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", repo.FullName, err)
+	}
+	return nil
+}
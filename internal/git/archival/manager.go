@@ -0,0 +1,317 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package archival
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+)
+
+// Result statuses.
+const (
+	StatusActive      = "active"
+	StatusExempt      = "exempt"
+	StatusNotified    = "notified"
+	StatusPending     = "pending"
+	StatusArchived    = "archived"
+	StatusReactivated = "reactivated"
+	StatusFailed      = "failed"
+)
+
+// Options control an archival run.
+type Options struct {
+	// DryRun only reports what would happen; nothing is changed.
+	DryRun bool
+	// Repositories further narrows the policy's selector to names matching
+	// any of these patterns.
+	Repositories []string
+	// Notifier defaults to the one configured by the policy.
+	Notifier Notifier
+	// Backuper takes the backup before archiving; nil skips backups.
+	Backuper Backuper
+	// Recorder receives the audit trail; nil discards it.
+	Recorder audit.Recorder
+	// Actor is recorded as the actor of audit events.
+	Actor string
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// Manager applies an archival policy to an organization.
+type Manager struct {
+	backend Backend
+	policy  *Policy
+	state   *State
+	opts    Options
+
+	auditErr error
+}
+
+// NewManager creates a manager. The state records notices between runs.
+func NewManager(backend Backend, policy *Policy, state *State, opts Options) *Manager {
+	if opts.Notifier == nil {
+		opts.Notifier = NewNotifier(policy.Notify, backend)
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Manager{backend: backend, policy: policy, state: state, opts: opts}
+}
+
+// Run moves every selected repository of org one step through the
+// lifecycle: inactive repositories are notified, notified repositories whose
+// grace period has passed are backed up and archived, and notices of
+// repositories that became active again are withdrawn. Failures on
+// individual repositories are recorded in the report rather than aborting
+// the run.
+func (m *Manager) Run(ctx context.Context, org string) (*Report, error) {
+	repos, err := m.backend.ListRepositories(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	now := m.opts.Now()
+	report := &Report{
+		Provider:       m.backend.Provider(),
+		Org:            org,
+		DryRun:         m.opts.DryRun,
+		GeneratedAt:    now,
+		InactiveMonths: m.policy.InactiveMonths,
+		GraceDays:      m.policy.GraceDays,
+	}
+
+	for _, repo := range repos {
+		if ctx.Err() != nil {
+			break
+		}
+		if !m.policy.Repositories.Matches(repo) || !m.matchesFilter(repo) {
+			continue
+		}
+		report.Results = append(report.Results, m.process(ctx, repo, now))
+	}
+
+	sort.Slice(report.Results, func(i, j int) bool {
+		return report.Results[i].Repository < report.Results[j].Repository
+	})
+
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	if m.auditErr != nil {
+		return report, fmt.Errorf("failed to record audit trail: %w", m.auditErr)
+	}
+	return report, nil
+}
+
+func (m *Manager) matchesFilter(repo Repository) bool {
+	if len(m.opts.Repositories) == 0 {
+		return true
+	}
+	for _, pattern := range m.opts.Repositories {
+		if ok, _ := path.Match(pattern, repo.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// process moves one repository through the lifecycle.
+func (m *Manager) process(ctx context.Context, repo Repository, now time.Time) Result {
+	result := Result{Repository: repo.FullName}
+	notice := m.state.Notices[repo.FullName]
+	if notice != nil {
+		notifiedAt := notice.NotifiedAt
+		result.NotifiedAt = &notifiedAt
+		result.Notice = noticeSummary(notice)
+	}
+
+	if m.policy.Repositories.Exempt(repo) {
+		result.Status = StatusExempt
+		if notice != nil {
+			m.withdraw(ctx, repo, notice, &result)
+		}
+		return result
+	}
+
+	activity, err := m.backend.LastActivity(ctx, repo)
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+	last := activity.Last()
+	if repo.CreatedAt.After(last) {
+		last = repo.CreatedAt
+	}
+	if !last.IsZero() {
+		result.LastActivity = &last
+	}
+
+	if !last.Before(m.policy.InactiveSince(now)) {
+		result.Status = StatusActive
+		if notice != nil {
+			result.Status = StatusReactivated
+			m.withdraw(ctx, repo, notice, &result)
+		}
+		return result
+	}
+
+	if notice == nil {
+		deadline := now.Add(m.policy.GracePeriod())
+		result.ArchiveAfter = &deadline
+		result.Status = StatusNotified
+		if !m.opts.DryRun {
+			m.notify(ctx, repo, last, deadline, now, &result)
+		}
+		return result
+	}
+
+	deadline := notice.NotifiedAt.Add(m.policy.GracePeriod())
+	result.ArchiveAfter = &deadline
+	if now.Before(deadline) {
+		result.Status = StatusPending
+		return result
+	}
+
+	result.Status = StatusArchived
+	if !m.opts.DryRun {
+		m.archive(ctx, repo, notice, &result)
+	}
+	return result
+}
+
+func (m *Manager) notify(ctx context.Context, repo Repository, last, deadline, now time.Time, result *Result) {
+	var owners []Owner
+	if m.policy.Notify.Method != NotifyNone {
+		var err error
+		if owners, err = m.backend.Owners(ctx, repo); err != nil {
+			m.fail(result, ActionNotified, repo, err)
+			return
+		}
+	}
+
+	notice, err := m.opts.Notifier.Notify(ctx, repo, owners, last, deadline)
+	if err != nil {
+		m.fail(result, ActionNotified, repo, err)
+		return
+	}
+	notice.NotifiedAt = now
+	result.NotifiedAt = &notice.NotifiedAt
+	result.Notice = noticeSummary(notice)
+
+	m.state.Notices[repo.FullName] = notice
+	if err := m.state.Save(); err != nil {
+		m.fail(result, ActionNotified, repo, err)
+		return
+	}
+	m.record(ActionNotified, repo, nil, "owners notified of pending archival", map[string]any{
+		"method":       notice.Method,
+		"recipients":   notice.Recipients,
+		"archiveAfter": deadline,
+		"lastActivity": last,
+	})
+}
+
+func (m *Manager) withdraw(ctx context.Context, repo Repository, notice *Notice, result *Result) {
+	if m.opts.DryRun {
+		return
+	}
+	if err := m.opts.Notifier.Withdraw(ctx, repo, notice); err != nil {
+		m.fail(result, ActionWithdrawn, repo, err)
+		return
+	}
+	delete(m.state.Notices, repo.FullName)
+	if err := m.state.Save(); err != nil {
+		m.fail(result, ActionWithdrawn, repo, err)
+		return
+	}
+	m.record(ActionWithdrawn, repo, nil, "archival notice withdrawn", map[string]any{"reason": result.Status})
+}
+
+// archive backs up and archives a repository. Without a successful backup,
+// when one is configured, the repository is left untouched and retried on
+// the next run.
+func (m *Manager) archive(ctx context.Context, repo Repository, notice *Notice, result *Result) {
+	if m.opts.Backuper != nil {
+		location, err := m.opts.Backuper.Backup(ctx, repo)
+		if err != nil {
+			m.fail(result, ActionBackedUp, repo, err)
+			return
+		}
+		result.Backup = location
+		m.record(ActionBackedUp, repo, nil, "repository backed up", map[string]any{"location": location})
+	}
+
+	// 보관 후에는 이슈를 닫을 수 없으므로 먼저 닫는다
+	if notice.Issue != nil {
+		comment := "The grace period has ended; this repository is being archived."
+		if err := m.backend.CloseIssue(ctx, repo, notice.Issue.Number, comment); err != nil {
+			m.fail(result, ActionArchived, repo, err)
+			return
+		}
+	}
+	if err := m.backend.Archive(ctx, repo); err != nil {
+		m.fail(result, ActionArchived, repo, err)
+		return
+	}
+
+	delete(m.state.Notices, repo.FullName)
+	if err := m.state.Save(); err != nil {
+		result.Error = err.Error()
+	}
+	m.record(ActionArchived, repo, nil, "repository archived", map[string]any{
+		"notifiedAt": notice.NotifiedAt,
+		"backup":     result.Backup,
+	})
+}
+
+func (m *Manager) fail(result *Result, action string, repo Repository, err error) {
+	result.Status = StatusFailed
+	result.Error = err.Error()
+	m.record(action, repo, err, "archival step failed", nil)
+}
+
+func (m *Manager) record(action string, repo Repository, err error, message string, metadata map[string]any) {
+	if m.opts.Recorder == nil {
+		return
+	}
+	event := audit.Event{
+		ID:        audit.NewEventID(),
+		Timestamp: m.opts.Now().UTC(),
+		Severity:  audit.SeverityInfo,
+		Action:    action,
+		Category:  "archival",
+		Actor:     m.opts.Actor,
+		Source:    "gz git repo archive",
+		Resource:  m.backend.Provider() + ":" + repo.FullName,
+		Outcome:   "success",
+		Message:   message,
+		Metadata:  metadata,
+	}
+	if err != nil {
+		event.Severity = audit.SeverityError
+		event.Outcome = "failure"
+		event.ErrMessage = err.Error()
+	}
+	if err := m.opts.Recorder.Record(event); err != nil {
+		m.auditErr = errors.Join(m.auditErr, err)
+	}
+}
+
+func noticeSummary(n *Notice) string {
+	if n.Issue != nil && n.Issue.URL != "" {
+		return n.Issue.URL
+	}
+	if len(n.Recipients) > 0 {
+		return n.Method + ": " + strings.Join(n.Recipients, ", ")
+	}
+	return n.Method
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package archival
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"time"
+)
+
+// Notifier tells repository owners about a pending archival.
+type Notifier interface {
+	// Notify announces that repo will be archived at deadline.
+	Notify(ctx context.Context, repo Repository, owners []Owner, lastActivity, deadline time.Time) (*Notice, error)
+	// Withdraw cancels an earlier notice because the repository became
+	// active again.
+	Withdraw(ctx context.Context, repo Repository, notice *Notice) error
+}
+
+// NewNotifier creates the notifier configured by the policy.
+func NewNotifier(cfg NotifyConfig, backend Backend) Notifier {
+	switch cfg.Method {
+	case NotifyEmail:
+		return &emailNotifier{cfg: cfg.Email, send: smtp.SendMail}
+	case NotifyNone:
+		return noneNotifier{}
+	default:
+		return &issueNotifier{backend: backend, labels: cfg.Labels}
+	}
+}
+
+func noticeTitle(repo Repository, deadline time.Time) string {
+	return fmt.Sprintf("%s will be archived on %s", repo.FullName, deadline.Format("2006-01-02"))
+}
+
+func noticeBody(repo Repository, lastActivity, deadline time.Time) string {
+	last := "never"
+	if !lastActivity.IsZero() {
+		last = lastActivity.Format("2006-01-02")
+	}
+	return fmt.Sprintf(`%s has had no commits or pull requests since %s and is scheduled to be archived on %s.
+
+Archived repositories become read-only; their history, issues and pull requests are kept.
+
+To keep the repository active, push a commit or open a pull request before %s.`,
+		repo.FullName, last, deadline.Format("2006-01-02"), deadline.Format("2006-01-02"))
+}
+
+const withdrawText = "New activity was detected, so the scheduled archival has been cancelled."
+
+// issueNotifier opens an issue assigned to the owners.
+type issueNotifier struct {
+	backend Backend
+	labels  []string
+}
+
+func (n *issueNotifier) Notify(ctx context.Context, repo Repository, owners []Owner, lastActivity, deadline time.Time) (*Notice, error) {
+	ref, err := n.backend.OpenIssue(ctx, repo, Issue{
+		Title:     noticeTitle(repo, deadline),
+		Body:      noticeBody(repo, lastActivity, deadline),
+		Labels:    n.labels,
+		Assignees: owners,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := make([]string, 0, len(owners))
+	for _, o := range owners {
+		recipients = append(recipients, o.Login)
+	}
+	return &Notice{Method: NotifyIssue, Issue: &ref, Recipients: recipients}, nil
+}
+
+func (n *issueNotifier) Withdraw(ctx context.Context, repo Repository, notice *Notice) error {
+	if notice.Issue == nil {
+		return nil
+	}
+	return n.backend.CloseIssue(ctx, repo, notice.Issue.Number, withdrawText)
+}
+
+// emailNotifier mails the owners, or the fallback addresses when no owner
+// has a known email address.
+type emailNotifier struct {
+	cfg  EmailConfig
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func (n *emailNotifier) Notify(_ context.Context, repo Repository, owners []Owner, lastActivity, deadline time.Time) (*Notice, error) {
+	var to []string
+	for _, o := range owners {
+		if o.Email != "" {
+			to = append(to, o.Email)
+		}
+	}
+	if len(to) == 0 {
+		to = n.cfg.Fallback
+	}
+	if len(to) == 0 {
+		return nil, fmt.Errorf("no email address known for the owners of %s and no fallback configured", repo.FullName)
+	}
+
+	if err := n.mail(to, noticeTitle(repo, deadline), noticeBody(repo, lastActivity, deadline)); err != nil {
+		return nil, err
+	}
+	return &Notice{Method: NotifyEmail, Recipients: to}, nil
+}
+
+func (n *emailNotifier) Withdraw(_ context.Context, repo Repository, notice *Notice) error {
+	if len(notice.Recipients) == 0 {
+		return nil
+	}
+	return n.mail(notice.Recipients, repo.FullName+" will not be archived", withdrawText)
+}
+
+func (n *emailNotifier) mail(to []string, subject, body string) error {
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		host, _, _ := net.SplitHostPort(n.cfg.SMTP)
+		auth = smtp.PlainAuth("", n.cfg.Username, os.ExpandEnv(n.cfg.Password), host)
+	}
+
+	msg := "From: " + n.cfg.From + "\r\n" +
+		"To: " + strings.Join(to, ", ") + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n\r\n" +
+		strings.ReplaceAll(body, "\n", "\r\n") + "\r\n"
+	if err := n.send(n.cfg.SMTP, auth, n.cfg.From, to, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send email via %s: %w", n.cfg.SMTP, err)
+	}
+	return nil
+}
+
+// noneNotifier only records the notice; the grace period still applies.
+type noneNotifier struct{}
+
+func (noneNotifier) Notify(context.Context, Repository, []Owner, time.Time, time.Time) (*Notice, error) {
+	return &Notice{Method: NotifyNone}, nil
+}
+
+func (noneNotifier) Withdraw(context.Context, Repository, *Notice) error { return nil }
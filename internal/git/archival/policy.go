@@ -0,0 +1,187 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package archival archives inactive repositories according to a policy:
+// owners of repositories without commits or pull requests for a number of
+// months are notified, and once a grace period has passed without new
+// activity the repository is backed up and archived on the provider. Every
+// step is recorded as an audit event.
+package archival
+
+import (
+	"fmt"
+	"net/mail"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Notification methods.
+const (
+	NotifyIssue = "issue"
+	NotifyEmail = "email"
+	NotifyNone  = "none"
+)
+
+// Policy is a declarative archival policy.
+//
+//	repositories:
+//	  exclude: ["infra-*"]
+//	  exempt_topics: [keep]
+//	inactive_months: 12
+//	grace_days: 30
+//	notify:
+//	  method: issue               # issue, email or none
+//	  labels: [archival]
+//	backup:
+//	  url: s3://repo-archive/github   # optional cold-storage location
+type Policy struct {
+	Repositories   Selector     `yaml:"repositories" json:"repositories"`
+	InactiveMonths int          `yaml:"inactive_months" json:"inactiveMonths"`
+	GraceDays      int          `yaml:"grace_days" json:"graceDays"`
+	Notify         NotifyConfig `yaml:"notify" json:"notify"`
+	Backup         BackupConfig `yaml:"backup" json:"backup"`
+}
+
+// Selector chooses the repositories the policy applies to. Patterns use
+// path.Match syntax against the repository name.
+type Selector struct {
+	Include      []string `yaml:"include" json:"include,omitempty"`
+	Exclude      []string `yaml:"exclude" json:"exclude,omitempty"`
+	ExemptTopics []string `yaml:"exempt_topics" json:"exemptTopics,omitempty"`
+	IncludeForks bool     `yaml:"include_forks" json:"includeForks,omitempty"`
+}
+
+// NotifyConfig configures how owners are told about a pending archival.
+type NotifyConfig struct {
+	Method string      `yaml:"method" json:"method"`
+	Labels []string    `yaml:"labels" json:"labels,omitempty"`
+	Email  EmailConfig `yaml:"email" json:"email,omitempty"`
+}
+
+// EmailConfig configures notification by email. The password may reference
+// an environment variable, e.g. ${SMTP_PASSWORD}.
+type EmailConfig struct {
+	// SMTP is the host:port of the mail server.
+	SMTP     string `yaml:"smtp" json:"smtp,omitempty"`
+	From     string `yaml:"from" json:"from,omitempty"`
+	Username string `yaml:"username" json:"username,omitempty"`
+	Password string `yaml:"password" json:"-"`
+	// Fallback receives notices for repositories without a known owner
+	// email address.
+	Fallback []string `yaml:"fallback" json:"fallback,omitempty"`
+}
+
+// BackupConfig configures the cold-storage backup taken before archiving.
+type BackupConfig struct {
+	// URL is an object store location understood by cloud.OpenObjectStore.
+	// No backup is taken when it is empty.
+	URL string `yaml:"url" json:"url,omitempty"`
+}
+
+// LoadPolicy reads and validates a policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy %s: %w", path, err)
+	}
+	return ParsePolicy(data)
+}
+
+// ParsePolicy parses and validates policy YAML. Unknown keys are rejected so
+// that a misspelled setting is not silently ignored.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var p Policy
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+	if p.Notify.Method == "" {
+		p.Notify.Method = NotifyIssue
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks the policy values.
+func (p *Policy) Validate() error {
+	if p.InactiveMonths <= 0 {
+		return fmt.Errorf("invalid policy: inactive_months must be positive")
+	}
+	if p.GraceDays < 0 {
+		return fmt.Errorf("invalid policy: grace_days must not be negative")
+	}
+
+	switch p.Notify.Method {
+	case NotifyIssue, NotifyNone:
+	case NotifyEmail:
+		e := p.Notify.Email
+		if e.SMTP == "" || e.From == "" {
+			return fmt.Errorf("invalid policy: notify.email requires smtp and from")
+		}
+		for _, addr := range append([]string{e.From}, e.Fallback...) {
+			if _, err := mail.ParseAddress(addr); err != nil {
+				return fmt.Errorf("invalid policy: bad email address %q", addr)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid policy: unknown notify.method %q (valid: issue, email, none)", p.Notify.Method)
+	}
+
+	for _, pattern := range append(append([]string{}, p.Repositories.Include...), p.Repositories.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid policy: bad repository pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// InactiveSince returns the time before which a repository's last activity
+// makes it inactive.
+func (p *Policy) InactiveSince(now time.Time) time.Time {
+	return now.AddDate(0, -p.InactiveMonths, 0)
+}
+
+// GracePeriod returns the time between the notice and the archival.
+func (p *Policy) GracePeriod() time.Duration {
+	return time.Duration(p.GraceDays) * 24 * time.Hour
+}
+
+// Matches reports whether the policy applies to a repository.
+func (s Selector) Matches(repo Repository) bool {
+	if repo.Archived || (repo.Fork && !s.IncludeForks) {
+		return false
+	}
+	for _, pattern := range s.Exclude {
+		if ok, _ := path.Match(pattern, repo.Name); ok {
+			return false
+		}
+	}
+	if len(s.Include) == 0 {
+		return true
+	}
+	for _, pattern := range s.Include {
+		if ok, _ := path.Match(pattern, repo.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Exempt reports whether a repository carries one of the exempt topics.
+func (s Selector) Exempt(repo Repository) bool {
+	for _, topic := range repo.Topics {
+		for _, exempt := range s.ExemptTopics {
+			if strings.EqualFold(topic, exempt) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package archival
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// Report is the outcome of an archival run.
+type Report struct {
+	Provider       string    `json:"provider"`
+	Org            string    `json:"org"`
+	DryRun         bool      `json:"dryRun"`
+	GeneratedAt    time.Time `json:"generatedAt"`
+	InactiveMonths int       `json:"inactiveMonths"`
+	GraceDays      int       `json:"graceDays"`
+	Results        []Result  `json:"results"`
+}
+
+// Result is the outcome for one repository. In a dry run the status is the
+// step that would be taken.
+type Result struct {
+	Repository   string     `json:"repository"`
+	Status       string     `json:"status"`
+	LastActivity *time.Time `json:"lastActivity,omitempty"`
+	NotifiedAt   *time.Time `json:"notifiedAt,omitempty"`
+	ArchiveAfter *time.Time `json:"archiveAfter,omitempty"`
+	Notice       string     `json:"notice,omitempty"`
+	Backup       string     `json:"backup,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// Counts returns the number of results per status.
+func (r *Report) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, res := range r.Results {
+		counts[res.Status]++
+	}
+	return counts
+}
+
+// WriteJSON writes the report to path.
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// PrintTable writes every repository that is not active.
+func (r *Report) PrintTable(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REPOSITORY\tSTATUS\tLAST ACTIVITY\tARCHIVE AFTER\tDETAILS")
+	for _, res := range r.Results {
+		if res.Status == StatusActive {
+			continue
+		}
+		details := res.Notice
+		if res.Backup != "" {
+			details = res.Backup
+		}
+		if res.Error != "" {
+			details = res.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", res.Repository, res.Status,
+			formatDate(res.LastActivity), formatDate(res.ArchiveAfter), details)
+	}
+	_ = tw.Flush()
+}
+
+// PrintSummary writes the per-status totals.
+func (r *Report) PrintSummary(w io.Writer) {
+	title := "Archival summary"
+	if r.DryRun {
+		title = "Archival preview (dry run)"
+	}
+	fmt.Fprintf(w, "\n📦 %s: %s:%s (inactive %d months, grace %d days)\n",
+		title, r.Provider, r.Org, r.InactiveMonths, r.GraceDays)
+
+	counts := r.Counts()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tREPOSITORIES")
+	for _, s := range []string{StatusActive, StatusExempt, StatusReactivated, StatusNotified, StatusPending, StatusArchived, StatusFailed} {
+		if counts[s] > 0 {
+			fmt.Fprintf(tw, "%s\t%d\n", s, counts[s])
+		}
+	}
+	_ = tw.Flush()
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package archival
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/filesystem"
+)
+
+// Notice records that the owners of a repository were told about its
+// pending archival.
+type Notice struct {
+	NotifiedAt time.Time `json:"notifiedAt"`
+	Method     string    `json:"method"`
+	Issue      *IssueRef `json:"issue,omitempty"`
+	Recipients []string  `json:"recipients,omitempty"`
+}
+
+// State is the archival state of one organization, kept between runs so
+// the grace period counts from the first notice. A repository leaves the
+// state when it becomes active again or is archived.
+type State struct {
+	Provider string             `json:"provider"`
+	Org      string             `json:"org"`
+	Notices  map[string]*Notice `json:"notices"`
+
+	path string
+}
+
+// DefaultStatePath returns the state file of an organization.
+func DefaultStatePath(provider, org string) string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "gzh-manager", "archival", provider+"-"+filepath.Base(org)+".json")
+}
+
+// LoadState reads the state stored at path, or returns an empty state when
+// the file does not exist yet.
+func LoadState(path, provider, org string) (*State, error) {
+	s := &State{Provider: provider, Org: org, Notices: make(map[string]*Notice), path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archival state: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse archival state %s: %w", path, err)
+	}
+	if s.Provider != provider || s.Org != org {
+		return nil, fmt.Errorf("archival state %s belongs to %s:%s", path, s.Provider, s.Org)
+	}
+	if s.Notices == nil {
+		s.Notices = make(map[string]*Notice)
+	}
+	return s, nil
+}
+
+// Save writes the state back to its file.
+func (s *State) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal archival state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := filesystem.WriteFileAtomic(filesystem.OS(), s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write archival state: %w", err)
+	}
+	return nil
+}
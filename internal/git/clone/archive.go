@@ -0,0 +1,258 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package clone
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+)
+
+// ArchiveFormat is the compression used for mirror archives.
+type ArchiveFormat string
+
+const (
+	// ArchiveZstd writes .tar.zst archives
+	ArchiveZstd ArchiveFormat = "zst"
+	// ArchiveGzip writes .tar.gz archives
+	ArchiveGzip ArchiveFormat = "gz"
+	// ArchiveLZ4 writes .tar.lz4 archives
+	ArchiveLZ4 ArchiveFormat = "lz4"
+)
+
+// archiveCodecs maps archive formats to their compression codecs.
+var archiveCodecs = map[ArchiveFormat]compress.Codec{
+	ArchiveZstd: compress.Zstd,
+	ArchiveGzip: compress.Gzip,
+	ArchiveLZ4:  compress.LZ4,
+}
+
+// archiveTimeLayout is the UTC timestamp in archive file names. It sorts
+// lexically in chronological order.
+const archiveTimeLayout = "20060102T150405Z"
+
+// Extension returns the file extension of the archive format.
+func (f ArchiveFormat) Extension() string {
+	return ".tar." + string(f)
+}
+
+// IsValidArchiveFormat checks if the given archive format is valid.
+func IsValidArchiveFormat(format string) bool {
+	_, ok := archiveCodecs[ArchiveFormat(format)]
+	return ok
+}
+
+// DefaultArchiveFormat returns the format of the codec configured for
+// archives, or ArchiveZstd when that codec has no archive format.
+func DefaultArchiveFormat() ArchiveFormat {
+	codec := compress.ForUse(compress.UseArchive)
+	for format, c := range archiveCodecs {
+		if c == codec {
+			return format
+		}
+	}
+	return ArchiveZstd
+}
+
+// RetentionPolicy decides which mirror archives are kept. Zero values
+// disable the respective rule; the newest archive is always kept.
+type RetentionPolicy struct {
+	// KeepLast keeps the N newest archives per repository
+	KeepLast int
+	// MaxAge removes archives older than this
+	MaxAge time.Duration
+}
+
+// ArchivePath returns the date-stamped archive path of a repository below
+// root, e.g. root/myorg/api/api-20250102T030405Z.tar.zst.
+func ArchivePath(root, fullName string, format ArchiveFormat, at time.Time) string {
+	name := filepath.Base(fullName)
+	return filepath.Join(root, fullName, name+"-"+at.UTC().Format(archiveTimeLayout)+format.Extension())
+}
+
+// ArchiveMirror packs the bare mirror at mirrorPath into a date-stamped
+// archive below root and returns its path. The archive is written to a
+// temporary file first so that an interrupted run never leaves a truncated
+// backup behind.
+func ArchiveMirror(ctx context.Context, mirrorPath, root, fullName string, format ArchiveFormat, at time.Time) (string, error) {
+	target := ArchivePath(root, fullName, format, at)
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	tmp := target + ".partial"
+	if err := writeArchive(ctx, mirrorPath, tmp, format); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to finalize archive: %w", err)
+	}
+	return target, nil
+}
+
+// writeArchive streams a compressed tar of src into dst.
+func writeArchive(ctx context.Context, src, dst string, format ArchiveFormat) error {
+	codec, ok := archiveCodecs[format]
+	if !ok {
+		return ErrInvalidArchiveFormat
+	}
+
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer f.Close()
+
+	zw, err := codec.NewWriter(f)
+	if err != nil {
+		return fmt.Errorf("failed to compress archive: %w", err)
+	}
+	if err := writeTar(ctx, zw, src); err != nil {
+		zw.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress archive: %w", err)
+	}
+	return f.Close()
+}
+
+// writeTar writes the directory src as a tar stream whose entries are rooted
+// at the base name of src.
+func writeTar(ctx context.Context, w io.Writer, src string) error {
+	tw := tar.NewWriter(w)
+	base := filepath.Dir(src)
+
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		link := ""
+		if info.Mode()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w", src, err)
+	}
+	return tw.Close()
+}
+
+// ApplyRetention removes archives in dir that fall outside the policy and
+// returns the removed paths. Only files produced by ArchiveMirror for the
+// repository name are considered.
+func ApplyRetention(dir, name string, policy RetentionPolicy, now time.Time) ([]string, error) {
+	if policy.KeepLast <= 0 && policy.MaxAge <= 0 {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read archive directory: %w", err)
+	}
+
+	type archive struct {
+		path string
+		at   time.Time
+	}
+	var archives []archive
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		at, ok := parseArchiveTime(entry.Name(), name)
+		if !ok {
+			continue
+		}
+		archives = append(archives, archive{path: filepath.Join(dir, entry.Name()), at: at})
+	}
+
+	// Newest first
+	slices.SortFunc(archives, func(a, b archive) int { return b.at.Compare(a.at) })
+
+	var removed []string
+	for i, a := range archives {
+		if i == 0 {
+			continue
+		}
+		expired := policy.MaxAge > 0 && now.Sub(a.at) > policy.MaxAge
+		surplus := policy.KeepLast > 0 && i >= policy.KeepLast
+		if !expired && !surplus {
+			continue
+		}
+		if err := os.Remove(a.path); err != nil {
+			return removed, fmt.Errorf("failed to remove archive: %w", err)
+		}
+		removed = append(removed, a.path)
+	}
+	return removed, nil
+}
+
+// parseArchiveTime extracts the timestamp of an archive file name of the
+// form <name>-<timestamp>.tar.<ext>.
+func parseArchiveTime(file, name string) (time.Time, bool) {
+	rest, ok := strings.CutPrefix(file, name+"-")
+	if !ok {
+		return time.Time{}, false
+	}
+	stamp, ext, ok := strings.Cut(rest, ".tar.")
+	if !ok || !IsValidArchiveFormat(ext) {
+		return time.Time{}, false
+	}
+	at, err := time.Parse(archiveTimeLayout, stamp)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return at, true
+}
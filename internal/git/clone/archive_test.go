@@ -0,0 +1,184 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package clone
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+)
+
+func TestArchiveMirrorGzip(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "api.git")
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "refs", "notes"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "refs", "notes", "commits"), []byte("abc\n"), 0o644))
+
+	root := t.TempDir()
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	path, err := ArchiveMirror(context.Background(), src, root, "acme/api", ArchiveGzip, at)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "acme", "api", "api-20250102T030405Z.tar.gz"), path)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	var names []string
+	tr := tar.NewReader(zr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+	assert.ElementsMatch(t, []string{"api.git/", "api.git/HEAD", "api.git/refs/", "api.git/refs/notes/", "api.git/refs/notes/commits"}, names)
+
+	_, err = os.Stat(path + ".partial")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestArchiveMirrorFormats(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "api.git")
+	require.NoError(t, os.MkdirAll(src, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "HEAD"), []byte("ref: refs/heads/main\n"), 0o644))
+
+	for _, format := range []ArchiveFormat{ArchiveZstd, ArchiveLZ4} {
+		path, err := ArchiveMirror(context.Background(), src, t.TempDir(), "acme/api", format, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, "."+string(format), filepath.Ext(path))
+
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		zr, codec, err := compress.NewDetectingReader(f)
+		require.NoError(t, err)
+		assert.Equal(t, archiveCodecs[format], codec)
+		hdr, err := tar.NewReader(zr).Next()
+		require.NoError(t, err)
+		assert.Equal(t, "api.git/", hdr.Name)
+		zr.Close()
+		f.Close()
+	}
+}
+
+func TestApplyRetention(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	for _, days := range []int{0, 1, 2, 40, 50} {
+		name := ArchivePath("", "api", ArchiveGzip, now.AddDate(0, 0, -days))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, filepath.Base(name)), nil, 0o600))
+	}
+	// Unrelated files are never touched
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api-notes.txt"), nil, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "api-v2-20200101T000000Z.tar.gz"), nil, 0o600))
+
+	removed, err := ApplyRetention(dir, "api", RetentionPolicy{MaxAge: 30 * 24 * time.Hour}, now)
+	require.NoError(t, err)
+	assert.Len(t, removed, 2)
+
+	removed, err = ApplyRetention(dir, "api", RetentionPolicy{KeepLast: 2}, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "api-20250308T000000Z.tar.gz")}, removed)
+
+	// The newest archive survives even when it is expired
+	removed, err = ApplyRetention(dir, "api", RetentionPolicy{MaxAge: time.Hour}, now.AddDate(1, 0, 0))
+	require.NoError(t, err)
+	assert.Len(t, removed, 1)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+}
+
+func TestValidateMirrorOptions(t *testing.T) {
+	opts := DefaultCloneOptions()
+	opts.Provider, opts.Org = "github", "acme"
+	opts.ArchiveRoot = "/backup"
+	assert.ErrorIs(t, opts.Validate(), ErrArchiveRequiresMirror)
+
+	opts.Strategy = StrategyMirror
+	require.NoError(t, opts.Validate())
+	assert.Equal(t, string(ArchiveZstd), opts.ArchiveFormat)
+
+	opts.Depth = 1
+	assert.ErrorIs(t, opts.Validate(), ErrMirrorPartialClone)
+
+	opts.Depth = 0
+	opts.ArchiveFormat = "zip"
+	assert.ErrorIs(t, opts.Validate(), ErrInvalidArchiveFormat)
+
+	// 설정의 global.compression.archive가 기본 형식이 된다
+	require.NoError(t, compress.SetOverrides(map[string]string{"archive": "lz4"}))
+	t.Cleanup(func() { require.NoError(t, compress.SetOverrides(nil)) })
+	opts.ArchiveFormat = ""
+	require.NoError(t, opts.Validate())
+	assert.Equal(t, string(ArchiveLZ4), opts.ArchiveFormat)
+}
+
+func TestCloneMirror(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+
+	upstream := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = upstream
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	git("commit", "-q", "--allow-empty", "-m", "initial")
+	git("notes", "add", "-m", "reviewed")
+
+	opts := DefaultCloneOptions()
+	opts.Provider, opts.Org = "github", "acme"
+	opts.Strategy = StrategyMirror
+	opts.Target = t.TempDir()
+	opts.ArchiveRoot = t.TempDir()
+	opts.ArchiveFormat = string(ArchiveGzip)
+	require.NoError(t, opts.Validate())
+
+	e := &CloneExecutor{options: opts, progress: NewProgressReporter(string(FormatQuiet), true, false)}
+	repo := RepositoryInfo{Name: "api", FullName: "acme/api", CloneURL: upstream}
+	target := e.targetPath(repo)
+	assert.Equal(t, filepath.Join(opts.Target, "acme", "api.git"), target)
+
+	require.NoError(t, e.cloneRepository(ctx, &CloneRequest{Repository: repo, TargetPath: target}))
+	assert.FileExists(t, filepath.Join(target, "HEAD"))
+	assert.NoFileExists(t, filepath.Join(target, ".gzh"))
+
+	// New refs, including notes, arrive on update
+	git("tag", "v1")
+	require.NoError(t, e.cloneRepository(ctx, &CloneRequest{Repository: repo, TargetPath: target}))
+	out, err := exec.Command("git", "-C", target, "for-each-ref", "--format=%(refname)").Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "refs/notes/commits")
+	assert.Contains(t, string(out), "refs/tags/v1")
+
+	e.archiveMirror(ctx, repo, target)
+	matches, err := filepath.Glob(filepath.Join(opts.ArchiveRoot, "acme", "api", "api-*.tar.gz"))
+	require.NoError(t, err)
+	assert.Len(t, matches, 1)
+}
@@ -19,6 +19,7 @@ var (
 	ErrInvalidVisibility       = errors.New("invalid visibility, must be 'all', 'public', or 'private'")
 	ErrInvalidMatchPattern     = errors.New("invalid match pattern")
 	ErrInvalidExcludePattern   = errors.New("invalid exclude pattern")
+	ErrInvalidFilter           = errors.New("invalid filter expression")
 	ErrSessionNotFound         = errors.New("session not found")
 	ErrSessionInvalid          = errors.New("session is invalid")
 	ErrCloneInProgress         = errors.New("clone operation already in progress")
@@ -31,6 +32,14 @@ var (
 	ErrInsufficientPermissions = errors.New("insufficient permissions")
 	ErrTargetPathInvalid       = errors.New("target path is invalid")
 	ErrDiskSpaceInsufficient   = errors.New("insufficient disk space")
+	ErrArchiveRequiresMirror   = errors.New("archiving requires the mirror strategy")
+	ErrMirrorPartialClone      = errors.New("mirror clones cannot be combined with depth, single-branch or branch")
+	ErrMirrorSecretScan        = errors.New("secret scanning is not supported for bare mirror clones")
+	ErrMirrorObjectCache       = errors.New("mirror clones must be self-contained and cannot use the object cache")
+	ErrInvalidArchiveFormat    = errors.New("invalid archive format, must be 'zst' or 'gz'")
+	ErrInvalidRetention        = errors.New("archive retention values must not be negative")
+	ErrInvalidLFSMode          = errors.New("invalid LFS mode, must be 'include' or 'skip' with a non-negative rate limit")
+	ErrInvalidSubmodules       = errors.New("invalid submodule settings")
 )
 
 // CloneError represents an error that occurred during cloning operations.
@@ -6,13 +6,24 @@ package clone
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/git/lfs"
+	"github.com/gizzahub/gzh-cli/internal/git/objcache"
+	"github.com/gizzahub/gzh-cli/internal/git/quarantine"
+	"github.com/gizzahub/gzh-cli/internal/git/repofilter"
+	"github.com/gizzahub/gzh-cli/internal/git/submodule"
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
 	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+	"github.com/gizzahub/gzh-cli/pkg/security/secrets"
 )
 
 // CloneExecutor handles the execution of repository cloning operations.
@@ -21,6 +32,41 @@ type CloneExecutor struct {
 	options  *CloneOptions
 	session  *Session
 	progress *ProgressReporter
+
+	// Secret scanning, when enabled
+	scanner       *secrets.Scanner
+	secretsReport *secrets.Report
+
+	// lfsLimiter is shared by all workers so --lfs-rate-limit caps the
+	// combined LFS download rate.
+	lfsLimiter *lfs.RateLimiter
+
+	// selector, when set, lets the user narrow the filtered repositories.
+	selector RepositorySelector
+
+	// objectCache, when enabled, supplies --reference-if-able stores.
+	objectCache *objcache.Cache
+
+	// submodulePool is shared by all workers so --submodule-jobs bounds the
+	// submodule updates of the whole run.
+	submodulePool *submodule.Pool
+
+	// quarantine, when enabled, holds the repositories that keep failing.
+	quarantine *quarantine.Store
+
+	// gitArgs pin the clone hosts to the host overrides and DNS servers of
+	// the provider's network settings, see httpclient.GitResolveArgs.
+	gitArgs []string
+}
+
+// RepositorySelector narrows the filtered repositories before cloning, for
+// example through an interactive picker. An empty result clones nothing.
+type RepositorySelector func(ctx context.Context, repos []RepositoryInfo) ([]RepositoryInfo, error)
+
+// PostCloneHook is implemented by providers that prepare fresh clones, such
+// as Gerrit installing its commit-msg hook.
+type PostCloneHook interface {
+	AfterClone(ctx context.Context, repoPath string) error
 }
 
 // NewCloneExecutor creates a new clone executor with the given provider and options.
@@ -32,12 +78,55 @@ func NewCloneExecutor(p provider.GitProvider, opts *CloneOptions) (*CloneExecuto
 	session := NewSession(opts)
 	progress := NewProgressReporter(opts.Format, opts.Quiet, opts.Verbose)
 
-	return &CloneExecutor{
+	executor := &CloneExecutor{
 		provider: p,
 		options:  opts,
 		session:  session,
 		progress: progress,
-	}, nil
+
+		lfsLimiter:    lfs.NewRateLimiter(opts.LFSRateLimit),
+		submodulePool: submodule.NewPool(opts.SubmoduleJobs),
+	}
+
+	if opts.ScanSecrets {
+		scanner, err := secrets.NewScannerFromFile(opts.SecretsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up secret scanning: %w", err)
+		}
+		executor.scanner = scanner
+		executor.secretsReport = secrets.NewReport()
+	}
+
+	if opts.ObjectCache {
+		executor.objectCache = objcache.New(opts.ObjectCacheDir)
+	}
+
+	if opts.Quarantine {
+		store, err := quarantine.Default()
+		if err != nil {
+			progress.Warning("Quarantine list unavailable, failing repositories are not skipped: %v", err)
+		} else {
+			executor.quarantine = store
+		}
+	}
+
+	return executor, nil
+}
+
+// SetEventSink routes progress events to sink instead of stdout, so that
+// callers running clones in the background can track them. It switches the
+// reporter to the event stream regardless of the configured format; sink may
+// be called from several goroutines.
+func (e *CloneExecutor) SetEventSink(sink func(cli.Event)) {
+	e.progress.format = FormatNDJSON
+	e.progress.quiet = false
+	e.progress.sink = sink
+}
+
+// SetSelector installs a selector that runs after filtering. Resumed
+// sessions skip it and reuse the saved selection.
+func (e *CloneExecutor) SetSelector(selector RepositorySelector) {
+	e.selector = selector
 }
 
 // Execute performs the clone operation based on the configured options.
@@ -54,7 +143,12 @@ func (e *CloneExecutor) Execute(ctx context.Context) error {
 		}
 	}
 
-	// 2. List repositories from provider
+	// 2. List repositories from provider, unless a required VPN is down
+	if e.options.BaseURL != "" {
+		if err := e.checkVPN(e.options.BaseURL); err != nil {
+			return err
+		}
+	}
 	repos, err := e.listRepositories(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list repositories: %w", err)
@@ -67,6 +161,17 @@ func (e *CloneExecutor) Execute(ctx context.Context) error {
 		return nil
 	}
 
+	if e.selector != nil && e.options.Resume == "" {
+		filtered, err = e.selectRepositories(ctx, filtered)
+		if err != nil {
+			return err
+		}
+		if len(filtered) == 0 {
+			e.progress.Info("No repositories selected")
+			return nil
+		}
+	}
+
 	// 4. Initialize progress tracking
 	e.progress.Start(len(filtered))
 	defer e.progress.Finish()
@@ -77,6 +182,9 @@ func (e *CloneExecutor) Execute(ctx context.Context) error {
 	}
 
 	// 6. Execute clone operations
+	if err := e.prepareNetwork(ctx, filtered); err != nil {
+		return err
+	}
 	summary, err := e.cloneRepositories(ctx, filtered)
 	if err != nil {
 		e.progress.Error("Clone operation failed: %v", err)
@@ -86,7 +194,8 @@ func (e *CloneExecutor) Execute(ctx context.Context) error {
 	// 7. Print summary
 	e.printSummary(summary)
 
-	return nil
+	// 8. Report secret scan findings
+	return e.finishSecretScan()
 }
 
 // listRepositories retrieves repositories from the provider.
@@ -130,23 +239,7 @@ func (e *CloneExecutor) filterRepositories(repos []provider.Repository) []Reposi
 	var filtered []RepositoryInfo
 
 	for _, repo := range repos {
-		repoInfo := RepositoryInfo{
-			ID:            repo.ID,
-			Name:          repo.Name,
-			FullName:      repo.FullName,
-			CloneURL:      repo.CloneURL,
-			SSHURL:        repo.SSHURL,
-			Private:       repo.Private,
-			Archived:      repo.Archived,
-			Fork:          repo.Fork,
-			Language:      repo.Language,
-			Topics:        repo.Topics,
-			Stars:         repo.Stars,
-			Forks:         repo.Forks,
-			UpdatedAt:     repo.UpdatedAt,
-			DefaultBranch: repo.DefaultBranch,
-		}
-
+		repoInfo := newRepositoryInfo(repo)
 		if repoInfo.Matches(e.options) {
 			filtered = append(filtered, repoInfo)
 		}
@@ -155,12 +248,96 @@ func (e *CloneExecutor) filterRepositories(repos []provider.Repository) []Reposi
 	return filtered
 }
 
+func newRepositoryInfo(repo provider.Repository) RepositoryInfo {
+	return RepositoryInfo{
+		ID:            repo.ID,
+		Name:          repo.Name,
+		FullName:      repo.FullName,
+		CloneURL:      repo.CloneURL,
+		SSHURL:        repo.SSHURL,
+		Private:       repo.Private,
+		Archived:      repo.Archived,
+		Fork:          repo.Fork,
+		Language:      repo.Language,
+		Topics:        repo.Topics,
+		Stars:         repo.Stars,
+		Forks:         repo.Forks,
+		UpdatedAt:     repo.UpdatedAt,
+		DefaultBranch: repo.DefaultBranch,
+		Description:   repo.Description,
+		Size:          repo.Size,
+		PushedAt:      repo.PushedAt,
+	}
+}
+
+// FilterMatch tells whether a repository passes the filters and why.
+type FilterMatch struct {
+	Repository string `json:"repository"`
+	Matched    bool   `json:"matched"`
+	// RejectedBy names the option that excluded the repository before the
+	// filter expression was evaluated.
+	RejectedBy string `json:"rejected_by,omitempty"`
+	// Steps are the filter expression conditions that decided the result.
+	Steps []repofilter.Step `json:"steps,omitempty"`
+}
+
+// ExplainFilters lists the repositories like Execute and reports for each
+// whether it would be cloned, without cloning anything.
+func (e *CloneExecutor) ExplainFilters(ctx context.Context) ([]FilterMatch, error) {
+	repos, err := e.listRepositories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	now := time.Now()
+	matches := make([]FilterMatch, 0, len(repos))
+	for _, repo := range repos {
+		info := newRepositoryInfo(repo)
+		m := FilterMatch{Repository: info.FullName, RejectedBy: info.rejectedBy(e.options)}
+		if m.Repository == "" {
+			m.Repository = info.Name
+		}
+		switch {
+		case m.RejectedBy != "":
+		case e.options.GetFilter() == nil:
+			m.Matched = true
+		default:
+			explanation := e.options.GetFilter().Explain(info.FilterAttributes(), now)
+			m.Matched, m.Steps = explanation.Matched, explanation.Steps
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+// selectRepositories runs the selector and records the selection in the
+// session, so --resume clones exactly the picked repositories.
+func (e *CloneExecutor) selectRepositories(ctx context.Context, repos []RepositoryInfo) ([]RepositoryInfo, error) {
+	selected, err := e.selector(ctx, repos)
+	if err != nil {
+		return nil, err
+	}
+
+	e.options.Repositories = make([]string, 0, len(selected))
+	for _, repo := range selected {
+		e.options.Repositories = append(e.options.Repositories, repoKey(repo))
+	}
+	if err := e.session.Save(); err != nil {
+		return nil, NewSessionError(e.session.ID, "save", "failed to save selection", err)
+	}
+	return selected, nil
+}
+
 // printDryRun prints what would be cloned without actually cloning.
 func (e *CloneExecutor) printDryRun(repos []RepositoryInfo) error {
 	e.progress.Info("Dry run - repositories that would be cloned:")
 	for _, repo := range repos {
-		targetPath := filepath.Join(e.options.Target, repo.FullName)
-		e.progress.Info("  %s -> %s", repo.FullName, targetPath)
+		if entry, skip := e.checkQuarantine(repo); skip {
+			e.progress.Info("  %s -> skipped, quarantined (%s) until %s",
+				repo.FullName, entry.Reason, entry.RetryAfter.Format(time.DateTime))
+			continue
+		}
+		e.progress.Info("  %s -> %s", repo.FullName, e.targetPath(repo))
 	}
 	e.progress.Info("Total: %d repositories", len(repos))
 	return nil
@@ -188,6 +365,18 @@ func (e *CloneExecutor) cloneRepositories(ctx context.Context, repos []Repositor
 			continue
 		}
 
+		entry, skip := e.checkQuarantine(repo)
+		if skip {
+			summary.Skipped++
+			summary.Quarantined++
+			e.progress.Skip(repo.FullName, fmt.Sprintf("quarantined (%s) until %s",
+				entry.Reason, entry.RetryAfter.Format(time.DateTime)))
+			continue
+		}
+		if entry != nil && entry.Quarantined() {
+			e.progress.Info("%s: retrying after quarantine (%s)", repo.FullName, entry.Reason)
+		}
+
 		wg.Add(1)
 		go func(r RepositoryInfo) {
 			defer wg.Done()
@@ -199,7 +388,7 @@ func (e *CloneExecutor) cloneRepositories(ctx context.Context, repos []Repositor
 			// Create clone request
 			request := &CloneRequest{
 				Repository: r,
-				TargetPath: filepath.Join(e.options.Target, r.FullName),
+				TargetPath: e.targetPath(r),
 				Options:    e.options,
 				SessionID:  e.session.ID,
 				StartedAt:  time.Now(),
@@ -214,6 +403,9 @@ func (e *CloneExecutor) cloneRepositories(ctx context.Context, repos []Repositor
 				e.session.MarkFailed(r.FullName, result.Error)
 			} else {
 				e.session.MarkCompleted(r.FullName)
+				e.syncLFS(ctx, r, request.TargetPath)
+				e.scanRepository(ctx, r, request.TargetPath)
+				e.archiveMirror(ctx, r, request.TargetPath)
 			}
 
 			// Save session progress
@@ -230,7 +422,9 @@ func (e *CloneExecutor) cloneRepositories(ctx context.Context, repos []Repositor
 	}()
 
 	// Collect results
+	var results []CloneResult
 	for result := range resultChan {
+		results = append(results, result)
 		if result.Error != nil {
 			summary.Failed++
 			summary.Errors = append(summary.Errors, result.Error)
@@ -244,9 +438,62 @@ func (e *CloneExecutor) cloneRepositories(ctx context.Context, repos []Repositor
 	summary.EndTime = time.Now()
 	summary.Duration = summary.EndTime.Sub(summary.StartTime)
 
+	e.recordQuarantine(results)
+
 	return summary, nil
 }
 
+// checkQuarantine returns the quarantine entry of repo, if any, and whether
+// the run must skip it.
+func (e *CloneExecutor) checkQuarantine(repo RepositoryInfo) (*quarantine.Entry, bool) {
+	entry, skip := e.quarantine.Check(e.options.Provider, repo.FullName, repoFingerprint(repo))
+	return entry, skip && !e.options.RetryQuarantined
+}
+
+// recordQuarantine updates the quarantine list with the outcome of a run.
+// When every repository of a run fails, the network or the credentials are
+// the likely cause rather than the repositories, so nothing is recorded.
+func (e *CloneExecutor) recordQuarantine(results []CloneResult) {
+	if e.quarantine == nil || len(results) == 0 {
+		return
+	}
+	failed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+		}
+	}
+	if len(results) > 1 && failed == len(results) {
+		return
+	}
+
+	for _, result := range results {
+		name := result.Repository.FullName
+		if result.Error == nil {
+			if e.quarantine.RecordSuccess(e.options.Provider, name) {
+				e.progress.Info("%s: released from quarantine", name)
+			}
+			continue
+		}
+		entry, quarantined := e.quarantine.RecordFailure(e.options.Provider, name, result.Error, repoFingerprint(result.Repository))
+		if quarantined {
+			e.progress.Warning("%s quarantined after %d failed runs (%s), skipped until %s",
+				name, entry.Failures, entry.Reason, entry.RetryAfter.Format(time.DateTime))
+		}
+	}
+	if err := e.quarantine.Save(); err != nil {
+		e.progress.Warning("Failed to save quarantine list: %v", err)
+	}
+}
+
+// repoFingerprint captures the provider-side state of a repository that a
+// fix changes: a push, a settings change or new visibility.
+func repoFingerprint(repo RepositoryInfo) string {
+	return fmt.Sprintf("%s|%s|%d|%t|%t",
+		repo.UpdatedAt.UTC().Format(time.RFC3339), repo.PushedAt.UTC().Format(time.RFC3339),
+		repo.Size, repo.Private, repo.Archived)
+}
+
 // cloneWithRetries performs clone operation with retry logic.
 func (e *CloneExecutor) cloneWithRetries(ctx context.Context, request *CloneRequest) CloneResult {
 	var lastErr error
@@ -300,14 +547,48 @@ func (e *CloneExecutor) cloneRepository(ctx context.Context, request *CloneReque
 	targetPath := request.TargetPath
 
 	// Check if repository already exists
-	if exists, err := e.pathExists(targetPath); err != nil {
+	exists, err := e.pathExists(targetPath)
+	if err != nil {
 		return NewCloneError(repo.FullName, "path_check", "failed to check target path", err)
-	} else if exists {
-		return e.handleExistingRepository(ctx, targetPath, repo)
+	}
+	if exists {
+		err = e.handleExistingRepository(ctx, targetPath, repo)
+	} else {
+		err = e.cloneNewRepository(ctx, targetPath, repo)
+	}
+	if err != nil {
+		return err
+	}
+
+	return e.updateSubmodules(ctx, targetPath, repo)
+}
+
+// updateSubmodules brings the submodules of a cloned or updated working tree
+// to the commits it records. A repository with failed submodules is
+// incomplete, so the failures, listed together, fail the repository.
+func (e *CloneExecutor) updateSubmodules(ctx context.Context, targetPath string, repo RepositoryInfo) error {
+	// Mirrors have no working tree, and fetch leaves it untouched
+	if e.options.IsMirror() || e.options.Strategy == StrategyFetch {
+		return nil
 	}
 
-	// Clone new repository
-	return e.cloneNewRepository(ctx, targetPath, repo)
+	report, err := submodule.Update(ctx, targetPath, submodule.Options{
+		Strategy: submodule.Strategy(e.options.Submodules),
+		Pool:     e.submodulePool,
+		Skip:     e.options.SkipSubmodules,
+		Pins:     e.options.PinSubmodules,
+	})
+	if err != nil {
+		return NewCloneError(repo.FullName, "submodule", err.Error(), err)
+	}
+	if n := len(report.Results); n > 0 && e.options.Verbose {
+		e.progress.Info("%s: %d submodule(s) updated, %d pinned, %d skipped", repo.FullName,
+			report.Count(submodule.StatusUpdated), report.Count(submodule.StatusPinned), report.Count(submodule.StatusSkipped))
+	}
+	if err := report.Err(); err != nil {
+		return NewCloneError(repo.FullName, "submodule", err.Error(), fmt.Errorf("%w: %w", ErrGitCommandFailed, err))
+	}
+	return nil
 }
 
 // cloneNewRepository clones a repository to a new location.
@@ -324,6 +605,10 @@ func (e *CloneExecutor) cloneNewRepository(ctx context.Context, targetPath strin
 	// Build git clone command
 	args := []string{"clone"}
 
+	if e.options.IsMirror() {
+		args = append(args, "--mirror")
+	}
+
 	if e.options.Depth > 0 {
 		args = append(args, "--depth", fmt.Sprintf("%d", e.options.Depth))
 	}
@@ -336,10 +621,14 @@ func (e *CloneExecutor) cloneNewRepository(ctx context.Context, targetPath strin
 		args = append(args, "--branch", e.options.Branch)
 	}
 
+	if store := e.referenceStore(ctx, cloneURL, targetPath, repo); store != "" {
+		args = append(args, "--reference-if-able", store)
+	}
+
 	args = append(args, cloneURL, targetPath)
 
 	// Execute git clone
-	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd := e.git(ctx, args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// Clean up partially cloned directory
@@ -347,16 +636,43 @@ func (e *CloneExecutor) cloneNewRepository(ctx context.Context, targetPath strin
 		return WrapGitError(repo.FullName, "clone", err, output)
 	}
 
-	// Create GZH file if requested
-	if e.options.CreateGZHFile {
+	// Create GZH file if requested; bare mirrors are left untouched
+	if e.options.CreateGZHFile && !e.options.IsMirror() {
 		if err := e.createGZHFile(targetPath, repo); err != nil {
 			e.progress.Warning("Failed to create .gzh file for %s: %v", repo.FullName, err)
 		}
 	}
 
+	if hook, ok := e.provider.(PostCloneHook); ok && !e.options.IsMirror() {
+		if err := hook.AfterClone(ctx, targetPath); err != nil {
+			e.progress.Warning("Failed to prepare %s: %v", repo.FullName, err)
+		}
+	}
+
 	return nil
 }
 
+// referenceStore returns the object cache store to clone targetPath with,
+// or "" to clone without one. Cache problems only cost the savings, so they
+// are reported as warnings and the clone goes ahead on its own.
+func (e *CloneExecutor) referenceStore(ctx context.Context, cloneURL, targetPath string, repo RepositoryInfo) string {
+	if e.objectCache == nil {
+		return ""
+	}
+	store, err := e.objectCache.Prepare(ctx, cloneURL)
+	if err != nil {
+		e.progress.Warning("Object cache unavailable for %s: %v", repo.FullName, err)
+		if store == "" {
+			return ""
+		}
+	}
+	if err := e.objectCache.Register(store, targetPath); err != nil {
+		e.progress.Warning("Object cache unavailable for %s: %v", repo.FullName, err)
+		return ""
+	}
+	return store
+}
+
 // handleExistingRepository handles operations on existing repositories.
 func (e *CloneExecutor) handleExistingRepository(ctx context.Context, targetPath string, repo RepositoryInfo) error {
 	switch e.options.Strategy {
@@ -366,6 +682,8 @@ func (e *CloneExecutor) handleExistingRepository(ctx context.Context, targetPath
 		return e.pull(ctx, targetPath, repo)
 	case StrategyFetch:
 		return e.fetch(ctx, targetPath, repo)
+	case StrategyMirror:
+		return e.updateMirror(ctx, targetPath, repo)
 	default:
 		return NewCloneError(repo.FullName, "strategy", "unknown strategy", ErrInvalidStrategy)
 	}
@@ -385,13 +703,13 @@ func (e *CloneExecutor) resetAndPull(ctx context.Context, targetPath string, rep
 	}
 
 	// Git reset --hard
-	cmd := exec.CommandContext(ctx, "git", "reset", "--hard")
+	cmd := gitCommand(ctx, "reset", "--hard")
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return WrapGitError(repo.FullName, "reset", err, output)
 	}
 
 	// Git pull
-	cmd = exec.CommandContext(ctx, "git", "pull")
+	cmd = e.git(ctx, "pull")
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return WrapGitError(repo.FullName, "pull", err, output)
 	}
@@ -409,9 +727,14 @@ func (e *CloneExecutor) fetch(ctx context.Context, targetPath string, repo Repos
 	return e.runGitCommand(ctx, targetPath, repo, "fetch", []string{"fetch"})
 }
 
+// updateMirror refreshes a bare mirror with all refs, pruning deleted ones.
+func (e *CloneExecutor) updateMirror(ctx context.Context, targetPath string, repo RepositoryInfo) error {
+	return e.runGitCommand(ctx, targetPath, repo, "remote update", []string{"remote", "update", "--prune"})
+}
+
 // runGitCommand runs a git command in the specified directory.
 func (e *CloneExecutor) runGitCommand(ctx context.Context, targetPath string, repo RepositoryInfo, operation string, args []string) error {
-	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd := e.git(ctx, args...)
 	cmd.Dir = targetPath
 
 	output, err := cmd.CombinedOutput()
@@ -422,6 +745,80 @@ func (e *CloneExecutor) runGitCommand(ctx context.Context, targetPath string, re
 	return nil
 }
 
+// gitCommand builds a git command that leaves LFS pointers in place. When
+// git-lfs is installed its smudge filter would otherwise download every
+// object during checkout, ignoring --skip-lfs and the rate limit; objects
+// are fetched separately by syncLFS instead.
+func gitCommand(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_LFS_SKIP_SMUDGE=1")
+	return cmd
+}
+
+// git builds a git command for a network operation, carrying the host
+// pinning options of the provider's network settings.
+func (e *CloneExecutor) git(ctx context.Context, args ...string) *exec.Cmd {
+	return gitCommand(ctx, append(slices.Clone(e.gitArgs), args...)...)
+}
+
+// prepareNetwork checks that the hosts of the clone URLs are reachable with
+// respect to a required VPN before hours of cloning start, and pins them
+// to the configured host overrides and DNS servers.
+func (e *CloneExecutor) prepareNetwork(ctx context.Context, repos []RepositoryInfo) error {
+	urls := make([]string, 0, len(repos))
+	for i := range repos {
+		urls = append(urls, repos[i].GetCloneURL(e.options.Protocol))
+	}
+	if err := e.checkVPN(urls...); err != nil {
+		return err
+	}
+	e.gitArgs = httpclient.GitResolveArgs(ctx, e.options.Provider, urls...)
+	return nil
+}
+
+// checkVPN fails when a host of urls is only reachable through a VPN and no
+// tunnel interface is active.
+func (e *CloneExecutor) checkVPN(urls ...string) error {
+	hosts := make([]string, 0, len(urls))
+	seen := make(map[string]bool)
+	for _, u := range urls {
+		if host := urlHost(u); host != "" && !seen[host] {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	if err := httpclient.CheckProviderVPN(e.options.Provider, hosts...); err != nil {
+		e.progress.Error("Pre-flight check failed: %v", err)
+		return fmt.Errorf("pre-flight check failed: %w", err)
+	}
+	return nil
+}
+
+// urlHost returns the host of a clone URL, including the scp-like
+// "git@host:path" form of SSH URLs.
+func urlHost(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		return u.Hostname()
+	}
+	if at := strings.Index(rawURL, "@"); at >= 0 {
+		rawURL = rawURL[at+1:]
+	}
+	if colon := strings.Index(rawURL, ":"); colon > 0 && !strings.Contains(rawURL[:colon], "/") {
+		return rawURL[:colon]
+	}
+	return ""
+}
+
+// targetPath returns the local path of a repository. Mirrors follow the bare
+// repository convention of a .git suffix.
+func (e *CloneExecutor) targetPath(repo RepositoryInfo) string {
+	path := filepath.Join(e.options.Target, repo.FullName)
+	if e.options.IsMirror() {
+		path += ".git"
+	}
+	return path
+}
+
 // pathExists checks if a path exists.
 func (e *CloneExecutor) pathExists(path string) (bool, error) {
 	_, err := os.Stat(path)
@@ -454,6 +851,9 @@ func (e *CloneExecutor) printSummary(summary *CloneSummary) {
 	e.progress.Info("  Succeeded: %d", summary.Succeeded)
 	e.progress.Info("  Failed:    %d", summary.Failed)
 	e.progress.Info("  Skipped:   %d", summary.Skipped)
+	if summary.Quarantined > 0 {
+		e.progress.Info("    quarantined: %d (see 'gz git repo quarantine list')", summary.Quarantined)
+	}
 	e.progress.Info("  Duration:  %v", summary.Duration)
 
 	if summary.Failed > 0 {
@@ -464,6 +864,146 @@ func (e *CloneExecutor) printSummary(summary *CloneSummary) {
 	}
 }
 
+// syncLFS reports the LFS objects of a working tree and, with --include-lfs,
+// downloads them and checks them out. Failures are reported but do not fail
+// the clone; a later run resumes incomplete downloads.
+func (e *CloneExecutor) syncLFS(ctx context.Context, repo RepositoryInfo, targetPath string) {
+	if e.options.LFS == LFSSkip || e.options.IsMirror() || !lfs.Detect(targetPath) {
+		return
+	}
+
+	files, err := lfs.ScanPointers(ctx, targetPath)
+	if err != nil {
+		e.progress.Warning("Failed to scan LFS files in %s: %v", repo.FullName, err)
+		return
+	}
+	objects, size := lfs.Unique(files)
+	if len(objects) == 0 {
+		return
+	}
+
+	if e.options.LFS != LFSInclude {
+		e.progress.Warning("%s tracks %d LFS object(s) (%s) that were not downloaded; use --include-lfs to fetch them",
+			repo.FullName, len(objects), lfs.FormatSize(size))
+		return
+	}
+
+	endpoint, err := lfs.Endpoint(targetPath, repo.GetCloneURL(e.options.Protocol))
+	if err != nil {
+		e.progress.Warning("Failed to resolve LFS endpoint for %s: %v", repo.FullName, err)
+		return
+	}
+	fetcher := &lfs.Fetcher{
+		Endpoint: endpoint,
+		Username: e.lfsUsername(),
+		Password: e.options.Token,
+		// 객체는 클 수 있으므로 전체 제한 시간 없이 공급자 네트워크 설정만 따른다
+		HTTPClient: httpclient.NewProviderClient(e.options.Provider, 0),
+		Limiter:    e.lfsLimiter,
+	}
+	stats, err := fetcher.Fetch(ctx, filepath.Join(targetPath, ".git"), objects)
+	e.progress.LFS(repo.FullName, stats)
+	if err != nil {
+		e.progress.Warning("LFS download incomplete for %s: %v", repo.FullName, err)
+		return
+	}
+
+	if err := lfs.Checkout(ctx, targetPath); err != nil {
+		e.progress.Warning("LFS objects for %s were downloaded but not checked out: %v", repo.FullName, err)
+	}
+}
+
+// lfsUsername returns the basic auth user that providers expect alongside
+// an access token.
+func (e *CloneExecutor) lfsUsername() string {
+	switch {
+	case e.options.Username != "":
+		return e.options.Username
+	case e.options.Provider == "gitlab":
+		return "oauth2"
+	default:
+		return "x-access-token"
+	}
+}
+
+// scanRepository scans a freshly cloned or updated working tree for secrets.
+func (e *CloneExecutor) scanRepository(ctx context.Context, repo RepositoryInfo, targetPath string) {
+	if e.scanner == nil {
+		return
+	}
+
+	findings, err := e.scanner.ScanDir(ctx, repo.FullName, targetPath)
+	if err != nil {
+		e.secretsReport.AddError(repo.FullName, err)
+		e.progress.Warning("Secret scan failed for %s: %v", repo.FullName, err)
+		return
+	}
+	e.secretsReport.Add(findings)
+	if len(findings) > 0 {
+		e.progress.Warning("%d potential secret(s) found in %s", len(findings), repo.FullName)
+	}
+}
+
+// archiveMirror writes a date-stamped archive of a mirror below the archive
+// root and prunes old archives. Failures are reported but do not fail the
+// clone, since the mirror itself is up to date.
+func (e *CloneExecutor) archiveMirror(ctx context.Context, repo RepositoryInfo, mirrorPath string) {
+	if !e.options.IsMirror() || e.options.ArchiveRoot == "" {
+		return
+	}
+
+	path, err := ArchiveMirror(ctx, mirrorPath, e.options.ArchiveRoot, repo.FullName,
+		ArchiveFormat(e.options.ArchiveFormat), time.Now())
+	if err != nil {
+		e.progress.Warning("Failed to archive %s: %v", repo.FullName, err)
+		return
+	}
+	e.progress.Info("Archived %s -> %s", repo.FullName, path)
+
+	policy := RetentionPolicy{KeepLast: e.options.KeepArchives, MaxAge: e.options.MaxArchiveAge}
+	removed, err := ApplyRetention(filepath.Dir(path), filepath.Base(repo.FullName), policy, time.Now())
+	if err != nil {
+		e.progress.Warning("Failed to apply archive retention for %s: %v", repo.FullName, err)
+	}
+	for _, old := range removed {
+		e.progress.Info("Removed expired archive %s", old)
+	}
+}
+
+// finishSecretScan prints and writes the consolidated findings and fails the
+// run when findings reach the --fail-on-secrets severity.
+func (e *CloneExecutor) finishSecretScan() error {
+	if e.secretsReport == nil {
+		return nil
+	}
+
+	switch OutputFormat(e.options.Format) {
+	case FormatProgress, FormatTable:
+		if !e.options.Quiet {
+			e.secretsReport.PrintSummary(os.Stdout)
+		}
+	}
+
+	if e.options.SecretsReport != "" {
+		if err := e.secretsReport.WriteJSON(e.options.SecretsReport); err != nil {
+			return err
+		}
+		e.progress.Info("Secret scan report written to %s", e.options.SecretsReport)
+	}
+
+	if e.options.FailOnSecrets == "" {
+		return nil
+	}
+	threshold, err := secrets.ParseSeverity(e.options.FailOnSecrets)
+	if err != nil {
+		return err
+	}
+	if n := e.secretsReport.CountAtLeast(threshold); n > 0 {
+		return fmt.Errorf("secret scan found %d finding(s) of %s severity or higher", n, threshold)
+	}
+	return nil
+}
+
 // CloneResult represents the result of a single clone operation.
 type CloneResult struct {
 	Repository RepositoryInfo
@@ -477,8 +1017,10 @@ type CloneSummary struct {
 	Succeeded int
 	Failed    int
 	Skipped   int
-	Errors    []error
-	StartTime time.Time
-	EndTime   time.Time
-	Duration  time.Duration
+	// Quarantined counts the skipped repositories that are quarantined.
+	Quarantined int
+	Errors      []error
+	StartTime   time.Time
+	EndTime     time.Time
+	Duration    time.Duration
 }
@@ -0,0 +1,192 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package clone
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/git/objcache"
+	"github.com/gizzahub/gzh-cli/internal/git/quarantine"
+	"github.com/gizzahub/gzh-cli/internal/git/repofilter"
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+func TestCloneWithObjectCache(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+
+	upstream := t.TempDir()
+	cmd := exec.Command("git", "-C", upstream, "init", "-q")
+	require.NoError(t, cmd.Run())
+	cmd = exec.Command("git", "-C", upstream, "commit", "-q", "--allow-empty", "-m", "initial")
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+		"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	opts := DefaultCloneOptions()
+	opts.Provider, opts.Org = "github", "acme"
+	opts.Target = t.TempDir()
+	opts.ObjectCache = true
+	opts.ObjectCacheDir = t.TempDir()
+	require.NoError(t, opts.Validate())
+
+	e := &CloneExecutor{
+		options:     opts,
+		progress:    NewProgressReporter(string(FormatQuiet), true, false),
+		objectCache: objcache.New(opts.ObjectCacheDir),
+	}
+	repo := RepositoryInfo{Name: "api", FullName: "acme/api", CloneURL: upstream}
+	target := e.targetPath(repo)
+	require.NoError(t, e.cloneRepository(ctx, &CloneRequest{Repository: repo, TargetPath: target}))
+
+	alternates, err := os.ReadFile(filepath.Join(target, ".git", "objects", "info", "alternates"))
+	require.NoError(t, err)
+	assert.Contains(t, string(alternates), opts.ObjectCacheDir)
+
+	stores, err := e.objectCache.List()
+	require.NoError(t, err)
+	require.Len(t, stores, 1)
+	assert.Equal(t, []string{target}, stores[0].LiveUsers)
+}
+
+func TestValidateObjectCacheOptions(t *testing.T) {
+	opts := DefaultCloneOptions()
+	opts.Provider, opts.Org = "github", "acme"
+	opts.ObjectCache = true
+	require.NoError(t, opts.Validate())
+
+	opts.Strategy = StrategyMirror
+	assert.ErrorIs(t, opts.Validate(), ErrMirrorObjectCache)
+}
+
+// listProvider serves a fixed repository list.
+type listProvider struct {
+	provider.GitProvider
+	repos []provider.Repository
+}
+
+func (p listProvider) ListRepositories(context.Context, provider.ListOptions) (*provider.RepositoryList, error) {
+	return &provider.RepositoryList{Repositories: p.repos}, nil
+}
+
+func TestExplainFilters(t *testing.T) {
+	opts := DefaultCloneOptions()
+	opts.Provider, opts.Org = "github", "acme"
+	opts.Exclude = "^sandbox$"
+	opts.Filter = `language == go and not fork`
+	require.NoError(t, opts.Validate())
+
+	e := &CloneExecutor{options: opts, provider: listProvider{repos: []provider.Repository{
+		{Name: "api", FullName: "acme/api", Language: "Go"},
+		{Name: "web", FullName: "acme/web", Language: "TypeScript"},
+		{Name: "sandbox", FullName: "acme/sandbox", Language: "Go"},
+	}}}
+
+	matches, err := e.ExplainFilters(context.Background())
+	require.NoError(t, err)
+	require.Len(t, matches, 3)
+
+	assert.True(t, matches[0].Matched)
+	assert.Len(t, matches[0].Steps, 2)
+
+	assert.False(t, matches[1].Matched)
+	assert.Equal(t, []repofilter.Step{{Condition: "language == go", Value: "TypeScript", Matched: false}}, matches[1].Steps)
+
+	assert.False(t, matches[2].Matched)
+	assert.Equal(t, "--exclude", matches[2].RejectedBy)
+	assert.Empty(t, matches[2].Steps)
+}
+
+func TestRecordQuarantine(t *testing.T) {
+	store, err := quarantine.Open(filepath.Join(t.TempDir(), "quarantine.json"))
+	require.NoError(t, err)
+	store.Threshold = 1
+
+	opts := DefaultCloneOptions()
+	opts.Provider = "github"
+	e := &CloneExecutor{
+		options:    opts,
+		progress:   NewProgressReporter(string(FormatQuiet), true, false),
+		quarantine: store,
+	}
+	ok := RepositoryInfo{FullName: "acme/ok"}
+	huge := RepositoryInfo{FullName: "acme/huge", Size: 9_000_000}
+	tooLarge := errors.New("remote: fatal: pack exceeds maximum allowed size")
+
+	// A run in which everything failed records nothing
+	e.recordQuarantine([]CloneResult{
+		{Repository: ok, Error: errors.New("fatal: Authentication failed")},
+		{Repository: huge, Error: tooLarge},
+	})
+	assert.Empty(t, store.List())
+
+	e.recordQuarantine([]CloneResult{{Repository: ok}, {Repository: huge, Error: tooLarge}})
+	_, skip := e.checkQuarantine(huge)
+	assert.True(t, skip)
+	_, skip = e.checkQuarantine(ok)
+	assert.False(t, skip)
+
+	e.options.RetryQuarantined = true
+	_, skip = e.checkQuarantine(huge)
+	assert.False(t, skip)
+	e.options.RetryQuarantined = false
+
+	// Reducing the repository's size on the provider side ends the quarantine
+	huge.Size = 400_000
+	_, skip = e.checkQuarantine(huge)
+	assert.False(t, skip)
+}
+
+func TestPrepareNetwork(t *testing.T) {
+	t.Cleanup(func() { _ = httpclient.Configure(httpclient.NetworkConfig{}, nil) })
+	require.NoError(t, httpclient.Configure(httpclient.NetworkConfig{}, map[string]httpclient.NetworkConfig{
+		"gitlab": {
+			Hosts:         map[string]string{"gitlab.corp.invalid": "10.20.0.8"},
+			VPNHosts:      []string{".corp.invalid"},
+			VPNInterfaces: []string{"gz-test-vpn*"},
+		},
+	}))
+
+	opts := DefaultCloneOptions()
+	opts.Provider, opts.Org = "gitlab", "platform"
+	e := &CloneExecutor{options: opts, progress: NewProgressReporter(string(FormatQuiet), true, false)}
+
+	repos := []RepositoryInfo{{FullName: "platform/api", CloneURL: "https://gitlab.corp.invalid/platform/api.git"}}
+	err := e.prepareNetwork(context.Background(), repos)
+	var vpnErr *httpclient.VPNRequiredError
+	require.True(t, errors.As(err, &vpnErr), "no gz-test-vpn interface exists: %v", err)
+	assert.Equal(t, "gitlab.corp.invalid", vpnErr.Host)
+
+	opts.Provider = "github"
+	require.NoError(t, e.prepareNetwork(context.Background(), repos))
+	assert.Empty(t, e.gitArgs)
+
+	require.NoError(t, httpclient.Configure(httpclient.NetworkConfig{}, map[string]httpclient.NetworkConfig{
+		"gitlab": {Hosts: map[string]string{"gitlab.corp.invalid": "10.20.0.8"}},
+	}))
+	opts.Provider = "gitlab"
+	require.NoError(t, e.prepareNetwork(context.Background(), repos))
+	assert.Equal(t, []string{"-c", "http.curloptResolve=gitlab.corp.invalid:443:10.20.0.8"}, e.gitArgs)
+	assert.Equal(t, []string{"-c", "http.curloptResolve=gitlab.corp.invalid:443:10.20.0.8", "fetch"}, e.git(context.Background(), "fetch").Args[1:])
+}
+
+func TestURLHost(t *testing.T) {
+	assert.Equal(t, "gitlab.corp.invalid", urlHost("https://gitlab.corp.invalid:8443/a/b.git"))
+	assert.Equal(t, "gitlab.corp.invalid", urlHost("ssh://git@gitlab.corp.invalid:2222/a/b.git"))
+	assert.Equal(t, "gitlab.corp.invalid", urlHost("git@gitlab.corp.invalid:a/b.git"))
+	assert.Empty(t, urlHost("/srv/git/a.git"))
+}
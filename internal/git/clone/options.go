@@ -4,15 +4,21 @@
 package clone
 
 import (
+	"fmt"
 	"regexp"
 	"slices"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/repofilter"
+	"github.com/gizzahub/gzh-cli/internal/git/submodule"
+	"github.com/gizzahub/gzh-cli/pkg/security/secrets"
 )
 
 // CloneOptions represents options for repository cloning operations.
 type CloneOptions struct {
 	// Provider configuration
 	Provider string `json:"provider"`
+	BaseURL  string `json:"base_url,omitempty"`
 	Org      string `json:"org"`
 	Target   string `json:"target"`
 	Config   string `json:"config,omitempty"`
@@ -28,6 +34,7 @@ type CloneOptions struct {
 	// Filtering options
 	Match           string   `json:"match,omitempty"`
 	Exclude         string   `json:"exclude,omitempty"`
+	Filter          string   `json:"filter,omitempty"` // repofilter expression
 	Visibility      string   `json:"visibility"`
 	IncludeArchived bool     `json:"include_archived"`
 	IncludeForks    bool     `json:"include_forks"`
@@ -36,6 +43,13 @@ type CloneOptions struct {
 	MinStars        int      `json:"min_stars"`
 	MaxStars        int      `json:"max_stars"`
 	UpdatedSince    string   `json:"updated_since,omitempty"`
+	// Repositories limits the run to these repository names, as picked
+	// with --interactive. It is saved with the session so a resumed run
+	// clones the same selection.
+	Repositories []string `json:"repositories,omitempty"`
+	// Interactive shows a picker over the filtered repositories. It is not
+	// saved: a resumed session reuses Repositories instead.
+	Interactive bool `json:"-"`
 
 	// Output and behavior
 	Format         string `json:"format"`
@@ -45,6 +59,12 @@ type CloneOptions struct {
 	CleanupOrphans bool   `json:"cleanup_orphans"`
 	CreateGZHFile  bool   `json:"create_gzh_file"`
 
+	// Quarantine skips repositories that failed several runs in a row (see
+	// internal/git/quarantine) and records the outcome of this run.
+	// RetryQuarantined attempts quarantined repositories anyway.
+	Quarantine       bool `json:"quarantine"`
+	RetryQuarantined bool `json:"retry_quarantined,omitempty"`
+
 	// Authentication
 	Token    string `json:"token,omitempty"`
 	Username string `json:"username,omitempty"`
@@ -56,9 +76,40 @@ type CloneOptions struct {
 	SingleBranch bool   `json:"single_branch"`
 	Branch       string `json:"branch,omitempty"`
 
+	// Object cache: new clones borrow objects from a shared store per fork
+	// family (see internal/git/objcache), ObjectCacheDir overrides its root.
+	ObjectCache    bool   `json:"object_cache"`
+	ObjectCacheDir string `json:"object_cache_dir,omitempty"`
+
+	// Secret scanning
+	ScanSecrets   bool   `json:"scan_secrets"`
+	SecretsConfig string `json:"secrets_config,omitempty"`
+	SecretsReport string `json:"secrets_report,omitempty"`
+	FailOnSecrets string `json:"fail_on_secrets,omitempty"` // minimum severity that fails the run
+
+	// Mirror archiving (mirror strategy only)
+	ArchiveRoot   string        `json:"archive_root,omitempty"`
+	ArchiveFormat string        `json:"archive_format,omitempty"`
+	KeepArchives  int           `json:"keep_archives"`
+	MaxArchiveAge time.Duration `json:"max_archive_age"`
+
+	// Git LFS: "" detects LFS usage and only reports it, LFSInclude
+	// downloads the objects and LFSSkip ignores LFS entirely.
+	LFS          string `json:"lfs,omitempty"`
+	LFSRateLimit int64  `json:"lfs_rate_limit,omitempty"` // bytes per second, 0 for unlimited
+
+	// Submodules: "" initializes submodules recursively, see
+	// submodule.Strategies. SubmoduleJobs bounds the submodule updates of
+	// all workers together.
+	Submodules     string            `json:"submodules,omitempty"`
+	SubmoduleJobs  int               `json:"submodule_jobs,omitempty"`
+	SkipSubmodules []string          `json:"skip_submodules,omitempty"`
+	PinSubmodules  map[string]string `json:"pin_submodules,omitempty"`
+
 	// Compiled patterns (internal use)
-	matchPattern   *regexp.Regexp `json:"-"`
-	excludePattern *regexp.Regexp `json:"-"`
+	matchPattern   *regexp.Regexp     `json:"-"`
+	excludePattern *regexp.Regexp     `json:"-"`
+	filter         *repofilter.Filter `json:"-"`
 }
 
 // CloneStrategy represents the strategy to use when cloning repositories.
@@ -71,6 +122,9 @@ const (
 	StrategyPull CloneStrategy = "pull"
 	// StrategyFetch performs git fetch only for existing repos
 	StrategyFetch CloneStrategy = "fetch"
+	// StrategyMirror keeps bare mirror clones (all refs, including notes)
+	// and runs git remote update --prune for existing mirrors
+	StrategyMirror CloneStrategy = "mirror"
 )
 
 // OutputFormat represents the output format for clone operations.
@@ -85,6 +139,8 @@ const (
 	FormatTable OutputFormat = "table"
 	// FormatQuiet suppresses most output
 	FormatQuiet OutputFormat = "quiet"
+	// FormatNDJSON streams one JSON event per line as each repository completes
+	FormatNDJSON OutputFormat = "ndjson"
 )
 
 // Validate validates the clone options and compiles regex patterns.
@@ -102,7 +158,7 @@ func (opts *CloneOptions) Validate() error {
 
 	// Validate strategy
 	switch opts.Strategy {
-	case StrategyReset, StrategyPull, StrategyFetch:
+	case StrategyReset, StrategyPull, StrategyFetch, StrategyMirror:
 		// Valid strategies
 	case "":
 		opts.Strategy = StrategyReset // Default
@@ -112,7 +168,7 @@ func (opts *CloneOptions) Validate() error {
 
 	// Validate format
 	switch OutputFormat(opts.Format) {
-	case FormatProgress, FormatJSON, FormatTable, FormatQuiet:
+	case FormatProgress, FormatJSON, FormatTable, FormatQuiet, FormatNDJSON:
 		// Valid formats
 	case "":
 		opts.Format = string(FormatProgress) // Default
@@ -157,6 +213,39 @@ func (opts *CloneOptions) Validate() error {
 		return ErrInvalidVisibility
 	}
 
+	// Validate secret scanning; a config, report or threshold implies scanning
+	if opts.SecretsConfig != "" || opts.SecretsReport != "" || opts.FailOnSecrets != "" {
+		opts.ScanSecrets = true
+	}
+	if opts.FailOnSecrets != "" {
+		if _, err := secrets.ParseSeverity(opts.FailOnSecrets); err != nil {
+			return err
+		}
+	}
+
+	// Validate mirror and archive settings
+	if err := opts.validateMirror(); err != nil {
+		return err
+	}
+
+	// Validate LFS settings
+	switch opts.LFS {
+	case "", LFSInclude, LFSSkip:
+	default:
+		return ErrInvalidLFSMode
+	}
+	if opts.LFSRateLimit < 0 {
+		return ErrInvalidLFSMode
+	}
+
+	// Validate submodule settings
+	if _, err := submodule.ParseStrategy(opts.Submodules); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSubmodules, err)
+	}
+	if opts.SubmoduleJobs < 0 {
+		return ErrInvalidSubmodules
+	}
+
 	// Compile regex patterns
 	if opts.Match != "" {
 		pattern, err := regexp.Compile(opts.Match)
@@ -174,14 +263,76 @@ func (opts *CloneOptions) Validate() error {
 		opts.excludePattern = pattern
 	}
 
+	if opts.Filter != "" {
+		filter, err := repofilter.Parse(opts.Filter)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+		}
+		opts.filter = filter
+	}
+
+	return nil
+}
+
+// validateMirror checks options that only apply to, or conflict with, the
+// mirror strategy.
+func (opts *CloneOptions) validateMirror() error {
+	if opts.Strategy != StrategyMirror {
+		if opts.ArchiveRoot != "" {
+			return ErrArchiveRequiresMirror
+		}
+		return nil
+	}
+
+	// A mirror contains every ref; partial clone options do not apply
+	if opts.Depth > 0 || opts.SingleBranch || opts.Branch != "" {
+		return ErrMirrorPartialClone
+	}
+	// Bare mirrors have no working tree to scan
+	if opts.ScanSecrets {
+		return ErrMirrorSecretScan
+	}
+	// Archives of a mirror that borrows objects would be incomplete
+	if opts.ObjectCache {
+		return ErrMirrorObjectCache
+	}
+
+	if opts.ArchiveRoot == "" {
+		return nil
+	}
+	if opts.ArchiveFormat == "" {
+		opts.ArchiveFormat = string(DefaultArchiveFormat())
+	}
+	if !IsValidArchiveFormat(opts.ArchiveFormat) {
+		return ErrInvalidArchiveFormat
+	}
+	if opts.KeepArchives < 0 || opts.MaxArchiveAge < 0 {
+		return ErrInvalidRetention
+	}
 	return nil
 }
 
+// LFS modes for CloneOptions.LFS.
+const (
+	LFSInclude = "include"
+	LFSSkip    = "skip"
+)
+
+// IsMirror reports whether repositories are kept as bare mirrors.
+func (opts *CloneOptions) IsMirror() bool {
+	return opts.Strategy == StrategyMirror
+}
+
 // GetMatchPattern returns the compiled match pattern.
 func (opts *CloneOptions) GetMatchPattern() *regexp.Regexp {
 	return opts.matchPattern
 }
 
+// GetFilter returns the compiled filter expression, or nil without one.
+func (opts *CloneOptions) GetFilter() *repofilter.Filter {
+	return opts.filter
+}
+
 // GetExcludePattern returns the compiled exclude pattern.
 func (opts *CloneOptions) GetExcludePattern() *regexp.Regexp {
 	return opts.excludePattern
@@ -190,7 +341,7 @@ func (opts *CloneOptions) GetExcludePattern() *regexp.Regexp {
 // IsValidStrategy checks if the given strategy is valid.
 func IsValidStrategy(strategy string) bool {
 	switch CloneStrategy(strategy) {
-	case StrategyReset, StrategyPull, StrategyFetch:
+	case StrategyReset, StrategyPull, StrategyFetch, StrategyMirror:
 		return true
 	default:
 		return false
@@ -203,6 +354,7 @@ func GetValidStrategies() []string {
 		string(StrategyReset),
 		string(StrategyPull),
 		string(StrategyFetch),
+		string(StrategyMirror),
 	}
 }
 
@@ -213,6 +365,7 @@ func GetValidFormats() []string {
 		string(FormatJSON),
 		string(FormatTable),
 		string(FormatQuiet),
+		string(FormatNDJSON),
 	}
 }
 
@@ -230,6 +383,7 @@ func DefaultCloneOptions() *CloneOptions {
 		IncludeForks:    true,
 		Protocol:        "https",
 		CreateGZHFile:   true,
+		Quarantine:      true,
 	}
 }
 
@@ -261,6 +415,9 @@ type RepositoryInfo struct {
 	Forks         int       `json:"forks"`
 	UpdatedAt     time.Time `json:"updated_at"`
 	DefaultBranch string    `json:"default_branch"`
+	Description   string    `json:"description,omitempty"`
+	Size          int64     `json:"size,omitempty"` // kilobytes
+	PushedAt      time.Time `json:"pushed_at,omitempty"`
 }
 
 // GetCloneURL returns the appropriate clone URL based on protocol.
@@ -281,37 +438,46 @@ func (r *RepositoryInfo) GetCloneURL(protocol string) string {
 
 // Matches checks if the repository matches the given options filters.
 func (r *RepositoryInfo) Matches(opts *CloneOptions) bool {
+	if r.rejectedBy(opts) != "" {
+		return false
+	}
+	return opts.GetFilter() == nil || opts.GetFilter().Match(r.FilterAttributes(), time.Now())
+}
+
+// rejectedBy returns the option, other than the filter expression, that
+// excludes the repository, or "" when none does.
+func (r *RepositoryInfo) rejectedBy(opts *CloneOptions) string {
 	// Check visibility
 	if opts.Visibility != "all" {
 		if opts.Visibility == "public" && r.Private {
-			return false
+			return "--visibility public"
 		}
 		if opts.Visibility == "private" && !r.Private {
-			return false
+			return "--visibility private"
 		}
 	}
 
 	// Check archived repositories
 	if !opts.IncludeArchived && r.Archived {
-		return false
+		return "archived (--include-archived)"
 	}
 
 	// Check forks
 	if !opts.IncludeForks && r.Fork {
-		return false
+		return "fork (--include-forks)"
 	}
 
 	// Check language
 	if opts.Language != "" && r.Language != opts.Language {
-		return false
+		return "--language"
 	}
 
 	// Check stars
 	if opts.MinStars > 0 && r.Stars < opts.MinStars {
-		return false
+		return "--min-stars"
 	}
 	if opts.MaxStars > 0 && r.Stars > opts.MaxStars {
-		return false
+		return "--max-stars"
 	}
 
 	// Check topics
@@ -326,23 +492,49 @@ func (r *RepositoryInfo) Matches(opts *CloneOptions) bool {
 			}
 		}
 		if !hasRequiredTopic {
-			return false
+			return "--topics"
 		}
 	}
 
+	// Check explicit selection
+	if len(opts.Repositories) > 0 && !slices.Contains(opts.Repositories, r.Name) && !slices.Contains(opts.Repositories, r.FullName) {
+		return "not selected"
+	}
+
 	// Check match pattern
 	if opts.GetMatchPattern() != nil {
 		if !opts.GetMatchPattern().MatchString(r.Name) && !opts.GetMatchPattern().MatchString(r.FullName) {
-			return false
+			return "--match"
 		}
 	}
 
 	// Check exclude pattern
 	if opts.GetExcludePattern() != nil {
 		if opts.GetExcludePattern().MatchString(r.Name) || opts.GetExcludePattern().MatchString(r.FullName) {
-			return false
+			return "--exclude"
 		}
 	}
 
-	return true
+	return ""
+}
+
+// FilterAttributes returns the attributes filter expressions test. Without
+// a push time, the last update stands in for it.
+func (r *RepositoryInfo) FilterAttributes() repofilter.Repo {
+	pushedAt := r.PushedAt
+	if pushedAt.IsZero() {
+		pushedAt = r.UpdatedAt
+	}
+	return repofilter.Repo{
+		Name:     r.Name,
+		FullName: r.FullName,
+		Language: r.Language,
+		Topics:   r.Topics,
+		Private:  r.Private,
+		Archived: r.Archived,
+		Fork:     r.Fork,
+		SizeKB:   r.Size,
+		Stars:    r.Stars,
+		PushedAt: pushedAt,
+	}
 }
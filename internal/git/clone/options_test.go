@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package clone
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryInfoMatchesSelection(t *testing.T) {
+	opts := DefaultCloneOptions()
+	opts.IncludeForks = true
+	api := RepositoryInfo{Name: "api", FullName: "acme/api"}
+	web := RepositoryInfo{Name: "web", FullName: "acme/web"}
+
+	assert.True(t, api.Matches(opts))
+	assert.True(t, web.Matches(opts))
+
+	opts.Repositories = []string{"acme/api"}
+	assert.True(t, api.Matches(opts))
+	assert.False(t, web.Matches(opts))
+
+	opts.Repositories = []string{"web"}
+	assert.True(t, web.Matches(opts), "bare names match too")
+}
+
+func TestRepositoryInfoMatchesFilter(t *testing.T) {
+	opts := DefaultCloneOptions()
+	opts.Provider, opts.Org = "github", "acme"
+	opts.IncludeArchived = true
+	opts.Filter = `language == go and (pushed < 30d or topic == keep)`
+	require.NoError(t, opts.Validate())
+
+	recent := RepositoryInfo{Name: "api", Language: "Go", PushedAt: time.Now().Add(-time.Hour)}
+	stale := RepositoryInfo{Name: "old", Language: "Go", PushedAt: time.Now().AddDate(-1, 0, 0)}
+	kept := RepositoryInfo{Name: "lib", Language: "Go", Topics: []string{"keep"}, UpdatedAt: time.Now().AddDate(-1, 0, 0)}
+	updated := RepositoryInfo{Name: "web", Language: "go", UpdatedAt: time.Now()}
+
+	assert.True(t, recent.Matches(opts))
+	assert.False(t, stale.Matches(opts))
+	assert.True(t, kept.Matches(opts))
+	assert.True(t, updated.Matches(opts), "the update time stands in for a missing push time")
+
+	opts.Filter = "language =="
+	assert.ErrorIs(t, opts.Validate(), ErrInvalidFilter)
+}
+
+func TestValidateSubmoduleOptions(t *testing.T) {
+	opts := DefaultCloneOptions()
+	opts.Provider, opts.Org = "github", "acme"
+	require.NoError(t, opts.Validate())
+
+	opts.Submodules = "shallow"
+	require.NoError(t, opts.Validate())
+
+	opts.Submodules = "all"
+	assert.ErrorIs(t, opts.Validate(), ErrInvalidSubmodules)
+
+	opts.Submodules, opts.SubmoduleJobs = "", -1
+	assert.ErrorIs(t, opts.Validate(), ErrInvalidSubmodules)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package clone
+
+import (
+	"context"
+	"io"
+
+	"github.com/gizzahub/gzh-cli/internal/tui/repopicker"
+)
+
+// InteractiveSelector returns a selector that lets the user pick
+// repositories in a fuzzy-searchable terminal list. Leaving the picker
+// without confirming returns repopicker.ErrCancelled.
+func InteractiveSelector(title string, in io.Reader, out io.Writer) RepositorySelector {
+	return func(ctx context.Context, repos []RepositoryInfo) ([]RepositoryInfo, error) {
+		items := make([]repopicker.Item, len(repos))
+		byName := make(map[string]RepositoryInfo, len(repos))
+		for i, repo := range repos {
+			items[i] = repopicker.Item{
+				Name:        repoKey(repo),
+				Description: repo.Description,
+				Language:    repo.Language,
+				Topics:      repo.Topics,
+				SizeKB:      repo.Size,
+				Stars:       repo.Stars,
+				Private:     repo.Private,
+				Archived:    repo.Archived,
+				Fork:        repo.Fork,
+			}
+			byName[items[i].Name] = repo
+		}
+
+		picked, err := repopicker.Run(ctx, title, items, in, out)
+		if err != nil {
+			return nil, err
+		}
+		selected := make([]RepositoryInfo, 0, len(picked))
+		for _, item := range picked {
+			selected = append(selected, byName[item.Name])
+		}
+		return selected, nil
+	}
+}
+
+// repoKey is the name that identifies repo in a selection.
+func repoKey(repo RepositoryInfo) string {
+	if repo.FullName != "" {
+		return repo.FullName
+	}
+	return repo.Name
+}
@@ -7,7 +7,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sync"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/git/lfs"
 )
 
 // ProgressReporter handles progress reporting for clone operations.
@@ -21,15 +25,33 @@ type ProgressReporter struct {
 	skipped   int
 	startTime time.Time
 	session   *Session
+
+	// stream writes NDJSON events for FormatNDJSON.
+	stream *cli.OutputFormatter
+	// sink, when set, receives the events instead of stream.
+	sink func(cli.Event)
+
+	// tracker renders FormatProgress and estimates the ETA for every
+	// format. It is created by Start once the format is final.
+	tracker cli.Progress
+
+	// LFS totals, updated from the clone workers.
+	lfsMu      sync.Mutex
+	lfsObjects int
+	lfsBytes   int64
 }
 
 // NewProgressReporter creates a new progress reporter.
 func NewProgressReporter(format string, quiet, verbose bool) *ProgressReporter {
-	return &ProgressReporter{
+	p := &ProgressReporter{
 		format:  OutputFormat(format),
 		quiet:   quiet,
 		verbose: verbose,
 	}
+	if p.format == FormatNDJSON {
+		p.stream = cli.NewOutputFormatter(cli.FormatNDJSON)
+	}
+	return p
 }
 
 // Start initializes the progress tracking.
@@ -40,15 +62,22 @@ func (p *ProgressReporter) Start(total int) {
 	p.skipped = 0
 	p.startTime = time.Now()
 
+	mode := cli.ProgressNone
+	if !p.quiet && p.format == FormatProgress {
+		mode = cli.ProgressAuto
+	}
+	p.tracker = cli.NewProgress(os.Stdout, mode)
+	p.tracker.Phase("Cloning repositories", total)
+
 	if !p.quiet {
 		switch p.format {
-		case FormatProgress:
-			p.Info("Starting clone operation for %d repositories...", total)
 		case FormatJSON:
 			p.printJSONEvent("start", map[string]any{
 				"total":      total,
 				"started_at": p.startTime.Format(time.RFC3339),
 			})
+		case FormatNDJSON:
+			p.emit(cli.Event{Type: "start", Data: map[string]any{"total": total}})
 		}
 	}
 }
@@ -56,11 +85,10 @@ func (p *ProgressReporter) Start(total int) {
 // Success reports a successful clone operation.
 func (p *ProgressReporter) Success(repoName string) {
 	p.completed++
+	p.trackItem(repoName, cli.ItemDone, nil)
 
 	if !p.quiet {
 		switch p.format {
-		case FormatProgress:
-			p.printProgress(repoName, "✅", "completed")
 		case FormatJSON:
 			p.printJSONEvent("success", map[string]any{
 				"repository": repoName,
@@ -69,6 +97,8 @@ func (p *ProgressReporter) Success(repoName string) {
 			})
 		case FormatTable:
 			fmt.Printf("%-50s %s\n", repoName, "SUCCESS")
+		case FormatNDJSON:
+			p.emit(cli.Event{Type: "success", Target: repoName, Data: p.counts()})
 		}
 	}
 }
@@ -76,15 +106,14 @@ func (p *ProgressReporter) Success(repoName string) {
 // Fail reports a failed clone operation.
 func (p *ProgressReporter) Fail(repoName string, err error) {
 	p.failed++
+	if p.verbose {
+		p.trackItem(repoName, cli.ItemFailed, err)
+	} else {
+		p.trackItem(repoName, cli.ItemFailed, nil)
+	}
 
 	if !p.quiet {
 		switch p.format {
-		case FormatProgress:
-			if p.verbose {
-				p.printProgress(repoName, "❌", fmt.Sprintf("failed: %v", err))
-			} else {
-				p.printProgress(repoName, "❌", "failed")
-			}
 		case FormatJSON:
 			p.printJSONEvent("fail", map[string]any{
 				"repository": repoName,
@@ -98,6 +127,8 @@ func (p *ProgressReporter) Fail(repoName string, err error) {
 			} else {
 				fmt.Printf("%-50s %s\n", repoName, "FAILED")
 			}
+		case FormatNDJSON:
+			p.emit(cli.Event{Type: "fail", Target: repoName, Error: err.Error(), Data: p.counts()})
 		}
 	}
 }
@@ -105,11 +136,16 @@ func (p *ProgressReporter) Fail(repoName string, err error) {
 // Skip reports a skipped repository.
 func (p *ProgressReporter) Skip(repoName, reason string) {
 	p.skipped++
+	p.trackItem(repoName, cli.ItemSkipped, nil)
+
+	// Streams carry every event regardless of verbosity; consumers filter.
+	if !p.quiet && p.format == FormatNDJSON {
+		p.emit(cli.Event{Type: "skip", Target: repoName, Message: reason, Data: p.counts()})
+		return
+	}
 
 	if !p.quiet && p.verbose {
 		switch p.format {
-		case FormatProgress:
-			p.printProgress(repoName, "⏭️", fmt.Sprintf("skipped: %s", reason))
 		case FormatJSON:
 			p.printJSONEvent("skip", map[string]any{
 				"repository": repoName,
@@ -125,10 +161,14 @@ func (p *ProgressReporter) Skip(repoName, reason string) {
 
 // Retry reports a retry attempt.
 func (p *ProgressReporter) Retry(repoName string, attempt int, err error) {
+	if !p.quiet && p.format == FormatNDJSON {
+		p.emit(cli.Event{Type: "retry", Target: repoName, Attempt: attempt, Error: err.Error()})
+		return
+	}
 	if !p.quiet && p.verbose {
 		switch p.format {
 		case FormatProgress:
-			p.printProgress(repoName, "🔄", fmt.Sprintf("retry %d: %v", attempt, err))
+			p.printf("🔄 %s: retry %d: %v", repoName, attempt, err)
 		case FormatJSON:
 			p.printJSONEvent("retry", map[string]any{
 				"repository": repoName,
@@ -141,16 +181,56 @@ func (p *ProgressReporter) Retry(repoName string, attempt int, err error) {
 	}
 }
 
+// LFS reports the LFS objects synced for a repository.
+func (p *ProgressReporter) LFS(repoName string, stats lfs.Stats) {
+	p.lfsMu.Lock()
+	p.lfsObjects += stats.Downloaded
+	p.lfsBytes += stats.DownloadedBytes
+	p.lfsMu.Unlock()
+
+	if p.quiet {
+		return
+	}
+	switch p.format {
+	case FormatProgress, FormatTable:
+		line := fmt.Sprintf("📦 %s: %d LFS object(s), %s downloaded", repoName, stats.Downloaded, lfs.FormatSize(stats.DownloadedBytes))
+		if stats.Cached > 0 {
+			line += fmt.Sprintf(", %d cached", stats.Cached)
+		}
+		if stats.Resumed > 0 {
+			line += fmt.Sprintf(", %d resumed", stats.Resumed)
+		}
+		p.printf("%s", line)
+	case FormatJSON:
+		p.printJSONEvent("lfs", map[string]any{
+			"repository": repoName,
+			"stats":      stats,
+		})
+	case FormatNDJSON:
+		p.emit(cli.Event{Type: "lfs", Target: repoName, Data: map[string]any{
+			"objects":         stats.Objects,
+			"bytes":           stats.Bytes,
+			"downloaded":      stats.Downloaded,
+			"downloadedBytes": stats.DownloadedBytes,
+			"cached":          stats.Cached,
+			"resumed":         stats.Resumed,
+			"failed":          stats.Failed,
+		}})
+	}
+}
+
 // Info prints an informational message.
 func (p *ProgressReporter) Info(format string, args ...any) {
 	if !p.quiet {
 		switch p.format {
 		case FormatProgress, FormatTable:
-			fmt.Printf(format+"\n", args...)
+			p.printf(format, args...)
 		case FormatJSON:
 			p.printJSONEvent("info", map[string]any{
 				"message": fmt.Sprintf(format, args...),
 			})
+		case FormatNDJSON:
+			p.emit(cli.Event{Type: "info", Message: fmt.Sprintf(format, args...)})
 		}
 	}
 }
@@ -160,11 +240,13 @@ func (p *ProgressReporter) Warning(format string, args ...any) {
 	if !p.quiet {
 		switch p.format {
 		case FormatProgress, FormatTable:
-			fmt.Printf("⚠️  "+format+"\n", args...)
+			p.printf("⚠️  "+format, args...)
 		case FormatJSON:
 			p.printJSONEvent("warning", map[string]any{
 				"message": fmt.Sprintf(format, args...),
 			})
+		case FormatNDJSON:
+			p.emit(cli.Event{Type: "warning", Message: fmt.Sprintf(format, args...)})
 		}
 	}
 }
@@ -178,6 +260,8 @@ func (p *ProgressReporter) Error(format string, args ...any) {
 		p.printJSONEvent("error", map[string]any{
 			"message": fmt.Sprintf(format, args...),
 		})
+	case FormatNDJSON:
+		p.emit(cli.Event{Type: "error", Message: fmt.Sprintf(format, args...)})
 	default:
 		fmt.Fprintf(os.Stderr, format+"\n", args...)
 	}
@@ -190,9 +274,10 @@ func (p *ProgressReporter) Finish() {
 
 		switch p.format {
 		case FormatProgress:
-			p.Info("\nOperation completed in %v", duration)
-			p.Info("Total: %d, Completed: %d, Failed: %d, Skipped: %d",
-				p.total, p.completed, p.failed, p.skipped)
+			p.tracker.Done()
+			if objects, bytes := p.lfsTotals(); objects > 0 {
+				p.Info("LFS: %d object(s), %s downloaded", objects, lfs.FormatSize(bytes))
+			}
 		case FormatJSON:
 			p.printJSONEvent("finish", map[string]any{
 				"total":     p.total,
@@ -204,6 +289,14 @@ func (p *ProgressReporter) Finish() {
 			})
 		case FormatTable:
 			fmt.Println("\n" + p.getSummaryTable())
+		case FormatNDJSON:
+			data := p.counts()
+			data["duration"] = duration.String()
+			if objects, bytes := p.lfsTotals(); objects > 0 {
+				data["lfsObjects"] = objects
+				data["lfsBytes"] = bytes
+			}
+			p.emit(cli.Event{Type: "finish", Data: data})
 		}
 	}
 }
@@ -222,14 +315,28 @@ func (p *ProgressReporter) ResumeSession(session *Session) {
 				"progress":   session.GetProgress(),
 				"started_at": session.StartedAt.Format(time.RFC3339),
 			})
+		case FormatNDJSON:
+			p.emit(cli.Event{Type: "resume", Target: session.ID, Data: map[string]any{"progress": session.GetProgress()}})
 		}
 	}
 }
 
-// printProgress prints a progress line for a repository.
-func (p *ProgressReporter) printProgress(repoName, icon, status string) {
-	progress := float64(p.completed+p.failed+p.skipped) / float64(p.total) * 100
-	fmt.Printf("[%5.1f%%] %s %-50s %s\n", progress, icon, repoName, status)
+// trackItem records an item with the tracker; it is nil for events
+// reported before Start.
+func (p *ProgressReporter) trackItem(repoName string, status cli.ItemStatus, err error) {
+	if p.tracker != nil {
+		p.tracker.Item(repoName, status, err)
+	}
+}
+
+// printf prints a line, through the tracker while it may be drawing a
+// progress bar.
+func (p *ProgressReporter) printf(format string, args ...any) {
+	if p.tracker != nil && p.format == FormatProgress {
+		p.tracker.Logf(format, args...)
+		return
+	}
+	fmt.Printf(format+"\n", args...)
 }
 
 // printJSONEvent prints a JSON event.
@@ -245,6 +352,43 @@ func (p *ProgressReporter) printJSONEvent(eventType string, data map[string]any)
 	}
 }
 
+// emit writes an NDJSON event. Write errors are ignored like the other
+// output paths; a closed pipe must not abort the clone.
+func (p *ProgressReporter) emit(event cli.Event) {
+	if p.sink != nil {
+		if event.Time.IsZero() {
+			event.Time = time.Now()
+		}
+		p.sink(event)
+		return
+	}
+	_ = p.stream.EmitEvent(event)
+}
+
+// lfsTotals returns the LFS objects and bytes downloaded so far.
+func (p *ProgressReporter) lfsTotals() (int, int64) {
+	p.lfsMu.Lock()
+	defer p.lfsMu.Unlock()
+	return p.lfsObjects, p.lfsBytes
+}
+
+// counts returns the running totals and estimates attached to NDJSON
+// events.
+func (p *ProgressReporter) counts() map[string]any {
+	counts := map[string]any{
+		"total":     p.total,
+		"completed": p.completed,
+		"failed":    p.failed,
+		"skipped":   p.skipped,
+	}
+	if p.tracker != nil {
+		snap := p.tracker.Snapshot()
+		counts["rate"] = snap.Rate
+		counts["etaSeconds"] = snap.ETA.Seconds()
+	}
+	return counts
+}
+
 // getSummaryTable returns a formatted summary table.
 func (p *ProgressReporter) getSummaryTable() string {
 	duration := time.Since(p.startTime)
@@ -283,6 +427,8 @@ type ProgressStats struct {
 	Skipped   int           `json:"skipped"`
 	Duration  time.Duration `json:"duration"`
 	Progress  float64       `json:"progress"`
+	// ETA is the estimated time left; zero when unknown.
+	ETA time.Duration `json:"eta"`
 }
 
 // GetStats returns current progress statistics.
@@ -292,7 +438,7 @@ func (p *ProgressReporter) GetStats() ProgressStats {
 		progress = float64(p.completed+p.failed+p.skipped) / float64(p.total) * 100
 	}
 
-	return ProgressStats{
+	stats := ProgressStats{
 		Total:     p.total,
 		Completed: p.completed,
 		Failed:    p.failed,
@@ -300,6 +446,10 @@ func (p *ProgressReporter) GetStats() ProgressStats {
 		Duration:  time.Since(p.startTime),
 		Progress:  progress,
 	}
+	if p.tracker != nil {
+		stats.ETA = p.tracker.Snapshot().ETA
+	}
+	return stats
 }
 
 // SetVerbose sets the verbose flag.
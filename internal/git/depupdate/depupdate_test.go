@@ -0,0 +1,309 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package depupdate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGoMod = `module example.com/app
+
+go 1.22
+
+require github.com/spf13/cobra v1.8.0
+
+require (
+	github.com/stretchr/testify v1.9.0 // test helpers
+	golang.org/x/sys v0.20.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1
+)
+`
+
+const testPackageJSON = `{
+  "name": "web",
+  "dependencies": {
+    "react": "^18.2.0",
+    "left-pad": "latest"
+  },
+  "devDependencies": {
+    "typescript": "~5.3.3"
+  }
+}
+`
+
+const testRequirements = `# runtime
+requests[security]==2.31.0 ; python_version >= "3.8"
+flask>=2.0
+Django==4.2.1
+`
+
+func writeRepo(t *testing.T, dir string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte(testGoMod), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(testPackageJSON), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "requirements.txt"), []byte(testRequirements), 0o644))
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            Severity
+		ok              bool
+	}{
+		{"v1.2.3", "v1.2.4", SeverityPatch, true},
+		{"1.2.3", "1.3.0", SeverityMinor, true},
+		{"v1.2.3", "v2.0.0", SeverityMajor, true},
+		{"v0.0.0-20230101000000-abcdef", "v0.0.1", SeverityPatch, true},
+		{"1.2.3", "1.2.3", "", false},
+		{"1.2.3", "1.2.2", "", false},
+		{"1.2.3", "1.3.0-rc.1", "", false},
+		{"latest", "1.0.0", "", false},
+	}
+	for _, tt := range tests {
+		sev, ok := Classify(tt.current, tt.latest)
+		assert.Equal(t, tt.ok, ok, "%s -> %s", tt.current, tt.latest)
+		assert.Equal(t, tt.want, sev, "%s -> %s", tt.current, tt.latest)
+	}
+}
+
+func TestScanAndApply(t *testing.T) {
+	dir := t.TempDir()
+	writeRepo(t, dir)
+
+	deps, err := ScanRepository(dir, nil)
+	require.NoError(t, err)
+
+	var names []string
+	for _, d := range deps {
+		names = append(names, string(d.Ecosystem)+":"+d.Name+"@"+d.Version)
+	}
+	assert.Equal(t, []string{
+		"go:github.com/spf13/cobra@v1.8.0",
+		"go:github.com/stretchr/testify@v1.9.0",
+		"go:gopkg.in/yaml.v3@v3.0.1",
+		"npm:react@18.2.0",
+		"npm:typescript@5.3.3",
+		"pip:requests@2.31.0",
+		"pip:Django@4.2.1",
+	}, names)
+
+	byName := make(map[string]Dependency)
+	for _, d := range deps {
+		byName[d.Name] = d
+	}
+	updates := []Update{
+		{Dependency: byName["github.com/stretchr/testify"], Latest: "v1.10.0"},
+		{Dependency: byName["github.com/spf13/cobra"], Latest: "v1.8.1"},
+		{Dependency: byName["react"], Latest: "19.0.0"},
+		{Dependency: byName["typescript"], Latest: "5.4.0"},
+		{Dependency: byName["requests"], Latest: "2.32.3"},
+	}
+	require.NoError(t, ApplyUpdates(dir, updates))
+
+	goMod, _ := os.ReadFile(filepath.Join(dir, "go.mod"))
+	assert.Contains(t, string(goMod), "require github.com/spf13/cobra v1.8.1\n")
+	assert.Contains(t, string(goMod), "\tgithub.com/stretchr/testify v1.10.0 // test helpers\n")
+	assert.Contains(t, string(goMod), "golang.org/x/sys v0.20.0 // indirect")
+
+	pkg, _ := os.ReadFile(filepath.Join(dir, "package.json"))
+	assert.Contains(t, string(pkg), `"react": "^19.0.0"`)
+	assert.Contains(t, string(pkg), `"typescript": "~5.4.0"`)
+
+	reqs, _ := os.ReadFile(filepath.Join(dir, "requirements.txt"))
+	assert.Contains(t, string(reqs), `requests[security]==2.32.3 ; python_version`)
+
+	err = ApplyUpdates(dir, []Update{{Dependency: byName["react"], Latest: "20.0.0"}})
+	assert.ErrorContains(t, err, "react 18.2.0 not found")
+}
+
+func TestPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`default:
+  max_severity: minor
+  deny: ["github.com/legacy/*"]
+orgs:
+  platform:
+    allow: ["github.com/platform/*", "react"]
+`), 0o600))
+
+	p, err := LoadPolicy(path)
+	require.NoError(t, err)
+
+	def := p.RulesFor("acme")
+	assert.True(t, def.Allows("react", SeverityMinor))
+	assert.False(t, def.Allows("react", SeverityMajor))
+	assert.False(t, def.Allows("github.com/legacy/util/v2", SeverityPatch))
+
+	platform := p.RulesFor("platform")
+	assert.True(t, platform.Allows("github.com/platform/sdk", SeverityMajor))
+	assert.True(t, platform.Allows("react", SeverityMajor))
+	assert.False(t, platform.Allows("vue", SeverityPatch))
+
+	assert.True(t, (*Policy)(nil).RulesFor("any").Allows("x", SeverityMajor))
+
+	require.NoError(t, os.WriteFile(path, []byte("default:\n  max_severity: huge\n"), 0o600))
+	_, err = LoadPolicy(path)
+	assert.ErrorContains(t, err, "invalid severity")
+}
+
+func TestRegistryResolverAndPlan(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.EscapedPath() {
+		case "/go/github.com/!burnt!sushi/toml/@latest":
+			_, _ = w.Write([]byte(`{"Version":"v1.4.0"}`))
+		case "/npm/@types%2Fnode/latest":
+			_, _ = w.Write([]byte(`{"version":"22.1.0"}`))
+		case "/pypi/pypi/requests/json":
+			_, _ = w.Write([]byte(`{"info":{"version":"2.32.3"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	r := NewRegistryResolver()
+	r.GoProxy, r.NPMRegistry, r.PyPI = server.URL+"/go", server.URL+"/npm", server.URL+"/pypi"
+	ctx := context.Background()
+
+	v, err := r.Latest(ctx, EcosystemGo, "github.com/BurntSushi/toml")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.0", v)
+	_, err = r.Latest(ctx, EcosystemGo, "github.com/BurntSushi/toml")
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests, "lookups are cached")
+
+	v, err = r.Latest(ctx, EcosystemNPM, "@types/node")
+	require.NoError(t, err)
+	assert.Equal(t, "22.1.0", v)
+
+	_, err = r.Latest(ctx, EcosystemPip, "missing")
+	assert.ErrorContains(t, err, "not found")
+
+	root := t.TempDir()
+	repoDir := filepath.Join(root, "acme", "api")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".git"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "requirements.txt"), []byte("requests==2.31.0\nflask==1.0\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "acme", "mirror.git"), 0o755))
+
+	repos, err := DiscoverRepositories(root)
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "acme/api", repos[0].FullName())
+
+	planner := &Planner{Resolver: r, Policy: &Policy{Default: Rules{MaxSeverity: SeverityPatch}}}
+	results := planner.Plan(ctx, repos)
+	require.Len(t, results, 1)
+	assert.Empty(t, results[0].Updates)
+	assert.Equal(t, 1, results[0].Skipped)
+	assert.Len(t, results[0].Errors, 1, "flask is unknown to the test registry")
+
+	planner.Policy = nil
+	results = planner.Plan(ctx, repos)
+	require.Len(t, results[0].Updates, 1)
+	assert.Equal(t, SeverityMinor, results[0].Updates[0].Severity)
+	assert.Equal(t, map[Severity]int{SeverityMinor: 1}, Counts(results))
+}
+
+func TestParseRemotePath(t *testing.T) {
+	for remote, want := range map[string]string{
+		"https://github.com/acme/api.git":               "acme/api",
+		"git@github.com:acme/api.git":                   "acme/api",
+		"ssh://git@gitlab.example.com:2222/grp/sub/api": "grp/sub/api",
+		"https://token@gitea.example.com/acme/api/":     "acme/api",
+	} {
+		got, err := parseRemotePath(remote)
+		require.NoError(t, err, remote)
+		assert.Equal(t, want, got, remote)
+	}
+	_, err := parseRemotePath("/srv/git/api.git")
+	assert.Error(t, err)
+}
+
+type fakeOpener struct{ opened []PullRequest }
+
+func (f *fakeOpener) Open(_ context.Context, pr PullRequest) (string, error) {
+	f.opened = append(f.opened, pr)
+	return "https://example.com/pr/" + pr.Head, nil
+}
+
+func TestOpenPullRequests(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote.git")
+	dir := filepath.Join(root, "acme", "web")
+
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+			"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git(root, "init", "-q", "--bare", remote)
+	writeRepo(t, dir)
+	git(dir, "init", "-q", "-b", "main")
+	git(dir, "add", ".")
+	git(dir, "commit", "-q", "-m", "initial")
+	git(dir, "remote", "add", "origin", remote)
+	// A scp-style URL is what the platform path is read from; push goes to
+	// the local bare repository.
+	git(dir, "config", "remote.origin.pushurl", remote)
+	git(dir, "config", "remote.origin.url", "git@github.com:acme/web.git")
+
+	deps, err := ScanRepository(dir, []Ecosystem{EcosystemNPM})
+	require.NoError(t, err)
+	updates := []Update{
+		{Dependency: deps[0], Latest: "19.0.0", Severity: SeverityMajor},
+		{Dependency: deps[1], Latest: "5.3.4", Severity: SeverityPatch},
+	}
+
+	opener := &fakeOpener{}
+	repo := Repository{Org: "acme", Name: "web", Path: dir}
+	t.Setenv("GIT_COMMITTER_NAME", "t")
+	t.Setenv("GIT_COMMITTER_EMAIL", "t@example.com")
+	t.Setenv("GIT_AUTHOR_NAME", "t")
+	t.Setenv("GIT_AUTHOR_EMAIL", "t@example.com")
+	opened, err := OpenPullRequests(ctx, repo, updates, opener)
+	require.NoError(t, err)
+	assert.Equal(t, map[Severity]string{
+		SeverityPatch: "https://example.com/pr/gz/deps-patch",
+		SeverityMajor: "https://example.com/pr/gz/deps-major",
+	}, opened)
+
+	require.Len(t, opener.opened, 2)
+	assert.Equal(t, "acme/web", opener.opened[0].Repo)
+	assert.Equal(t, "main", opener.opened[0].Base)
+	assert.Equal(t, "chore(deps): bump 1 patch dependency", opener.opened[0].Title)
+	assert.Contains(t, opener.opened[1].Body, "| react | npm | 18.2.0 | 19.0.0 |")
+
+	// The clone is back on main and unchanged; the branches carry the bumps
+	pkg, _ := os.ReadFile(filepath.Join(dir, "package.json"))
+	assert.Contains(t, string(pkg), `"react": "^18.2.0"`)
+	out, err := exec.Command("git", "-C", remote, "show", "gz/deps-major:package.json").Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"react": "^19.0.0"`)
+	assert.Contains(t, string(out), `"typescript": "~5.3.3"`)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dirty"), nil, 0o644))
+	_, err = OpenPullRequests(ctx, repo, updates, opener)
+	assert.ErrorContains(t, err, "uncommitted changes")
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package depupdate finds outdated dependencies in cloned repositories and
+// opens pull requests that bump them, grouped by update severity.
+package depupdate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Ecosystem is a dependency ecosystem with its manifest file.
+type Ecosystem string
+
+const (
+	EcosystemGo  Ecosystem = "go"
+	EcosystemNPM Ecosystem = "npm"
+	EcosystemPip Ecosystem = "pip"
+)
+
+// Ecosystems lists the supported ecosystems.
+var Ecosystems = []Ecosystem{EcosystemGo, EcosystemNPM, EcosystemPip}
+
+// manifestFiles maps each ecosystem to the manifest it reads.
+var manifestFiles = map[Ecosystem]string{
+	EcosystemGo:  "go.mod",
+	EcosystemNPM: "package.json",
+	EcosystemPip: "requirements.txt",
+}
+
+// Dependency is a direct dependency declared in a manifest.
+type Dependency struct {
+	Ecosystem Ecosystem `json:"ecosystem"`
+	Name      string    `json:"name"`
+	// Version is the declared version without range operators.
+	Version string `json:"version"`
+	// Manifest is the manifest path relative to the repository root.
+	Manifest string `json:"manifest"`
+	// spec is the declared version as written, e.g. ^1.2.3.
+	spec string
+}
+
+// ScanRepository returns the direct dependencies declared in the manifests
+// at the root of the repository at dir. Only pinned or caret/tilde versions
+// are reported, since other specs have no single current version.
+func ScanRepository(dir string, ecosystems []Ecosystem) ([]Dependency, error) {
+	if len(ecosystems) == 0 {
+		ecosystems = Ecosystems
+	}
+
+	var deps []Dependency
+	for _, eco := range ecosystems {
+		name := manifestFiles[eco]
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		var found []Dependency
+		switch eco {
+		case EcosystemGo:
+			found = parseGoMod(data)
+		case EcosystemNPM:
+			found, err = parsePackageJSON(data)
+		case EcosystemPip:
+			found = parseRequirements(data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		for i := range found {
+			found[i].Ecosystem = eco
+			found[i].Manifest = name
+		}
+		deps = append(deps, found...)
+	}
+	return deps, nil
+}
+
+// parseGoMod returns the direct requirements of a go.mod file.
+func parseGoMod(data []byte) []Dependency {
+	var deps []Dependency
+	inBlock := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.Contains(line, "// indirect") {
+			continue
+		}
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+
+		switch {
+		case inBlock && len(fields) == 1 && fields[0] == ")":
+			inBlock = false
+			continue
+		case len(fields) == 2 && fields[0] == "require" && fields[1] == "(":
+			inBlock = true
+			continue
+		case len(fields) == 3 && fields[0] == "require":
+			fields = fields[1:]
+		case !inBlock || len(fields) != 2:
+			continue
+		}
+		deps = append(deps, Dependency{Name: fields[0], Version: fields[1], spec: fields[1]})
+	}
+	return deps
+}
+
+// parsePackageJSON returns the dependencies and devDependencies of a
+// package.json file.
+func parsePackageJSON(data []byte) ([]Dependency, error) {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, err
+	}
+
+	var deps []Dependency
+	for _, section := range []map[string]string{pkg.Dependencies, pkg.DevDependencies} {
+		for name, spec := range section {
+			v := strings.TrimLeft(spec, "^~")
+			if _, ok := parseVersion(v); !ok || strings.HasPrefix(v, "v") {
+				continue
+			}
+			deps = append(deps, Dependency{Name: name, Version: v, spec: spec})
+		}
+	}
+	sortDependencies(deps)
+	return deps, nil
+}
+
+// requirementRe matches a pinned requirement such as requests[security]==2.31.0.
+var requirementRe = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*==\s*([^\s;#]+)`)
+
+// parseRequirements returns the pinned requirements of a requirements.txt.
+func parseRequirements(data []byte) []Dependency {
+	var deps []Dependency
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		m := requirementRe.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m == nil {
+			continue
+		}
+		deps = append(deps, Dependency{Name: m[1], Version: m[3], spec: m[3]})
+	}
+	return deps
+}
+
+// ApplyUpdates rewrites the manifests in dir to the latest versions of
+// updates. Formatting and range operators are kept.
+func ApplyUpdates(dir string, updates []Update) error {
+	byManifest := make(map[string][]Update)
+	for _, u := range updates {
+		byManifest[u.Manifest] = append(byManifest[u.Manifest], u)
+	}
+
+	for manifest, list := range byManifest {
+		path := filepath.Join(dir, manifest)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", manifest, err)
+		}
+		for _, u := range list {
+			updated, ok := rewrite(data, u)
+			if !ok {
+				return fmt.Errorf("%s: %s %s not found", manifest, u.Name, u.Version)
+			}
+			data = updated
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", manifest, err)
+		}
+	}
+	return nil
+}
+
+// rewrite replaces the declared version of one dependency.
+func rewrite(data []byte, u Update) ([]byte, bool) {
+	name := regexp.QuoteMeta(u.Name)
+	var re *regexp.Regexp
+	var repl string
+
+	switch u.Ecosystem {
+	case EcosystemGo:
+		latest := u.Latest
+		if !strings.HasPrefix(latest, "v") {
+			latest = "v" + latest
+		}
+		re = regexp.MustCompile(`(?m)^(\s*(?:require\s+)?` + name + `\s+)` + regexp.QuoteMeta(u.Version) + `(\s|$)`)
+		repl = "${1}" + latest + "${2}"
+	case EcosystemNPM:
+		prefix := strings.TrimSuffix(u.spec, u.Version)
+		re = regexp.MustCompile(`("` + name + `"\s*:\s*")` + regexp.QuoteMeta(u.spec) + `"`)
+		repl = "${1}" + prefix + u.Latest + `"`
+	case EcosystemPip:
+		re = regexp.MustCompile(`(?im)^(\s*` + name + `(?:\[[^\]]*\])?\s*==\s*)` + regexp.QuoteMeta(u.Version) + `([\s;#]|$)`)
+		repl = "${1}" + u.Latest + "${2}"
+	default:
+		return nil, false
+	}
+
+	if !re.Match(data) {
+		return nil, false
+	}
+	return re.ReplaceAll(data, []byte(repl)), true
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package depupdate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// maxDiscoveryDepth bounds the directory levels searched for clones, which
+// covers the <root>/<org>/<repo> layout of bulk clones.
+const maxDiscoveryDepth = 3
+
+// Repository is a cloned repository with a working tree.
+type Repository struct {
+	// Org is the directory the clone lives in, relative to the scan root;
+	// bulk clones use the organization name.
+	Org  string `json:"org"`
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// FullName returns org/name, or name for clones at the scan root.
+func (r Repository) FullName() string {
+	if r.Org == "" {
+		return r.Name
+	}
+	return r.Org + "/" + r.Name
+}
+
+// DiscoverRepositories returns the clones below root, including root itself
+// when it is a clone. Bare mirrors are skipped.
+func DiscoverRepositories(root string) ([]Repository, error) {
+	root = filepath.Clean(root)
+	var repos []Repository
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != root && (d.Name() == ".git" || d.Name() == "node_modules" || d.Name() == "vendor") {
+			return filepath.SkipDir
+		}
+		rel, _ := filepath.Rel(root, p)
+		depth := 0
+		if rel != "." {
+			depth = strings.Count(filepath.ToSlash(rel), "/") + 1
+		}
+
+		if _, err := os.Stat(filepath.Join(p, ".git")); err == nil {
+			org := filepath.ToSlash(filepath.Dir(rel))
+			if rel == "." || org == "." {
+				org = ""
+			}
+			repos = append(repos, Repository{Org: org, Name: filepath.Base(p), Path: p})
+			return filepath.SkipDir
+		}
+		if depth >= maxDiscoveryDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	return repos, nil
+}
+
+// Update is an available update of a dependency.
+type Update struct {
+	Dependency
+	Latest   string   `json:"latest"`
+	Severity Severity `json:"severity"`
+}
+
+// RepoResult holds the updates found in one repository.
+type RepoResult struct {
+	Repository Repository `json:"repository"`
+	Updates    []Update   `json:"updates,omitempty"`
+	// Skipped counts updates excluded by the policy.
+	Skipped int `json:"skipped,omitempty"`
+	// PullRequests lists the pull requests opened, by severity.
+	PullRequests map[Severity]string `json:"pullRequests,omitempty"`
+	Errors       []string            `json:"errors,omitempty"`
+}
+
+// Group returns the updates of the result by severity.
+func (r *RepoResult) Group() map[Severity][]Update {
+	groups := make(map[Severity][]Update)
+	for _, u := range r.Updates {
+		groups[u.Severity] = append(groups[u.Severity], u)
+	}
+	return groups
+}
+
+// Planner computes the available updates of cloned repositories.
+type Planner struct {
+	Resolver    Resolver
+	Policy      *Policy
+	Ecosystems  []Ecosystem
+	Concurrency int
+}
+
+// Plan scans every repository and resolves its dependencies' latest
+// versions. Lookup failures are recorded per repository.
+func (p *Planner) Plan(ctx context.Context, repos []Repository) []RepoResult {
+	results := make([]RepoResult, len(repos))
+	concurrency := p.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = p.planRepository(ctx, repo)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func (p *Planner) planRepository(ctx context.Context, repo Repository) RepoResult {
+	result := RepoResult{Repository: repo}
+
+	deps, err := ScanRepository(repo.Path, p.Ecosystems)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+
+	rules := p.Policy.RulesFor(repo.Org)
+	for _, dep := range deps {
+		latest, err := p.Resolver.Latest(ctx, dep.Ecosystem, dep.Name)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		sev, ok := Classify(dep.Version, latest)
+		if !ok {
+			continue
+		}
+		if !rules.Allows(dep.Name, sev) {
+			result.Skipped++
+			continue
+		}
+		result.Updates = append(result.Updates, Update{Dependency: dep, Latest: latest, Severity: sev})
+	}
+	return result
+}
+
+// sortDependencies orders dependencies by name for stable output.
+func sortDependencies(deps []Dependency) {
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package depupdate
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy limits which updates are proposed, per organization.
+//
+//	default:
+//	  max_severity: minor
+//	  deny: ["github.com/legacy/*"]
+//	orgs:
+//	  platform:
+//	    allow: ["github.com/platform/*", "react"]
+//	    max_severity: major
+//
+// An organization listed under orgs uses its own rules instead of the
+// default ones.
+type Policy struct {
+	Default Rules            `yaml:"default" json:"default"`
+	Orgs    map[string]Rules `yaml:"orgs" json:"orgs,omitempty"`
+}
+
+// Rules select the dependencies and severities updated. Patterns use
+// path.Match syntax against the dependency name; a trailing * also matches
+// across slashes, so github.com/acme/* covers every module of acme.
+type Rules struct {
+	// Allow, when set, restricts updates to matching dependencies.
+	Allow []string `yaml:"allow" json:"allow,omitempty"`
+	// Deny excludes matching dependencies.
+	Deny []string `yaml:"deny" json:"deny,omitempty"`
+	// MaxSeverity is the most disruptive update proposed; default major.
+	MaxSeverity Severity `yaml:"max_severity" json:"maxSeverity,omitempty"`
+}
+
+// LoadPolicy reads a policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Validate checks severities and patterns.
+func (p *Policy) Validate() error {
+	check := func(where string, r Rules) error {
+		if r.MaxSeverity != "" {
+			if _, err := ParseSeverity(string(r.MaxSeverity)); err != nil {
+				return fmt.Errorf("%s: %w", where, err)
+			}
+		}
+		for _, pattern := range append(append([]string(nil), r.Allow...), r.Deny...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("%s: invalid pattern %q", where, pattern)
+			}
+		}
+		return nil
+	}
+
+	if err := check("default", p.Default); err != nil {
+		return err
+	}
+	for org, r := range p.Orgs {
+		if err := check("orgs."+org, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RulesFor returns the rules applying to org.
+func (p *Policy) RulesFor(org string) Rules {
+	if p == nil {
+		return Rules{}
+	}
+	if r, ok := p.Orgs[org]; ok {
+		return r
+	}
+	return p.Default
+}
+
+// Allows reports whether an update of the given severity is proposed for
+// the dependency.
+func (r Rules) Allows(name string, sev Severity) bool {
+	if r.MaxSeverity != "" && !sev.AtMost(r.MaxSeverity) {
+		return false
+	}
+	if matchAny(r.Deny, name) {
+		return false
+	}
+	return len(r.Allow) == 0 || matchAny(r.Allow, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(p, "*"); ok && strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
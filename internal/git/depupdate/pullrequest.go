@@ -0,0 +1,295 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package depupdate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+// ErrPullRequestExists is returned when a pull request for the branch is
+// already open; the force-pushed branch updates it.
+var ErrPullRequestExists = errors.New("pull request already exists")
+
+// PullRequest describes a pull request to open.
+type PullRequest struct {
+	// Repo is the owner/name (GitLab: full project path) on the platform.
+	Repo  string
+	Head  string
+	Base  string
+	Title string
+	Body  string
+}
+
+// PullRequestOpener opens pull requests on a hosting platform.
+type PullRequestOpener interface {
+	Open(ctx context.Context, pr PullRequest) (string, error)
+}
+
+// Default API endpoints.
+const (
+	DefaultGitHubAPI = "https://api.github.com"
+	DefaultGitLabAPI = "https://gitlab.com/api/v4"
+	DefaultGiteaAPI  = "https://gitea.com/api/v1"
+)
+
+// NewPullRequestOpener creates the opener for provider. baseURL selects a
+// self-hosted API and may be empty.
+func NewPullRequestOpener(provider, baseURL, token string) (PullRequestOpener, error) {
+	client := httpclient.NewProviderClient(provider, 60*time.Second)
+	switch provider {
+	case "github":
+		return &githubOpener{api: apiURL(baseURL, DefaultGitHubAPI), token: token, client: client}, nil
+	case "gitlab":
+		return &gitlabOpener{api: apiURL(baseURL, DefaultGitLabAPI), token: token, client: client}, nil
+	case "gitea":
+		return &giteaOpener{api: apiURL(baseURL, DefaultGiteaAPI), token: token, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unsupported provider for pull requests: %s (supported: github, gitlab, gitea)", provider)
+	}
+}
+
+func apiURL(baseURL, def string) string {
+	if baseURL == "" {
+		return def
+	}
+	return strings.TrimSuffix(baseURL, "/")
+}
+
+// postJSON sends body and decodes the response into out. Conflict statuses
+// map to ErrPullRequestExists.
+func postJSON(ctx context.Context, client *http.Client, rawURL string, authorize func(*http.Request), body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gzh-cli")
+	authorize(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("POST %s: %w", req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	switch {
+	case resp.StatusCode == http.StatusConflict,
+		resp.StatusCode == http.StatusUnprocessableEntity && bytes.Contains(respBody, []byte("already exists")):
+		return ErrPullRequestExists
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("POST %s: HTTP %d - %s", req.URL.Path, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", req.URL.Path, err)
+	}
+	return nil
+}
+
+type githubOpener struct {
+	api    string
+	token  string
+	client *http.Client
+}
+
+func (o *githubOpener) Open(ctx context.Context, pr PullRequest) (string, error) {
+	var out struct {
+		HTMLURL string `json:"html_url"` //nolint:tagliatelle // GitHub API
+	}
+	body := map[string]any{"title": pr.Title, "head": pr.Head, "base": pr.Base, "body": pr.Body}
+	err := postJSON(ctx, o.client, o.api+"/repos/"+pr.Repo+"/pulls", func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+o.token)
+		req.Header.Set("Accept", "application/vnd.github+json")
+	}, body, &out)
+	return out.HTMLURL, err
+}
+
+type gitlabOpener struct {
+	api    string
+	token  string
+	client *http.Client
+}
+
+func (o *gitlabOpener) Open(ctx context.Context, pr PullRequest) (string, error) {
+	var out struct {
+		WebURL string `json:"web_url"` //nolint:tagliatelle // GitLab API
+	}
+	body := map[string]any{
+		"title":                pr.Title,
+		"source_branch":        pr.Head,
+		"target_branch":        pr.Base,
+		"description":          pr.Body,
+		"remove_source_branch": true,
+	}
+	err := postJSON(ctx, o.client, o.api+"/projects/"+url.PathEscape(pr.Repo)+"/merge_requests", func(req *http.Request) {
+		req.Header.Set("PRIVATE-TOKEN", o.token)
+	}, body, &out)
+	return out.WebURL, err
+}
+
+type giteaOpener struct {
+	api    string
+	token  string
+	client *http.Client
+}
+
+func (o *giteaOpener) Open(ctx context.Context, pr PullRequest) (string, error) {
+	var out struct {
+		HTMLURL string `json:"html_url"` //nolint:tagliatelle // Gitea API
+	}
+	body := map[string]any{"title": pr.Title, "head": pr.Head, "base": pr.Base, "body": pr.Body}
+	err := postJSON(ctx, o.client, o.api+"/repos/"+pr.Repo+"/pulls", func(req *http.Request) {
+		req.Header.Set("Authorization", "token "+o.token)
+	}, body, &out)
+	return out.HTMLURL, err
+}
+
+// remotePathRe extracts the repository path from https, ssh and scp-style
+// remote URLs.
+var remotePathRe = regexp.MustCompile(`^(?:[a-z+]+://)?(?:[^@/]+@)?[^/:]+(?::\d+)?[:/](.+?)(?:\.git)?/?$`)
+
+// RemoteRepo returns the platform path (owner/name) of the origin remote of
+// the clone at dir.
+func RemoteRepo(ctx context.Context, dir string) (string, error) {
+	out, err := runGit(ctx, dir, "remote", "get-url", "origin")
+	if err != nil {
+		return "", err
+	}
+	return parseRemotePath(strings.TrimSpace(out))
+}
+
+func parseRemotePath(remote string) (string, error) {
+	m := remotePathRe.FindStringSubmatch(remote)
+	if m == nil || !strings.Contains(m[1], "/") {
+		return "", fmt.Errorf("cannot determine repository from remote %q", remote)
+	}
+	return m[1], nil
+}
+
+// BranchName returns the update branch of a severity group.
+func BranchName(sev Severity) string {
+	return "gz/deps-" + string(sev)
+}
+
+// OpenPullRequests commits the updates of each severity group to its own
+// branch, pushes it and opens a pull request against the current branch.
+// The clone must have no uncommitted changes; it is left on its original
+// branch. The returned map holds the pull request URL per severity.
+func OpenPullRequests(ctx context.Context, repo Repository, updates []Update, opener PullRequestOpener) (map[Severity]string, error) {
+	status, err := runGit(ctx, repo.Path, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(status) != "" {
+		return nil, fmt.Errorf("%s has uncommitted changes", repo.FullName())
+	}
+	remote, err := RemoteRepo(ctx, repo.Path)
+	if err != nil {
+		return nil, err
+	}
+	base, err := runGit(ctx, repo.Path, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	base = strings.TrimSpace(base)
+
+	groups := (&RepoResult{Updates: updates}).Group()
+	opened := make(map[Severity]string)
+	var errs []error
+	for _, sev := range Severities {
+		group := groups[sev]
+		if len(group) == 0 {
+			continue
+		}
+		link, err := openGroup(ctx, repo, remote, base, sev, group, opener)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s updates: %w", sev, err))
+			continue
+		}
+		opened[sev] = link
+	}
+	return opened, errors.Join(errs...)
+}
+
+func openGroup(ctx context.Context, repo Repository, remote, base string, sev Severity, updates []Update, opener PullRequestOpener) (string, error) {
+	branch := BranchName(sev)
+	if _, err := runGit(ctx, repo.Path, "checkout", "-q", "-B", branch, base); err != nil {
+		return "", err
+	}
+	// Always return to the base branch, discarding a failed rewrite.
+	defer func() {
+		_, _ = runGit(context.WithoutCancel(ctx), repo.Path, "checkout", "-q", "-f", base)
+	}()
+
+	if err := ApplyUpdates(repo.Path, updates); err != nil {
+		return "", err
+	}
+	title := fmt.Sprintf("chore(deps): bump %d %s dependenc%s", len(updates), sev, pluralY(len(updates)))
+	if _, err := runGit(ctx, repo.Path, "commit", "-q", "-a", "-m", title); err != nil {
+		return "", err
+	}
+	if _, err := runGit(ctx, repo.Path, "push", "-q", "-f", "origin", branch); err != nil {
+		return "", err
+	}
+
+	link, err := opener.Open(ctx, PullRequest{
+		Repo:  remote,
+		Head:  branch,
+		Base:  base,
+		Title: title,
+		Body:  pullRequestBody(updates),
+	})
+	if errors.Is(err, ErrPullRequestExists) {
+		return "updated " + branch, nil
+	}
+	return link, err
+}
+
+func pluralY(n int) string {
+	if n == 1 {
+		return "y"
+	}
+	return "ies"
+}
+
+func pullRequestBody(updates []Update) string {
+	var b strings.Builder
+	b.WriteString("Dependency updates found by `gz git repo deps`.\n\n")
+	b.WriteString("| Dependency | Ecosystem | From | To |\n|---|---|---|---|\n")
+	for _, u := range updates {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", u.Name, u.Ecosystem, u.Version, u.Latest)
+	}
+	b.WriteString("\nOnly manifests are changed; lock files such as go.sum or package-lock.json must be regenerated by CI or the reviewer.\n")
+	return b.String()
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
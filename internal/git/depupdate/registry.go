@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package depupdate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+// errNotFound is returned for HTTP 404 responses.
+var errNotFound = errors.New("not found")
+
+// Resolver looks up the latest released version of a dependency.
+type Resolver interface {
+	Latest(ctx context.Context, eco Ecosystem, name string) (string, error)
+}
+
+// Default registry endpoints.
+const (
+	DefaultGoProxy     = "https://proxy.golang.org"
+	DefaultNPMRegistry = "https://registry.npmjs.org"
+	DefaultPyPI        = "https://pypi.org"
+)
+
+// RegistryResolver queries the public package registries. Results are
+// cached for the lifetime of the resolver, since many repositories share
+// dependencies.
+type RegistryResolver struct {
+	GoProxy     string
+	NPMRegistry string
+	PyPI        string
+
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+// NewRegistryResolver creates a resolver for the default registries. A
+// GOPROXY environment variable naming an HTTP proxy is honored.
+func NewRegistryResolver() *RegistryResolver {
+	goProxy := DefaultGoProxy
+	if env := strings.Split(os.Getenv("GOPROXY"), ",")[0]; strings.HasPrefix(env, "http") {
+		goProxy = env
+	}
+	return &RegistryResolver{
+		GoProxy:     goProxy,
+		NPMRegistry: DefaultNPMRegistry,
+		PyPI:        DefaultPyPI,
+		httpClient:  httpclient.GetGlobalClient("default"),
+		cache:       make(map[string]string),
+	}
+}
+
+// Latest implements Resolver.
+func (r *RegistryResolver) Latest(ctx context.Context, eco Ecosystem, name string) (string, error) {
+	key := string(eco) + ":" + name
+	r.mu.Lock()
+	if v, ok := r.cache[key]; ok {
+		r.mu.Unlock()
+		return v, nil
+	}
+	r.mu.Unlock()
+
+	var latest string
+	var err error
+	switch eco {
+	case EcosystemGo:
+		var info struct {
+			Version string `json:"Version"` //nolint:tagliatelle // Go module proxy API
+		}
+		err = r.get(ctx, strings.TrimSuffix(r.GoProxy, "/")+"/"+escapeModulePath(name)+"/@latest", &info)
+		latest = info.Version
+	case EcosystemNPM:
+		var info struct {
+			Version string `json:"version"`
+		}
+		err = r.get(ctx, strings.TrimSuffix(r.NPMRegistry, "/")+"/"+strings.Replace(name, "/", "%2F", 1)+"/latest", &info)
+		latest = info.Version
+	case EcosystemPip:
+		var info struct {
+			Info struct {
+				Version string `json:"version"`
+			} `json:"info"`
+		}
+		err = r.get(ctx, strings.TrimSuffix(r.PyPI, "/")+"/pypi/"+url.PathEscape(name)+"/json", &info)
+		latest = info.Info.Version
+	default:
+		return "", fmt.Errorf("unsupported ecosystem: %s", eco)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up %s %s: %w", eco, name, err)
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no version published for %s %s", eco, name)
+	}
+
+	r.mu.Lock()
+	r.cache[key] = latest
+	r.mu.Unlock()
+	return latest, nil
+}
+
+func (r *RegistryResolver) get(ctx context.Context, rawURL string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "gzh-cli")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("HTTP %d - %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// escapeModulePath applies the module proxy case encoding: each upper-case
+// letter becomes '!' followed by its lower-case form.
+func escapeModulePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
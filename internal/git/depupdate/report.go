@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package depupdate
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Counts returns the number of updates per severity across results.
+func Counts(results []RepoResult) map[Severity]int {
+	counts := make(map[Severity]int)
+	for _, r := range results {
+		for _, u := range r.Updates {
+			counts[u.Severity]++
+		}
+	}
+	return counts
+}
+
+// PrintTable writes the updates of each repository, grouped by severity.
+func PrintTable(w io.Writer, results []RepoResult) {
+	for _, r := range results {
+		if len(r.Updates) == 0 && len(r.Errors) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n📦 %s\n", r.Repository.FullName())
+
+		groups := r.Group()
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, sev := range Severities {
+			for _, u := range groups[sev] {
+				fmt.Fprintf(tw, "  %s\t%s\t%s\t%s → %s\n", sev, u.Ecosystem, u.Name, u.Version, u.Latest)
+			}
+			if link, ok := r.PullRequests[sev]; ok {
+				fmt.Fprintf(tw, "  %s\tPR\t%s\t\n", sev, link)
+			}
+		}
+		tw.Flush()
+
+		for _, e := range r.Errors {
+			fmt.Fprintf(w, "  ⚠️  %s\n", e)
+		}
+	}
+}
+
+// PrintSummary writes the update counts.
+func PrintSummary(w io.Writer, results []RepoResult) {
+	counts := Counts(results)
+	withUpdates, skipped := 0, 0
+	for _, r := range results {
+		if len(r.Updates) > 0 {
+			withUpdates++
+		}
+		skipped += r.Skipped
+	}
+
+	fmt.Fprintf(w, "\n%d of %d repositories have updates: %d patch, %d minor, %d major",
+		withUpdates, len(results), counts[SeverityPatch], counts[SeverityMinor], counts[SeverityMajor])
+	if skipped > 0 {
+		fmt.Fprintf(w, " (%d excluded by policy)", skipped)
+	}
+	fmt.Fprintln(w)
+}
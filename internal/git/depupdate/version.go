@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package depupdate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Severity classifies an update by the semantic version component that
+// changes.
+type Severity string
+
+const (
+	SeverityPatch Severity = "patch"
+	SeverityMinor Severity = "minor"
+	SeverityMajor Severity = "major"
+)
+
+// Severities lists every severity from the least to the most disruptive.
+var Severities = []Severity{SeverityPatch, SeverityMinor, SeverityMajor}
+
+// ParseSeverity parses a severity name.
+func ParseSeverity(s string) (Severity, error) {
+	for _, sev := range Severities {
+		if string(sev) == strings.ToLower(s) {
+			return sev, nil
+		}
+	}
+	return "", fmt.Errorf("invalid severity %q (valid: patch, minor, major)", s)
+}
+
+// rank orders severities; unknown values rank highest.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityPatch:
+		return 0
+	case SeverityMinor:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// AtMost reports whether s is not more disruptive than limit.
+func (s Severity) AtMost(limit Severity) bool {
+	return s.rank() <= limit.rank()
+}
+
+// version is a parsed semantic version.
+type version struct {
+	parts      [3]int
+	prerelease string
+}
+
+// parseVersion parses versions such as v1.2.3, 1.2 and 1.2.3-rc.1. Build
+// metadata and Go pseudo-version suffixes are treated as pre-releases.
+func parseVersion(s string) (version, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	core, pre, _ := strings.Cut(s, "-")
+
+	fields := strings.Split(core, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return version{}, false
+	}
+	var v version
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		v.parts[i] = n
+	}
+	v.prerelease = pre
+	return v, true
+}
+
+func (v version) compare(o version) int {
+	for i := range v.parts {
+		switch {
+		case v.parts[i] < o.parts[i]:
+			return -1
+		case v.parts[i] > o.parts[i]:
+			return 1
+		}
+	}
+	switch {
+	case v.prerelease == o.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case o.prerelease == "":
+		return -1
+	case v.prerelease < o.prerelease:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// Classify returns the severity of updating from current to latest and
+// whether latest is an update at all. Pre-release latest versions are never
+// proposed.
+func Classify(current, latest string) (Severity, bool) {
+	cur, ok := parseVersion(current)
+	if !ok {
+		return "", false
+	}
+	lat, ok := parseVersion(latest)
+	if !ok || lat.prerelease != "" || lat.compare(cur) <= 0 {
+		return "", false
+	}
+
+	switch {
+	case lat.parts[0] != cur.parts[0]:
+		return SeverityMajor, true
+	case lat.parts[1] != cur.parts[1]:
+		return SeverityMinor, true
+	default:
+		return SeverityPatch, true
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package forksync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+// Upstream is the repository a fork follows.
+type Upstream struct {
+	URL string `json:"url"`
+	// Branch is the upstream default branch when the platform reports it.
+	Branch string `json:"branch,omitempty"`
+}
+
+// Detector finds the upstream of a fork from its hosting platform.
+type Detector interface {
+	// Upstream returns the upstream of the repository (owner/name; GitLab:
+	// full project path), or nil when it is not a fork.
+	Upstream(ctx context.Context, repo string) (*Upstream, error)
+}
+
+// NewDetector creates the detector for the platform hosting the forks.
+// baseURL selects a self-hosted API and may be empty.
+//
+// Besides regular forks, repositories imported or mirrored from another
+// platform are detected: GitLab reports the import URL and Gitea the
+// original URL of a migration, which is how forks of GitHub projects on an
+// internal GitLab or Gitea usually come about.
+func NewDetector(provider, baseURL, token string) (Detector, error) {
+	client := httpclient.NewProviderClient(provider, 60*time.Second)
+	switch provider {
+	case "github":
+		return &githubDetector{api: apiURL(baseURL, depupdate.DefaultGitHubAPI), token: token, client: client}, nil
+	case "gitlab":
+		return &gitlabDetector{api: apiURL(baseURL, depupdate.DefaultGitLabAPI), token: token, client: client}, nil
+	case "gitea":
+		return &giteaDetector{api: apiURL(baseURL, depupdate.DefaultGiteaAPI), token: token, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unsupported provider for fork detection: %s (supported: github, gitlab, gitea)", provider)
+	}
+}
+
+func apiURL(baseURL, def string) string {
+	if baseURL == "" {
+		return def
+	}
+	return strings.TrimSuffix(baseURL, "/")
+}
+
+func getJSON(ctx context.Context, client *http.Client, rawURL string, authorize func(*http.Request), out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "gzh-cli")
+	authorize(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("GET %s: HTTP %d - %s", req.URL.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", req.URL.Path, err)
+	}
+	return nil
+}
+
+// stripCredentials removes the user info GitLab and Gitea keep in import
+// URLs.
+func stripCredentials(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User == nil {
+		return rawURL
+	}
+	u.User = nil
+	return u.String()
+}
+
+type githubDetector struct {
+	api    string
+	token  string
+	client *http.Client
+}
+
+func (d *githubDetector) Upstream(ctx context.Context, repo string) (*Upstream, error) {
+	var out struct {
+		Parent *struct {
+			CloneURL      string `json:"clone_url"`      //nolint:tagliatelle // GitHub API
+			DefaultBranch string `json:"default_branch"` //nolint:tagliatelle // GitHub API
+		} `json:"parent"`
+	}
+	err := getJSON(ctx, d.client, d.api+"/repos/"+repo, func(req *http.Request) {
+		if d.token != "" {
+			req.Header.Set("Authorization", "Bearer "+d.token)
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+	}, &out)
+	if err != nil || out.Parent == nil {
+		return nil, err
+	}
+	return &Upstream{URL: out.Parent.CloneURL, Branch: out.Parent.DefaultBranch}, nil
+}
+
+type gitlabDetector struct {
+	api    string
+	token  string
+	client *http.Client
+}
+
+func (d *gitlabDetector) Upstream(ctx context.Context, repo string) (*Upstream, error) {
+	var out struct {
+		ForkedFrom *struct {
+			HTTPURL       string `json:"http_url_to_repo"` //nolint:tagliatelle // GitLab API
+			DefaultBranch string `json:"default_branch"`   //nolint:tagliatelle // GitLab API
+		} `json:"forked_from_project"` //nolint:tagliatelle // GitLab API
+		ImportURL string `json:"import_url"` //nolint:tagliatelle // GitLab API
+	}
+	err := getJSON(ctx, d.client, d.api+"/projects/"+url.PathEscape(repo), func(req *http.Request) {
+		if d.token != "" {
+			req.Header.Set("PRIVATE-TOKEN", d.token)
+		}
+	}, &out)
+	switch {
+	case err != nil:
+		return nil, err
+	case out.ForkedFrom != nil:
+		return &Upstream{URL: out.ForkedFrom.HTTPURL, Branch: out.ForkedFrom.DefaultBranch}, nil
+	case out.ImportURL != "":
+		return &Upstream{URL: stripCredentials(out.ImportURL)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+type giteaDetector struct {
+	api    string
+	token  string
+	client *http.Client
+}
+
+func (d *giteaDetector) Upstream(ctx context.Context, repo string) (*Upstream, error) {
+	var out struct {
+		Parent *struct {
+			CloneURL      string `json:"clone_url"`      //nolint:tagliatelle // Gitea API
+			DefaultBranch string `json:"default_branch"` //nolint:tagliatelle // Gitea API
+		} `json:"parent"`
+		OriginalURL string `json:"original_url"` //nolint:tagliatelle // Gitea API
+	}
+	err := getJSON(ctx, d.client, d.api+"/repos/"+repo, func(req *http.Request) {
+		if d.token != "" {
+			req.Header.Set("Authorization", "token "+d.token)
+		}
+	}, &out)
+	switch {
+	case err != nil:
+		return nil, err
+	case out.Parent != nil:
+		return &Upstream{URL: out.Parent.CloneURL, Branch: out.Parent.DefaultBranch}, nil
+	case out.OriginalURL != "":
+		return &Upstream{URL: stripCredentials(out.OriginalURL)}, nil
+	default:
+		return nil, nil
+	}
+}
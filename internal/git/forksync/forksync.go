@@ -0,0 +1,342 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package forksync keeps forks up to date with their upstream repositories,
+// also when fork and upstream live on different platforms.
+package forksync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+)
+
+// Status is the outcome of syncing one fork.
+type Status string
+
+// Sync statuses.
+const (
+	StatusUpToDate        Status = "up-to-date"
+	StatusFastForwarded   Status = "fast-forwarded"
+	StatusFastForwardable Status = "fast-forwardable"
+	StatusMergeRequest    Status = "merge-request"
+	// StatusNeedsMerge is reported when a merge request would be opened but
+	// the run is a dry run or has no platform access.
+	StatusNeedsMerge Status = "needs-merge"
+	// StatusDiverged is reported for diverged forks whose policy only allows
+	// fast-forwards.
+	StatusDiverged Status = "diverged"
+	StatusNotFork  Status = "not-a-fork"
+	StatusSkipped  Status = "skipped"
+	StatusFailed   Status = "failed"
+)
+
+// Upstream sources, in order of precedence.
+const (
+	SourcePolicy   = "policy"
+	SourceRemote   = "remote"
+	SourceProvider = "provider"
+)
+
+// upstreamRemote is the clone remote taken as the upstream of a fork.
+const upstreamRemote = "upstream"
+
+// Result is the outcome of syncing one fork.
+type Result struct {
+	Repository     depupdate.Repository `json:"repository"`
+	Status         Status               `json:"status"`
+	Mode           Mode                 `json:"mode,omitempty"`
+	Upstream       string               `json:"upstream,omitempty"`
+	UpstreamSource string               `json:"upstreamSource,omitempty"`
+	UpstreamBranch string               `json:"upstreamBranch,omitempty"`
+	Branch         string               `json:"branch,omitempty"`
+	// Ahead and Behind count the commits only on the fork and only on the
+	// upstream branch.
+	Ahead  int `json:"ahead"`
+	Behind int `json:"behind"`
+	// Conflicts lists the files a merge of the upstream changes conflicts in.
+	Conflicts    []string `json:"conflicts,omitempty"`
+	MergeRequest string   `json:"mergeRequest,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// Syncer brings forks up to date with their upstream.
+type Syncer struct {
+	Policy *Policy
+	// Detector finds upstreams from the platform's fork metadata; optional.
+	Detector Detector
+	// Opener opens merge requests on the fork's platform; without it,
+	// forks that cannot be fast-forwarded are only reported.
+	Opener depupdate.PullRequestOpener
+	// DryRun fetches and compares but changes nothing.
+	DryRun      bool
+	Concurrency int
+}
+
+// Sync syncs the clones of forks. The fork is the origin remote of each
+// clone; branches are updated on the remote and working trees are left
+// untouched.
+func (s *Syncer) Sync(ctx context.Context, repos []depupdate.Repository) []Result {
+	results := make([]Result, len(repos))
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = s.syncRepository(ctx, repo)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// SyncBranch returns the branch merge requests for upstream changes are
+// opened from.
+func SyncBranch(upstreamBranch string) string {
+	return "gz/upstream-" + upstreamBranch
+}
+
+func (s *Syncer) syncRepository(ctx context.Context, repo depupdate.Repository) Result {
+	result := Result{Repository: repo}
+	rules := s.Policy.RulesFor(repo.FullName())
+	result.Mode = rules.Mode
+	if rules.Mode == ModeSkip {
+		result.Status = StatusSkipped
+		return result
+	}
+
+	fail := func(err error) Result {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	upstream, err := s.resolveUpstream(ctx, repo, rules, &result)
+	if err != nil {
+		return fail(err)
+	}
+	if upstream == nil {
+		result.Status = StatusNotFork
+		return result
+	}
+	result.Upstream = upstream.URL
+
+	if result.Branch = rules.Branch; result.Branch == "" {
+		out, err := runGit(ctx, repo.Path, "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return fail(err)
+		}
+		result.Branch = strings.TrimSpace(out)
+	}
+	if result.UpstreamBranch = rules.UpstreamBranch; result.UpstreamBranch == "" {
+		result.UpstreamBranch = upstream.Branch
+	}
+	if result.UpstreamBranch == "" {
+		result.UpstreamBranch = defaultBranch(ctx, repo.Path, upstream.URL, result.Branch)
+	}
+
+	forkRef := "refs/remotes/origin/" + result.Branch
+	upstreamRef := "refs/remotes/gz-upstream/" + result.UpstreamBranch
+	if _, err := runGit(ctx, repo.Path, "fetch", "-q", "--no-tags", "origin", "+refs/heads/"+result.Branch+":"+forkRef); err != nil {
+		return fail(err)
+	}
+	if _, err := runGit(ctx, repo.Path, "fetch", "-q", "--no-tags", upstream.URL, "+refs/heads/"+result.UpstreamBranch+":"+upstreamRef); err != nil {
+		return fail(fmt.Errorf("failed to fetch upstream: %w", err))
+	}
+
+	out, err := runGit(ctx, repo.Path, "rev-list", "--left-right", "--count", forkRef+"..."+upstreamRef)
+	if err != nil {
+		return fail(err)
+	}
+	if _, err := fmt.Sscan(out, &result.Ahead, &result.Behind); err != nil {
+		return fail(fmt.Errorf("unexpected rev-list output %q", strings.TrimSpace(out)))
+	}
+	if result.Behind == 0 {
+		result.Status = StatusUpToDate
+		return result
+	}
+
+	if result.Ahead == 0 && rules.Mode != ModeMergeRequest {
+		if s.DryRun {
+			result.Status = StatusFastForwardable
+			return result
+		}
+		// 리모트 브랜치만 갱신하고 작업 트리는 건드리지 않는다
+		if _, err := runGit(ctx, repo.Path, "push", "-q", "origin", upstreamRef+":refs/heads/"+result.Branch); err != nil {
+			return fail(err)
+		}
+		result.Status = StatusFastForwarded
+		return result
+	}
+
+	if result.Ahead > 0 {
+		if result.Conflicts, err = mergeConflicts(ctx, repo.Path, forkRef, upstreamRef); err != nil {
+			return fail(err)
+		}
+	}
+	switch {
+	case rules.Mode == ModeFastForward:
+		result.Status = StatusDiverged
+		return result
+	case s.DryRun || s.Opener == nil:
+		result.Status = StatusNeedsMerge
+		return result
+	}
+
+	if result.MergeRequest, err = s.openMergeRequest(ctx, repo, upstreamRef, &result); err != nil {
+		return fail(err)
+	}
+	result.Status = StatusMergeRequest
+	return result
+}
+
+// resolveUpstream finds the upstream from the policy, the clone's upstream
+// remote or the platform, in that order.
+func (s *Syncer) resolveUpstream(ctx context.Context, repo depupdate.Repository, rules Rules, result *Result) (*Upstream, error) {
+	if rules.Upstream != "" {
+		result.UpstreamSource = SourcePolicy
+		return &Upstream{URL: rules.Upstream}, nil
+	}
+	if out, err := runGit(ctx, repo.Path, "remote", "get-url", upstreamRemote); err == nil {
+		result.UpstreamSource = SourceRemote
+		return &Upstream{URL: strings.TrimSpace(out)}, nil
+	}
+	if s.Detector == nil {
+		return nil, nil
+	}
+
+	remote, err := depupdate.RemoteRepo(ctx, repo.Path)
+	if err != nil {
+		return nil, err
+	}
+	upstream, err := s.Detector.Upstream(ctx, remote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect upstream of %s: %w", remote, err)
+	}
+	if upstream != nil {
+		result.UpstreamSource = SourceProvider
+	}
+	return upstream, nil
+}
+
+// defaultBranch asks the upstream for its default branch, falling back to
+// the fork branch.
+func defaultBranch(ctx context.Context, dir, upstreamURL, fallback string) string {
+	out, err := runGit(ctx, dir, "ls-remote", "--symref", upstreamURL, "HEAD")
+	if err != nil {
+		return fallback
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if ref, ok := strings.CutPrefix(line, "ref: refs/heads/"); ok {
+			if branch, _, ok := strings.Cut(ref, "\tHEAD"); ok {
+				return branch
+			}
+		}
+	}
+	return fallback
+}
+
+// mergeConflicts returns the files merging theirs into ours conflicts in,
+// computed without touching the working tree.
+func mergeConflicts(ctx context.Context, dir, ours, theirs string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "merge-tree", "--write-tree", "--no-messages", ours, theirs)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// 종료 코드 1은 충돌이 있음을 뜻한다
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			return nil, fmt.Errorf("git merge-tree: %w: %s (git 2.38 or newer is required)", err, strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	// The first line is the merged tree, followed by "<mode> <oid> <stage>\t<path>"
+	// per conflicted stage
+	var conflicts []string
+	seen := make(map[string]bool)
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	for _, line := range lines[1:] {
+		_, path, ok := strings.Cut(line, "\t")
+		if !ok || seen[path] {
+			continue
+		}
+		seen[path] = true
+		conflicts = append(conflicts, path)
+	}
+	return conflicts, nil
+}
+
+// openMergeRequest pushes the upstream branch to the sync branch of the
+// fork and opens a merge request into the fork branch.
+func (s *Syncer) openMergeRequest(ctx context.Context, repo depupdate.Repository, upstreamRef string, result *Result) (string, error) {
+	remote, err := depupdate.RemoteRepo(ctx, repo.Path)
+	if err != nil {
+		return "", err
+	}
+	branch := SyncBranch(result.UpstreamBranch)
+	if _, err := runGit(ctx, repo.Path, "push", "-q", "-f", "origin", upstreamRef+":refs/heads/"+branch); err != nil {
+		return "", err
+	}
+
+	link, err := s.Opener.Open(ctx, depupdate.PullRequest{
+		Repo:  remote,
+		Head:  branch,
+		Base:  result.Branch,
+		Title: fmt.Sprintf("chore: sync %s with upstream %s", result.Branch, result.UpstreamBranch),
+		Body:  mergeRequestBody(result),
+	})
+	if errors.Is(err, depupdate.ErrPullRequestExists) {
+		// 강제 푸시로 기존 머지 리퀘스트가 갱신되었다
+		return "", nil
+	}
+	return link, err
+}
+
+func mergeRequestBody(result *Result) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Brings %d commit%s from `%s` of %s into `%s`.\n",
+		result.Behind, plural(result.Behind), result.UpstreamBranch, result.Upstream, result.Branch)
+	if len(result.Conflicts) > 0 {
+		b.WriteString("\nThe merge conflicts in:\n\n")
+		for _, c := range result.Conflicts {
+			fmt.Fprintf(&b, "- `%s`\n", c)
+		}
+	}
+	b.WriteString("\nOpened by `gz git repo sync-forks`.\n")
+	return b.String()
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
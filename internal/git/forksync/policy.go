@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package forksync
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mode selects how a fork is brought up to date with its upstream.
+type Mode string
+
+// Sync modes.
+const (
+	// ModeAuto fast-forwards when possible and opens a merge request
+	// otherwise.
+	ModeAuto Mode = "auto"
+	// ModeFastForward only fast-forwards; diverged forks are reported.
+	ModeFastForward Mode = "fast-forward"
+	// ModeMergeRequest always opens a merge request, for forks whose
+	// upstream changes are reviewed.
+	ModeMergeRequest Mode = "merge-request"
+	// ModeSkip leaves the fork alone.
+	ModeSkip Mode = "skip"
+)
+
+// ParseMode parses a sync mode name.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(s); m {
+	case ModeAuto, ModeFastForward, ModeMergeRequest, ModeSkip:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown sync mode: %s (supported: auto, fast-forward, merge-request, skip)", s)
+	}
+}
+
+// Policy controls how forks are synced, per repository.
+//
+//	default:
+//	  mode: auto
+//	repos:
+//	  oss/kubernetes:
+//	    upstream: https://github.com/kubernetes/kubernetes.git
+//	    upstream_branch: master
+//	    branch: main
+//	    mode: merge-request
+//	  oss/legacy:
+//	    mode: skip
+//
+// Repositories are keyed by org/name as laid out below the scan root. Their
+// rules override the default ones field by field.
+type Policy struct {
+	Default Rules            `yaml:"default" json:"default"`
+	Repos   map[string]Rules `yaml:"repos" json:"repos,omitempty"`
+}
+
+// Rules describe the upstream of a fork and how it is synced.
+type Rules struct {
+	// Upstream is the clone URL of the upstream repository. It overrides the
+	// upstream remote of the clone and the platform's fork metadata.
+	Upstream string `yaml:"upstream" json:"upstream,omitempty"`
+	// UpstreamBranch is the upstream branch followed; default the
+	// upstream's default branch.
+	UpstreamBranch string `yaml:"upstream_branch" json:"upstreamBranch,omitempty"`
+	// Branch is the fork branch updated; default the clone's current branch.
+	Branch string `yaml:"branch" json:"branch,omitempty"`
+	// Mode is the sync mode; default auto.
+	Mode Mode `yaml:"mode" json:"mode,omitempty"`
+}
+
+// LoadPolicy reads a policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %w", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Validate checks the sync modes.
+func (p *Policy) Validate() error {
+	if p.Default.Upstream != "" {
+		return fmt.Errorf("default: upstream can only be set per repository")
+	}
+	check := func(where string, r Rules) error {
+		if r.Mode != "" {
+			if _, err := ParseMode(string(r.Mode)); err != nil {
+				return fmt.Errorf("%s: %w", where, err)
+			}
+		}
+		return nil
+	}
+
+	if err := check("default", p.Default); err != nil {
+		return err
+	}
+	for repo, r := range p.Repos {
+		if err := check("repos."+repo, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RulesFor returns the rules applying to the repository org/name.
+func (p *Policy) RulesFor(repo string) Rules {
+	rules := Rules{Mode: ModeAuto}
+	if p == nil {
+		return rules
+	}
+	for _, r := range []Rules{p.Default, p.Repos[repo]} {
+		if r.Upstream != "" {
+			rules.Upstream = r.Upstream
+		}
+		if r.UpstreamBranch != "" {
+			rules.UpstreamBranch = r.UpstreamBranch
+		}
+		if r.Branch != "" {
+			rules.Branch = r.Branch
+		}
+		if r.Mode != "" {
+			rules.Mode = r.Mode
+		}
+	}
+	return rules
+}
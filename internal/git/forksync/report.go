@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package forksync
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+var statusIcons = map[Status]string{
+	StatusUpToDate:        "✅",
+	StatusFastForwarded:   "⏩",
+	StatusFastForwardable: "⏩",
+	StatusMergeRequest:    "🔀",
+	StatusNeedsMerge:      "🔀",
+	StatusDiverged:        "⚠️ ",
+	StatusSkipped:         "⏭️ ",
+	StatusFailed:          "❌",
+}
+
+// PrintTable writes one line per fork, followed by merge request links and
+// conflicting files. Repositories that are not forks are left out.
+func PrintTable(w io.Writer, results []Result) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, r := range results {
+		if r.Status == StatusNotFork {
+			continue
+		}
+		fmt.Fprintf(tw, "%s %s\t%s\t%s\n", statusIcons[r.Status], r.Repository.FullName(), r.Status, detail(r))
+	}
+	tw.Flush()
+
+	for _, r := range results {
+		if r.MergeRequest == "" && len(r.Conflicts) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n📦 %s\n", r.Repository.FullName())
+		if r.MergeRequest != "" {
+			fmt.Fprintf(w, "  MR: %s\n", r.MergeRequest)
+		}
+		for _, c := range r.Conflicts {
+			fmt.Fprintf(w, "  conflict: %s\n", c)
+		}
+	}
+}
+
+func detail(r Result) string {
+	switch r.Status {
+	case StatusSkipped:
+		return "policy"
+	case StatusFailed:
+		return r.Error
+	}
+	d := fmt.Sprintf("%s ← %s %s (%s)", r.Branch, r.Upstream, r.UpstreamBranch, r.UpstreamSource)
+	if r.Behind > 0 {
+		d += fmt.Sprintf(", %d behind", r.Behind)
+	}
+	if r.Ahead > 0 {
+		d += fmt.Sprintf(", %d ahead", r.Ahead)
+	}
+	return d
+}
+
+// Counts returns the number of results per status.
+func Counts(results []Result) map[Status]int {
+	counts := make(map[Status]int)
+	for _, r := range results {
+		counts[r.Status]++
+	}
+	return counts
+}
+
+// PrintSummary writes the sync counts.
+func PrintSummary(w io.Writer, results []Result) {
+	counts := Counts(results)
+	conflicts := 0
+	for _, r := range results {
+		if len(r.Conflicts) > 0 {
+			conflicts++
+		}
+	}
+
+	forks := len(results) - counts[StatusNotFork]
+	fmt.Fprintf(w, "\n%d forks of %d repositories: %d up to date, %d fast-forwarded, %d merge requests, %d diverged, %d failed",
+		forks, len(results), counts[StatusUpToDate],
+		counts[StatusFastForwarded]+counts[StatusFastForwardable],
+		counts[StatusMergeRequest]+counts[StatusNeedsMerge],
+		counts[StatusDiverged], counts[StatusFailed])
+	if conflicts > 0 {
+		fmt.Fprintf(w, " (%d with conflicts)", conflicts)
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package labels
+
+import (
+	"context"
+	"fmt"
+)
+
+// Backend reads and writes labels and milestones on one platform. Label
+// and milestone values passed in and returned use the spec's conventions:
+// lowercase colors without '#', YYYY-MM-DD dates and StateOpen or
+// StateClosed.
+type Backend interface {
+	// Provider returns the platform name.
+	Provider() string
+	// ListRepositories lists the repositories of an organization or group
+	// that are not archived.
+	ListRepositories(ctx context.Context, org string) ([]string, error)
+
+	ListLabels(ctx context.Context, org, repo string) ([]Label, error)
+	CreateLabel(ctx context.Context, org, repo string, label Label) error
+	// UpdateLabel changes current to desired, renaming it when the names
+	// differ.
+	UpdateLabel(ctx context.Context, org, repo string, current, desired Label) error
+	DeleteLabel(ctx context.Context, org, repo string, label Label) error
+	// LabelInUse reports whether any issue or pull request, open or closed,
+	// carries the label.
+	LabelInUse(ctx context.Context, org, repo string, label Label) (bool, error)
+
+	ListMilestones(ctx context.Context, org, repo string) ([]Milestone, error)
+	CreateMilestone(ctx context.Context, org, repo string, milestone Milestone) error
+	// UpdateMilestone changes current to desired. Empty fields of desired
+	// other than Description are left alone.
+	UpdateMilestone(ctx context.Context, org, repo string, current, desired Milestone) error
+	DeleteMilestone(ctx context.Context, org, repo string, milestone Milestone) error
+	// MilestoneInUse reports whether any issue or pull request, open or
+	// closed, is assigned to the milestone.
+	MilestoneInUse(ctx context.Context, org, repo string, milestone Milestone) (bool, error)
+}
+
+// NewBackend creates the backend for provider. baseURL selects a
+// self-hosted API and may be empty.
+func NewBackend(provider, baseURL, token string) (Backend, error) {
+	switch provider {
+	case "github":
+		return newGitHubBackend(baseURL, token), nil
+	case "gitlab":
+		return newGitLabBackend(baseURL, token), nil
+	case "gitea":
+		return newGiteaBackend(baseURL, token), nil
+	default:
+		return nil, fmt.Errorf("unsupported provider for label sync: %s (supported: github, gitlab, gitea)", provider)
+	}
+}
+
+// dueDate returns the date part of an RFC 3339 time or date, or "".
+func dueDate(value *string) string {
+	if value == nil || len(*value) < len(dateLayout) {
+		return ""
+	}
+	return (*value)[:len(dateLayout)]
+}
+
+// dueTime returns a date as the RFC 3339 time GitHub and Gitea expect, or
+// nil for an empty date.
+func dueTime(date string) *string {
+	if date == "" {
+		return nil
+	}
+	t := date + "T00:00:00Z"
+	return &t
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package labels
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// giteaBackend implements Backend against the Gitea API (v1).
+type giteaBackend struct {
+	client *restapi.Client
+}
+
+func newGiteaBackend(baseURL, token string) *giteaBackend {
+	if baseURL == "" {
+		baseURL = "https://gitea.com/api/v1"
+	}
+	return &giteaBackend{
+		client: restapi.New("gitea", baseURL, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("Authorization", "token "+token)
+			}
+		}),
+	}
+}
+
+func (b *giteaBackend) Provider() string { return "gitea" }
+
+// nolint:tagliatelle // External API format - must match Gitea JSON output
+type giteaMilestone struct {
+	ID           int64   `json:"id"`
+	Title        string  `json:"title"`
+	Description  string  `json:"description"`
+	DueOn        *string `json:"due_on"`
+	State        string  `json:"state"`
+	OpenIssues   int     `json:"open_issues"`
+	ClosedIssues int     `json:"closed_issues"`
+}
+
+func (b *giteaBackend) repoPath(org, repo string) string {
+	return "/repos/" + url.PathEscape(org) + "/" + url.PathEscape(repo)
+}
+
+func (b *giteaBackend) ListRepositories(ctx context.Context, org string) ([]string, error) {
+	repos, err := restapi.ListAll[ghRepo](ctx, b.client, "/orgs/"+url.PathEscape(org)+"/repos", "limit")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories of %s: %w", org, err)
+	}
+	var names []string
+	for _, r := range repos {
+		if !r.Archived {
+			names = append(names, r.Name)
+		}
+	}
+	return names, nil
+}
+
+func (b *giteaBackend) ListLabels(ctx context.Context, org, repo string) ([]Label, error) {
+	items, err := restapi.ListAll[ghLabel](ctx, b.client, b.repoPath(org, repo)+"/labels", "limit")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels of %s: %w", repo, err)
+	}
+	out := make([]Label, 0, len(items))
+	for _, l := range items {
+		out = append(out, Label{ID: l.ID, Name: l.Name, Color: normalizeColor(l.Color), Description: l.Description})
+	}
+	return out, nil
+}
+
+func (b *giteaBackend) CreateLabel(ctx context.Context, org, repo string, label Label) error {
+	body := map[string]string{"name": label.Name, "color": "#" + label.Color, "description": label.Description}
+	return b.client.Do(ctx, http.MethodPost, b.repoPath(org, repo)+"/labels", body, nil)
+}
+
+func (b *giteaBackend) labelPath(org, repo string, label Label) string {
+	return b.repoPath(org, repo) + "/labels/" + strconv.FormatInt(label.ID, 10)
+}
+
+func (b *giteaBackend) UpdateLabel(ctx context.Context, org, repo string, current, desired Label) error {
+	body := map[string]string{"name": desired.Name, "color": "#" + desired.Color, "description": desired.Description}
+	return b.client.Do(ctx, http.MethodPatch, b.labelPath(org, repo, current), body, nil)
+}
+
+func (b *giteaBackend) DeleteLabel(ctx context.Context, org, repo string, label Label) error {
+	return b.client.Do(ctx, http.MethodDelete, b.labelPath(org, repo, label), nil, nil)
+}
+
+// LabelInUse looks for one issue with the label; without a type filter the
+// issues endpoint also returns pull requests.
+func (b *giteaBackend) LabelInUse(ctx context.Context, org, repo string, label Label) (bool, error) {
+	return b.client.Exists(ctx, b.repoPath(org, repo)+"/issues?state=all&limit=1&labels="+url.QueryEscape(label.Name))
+}
+
+func (m giteaMilestone) milestone() Milestone {
+	return Milestone{ID: m.ID, Title: m.Title, Description: m.Description, DueOn: dueDate(m.DueOn), State: m.State}
+}
+
+func (b *giteaBackend) ListMilestones(ctx context.Context, org, repo string) ([]Milestone, error) {
+	items, err := restapi.ListAll[giteaMilestone](ctx, b.client, b.repoPath(org, repo)+"/milestones?state=all", "limit")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones of %s: %w", repo, err)
+	}
+	out := make([]Milestone, 0, len(items))
+	for _, m := range items {
+		out = append(out, m.milestone())
+	}
+	return out, nil
+}
+
+func (b *giteaBackend) CreateMilestone(ctx context.Context, org, repo string, milestone Milestone) error {
+	return b.client.Do(ctx, http.MethodPost, b.repoPath(org, repo)+"/milestones", milestoneBody(milestone), nil)
+}
+
+func (b *giteaBackend) milestonePath(org, repo string, m Milestone) string {
+	return b.repoPath(org, repo) + "/milestones/" + strconv.FormatInt(m.ID, 10)
+}
+
+func (b *giteaBackend) UpdateMilestone(ctx context.Context, org, repo string, current, desired Milestone) error {
+	return b.client.Do(ctx, http.MethodPatch, b.milestonePath(org, repo, current), milestoneBody(desired), nil)
+}
+
+func (b *giteaBackend) DeleteMilestone(ctx context.Context, org, repo string, milestone Milestone) error {
+	return b.client.Do(ctx, http.MethodDelete, b.milestonePath(org, repo, milestone), nil, nil)
+}
+
+func (b *giteaBackend) MilestoneInUse(ctx context.Context, org, repo string, milestone Milestone) (bool, error) {
+	var m giteaMilestone
+	if err := b.client.Do(ctx, http.MethodGet, b.milestonePath(org, repo, milestone), nil, &m); err != nil {
+		return false, err
+	}
+	return m.OpenIssues+m.ClosedIssues > 0, nil
+}
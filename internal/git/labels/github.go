@@ -0,0 +1,159 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package labels
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// gitHubBackend implements Backend against the GitHub REST API.
+type gitHubBackend struct {
+	client *restapi.Client
+}
+
+func newGitHubBackend(baseURL, token string) *gitHubBackend {
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return &gitHubBackend{
+		client: restapi.New("github", baseURL, func(req *http.Request) {
+			req.Header.Set("Accept", "application/vnd.github+json")
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}),
+	}
+}
+
+func (b *gitHubBackend) Provider() string { return "github" }
+
+type ghRepo struct {
+	Name     string `json:"name"`
+	Archived bool   `json:"archived"`
+}
+
+type ghLabel struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghMilestone struct {
+	Number       int64   `json:"number"`
+	Title        string  `json:"title"`
+	Description  string  `json:"description"`
+	DueOn        *string `json:"due_on"`
+	State        string  `json:"state"`
+	OpenIssues   int     `json:"open_issues"`
+	ClosedIssues int     `json:"closed_issues"`
+}
+
+func (b *gitHubBackend) repoPath(org, repo string) string {
+	return "/repos/" + url.PathEscape(org) + "/" + url.PathEscape(repo)
+}
+
+func (b *gitHubBackend) ListRepositories(ctx context.Context, org string) ([]string, error) {
+	repos, err := restapi.ListAll[ghRepo](ctx, b.client, "/orgs/"+url.PathEscape(org)+"/repos?type=all", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories of %s: %w", org, err)
+	}
+	var names []string
+	for _, r := range repos {
+		if !r.Archived {
+			names = append(names, r.Name)
+		}
+	}
+	return names, nil
+}
+
+func (b *gitHubBackend) ListLabels(ctx context.Context, org, repo string) ([]Label, error) {
+	items, err := restapi.ListAll[ghLabel](ctx, b.client, b.repoPath(org, repo)+"/labels", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels of %s: %w", repo, err)
+	}
+	out := make([]Label, 0, len(items))
+	for _, l := range items {
+		out = append(out, Label{ID: l.ID, Name: l.Name, Color: normalizeColor(l.Color), Description: l.Description})
+	}
+	return out, nil
+}
+
+func (b *gitHubBackend) CreateLabel(ctx context.Context, org, repo string, label Label) error {
+	body := map[string]string{"name": label.Name, "color": label.Color, "description": label.Description}
+	return b.client.Do(ctx, http.MethodPost, b.repoPath(org, repo)+"/labels", body, nil)
+}
+
+func (b *gitHubBackend) UpdateLabel(ctx context.Context, org, repo string, current, desired Label) error {
+	body := map[string]string{"new_name": desired.Name, "color": desired.Color, "description": desired.Description}
+	return b.client.Do(ctx, http.MethodPatch, b.repoPath(org, repo)+"/labels/"+url.PathEscape(current.Name), body, nil)
+}
+
+func (b *gitHubBackend) DeleteLabel(ctx context.Context, org, repo string, label Label) error {
+	return b.client.Do(ctx, http.MethodDelete, b.repoPath(org, repo)+"/labels/"+url.PathEscape(label.Name), nil, nil)
+}
+
+// LabelInUse looks for one issue with the label; the issues endpoint also
+// returns pull requests.
+func (b *gitHubBackend) LabelInUse(ctx context.Context, org, repo string, label Label) (bool, error) {
+	return b.client.Exists(ctx, b.repoPath(org, repo)+"/issues?state=all&per_page=1&labels="+url.QueryEscape(label.Name))
+}
+
+func (m ghMilestone) milestone() Milestone {
+	return Milestone{ID: m.Number, Title: m.Title, Description: m.Description, DueOn: dueDate(m.DueOn), State: m.State}
+}
+
+func (b *gitHubBackend) ListMilestones(ctx context.Context, org, repo string) ([]Milestone, error) {
+	items, err := restapi.ListAll[ghMilestone](ctx, b.client, b.repoPath(org, repo)+"/milestones?state=all", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones of %s: %w", repo, err)
+	}
+	out := make([]Milestone, 0, len(items))
+	for _, m := range items {
+		out = append(out, m.milestone())
+	}
+	return out, nil
+}
+
+func milestoneBody(m Milestone) map[string]any {
+	body := map[string]any{"title": m.Title, "description": m.Description}
+	if m.DueOn != "" {
+		body["due_on"] = dueTime(m.DueOn)
+	}
+	if m.State != "" {
+		body["state"] = m.State
+	}
+	return body
+}
+
+func (b *gitHubBackend) CreateMilestone(ctx context.Context, org, repo string, milestone Milestone) error {
+	return b.client.Do(ctx, http.MethodPost, b.repoPath(org, repo)+"/milestones", milestoneBody(milestone), nil)
+}
+
+func (b *gitHubBackend) milestonePath(org, repo string, m Milestone) string {
+	return b.repoPath(org, repo) + "/milestones/" + strconv.FormatInt(m.ID, 10)
+}
+
+func (b *gitHubBackend) UpdateMilestone(ctx context.Context, org, repo string, current, desired Milestone) error {
+	return b.client.Do(ctx, http.MethodPatch, b.milestonePath(org, repo, current), milestoneBody(desired), nil)
+}
+
+func (b *gitHubBackend) DeleteMilestone(ctx context.Context, org, repo string, milestone Milestone) error {
+	return b.client.Do(ctx, http.MethodDelete, b.milestonePath(org, repo, milestone), nil, nil)
+}
+
+func (b *gitHubBackend) MilestoneInUse(ctx context.Context, org, repo string, milestone Milestone) (bool, error) {
+	var m ghMilestone
+	if err := b.client.Do(ctx, http.MethodGet, b.milestonePath(org, repo, milestone), nil, &m); err != nil {
+		return false, err
+	}
+	return m.OpenIssues+m.ClosedIssues > 0, nil
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package labels
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// gitLabBackend implements Backend against the GitLab REST API (v4).
+//
+// Repositories are the projects directly in the group. Only project labels
+// are managed; labels inherited from groups are left alone. GitLab calls
+// open milestones "active".
+type gitLabBackend struct {
+	client *restapi.Client
+}
+
+func newGitLabBackend(baseURL, token string) *gitLabBackend {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com/api/v4"
+	}
+	return &gitLabBackend{
+		client: restapi.New("gitlab", baseURL, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("PRIVATE-TOKEN", token)
+			}
+		}),
+	}
+}
+
+func (b *gitLabBackend) Provider() string { return "gitlab" }
+
+type glProject struct {
+	Path     string `json:"path"`
+	Archived bool   `json:"archived"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glLabel struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+	IsProject   *bool  `json:"is_project_label"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glMilestone struct {
+	ID          int64   `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	DueDate     *string `json:"due_date"`
+	State       string  `json:"state"`
+}
+
+func (b *gitLabBackend) projectPath(org, repo string) string {
+	return "/projects/" + url.PathEscape(org+"/"+repo)
+}
+
+func (b *gitLabBackend) ListRepositories(ctx context.Context, org string) ([]string, error) {
+	projects, err := restapi.ListAll[glProject](ctx, b.client, "/groups/"+url.PathEscape(org)+"/projects?archived=false", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects of %s: %w", org, err)
+	}
+	var names []string
+	for _, p := range projects {
+		if !p.Archived {
+			names = append(names, p.Path)
+		}
+	}
+	return names, nil
+}
+
+func (b *gitLabBackend) ListLabels(ctx context.Context, org, repo string) ([]Label, error) {
+	items, err := restapi.ListAll[glLabel](ctx, b.client, b.projectPath(org, repo)+"/labels?include_ancestor_groups=false", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list labels of %s: %w", repo, err)
+	}
+	out := make([]Label, 0, len(items))
+	for _, l := range items {
+		if l.IsProject != nil && !*l.IsProject {
+			continue
+		}
+		out = append(out, Label{ID: l.ID, Name: l.Name, Color: normalizeColor(l.Color), Description: l.Description})
+	}
+	return out, nil
+}
+
+func (b *gitLabBackend) CreateLabel(ctx context.Context, org, repo string, label Label) error {
+	body := map[string]string{"name": label.Name, "color": "#" + label.Color, "description": label.Description}
+	return b.client.Do(ctx, http.MethodPost, b.projectPath(org, repo)+"/labels", body, nil)
+}
+
+func (b *gitLabBackend) labelPath(org, repo string, label Label) string {
+	return b.projectPath(org, repo) + "/labels/" + strconv.FormatInt(label.ID, 10)
+}
+
+func (b *gitLabBackend) UpdateLabel(ctx context.Context, org, repo string, current, desired Label) error {
+	body := map[string]string{"color": "#" + desired.Color, "description": desired.Description}
+	if desired.Name != current.Name {
+		body["new_name"] = desired.Name
+	}
+	return b.client.Do(ctx, http.MethodPut, b.labelPath(org, repo, current), body, nil)
+}
+
+func (b *gitLabBackend) DeleteLabel(ctx context.Context, org, repo string, label Label) error {
+	return b.client.Do(ctx, http.MethodDelete, b.labelPath(org, repo, label), nil, nil)
+}
+
+// LabelInUse looks for one issue and then one merge request with the label.
+func (b *gitLabBackend) LabelInUse(ctx context.Context, org, repo string, label Label) (bool, error) {
+	query := "?state=all&per_page=1&labels=" + url.QueryEscape(label.Name)
+	for _, kind := range []string{"/issues", "/merge_requests"} {
+		used, err := b.client.Exists(ctx, b.projectPath(org, repo)+kind+query)
+		if err != nil || used {
+			return used, err
+		}
+	}
+	return false, nil
+}
+
+func (m glMilestone) milestone() Milestone {
+	state := StateOpen
+	if m.State == "closed" {
+		state = StateClosed
+	}
+	return Milestone{ID: m.ID, Title: m.Title, Description: m.Description, DueOn: dueDate(m.DueDate), State: state}
+}
+
+func (b *gitLabBackend) ListMilestones(ctx context.Context, org, repo string) ([]Milestone, error) {
+	items, err := restapi.ListAll[glMilestone](ctx, b.client, b.projectPath(org, repo)+"/milestones", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list milestones of %s: %w", repo, err)
+	}
+	out := make([]Milestone, 0, len(items))
+	for _, m := range items {
+		out = append(out, m.milestone())
+	}
+	return out, nil
+}
+
+func (b *gitLabBackend) milestonePath(org, repo string, m Milestone) string {
+	return b.projectPath(org, repo) + "/milestones/" + strconv.FormatInt(m.ID, 10)
+}
+
+// CreateMilestone creates the milestone and closes it when its state is
+// closed, since GitLab creates milestones active.
+func (b *gitLabBackend) CreateMilestone(ctx context.Context, org, repo string, milestone Milestone) error {
+	body := map[string]any{"title": milestone.Title, "description": milestone.Description}
+	if milestone.DueOn != "" {
+		body["due_date"] = milestone.DueOn
+	}
+	var created glMilestone
+	if err := b.client.Do(ctx, http.MethodPost, b.projectPath(org, repo)+"/milestones", body, &created); err != nil {
+		return err
+	}
+	if milestone.State != StateClosed {
+		return nil
+	}
+	return b.UpdateMilestone(ctx, org, repo, created.milestone(), Milestone{
+		Title: milestone.Title, Description: milestone.Description, State: StateClosed,
+	})
+}
+
+func (b *gitLabBackend) UpdateMilestone(ctx context.Context, org, repo string, current, desired Milestone) error {
+	body := map[string]any{"title": desired.Title, "description": desired.Description}
+	if desired.DueOn != "" {
+		body["due_date"] = desired.DueOn
+	}
+	switch {
+	case desired.State == StateClosed && current.State != StateClosed:
+		body["state_event"] = "close"
+	case desired.State == StateOpen && current.State == StateClosed:
+		body["state_event"] = "activate"
+	}
+	return b.client.Do(ctx, http.MethodPut, b.milestonePath(org, repo, current), body, nil)
+}
+
+func (b *gitLabBackend) DeleteMilestone(ctx context.Context, org, repo string, milestone Milestone) error {
+	return b.client.Do(ctx, http.MethodDelete, b.milestonePath(org, repo, milestone), nil, nil)
+}
+
+func (b *gitLabBackend) MilestoneInUse(ctx context.Context, org, repo string, milestone Milestone) (bool, error) {
+	for _, kind := range []string{"/issues", "/merge_requests"} {
+		used, err := b.client.Exists(ctx, b.milestonePath(org, repo, milestone)+kind+"?per_page=1")
+		if err != nil || used {
+			return used, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,329 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package labels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/testutil/mocks"
+)
+
+const testSpec = `
+prune_labels: true
+labels:
+  - name: bug
+    color: "#D73A4A"
+    description: Something isn't working
+  - name: feature
+    color: a2eeef
+  - name: docs
+    color: 0075ca
+renames:
+  labels:
+    enhancement: feature
+    documentation: docs
+milestones:
+  - title: v2.0
+    due_on: 2025-09-30
+  - title: v1.0
+    state: closed
+`
+
+func TestParseYAML(t *testing.T) {
+	spec, err := ParseYAML(strings.NewReader(testSpec))
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate())
+	assert.Equal(t, "d73a4a", spec.Labels[0].Color)
+	assert.Equal(t, "2025-09-30", spec.Milestones[0].DueOn)
+
+	_, err = ParseYAML(strings.NewReader("labels:\n  - name: a\n    colour: ffffff\n"))
+	assert.Error(t, err, "misspelled keys must be rejected")
+
+	for name, doc := range map[string]string{
+		"empty":          "prune_labels: true\n",
+		"duplicate":      "labels:\n  - {name: a, color: ffffff}\n  - {name: A, color: ffffff}\n",
+		"color":          "labels:\n  - {name: a, color: red}\n",
+		"due date":       "milestones:\n  - {title: v1, due_on: 30.09.2025}\n",
+		"state":          "milestones:\n  - {title: v1, state: active}\n",
+		"rename target":  "labels:\n  - {name: a, color: ffffff}\nrenames:\n  labels: {b: c}\n",
+		"renamed listed": "labels:\n  - {name: a, color: ffffff}\n  - {name: b, color: ffffff}\nrenames:\n  labels: {b: a}\n",
+	} {
+		spec, err := ParseYAML(strings.NewReader(doc))
+		require.NoError(t, err, name)
+		assert.Error(t, spec.Validate(), name)
+	}
+}
+
+// fakeBackend records calls against in-memory repositories.
+type fakeBackend struct {
+	mocks.CallLog
+	labels     map[string][]Label
+	milestones map[string][]Milestone
+	inUse      map[string]bool
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{
+		labels: map[string][]Label{
+			"api": {
+				{Name: "Bug", Color: "d73a4a", Description: "Something isn't working"},
+				{Name: "enhancement", Color: "a2eeef"},
+				{Name: "documentation", Color: "0075ca"},
+				{Name: "docs", Color: "0075ca"},
+				{Name: "wontfix", Color: "ffffff"},
+				{Name: "question", Color: "d876e3"},
+			},
+			"web": nil,
+		},
+		milestones: map[string][]Milestone{
+			"api": {
+				{ID: 1, Title: "v1.0", State: StateOpen},
+				{ID: 2, Title: "backlog", State: StateOpen},
+			},
+		},
+		inUse: map[string]bool{"api/documentation": true, "api/question": true},
+	}
+}
+
+func (f *fakeBackend) Provider() string { return "github" }
+
+func (f *fakeBackend) ListRepositories(context.Context, string) ([]string, error) {
+	return []string{"web", "api"}, nil
+}
+
+func (f *fakeBackend) ListLabels(_ context.Context, _, repo string) ([]Label, error) {
+	return f.labels[repo], nil
+}
+
+func (f *fakeBackend) CreateLabel(_ context.Context, _, repo string, label Label) error {
+	return f.Call("create-label %s %s", repo, label.Name)
+}
+
+func (f *fakeBackend) UpdateLabel(_ context.Context, _, repo string, current, desired Label) error {
+	return f.Call("update-label %s %s %s", repo, current.Name, desired.Name)
+}
+
+func (f *fakeBackend) DeleteLabel(_ context.Context, _, repo string, label Label) error {
+	return f.Call("delete-label %s %s", repo, label.Name)
+}
+
+func (f *fakeBackend) LabelInUse(_ context.Context, _, repo string, label Label) (bool, error) {
+	return f.inUse[repo+"/"+label.Name], nil
+}
+
+func (f *fakeBackend) ListMilestones(_ context.Context, _, repo string) ([]Milestone, error) {
+	return f.milestones[repo], nil
+}
+
+func (f *fakeBackend) CreateMilestone(_ context.Context, _, repo string, milestone Milestone) error {
+	return f.Call("create-milestone %s %s", repo, milestone.Title)
+}
+
+func (f *fakeBackend) UpdateMilestone(_ context.Context, _, repo string, current, desired Milestone) error {
+	return f.Call("update-milestone %s %d %s", repo, current.ID, desired.State)
+}
+
+func (f *fakeBackend) DeleteMilestone(_ context.Context, _, repo string, milestone Milestone) error {
+	return f.Call("delete-milestone %s %d", repo, milestone.ID)
+}
+
+func (f *fakeBackend) MilestoneInUse(_ context.Context, _, repo string, milestone Milestone) (bool, error) {
+	return f.inUse[repo+"/"+milestone.Title], nil
+}
+
+func TestSyncerDryRun(t *testing.T) {
+	spec, err := ParseYAML(strings.NewReader(testSpec))
+	require.NoError(t, err)
+	backend := newFakeBackend()
+
+	report, err := NewSyncer(backend, spec, Options{DryRun: true}).Run(context.Background(), "acme")
+	require.NoError(t, err)
+	assert.Empty(t, backend.Calls(), "a dry run must not change anything")
+	require.Len(t, report.Results, 2)
+
+	api := report.Results[0]
+	assert.Equal(t, "api", api.Repository)
+	assert.Equal(t, StatusDrift, api.Status)
+	var got []string
+	for _, c := range api.Changes {
+		got = append(got, c.String())
+	}
+	assert.Equal(t, []string{
+		`update label bug: name "Bug" → "bug"`,
+		"rename label enhancement → feature",
+		`create milestone v2.0: due_on "2025-09-30"`,
+		`update milestone v1.0: state "open" → "closed"`,
+		"keep label documentation (in use)",
+		"delete label wontfix",
+		"keep label question (in use)",
+	}, got, "deletions come last; backlog is kept without prune_milestones")
+
+	web := report.Results[1]
+	assert.Equal(t, StatusDrift, web.Status)
+	assert.Len(t, web.Changes, 5)
+	assert.Equal(t, `create label bug: color "d73a4a", description "Something isn't working"`, web.Changes[0].String())
+
+	var out bytes.Buffer
+	report.PrintPlan(&out)
+	assert.Contains(t, out.String(), `~ label "api/feature"`)
+	assert.Contains(t, out.String(), `- label "api/wontfix"`)
+	assert.Contains(t, out.String(), `+ milestone "web/v1.0"`)
+	assert.NotContains(t, out.String(), `"api/question"`, "protected deletions are not planned")
+	assert.Contains(t, out.String(), "! api: keep label question (in use)")
+	assert.Contains(t, out.String(), "Plan: 6 to create, 3 to update, 1 to delete.")
+	assert.Equal(t, 2, report.Protected())
+}
+
+func TestSyncerApply(t *testing.T) {
+	spec, err := ParseYAML(strings.NewReader(testSpec))
+	require.NoError(t, err)
+	spec.PruneMilestones = true
+	backend := newFakeBackend()
+	backend.FailOn = "create-label web feature"
+	recorder := &mocks.AuditRecorder{}
+
+	report, err := NewSyncer(backend, spec, Options{
+		Recorder:    recorder,
+		Actor:       "ci",
+		Concurrency: 1,
+		Now:         func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) },
+	}).Run(context.Background(), "acme")
+	require.NoError(t, err)
+
+	assert.Contains(t, backend.Calls(), "update-label api Bug bug")
+	assert.Contains(t, backend.Calls(), "update-label api enhancement feature")
+	assert.Contains(t, backend.Calls(), "update-milestone api 1 closed")
+	assert.Contains(t, backend.Calls(), "delete-label api wontfix")
+	assert.Contains(t, backend.Calls(), "delete-milestone api 2")
+	assert.NotContains(t, backend.Calls(), "delete-label api question", "labels in use are never deleted")
+	assert.NotContains(t, backend.Calls(), "delete-label api documentation")
+
+	counts := report.Counts()
+	assert.Equal(t, 1, counts[StatusApplied])
+	assert.Equal(t, 1, counts[StatusFailed], "a failed change fails its repository but not the others")
+
+	require.Len(t, recorder.Events(), 11)
+	var failures int
+	for _, e := range recorder.Events() {
+		assert.Equal(t, "ci", e.Actor)
+		assert.True(t, strings.HasPrefix(e.Action, "label."))
+		if e.Outcome == "failure" {
+			failures++
+			assert.Equal(t, "github:acme/web", e.Resource)
+		}
+	}
+	assert.Equal(t, 1, failures)
+}
+
+func TestSyncerInSync(t *testing.T) {
+	spec := &Spec{Labels: []Label{{Name: "wontfix", Color: "ffffff"}}, PruneLabels: true}
+	backend := newFakeBackend()
+
+	report, err := NewSyncer(backend, spec, Options{Repositories: []string{"a*"}}).Run(context.Background(), "acme")
+	require.NoError(t, err)
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, "api", report.Results[0].Repository)
+
+	backend.labels["api"] = []Label{{Name: "wontfix", Color: "ffffff"}, {Name: "question", Color: "d876e3"}}
+	report, err = NewSyncer(backend, spec, Options{Repositories: []string{"api"}}).Run(context.Background(), "acme")
+	require.NoError(t, err)
+	assert.Equal(t, StatusInSync, report.Results[0].Status, "a protected deletion alone is not drift")
+	assert.False(t, report.HasDrift())
+}
+
+func TestGitHubBackendRename(t *testing.T) {
+	var server *httptest.Server
+	var patched map[string]any
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch {
+		case r.URL.Path == "/repos/acme/api/labels" && r.URL.Query().Get("cursor") == "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/acme/api/labels?cursor=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"id":1,"name":"enhancement","color":"A2EEEF"}]`)
+		case r.URL.Path == "/repos/acme/api/labels":
+			fmt.Fprint(w, `[{"id":2,"name":"bug","color":"d73a4a"}]`)
+		case r.URL.Path == "/repos/acme/api/labels/enhancement" && r.Method == http.MethodPatch:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&patched))
+			fmt.Fprint(w, `{}`)
+		case r.URL.Path == "/repos/acme/api/issues":
+			assert.Equal(t, "enhancement", r.URL.Query().Get("labels"))
+			fmt.Fprint(w, `[{"number":7}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	backend := newGitHubBackend(server.URL, "token")
+	labels, err := backend.ListLabels(context.Background(), "acme", "api")
+	require.NoError(t, err)
+	require.Len(t, labels, 2)
+	assert.Equal(t, "a2eeef", labels[0].Color)
+
+	used, err := backend.LabelInUse(context.Background(), "acme", "api", labels[0])
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	require.NoError(t, backend.UpdateLabel(context.Background(), "acme", "api", labels[0], Label{Name: "feature", Color: "a2eeef"}))
+	assert.Equal(t, "feature", patched["new_name"])
+}
+
+func TestGitLabBackendMilestones(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("PRIVATE-TOKEN"))
+		var body map[string]any
+		if r.Method != http.MethodGet {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			calls = append(calls, fmt.Sprintf("%s %s %v", r.Method, r.URL.EscapedPath(), body["state_event"]))
+		}
+		switch {
+		case r.URL.EscapedPath() == "/projects/acme%2Fapi/milestones" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `[{"id":3,"title":"v1.0","state":"active","due_date":"2025-06-30"}]`)
+		case r.URL.EscapedPath() == "/projects/acme%2Fapi/milestones":
+			fmt.Fprint(w, `{"id":4,"title":"v0.9","state":"active"}`)
+		case strings.HasSuffix(r.URL.Path, "/milestones/3/issues"):
+			fmt.Fprint(w, `[]`)
+		case strings.HasSuffix(r.URL.Path, "/milestones/3/merge_requests"):
+			fmt.Fprint(w, `[{"iid":1}]`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	backend, err := NewBackend("gitlab", server.URL, "token")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	milestones, err := backend.ListMilestones(ctx, "acme", "api")
+	require.NoError(t, err)
+	assert.Equal(t, []Milestone{{ID: 3, Title: "v1.0", DueOn: "2025-06-30", State: StateOpen}}, milestones)
+
+	used, err := backend.MilestoneInUse(ctx, "acme", "api", milestones[0])
+	require.NoError(t, err)
+	assert.True(t, used, "merge requests count as use")
+
+	require.NoError(t, backend.CreateMilestone(ctx, "acme", "api", Milestone{Title: "v0.9", State: StateClosed}))
+	assert.Equal(t, []string{
+		"POST /projects/acme%2Fapi/milestones <nil>",
+		"PUT /projects/acme%2Fapi/milestones/4 close",
+	}, calls, "GitLab creates milestones active, so a closed one is closed afterwards")
+}
+
+func TestNewBackendUnsupported(t *testing.T) {
+	_, err := NewBackend("bitbucket", "", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package labels
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// Report is the outcome of a sync run.
+type Report struct {
+	Provider    string    `json:"provider"`
+	Org         string    `json:"org"`
+	DryRun      bool      `json:"dryRun"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	Results     []Result  `json:"results"`
+}
+
+// Result is the outcome for one repository.
+type Result struct {
+	Repository string   `json:"repository"`
+	Status     string   `json:"status"`
+	Changes    []Change `json:"changes,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// Change is one label or milestone change. Target is the label name or
+// milestone title after the change. A protected change is a deletion that
+// is skipped because the label or milestone is still in use; Reason says
+// why.
+type Change struct {
+	Action     string                     `json:"action"`
+	Target     string                     `json:"target"`
+	Attributes []provider.AttributeChange `json:"attributes,omitempty"`
+	Protected  bool                       `json:"protected,omitempty"`
+	Reason     string                     `json:"reason,omitempty"`
+	Error      string                     `json:"error,omitempty"`
+
+	currentLabel     Label
+	desiredLabel     Label
+	currentMilestone Milestone
+	desiredMilestone Milestone
+}
+
+// String describes the change in one line.
+func (c Change) String() string {
+	var s string
+	switch c.Action {
+	case ActionCreateLabel:
+		s = "create label " + c.Target
+	case ActionUpdateLabel:
+		s = "update label " + c.Target
+	case ActionRenameLabel:
+		s = fmt.Sprintf("rename label %s → %s", c.currentLabel.Name, c.Target)
+	case ActionDeleteLabel:
+		s = "delete label " + c.Target
+	case ActionCreateMilestone:
+		s = "create milestone " + c.Target
+	case ActionUpdateMilestone:
+		s = "update milestone " + c.Target
+	case ActionRenameMilestone:
+		s = fmt.Sprintf("rename milestone %s → %s", c.currentMilestone.Title, c.Target)
+	case ActionDeleteMilestone:
+		s = "delete milestone " + c.Target
+	default:
+		s = c.Action + " " + c.Target
+	}
+	if c.Protected {
+		return fmt.Sprintf("keep %s (%s)", strings.TrimPrefix(s, "delete "), c.Reason)
+	}
+
+	var attrs []string
+	for _, a := range c.Attributes {
+		// The name is already part of the description except for case-only
+		// updates.
+		if (a.Name == "name" || a.Name == "title") && c.Action != ActionUpdateLabel && c.Action != ActionUpdateMilestone {
+			continue
+		}
+		if a.Before != nil {
+			attrs = append(attrs, fmt.Sprintf("%s %q → %q", a.Name, a.Before, a.After))
+		} else {
+			attrs = append(attrs, fmt.Sprintf("%s %q", a.Name, a.After))
+		}
+	}
+	if len(attrs) > 0 {
+		s += ": " + strings.Join(attrs, ", ")
+	}
+	return s
+}
+
+// Counts returns the number of results per status.
+func (r *Report) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, res := range r.Results {
+		counts[res.Status]++
+	}
+	return counts
+}
+
+// HasDrift reports whether any repository differed from the spec. Protected
+// deletions do not count.
+func (r *Report) HasDrift() bool {
+	for _, res := range r.Results {
+		for _, c := range res.Changes {
+			if !c.Protected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Protected returns the number of deletions skipped because the label or
+// milestone is in use.
+func (r *Report) Protected() int {
+	n := 0
+	for _, res := range r.Results {
+		for _, c := range res.Changes {
+			if c.Protected {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// WriteJSON writes the report to path.
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// PrintDiff writes each repository that changed, failed or kept a label or
+// milestone in use, with its changes.
+func (r *Report) PrintDiff(w io.Writer) {
+	for _, res := range r.Results {
+		if len(res.Changes) == 0 && res.Error == "" {
+			continue
+		}
+		fmt.Fprintf(w, "%s %s (%s)\n", statusIcon(res.Status), res.Repository, res.Status)
+		for _, c := range res.Changes {
+			switch {
+			case c.Error != "":
+				fmt.Fprintf(w, "    ✗ %s: %s\n", c, c.Error)
+			case c.Protected:
+				fmt.Fprintf(w, "    ! %s\n", c)
+			default:
+				fmt.Fprintf(w, "    %s\n", c)
+			}
+		}
+		if res.Error != "" {
+			fmt.Fprintf(w, "    %s\n", res.Error)
+		}
+	}
+}
+
+// Plan returns the changes of a dry run as planned label and milestone
+// changes. Protected deletions are left out.
+func (r *Report) Plan() *provider.Plan {
+	plan := provider.NewPlan()
+	for _, res := range r.Results {
+		if res.Status != StatusDrift {
+			continue
+		}
+		for _, c := range res.Changes {
+			if !c.Protected {
+				plan.Record(c.planned(res.Repository))
+			}
+		}
+	}
+	return plan
+}
+
+func (c Change) planned(repo string) provider.PlannedChange {
+	pc := provider.PlannedChange{Resource: provider.ResourceLabel, Target: repo + "/" + c.Target, Attributes: c.Attributes}
+	switch c.Action {
+	case ActionCreateMilestone, ActionUpdateMilestone, ActionRenameMilestone, ActionDeleteMilestone:
+		pc.Resource = provider.ResourceMilestone
+	}
+	switch c.Action {
+	case ActionCreateLabel, ActionCreateMilestone:
+		pc.Action = provider.ChangeCreate
+	case ActionDeleteLabel, ActionDeleteMilestone:
+		pc.Action = provider.ChangeDelete
+	default:
+		pc.Action = provider.ChangeUpdate
+	}
+	return pc
+}
+
+// PrintPlan writes the changes of a dry run as a plan, followed by the
+// deletions that are protected and the repositories that could not be
+// checked.
+func (r *Report) PrintPlan(w io.Writer) {
+	r.Plan().Render(w)
+	for _, res := range r.Results {
+		for _, c := range res.Changes {
+			if c.Protected {
+				fmt.Fprintf(w, "! %s: %s\n", res.Repository, c)
+			}
+		}
+		if res.Status == StatusFailed {
+			fmt.Fprintf(w, "%s %s (%s)\n    %s\n", statusIcon(res.Status), res.Repository, res.Status, res.Error)
+		}
+	}
+}
+
+// PrintSummary writes the per-status totals.
+func (r *Report) PrintSummary(w io.Writer) {
+	title := "Label sync summary"
+	if r.DryRun {
+		title = "Label sync plan (dry run)"
+	}
+	fmt.Fprintf(w, "\n📋 %s: %s:%s\n", title, r.Provider, r.Org)
+
+	counts := r.Counts()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tREPOSITORIES")
+	for _, s := range []string{StatusInSync, StatusDrift, StatusApplied, StatusFailed} {
+		if counts[s] > 0 {
+			fmt.Fprintf(tw, "%s\t%d\n", s, counts[s])
+		}
+	}
+	_ = tw.Flush()
+	if n := r.Protected(); n > 0 {
+		fmt.Fprintf(w, "🛡️  %d label(s) or milestone(s) kept because they are in use\n", n)
+	}
+}
+
+func statusIcon(status string) string {
+	switch status {
+	case StatusDrift:
+		return "~"
+	case StatusApplied:
+		return "✓"
+	case StatusFailed:
+		return "✗"
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package labels enforces a canonical set of issue labels and milestones
+// across the repositories of a GitHub or Gitea organization or a GitLab
+// group.
+package labels
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Milestone states.
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+)
+
+// dateLayout is the format of milestone due dates.
+const dateLayout = "2006-01-02"
+
+// Spec is the canonical label and milestone set.
+//
+//	prune_labels: true
+//	labels:
+//	  - name: bug
+//	    color: d73a4a
+//	    description: Something isn't working
+//	  - name: feature
+//	    color: "#a2eeef"
+//	renames:
+//	  labels:
+//	    enhancement: feature
+//	milestones:
+//	  - title: v2.0
+//	    due_on: 2025-09-30
+//
+// A label or milestone named in renames is renamed when the repository
+// does not have the new one yet, so issues keep it; otherwise the old one
+// is deleted. Labels and milestones missing from the spec are deleted only
+// when PruneLabels and PruneMilestones are set. Nothing that is still used
+// by an issue or pull request is ever deleted.
+type Spec struct {
+	PruneLabels     bool        `yaml:"prune_labels" json:"pruneLabels,omitempty"`
+	PruneMilestones bool        `yaml:"prune_milestones" json:"pruneMilestones,omitempty"`
+	Labels          []Label     `yaml:"labels" json:"labels,omitempty"`
+	Milestones      []Milestone `yaml:"milestones" json:"milestones,omitempty"`
+	Renames         Renames     `yaml:"renames" json:"renames,omitempty"`
+}
+
+// Renames maps old names to the names of the spec.
+type Renames struct {
+	Labels     map[string]string `yaml:"labels" json:"labels,omitempty"`
+	Milestones map[string]string `yaml:"milestones" json:"milestones,omitempty"`
+}
+
+// Label is an issue label.
+type Label struct {
+	ID          int64  `yaml:"-" json:"-"`
+	Name        string `yaml:"name" json:"name"`
+	Color       string `yaml:"color" json:"color"`
+	Description string `yaml:"description" json:"description,omitempty"`
+}
+
+// Milestone is a repository milestone. DueOn is a date (YYYY-MM-DD) and
+// State is StateOpen or StateClosed; empty values in the spec leave the
+// repository's value alone.
+type Milestone struct {
+	ID          int64  `yaml:"-" json:"-"`
+	Title       string `yaml:"title" json:"title"`
+	Description string `yaml:"description" json:"description,omitempty"`
+	DueOn       string `yaml:"due_on" json:"dueOn,omitempty"`
+	State       string `yaml:"state" json:"state,omitempty"`
+}
+
+// LoadSpec reads a YAML spec.
+func LoadSpec(path string) (*Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read label spec %s: %w", path, err)
+	}
+	defer f.Close()
+
+	spec, err := ParseYAML(f)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// ParseYAML parses a YAML spec. Unknown keys are rejected so that a
+// misspelled setting is not silently ignored.
+func ParseYAML(r io.Reader) (*Spec, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	var spec Spec
+	if err := dec.Decode(&spec); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse label spec: %w", err)
+	}
+	for i := range spec.Labels {
+		spec.Labels[i].Color = normalizeColor(spec.Labels[i].Color)
+	}
+	return &spec, nil
+}
+
+var colorPattern = regexp.MustCompile(`^[0-9a-f]{6}$`)
+
+// Validate checks the spec for duplicate names, invalid colors, dates and
+// states, and renames that do not lead to a name of the spec.
+func (s *Spec) Validate() error {
+	if len(s.Labels) == 0 && len(s.Milestones) == 0 {
+		return errors.New("invalid label spec: no labels or milestones")
+	}
+
+	labels := make(map[string]bool)
+	for i, l := range s.Labels {
+		key := fold(l.Name)
+		if key == "" {
+			return fmt.Errorf("invalid label spec: labels[%d] has no name", i)
+		}
+		if labels[key] {
+			return fmt.Errorf("invalid label spec: label %s listed more than once", l.Name)
+		}
+		labels[key] = true
+		if !colorPattern.MatchString(l.Color) {
+			return fmt.Errorf("invalid label spec: label %s has invalid color %q (use a hex color such as d73a4a)", l.Name, l.Color)
+		}
+	}
+
+	milestones := make(map[string]bool)
+	for i, m := range s.Milestones {
+		key := fold(m.Title)
+		if key == "" {
+			return fmt.Errorf("invalid label spec: milestones[%d] has no title", i)
+		}
+		if milestones[key] {
+			return fmt.Errorf("invalid label spec: milestone %s listed more than once", m.Title)
+		}
+		milestones[key] = true
+		if m.DueOn != "" {
+			if _, err := time.Parse(dateLayout, m.DueOn); err != nil {
+				return fmt.Errorf("invalid label spec: milestone %s has invalid due_on %q (use YYYY-MM-DD)", m.Title, m.DueOn)
+			}
+		}
+		switch m.State {
+		case "", StateOpen, StateClosed:
+		default:
+			return fmt.Errorf("invalid label spec: milestone %s has unknown state %q (open, closed)", m.Title, m.State)
+		}
+	}
+
+	if err := validateRenames("label", s.Renames.Labels, labels); err != nil {
+		return err
+	}
+	return validateRenames("milestone", s.Renames.Milestones, milestones)
+}
+
+func validateRenames(kind string, renames map[string]string, names map[string]bool) error {
+	for _, old := range sortedKeys(renames) {
+		switch {
+		case !names[fold(renames[old])]:
+			return fmt.Errorf("invalid label spec: %s rename %s → %s does not lead to a %s of the spec", kind, old, renames[old], kind)
+		case names[fold(old)]:
+			return fmt.Errorf("invalid label spec: %s %s is renamed but also listed", kind, old)
+		}
+	}
+	return nil
+}
+
+// renameSources returns the old names renamed to each name, keyed by the
+// folded new name.
+func renameSources(renames map[string]string) map[string][]string {
+	sources := make(map[string][]string)
+	for _, old := range sortedKeys(renames) {
+		key := fold(renames[old])
+		sources[key] = append(sources[key], old)
+	}
+	return sources
+}
+
+// fold returns the key names are compared by: the platforms treat label
+// and milestone names case-insensitively.
+func fold(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func normalizeColor(color string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(color), "#"))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
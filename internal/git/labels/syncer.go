@@ -0,0 +1,453 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package labels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// Result statuses.
+const (
+	StatusInSync  = "in_sync"
+	StatusDrift   = "drift"
+	StatusApplied = "applied"
+	StatusFailed  = "failed"
+)
+
+// Change actions, also used as audit event actions with a "label." prefix.
+const (
+	ActionCreateLabel     = "create_label"
+	ActionUpdateLabel     = "update_label"
+	ActionRenameLabel     = "rename_label"
+	ActionDeleteLabel     = "delete_label"
+	ActionCreateMilestone = "create_milestone"
+	ActionUpdateMilestone = "update_milestone"
+	ActionRenameMilestone = "rename_milestone"
+	ActionDeleteMilestone = "delete_milestone"
+)
+
+// reasonInUse explains a protected deletion.
+const reasonInUse = "in use"
+
+// DefaultAuditLogPath returns the local label sync audit log.
+func DefaultAuditLogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "gzh-manager", "labels", "audit.jsonl")
+}
+
+// Options control a sync run.
+type Options struct {
+	// DryRun only reports the changes; nothing is modified.
+	DryRun bool
+	// Repositories narrows the run to repositories whose name matches any
+	// of these patterns.
+	Repositories []string
+	// Concurrency is the number of repositories processed at once.
+	Concurrency int
+	// Recorder receives an audit event per applied or failed change; nil
+	// discards them.
+	Recorder audit.Recorder
+	// Actor is recorded as the audit event actor.
+	Actor string
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+}
+
+// Syncer reconciles the labels and milestones of an organization's
+// repositories with a spec.
+type Syncer struct {
+	backend Backend
+	spec    *Spec
+	opts    Options
+
+	auditMu  sync.Mutex
+	auditErr error
+}
+
+// NewSyncer creates a syncer.
+func NewSyncer(backend Backend, spec *Spec, opts Options) *Syncer {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Syncer{backend: backend, spec: spec, opts: opts}
+}
+
+// Run reconciles every selected repository of org. Failures on individual
+// repositories are recorded in the report rather than aborting the run; the
+// returned error reports a cancelled context or audit events that could
+// not be recorded.
+func (s *Syncer) Run(ctx context.Context, org string) (*Report, error) {
+	repos, err := s.backend.ListRepositories(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Provider:    s.backend.Provider(),
+		Org:         org,
+		DryRun:      s.opts.DryRun,
+		GeneratedAt: s.opts.Now(),
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		work = make(chan string)
+	)
+	for i := 0; i < s.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range work {
+				result := s.syncRepository(ctx, org, repo)
+				mu.Lock()
+				report.Results = append(report.Results, result)
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, repo := range repos {
+		if ctx.Err() != nil {
+			break
+		}
+		if s.selected(repo) {
+			work <- repo
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	sort.Slice(report.Results, func(i, j int) bool {
+		return report.Results[i].Repository < report.Results[j].Repository
+	})
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	return report, s.auditErr
+}
+
+func (s *Syncer) selected(repo string) bool {
+	if len(s.opts.Repositories) == 0 {
+		return true
+	}
+	for _, pattern := range s.opts.Repositories {
+		if ok, _ := path.Match(pattern, repo); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// syncRepository plans and, unless DryRun is set, applies the changes for
+// one repository. Changes are applied in plan order and a failed change
+// does not stop the ones after it.
+func (s *Syncer) syncRepository(ctx context.Context, org, repo string) Result {
+	result := Result{Repository: repo}
+	fail := func(err error) Result {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	labels, err := s.backend.ListLabels(ctx, org, repo)
+	if err != nil {
+		return fail(err)
+	}
+	milestones, err := s.backend.ListMilestones(ctx, org, repo)
+	if err != nil {
+		return fail(err)
+	}
+	planned, err := s.plan(ctx, org, repo, labels, milestones)
+	if err != nil {
+		return fail(err)
+	}
+
+	pending := 0
+	for _, c := range planned {
+		if !c.Protected {
+			pending++
+		}
+	}
+	if s.opts.DryRun || pending == 0 {
+		result.Changes = planned
+		result.Status = StatusInSync
+		if pending > 0 {
+			result.Status = StatusDrift
+		}
+		return result
+	}
+
+	var failed int
+	for _, c := range planned {
+		if !c.Protected {
+			err := s.apply(ctx, org, repo, c)
+			s.record(org, repo, c, err)
+			if err != nil {
+				c.Error = err.Error()
+				failed++
+			}
+		}
+		result.Changes = append(result.Changes, c)
+	}
+	if failed > 0 {
+		result.Status = StatusFailed
+		result.Error = fmt.Sprintf("%d of %d change(s) failed", failed, pending)
+	} else {
+		result.Status = StatusApplied
+	}
+	return result
+}
+
+// plan lists the changes that bring a repository in line with the spec:
+// creations, updates and renames first, then deletions, so that nothing is
+// missing longer than necessary when a run is interrupted. Deletions of
+// labels and milestones still in use are kept as protected changes.
+func (s *Syncer) plan(ctx context.Context, org, repo string, labels []Label, milestones []Milestone) ([]Change, error) {
+	changes, removals := s.planLabels(labels)
+	mChanges, mRemovals := s.planMilestones(milestones)
+	changes = append(changes, mChanges...)
+	removals = append(removals, mRemovals...)
+
+	for i := range removals {
+		c := &removals[i]
+		var (
+			used bool
+			err  error
+		)
+		if c.Action == ActionDeleteLabel {
+			used, err = s.backend.LabelInUse(ctx, org, repo, c.currentLabel)
+		} else {
+			used, err = s.backend.MilestoneInUse(ctx, org, repo, c.currentMilestone)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check whether %s is in use: %w", c.Target, err)
+		}
+		if used {
+			c.Protected, c.Reason = true, reasonInUse
+		}
+	}
+	return append(changes, removals...), nil
+}
+
+func (s *Syncer) planLabels(existing []Label) (changes, removals []Change) {
+	byName := make(map[string]Label, len(existing))
+	for _, l := range existing {
+		byName[fold(l.Name)] = l
+	}
+	handled := make(map[string]bool)
+	sources := renameSources(s.spec.Renames.Labels)
+
+	for _, desired := range s.spec.Labels {
+		key := fold(desired.Name)
+		if cur, ok := byName[key]; ok {
+			handled[key] = true
+			if attrs := labelDiff(cur, desired); len(attrs) > 0 {
+				changes = append(changes, Change{Action: ActionUpdateLabel, Target: desired.Name, Attributes: attrs, currentLabel: cur, desiredLabel: desired})
+			}
+			continue
+		}
+		renamed := false
+		for _, old := range sources[key] {
+			cur, ok := byName[fold(old)]
+			if !ok || handled[fold(old)] {
+				continue
+			}
+			handled[fold(old)] = true
+			changes = append(changes, Change{Action: ActionRenameLabel, Target: desired.Name, Attributes: labelDiff(cur, desired), currentLabel: cur, desiredLabel: desired})
+			renamed = true
+			break
+		}
+		if !renamed {
+			changes = append(changes, Change{Action: ActionCreateLabel, Target: desired.Name, Attributes: labelDiff(Label{}, desired), desiredLabel: desired})
+		}
+	}
+
+	for _, l := range existing {
+		key := fold(l.Name)
+		if handled[key] {
+			continue
+		}
+		// A renamed label left over because its new name already exists is
+		// always removed; any other label only when pruning.
+		if s.renamedLabel(key) || s.spec.PruneLabels {
+			handled[key] = true
+			removals = append(removals, Change{Action: ActionDeleteLabel, Target: l.Name, currentLabel: l})
+		}
+	}
+	return changes, removals
+}
+
+func (s *Syncer) renamedLabel(key string) bool {
+	for old := range s.spec.Renames.Labels {
+		if fold(old) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Syncer) planMilestones(existing []Milestone) (changes, removals []Change) {
+	byTitle := make(map[string]Milestone, len(existing))
+	for _, m := range existing {
+		byTitle[fold(m.Title)] = m
+	}
+	handled := make(map[string]bool)
+	sources := renameSources(s.spec.Renames.Milestones)
+
+	for _, desired := range s.spec.Milestones {
+		key := fold(desired.Title)
+		if cur, ok := byTitle[key]; ok {
+			handled[key] = true
+			if attrs := milestoneDiff(cur, desired); len(attrs) > 0 {
+				changes = append(changes, Change{Action: ActionUpdateMilestone, Target: desired.Title, Attributes: attrs, currentMilestone: cur, desiredMilestone: desired})
+			}
+			continue
+		}
+		renamed := false
+		for _, old := range sources[key] {
+			cur, ok := byTitle[fold(old)]
+			if !ok || handled[fold(old)] {
+				continue
+			}
+			handled[fold(old)] = true
+			changes = append(changes, Change{Action: ActionRenameMilestone, Target: desired.Title, Attributes: milestoneDiff(cur, desired), currentMilestone: cur, desiredMilestone: desired})
+			renamed = true
+			break
+		}
+		if !renamed {
+			changes = append(changes, Change{Action: ActionCreateMilestone, Target: desired.Title, Attributes: milestoneDiff(Milestone{}, desired), desiredMilestone: desired})
+		}
+	}
+
+	for _, m := range existing {
+		key := fold(m.Title)
+		if handled[key] {
+			continue
+		}
+		if s.renamedMilestone(key) || s.spec.PruneMilestones {
+			handled[key] = true
+			removals = append(removals, Change{Action: ActionDeleteMilestone, Target: m.Title, currentMilestone: m})
+		}
+	}
+	return changes, removals
+}
+
+func (s *Syncer) renamedMilestone(key string) bool {
+	for old := range s.spec.Renames.Milestones {
+		if fold(old) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// labelDiff lists the attributes of desired that differ from cur. A name
+// that differs only in case is updated too.
+func labelDiff(cur, desired Label) []provider.AttributeChange {
+	var attrs []provider.AttributeChange
+	add := func(name, before, after string) {
+		if before == after {
+			return
+		}
+		change := provider.AttributeChange{Name: name, After: after}
+		if before != "" {
+			change.Before = before
+		}
+		attrs = append(attrs, change)
+	}
+	add("name", cur.Name, desired.Name)
+	add("color", cur.Color, desired.Color)
+	add("description", cur.Description, desired.Description)
+	return attrs
+}
+
+// milestoneDiff lists the attributes of desired that differ from cur. An
+// empty due date or state in the spec leaves the repository's value alone.
+func milestoneDiff(cur, desired Milestone) []provider.AttributeChange {
+	var attrs []provider.AttributeChange
+	add := func(name, before, after string) {
+		if before == after {
+			return
+		}
+		change := provider.AttributeChange{Name: name, After: after}
+		if before != "" {
+			change.Before = before
+		}
+		attrs = append(attrs, change)
+	}
+	add("title", cur.Title, desired.Title)
+	add("description", cur.Description, desired.Description)
+	if desired.DueOn != "" {
+		add("due_on", cur.DueOn, desired.DueOn)
+	}
+	if desired.State != "" {
+		add("state", cur.State, desired.State)
+	}
+	return attrs
+}
+
+func (s *Syncer) apply(ctx context.Context, org, repo string, c Change) error {
+	switch c.Action {
+	case ActionCreateLabel:
+		return s.backend.CreateLabel(ctx, org, repo, c.desiredLabel)
+	case ActionUpdateLabel, ActionRenameLabel:
+		return s.backend.UpdateLabel(ctx, org, repo, c.currentLabel, c.desiredLabel)
+	case ActionDeleteLabel:
+		return s.backend.DeleteLabel(ctx, org, repo, c.currentLabel)
+	case ActionCreateMilestone:
+		return s.backend.CreateMilestone(ctx, org, repo, c.desiredMilestone)
+	case ActionUpdateMilestone, ActionRenameMilestone:
+		return s.backend.UpdateMilestone(ctx, org, repo, c.currentMilestone, c.desiredMilestone)
+	case ActionDeleteMilestone:
+		return s.backend.DeleteMilestone(ctx, org, repo, c.currentMilestone)
+	default:
+		return fmt.Errorf("unknown change action %q", c.Action)
+	}
+}
+
+func (s *Syncer) record(org, repo string, c Change, err error) {
+	if s.opts.Recorder == nil {
+		return
+	}
+	event := audit.Event{
+		ID:        audit.NewEventID(),
+		Timestamp: s.opts.Now().UTC(),
+		Severity:  audit.SeverityInfo,
+		Action:    "label." + c.Action,
+		Category:  "labels",
+		Actor:     s.opts.Actor,
+		Source:    "gz git labels sync",
+		Resource:  s.backend.Provider() + ":" + org + "/" + repo,
+		Outcome:   "success",
+		Message:   c.String(),
+		Metadata: map[string]any{
+			"target":     c.Target,
+			"attributes": c.Attributes,
+		},
+	}
+	if err != nil {
+		event.Severity = audit.SeverityError
+		event.Outcome = "failure"
+		event.ErrMessage = err.Error()
+	}
+	if err := s.opts.Recorder.Record(event); err != nil {
+		s.auditMu.Lock()
+		s.auditErr = errors.Join(s.auditErr, err)
+		s.auditMu.Unlock()
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package lfs
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Endpoint returns the LFS API URL for a repository cloned from remoteURL.
+// An lfs.url in the repository's .lfsconfig takes precedence. SSH remotes
+// are mapped to the HTTPS endpoint of the same host, which every major
+// provider serves.
+func Endpoint(repoPath, remoteURL string) (string, error) {
+	if repoPath != "" {
+		cmd := exec.Command("git", "config", "-f", filepath.Join(repoPath, ".lfsconfig"), "--get", "lfs.url")
+		if out, err := cmd.Output(); err == nil {
+			if configured := strings.TrimSpace(string(out)); configured != "" {
+				return strings.TrimSuffix(configured, "/"), nil
+			}
+		}
+	}
+
+	host, path, err := splitRemote(remoteURL)
+	if err != nil {
+		return "", err
+	}
+	path = strings.Trim(path, "/")
+	if !strings.HasSuffix(path, ".git") {
+		path += ".git"
+	}
+	return "https://" + host + "/" + path + "/info/lfs", nil
+}
+
+// splitRemote returns the host and repository path of an HTTPS, ssh:// or
+// scp-style remote. Credentials and SSH ports are dropped.
+func splitRemote(remote string) (string, string, error) {
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid remote URL: %w", err)
+		}
+		host := u.Host
+		if u.Scheme == "ssh" || u.Scheme == "git" {
+			host = u.Hostname()
+		}
+		if host == "" {
+			return "", "", fmt.Errorf("remote URL %q has no host", remote)
+		}
+		return host, u.Path, nil
+	}
+
+	// git@host:org/repo.git
+	hostPart, path, ok := strings.Cut(remote, ":")
+	if !ok || path == "" {
+		return "", "", fmt.Errorf("unsupported remote URL %q", remote)
+	}
+	if at := strings.LastIndex(hostPart, "@"); at >= 0 {
+		hostPart = hostPart[at+1:]
+	}
+	return hostPart, path, nil
+}
@@ -0,0 +1,411 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package lfs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultBatchSize is the number of objects requested per batch call.
+	DefaultBatchSize = 100
+	// DefaultMaxRetries is the number of retries after a rate limited or
+	// unavailable response.
+	DefaultMaxRetries = 3
+
+	mediaType = "application/vnd.git-lfs+json"
+	chunkSize = 32 << 10
+)
+
+// ErrGitLFSNotInstalled is returned by Checkout when git-lfs is missing.
+var ErrGitLFSNotInstalled = errors.New("git-lfs is not installed")
+
+// Progress is reported after every completed object.
+type Progress struct {
+	Objects      int   `json:"objects"`
+	TotalObjects int   `json:"totalObjects"`
+	Bytes        int64 `json:"bytes"`
+	TotalBytes   int64 `json:"totalBytes"`
+}
+
+// Stats summarizes a Fetch.
+type Stats struct {
+	// Objects and Bytes count every object requested.
+	Objects int   `json:"objects"`
+	Bytes   int64 `json:"bytes"`
+	// Downloaded objects were transferred in this run; Resumed of them
+	// continued an earlier partial download.
+	Downloaded      int   `json:"downloaded"`
+	DownloadedBytes int64 `json:"downloadedBytes"`
+	Resumed         int   `json:"resumed"`
+	// Cached objects were already in the local object store.
+	Cached int `json:"cached"`
+	Failed int `json:"failed"`
+}
+
+// Fetcher downloads LFS objects into a repository's local object store.
+type Fetcher struct {
+	// Endpoint is the LFS API URL, see Endpoint.
+	Endpoint string
+	// Username and Password authenticate batch requests with HTTP basic
+	// authentication; providers accept an access token as the password.
+	Username string
+	Password string
+	// HTTPClient defaults to a client without a total timeout, since
+	// objects may be large.
+	HTTPClient *http.Client
+	// BatchSize defaults to DefaultBatchSize.
+	BatchSize int
+	// MaxRetries defaults to DefaultMaxRetries; negative disables retries.
+	MaxRetries int
+	// Limiter caps the download rate; it may be shared between fetchers.
+	Limiter *RateLimiter
+	// OnProgress is called after each object.
+	OnProgress func(Progress)
+}
+
+type batchObject struct {
+	OID     string `json:"oid"`
+	Size    int64  `json:"size"`
+	Actions struct {
+		Download *struct {
+			Href   string            `json:"href"`
+			Header map[string]string `json:"header"`
+		} `json:"download"`
+	} `json:"actions"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Fetch downloads the objects missing from gitDir/lfs/objects. Objects that
+// were partially downloaded by an earlier run continue where they stopped.
+// Failed objects do not stop the others; the returned error lists how many
+// failed.
+func (f *Fetcher) Fetch(ctx context.Context, gitDir string, objects []Pointer) (Stats, error) {
+	stats := Stats{Objects: len(objects)}
+	progress := Progress{TotalObjects: len(objects)}
+	for _, o := range objects {
+		stats.Bytes += o.Size
+	}
+	progress.TotalBytes = stats.Bytes
+
+	report := func(o Pointer) {
+		progress.Objects++
+		progress.Bytes += o.Size
+		if f.OnProgress != nil {
+			f.OnProgress(progress)
+		}
+	}
+
+	var missing []Pointer
+	for _, o := range objects {
+		if info, err := os.Stat(objectPath(gitDir, o.OID)); err == nil && info.Size() == o.Size {
+			stats.Cached++
+			report(o)
+			continue
+		}
+		missing = append(missing, o)
+	}
+
+	batchSize := f.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	var errs []error
+	for start := 0; start < len(missing); start += batchSize {
+		batch := missing[start:min(start+batchSize, len(missing))]
+		actions, err := f.batch(ctx, batch)
+		if err != nil {
+			return stats, err
+		}
+		for _, obj := range actions {
+			pointer := Pointer{OID: obj.OID, Size: obj.Size}
+			err := f.objectError(obj)
+			if err == nil {
+				var resumed bool
+				resumed, err = f.download(ctx, gitDir, obj)
+				if err == nil {
+					stats.Downloaded++
+					stats.DownloadedBytes += obj.Size
+					if resumed {
+						stats.Resumed++
+					}
+				}
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					return stats, ctx.Err()
+				}
+				stats.Failed++
+				errs = append(errs, fmt.Errorf("%s: %w", obj.OID, err))
+			}
+			report(pointer)
+		}
+	}
+
+	if len(errs) > 0 {
+		return stats, fmt.Errorf("%d of %d LFS objects failed: %w", len(errs), len(objects), errors.Join(errs...))
+	}
+	return stats, nil
+}
+
+func (f *Fetcher) objectError(obj batchObject) error {
+	if obj.Error != nil {
+		return fmt.Errorf("%s (%d)", obj.Error.Message, obj.Error.Code)
+	}
+	if obj.Actions.Download == nil {
+		return errors.New("server returned no download action")
+	}
+	return nil
+}
+
+// batch requests download actions for objects.
+func (f *Fetcher) batch(ctx context.Context, objects []Pointer) ([]batchObject, error) {
+	body, err := json.Marshal(map[string]any{
+		"operation": "download",
+		"transfers": []string{"basic"},
+		"objects":   objects,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.Endpoint+"/objects/batch", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", mediaType)
+		req.Header.Set("Content-Type", mediaType)
+		if f.Username != "" || f.Password != "" {
+			req.SetBasicAuth(f.Username, f.Password)
+		}
+		return req, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("LFS batch request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return nil, fmt.Errorf("LFS batch request failed: %s: %s", resp.Status, apiErr.Message)
+	}
+
+	var out struct {
+		Objects []batchObject `json:"objects"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("invalid LFS batch response: %w", err)
+	}
+	return out.Objects, nil
+}
+
+// download transfers one object, resuming a partial download when the
+// server honors range requests, and moves it into the object store once its
+// hash matches.
+func (f *Fetcher) download(ctx context.Context, gitDir string, obj batchObject) (bool, error) {
+	partial := filepath.Join(gitDir, "lfs", "incomplete", obj.OID)
+	if err := os.MkdirAll(filepath.Dir(partial), 0o755); err != nil {
+		return false, err
+	}
+
+	var offset int64
+	if info, err := os.Stat(partial); err == nil && info.Size() < obj.Size {
+		offset = info.Size()
+	}
+
+	resp, err := f.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, obj.Actions.Download.Href, nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range obj.Actions.Download.Header {
+			req.Header.Set(k, v)
+		}
+		if offset > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		}
+		return req, nil
+	})
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		// 서버가 Range를 무시하면 처음부터 다시 받는다
+		offset = 0
+		flags |= os.O_TRUNC
+	default:
+		return false, fmt.Errorf("download failed: %s", resp.Status)
+	}
+
+	file, err := os.OpenFile(partial, flags, 0o644)
+	if err != nil {
+		return false, err
+	}
+	written, copyErr := f.copy(ctx, file, resp.Body)
+	if err := file.Close(); copyErr == nil {
+		copyErr = err
+	}
+	if copyErr != nil {
+		// 부분 파일은 다음 실행에서 이어받기 위해 남겨둔다
+		return false, copyErr
+	}
+	if offset+written != obj.Size {
+		return false, fmt.Errorf("size mismatch: got %d bytes, want %d", offset+written, obj.Size)
+	}
+
+	if err := verify(partial, obj.OID); err != nil {
+		_ = os.Remove(partial)
+		return false, err
+	}
+	dest := objectPath(gitDir, obj.OID)
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return false, err
+	}
+	if err := os.Rename(partial, dest); err != nil {
+		return false, err
+	}
+	return offset > 0, nil
+}
+
+// copy writes src to dst in chunks, honoring the rate limit.
+func (f *Fetcher) copy(ctx context.Context, dst io.Writer, src io.Reader) (int64, error) {
+	buf := make([]byte, chunkSize)
+	var written int64
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			if waitErr := f.Limiter.WaitN(ctx, n); waitErr != nil {
+				return written, waitErr
+			}
+			m, writeErr := dst.Write(buf[:n])
+			written += int64(m)
+			if writeErr != nil {
+				return written, writeErr
+			}
+		}
+		if err == io.EOF {
+			return written, nil
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+}
+
+// do sends the request built by newRequest, retrying rate limited and
+// unavailable responses after the server's Retry-After delay.
+func (f *Fetcher) do(ctx context.Context, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	client := f.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	retries := f.MaxRetries
+	if retries == 0 {
+		retries = DefaultMaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+		if !retryable || attempt >= retries {
+			return resp, nil
+		}
+
+		wait := retryAfter(resp.Header.Get("Retry-After"), attempt)
+		_ = resp.Body.Close()
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryAfter parses a Retry-After header, falling back to exponential
+// backoff starting at one second.
+func retryAfter(header string, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return time.Duration(1<<attempt) * time.Second
+}
+
+func verify(path, oid string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != oid {
+		return fmt.Errorf("checksum mismatch: got %s", got)
+	}
+	return nil
+}
+
+// objectPath is where git-lfs expects an object in the local store.
+func objectPath(gitDir, oid string) string {
+	return filepath.Join(gitDir, "lfs", "objects", oid[0:2], oid[2:4], oid)
+}
+
+// Checkout replaces pointer files in the working tree with the downloaded
+// objects. It needs git-lfs, which also installs the filters that keep git
+// from reporting the files as modified.
+func Checkout(ctx context.Context, repoPath string) error {
+	if err := exec.CommandContext(ctx, "git", "lfs", "version").Run(); err != nil {
+		return ErrGitLFSNotInstalled
+	}
+	cmd := exec.CommandContext(ctx, "git", "lfs", "checkout")
+	cmd.Dir = repoPath
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git lfs checkout failed: %w: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
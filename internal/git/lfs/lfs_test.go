@@ -0,0 +1,282 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package lfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func oidOf(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestParsePointer(t *testing.T) {
+	oid := oidOf("hello")
+	p, err := ParsePointer([]byte(pointerVersion + "\noid sha256:" + oid + "\nsize 5\n"))
+	require.NoError(t, err)
+	assert.Equal(t, Pointer{OID: oid, Size: 5}, p)
+
+	_, err = ParsePointer([]byte("plain file"))
+	assert.Error(t, err)
+	_, err = ParsePointer([]byte(pointerVersion + "\noid sha256:abc\nsize 5\n"))
+	assert.Error(t, err)
+	_, err = ParsePointer([]byte(pointerVersion + "\noid sha256:" + oid + "\nsize -1\n"))
+	assert.Error(t, err)
+}
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+	assert.False(t, Detect(dir))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "assets"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assets", ".gitattributes"),
+		[]byte("*.psd filter=lfs diff=lfs merge=lfs -text\n"), 0o644))
+	assert.True(t, Detect(dir))
+}
+
+func TestUniqueAndFormatSize(t *testing.T) {
+	files := []PointerFile{
+		{Path: "a.bin", Pointer: Pointer{OID: "1", Size: 1024}},
+		{Path: "b.bin", Pointer: Pointer{OID: "1", Size: 1024}},
+		{Path: "c.bin", Pointer: Pointer{OID: "2", Size: 2048}},
+	}
+	objects, total := Unique(files)
+	assert.Len(t, objects, 2)
+	assert.Equal(t, int64(3072), total)
+
+	assert.Equal(t, "512 B", FormatSize(512))
+	assert.Equal(t, "3.0 KiB", FormatSize(3072))
+	assert.Equal(t, "1.5 GiB", FormatSize(3<<29))
+}
+
+func TestEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/org/repo.git":        "https://github.com/org/repo.git/info/lfs",
+		"https://token@gitlab.com/group/sub/r":   "https://gitlab.com/group/sub/r.git/info/lfs",
+		"git@github.com:org/repo.git":            "https://github.com/org/repo.git/info/lfs",
+		"ssh://git@git.example.com:2222/org/r":   "https://git.example.com/org/r.git/info/lfs",
+		"https://git.example.com:8443/org/r.git": "https://git.example.com:8443/org/r.git/info/lfs",
+	}
+	for remote, want := range tests {
+		got, err := Endpoint("", remote)
+		require.NoError(t, err, remote)
+		assert.Equal(t, want, got, remote)
+	}
+
+	_, err := Endpoint("", "not-a-remote")
+	assert.Error(t, err)
+}
+
+// lfsServer serves the batch API and objects from contents, keyed by oid.
+type lfsServer struct {
+	*httptest.Server
+	contents     map[string]string
+	throttle     atomic.Int32 // batch requests to answer with 429
+	ranges       []string
+	batchCalls   atomic.Int32
+	downloadHits atomic.Int32
+}
+
+func newLFSServer(t *testing.T, contents ...string) *lfsServer {
+	t.Helper()
+	s := &lfsServer{contents: map[string]string{}}
+	for _, c := range contents {
+		s.contents[oidOf(c)] = c
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /objects/batch", func(w http.ResponseWriter, r *http.Request) {
+		s.batchCalls.Add(1)
+		if s.throttle.Load() > 0 {
+			s.throttle.Add(-1)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		user, pass, _ := r.BasicAuth()
+		if user != "x-access-token" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var req struct {
+			Objects []Pointer `json:"objects"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var objects []map[string]any
+		for _, o := range req.Objects {
+			obj := map[string]any{"oid": o.OID, "size": o.Size}
+			if _, ok := s.contents[o.OID]; ok {
+				obj["actions"] = map[string]any{"download": map[string]any{
+					"href":   s.URL + "/objects/" + o.OID,
+					"header": map[string]string{"X-Token": "download"},
+				}}
+			} else {
+				obj["error"] = map[string]any{"code": 404, "message": "Object does not exist"}
+			}
+			objects = append(objects, obj)
+		}
+		w.Header().Set("Content-Type", mediaType)
+		_ = json.NewEncoder(w).Encode(map[string]any{"objects": objects})
+	})
+	mux.HandleFunc("GET /objects/{oid}", func(w http.ResponseWriter, r *http.Request) {
+		s.downloadHits.Add(1)
+		if r.Header.Get("X-Token") != "download" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		s.ranges = append(s.ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader(s.contents[r.PathValue("oid")]))
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *lfsServer) fetcher() *Fetcher {
+	return &Fetcher{Endpoint: s.URL, Username: "x-access-token", Password: "secret"}
+}
+
+func pointerFor(content string) Pointer {
+	return Pointer{OID: oidOf(content), Size: int64(len(content))}
+}
+
+func TestFetchDownloadsAndCaches(t *testing.T) {
+	a, b := strings.Repeat("a", 100), strings.Repeat("b", 50)
+	srv := newLFSServer(t, a, b)
+	gitDir := t.TempDir()
+
+	var last Progress
+	f := srv.fetcher()
+	f.BatchSize = 1
+	f.OnProgress = func(p Progress) { last = p }
+
+	stats, err := f.Fetch(context.Background(), gitDir, []Pointer{pointerFor(a), pointerFor(b)})
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Downloaded)
+	assert.Equal(t, int64(150), stats.DownloadedBytes)
+	assert.Equal(t, int32(2), srv.batchCalls.Load())
+	assert.Equal(t, Progress{Objects: 2, TotalObjects: 2, Bytes: 150, TotalBytes: 150}, last)
+
+	data, err := os.ReadFile(objectPath(gitDir, oidOf(a)))
+	require.NoError(t, err)
+	assert.Equal(t, a, string(data))
+
+	// 두 번째 실행은 로컬 저장소에서 해결되어야 한다
+	stats, err = f.Fetch(context.Background(), gitDir, []Pointer{pointerFor(a), pointerFor(b)})
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Cached)
+	assert.Equal(t, 0, stats.Downloaded)
+	assert.Equal(t, int32(2), srv.downloadHits.Load())
+}
+
+func TestFetchResumesPartialDownload(t *testing.T) {
+	content := strings.Repeat("0123456789", 20)
+	srv := newLFSServer(t, content)
+	gitDir := t.TempDir()
+
+	partial := filepath.Join(gitDir, "lfs", "incomplete", oidOf(content))
+	require.NoError(t, os.MkdirAll(filepath.Dir(partial), 0o755))
+	require.NoError(t, os.WriteFile(partial, []byte(content[:70]), 0o644))
+
+	stats, err := srv.fetcher().Fetch(context.Background(), gitDir, []Pointer{pointerFor(content)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Resumed)
+	assert.Equal(t, []string{"bytes=70-"}, srv.ranges)
+
+	data, err := os.ReadFile(objectPath(gitDir, oidOf(content)))
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+	assert.NoFileExists(t, partial)
+}
+
+func TestFetchRejectsCorruptPartial(t *testing.T) {
+	content := strings.Repeat("x", 40)
+	srv := newLFSServer(t, content)
+	gitDir := t.TempDir()
+
+	partial := filepath.Join(gitDir, "lfs", "incomplete", oidOf(content))
+	require.NoError(t, os.MkdirAll(filepath.Dir(partial), 0o755))
+	require.NoError(t, os.WriteFile(partial, []byte("garbage"), 0o644))
+
+	_, err := srv.fetcher().Fetch(context.Background(), gitDir, []Pointer{pointerFor(content)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+	assert.NoFileExists(t, partial)
+
+	// 손상된 부분 파일이 지워졌으므로 재시도는 처음부터 받는다
+	_, err = srv.fetcher().Fetch(context.Background(), gitDir, []Pointer{pointerFor(content)})
+	require.NoError(t, err)
+}
+
+func TestFetchRetriesRateLimitedBatch(t *testing.T) {
+	content := "payload"
+	srv := newLFSServer(t, content)
+	srv.throttle.Store(2)
+
+	stats, err := srv.fetcher().Fetch(context.Background(), t.TempDir(), []Pointer{pointerFor(content)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Downloaded)
+	assert.Equal(t, int32(3), srv.batchCalls.Load())
+
+	srv.throttle.Store(5)
+	f := srv.fetcher()
+	f.MaxRetries = -1
+	_, err = f.Fetch(context.Background(), t.TempDir(), []Pointer{pointerFor(content)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "429")
+}
+
+func TestFetchCollectsObjectErrors(t *testing.T) {
+	present := "present"
+	srv := newLFSServer(t, present)
+
+	stats, err := srv.fetcher().Fetch(context.Background(), t.TempDir(),
+		[]Pointer{pointerFor(present), pointerFor("missing")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 LFS objects failed")
+	assert.Contains(t, err.Error(), "Object does not exist")
+	assert.Equal(t, 1, stats.Downloaded)
+	assert.Equal(t, 1, stats.Failed)
+}
+
+func TestRateLimiter(t *testing.T) {
+	assert.Nil(t, NewRateLimiter(0))
+	assert.NoError(t, (*RateLimiter)(nil).WaitN(context.Background(), 1<<20))
+
+	l := NewRateLimiter(1000)
+	start := time.Now()
+	require.NoError(t, l.WaitN(context.Background(), 1000)) // initial burst
+	require.NoError(t, l.WaitN(context.Background(), 200))
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, l.WaitN(ctx, 10_000), context.Canceled)
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, 3*time.Second, retryAfter("3", 0))
+	assert.Equal(t, 4*time.Second, retryAfter("", 2))
+	assert.Equal(t, time.Duration(0), retryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0))
+	assert.Equal(t, time.Second, retryAfter("soon", 0))
+}
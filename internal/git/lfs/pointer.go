@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package lfs detects Git LFS usage in cloned repositories and downloads
+// LFS objects through the LFS batch API, without requiring git-lfs for the
+// transfer. Downloads are batched, resumable and optionally rate limited.
+package lfs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// pointerVersion is the first line of every LFS pointer file.
+const pointerVersion = "version https://git-lfs.github.com/spec/v1"
+
+// maxPointerSize bounds the size of a pointer file; larger files are never
+// pointers.
+const maxPointerSize = 1024
+
+// Pointer identifies an LFS object.
+type Pointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// PointerFile is a tracked file that still holds a pointer instead of the
+// object content.
+type PointerFile struct {
+	Path string
+	Pointer
+}
+
+// ParsePointer parses the content of a pointer file.
+func ParsePointer(data []byte) (Pointer, error) {
+	if len(data) > maxPointerSize || !bytes.HasPrefix(data, []byte(pointerVersion)) {
+		return Pointer{}, fmt.Errorf("not an LFS pointer")
+	}
+
+	var p Pointer
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), " ")
+		switch key {
+		case "oid":
+			p.OID = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || size < 0 {
+				return Pointer{}, fmt.Errorf("invalid LFS pointer size %q", value)
+			}
+			p.Size = size
+		}
+	}
+	if len(p.OID) != 64 {
+		return Pointer{}, fmt.Errorf("invalid LFS pointer oid %q", p.OID)
+	}
+	return p, nil
+}
+
+// Detect reports whether the working tree tracks files with LFS, based on
+// the filter=lfs attribute in .gitattributes files.
+func Detect(repoPath string) bool {
+	found := false
+	_ = filepath.WalkDir(repoPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil //nolint:nilerr // unreadable directories are skipped
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || d.Name() != ".gitattributes" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err == nil && bytes.Contains(data, []byte("filter=lfs")) {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found
+}
+
+// ScanPointers returns the tracked files of repoPath whose content is still
+// an LFS pointer.
+func ScanPointers(ctx context.Context, repoPath string) ([]PointerFile, error) {
+	cmd := exec.CommandContext(ctx, "git", "ls-files", "-z")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked files: %w", err)
+	}
+
+	var files []PointerFile
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name == "" {
+			continue
+		}
+		path := filepath.Join(repoPath, filepath.FromSlash(name))
+		info, err := os.Lstat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Size() > maxPointerSize {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if p, err := ParsePointer(data); err == nil {
+			files = append(files, PointerFile{Path: name, Pointer: p})
+		}
+	}
+	return files, nil
+}
+
+// Unique returns the distinct objects referenced by files and their total
+// size.
+func Unique(files []PointerFile) ([]Pointer, int64) {
+	seen := make(map[string]bool, len(files))
+	var (
+		objects []Pointer
+		total   int64
+	)
+	for _, f := range files {
+		if seen[f.OID] {
+			continue
+		}
+		seen[f.OID] = true
+		objects = append(objects, f.Pointer)
+		total += f.Size
+	}
+	return objects, total
+}
+
+// FormatSize formats a byte count for display.
+func FormatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
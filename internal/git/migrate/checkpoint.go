@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Kinds of migrated items recorded in the mapping report.
+const (
+	KindRepository  = "repository"
+	KindLabel       = "label"
+	KindMilestone   = "milestone"
+	KindIssue       = "issue"
+	KindPullRequest = "pull_request"
+	KindRelease     = "release"
+)
+
+// Mapping statuses.
+const (
+	StatusCreated = "created"
+	StatusExisted = "existed"
+	StatusPartial = "partial"
+	StatusFailed  = "failed"
+)
+
+// Mapping records how one source item was migrated.
+type Mapping struct {
+	Kind        string `json:"kind"`
+	Source      string `json:"source"`
+	Destination string `json:"destination,omitempty"`
+	Status      string `json:"status"`
+	// Comments counts comments already copied, so a resumed migration
+	// continues an issue instead of recreating it.
+	Comments int    `json:"comments,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Checkpoint is the resumable state of a migration. It is saved after every
+// migrated item; on resume, items that already have a created or existed
+// mapping are skipped, partial ones are continued and failed ones are retried.
+type Checkpoint struct {
+	Source      string    `json:"source"`
+	Destination string    `json:"destination"`
+	StartedAt   time.Time `json:"startedAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	Mappings    []Mapping `json:"mappings"`
+
+	path  string
+	index map[string]int
+}
+
+// NewCheckpoint creates an empty checkpoint stored at path.
+func NewCheckpoint(path, source, destination string) *Checkpoint {
+	now := time.Now()
+	return &Checkpoint{
+		Source:      source,
+		Destination: destination,
+		StartedAt:   now,
+		UpdatedAt:   now,
+		path:        path,
+		index:       make(map[string]int),
+	}
+}
+
+// LoadCheckpoint reads a checkpoint from path. It fails if the checkpoint
+// belongs to a different source/destination pair.
+func LoadCheckpoint(path, source, destination string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	if cp.Source != source || cp.Destination != destination {
+		return nil, fmt.Errorf("checkpoint %s is for %s -> %s", path, cp.Source, cp.Destination)
+	}
+
+	cp.path = path
+	cp.index = make(map[string]int, len(cp.Mappings))
+	for i, m := range cp.Mappings {
+		cp.index[m.Kind+"\x00"+m.Source] = i
+	}
+	return &cp, nil
+}
+
+// DefaultCheckpointPath returns the checkpoint file for a migration under the
+// gzh-manager config directory.
+func DefaultCheckpointPath(source, destination string) string {
+	homeDir, _ := os.UserHomeDir()
+	name := strings.NewReplacer(":", "_", "/", "_").Replace(source + "__" + destination)
+	return filepath.Join(homeDir, ".config", "gzh-manager", "migrate", name+".json")
+}
+
+// Path returns the checkpoint file path.
+func (c *Checkpoint) Path() string { return c.path }
+
+// Lookup returns the mapping for a source item, if any.
+func (c *Checkpoint) Lookup(kind, source string) (Mapping, bool) {
+	i, ok := c.index[kind+"\x00"+source]
+	if !ok {
+		return Mapping{}, false
+	}
+	return c.Mappings[i], true
+}
+
+// Done reports whether a source item was already migrated.
+func (c *Checkpoint) Done(kind, source string) bool {
+	m, ok := c.Lookup(kind, source)
+	return ok && (m.Status == StatusCreated || m.Status == StatusExisted)
+}
+
+// Record adds or replaces the mapping for a source item and saves the
+// checkpoint.
+func (c *Checkpoint) Record(m Mapping) error {
+	key := m.Kind + "\x00" + m.Source
+	if i, ok := c.index[key]; ok {
+		c.Mappings[i] = m
+	} else {
+		c.index[key] = len(c.Mappings)
+		c.Mappings = append(c.Mappings, m)
+	}
+	return c.Save()
+}
+
+// Save writes the checkpoint atomically.
+func (c *Checkpoint) Save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return os.Rename(tmp, c.path)
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"net/url"
+	"strings"
+)
+
+// webHost derives the git host from an API base URL, e.g.
+// https://gitlab.example.com/api/v4 -> gitlab.example.com.
+func webHost(apiBase, apiSuffix string) string {
+	u, err := url.Parse(apiBase)
+	if err != nil {
+		return ""
+	}
+	host := u.Host
+	if strings.HasPrefix(host, "api.") {
+		host = strings.TrimPrefix(host, "api.")
+	}
+	prefix := strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), apiSuffix)
+	return host + prefix
+}
+
+// splitRepo splits a repository path into its namespace and name.
+func splitRepo(repo string) (namespace, name string) {
+	i := strings.LastIndex(repo, "/")
+	if i < 0 {
+		return "", repo
+	}
+	return repo[:i], repo[i+1:]
+}
@@ -0,0 +1,330 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// giteaTracker implements Tracker against the Gitea REST API (v1). Gitea
+// references labels and milestones by ID, so both are cached by name.
+type giteaTracker struct {
+	client *restapi.Client
+	repo   string
+	token  string
+	host   string
+
+	mu         sync.Mutex
+	labels     map[string]int64 // name -> id
+	milestones map[string]int64 // title -> id
+}
+
+type gtUser struct {
+	Login string `json:"login"`
+}
+
+type gtLabel struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+type gtMilestone struct {
+	ID          int64      `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	DueOn       *time.Time `json:"due_on"` //nolint:tagliatelle // Gitea API field
+}
+
+type gtIssue struct {
+	Number    int          `json:"number"`
+	Title     string       `json:"title"`
+	Body      string       `json:"body"`
+	State     string       `json:"state"`
+	User      gtUser       `json:"user"`
+	Labels    []gtLabel    `json:"labels"`
+	Milestone *gtMilestone `json:"milestone"`
+	HTMLURL   string       `json:"html_url"`   //nolint:tagliatelle // Gitea API field
+	CreatedAt time.Time    `json:"created_at"` //nolint:tagliatelle // Gitea API field
+}
+
+type gtPull struct {
+	gtIssue
+	Merged bool `json:"merged"`
+	Head   struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+type gtComment struct {
+	Body      string    `json:"body"`
+	User      gtUser    `json:"user"`
+	CreatedAt time.Time `json:"created_at"` //nolint:tagliatelle // Gitea API field
+}
+
+type gtRelease struct {
+	TagName    string `json:"tag_name"` //nolint:tagliatelle // Gitea API field
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+func newGiteaTracker(ep Endpoint) *giteaTracker {
+	baseURL := ep.BaseURL
+	if baseURL == "" {
+		baseURL = "https://gitea.com/api/v1"
+	}
+
+	return &giteaTracker{
+		client: restapi.New("gitea", baseURL, func(req *http.Request) {
+			if ep.Token != "" {
+				req.Header.Set("Authorization", "token "+ep.Token)
+			}
+		}),
+		repo:  ep.Repo,
+		token: ep.Token,
+		host:  webHost(baseURL, "/api/v1"),
+	}
+}
+
+func (t *giteaTracker) Provider() string   { return "gitea" }
+func (t *giteaTracker) Repository() string { return t.repo }
+
+func (t *giteaTracker) CloneURL() string {
+	if t.token == "" {
+		return fmt.Sprintf("https://%s/%s.git", t.host, t.repo)
+	}
+	return fmt.Sprintf("https://%s@%s/%s.git", t.token, t.host, t.repo)
+}
+
+func (t *giteaTracker) EnsureRepository(ctx context.Context, private bool) (bool, error) {
+	err := t.client.Do(ctx, http.MethodGet, "/repos/"+t.repo, nil, nil)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, restapi.ErrNotFound) {
+		return false, err
+	}
+
+	owner, name := splitRepo(t.repo)
+	body := map[string]any{"name": name, "private": private}
+	err = t.client.Do(ctx, http.MethodPost, "/orgs/"+owner+"/repos", body, nil)
+	if errors.Is(err, restapi.ErrNotFound) {
+		err = t.client.Do(ctx, http.MethodPost, "/user/repos", body, nil)
+	}
+	return err == nil, err
+}
+
+func (t *giteaTracker) ListLabels(ctx context.Context) ([]Label, error) {
+	items, err := restapi.ListAll[gtLabel](ctx, t.client, "/repos/"+t.repo+"/labels", "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.labels = make(map[string]int64, len(items))
+
+	labels := make([]Label, 0, len(items))
+	for _, l := range items {
+		t.labels[l.Name] = l.ID
+		labels = append(labels, Label{ID: l.ID, Name: l.Name, Color: strings.TrimPrefix(l.Color, "#"), Description: l.Description})
+	}
+	return labels, nil
+}
+
+func (t *giteaTracker) CreateLabel(ctx context.Context, label Label) error {
+	color := label.Color
+	if color == "" {
+		color = "ededed"
+	}
+
+	var created gtLabel
+	if err := t.client.Do(ctx, http.MethodPost, "/repos/"+t.repo+"/labels", map[string]string{
+		"name": label.Name, "color": "#" + color, "description": label.Description,
+	}, &created); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.labels == nil {
+		t.labels = make(map[string]int64)
+	}
+	t.labels[created.Name] = created.ID
+	return nil
+}
+
+func (t *giteaTracker) ListMilestones(ctx context.Context) ([]Milestone, error) {
+	items, err := restapi.ListAll[gtMilestone](ctx, t.client, "/repos/"+t.repo+"/milestones?state=all", "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.milestones = make(map[string]int64, len(items))
+
+	milestones := make([]Milestone, 0, len(items))
+	for _, m := range items {
+		t.milestones[m.Title] = m.ID
+		milestones = append(milestones, Milestone{
+			ID: m.ID, Title: m.Title, Description: m.Description, State: m.State, DueOn: m.DueOn,
+		})
+	}
+	return milestones, nil
+}
+
+func (t *giteaTracker) CreateMilestone(ctx context.Context, milestone Milestone) error {
+	body := map[string]any{"title": milestone.Title, "description": milestone.Description, "state": milestone.State}
+	if milestone.DueOn != nil {
+		body["due_on"] = milestone.DueOn.UTC().Format(time.RFC3339)
+	}
+
+	var created gtMilestone
+	if err := t.client.Do(ctx, http.MethodPost, "/repos/"+t.repo+"/milestones", body, &created); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.milestones == nil {
+		t.milestones = make(map[string]int64)
+	}
+	t.milestones[created.Title] = created.ID
+	return nil
+}
+
+func (t *giteaTracker) ListIssues(ctx context.Context) ([]Issue, error) {
+	items, err := restapi.ListAll[gtIssue](ctx, t.client, "/repos/"+t.repo+"/issues?state=all&type=issues", "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0, len(items))
+	for _, it := range items {
+		issues = append(issues, it.toIssue())
+	}
+	sortByNumber(issues)
+	return issues, nil
+}
+
+func (t *giteaTracker) ListPullRequests(ctx context.Context) ([]PullRequest, error) {
+	items, err := restapi.ListAll[gtPull](ctx, t.client, "/repos/"+t.repo+"/pulls?state=all&sort=oldest", "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	pulls := make([]PullRequest, 0, len(items))
+	for _, p := range items {
+		pulls = append(pulls, PullRequest{
+			Issue:        p.toIssue(),
+			SourceBranch: p.Head.Ref,
+			TargetBranch: p.Base.Ref,
+			Merged:       p.Merged,
+		})
+	}
+	return pulls, nil
+}
+
+func (t *giteaTracker) ListComments(ctx context.Context, number int, _ bool) ([]Comment, error) {
+	items, err := restapi.ListAll[gtComment](ctx, t.client,
+		"/repos/"+t.repo+"/issues/"+strconv.Itoa(number)+"/comments", "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]Comment, 0, len(items))
+	for _, c := range items {
+		comments = append(comments, Comment{Author: c.User.Login, Body: c.Body, CreatedAt: c.CreatedAt})
+	}
+	return comments, nil
+}
+
+func (t *giteaTracker) CreateIssue(ctx context.Context, input IssueInput) (int, error) {
+	body := map[string]any{"title": input.Title, "body": input.Body}
+
+	t.mu.Lock()
+	var labelIDs []int64
+	for _, name := range input.Labels {
+		if id, ok := t.labels[name]; ok {
+			labelIDs = append(labelIDs, id)
+		}
+	}
+	if id, ok := t.milestones[input.Milestone]; ok && input.Milestone != "" {
+		body["milestone"] = id
+	}
+	t.mu.Unlock()
+
+	if len(labelIDs) > 0 {
+		body["labels"] = labelIDs
+	}
+
+	var created gtIssue
+	if err := t.client.Do(ctx, http.MethodPost, "/repos/"+t.repo+"/issues", body, &created); err != nil {
+		return 0, err
+	}
+	return created.Number, nil
+}
+
+func (t *giteaTracker) CreateComment(ctx context.Context, number int, body string) error {
+	return t.client.Do(ctx, http.MethodPost,
+		"/repos/"+t.repo+"/issues/"+strconv.Itoa(number)+"/comments", map[string]string{"body": body}, nil)
+}
+
+func (t *giteaTracker) CloseIssue(ctx context.Context, number int) error {
+	return t.client.Do(ctx, http.MethodPatch,
+		"/repos/"+t.repo+"/issues/"+strconv.Itoa(number), map[string]string{"state": "closed"}, nil)
+}
+
+func (t *giteaTracker) ListReleases(ctx context.Context) ([]Release, error) {
+	items, err := restapi.ListAll[gtRelease](ctx, t.client, "/repos/"+t.repo+"/releases", "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]Release, 0, len(items))
+	for _, r := range items {
+		releases = append(releases, Release(r))
+	}
+	return releases, nil
+}
+
+func (t *giteaTracker) CreateRelease(ctx context.Context, release Release) error {
+	return t.client.Do(ctx, http.MethodPost, "/repos/"+t.repo+"/releases", gtRelease(release), nil)
+}
+
+func (i gtIssue) toIssue() Issue {
+	issue := Issue{
+		Number:    i.Number,
+		Title:     i.Title,
+		Body:      i.Body,
+		State:     i.State,
+		Author:    i.User.Login,
+		URL:       i.HTMLURL,
+		CreatedAt: i.CreatedAt,
+	}
+	for _, l := range i.Labels {
+		issue.Labels = append(issue.Labels, l.Name)
+	}
+	if i.Milestone != nil {
+		issue.Milestone = i.Milestone.Title
+	}
+	return issue
+}
@@ -0,0 +1,305 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// gitHubTracker implements Tracker against the GitHub REST API.
+type gitHubTracker struct {
+	client *restapi.Client
+	repo   string
+	token  string
+	host   string
+
+	mu         sync.Mutex
+	milestones map[string]int64 // title -> number
+}
+
+type ghUser struct {
+	Login string `json:"login"`
+}
+
+type ghLabel struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+type ghMilestone struct {
+	Number      int64      `json:"number"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	State       string     `json:"state"`
+	DueOn       *time.Time `json:"due_on"` //nolint:tagliatelle // GitHub API field
+}
+
+type ghIssue struct {
+	Number      int          `json:"number"`
+	Title       string       `json:"title"`
+	Body        string       `json:"body"`
+	State       string       `json:"state"`
+	User        ghUser       `json:"user"`
+	Labels      []ghLabel    `json:"labels"`
+	Milestone   *ghMilestone `json:"milestone"`
+	HTMLURL     string       `json:"html_url"`               //nolint:tagliatelle // GitHub API field
+	CreatedAt   time.Time    `json:"created_at"`             //nolint:tagliatelle // GitHub API field
+	PullRequest *struct{}    `json:"pull_request,omitempty"` //nolint:tagliatelle // GitHub API field
+}
+
+type ghPull struct {
+	ghIssue
+	MergedAt *time.Time `json:"merged_at"` //nolint:tagliatelle // GitHub API field
+	Head     struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+type ghComment struct {
+	Body      string    `json:"body"`
+	User      ghUser    `json:"user"`
+	CreatedAt time.Time `json:"created_at"` //nolint:tagliatelle // GitHub API field
+}
+
+type ghRelease struct {
+	TagName    string `json:"tag_name"` //nolint:tagliatelle // GitHub API field
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+func newGitHubTracker(ep Endpoint) *gitHubTracker {
+	baseURL := ep.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+
+	return &gitHubTracker{
+		client: restapi.New("github", baseURL, func(req *http.Request) {
+			if ep.Token != "" {
+				req.Header.Set("Authorization", "token "+ep.Token)
+			}
+			req.Header.Set("Accept", "application/vnd.github.v3+json")
+		}),
+		repo:  ep.Repo,
+		token: ep.Token,
+		host:  webHost(baseURL, "/api/v3"),
+	}
+}
+
+func (t *gitHubTracker) Provider() string   { return "github" }
+func (t *gitHubTracker) Repository() string { return t.repo }
+
+func (t *gitHubTracker) CloneURL() string {
+	if t.token == "" {
+		return fmt.Sprintf("https://%s/%s.git", t.host, t.repo)
+	}
+	return fmt.Sprintf("https://x-access-token:%s@%s/%s.git", t.token, t.host, t.repo)
+}
+
+func (t *gitHubTracker) EnsureRepository(ctx context.Context, private bool) (bool, error) {
+	err := t.client.Do(ctx, http.MethodGet, "/repos/"+t.repo, nil, nil)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, restapi.ErrNotFound) {
+		return false, err
+	}
+
+	owner, name := splitRepo(t.repo)
+	body := map[string]any{"name": name, "private": private}
+	err = t.client.Do(ctx, http.MethodPost, "/orgs/"+owner+"/repos", body, nil)
+	if errors.Is(err, restapi.ErrNotFound) {
+		// Not an organization; create under the authenticated user.
+		err = t.client.Do(ctx, http.MethodPost, "/user/repos", body, nil)
+	}
+	return err == nil, err
+}
+
+func (t *gitHubTracker) ListLabels(ctx context.Context) ([]Label, error) {
+	items, err := restapi.ListAll[ghLabel](ctx, t.client, "/repos/"+t.repo+"/labels", "per_page")
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]Label, 0, len(items))
+	for _, l := range items {
+		labels = append(labels, Label{Name: l.Name, Color: l.Color, Description: l.Description})
+	}
+	return labels, nil
+}
+
+func (t *gitHubTracker) CreateLabel(ctx context.Context, label Label) error {
+	return t.client.Do(ctx, http.MethodPost, "/repos/"+t.repo+"/labels", ghLabel{
+		Name: label.Name, Color: label.Color, Description: label.Description,
+	}, nil)
+}
+
+func (t *gitHubTracker) ListMilestones(ctx context.Context) ([]Milestone, error) {
+	items, err := restapi.ListAll[ghMilestone](ctx, t.client, "/repos/"+t.repo+"/milestones?state=all", "per_page")
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.milestones = make(map[string]int64, len(items))
+
+	milestones := make([]Milestone, 0, len(items))
+	for _, m := range items {
+		t.milestones[m.Title] = m.Number
+		milestones = append(milestones, Milestone{
+			ID: m.Number, Title: m.Title, Description: m.Description, State: m.State, DueOn: m.DueOn,
+		})
+	}
+	return milestones, nil
+}
+
+func (t *gitHubTracker) CreateMilestone(ctx context.Context, milestone Milestone) error {
+	var created ghMilestone
+	body := map[string]any{"title": milestone.Title, "description": milestone.Description, "state": milestone.State}
+	if milestone.DueOn != nil {
+		body["due_on"] = milestone.DueOn.UTC().Format(time.RFC3339)
+	}
+	if err := t.client.Do(ctx, http.MethodPost, "/repos/"+t.repo+"/milestones", body, &created); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.milestones == nil {
+		t.milestones = make(map[string]int64)
+	}
+	t.milestones[created.Title] = created.Number
+	return nil
+}
+
+func (t *gitHubTracker) ListIssues(ctx context.Context) ([]Issue, error) {
+	items, err := restapi.ListAll[ghIssue](ctx, t.client,
+		"/repos/"+t.repo+"/issues?state=all&sort=created&direction=asc", "per_page")
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0, len(items))
+	for _, it := range items {
+		if it.PullRequest != nil {
+			continue
+		}
+		issues = append(issues, it.toIssue())
+	}
+	return issues, nil
+}
+
+func (t *gitHubTracker) ListPullRequests(ctx context.Context) ([]PullRequest, error) {
+	items, err := restapi.ListAll[ghPull](ctx, t.client,
+		"/repos/"+t.repo+"/pulls?state=all&sort=created&direction=asc", "per_page")
+	if err != nil {
+		return nil, err
+	}
+
+	pulls := make([]PullRequest, 0, len(items))
+	for _, p := range items {
+		pulls = append(pulls, PullRequest{
+			Issue:        p.toIssue(),
+			SourceBranch: p.Head.Ref,
+			TargetBranch: p.Base.Ref,
+			Merged:       p.MergedAt != nil,
+		})
+	}
+	return pulls, nil
+}
+
+func (t *gitHubTracker) ListComments(ctx context.Context, number int, _ bool) ([]Comment, error) {
+	items, err := restapi.ListAll[ghComment](ctx, t.client,
+		"/repos/"+t.repo+"/issues/"+strconv.Itoa(number)+"/comments", "per_page")
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]Comment, 0, len(items))
+	for _, c := range items {
+		comments = append(comments, Comment{Author: c.User.Login, Body: c.Body, CreatedAt: c.CreatedAt})
+	}
+	return comments, nil
+}
+
+func (t *gitHubTracker) CreateIssue(ctx context.Context, input IssueInput) (int, error) {
+	body := map[string]any{"title": input.Title, "body": input.Body}
+	if len(input.Labels) > 0 {
+		body["labels"] = input.Labels
+	}
+	if input.Milestone != "" {
+		t.mu.Lock()
+		number, ok := t.milestones[input.Milestone]
+		t.mu.Unlock()
+		if ok {
+			body["milestone"] = number
+		}
+	}
+
+	var created ghIssue
+	if err := t.client.Do(ctx, http.MethodPost, "/repos/"+t.repo+"/issues", body, &created); err != nil {
+		return 0, err
+	}
+	return created.Number, nil
+}
+
+func (t *gitHubTracker) CreateComment(ctx context.Context, number int, body string) error {
+	return t.client.Do(ctx, http.MethodPost,
+		"/repos/"+t.repo+"/issues/"+strconv.Itoa(number)+"/comments", map[string]string{"body": body}, nil)
+}
+
+func (t *gitHubTracker) CloseIssue(ctx context.Context, number int) error {
+	return t.client.Do(ctx, http.MethodPatch,
+		"/repos/"+t.repo+"/issues/"+strconv.Itoa(number), map[string]string{"state": "closed"}, nil)
+}
+
+func (t *gitHubTracker) ListReleases(ctx context.Context) ([]Release, error) {
+	items, err := restapi.ListAll[ghRelease](ctx, t.client, "/repos/"+t.repo+"/releases", "per_page")
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]Release, 0, len(items))
+	for _, r := range items {
+		releases = append(releases, Release(r))
+	}
+	return releases, nil
+}
+
+func (t *gitHubTracker) CreateRelease(ctx context.Context, release Release) error {
+	return t.client.Do(ctx, http.MethodPost, "/repos/"+t.repo+"/releases", ghRelease(release), nil)
+}
+
+func (i ghIssue) toIssue() Issue {
+	issue := Issue{
+		Number:    i.Number,
+		Title:     i.Title,
+		Body:      i.Body,
+		State:     i.State,
+		Author:    i.User.Login,
+		URL:       i.HTMLURL,
+		CreatedAt: i.CreatedAt,
+	}
+	for _, l := range i.Labels {
+		issue.Labels = append(issue.Labels, l.Name)
+	}
+	if i.Milestone != nil {
+		issue.Milestone = i.Milestone.Title
+	}
+	return issue
+}
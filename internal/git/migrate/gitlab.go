@@ -0,0 +1,340 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// gitLabTracker implements Tracker against the GitLab REST API (v4).
+type gitLabTracker struct {
+	client  *restapi.Client
+	repo    string
+	project string // URL-encoded project path
+	token   string
+	host    string
+
+	mu         sync.Mutex
+	milestones map[string]int64 // title -> id
+}
+
+type glUser struct {
+	Username string `json:"username"`
+}
+
+type glLabel struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+type glMilestone struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	DueDate     string `json:"due_date"` //nolint:tagliatelle // GitLab API field
+}
+
+type glIssue struct {
+	IID         int          `json:"iid"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	State       string       `json:"state"`
+	Author      glUser       `json:"author"`
+	Labels      []string     `json:"labels"`
+	Milestone   *glMilestone `json:"milestone"`
+	WebURL      string       `json:"web_url"`    //nolint:tagliatelle // GitLab API field
+	CreatedAt   time.Time    `json:"created_at"` //nolint:tagliatelle // GitLab API field
+}
+
+type glMergeRequest struct {
+	glIssue
+	SourceBranch string `json:"source_branch"` //nolint:tagliatelle // GitLab API field
+	TargetBranch string `json:"target_branch"` //nolint:tagliatelle // GitLab API field
+}
+
+type glNote struct {
+	Body      string    `json:"body"`
+	Author    glUser    `json:"author"`
+	System    bool      `json:"system"`
+	CreatedAt time.Time `json:"created_at"` //nolint:tagliatelle // GitLab API field
+}
+
+type glRelease struct {
+	TagName     string `json:"tag_name"` //nolint:tagliatelle // GitLab API field
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Upcoming    bool   `json:"upcoming_release"` //nolint:tagliatelle // GitLab API field
+}
+
+func newGitLabTracker(ep Endpoint) *gitLabTracker {
+	baseURL := ep.BaseURL
+	if baseURL == "" {
+		baseURL = "https://gitlab.com/api/v4"
+	}
+
+	return &gitLabTracker{
+		client: restapi.New("gitlab", baseURL, func(req *http.Request) {
+			if ep.Token != "" {
+				req.Header.Set("PRIVATE-TOKEN", ep.Token)
+			}
+		}),
+		repo:    ep.Repo,
+		project: url.PathEscape(ep.Repo),
+		token:   ep.Token,
+		host:    webHost(baseURL, "/api/v4"),
+	}
+}
+
+func (t *gitLabTracker) Provider() string   { return "gitlab" }
+func (t *gitLabTracker) Repository() string { return t.repo }
+
+func (t *gitLabTracker) CloneURL() string {
+	if t.token == "" {
+		return fmt.Sprintf("https://%s/%s.git", t.host, t.repo)
+	}
+	return fmt.Sprintf("https://oauth2:%s@%s/%s.git", t.token, t.host, t.repo)
+}
+
+func (t *gitLabTracker) EnsureRepository(ctx context.Context, private bool) (bool, error) {
+	err := t.client.Do(ctx, http.MethodGet, "/projects/"+t.project, nil, nil)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, restapi.ErrNotFound) {
+		return false, err
+	}
+
+	namespace, name := splitRepo(t.repo)
+	var ns struct {
+		ID int64 `json:"id"`
+	}
+	if err := t.client.Do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(namespace), nil, &ns); err != nil {
+		return false, fmt.Errorf("failed to resolve namespace %s: %w", namespace, err)
+	}
+
+	visibility := "public"
+	if private {
+		visibility = "private"
+	}
+	err = t.client.Do(ctx, http.MethodPost, "/projects", map[string]any{
+		"name": name, "path": name, "namespace_id": ns.ID, "visibility": visibility,
+	}, nil)
+	return err == nil, err
+}
+
+func (t *gitLabTracker) ListLabels(ctx context.Context) ([]Label, error) {
+	items, err := restapi.ListAll[glLabel](ctx, t.client, "/projects/"+t.project+"/labels", "per_page")
+	if err != nil {
+		return nil, err
+	}
+	labels := make([]Label, 0, len(items))
+	for _, l := range items {
+		labels = append(labels, Label{Name: l.Name, Color: strings.TrimPrefix(l.Color, "#"), Description: l.Description})
+	}
+	return labels, nil
+}
+
+func (t *gitLabTracker) CreateLabel(ctx context.Context, label Label) error {
+	color := label.Color
+	if color == "" {
+		color = "ededed"
+	}
+	return t.client.Do(ctx, http.MethodPost, "/projects/"+t.project+"/labels", glLabel{
+		Name: label.Name, Color: "#" + color, Description: label.Description,
+	}, nil)
+}
+
+func (t *gitLabTracker) ListMilestones(ctx context.Context) ([]Milestone, error) {
+	items, err := restapi.ListAll[glMilestone](ctx, t.client, "/projects/"+t.project+"/milestones", "per_page")
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.milestones = make(map[string]int64, len(items))
+
+	milestones := make([]Milestone, 0, len(items))
+	for _, m := range items {
+		t.milestones[m.Title] = m.ID
+		state := "open"
+		if m.State == "closed" {
+			state = "closed"
+		}
+		ms := Milestone{ID: m.ID, Title: m.Title, Description: m.Description, State: state}
+		if due, err := time.Parse(time.DateOnly, m.DueDate); err == nil {
+			ms.DueOn = &due
+		}
+		milestones = append(milestones, ms)
+	}
+	return milestones, nil
+}
+
+func (t *gitLabTracker) CreateMilestone(ctx context.Context, milestone Milestone) error {
+	body := map[string]any{"title": milestone.Title, "description": milestone.Description}
+	if milestone.DueOn != nil {
+		body["due_date"] = milestone.DueOn.Format(time.DateOnly)
+	}
+
+	var created glMilestone
+	if err := t.client.Do(ctx, http.MethodPost, "/projects/"+t.project+"/milestones", body, &created); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	if t.milestones == nil {
+		t.milestones = make(map[string]int64)
+	}
+	t.milestones[created.Title] = created.ID
+	t.mu.Unlock()
+
+	// Milestones are created active; close them afterwards to match the source.
+	if milestone.State == "closed" {
+		return t.client.Do(ctx, http.MethodPut,
+			"/projects/"+t.project+"/milestones/"+strconv.FormatInt(created.ID, 10),
+			map[string]string{"state_event": "close"}, nil)
+	}
+	return nil
+}
+
+func (t *gitLabTracker) ListIssues(ctx context.Context) ([]Issue, error) {
+	items, err := restapi.ListAll[glIssue](ctx, t.client,
+		"/projects/"+t.project+"/issues?scope=all&order_by=created_at&sort=asc", "per_page")
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0, len(items))
+	for _, it := range items {
+		issues = append(issues, it.toIssue())
+	}
+	return issues, nil
+}
+
+func (t *gitLabTracker) ListPullRequests(ctx context.Context) ([]PullRequest, error) {
+	items, err := restapi.ListAll[glMergeRequest](ctx, t.client,
+		"/projects/"+t.project+"/merge_requests?scope=all&state=all&order_by=created_at&sort=asc", "per_page")
+	if err != nil {
+		return nil, err
+	}
+
+	pulls := make([]PullRequest, 0, len(items))
+	for _, mr := range items {
+		pulls = append(pulls, PullRequest{
+			Issue:        mr.toIssue(),
+			SourceBranch: mr.SourceBranch,
+			TargetBranch: mr.TargetBranch,
+			Merged:       mr.State == "merged",
+		})
+	}
+	return pulls, nil
+}
+
+func (t *gitLabTracker) ListComments(ctx context.Context, number int, pullRequest bool) ([]Comment, error) {
+	kind := "issues"
+	if pullRequest {
+		kind = "merge_requests"
+	}
+
+	items, err := restapi.ListAll[glNote](ctx, t.client,
+		"/projects/"+t.project+"/"+kind+"/"+strconv.Itoa(number)+"/notes?sort=asc&order_by=created_at", "per_page")
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]Comment, 0, len(items))
+	for _, n := range items {
+		if n.System {
+			continue
+		}
+		comments = append(comments, Comment{Author: n.Author.Username, Body: n.Body, CreatedAt: n.CreatedAt})
+	}
+	return comments, nil
+}
+
+func (t *gitLabTracker) CreateIssue(ctx context.Context, input IssueInput) (int, error) {
+	body := map[string]any{"title": input.Title, "description": input.Body}
+	if len(input.Labels) > 0 {
+		body["labels"] = strings.Join(input.Labels, ",")
+	}
+	if input.Milestone != "" {
+		t.mu.Lock()
+		id, ok := t.milestones[input.Milestone]
+		t.mu.Unlock()
+		if ok {
+			body["milestone_id"] = id
+		}
+	}
+
+	var created glIssue
+	if err := t.client.Do(ctx, http.MethodPost, "/projects/"+t.project+"/issues", body, &created); err != nil {
+		return 0, err
+	}
+	return created.IID, nil
+}
+
+func (t *gitLabTracker) CreateComment(ctx context.Context, number int, body string) error {
+	return t.client.Do(ctx, http.MethodPost,
+		"/projects/"+t.project+"/issues/"+strconv.Itoa(number)+"/notes", map[string]string{"body": body}, nil)
+}
+
+func (t *gitLabTracker) CloseIssue(ctx context.Context, number int) error {
+	return t.client.Do(ctx, http.MethodPut,
+		"/projects/"+t.project+"/issues/"+strconv.Itoa(number), map[string]string{"state_event": "close"}, nil)
+}
+
+func (t *gitLabTracker) ListReleases(ctx context.Context) ([]Release, error) {
+	items, err := restapi.ListAll[glRelease](ctx, t.client, "/projects/"+t.project+"/releases", "per_page")
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]Release, 0, len(items))
+	for _, r := range items {
+		releases = append(releases, Release{TagName: r.TagName, Name: r.Name, Body: r.Description, Prerelease: r.Upcoming})
+	}
+	return releases, nil
+}
+
+// CreateRelease creates a release for an existing tag. GitLab has no drafts
+// or pre-releases, so those flags are dropped.
+func (t *gitLabTracker) CreateRelease(ctx context.Context, release Release) error {
+	return t.client.Do(ctx, http.MethodPost, "/projects/"+t.project+"/releases", map[string]string{
+		"tag_name": release.TagName, "name": release.Name, "description": release.Body,
+	}, nil)
+}
+
+func (i glIssue) toIssue() Issue {
+	state := "open"
+	if i.State != "opened" {
+		state = "closed"
+	}
+
+	issue := Issue{
+		Number:    i.IID,
+		Title:     i.Title,
+		Body:      i.Description,
+		State:     state,
+		Author:    i.Author.Username,
+		Labels:    i.Labels,
+		URL:       i.WebURL,
+		CreatedAt: i.CreatedAt,
+	}
+	if i.Milestone != nil {
+		issue.Milestone = i.Milestone.Title
+	}
+	return issue
+}
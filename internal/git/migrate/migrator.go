@@ -0,0 +1,561 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+)
+
+// Options selects what a migration transfers.
+type Options struct {
+	Git          bool
+	Labels       bool
+	Milestones   bool
+	Issues       bool
+	PullRequests bool
+	Releases     bool
+	Comments     bool
+
+	// CreateMissing creates the destination repository if it does not exist.
+	CreateMissing bool
+	Private       bool
+
+	DryRun bool
+	Out    io.Writer
+
+	// Progress reports each phase and item; defaults to lines on Out.
+	Progress cli.Progress
+}
+
+// Migrator transfers one repository from a source tracker to a destination
+// tracker, recording progress in a checkpoint.
+type Migrator struct {
+	src        Tracker
+	dst        Tracker
+	opts       Options
+	checkpoint *Checkpoint
+
+	// runGit is swapped out in tests.
+	runGit func(ctx context.Context, dir string, args ...string) error
+}
+
+// NewMigrator creates a migrator. A nil checkpoint keeps progress in memory
+// only.
+func NewMigrator(src, dst Tracker, checkpoint *Checkpoint, opts Options) *Migrator {
+	if opts.Out == nil {
+		opts.Out = os.Stdout
+	}
+	if opts.Progress == nil {
+		opts.Progress = cli.NewProgress(opts.Out, cli.ProgressLines)
+	}
+	if checkpoint == nil {
+		checkpoint = NewCheckpoint("", endpointName(src), endpointName(dst))
+	}
+
+	return &Migrator{
+		src:        src,
+		dst:        dst,
+		opts:       opts,
+		checkpoint: checkpoint,
+		runGit:     runGit,
+	}
+}
+
+// Run performs the migration. Individual item failures are recorded in the
+// report rather than aborting the run; an error is returned only when a
+// whole phase cannot proceed.
+func (m *Migrator) Run(ctx context.Context) (*Report, error) {
+	if m.opts.DryRun {
+		return m.plan(ctx)
+	}
+
+	phases := []struct {
+		enabled bool
+		name    string
+		run     func(context.Context) error
+	}{
+		{m.opts.CreateMissing, "repository", m.ensureRepository},
+		{m.opts.Git, "git data", m.migrateGit},
+		{m.opts.Labels, "labels", m.migrateLabels},
+		{m.opts.Milestones, "milestones", m.migrateMilestones},
+		{m.opts.Issues, "issues", m.migrateIssues},
+		{m.opts.PullRequests, "pull requests", m.migratePullRequests},
+		{m.opts.Releases, "releases", m.migrateReleases},
+	}
+
+	defer m.opts.Progress.Done()
+	for _, phase := range phases {
+		if !phase.enabled {
+			continue
+		}
+		m.opts.Progress.Phase("Migrating "+phase.name, 0)
+		if err := phase.run(ctx); err != nil {
+			return m.report(), fmt.Errorf("%s migration failed: %w", phase.name, err)
+		}
+	}
+
+	return m.report(), nil
+}
+
+func (m *Migrator) ensureRepository(ctx context.Context) error {
+	created, err := m.dst.EnsureRepository(ctx, m.opts.Private)
+	if err != nil {
+		return err
+	}
+	if created {
+		m.opts.Progress.Logf("  ✅ Created %s", endpointName(m.dst))
+	}
+	return nil
+}
+
+// migrateGit mirrors all branches and tags to the destination.
+func (m *Migrator) migrateGit(ctx context.Context) error {
+	key := m.src.Repository()
+	m.opts.Progress.AddTotal(1)
+	if m.checkpoint.Done(KindRepository, key) {
+		m.opts.Progress.Item(key, cli.ItemSkipped, nil)
+		return nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "gzh-migrate-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	repoDir := filepath.Join(tempDir, "repo.git")
+	err = m.runGit(ctx, tempDir, "clone", "--mirror", m.src.CloneURL(), repoDir)
+	if err == nil {
+		err = m.runGit(ctx, repoDir, "push", m.dst.CloneURL(),
+			"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*")
+	}
+
+	mapping := Mapping{Kind: KindRepository, Source: key, Destination: m.dst.Repository(), Status: StatusCreated}
+	if err != nil {
+		mapping.Status, mapping.Error = StatusFailed, err.Error()
+	}
+	if rerr := m.checkpoint.Record(mapping); rerr != nil {
+		return rerr
+	}
+	m.track(mapping)
+	return err
+}
+
+func (m *Migrator) migrateLabels(ctx context.Context) error {
+	srcLabels, err := m.src.ListLabels(ctx)
+	if err != nil {
+		return err
+	}
+	dstLabels, err := m.dst.ListLabels(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(dstLabels))
+	for _, l := range dstLabels {
+		existing[strings.ToLower(l.Name)] = true
+	}
+
+	m.opts.Progress.AddTotal(len(srcLabels))
+	for _, label := range srcLabels {
+		if m.checkpoint.Done(KindLabel, label.Name) {
+			m.opts.Progress.Item(label.Name, cli.ItemSkipped, nil)
+			continue
+		}
+
+		mapping := Mapping{Kind: KindLabel, Source: label.Name, Destination: label.Name, Status: StatusExisted}
+		if !existing[strings.ToLower(label.Name)] {
+			mapping.Status = StatusCreated
+			if err := m.dst.CreateLabel(ctx, label); err != nil {
+				mapping.Status, mapping.Error = StatusFailed, err.Error()
+			}
+		}
+		if err := m.checkpoint.Record(mapping); err != nil {
+			return err
+		}
+		m.track(mapping)
+	}
+	return nil
+}
+
+func (m *Migrator) migrateMilestones(ctx context.Context) error {
+	srcMilestones, err := m.src.ListMilestones(ctx)
+	if err != nil {
+		return err
+	}
+	dstMilestones, err := m.dst.ListMilestones(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(dstMilestones))
+	for _, ms := range dstMilestones {
+		existing[ms.Title] = true
+	}
+
+	m.opts.Progress.AddTotal(len(srcMilestones))
+	for _, ms := range srcMilestones {
+		if m.checkpoint.Done(KindMilestone, ms.Title) {
+			m.opts.Progress.Item(ms.Title, cli.ItemSkipped, nil)
+			continue
+		}
+
+		mapping := Mapping{Kind: KindMilestone, Source: ms.Title, Destination: ms.Title, Status: StatusExisted}
+		if !existing[ms.Title] {
+			mapping.Status = StatusCreated
+			if err := m.dst.CreateMilestone(ctx, ms); err != nil {
+				mapping.Status, mapping.Error = StatusFailed, err.Error()
+			}
+		}
+		if err := m.checkpoint.Record(mapping); err != nil {
+			return err
+		}
+		m.track(mapping)
+	}
+	return nil
+}
+
+// prepareReferences loads destination labels and milestones so trackers can
+// resolve them by name when creating issues.
+func (m *Migrator) prepareReferences(ctx context.Context) error {
+	if _, err := m.dst.ListLabels(ctx); err != nil {
+		return err
+	}
+	_, err := m.dst.ListMilestones(ctx)
+	return err
+}
+
+func (m *Migrator) migrateIssues(ctx context.Context) error {
+	issues, err := m.src.ListIssues(ctx)
+	if err != nil {
+		return err
+	}
+	if err := m.prepareReferences(ctx); err != nil {
+		return err
+	}
+
+	m.opts.Progress.AddTotal(len(issues))
+	for _, issue := range issues {
+		input := IssueInput{
+			Title:     issue.Title,
+			Body:      m.issueHeader(issue, "issue") + issue.Body,
+			Labels:    issue.Labels,
+			Milestone: issue.Milestone,
+		}
+		if err := m.migrateThread(ctx, KindIssue, issue.Number, false, input, issue.State == "closed"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migratePullRequests recreates pull/merge requests as closed issues that
+// preserve the discussion and branch information. Code review state cannot
+// be transferred between platforms.
+func (m *Migrator) migratePullRequests(ctx context.Context) error {
+	pulls, err := m.src.ListPullRequests(ctx)
+	if err != nil {
+		return err
+	}
+	if err := m.prepareReferences(ctx); err != nil {
+		return err
+	}
+
+	m.opts.Progress.AddTotal(len(pulls))
+	for _, pr := range pulls {
+		state := pr.State
+		if pr.Merged {
+			state = "merged"
+		}
+		header := m.issueHeader(pr.Issue, "pull request") +
+			fmt.Sprintf("_Branches: `%s` → `%s` · State: %s_\n\n", pr.SourceBranch, pr.TargetBranch, state)
+
+		input := IssueInput{
+			Title:     fmt.Sprintf("[PR #%d] %s", pr.Number, pr.Title),
+			Body:      header + pr.Body,
+			Labels:    pr.Labels,
+			Milestone: pr.Milestone,
+		}
+		if err := m.migrateThread(ctx, KindPullRequest, pr.Number, true, input, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateThread creates the destination issue, copies comments and closes it
+// as needed. Progress is checkpointed after each step so an interrupted
+// thread is continued rather than duplicated.
+func (m *Migrator) migrateThread(ctx context.Context, kind string, number int, pullRequest bool, input IssueInput, closeIt bool) error {
+	key := strconv.Itoa(number)
+	if m.checkpoint.Done(kind, key) {
+		m.opts.Progress.Item("#"+key, cli.ItemSkipped, nil)
+		return nil
+	}
+
+	mapping, _ := m.checkpoint.Lookup(kind, key)
+	mapping.Kind, mapping.Source, mapping.Error = kind, key, ""
+
+	fail := func(err error) error {
+		mapping.Error = err.Error()
+		if mapping.Destination == "" {
+			mapping.Status = StatusFailed
+		} else {
+			mapping.Status = StatusPartial
+		}
+		if rerr := m.checkpoint.Record(mapping); rerr != nil {
+			return rerr
+		}
+		m.track(mapping)
+		return nil
+	}
+
+	if mapping.Destination == "" {
+		created, err := m.dst.CreateIssue(ctx, input)
+		if err != nil {
+			return fail(err)
+		}
+		mapping.Destination = strconv.Itoa(created)
+		mapping.Status = StatusPartial
+		if err := m.checkpoint.Record(mapping); err != nil {
+			return err
+		}
+	}
+	dstNumber, _ := strconv.Atoi(mapping.Destination)
+
+	if m.opts.Comments {
+		comments, err := m.src.ListComments(ctx, number, pullRequest)
+		if err != nil {
+			return fail(err)
+		}
+		for i := mapping.Comments; i < len(comments); i++ {
+			c := comments[i]
+			body := fmt.Sprintf("_@%s commented on %s:_\n\n%s", c.Author, c.CreatedAt.Format("2006-01-02 15:04 MST"), c.Body)
+			if err := m.dst.CreateComment(ctx, dstNumber, body); err != nil {
+				return fail(err)
+			}
+			mapping.Comments = i + 1
+			if err := m.checkpoint.Record(mapping); err != nil {
+				return err
+			}
+		}
+	}
+
+	if closeIt {
+		if err := m.dst.CloseIssue(ctx, dstNumber); err != nil {
+			return fail(err)
+		}
+	}
+
+	mapping.Status = StatusCreated
+	if err := m.checkpoint.Record(mapping); err != nil {
+		return err
+	}
+	m.track(mapping)
+	return nil
+}
+
+// track reports the outcome of a recorded mapping. Items that already
+// existed at the destination count as skipped.
+func (m *Migrator) track(mapping Mapping) {
+	name := mapping.Source
+	if mapping.Kind == KindIssue || mapping.Kind == KindPullRequest {
+		name = "#" + name
+	}
+
+	switch mapping.Status {
+	case StatusFailed, StatusPartial:
+		m.opts.Progress.Item(name, cli.ItemFailed, errors.New(mapping.Error))
+	case StatusExisted:
+		m.opts.Progress.Item(name, cli.ItemSkipped, nil)
+	default:
+		m.opts.Progress.Item(name, cli.ItemDone, nil)
+	}
+}
+
+func (m *Migrator) migrateReleases(ctx context.Context) error {
+	srcReleases, err := m.src.ListReleases(ctx)
+	if err != nil {
+		return err
+	}
+	dstReleases, err := m.dst.ListReleases(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool, len(dstReleases))
+	for _, r := range dstReleases {
+		existing[r.TagName] = true
+	}
+
+	// Create oldest first so the destination lists them in the same order.
+	m.opts.Progress.AddTotal(len(srcReleases))
+	for i := len(srcReleases) - 1; i >= 0; i-- {
+		release := srcReleases[i]
+		if m.checkpoint.Done(KindRelease, release.TagName) {
+			m.opts.Progress.Item(release.TagName, cli.ItemSkipped, nil)
+			continue
+		}
+
+		mapping := Mapping{Kind: KindRelease, Source: release.TagName, Destination: release.TagName, Status: StatusExisted}
+		if !existing[release.TagName] {
+			mapping.Status = StatusCreated
+			if err := m.dst.CreateRelease(ctx, release); err != nil {
+				mapping.Status, mapping.Error = StatusFailed, err.Error()
+			}
+		}
+		if err := m.checkpoint.Record(mapping); err != nil {
+			return err
+		}
+		m.track(mapping)
+	}
+	return nil
+}
+
+// plan lists what would be migrated without changing the destination.
+func (m *Migrator) plan(ctx context.Context) (*Report, error) {
+	report := &Report{Source: m.checkpoint.Source, Destination: m.checkpoint.Destination, DryRun: true}
+
+	add := func(kind string, keys []string) {
+		for _, k := range keys {
+			status := "pending"
+			if m.checkpoint.Done(kind, k) {
+				status = "done"
+			}
+			report.Mappings = append(report.Mappings, Mapping{Kind: kind, Source: k, Status: status})
+		}
+	}
+
+	if m.opts.Git {
+		add(KindRepository, []string{m.src.Repository()})
+	}
+	if m.opts.Labels {
+		labels, err := m.src.ListLabels(ctx)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(labels))
+		for _, l := range labels {
+			keys = append(keys, l.Name)
+		}
+		add(KindLabel, keys)
+	}
+	if m.opts.Milestones {
+		milestones, err := m.src.ListMilestones(ctx)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(milestones))
+		for _, ms := range milestones {
+			keys = append(keys, ms.Title)
+		}
+		add(KindMilestone, keys)
+	}
+	if m.opts.Issues {
+		issues, err := m.src.ListIssues(ctx)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(issues))
+		for _, i := range issues {
+			keys = append(keys, strconv.Itoa(i.Number))
+		}
+		add(KindIssue, keys)
+	}
+	if m.opts.PullRequests {
+		pulls, err := m.src.ListPullRequests(ctx)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(pulls))
+		for _, p := range pulls {
+			keys = append(keys, strconv.Itoa(p.Number))
+		}
+		add(KindPullRequest, keys)
+	}
+	if m.opts.Releases {
+		releases, err := m.src.ListReleases(ctx)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]string, 0, len(releases))
+		for _, r := range releases {
+			keys = append(keys, r.TagName)
+		}
+		add(KindRelease, keys)
+	}
+
+	return report, nil
+}
+
+func (m *Migrator) report() *Report {
+	return &Report{
+		Source:      m.checkpoint.Source,
+		Destination: m.checkpoint.Destination,
+		Checkpoint:  m.checkpoint.Path(),
+		Mappings:    append([]Mapping(nil), m.checkpoint.Mappings...),
+	}
+}
+
+func (m *Migrator) issueHeader(issue Issue, kind string) string {
+	ref := fmt.Sprintf("%s#%d", endpointName(m.src), issue.Number)
+	if issue.URL != "" {
+		ref = fmt.Sprintf("[%s](%s)", ref, issue.URL)
+	}
+	return fmt.Sprintf("_Migrated %s %s, opened by @%s on %s._\n\n",
+		kind, ref, issue.Author, issue.CreatedAt.Format("2006-01-02"))
+}
+
+func endpointName(t Tracker) string {
+	return t.Provider() + ":" + t.Repository()
+}
+
+func sortByNumber(issues []Issue) {
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Number < issues[j].Number })
+}
+
+// runGit runs a git command, keeping credentials embedded in URLs out of
+// error messages.
+func runGit(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s: %w: %s", redact(args[0]), err, redact(strings.TrimSpace(string(out))))
+	}
+	return nil
+}
+
+// redact strips userinfo from https URLs.
+func redact(s string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, "https://")
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:i+len("https://")])
+		s = s[i+len("https://"):]
+
+		end := strings.IndexAny(s, " \t\n'\"/")
+		if at := strings.Index(s, "@"); at >= 0 && (end < 0 || at < end) {
+			b.WriteString("***@")
+			s = s[at+1:]
+		}
+	}
+}
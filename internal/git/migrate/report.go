@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// Report maps migrated source items to their destination counterparts.
+type Report struct {
+	Source      string    `json:"source"`
+	Destination string    `json:"destination"`
+	Checkpoint  string    `json:"checkpoint,omitempty"`
+	DryRun      bool      `json:"dryRun,omitempty"`
+	Mappings    []Mapping `json:"mappings"`
+}
+
+// Counts returns the number of mappings per kind and status.
+func (r *Report) Counts() map[string]map[string]int {
+	counts := make(map[string]map[string]int)
+	for _, m := range r.Mappings {
+		if counts[m.Kind] == nil {
+			counts[m.Kind] = make(map[string]int)
+		}
+		counts[m.Kind][m.Status]++
+	}
+	return counts
+}
+
+// Failed returns mappings that did not complete.
+func (r *Report) Failed() []Mapping {
+	var failed []Mapping
+	for _, m := range r.Mappings {
+		if m.Status == StatusFailed || m.Status == StatusPartial {
+			failed = append(failed, m)
+		}
+	}
+	return failed
+}
+
+// WriteJSON writes the full mapping report to path.
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Plan returns the pending items of a dry run as planned creations in the
+// destination. Items a previous run already migrated are left out.
+func (r *Report) Plan() *provider.Plan {
+	plan := provider.NewPlan()
+	for _, m := range r.Mappings {
+		if m.Status != "pending" {
+			continue
+		}
+		if m.Kind == KindRepository {
+			plan.Create(provider.ResourceRepository, r.Destination, map[string]any{"mirror_of": r.Source + " (" + m.Source + ")"})
+			continue
+		}
+		plan.Create(m.Kind, m.Source, map[string]any{"from": r.Source})
+	}
+	return plan
+}
+
+// PrintSummary writes a per-kind summary followed by any failures.
+func (r *Report) PrintSummary(w io.Writer) {
+	title := "Migration summary"
+	if r.DryRun {
+		title = "Migration plan (dry run)"
+	}
+	fmt.Fprintf(w, "\n📋 %s: %s → %s\n", title, r.Source, r.Destination)
+
+	counts := r.Counts()
+	kinds := []string{KindRepository, KindLabel, KindMilestone, KindIssue, KindPullRequest, KindRelease}
+	statuses := []string{StatusCreated, StatusExisted, StatusPartial, StatusFailed, "pending", "done"}
+
+	fmt.Fprintf(w, "%-14s", "KIND")
+	for _, s := range statuses {
+		fmt.Fprintf(w, " %8s", s)
+	}
+	fmt.Fprintln(w)
+	for _, kind := range kinds {
+		c, ok := counts[kind]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(w, "%-14s", kind)
+		for _, s := range statuses {
+			fmt.Fprintf(w, " %8d", c[s])
+		}
+		fmt.Fprintln(w)
+	}
+
+	if failed := r.Failed(); len(failed) > 0 {
+		fmt.Fprintf(w, "\n❌ %d item(s) incomplete:\n", len(failed))
+		for _, m := range failed {
+			fmt.Fprintf(w, "  %s %s: %s\n", m.Kind, m.Source, m.Error)
+		}
+	}
+	if r.Checkpoint != "" {
+		fmt.Fprintf(w, "\nCheckpoint: %s\n", r.Checkpoint)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package migrate transfers a repository between GitHub, GitLab and Gitea,
+// including its labels, milestones, issues, pull/merge requests and releases.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Label is a repository label.
+type Label struct {
+	ID          int64
+	Name        string
+	Color       string // hex without leading '#'
+	Description string
+}
+
+// Milestone is a repository milestone.
+type Milestone struct {
+	ID          int64
+	Title       string
+	Description string
+	State       string // open, closed
+	DueOn       *time.Time
+}
+
+// Comment is an issue or pull request comment.
+type Comment struct {
+	Author    string
+	Body      string
+	CreatedAt time.Time
+}
+
+// Issue is an issue as read from the source tracker.
+type Issue struct {
+	Number    int
+	Title     string
+	Body      string
+	State     string // open, closed
+	Author    string
+	Labels    []string
+	Milestone string
+	URL       string
+	CreatedAt time.Time
+}
+
+// PullRequest is a pull or merge request as read from the source tracker.
+type PullRequest struct {
+	Issue
+	SourceBranch string
+	TargetBranch string
+	Merged       bool
+}
+
+// Release is a tagged release.
+type Release struct {
+	TagName    string
+	Name       string
+	Body       string
+	Draft      bool
+	Prerelease bool
+}
+
+// IssueInput describes an issue to create. Labels and Milestone are given by
+// name; trackers resolve them to their own identifiers.
+type IssueInput struct {
+	Title     string
+	Body      string
+	Labels    []string
+	Milestone string
+}
+
+// Tracker is the per-repository view of a Git platform used by migrations.
+// Implementations are bound to a single repository.
+type Tracker interface {
+	// Provider returns the provider name (github, gitlab, gitea).
+	Provider() string
+	// Repository returns the repository path the tracker is bound to.
+	Repository() string
+	// CloneURL returns an authenticated HTTPS URL for git operations.
+	CloneURL() string
+	// EnsureRepository creates the repository if it does not exist.
+	EnsureRepository(ctx context.Context, private bool) (created bool, err error)
+
+	ListLabels(ctx context.Context) ([]Label, error)
+	CreateLabel(ctx context.Context, label Label) error
+
+	ListMilestones(ctx context.Context) ([]Milestone, error)
+	CreateMilestone(ctx context.Context, milestone Milestone) error
+
+	// ListIssues returns issues oldest first, excluding pull requests.
+	ListIssues(ctx context.Context) ([]Issue, error)
+	// ListPullRequests returns pull/merge requests oldest first.
+	ListPullRequests(ctx context.Context) ([]PullRequest, error)
+	// ListComments returns user comments on an issue or pull request.
+	ListComments(ctx context.Context, number int, pullRequest bool) ([]Comment, error)
+
+	CreateIssue(ctx context.Context, input IssueInput) (int, error)
+	CreateComment(ctx context.Context, number int, body string) error
+	CloseIssue(ctx context.Context, number int) error
+
+	ListReleases(ctx context.Context) ([]Release, error)
+	CreateRelease(ctx context.Context, release Release) error
+}
+
+// Endpoint identifies a repository on a provider.
+type Endpoint struct {
+	Provider string
+	BaseURL  string // API base URL; provider default when empty
+	Token    string
+	Repo     string // owner/name, or group/subgroup/name on GitLab
+}
+
+// NewTracker creates a tracker for the endpoint's provider.
+func NewTracker(ep Endpoint) (Tracker, error) {
+	switch ep.Provider {
+	case "github":
+		return newGitHubTracker(ep), nil
+	case "gitlab":
+		return newGitLabTracker(ep), nil
+	case "gitea":
+		return newGiteaTracker(ep), nil
+	default:
+		return nil, fmt.Errorf("unsupported provider for migration: %s", ep.Provider)
+	}
+}
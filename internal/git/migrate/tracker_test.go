@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLabTracker_IssuesAndCreate(t *testing.T) {
+	var created map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/group%2Fsub%2Fapp/issues", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(body, &created))
+			_, _ = io.WriteString(w, `{"iid": 7}`)
+			return
+		}
+		_, _ = io.WriteString(w, `[{"iid":1,"title":"T","description":"D","state":"opened","labels":["bug"],
+			"author":{"username":"alice"},"milestone":{"title":"v1"}}]`)
+	})
+	mux.HandleFunc("/api/v4/projects/group%2Fsub%2Fapp/milestones", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `[{"id":42,"title":"v1","state":"active","due_date":"2025-01-31"}]`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tracker, err := NewTracker(Endpoint{Provider: "gitlab", BaseURL: server.URL + "/api/v4", Token: "secret", Repo: "group/sub/app"})
+	require.NoError(t, err)
+
+	issues, err := tracker.ListIssues(context.Background())
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, Issue{Number: 1, Title: "T", Body: "D", State: "open", Author: "alice", Labels: []string{"bug"}, Milestone: "v1"}, issues[0])
+
+	milestones, err := tracker.ListMilestones(context.Background())
+	require.NoError(t, err)
+	require.Len(t, milestones, 1)
+	assert.Equal(t, "open", milestones[0].State)
+	require.NotNil(t, milestones[0].DueOn)
+
+	number, err := tracker.CreateIssue(context.Background(), IssueInput{Title: "T", Labels: []string{"a", "b"}, Milestone: "v1"})
+	require.NoError(t, err)
+	assert.Equal(t, 7, number)
+	assert.Equal(t, "a,b", created["labels"])
+	assert.InDelta(t, 42, created["milestone_id"], 0)
+
+	assert.Equal(t, "https://oauth2:secret@"+server.Listener.Addr().String()+"/group/sub/app.git", tracker.CloneURL())
+}
+
+func TestGitHubTracker_SkipsPullRequestsInIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/org/app/issues", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("page"))
+		_, _ = io.WriteString(w, `[{"number":1,"title":"issue","state":"open","user":{"login":"a"}},
+			{"number":2,"title":"pr","state":"open","user":{"login":"a"},"pull_request":{}}]`)
+	}))
+	defer server.Close()
+
+	tracker, err := NewTracker(Endpoint{Provider: "github", BaseURL: server.URL, Repo: "org/app"})
+	require.NoError(t, err)
+
+	issues, err := tracker.ListIssues(context.Background())
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, 1, issues[0].Number)
+}
+
+func TestGiteaTracker_ResolvesLabelIDs(t *testing.T) {
+	var created map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/org/app/labels", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `[{"id":5,"name":"bug","color":"#ff0000"}]`)
+	})
+	mux.HandleFunc("/api/v1/repos/org/app/issues", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(body, &created))
+		_, _ = io.WriteString(w, `{"number": 3}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tracker, err := NewTracker(Endpoint{Provider: "gitea", BaseURL: server.URL + "/api/v1", Repo: "org/app"})
+	require.NoError(t, err)
+
+	labels, err := tracker.ListLabels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ff0000", labels[0].Color)
+
+	number, err := tracker.CreateIssue(context.Background(), IssueInput{Title: "T", Labels: []string{"bug", "unknown"}})
+	require.NoError(t, err)
+	assert.Equal(t, 3, number)
+	assert.Equal(t, []any{float64(5)}, created["labels"])
+}
+
+func TestNewTracker_UnsupportedProvider(t *testing.T) {
+	_, err := NewTracker(Endpoint{Provider: "gogs"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,288 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package objcache keeps shared Git object stores so that repeated clones of
+// a repository, or of its forks, borrow objects instead of downloading and
+// storing them again.
+//
+// A store is a bare repository per fork family: every repository with the
+// same name on the same host, such as github.com/acme/api and its fork
+// github.com/alice/api, fetches into one store under its own remote. Clones
+// reference the store with --reference-if-able, which records it in
+// .git/objects/info/alternates. Stores never prune objects, because a clone
+// may still need an object after the branch that brought it was rewritten;
+// Cache.GC repacks them and removes stores no clone uses any more.
+package objcache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	pkgcache "github.com/gizzahub/gzh-cli/pkg/cache"
+)
+
+// usersFile lists the clones that borrow objects from a store, one
+// "<RFC 3339 time>\t<path>" line per clone.
+const usersFile = "gz-users"
+
+// DefaultRoot returns ~/.gzh/cache/git-objects.
+func DefaultRoot() string {
+	return pkgcache.DefaultDir("git-objects")
+}
+
+// Cache manages the object stores below a root directory. It is safe for
+// concurrent use; stores of one family are updated one at a time.
+type Cache struct {
+	root string
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+
+	// git runs a git command in dir; swapped out in tests.
+	git func(ctx context.Context, dir string, args ...string) ([]byte, error)
+	now func() time.Time
+}
+
+// New returns a cache rooted at root; an empty root uses DefaultRoot.
+func New(root string) *Cache {
+	if root == "" {
+		root = DefaultRoot()
+	}
+	return &Cache{
+		root:  root,
+		locks: make(map[string]*sync.Mutex),
+		git:   runGit,
+		now:   time.Now,
+	}
+}
+
+// Root returns the directory holding the stores.
+func (c *Cache) Root() string {
+	return c.root
+}
+
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...) // #nosec G204 -- fixed git subcommands
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_LFS_SKIP_SMUDGE=1")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return out, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return out, nil
+}
+
+// scpLike matches SSH remotes in the git@host:owner/repo.git form.
+var scpLike = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.+)$`)
+
+// parseRemote splits a clone URL into host and repository path. Local
+// paths use the host "local".
+func parseRemote(cloneURL string) (host, repoPath string, err error) {
+	switch {
+	case strings.Contains(cloneURL, "://"):
+		u, err := url.Parse(cloneURL)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid clone URL %q: %w", cloneURL, err)
+		}
+		host, repoPath = u.Hostname(), u.Path
+		if u.Scheme == "file" {
+			host = "local"
+		}
+	case scpLike.MatchString(cloneURL) && !filepath.IsAbs(cloneURL):
+		m := scpLike.FindStringSubmatch(cloneURL)
+		host, repoPath = m[1], m[2]
+	default:
+		host, repoPath = "local", filepath.ToSlash(cloneURL)
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if host == "" || repoPath == "" || repoPath == "." {
+		return "", "", fmt.Errorf("invalid clone URL %q", cloneURL)
+	}
+	return strings.ToLower(host), strings.ToLower(repoPath), nil
+}
+
+// FamilyKey returns the store key of cloneURL: the host and the repository
+// name, so forks share a store, e.g. "github.com/api".
+func FamilyKey(cloneURL string) (string, error) {
+	host, repoPath, err := parseRemote(cloneURL)
+	if err != nil {
+		return "", err
+	}
+	return host + "/" + path.Base(repoPath), nil
+}
+
+// remoteName returns the name of the store remote for cloneURL, e.g.
+// "acme/api".
+func remoteName(cloneURL string) (string, error) {
+	_, repoPath, err := parseRemote(cloneURL)
+	if err != nil {
+		return "", err
+	}
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '/', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '-'
+		}
+	}, repoPath)
+	return strings.Trim(strings.ReplaceAll(name, "..", "-"), "/."), nil
+}
+
+// Path returns the directory of the store with key.
+func (c *Cache) Path(key string) string {
+	return filepath.Join(c.root, filepath.FromSlash(key)+".git")
+}
+
+func (c *Cache) lock(key string) func() {
+	c.mu.Lock()
+	l, ok := c.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		c.locks[key] = l
+	}
+	c.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// Prepare makes sure the store of cloneURL exists and holds the objects of
+// the repository, and returns its path for --reference-if-able. A failed
+// fetch leaves an older store usable, so callers can still clone with it;
+// clones never depend on Prepare succeeding.
+func (c *Cache) Prepare(ctx context.Context, cloneURL string) (string, error) {
+	key, err := FamilyKey(cloneURL)
+	if err != nil {
+		return "", err
+	}
+	remote, err := remoteName(cloneURL)
+	if err != nil {
+		return "", err
+	}
+	defer c.lock(key)()
+
+	store := c.Path(key)
+	if _, err := os.Stat(filepath.Join(store, "objects")); err != nil {
+		if err := c.create(ctx, store); err != nil {
+			os.RemoveAll(store)
+			return "", err
+		}
+	}
+
+	if _, err := c.git(ctx, store, "config", "--get", "remote."+remote+".url"); err != nil {
+		// Tags of different forks collide, so they are kept per remote.
+		for _, args := range [][]string{
+			{"remote", "add", "--no-tags", remote, cloneURL},
+			{"config", "--add", "remote." + remote + ".fetch", "+refs/tags/*:refs/remotes/" + remote + "/tags/*"},
+		} {
+			if _, err := c.git(ctx, store, args...); err != nil {
+				return "", fmt.Errorf("failed to add %s to object cache: %w", remote, err)
+			}
+		}
+	}
+
+	if _, err := c.git(ctx, store, "fetch", "--quiet", "--no-prune", remote); err != nil {
+		return store, fmt.Errorf("failed to fetch %s into object cache: %w", remote, err)
+	}
+	return store, nil
+}
+
+// create initializes a store that never drops objects on its own.
+func (c *Cache) create(ctx context.Context, store string) error {
+	if err := os.MkdirAll(store, 0o750); err != nil {
+		return fmt.Errorf("failed to create object cache: %w", err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet", "--bare"},
+		{"config", "gc.auto", "0"},
+		{"config", "gc.pruneExpire", "never"},
+		{"config", "gc.reflogExpireUnreachable", "never"},
+	} {
+		if _, err := c.git(ctx, store, args...); err != nil {
+			return fmt.Errorf("failed to create object cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// Register records that the clone at repoPath borrows objects from store,
+// so GC keeps the store. Register before cloning: a clone in progress has
+// no alternates file yet and is only protected by its recent registration.
+func (c *Cache) Register(store, repoPath string) error {
+	abs, err := filepath.Abs(repoPath)
+	if err != nil {
+		return err
+	}
+	key, err := c.keyOf(store)
+	if err != nil {
+		return err
+	}
+	defer c.lock(key)()
+
+	users, err := readUsers(store)
+	if err != nil {
+		return err
+	}
+	users[abs] = c.now()
+	return writeUsers(store, users)
+}
+
+// keyOf returns the key of a store path below the root.
+func (c *Cache) keyOf(store string) (string, error) {
+	rel, err := filepath.Rel(c.root, store)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is not an object store below %s", store, c.root)
+	}
+	return strings.TrimSuffix(filepath.ToSlash(rel), ".git"), nil
+}
+
+func readUsers(store string) (map[string]time.Time, error) {
+	users := make(map[string]time.Time)
+	f, err := os.Open(filepath.Join(store, usersFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return users, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		at, repoPath, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			continue
+		}
+		users[repoPath] = t
+	}
+	return users, scanner.Err()
+}
+
+func writeUsers(store string, users map[string]time.Time) error {
+	var b strings.Builder
+	for _, repoPath := range sortedKeys(users) {
+		fmt.Fprintf(&b, "%s\t%s\n", users[repoPath].UTC().Format(time.RFC3339), repoPath)
+	}
+	tmp := filepath.Join(store, usersFile+".tmp")
+	if err := os.WriteFile(tmp, []byte(b.String()), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(store, usersFile))
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package objcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// registrationGrace protects clones that registered but have not written
+// their alternates file yet, i.e. clones still in progress.
+const registrationGrace = time.Hour
+
+// Store describes one object store.
+type Store struct {
+	Key       string   `json:"key"`
+	Path      string   `json:"path"`
+	SizeBytes int64    `json:"size_bytes"`
+	Users     []string `json:"users"`
+	LiveUsers []string `json:"live_users"`
+}
+
+// GCOptions controls Cache.GC.
+type GCOptions struct {
+	// DryRun reports what would happen without changing anything.
+	DryRun bool
+	// PruneUnused removes stores that no clone borrows objects from.
+	PruneUnused bool
+}
+
+// GCResult reports what GC did to one store.
+type GCResult struct {
+	Key        string `json:"key"`
+	Path       string `json:"path"`
+	Action     string `json:"action"` // "repacked", "removed", "kept"
+	LiveUsers  int    `json:"live_users"`
+	StaleUsers int    `json:"stale_users"`
+	SizeBefore int64  `json:"size_before"`
+	SizeAfter  int64  `json:"size_after"`
+	Error      string `json:"error,omitempty"`
+}
+
+// List returns the stores below the root, sorted by key.
+func (c *Cache) List() ([]Store, error) {
+	var stores []Store
+	err := filepath.WalkDir(c.root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && p == c.root {
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if !d.IsDir() || !strings.HasSuffix(d.Name(), ".git") {
+			return nil
+		}
+		key, err := c.keyOf(p)
+		if err != nil {
+			return err
+		}
+		store, err := c.describe(key, p)
+		if err != nil {
+			return err
+		}
+		stores = append(stores, store)
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list object cache: %w", err)
+	}
+	return stores, nil
+}
+
+func (c *Cache) describe(key, store string) (Store, error) {
+	users, err := readUsers(store)
+	if err != nil {
+		return Store{}, err
+	}
+	s := Store{Key: key, Path: store, SizeBytes: dirSize(store), Users: sortedKeys(users), LiveUsers: []string{}}
+	for _, repoPath := range s.Users {
+		if c.isLive(store, repoPath, users[repoPath]) {
+			s.LiveUsers = append(s.LiveUsers, repoPath)
+		}
+	}
+	return s, nil
+}
+
+// isLive reports whether the clone at repoPath still borrows objects from
+// store, or registered too recently to tell.
+func (c *Cache) isLive(store, repoPath string, registered time.Time) bool {
+	if c.now().Sub(registered) < registrationGrace {
+		return true
+	}
+	objects := resolvePath(filepath.Join(store, "objects"))
+	for _, alternates := range []string{
+		filepath.Join(repoPath, ".git", "objects", "info", "alternates"),
+		filepath.Join(repoPath, "objects", "info", "alternates"),
+	} {
+		data, err := os.ReadFile(alternates) // #nosec G304 -- registered clone paths
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" && resolvePath(line) == objects {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GC drops clones that no longer use a store from its users, then removes
+// unused stores (with PruneUnused) and repacks the rest. Unreachable
+// objects are kept, since a clone may still depend on them.
+func (c *Cache) GC(ctx context.Context, opts GCOptions) ([]GCResult, error) {
+	stores, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]GCResult, 0, len(stores))
+	for _, s := range stores {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		results = append(results, c.gcStore(ctx, s, opts))
+	}
+	return results, nil
+}
+
+func (c *Cache) gcStore(ctx context.Context, s Store, opts GCOptions) GCResult {
+	defer c.lock(s.Key)()
+
+	result := GCResult{
+		Key:        s.Key,
+		Path:       s.Path,
+		LiveUsers:  len(s.LiveUsers),
+		StaleUsers: len(s.Users) - len(s.LiveUsers),
+		SizeBefore: s.SizeBytes,
+		SizeAfter:  s.SizeBytes,
+	}
+
+	switch {
+	case len(s.LiveUsers) == 0 && opts.PruneUnused:
+		result.Action = "removed"
+		result.SizeAfter = 0
+		if opts.DryRun {
+			return result
+		}
+		if err := os.RemoveAll(s.Path); err != nil {
+			result.Error = err.Error()
+			result.SizeAfter = dirSize(s.Path)
+		}
+		return result
+	case opts.DryRun:
+		result.Action = "repacked"
+		return result
+	}
+
+	if result.StaleUsers > 0 {
+		users, err := readUsers(s.Path)
+		if err == nil {
+			for _, repoPath := range s.Users {
+				if !c.isLive(s.Path, repoPath, users[repoPath]) {
+					delete(users, repoPath)
+				}
+			}
+			err = writeUsers(s.Path, users)
+		}
+		if err != nil {
+			result.Error = err.Error()
+			result.Action = "kept"
+			return result
+		}
+	}
+
+	result.Action = "repacked"
+	if _, err := c.git(ctx, s.Path, "gc", "--quiet", "--prune=never"); err != nil {
+		result.Error = err.Error()
+		result.Action = "kept"
+	}
+	result.SizeAfter = dirSize(s.Path)
+	return result
+}
+
+// resolvePath follows symlinks where possible, as git records the real path
+// of a reference repository.
+func resolvePath(p string) string {
+	if resolved, err := filepath.EvalSymlinks(p); err == nil {
+		return resolved
+	}
+	return filepath.Clean(p)
+}
+
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil //nolint:nilerr // best-effort size
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package protect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrBranchNotFound is returned when a policy branch does not exist in a
+	// repository.
+	ErrBranchNotFound = errors.New("branch not found")
+)
+
+// Repository is a repository the policy may apply to.
+type Repository struct {
+	ID            int64  `json:"-"`
+	Name          string `json:"name"`
+	FullName      string `json:"fullName"`
+	DefaultBranch string `json:"defaultBranch"`
+	Archived      bool   `json:"archived,omitempty"`
+}
+
+// Backend reads and writes branch protection on one platform.
+type Backend interface {
+	// Provider returns the platform name.
+	Provider() string
+	// ListRepositories lists every repository of an organization or group.
+	ListRepositories(ctx context.Context, org string) ([]Repository, error)
+	// GetProtection returns the current protection of a branch, or
+	// ErrBranchNotFound.
+	GetProtection(ctx context.Context, repo Repository, branch string) (Protection, error)
+	// ApplyProtection changes a branch's protection from current to desired.
+	// A desired protection that is not Protected removes the protection.
+	ApplyProtection(ctx context.Context, repo Repository, branch string, current, desired Protection) error
+	// Unsupported lists the settings the platform cannot enforce.
+	Unsupported() []string
+}
+
+// NewBackend creates the backend for provider. baseURL selects a
+// self-hosted API and may be empty.
+func NewBackend(provider, baseURL, token string) (Backend, error) {
+	switch provider {
+	case "github":
+		return newGitHubBackend(baseURL, token), nil
+	case "gitlab":
+		return newGitLabBackend(baseURL, token), nil
+	default:
+		return nil, fmt.Errorf("unsupported provider for branch protection: %s (supported: github, gitlab)", provider)
+	}
+}
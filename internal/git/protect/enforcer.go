@@ -0,0 +1,233 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package protect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Result statuses.
+const (
+	StatusCompliant = "compliant"
+	StatusDrift     = "drift"
+	StatusApplied   = "applied"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped"
+)
+
+// Options control an enforcement run.
+type Options struct {
+	// DryRun only reports drift; nothing is changed.
+	DryRun bool
+	// Repositories further narrows the policy's selector to names matching
+	// any of these patterns.
+	Repositories []string
+	// Concurrency is the number of repositories processed at once.
+	Concurrency int
+	// Applied, if set, is called after a branch's protection was changed,
+	// with the protection it had before. It is called concurrently.
+	Applied func(repo Repository, branch string, before, after Protection)
+}
+
+// Enforcer audits and applies a policy across an organization.
+type Enforcer struct {
+	backend Backend
+	policy  *Policy
+	opts    Options
+}
+
+// NewEnforcer creates an enforcer.
+func NewEnforcer(backend Backend, policy *Policy, opts Options) *Enforcer {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	return &Enforcer{backend: backend, policy: policy, opts: opts}
+}
+
+// Run audits every selected repository of org and, unless DryRun is set,
+// brings drifted branches in line with the policy. Failures on individual
+// repositories are recorded in the report rather than aborting the run.
+func (e *Enforcer) Run(ctx context.Context, org string) (*Report, error) {
+	repos, err := e.backend.ListRepositories(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Provider:    e.backend.Provider(),
+		Org:         org,
+		DryRun:      e.opts.DryRun,
+		GeneratedAt: time.Now(),
+		Unsupported: e.unsupportedInPolicy(),
+	}
+
+	var selected []Repository
+	for _, r := range repos {
+		if e.policy.Repositories.Matches(r) && e.matchesFilter(r) {
+			selected = append(selected, r)
+		}
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		work = make(chan Repository)
+	)
+	for i := 0; i < e.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range work {
+				results := e.enforceRepository(ctx, repo)
+				mu.Lock()
+				report.Results = append(report.Results, results...)
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, r := range selected {
+		if ctx.Err() != nil {
+			break
+		}
+		work <- r
+	}
+	close(work)
+	wg.Wait()
+
+	sort.Slice(report.Results, func(i, j int) bool {
+		a, b := report.Results[i], report.Results[j]
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		return a.Branch < b.Branch
+	})
+	return report, ctx.Err()
+}
+
+func (e *Enforcer) matchesFilter(repo Repository) bool {
+	if len(e.opts.Repositories) == 0 {
+		return true
+	}
+	for _, pattern := range e.opts.Repositories {
+		if ok, _ := path.Match(pattern, repo.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// enforceRepository applies every branch rule to one repository.
+func (e *Enforcer) enforceRepository(ctx context.Context, repo Repository) []Result {
+	results := make([]Result, 0, len(e.policy.Branches))
+	for _, rule := range e.policy.Branches {
+		branch := rule.Branch
+		if branch == "" {
+			branch = repo.DefaultBranch
+		}
+		result := Result{Repository: repo.FullName, Branch: branch}
+		if branch == "" {
+			result.Status = StatusSkipped
+			result.Error = "repository has no default branch"
+			results = append(results, result)
+			continue
+		}
+
+		current, err := e.backend.GetProtection(ctx, repo, branch)
+		if errors.Is(err, ErrBranchNotFound) {
+			result.Status = StatusSkipped
+			result.Error = "branch does not exist"
+			results = append(results, result)
+			continue
+		}
+		if err != nil {
+			result.Status = StatusFailed
+			result.Error = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		desired := rule.Desired(current)
+		result.Changes = e.supportedChanges(Diff(current, desired))
+		switch {
+		case len(result.Changes) == 0:
+			result.Status = StatusCompliant
+		case e.opts.DryRun:
+			result.Status = StatusDrift
+		default:
+			if err := e.backend.ApplyProtection(ctx, repo, branch, current, desired); err != nil {
+				result.Status = StatusFailed
+				result.Error = fmt.Sprintf("failed to apply policy: %v", err)
+			} else {
+				result.Status = StatusApplied
+				if e.opts.Applied != nil {
+					e.opts.Applied(repo, branch, current, desired)
+				}
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// supportedChanges drops changes to settings the platform cannot enforce.
+func (e *Enforcer) supportedChanges(changes []Change) []Change {
+	unsupported := make(map[string]bool)
+	for _, s := range e.backend.Unsupported() {
+		unsupported[s] = true
+	}
+	out := changes[:0]
+	for _, c := range changes {
+		if !unsupported[c.Setting] {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// unsupportedInPolicy lists the policy settings the platform ignores.
+func (e *Enforcer) unsupportedInPolicy() []string {
+	var out []string
+	for _, s := range e.backend.Unsupported() {
+		for _, b := range e.policy.Branches {
+			if b.sets(s) {
+				out = append(out, s)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// sets reports whether the rules enforce a setting.
+func (r Rules) sets(setting string) bool {
+	switch setting {
+	case SettingRequiredReviews:
+		return r.RequiredReviews != nil
+	case SettingDismissStaleReviews:
+		return r.DismissStaleReviews != nil
+	case SettingRequireCodeOwnerReviews:
+		return r.RequireCodeOwnerReviews != nil
+	case SettingRequireStatusChecks:
+		return r.RequireStatusChecks != nil
+	case SettingStatusChecks:
+		return len(r.StatusChecks) > 0
+	case SettingStrictStatusChecks:
+		return r.StrictStatusChecks != nil
+	case SettingRequireSignedCommits:
+		return r.RequireSignedCommits != nil
+	case SettingAllowForcePushes:
+		return r.AllowForcePushes != nil
+	case SettingAllowDeletions:
+		return r.AllowDeletions != nil
+	case SettingEnforceAdmins:
+		return r.EnforceAdmins != nil
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package protect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// gitHubBackend implements Backend against the GitHub REST API.
+type gitHubBackend struct {
+	client *restapi.Client
+}
+
+func newGitHubBackend(baseURL, token string) *gitHubBackend {
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return &gitHubBackend{
+		client: restapi.New("github", baseURL, func(req *http.Request) {
+			req.Header.Set("Accept", "application/vnd.github+json")
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}),
+	}
+}
+
+func (b *gitHubBackend) Provider() string { return "github" }
+
+// Unsupported returns nothing: every policy setting maps to GitHub branch
+// protection.
+func (b *gitHubBackend) Unsupported() []string { return nil }
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghRepo struct {
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+	Archived      bool   `json:"archived"`
+}
+
+type ghEnabled struct {
+	Enabled bool `json:"enabled"`
+}
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghProtection struct {
+	RequiredStatusChecks *struct {
+		Strict   bool     `json:"strict"`
+		Contexts []string `json:"contexts"`
+	} `json:"required_status_checks"`
+	EnforceAdmins              *ghEnabled `json:"enforce_admins"`
+	RequiredPullRequestReviews *struct {
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	} `json:"required_pull_request_reviews"`
+	RequiredSignatures *ghEnabled `json:"required_signatures"`
+	AllowForcePushes   *ghEnabled `json:"allow_force_pushes"`
+	AllowDeletions     *ghEnabled `json:"allow_deletions"`
+	Restrictions       *struct {
+		Users []struct {
+			Login string `json:"login"`
+		} `json:"users"`
+		Teams []struct {
+			Slug string `json:"slug"`
+		} `json:"teams"`
+		Apps []struct {
+			Slug string `json:"slug"`
+		} `json:"apps"`
+	} `json:"restrictions"`
+}
+
+// nolint:tagliatelle // External API format - must match GitHub JSON input
+type ghProtectionUpdate struct {
+	RequiredStatusChecks       *ghStatusChecksUpdate `json:"required_status_checks"`
+	EnforceAdmins              bool                  `json:"enforce_admins"`
+	RequiredPullRequestReviews *ghReviewsUpdate      `json:"required_pull_request_reviews"`
+	Restrictions               *ghRestrictions       `json:"restrictions"`
+	AllowForcePushes           bool                  `json:"allow_force_pushes"`
+	AllowDeletions             bool                  `json:"allow_deletions"`
+}
+
+type ghStatusChecksUpdate struct {
+	Strict   bool     `json:"strict"`
+	Contexts []string `json:"contexts"`
+}
+
+// nolint:tagliatelle // External API format - must match GitHub JSON input
+type ghReviewsUpdate struct {
+	DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+	RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+}
+
+type ghRestrictions struct {
+	Users []string `json:"users"`
+	Teams []string `json:"teams"`
+	Apps  []string `json:"apps"`
+}
+
+func (b *gitHubBackend) ListRepositories(ctx context.Context, org string) ([]Repository, error) {
+	repos, err := restapi.ListAll[ghRepo](ctx, b.client, "/orgs/"+url.PathEscape(org)+"/repos?type=all", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories of %s: %w", org, err)
+	}
+
+	out := make([]Repository, 0, len(repos))
+	for _, r := range repos {
+		out = append(out, Repository{
+			Name:          r.Name,
+			FullName:      r.FullName,
+			DefaultBranch: r.DefaultBranch,
+			Archived:      r.Archived,
+		})
+	}
+	return out, nil
+}
+
+func (b *gitHubBackend) branchPath(repo Repository, branch string) string {
+	return "/repos/" + repo.FullName + "/branches/" + url.PathEscape(branch)
+}
+
+func (b *gitHubBackend) GetProtection(ctx context.Context, repo Repository, branch string) (Protection, error) {
+	if err := b.client.Do(ctx, http.MethodGet, b.branchPath(repo, branch), nil, nil); err != nil {
+		if errors.Is(err, restapi.ErrNotFound) {
+			return Protection{}, ErrBranchNotFound
+		}
+		return Protection{}, err
+	}
+
+	raw, err := b.getRaw(ctx, repo, branch)
+	if err != nil {
+		return Protection{}, err
+	}
+	if raw == nil {
+		return Unprotected(), nil
+	}
+
+	p := Protection{Protected: true}
+	if c := raw.RequiredStatusChecks; c != nil {
+		p.RequireStatusChecks = true
+		p.StrictStatusChecks = c.Strict
+		p.StatusChecks = sortedUnique(c.Contexts)
+	}
+	if r := raw.RequiredPullRequestReviews; r != nil {
+		p.RequiredReviews = r.RequiredApprovingReviewCount
+		p.DismissStaleReviews = r.DismissStaleReviews
+		p.RequireCodeOwnerReviews = r.RequireCodeOwnerReviews
+	}
+	p.EnforceAdmins = raw.EnforceAdmins != nil && raw.EnforceAdmins.Enabled
+	p.RequireSignedCommits = raw.RequiredSignatures != nil && raw.RequiredSignatures.Enabled
+	p.AllowForcePushes = raw.AllowForcePushes != nil && raw.AllowForcePushes.Enabled
+	p.AllowDeletions = raw.AllowDeletions != nil && raw.AllowDeletions.Enabled
+	return p, nil
+}
+
+// getRaw returns the branch's protection, or nil when it is unprotected.
+func (b *gitHubBackend) getRaw(ctx context.Context, repo Repository, branch string) (*ghProtection, error) {
+	var raw ghProtection
+	err := b.client.Do(ctx, http.MethodGet, b.branchPath(repo, branch)+"/protection", nil, &raw)
+	if errors.Is(err, restapi.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &raw, nil
+}
+
+// ApplyProtection replaces the branch protection. GitHub's update endpoint
+// replaces every field, so existing push restrictions are read back and
+// preserved. Signed commits have their own endpoint.
+func (b *gitHubBackend) ApplyProtection(ctx context.Context, repo Repository, branch string, current, desired Protection) error {
+	path := b.branchPath(repo, branch) + "/protection"
+
+	if !desired.Protected {
+		if !current.Protected {
+			return nil
+		}
+		if err := b.client.Do(ctx, http.MethodDelete, path, nil, nil); err != nil {
+			return fmt.Errorf("failed to remove protection: %w", err)
+		}
+		return nil
+	}
+
+	update := ghProtectionUpdate{
+		EnforceAdmins:    desired.EnforceAdmins,
+		AllowForcePushes: desired.AllowForcePushes,
+		AllowDeletions:   desired.AllowDeletions,
+	}
+	if desired.RequireStatusChecks {
+		update.RequiredStatusChecks = &ghStatusChecksUpdate{
+			Strict:   desired.StrictStatusChecks,
+			Contexts: append([]string{}, desired.StatusChecks...),
+		}
+	}
+	if desired.RequiredReviews > 0 || desired.DismissStaleReviews || desired.RequireCodeOwnerReviews {
+		update.RequiredPullRequestReviews = &ghReviewsUpdate{
+			DismissStaleReviews:          desired.DismissStaleReviews,
+			RequireCodeOwnerReviews:      desired.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: desired.RequiredReviews,
+		}
+	}
+
+	if current.Protected {
+		raw, err := b.getRaw(ctx, repo, branch)
+		if err != nil {
+			return err
+		}
+		if raw != nil && raw.Restrictions != nil {
+			r := &ghRestrictions{Users: []string{}, Teams: []string{}, Apps: []string{}}
+			for _, u := range raw.Restrictions.Users {
+				r.Users = append(r.Users, u.Login)
+			}
+			for _, t := range raw.Restrictions.Teams {
+				r.Teams = append(r.Teams, t.Slug)
+			}
+			for _, a := range raw.Restrictions.Apps {
+				r.Apps = append(r.Apps, a.Slug)
+			}
+			update.Restrictions = r
+		}
+	}
+
+	if err := b.client.Do(ctx, http.MethodPut, path, update, nil); err != nil {
+		return fmt.Errorf("failed to update protection: %w", err)
+	}
+
+	// A freshly created protection never requires signatures, so compare
+	// against what the update above left behind.
+	signed := current.Protected && current.RequireSignedCommits
+	switch {
+	case desired.RequireSignedCommits && !signed:
+		if err := b.client.Do(ctx, http.MethodPost, path+"/required_signatures", nil, nil); err != nil {
+			return fmt.Errorf("failed to require signed commits: %w", err)
+		}
+	case !desired.RequireSignedCommits && signed:
+		if err := b.client.Do(ctx, http.MethodDelete, path+"/required_signatures", nil, nil); err != nil {
+			return fmt.Errorf("failed to stop requiring signed commits: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package protect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// gitLabBackend implements Backend against the GitLab REST API (v4).
+//
+// GitLab spreads the policy settings over several resources: force pushes
+// and code owner approval live on the protected branch, review counts on the
+// project approval settings, pipeline success on the project itself and
+// signed commits on the push rule. Approval, pipeline and push rule settings
+// are project-wide, so every branch rule of a project shares them.
+type gitLabBackend struct {
+	client *restapi.Client
+}
+
+func newGitLabBackend(baseURL, token string) *gitLabBackend {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com/api/v4"
+	}
+	return &gitLabBackend{
+		client: restapi.New("gitlab", baseURL, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("PRIVATE-TOKEN", token)
+			}
+		}),
+	}
+}
+
+func (b *gitLabBackend) Provider() string { return "gitlab" }
+
+// Unsupported lists the settings GitLab has no equivalent for. Protected
+// branches can never be deleted by a push and administrators are not exempt
+// from them, so allow_deletions and enforce_admins have nothing to change.
+func (b *gitLabBackend) Unsupported() []string {
+	return []string{SettingStatusChecks, SettingStrictStatusChecks, SettingAllowDeletions, SettingEnforceAdmins}
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glProject struct {
+	ID                               int64  `json:"id"`
+	Path                             string `json:"path"`
+	PathWithNamespace                string `json:"path_with_namespace"`
+	DefaultBranch                    string `json:"default_branch"`
+	Archived                         bool   `json:"archived"`
+	OnlyAllowMergeIfPipelineSucceeds bool   `json:"only_allow_merge_if_pipeline_succeeds"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glProtectedBranch struct {
+	Name                      string `json:"name"`
+	AllowForcePush            bool   `json:"allow_force_push"`
+	CodeOwnerApprovalRequired bool   `json:"code_owner_approval_required"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glApprovals struct {
+	ApprovalsBeforeMerge int  `json:"approvals_before_merge"`
+	ResetApprovalsOnPush bool `json:"reset_approvals_on_push"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glPushRule struct {
+	RejectUnsignedCommits bool `json:"reject_unsigned_commits"`
+}
+
+func (b *gitLabBackend) ListRepositories(ctx context.Context, org string) ([]Repository, error) {
+	path := "/groups/" + url.PathEscape(org) + "/projects?include_subgroups=true"
+	projects, err := restapi.ListAll[glProject](ctx, b.client, path, "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects of %s: %w", org, err)
+	}
+
+	out := make([]Repository, 0, len(projects))
+	for _, p := range projects {
+		out = append(out, Repository{
+			ID:            p.ID,
+			Name:          p.Path,
+			FullName:      p.PathWithNamespace,
+			DefaultBranch: p.DefaultBranch,
+			Archived:      p.Archived,
+		})
+	}
+	return out, nil
+}
+
+func (b *gitLabBackend) projectPath(repo Repository) string {
+	if repo.ID != 0 {
+		return "/projects/" + strconv.FormatInt(repo.ID, 10)
+	}
+	return "/projects/" + url.PathEscape(repo.FullName)
+}
+
+func (b *gitLabBackend) GetProtection(ctx context.Context, repo Repository, branch string) (Protection, error) {
+	project := b.projectPath(repo)
+
+	err := b.client.Do(ctx, http.MethodGet, project+"/repository/branches/"+url.PathEscape(branch), nil, nil)
+	if errors.Is(err, restapi.ErrNotFound) {
+		return Protection{}, ErrBranchNotFound
+	}
+	if err != nil {
+		return Protection{}, err
+	}
+
+	var pb glProtectedBranch
+	err = b.client.Do(ctx, http.MethodGet, project+"/protected_branches/"+url.PathEscape(branch), nil, &pb)
+	if errors.Is(err, restapi.ErrNotFound) {
+		return Unprotected(), nil
+	}
+	if err != nil {
+		return Protection{}, err
+	}
+
+	p := Protection{
+		Protected:               true,
+		AllowForcePushes:        pb.AllowForcePush,
+		RequireCodeOwnerReviews: pb.CodeOwnerApprovalRequired,
+	}
+
+	var proj glProject
+	if err := b.client.Do(ctx, http.MethodGet, project, nil, &proj); err != nil {
+		return Protection{}, err
+	}
+	p.RequireStatusChecks = proj.OnlyAllowMergeIfPipelineSucceeds
+
+	var approvals glApprovals
+	if err := b.client.Do(ctx, http.MethodGet, project+"/approvals", nil, &approvals); err != nil {
+		return Protection{}, err
+	}
+	p.RequiredReviews = approvals.ApprovalsBeforeMerge
+	p.DismissStaleReviews = approvals.ResetApprovalsOnPush
+
+	var rule *glPushRule
+	err = b.client.Do(ctx, http.MethodGet, project+"/push_rule", nil, &rule)
+	if err != nil && !errors.Is(err, restapi.ErrNotFound) {
+		return Protection{}, err
+	}
+	p.RequireSignedCommits = rule != nil && rule.RejectUnsignedCommits
+	return p, nil
+}
+
+func (b *gitLabBackend) ApplyProtection(ctx context.Context, repo Repository, branch string, current, desired Protection) error {
+	project := b.projectPath(repo)
+
+	branchSettings := map[string]any{
+		"allow_force_push":             desired.AllowForcePushes,
+		"code_owner_approval_required": desired.RequireCodeOwnerReviews,
+	}
+	switch {
+	case !desired.Protected:
+		// 승인, 파이프라인, 푸시 규칙은 프로젝트 설정이라 아래에서 따로 되돌린다
+		if current.Protected {
+			if err := b.client.Do(ctx, http.MethodDelete, project+"/protected_branches/"+url.PathEscape(branch), nil, nil); err != nil {
+				return fmt.Errorf("failed to unprotect branch: %w", err)
+			}
+		}
+	case !current.Protected:
+		branchSettings["name"] = branch
+		if err := b.client.Do(ctx, http.MethodPost, project+"/protected_branches", branchSettings, nil); err != nil {
+			return fmt.Errorf("failed to protect branch: %w", err)
+		}
+	case current.AllowForcePushes != desired.AllowForcePushes || current.RequireCodeOwnerReviews != desired.RequireCodeOwnerReviews:
+		if err := b.client.Do(ctx, http.MethodPatch, project+"/protected_branches/"+url.PathEscape(branch), branchSettings, nil); err != nil {
+			return fmt.Errorf("failed to update protected branch: %w", err)
+		}
+	}
+
+	if current.RequiredReviews != desired.RequiredReviews || current.DismissStaleReviews != desired.DismissStaleReviews {
+		body := map[string]any{
+			"approvals_before_merge":  desired.RequiredReviews,
+			"reset_approvals_on_push": desired.DismissStaleReviews,
+		}
+		if err := b.client.Do(ctx, http.MethodPost, project+"/approvals", body, nil); err != nil {
+			return fmt.Errorf("failed to update approval settings: %w", err)
+		}
+	}
+
+	if current.RequireStatusChecks != desired.RequireStatusChecks {
+		body := map[string]any{"only_allow_merge_if_pipeline_succeeds": desired.RequireStatusChecks}
+		if err := b.client.Do(ctx, http.MethodPut, project, body, nil); err != nil {
+			return fmt.Errorf("failed to update pipeline requirement: %w", err)
+		}
+	}
+
+	if current.RequireSignedCommits != desired.RequireSignedCommits {
+		body := map[string]any{"reject_unsigned_commits": desired.RequireSignedCommits}
+		var rule *glPushRule
+		err := b.client.Do(ctx, http.MethodGet, project+"/push_rule", nil, &rule)
+		if err != nil && !errors.Is(err, restapi.ErrNotFound) {
+			return err
+		}
+		method := http.MethodPut
+		if rule == nil {
+			method = http.MethodPost
+		}
+		if err := b.client.Do(ctx, method, project+"/push_rule", body, nil); err != nil {
+			return fmt.Errorf("failed to update push rule: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package protect enforces a declarative branch protection policy across
+// every repository of an organization or group.
+package protect
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Setting names, as written in policy files and drift reports.
+const (
+	SettingProtected               = "protected"
+	SettingRequiredReviews         = "required_reviews"
+	SettingDismissStaleReviews     = "dismiss_stale_reviews"
+	SettingRequireCodeOwnerReviews = "require_code_owner_reviews"
+	SettingRequireStatusChecks     = "require_status_checks"
+	SettingStatusChecks            = "status_checks"
+	SettingStrictStatusChecks      = "strict_status_checks"
+	SettingRequireSignedCommits    = "require_signed_commits"
+	SettingAllowForcePushes        = "allow_force_pushes"
+	SettingAllowDeletions          = "allow_deletions"
+	SettingEnforceAdmins           = "enforce_admins"
+)
+
+// Policy is a declarative branch protection policy.
+//
+//	repositories:
+//	  include: ["*"]
+//	  exclude: ["sandbox-*"]
+//	branches:
+//	  - required_reviews: 2          # no branch: the repository default branch
+//	    require_code_owner_reviews: true
+//	    status_checks: [ci/build, ci/test]
+//	    require_signed_commits: true
+//	    allow_force_pushes: false
+//	  - branch: release
+//	    allow_deletions: false
+//
+// Settings left out of a rule are not enforced: whatever the repository
+// currently has is kept.
+type Policy struct {
+	Repositories Selector     `yaml:"repositories" json:"repositories"`
+	Branches     []BranchRule `yaml:"branches" json:"branches"`
+}
+
+// Selector chooses the repositories a policy applies to. Patterns use
+// path.Match syntax against the repository name.
+type Selector struct {
+	Include         []string `yaml:"include" json:"include,omitempty"`
+	Exclude         []string `yaml:"exclude" json:"exclude,omitempty"`
+	IncludeArchived bool     `yaml:"include_archived" json:"includeArchived,omitempty"`
+}
+
+// BranchRule is the protection required on one branch.
+type BranchRule struct {
+	// Branch is the branch name; empty selects each repository's default
+	// branch.
+	Branch string `yaml:"branch" json:"branch,omitempty"`
+	Rules  `yaml:",inline"`
+}
+
+// Rules lists the enforced protection settings. Nil fields are not enforced.
+type Rules struct {
+	RequiredReviews         *int     `yaml:"required_reviews" json:"requiredReviews,omitempty"`
+	DismissStaleReviews     *bool    `yaml:"dismiss_stale_reviews" json:"dismissStaleReviews,omitempty"`
+	RequireCodeOwnerReviews *bool    `yaml:"require_code_owner_reviews" json:"requireCodeOwnerReviews,omitempty"`
+	RequireStatusChecks     *bool    `yaml:"require_status_checks" json:"requireStatusChecks,omitempty"`
+	StatusChecks            []string `yaml:"status_checks" json:"statusChecks,omitempty"`
+	StrictStatusChecks      *bool    `yaml:"strict_status_checks" json:"strictStatusChecks,omitempty"`
+	RequireSignedCommits    *bool    `yaml:"require_signed_commits" json:"requireSignedCommits,omitempty"`
+	AllowForcePushes        *bool    `yaml:"allow_force_pushes" json:"allowForcePushes,omitempty"`
+	AllowDeletions          *bool    `yaml:"allow_deletions" json:"allowDeletions,omitempty"`
+	EnforceAdmins           *bool    `yaml:"enforce_admins" json:"enforceAdmins,omitempty"`
+}
+
+// LoadPolicy reads and validates a policy file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy %s: %w", path, err)
+	}
+	return ParsePolicy(data)
+}
+
+// ParsePolicy parses and validates policy YAML. Unknown keys are rejected so
+// that a misspelled setting is not silently left unenforced.
+func ParsePolicy(data []byte) (*Policy, error) {
+	var p Policy
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks the policy for contradictions.
+func (p *Policy) Validate() error {
+	if len(p.Branches) == 0 {
+		return fmt.Errorf("invalid policy: no branch rules")
+	}
+
+	seen := make(map[string]bool)
+	for i, b := range p.Branches {
+		name := b.Branch
+		if name == "" {
+			name = "(default branch)"
+		}
+		if seen[name] {
+			return fmt.Errorf("invalid policy: branch %s listed more than once", name)
+		}
+		seen[name] = true
+
+		if b.RequiredReviews != nil && (*b.RequiredReviews < 0 || *b.RequiredReviews > 10) {
+			return fmt.Errorf("invalid policy: branches[%d].required_reviews must be between 0 and 10", i)
+		}
+		if b.RequireStatusChecks != nil && !*b.RequireStatusChecks && len(b.StatusChecks) > 0 {
+			return fmt.Errorf("invalid policy: branches[%d] lists status_checks but sets require_status_checks to false", i)
+		}
+	}
+
+	for _, pattern := range append(append([]string{}, p.Repositories.Include...), p.Repositories.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid policy: bad repository pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the policy applies to a repository.
+func (s Selector) Matches(repo Repository) bool {
+	if repo.Archived && !s.IncludeArchived {
+		return false
+	}
+	for _, pattern := range s.Exclude {
+		if ok, _ := path.Match(pattern, repo.Name); ok {
+			return false
+		}
+	}
+	if len(s.Include) == 0 {
+		return true
+	}
+	for _, pattern := range s.Include {
+		if ok, _ := path.Match(pattern, repo.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Protection is the effective protection of a branch, normalized across
+// providers.
+type Protection struct {
+	Protected               bool     `json:"protected"`
+	RequiredReviews         int      `json:"requiredReviews"`
+	DismissStaleReviews     bool     `json:"dismissStaleReviews"`
+	RequireCodeOwnerReviews bool     `json:"requireCodeOwnerReviews"`
+	RequireStatusChecks     bool     `json:"requireStatusChecks"`
+	StatusChecks            []string `json:"statusChecks,omitempty"`
+	StrictStatusChecks      bool     `json:"strictStatusChecks"`
+	RequireSignedCommits    bool     `json:"requireSignedCommits"`
+	AllowForcePushes        bool     `json:"allowForcePushes"`
+	AllowDeletions          bool     `json:"allowDeletions"`
+	EnforceAdmins           bool     `json:"enforceAdmins"`
+}
+
+// Unprotected is the state of a branch without any protection: anyone with
+// write access may force-push to or delete it.
+func Unprotected() Protection {
+	return Protection{AllowForcePushes: true, AllowDeletions: true}
+}
+
+// Desired returns current with the rules applied on top. Settings the rules
+// do not mention keep their current value.
+func (r Rules) Desired(current Protection) Protection {
+	d := current
+	d.Protected = true
+	d.StatusChecks = append([]string(nil), current.StatusChecks...)
+
+	if r.RequiredReviews != nil {
+		d.RequiredReviews = *r.RequiredReviews
+	}
+	setBool(&d.DismissStaleReviews, r.DismissStaleReviews)
+	setBool(&d.RequireCodeOwnerReviews, r.RequireCodeOwnerReviews)
+	setBool(&d.RequireStatusChecks, r.RequireStatusChecks)
+	if len(r.StatusChecks) > 0 {
+		d.RequireStatusChecks = true
+		d.StatusChecks = sortedUnique(r.StatusChecks)
+	}
+	if !d.RequireStatusChecks {
+		d.StatusChecks = nil
+		d.StrictStatusChecks = false
+	}
+	setBool(&d.StrictStatusChecks, r.StrictStatusChecks)
+	if d.StrictStatusChecks {
+		d.RequireStatusChecks = true
+	}
+	setBool(&d.RequireSignedCommits, r.RequireSignedCommits)
+	setBool(&d.AllowForcePushes, r.AllowForcePushes)
+	setBool(&d.AllowDeletions, r.AllowDeletions)
+	setBool(&d.EnforceAdmins, r.EnforceAdmins)
+	return d
+}
+
+func setBool(dst *bool, v *bool) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func sortedUnique(values []string) []string {
+	set := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !set[v] {
+			set[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Change is one setting that differs from the policy.
+type Change struct {
+	Setting string `json:"setting"`
+	Current any    `json:"current"`
+	Desired any    `json:"desired"`
+}
+
+// Diff lists the settings where current differs from desired.
+func Diff(current, desired Protection) []Change {
+	var changes []Change
+	add := func(setting string, cur, want any) {
+		if !reflect.DeepEqual(cur, want) {
+			changes = append(changes, Change{Setting: setting, Current: cur, Desired: want})
+		}
+	}
+
+	add(SettingProtected, current.Protected, desired.Protected)
+	add(SettingRequiredReviews, current.RequiredReviews, desired.RequiredReviews)
+	add(SettingDismissStaleReviews, current.DismissStaleReviews, desired.DismissStaleReviews)
+	add(SettingRequireCodeOwnerReviews, current.RequireCodeOwnerReviews, desired.RequireCodeOwnerReviews)
+	add(SettingRequireStatusChecks, current.RequireStatusChecks, desired.RequireStatusChecks)
+	add(SettingStatusChecks, sortedUnique(current.StatusChecks), sortedUnique(desired.StatusChecks))
+	add(SettingStrictStatusChecks, current.StrictStatusChecks, desired.StrictStatusChecks)
+	add(SettingRequireSignedCommits, current.RequireSignedCommits, desired.RequireSignedCommits)
+	add(SettingAllowForcePushes, current.AllowForcePushes, desired.AllowForcePushes)
+	add(SettingAllowDeletions, current.AllowDeletions, desired.AllowDeletions)
+	add(SettingEnforceAdmins, current.EnforceAdmins, desired.EnforceAdmins)
+	return changes
+}
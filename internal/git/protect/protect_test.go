@@ -0,0 +1,311 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package protect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/testutil/mocks"
+)
+
+const testPolicy = `
+repositories:
+  exclude: ["sandbox-*"]
+branches:
+  - required_reviews: 2
+    require_code_owner_reviews: true
+    status_checks: [ci/test, ci/build]
+    require_signed_commits: true
+    allow_force_pushes: false
+  - branch: release
+    allow_deletions: false
+`
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	require.Len(t, p.Branches, 2)
+	assert.Equal(t, "", p.Branches[0].Branch)
+	assert.Equal(t, 2, *p.Branches[0].RequiredReviews)
+	assert.Equal(t, []string{"ci/test", "ci/build"}, p.Branches[0].StatusChecks)
+	assert.Nil(t, p.Branches[0].EnforceAdmins)
+	assert.Equal(t, "release", p.Branches[1].Branch)
+
+	_, err = ParsePolicy([]byte("branches:\n  - required_review: 2\n"))
+	assert.Error(t, err, "misspelled settings must be rejected")
+
+	_, err = ParsePolicy([]byte("branches:\n  - required_reviews: 20\n"))
+	assert.Error(t, err)
+
+	_, err = ParsePolicy([]byte("branches:\n  - allow_deletions: false\n  - allow_force_pushes: false\n"))
+	assert.Error(t, err, "duplicate default branch rules must be rejected")
+
+	_, err = ParsePolicy([]byte("repositories:\n  include: [\"*\"]\n"))
+	assert.Error(t, err)
+}
+
+func TestSelectorMatches(t *testing.T) {
+	s := Selector{Include: []string{"svc-*", "web"}, Exclude: []string{"svc-legacy"}}
+	assert.True(t, s.Matches(Repository{Name: "svc-api"}))
+	assert.True(t, s.Matches(Repository{Name: "web"}))
+	assert.False(t, s.Matches(Repository{Name: "svc-legacy"}))
+	assert.False(t, s.Matches(Repository{Name: "docs"}))
+	assert.False(t, s.Matches(Repository{Name: "svc-old", Archived: true}))
+	assert.True(t, Selector{}.Matches(Repository{Name: "anything"}))
+}
+
+func TestDesiredAndDiff(t *testing.T) {
+	p, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	rule := p.Branches[0]
+
+	current := Protection{Protected: true, RequiredReviews: 1, EnforceAdmins: true, AllowDeletions: true}
+	desired := rule.Desired(current)
+	assert.True(t, desired.EnforceAdmins, "settings outside the policy are kept")
+	assert.True(t, desired.AllowDeletions)
+	assert.True(t, desired.RequireStatusChecks)
+	assert.Equal(t, []string{"ci/build", "ci/test"}, desired.StatusChecks)
+
+	changes := Diff(current, desired)
+	var settings []string
+	for _, c := range changes {
+		settings = append(settings, c.Setting)
+	}
+	assert.Equal(t, []string{
+		SettingRequiredReviews,
+		SettingRequireCodeOwnerReviews,
+		SettingRequireStatusChecks,
+		SettingStatusChecks,
+		SettingRequireSignedCommits,
+	}, settings)
+
+	assert.Empty(t, Diff(desired, rule.Desired(desired)))
+
+	unprotected := rule.Desired(Unprotected())
+	assert.True(t, unprotected.Protected)
+	assert.False(t, unprotected.AllowForcePushes)
+	assert.True(t, unprotected.AllowDeletions)
+}
+
+type fakeBackend struct {
+	mocks.CallLog
+	mu          sync.Mutex
+	repos       []Repository
+	protection  map[string]Protection // repo@branch
+	unsupported []string
+}
+
+func (f *fakeBackend) Provider() string { return "fake" }
+
+func (f *fakeBackend) Unsupported() []string { return f.unsupported }
+
+func (f *fakeBackend) ListRepositories(context.Context, string) ([]Repository, error) {
+	return f.repos, nil
+}
+
+func (f *fakeBackend) GetProtection(_ context.Context, repo Repository, branch string) (Protection, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.protection[repo.Name+"@"+branch]
+	if !ok {
+		return Protection{}, ErrBranchNotFound
+	}
+	return p, nil
+}
+
+func (f *fakeBackend) ApplyProtection(_ context.Context, repo Repository, branch string, _, desired Protection) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.protection[repo.Name+"@"+branch] = desired
+	return f.Call("%s@%s", repo.Name, branch)
+}
+
+func newFakeBackend() *fakeBackend {
+	compliant := Protection{
+		Protected: true, RequiredReviews: 2, RequireCodeOwnerReviews: true,
+		RequireStatusChecks: true, StatusChecks: []string{"ci/build", "ci/test"},
+		RequireSignedCommits: true, EnforceAdmins: true,
+	}
+	return &fakeBackend{
+		repos: []Repository{
+			{Name: "api", FullName: "org/api", DefaultBranch: "main"},
+			{Name: "web", FullName: "org/web", DefaultBranch: "master"},
+			{Name: "sandbox-x", FullName: "org/sandbox-x", DefaultBranch: "main"},
+			{Name: "old", FullName: "org/old", DefaultBranch: "main", Archived: true},
+		},
+		protection: map[string]Protection{
+			"api@main":    compliant,
+			"api@release": {Protected: true},
+			"web@master":  Unprotected(),
+		},
+	}
+}
+
+func TestEnforcerDryRun(t *testing.T) {
+	p, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	backend := newFakeBackend()
+
+	report, err := NewEnforcer(backend, p, Options{DryRun: true}).Run(context.Background(), "org")
+	require.NoError(t, err)
+	assert.Empty(t, backend.Calls(), "dry run must not change anything")
+
+	statuses := make(map[string]string)
+	for _, r := range report.Results {
+		statuses[r.Repository+"@"+r.Branch] = r.Status
+	}
+	assert.Equal(t, map[string]string{
+		"org/api@main":    StatusCompliant,
+		"org/api@release": StatusCompliant,
+		"org/web@master":  StatusDrift,
+		"org/web@release": StatusSkipped,
+	}, statuses)
+	assert.True(t, report.HasDrift())
+
+	plan := report.Plan()
+	_, update, _ := plan.Counts()
+	assert.Equal(t, 1, update)
+	changes := plan.Changes()
+	assert.Equal(t, "org/web@master", changes[0].Target)
+
+	var out bytes.Buffer
+	report.PrintPlan(&out)
+	assert.Contains(t, out.String(), `~ branch_protection "org/web@master"`)
+	assert.Contains(t, out.String(), "Plan: 0 to create, 1 to update, 0 to delete.")
+}
+
+func TestEnforcerApply(t *testing.T) {
+	p, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	backend := newFakeBackend()
+	backend.unsupported = []string{SettingStatusChecks, SettingEnforceAdmins}
+	before := backend.protection["web@master"]
+
+	var applied []Protection
+	opts := Options{
+		Repositories: []string{"web"},
+		Applied: func(repo Repository, branch string, before, after Protection) {
+			assert.Equal(t, "web@master", repo.Name+"@"+branch)
+			applied = append(applied, before, after)
+		},
+	}
+	report, err := NewEnforcer(backend, p, opts).Run(context.Background(), "org")
+	require.NoError(t, err)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, StatusApplied, report.Results[0].Status)
+	assert.Equal(t, []string{"web@master"}, backend.Calls())
+	assert.Equal(t, []Protection{before, backend.protection["web@master"]}, applied)
+	assert.Equal(t, []string{SettingStatusChecks}, report.Unsupported)
+	for _, c := range report.Results[0].Changes {
+		assert.NotEqual(t, SettingStatusChecks, c.Setting)
+	}
+
+	// A second run finds nothing left to change.
+	backend.Reset()
+	report, err = NewEnforcer(backend, p, Options{Repositories: []string{"web"}}).Run(context.Background(), "org")
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompliant, report.Results[0].Status)
+	assert.Empty(t, backend.Calls())
+}
+
+func TestGitHubBackendApply(t *testing.T) {
+	var (
+		update    map[string]any
+		signature string
+		removed   bool
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/org/api/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"main"}`))
+	})
+	mux.HandleFunc("/repos/org/api/branches/main/protection", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{
+				"required_pull_request_reviews": {"required_approving_review_count": 1},
+				"enforce_admins": {"enabled": true},
+				"allow_force_pushes": {"enabled": true},
+				"restrictions": {"users": [{"login": "octocat"}], "teams": [], "apps": []}
+			}`))
+		case http.MethodPut:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			_, _ = w.Write([]byte(`{}`))
+		case http.MethodDelete:
+			removed = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	mux.HandleFunc("/repos/org/api/branches/main/protection/required_signatures", func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Method
+		_, _ = w.Write([]byte(`{"enabled":true}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	backend := newGitHubBackend(srv.URL, "token")
+	repo := Repository{Name: "api", FullName: "org/api"}
+
+	current, err := backend.GetProtection(context.Background(), repo, "main")
+	require.NoError(t, err)
+	assert.Equal(t, Protection{Protected: true, RequiredReviews: 1, EnforceAdmins: true, AllowForcePushes: true}, current)
+
+	_, err = backend.GetProtection(context.Background(), repo, "missing")
+	assert.ErrorIs(t, err, ErrBranchNotFound)
+
+	p, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	desired := p.Branches[0].Desired(current)
+	require.NoError(t, backend.ApplyProtection(context.Background(), repo, "main", current, desired))
+
+	assert.Equal(t, false, update["allow_force_pushes"])
+	assert.Equal(t, true, update["enforce_admins"])
+	assert.Equal(t, map[string]any{"strict": false, "contexts": []any{"ci/build", "ci/test"}}, update["required_status_checks"])
+	assert.Equal(t, float64(2), update["required_pull_request_reviews"].(map[string]any)["required_approving_review_count"])
+	assert.Equal(t, []any{"octocat"}, update["restrictions"].(map[string]any)["users"], "push restrictions are preserved")
+	assert.Equal(t, http.MethodPost, signature)
+
+	require.NoError(t, backend.ApplyProtection(context.Background(), repo, "main", desired, Unprotected()))
+	assert.True(t, removed, "an unprotected desired state removes the protection")
+}
+
+func TestGitLabBackendGetProtection(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/projects/7/repository/branches/main", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"main"}`))
+	})
+	mux.HandleFunc("/projects/7/protected_branches/main", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"main","allow_force_push":false,"code_owner_approval_required":true}`))
+	})
+	mux.HandleFunc("/projects/7", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":7,"only_allow_merge_if_pipeline_succeeds":true}`))
+	})
+	mux.HandleFunc("/projects/7/approvals", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"approvals_before_merge":2,"reset_approvals_on_push":true}`))
+	})
+	mux.HandleFunc("/projects/7/push_rule", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`null`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	backend := newGitLabBackend(srv.URL, "token")
+	p, err := backend.GetProtection(context.Background(), Repository{ID: 7, FullName: "group/app"}, "main")
+	require.NoError(t, err)
+	assert.Equal(t, Protection{
+		Protected:               true,
+		RequiredReviews:         2,
+		DismissStaleReviews:     true,
+		RequireCodeOwnerReviews: true,
+		RequireStatusChecks:     true,
+	}, p)
+}
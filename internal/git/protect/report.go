@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package protect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// Report is the outcome of an enforcement run.
+type Report struct {
+	Provider    string    `json:"provider"`
+	Org         string    `json:"org"`
+	DryRun      bool      `json:"dryRun"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Unsupported lists policy settings the provider cannot enforce; they
+	// are left out of the drift comparison.
+	Unsupported []string `json:"unsupported,omitempty"`
+	Results     []Result `json:"results"`
+}
+
+// Result is the outcome for one repository branch.
+type Result struct {
+	Repository string   `json:"repository"`
+	Branch     string   `json:"branch"`
+	Status     string   `json:"status"`
+	Changes    []Change `json:"changes,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// Counts returns the number of results per status.
+func (r *Report) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, res := range r.Results {
+		counts[res.Status]++
+	}
+	return counts
+}
+
+// HasDrift reports whether any branch differed from the policy.
+func (r *Report) HasDrift() bool {
+	for _, res := range r.Results {
+		if len(res.Changes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteJSON writes the report to path.
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// PrintDiff writes each non-compliant branch with its setting changes.
+func (r *Report) PrintDiff(w io.Writer) {
+	for _, res := range r.Results {
+		if res.Status == StatusCompliant {
+			continue
+		}
+		fmt.Fprintf(w, "%s %s@%s (%s)\n", statusIcon(res.Status), res.Repository, res.Branch, res.Status)
+		for _, c := range res.Changes {
+			fmt.Fprintf(w, "    %s: %s → %s\n", c.Setting, formatValue(c.Current), formatValue(c.Desired))
+		}
+		if res.Error != "" {
+			fmt.Fprintf(w, "    %s\n", res.Error)
+		}
+	}
+}
+
+// Plan returns the drifting branches as planned branch protection updates.
+// Settings whose desired value is empty are kept, unlike
+// provider.DiffAttributes, because clearing a setting is a change.
+func (r *Report) Plan() *provider.Plan {
+	plan := provider.NewPlan()
+	for _, res := range r.Results {
+		if res.Status != StatusDrift || len(res.Changes) == 0 {
+			continue
+		}
+		attrs := make([]provider.AttributeChange, 0, len(res.Changes))
+		for _, c := range res.Changes {
+			attrs = append(attrs, provider.AttributeChange{Name: c.Setting, Before: c.Current, After: c.Desired})
+		}
+		plan.Record(provider.PlannedChange{
+			Action:     provider.ChangeUpdate,
+			Resource:   provider.ResourceBranchProtection,
+			Target:     res.Repository + "@" + res.Branch,
+			Attributes: attrs,
+		})
+	}
+	return plan
+}
+
+// PrintPlan writes the drift of a dry run as a plan, followed by the
+// branches that could not be checked.
+func (r *Report) PrintPlan(w io.Writer) {
+	r.Plan().Render(w)
+	for _, res := range r.Results {
+		if res.Status == StatusFailed {
+			fmt.Fprintf(w, "%s %s@%s (%s)\n    %s\n", statusIcon(res.Status), res.Repository, res.Branch, res.Status, res.Error)
+		}
+	}
+}
+
+// PrintSummary writes the per-status totals.
+func (r *Report) PrintSummary(w io.Writer) {
+	title := "Branch protection summary"
+	if r.DryRun {
+		title = "Branch protection audit (dry run)"
+	}
+	fmt.Fprintf(w, "\n📋 %s: %s:%s\n", title, r.Provider, r.Org)
+
+	counts := r.Counts()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tBRANCHES")
+	for _, s := range []string{StatusCompliant, StatusDrift, StatusApplied, StatusSkipped, StatusFailed} {
+		if counts[s] > 0 {
+			fmt.Fprintf(tw, "%s\t%d\n", s, counts[s])
+		}
+	}
+	_ = tw.Flush()
+
+	if len(r.Unsupported) > 0 {
+		fmt.Fprintf(w, "⚠️  Not enforceable on %s: %s\n", r.Provider, strings.Join(r.Unsupported, ", "))
+	}
+}
+
+func statusIcon(status string) string {
+	switch status {
+	case StatusDrift:
+		return "~"
+	case StatusApplied:
+		return "✓"
+	case StatusFailed:
+		return "✗"
+	default:
+		return "-"
+	}
+}
+
+func formatValue(v any) string {
+	if list, ok := v.([]string); ok {
+		if len(list) == 0 {
+			return "[]"
+		}
+		return "[" + strings.Join(list, ", ") + "]"
+	}
+	return fmt.Sprint(v)
+}
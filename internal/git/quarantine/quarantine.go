@@ -0,0 +1,365 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package quarantine keeps repositories that fail every run of a bulk
+// operation out of later runs.
+//
+// A repository is quarantined after Threshold consecutive failed runs with
+// an error that is not transient (permission denied, missing, corrupted, too
+// large, ...). Bulk operations skip it until its retry time, which doubles
+// with every quarantine, or until the repository changes on the provider
+// side. Either way the next run puts it on probation: one attempt, after
+// which it is released on success or quarantined again on failure.
+package quarantine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultThreshold is the number of consecutive failed runs after which
+	// a repository is quarantined.
+	DefaultThreshold = 3
+	// DefaultBackoff is how long the first quarantine lasts.
+	DefaultBackoff = 24 * time.Hour
+	// MaxBackoff caps the doubling quarantine period.
+	MaxBackoff = 7 * 24 * time.Hour
+)
+
+// ErrNotQuarantined is returned when clearing a repository that has no
+// entry.
+var ErrNotQuarantined = errors.New("repository is not quarantined")
+
+// Reason classifies why a repository keeps failing.
+type Reason string
+
+const (
+	ReasonPermission     Reason = "permission"
+	ReasonAuthentication Reason = "authentication"
+	ReasonNotFound       Reason = "not_found"
+	ReasonCorrupted      Reason = "corrupted"
+	ReasonTooLarge       Reason = "too_large"
+	ReasonError          Reason = "error"
+)
+
+// reasonPatterns map git and API error messages to reasons, in order.
+var reasonPatterns = []struct {
+	reason   Reason
+	patterns []string
+}{
+	{ReasonAuthentication, []string{"authentication failed", "401", "could not read username", "invalid credentials"}},
+	{ReasonPermission, []string{"403", "permission denied", "access denied", "forbidden", "insufficient permissions"}},
+	{ReasonNotFound, []string{"404", "not found", "does not exist"}},
+	{ReasonTooLarge, []string{"413", "too large", "exceeds", "file size limit", "no space left", "insufficient disk space"}},
+	{ReasonCorrupted, []string{"corrupt", "bad object", "index-pack failed", "invalid object", "fsck", "unpack failed", "did not send all necessary objects"}},
+}
+
+// transientPatterns mark failures of the network or the provider rather
+// than of the repository, which never count towards a quarantine.
+var transientPatterns = []string{
+	"rate limit", "timed out", "timeout", "connection reset", "connection refused",
+	"could not resolve host", "temporary failure", "502", "503", "504", "tls handshake",
+}
+
+// Classify returns the reason for err, and whether it is transient.
+func Classify(err error) (Reason, bool) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ReasonError, true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, p := range transientPatterns {
+		if strings.Contains(msg, p) {
+			return ReasonError, true
+		}
+	}
+	for _, rp := range reasonPatterns {
+		for _, p := range rp.patterns {
+			if strings.Contains(msg, p) {
+				return rp.reason, false
+			}
+		}
+	}
+	return ReasonError, false
+}
+
+// Entry is the failure record of one repository.
+type Entry struct {
+	Provider   string `json:"provider"`
+	Repository string `json:"repository"`
+	Reason     Reason `json:"reason"`
+	LastError  string `json:"lastError"`
+	// Failures counts consecutive failed runs.
+	Failures     int       `json:"failures"`
+	FirstFailure time.Time `json:"firstFailure"`
+	LastFailure  time.Time `json:"lastFailure"`
+	// Quarantines counts how often the repository has been quarantined
+	// since it last succeeded; it doubles the period each time.
+	Quarantines   int        `json:"quarantines,omitempty"`
+	QuarantinedAt *time.Time `json:"quarantinedAt,omitempty"`
+	RetryAfter    time.Time  `json:"retryAfter,omitzero"`
+	// Fingerprint is the provider-side state of the repository when it was
+	// quarantined. A different fingerprint ends the quarantine early.
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// Quarantined reports whether the repository is quarantined, as opposed to
+// failing without having reached the threshold yet.
+func (e *Entry) Quarantined() bool {
+	return e.QuarantinedAt != nil
+}
+
+// Store is the persisted quarantine list. It is safe for concurrent use.
+type Store struct {
+	path string
+
+	// Threshold and Backoff default to DefaultThreshold and DefaultBackoff.
+	Threshold int
+	Backoff   time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*Entry
+	now     func() time.Time
+}
+
+// DefaultPath returns the quarantine file, GZH_QUARANTINE_FILE or
+// ~/.gzh/quarantine.json.
+func DefaultPath() (string, error) {
+	if path := os.Getenv("GZH_QUARANTINE_FILE"); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".gzh", "quarantine.json"), nil
+}
+
+// Open loads the quarantine list at path. A missing file is an empty list.
+func Open(path string) (*Store, error) {
+	s := &Store{
+		path:      path,
+		Threshold: DefaultThreshold,
+		Backoff:   DefaultBackoff,
+		entries:   make(map[string]*Entry),
+		now:       time.Now,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quarantine list: %w", err)
+	}
+	var entries []*Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse quarantine list %s: %w", path, err)
+	}
+	for _, e := range entries {
+		s.entries[key(e.Provider, e.Repository)] = e
+	}
+	return s, nil
+}
+
+// Default opens the quarantine list at DefaultPath.
+func Default() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return Open(path)
+}
+
+// Path returns the file the list is saved to.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Check decides whether a bulk operation should skip a repository. It
+// returns the repository's entry, if any, and true when the repository is
+// quarantined, its retry time has not come and its fingerprint, when both
+// are known, is unchanged. A quarantined repository that is not skipped is
+// on probation.
+func (s *Store) Check(provider, repository, fingerprint string) (*Entry, bool) {
+	if s == nil {
+		return nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key(provider, repository)]
+	if !ok {
+		return nil, false
+	}
+	entry := *e
+	if !e.Quarantined() {
+		return &entry, false
+	}
+	if fingerprint != "" && e.Fingerprint != "" && fingerprint != e.Fingerprint {
+		return &entry, false
+	}
+	return &entry, s.now().Before(e.RetryAfter)
+}
+
+// RecordFailure records a failed run of a repository and reports whether it
+// was quarantined by it. Transient failures are ignored. fingerprint is the
+// repository's current provider-side state and may be empty.
+func (s *Store) RecordFailure(provider, repository string, err error, fingerprint string) (*Entry, bool) {
+	if s == nil || err == nil {
+		return nil, false
+	}
+	reason, transient := Classify(err)
+	if transient {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	k := key(provider, repository)
+	e, ok := s.entries[k]
+	if !ok {
+		e = &Entry{Provider: provider, Repository: repository, FirstFailure: now}
+		s.entries[k] = e
+	}
+	e.Reason = reason
+	e.LastError = err.Error()
+	e.Failures++
+	e.LastFailure = now
+
+	// A repository on probation goes straight back into quarantine
+	if !e.Quarantined() && e.Failures < s.threshold() {
+		entry := *e
+		return &entry, false
+	}
+	e.Quarantines++
+	e.QuarantinedAt = &now
+	e.RetryAfter = now.Add(s.period(e.Quarantines))
+	e.Fingerprint = fingerprint
+	entry := *e
+	return &entry, true
+}
+
+// RecordSuccess forgets the failures of a repository and reports whether it
+// was quarantined, that is released from probation.
+func (s *Store) RecordSuccess(provider, repository string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(provider, repository)
+	e, ok := s.entries[k]
+	if !ok {
+		return false
+	}
+	delete(s.entries, k)
+	return e.Quarantined()
+}
+
+// List returns every entry, quarantined repositories first, then by
+// provider and name.
+func (s *Store) List() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]Entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Quarantined() != b.Quarantined() {
+			return a.Quarantined()
+		}
+		return key(a.Provider, a.Repository) < key(b.Provider, b.Repository)
+	})
+	return entries
+}
+
+// Clear removes the entry of a repository, or returns ErrNotQuarantined.
+func (s *Store) Clear(provider, repository string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key(provider, repository)
+	if _, ok := s.entries[k]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotQuarantined, k)
+	}
+	delete(s.entries, k)
+	return nil
+}
+
+// ClearAll removes every entry, of one provider when provider is not empty,
+// and returns how many were removed.
+func (s *Store) ClearAll(provider string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for k, e := range s.entries {
+		if provider == "" || e.Provider == provider {
+			delete(s.entries, k)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Save writes the list to disk atomically.
+func (s *Store) Save() error {
+	if s == nil {
+		return nil
+	}
+	entries := s.List()
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode quarantine list: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write quarantine list: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write quarantine list: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) threshold() int {
+	if s.Threshold > 0 {
+		return s.Threshold
+	}
+	return DefaultThreshold
+}
+
+// period returns how long the n-th quarantine lasts.
+func (s *Store) period(n int) time.Duration {
+	period := s.Backoff
+	if period <= 0 {
+		period = DefaultBackoff
+	}
+	for i := 1; i < n && period < MaxBackoff; i++ {
+		period *= 2
+	}
+	return min(period, MaxBackoff)
+}
+
+func key(provider, repository string) string {
+	return provider + "/" + repository
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package quarantine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		err       error
+		reason    Reason
+		transient bool
+	}{
+		{errors.New("fatal: unable to access 'https://github.com/acme/x/': The requested URL returned error: 403"), ReasonPermission, false},
+		{errors.New("remote: Repository not found."), ReasonNotFound, false},
+		{errors.New("error: object file is empty\nfatal: loose object is corrupt"), ReasonCorrupted, false},
+		{errors.New("remote: error: File big.bin is 120 MB; this exceeds the file size limit"), ReasonTooLarge, false},
+		{errors.New("fatal: Authentication failed for 'https://github.com/acme/x/'"), ReasonAuthentication, false},
+		{errors.New("fatal: unable to access: Could not resolve host: github.com"), ReasonError, true},
+		{errors.New("API rate limit exceeded"), ReasonError, true},
+		{fmt.Errorf("clone: %w", context.Canceled), ReasonError, true},
+		{errors.New("exit status 128"), ReasonError, false},
+	}
+	for _, tt := range tests {
+		reason, transient := Classify(tt.err)
+		assert.Equal(t, tt.reason, reason, tt.err.Error())
+		assert.Equal(t, tt.transient, transient, tt.err.Error())
+	}
+}
+
+func TestStoreLifecycle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quarantine.json")
+	store, err := Open(path)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	forbidden := errors.New("The requested URL returned error: 403")
+
+	// Transient failures never count
+	entry, quarantined := store.RecordFailure("github", "acme/app", errors.New("connection reset by peer"), "fp1")
+	assert.Nil(t, entry)
+	assert.False(t, quarantined)
+
+	for i := 1; i < DefaultThreshold; i++ {
+		entry, quarantined = store.RecordFailure("github", "acme/app", forbidden, "fp1")
+		assert.False(t, quarantined)
+		assert.Equal(t, i, entry.Failures)
+		_, skip := store.Check("github", "acme/app", "fp1")
+		assert.False(t, skip, "not quarantined before the threshold")
+	}
+
+	entry, quarantined = store.RecordFailure("github", "acme/app", forbidden, "fp1")
+	require.True(t, quarantined)
+	assert.Equal(t, ReasonPermission, entry.Reason)
+	assert.Equal(t, now.Add(DefaultBackoff), entry.RetryAfter)
+
+	_, skip := store.Check("github", "acme/app", "fp1")
+	assert.True(t, skip)
+	_, skip = store.Check("github", "acme/app", "fp2")
+	assert.False(t, skip, "a provider-side change ends the quarantine")
+
+	// Persisted and reloaded
+	require.NoError(t, store.Save())
+	reloaded, err := Open(path)
+	require.NoError(t, err)
+	reloaded.now = func() time.Time { return now }
+	_, skip = reloaded.Check("github", "acme/app", "fp1")
+	assert.True(t, skip)
+
+	// Probation after the retry time; a failure doubles the period
+	now = now.Add(DefaultBackoff)
+	entry, skip = store.Check("github", "acme/app", "fp1")
+	assert.False(t, skip)
+	assert.True(t, entry.Quarantined())
+	entry, quarantined = store.RecordFailure("github", "acme/app", forbidden, "fp1")
+	require.True(t, quarantined)
+	assert.Equal(t, now.Add(2*DefaultBackoff), entry.RetryAfter)
+
+	// Success on probation releases the repository
+	now = entry.RetryAfter
+	assert.True(t, store.RecordSuccess("github", "acme/app"))
+	assert.Empty(t, store.List())
+	assert.False(t, store.RecordSuccess("github", "acme/app"))
+}
+
+func TestStoreClear(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "quarantine.json"))
+	require.NoError(t, err)
+	store.Threshold = 1
+	broken := errors.New("fatal: bad object HEAD")
+
+	store.RecordFailure("github", "acme/a", broken, "")
+	store.RecordFailure("github", "acme/b", broken, "")
+	store.RecordFailure("gitlab", "group/c", broken, "")
+	require.Len(t, store.List(), 3)
+
+	require.NoError(t, store.Clear("github", "acme/a"))
+	assert.ErrorIs(t, store.Clear("github", "acme/a"), ErrNotQuarantined)
+	assert.Equal(t, 1, store.ClearAll("gitlab"))
+	assert.Equal(t, 1, store.ClearAll(""))
+	assert.Empty(t, store.List())
+}
+
+func TestPeriod(t *testing.T) {
+	s := &Store{Backoff: DefaultBackoff}
+	assert.Equal(t, 24*time.Hour, s.period(1))
+	assert.Equal(t, 48*time.Hour, s.period(2))
+	assert.Equal(t, 96*time.Hour, s.period(3))
+	assert.Equal(t, MaxBackoff, s.period(10))
+}
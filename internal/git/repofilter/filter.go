@@ -0,0 +1,436 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package repofilter implements the filter expressions that select
+// repositories for bulk cloning, e.g.
+//
+//	language == go and not archived and (topic in [cli, sdk] or name ~ "api-*")
+//	visibility == private and pushed < 90d and size < 500MB
+//
+// Conditions compare an attribute with a value and combine with and, or,
+// not (also &&, ||, !) and parentheses. Strings compare case-insensitively;
+// ~ and !~ match shell globs, =~ matches a regular expression and in tests
+// a list of values. Topic conditions hold when any topic matches. Sizes
+// take B, KB, MB, GB or TB units; plain numbers are kilobytes, as the
+// providers report them. pushed is the time since the last push, in h, d,
+// w, mo or y.
+package repofilter
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Repo holds the attributes a filter can test.
+type Repo struct {
+	Name     string
+	FullName string
+	Language string
+	Topics   []string
+	Private  bool
+	Archived bool
+	Fork     bool
+	SizeKB   int64
+	Stars    int
+	// PushedAt is the time of the last push; zero when unknown.
+	PushedAt time.Time
+}
+
+type attrKind int
+
+const (
+	kindString attrKind = iota
+	kindSet
+	kindBool
+	kindSize
+	kindNumber
+	kindAge
+)
+
+type attribute struct {
+	name string
+	kind attrKind
+	// exactly one of the getters is set, matching kind
+	str  func(Repo) string
+	set  func(Repo) []string
+	flag func(Repo) bool
+	num  func(Repo, time.Time) float64
+}
+
+var attributes = []attribute{
+	{name: "name", kind: kindString, str: func(r Repo) string { return r.Name }},
+	{name: "full_name", kind: kindString, str: func(r Repo) string { return r.FullName }},
+	{name: "language", kind: kindString, str: func(r Repo) string { return r.Language }},
+	{name: "visibility", kind: kindString, str: func(r Repo) string {
+		if r.Private {
+			return "private"
+		}
+		return "public"
+	}},
+	{name: "topic", kind: kindSet, set: func(r Repo) []string { return r.Topics }},
+	{name: "archived", kind: kindBool, flag: func(r Repo) bool { return r.Archived }},
+	{name: "fork", kind: kindBool, flag: func(r Repo) bool { return r.Fork }},
+	{name: "private", kind: kindBool, flag: func(r Repo) bool { return r.Private }},
+	{name: "size", kind: kindSize, num: func(r Repo, _ time.Time) float64 { return float64(r.SizeKB) }},
+	{name: "stars", kind: kindNumber, num: func(r Repo, _ time.Time) float64 { return float64(r.Stars) }},
+	{name: "pushed", kind: kindAge, num: func(r Repo, now time.Time) float64 {
+		if r.PushedAt.IsZero() {
+			return math.Inf(1)
+		}
+		return float64(now.Sub(r.PushedAt))
+	}},
+}
+
+// aliases are alternative attribute spellings.
+var aliases = map[string]string{
+	"repo":   "full_name",
+	"topics": "topic",
+	"lang":   "language",
+}
+
+func lookupAttribute(name string) (*attribute, bool) {
+	name = strings.ToLower(name)
+	if canonical, ok := aliases[name]; ok {
+		name = canonical
+	}
+	for i := range attributes {
+		if attributes[i].name == name {
+			return &attributes[i], true
+		}
+	}
+	return nil, false
+}
+
+func attributeNames() []string {
+	names := make([]string, len(attributes))
+	for i, a := range attributes {
+		names[i] = a.name
+	}
+	return names
+}
+
+// validOps lists the operators each kind of attribute accepts.
+var validOps = map[attrKind][]string{
+	kindString: {"==", "!=", "~", "!~", "=~", "in"},
+	kindSet:    {"==", "!=", "~", "!~", "=~", "in"},
+	kindBool:   {"==", "!="},
+	kindSize:   {"==", "!=", "<", "<=", ">", ">="},
+	kindNumber: {"==", "!=", "<", "<=", ">", ">="},
+	kindAge:    {"==", "!=", "<", "<=", ">", ">="},
+}
+
+// Filter is a compiled filter expression.
+type Filter struct {
+	expr string
+	root node
+}
+
+// String returns the expression the filter was parsed from.
+func (f *Filter) String() string {
+	return f.expr
+}
+
+// Match reports whether r satisfies the filter; now anchors pushed ages.
+func (f *Filter) Match(r Repo, now time.Time) bool {
+	return f.root.eval(r, now, false, nil)
+}
+
+// Step is one condition evaluated while matching a repository.
+type Step struct {
+	Condition string `json:"condition"`
+	Value     string `json:"value"`
+	Matched   bool   `json:"matched"`
+}
+
+// Explanation tells why a repository matched a filter or not.
+type Explanation struct {
+	Matched bool `json:"matched"`
+	// Steps are the conditions in evaluation order, with negations pushed
+	// down to them. Conditions skipped by short-circuiting are left out,
+	// so the steps are exactly the ones that decided the result.
+	Steps []Step `json:"steps"`
+}
+
+// Explain matches r like Match and records the conditions it evaluated.
+func (f *Filter) Explain(r Repo, now time.Time) Explanation {
+	var steps []Step
+	matched := f.root.eval(r, now, false, &steps)
+	return Explanation{Matched: matched, Steps: steps}
+}
+
+// node is an expression; eval returns its value, negated when negate is
+// set, and appends the evaluated conditions to steps when it is not nil.
+type node interface {
+	eval(r Repo, now time.Time, negate bool, steps *[]Step) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n *andNode) eval(r Repo, now time.Time, negate bool, steps *[]Step) bool {
+	// not (a and b) == not a or not b
+	if negate {
+		return n.left.eval(r, now, true, steps) || n.right.eval(r, now, true, steps)
+	}
+	return n.left.eval(r, now, false, steps) && n.right.eval(r, now, false, steps)
+}
+
+type orNode struct{ left, right node }
+
+func (n *orNode) eval(r Repo, now time.Time, negate bool, steps *[]Step) bool {
+	// not (a or b) == not a and not b
+	if negate {
+		return n.left.eval(r, now, true, steps) && n.right.eval(r, now, true, steps)
+	}
+	return n.left.eval(r, now, false, steps) || n.right.eval(r, now, false, steps)
+}
+
+type notNode struct{ inner node }
+
+func (n *notNode) eval(r Repo, now time.Time, negate bool, steps *[]Step) bool {
+	return n.inner.eval(r, now, !negate, steps)
+}
+
+type condition struct {
+	attr   *attribute
+	op     string
+	values []string
+
+	re     *regexp.Regexp
+	number float64
+	flag   bool
+}
+
+func newCondition(attr *attribute, op string, values []string, errorf func(string) error) (*condition, error) {
+	valid := false
+	for _, o := range validOps[attr.kind] {
+		valid = valid || o == op
+	}
+	if !valid {
+		return nil, errorf(fmt.Sprintf("%s does not support %s (use %s)", attr.name, op, strings.Join(validOps[attr.kind], ", ")))
+	}
+
+	c := &condition{attr: attr, op: op, values: values}
+	var err error
+	switch {
+	case op == "=~":
+		c.re, err = regexp.Compile(values[0])
+	case op == "~" || op == "!~":
+		_, err = path.Match(values[0], "")
+	case attr.kind == kindBool:
+		c.flag, err = strconv.ParseBool(values[0])
+	case attr.kind == kindSize:
+		c.number, err = parseSize(values[0])
+	case attr.kind == kindNumber:
+		c.number, err = strconv.ParseFloat(values[0], 64)
+	case attr.kind == kindAge:
+		var age time.Duration
+		age, err = parseAge(values[0])
+		c.number = float64(age)
+	}
+	if err != nil {
+		return nil, errorf(fmt.Sprintf("invalid value %q for %s: %v", values[0], attr.name, err))
+	}
+	return c, nil
+}
+
+func (c *condition) eval(r Repo, now time.Time, negate bool, steps *[]Step) bool {
+	matched := c.test(r, now) != negate
+	if steps != nil {
+		text := c.String()
+		if negate {
+			text = "not " + text
+		}
+		*steps = append(*steps, Step{Condition: text, Value: c.actual(r, now), Matched: matched})
+	}
+	return matched
+}
+
+func (c *condition) test(r Repo, now time.Time) bool {
+	switch c.attr.kind {
+	case kindString:
+		return c.matchString(c.attr.str(r))
+	case kindSet:
+		// != and !~ hold when no element matches
+		positive := map[string]string{"!=": "==", "!~": "~"}
+		if op, ok := positive[c.op]; ok {
+			inverse := &condition{attr: c.attr, op: op, values: c.values}
+			return !inverse.test(r, now)
+		}
+		for _, v := range c.attr.set(r) {
+			if c.matchString(v) {
+				return true
+			}
+		}
+		return false
+	case kindBool:
+		return (c.attr.flag(r) == c.flag) == (c.op == "==")
+	default:
+		return compare(c.attr.num(r, now), c.op, c.number)
+	}
+}
+
+func (c *condition) matchString(s string) bool {
+	switch c.op {
+	case "==":
+		return strings.EqualFold(s, c.values[0])
+	case "!=":
+		return !strings.EqualFold(s, c.values[0])
+	case "~", "!~":
+		ok, _ := path.Match(strings.ToLower(c.values[0]), strings.ToLower(s))
+		return ok == (c.op == "~")
+	case "=~":
+		return c.re.MatchString(s)
+	case "in":
+		for _, v := range c.values {
+			if strings.EqualFold(s, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func compare(a float64, op string, b float64) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	default:
+		return a >= b
+	}
+}
+
+// String returns the condition in canonical form.
+func (c *condition) String() string {
+	if c.attr.kind == kindBool && c.op == "==" && c.flag {
+		return c.attr.name
+	}
+	if c.op == "in" {
+		quoted := make([]string, len(c.values))
+		for i, v := range c.values {
+			quoted[i] = quote(v)
+		}
+		return fmt.Sprintf("%s in [%s]", c.attr.name, strings.Join(quoted, ", "))
+	}
+	return fmt.Sprintf("%s %s %s", c.attr.name, c.op, quote(c.values[0]))
+}
+
+func quote(v string) string {
+	for i := 0; i < len(v); i++ {
+		if !isWordByte(v[i]) {
+			return strconv.Quote(v)
+		}
+	}
+	if v == "" {
+		return `""`
+	}
+	return v
+}
+
+// actual formats the attribute value of r for explanations.
+func (c *condition) actual(r Repo, now time.Time) string {
+	switch c.attr.kind {
+	case kindString:
+		return c.attr.str(r)
+	case kindSet:
+		topics := append([]string(nil), c.attr.set(r)...)
+		sort.Strings(topics)
+		return "[" + strings.Join(topics, ", ") + "]"
+	case kindBool:
+		return strconv.FormatBool(c.attr.flag(r))
+	case kindSize:
+		return formatSize(c.attr.num(r, now))
+	case kindAge:
+		age := c.attr.num(r, now)
+		if math.IsInf(age, 1) {
+			return "never"
+		}
+		return formatAge(time.Duration(age)) + " ago"
+	default:
+		return strconv.FormatFloat(c.attr.num(r, now), 'f', -1, 64)
+	}
+}
+
+var sizeUnits = []struct {
+	suffix string
+	kb     float64
+}{
+	{"tb", 1 << 30}, {"gb", 1 << 20}, {"mb", 1 << 10}, {"kb", 1}, {"t", 1 << 30}, {"g", 1 << 20}, {"m", 1 << 10}, {"k", 1}, {"b", 1.0 / 1024},
+}
+
+// parseSize returns a size in kilobytes.
+func parseSize(s string) (float64, error) {
+	lower := strings.ToLower(s)
+	multiplier := 1.0
+	for _, u := range sizeUnits {
+		if strings.HasSuffix(lower, u.suffix) {
+			lower, multiplier = strings.TrimSuffix(lower, u.suffix), u.kb
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(lower, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("expected a size such as 500MB")
+	}
+	return n * multiplier, nil
+}
+
+func formatSize(kb float64) string {
+	for _, u := range sizeUnits[:3] {
+		if kb >= u.kb {
+			return strconv.FormatFloat(kb/u.kb, 'f', 1, 64) + strings.ToUpper(u.suffix)
+		}
+	}
+	return strconv.FormatFloat(kb, 'f', 0, 64) + "KB"
+}
+
+const day = 24 * time.Hour
+
+var ageUnits = []struct {
+	suffix string
+	unit   time.Duration
+}{
+	{"mo", 30 * day}, {"y", 365 * day}, {"w", 7 * day}, {"d", day}, {"h", time.Hour},
+}
+
+func parseAge(s string) (time.Duration, error) {
+	lower := strings.ToLower(s)
+	for _, u := range ageUnits {
+		if strings.HasSuffix(lower, u.suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSuffix(lower, u.suffix), 64)
+			if err != nil || n < 0 {
+				break
+			}
+			return time.Duration(n * float64(u.unit)), nil
+		}
+	}
+	d, err := time.ParseDuration(lower)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("expected an age such as 90d, 6mo or 1y")
+	}
+	return d, nil
+}
+
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 365*day:
+		return strconv.FormatFloat(float64(d)/float64(365*day), 'f', 1, 64) + "y"
+	case d >= day:
+		return strconv.Itoa(int(d/day)) + "d"
+	default:
+		return d.Round(time.Minute).String()
+	}
+}
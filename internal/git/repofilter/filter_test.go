@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repofilter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+var (
+	api = Repo{
+		Name: "api-gateway", FullName: "acme/api-gateway", Language: "Go",
+		Topics: []string{"cli", "platform"}, Private: true, SizeKB: 20 * 1024, Stars: 42,
+		PushedAt: now.Add(-10 * 24 * time.Hour),
+	}
+	legacy = Repo{
+		Name: "legacy-web", FullName: "acme/legacy-web", Language: "PHP",
+		Archived: true, SizeKB: 900 * 1024, PushedAt: now.Add(-3 * 365 * 24 * time.Hour),
+	}
+	fork = Repo{Name: "api-docs", FullName: "acme/api-docs", Fork: true}
+)
+
+func TestFilter_Match(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []bool // api, legacy, fork
+	}{
+		{`name ~ "api-*"`, []bool{true, false, true}},
+		{`name !~ api-*`, []bool{false, true, false}},
+		{`language == go`, []bool{true, false, false}},
+		{`lang in [php, ruby]`, []bool{false, true, false}},
+		{`topic == cli`, []bool{true, false, false}},
+		{`topic != cli`, []bool{false, true, true}},
+		{`topics ~ plat*`, []bool{true, false, false}},
+		{`visibility == private`, []bool{true, false, false}},
+		{`archived`, []bool{false, true, false}},
+		{`not archived and not fork`, []bool{true, false, false}},
+		{`!archived && fork == false`, []bool{true, false, false}},
+		{`size < 100MB`, []bool{true, false, true}},
+		{`size >= 0.5gb`, []bool{false, true, false}},
+		{`stars > 10`, []bool{true, false, false}},
+		{`pushed < 90d`, []bool{true, false, false}},
+		{`pushed > 1y`, []bool{false, true, true}},
+		{`full_name =~ "^acme/(api|web)-"`, []bool{true, false, true}},
+		{`archived or (name ~ api-* and not fork)`, []bool{true, true, false}},
+		{`not (archived or fork)`, []bool{true, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, []bool{f.Match(api, now), f.Match(legacy, now), f.Match(fork, now)})
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		``:                  "empty expression",
+		`owner == acme`:     `unknown attribute "owner"`,
+		`name`:              `expected an operator after "name"`,
+		`name ==`:           `expected a value after "=="`,
+		`stars ~ 1*`:        "stars does not support ~",
+		`size < lots`:       `invalid value "lots" for size`,
+		`pushed < soon`:     `invalid value "soon" for pushed`,
+		`name =~ "("`:       `invalid value "(" for name`,
+		`(archived`:         "expected )",
+		`archived fork`:     `unexpected "fork"`,
+		`topic in [cli`:     "expected , or ]",
+		`name == "unclosed`: "unterminated string",
+		`archived and or`:   `unexpected "or"`,
+		`archived == maybe`: `invalid value "maybe" for archived`,
+		`language in cli`:   "expected [ after in",
+		`name == api )`:     `unexpected ")"`,
+	}
+	for expr, want := range tests {
+		t.Run(expr, func(t *testing.T) {
+			_, err := Parse(expr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), want)
+		})
+	}
+}
+
+func TestFilter_Explain(t *testing.T) {
+	f, err := Parse(`not archived and (topic in [sdk, cli] or size < 1MB)`)
+	require.NoError(t, err)
+	assert.Equal(t, `not archived and (topic in [sdk, cli] or size < 1MB)`, f.String())
+
+	got := f.Explain(api, now)
+	assert.True(t, got.Matched)
+	assert.Equal(t, []Step{
+		{Condition: "not archived", Value: "false", Matched: true},
+		{Condition: "topic in [sdk, cli]", Value: "[cli, platform]", Matched: true},
+	}, got.Steps, "short-circuited conditions are left out")
+
+	got = f.Explain(legacy, now)
+	assert.False(t, got.Matched)
+	require.Len(t, got.Steps, 1)
+	assert.Equal(t, "true", got.Steps[0].Value)
+
+	f, err = Parse(`pushed < 30d`)
+	require.NoError(t, err)
+	assert.Equal(t, "never", f.Explain(fork, now).Steps[0].Value)
+	assert.Equal(t, "10d ago", f.Explain(api, now).Steps[0].Value)
+}
@@ -0,0 +1,286 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repofilter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SyntaxError reports where an expression could not be parsed.
+type SyntaxError struct {
+	Expr string
+	Pos  int // byte offset in Expr
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("filter %q: %s at position %d", e.Expr, e.Msg, e.Pos+1)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// operators, longest first so that "<=" wins over "<".
+var operators = []string{"==", "!=", "<=", ">=", "=~", "!~", "&&", "||", "=", "<", ">", "~", "!"}
+
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+			continue
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+			continue
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+			continue
+		case c == '[':
+			tokens = append(tokens, token{tokLBracket, "[", i})
+			i++
+			continue
+		case c == ']':
+			tokens = append(tokens, token{tokRBracket, "]", i})
+			i++
+			continue
+		case c == ',':
+			tokens = append(tokens, token{tokComma, ",", i})
+			i++
+			continue
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, &SyntaxError{expr, i, "unterminated string"}
+			}
+			tokens = append(tokens, token{tokString, expr[i+1 : i+1+end], i})
+			i += end + 2
+			continue
+		}
+
+		if op := matchOperator(expr[i:]); op != "" {
+			tokens = append(tokens, token{tokOp, op, i})
+			i += len(op)
+			continue
+		}
+
+		start := i
+		for i < len(expr) && isWordByte(expr[i]) {
+			i++
+		}
+		if i == start {
+			return nil, &SyntaxError{expr, i, fmt.Sprintf("unexpected %q", expr[i])}
+		}
+		tokens = append(tokens, token{tokWord, expr[start:i], start})
+	}
+	return append(tokens, token{tokEOF, "", len(expr)}), nil
+}
+
+func matchOperator(s string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// isWordByte reports whether c can appear in a bare word: attribute names
+// and unquoted values such as api-*, go or 90d.
+func isWordByte(c byte) bool {
+	if unicode.IsSpace(rune(c)) {
+		return false
+	}
+	return !strings.ContainsRune(`()[],"'=!<>~&|`, rune(c))
+}
+
+type parser struct {
+	expr   string
+	tokens []token
+	pos    int
+}
+
+// Parse compiles a filter expression.
+func Parse(expr string) (*Filter, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{expr: expr, tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, &SyntaxError{expr, 0, "empty expression"}
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+	return &Filter{expr: expr, root: root}, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...any) error {
+	return &SyntaxError{p.expr, t.pos, fmt.Sprintf(format, args...)}
+}
+
+// isKeyword reports whether t is the keyword or operator spelling kw.
+func isKeyword(t token, kw, op string) bool {
+	return (t.kind == tokWord && strings.EqualFold(t.text, kw)) || (t.kind == tokOp && t.text == op)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "or", "||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "and", "&&") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	t := p.peek()
+	switch {
+	case isKeyword(t, "not", "!"):
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{inner}, nil
+	case t.kind == tokLParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.errorf(closing, "expected )")
+		}
+		return inner, nil
+	case t.kind == tokWord && !isKeyword(t, "and", "&&") && !isKeyword(t, "or", "||"):
+		return p.parseCondition()
+	case t.kind == tokEOF:
+		return nil, p.errorf(t, "unexpected end of expression")
+	default:
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+}
+
+func (p *parser) parseCondition() (node, error) {
+	nameTok := p.next()
+	attr, ok := lookupAttribute(nameTok.text)
+	if !ok {
+		return nil, p.errorf(nameTok, "unknown attribute %q (available: %s)", nameTok.text, strings.Join(attributeNames(), ", "))
+	}
+
+	t := p.peek()
+	var op string
+	switch {
+	case t.kind == tokOp && t.text != "!" && t.text != "&&" && t.text != "||":
+		op = p.next().text
+		if op == "=" {
+			op = "=="
+		}
+	case t.kind == tokWord && strings.EqualFold(t.text, "in"):
+		p.next()
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return newCondition(attr, "in", values, func(msg string) error { return p.errorf(t, "%s", msg) })
+	default:
+		// A bare boolean attribute, e.g. "archived"
+		if attr.kind != kindBool {
+			return nil, p.errorf(t, "expected an operator after %q", attr.name)
+		}
+		return newCondition(attr, "==", []string{"true"}, nil)
+	}
+
+	valueTok := p.next()
+	if valueTok.kind != tokWord && valueTok.kind != tokString {
+		return nil, p.errorf(valueTok, "expected a value after %q", op)
+	}
+	return newCondition(attr, op, []string{valueTok.text}, func(msg string) error { return p.errorf(valueTok, "%s", msg) })
+}
+
+func (p *parser) parseList() ([]string, error) {
+	if t := p.next(); t.kind != tokLBracket {
+		return nil, p.errorf(t, "expected [ after in")
+	}
+	var values []string
+	for {
+		t := p.next()
+		if t.kind != tokWord && t.kind != tokString {
+			return nil, p.errorf(t, "expected a value")
+		}
+		values = append(values, t.text)
+
+		switch sep := p.next(); sep.kind {
+		case tokComma:
+			continue
+		case tokRBracket:
+			return values, nil
+		default:
+			return nil, p.errorf(sep, "expected , or ]")
+		}
+	}
+}
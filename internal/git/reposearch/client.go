@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package reposearch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// Backend searches repositories on one platform.
+type Backend interface {
+	// Provider returns the platform name.
+	Provider() string
+	// Search returns one page of results in the provider's own order and
+	// whether more pages exist. Results use the common repository schema.
+	Search(ctx context.Context, q Query, page, perPage int) (repos []provider.Repository, more bool, err error)
+}
+
+// NewBackend creates the backend for name. baseURL selects a self-hosted
+// API and may be empty.
+func NewBackend(name, baseURL, token string) (Backend, error) {
+	switch name {
+	case "github":
+		return newGitHubBackend(baseURL, token), nil
+	case "gitlab":
+		return newGitLabBackend(baseURL, token), nil
+	case "gitea":
+		return newGiteaBackend(baseURL, token), nil
+	default:
+		return nil, fmt.Errorf("unsupported provider for repository search: %s (supported: github, gitlab, gitea)", name)
+	}
+}
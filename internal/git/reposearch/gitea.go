@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package reposearch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// giteaBackend searches through the Gitea REST API (v1).
+type giteaBackend struct {
+	client *restapi.Client
+}
+
+func newGiteaBackend(baseURL, token string) *giteaBackend {
+	if baseURL == "" {
+		baseURL = "https://gitea.com/api/v1"
+	}
+	return &giteaBackend{
+		client: restapi.New("gitea", baseURL, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("Authorization", "token "+token)
+			}
+		}),
+	}
+}
+
+func (b *giteaBackend) Provider() string { return "gitea" }
+
+// nolint:tagliatelle // External API format - must match Gitea JSON output
+type gtRepo struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	Owner       struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	Private       bool      `json:"private"`
+	Archived      bool      `json:"archived"`
+	Fork          bool      `json:"fork"`
+	Mirror        bool      `json:"mirror"`
+	OriginalURL   string    `json:"original_url"`
+	CloneURL      string    `json:"clone_url"`
+	SSHURL        string    `json:"ssh_url"`
+	HTMLURL       string    `json:"html_url"`
+	DefaultBranch string    `json:"default_branch"`
+	Language      string    `json:"language"`
+	Size          int64     `json:"size"`
+	Topics        []string  `json:"topics"`
+	Stars         int       `json:"stars_count"`
+	Forks         int       `json:"forks_count"`
+	Watchers      int       `json:"watchers_count"`
+	Issues        int       `json:"open_issues_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Parent        *struct {
+		FullName string `json:"full_name"`
+		CloneURL string `json:"clone_url"`
+	} `json:"parent"`
+}
+
+type gtSearchResponse struct {
+	OK   bool     `json:"ok"`
+	Data []gtRepo `json:"data"`
+}
+
+func (b *giteaBackend) Search(ctx context.Context, q Query, page, perPage int) ([]provider.Repository, bool, error) {
+	params := url.Values{}
+	switch {
+	case q.Text != "":
+		params.Set("q", q.Text)
+	case q.Topic != "":
+		params.Set("q", q.Topic)
+		params.Set("topic", "true")
+	}
+	params.Set("sort", "updated")
+	params.Set("order", "desc")
+	params.Set("page", strconv.Itoa(page))
+	params.Set("limit", strconv.Itoa(perPage))
+	if !q.IncludeArchived {
+		params.Set("archived", "false")
+	}
+
+	var resp gtSearchResponse
+	if err := b.client.Do(ctx, http.MethodGet, "/repos/search?"+params.Encode(), nil, &resp); err != nil {
+		return nil, false, err
+	}
+
+	repos := make([]provider.Repository, 0, len(resp.Data))
+	for _, r := range resp.Data {
+		// Gitea cannot scope a search by owner name or language.
+		if q.Owner != "" && !strings.EqualFold(r.Owner.Login, q.Owner) {
+			continue
+		}
+		if q.Language != "" && !strings.EqualFold(r.Language, q.Language) {
+			continue
+		}
+		repo := provider.Repository{
+			ID:            strconv.FormatInt(r.ID, 10),
+			Name:          r.Name,
+			FullName:      r.FullName,
+			Owner:         provider.Owner{Login: r.Owner.Login},
+			Description:   r.Description,
+			Private:       r.Private,
+			Archived:      r.Archived,
+			Fork:          r.Fork,
+			CloneURL:      r.CloneURL,
+			SSHURL:        r.SSHURL,
+			HTMLURL:       r.HTMLURL,
+			DefaultBranch: r.DefaultBranch,
+			Language:      r.Language,
+			Size:          r.Size,
+			Topics:        r.Topics,
+			CreatedAt:     r.CreatedAt,
+			UpdatedAt:     r.UpdatedAt,
+			PushedAt:      r.UpdatedAt,
+			Stars:         r.Stars,
+			Forks:         r.Forks,
+			Watchers:      r.Watchers,
+			Issues:        r.Issues,
+			ProviderType:  "gitea",
+		}
+		repo.Visibility = provider.VisibilityPublic
+		if r.Private {
+			repo.Visibility = provider.VisibilityPrivate
+		}
+		switch {
+		case r.Fork && r.Parent != nil:
+			setUpstream(&repo, r.Parent.CloneURL, false)
+		case r.Mirror && r.OriginalURL != "":
+			setUpstream(&repo, r.OriginalURL, true)
+		}
+		repos = append(repos, repo)
+	}
+	return repos, len(resp.Data) == perPage, nil
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package reposearch
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// gitHubSearchLimit is the number of results the GitHub search API returns
+// for a query at most.
+const gitHubSearchLimit = 1000
+
+// gitHubBackend searches through the GitHub search API.
+type gitHubBackend struct {
+	client *restapi.Client
+}
+
+func newGitHubBackend(baseURL, token string) *gitHubBackend {
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return &gitHubBackend{
+		client: restapi.New("github", baseURL, func(req *http.Request) {
+			req.Header.Set("Accept", "application/vnd.github+json")
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}),
+	}
+}
+
+func (b *gitHubBackend) Provider() string { return "github" }
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghRepo struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	Owner       struct {
+		Login   string `json:"login"`
+		Type    string `json:"type"`
+		HTMLURL string `json:"html_url"`
+	} `json:"owner"`
+	Private       bool      `json:"private"`
+	Archived      bool      `json:"archived"`
+	Fork          bool      `json:"fork"`
+	MirrorURL     string    `json:"mirror_url"`
+	CloneURL      string    `json:"clone_url"`
+	SSHURL        string    `json:"ssh_url"`
+	HTMLURL       string    `json:"html_url"`
+	DefaultBranch string    `json:"default_branch"`
+	Language      string    `json:"language"`
+	Size          int64     `json:"size"`
+	Topics        []string  `json:"topics"`
+	Stars         int       `json:"stargazers_count"`
+	Forks         int       `json:"forks_count"`
+	Watchers      int       `json:"watchers_count"`
+	Issues        int       `json:"open_issues_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	PushedAt      time.Time `json:"pushed_at"`
+	License       *struct {
+		Key    string `json:"key"`
+		Name   string `json:"name"`
+		SPDXID string `json:"spdx_id"`
+	} `json:"license"`
+}
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghSearchResponse struct {
+	TotalCount int      `json:"total_count"`
+	Items      []ghRepo `json:"items"`
+}
+
+// searchTerms builds the GitHub search expression for q.
+func (b *gitHubBackend) searchTerms(q Query) string {
+	terms := []string{}
+	if q.Text != "" {
+		terms = append(terms, q.Text)
+	}
+	if q.Owner != "" {
+		terms = append(terms, "user:"+q.Owner)
+	}
+	if q.Language != "" {
+		terms = append(terms, "language:"+q.Language)
+	}
+	if q.Topic != "" {
+		terms = append(terms, "topic:"+q.Topic)
+	}
+	// Forks are included so that they can be folded into their upstream.
+	terms = append(terms, "fork:true")
+	if !q.IncludeArchived {
+		terms = append(terms, "archived:false")
+	}
+	return strings.Join(terms, " ")
+}
+
+func (b *gitHubBackend) Search(ctx context.Context, q Query, page, perPage int) ([]provider.Repository, bool, error) {
+	params := url.Values{}
+	params.Set("q", b.searchTerms(q))
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(perPage))
+
+	var resp ghSearchResponse
+	if err := b.client.Do(ctx, http.MethodGet, "/search/repositories?"+params.Encode(), nil, &resp); err != nil {
+		return nil, false, err
+	}
+
+	repos := make([]provider.Repository, 0, len(resp.Items))
+	for _, r := range resp.Items {
+		repo := provider.Repository{
+			ID:            strconv.FormatInt(r.ID, 10),
+			Name:          r.Name,
+			FullName:      r.FullName,
+			Owner:         provider.Owner{Login: r.Owner.Login, HTMLURL: r.Owner.HTMLURL, Type: provider.OwnerTypeUser},
+			Description:   r.Description,
+			Private:       r.Private,
+			Archived:      r.Archived,
+			Fork:          r.Fork,
+			CloneURL:      r.CloneURL,
+			SSHURL:        r.SSHURL,
+			HTMLURL:       r.HTMLURL,
+			DefaultBranch: r.DefaultBranch,
+			Language:      r.Language,
+			Size:          r.Size,
+			Topics:        r.Topics,
+			CreatedAt:     r.CreatedAt,
+			UpdatedAt:     r.UpdatedAt,
+			PushedAt:      r.PushedAt,
+			Stars:         r.Stars,
+			Forks:         r.Forks,
+			Watchers:      r.Watchers,
+			Issues:        r.Issues,
+			ProviderType:  "github",
+		}
+		if r.Owner.Type == "Organization" {
+			repo.Owner.Type = provider.OwnerTypeOrganization
+		}
+		repo.Visibility = provider.VisibilityPublic
+		if r.Private {
+			repo.Visibility = provider.VisibilityPrivate
+		}
+		if r.License != nil {
+			repo.License = provider.License{Key: r.License.Key, Name: r.License.Name, SPDXID: r.License.SPDXID}
+		}
+		if r.MirrorURL != "" {
+			setUpstream(&repo, r.MirrorURL, true)
+		}
+		repos = append(repos, repo)
+	}
+
+	seen := page * perPage
+	return repos, seen < resp.TotalCount && seen < gitHubSearchLimit, nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package reposearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// gitLabBackend searches projects through the GitLab REST API (v4).
+type gitLabBackend struct {
+	client *restapi.Client
+}
+
+func newGitLabBackend(baseURL, token string) *gitLabBackend {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com/api/v4"
+	}
+	return &gitLabBackend{
+		client: restapi.New("gitlab", baseURL, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("PRIVATE-TOKEN", token)
+			}
+		}),
+	}
+}
+
+func (b *gitLabBackend) Provider() string { return "gitlab" }
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glProject struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	Path              string `json:"path"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	Description       string `json:"description"`
+	Namespace         struct {
+		FullPath string `json:"full_path"`
+		Kind     string `json:"kind"`
+		WebURL   string `json:"web_url"`
+	} `json:"namespace"`
+	Visibility        string    `json:"visibility"`
+	Archived          bool      `json:"archived"`
+	HTTPURLToRepo     string    `json:"http_url_to_repo"`
+	SSHURLToRepo      string    `json:"ssh_url_to_repo"`
+	WebURL            string    `json:"web_url"`
+	DefaultBranch     string    `json:"default_branch"`
+	Topics            []string  `json:"topics"`
+	StarCount         int       `json:"star_count"`
+	ForksCount        int       `json:"forks_count"`
+	OpenIssuesCount   int       `json:"open_issues_count"`
+	CreatedAt         time.Time `json:"created_at"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	Mirror            bool      `json:"mirror"`
+	ImportURL         string    `json:"import_url"`
+	ForkedFromProject *struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+		HTTPURLToRepo     string `json:"http_url_to_repo"`
+	} `json:"forked_from_project"`
+}
+
+func (b *gitLabBackend) Search(ctx context.Context, q Query, page, perPage int) ([]provider.Repository, bool, error) {
+	params := url.Values{}
+	if q.Text != "" {
+		params.Set("search", q.Text)
+	}
+	params.Set("order_by", "last_activity_at")
+	params.Set("sort", "desc")
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", strconv.Itoa(perPage))
+	if q.Language != "" {
+		params.Set("with_programming_language", q.Language)
+	}
+	if q.Topic != "" {
+		params.Set("topic", q.Topic)
+	}
+	if !q.IncludeArchived {
+		params.Set("archived", "false")
+	}
+
+	path := "/projects"
+	if q.Owner != "" {
+		path = "/groups/" + url.PathEscape(q.Owner) + "/projects"
+		params.Set("include_subgroups", "true")
+	}
+
+	var projects []glProject
+	err := b.client.Do(ctx, http.MethodGet, path+"?"+params.Encode(), nil, &projects)
+	if q.Owner != "" && errors.Is(err, restapi.ErrNotFound) {
+		// Not a group: look for a user namespace instead.
+		err = b.client.Do(ctx, http.MethodGet, "/users/"+url.PathEscape(q.Owner)+"/projects?"+params.Encode(), nil, &projects)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	repos := make([]provider.Repository, 0, len(projects))
+	for _, p := range projects {
+		repo := provider.Repository{
+			ID:            strconv.FormatInt(p.ID, 10),
+			Name:          p.Path,
+			FullName:      p.PathWithNamespace,
+			Owner:         provider.Owner{Login: p.Namespace.FullPath, HTMLURL: p.Namespace.WebURL, Type: provider.OwnerTypeGroup},
+			Description:   p.Description,
+			Private:       p.Visibility == "private",
+			Visibility:    provider.VisibilityType(p.Visibility),
+			Archived:      p.Archived,
+			Fork:          p.ForkedFromProject != nil,
+			CloneURL:      p.HTTPURLToRepo,
+			SSHURL:        p.SSHURLToRepo,
+			HTMLURL:       p.WebURL,
+			DefaultBranch: p.DefaultBranch,
+			Language:      q.Language,
+			Topics:        p.Topics,
+			CreatedAt:     p.CreatedAt,
+			UpdatedAt:     p.LastActivityAt,
+			PushedAt:      p.LastActivityAt,
+			Stars:         p.StarCount,
+			Forks:         p.ForksCount,
+			Issues:        p.OpenIssuesCount,
+			ProviderType:  "gitlab",
+		}
+		if p.Namespace.Kind == "user" {
+			repo.Owner.Type = provider.OwnerTypeUser
+		}
+		switch {
+		case p.ForkedFromProject != nil:
+			setUpstream(&repo, p.ForkedFromProject.HTTPURLToRepo, false)
+		case p.Mirror && p.ImportURL != "":
+			setUpstream(&repo, p.ImportURL, true)
+		}
+		repos = append(repos, repo)
+	}
+	return repos, len(projects) == perPage, nil
+}
@@ -0,0 +1,399 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package reposearch runs one repository search against several Git
+// platforms at once and merges the answers into a single ranked list.
+package reposearch
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// Provider data keys set by the backends.
+const (
+	// DataUpstream is the clone URL of the repository a fork or mirror
+	// was created from, when the platform reports it.
+	DataUpstream = "upstream"
+	// DataMirror marks pull mirrors.
+	DataMirror = "mirror"
+)
+
+// maxFetchPerProvider bounds how many results are fetched from one provider.
+const maxFetchPerProvider = 1000
+
+// Sort orders.
+const (
+	SortRelevance = "relevance"
+	SortStars     = "stars"
+	SortUpdated   = "updated"
+	SortName      = "name"
+)
+
+// Query is a provider-independent search.
+type Query struct {
+	// Text is matched against names and descriptions.
+	Text string `json:"text,omitempty"`
+	// Owner restricts results to an organization, group or user.
+	Owner           string `json:"owner,omitempty"`
+	Language        string `json:"language,omitempty"`
+	Topic           string `json:"topic,omitempty"`
+	IncludeArchived bool   `json:"includeArchived,omitempty"`
+}
+
+// Options control merging and pagination.
+type Options struct {
+	// Page and PerPage select a page of the merged, ranked results.
+	Page    int
+	PerPage int
+	// Sort is one of relevance (default), stars, updated or name.
+	Sort string
+	// KeepDuplicates disables folding forks and mirrors into their upstream.
+	KeepDuplicates bool
+	// Now anchors recency scoring; defaults to time.Now.
+	Now time.Time
+}
+
+// Hit is a merged search result.
+type Hit struct {
+	provider.Repository
+	// Score combines relevance, popularity and recent activity (0–1).
+	Score float64 `json:"score"`
+	// Duplicates lists the forks and mirrors folded into this result as
+	// provider:full_name.
+	Duplicates []string `json:"duplicates,omitempty"`
+}
+
+// ID returns the provider-qualified repository name.
+func (h Hit) ID() string {
+	return identity(h.Repository)
+}
+
+// Results is one page of merged search results.
+type Results struct {
+	Query   Query `json:"query"`
+	Hits    []Hit `json:"hits"`
+	Page    int   `json:"page"`
+	PerPage int   `json:"perPage"`
+	// Total counts the merged results fetched; Truncated is set when a
+	// provider had more results than were fetched.
+	Total     int  `json:"total"`
+	Truncated bool `json:"truncated,omitempty"`
+	HasNext   bool `json:"hasNext"`
+	// Errors holds the providers that failed, keyed by provider name.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// Search queries every backend concurrently and returns the requested page of
+// the merged, de-duplicated and ranked results. Failing providers are
+// reported in Results.Errors; Search fails only when every provider fails.
+func Search(ctx context.Context, backends []Backend, q Query, opts Options) (*Results, error) {
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("no providers to search")
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	if opts.PerPage < 1 {
+		opts.PerPage = 20
+	}
+	if opts.Sort == "" {
+		opts.Sort = SortRelevance
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	switch opts.Sort {
+	case SortRelevance, SortStars, SortUpdated, SortName:
+	default:
+		return nil, fmt.Errorf("invalid sort %q (valid: relevance, stars, updated, name)", opts.Sort)
+	}
+
+	// Duplicates collapse after fetching, so every provider is asked for
+	// enough results to fill all pages up to the requested one.
+	need := min(opts.Page*opts.PerPage, maxFetchPerProvider)
+
+	type answer struct {
+		provider string
+		repos    []provider.Repository
+		more     bool
+		err      error
+	}
+	answers := make([]answer, len(backends))
+	var wg sync.WaitGroup
+	for i, b := range backends {
+		wg.Add(1)
+		go func(i int, b Backend) {
+			defer wg.Done()
+			repos, more, err := fetch(ctx, b, q, need)
+			answers[i] = answer{b.Provider(), repos, more, err}
+		}(i, b)
+	}
+	wg.Wait()
+
+	results := &Results{Query: q, Page: opts.Page, PerPage: opts.PerPage}
+	var all []provider.Repository
+	for _, a := range answers {
+		if a.err != nil {
+			if results.Errors == nil {
+				results.Errors = map[string]string{}
+			}
+			results.Errors[a.provider] = a.err.Error()
+			continue
+		}
+		all = append(all, a.repos...)
+		results.Truncated = results.Truncated || a.more
+	}
+	if len(results.Errors) == len(backends) {
+		msgs := make([]string, 0, len(results.Errors))
+		for p, e := range results.Errors {
+			msgs = append(msgs, p+": "+e)
+		}
+		sort.Strings(msgs)
+		return nil, fmt.Errorf("search failed on every provider: %s", strings.Join(msgs, "; "))
+	}
+
+	var hits []Hit
+	if opts.KeepDuplicates {
+		hits = make([]Hit, 0, len(all))
+		for _, r := range all {
+			hits = append(hits, Hit{Repository: r})
+		}
+	} else {
+		hits = Deduplicate(all)
+	}
+	Rank(hits, q, opts.Sort, opts.Now)
+
+	results.Total = len(hits)
+	start := (opts.Page - 1) * opts.PerPage
+	end := min(start+opts.PerPage, len(hits))
+	if start < len(hits) {
+		results.Hits = hits[start:end]
+	} else {
+		results.Hits = []Hit{}
+	}
+	results.HasNext = end < len(hits) || (results.Truncated && need < maxFetchPerProvider)
+	return results, nil
+}
+
+// fetch reads pages from one backend until need results were collected.
+func fetch(ctx context.Context, b Backend, q Query, need int) ([]provider.Repository, bool, error) {
+	perPage := min(need, 100)
+	var all []provider.Repository
+	for page := 1; len(all) < need; page++ {
+		repos, more, err := b.Search(ctx, q, page, perPage)
+		if err != nil {
+			if len(all) > 0 {
+				// Keep what earlier pages returned.
+				return all, false, nil
+			}
+			return nil, false, err
+		}
+		all = append(all, repos...)
+		if !more {
+			return all, false, nil
+		}
+	}
+	return all[:need], true, nil
+}
+
+// setUpstream records where a fork or mirror came from.
+func setUpstream(repo *provider.Repository, upstream string, mirror bool) {
+	if repo.ProviderData == nil {
+		repo.ProviderData = map[string]any{}
+	}
+	repo.ProviderData[DataUpstream] = upstream
+	if mirror {
+		repo.ProviderData[DataMirror] = true
+	}
+}
+
+func identity(r provider.Repository) string {
+	return r.ProviderType + ":" + r.FullName
+}
+
+func upstreamOf(r provider.Repository) string {
+	s, _ := r.ProviderData[DataUpstream].(string)
+	return s
+}
+
+func isMirror(r provider.Repository) bool {
+	m, _ := r.ProviderData[DataMirror].(bool)
+	return m
+}
+
+// normalizeURL reduces clone and web URLs of the same repository to one
+// form: host/path in lower case without scheme, credentials or .git.
+func normalizeURL(u string) string {
+	u = strings.ToLower(strings.TrimSpace(u))
+	if u == "" {
+		return ""
+	}
+	if rest, ok := strings.CutPrefix(u, "git@"); ok {
+		u = strings.Replace(rest, ":", "/", 1)
+	}
+	if _, rest, ok := strings.Cut(u, "://"); ok {
+		u = rest
+	}
+	if at := strings.Index(u, "@"); at >= 0 && !strings.Contains(u[:at], "/") {
+		u = u[at+1:]
+	}
+	u = strings.TrimSuffix(strings.TrimSuffix(u, "/"), ".git")
+	return u
+}
+
+// Deduplicate folds forks and mirrors into the repository they were created
+// from. A result is folded when its reported upstream is among the results,
+// or when it is a fork or mirror sharing name and description with exactly
+// one original repository. The original keeps its place and lists the
+// folded results as duplicates.
+func Deduplicate(repos []provider.Repository) []Hit {
+	parent := make([]int, len(repos))
+	byURL := map[string]int{}
+	for i, r := range repos {
+		parent[i] = i
+		for _, u := range []string{r.CloneURL, r.HTMLURL, r.SSHURL} {
+			if key := normalizeURL(u); key != "" {
+				if _, ok := byURL[key]; !ok {
+					byURL[key] = i
+				}
+			}
+		}
+	}
+
+	root := func(i int) int {
+		for parent[i] != i {
+			i = parent[i]
+		}
+		return i
+	}
+	fold := func(dup, into int) {
+		if r := root(into); r != dup {
+			parent[dup] = r
+		}
+	}
+
+	// Explicit upstream links first.
+	for i, r := range repos {
+		if j, ok := byURL[normalizeURL(upstreamOf(r))]; ok && j != i {
+			fold(i, j)
+		}
+	}
+
+	// Then forks and mirrors whose upstream was not reported.
+	originals := map[string][]int{}
+	for i, r := range repos {
+		if !r.Fork && !isMirror(r) {
+			key := strings.ToLower(r.Name) + "\x00" + strings.ToLower(strings.TrimSpace(r.Description))
+			originals[key] = append(originals[key], i)
+		}
+	}
+	for i, r := range repos {
+		if parent[i] != i || (!r.Fork && !isMirror(r)) {
+			continue
+		}
+		key := strings.ToLower(r.Name) + "\x00" + strings.ToLower(strings.TrimSpace(r.Description))
+		if candidates := originals[key]; len(candidates) == 1 && strings.TrimSpace(r.Description) != "" {
+			fold(i, candidates[0])
+		}
+	}
+
+	index := map[int]int{}
+	hits := make([]Hit, 0, len(repos))
+	for i, r := range repos {
+		if root(i) == i {
+			index[i] = len(hits)
+			hits = append(hits, Hit{Repository: r})
+		}
+	}
+	for i, r := range repos {
+		if rt := root(i); rt != i {
+			h := &hits[index[rt]]
+			h.Duplicates = append(h.Duplicates, identity(r))
+		}
+	}
+	return hits
+}
+
+// Rank scores hits against the query and sorts them. Scores weigh how well
+// names, topics and descriptions match (60%), stars and forks (25%) and how
+// recently the repository was active (15%).
+func Rank(hits []Hit, q Query, order string, now time.Time) {
+	text := strings.ToLower(strings.TrimSpace(q.Text))
+	terms := strings.Fields(text)
+	for i := range hits {
+		hits[i].Score = math.Round(score(hits[i].Repository, text, terms, now)*1000) / 1000
+	}
+
+	less := func(a, b Hit) bool { return a.Score > b.Score }
+	switch order {
+	case SortStars:
+		less = func(a, b Hit) bool { return a.Stars > b.Stars }
+	case SortUpdated:
+		less = func(a, b Hit) bool { return activity(a.Repository).After(activity(b.Repository)) }
+	case SortName:
+		less = func(a, b Hit) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if less(hits[i], hits[j]) {
+			return true
+		}
+		if less(hits[j], hits[i]) {
+			return false
+		}
+		return hits[i].ID() < hits[j].ID()
+	})
+}
+
+func score(r provider.Repository, text string, terms []string, now time.Time) float64 {
+	relevance := 1.0
+	if len(terms) > 0 {
+		name := strings.ToLower(r.Name)
+		description := strings.ToLower(r.Description)
+		topics := strings.ToLower(strings.Join(r.Topics, " "))
+
+		relevance = 0
+		for _, t := range terms {
+			switch {
+			case strings.Contains(name, t):
+				relevance += 0.7
+			case strings.Contains(topics, t):
+				relevance += 0.5
+			case strings.Contains(description, t):
+				relevance += 0.3
+			}
+		}
+		relevance /= float64(len(terms))
+		dashed := strings.ReplaceAll(text, " ", "-")
+		switch {
+		case name == text || name == dashed:
+			relevance = 1
+		case strings.Contains(name, text) || strings.Contains(name, dashed):
+			relevance = max(relevance, 0.85)
+		}
+	}
+
+	popularity := math.Min(1, math.Log1p(float64(r.Stars+r.Forks))/math.Log1p(10000))
+
+	recency := 0.0
+	if t := activity(r); !t.IsZero() {
+		days := now.Sub(t).Hours() / 24
+		recency = math.Exp(-math.Max(days, 0) / 180)
+	}
+
+	return 0.6*relevance + 0.25*popularity + 0.15*recency
+}
+
+func activity(r provider.Repository) time.Time {
+	if r.PushedAt.After(r.UpdatedAt) {
+		return r.PushedAt
+	}
+	return r.UpdatedAt
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repostats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long fetched statistics are reused.
+const DefaultCacheTTL = time.Hour
+
+type cacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Stats     *Stats    `json:"stats"`
+}
+
+// Cache keeps fetched statistics in a JSON file so that repeated listings do
+// not hit the API rate limits. It is safe for concurrent use.
+type Cache struct {
+	path    string
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	dirty   bool
+}
+
+// DefaultCachePath returns ~/.gzh/cache/repo-stats.json.
+func DefaultCachePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".gzh", "cache", "repo-stats.json")
+	}
+	return filepath.Join(home, ".gzh", "cache", "repo-stats.json")
+}
+
+// LoadCache reads the cache file at path. A missing or unreadable file
+// yields an empty cache, since the cache can always be rebuilt.
+func LoadCache(path string, ttl time.Duration) *Cache {
+	c := &Cache{path: path, ttl: ttl, entries: make(map[string]cacheEntry)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c
+	}
+	if err := json.Unmarshal(data, &c.entries); err != nil || c.entries == nil {
+		c.entries = make(map[string]cacheEntry)
+	}
+	return c
+}
+
+// Get returns the cached statistics for key if they are younger than the TTL.
+func (c *Cache) Get(key string, now time.Time) (*Stats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || e.Stats == nil || now.Sub(e.FetchedAt) > c.ttl {
+		return nil, false
+	}
+	return e.Stats, true
+}
+
+// Put stores the statistics for key.
+func (c *Cache) Put(key string, s *Stats, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{FetchedAt: now, Stats: s}
+	c.dirty = true
+}
+
+// Save writes the cache file, dropping expired entries.
+func (c *Cache) Save(now time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	for key, e := range c.entries {
+		if now.Sub(e.FetchedAt) > c.ttl {
+			delete(c.entries, key)
+		}
+	}
+
+	data, err := json.Marshal(c.entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode stats cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write stats cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return errors.Join(fmt.Errorf("failed to replace stats cache: %w", err), os.Remove(tmp))
+	}
+	c.dirty = false
+	return nil
+}
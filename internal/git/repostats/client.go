@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repostats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// Fetcher fetches the statistics of repositories on one platform.
+type Fetcher interface {
+	// Provider returns the platform name.
+	Provider() string
+	// Fetch returns the statistics of repo.
+	Fetch(ctx context.Context, repo provider.Repository) (*Stats, error)
+}
+
+// NewFetcher creates the fetcher for provider. baseURL selects a self-hosted
+// API and may be empty.
+func NewFetcher(providerType, baseURL, token string) (Fetcher, error) {
+	switch providerType {
+	case "github":
+		return newGitHubFetcher(baseURL, token), nil
+	case "gitlab":
+		return newGitLabFetcher(baseURL, token), nil
+	case "gitea":
+		return newGiteaFetcher(baseURL, token), nil
+	default:
+		return nil, fmt.Errorf("unsupported provider for repository stats: %s (supported: github, gitlab, gitea)", providerType)
+	}
+}
+
+// isEmptyRepository reports whether err is the HTTP 409 GitHub and Gitea
+// answer for commit queries on repositories without commits.
+func isEmptyRepository(err error) bool {
+	var status *restapi.StatusError
+	return errors.As(err, &status) && status.StatusCode == http.StatusConflict
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repostats
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// DefaultConcurrency is the number of repositories fetched in parallel.
+const DefaultConcurrency = 5
+
+// Key identifies a repository across providers.
+func Key(repo provider.Repository) string {
+	return repo.ProviderType + ":" + repo.FullName
+}
+
+// Collector fetches statistics for many repositories concurrently.
+type Collector struct {
+	// Fetchers maps a provider type to its fetcher. Repositories of other
+	// providers get the partial statistics of FromRepository.
+	Fetchers map[string]Fetcher
+	// Cache is optional.
+	Cache *Cache
+	// Concurrency defaults to DefaultConcurrency.
+	Concurrency int
+
+	now func() time.Time
+}
+
+// Collect returns the statistics of every repository keyed by Key. A failed
+// fetch does not abort the others: the repository gets partial statistics
+// and the error is returned alongside.
+func (c *Collector) Collect(ctx context.Context, repos []provider.Repository) (map[string]*Stats, []error) {
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	concurrency := c.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	var (
+		results = make(map[string]*Stats, len(repos))
+		errs    []error
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, concurrency)
+	)
+
+	for _, repo := range repos {
+		key := Key(repo)
+		fetcher, ok := c.Fetchers[repo.ProviderType]
+		if !ok {
+			mu.Lock()
+			results[key] = FromRepository(repo)
+			mu.Unlock()
+			continue
+		}
+		if c.Cache != nil {
+			if s, ok := c.Cache.Get(key, now()); ok {
+				// 앞서 시작한 고루틴이 같은 맵에 쓰고 있으므로 잠금이 필요하다
+				mu.Lock()
+				results[key] = s
+				mu.Unlock()
+				continue
+			}
+		}
+
+		wg.Add(1)
+		go func(repo provider.Repository) {
+			defer wg.Done()
+
+			select {
+			case <-ctx.Done():
+				mu.Lock()
+				results[key] = FromRepository(repo)
+				errs = append(errs, fmt.Errorf("%s: %w", repo.FullName, ctx.Err()))
+				mu.Unlock()
+				return
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			}
+
+			s, err := fetcher.Fetch(ctx, repo)
+			if err != nil {
+				if s == nil {
+					s = FromRepository(repo)
+				}
+				s.Partial = true
+			} else if c.Cache != nil {
+				c.Cache.Put(key, s, now())
+			}
+
+			mu.Lock()
+			results[key] = s
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", repo.FullName, err))
+			}
+			mu.Unlock()
+		}(repo)
+	}
+
+	wg.Wait()
+	return results, errs
+}
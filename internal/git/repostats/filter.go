@@ -0,0 +1,209 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repostats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter fields accepted by ParseFilter.
+const (
+	FieldLanguage = "language" // primary language, or any language with !=
+	FieldStale    = "stale"    // time since the last commit, e.g. 90d, 6mo
+	FieldOpenPRs  = "open-prs"
+	FieldIssues   = "issues" // open issues
+	FieldSize     = "size"   // repository size, e.g. 500K, 20M, 1G
+	FieldArchived = "archived"
+)
+
+// filterOperators is ordered so that two-character operators match first.
+var filterOperators = []string{">=", "<=", "!=", "=", ">", "<"}
+
+// Condition is one comparison of a Filter.
+type Condition struct {
+	Field string
+	Op    string
+	Value string
+
+	number float64
+	flag   bool
+}
+
+// Filter is a conjunction of conditions, e.g. "language=Go,stale>90d".
+type Filter []Condition
+
+// ParseFilter parses a comma separated list of conditions. An empty
+// expression yields an empty filter that matches everything.
+func ParseFilter(expr string) (Filter, error) {
+	var f Filter
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		c, err := parseCondition(part)
+		if err != nil {
+			return nil, err
+		}
+		f = append(f, c)
+	}
+	return f, nil
+}
+
+func parseCondition(s string) (Condition, error) {
+	var c Condition
+	for _, op := range filterOperators {
+		if i := strings.Index(s, op); i > 0 {
+			c = Condition{
+				Field: strings.ToLower(strings.TrimSpace(s[:i])),
+				Op:    op,
+				Value: strings.TrimSpace(s[i+len(op):]),
+			}
+			break
+		}
+	}
+	if c.Op == "" {
+		return c, fmt.Errorf("invalid filter %q: expected <field><op><value>, e.g. language=Go", s)
+	}
+	if c.Value == "" {
+		return c, fmt.Errorf("invalid filter %q: missing value", s)
+	}
+
+	var err error
+	switch c.Field {
+	case FieldLanguage:
+		if c.Op != "=" && c.Op != "!=" {
+			return c, fmt.Errorf("invalid filter %q: %s only supports = and !=", s, c.Field)
+		}
+	case FieldArchived:
+		if c.Op != "=" && c.Op != "!=" {
+			return c, fmt.Errorf("invalid filter %q: %s only supports = and !=", s, c.Field)
+		}
+		c.flag, err = strconv.ParseBool(c.Value)
+	case FieldStale:
+		var d time.Duration
+		d, err = ParseAge(c.Value)
+		c.number = float64(d)
+	case FieldOpenPRs, FieldIssues:
+		var n int
+		n, err = strconv.Atoi(c.Value)
+		c.number = float64(n)
+	case FieldSize:
+		var kb int64
+		kb, err = parseSizeKB(c.Value)
+		c.number = float64(kb)
+	default:
+		return c, fmt.Errorf("invalid filter %q: unknown field %q (valid: %s)", s, c.Field,
+			strings.Join([]string{FieldLanguage, FieldStale, FieldOpenPRs, FieldIssues, FieldSize, FieldArchived}, ", "))
+	}
+	if err != nil {
+		return c, fmt.Errorf("invalid filter %q: %w", s, err)
+	}
+	return c, nil
+}
+
+// Match reports whether s satisfies every condition. Stale conditions never
+// match repositories whose last commit is unknown.
+func (f Filter) Match(s *Stats, now time.Time) bool {
+	for _, c := range f {
+		if !c.match(s, now) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Condition) match(s *Stats, now time.Time) bool {
+	switch c.Field {
+	case FieldLanguage:
+		if c.Op == "=" {
+			return strings.EqualFold(s.PrimaryLanguage(), c.Value)
+		}
+		return !s.HasLanguage(c.Value)
+	case FieldArchived:
+		return (s.Archived == c.flag) == (c.Op == "=")
+	case FieldStale:
+		if s.LastCommitAt.IsZero() {
+			return false
+		}
+		return compare(float64(s.Age(now)), c.Op, c.number)
+	case FieldOpenPRs:
+		return compare(float64(s.OpenPRs), c.Op, c.number)
+	case FieldIssues:
+		return compare(float64(s.OpenIssues), c.Op, c.number)
+	case FieldSize:
+		return compare(float64(s.SizeKB), c.Op, c.number)
+	}
+	return false
+}
+
+func compare(a float64, op string, b float64) bool {
+	switch op {
+	case "=":
+		return a == b
+	case "!=":
+		return a != b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	}
+	return false
+}
+
+// ParseAge parses an age such as "90d", "2w", "6mo" or "1y". Go duration
+// strings like "36h" are accepted as well.
+func ParseAge(s string) (time.Duration, error) {
+	const day = 24 * time.Hour
+	units := []struct {
+		suffix string
+		unit   time.Duration
+	}{
+		{"mo", 30 * day},
+		{"y", 365 * day},
+		{"w", 7 * day},
+		{"d", day},
+	}
+	for _, u := range units {
+		if n, ok := strings.CutSuffix(s, u.suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("invalid age %q", s)
+			}
+			return time.Duration(v) * u.unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid age %q (use e.g. 90d, 2w, 6mo, 1y)", s)
+	}
+	return d, nil
+}
+
+// parseSizeKB parses a size in kilobytes with an optional K, M or G suffix
+// (a trailing B is ignored).
+func parseSizeKB(s string) (int64, error) {
+	v := strings.TrimSuffix(strings.ToUpper(s), "B")
+	mult := int64(1)
+	switch {
+	case strings.HasSuffix(v, "K"):
+		v = strings.TrimSuffix(v, "K")
+	case strings.HasSuffix(v, "M"):
+		v, mult = strings.TrimSuffix(v, "M"), 1024
+	case strings.HasSuffix(v, "G"):
+		v, mult = strings.TrimSuffix(v, "G"), 1024*1024
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q (use e.g. 500K, 20M, 1G)", s)
+	}
+	return int64(n * float64(mult)), nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repostats
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// giteaFetcher implements Fetcher against the Gitea REST API.
+type giteaFetcher struct {
+	client *restapi.Client
+}
+
+func newGiteaFetcher(baseURL, token string) *giteaFetcher {
+	if baseURL == "" {
+		baseURL = "https://gitea.com/api/v1"
+	}
+	return &giteaFetcher{
+		client: restapi.New("gitea", baseURL, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("Authorization", "token "+token)
+			}
+		}),
+	}
+}
+
+func (f *giteaFetcher) Provider() string { return "gitea" }
+
+// Fetch reads the language breakdown, the newest commit of the default
+// branch and the open pull requests. Gitea's issue count already excludes
+// pull requests.
+func (f *giteaFetcher) Fetch(ctx context.Context, repo provider.Repository) (*Stats, error) {
+	s := FromRepository(repo)
+	base := "/repos/" + escapeFullName(repo.FullName)
+
+	var languages map[string]int64
+	if err := f.client.Do(ctx, http.MethodGet, base+"/languages", nil, &languages); err != nil {
+		return s, err
+	}
+	if shares := languageShares(languages); shares != nil {
+		s.Languages = shares
+	}
+
+	path := base + "/commits?limit=1&stat=false"
+	if repo.DefaultBranch != "" {
+		path += "&sha=" + url.QueryEscape(repo.DefaultBranch)
+	}
+	var commits []ghCommit
+	switch err := f.client.Do(ctx, http.MethodGet, path, nil, &commits); {
+	case isEmptyRepository(err):
+		s.LastCommitAt = time.Time{}
+	case err != nil:
+		return s, err
+	case len(commits) > 0:
+		s.LastCommitAt = commits[0].Commit.Committer.Date
+	}
+
+	prs, err := restapi.Count(ctx, f.client, base+"/pulls?state=open", "limit")
+	if err != nil {
+		return s, err
+	}
+	s.OpenPRs = prs
+	s.Partial = false
+	return s, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repostats
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// gitHubFetcher implements Fetcher against the GitHub REST API.
+type gitHubFetcher struct {
+	client *restapi.Client
+}
+
+func newGitHubFetcher(baseURL, token string) *gitHubFetcher {
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return &gitHubFetcher{
+		client: restapi.New("github", baseURL, func(req *http.Request) {
+			req.Header.Set("Accept", "application/vnd.github+json")
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}),
+	}
+}
+
+func (f *gitHubFetcher) Provider() string { return "github" }
+
+type ghCommit struct {
+	Commit struct {
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+}
+
+// Fetch reads the language breakdown, the newest commit of the default
+// branch and the open pull requests. GitHub counts pull requests as issues,
+// so they are subtracted from the listed issue count.
+func (f *gitHubFetcher) Fetch(ctx context.Context, repo provider.Repository) (*Stats, error) {
+	s := FromRepository(repo)
+	base := "/repos/" + escapeFullName(repo.FullName)
+
+	var languages map[string]int64
+	if err := f.client.Do(ctx, http.MethodGet, base+"/languages", nil, &languages); err != nil {
+		return s, err
+	}
+	if shares := languageShares(languages); shares != nil {
+		s.Languages = shares
+	}
+
+	path := base + "/commits?per_page=1"
+	if repo.DefaultBranch != "" {
+		path += "&sha=" + url.QueryEscape(repo.DefaultBranch)
+	}
+	var commits []ghCommit
+	switch err := f.client.Do(ctx, http.MethodGet, path, nil, &commits); {
+	case isEmptyRepository(err):
+		s.LastCommitAt = time.Time{}
+	case err != nil:
+		return s, err
+	case len(commits) > 0:
+		s.LastCommitAt = commits[0].Commit.Committer.Date
+	}
+
+	prs, err := restapi.Count(ctx, f.client, base+"/pulls?state=open", "per_page")
+	if err != nil {
+		return s, err
+	}
+	s.OpenPRs = prs
+	s.OpenIssues = max(repo.Issues-prs, 0)
+	s.Partial = false
+	return s, nil
+}
+
+// escapeFullName escapes each segment of an owner/name path.
+func escapeFullName(fullName string) string {
+	parts := strings.Split(fullName, "/")
+	for i, p := range parts {
+		parts[i] = url.PathEscape(p)
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repostats
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// gitLabFetcher implements Fetcher against the GitLab REST API.
+type gitLabFetcher struct {
+	client *restapi.Client
+}
+
+func newGitLabFetcher(baseURL, token string) *gitLabFetcher {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com/api/v4"
+	}
+	return &gitLabFetcher{
+		client: restapi.New("gitlab", baseURL, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("PRIVATE-TOKEN", token)
+			}
+		}),
+	}
+}
+
+func (f *gitLabFetcher) Provider() string { return "gitlab" }
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glCommit struct {
+	CommittedDate time.Time `json:"committed_date"`
+}
+
+// Fetch reads the language breakdown, the newest commit of the default
+// branch and the open merge requests. GitLab reports languages as
+// percentages and its issue count already excludes merge requests.
+func (f *gitLabFetcher) Fetch(ctx context.Context, repo provider.Repository) (*Stats, error) {
+	s := FromRepository(repo)
+	base := "/projects/" + url.PathEscape(repo.FullName)
+
+	var languages map[string]float64
+	if err := f.client.Do(ctx, http.MethodGet, base+"/languages", nil, &languages); err != nil {
+		return s, err
+	}
+	if shares := languageShares(languages); shares != nil {
+		s.Languages = shares
+	}
+
+	path := base + "/repository/commits?per_page=1"
+	if repo.DefaultBranch != "" {
+		path += "&ref_name=" + url.QueryEscape(repo.DefaultBranch)
+	}
+	var commits []glCommit
+	switch err := f.client.Do(ctx, http.MethodGet, path, nil, &commits); {
+	case errors.Is(err, restapi.ErrNotFound):
+		// The project exists (languages succeeded), so the branch has no
+		// commits yet.
+		s.LastCommitAt = time.Time{}
+	case err != nil:
+		return s, err
+	case len(commits) > 0:
+		s.LastCommitAt = commits[0].CommittedDate
+	default:
+		s.LastCommitAt = time.Time{}
+	}
+
+	mrs, err := restapi.Count(ctx, f.client, base+"/merge_requests?state=opened", "per_page")
+	if err != nil {
+		return s, err
+	}
+	s.OpenPRs = mrs
+	s.Partial = false
+	return s, nil
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repostats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+var testNow = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func TestGitHubFetcher(t *testing.T) {
+	var pullRequests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer tok", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/repos/acme/api/languages":
+			fmt.Fprint(w, `{"Go": 7500, "Shell": 2500}`)
+		case "/repos/acme/api/commits":
+			assert.Equal(t, "main", r.URL.Query().Get("sha"))
+			fmt.Fprint(w, `[{"commit": {"committer": {"date": "2025-05-01T10:00:00Z"}}}]`)
+		case "/repos/acme/api/pulls":
+			// 한 항목씩의 페이지에서 마지막 페이지 번호가 전체 개수다
+			pullRequests.Add(1)
+			assert.Equal(t, "1", r.URL.Query().Get("per_page"))
+			w.Header().Set("Link", `<http://`+r.Host+`/repos/acme/api/pulls?state=open&per_page=1&page=2>; rel="next", `+
+				`<http://`+r.Host+`/repos/acme/api/pulls?state=open&per_page=1&page=205>; rel="last"`)
+			fmt.Fprint(w, "[{}]")
+		case "/repos/acme/empty/languages":
+			fmt.Fprint(w, `{}`)
+		case "/repos/acme/empty/commits":
+			w.WriteHeader(http.StatusConflict)
+		case "/repos/acme/empty/pulls":
+			fmt.Fprint(w, `[]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	f, err := NewFetcher("github", srv.URL, "tok")
+	require.NoError(t, err)
+
+	s, err := f.Fetch(context.Background(), provider.Repository{
+		FullName: "acme/api", DefaultBranch: "main", Issues: 210, Size: 2048, Language: "Go",
+	})
+	require.NoError(t, err)
+	assert.False(t, s.Partial)
+	assert.Equal(t, []LanguageShare{{Name: "Go", Percent: 75}, {Name: "Shell", Percent: 25}}, s.Languages)
+	assert.Equal(t, time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC), s.LastCommitAt)
+	assert.Equal(t, 205, s.OpenPRs)
+	assert.Equal(t, int32(1), pullRequests.Load(), "open pull requests are counted with one request")
+	assert.Equal(t, 5, s.OpenIssues, "pull requests are not issues")
+	assert.Equal(t, int64(2048), s.SizeKB)
+
+	s, err = f.Fetch(context.Background(), provider.Repository{FullName: "acme/empty", PushedAt: testNow})
+	require.NoError(t, err)
+	assert.True(t, s.LastCommitAt.IsZero())
+	assert.Empty(t, s.Languages)
+
+	_, err = f.Fetch(context.Background(), provider.Repository{FullName: "acme/missing"})
+	assert.ErrorIs(t, err, restapi.ErrNotFound)
+
+	_, err = NewFetcher("bitbucket", "", "")
+	assert.Error(t, err)
+}
+
+func TestGitLabFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tok", r.Header.Get("PRIVATE-TOKEN"))
+		switch r.URL.EscapedPath() {
+		case "/projects/grp%2Fsub%2Fsvc/languages":
+			fmt.Fprint(w, `{"Python": 60.5, "Go": 39.5}`)
+		case "/projects/grp%2Fsub%2Fsvc/repository/commits":
+			fmt.Fprint(w, `[{"committed_date": "2025-01-15T00:00:00Z"}]`)
+		case "/projects/grp%2Fsub%2Fsvc/merge_requests":
+			w.Header().Set("X-Total", "2")
+			fmt.Fprint(w, `[{}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	f, err := NewFetcher("gitlab", srv.URL, "tok")
+	require.NoError(t, err)
+	s, err := f.Fetch(context.Background(), provider.Repository{FullName: "grp/sub/svc", Issues: 4})
+	require.NoError(t, err)
+	assert.Equal(t, "Python", s.PrimaryLanguage())
+	assert.Equal(t, 2, s.OpenPRs)
+	assert.Equal(t, 4, s.OpenIssues)
+	assert.Equal(t, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), s.LastCommitAt)
+}
+
+type stubFetcher struct {
+	calls atomic.Int32
+	fail  string
+}
+
+func (f *stubFetcher) Provider() string { return "github" }
+
+func (f *stubFetcher) Fetch(_ context.Context, repo provider.Repository) (*Stats, error) {
+	f.calls.Add(1)
+	s := FromRepository(repo)
+	if repo.Name == f.fail {
+		return s, errors.New("boom")
+	}
+	s.OpenPRs = 3
+	s.Partial = false
+	return s, nil
+}
+
+func TestCollector(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "stats.json")
+	fetcher := &stubFetcher{fail: "broken"}
+	repos := []provider.Repository{
+		{Name: "a", FullName: "acme/a", ProviderType: "github"},
+		{Name: "b", FullName: "acme/b", ProviderType: "github"},
+		{Name: "broken", FullName: "acme/broken", ProviderType: "github"},
+		{Name: "c", FullName: "grp/c", ProviderType: "gogs", Language: "Go"},
+	}
+
+	c := &Collector{
+		Fetchers: map[string]Fetcher{"github": fetcher},
+		Cache:    LoadCache(path, time.Hour),
+		now:      func() time.Time { return testNow },
+	}
+	stats, errs := c.Collect(context.Background(), repos)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "acme/broken")
+	assert.Equal(t, 3, stats["github:acme/a"].OpenPRs)
+	assert.True(t, stats["github:acme/broken"].Partial)
+	assert.True(t, stats["gogs:grp/c"].Partial)
+	assert.Equal(t, "Go", stats["gogs:grp/c"].PrimaryLanguage())
+	assert.Equal(t, int32(3), fetcher.calls.Load())
+	require.NoError(t, c.Cache.Save(testNow))
+
+	// A fresh collector reuses the cached results and only retries the
+	// failed repository.
+	c = &Collector{
+		Fetchers: map[string]Fetcher{"github": fetcher},
+		Cache:    LoadCache(path, time.Hour),
+		now:      func() time.Time { return testNow.Add(30 * time.Minute) },
+	}
+	stats, _ = c.Collect(context.Background(), repos)
+	assert.Equal(t, 3, stats["github:acme/b"].OpenPRs)
+	assert.Equal(t, int32(4), fetcher.calls.Load())
+
+	// Expired entries are fetched again.
+	c.now = func() time.Time { return testNow.Add(2 * time.Hour) }
+	c.Collect(context.Background(), repos)
+	assert.Equal(t, int32(7), fetcher.calls.Load())
+}
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter("language=go, stale>90d, open-prs>=1, size<1G, archived=false")
+	require.NoError(t, err)
+	require.Len(t, f, 5)
+
+	s := &Stats{
+		Languages:    []LanguageShare{{Name: "Go", Percent: 90}, {Name: "Shell", Percent: 10}},
+		LastCommitAt: testNow.Add(-100 * 24 * time.Hour),
+		OpenPRs:      2,
+		SizeKB:       20 * 1024,
+	}
+	assert.True(t, f.Match(s, testNow))
+
+	s.LastCommitAt = testNow.Add(-10 * 24 * time.Hour)
+	assert.False(t, f.Match(s, testNow))
+
+	s.LastCommitAt = time.Time{}
+	assert.False(t, f.Match(s, testNow), "unknown last commit is not stale")
+
+	f, err = ParseFilter("language!=Shell")
+	require.NoError(t, err)
+	assert.True(t, f.Match(&Stats{Languages: []LanguageShare{{Name: "Go"}}}, testNow))
+	assert.False(t, f.Match(&Stats{Languages: []LanguageShare{{Name: "Go"}, {Name: "Shell"}}}, testNow))
+
+	f, err = ParseFilter("")
+	require.NoError(t, err)
+	assert.True(t, f.Match(&Stats{}, testNow))
+
+	for _, bad := range []string{"language>Go", "stale>soon", "stars>10", "size>big", "archived=maybe", "open-prs", "issues="} {
+		_, err := ParseFilter(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	const day = 24 * time.Hour
+	for in, want := range map[string]time.Duration{
+		"90d": 90 * day, "2w": 14 * day, "6mo": 180 * day, "1y": 365 * day, "36h": 36 * time.Hour,
+	} {
+		got, err := ParseAge(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	const day = 24 * time.Hour
+	assert.Equal(t, "n/a", FormatAge(0))
+	assert.Equal(t, "today", FormatAge(time.Hour))
+	assert.Equal(t, "3d", FormatAge(3*day))
+	assert.Equal(t, "5w", FormatAge(35*day))
+	assert.Equal(t, "8mo", FormatAge(245*day))
+	assert.Equal(t, "2y", FormatAge(800*day))
+
+	assert.Equal(t, "512K", FormatSize(512))
+	assert.Equal(t, "1.5M", FormatSize(1536))
+	assert.Equal(t, "2.0G", FormatSize(2*1024*1024))
+
+	langs := []LanguageShare{{Name: "Go", Percent: 81.2}, {Name: "Shell", Percent: 12}, {Name: "Make", Percent: 6.8}}
+	assert.Equal(t, "Go 81%, Shell 12%", FormatLanguages(langs, 2))
+	assert.Equal(t, "n/a", FormatLanguages(nil, 2))
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package repostats computes per-repository statistics such as the language
+// breakdown, last commit age and open pull request counts, which the
+// provider list APIs do not return.
+package repostats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// LanguageShare is one language of a repository's language breakdown.
+type LanguageShare struct {
+	Name    string  `json:"name" yaml:"name"`
+	Percent float64 `json:"percent" yaml:"percent"`
+}
+
+// Stats holds the computed statistics of a repository.
+type Stats struct {
+	Languages    []LanguageShare `json:"languages,omitempty" yaml:"languages,omitempty"`
+	LastCommitAt time.Time       `json:"last_commit_at,omitempty" yaml:"last_commit_at,omitempty"`
+	OpenPRs      int             `json:"open_prs" yaml:"open_prs"`
+	OpenIssues   int             `json:"open_issues" yaml:"open_issues"`
+	SizeKB       int64           `json:"size_kb" yaml:"size_kb"`
+	Archived     bool            `json:"archived" yaml:"archived"`
+
+	// Partial is set when only the fields of the listing were available,
+	// either because the provider has no stats fetcher or a request failed.
+	Partial bool `json:"partial,omitempty" yaml:"partial,omitempty"`
+}
+
+// FromRepository returns the statistics that can be derived from a listed
+// repository without further API calls.
+func FromRepository(repo provider.Repository) *Stats {
+	s := &Stats{
+		LastCommitAt: repo.PushedAt,
+		OpenIssues:   repo.Issues,
+		SizeKB:       repo.Size,
+		Archived:     repo.Archived,
+		Partial:      true,
+	}
+	if repo.Language != "" {
+		s.Languages = []LanguageShare{{Name: repo.Language, Percent: 100}}
+	}
+	return s
+}
+
+// PrimaryLanguage returns the language with the largest share, or "".
+func (s *Stats) PrimaryLanguage() string {
+	if len(s.Languages) == 0 {
+		return ""
+	}
+	return s.Languages[0].Name
+}
+
+// HasLanguage reports whether name is part of the language breakdown,
+// ignoring case.
+func (s *Stats) HasLanguage(name string) bool {
+	for _, l := range s.Languages {
+		if strings.EqualFold(l.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Age returns the time since the last commit relative to now, or 0 when it
+// is unknown.
+func (s *Stats) Age(now time.Time) time.Duration {
+	if s.LastCommitAt.IsZero() {
+		return 0
+	}
+	return now.Sub(s.LastCommitAt)
+}
+
+// languageShares converts a language → amount map into shares sorted by size.
+// Amounts may be byte counts (GitHub, Gitea) or percentages (GitLab).
+func languageShares[N int64 | float64](amounts map[string]N) []LanguageShare {
+	var total float64
+	for _, v := range amounts {
+		total += float64(v)
+	}
+	if total <= 0 {
+		return nil
+	}
+
+	shares := make([]LanguageShare, 0, len(amounts))
+	for name, v := range amounts {
+		shares = append(shares, LanguageShare{Name: name, Percent: float64(v) * 100 / total})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Percent != shares[j].Percent {
+			return shares[i].Percent > shares[j].Percent
+		}
+		return shares[i].Name < shares[j].Name
+	})
+	return shares
+}
+
+// FormatLanguages renders the top n languages, e.g. "Go 81%, Shell 12%".
+func FormatLanguages(languages []LanguageShare, n int) string {
+	if len(languages) == 0 {
+		return "n/a"
+	}
+	parts := make([]string, 0, n)
+	for i, l := range languages {
+		if i == n {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", l.Name, l.Percent))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// FormatAge renders a duration in the coarsest sensible unit, e.g. "3d",
+// "5w", "8mo" or "2y".
+func FormatAge(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d <= 0:
+		return "n/a"
+	case d < day:
+		return "today"
+	case d < 14*day:
+		return fmt.Sprintf("%dd", d/day)
+	case d < 60*day:
+		return fmt.Sprintf("%dw", d/(7*day))
+	case d < 365*day:
+		return fmt.Sprintf("%dmo", d/(30*day))
+	default:
+		return fmt.Sprintf("%dy", d/(365*day))
+	}
+}
+
+// FormatSize renders a size given in kilobytes, e.g. "512K", "1.4M".
+func FormatSize(kb int64) string {
+	switch {
+	case kb < 1024:
+		return fmt.Sprintf("%dK", kb)
+	case kb < 1024*1024:
+		return fmt.Sprintf("%.1fM", float64(kb)/1024)
+	default:
+		return fmt.Sprintf("%.1fG", float64(kb)/(1024*1024))
+	}
+}
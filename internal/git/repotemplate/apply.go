@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repotemplate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/git/protect"
+)
+
+// Step statuses.
+const (
+	StatusPlanned = "planned"
+	StatusApplied = "applied"
+	StatusSkipped = "skipped"
+	StatusFailed  = "failed"
+)
+
+// Step is one action taken while applying a plan.
+type Step struct {
+	Action string `json:"action"`
+	Target string `json:"target"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Result is the outcome of applying a plan.
+type Result struct {
+	Repository protect.Repository `json:"repository"`
+	Steps      []Step             `json:"steps"`
+}
+
+// Failed reports whether any step failed.
+func (r *Result) Failed() bool {
+	for _, s := range r.Steps {
+		if s.Status == StatusFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// Print writes the steps as a list.
+func (r *Result) Print(w io.Writer) {
+	icons := map[string]string{StatusPlanned: "•", StatusApplied: "✓", StatusSkipped: "-", StatusFailed: "✗"}
+	for _, s := range r.Steps {
+		line := fmt.Sprintf("%s %-10s %s", icons[s.Status], s.Action, s.Target)
+		if s.Detail != "" {
+			line += " (" + s.Detail + ")"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// Options configure an Applier.
+type Options struct {
+	// DryRun lists the steps without calling the provider.
+	DryRun bool
+	// CommitMessage is used for the commit with the template files.
+	CommitMessage string
+}
+
+// Applier creates a repository from a rendered plan.
+type Applier struct {
+	backend    Backend
+	protection protect.Backend
+	opts       Options
+}
+
+// NewApplier creates an Applier. protection may be nil when the provider has
+// no branch protection backend; protection rules are then skipped.
+func NewApplier(backend Backend, protection protect.Backend, opts Options) *Applier {
+	if opts.CommitMessage == "" {
+		opts.CommitMessage = "Apply repository template"
+	}
+	return &Applier{backend: backend, protection: protection, opts: opts}
+}
+
+// Apply creates the repository and then configures files, topics, labels,
+// webhooks and branch protection in that order. A failure to create the
+// repository is returned as an error; later failures are recorded as failed
+// steps and the remaining steps still run.
+func (a *Applier) Apply(ctx context.Context, plan *Plan) (*Result, error) {
+	fullName := plan.Target.Name
+	if plan.Target.Org != "" {
+		fullName = plan.Target.Org + "/" + plan.Target.Name
+	}
+	result := &Result{Repository: protect.Repository{
+		Name:          plan.Target.Name,
+		FullName:      fullName,
+		DefaultBranch: plan.Repository.DefaultBranch,
+	}}
+
+	visibility := "public"
+	if plan.Repository.Private {
+		visibility = "private"
+	}
+	if a.opts.DryRun {
+		result.Steps = a.plannedSteps(plan, fullName, visibility)
+		return result, nil
+	}
+
+	repo, err := a.backend.CreateRepository(ctx, plan.Target.Org, plan.Target.Name, plan.Repository)
+	if err != nil {
+		return nil, err
+	}
+	if repo.DefaultBranch == "" {
+		repo.DefaultBranch = plan.Repository.DefaultBranch
+	}
+	result.Repository = repo
+	result.Steps = append(result.Steps, Step{Action: "create", Target: repo.FullName, Status: StatusApplied, Detail: visibility})
+
+	record := func(action, target string, err error) {
+		step := Step{Action: action, Target: target, Status: StatusApplied}
+		if err != nil {
+			step.Status, step.Detail = StatusFailed, err.Error()
+		}
+		result.Steps = append(result.Steps, step)
+	}
+
+	if len(plan.Files) > 0 {
+		err := a.backend.CommitFiles(ctx, repo, repo.DefaultBranch, a.opts.CommitMessage, plan.Files)
+		record("files", fileList(plan.Files), err)
+	}
+	if len(plan.Repository.Topics) > 0 {
+		record("topics", strings.Join(plan.Repository.Topics, ", "), a.backend.SetTopics(ctx, repo, plan.Repository.Topics))
+	}
+	for _, l := range plan.Labels {
+		record("label", l.Name, a.backend.EnsureLabel(ctx, repo, l))
+	}
+	for _, h := range plan.Webhooks {
+		record("webhook", h.URL, a.backend.EnsureWebhook(ctx, repo, h))
+	}
+	for _, rule := range plan.Protection {
+		branch := ruleBranch(rule, repo.DefaultBranch)
+		if a.protection == nil {
+			result.Steps = append(result.Steps, Step{
+				Action: "protect", Target: branch, Status: StatusSkipped,
+				Detail: "branch protection is not supported for " + a.backend.Provider(),
+			})
+			continue
+		}
+		record("protect", branch, a.protect(ctx, repo, branch, rule))
+	}
+	return result, nil
+}
+
+func (a *Applier) protect(ctx context.Context, repo protect.Repository, branch string, rule protect.BranchRule) error {
+	current, err := a.protection.GetProtection(ctx, repo, branch)
+	if err != nil {
+		return err
+	}
+	desired := rule.Desired(current)
+	if len(protect.Diff(current, desired)) == 0 {
+		return nil
+	}
+	return a.protection.ApplyProtection(ctx, repo, branch, current, desired)
+}
+
+func (a *Applier) plannedSteps(plan *Plan, fullName, visibility string) []Step {
+	steps := []Step{{Action: "create", Target: fullName, Status: StatusPlanned, Detail: visibility}}
+	if len(plan.Files) > 0 {
+		steps = append(steps, Step{Action: "files", Target: fileList(plan.Files), Status: StatusPlanned})
+	}
+	if len(plan.Repository.Topics) > 0 {
+		steps = append(steps, Step{Action: "topics", Target: strings.Join(plan.Repository.Topics, ", "), Status: StatusPlanned})
+	}
+	for _, l := range plan.Labels {
+		steps = append(steps, Step{Action: "label", Target: l.Name, Status: StatusPlanned})
+	}
+	for _, h := range plan.Webhooks {
+		steps = append(steps, Step{Action: "webhook", Target: h.URL, Status: StatusPlanned, Detail: strings.Join(h.Events, ", ")})
+	}
+	for _, rule := range plan.Protection {
+		step := Step{Action: "protect", Target: ruleBranch(rule, plan.Repository.DefaultBranch), Status: StatusPlanned}
+		if a.protection == nil {
+			step.Status, step.Detail = StatusSkipped, "branch protection is not supported for "+a.backend.Provider()
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func ruleBranch(rule protect.BranchRule, defaultBranch string) string {
+	if rule.Branch != "" {
+		return rule.Branch
+	}
+	return defaultBranch
+}
+
+func fileList(files []File) string {
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	return strings.Join(paths, ", ")
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repotemplate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gizzahub/gzh-cli/internal/git/protect"
+)
+
+// Backend creates and configures repositories on one platform.
+type Backend interface {
+	// Provider returns the platform name.
+	Provider() string
+	// CreateRepository creates an initialized repository in org, or in the
+	// authenticated user's namespace when org is empty.
+	CreateRepository(ctx context.Context, org, name string, spec RepositorySpec) (protect.Repository, error)
+	// CommitFiles creates or updates files on branch in a single commit.
+	CommitFiles(ctx context.Context, repo protect.Repository, branch, message string, files []File) error
+	// SetTopics replaces the repository topics.
+	SetTopics(ctx context.Context, repo protect.Repository, topics []string) error
+	// EnsureLabel creates the label or updates an existing one of that name.
+	EnsureLabel(ctx context.Context, repo protect.Repository, label Label) error
+	// EnsureWebhook adds the webhook unless one with the same URL exists.
+	EnsureWebhook(ctx context.Context, repo protect.Repository, hook Webhook) error
+}
+
+// NewBackend creates the backend for provider. baseURL selects a
+// self-hosted API and may be empty.
+func NewBackend(provider, baseURL, token string) (Backend, error) {
+	switch provider {
+	case "github":
+		return newGitHubBackend(baseURL, token), nil
+	case "gitlab":
+		return newGitLabBackend(baseURL, token), nil
+	case "gitea":
+		return newGiteaBackend(baseURL, token), nil
+	default:
+		return nil, fmt.Errorf("unsupported provider for repository templates: %s (supported: github, gitlab, gitea)", provider)
+	}
+}
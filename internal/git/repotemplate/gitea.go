@@ -0,0 +1,176 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repotemplate
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/git/protect"
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// giteaBackend implements Backend against the Gitea REST API (v1). Writing
+// several files in one commit requires Gitea 1.20 or later.
+type giteaBackend struct {
+	client *restapi.Client
+}
+
+func newGiteaBackend(baseURL, token string) *giteaBackend {
+	if baseURL == "" {
+		baseURL = "https://gitea.com/api/v1"
+	}
+	return &giteaBackend{
+		client: restapi.New("gitea", baseURL, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("Authorization", "token "+token)
+			}
+		}),
+	}
+}
+
+func (b *giteaBackend) Provider() string { return "gitea" }
+
+// nolint:tagliatelle // External API format - must match Gitea JSON output
+type gtRepo struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+type gtContent struct {
+	SHA string `json:"sha"`
+}
+
+type gtFileChange struct {
+	Operation string `json:"operation"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+	SHA       string `json:"sha,omitempty"`
+}
+
+type gtLabel struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type gtHook struct {
+	Config map[string]string `json:"config"`
+}
+
+func (b *giteaBackend) repoPath(repo protect.Repository) string {
+	return "/repos/" + repo.FullName
+}
+
+func (b *giteaBackend) CreateRepository(ctx context.Context, org, name string, spec RepositorySpec) (protect.Repository, error) {
+	path := "/user/repos"
+	if org != "" {
+		path = "/orgs/" + url.PathEscape(org) + "/repos"
+	}
+	body := map[string]any{
+		"name":        name,
+		"description": spec.Description,
+		"private":     spec.Private,
+		"auto_init":   true,
+		"readme":      "Default",
+	}
+	if spec.DefaultBranch != "" {
+		body["default_branch"] = spec.DefaultBranch
+	}
+
+	var created gtRepo
+	if err := b.client.Do(ctx, http.MethodPost, path, body, &created); err != nil {
+		return protect.Repository{}, fmt.Errorf("failed to create repository: %w", err)
+	}
+	repo := protect.Repository{ID: created.ID, Name: created.Name, FullName: created.FullName, DefaultBranch: created.DefaultBranch}
+
+	// Settings Gitea does not take on creation.
+	settings := map[string]any{}
+	if spec.Homepage != "" {
+		settings["website"] = spec.Homepage
+	}
+	if spec.Issues != nil {
+		settings["has_issues"] = *spec.Issues
+	}
+	if spec.Wiki != nil {
+		settings["has_wiki"] = *spec.Wiki
+	}
+	if len(settings) > 0 {
+		if err := b.client.Do(ctx, http.MethodPatch, b.repoPath(repo), settings, nil); err != nil {
+			return repo, fmt.Errorf("failed to update repository settings: %w", err)
+		}
+	}
+	return repo, nil
+}
+
+func (b *giteaBackend) CommitFiles(ctx context.Context, repo protect.Repository, branch, message string, files []File) error {
+	path := b.repoPath(repo) + "/contents"
+
+	changes := make([]gtFileChange, 0, len(files))
+	for _, f := range files {
+		change := gtFileChange{Operation: "create", Path: f.Path, Content: base64.StdEncoding.EncodeToString(f.Content)}
+		var existing gtContent
+		err := b.client.Do(ctx, http.MethodGet, path+"/"+f.Path+"?ref="+url.QueryEscape(branch), nil, &existing)
+		switch {
+		case err == nil:
+			change.Operation, change.SHA = "update", existing.SHA
+		case !errors.Is(err, restapi.ErrNotFound):
+			return err
+		}
+		changes = append(changes, change)
+	}
+
+	body := map[string]any{"branch": branch, "message": message, "files": changes}
+	if err := b.client.Do(ctx, http.MethodPost, path, body, nil); err != nil {
+		return fmt.Errorf("failed to commit files: %w", err)
+	}
+	return nil
+}
+
+func (b *giteaBackend) SetTopics(ctx context.Context, repo protect.Repository, topics []string) error {
+	return b.client.Do(ctx, http.MethodPut, b.repoPath(repo)+"/topics", map[string][]string{"topics": topics}, nil)
+}
+
+func (b *giteaBackend) EnsureLabel(ctx context.Context, repo protect.Repository, label Label) error {
+	path := b.repoPath(repo) + "/labels"
+	labels, err := restapi.ListAll[gtLabel](ctx, b.client, path, "limit")
+	if err != nil {
+		return err
+	}
+
+	body := map[string]string{"name": label.Name, "color": "#" + label.Color, "description": label.Description}
+	for _, l := range labels {
+		if l.Name == label.Name {
+			return b.client.Do(ctx, http.MethodPatch, path+"/"+strconv.FormatInt(l.ID, 10), body, nil)
+		}
+	}
+	return b.client.Do(ctx, http.MethodPost, path, body, nil)
+}
+
+func (b *giteaBackend) EnsureWebhook(ctx context.Context, repo protect.Repository, hook Webhook) error {
+	path := b.repoPath(repo) + "/hooks"
+	hooks, err := restapi.ListAll[gtHook](ctx, b.client, path, "limit")
+	if err != nil {
+		return err
+	}
+	for _, h := range hooks {
+		if strings.EqualFold(h.Config["url"], hook.URL) {
+			return nil
+		}
+	}
+
+	config := map[string]string{"url": hook.URL, "content_type": hook.ContentType}
+	if hook.Secret != "" {
+		config["secret"] = hook.Secret
+	}
+	body := map[string]any{"type": "gitea", "active": true, "events": hook.Events, "config": config}
+	return b.client.Do(ctx, http.MethodPost, path, body, nil)
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repotemplate
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/git/protect"
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// gitHubBackend implements Backend against the GitHub REST API. Files are
+// written through the Git data API so a template lands in one commit.
+type gitHubBackend struct {
+	client *restapi.Client
+}
+
+func newGitHubBackend(baseURL, token string) *gitHubBackend {
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return &gitHubBackend{
+		client: restapi.New("github", baseURL, func(req *http.Request) {
+			req.Header.Set("Accept", "application/vnd.github+json")
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}),
+	}
+}
+
+func (b *gitHubBackend) Provider() string { return "github" }
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghRepo struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// nolint:tagliatelle // External API format - must match GitHub JSON input
+type ghCreateRepo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Homepage    string `json:"homepage,omitempty"`
+	Private     bool   `json:"private"`
+	HasIssues   *bool  `json:"has_issues,omitempty"`
+	HasWiki     *bool  `json:"has_wiki,omitempty"`
+	AutoInit    bool   `json:"auto_init"`
+}
+
+type ghObject struct {
+	SHA string `json:"sha"`
+}
+
+type ghRef struct {
+	Object ghObject `json:"object"`
+}
+
+type ghCommit struct {
+	SHA  string   `json:"sha"`
+	Tree ghObject `json:"tree"`
+}
+
+type ghTreeEntry struct {
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+}
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghHook struct {
+	ID     int64             `json:"id,omitempty"`
+	Name   string            `json:"name,omitempty"`
+	Active bool              `json:"active"`
+	Events []string          `json:"events"`
+	Config map[string]string `json:"config"`
+}
+
+func (b *gitHubBackend) repoPath(repo protect.Repository) string {
+	return "/repos/" + repo.FullName
+}
+
+func (b *gitHubBackend) CreateRepository(ctx context.Context, org, name string, spec RepositorySpec) (protect.Repository, error) {
+	path := "/user/repos"
+	if org != "" {
+		path = "/orgs/" + url.PathEscape(org) + "/repos"
+	}
+
+	var created ghRepo
+	err := b.client.Do(ctx, http.MethodPost, path, ghCreateRepo{
+		Name:        name,
+		Description: spec.Description,
+		Homepage:    spec.Homepage,
+		Private:     spec.Private,
+		HasIssues:   spec.Issues,
+		HasWiki:     spec.Wiki,
+		AutoInit:    true,
+	}, &created)
+	if err != nil {
+		return protect.Repository{}, fmt.Errorf("failed to create repository: %w", err)
+	}
+	repo := protect.Repository{ID: created.ID, Name: created.Name, FullName: created.FullName, DefaultBranch: created.DefaultBranch}
+
+	// GitHub initializes the branch named in the owner's settings; move the
+	// default to the template's branch when they differ.
+	if spec.DefaultBranch != "" && spec.DefaultBranch != repo.DefaultBranch {
+		var ref ghRef
+		if err := b.client.Do(ctx, http.MethodGet, b.repoPath(repo)+"/git/ref/heads/"+repo.DefaultBranch, nil, &ref); err != nil {
+			return repo, fmt.Errorf("failed to read initial branch: %w", err)
+		}
+		body := map[string]string{"ref": "refs/heads/" + spec.DefaultBranch, "sha": ref.Object.SHA}
+		if err := b.client.Do(ctx, http.MethodPost, b.repoPath(repo)+"/git/refs", body, nil); err != nil {
+			return repo, fmt.Errorf("failed to create branch %s: %w", spec.DefaultBranch, err)
+		}
+		body = map[string]string{"default_branch": spec.DefaultBranch}
+		if err := b.client.Do(ctx, http.MethodPatch, b.repoPath(repo), body, nil); err != nil {
+			return repo, fmt.Errorf("failed to set default branch: %w", err)
+		}
+		_ = b.client.Do(ctx, http.MethodDelete, b.repoPath(repo)+"/git/refs/heads/"+repo.DefaultBranch, nil, nil)
+		repo.DefaultBranch = spec.DefaultBranch
+	}
+	return repo, nil
+}
+
+func (b *gitHubBackend) CommitFiles(ctx context.Context, repo protect.Repository, branch, message string, files []File) error {
+	base := b.repoPath(repo) + "/git"
+
+	var ref ghRef
+	if err := b.client.Do(ctx, http.MethodGet, base+"/ref/heads/"+branch, nil, &ref); err != nil {
+		return fmt.Errorf("failed to read branch %s: %w", branch, err)
+	}
+	var parent ghCommit
+	if err := b.client.Do(ctx, http.MethodGet, base+"/commits/"+ref.Object.SHA, nil, &parent); err != nil {
+		return fmt.Errorf("failed to read head commit: %w", err)
+	}
+
+	entries := make([]ghTreeEntry, 0, len(files))
+	for _, f := range files {
+		var blob ghObject
+		body := map[string]string{"content": base64.StdEncoding.EncodeToString(f.Content), "encoding": "base64"}
+		if err := b.client.Do(ctx, http.MethodPost, base+"/blobs", body, &blob); err != nil {
+			return fmt.Errorf("failed to upload %s: %w", f.Path, err)
+		}
+		entries = append(entries, ghTreeEntry{Path: f.Path, Mode: "100644", Type: "blob", SHA: blob.SHA})
+	}
+
+	var tree ghObject
+	if err := b.client.Do(ctx, http.MethodPost, base+"/trees", map[string]any{"base_tree": parent.Tree.SHA, "tree": entries}, &tree); err != nil {
+		return fmt.Errorf("failed to create tree: %w", err)
+	}
+	var commit ghCommit
+	body := map[string]any{"message": message, "tree": tree.SHA, "parents": []string{parent.SHA}}
+	if err := b.client.Do(ctx, http.MethodPost, base+"/commits", body, &commit); err != nil {
+		return fmt.Errorf("failed to create commit: %w", err)
+	}
+	if err := b.client.Do(ctx, http.MethodPatch, base+"/refs/heads/"+branch, map[string]string{"sha": commit.SHA}, nil); err != nil {
+		return fmt.Errorf("failed to update branch %s: %w", branch, err)
+	}
+	return nil
+}
+
+func (b *gitHubBackend) SetTopics(ctx context.Context, repo protect.Repository, topics []string) error {
+	return b.client.Do(ctx, http.MethodPut, b.repoPath(repo)+"/topics", map[string][]string{"names": topics}, nil)
+}
+
+func (b *gitHubBackend) EnsureLabel(ctx context.Context, repo protect.Repository, label Label) error {
+	path := b.repoPath(repo) + "/labels"
+	body := map[string]string{"name": label.Name, "color": label.Color, "description": label.Description}
+
+	err := b.client.Do(ctx, http.MethodGet, path+"/"+url.PathEscape(label.Name), nil, nil)
+	if errors.Is(err, restapi.ErrNotFound) {
+		return b.client.Do(ctx, http.MethodPost, path, body, nil)
+	}
+	if err != nil {
+		return err
+	}
+	return b.client.Do(ctx, http.MethodPatch, path+"/"+url.PathEscape(label.Name), body, nil)
+}
+
+func (b *gitHubBackend) EnsureWebhook(ctx context.Context, repo protect.Repository, hook Webhook) error {
+	path := b.repoPath(repo) + "/hooks"
+	hooks, err := restapi.ListAll[ghHook](ctx, b.client, path, "per_page")
+	if err != nil {
+		return err
+	}
+	for _, h := range hooks {
+		if strings.EqualFold(h.Config["url"], hook.URL) {
+			return nil
+		}
+	}
+
+	config := map[string]string{"url": hook.URL, "content_type": hook.ContentType}
+	if hook.Secret != "" {
+		config["secret"] = hook.Secret
+	}
+	return b.client.Do(ctx, http.MethodPost, path, ghHook{Name: "web", Active: true, Events: hook.Events, Config: config}, nil)
+}
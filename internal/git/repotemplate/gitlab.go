@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repotemplate
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/git/protect"
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// gitLabBackend implements Backend against the GitLab REST API (v4).
+type gitLabBackend struct {
+	client *restapi.Client
+}
+
+func newGitLabBackend(baseURL, token string) *gitLabBackend {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com/api/v4"
+	}
+	return &gitLabBackend{
+		client: restapi.New("gitlab", baseURL, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("PRIVATE-TOKEN", token)
+			}
+		}),
+	}
+}
+
+func (b *gitLabBackend) Provider() string { return "gitlab" }
+
+// gitLabHookEvents maps webhook event names to GitLab hook flags.
+var gitLabHookEvents = map[string]string{
+	"push":          "push_events",
+	"pull_request":  "merge_requests_events",
+	"issues":        "issues_events",
+	"issue_comment": "note_events",
+	"release":       "releases_events",
+	"create":        "tag_push_events",
+	"delete":        "tag_push_events",
+	"pipeline":      "pipeline_events",
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glProject struct {
+	ID                int64  `json:"id"`
+	Path              string `json:"path"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	DefaultBranch     string `json:"default_branch"`
+}
+
+type glNamespace struct {
+	ID int64 `json:"id"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON input
+type glCommitAction struct {
+	Action   string `json:"action"`
+	FilePath string `json:"file_path"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+type glHook struct {
+	URL string `json:"url"`
+}
+
+func (b *gitLabBackend) projectPath(repo protect.Repository) string {
+	if repo.ID != 0 {
+		return "/projects/" + strconv.FormatInt(repo.ID, 10)
+	}
+	return "/projects/" + url.PathEscape(repo.FullName)
+}
+
+func (b *gitLabBackend) CreateRepository(ctx context.Context, org, name string, spec RepositorySpec) (protect.Repository, error) {
+	visibility := "public"
+	if spec.Private {
+		visibility = "private"
+	}
+	body := map[string]any{
+		"name":                   name,
+		"path":                   name,
+		"description":            spec.Description,
+		"visibility":             visibility,
+		"initialize_with_readme": true,
+	}
+	if spec.DefaultBranch != "" {
+		body["default_branch"] = spec.DefaultBranch
+	}
+	if spec.Issues != nil {
+		body["issues_enabled"] = *spec.Issues
+	}
+	if spec.Wiki != nil {
+		body["wiki_enabled"] = *spec.Wiki
+	}
+	if org != "" {
+		var ns glNamespace
+		if err := b.client.Do(ctx, http.MethodGet, "/namespaces/"+url.PathEscape(org), nil, &ns); err != nil {
+			return protect.Repository{}, fmt.Errorf("failed to look up namespace %s: %w", org, err)
+		}
+		body["namespace_id"] = ns.ID
+	}
+
+	var p glProject
+	if err := b.client.Do(ctx, http.MethodPost, "/projects", body, &p); err != nil {
+		return protect.Repository{}, fmt.Errorf("failed to create project: %w", err)
+	}
+	return protect.Repository{ID: p.ID, Name: p.Path, FullName: p.PathWithNamespace, DefaultBranch: p.DefaultBranch}, nil
+}
+
+func (b *gitLabBackend) CommitFiles(ctx context.Context, repo protect.Repository, branch, message string, files []File) error {
+	project := b.projectPath(repo)
+
+	actions := make([]glCommitAction, 0, len(files))
+	for _, f := range files {
+		action := "update"
+		err := b.client.Do(ctx, http.MethodGet, project+"/repository/files/"+url.PathEscape(f.Path)+"?ref="+url.QueryEscape(branch), nil, nil)
+		if errors.Is(err, restapi.ErrNotFound) {
+			action = "create"
+		} else if err != nil {
+			return err
+		}
+		actions = append(actions, glCommitAction{
+			Action:   action,
+			FilePath: f.Path,
+			Content:  base64.StdEncoding.EncodeToString(f.Content),
+			Encoding: "base64",
+		})
+	}
+
+	body := map[string]any{"branch": branch, "commit_message": message, "actions": actions}
+	if err := b.client.Do(ctx, http.MethodPost, project+"/repository/commits", body, nil); err != nil {
+		return fmt.Errorf("failed to commit files: %w", err)
+	}
+	return nil
+}
+
+func (b *gitLabBackend) SetTopics(ctx context.Context, repo protect.Repository, topics []string) error {
+	return b.client.Do(ctx, http.MethodPut, b.projectPath(repo), map[string][]string{"topics": topics}, nil)
+}
+
+func (b *gitLabBackend) EnsureLabel(ctx context.Context, repo protect.Repository, label Label) error {
+	path := b.projectPath(repo) + "/labels"
+	body := map[string]string{"name": label.Name, "color": "#" + label.Color, "description": label.Description}
+
+	err := b.client.Do(ctx, http.MethodGet, path+"/"+url.PathEscape(label.Name), nil, nil)
+	if errors.Is(err, restapi.ErrNotFound) {
+		return b.client.Do(ctx, http.MethodPost, path, body, nil)
+	}
+	if err != nil {
+		return err
+	}
+	return b.client.Do(ctx, http.MethodPut, path+"/"+url.PathEscape(label.Name), body, nil)
+}
+
+func (b *gitLabBackend) EnsureWebhook(ctx context.Context, repo protect.Repository, hook Webhook) error {
+	path := b.projectPath(repo) + "/hooks"
+	hooks, err := restapi.ListAll[glHook](ctx, b.client, path, "per_page")
+	if err != nil {
+		return err
+	}
+	for _, h := range hooks {
+		if strings.EqualFold(h.URL, hook.URL) {
+			return nil
+		}
+	}
+
+	// GitLab enables push events unless told otherwise.
+	body := map[string]any{"url": hook.URL, "push_events": false, "enable_ssl_verification": true}
+	for _, event := range hook.Events {
+		flag, ok := gitLabHookEvents[event]
+		if !ok {
+			return fmt.Errorf("webhook event %q has no GitLab equivalent", event)
+		}
+		body[flag] = true
+	}
+	if hook.Secret != "" {
+		body["token"] = hook.Secret
+	}
+	return b.client.Do(ctx, http.MethodPost, path, body, nil)
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repotemplate
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/git/protect"
+)
+
+const testTemplate = `
+name: go-service
+variables:
+  - name: team
+    required: true
+  - name: license
+    default: MIT
+repository:
+  description: "{{ .Name }} owned by {{ .team }}"
+  private: true
+  default_branch: main
+  topics: [go, "team-{{ .team }}"]
+files:
+  - path: README.md
+    content: "# {{ .Name }} ({{ .license }})"
+  - path: ci.yml
+    source: files/ci.yml
+    raw: true
+  - path: docs
+    source: skeleton
+codeowners:
+  - pattern: "*"
+    owners: ["@acme/{{ .team }}"]
+labels:
+  - {name: bug, color: "#d73a4a"}
+webhooks:
+  - url: https://ci.example.com/hook
+    events: [push, pull_request]
+protection:
+  - required_reviews: 1
+`
+
+func writeTemplate(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		FileName:              testTemplate,
+		"files/ci.yml":        "name: {{ not rendered }}",
+		"skeleton/index.md":   "Welcome to {{ .Name }}",
+		"skeleton/a/notes.md": "{{ upper .team }}",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+	return dir
+}
+
+func TestRender(t *testing.T) {
+	tmpl, err := Load(writeTemplate(t))
+	require.NoError(t, err)
+
+	plan, err := tmpl.Render(Target{Provider: "github", Org: "acme", Name: "billing"}, map[string]string{"team": "payments"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "billing owned by payments", plan.Repository.Description)
+	assert.Equal(t, []string{"go", "team-payments"}, plan.Repository.Topics)
+	assert.Equal(t, "d73a4a", plan.Labels[0].Color)
+	assert.Equal(t, "json", plan.Webhooks[0].ContentType)
+
+	files := map[string]string{}
+	for _, f := range plan.Files {
+		files[f.Path] = string(f.Content)
+	}
+	assert.Equal(t, map[string]string{
+		"README.md":       "# billing (MIT)",
+		"ci.yml":          "name: {{ not rendered }}",
+		"docs/index.md":   "Welcome to billing",
+		"docs/a/notes.md": "PAYMENTS",
+		CodeOwnersPath:    "# Generated from repository template\n* @acme/payments\n",
+	}, files)
+}
+
+func TestRenderVariableErrors(t *testing.T) {
+	tmpl, err := Load(writeTemplate(t))
+	require.NoError(t, err)
+	target := Target{Provider: "github", Name: "svc"}
+
+	_, err = tmpl.Render(target, nil)
+	assert.ErrorContains(t, err, "missing required template variables: team")
+
+	_, err = tmpl.Render(target, map[string]string{"team": "x", "tema": "y"})
+	assert.ErrorContains(t, err, "unknown template variables: tema")
+
+	bad, err := Parse([]byte("files:\n  - path: a\n    content: '{{ .missing }}'\n"))
+	require.NoError(t, err)
+	_, err = bad.Render(target, nil)
+	assert.Error(t, err, "undeclared variables must not render as empty")
+}
+
+func TestParseValidation(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":        "labels:\n  - name: a\n    colour: ffffff\n",
+		"bad color":          "labels:\n  - {name: a, color: red}\n",
+		"builtin shadowed":   "variables:\n  - name: Name\n",
+		"webhook w/o events": "webhooks:\n  - url: https://x\n",
+		"bad protection":     "protection:\n  - required_reviews: 20\n",
+		"content and source": "files:\n  - {path: a, content: x, source: y}\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestResolveNamedTemplate(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Rename(writeTemplate(t), filepath.Join(root, "go-service")))
+
+	tmpl, cleanup, err := Resolve(context.Background(), "go-service", root)
+	require.NoError(t, err)
+	defer cleanup()
+	assert.Equal(t, "go-service", tmpl.Name)
+
+	_, _, err = Resolve(context.Background(), "missing", root)
+	assert.ErrorContains(t, err, "not found")
+}
+
+// fakeGitHub records the requests of a template application.
+type fakeGitHub struct {
+	mu       sync.Mutex
+	requests []string
+	tree     []map[string]any
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/orgs/acme/repos":
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1, "name": "billing", "full_name": "acme/billing", "default_branch": "main"})
+	case r.URL.Path == "/repos/acme/billing/git/ref/heads/main":
+		_ = json.NewEncoder(w).Encode(map[string]any{"object": map[string]string{"sha": "head"}})
+	case r.URL.Path == "/repos/acme/billing/git/commits/head":
+		_ = json.NewEncoder(w).Encode(map[string]any{"sha": "head", "tree": map[string]string{"sha": "tree0"}})
+	case r.URL.Path == "/repos/acme/billing/git/blobs":
+		content, _ := base64.StdEncoding.DecodeString(body["content"].(string))
+		_ = json.NewEncoder(w).Encode(map[string]string{"sha": "blob-" + string(content)})
+	case r.URL.Path == "/repos/acme/billing/git/trees":
+		for _, e := range body["tree"].([]any) {
+			f.tree = append(f.tree, e.(map[string]any))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"sha": "tree1"})
+	case r.URL.Path == "/repos/acme/billing/git/commits":
+		_ = json.NewEncoder(w).Encode(map[string]string{"sha": "commit1"})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/repos/acme/billing/labels/"):
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/billing/hooks":
+		_, _ = w.Write([]byte("[]"))
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/billing/branches/main/protection":
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/billing/branches/main":
+		_, _ = w.Write([]byte(`{"name":"main"}`))
+	default:
+		_, _ = w.Write([]byte("{}"))
+	}
+}
+
+func TestApplyGitHub(t *testing.T) {
+	fake := &fakeGitHub{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	tmpl, err := Load(writeTemplate(t))
+	require.NoError(t, err)
+	plan, err := tmpl.Render(Target{Provider: "github", Org: "acme", Name: "billing"}, map[string]string{"team": "payments"})
+	require.NoError(t, err)
+
+	backend, err := NewBackend("github", server.URL, "token")
+	require.NoError(t, err)
+	protection, err := protect.NewBackend("github", server.URL, "token")
+	require.NoError(t, err)
+
+	result, err := NewApplier(backend, protection, Options{}).Apply(context.Background(), plan)
+	require.NoError(t, err)
+	assert.False(t, result.Failed(), "%+v", result.Steps)
+	assert.Equal(t, "acme/billing", result.Repository.FullName)
+
+	var actions []string
+	for _, s := range result.Steps {
+		actions = append(actions, s.Action)
+	}
+	assert.Equal(t, []string{"create", "files", "topics", "label", "webhook", "protect"}, actions)
+
+	assert.Len(t, fake.tree, 5, "all files land in one tree")
+	assert.Contains(t, fake.requests, "PATCH /repos/acme/billing/git/refs/heads/main")
+	assert.Contains(t, fake.requests, "PUT /repos/acme/billing/topics")
+	assert.Contains(t, fake.requests, "POST /repos/acme/billing/labels")
+	assert.Contains(t, fake.requests, "POST /repos/acme/billing/hooks")
+	assert.Contains(t, fake.requests, "PUT /repos/acme/billing/branches/main/protection")
+}
+
+func TestApplyDryRunAndUnsupportedProtection(t *testing.T) {
+	tmpl, err := Load(writeTemplate(t))
+	require.NoError(t, err)
+	plan, err := tmpl.Render(Target{Provider: "gitea", Org: "acme", Name: "billing"}, map[string]string{"team": "payments"})
+	require.NoError(t, err)
+
+	backend, err := NewBackend("gitea", "http://127.0.0.1:0", "")
+	require.NoError(t, err)
+	result, err := NewApplier(backend, nil, Options{DryRun: true}).Apply(context.Background(), plan)
+	require.NoError(t, err)
+
+	last := result.Steps[len(result.Steps)-1]
+	assert.Equal(t, "protect", last.Action)
+	assert.Equal(t, StatusSkipped, last.Status)
+	for _, s := range result.Steps[:len(result.Steps)-1] {
+		assert.Equal(t, StatusPlanned, s.Status)
+	}
+}
+
+func TestGitLabWebhookEvents(t *testing.T) {
+	var hook map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte("[]"))
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&hook)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	b := newGitLabBackend(server.URL, "token")
+	repo := protect.Repository{ID: 7}
+	require.NoError(t, b.EnsureWebhook(context.Background(), repo, Webhook{URL: "https://x", Events: []string{"pull_request"}, Secret: "s"}))
+	assert.Equal(t, false, hook["push_events"])
+	assert.Equal(t, true, hook["merge_requests_events"])
+	assert.Equal(t, "s", hook["token"])
+
+	err := b.EnsureWebhook(context.Background(), repo, Webhook{URL: "https://y", Events: []string{"star"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,522 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package repotemplate bootstraps new repositories from declarative
+// templates: initial files, CODEOWNERS, labels, webhooks, topics and branch
+// protection, applied the same way on GitHub, GitLab and Gitea.
+package repotemplate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/internal/git/protect"
+)
+
+// FileName is the template definition inside a template directory.
+const FileName = "template.yaml"
+
+// Template is a declarative repository template.
+//
+//	name: go-service
+//	variables:
+//	  - name: team
+//	    required: true
+//	repository:
+//	  description: "{{ .Name }} service owned by {{ .team }}"
+//	  private: true
+//	  topics: [go, "team-{{ .team }}"]
+//	files:
+//	  - path: README.md
+//	    content: "# {{ .Name }}"
+//	  - path: .github/workflows/ci.yml
+//	    source: files/ci.yml
+//	    raw: true
+//	  - source: skeleton/           # a directory is copied recursively
+//	codeowners:
+//	  - pattern: "*"
+//	    owners: ["@acme/{{ .team }}"]
+//	labels:
+//	  - {name: bug, color: d73a4a, description: Something is broken}
+//	webhooks:
+//	  - url: https://ci.example.com/hook
+//	    events: [push, pull_request]
+//	    secret: '{{ env "CI_HOOK_SECRET" }}'
+//	protection:
+//	  - required_reviews: 1          # no branch: the default branch
+//	    require_code_owner_reviews: true
+//
+// Every string value is a text/template. Besides the declared variables,
+// .Name, .Org, .Provider, .DefaultBranch and .Year are available, and the
+// functions env, lower, upper and replace.
+type Template struct {
+	Name        string               `yaml:"name"`
+	Description string               `yaml:"description"`
+	Variables   []Variable           `yaml:"variables"`
+	Repository  RepositorySpec       `yaml:"repository"`
+	Files       []FileSpec           `yaml:"files"`
+	CodeOwners  []CodeOwnerRule      `yaml:"codeowners"`
+	Labels      []Label              `yaml:"labels"`
+	Webhooks    []Webhook            `yaml:"webhooks"`
+	Protection  []protect.BranchRule `yaml:"protection"`
+
+	// dir is the directory file sources are relative to.
+	dir string
+}
+
+// Variable is a template parameter.
+type Variable struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Default     string `yaml:"default"`
+	Required    bool   `yaml:"required"`
+}
+
+// RepositorySpec holds the repository settings.
+type RepositorySpec struct {
+	Description   string   `yaml:"description" json:"description,omitempty"`
+	Homepage      string   `yaml:"homepage" json:"homepage,omitempty"`
+	Private       bool     `yaml:"private" json:"private"`
+	DefaultBranch string   `yaml:"default_branch" json:"defaultBranch,omitempty"`
+	Topics        []string `yaml:"topics" json:"topics,omitempty"`
+	Issues        *bool    `yaml:"issues" json:"issues,omitempty"`
+	Wiki          *bool    `yaml:"wiki" json:"wiki,omitempty"`
+}
+
+// FileSpec is an initial file or, when Source is a directory, a tree of
+// files placed under Path.
+type FileSpec struct {
+	Path    string `yaml:"path"`
+	Content string `yaml:"content"`
+	Source  string `yaml:"source"`
+	// Raw files are copied without variable substitution.
+	Raw bool `yaml:"raw"`
+}
+
+// CodeOwnerRule is one CODEOWNERS line.
+type CodeOwnerRule struct {
+	Pattern string   `yaml:"pattern"`
+	Owners  []string `yaml:"owners"`
+}
+
+// Label is an issue label. Color is six hex digits, with or without '#'.
+type Label struct {
+	Name        string `yaml:"name" json:"name"`
+	Color       string `yaml:"color" json:"color"`
+	Description string `yaml:"description" json:"description,omitempty"`
+}
+
+// Webhook is a repository webhook. Events use GitHub names (push,
+// pull_request, issues, issue_comment, release, create, delete); backends
+// map them to their own.
+type Webhook struct {
+	URL         string   `yaml:"url" json:"url"`
+	Events      []string `yaml:"events" json:"events"`
+	ContentType string   `yaml:"content_type" json:"contentType,omitempty"`
+	Secret      string   `yaml:"secret" json:"-"`
+}
+
+var (
+	variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	labelColor   = regexp.MustCompile(`^#?[0-9A-Fa-f]{6}$`)
+
+	// builtinVariables may not be redeclared by templates.
+	builtinVariables = map[string]bool{"Name": true, "Org": true, "Provider": true, "DefaultBranch": true, "Year": true}
+)
+
+// Load reads a template from a template directory or a template.yaml file.
+func Load(location string) (*Template, error) {
+	file := location
+	if info, err := os.Stat(location); err == nil && info.IsDir() {
+		file = filepath.Join(location, FileName)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	t, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	t.dir = filepath.Dir(file)
+	return t, nil
+}
+
+// Parse parses and validates template YAML. Unknown keys are rejected.
+func Parse(data []byte) (*Template, error) {
+	var t Template
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Validate checks the template for mistakes that do not depend on variables.
+func (t *Template) Validate() error {
+	seen := map[string]bool{}
+	for _, v := range t.Variables {
+		switch {
+		case !variableName.MatchString(v.Name):
+			return fmt.Errorf("invalid variable name %q", v.Name)
+		case builtinVariables[v.Name]:
+			return fmt.Errorf("variable %q shadows a built-in variable", v.Name)
+		case seen[v.Name]:
+			return fmt.Errorf("variable %q declared twice", v.Name)
+		}
+		seen[v.Name] = true
+	}
+	for i, f := range t.Files {
+		if f.Content != "" && f.Source != "" {
+			return fmt.Errorf("files[%d]: content and source are mutually exclusive", i)
+		}
+		if f.Source == "" && f.Path == "" {
+			return fmt.Errorf("files[%d]: path is required for inline content", i)
+		}
+	}
+	for _, l := range t.Labels {
+		if l.Name == "" {
+			return fmt.Errorf("label without a name")
+		}
+		if !labelColor.MatchString(l.Color) {
+			return fmt.Errorf("label %q: color must be six hex digits", l.Name)
+		}
+	}
+	for _, w := range t.Webhooks {
+		if w.URL == "" {
+			return fmt.Errorf("webhook without a url")
+		}
+		if len(w.Events) == 0 {
+			return fmt.Errorf("webhook %s: at least one event is required", w.URL)
+		}
+	}
+	for _, r := range t.CodeOwners {
+		if r.Pattern == "" || len(r.Owners) == 0 {
+			return fmt.Errorf("codeowners rules need a pattern and owners")
+		}
+	}
+	if len(t.Protection) > 0 {
+		policy := protect.Policy{Branches: t.Protection}
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("protection: %w", err)
+		}
+	}
+	return nil
+}
+
+// Target identifies the repository to create.
+type Target struct {
+	Provider string
+	Org      string
+	Name     string
+}
+
+// File is a rendered file.
+type File struct {
+	Path    string `json:"path"`
+	Content []byte `json:"-"`
+}
+
+// Plan is a template rendered for one repository.
+type Plan struct {
+	Target     Target               `json:"target"`
+	Repository RepositorySpec       `json:"repository"`
+	Files      []File               `json:"files"`
+	Labels     []Label              `json:"labels,omitempty"`
+	Webhooks   []Webhook            `json:"webhooks,omitempty"`
+	Protection []protect.BranchRule `json:"protection,omitempty"`
+}
+
+// CodeOwnersPath is where the rendered CODEOWNERS file is placed; the root
+// is honored by GitHub, GitLab and Gitea alike.
+const CodeOwnersPath = "CODEOWNERS"
+
+// Render substitutes variables into the template. vars holds the values of
+// declared variables; missing required variables and unknown names are
+// errors.
+func (t *Template) Render(target Target, vars map[string]string) (*Plan, error) {
+	data, err := t.variables(target, vars)
+	if err != nil {
+		return nil, err
+	}
+	r := &renderer{data: data}
+
+	plan := &Plan{Target: target}
+	spec := t.Repository
+	spec.Description = r.render("repository.description", spec.Description)
+	spec.Homepage = r.render("repository.homepage", spec.Homepage)
+	spec.DefaultBranch = data["DefaultBranch"].(string)
+	spec.Topics = r.renderAll("repository.topics", spec.Topics)
+	plan.Repository = spec
+
+	files, err := t.renderFiles(r)
+	if err != nil {
+		return nil, err
+	}
+	plan.Files = files
+
+	for _, l := range t.Labels {
+		plan.Labels = append(plan.Labels, Label{
+			Name:        r.render("labels.name", l.Name),
+			Color:       strings.TrimPrefix(l.Color, "#"),
+			Description: r.render("labels.description", l.Description),
+		})
+	}
+	for _, w := range t.Webhooks {
+		w.URL = r.render("webhooks.url", w.URL)
+		w.Secret = r.render("webhooks.secret", w.Secret)
+		if w.ContentType == "" {
+			w.ContentType = "json"
+		}
+		plan.Webhooks = append(plan.Webhooks, w)
+	}
+	for _, rule := range t.Protection {
+		rule.Branch = r.render("protection.branch", rule.Branch)
+		plan.Protection = append(plan.Protection, rule)
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	return plan, nil
+}
+
+// variables builds the template data from built-ins, defaults and vars.
+func (t *Template) variables(target Target, vars map[string]string) (map[string]any, error) {
+	branch := t.Repository.DefaultBranch
+	if branch == "" {
+		branch = "main"
+	}
+	data := map[string]any{
+		"Name":          target.Name,
+		"Org":           target.Org,
+		"Provider":      target.Provider,
+		"DefaultBranch": branch,
+		"Year":          time.Now().Year(),
+	}
+
+	declared := map[string]bool{}
+	var missing []string
+	for _, v := range t.Variables {
+		declared[v.Name] = true
+		value, ok := vars[v.Name]
+		if !ok {
+			value = v.Default
+		}
+		if value == "" && v.Required {
+			missing = append(missing, v.Name)
+		}
+		data[v.Name] = value
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required template variables: %s (set with --var name=value)", strings.Join(missing, ", "))
+	}
+
+	var unknown []string
+	for name := range vars {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown template variables: %s", strings.Join(unknown, ", "))
+	}
+	return data, nil
+}
+
+func (t *Template) renderFiles(r *renderer) ([]File, error) {
+	var files []File
+	add := func(p string, content []byte, raw bool) {
+		if !raw {
+			content = []byte(r.render(p, string(content)))
+		}
+		files = append(files, File{Path: path.Clean(strings.TrimPrefix(filepath.ToSlash(p), "/")), Content: content})
+	}
+
+	for _, f := range t.Files {
+		dest := r.render("files.path", f.Path)
+		if f.Source == "" {
+			add(dest, []byte(f.Content), f.Raw)
+			continue
+		}
+
+		src := filepath.Join(t.dir, filepath.FromSlash(f.Source))
+		info, err := os.Stat(src)
+		if err != nil {
+			return nil, fmt.Errorf("template file source: %w", err)
+		}
+		if !info.IsDir() {
+			if dest == "" {
+				dest = filepath.Base(src)
+			}
+			content, err := os.ReadFile(src)
+			if err != nil {
+				return nil, fmt.Errorf("template file source: %w", err)
+			}
+			add(dest, content, f.Raw)
+			continue
+		}
+
+		err = filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(src, p)
+			if err != nil {
+				return err
+			}
+			content, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			add(path.Join(dest, filepath.ToSlash(rel)), content, f.Raw)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("template file source: %w", err)
+		}
+	}
+
+	if len(t.CodeOwners) > 0 {
+		var b strings.Builder
+		b.WriteString("# Generated from repository template\n")
+		for _, rule := range t.CodeOwners {
+			owners := r.renderAll("codeowners.owners", rule.Owners)
+			fmt.Fprintf(&b, "%s %s\n", r.render("codeowners.pattern", rule.Pattern), strings.Join(owners, " "))
+		}
+		add(CodeOwnersPath, []byte(b.String()), true)
+	}
+
+	// Later entries override earlier ones for the same path.
+	index := map[string]int{}
+	deduped := files[:0]
+	for _, f := range files {
+		if i, ok := index[f.Path]; ok {
+			deduped[i] = f
+			continue
+		}
+		index[f.Path] = len(deduped)
+		deduped = append(deduped, f)
+	}
+	return deduped, nil
+}
+
+// renderer executes text templates and keeps the first error.
+type renderer struct {
+	data map[string]any
+	err  error
+}
+
+var funcs = template.FuncMap{
+	"env":     os.Getenv,
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"replace": func(old, repl, s string) string { return strings.ReplaceAll(s, old, repl) },
+}
+
+func (r *renderer) render(name, text string) string {
+	if r.err != nil || !strings.Contains(text, "{{") {
+		return text
+	}
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		r.err = fmt.Errorf("invalid template in %s: %w", name, err)
+		return ""
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, r.data); err != nil {
+		r.err = fmt.Errorf("failed to render %s: %w", name, err)
+		return ""
+	}
+	return b.String()
+}
+
+func (r *renderer) renderAll(name string, values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, r.render(name, v))
+	}
+	return out
+}
+
+// DefaultDir returns the directory searched for named templates:
+// $GZH_CONFIG_DIR/templates or ~/.config/gzh-manager/templates.
+func DefaultDir() string {
+	if dir := os.Getenv("GZH_CONFIG_DIR"); dir != "" {
+		return filepath.Join(dir, "templates")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".config", "gzh-manager", "templates")
+}
+
+// IsRemote reports whether ref names a template repository to clone rather
+// than a local template.
+func IsRemote(ref string) bool {
+	return strings.Contains(ref, "://") || strings.HasPrefix(ref, "git@") || strings.HasSuffix(ref, ".git")
+}
+
+// Resolve loads the template ref refers to: a template repository URL
+// (cloned shallowly; "url#subdir" selects a directory inside it), a local
+// directory or template.yaml, or the name of a directory in templatesDir.
+// The returned cleanup removes any clone and must be called once the plan
+// has been rendered.
+func Resolve(ctx context.Context, ref, templatesDir string) (*Template, func(), error) {
+	noop := func() {}
+
+	if IsRemote(ref) {
+		repoURL, subdir, _ := strings.Cut(ref, "#")
+		tmp, err := os.MkdirTemp("", "gz-repo-template-")
+		if err != nil {
+			return nil, noop, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		cleanup := func() { os.RemoveAll(tmp) }
+
+		cmd := exec.CommandContext(ctx, "git", "clone", "--depth", "1", "--quiet", repoURL, tmp)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			cleanup()
+			return nil, noop, fmt.Errorf("failed to clone template repository %s: %w: %s", repoURL, err, strings.TrimSpace(string(out)))
+		}
+		t, err := Load(filepath.Join(tmp, filepath.FromSlash(subdir)))
+		if err != nil {
+			cleanup()
+			return nil, noop, err
+		}
+		return t, cleanup, nil
+	}
+
+	if _, err := os.Stat(ref); err == nil {
+		t, err := Load(ref)
+		return t, noop, err
+	}
+
+	if strings.ContainsAny(ref, `/\`) {
+		return nil, noop, fmt.Errorf("template %s not found", ref)
+	}
+	dir := filepath.Join(templatesDir, ref)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, noop, fmt.Errorf("template %q not found in %s", ref, templatesDir)
+	}
+	t, err := Load(dir)
+	return t, noop, err
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package restapi is the minimal JSON REST client shared by the provider
+// backends of the git subcommands (migrate, protect, teams, labels, ...).
+// Requests go through httpclient.NewProviderClient, so they honor the
+// proxy, CA and client certificate settings of the provider.
+package restapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+// PageSize is the number of items ListAll requests per page.
+const PageSize = 100
+
+// maxPages bounds ListAll so that a server repeating its next link cannot
+// loop forever.
+const maxPages = 1000
+
+// ErrNotFound is returned for HTTP 404 responses.
+var ErrNotFound = errors.New("not found")
+
+// StatusError is returned for unsuccessful responses other than HTTP 404.
+type StatusError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Body is the start of the response body.
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s: HTTP %d - %s", e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+// Client sends JSON requests to one provider API.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	authorize  func(req *http.Request)
+}
+
+// New creates a client for the API at baseURL. authorize adds the
+// credentials to each request.
+func New(provider, baseURL string, authorize func(req *http.Request)) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpclient.NewProviderClient(provider, 60*time.Second),
+		authorize:  authorize,
+	}
+}
+
+// BaseURL returns the API base URL without a trailing slash.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Do sends a JSON request to path below the base URL and decodes a JSON
+// response into out when non-nil.
+func (c *Client) Do(ctx context.Context, method, path string, body, out any) error {
+	_, err := c.Request(ctx, method, c.baseURL+path, body, out)
+	return err
+}
+
+// Request sends a JSON request to an absolute URL and returns the response
+// headers.
+func (c *Client) Request(ctx context.Context, method, target string, body, out any) (http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	path := strings.TrimPrefix(target, c.baseURL)
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gzh-cli")
+	c.authorize(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s %s: %w", method, path, ErrNotFound)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, &StatusError{Method: method, Path: path, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+
+	if out == nil {
+		return resp.Header, nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", path, err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return resp.Header, nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("failed to decode %s response: %w", path, err)
+	}
+	return resp.Header, nil
+}
+
+// Exists reports whether a list endpoint returns at least one item. path
+// must already ask for a single item per page.
+func (c *Client) Exists(ctx context.Context, path string) (bool, error) {
+	var items []json.RawMessage
+	if err := c.Do(ctx, http.MethodGet, path, nil, &items); err != nil {
+		return false, err
+	}
+	return len(items) > 0, nil
+}
+
+var (
+	nextLinkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+	lastLinkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="last"`)
+)
+
+// totalHeaders report the number of items of a list: Gitea sends
+// X-Total-Count and GitLab X-Total.
+var totalHeaders = []string{"X-Total-Count", "X-Total"}
+
+// ListAll fetches every page of a list endpoint. It follows the Link
+// header's next URL when the server sends one, which keeps listing correct
+// under keyset pagination and when entries change between pages, and falls
+// back to page numbers otherwise. sizeParam is the page size query
+// parameter, which differs between providers.
+func ListAll[T any](ctx context.Context, c *Client, path, sizeParam string) ([]T, error) {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	pageURL := func(page int) string {
+		return c.baseURL + path + sep + "page=" + strconv.Itoa(page) + "&" + sizeParam + "=" + strconv.Itoa(PageSize)
+	}
+
+	next := pageURL(1)
+	var all []T
+	for page := 1; page <= maxPages; page++ {
+		var items []T
+		header, err := c.Request(ctx, http.MethodGet, next, nil, &items)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if m := nextLinkPattern.FindStringSubmatch(header.Get("Link")); m != nil {
+			link, err := url.Parse(m[1])
+			if err != nil || !strings.HasPrefix(link.String(), c.baseURL) {
+				return nil, fmt.Errorf("GET %s: unexpected next page link %q", path, m[1])
+			}
+			next = link.String()
+			continue
+		}
+		if header.Get("Link") != "" || len(items) < PageSize {
+			return all, nil
+		}
+		next = pageURL(page + 1)
+	}
+	return nil, fmt.Errorf("GET %s: more than %d pages", path, maxPages)
+}
+
+// Count returns the number of items of a list endpoint with one request
+// for a single item per page. The total is read from the X-Total-Count or
+// X-Total header, or else from the page number of the Link header's last
+// URL, as GitHub reports it. Only when the server reports neither, as
+// GitLab does for very large lists, are the pages listed with ListAll,
+// which stops after maxPages.
+func Count(ctx context.Context, c *Client, path, sizeParam string) (int, error) {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+
+	var items []json.RawMessage
+	header, err := c.Request(ctx, http.MethodGet, c.baseURL+path+sep+sizeParam+"=1", nil, &items)
+	if err != nil {
+		return 0, err
+	}
+	for _, name := range totalHeaders {
+		if total, err := strconv.Atoi(header.Get(name)); err == nil && total >= 0 {
+			return total, nil
+		}
+	}
+	if m := lastLinkPattern.FindStringSubmatch(header.Get("Link")); m != nil {
+		if link, err := url.Parse(m[1]); err == nil {
+			if last, err := strconv.Atoi(link.Query().Get("page")); err == nil && last > 0 {
+				return last, nil
+			}
+		}
+	}
+	// 페이지가 하나뿐이면 받은 항목 수가 전체다
+	if header.Get("Link") == "" && len(items) <= 1 {
+		return len(items), nil
+	}
+
+	all, err := ListAll[json.RawMessage](ctx, c, path, sizeParam)
+	if err != nil {
+		return 0, err
+	}
+	return len(all), nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return New("github", srv.URL+"/", func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer token")
+	})
+}
+
+func TestClientDo(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/echo":
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			_ = json.NewEncoder(w).Encode(body)
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+		case "/forbidden":
+			http.Error(w, "no access", http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	ctx := context.Background()
+
+	var out map[string]string
+	require.NoError(t, c.Do(ctx, http.MethodPost, "/echo", map[string]string{"name": "gz"}, &out))
+	assert.Equal(t, map[string]string{"name": "gz"}, out)
+	assert.NoError(t, c.Do(ctx, http.MethodDelete, "/empty", nil, &out))
+
+	err := c.Do(ctx, http.MethodGet, "/missing", nil, nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "GET /missing: not found")
+	err = c.Do(ctx, http.MethodGet, "/forbidden", nil, nil)
+	assert.EqualError(t, err, "GET /forbidden: HTTP 403 - no access")
+	var status *StatusError
+	require.ErrorAs(t, err, &status)
+	assert.Equal(t, http.StatusForbidden, status.StatusCode)
+}
+
+func TestListAll(t *testing.T) {
+	items := func(from, n int) []int {
+		out := make([]int, n)
+		for i := range out {
+			out[i] = from + i
+		}
+		return out
+	}
+
+	t.Run("page numbers", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+			assert.Equal(t, "all", r.URL.Query().Get("type"))
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			n := PageSize
+			if page == 2 {
+				n = 5
+			}
+			_ = json.NewEncoder(w).Encode(items((page-1)*PageSize, n))
+		})
+		all, err := ListAll[int](context.Background(), c, "/repos?type=all", "per_page")
+		require.NoError(t, err)
+		assert.Len(t, all, PageSize+5)
+	})
+
+	t.Run("link header", func(t *testing.T) {
+		var c *Client
+		c = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			// 커서 기반 페이지는 항목 수와 관계없이 Link 헤더를 따른다
+			if r.URL.Query().Get("cursor") == "" {
+				w.Header().Set("Link", `<`+c.BaseURL()+`/repos?cursor=b>; rel="next"`)
+				_ = json.NewEncoder(w).Encode(items(0, 3))
+				return
+			}
+			w.Header().Set("Link", `<`+c.BaseURL()+`/repos?page=1>; rel="first"`)
+			_ = json.NewEncoder(w).Encode(items(3, PageSize))
+		})
+		all, err := ListAll[int](context.Background(), c, "/repos", "per_page")
+		require.NoError(t, err)
+		assert.Equal(t, items(0, PageSize+3), all)
+	})
+
+	t.Run("foreign next link", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Link", `<https://evil.example.com/repos?page=2>; rel="next"`)
+			_ = json.NewEncoder(w).Encode(items(0, 1))
+		})
+		_, err := ListAll[int](context.Background(), c, "/repos", "per_page")
+		assert.ErrorContains(t, err, "unexpected next page link")
+	})
+}
+
+func TestClientExists(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("search") == "bug" {
+			_, _ = w.Write([]byte(`[{"name":"bug"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	})
+	ctx := context.Background()
+
+	ok, err := c.Exists(ctx, "/labels?search=bug&per_page=1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = c.Exists(ctx, "/labels?search=docs&per_page=1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestCount(t *testing.T) {
+	var requests int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/gitea":
+			assert.Equal(t, "1", r.URL.Query().Get("limit"))
+			w.Header().Set("X-Total-Count", "73")
+			_, _ = w.Write([]byte(`[{}]`))
+		case "/gitlab":
+			w.Header().Set("X-Total", "12")
+			_, _ = w.Write([]byte(`[{}]`))
+		case "/github":
+			assert.Equal(t, "open", r.URL.Query().Get("state"))
+			w.Header().Set("Link", `<http://api/github?page=2>; rel="next", <http://api/github?state=open&per_page=1&page=4021>; rel="last"`)
+			_, _ = w.Write([]byte(`[{}]`))
+		case "/single":
+			_, _ = w.Write([]byte(`[{}]`))
+		case "/unreported":
+			// 전체 개수를 알려주지 않으면 페이지를 모두 센다
+			if r.URL.Query().Get("page") == "" {
+				w.Header().Set("Link", `<http://api/unreported?page=2>; rel="next"`)
+				_, _ = w.Write([]byte(`[{}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{}, {}, {}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	ctx := context.Background()
+
+	for path, want := range map[string]int{
+		"/gitea":             73,
+		"/gitlab":            12,
+		"/github?state=open": 4021,
+		"/single":            1,
+		"/unreported":        3,
+	} {
+		requests = 0
+		sizeParam := "per_page"
+		if path == "/gitea" {
+			sizeParam = "limit"
+		}
+		got, err := Count(ctx, c, path, sizeParam)
+		require.NoError(t, err, path)
+		assert.Equal(t, want, got, path)
+		if path != "/unreported" {
+			assert.Equal(t, 1, requests, path)
+		}
+	}
+
+	_, err := Count(ctx, c, "/missing", "per_page")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
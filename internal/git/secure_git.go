@@ -43,6 +43,9 @@ var AllowedGitOptions = map[string]bool{
 	"--depth":          true,
 	"--shallow-depth":  true,
 	"--single-branch":  true,
+	// Partial clones used by sparse checkouts
+	"--no-checkout":      true,
+	"--filter=blob:none": true,
 }
 
 // SecureGitExecutor provides safe git command execution with input validation
@@ -0,0 +1,415 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package submodule initializes and updates the submodules of a working
+// tree, recursively and in parallel.
+//
+// Submodules are updated with their own pool of workers, which may be shared
+// by the repositories of a bulk operation so that the number of concurrent
+// submodule clones stays bounded however many repositories are processed.
+// Individual submodules can be skipped or pinned to another commit, and every
+// failure is collected instead of stopping at the first one.
+package submodule
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Strategy selects how submodules are brought up to date.
+type Strategy string
+
+const (
+	// StrategyRecursive checks out the commits recorded by the superproject,
+	// including nested submodules.
+	StrategyRecursive Strategy = "recursive"
+	// StrategyShallow is StrategyRecursive with depth-1 submodule clones.
+	StrategyShallow Strategy = "shallow"
+	// StrategyRemote checks out the tip of each submodule's tracking branch
+	// instead of the recorded commit.
+	StrategyRemote Strategy = "remote"
+	// StrategySkip leaves submodules uninitialized.
+	StrategySkip Strategy = "skip"
+)
+
+// DefaultJobs is the size of a pool created for a single Update.
+const DefaultJobs = 4
+
+// ErrInvalidStrategy is returned for an unknown strategy.
+var ErrInvalidStrategy = errors.New("invalid submodule strategy, must be 'recursive', 'shallow', 'remote' or 'skip'")
+
+// Strategies lists the valid strategies.
+func Strategies() []string {
+	return []string{string(StrategyRecursive), string(StrategyShallow), string(StrategyRemote), string(StrategySkip)}
+}
+
+// ParseStrategy parses a strategy; "" is StrategyRecursive.
+func ParseStrategy(s string) (Strategy, error) {
+	switch Strategy(s) {
+	case "":
+		return StrategyRecursive, nil
+	case StrategyRecursive, StrategyShallow, StrategyRemote, StrategySkip:
+		return Strategy(s), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidStrategy, s)
+	}
+}
+
+// Pool bounds the number of submodules updated at once. A pool may be
+// shared by concurrent Update calls.
+type Pool struct {
+	sem chan struct{}
+}
+
+// NewPool returns a pool of n workers; n < 1 uses DefaultJobs.
+func NewPool(n int) *Pool {
+	if n < 1 {
+		n = DefaultJobs
+	}
+	return &Pool{sem: make(chan struct{}, n)}
+}
+
+func (p *Pool) acquire(ctx context.Context) error {
+	select {
+	case p.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) release() { <-p.sem }
+
+// Options controls Update.
+type Options struct {
+	Strategy Strategy
+
+	// Pool runs the updates; nil creates a pool of DefaultJobs for this
+	// call.
+	Pool *Pool
+
+	// Skip lists submodules to leave uninitialized, by path relative to the
+	// top-level repository or by name. Patterns use path.Match syntax, and
+	// skipping a submodule also skips the submodules nested in it.
+	Skip []string
+
+	// Pins maps submodule paths or names to a commit, tag or branch to check
+	// out instead of the commit the superproject records.
+	Pins map[string]string
+
+	// Env is added to the environment of git commands.
+	Env []string
+}
+
+// Status is the outcome for one submodule.
+type Status string
+
+const (
+	StatusUpdated Status = "updated"
+	StatusPinned  Status = "pinned"
+	StatusSkipped Status = "skipped"
+	StatusFailed  Status = "failed"
+)
+
+// Result is the outcome for one submodule.
+type Result struct {
+	// Path is relative to the top-level repository.
+	Path   string `json:"path"`
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	// Ref is the pinned ref, for StatusPinned.
+	Ref string `json:"ref,omitempty"`
+	Err error  `json:"-"`
+}
+
+// Report lists the results of an Update ordered by path.
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// Count returns the number of results with the given status.
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Err returns an *Error listing every failed submodule, or nil.
+func (r *Report) Err() error {
+	var failed []Result
+	for _, res := range r.Results {
+		if res.Status == StatusFailed {
+			failed = append(failed, res)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return &Error{Failed: failed, Total: len(r.Results)}
+}
+
+// Error aggregates the failures of an Update.
+type Error struct {
+	Failed []Result
+	Total  int
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	parts := make([]string, 0, len(e.Failed))
+	for _, f := range e.Failed {
+		parts = append(parts, fmt.Sprintf("%s: %v", f.Path, f.Err))
+	}
+	return fmt.Sprintf("%d of %d submodule(s) failed: %s", len(e.Failed), e.Total, strings.Join(parts, "; "))
+}
+
+// Unwrap returns the individual failures.
+func (e *Error) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, f := range e.Failed {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// Submodule is an entry of a .gitmodules file.
+type Submodule struct {
+	Name string
+	Path string
+}
+
+// List reads the submodules declared in the .gitmodules file of the working
+// tree at dir. A tree without the file has none.
+func List(ctx context.Context, dir string) ([]Submodule, error) {
+	if _, err := os.Stat(filepath.Join(dir, ".gitmodules")); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	out, err := runGit(ctx, dir, nil, "config", "--file", ".gitmodules", "--get-regexp", `^submodule\..*\.path$`)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil, nil // no entries
+		}
+		return nil, fmt.Errorf("failed to read .gitmodules: %w", err)
+	}
+
+	var subs []Submodule
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "submodule."), ".path")
+		subs = append(subs, Submodule{Name: name, Path: value})
+	}
+	return subs, nil
+}
+
+// Update initializes and updates the submodules of the working tree at dir
+// according to opts. Failures of individual submodules are recorded in the
+// report, see Report.Err; the error is only set for invalid options.
+func Update(ctx context.Context, dir string, opts Options) (*Report, error) {
+	strategy, err := ParseStrategy(string(opts.Strategy))
+	if err != nil {
+		return nil, err
+	}
+	opts.Strategy = strategy
+	if opts.Pool == nil {
+		opts.Pool = NewPool(DefaultJobs)
+	}
+
+	report := &Report{}
+	if opts.Strategy == StrategySkip {
+		return report, nil
+	}
+
+	u := &updater{opts: opts, report: report}
+	u.updateTree(ctx, dir, "")
+	u.wg.Wait()
+
+	sort.Slice(report.Results, func(i, j int) bool { return report.Results[i].Path < report.Results[j].Path })
+	return report, nil
+}
+
+type updater struct {
+	opts   Options
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	report *Report
+}
+
+func (u *updater) record(r Result) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.report.Results = append(u.report.Results, r)
+}
+
+// updateTree updates the submodules of one working tree and, as each one
+// finishes, the submodules nested in it. prefix is the path of dir relative
+// to the top-level repository.
+func (u *updater) updateTree(ctx context.Context, dir, prefix string) {
+	subs, err := List(ctx, dir)
+	if err != nil {
+		u.record(Result{Path: displayPath(prefix), Status: StatusFailed, Err: err})
+		return
+	}
+
+	var selected []Submodule
+	for _, s := range subs {
+		full := path.Join(prefix, s.Path)
+		if matchAny(u.opts.Skip, s.Name, full) {
+			u.record(Result{Path: full, Name: s.Name, Status: StatusSkipped})
+			continue
+		}
+		selected = append(selected, s)
+	}
+	if len(selected) == 0 {
+		return
+	}
+
+	// Initialization writes the superproject's config, so it runs once per
+	// tree before the parallel updates.
+	args := []string{"submodule", "init", "--"}
+	for _, s := range selected {
+		args = append(args, s.Path)
+	}
+	if _, err := runGit(ctx, dir, u.opts.Env, args...); err != nil {
+		for _, s := range selected {
+			u.record(Result{Path: path.Join(prefix, s.Path), Name: s.Name, Status: StatusFailed, Err: err})
+		}
+		return
+	}
+
+	for _, s := range selected {
+		u.wg.Add(1)
+		go func(s Submodule) {
+			defer u.wg.Done()
+			full := path.Join(prefix, s.Path)
+			result := Result{Path: full, Name: s.Name, Status: StatusUpdated}
+
+			if err := u.opts.Pool.acquire(ctx); err != nil {
+				result.Status, result.Err = StatusFailed, err
+				u.record(result)
+				return
+			}
+			ref, err := u.updateOne(ctx, dir, s, full)
+			u.opts.Pool.release()
+
+			if err != nil {
+				result.Status, result.Err = StatusFailed, err
+				u.record(result)
+				return
+			}
+			if ref != "" {
+				result.Status, result.Ref = StatusPinned, ref
+			}
+			u.record(result)
+
+			// Nested submodules queue for the pool like any other, after
+			// this worker has given its slot back.
+			u.updateTree(ctx, filepath.Join(dir, filepath.FromSlash(s.Path)), full)
+		}(s)
+	}
+}
+
+// updateOne updates a single submodule and checks out its pin, returning
+// the pinned ref.
+func (u *updater) updateOne(ctx context.Context, dir string, s Submodule, full string) (string, error) {
+	args := []string{"submodule", "update"}
+	switch u.opts.Strategy {
+	case StrategyShallow:
+		args = append(args, "--depth", "1")
+	case StrategyRemote:
+		args = append(args, "--remote")
+	}
+	args = append(args, "--", s.Path)
+	if _, err := runGit(ctx, dir, u.opts.Env, args...); err != nil {
+		return "", err
+	}
+
+	ref := u.pin(s.Name, full)
+	if ref == "" {
+		return "", nil
+	}
+	subdir := filepath.Join(dir, filepath.FromSlash(s.Path))
+	target := ref
+	if _, err := runGit(ctx, subdir, u.opts.Env, "rev-parse", "--verify", "--quiet", ref+"^{commit}"); err != nil {
+		if _, err := runGit(ctx, subdir, u.opts.Env, "fetch", "origin", ref); err != nil {
+			return "", fmt.Errorf("pin %s: %w", ref, err)
+		}
+		target = "FETCH_HEAD"
+	}
+	if _, err := runGit(ctx, subdir, u.opts.Env, "checkout", "--detach", target); err != nil {
+		return "", fmt.Errorf("pin %s: %w", ref, err)
+	}
+	return ref, nil
+}
+
+// pin returns the pinned ref of a submodule, preferring its path.
+func (u *updater) pin(name, full string) string {
+	if ref, ok := u.opts.Pins[full]; ok {
+		return ref
+	}
+	return u.opts.Pins[name]
+}
+
+// matchAny reports whether a pattern matches the submodule name or path.
+func matchAny(patterns []string, name, full string) bool {
+	for _, p := range patterns {
+		if p == name || p == full {
+			return true
+		}
+		if ok, _ := path.Match(p, full); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func displayPath(p string) string {
+	if p == "" {
+		return "."
+	}
+	return p
+}
+
+// runGit runs git in dir and returns stdout. The error includes git's
+// stderr. LFS smudging is disabled like for the superproject clone.
+func runGit(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(append(os.Environ(), "GIT_LFS_SKIP_SMUDGE=1", "GIT_TERMINAL_PROMPT=0"), env...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), &gitError{msg: msg, err: err}
+		}
+		return stdout.String(), err
+	}
+	return stdout.String(), nil
+}
+
+// gitError keeps the exit error inspectable while showing git's message.
+type gitError struct {
+	msg string
+	err error
+}
+
+func (e *gitError) Error() string { return e.msg }
+func (e *gitError) Unwrap() error { return e.err }
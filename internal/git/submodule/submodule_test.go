@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package submodule
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Local submodule URLs need the file protocol, which git disables for
+// submodules by default.
+var fileProtocol = []string{
+	"GIT_CONFIG_COUNT=1",
+	"GIT_CONFIG_KEY_0=protocol.file.allow",
+	"GIT_CONFIG_VALUE_0=always",
+}
+
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com"), fileProtocol...)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, out)
+	return strings.TrimSpace(string(out))
+}
+
+func newRepo(t *testing.T, dir, file string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	git(t, dir, "init", "-q", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(file), 0o644))
+	git(t, dir, "add", ".")
+	git(t, dir, "commit", "-q", "-m", "init")
+}
+
+// fixture builds a superproject with lib (which nests deep) and docs, and
+// clones it without submodules.
+func fixture(t *testing.T) (clone string, libFirst string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	deep, lib, docs, super := filepath.Join(root, "deep"), filepath.Join(root, "lib"), filepath.Join(root, "docs"), filepath.Join(root, "super")
+	newRepo(t, deep, "deep.txt")
+	newRepo(t, lib, "lib.txt")
+	libFirst = git(t, lib, "rev-parse", "HEAD")
+	git(t, lib, "submodule", "add", "-q", deep, "vendor/deep")
+	git(t, lib, "commit", "-q", "-m", "add deep")
+	newRepo(t, docs, "docs.txt")
+	newRepo(t, super, "README")
+	git(t, super, "submodule", "add", "-q", lib, "lib")
+	git(t, super, "submodule", "add", "-q", docs, "docs")
+	git(t, super, "commit", "-q", "-m", "add submodules")
+
+	clone = filepath.Join(root, "clone")
+	git(t, root, "clone", "-q", super, clone)
+	return clone, libFirst
+}
+
+func TestUpdateRecursive(t *testing.T) {
+	clone, _ := fixture(t)
+
+	report, err := Update(context.Background(), clone, Options{Env: fileProtocol, Pool: NewPool(2)})
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+	assert.Equal(t, 3, report.Count(StatusUpdated))
+	assert.Equal(t, "lib/vendor/deep", report.Results[2].Path)
+	assert.FileExists(t, filepath.Join(clone, "lib", "vendor", "deep", "deep.txt"))
+	assert.FileExists(t, filepath.Join(clone, "docs", "docs.txt"))
+}
+
+func TestUpdateSkipAndPin(t *testing.T) {
+	clone, libFirst := fixture(t)
+
+	report, err := Update(context.Background(), clone, Options{
+		Env:  fileProtocol,
+		Skip: []string{"docs"},
+		Pins: map[string]string{"lib": libFirst},
+	})
+	require.NoError(t, err)
+	require.NoError(t, report.Err())
+
+	byPath := map[string]Result{}
+	for _, r := range report.Results {
+		byPath[r.Path] = r
+	}
+	assert.Equal(t, StatusSkipped, byPath["docs"].Status)
+	assert.Equal(t, StatusPinned, byPath["lib"].Status)
+	assert.Equal(t, libFirst, git(t, filepath.Join(clone, "lib"), "rev-parse", "HEAD"))
+	assert.NoFileExists(t, filepath.Join(clone, "docs", "docs.txt"))
+	// The pinned commit predates the nested submodule.
+	assert.NotContains(t, byPath, "lib/vendor/deep")
+}
+
+func TestUpdateAggregatesFailures(t *testing.T) {
+	clone, _ := fixture(t)
+	git(t, clone, "config", "--file", ".gitmodules", "submodule.docs.url", filepath.Join(t.TempDir(), "missing"))
+
+	report, err := Update(context.Background(), clone, Options{Env: fileProtocol, Pins: map[string]string{"lib": "no-such-ref"}})
+	require.NoError(t, err)
+
+	var subErr *Error
+	require.ErrorAs(t, report.Err(), &subErr)
+	assert.Len(t, subErr.Failed, 2)
+	assert.Contains(t, subErr.Error(), "2 of 2 submodule(s) failed")
+	assert.Contains(t, subErr.Error(), "lib: pin no-such-ref")
+}
+
+func TestUpdateSkipStrategy(t *testing.T) {
+	report, err := Update(context.Background(), t.TempDir(), Options{Strategy: StrategySkip})
+	require.NoError(t, err)
+	assert.Empty(t, report.Results)
+
+	_, err = Update(context.Background(), t.TempDir(), Options{Strategy: "all"})
+	assert.ErrorIs(t, err, ErrInvalidStrategy)
+}
@@ -0,0 +1,592 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConflictPolicy determines how diverged branches are resolved in bidirectional sync.
+type ConflictPolicy string
+
+const (
+	ConflictOurs   ConflictPolicy = "ours"   // 소스(--from) 브랜치가 우선
+	ConflictTheirs ConflictPolicy = "theirs" // 대상(--to) 브랜치가 우선
+	ConflictManual ConflictPolicy = "manual" // 충돌 큐에 기록하고 건너뜀
+	ConflictMerge  ConflictPolicy = "merge"  // 병합 커밋을 양쪽에 push, 실패 시 충돌 큐에 기록
+)
+
+// ParseConflictPolicy validates a conflict policy name.
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch p := ConflictPolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case ConflictOurs, ConflictTheirs, ConflictManual, ConflictMerge:
+		return p, nil
+	case "":
+		return ConflictManual, nil
+	default:
+		return "", fmt.Errorf("invalid conflict policy %q (expected ours, theirs, manual or merge)", s)
+	}
+}
+
+// BranchMapping maps branch names on the source side to the destination side.
+// Patterns may end with "*" to map a prefix, e.g. "release/*:rel/*".
+type BranchMapping struct {
+	Source      string
+	Destination string
+}
+
+// ParseBranchMappings parses mappings in "source:destination" form.
+func ParseBranchMappings(specs []string) ([]BranchMapping, error) {
+	mappings := make([]BranchMapping, 0, len(specs))
+
+	for _, spec := range specs {
+		src, dst, ok := strings.Cut(spec, ":")
+		src, dst = strings.TrimSpace(src), strings.TrimSpace(dst)
+		if !ok || src == "" || dst == "" {
+			return nil, fmt.Errorf("invalid branch mapping %q (expected source:destination)", spec)
+		}
+
+		if strings.HasSuffix(src, "*") != strings.HasSuffix(dst, "*") {
+			return nil, fmt.Errorf("invalid branch mapping %q: wildcard must appear on both sides", spec)
+		}
+
+		mappings = append(mappings, BranchMapping{Source: src, Destination: dst})
+	}
+
+	return mappings, nil
+}
+
+// mapBranch maps a branch name between sides. Unmapped branches keep their name.
+func mapBranch(mappings []BranchMapping, name string, reverse bool) string {
+	for _, m := range mappings {
+		from, to := m.Source, m.Destination
+		if reverse {
+			from, to = to, from
+		}
+
+		if prefix, ok := strings.CutSuffix(from, "*"); ok {
+			if rest, ok := strings.CutPrefix(name, prefix); ok {
+				return strings.TrimSuffix(to, "*") + rest
+			}
+			continue
+		}
+
+		if name == from {
+			return to
+		}
+	}
+
+	return name
+}
+
+// BranchActionType describes what bidirectional sync does with a branch pair.
+type BranchActionType string
+
+const (
+	BranchInSync         BranchActionType = "in-sync"
+	BranchPushToDest     BranchActionType = "push-to-destination"
+	BranchPushToSource   BranchActionType = "push-to-source"
+	BranchCreateInDest   BranchActionType = "create-in-destination"
+	BranchCreateInSource BranchActionType = "create-in-source"
+	BranchConflictOurs   BranchActionType = "conflict-ours"
+	BranchConflictTheirs BranchActionType = "conflict-theirs"
+	BranchConflictQueued BranchActionType = "conflict-queued"
+	BranchConflictMerge  BranchActionType = "conflict-merge"
+)
+
+// BranchAction is a planned change for one source/destination branch pair.
+type BranchAction struct {
+	SourceBranch      string           `json:"source_branch"`
+	DestinationBranch string           `json:"destination_branch"`
+	SourceSHA         string           `json:"source_sha,omitempty"`
+	DestinationSHA    string           `json:"destination_sha,omitempty"`
+	Ahead             int              `json:"ahead"`  // 소스에만 있는 커밋 수
+	Behind            int              `json:"behind"` // 대상에만 있는 커밋 수
+	Action            BranchActionType `json:"action"`
+	// MergeSHA is the merge commit pushed to both sides by the merge policy.
+	MergeSHA string `json:"merge_sha,omitempty"`
+	// Resolutions lists the paths changed on both sides and what merged them.
+	Resolutions []ConflictResolution `json:"resolutions,omitempty"`
+	// Unresolved lists the paths no driver could merge; the branch pair was
+	// queued for manual resolution instead.
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+// IsConflict reports whether the branches have diverged.
+func (a BranchAction) IsConflict() bool {
+	return a.Ahead > 0 && a.Behind > 0
+}
+
+// BidirectionalReport is the result (or dry-run plan) of a bidirectional sync.
+type BidirectionalReport struct {
+	Repository string         `json:"repository"`
+	Policy     ConflictPolicy `json:"policy"`
+	DryRun     bool           `json:"dry_run"`
+	Actions    []BranchAction `json:"actions"`
+}
+
+// Conflicts returns the actions whose branches have diverged.
+func (r *BidirectionalReport) Conflicts() []BranchAction {
+	var conflicts []BranchAction
+	for _, a := range r.Actions {
+		if a.IsConflict() {
+			conflicts = append(conflicts, a)
+		}
+	}
+	return conflicts
+}
+
+// Print writes a human readable diff report.
+func (r *BidirectionalReport) Print(w io.Writer) {
+	title := "Bidirectional sync"
+	if r.DryRun {
+		title += " (dry run)"
+	}
+	fmt.Fprintf(w, "\n🔀 %s: %s [policy: %s]\n", title, r.Repository, r.Policy)
+
+	for _, a := range r.Actions {
+		symbol := "="
+		switch a.Action {
+		case BranchPushToDest, BranchCreateInDest, BranchConflictOurs:
+			symbol = "→"
+		case BranchPushToSource, BranchCreateInSource, BranchConflictTheirs:
+			symbol = "←"
+		case BranchConflictMerge:
+			symbol = "⇄"
+		case BranchConflictQueued:
+			symbol = "!"
+		}
+
+		name := a.SourceBranch
+		if a.SourceBranch != a.DestinationBranch {
+			name = fmt.Sprintf("%s ⇄ %s", a.SourceBranch, a.DestinationBranch)
+		}
+
+		fmt.Fprintf(w, "  %s %-40s %s (+%d/-%d) %s..%s\n",
+			symbol, name, a.Action, a.Ahead, a.Behind, shortSHA(a.SourceSHA), shortSHA(a.DestinationSHA))
+		if a.MergeSHA != "" {
+			fmt.Fprintf(w, "      merged as %s\n", shortSHA(a.MergeSHA))
+		}
+		for _, res := range a.Resolutions {
+			fmt.Fprintf(w, "      %s: resolved by %s\n", res.Path, res.Driver)
+		}
+		for _, path := range a.Unresolved {
+			fmt.Fprintf(w, "      %s: unresolved\n", path)
+		}
+	}
+}
+
+// ConflictEntry is a diverged branch pair waiting for manual resolution.
+type ConflictEntry struct {
+	Repository        string    `json:"repository"`
+	SourceBranch      string    `json:"source_branch"`
+	DestinationBranch string    `json:"destination_branch"`
+	SourceSHA         string    `json:"source_sha"`
+	DestinationSHA    string    `json:"destination_sha"`
+	DetectedAt        time.Time `json:"detected_at"`
+	// Unresolved lists the paths a merge attempt could not resolve.
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+// ConflictQueue persists conflicts that require manual resolution.
+type ConflictQueue struct {
+	path string
+}
+
+// NewConflictQueue creates a queue backed by the given JSON file.
+func NewConflictQueue(path string) *ConflictQueue {
+	return &ConflictQueue{path: path}
+}
+
+// DefaultConflictQueuePath returns the default manual conflict queue location.
+func DefaultConflictQueuePath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "gzh-sync-conflicts.json")
+	}
+	return filepath.Join(homeDir, ".config", "gzh-manager", "sync", "conflicts.json")
+}
+
+// List returns all queued conflicts.
+func (q *ConflictQueue) List() ([]ConflictEntry, error) {
+	data, err := os.ReadFile(q.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conflict queue: %w", err)
+	}
+
+	var entries []ConflictEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse conflict queue: %w", err)
+	}
+	return entries, nil
+}
+
+// Add queues a conflict, replacing any previous entry for the same branch pair.
+func (q *ConflictQueue) Add(entry ConflictEntry) error {
+	entries, err := q.List()
+	if err != nil {
+		return err
+	}
+
+	filtered := entries[:0]
+	for _, e := range entries {
+		if e.Repository != entry.Repository || e.SourceBranch != entry.SourceBranch {
+			filtered = append(filtered, e)
+		}
+	}
+	filtered = append(filtered, entry)
+
+	return q.save(filtered)
+}
+
+// Resolve removes the conflict for a repository branch from the queue.
+func (q *ConflictQueue) Resolve(repository, sourceBranch string) error {
+	entries, err := q.List()
+	if err != nil {
+		return err
+	}
+
+	filtered := entries[:0]
+	for _, e := range entries {
+		if e.Repository != repository || e.SourceBranch != sourceBranch {
+			filtered = append(filtered, e)
+		}
+	}
+
+	return q.save(filtered)
+}
+
+func (q *ConflictQueue) save(entries []ConflictEntry) error {
+	if err := os.MkdirAll(filepath.Dir(q.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create conflict queue directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode conflict queue: %w", err)
+	}
+
+	return os.WriteFile(q.path, data, 0o600)
+}
+
+// BidirectionalSyncer synchronizes branches between two remotes in both directions.
+type BidirectionalSyncer struct {
+	name           string
+	sourceURL      string
+	destinationURL string
+	policy         ConflictPolicy
+	mappings       []BranchMapping
+	drivers        []MergeDriver
+	queue          *ConflictQueue
+	options        SyncOptions
+}
+
+// NewBidirectionalSyncer creates a syncer for a repository available at two remotes.
+func NewBidirectionalSyncer(name, sourceURL, destinationURL string, opts SyncOptions) (*BidirectionalSyncer, error) {
+	policy, err := ParseConflictPolicy(opts.ConflictPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, err := ParseBranchMappings(opts.BranchMappings)
+	if err != nil {
+		return nil, err
+	}
+
+	var drivers []MergeDriver
+	if opts.MergeDrivers != "" {
+		if drivers, err = LoadMergeDrivers(opts.MergeDrivers); err != nil {
+			return nil, err
+		}
+	}
+
+	queuePath := opts.ConflictQueue
+	if queuePath == "" {
+		queuePath = DefaultConflictQueuePath()
+	}
+
+	return &BidirectionalSyncer{
+		name:           name,
+		sourceURL:      sourceURL,
+		destinationURL: destinationURL,
+		policy:         policy,
+		mappings:       mappings,
+		drivers:        drivers,
+		queue:          NewConflictQueue(queuePath),
+		options:        opts,
+	}, nil
+}
+
+// Sync fetches both remotes, plans branch updates and applies them unless DryRun is set.
+func (b *BidirectionalSyncer) Sync(ctx context.Context) (*BidirectionalReport, error) {
+	tempDir, err := os.MkdirTemp("", "gzh-bisync-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := b.prepare(ctx, tempDir); err != nil {
+		return nil, err
+	}
+
+	report, err := b.plan(ctx, tempDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.options.DryRun {
+		return report, nil
+	}
+
+	if err := b.apply(ctx, tempDir, report); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// prepare creates a bare repository and fetches branches from both remotes.
+func (b *BidirectionalSyncer) prepare(ctx context.Context, dir string) error {
+	steps := [][]string{
+		{"init", "--bare", "--quiet"},
+		{"remote", "add", "source", b.sourceURL},
+		{"remote", "add", "destination", b.destinationURL},
+		{"fetch", "--quiet", "--prune", "source", "+refs/heads/*:refs/remotes/source/*"},
+		{"fetch", "--quiet", "--prune", "destination", "+refs/heads/*:refs/remotes/destination/*"},
+	}
+
+	for _, args := range steps {
+		if _, err := b.git(ctx, dir, args...); err != nil {
+			return err
+		}
+	}
+
+	if b.policy == ConflictMerge {
+		return b.configureMerge(ctx, dir)
+	}
+	return nil
+}
+
+// plan compares mapped branch pairs and decides an action for each.
+func (b *BidirectionalSyncer) plan(ctx context.Context, dir string) (*BidirectionalReport, error) {
+	sourceRefs, err := b.branches(ctx, dir, "source")
+	if err != nil {
+		return nil, err
+	}
+
+	destRefs, err := b.branches(ctx, dir, "destination")
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BidirectionalReport{Repository: b.name, Policy: b.policy, DryRun: b.options.DryRun}
+	seenDest := make(map[string]bool)
+
+	for _, srcBranch := range sortedKeys(sourceRefs) {
+		dstBranch := mapBranch(b.mappings, srcBranch, false)
+		seenDest[dstBranch] = true
+
+		action := BranchAction{
+			SourceBranch:      srcBranch,
+			DestinationBranch: dstBranch,
+			SourceSHA:         sourceRefs[srcBranch],
+			DestinationSHA:    destRefs[dstBranch],
+		}
+
+		if action.DestinationSHA == "" {
+			action.Action = BranchCreateInDest
+			report.Actions = append(report.Actions, action)
+			continue
+		}
+
+		if err := b.compare(ctx, dir, &action); err != nil {
+			return nil, err
+		}
+		report.Actions = append(report.Actions, action)
+	}
+
+	// 대상에만 존재하는 브랜치는 소스에 생성
+	for _, dstBranch := range sortedKeys(destRefs) {
+		if seenDest[dstBranch] {
+			continue
+		}
+
+		report.Actions = append(report.Actions, BranchAction{
+			SourceBranch:      mapBranch(b.mappings, dstBranch, true),
+			DestinationBranch: dstBranch,
+			DestinationSHA:    destRefs[dstBranch],
+			Action:            BranchCreateInSource,
+		})
+	}
+
+	return report, nil
+}
+
+// compare fills ahead/behind counts and the resulting action for an existing pair.
+func (b *BidirectionalSyncer) compare(ctx context.Context, dir string, action *BranchAction) error {
+	if action.SourceSHA == action.DestinationSHA {
+		action.Action = BranchInSync
+		return nil
+	}
+
+	out, err := b.git(ctx, dir, "rev-list", "--left-right", "--count",
+		action.SourceSHA+"..."+action.DestinationSHA)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Sscanf(strings.TrimSpace(out), "%d %d", &action.Ahead, &action.Behind); err != nil {
+		return fmt.Errorf("failed to parse rev-list output %q: %w", out, err)
+	}
+
+	switch {
+	case action.Behind == 0:
+		action.Action = BranchPushToDest
+	case action.Ahead == 0:
+		action.Action = BranchPushToSource
+	case b.policy == ConflictOurs:
+		action.Action = BranchConflictOurs
+	case b.policy == ConflictTheirs:
+		action.Action = BranchConflictTheirs
+	case b.policy == ConflictMerge:
+		action.Action = BranchConflictMerge
+	default:
+		action.Action = BranchConflictQueued
+	}
+
+	return nil
+}
+
+// apply executes the planned actions and queues unresolved conflicts.
+// Merges that leave paths unresolved are recorded in the report as queued.
+func (b *BidirectionalSyncer) apply(ctx context.Context, dir string, report *BidirectionalReport) error {
+	for i := range report.Actions {
+		a := &report.Actions[i]
+		var err error
+
+		switch a.Action {
+		case BranchPushToDest, BranchCreateInDest:
+			err = b.push(ctx, dir, "destination", a.SourceSHA, a.DestinationBranch, "")
+		case BranchPushToSource, BranchCreateInSource:
+			err = b.push(ctx, dir, "source", a.DestinationSHA, a.SourceBranch, "")
+		case BranchConflictOurs:
+			err = b.push(ctx, dir, "destination", a.SourceSHA, a.DestinationBranch, a.DestinationSHA)
+		case BranchConflictTheirs:
+			err = b.push(ctx, dir, "source", a.DestinationSHA, a.SourceBranch, a.SourceSHA)
+		case BranchConflictMerge:
+			if err = b.merge(ctx, dir, a); err == nil && a.MergeSHA == "" {
+				a.Action = BranchConflictQueued
+				err = b.enqueue(*a)
+			}
+		case BranchConflictQueued:
+			err = b.enqueue(*a)
+		case BranchInSync:
+		}
+
+		if err != nil {
+			return fmt.Errorf("branch %s: %w", a.SourceBranch, err)
+		}
+	}
+
+	return nil
+}
+
+// enqueue records a diverged branch pair for manual resolution.
+func (b *BidirectionalSyncer) enqueue(a BranchAction) error {
+	return b.queue.Add(ConflictEntry{
+		Repository:        b.name,
+		SourceBranch:      a.SourceBranch,
+		DestinationBranch: a.DestinationBranch,
+		SourceSHA:         a.SourceSHA,
+		DestinationSHA:    a.DestinationSHA,
+		DetectedAt:        time.Now(),
+		Unresolved:        a.Unresolved,
+	})
+}
+
+// push updates a remote branch. When expected is set the push overwrites the
+// branch, but only if it still points at the expected commit.
+func (b *BidirectionalSyncer) push(ctx context.Context, dir, remote, sha, branch, expected string) error {
+	ref := "refs/heads/" + branch
+	args := []string{"push", "--quiet"}
+	if expected != "" {
+		// 계획 이후 원격 브랜치가 변경되었다면 덮어쓰지 않음
+		args = append(args, "--force-with-lease="+ref+":"+expected)
+	}
+	args = append(args, remote, sha+":"+ref)
+	if b.options.Verbose {
+		fmt.Printf("Pushing %s to %s/%s\n", shortSHA(sha), remote, branch)
+	}
+
+	_, err := b.git(ctx, dir, args...)
+	return err
+}
+
+// branches returns branch name -> SHA for a fetched remote.
+func (b *BidirectionalSyncer) branches(ctx context.Context, dir, remote string) (map[string]string, error) {
+	prefix := "refs/remotes/" + remote + "/"
+	out, err := b.git(ctx, dir, "for-each-ref", "--format=%(objectname) %(refname)", prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		sha, ref, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+
+		name := strings.TrimPrefix(ref, prefix)
+		if name == "HEAD" {
+			continue
+		}
+		refs[name] = sha
+	}
+
+	return refs, nil
+}
+
+func (b *BidirectionalSyncer) git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w\nOutput: %s", args[0], err, output)
+	}
+
+	return string(output), nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	if sha == "" {
+		return "-"
+	}
+	return sha
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sync
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %s: %s", strings.Join(args, " "), out)
+
+	return strings.TrimSpace(string(out))
+}
+
+// newRemotePair creates two bare repositories seeded with the same commit on main.
+func newRemotePair(t *testing.T) (source, destination, work string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	root := t.TempDir()
+	work = filepath.Join(root, "work")
+	source = filepath.Join(root, "source.git")
+	destination = filepath.Join(root, "destination.git")
+
+	require.NoError(t, os.MkdirAll(work, 0o755))
+	runGit(t, work, "init", "--quiet", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(work, "README.md"), []byte("hello\n"), 0o644))
+	runGit(t, work, "add", ".")
+	runGit(t, work, "commit", "--quiet", "-m", "initial")
+
+	runGit(t, root, "clone", "--quiet", "--bare", work, source)
+	runGit(t, root, "clone", "--quiet", "--bare", work, destination)
+
+	return source, destination, work
+}
+
+// commitTo pushes a new commit on branch to remote, starting from the remote's base branch.
+func commitTo(t *testing.T, work, remote, base, branch, file string) {
+	t.Helper()
+
+	runGit(t, work, "fetch", "--quiet", remote, base)
+	runGit(t, work, "checkout", "--quiet", "-B", branch, "FETCH_HEAD")
+	require.NoError(t, os.WriteFile(filepath.Join(work, file), []byte(file), 0o644))
+	runGit(t, work, "add", ".")
+	runGit(t, work, "commit", "--quiet", "-m", file)
+	runGit(t, work, "push", "--quiet", "--force", remote, branch)
+}
+
+func newTestSyncer(t *testing.T, source, destination string, opts SyncOptions) *BidirectionalSyncer {
+	t.Helper()
+
+	opts.ConflictQueue = filepath.Join(t.TempDir(), "conflicts.json")
+	syncer, err := NewBidirectionalSyncer("org/repo", source, destination, opts)
+	require.NoError(t, err)
+
+	return syncer
+}
+
+func findAction(t *testing.T, report *BidirectionalReport, branch string) BranchAction {
+	t.Helper()
+
+	for _, a := range report.Actions {
+		if a.SourceBranch == branch {
+			return a
+		}
+	}
+	t.Fatalf("no action for branch %s", branch)
+	return BranchAction{}
+}
+
+func TestParseBranchMappings(t *testing.T) {
+	mappings, err := ParseBranchMappings([]string{"main:master", "release/*:rel/*"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "master", mapBranch(mappings, "main", false))
+	assert.Equal(t, "rel/1.0", mapBranch(mappings, "release/1.0", false))
+	assert.Equal(t, "feature", mapBranch(mappings, "feature", false))
+	assert.Equal(t, "main", mapBranch(mappings, "master", true))
+	assert.Equal(t, "release/2.0", mapBranch(mappings, "rel/2.0", true))
+
+	_, err = ParseBranchMappings([]string{"main"})
+	assert.Error(t, err)
+	_, err = ParseBranchMappings([]string{"release/*:rel"})
+	assert.Error(t, err)
+}
+
+func TestBidirectionalSyncer_FastForwardBothWays(t *testing.T) {
+	source, destination, work := newRemotePair(t)
+	commitTo(t, work, source, "main", "main", "from-source.txt")
+	commitTo(t, work, destination, "main", "feature", "from-destination.txt")
+
+	syncer := newTestSyncer(t, source, destination, SyncOptions{})
+	report, err := syncer.Sync(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, BranchPushToDest, findAction(t, report, "main").Action)
+	assert.Equal(t, BranchCreateInSource, findAction(t, report, "feature").Action)
+
+	assert.Equal(t, runGit(t, source, "rev-parse", "main"), runGit(t, destination, "rev-parse", "main"))
+	assert.Equal(t, runGit(t, destination, "rev-parse", "feature"), runGit(t, source, "rev-parse", "feature"))
+}
+
+func TestBidirectionalSyncer_DryRunDoesNotPush(t *testing.T) {
+	source, destination, work := newRemotePair(t)
+	commitTo(t, work, source, "main", "main", "a.txt")
+	before := runGit(t, destination, "rev-parse", "main")
+
+	syncer := newTestSyncer(t, source, destination, SyncOptions{DryRun: true})
+	report, err := syncer.Sync(context.Background())
+	require.NoError(t, err)
+
+	action := findAction(t, report, "main")
+	assert.Equal(t, BranchPushToDest, action.Action)
+	assert.Equal(t, 1, action.Ahead)
+	assert.Equal(t, before, runGit(t, destination, "rev-parse", "main"))
+}
+
+func TestBidirectionalSyncer_ConflictPolicies(t *testing.T) {
+	tests := []struct {
+		policy string
+		want   BranchActionType
+	}{
+		{"ours", BranchConflictOurs},
+		{"theirs", BranchConflictTheirs},
+		{"manual", BranchConflictQueued},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			source, destination, work := newRemotePair(t)
+			commitTo(t, work, source, "main", "main", "source.txt")
+			runGit(t, work, "reset", "--quiet", "--hard", "HEAD~1")
+			commitTo(t, work, destination, "main", "main", "destination.txt")
+
+			srcSHA := runGit(t, source, "rev-parse", "main")
+			dstSHA := runGit(t, destination, "rev-parse", "main")
+
+			syncer := newTestSyncer(t, source, destination, SyncOptions{ConflictPolicy: tt.policy})
+			report, err := syncer.Sync(context.Background())
+			require.NoError(t, err)
+
+			action := findAction(t, report, "main")
+			assert.Equal(t, tt.want, action.Action)
+			assert.True(t, action.IsConflict())
+
+			switch tt.want {
+			case BranchConflictOurs:
+				assert.Equal(t, srcSHA, runGit(t, destination, "rev-parse", "main"))
+			case BranchConflictTheirs:
+				assert.Equal(t, dstSHA, runGit(t, source, "rev-parse", "main"))
+			default:
+				assert.Equal(t, dstSHA, runGit(t, destination, "rev-parse", "main"))
+				entries, err := syncer.queue.List()
+				require.NoError(t, err)
+				require.Len(t, entries, 1)
+				assert.Equal(t, srcSHA, entries[0].SourceSHA)
+			}
+		})
+	}
+}
+
+func TestBidirectionalSyncer_BranchMapping(t *testing.T) {
+	source, destination, work := newRemotePair(t)
+	commitTo(t, work, destination, "main", "master", "master.txt")
+	runGit(t, destination, "update-ref", "-d", "refs/heads/main")
+
+	syncer := newTestSyncer(t, source, destination, SyncOptions{BranchMappings: []string{"main:master"}})
+	report, err := syncer.Sync(context.Background())
+	require.NoError(t, err)
+
+	action := findAction(t, report, "main")
+	assert.Equal(t, "master", action.DestinationBranch)
+	assert.Equal(t, BranchPushToSource, action.Action)
+	assert.Equal(t, runGit(t, destination, "rev-parse", "master"), runGit(t, source, "rev-parse", "main"))
+}
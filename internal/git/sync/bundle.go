@@ -0,0 +1,557 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/cloud"
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+)
+
+const (
+	bundleManifestName = "manifest.json"
+
+	// bundleSequenceKey records the last applied bundle in the receiving repository.
+	bundleSequenceKey = "gz.bundlesync.sequence"
+)
+
+// BundleEntry is one exported bundle. Refs is the complete ref state of the
+// source after the bundle, so receivers can set refs whose objects were
+// already shipped in an earlier bundle.
+type BundleEntry struct {
+	Sequence    int               `json:"sequence"`
+	Key         string            `json:"key,omitempty"` // empty when only refs were deleted or moved
+	SHA256      string            `json:"sha256,omitempty"`
+	Size        int64             `json:"size,omitempty"`
+	Full        bool              `json:"full,omitempty"`
+	Compression string            `json:"compression,omitempty"` // codec of the stored bundle; SHA256 and Size cover the stored bytes
+	Created     time.Time         `json:"created"`
+	Refs        map[string]string `json:"refs"`
+	Deleted     []string          `json:"deleted,omitempty"`
+}
+
+// BundleManifest lists the bundles exported for one repository, oldest first.
+type BundleManifest struct {
+	Repository string        `json:"repository"`
+	Source     string        `json:"source,omitempty"`
+	Bundles    []BundleEntry `json:"bundles"`
+}
+
+// Latest returns the most recent bundle, or nil if none were exported.
+func (m *BundleManifest) Latest() *BundleEntry {
+	if len(m.Bundles) == 0 {
+		return nil
+	}
+
+	return &m.Bundles[len(m.Bundles)-1]
+}
+
+// BundleSync exports incremental git bundles of a repository to an object
+// store and applies them on the other side of an air gap. Bundles for a
+// repository live under "<repository>/" in the store next to a manifest.
+type BundleSync struct {
+	store   cloud.ObjectStore
+	workDir string
+	codec   compress.Codec
+	dryRun  bool
+	out     io.Writer
+}
+
+// BundleSyncOptions configures a BundleSync.
+type BundleSyncOptions struct {
+	// WorkDir holds mirror clones used for exporting (default DefaultBundleWorkDir).
+	WorkDir string
+	// Codec compresses exported bundles (default: the codec configured for
+	// bundles). Applying reads the codec of each bundle from the manifest.
+	Codec  compress.Codec
+	DryRun bool
+	Output io.Writer
+}
+
+// DefaultBundleWorkDir returns the default directory for export mirrors.
+func DefaultBundleWorkDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "gzh-sync-bundles")
+	}
+	return filepath.Join(homeDir, ".config", "gzh-manager", "sync", "bundles")
+}
+
+// NewBundleSync creates a bundle syncer backed by store.
+func NewBundleSync(store cloud.ObjectStore, opts BundleSyncOptions) *BundleSync {
+	if opts.WorkDir == "" {
+		opts.WorkDir = DefaultBundleWorkDir()
+	}
+	if opts.Output == nil {
+		opts.Output = io.Discard
+	}
+	if opts.Codec == nil {
+		opts.Codec = compress.ForUse(compress.UseBundle)
+	}
+
+	return &BundleSync{
+		store:   store,
+		workDir: opts.WorkDir,
+		codec:   opts.Codec,
+		dryRun:  opts.DryRun,
+		out:     opts.Output,
+	}
+}
+
+// LoadManifest reads the manifest of a repository. A repository without
+// exports yields an empty manifest.
+func (b *BundleSync) LoadManifest(ctx context.Context, repository string) (*BundleManifest, error) {
+	r, err := b.store.Get(ctx, path.Join(repository, bundleManifestName))
+	if errors.Is(err, cloud.ErrObjectNotFound) {
+		return &BundleManifest{Repository: repository}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var m BundleManifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("parse bundle manifest for %s: %w", repository, err)
+	}
+
+	return &m, nil
+}
+
+func (b *BundleSync) saveManifest(ctx context.Context, m *BundleManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return b.store.Put(ctx, path.Join(m.Repository, bundleManifestName), bytes.NewReader(data), int64(len(data)))
+}
+
+// Export fetches sourceURL into a local mirror and uploads a bundle with the
+// objects added since the previous export. It returns nil when the source is
+// unchanged. full forces a self-contained bundle of every ref.
+func (b *BundleSync) Export(ctx context.Context, repository, sourceURL string, full bool) (*BundleEntry, error) {
+	manifest, err := b.LoadManifest(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror, err := b.updateMirror(ctx, repository, sourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := listRefs(ctx, mirror)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := map[string]string{}
+	if latest := manifest.Latest(); latest != nil && !full {
+		previous = latest.Refs
+	}
+
+	var changed, deleted []string
+	for _, ref := range sortedKeys(refs) {
+		if previous[ref] != refs[ref] {
+			changed = append(changed, ref)
+		}
+	}
+	for _, ref := range sortedKeys(previous) {
+		if _, ok := refs[ref]; !ok {
+			deleted = append(deleted, ref)
+		}
+	}
+
+	if len(changed) == 0 && len(deleted) == 0 {
+		if latest := manifest.Latest(); latest != nil {
+			fmt.Fprintf(b.out, "✓ %s is up to date (bundle %d)\n", repository, latest.Sequence)
+		} else {
+			fmt.Fprintf(b.out, "✓ %s has no branches or tags to export\n", repository)
+		}
+		return nil, nil
+	}
+
+	entry := &BundleEntry{
+		Sequence: 1,
+		Full:     len(previous) == 0,
+		Created:  time.Now().UTC(),
+		Refs:     refs,
+		Deleted:  deleted,
+	}
+	if latest := manifest.Latest(); latest != nil {
+		entry.Sequence = latest.Sequence + 1
+	}
+	manifest.Source = sourceURL
+
+	if b.dryRun {
+		fmt.Fprintf(b.out, "Would export bundle %d for %s: %d changed, %d deleted refs\n",
+			entry.Sequence, repository, len(changed), len(deleted))
+		return entry, nil
+	}
+
+	if len(changed) > 0 {
+		if err := b.uploadBundle(ctx, mirror, repository, entry, changed, previous); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest.Bundles = append(manifest.Bundles, *entry)
+	if err := b.saveManifest(ctx, manifest); err != nil {
+		return nil, fmt.Errorf("update bundle manifest: %w", err)
+	}
+
+	kind := "incremental"
+	if entry.Full {
+		kind = "full"
+	}
+	fmt.Fprintf(b.out, "📦 Exported %s bundle %d for %s (%d changed, %d deleted refs, %d bytes)\n",
+		kind, entry.Sequence, repository, len(changed), len(deleted), entry.Size)
+
+	return entry, nil
+}
+
+// uploadBundle creates a bundle of the changed refs, excluding everything
+// reachable from the previous export, and uploads it.
+func (b *BundleSync) uploadBundle(ctx context.Context, mirror, repository string, entry *BundleEntry, changed []string, previous map[string]string) error {
+	tmp, err := os.CreateTemp("", "gz-bundle-*.bundle")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	args := append([]string{"bundle", "create", "--quiet", tmp.Name()}, changed...)
+	var exclude []string
+	for _, ref := range sortedKeys(previous) {
+		// 강제 push로 사라진 객체는 제외 기준으로 쓸 수 없다
+		if _, err := runGitIn(ctx, mirror, "cat-file", "-e", previous[ref]); err == nil {
+			exclude = append(exclude, previous[ref])
+		}
+	}
+	if len(exclude) > 0 {
+		args = append(append(args, "--not"), exclude...)
+	}
+
+	if _, err := runGitIn(ctx, mirror, args...); err != nil {
+		if strings.Contains(err.Error(), "empty bundle") {
+			// 새 객체 없이 ref만 이동한 경우: manifest의 ref 상태만으로 충분하다
+			return nil
+		}
+		return err
+	}
+
+	stored := tmp.Name()
+	if b.codec != compress.None {
+		if stored, err = compressFile(tmp.Name(), b.codec); err != nil {
+			return err
+		}
+		defer os.Remove(stored)
+		entry.Compression = b.codec.Name()
+	}
+
+	f, err := os.Open(stored)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	entry.Key = path.Join(repository, "bundles", fmt.Sprintf("%06d.bundle", entry.Sequence)+b.codec.Extension())
+	entry.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	entry.Size = info.Size()
+
+	if err := b.store.Put(ctx, entry.Key, f, entry.Size); err != nil {
+		return fmt.Errorf("upload bundle: %w", err)
+	}
+
+	return nil
+}
+
+// compressFile streams src through codec into a new temporary file and
+// returns its name, so bundles of any size are never held in memory.
+func compressFile(src string, codec compress.Codec) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+
+	out, err := os.CreateTemp("", "gz-bundle-*.bundle"+codec.Extension())
+	if err != nil {
+		return "", err
+	}
+	zw, err := codec.NewWriter(out)
+	if err == nil {
+		_, err = io.Copy(zw, in)
+		if cerr := zw.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(out.Name())
+		return "", fmt.Errorf("compress bundle: %w", err)
+	}
+
+	return out.Name(), nil
+}
+
+// updateMirror clones or fetches sourceURL into the export mirror.
+func (b *BundleSync) updateMirror(ctx context.Context, repository, sourceURL string) (string, error) {
+	mirror := filepath.Join(b.workDir, strings.ReplaceAll(repository, "/", "__")+".git")
+
+	if _, err := os.Stat(mirror); os.IsNotExist(err) {
+		if err := os.MkdirAll(b.workDir, 0o755); err != nil {
+			return "", err
+		}
+		if _, err := runGitIn(ctx, b.workDir, "clone", "--quiet", "--mirror", sourceURL, mirror); err != nil {
+			return "", err
+		}
+		return mirror, nil
+	}
+
+	if _, err := runGitIn(ctx, mirror, "remote", "set-url", "origin", sourceURL); err != nil {
+		return "", err
+	}
+	if _, err := runGitIn(ctx, mirror, "fetch", "--quiet", "--prune", "origin", "+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"); err != nil {
+		return "", err
+	}
+
+	return mirror, nil
+}
+
+// BundleApplyResult summarizes an Apply run.
+type BundleApplyResult struct {
+	Repository string        `json:"repository"`
+	Previous   int           `json:"previous"`
+	Applied    []BundleEntry `json:"applied"`
+	Pushed     bool          `json:"pushed"`
+}
+
+// Apply downloads the bundles of repository not yet applied to repoDir, a bare
+// repository created if missing, and applies them in order. When pushURL is
+// set the resulting branches and tags are pushed there afterwards.
+func (b *BundleSync) Apply(ctx context.Context, repository, repoDir, pushURL string) (*BundleApplyResult, error) {
+	manifest, err := b.LoadManifest(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Bundles) == 0 {
+		return nil, fmt.Errorf("no bundles exported for %s in %s", repository, b.store)
+	}
+
+	if _, err := os.Stat(repoDir); os.IsNotExist(err) && !b.dryRun {
+		if _, err := runGitIn(ctx, ".", "init", "--quiet", "--bare", repoDir); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &BundleApplyResult{Repository: repository}
+	if out, err := runGitIn(ctx, repoDir, "config", "--get", bundleSequenceKey); err == nil {
+		result.Previous, _ = strconv.Atoi(strings.TrimSpace(out))
+	}
+
+	pending := manifest.Bundles
+	if result.Previous == 0 {
+		// 처음 적용할 때는 가장 최근의 전체 번들부터 시작한다
+		start := -1
+		for i, entry := range pending {
+			if entry.Full {
+				start = i
+			}
+		}
+		if start < 0 {
+			return nil, fmt.Errorf("no full bundle available for %s: re-export with --full", repository)
+		}
+		pending = pending[start:]
+	}
+
+	for _, entry := range pending {
+		if entry.Sequence <= result.Previous {
+			continue
+		}
+
+		if b.dryRun {
+			fmt.Fprintf(b.out, "Would apply bundle %d for %s (%d refs, %d deleted)\n",
+				entry.Sequence, repository, len(entry.Refs), len(entry.Deleted))
+		} else if err := b.applyEntry(ctx, repoDir, entry); err != nil {
+			return result, fmt.Errorf("apply bundle %d of %s: %w", entry.Sequence, repository, err)
+		}
+
+		result.Applied = append(result.Applied, entry)
+	}
+
+	if len(result.Applied) == 0 {
+		fmt.Fprintf(b.out, "✓ %s is up to date (bundle %d)\n", repository, result.Previous)
+	} else if !b.dryRun {
+		fmt.Fprintf(b.out, "📥 Applied %d bundle(s) to %s (now at %d)\n",
+			len(result.Applied), repository, result.Applied[len(result.Applied)-1].Sequence)
+	}
+
+	if pushURL != "" && !b.dryRun {
+		if _, err := runGitIn(ctx, repoDir, "push", "--quiet", "--prune", pushURL,
+			"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*"); err != nil {
+			return result, fmt.Errorf("push %s: %w", repository, err)
+		}
+		result.Pushed = true
+		fmt.Fprintf(b.out, "⬆️  Pushed %s to destination\n", repository)
+	}
+
+	return result, nil
+}
+
+// applyEntry verifies and fetches one bundle, then sets refs to the recorded state.
+func (b *BundleSync) applyEntry(ctx context.Context, repoDir string, entry BundleEntry) error {
+	if entry.Key != "" {
+		file, err := b.download(ctx, entry)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(file)
+
+		if _, err := runGitIn(ctx, repoDir, "bundle", "verify", "--quiet", file); err != nil {
+			return fmt.Errorf("bundle prerequisites missing (apply earlier bundles or re-export with --full): %w", err)
+		}
+		if _, err := runGitIn(ctx, repoDir, "fetch", "--quiet", "--update-head-ok", file, "+refs/*:refs/*"); err != nil {
+			return err
+		}
+	}
+
+	for _, ref := range sortedKeys(entry.Refs) {
+		if _, err := runGitIn(ctx, repoDir, "update-ref", ref, entry.Refs[ref]); err != nil {
+			return err
+		}
+	}
+	deleted := entry.Deleted
+	if entry.Full {
+		// 전체 번들은 삭제 목록 대신 완전한 ref 상태를 가진다
+		local, err := listRefs(ctx, repoDir)
+		if err != nil {
+			return err
+		}
+		for ref := range local {
+			if _, ok := entry.Refs[ref]; !ok {
+				deleted = append(deleted, ref)
+			}
+		}
+	}
+	for _, ref := range deleted {
+		if _, err := runGitIn(ctx, repoDir, "update-ref", "-d", ref); err != nil {
+			return err
+		}
+	}
+
+	_, err := runGitIn(ctx, repoDir, "config", bundleSequenceKey, strconv.Itoa(entry.Sequence))
+	return err
+}
+
+// download fetches a bundle into a temporary file and checks its digest.
+func (b *BundleSync) download(ctx context.Context, entry BundleEntry) (string, error) {
+	r, err := b.store.Get(ctx, entry.Key)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	tmp, err := os.CreateTemp("", "gz-bundle-*.bundle")
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	err = decompressTo(tmp, io.TeeReader(r, hash), entry.Compression)
+	if err == nil {
+		// 체크섬은 저장된 바이트 전체로 계산한다
+		_, err = io.Copy(hash, r)
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && entry.SHA256 != "" && hex.EncodeToString(hash.Sum(nil)) != entry.SHA256 {
+		err = fmt.Errorf("checksum mismatch for %s", entry.Key)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return tmp.Name(), nil
+}
+
+// decompressTo copies r, compressed with the named codec, to w.
+func decompressTo(w io.Writer, r io.Reader, codecName string) error {
+	codec, err := compress.Lookup(codecName)
+	if err != nil {
+		return err
+	}
+	zr, err := codec.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("decompress bundle: %w", err)
+	}
+	defer zr.Close()
+
+	if _, err := io.Copy(w, zr); err != nil {
+		return fmt.Errorf("decompress bundle: %w", err)
+	}
+	return nil
+}
+
+// listRefs returns branches and tags of a repository.
+func listRefs(ctx context.Context, dir string) (map[string]string, error) {
+	out, err := runGitIn(ctx, dir, "for-each-ref", "--format=%(objectname) %(refname)", "refs/heads", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+
+	refs := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if sha, ref, ok := strings.Cut(line, " "); ok {
+			refs[ref] = sha
+		}
+	}
+
+	return refs, nil
+}
+
+func runGitIn(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w\nOutput: %s", args[0], err, output)
+	}
+
+	return string(output), nil
+}
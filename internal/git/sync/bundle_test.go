@@ -0,0 +1,162 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sync
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/cloud"
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+)
+
+func newTestBundleSync(t *testing.T) *BundleSync {
+	t.Helper()
+
+	store, err := cloud.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	return NewBundleSync(store, BundleSyncOptions{WorkDir: t.TempDir()})
+}
+
+func TestBundleSync_IncrementalRoundTrip(t *testing.T) {
+	source, _, work := newRemotePair(t)
+	ctx := context.Background()
+	bs := newTestBundleSync(t)
+	target := filepath.Join(t.TempDir(), "mirror.git")
+
+	first, err := bs.Export(ctx, "org/repo", source, false)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+	assert.True(t, first.Full)
+	assert.NotEmpty(t, first.Key)
+
+	again, err := bs.Export(ctx, "org/repo", source, false)
+	require.NoError(t, err)
+	assert.Nil(t, again, "unchanged source exports nothing")
+
+	result, err := bs.Apply(ctx, "org/repo", target, "")
+	require.NoError(t, err)
+	require.Len(t, result.Applied, 1)
+	assert.Equal(t, runGit(t, source, "rev-parse", "main"), runGit(t, target, "rev-parse", "main"))
+
+	// 새 브랜치와 커밋, 태그는 증분 번들로 전달된다
+	commitTo(t, work, source, "main", "main", "a.txt")
+	commitTo(t, work, source, "main", "feature", "b.txt")
+	runGit(t, work, "tag", "v1.0")
+	runGit(t, work, "push", "--quiet", source, "v1.0")
+
+	second, err := bs.Export(ctx, "org/repo", source, false)
+	require.NoError(t, err)
+	require.NotNil(t, second)
+	assert.False(t, second.Full)
+	assert.Equal(t, 2, second.Sequence)
+	assert.Less(t, second.Size, first.Size+1024)
+
+	result, err = bs.Apply(ctx, "org/repo", target, "")
+	require.NoError(t, err)
+	require.Len(t, result.Applied, 1)
+	assert.Equal(t, 1, result.Previous)
+	for _, ref := range []string{"main", "feature", "v1.0"} {
+		assert.Equal(t, runGit(t, source, "rev-parse", ref), runGit(t, target, "rev-parse", ref), ref)
+	}
+
+	// 삭제된 브랜치는 ref 전용 항목으로 전달된다
+	runGit(t, source, "branch", "-D", "feature")
+	third, err := bs.Export(ctx, "org/repo", source, false)
+	require.NoError(t, err)
+	require.NotNil(t, third)
+	assert.Empty(t, third.Key)
+	assert.Equal(t, []string{"refs/heads/feature"}, third.Deleted)
+
+	_, err = bs.Apply(ctx, "org/repo", target, "")
+	require.NoError(t, err)
+	assert.Empty(t, runGit(t, target, "branch", "--list", "feature"))
+}
+
+func TestBundleSync_CompressedRoundTrip(t *testing.T) {
+	source, _, work := newRemotePair(t)
+	ctx := context.Background()
+	store, err := cloud.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	target := filepath.Join(t.TempDir(), "mirror.git")
+
+	for i, codec := range []compress.Codec{compress.Zstd, compress.LZ4} {
+		// 번들마다 다른 코덱을 써도 manifest에 기록된 코덱으로 적용된다
+		bs := NewBundleSync(store, BundleSyncOptions{WorkDir: t.TempDir(), Codec: codec})
+		commitTo(t, work, source, "main", "main", codec.Name()+".txt")
+
+		entry, err := bs.Export(ctx, "org/repo", source, false)
+		require.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, codec.Name(), entry.Compression)
+		assert.True(t, strings.HasSuffix(entry.Key, ".bundle"+codec.Extension()), entry.Key)
+
+		result, err := bs.Apply(ctx, "org/repo", target, "")
+		require.NoError(t, err)
+		require.Len(t, result.Applied, 1, "bundle %d", i+1)
+		assert.Equal(t, runGit(t, source, "rev-parse", "main"), runGit(t, target, "rev-parse", "main"))
+	}
+}
+
+func TestBundleSync_ApplyPushesToDestination(t *testing.T) {
+	source, destination, work := newRemotePair(t)
+	ctx := context.Background()
+	bs := newTestBundleSync(t)
+
+	commitTo(t, work, source, "main", "main", "new.txt")
+	_, err := bs.Export(ctx, "org/repo", source, false)
+	require.NoError(t, err)
+
+	result, err := bs.Apply(ctx, "org/repo", filepath.Join(t.TempDir(), "mirror.git"), destination)
+	require.NoError(t, err)
+	assert.True(t, result.Pushed)
+	assert.Equal(t, runGit(t, source, "rev-parse", "main"), runGit(t, destination, "rev-parse", "main"))
+}
+
+func TestBundleSync_IncrementalNeedsBase(t *testing.T) {
+	source, _, work := newRemotePair(t)
+	ctx := context.Background()
+	bs := newTestBundleSync(t)
+
+	_, err := bs.Export(ctx, "org/repo", source, false)
+	require.NoError(t, err)
+	commitTo(t, work, source, "main", "main", "x.txt")
+	_, err = bs.Export(ctx, "org/repo", source, false)
+	require.NoError(t, err)
+
+	// 수신 측이 첫 번째 번들을 놓친 경우
+	manifest, err := bs.LoadManifest(ctx, "org/repo")
+	require.NoError(t, err)
+	manifest.Bundles = manifest.Bundles[1:]
+	require.NoError(t, bs.saveManifest(ctx, manifest))
+
+	_, err = bs.Apply(ctx, "org/repo", filepath.Join(t.TempDir(), "mirror.git"), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--full")
+
+	full, err := bs.Export(ctx, "org/repo", source, true)
+	require.NoError(t, err)
+	assert.True(t, full.Full)
+
+	target := filepath.Join(t.TempDir(), "fresh.git")
+	result, err := bs.Apply(ctx, "org/repo", target, "")
+	require.NoError(t, err, "a fresh receiver starts from the latest full bundle")
+	require.Len(t, result.Applied, 1)
+	assert.Equal(t, full.Sequence, result.Applied[0].Sequence)
+	assert.Equal(t, runGit(t, source, "rev-parse", "main"), runGit(t, target, "rev-parse", "main"))
+}
+
+func TestBundleSync_ApplyWithoutExports(t *testing.T) {
+	bs := newTestBundleSync(t)
+
+	_, err := bs.Apply(context.Background(), "org/none", filepath.Join(t.TempDir(), "x.git"), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no bundles exported")
+}
@@ -19,6 +19,7 @@ type CodeSyncer struct {
 	source      provider.Repository
 	destination *provider.Repository
 	options     SyncOptions
+	secrets     *secretGate
 }
 
 // Sync synchronizes repository code between source and destination.
@@ -39,6 +40,11 @@ func (c *CodeSyncer) Sync(ctx context.Context) error {
 		return fmt.Errorf("failed to clone source: %w", err)
 	}
 
+	// Scan for leaked credentials before anything is pushed
+	if err := c.secrets.check(ctx, c.source.FullName, filepath.Join(tempDir, "repo")); err != nil {
+		return err
+	}
+
 	// Add destination remote
 	if err := c.addDestinationRemote(ctx, tempDir); err != nil {
 		return fmt.Errorf("failed to add destination remote: %w", err)
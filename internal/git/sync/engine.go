@@ -6,8 +6,10 @@ package sync
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 
+	"github.com/gizzahub/gzh-cli/internal/cli"
 	"github.com/gizzahub/gzh-cli/pkg/git/provider"
 )
 
@@ -16,10 +18,14 @@ type SyncEngine struct {
 	source      provider.GitProvider
 	destination provider.GitProvider
 	options     SyncOptions
+	secrets     *secretGate
 }
 
 // NewSyncEngine creates a new sync engine.
 func NewSyncEngine(src, dst provider.GitProvider, opts SyncOptions) *SyncEngine {
+	if opts.Progress == nil {
+		opts.Progress = cli.NewProgress(os.Stdout, cli.ProgressLines)
+	}
 	return &SyncEngine{
 		source:      src,
 		destination: dst,
@@ -51,11 +57,28 @@ func (e *SyncEngine) Sync(ctx context.Context) error {
 
 	// 4. Handle dry run
 	if e.options.DryRun {
-		return e.printSyncPlan(plan)
+		if err := e.printSyncPlan(plan); err != nil {
+			return err
+		}
+		if e.options.Bidirectional && e.options.IncludeCode {
+			return e.printBidirectionalDiff(ctx, plan)
+		}
+		return nil
 	}
 
-	// 5. Execute synchronization plan
-	return e.executeSyncPlan(ctx, plan)
+	// 5. Set up secret scanning of pushed code
+	gate, err := newSecretGate(e.options)
+	if err != nil {
+		return err
+	}
+	e.secrets = gate
+
+	// 6. Execute synchronization plan
+	syncErr := e.executeSyncPlan(ctx, plan)
+	if err := e.secrets.finish(e.options.SecretsReport); err != nil && syncErr == nil {
+		return fmt.Errorf("failed to write secret scan report: %w", err)
+	}
+	return syncErr
 }
 
 // analyzeSource analyzes the source to get repositories to sync.
@@ -210,14 +233,25 @@ func (e *SyncEngine) createSyncActions(source provider.Repository, destination *
 
 	// Code synchronization
 	if e.options.IncludeCode {
+		description := "Sync repository code and branches"
+		if e.options.Bidirectional && destination != nil {
+			description = fmt.Sprintf("Bidirectional branch sync (conflict policy: %s)", e.conflictPolicy())
+		}
+
 		actions = append(actions, SyncAction{
 			Type:        "code",
-			Description: "Sync repository code and branches",
+			Description: description,
 			Handler: func(ctx context.Context) error {
+				// 대상 저장소가 이미 존재하면 양방향 동기화 수행
+				if e.options.Bidirectional && destination != nil {
+					return e.syncBidirectional(ctx, source, *destination)
+				}
+
 				syncer := &CodeSyncer{
 					source:      source,
 					destination: destination,
 					options:     e.options,
+					secrets:     e.secrets,
 				}
 				return syncer.Sync(ctx)
 			},
@@ -295,6 +329,70 @@ func (e *SyncEngine) createSyncActions(source provider.Repository, destination *
 	return actions
 }
 
+// syncBidirectional synchronizes branches of an existing repository pair in both directions.
+func (e *SyncEngine) syncBidirectional(ctx context.Context, source, destination provider.Repository) error {
+	syncer, err := NewBidirectionalSyncer(source.FullName, source.CloneURL, destination.CloneURL, e.options)
+	if err != nil {
+		return err
+	}
+
+	report, err := syncer.Sync(ctx)
+	if report != nil {
+		report.Print(os.Stdout)
+	}
+	if err != nil {
+		return err
+	}
+
+	if queued := countQueued(report); queued > 0 {
+		fmt.Printf("⚠️  %d diverged branch(es) queued for manual resolution in %s\n", queued, syncer.queue.path)
+	}
+
+	return nil
+}
+
+// printBidirectionalDiff fetches both sides of each existing repository and
+// prints the branch-level changes bidirectional sync would make.
+func (e *SyncEngine) printBidirectionalDiff(ctx context.Context, plan SyncPlan) error {
+	for _, repoSync := range plan.Update {
+		if repoSync.Destination == nil {
+			continue
+		}
+
+		syncer, err := NewBidirectionalSyncer(repoSync.Source.FullName, repoSync.Source.CloneURL, repoSync.Destination.CloneURL, e.options)
+		if err != nil {
+			return err
+		}
+
+		report, err := syncer.Sync(ctx)
+		if err != nil {
+			fmt.Printf("  ✗ %s: %v\n", repoSync.Source.FullName, err)
+			continue
+		}
+		report.Print(os.Stdout)
+	}
+
+	return nil
+}
+
+func (e *SyncEngine) conflictPolicy() ConflictPolicy {
+	policy, err := ParseConflictPolicy(e.options.ConflictPolicy)
+	if err != nil {
+		return ConflictManual
+	}
+	return policy
+}
+
+func countQueued(report *BidirectionalReport) int {
+	count := 0
+	for _, a := range report.Actions {
+		if a.Action == BranchConflictQueued {
+			count++
+		}
+	}
+	return count
+}
+
 // printSyncPlan prints the synchronization plan for dry run.
 func (e *SyncEngine) printSyncPlan(plan SyncPlan) error {
 	fmt.Printf("\n🔍 Synchronization Plan (Dry Run)\n")
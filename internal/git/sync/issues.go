@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
 	"github.com/gizzahub/gzh-cli/pkg/git/provider"
 )
 
@@ -34,7 +35,7 @@ func NewIssueSyncer(source, destination provider.GitProvider) *IssueSyncer {
 		source:      source,
 		destination: destination,
 		mapping:     make(map[string]string),
-		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		httpClient:  httpclient.NewProviderClient("github", 30*time.Second),
 		baseURL:     "https://api.github.com",
 	}
 }
@@ -0,0 +1,308 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Builtin merge drivers that need no command.
+const (
+	MergeDriverOurs   = "ours"   // 소스 쪽 내용을 유지
+	MergeDriverTheirs = "theirs" // 대상 쪽 내용을 사용
+	MergeDriverUnion  = "union"  // 양쪽 줄을 모두 유지 (lockfile, CHANGELOG 등)
+)
+
+// MergeResolvedByGit marks paths git's own text merge resolved without a
+// configured driver.
+const MergeResolvedByGit = "git"
+
+// builtinDriverCommands implement the builtin drivers as git merge driver
+// commands. %O is the ancestor, %A the source side and result, %B the
+// destination side.
+var builtinDriverCommands = map[string]string{
+	MergeDriverOurs:   "true",
+	MergeDriverTheirs: "cp %B %A",
+	MergeDriverUnion:  "git merge-file --union %A %O %B",
+}
+
+var driverNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// MergeDriver resolves conflicting changes to files matching its patterns
+// when diverged branches are merged by the merge conflict policy.
+type MergeDriver struct {
+	Name string `yaml:"name"`
+	// Patterns are gitattributes patterns, e.g. "go.sum" or "gen/**".
+	Patterns []string `yaml:"patterns"`
+	// Command is a git merge driver command line with the %O, %A, %B, %L
+	// and %P placeholders. It writes the result to %A and exits non-zero
+	// when it cannot resolve the conflict.
+	Command string `yaml:"command,omitempty"`
+	// Builtin selects ours, theirs or union instead of a command.
+	Builtin string `yaml:"builtin,omitempty"`
+}
+
+// command returns the git driver command line.
+func (d MergeDriver) command() string {
+	if d.Builtin != "" {
+		return builtinDriverCommands[d.Builtin]
+	}
+	return d.Command
+}
+
+// MergeDriverConfig is the merge_drivers section of a repo-sync
+// configuration file.
+type MergeDriverConfig struct {
+	Drivers []MergeDriver `yaml:"merge_drivers"`
+}
+
+// LoadMergeDrivers reads and validates merge drivers from a YAML file.
+func LoadMergeDrivers(path string) ([]MergeDriver, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read merge driver config: %w", err)
+	}
+
+	var cfg MergeDriverConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse merge driver config %s: %w", path, err)
+	}
+	if err := ValidateMergeDrivers(cfg.Drivers); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg.Drivers, nil
+}
+
+// ValidateMergeDrivers checks names, patterns and commands of drivers.
+func ValidateMergeDrivers(drivers []MergeDriver) error {
+	seen := make(map[string]bool, len(drivers))
+	for i, d := range drivers {
+		if !driverNamePattern.MatchString(d.Name) {
+			return fmt.Errorf("merge driver %d: invalid name %q", i+1, d.Name)
+		}
+		if d.Name == MergeResolvedByGit || seen[d.Name] {
+			return fmt.Errorf("merge driver %q: name is reserved or used twice", d.Name)
+		}
+		seen[d.Name] = true
+
+		if len(d.Patterns) == 0 {
+			return fmt.Errorf("merge driver %q: at least one pattern is required", d.Name)
+		}
+		for _, p := range d.Patterns {
+			if strings.TrimSpace(p) == "" || strings.ContainsAny(p, " \t\n") {
+				return fmt.Errorf("merge driver %q: invalid pattern %q", d.Name, p)
+			}
+		}
+
+		switch {
+		case d.Command != "" && d.Builtin != "":
+			return fmt.Errorf("merge driver %q: set either command or builtin, not both", d.Name)
+		case d.Builtin != "":
+			if _, ok := builtinDriverCommands[d.Builtin]; !ok {
+				return fmt.Errorf("merge driver %q: unknown builtin %q (expected ours, theirs or union)", d.Name, d.Builtin)
+			}
+		case strings.TrimSpace(d.Command) == "":
+			return fmt.Errorf("merge driver %q: command or builtin is required", d.Name)
+		}
+	}
+	return nil
+}
+
+// mergeAttributes renders drivers as gitattributes lines. Later lines win
+// in gitattributes, so the drivers are written in reverse to let the first
+// matching driver in the configuration take precedence.
+func mergeAttributes(drivers []MergeDriver) string {
+	var sb strings.Builder
+	for i := len(drivers) - 1; i >= 0; i-- {
+		for _, p := range drivers[i].Patterns {
+			fmt.Fprintf(&sb, "%s merge=%s\n", p, drivers[i].Name)
+		}
+	}
+	return sb.String()
+}
+
+// ConflictResolution records how a path changed on both sides was merged.
+type ConflictResolution struct {
+	Path string `json:"path"`
+	// Driver is the merge driver name, or MergeResolvedByGit.
+	Driver string `json:"driver"`
+}
+
+// configureMerge registers the merge drivers in the bare repository at dir
+// and makes sure merge commits have a committer identity.
+func (b *BidirectionalSyncer) configureMerge(ctx context.Context, dir string) error {
+	for _, d := range b.drivers {
+		if _, err := b.git(ctx, dir, "config", "merge."+d.Name+".name", "gz sync driver "+d.Name); err != nil {
+			return err
+		}
+		if _, err := b.git(ctx, dir, "config", "merge."+d.Name+".driver", d.command()); err != nil {
+			return err
+		}
+	}
+
+	// info/attributes는 작업 트리의 .gitattributes보다 우선함
+	if err := os.MkdirAll(filepath.Join(dir, "info"), 0o755); err != nil {
+		return fmt.Errorf("failed to configure merge drivers: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "info", "attributes"), []byte(mergeAttributes(b.drivers)), 0o600); err != nil {
+		return fmt.Errorf("failed to configure merge drivers: %w", err)
+	}
+
+	if _, err := b.git(ctx, dir, "config", "user.email"); err != nil {
+		for key, value := range map[string]string{"user.name": "gz sync", "user.email": "gz-sync@localhost"} {
+			if _, err := b.git(ctx, dir, "config", key, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// merge merges the destination branch into the source branch in a
+// temporary worktree and pushes the merge commit to both sides. When paths
+// stay unresolved the merge is abandoned and a.Unresolved is set; a.MergeSHA
+// is only set once both pushes succeeded.
+func (b *BidirectionalSyncer) merge(ctx context.Context, dir string, a *BranchAction) error {
+	worktree, err := os.MkdirTemp(dir, "merge-*")
+	if err != nil {
+		return fmt.Errorf("failed to create merge worktree: %w", err)
+	}
+	if _, err := b.git(ctx, dir, "worktree", "add", "--quiet", "--detach", worktree, a.SourceSHA); err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = b.git(context.WithoutCancel(ctx), dir, "worktree", "remove", "--force", worktree)
+	}()
+
+	conflicting, err := b.conflictingPaths(ctx, dir, a.SourceSHA, a.DestinationSHA)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Merge destination %s into %s", a.DestinationBranch, a.SourceBranch)
+	if _, mergeErr := b.git(ctx, worktree, "merge", "--no-ff", "--no-edit", "-m", message, a.DestinationSHA); mergeErr != nil {
+		out, err := b.git(ctx, worktree, "diff", "--name-only", "--diff-filter=U")
+		if err != nil {
+			return err
+		}
+		unresolved := splitLines(out)
+		if len(unresolved) == 0 {
+			return mergeErr
+		}
+		_, _ = b.git(ctx, worktree, "merge", "--abort")
+		a.Unresolved = unresolved
+		return nil
+	}
+
+	resolutions, err := b.resolutions(ctx, worktree, conflicting)
+	if err != nil {
+		return err
+	}
+	merged, err := b.git(ctx, worktree, "rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	merged = strings.TrimSpace(merged)
+
+	if err := b.push(ctx, dir, "destination", merged, a.DestinationBranch, a.DestinationSHA); err != nil {
+		return err
+	}
+	if err := b.push(ctx, dir, "source", merged, a.SourceBranch, a.SourceSHA); err != nil {
+		return err
+	}
+	a.MergeSHA = merged
+	a.Resolutions = resolutions
+	return nil
+}
+
+// conflictingPaths returns the paths both sides changed differently since
+// their merge base, which are the ones a merge driver may be asked to merge.
+func (b *BidirectionalSyncer) conflictingPaths(ctx context.Context, dir, ours, theirs string) ([]string, error) {
+	base, err := b.git(ctx, dir, "merge-base", ours, theirs)
+	if err != nil {
+		return nil, err
+	}
+	base = strings.TrimSpace(base)
+
+	changed := func(from, to string) (map[string]bool, error) {
+		out, err := b.git(ctx, dir, "diff", "--name-only", "--no-renames", from, to)
+		if err != nil {
+			return nil, err
+		}
+		paths := map[string]bool{}
+		for _, p := range splitLines(out) {
+			paths[p] = true
+		}
+		return paths, nil
+	}
+	oursChanged, err := changed(base, ours)
+	if err != nil {
+		return nil, err
+	}
+	theirsChanged, err := changed(base, theirs)
+	if err != nil {
+		return nil, err
+	}
+	differ, err := changed(ours, theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for p := range oursChanged {
+		if theirsChanged[p] && differ[p] {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// resolutions reports the merge driver git selected for each path.
+func (b *BidirectionalSyncer) resolutions(ctx context.Context, worktree string, paths []string) ([]ConflictResolution, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	out, err := b.git(ctx, worktree, append([]string{"check-attr", "merge", "--"}, paths...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	drivers := make(map[string]bool, len(b.drivers))
+	for _, d := range b.drivers {
+		drivers[d.Name] = true
+	}
+	resolutions := make([]ConflictResolution, 0, len(paths))
+	for _, line := range splitLines(out) {
+		// <path>: merge: <value>
+		idx := strings.LastIndex(line, ": merge: ")
+		if idx < 0 {
+			continue
+		}
+		driver := line[idx+len(": merge: "):]
+		if !drivers[driver] {
+			driver = MergeResolvedByGit
+		}
+		resolutions = append(resolutions, ConflictResolution{Path: line[:idx], Driver: driver})
+	}
+	return resolutions, nil
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTo commits file with content on top of base and pushes it as branch.
+func writeTo(t *testing.T, work, remote, base, branch, file, content string) {
+	t.Helper()
+
+	runGit(t, work, "fetch", "--quiet", remote, base)
+	runGit(t, work, "checkout", "--quiet", "-B", branch, "FETCH_HEAD")
+	require.NoError(t, os.WriteFile(filepath.Join(work, file), []byte(content), 0o644))
+	runGit(t, work, "add", ".")
+	runGit(t, work, "commit", "--quiet", "-m", file)
+	runGit(t, work, "push", "--quiet", "--force", remote, branch)
+}
+
+// divergeFile changes file differently on source and destination main.
+func divergeFile(t *testing.T, source, destination, work, file string) {
+	t.Helper()
+
+	writeTo(t, work, source, "main", "main", file, "base\n")
+	runGit(t, work, "push", "--quiet", destination, "main")
+	writeTo(t, work, source, "main", "main", file, "base\nsource\n")
+	writeTo(t, work, destination, "main", "main", file, "base\ndestination\n")
+}
+
+func writeDrivers(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "merge-drivers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadMergeDrivers(t *testing.T) {
+	path := writeDrivers(t, `
+merge_drivers:
+  - name: lockfile
+    patterns: ["go.sum", "*.lock"]
+    builtin: union
+  - name: generated
+    patterns: ["gen/**"]
+    command: ./scripts/regen.sh %O %A %B
+`)
+	drivers, err := LoadMergeDrivers(path)
+	require.NoError(t, err)
+	require.Len(t, drivers, 2)
+	assert.Equal(t, "git merge-file --union %A %O %B", drivers[0].command())
+	assert.Equal(t, "gen/** merge=generated\ngo.sum merge=lockfile\n*.lock merge=lockfile\n", mergeAttributes(drivers))
+
+	invalid := []struct {
+		name    string
+		drivers []MergeDriver
+		want    string
+	}{
+		{"bad name", []MergeDriver{{Name: "a b", Patterns: []string{"x"}, Builtin: "ours"}}, "invalid name"},
+		{"reserved", []MergeDriver{{Name: "git", Patterns: []string{"x"}, Builtin: "ours"}}, "reserved"},
+		{"no pattern", []MergeDriver{{Name: "a", Builtin: "ours"}}, "pattern"},
+		{"both", []MergeDriver{{Name: "a", Patterns: []string{"x"}, Builtin: "ours", Command: "true"}}, "either"},
+		{"unknown builtin", []MergeDriver{{Name: "a", Patterns: []string{"x"}, Builtin: "newest"}}, "unknown builtin"},
+		{"no command", []MergeDriver{{Name: "a", Patterns: []string{"x"}}}, "required"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, ValidateMergeDrivers(tt.drivers), tt.want)
+		})
+	}
+}
+
+func TestSyncOptionsValidateMergeDrivers(t *testing.T) {
+	path := writeDrivers(t, "merge_drivers:\n  - name: lock\n    patterns: [go.sum]\n    builtin: union\n")
+	opts := SyncOptions{
+		From: "github:org/repo", To: "gitlab:group/repo", IncludeCode: true,
+		Bidirectional: true, ConflictPolicy: "manual", MergeDrivers: path,
+	}
+	assert.ErrorContains(t, opts.Validate(), "conflict-policy merge")
+
+	opts.ConflictPolicy = "merge"
+	assert.NoError(t, opts.Validate())
+}
+
+func TestBidirectionalSyncer_MergeWithDriver(t *testing.T) {
+	source, destination, work := newRemotePair(t)
+	divergeFile(t, source, destination, work, "go.sum")
+	writeTo(t, work, destination, "main", "main", "other.txt", "destination only\n")
+
+	drivers := writeDrivers(t, "merge_drivers:\n  - name: lockfile\n    patterns: [go.sum]\n    builtin: union\n")
+	syncer := newTestSyncer(t, source, destination, SyncOptions{ConflictPolicy: "merge", MergeDrivers: drivers})
+	report, err := syncer.Sync(context.Background())
+	require.NoError(t, err)
+
+	action := findAction(t, report, "main")
+	assert.Equal(t, BranchConflictMerge, action.Action)
+	require.NotEmpty(t, action.MergeSHA)
+	assert.Equal(t, []ConflictResolution{{Path: "go.sum", Driver: "lockfile"}}, action.Resolutions)
+	assert.Equal(t, action.MergeSHA, runGit(t, source, "rev-parse", "main"))
+	assert.Equal(t, action.MergeSHA, runGit(t, destination, "rev-parse", "main"))
+	assert.Equal(t, "base\nsource\ndestination", runGit(t, source, "show", "main:go.sum"))
+
+	entries, err := syncer.queue.List()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestBidirectionalSyncer_MergeQueuesUnresolved(t *testing.T) {
+	source, destination, work := newRemotePair(t)
+	divergeFile(t, source, destination, work, "main.go")
+
+	srcSHA := runGit(t, source, "rev-parse", "main")
+	dstSHA := runGit(t, destination, "rev-parse", "main")
+
+	syncer := newTestSyncer(t, source, destination, SyncOptions{ConflictPolicy: "merge"})
+	report, err := syncer.Sync(context.Background())
+	require.NoError(t, err)
+
+	action := findAction(t, report, "main")
+	assert.Equal(t, BranchConflictQueued, action.Action)
+	assert.Equal(t, []string{"main.go"}, action.Unresolved)
+	assert.Equal(t, srcSHA, runGit(t, source, "rev-parse", "main"))
+	assert.Equal(t, dstSHA, runGit(t, destination, "rev-parse", "main"))
+
+	entries, err := syncer.queue.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, []string{"main.go"}, entries[0].Unresolved)
+}
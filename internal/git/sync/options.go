@@ -6,6 +6,9 @@ package sync
 import (
 	"fmt"
 	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/pkg/security/secrets"
 )
 
 // SyncOptions contains options for repository synchronization.
@@ -19,6 +22,13 @@ type SyncOptions struct {
 	UpdateExisting bool
 	Force          bool
 
+	// Bidirectional sync options
+	Bidirectional  bool
+	ConflictPolicy string   // ours, theirs, manual, merge
+	BranchMappings []string // source:destination (e.g. main:master, release/*:rel/*)
+	ConflictQueue  string   // manual 충돌 큐 파일 경로
+	MergeDrivers   string   // merge 정책에서 사용할 병합 드라이버 설정 (YAML)
+
 	// Include options
 	IncludeCode     bool
 	IncludeIssues   bool
@@ -35,6 +45,16 @@ type SyncOptions struct {
 	Parallel int
 	DryRun   bool
 	Verbose  bool
+
+	// Secret scanning of source code before it is pushed
+	ScanSecrets   bool
+	SecretsConfig string
+	SecretsReport string
+	FailOnSecrets string // 이 심각도 이상의 발견 시 해당 저장소 push 차단
+
+	// Progress reports each synchronized repository; defaults to lines on
+	// stdout.
+	Progress cli.Progress
 }
 
 // SyncTarget represents a parsed sync target (provider:org/repo or provider:org).
@@ -152,6 +172,41 @@ func (opts *SyncOptions) Validate() error {
 		return fmt.Errorf("parallel workers cannot exceed 20")
 	}
 
+	// Validate bidirectional sync settings
+	if opts.Bidirectional {
+		if !opts.IncludeCode {
+			return fmt.Errorf("bidirectional sync requires code sync (--include-code)")
+		}
+		policy, err := ParseConflictPolicy(opts.ConflictPolicy)
+		if err != nil {
+			return err
+		}
+		if _, err := ParseBranchMappings(opts.BranchMappings); err != nil {
+			return err
+		}
+		if opts.MergeDrivers != "" {
+			if policy != ConflictMerge {
+				return fmt.Errorf("merge drivers require --conflict-policy merge")
+			}
+			if _, err := LoadMergeDrivers(opts.MergeDrivers); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Validate secret scanning; a config, report or threshold implies scanning
+	if opts.SecretsConfig != "" || opts.SecretsReport != "" || opts.FailOnSecrets != "" {
+		opts.ScanSecrets = true
+	}
+	if opts.FailOnSecrets != "" {
+		if _, err := secrets.ParseSeverity(opts.FailOnSecrets); err != nil {
+			return err
+		}
+	}
+	if opts.ScanSecrets && !opts.IncludeCode {
+		return fmt.Errorf("secret scanning requires code sync (--include-code)")
+	}
+
 	// Validate that at least one sync feature is enabled
 	if !opts.IncludeCode && !opts.IncludeIssues && !opts.IncludePRs &&
 		!opts.IncludeWiki && !opts.IncludeReleases && !opts.IncludeSettings {
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gizzahub/gzh-cli/internal/cli"
 	"github.com/gizzahub/gzh-cli/pkg/git/provider"
 )
 
@@ -29,44 +30,21 @@ func (e *SyncEngine) executeSequential(ctx context.Context, plan SyncPlan) error
 		return nil
 	}
 
-	fmt.Printf("🔄 Executing synchronization plan (%d repositories)...\n\n", totalTasks)
+	progress := e.options.Progress
+	progress.Phase("Synchronizing repositories", totalTasks)
 
 	var errors []error
-
-	// Execute create tasks
-	for i, repoSync := range plan.Create {
-		fmt.Printf("[%d/%d] Creating %s...\n", i+1, totalTasks, repoSync.Source.FullName)
+	for _, repoSync := range append(append([]RepoSync{}, plan.Create...), plan.Update...) {
 		if err := e.syncRepository(ctx, repoSync); err != nil {
-			fmt.Printf("❌ Failed: %v\n", err)
+			progress.Item(repoSync.Source.FullName, cli.ItemFailed, err)
 			errors = append(errors, fmt.Errorf("%s: %w", repoSync.Source.FullName, err))
 		} else {
-			fmt.Printf("✅ Success\n")
+			progress.Item(repoSync.Source.FullName, cli.ItemDone, nil)
 		}
-		fmt.Println()
 	}
+	progress.Done()
 
-	// Execute update tasks
-	for i, repoSync := range plan.Update {
-		fmt.Printf("[%d/%d] Updating %s...\n", len(plan.Create)+i+1, totalTasks, repoSync.Source.FullName)
-		if err := e.syncRepository(ctx, repoSync); err != nil {
-			fmt.Printf("❌ Failed: %v\n", err)
-			errors = append(errors, fmt.Errorf("%s: %w", repoSync.Source.FullName, err))
-		} else {
-			fmt.Printf("✅ Success\n")
-		}
-		fmt.Println()
-	}
-
-	if len(errors) > 0 {
-		fmt.Printf("❌ Synchronization completed with %d errors\n", len(errors))
-		for _, err := range errors {
-			fmt.Printf("  - %v\n", err)
-		}
-		return fmt.Errorf("synchronization failed for %d repositories", len(errors))
-	}
-
-	fmt.Printf("✅ Synchronization completed successfully!\n")
-	return nil
+	return reportSyncErrors(errors)
 }
 
 // executeParallel executes synchronization tasks in parallel.
@@ -77,8 +55,8 @@ func (e *SyncEngine) executeParallel(ctx context.Context, plan SyncPlan) error {
 		return nil
 	}
 
-	fmt.Printf("🔄 Executing synchronization plan (%d repositories, %d workers)...\n\n",
-		totalTasks, e.options.Parallel)
+	progress := e.options.Progress
+	progress.Phase(fmt.Sprintf("Synchronizing repositories (%d workers)", e.options.Parallel), totalTasks)
 
 	// Create task queue
 	tasks := make(chan RepoSync, totalTasks)
@@ -111,18 +89,22 @@ func (e *SyncEngine) executeParallel(ctx context.Context, plan SyncPlan) error {
 
 	// Collect results
 	var errors []error
-	completed := 0
 	for result := range results {
-		completed++
 		if result.Error != nil {
-			fmt.Printf("❌ [%d/%d] %s: %v\n", completed, totalTasks, result.Repository, result.Error)
+			progress.Item(result.Repository, cli.ItemFailed, result.Error)
 			errors = append(errors, fmt.Errorf("%s: %w", result.Repository, result.Error))
 		} else {
-			fmt.Printf("✅ [%d/%d] %s: completed in %v\n",
-				completed, totalTasks, result.Repository, result.Duration)
+			progress.Item(result.Repository, cli.ItemDone, nil)
 		}
 	}
+	progress.Done()
+
+	return reportSyncErrors(errors)
+}
 
+// reportSyncErrors prints the failed repositories once the progress output
+// is complete.
+func reportSyncErrors(errors []error) error {
 	if len(errors) > 0 {
 		fmt.Printf("\n❌ Synchronization completed with %d errors\n", len(errors))
 		for _, err := range errors {
@@ -141,7 +123,7 @@ func (e *SyncEngine) worker(ctx context.Context, workerID int, tasks <-chan Repo
 		start := time.Now()
 
 		if e.options.Verbose {
-			fmt.Printf("🔧 Worker %d: Starting %s\n", workerID, repoSync.Source.FullName)
+			e.options.Progress.Logf("🔧 Worker %d: Starting %s", workerID, repoSync.Source.FullName)
 		}
 
 		err := e.syncRepository(ctx, repoSync)
@@ -189,7 +171,7 @@ func (e *SyncEngine) syncRepository(ctx context.Context, repoSync RepoSync) erro
 	// Execute all sync actions
 	for _, action := range repoSync.Actions {
 		if e.options.Verbose {
-			fmt.Printf("  Executing %s: %s\n", action.Type, action.Description)
+			e.options.Progress.Logf("  Executing %s: %s", action.Type, action.Description)
 		}
 
 		if err := action.Handler(ctx); err != nil {
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/gizzahub/gzh-cli/pkg/security/secrets"
+)
+
+// secretGate scans mirrored source repositories before their code is pushed
+// and blocks pushes whose findings reach the configured severity.
+type secretGate struct {
+	scanner   *secrets.Scanner
+	report    *secrets.Report
+	threshold secrets.Severity // 0: 차단하지 않음
+}
+
+// newSecretGate returns nil when secret scanning is disabled.
+func newSecretGate(opts SyncOptions) (*secretGate, error) {
+	if !opts.ScanSecrets {
+		return nil, nil
+	}
+
+	scanner, err := secrets.NewScannerFromFile(opts.SecretsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up secret scanning: %w", err)
+	}
+	gate := &secretGate{scanner: scanner, report: secrets.NewReport()}
+	if opts.FailOnSecrets != "" {
+		if gate.threshold, err = secrets.ParseSeverity(opts.FailOnSecrets); err != nil {
+			return nil, err
+		}
+	}
+	return gate, nil
+}
+
+// check scans the default branch of the mirror at gitDir. It is a no-op on a
+// nil gate.
+func (g *secretGate) check(ctx context.Context, repository, gitDir string) error {
+	if g == nil {
+		return nil
+	}
+
+	findings, err := g.scanner.ScanGitTree(ctx, repository, gitDir, "HEAD")
+	if err != nil {
+		g.report.AddError(repository, err)
+		return fmt.Errorf("secret scan failed: %w", err)
+	}
+	g.report.Add(findings)
+
+	if g.threshold == 0 {
+		return nil
+	}
+	blocking := 0
+	for _, f := range findings {
+		if f.Severity >= g.threshold {
+			blocking++
+		}
+	}
+	if blocking > 0 {
+		return fmt.Errorf("push blocked: %d potential secret(s) of %s severity or higher", blocking, g.threshold)
+	}
+	return nil
+}
+
+// finish prints the consolidated findings and writes the report file.
+func (g *secretGate) finish(reportPath string) error {
+	if g == nil {
+		return nil
+	}
+	g.report.PrintSummary(os.Stdout)
+	if reportPath == "" {
+		return nil
+	}
+	if err := g.report.WriteJSON(reportPath); err != nil {
+		return err
+	}
+	fmt.Printf("Secret scan report written to %s\n", reportPath)
+	return nil
+}
@@ -0,0 +1,465 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch defaults.
+const (
+	DefaultWatchBranch   = "gz-sync"
+	DefaultWatchRemote   = "mirror"
+	DefaultWatchDebounce = 2 * time.Second
+	DefaultWatchMaxDelay = time.Minute
+)
+
+// WatchState is the sync state of a watched working tree.
+type WatchState string
+
+const (
+	WatchIdle     WatchState = "idle"     // 미러와 동기화됨
+	WatchPending  WatchState = "pending"  // debounce 대기 중인 변경 있음
+	WatchSyncing  WatchState = "syncing"  // 커밋 또는 push 진행 중
+	WatchConflict WatchState = "conflict" // 미러 브랜치가 갈라졌거나 병합되지 않은 경로 존재
+	WatchError    WatchState = "error"
+)
+
+// ErrWatchConflict is returned when a working tree cannot be synced without
+// overwriting commits that only exist on the mirror.
+var ErrWatchConflict = errors.New("sync conflict")
+
+// WatchOptions configures a WorkTreeWatcher.
+type WatchOptions struct {
+	// Branch receives the auto-commits. The checked-out branch, its index
+	// and the working tree are never modified.
+	Branch string
+	// Remote is the mirror the sync branch is pushed to.
+	Remote string
+	// Debounce is the quiet period after the last change before syncing.
+	Debounce time.Duration
+	// MaxDelay bounds how long a continuous stream of changes can postpone
+	// a sync.
+	MaxDelay time.Duration
+	// Ignore lists glob patterns, relative to the working tree, whose
+	// changes neither trigger a sync nor enter the sync commits. Patterns
+	// without a slash match a file or directory name at any depth.
+	// Files ignored by .gitignore are never committed either.
+	Ignore []string
+	// OnUpdate, when set, is called whenever the status of a working tree
+	// changes.
+	OnUpdate func(WatchStatus)
+}
+
+// WatchStatus is the sync status of one working tree.
+type WatchStatus struct {
+	Path  string     `json:"path"`
+	State WatchState `json:"state"`
+	// Changed is when the oldest change not yet on the mirror was seen.
+	Changed  time.Time `json:"changed,omitzero"`
+	LastSync time.Time `json:"last_sync,omitzero"`
+	Commit   string    `json:"commit,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Lag is how long the oldest unsynced change has been waiting.
+func (s WatchStatus) Lag(now time.Time) time.Duration {
+	if s.Changed.IsZero() {
+		return 0
+	}
+	return now.Sub(s.Changed)
+}
+
+// WorkTreeWatcher watches working trees and mirrors their state, including
+// uncommitted changes, to a sync branch on a mirror remote.
+type WorkTreeWatcher struct {
+	opts  WatchOptions
+	paths []string
+
+	mu     sync.Mutex
+	status map[string]*WatchStatus
+}
+
+// NewWorkTreeWatcher creates a watcher for the working trees at paths. Each
+// must be a git working tree with the mirror remote configured.
+func NewWorkTreeWatcher(paths []string, opts WatchOptions) (*WorkTreeWatcher, error) {
+	if opts.Branch == "" {
+		opts.Branch = DefaultWatchBranch
+	}
+	if opts.Remote == "" {
+		opts.Remote = DefaultWatchRemote
+	}
+	if opts.Debounce <= 0 {
+		opts.Debounce = DefaultWatchDebounce
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = DefaultWatchMaxDelay
+	}
+	if len(paths) == 0 {
+		return nil, errors.New("no working trees to watch")
+	}
+
+	ctx := context.Background()
+	if _, err := watchGit(ctx, ".", nil, "check-ref-format", "--branch", opts.Branch); err != nil {
+		return nil, fmt.Errorf("invalid sync branch %q", opts.Branch)
+	}
+
+	w := &WorkTreeWatcher{opts: opts, status: make(map[string]*WatchStatus)}
+	for _, p := range paths {
+		root, err := watchGit(ctx, p, nil, "rev-parse", "--show-toplevel")
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a git working tree", p)
+		}
+		root = filepath.Clean(root)
+		if _, err := watchGit(ctx, root, nil, "remote", "get-url", opts.Remote); err != nil {
+			return nil, fmt.Errorf("%s has no remote %q", root, opts.Remote)
+		}
+		if _, ok := w.status[root]; ok {
+			continue
+		}
+		w.paths = append(w.paths, root)
+		w.status[root] = &WatchStatus{Path: root, State: WatchIdle}
+	}
+	return w, nil
+}
+
+// Status returns the status of every working tree in watch order.
+func (w *WorkTreeWatcher) Status() []WatchStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	out := make([]WatchStatus, 0, len(w.paths))
+	for _, p := range w.paths {
+		out = append(out, *w.status[p])
+	}
+	return out
+}
+
+// Run syncs every working tree once, then keeps syncing them as they change
+// until ctx is done.
+func (w *WorkTreeWatcher) Run(ctx context.Context) error {
+	errs := make([]error, len(w.paths))
+	var wg sync.WaitGroup
+	for i, root := range w.paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.watch(ctx, root); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", root, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (w *WorkTreeWatcher) watch(ctx context.Context, root string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	if err := w.addTree(watcher, root, root); err != nil {
+		return err
+	}
+
+	// Changes made while nobody was watching are synced right away.
+	w.Sync(ctx, root)
+
+	debounce := time.NewTimer(w.opts.Debounce)
+	debounce.Stop()
+	defer debounce.Stop()
+	var deadline *time.Timer
+	var deadlineC <-chan time.Time
+
+	flush := func() {
+		debounce.Stop()
+		if deadline != nil {
+			deadline.Stop()
+			deadline, deadlineC = nil, nil
+		}
+		w.Sync(ctx, root)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			rel, err := filepath.Rel(root, event.Name)
+			if err != nil || w.ignored(rel) || event.Op == fsnotify.Chmod {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Lstat(event.Name); err == nil && info.IsDir() {
+					_ = w.addTree(watcher, root, event.Name)
+				}
+			}
+			w.markChanged(root)
+			debounce.Reset(w.opts.Debounce)
+			if deadline == nil {
+				deadline = time.NewTimer(w.opts.MaxDelay)
+				deadlineC = deadline.C
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.update(root, func(s *WatchStatus) { s.State, s.Error = WatchError, err.Error() })
+		case <-debounce.C:
+			flush()
+		case <-deadlineC:
+			flush()
+		}
+	}
+}
+
+// addTree watches dir and its subdirectories, as fsnotify is not recursive.
+func (w *WorkTreeWatcher) addTree(watcher *fsnotify.Watcher, root, dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// 감시 도중 삭제된 디렉터리는 건너뛴다
+			if p != dir {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if rel, _ := filepath.Rel(root, p); rel != "." && w.ignored(rel) {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(p); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", p, err)
+		}
+		return nil
+	})
+}
+
+// ignored reports whether a path relative to the working tree, or one of
+// its parent directories, matches .git or an ignore pattern.
+func (w *WorkTreeWatcher) ignored(rel string) bool {
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for i, part := range parts {
+		if part == ".git" {
+			return true
+		}
+		prefix := strings.Join(parts[:i+1], "/")
+		for _, pattern := range w.opts.Ignore {
+			pattern = strings.TrimSuffix(pattern, "/")
+			target := prefix
+			if !strings.Contains(pattern, "/") {
+				target = part
+			}
+			if ok, _ := path.Match(pattern, target); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (w *WorkTreeWatcher) markChanged(root string) {
+	w.update(root, func(s *WatchStatus) {
+		if s.State != WatchConflict {
+			s.State = WatchPending
+		}
+		if s.Changed.IsZero() {
+			s.Changed = time.Now()
+		}
+	})
+}
+
+func (w *WorkTreeWatcher) update(root string, fn func(*WatchStatus)) {
+	w.mu.Lock()
+	s := w.status[root]
+	fn(s)
+	snapshot := *s
+	w.mu.Unlock()
+
+	if w.opts.OnUpdate != nil {
+		w.opts.OnUpdate(snapshot)
+	}
+}
+
+// Sync commits the current state of the working tree at root to the sync
+// branch and pushes it to the mirror, recording the outcome in its status.
+func (w *WorkTreeWatcher) Sync(ctx context.Context, root string) {
+	w.update(root, func(s *WatchStatus) {
+		if s.State != WatchConflict {
+			s.State = WatchSyncing
+		}
+	})
+	commit, err := w.syncOnce(ctx, root)
+	if ctx.Err() != nil {
+		return
+	}
+	now := time.Now()
+	w.update(root, func(s *WatchStatus) {
+		switch {
+		case errors.Is(err, ErrWatchConflict):
+			s.State, s.Error = WatchConflict, err.Error()
+		case err != nil:
+			s.State, s.Error = WatchError, err.Error()
+		default:
+			s.State, s.Error, s.Changed, s.LastSync, s.Commit = WatchIdle, "", time.Time{}, now, commit
+		}
+	})
+}
+
+func (w *WorkTreeWatcher) syncOnce(ctx context.Context, root string) (string, error) {
+	unmerged, err := watchGit(ctx, root, nil, "diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return "", err
+	}
+	if unmerged != "" {
+		return "", fmt.Errorf("%w: unmerged paths in the working tree", ErrWatchConflict)
+	}
+
+	commit, err := w.snapshot(ctx, root)
+	if err != nil {
+		return "", err
+	}
+
+	// Someone else pushing to the sync branch, e.g. from another machine,
+	// shows up as a mirror tip the local branch does not contain.
+	remote, err := watchGit(ctx, root, nil, "ls-remote", w.opts.Remote, "refs/heads/"+w.opts.Branch)
+	if err != nil {
+		return "", err
+	}
+	remoteTip, _, _ := strings.Cut(remote, "\t")
+	switch {
+	case remoteTip == commit:
+		return commit, nil
+	case remoteTip != "" && !isAncestor(ctx, root, remoteTip, commit):
+		return "", fmt.Errorf("%w: %s/%s has commits that are not in the local %s branch",
+			ErrWatchConflict, w.opts.Remote, w.opts.Branch, w.opts.Branch)
+	}
+
+	if _, err := watchGit(ctx, root, nil, "push", "--quiet", w.opts.Remote,
+		commit+":refs/heads/"+w.opts.Branch); err != nil {
+		if strings.Contains(err.Error(), "rejected") {
+			return "", fmt.Errorf("%w: %v", ErrWatchConflict, err)
+		}
+		return "", err
+	}
+	return commit, nil
+}
+
+// snapshot commits the working tree, minus ignored paths, to the sync
+// branch through a private index and returns the branch tip. No commit is
+// made when nothing changed since the last snapshot.
+func (w *WorkTreeWatcher) snapshot(ctx context.Context, root string) (string, error) {
+	index, err := os.CreateTemp("", "gz-sync-index-*")
+	if err != nil {
+		return "", err
+	}
+	indexPath := index.Name()
+	_ = index.Close()
+	// git refuses an empty index file, so it starts from none.
+	_ = os.Remove(indexPath)
+	defer os.Remove(indexPath)
+	env := []string{"GIT_INDEX_FILE=" + indexPath}
+
+	args := append([]string{"add", "--all", "--", "."}, excludePathspecs(w.opts.Ignore)...)
+	if _, err := watchGit(ctx, root, env, args...); err != nil {
+		return "", err
+	}
+	tree, err := watchGit(ctx, root, env, "write-tree")
+	if err != nil {
+		return "", err
+	}
+
+	branchRef := "refs/heads/" + w.opts.Branch
+	head, _ := watchGit(ctx, root, nil, "rev-parse", "--verify", "--quiet", "HEAD^{commit}")
+	tip, _ := watchGit(ctx, root, nil, "rev-parse", "--verify", "--quiet", branchRef+"^{commit}")
+
+	// The sync branch follows new commits on HEAD by merging them in, so it
+	// always fast-forwards on the mirror.
+	var parents []string
+	if tip != "" {
+		parents = append(parents, tip)
+	}
+	if head != "" && (tip == "" || !isAncestor(ctx, root, head, tip)) {
+		parents = append(parents, head)
+	}
+	if len(parents) == 1 {
+		parentTree, err := watchGit(ctx, root, nil, "rev-parse", parents[0]+"^{tree}")
+		if err != nil {
+			return "", err
+		}
+		if parentTree == tree {
+			if tip == "" {
+				if _, err := watchGit(ctx, root, nil, "update-ref", branchRef, parents[0], ""); err != nil {
+					return "", err
+				}
+			}
+			return parents[0], nil
+		}
+	}
+
+	args = []string{"commit-tree", tree, "-m", "gz sync: snapshot of " + filepath.Base(root)}
+	for _, p := range parents {
+		args = append(args, "-p", p)
+	}
+	commit, err := watchGit(ctx, root, nil, args...)
+	if err != nil {
+		return "", err
+	}
+	// Compare-and-swap so that a concurrent writer is not overwritten.
+	if _, err := watchGit(ctx, root, nil, "update-ref", branchRef, commit, tip); err != nil {
+		return "", err
+	}
+	return commit, nil
+}
+
+// excludePathspecs turns ignore patterns into git pathspecs.
+func excludePathspecs(patterns []string) []string {
+	var specs []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(pattern, "/")
+		if !strings.Contains(pattern, "/") {
+			pattern = "**/" + pattern
+		}
+		specs = append(specs, ":(exclude,glob)"+pattern, ":(exclude,glob)"+pattern+"/**")
+	}
+	return specs
+}
+
+func isAncestor(ctx context.Context, dir, ancestor, descendant string) bool {
+	_, err := watchGit(ctx, dir, nil, "merge-base", "--is-ancestor", ancestor, descendant)
+	return err == nil
+}
+
+func watchGit(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
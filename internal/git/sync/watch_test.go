@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWatchedWorkTree creates a working tree with a bare mirror remote.
+func newWatchedWorkTree(t *testing.T) (work, mirror string) {
+	t.Helper()
+
+	_, mirror, work = newRemotePair(t)
+	runGit(t, work, "remote", "add", DefaultWatchRemote, mirror)
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "test"}, {"GIT_AUTHOR_EMAIL", "test@example.com"},
+		{"GIT_COMMITTER_NAME", "test"}, {"GIT_COMMITTER_EMAIL", "test@example.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+	return work, mirror
+}
+
+func TestWorkTreeWatcher_SyncSnapshotsWorkingTree(t *testing.T) {
+	work, mirror := newWatchedWorkTree(t)
+	w, err := NewWorkTreeWatcher([]string{work}, WatchOptions{Ignore: []string{"*.log", "build/"}})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, os.WriteFile(filepath.Join(work, "notes.txt"), []byte("draft\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(work, "debug.log"), []byte("noise\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(work, "build"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(work, "build", "out"), []byte("bin\n"), 0o644))
+	w.Sync(ctx, work)
+
+	status := w.Status()[0]
+	require.Equal(t, WatchIdle, status.State, status.Error)
+	assert.Equal(t, runGit(t, mirror, "rev-parse", DefaultWatchBranch), status.Commit)
+	assert.Equal(t, "draft", runGit(t, mirror, "show", DefaultWatchBranch+":notes.txt"))
+	assert.Equal(t, "README.md\nnotes.txt", runGit(t, mirror, "ls-tree", "--name-only", DefaultWatchBranch))
+	assert.Equal(t, "main", runGit(t, work, "rev-parse", "--abbrev-ref", "HEAD"))
+	assert.Equal(t, "?? debug.log\n?? notes.txt", runGit(t, work, "status", "--porcelain", "--untracked-files=normal", "--", "debug.log", "notes.txt"),
+		"the index of the checked-out branch is left alone")
+
+	// Nothing changed: no new commit.
+	w.Sync(ctx, work)
+	assert.Equal(t, status.Commit, w.Status()[0].Commit)
+
+	// A commit on HEAD is merged into the sync branch.
+	runGit(t, work, "add", "notes.txt")
+	runGit(t, work, "commit", "--quiet", "-m", "notes")
+	w.Sync(ctx, work)
+	tip := w.Status()[0].Commit
+	runGit(t, work, "merge-base", "--is-ancestor", "HEAD", tip)
+	runGit(t, work, "merge-base", "--is-ancestor", status.Commit, tip)
+}
+
+func TestWorkTreeWatcher_DetectsMirrorConflict(t *testing.T) {
+	work, mirror := newWatchedWorkTree(t)
+	w, err := NewWorkTreeWatcher([]string{work}, WatchOptions{})
+	require.NoError(t, err)
+
+	// Another machine pushed to the sync branch.
+	commitTo(t, work, mirror, "main", DefaultWatchBranch, "elsewhere.txt")
+	runGit(t, work, "checkout", "--quiet", "main")
+	runGit(t, work, "branch", "--quiet", "-D", DefaultWatchBranch)
+
+	require.NoError(t, os.WriteFile(filepath.Join(work, "local.txt"), []byte("local\n"), 0o644))
+	w.Sync(context.Background(), work)
+
+	status := w.Status()[0]
+	assert.Equal(t, WatchConflict, status.State)
+	assert.Contains(t, status.Error, "has commits that are not in the local gz-sync branch")
+}
+
+func TestWorkTreeWatcher_RunDebouncesChanges(t *testing.T) {
+	work, mirror := newWatchedWorkTree(t)
+	updates := make(chan WatchStatus, 100)
+	w, err := NewWorkTreeWatcher([]string{work}, WatchOptions{
+		Debounce: 100 * time.Millisecond,
+		OnUpdate: func(s WatchStatus) { updates <- s },
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	// Wait for the initial sync.
+	waitForState(t, updates, WatchIdle)
+	for i := range 5 {
+		require.NoError(t, os.WriteFile(filepath.Join(work, "burst.txt"), []byte{byte('a' + i), '\n'}, 0o644))
+		time.Sleep(10 * time.Millisecond)
+	}
+	waitForState(t, updates, WatchPending)
+	synced := waitForState(t, updates, WatchIdle)
+
+	assert.Equal(t, "e", runGit(t, mirror, "show", DefaultWatchBranch+":burst.txt"))
+	assert.Equal(t, runGit(t, mirror, "rev-parse", DefaultWatchBranch), synced.Commit)
+	assert.Zero(t, synced.Lag(time.Now()))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func waitForState(t *testing.T, updates <-chan WatchStatus, state WatchState) WatchStatus {
+	t.Helper()
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case s := <-updates:
+			if s.State == state {
+				return s
+			}
+			require.NotEqual(t, WatchError, s.State, s.Error)
+		case <-timeout:
+			t.Fatalf("timed out waiting for %s", state)
+		}
+	}
+}
+
+func TestWorkTreeWatcher_Ignored(t *testing.T) {
+	w := &WorkTreeWatcher{opts: WatchOptions{Ignore: []string{"*.log", "dist/", "docs/generated"}}}
+
+	assert.True(t, w.ignored(".git/index"))
+	assert.True(t, w.ignored("app.log"))
+	assert.True(t, w.ignored("logs/app.log"))
+	assert.True(t, w.ignored("dist/bundle.js"))
+	assert.True(t, w.ignored("web/dist"))
+	assert.True(t, w.ignored("docs/generated/api.md"))
+	assert.False(t, w.ignored("src/docs/generated/api.md"))
+	assert.False(t, w.ignored("main.go"))
+}
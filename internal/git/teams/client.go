@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package teams
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExistingTeam is a team as it exists on the platform.
+type ExistingTeam struct {
+	ID          int64  `json:"-"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description,omitempty"`
+	Privacy     string `json:"privacy,omitempty"`
+}
+
+// Backend reads and writes team membership on one platform.
+type Backend interface {
+	// Provider returns the platform name.
+	Provider() string
+	// ListTeams lists every team of an organization or group.
+	ListTeams(ctx context.Context, org string) ([]ExistingTeam, error)
+	// CreateTeam creates a team and returns it.
+	CreateTeam(ctx context.Context, org string, team Team) (ExistingTeam, error)
+	// ListMembers returns the role of each team member, keyed by lowercase
+	// login.
+	ListMembers(ctx context.Context, org string, team ExistingTeam) (map[string]string, error)
+	// SetMember adds a member or changes their role.
+	SetMember(ctx context.Context, org string, team ExistingTeam, login, role string) error
+	// RemoveMember removes a member from the team.
+	RemoveMember(ctx context.Context, org string, team ExistingTeam, login string) error
+	// ListRepositories returns the team's permission on each repository,
+	// keyed by repository name.
+	ListRepositories(ctx context.Context, org string, team ExistingTeam) (map[string]string, error)
+	// SetRepository grants the team a permission on a repository.
+	SetRepository(ctx context.Context, org string, team ExistingTeam, repo, permission string) error
+	// RemoveRepository revokes the team's access to a repository.
+	RemoveRepository(ctx context.Context, org string, team ExistingTeam, repo string) error
+	// NormalizePermission returns the permission ListRepositories reports
+	// after permission is granted.
+	NormalizePermission(permission string) string
+	// NormalizeRole returns the role ListMembers reports after a member is
+	// given role.
+	NormalizeRole(role string) string
+}
+
+// NewBackend creates the backend for provider. baseURL selects a
+// self-hosted API and may be empty.
+func NewBackend(provider, baseURL, token string) (Backend, error) {
+	switch provider {
+	case "github":
+		return newGitHubBackend(baseURL, token), nil
+	case "gitlab":
+		return newGitLabBackend(baseURL, token), nil
+	case "gitea":
+		return newGiteaBackend(baseURL, token), nil
+	default:
+		return nil, fmt.Errorf("unsupported provider for team sync: %s (supported: github, gitlab, gitea)", provider)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package teams
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gizzahub/gzh-cli/pkg/gitea"
+)
+
+// giteaBackend implements Backend against the Gitea API (v1).
+//
+// Gitea teams have no member roles, so maintainers join as plain members.
+// A team also holds one permission for all of its repositories: the team is
+// raised to the highest permission granted on any of them, and every
+// repository of the team reports that permission. Triage is granted as
+// read and maintain as admin.
+type giteaBackend struct {
+	api *gitea.OrgManager
+
+	mu    sync.Mutex
+	teams map[int64]gitea.Team
+}
+
+func newGiteaBackend(baseURL, token string) *giteaBackend {
+	return &giteaBackend{api: gitea.NewOrgManager(baseURL, token), teams: make(map[int64]gitea.Team)}
+}
+
+func (b *giteaBackend) Provider() string { return "gitea" }
+
+// NormalizeRole returns RoleMember: Gitea teams have no maintainers.
+func (b *giteaBackend) NormalizeRole(string) string { return RoleMember }
+
+// NormalizePermission maps a permission to the one Gitea reports back.
+func (b *giteaBackend) NormalizePermission(permission string) string {
+	return permissionFromGitea(giteaPermission(permission))
+}
+
+func giteaPermission(permission string) string {
+	switch permission {
+	case PermissionPush:
+		return gitea.TeamPermissionWrite
+	case PermissionMaintain, PermissionAdmin:
+		return gitea.TeamPermissionAdmin
+	default:
+		return gitea.TeamPermissionRead
+	}
+}
+
+func permissionFromGitea(permission string) string {
+	switch permission {
+	case gitea.TeamPermissionOwner, gitea.TeamPermissionAdmin:
+		return PermissionAdmin
+	case gitea.TeamPermissionWrite:
+		return PermissionPush
+	default:
+		return PermissionPull
+	}
+}
+
+// giteaRank orders team permissions from lowest to highest.
+func giteaRank(permission string) int {
+	switch permission {
+	case gitea.TeamPermissionOwner:
+		return 3
+	case gitea.TeamPermissionAdmin:
+		return 2
+	case gitea.TeamPermissionWrite:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (b *giteaBackend) remember(t gitea.Team) ExistingTeam {
+	b.mu.Lock()
+	b.teams[t.ID] = t
+	b.mu.Unlock()
+	// 팀 이름은 영숫자와 -_. 만 허용되므로 그대로 슬러그로 쓴다
+	return ExistingTeam{ID: t.ID, Name: t.Name, Slug: t.Name, Description: t.Description}
+}
+
+func (b *giteaBackend) team(id int64) gitea.Team {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.teams[id]
+}
+
+func (b *giteaBackend) ListTeams(ctx context.Context, org string) ([]ExistingTeam, error) {
+	teams, err := b.api.ListTeams(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ExistingTeam, 0, len(teams))
+	for _, t := range teams {
+		out = append(out, b.remember(t))
+	}
+	return out, nil
+}
+
+// CreateTeam creates the team under its slug, since Gitea team names cannot
+// contain spaces, with the highest permission of its repositories.
+func (b *giteaBackend) CreateTeam(ctx context.Context, org string, team Team) (ExistingTeam, error) {
+	permission := gitea.TeamPermissionRead
+	for _, p := range team.Repositories {
+		if gp := giteaPermission(p); giteaRank(gp) > giteaRank(permission) {
+			permission = gp
+		}
+	}
+	description := team.Description
+	if description == "" && team.Name != team.Slug() {
+		description = team.Name
+	}
+
+	created, err := b.api.CreateTeam(ctx, org, gitea.TeamOptions{
+		Name:        team.Slug(),
+		Description: description,
+		Permission:  permission,
+	})
+	if err != nil {
+		return ExistingTeam{}, err
+	}
+	return b.remember(*created), nil
+}
+
+func (b *giteaBackend) ListMembers(ctx context.Context, _ string, team ExistingTeam) (map[string]string, error) {
+	users, err := b.api.ListTeamMembers(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	roles := make(map[string]string, len(users))
+	for _, u := range users {
+		roles[normalizeLogin(u.Login)] = RoleMember
+	}
+	return roles, nil
+}
+
+func (b *giteaBackend) SetMember(ctx context.Context, _ string, team ExistingTeam, login, _ string) error {
+	return b.api.AddTeamMember(ctx, team.ID, login)
+}
+
+func (b *giteaBackend) RemoveMember(ctx context.Context, _ string, team ExistingTeam, login string) error {
+	return b.api.RemoveTeamMember(ctx, team.ID, login)
+}
+
+func (b *giteaBackend) ListRepositories(ctx context.Context, _ string, team ExistingTeam) (map[string]string, error) {
+	repos, err := b.api.ListTeamRepositories(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	permission := permissionFromGitea(b.team(team.ID).Permission)
+	out := make(map[string]string, len(repos))
+	for _, name := range repos {
+		out[name] = permission
+	}
+	return out, nil
+}
+
+// SetRepository adds the repository to the team and raises the team's
+// permission when the grant needs more than it holds.
+func (b *giteaBackend) SetRepository(ctx context.Context, org string, team ExistingTeam, repo, permission string) error {
+	current := b.team(team.ID)
+	if want := giteaPermission(permission); giteaRank(want) > giteaRank(current.Permission) {
+		if current.ID == 0 {
+			return fmt.Errorf("team %s is unknown; list teams first", team.Slug)
+		}
+		updated, err := b.api.EditTeam(ctx, current, gitea.TeamOptions{Permission: want})
+		if err != nil {
+			return err
+		}
+		b.remember(*updated)
+	}
+	return b.api.AddTeamRepository(ctx, team.ID, org, repo)
+}
+
+func (b *giteaBackend) RemoveRepository(ctx context.Context, org string, team ExistingTeam, repo string) error {
+	return b.api.RemoveTeamRepository(ctx, team.ID, org, repo)
+}
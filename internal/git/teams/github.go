@@ -0,0 +1,176 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package teams
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// gitHubBackend implements Backend against the GitHub REST API.
+type gitHubBackend struct {
+	client *restapi.Client
+}
+
+func newGitHubBackend(baseURL, token string) *gitHubBackend {
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return &gitHubBackend{
+		client: restapi.New("github", baseURL, func(req *http.Request) {
+			req.Header.Set("Accept", "application/vnd.github+json")
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}),
+	}
+}
+
+func (b *gitHubBackend) Provider() string { return "github" }
+
+// NormalizePermission returns permission unchanged: GitHub reports every
+// permission it grants.
+func (b *gitHubBackend) NormalizePermission(permission string) string { return permission }
+
+// NormalizeRole returns role unchanged: GitHub teams have both roles.
+func (b *gitHubBackend) NormalizeRole(role string) string { return role }
+
+type ghTeam struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	Privacy     string `json:"privacy"`
+}
+
+type ghUser struct {
+	Login string `json:"login"`
+}
+
+// nolint:tagliatelle // External API format - must match GitHub JSON output
+type ghTeamRepo struct {
+	Name        string `json:"name"`
+	RoleName    string `json:"role_name"`
+	Permissions struct {
+		Admin    bool `json:"admin"`
+		Maintain bool `json:"maintain"`
+		Push     bool `json:"push"`
+		Triage   bool `json:"triage"`
+		Pull     bool `json:"pull"`
+	} `json:"permissions"`
+}
+
+func (t ghTeam) existing() ExistingTeam {
+	return ExistingTeam{ID: t.ID, Name: t.Name, Slug: t.Slug, Description: t.Description, Privacy: t.Privacy}
+}
+
+func (b *gitHubBackend) teamPath(org string, team ExistingTeam) string {
+	return "/orgs/" + url.PathEscape(org) + "/teams/" + url.PathEscape(team.Slug)
+}
+
+func (b *gitHubBackend) ListTeams(ctx context.Context, org string) ([]ExistingTeam, error) {
+	teams, err := restapi.ListAll[ghTeam](ctx, b.client, "/orgs/"+url.PathEscape(org)+"/teams", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams of %s: %w", org, err)
+	}
+	out := make([]ExistingTeam, 0, len(teams))
+	for _, t := range teams {
+		out = append(out, t.existing())
+	}
+	return out, nil
+}
+
+func (b *gitHubBackend) CreateTeam(ctx context.Context, org string, team Team) (ExistingTeam, error) {
+	body := map[string]string{"name": team.Name}
+	if team.Description != "" {
+		body["description"] = team.Description
+	}
+	if team.Privacy != "" {
+		body["privacy"] = team.Privacy
+	}
+	var created ghTeam
+	if err := b.client.Do(ctx, http.MethodPost, "/orgs/"+url.PathEscape(org)+"/teams", body, &created); err != nil {
+		return ExistingTeam{}, fmt.Errorf("failed to create team %s: %w", team.Name, err)
+	}
+	return created.existing(), nil
+}
+
+// ListMembers lists maintainers separately from everyone else, since the
+// members endpoint does not return roles.
+func (b *gitHubBackend) ListMembers(ctx context.Context, org string, team ExistingTeam) (map[string]string, error) {
+	path := b.teamPath(org, team) + "/members"
+	all, err := restapi.ListAll[ghUser](ctx, b.client, path+"?role=all", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of %s: %w", team.Slug, err)
+	}
+	maintainers, err := restapi.ListAll[ghUser](ctx, b.client, path+"?role=maintainer", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list maintainers of %s: %w", team.Slug, err)
+	}
+
+	roles := make(map[string]string, len(all))
+	for _, u := range all {
+		roles[normalizeLogin(u.Login)] = RoleMember
+	}
+	for _, u := range maintainers {
+		roles[normalizeLogin(u.Login)] = RoleMaintainer
+	}
+	return roles, nil
+}
+
+func (b *gitHubBackend) SetMember(ctx context.Context, org string, team ExistingTeam, login, role string) error {
+	path := b.teamPath(org, team) + "/memberships/" + url.PathEscape(login)
+	return b.client.Do(ctx, http.MethodPut, path, map[string]string{"role": role}, nil)
+}
+
+func (b *gitHubBackend) RemoveMember(ctx context.Context, org string, team ExistingTeam, login string) error {
+	return b.client.Do(ctx, http.MethodDelete, b.teamPath(org, team)+"/memberships/"+url.PathEscape(login), nil, nil)
+}
+
+func (b *gitHubBackend) ListRepositories(ctx context.Context, org string, team ExistingTeam) (map[string]string, error) {
+	repos, err := restapi.ListAll[ghTeamRepo](ctx, b.client, b.teamPath(org, team)+"/repos", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories of %s: %w", team.Slug, err)
+	}
+	out := make(map[string]string, len(repos))
+	for _, r := range repos {
+		out[r.Name] = r.permission()
+	}
+	return out, nil
+}
+
+// permission returns the team's highest permission on the repository.
+func (r ghTeamRepo) permission() string {
+	if validPermission(r.RoleName) {
+		return r.RoleName
+	}
+	switch {
+	case r.Permissions.Admin:
+		return PermissionAdmin
+	case r.Permissions.Maintain:
+		return PermissionMaintain
+	case r.Permissions.Push:
+		return PermissionPush
+	case r.Permissions.Triage:
+		return PermissionTriage
+	default:
+		return PermissionPull
+	}
+}
+
+func (b *gitHubBackend) repoPath(org string, team ExistingTeam, repo string) string {
+	return b.teamPath(org, team) + "/repos/" + url.PathEscape(org) + "/" + url.PathEscape(repo)
+}
+
+func (b *gitHubBackend) SetRepository(ctx context.Context, org string, team ExistingTeam, repo, permission string) error {
+	return b.client.Do(ctx, http.MethodPut, b.repoPath(org, team, repo), map[string]string{"permission": permission}, nil)
+}
+
+func (b *gitHubBackend) RemoveRepository(ctx context.Context, org string, team ExistingTeam, repo string) error {
+	return b.client.Do(ctx, http.MethodDelete, b.repoPath(org, team, repo), nil, nil)
+}
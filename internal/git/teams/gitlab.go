@@ -0,0 +1,268 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package teams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/gizzahub/gzh-cli/internal/git/restapi"
+)
+
+// GitLab access levels.
+const (
+	glReporter   = 20
+	glDeveloper  = 30
+	glMaintainer = 40
+)
+
+// gitLabBackend implements Backend against the GitLab REST API (v4).
+//
+// GitLab has no teams; the direct subgroups of the group play their role.
+// Team maintainers become subgroup Maintainers and members Developers, and
+// repository permissions are project shares with the subgroup. GitLab has
+// fewer levels than GitHub: triage is granted as Reporter and admin as
+// Maintainer.
+type gitLabBackend struct {
+	client *restapi.Client
+
+	mu      sync.Mutex
+	userIDs map[string]int64
+}
+
+func newGitLabBackend(baseURL, token string) *gitLabBackend {
+	if baseURL == "" {
+		baseURL = "https://gitlab.com/api/v4"
+	}
+	return &gitLabBackend{
+		client: restapi.New("gitlab", baseURL, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("PRIVATE-TOKEN", token)
+			}
+		}),
+		userIDs: make(map[string]int64),
+	}
+}
+
+func (b *gitLabBackend) Provider() string { return "gitlab" }
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glGroup struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	FullPath    string `json:"full_path"`
+	Description string `json:"description"`
+	Visibility  string `json:"visibility"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glMember struct {
+	ID          int64  `json:"id"`
+	Username    string `json:"username"`
+	AccessLevel int    `json:"access_level"`
+}
+
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type glSharedProject struct {
+	ID               int64  `json:"id"`
+	Path             string `json:"path"`
+	SharedWithGroups []struct {
+		GroupID          int64 `json:"group_id"`
+		GroupAccessLevel int   `json:"group_access_level"`
+	} `json:"shared_with_groups"`
+}
+
+func (g glGroup) existing() ExistingTeam {
+	privacy := "closed"
+	if g.Visibility == "private" {
+		privacy = "secret"
+	}
+	return ExistingTeam{ID: g.ID, Name: g.Name, Slug: g.Path, Description: g.Description, Privacy: privacy}
+}
+
+func (b *gitLabBackend) ListTeams(ctx context.Context, org string) ([]ExistingTeam, error) {
+	groups, err := restapi.ListAll[glGroup](ctx, b.client, "/groups/"+url.PathEscape(org)+"/subgroups", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subgroups of %s: %w", org, err)
+	}
+	out := make([]ExistingTeam, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, g.existing())
+	}
+	return out, nil
+}
+
+func (b *gitLabBackend) CreateTeam(ctx context.Context, org string, team Team) (ExistingTeam, error) {
+	var parent glGroup
+	if err := b.client.Do(ctx, http.MethodGet, "/groups/"+url.PathEscape(org), nil, &parent); err != nil {
+		return ExistingTeam{}, fmt.Errorf("failed to look up group %s: %w", org, err)
+	}
+	visibility := "private"
+	if team.Privacy == "closed" {
+		visibility = "internal"
+	}
+	body := map[string]any{
+		"name":       team.Name,
+		"path":       team.Slug(),
+		"parent_id":  parent.ID,
+		"visibility": visibility,
+	}
+	if team.Description != "" {
+		body["description"] = team.Description
+	}
+	var created glGroup
+	if err := b.client.Do(ctx, http.MethodPost, "/groups", body, &created); err != nil {
+		return ExistingTeam{}, fmt.Errorf("failed to create subgroup %s: %w", team.Name, err)
+	}
+	return created.existing(), nil
+}
+
+func (b *gitLabBackend) groupPath(team ExistingTeam) string {
+	return "/groups/" + strconv.FormatInt(team.ID, 10)
+}
+
+// ListMembers lists the direct members of the subgroup; members inherited
+// from the parent group are not managed here.
+func (b *gitLabBackend) ListMembers(ctx context.Context, _ string, team ExistingTeam) (map[string]string, error) {
+	members, err := restapi.ListAll[glMember](ctx, b.client, b.groupPath(team)+"/members", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of %s: %w", team.Slug, err)
+	}
+	roles := make(map[string]string, len(members))
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, m := range members {
+		login := normalizeLogin(m.Username)
+		b.userIDs[login] = m.ID
+		if m.AccessLevel >= glMaintainer {
+			roles[login] = RoleMaintainer
+		} else {
+			roles[login] = RoleMember
+		}
+	}
+	return roles, nil
+}
+
+// userID resolves a username to its numeric ID.
+func (b *gitLabBackend) userID(ctx context.Context, login string) (int64, error) {
+	login = normalizeLogin(login)
+	b.mu.Lock()
+	id, ok := b.userIDs[login]
+	b.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	var users []glMember
+	if err := b.client.Do(ctx, http.MethodGet, "/users?username="+url.QueryEscape(login), nil, &users); err != nil {
+		return 0, fmt.Errorf("failed to look up user %s: %w", login, err)
+	}
+	if len(users) == 0 {
+		return 0, fmt.Errorf("user %s does not exist", login)
+	}
+	b.mu.Lock()
+	b.userIDs[login] = users[0].ID
+	b.mu.Unlock()
+	return users[0].ID, nil
+}
+
+func (b *gitLabBackend) SetMember(ctx context.Context, _ string, team ExistingTeam, login, role string) error {
+	id, err := b.userID(ctx, login)
+	if err != nil {
+		return err
+	}
+	level := glDeveloper
+	if role == RoleMaintainer {
+		level = glMaintainer
+	}
+
+	memberPath := b.groupPath(team) + "/members/" + strconv.FormatInt(id, 10)
+	err = b.client.Do(ctx, http.MethodPut, memberPath, map[string]int{"access_level": level}, nil)
+	if !errors.Is(err, restapi.ErrNotFound) {
+		return err
+	}
+	body := map[string]int64{"user_id": id, "access_level": int64(level)}
+	return b.client.Do(ctx, http.MethodPost, b.groupPath(team)+"/members", body, nil)
+}
+
+func (b *gitLabBackend) RemoveMember(ctx context.Context, _ string, team ExistingTeam, login string) error {
+	id, err := b.userID(ctx, login)
+	if err != nil {
+		return err
+	}
+	return b.client.Do(ctx, http.MethodDelete, b.groupPath(team)+"/members/"+strconv.FormatInt(id, 10), nil, nil)
+}
+
+func (b *gitLabBackend) ListRepositories(ctx context.Context, _ string, team ExistingTeam) (map[string]string, error) {
+	projects, err := restapi.ListAll[glSharedProject](ctx, b.client, b.groupPath(team)+"/projects/shared", "per_page")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects shared with %s: %w", team.Slug, err)
+	}
+	out := make(map[string]string, len(projects))
+	for _, p := range projects {
+		for _, s := range p.SharedWithGroups {
+			if s.GroupID == team.ID {
+				out[p.Path] = permissionFromLevel(s.GroupAccessLevel)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (b *gitLabBackend) projectPath(org, repo string) string {
+	return "/projects/" + url.PathEscape(org+"/"+repo)
+}
+
+// SetRepository shares the project with the subgroup. GitLab cannot change
+// the access level of an existing share, so any existing share is removed
+// first.
+func (b *gitLabBackend) SetRepository(ctx context.Context, org string, team ExistingTeam, repo, permission string) error {
+	if err := b.RemoveRepository(ctx, org, team, repo); err != nil && !errors.Is(err, restapi.ErrNotFound) {
+		return err
+	}
+	body := map[string]int64{"group_id": team.ID, "group_access": int64(levelFromPermission(permission))}
+	return b.client.Do(ctx, http.MethodPost, b.projectPath(org, repo)+"/share", body, nil)
+}
+
+func (b *gitLabBackend) RemoveRepository(ctx context.Context, org string, team ExistingTeam, repo string) error {
+	return b.client.Do(ctx, http.MethodDelete, b.projectPath(org, repo)+"/share/"+strconv.FormatInt(team.ID, 10), nil, nil)
+}
+
+// NormalizeRole returns role unchanged: maintainers and members map to
+// distinct access levels.
+func (b *gitLabBackend) NormalizeRole(role string) string { return role }
+
+// NormalizePermission maps a permission to the one GitLab reports back for
+// it, so that triage and admin grants do not show as drift on every run.
+func (b *gitLabBackend) NormalizePermission(permission string) string {
+	return permissionFromLevel(levelFromPermission(permission))
+}
+
+func levelFromPermission(permission string) int {
+	switch permission {
+	case PermissionPush:
+		return glDeveloper
+	case PermissionMaintain, PermissionAdmin:
+		return glMaintainer
+	default:
+		return glReporter
+	}
+}
+
+func permissionFromLevel(level int) string {
+	switch {
+	case level >= glMaintainer:
+		return PermissionMaintain
+	case level >= glDeveloper:
+		return PermissionPush
+	default:
+		return PermissionPull
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package teams
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// Report is the outcome of a sync run.
+type Report struct {
+	Provider    string    `json:"provider"`
+	Org         string    `json:"org"`
+	DryRun      bool      `json:"dryRun"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	Results     []Result  `json:"results"`
+}
+
+// Result is the outcome for one team.
+type Result struct {
+	Team    string   `json:"team"`
+	Status  string   `json:"status"`
+	Changes []Change `json:"changes,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// Change is one membership or permission change. Target is the login for
+// member changes, the repository name for repository changes and the team
+// name for ActionCreateTeam.
+type Change struct {
+	Action  string `json:"action"`
+	Target  string `json:"target"`
+	Current string `json:"current,omitempty"`
+	Desired string `json:"desired,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// String describes the change in one line.
+func (c Change) String() string {
+	switch c.Action {
+	case ActionCreateTeam:
+		return "create team " + c.Target
+	case ActionAddMember:
+		return fmt.Sprintf("add %s as %s", c.Target, c.Desired)
+	case ActionUpdateMember:
+		return fmt.Sprintf("change %s from %s to %s", c.Target, c.Current, c.Desired)
+	case ActionRemoveMember:
+		return fmt.Sprintf("remove %s (%s)", c.Target, c.Current)
+	case ActionGrantRepository:
+		return fmt.Sprintf("grant %s on %s", c.Desired, c.Target)
+	case ActionUpdateRepository:
+		return fmt.Sprintf("change %s from %s to %s", c.Target, c.Current, c.Desired)
+	case ActionRevokeRepository:
+		return fmt.Sprintf("revoke %s on %s", c.Current, c.Target)
+	default:
+		return c.Action + " " + c.Target
+	}
+}
+
+// Counts returns the number of results per status.
+func (r *Report) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, res := range r.Results {
+		counts[res.Status]++
+	}
+	return counts
+}
+
+// HasDrift reports whether any team differed from the spec.
+func (r *Report) HasDrift() bool {
+	for _, res := range r.Results {
+		if len(res.Changes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteJSON writes the report to path.
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// PrintDiff writes each team that changed or failed with its changes.
+func (r *Report) PrintDiff(w io.Writer) {
+	for _, res := range r.Results {
+		if res.Status == StatusInSync {
+			continue
+		}
+		fmt.Fprintf(w, "%s %s (%s)\n", statusIcon(res.Status), res.Team, res.Status)
+		for _, c := range res.Changes {
+			if c.Error != "" {
+				fmt.Fprintf(w, "    ✗ %s: %s\n", c, c.Error)
+			} else {
+				fmt.Fprintf(w, "    %s\n", c)
+			}
+		}
+		if res.Error != "" {
+			fmt.Fprintf(w, "    %s\n", res.Error)
+		}
+	}
+}
+
+// Plan returns the changes of a dry run as planned team, membership and
+// repository permission changes.
+func (r *Report) Plan() *provider.Plan {
+	plan := provider.NewPlan()
+	for _, res := range r.Results {
+		if res.Status != StatusDrift {
+			continue
+		}
+		for _, c := range res.Changes {
+			plan.Record(c.planned(res.Team))
+		}
+	}
+	return plan
+}
+
+func (c Change) planned(team string) provider.PlannedChange {
+	pc := provider.PlannedChange{Target: team + "/" + c.Target}
+	switch c.Action {
+	case ActionCreateTeam:
+		pc.Action, pc.Resource, pc.Target = provider.ChangeCreate, provider.ResourceTeam, team
+		pc.Attributes = []provider.AttributeChange{{Name: "name", After: c.Target}}
+		return pc
+	case ActionAddMember, ActionUpdateMember, ActionRemoveMember:
+		pc.Resource = provider.ResourceTeamMember
+		pc.Attributes = []provider.AttributeChange{{Name: "role", After: c.Desired}}
+	default:
+		pc.Resource = provider.ResourceTeamRepository
+		pc.Attributes = []provider.AttributeChange{{Name: "permission", After: c.Desired}}
+	}
+	switch c.Action {
+	case ActionAddMember, ActionGrantRepository:
+		pc.Action = provider.ChangeCreate
+	case ActionRemoveMember, ActionRevokeRepository:
+		pc.Action, pc.Attributes = provider.ChangeDelete, nil
+	default:
+		pc.Action = provider.ChangeUpdate
+		pc.Attributes[0].Before = c.Current
+	}
+	return pc
+}
+
+// PrintPlan writes the changes of a dry run as a plan, followed by the
+// teams that could not be checked.
+func (r *Report) PrintPlan(w io.Writer) {
+	r.Plan().Render(w)
+	for _, res := range r.Results {
+		if res.Status == StatusFailed {
+			fmt.Fprintf(w, "%s %s (%s)\n    %s\n", statusIcon(res.Status), res.Team, res.Status, res.Error)
+		}
+	}
+}
+
+// PrintSummary writes the per-status totals.
+func (r *Report) PrintSummary(w io.Writer) {
+	title := "Team sync summary"
+	if r.DryRun {
+		title = "Team sync plan (dry run)"
+	}
+	fmt.Fprintf(w, "\n📋 %s: %s:%s\n", title, r.Provider, r.Org)
+
+	counts := r.Counts()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tTEAMS")
+	for _, s := range []string{StatusInSync, StatusDrift, StatusApplied, StatusFailed} {
+		if counts[s] > 0 {
+			fmt.Fprintf(tw, "%s\t%d\n", s, counts[s])
+		}
+	}
+	_ = tw.Flush()
+}
+
+func statusIcon(status string) string {
+	switch status {
+	case StatusDrift:
+		return "~"
+	case StatusApplied:
+		return "✓"
+	case StatusFailed:
+		return "✗"
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,456 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package teams reconciles the teams of a GitHub organization or GitLab group
+// with a declarative membership source: a YAML spec, a CSV roster or an LDAP
+// (LDIF) export.
+package teams
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Member roles within a team.
+const (
+	RoleMember     = "member"
+	RoleMaintainer = "maintainer"
+)
+
+// Repository permissions, in GitHub terms. GitLab maps them to access levels.
+const (
+	PermissionPull     = "pull"
+	PermissionTriage   = "triage"
+	PermissionPush     = "push"
+	PermissionMaintain = "maintain"
+	PermissionAdmin    = "admin"
+)
+
+// Source formats accepted by LoadSpec.
+const (
+	FormatYAML = "yaml"
+	FormatCSV  = "csv"
+	FormatLDIF = "ldif"
+)
+
+// Spec is the desired team layout of an organization.
+//
+//	prune_members: true
+//	teams:
+//	  - name: platform
+//	    description: Platform engineering
+//	    privacy: closed
+//	    maintainers: [alice]
+//	    members: [bob, carol]
+//	    repositories:
+//	      infra: admin
+//	      api: push
+//
+// Teams present in the organization but missing from the spec are never
+// deleted. Members and repository grants not in the spec are removed only
+// when PruneMembers and PruneRepositories are set.
+type Spec struct {
+	PruneMembers      bool   `yaml:"prune_members" json:"pruneMembers,omitempty"`
+	PruneRepositories bool   `yaml:"prune_repositories" json:"pruneRepositories,omitempty"`
+	Teams             []Team `yaml:"teams" json:"teams"`
+}
+
+// Team is the desired state of one team.
+type Team struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description,omitempty"`
+	// Privacy is "closed" (visible to the organization) or "secret". Empty
+	// keeps the provider default.
+	Privacy      string            `yaml:"privacy" json:"privacy,omitempty"`
+	Maintainers  []string          `yaml:"maintainers" json:"maintainers,omitempty"`
+	Members      []string          `yaml:"members" json:"members,omitempty"`
+	Repositories map[string]string `yaml:"repositories" json:"repositories,omitempty"`
+}
+
+// Roles returns the desired role of each member, keyed by lowercase login.
+// A login listed both as maintainer and member is a maintainer.
+func (t Team) Roles() map[string]string {
+	roles := make(map[string]string, len(t.Maintainers)+len(t.Members))
+	for _, m := range t.Members {
+		roles[normalizeLogin(m)] = RoleMember
+	}
+	for _, m := range t.Maintainers {
+		roles[normalizeLogin(m)] = RoleMaintainer
+	}
+	return roles
+}
+
+// Slug returns the URL-safe team name GitHub and GitLab derive from Name.
+func (t Team) Slug() string {
+	return Slugify(t.Name)
+}
+
+// Slugify lowercases name and replaces every run of characters other than
+// letters, digits, '-' and '_' with a single '-'.
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+func normalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(login), "@"))
+}
+
+// LoadSpec reads a membership source. format is one of FormatYAML,
+// FormatCSV or FormatLDIF; empty picks it from the file extension.
+func LoadSpec(path, format string) (*Spec, error) {
+	if format == "" {
+		format = formatFromExtension(path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read team spec %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var spec *Spec
+	switch format {
+	case FormatYAML:
+		spec, err = ParseYAML(f)
+	case FormatCSV:
+		spec, err = ParseCSV(f)
+	case FormatLDIF:
+		spec, err = ParseLDIF(f)
+	default:
+		return nil, fmt.Errorf("unsupported team spec format: %s (supported: yaml, csv, ldif)", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func formatFromExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV
+	case ".ldif", ".ldf":
+		return FormatLDIF
+	default:
+		return FormatYAML
+	}
+}
+
+// ParseYAML parses a YAML spec. Unknown keys are rejected so that a
+// misspelled setting is not silently ignored.
+func ParseYAML(r io.Reader) (*Spec, error) {
+	var spec Spec
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("invalid team spec: %w", err)
+	}
+	return &spec, nil
+}
+
+// ParseCSV parses a roster with a header row and the columns team, user and
+// optionally role and repository/permission:
+//
+//	team,user,role,repository,permission
+//	platform,alice,maintainer,infra,admin
+//	platform,bob,member,,
+//
+// A row may leave user empty to only grant a repository.
+func ParseCSV(r io.Reader) (*Spec, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid team roster: missing header: %w", err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := cols["team"]; !ok {
+		return nil, errors.New("invalid team roster: no team column")
+	}
+	if _, ok := cols["user"]; !ok {
+		return nil, errors.New("invalid team roster: no user column")
+	}
+	field := func(rec []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	b := newSpecBuilder()
+	for line := 2; ; line++ {
+		rec, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid team roster: %w", err)
+		}
+		team := field(rec, "team")
+		if team == "" {
+			return nil, fmt.Errorf("invalid team roster: line %d has no team", line)
+		}
+		t := b.team(team)
+		if user := field(rec, "user"); user != "" {
+			switch role := strings.ToLower(field(rec, "role")); role {
+			case "", RoleMember:
+				t.Members = append(t.Members, user)
+			case RoleMaintainer:
+				t.Maintainers = append(t.Maintainers, user)
+			default:
+				return nil, fmt.Errorf("invalid team roster: line %d has unknown role %q", line, role)
+			}
+		}
+		if repo := field(rec, "repository"); repo != "" {
+			perm := field(rec, "permission")
+			if perm == "" {
+				perm = PermissionPull
+			}
+			if t.Repositories == nil {
+				t.Repositories = make(map[string]string)
+			}
+			t.Repositories[repo] = strings.ToLower(perm)
+		}
+	}
+	return b.spec(), nil
+}
+
+// ParseLDIF parses an LDAP export of group entries. Each entry with a cn
+// becomes a team; its member, uniqueMember and memberUid values become team
+// members, using the uid (or first RDN value) of DN-valued attributes as the
+// login. Entries without members are skipped.
+//
+//	dn: cn=platform,ou=groups,dc=example,dc=com
+//	cn: platform
+//	description: Platform engineering
+//	member: uid=alice,ou=people,dc=example,dc=com
+//	memberUid: bob
+func ParseLDIF(r io.Reader) (*Spec, error) {
+	b := newSpecBuilder()
+	var entry map[string][]string
+	flush := func() {
+		if entry == nil {
+			return
+		}
+		defer func() { entry = nil }()
+		cn := first(entry["cn"])
+		if cn == "" {
+			return
+		}
+		var members []string
+		for _, attr := range []string{"member", "uniquemember", "memberuid"} {
+			for _, v := range entry[attr] {
+				if login := loginFromDN(v); login != "" {
+					members = append(members, login)
+				}
+			}
+		}
+		if len(members) == 0 {
+			return
+		}
+		t := b.team(cn)
+		if d := first(entry["description"]); d != "" {
+			t.Description = d
+		}
+		t.Members = append(t.Members, members...)
+	}
+
+	lines, err := unfoldLDIF(r)
+	if err != nil {
+		return nil, err
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid LDIF: line %d is not an attribute", i+1)
+		}
+		switch {
+		case strings.HasPrefix(value, ":"):
+			decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value[1:]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid LDIF: line %d: %w", i+1, err)
+			}
+			value = string(decoded)
+		case strings.HasPrefix(value, "<"):
+			// URL references are not followed.
+			continue
+		}
+		if entry == nil {
+			entry = make(map[string][]string)
+		}
+		key := strings.ToLower(strings.TrimSpace(name))
+		entry[key] = append(entry[key], strings.TrimSpace(value))
+	}
+	flush()
+	return b.spec(), nil
+}
+
+// unfoldLDIF joins continuation lines, which start with a single space.
+func unfoldLDIF(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, " ") && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read LDIF: %w", err)
+	}
+	return lines, nil
+}
+
+// loginFromDN returns the uid of a DN, the value of its first RDN when it
+// has no uid, or v itself when it is not a DN.
+func loginFromDN(v string) string {
+	if !strings.Contains(v, "=") {
+		return strings.TrimSpace(v)
+	}
+	var firstValue string
+	for _, rdn := range strings.Split(v, ",") {
+		key, value, ok := strings.Cut(rdn, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if key == "uid" {
+			return value
+		}
+		if firstValue == "" {
+			firstValue = value
+		}
+	}
+	return firstValue
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// specBuilder collects teams in first-seen order.
+type specBuilder struct {
+	order []string
+	teams map[string]*Team
+}
+
+func newSpecBuilder() *specBuilder {
+	return &specBuilder{teams: make(map[string]*Team)}
+}
+
+func (b *specBuilder) team(name string) *Team {
+	slug := Slugify(name)
+	if t, ok := b.teams[slug]; ok {
+		return t
+	}
+	t := &Team{Name: name}
+	b.teams[slug] = t
+	b.order = append(b.order, slug)
+	return t
+}
+
+func (b *specBuilder) spec() *Spec {
+	spec := &Spec{Teams: make([]Team, 0, len(b.order))}
+	for _, slug := range b.order {
+		spec.Teams = append(spec.Teams, *b.teams[slug])
+	}
+	return spec
+}
+
+// Validate checks the spec for duplicate teams, unknown permissions and
+// logins listed twice with different roles.
+func (s *Spec) Validate() error {
+	if len(s.Teams) == 0 {
+		return errors.New("invalid team spec: no teams")
+	}
+	seen := make(map[string]bool)
+	for i, t := range s.Teams {
+		slug := t.Slug()
+		if slug == "" {
+			return fmt.Errorf("invalid team spec: teams[%d] has no name", i)
+		}
+		if seen[slug] {
+			return fmt.Errorf("invalid team spec: team %s listed more than once", t.Name)
+		}
+		seen[slug] = true
+
+		switch t.Privacy {
+		case "", "closed", "secret":
+		default:
+			return fmt.Errorf("invalid team spec: team %s has unknown privacy %q (closed, secret)", t.Name, t.Privacy)
+		}
+		for repo, perm := range t.Repositories {
+			if !validPermission(perm) {
+				return fmt.Errorf("invalid team spec: team %s grants unknown permission %q on %s", t.Name, perm, repo)
+			}
+		}
+		for _, m := range append(append([]string{}, t.Maintainers...), t.Members...) {
+			if normalizeLogin(m) == "" {
+				return fmt.Errorf("invalid team spec: team %s lists an empty login", t.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func validPermission(perm string) bool {
+	switch perm {
+	case PermissionPull, PermissionTriage, PermissionPush, PermissionMaintain, PermissionAdmin:
+		return true
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package teams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+)
+
+// Result statuses.
+const (
+	StatusInSync  = "in_sync"
+	StatusDrift   = "drift"
+	StatusApplied = "applied"
+	StatusFailed  = "failed"
+)
+
+// Change actions, also used as audit event actions with a "team." prefix.
+const (
+	ActionCreateTeam       = "create_team"
+	ActionAddMember        = "add_member"
+	ActionUpdateMember     = "update_member"
+	ActionRemoveMember     = "remove_member"
+	ActionGrantRepository  = "grant_repository"
+	ActionUpdateRepository = "update_repository"
+	ActionRevokeRepository = "revoke_repository"
+)
+
+// DefaultAuditLogPath returns the local team sync audit log.
+func DefaultAuditLogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "gzh-manager", "teams", "audit.jsonl")
+}
+
+// Options control a sync run.
+type Options struct {
+	// DryRun only reports the changes; nothing is modified.
+	DryRun bool
+	// Teams narrows the spec to teams whose name or slug matches any of
+	// these patterns.
+	Teams []string
+	// Concurrency is the number of teams processed at once.
+	Concurrency int
+	// Recorder receives an audit event per applied or failed change; nil
+	// discards them.
+	Recorder audit.Recorder
+	// Actor is recorded as the audit event actor.
+	Actor string
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+}
+
+// Syncer reconciles an organization's teams with a spec.
+type Syncer struct {
+	backend Backend
+	spec    *Spec
+	opts    Options
+
+	auditMu  sync.Mutex
+	auditErr error
+}
+
+// NewSyncer creates a syncer.
+func NewSyncer(backend Backend, spec *Spec, opts Options) *Syncer {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 4
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Syncer{backend: backend, spec: spec, opts: opts}
+}
+
+// Run reconciles every selected team of org. Failures on individual teams
+// are recorded in the report rather than aborting the run; the returned
+// error reports a cancelled context or audit events that could not be
+// recorded.
+func (s *Syncer) Run(ctx context.Context, org string) (*Report, error) {
+	existing, err := s.backend.ListTeams(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	bySlug := make(map[string]ExistingTeam, len(existing))
+	for _, t := range existing {
+		bySlug[Slugify(t.Slug)] = t
+	}
+
+	report := &Report{
+		Provider:    s.backend.Provider(),
+		Org:         org,
+		DryRun:      s.opts.DryRun,
+		GeneratedAt: s.opts.Now(),
+	}
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		work = make(chan Team)
+	)
+	for i := 0; i < s.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for team := range work {
+				current, exists := bySlug[team.Slug()]
+				result := s.syncTeam(ctx, org, team, current, exists)
+				mu.Lock()
+				report.Results = append(report.Results, result)
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, t := range s.spec.Teams {
+		if ctx.Err() != nil {
+			break
+		}
+		if s.selected(t) {
+			work <- t
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	sort.Slice(report.Results, func(i, j int) bool {
+		return report.Results[i].Team < report.Results[j].Team
+	})
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	return report, s.auditErr
+}
+
+func (s *Syncer) selected(team Team) bool {
+	if len(s.opts.Teams) == 0 {
+		return true
+	}
+	for _, pattern := range s.opts.Teams {
+		if ok, _ := path.Match(pattern, team.Name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, team.Slug()); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// syncTeam plans and, unless DryRun is set, applies the changes for one
+// team. Changes are applied in plan order and a failed change does not stop
+// the ones after it.
+func (s *Syncer) syncTeam(ctx context.Context, org string, team Team, current ExistingTeam, exists bool) Result {
+	result := Result{Team: team.Slug()}
+	fail := func(err error) Result {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	members := map[string]string{}
+	repos := map[string]string{}
+	if !exists {
+		create := Change{Action: ActionCreateTeam, Target: team.Name}
+		if s.opts.DryRun {
+			result.Changes = append(result.Changes, create)
+		} else {
+			created, err := s.backend.CreateTeam(ctx, org, team)
+			s.record(org, team, create, err)
+			if err != nil {
+				create.Error = err.Error()
+				result.Changes = append(result.Changes, create)
+				return fail(fmt.Errorf("failed to create team: %w", err))
+			}
+			result.Changes = append(result.Changes, create)
+			current, exists = created, true
+		}
+	}
+	// A team created above may already have members, such as its creator
+	// on GitHub, so it is listed like any other.
+	if exists {
+		var err error
+		if members, err = s.backend.ListMembers(ctx, org, current); err != nil {
+			return fail(err)
+		}
+		if repos, err = s.backend.ListRepositories(ctx, org, current); err != nil {
+			return fail(err)
+		}
+	}
+
+	planned := s.plan(team, members, repos)
+	if s.opts.DryRun {
+		result.Changes = append(result.Changes, planned...)
+		result.Status = StatusInSync
+		if len(result.Changes) > 0 {
+			result.Status = StatusDrift
+		}
+		return result
+	}
+
+	var failed int
+	for _, c := range planned {
+		err := s.apply(ctx, org, current, c)
+		s.record(org, team, c, err)
+		if err != nil {
+			c.Error = err.Error()
+			failed++
+		}
+		result.Changes = append(result.Changes, c)
+	}
+	switch {
+	case failed > 0:
+		result.Status = StatusFailed
+		result.Error = fmt.Sprintf("%d of %d change(s) failed", failed, len(planned))
+	case len(result.Changes) > 0:
+		result.Status = StatusApplied
+	default:
+		result.Status = StatusInSync
+	}
+	return result
+}
+
+// plan lists the changes that bring members and repos in line with team:
+// additions first, then removals, so that a team is never left emptier than
+// necessary when a run is interrupted.
+func (s *Syncer) plan(team Team, members, repos map[string]string) []Change {
+	var changes, removals []Change
+
+	desired := team.Roles()
+	for _, login := range sortedKeys(desired) {
+		role := desired[login]
+		switch cur, ok := members[login]; {
+		case !ok:
+			changes = append(changes, Change{Action: ActionAddMember, Target: login, Desired: role})
+		case cur != s.backend.NormalizeRole(role):
+			changes = append(changes, Change{Action: ActionUpdateMember, Target: login, Current: cur, Desired: role})
+		}
+	}
+	if s.spec.PruneMembers {
+		for _, login := range sortedKeys(members) {
+			if _, ok := desired[login]; !ok {
+				removals = append(removals, Change{Action: ActionRemoveMember, Target: login, Current: members[login]})
+			}
+		}
+	}
+
+	for _, repo := range sortedKeys(team.Repositories) {
+		perm := team.Repositories[repo]
+		switch cur, ok := repos[repo]; {
+		case !ok:
+			changes = append(changes, Change{Action: ActionGrantRepository, Target: repo, Desired: perm})
+		case cur != s.backend.NormalizePermission(perm):
+			changes = append(changes, Change{Action: ActionUpdateRepository, Target: repo, Current: cur, Desired: perm})
+		}
+	}
+	if s.spec.PruneRepositories {
+		for _, repo := range sortedKeys(repos) {
+			if _, ok := team.Repositories[repo]; !ok {
+				removals = append(removals, Change{Action: ActionRevokeRepository, Target: repo, Current: repos[repo]})
+			}
+		}
+	}
+	return append(changes, removals...)
+}
+
+func (s *Syncer) apply(ctx context.Context, org string, team ExistingTeam, c Change) error {
+	switch c.Action {
+	case ActionAddMember, ActionUpdateMember:
+		return s.backend.SetMember(ctx, org, team, c.Target, c.Desired)
+	case ActionRemoveMember:
+		return s.backend.RemoveMember(ctx, org, team, c.Target)
+	case ActionGrantRepository, ActionUpdateRepository:
+		return s.backend.SetRepository(ctx, org, team, c.Target, c.Desired)
+	case ActionRevokeRepository:
+		return s.backend.RemoveRepository(ctx, org, team, c.Target)
+	default:
+		return fmt.Errorf("unknown change action %q", c.Action)
+	}
+}
+
+func (s *Syncer) record(org string, team Team, c Change, err error) {
+	if s.opts.Recorder == nil {
+		return
+	}
+	event := audit.Event{
+		ID:        audit.NewEventID(),
+		Timestamp: s.opts.Now().UTC(),
+		Severity:  audit.SeverityInfo,
+		Action:    "team." + c.Action,
+		Category:  "teams",
+		Actor:     s.opts.Actor,
+		Source:    "gz git org sync-teams",
+		Resource:  s.backend.Provider() + ":" + org + "/" + team.Slug(),
+		Outcome:   "success",
+		Message:   c.String(),
+		Metadata: map[string]any{
+			"target":  c.Target,
+			"current": c.Current,
+			"desired": c.Desired,
+		},
+	}
+	if err != nil {
+		event.Severity = audit.SeverityError
+		event.Outcome = "failure"
+		event.ErrMessage = err.Error()
+	}
+	if err := s.opts.Recorder.Record(event); err != nil {
+		s.auditMu.Lock()
+		s.auditErr = errors.Join(s.auditErr, err)
+		s.auditMu.Unlock()
+	}
+}
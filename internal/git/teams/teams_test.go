@@ -0,0 +1,319 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/testutil/mocks"
+)
+
+const testSpec = `
+prune_members: true
+teams:
+  - name: Platform Team
+    maintainers: [alice]
+    members: [Bob, carol]
+    repositories:
+      infra: admin
+      api: push
+  - name: docs
+    members: [dave]
+`
+
+func TestParseYAML(t *testing.T) {
+	spec, err := ParseYAML(strings.NewReader(testSpec))
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate())
+	require.Len(t, spec.Teams, 2)
+	assert.Equal(t, "platform-team", spec.Teams[0].Slug())
+	assert.Equal(t, map[string]string{"alice": RoleMaintainer, "bob": RoleMember, "carol": RoleMember}, spec.Teams[0].Roles())
+
+	_, err = ParseYAML(strings.NewReader("teams:\n  - name: a\n    member: [x]\n"))
+	assert.Error(t, err, "misspelled keys must be rejected")
+
+	spec, err = ParseYAML(strings.NewReader("teams:\n  - name: a\n  - name: A\n"))
+	require.NoError(t, err)
+	assert.Error(t, spec.Validate(), "teams with the same slug must be rejected")
+
+	spec, err = ParseYAML(strings.NewReader("teams:\n  - name: a\n    repositories: {x: write}\n"))
+	require.NoError(t, err)
+	assert.Error(t, spec.Validate())
+}
+
+func TestParseCSV(t *testing.T) {
+	roster := `team,user,role,repository,permission
+# comment
+platform,alice,maintainer,infra,admin
+platform,bob,,,
+platform,,,api,
+docs,dave,member,,
+`
+	spec, err := ParseCSV(strings.NewReader(roster))
+	require.NoError(t, err)
+	require.Len(t, spec.Teams, 2)
+	assert.Equal(t, []string{"alice"}, spec.Teams[0].Maintainers)
+	assert.Equal(t, []string{"bob"}, spec.Teams[0].Members)
+	assert.Equal(t, map[string]string{"infra": "admin", "api": "pull"}, spec.Teams[0].Repositories)
+	assert.Equal(t, "docs", spec.Teams[1].Name)
+
+	_, err = ParseCSV(strings.NewReader("team,user,role\nplatform,alice,owner\n"))
+	assert.Error(t, err)
+	_, err = ParseCSV(strings.NewReader("group,user\nplatform,alice\n"))
+	assert.Error(t, err)
+}
+
+func TestParseLDIF(t *testing.T) {
+	export := `# groups
+dn: cn=platform,ou=groups,dc=example,dc=com
+cn: platform
+description: Platform
+  engineering
+member: uid=alice,ou=people,dc=example,dc=com
+uniqueMember: cn=bob,ou=people,dc=example,dc=com
+memberUid: carol
+
+dn: cn=empty,ou=groups,dc=example,dc=com
+cn: empty
+
+dn: cn=docs,ou=groups,dc=example,dc=com
+cn:: ZG9jcw==
+memberUid: dave
+`
+	spec, err := ParseLDIF(strings.NewReader(export))
+	require.NoError(t, err)
+	require.Len(t, spec.Teams, 2)
+	assert.Equal(t, "platform", spec.Teams[0].Name)
+	assert.Equal(t, "Platform engineering", spec.Teams[0].Description)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, spec.Teams[0].Members)
+	assert.Equal(t, "docs", spec.Teams[1].Name)
+}
+
+// fakeBackend records calls against in-memory teams.
+type fakeBackend struct {
+	mocks.CallLog
+	teams   []ExistingTeam
+	members map[string]map[string]string
+	repos   map[string]map[string]string
+}
+
+func (f *fakeBackend) Provider() string { return "github" }
+
+func (f *fakeBackend) NormalizePermission(p string) string { return p }
+
+func (f *fakeBackend) NormalizeRole(r string) string { return r }
+
+func (f *fakeBackend) ListTeams(context.Context, string) ([]ExistingTeam, error) {
+	return f.teams, nil
+}
+
+func (f *fakeBackend) CreateTeam(_ context.Context, _ string, team Team) (ExistingTeam, error) {
+	return ExistingTeam{Name: team.Name, Slug: team.Slug()}, f.Call("create %s", team.Slug())
+}
+
+func (f *fakeBackend) ListMembers(_ context.Context, _ string, team ExistingTeam) (map[string]string, error) {
+	return f.members[team.Slug], nil
+}
+
+func (f *fakeBackend) SetMember(_ context.Context, _ string, team ExistingTeam, login, role string) error {
+	return f.Call("set %s %s %s", team.Slug, login, role)
+}
+
+func (f *fakeBackend) RemoveMember(_ context.Context, _ string, team ExistingTeam, login string) error {
+	return f.Call("remove %s %s", team.Slug, login)
+}
+
+func (f *fakeBackend) ListRepositories(_ context.Context, _ string, team ExistingTeam) (map[string]string, error) {
+	return f.repos[team.Slug], nil
+}
+
+func (f *fakeBackend) SetRepository(_ context.Context, _ string, team ExistingTeam, repo, perm string) error {
+	return f.Call("grant %s %s %s", team.Slug, repo, perm)
+}
+
+func (f *fakeBackend) RemoveRepository(_ context.Context, _ string, team ExistingTeam, repo string) error {
+	return f.Call("revoke %s %s", team.Slug, repo)
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{
+		teams:   []ExistingTeam{{Name: "Platform Team", Slug: "platform-team"}},
+		members: map[string]map[string]string{"platform-team": {"alice": RoleMember, "bob": RoleMember, "eve": RoleMember}},
+		repos:   map[string]map[string]string{"platform-team": {"infra": PermissionAdmin, "old": PermissionPull}},
+	}
+}
+
+func TestSyncerDryRun(t *testing.T) {
+	spec, err := ParseYAML(strings.NewReader(testSpec))
+	require.NoError(t, err)
+	backend := newFakeBackend()
+
+	report, err := NewSyncer(backend, spec, Options{DryRun: true}).Run(context.Background(), "acme")
+	require.NoError(t, err)
+	assert.Empty(t, backend.Calls(), "a dry run must not change anything")
+	require.Len(t, report.Results, 2)
+
+	platform := report.Results[1]
+	assert.Equal(t, "platform-team", platform.Team)
+	assert.Equal(t, StatusDrift, platform.Status)
+	var got []string
+	for _, c := range platform.Changes {
+		got = append(got, c.String())
+	}
+	assert.Equal(t, []string{
+		"change alice from member to maintainer",
+		"add carol as member",
+		"grant push on api",
+		"remove eve (member)",
+	}, got, "removals come last; old is kept without prune_repositories")
+
+	docs := report.Results[0]
+	assert.Equal(t, StatusDrift, docs.Status)
+	assert.Equal(t, ActionCreateTeam, docs.Changes[0].Action)
+
+	var out bytes.Buffer
+	report.PrintPlan(&out)
+	assert.Contains(t, out.String(), `+ team "docs"`)
+	assert.Contains(t, out.String(), `~ team_member "platform-team/alice"`)
+	assert.Contains(t, out.String(), `- team_member "platform-team/eve"`)
+	assert.Contains(t, out.String(), "Plan: 4 to create, 1 to update, 1 to delete.")
+}
+
+func TestSyncerApply(t *testing.T) {
+	spec, err := ParseYAML(strings.NewReader(testSpec))
+	require.NoError(t, err)
+	spec.PruneRepositories = true
+	backend := newFakeBackend()
+	backend.FailOn = "grant platform-team api"
+	recorder := &mocks.AuditRecorder{}
+
+	report, err := NewSyncer(backend, spec, Options{
+		Recorder:    recorder,
+		Actor:       "ci",
+		Concurrency: 1,
+		Now:         func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) },
+	}).Run(context.Background(), "acme")
+	require.NoError(t, err)
+
+	assert.Contains(t, backend.Calls(), "create docs")
+	assert.Contains(t, backend.Calls(), "set docs dave member")
+	assert.Contains(t, backend.Calls(), "set platform-team alice maintainer")
+	assert.Contains(t, backend.Calls(), "remove platform-team eve")
+	assert.Contains(t, backend.Calls(), "revoke platform-team old")
+
+	counts := report.Counts()
+	assert.Equal(t, 1, counts[StatusApplied])
+	assert.Equal(t, 1, counts[StatusFailed], "a failed change fails its team but not the others")
+
+	require.Len(t, recorder.Events(), 7)
+	var failures int
+	for _, e := range recorder.Events() {
+		assert.Equal(t, "ci", e.Actor)
+		assert.True(t, strings.HasPrefix(e.Action, "team."))
+		if e.Outcome == "failure" {
+			failures++
+			assert.Equal(t, "github:acme/platform-team", e.Resource)
+		}
+	}
+	assert.Equal(t, 1, failures)
+}
+
+func TestSyncerTeamFilter(t *testing.T) {
+	spec, err := ParseYAML(strings.NewReader(testSpec))
+	require.NoError(t, err)
+
+	report, err := NewSyncer(newFakeBackend(), spec, Options{DryRun: true, Teams: []string{"doc*"}}).Run(context.Background(), "acme")
+	require.NoError(t, err)
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, "docs", report.Results[0].Team)
+}
+
+func TestGitHubListMembersFollowsLinkHeader(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		switch {
+		case r.URL.Path == "/orgs/acme/teams/platform/members" && r.URL.Query().Get("role") == "maintainer":
+			fmt.Fprint(w, `[{"login":"Alice"}]`)
+		case r.URL.Path == "/orgs/acme/teams/platform/members":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/next-page?cursor=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"login":"Alice"}]`)
+		case r.URL.Path == "/next-page":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/teams/platform/members?page=1>; rel="first"`, server.URL))
+			fmt.Fprint(w, `[{"login":"bob"}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	backend := newGitHubBackend(server.URL, "token")
+	members, err := backend.ListMembers(context.Background(), "acme", ExistingTeam{Slug: "platform"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"alice": RoleMaintainer, "bob": RoleMember}, members)
+}
+
+func TestGitLabPermissionMapping(t *testing.T) {
+	b := newGitLabBackend("", "")
+	assert.Equal(t, PermissionPull, b.NormalizePermission(PermissionTriage))
+	assert.Equal(t, PermissionPush, b.NormalizePermission(PermissionPush))
+	assert.Equal(t, PermissionMaintain, b.NormalizePermission(PermissionAdmin))
+}
+
+func TestGiteaBackendSync(t *testing.T) {
+	var calls []string
+	var edited map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token token", r.Header.Get("Authorization"))
+		if r.Method != http.MethodGet {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+		}
+		switch {
+		case r.URL.Path == "/orgs/acme/teams":
+			fmt.Fprint(w, `[{"id":7,"name":"platform","permission":"read","units":["repo.code"]}]`)
+		case r.URL.Path == "/teams/7/members" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `[{"login":"Alice"}]`)
+		case r.URL.Path == "/teams/7/repos" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `[{"name":"docs"}]`)
+		case r.URL.Path == "/teams/7" && r.Method == http.MethodPatch:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&edited))
+			fmt.Fprint(w, `{"id":7,"name":"platform","permission":"write"}`)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	spec := &Spec{Teams: []Team{{
+		Name:         "platform",
+		Maintainers:  []string{"alice"},
+		Members:      []string{"bob"},
+		Repositories: map[string]string{"docs": PermissionTriage, "infra": PermissionPush},
+	}}}
+	backend, err := NewBackend("gitea", server.URL, "token")
+	require.NoError(t, err)
+
+	report, err := NewSyncer(backend, spec, Options{}).Run(context.Background(), "acme")
+	require.NoError(t, err)
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, StatusApplied, report.Results[0].Status)
+	assert.Equal(t, []string{
+		"PUT /teams/7/members/bob",
+		"PATCH /teams/7",
+		"PUT /teams/7/repos/acme/infra",
+	}, calls)
+	assert.Equal(t, "write", edited["permission"])
+	assert.Equal(t, map[string]any{"repo.code": "write"}, edited["units_map"])
+}
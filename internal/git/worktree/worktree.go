@@ -0,0 +1,512 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package worktree manages linked Git worktrees across many clones: it
+// checks out the same branch of every clone into a parallel tree, finds
+// worktrees that are no longer needed and removes them.
+package worktree
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+)
+
+// Status is the outcome of an operation on one clone or worktree.
+type Status string
+
+// Operation statuses.
+const (
+	StatusCreated     Status = "created"
+	StatusWouldCreate Status = "would-create"
+	StatusExists      Status = "exists"
+	StatusRemoved     Status = "removed"
+	StatusWouldRemove Status = "would-remove"
+	// StatusKept is reported for stale worktrees that are locked or have
+	// uncommitted changes.
+	StatusKept    Status = "kept"
+	StatusSkipped Status = "skipped"
+	StatusFailed  Status = "failed"
+)
+
+// Reasons a worktree is stale.
+const (
+	ReasonMissing      = "missing"
+	ReasonMerged       = "merged"
+	ReasonUpstreamGone = "upstream-gone"
+	ReasonInactive     = "inactive"
+)
+
+// Worktree is a linked worktree of a clone. The main working tree of the
+// clone is never listed.
+type Worktree struct {
+	Repository depupdate.Repository `json:"repository"`
+	Path       string               `json:"path"`
+	// Branch is the checked-out branch; empty for a detached HEAD.
+	Branch string `json:"branch,omitempty"`
+	Head   string `json:"head"`
+	Locked bool   `json:"locked,omitempty"`
+	// Missing is set when the worktree directory was deleted without
+	// telling git.
+	Missing    bool      `json:"missing,omitempty"`
+	Dirty      bool      `json:"dirty,omitempty"`
+	LastCommit time.Time `json:"lastCommit,omitempty"`
+	// Reasons lists why the worktree is stale; empty when it is in use.
+	Reasons []string `json:"staleReasons,omitempty"`
+}
+
+// Stale reports whether the worktree is no longer needed.
+func (w Worktree) Stale() bool { return len(w.Reasons) > 0 }
+
+// Result is the outcome of creating or removing one worktree.
+type Result struct {
+	Repository depupdate.Repository `json:"repository"`
+	Path       string               `json:"path,omitempty"`
+	Branch     string               `json:"branch,omitempty"`
+	Status     Status               `json:"status"`
+	Detail     string               `json:"detail,omitempty"`
+	Error      string               `json:"error,omitempty"`
+}
+
+// Repositories returns the clones below root, leaving out linked worktrees,
+// whose .git is a file pointing into another clone. With patterns, only
+// clones whose org/name or name matches one of them (shell globs) are
+// returned; exclude patterns drop clones again.
+func Repositories(root string, patterns, exclude []string) ([]depupdate.Repository, error) {
+	repos, err := depupdate.DiscoverRepositories(root)
+	if err != nil {
+		return nil, err
+	}
+	var selected []depupdate.Repository
+	for _, r := range repos {
+		if isLinkedWorktree(r.Path) {
+			continue
+		}
+		if len(patterns) > 0 && !matchAny(patterns, r) {
+			continue
+		}
+		if matchAny(exclude, r) {
+			continue
+		}
+		selected = append(selected, r)
+	}
+	return selected, nil
+}
+
+func isLinkedWorktree(dir string) bool {
+	data, err := os.ReadFile(filepath.Join(dir, ".git"))
+	if err != nil {
+		return false
+	}
+	gitdir := strings.TrimSpace(strings.TrimPrefix(string(data), "gitdir:"))
+	return strings.Contains(filepath.ToSlash(gitdir), "/worktrees/")
+}
+
+func matchAny(patterns []string, r depupdate.Repository) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, r.FullName()); ok {
+			return true
+		}
+		if ok, _ := path.Match(p, r.Name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// BranchSlug returns branch as a single path element, e.g. release-1.2 for
+// release/1.2.
+func BranchSlug(branch string) string {
+	return strings.NewReplacer("/", "-", "\\", "-", ":", "-").Replace(branch)
+}
+
+// Path returns where the worktree of repo is placed in the parallel tree
+// into, mirroring the layout of the clones below root.
+func Path(into, root string, repo depupdate.Repository) string {
+	rel, err := filepath.Rel(filepath.Clean(root), repo.Path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel = filepath.Join(repo.Org, repo.Name)
+	}
+	return filepath.Join(into, rel)
+}
+
+// CreateOptions control Create.
+type CreateOptions struct {
+	Branch string
+	// Root is the directory the clones were discovered below and Into the
+	// directory the worktrees are created in, in the same layout.
+	Root string
+	Into string
+	// Fetch fetches origin before looking for the branch.
+	Fetch bool
+	// CreateBranch creates the branch from Base in clones that have neither
+	// a local nor a remote branch of that name.
+	CreateBranch bool
+	// Base defaults to the default branch of origin.
+	Base        string
+	DryRun      bool
+	Concurrency int
+}
+
+// Create adds a worktree for the branch to every clone. A clone that has
+// the branch checked out already is reported as StatusExists; a clone
+// without the branch is skipped unless CreateBranch is set.
+func Create(ctx context.Context, repos []depupdate.Repository, opts CreateOptions) []Result {
+	results := make([]Result, len(repos))
+	forEach(repos, opts.Concurrency, func(i int, repo depupdate.Repository) {
+		results[i] = createOne(ctx, repo, opts)
+	})
+	return results
+}
+
+func createOne(ctx context.Context, repo depupdate.Repository, opts CreateOptions) Result {
+	result := Result{Repository: repo, Branch: opts.Branch, Path: Path(opts.Into, opts.Root, repo)}
+	fail := func(err error) Result {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	if opts.Fetch {
+		if _, err := runGit(ctx, repo.Path, "fetch", "--quiet", "--prune", "origin"); err != nil {
+			return fail(err)
+		}
+	}
+
+	worktrees, err := list(ctx, repo)
+	if err != nil {
+		return fail(err)
+	}
+	for _, w := range worktrees {
+		if w.Branch == opts.Branch {
+			result.Status = StatusExists
+			result.Path = w.Path
+			return result
+		}
+	}
+	if _, err := os.Stat(result.Path); err == nil {
+		return fail(fmt.Errorf("%s already exists", result.Path))
+	}
+
+	var args []string
+	switch {
+	case refExists(ctx, repo.Path, "refs/heads/"+opts.Branch):
+		args = []string{"worktree", "add", result.Path, opts.Branch}
+	case refExists(ctx, repo.Path, "refs/remotes/origin/"+opts.Branch):
+		args = []string{"worktree", "add", "--track", "-b", opts.Branch, result.Path, "origin/" + opts.Branch}
+		result.Detail = "tracking origin/" + opts.Branch
+	case opts.CreateBranch:
+		base := opts.Base
+		if base == "" {
+			if base = defaultBranch(ctx, repo.Path); base == "" {
+				base = "HEAD"
+			}
+		}
+		if !refExists(ctx, repo.Path, base) {
+			return fail(fmt.Errorf("base %s not found", base))
+		}
+		args = []string{"worktree", "add", "--no-track", "-b", opts.Branch, result.Path, base}
+		result.Detail = "new branch from " + base
+	default:
+		result.Status = StatusSkipped
+		result.Detail = "branch not found"
+		return result
+	}
+
+	if opts.DryRun {
+		result.Status = StatusWouldCreate
+		return result
+	}
+	if err := os.MkdirAll(filepath.Dir(result.Path), 0o755); err != nil {
+		return fail(err)
+	}
+	if _, err := runGit(ctx, repo.Path, args...); err != nil {
+		return fail(err)
+	}
+	result.Status = StatusCreated
+	return result
+}
+
+// StaleOptions decide which worktrees are stale. A worktree is stale when
+// its directory is gone, its branch is merged into Base or its upstream
+// branch was deleted, or, with MaxAge, its last commit is older than that.
+type StaleOptions struct {
+	// Base defaults to the default branch of origin.
+	Base   string
+	MaxAge time.Duration
+	Now    func() time.Time
+}
+
+// List returns the linked worktrees of the clones with their stale reasons.
+// Clones that fail are reported in the joined error; the others are still
+// listed.
+func List(ctx context.Context, repos []depupdate.Repository, opts StaleOptions, concurrency int) ([]Worktree, error) {
+	perRepo := make([][]Worktree, len(repos))
+	errs := make([]error, len(repos))
+	forEach(repos, concurrency, func(i int, repo depupdate.Repository) {
+		worktrees, err := list(ctx, repo)
+		if err != nil {
+			errs[i] = fmt.Errorf("%s: %w", repo.FullName(), err)
+			return
+		}
+		for j := range worktrees {
+			evaluate(ctx, &worktrees[j], opts)
+		}
+		perRepo[i] = worktrees
+	})
+
+	var all []Worktree
+	for _, worktrees := range perRepo {
+		all = append(all, worktrees...)
+	}
+	return all, errors.Join(errs...)
+}
+
+// PruneOptions control Prune.
+type PruneOptions struct {
+	StaleOptions
+	// Force removes worktrees with uncommitted changes.
+	Force bool
+	// DeleteBranch deletes the branches of removed worktrees that are
+	// merged into the base.
+	DeleteBranch bool
+	DryRun       bool
+	Concurrency  int
+}
+
+// Prune removes the stale worktrees of the clones. Locked worktrees and,
+// without Force, worktrees with uncommitted changes are kept.
+func Prune(ctx context.Context, repos []depupdate.Repository, opts PruneOptions) []Result {
+	perRepo := make([][]Result, len(repos))
+	forEach(repos, opts.Concurrency, func(i int, repo depupdate.Repository) {
+		perRepo[i] = pruneRepo(ctx, repo, opts)
+	})
+
+	var results []Result
+	for _, r := range perRepo {
+		results = append(results, r...)
+	}
+	return results
+}
+
+func pruneRepo(ctx context.Context, repo depupdate.Repository, opts PruneOptions) []Result {
+	worktrees, err := list(ctx, repo)
+	if err != nil {
+		return []Result{{Repository: repo, Status: StatusFailed, Error: err.Error()}}
+	}
+
+	var results []Result
+	missing := false
+	for _, w := range worktrees {
+		evaluate(ctx, &w, opts.StaleOptions)
+		if !w.Stale() {
+			continue
+		}
+		result := Result{Repository: repo, Path: w.Path, Branch: w.Branch, Detail: strings.Join(w.Reasons, ", ")}
+		switch {
+		case w.Locked:
+			result.Status, result.Detail = StatusKept, "locked"
+		case w.Dirty && !opts.Force:
+			result.Status, result.Detail = StatusKept, "uncommitted changes"
+		case opts.DryRun:
+			result.Status = StatusWouldRemove
+		case w.Missing:
+			// 디렉터리가 이미 없으므로 아래의 worktree prune이 정리한다
+			missing = true
+			result.Status = StatusRemoved
+		default:
+			args := []string{"worktree", "remove", w.Path}
+			if w.Dirty {
+				args = []string{"worktree", "remove", "--force", w.Path}
+			}
+			if _, err := runGit(ctx, repo.Path, args...); err != nil {
+				result.Status, result.Error = StatusFailed, err.Error()
+				break
+			}
+			result.Status = StatusRemoved
+		}
+		results = append(results, result)
+	}
+
+	if missing {
+		if _, err := runGit(ctx, repo.Path, "worktree", "prune"); err != nil {
+			for i := range results {
+				if results[i].Status == StatusRemoved {
+					results[i].Status, results[i].Error = StatusFailed, err.Error()
+				}
+			}
+		}
+	}
+	if opts.DeleteBranch {
+		for i, r := range results {
+			if r.Status != StatusRemoved || r.Branch == "" || !strings.Contains(r.Detail, ReasonMerged) {
+				continue
+			}
+			// -d는 병합되지 않은 브랜치를 지우지 않는다
+			if _, err := runGit(ctx, repo.Path, "branch", "-d", r.Branch); err == nil {
+				results[i].Detail += ", branch deleted"
+			}
+		}
+	}
+	return results
+}
+
+// list returns the linked worktrees of repo.
+func list(ctx context.Context, repo depupdate.Repository) ([]Worktree, error) {
+	out, err := runGit(ctx, repo.Path, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	worktrees := parsePorcelain(out)
+	if len(worktrees) > 0 {
+		// 첫 번째 항목은 클론의 메인 작업 트리다
+		worktrees = worktrees[1:]
+	}
+	for i := range worktrees {
+		w := &worktrees[i]
+		w.Repository = repo
+		if w.Head != "" {
+			if ts, err := runGit(ctx, repo.Path, "show", "-s", "--format=%ct", w.Head); err == nil {
+				if sec, err := strconv.ParseInt(strings.TrimSpace(ts), 10, 64); err == nil {
+					w.LastCommit = time.Unix(sec, 0).UTC()
+				}
+			}
+		}
+		if !w.Missing {
+			status, err := runGit(ctx, w.Path, "status", "--porcelain")
+			w.Dirty = err == nil && strings.TrimSpace(status) != ""
+		}
+	}
+	return worktrees, nil
+}
+
+// parsePorcelain parses the output of git worktree list --porcelain:
+// records of "key value" lines separated by blank lines.
+func parsePorcelain(out string) []Worktree {
+	var (
+		worktrees []Worktree
+		current   *Worktree
+	)
+	for _, line := range strings.Split(out, "\n") {
+		key, value, _ := strings.Cut(strings.TrimRight(line, "\r"), " ")
+		switch key {
+		case "worktree":
+			worktrees = append(worktrees, Worktree{Path: value})
+			current = &worktrees[len(worktrees)-1]
+		case "HEAD":
+			if current != nil {
+				current.Head = value
+			}
+		case "branch":
+			if current != nil {
+				current.Branch = strings.TrimPrefix(value, "refs/heads/")
+			}
+		case "locked":
+			if current != nil {
+				current.Locked = true
+			}
+		case "prunable":
+			if current != nil {
+				current.Missing = true
+			}
+		}
+	}
+	return worktrees
+}
+
+// evaluate sets the stale reasons of w.
+func evaluate(ctx context.Context, w *Worktree, opts StaleOptions) {
+	w.Reasons = nil
+	if w.Missing {
+		w.Reasons = append(w.Reasons, ReasonMissing)
+	}
+	dir := w.Repository.Path
+	if w.Branch != "" {
+		track, err := runGit(ctx, dir, "for-each-ref", "--format=%(upstream:track)", "refs/heads/"+w.Branch)
+		if err == nil && strings.TrimSpace(track) == "[gone]" {
+			w.Reasons = append(w.Reasons, ReasonUpstreamGone)
+		}
+	}
+
+	base := opts.Base
+	if base == "" {
+		base = defaultBranch(ctx, dir)
+	}
+	if base != "" && w.Head != "" {
+		baseHead, err := runGit(ctx, dir, "rev-parse", "--verify", "--quiet", base+"^{commit}")
+		// 기준 브랜치와 같은 커밋인 새 워크트리는 병합된 것으로 보지 않는다
+		if err == nil && strings.TrimSpace(baseHead) != w.Head {
+			if _, err := runGit(ctx, dir, "merge-base", "--is-ancestor", w.Head, base); err == nil {
+				w.Reasons = append(w.Reasons, ReasonMerged)
+			}
+		}
+	}
+
+	if opts.MaxAge > 0 && !w.LastCommit.IsZero() {
+		now := time.Now
+		if opts.Now != nil {
+			now = opts.Now
+		}
+		if now().Sub(w.LastCommit) > opts.MaxAge {
+			w.Reasons = append(w.Reasons, ReasonInactive)
+		}
+	}
+}
+
+// defaultBranch returns the default branch of origin, e.g. origin/main, or
+// "" when the clone does not know it.
+func defaultBranch(ctx context.Context, dir string) string {
+	out, err := runGit(ctx, dir, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+func refExists(ctx context.Context, dir, ref string) bool {
+	_, err := runGit(ctx, dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return err == nil
+}
+
+func forEach(repos []depupdate.Repository, concurrency int, fn func(int, depupdate.Repository)) {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			fn(i, repo)
+		}()
+	}
+	wg.Wait()
+}
+
+// runGit runs git in dir and returns stdout. The error includes git's
+// stderr.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
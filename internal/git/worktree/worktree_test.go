@@ -0,0 +1,187 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package worktree
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/git/depupdate"
+)
+
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com",
+		"GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, out)
+	return string(out)
+}
+
+// setupClones creates <root>/acme/api and <root>/acme/web, clones of bare
+// origins with a main branch. api's origin also has release/1.0.
+func setupClones(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	base := t.TempDir()
+	root := filepath.Join(base, "src")
+	for _, name := range []string{"api", "web"} {
+		origin := filepath.Join(base, "origins", name+".git")
+		require.NoError(t, os.MkdirAll(origin, 0o755))
+		git(t, origin, "init", "--quiet", "--bare", "--initial-branch=main")
+
+		seed := filepath.Join(t.TempDir(), "seed")
+		git(t, base, "clone", "--quiet", origin, seed)
+		require.NoError(t, os.WriteFile(filepath.Join(seed, "README"), []byte(name), 0o644))
+		git(t, seed, "add", ".")
+		git(t, seed, "commit", "--quiet", "-m", "init")
+		git(t, seed, "push", "--quiet", "origin", "HEAD:main")
+		if name == "api" {
+			git(t, seed, "push", "--quiet", "origin", "HEAD:release/1.0")
+		}
+
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "acme"), 0o755))
+		git(t, root, "clone", "--quiet", origin, filepath.Join("acme", name))
+	}
+	return root
+}
+
+func TestCreate(t *testing.T) {
+	root := setupClones(t)
+	ctx := context.Background()
+	repos, err := Repositories(root, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+
+	into := root + "@" + BranchSlug("release/1.0")
+	opts := CreateOptions{Branch: "release/1.0", Root: root, Into: into, DryRun: true}
+	results := Create(ctx, repos, opts)
+	assert.Equal(t, StatusWouldCreate, results[0].Status)
+	assert.Equal(t, StatusSkipped, results[1].Status)
+	assert.NoDirExists(t, into)
+
+	opts.DryRun = false
+	opts.CreateBranch = true
+	results = Create(ctx, repos, opts)
+	require.Equal(t, StatusCreated, results[0].Status, results[0].Error)
+	assert.Equal(t, "tracking origin/release/1.0", results[0].Detail)
+	require.Equal(t, StatusCreated, results[1].Status, results[1].Error)
+	assert.Equal(t, "new branch from origin/main", results[1].Detail)
+	assert.FileExists(t, filepath.Join(into, "acme", "api", "README"))
+	assert.FileExists(t, filepath.Join(into, "acme", "web", "README"))
+
+	results = Create(ctx, repos, opts)
+	assert.Equal(t, StatusExists, results[0].Status)
+	assert.Equal(t, filepath.Join(into, "acme", "api"), results[0].Path)
+
+	// 워크트리는 저장소 목록에 다시 나타나지 않는다
+	repos, err = Repositories(filepath.Dir(root), nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, repos, 2)
+
+	repos, err = Repositories(root, []string{"acme/w*"}, nil)
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "web", repos[0].Name)
+	repos, err = Repositories(root, nil, []string{"web"})
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "api", repos[0].Name)
+}
+
+func TestListAndPrune(t *testing.T) {
+	root := setupClones(t)
+	ctx := context.Background()
+	repos, err := Repositories(root, nil, nil)
+	require.NoError(t, err)
+	api, web := repos[0], repos[1]
+
+	into := root + "@feature"
+	Create(ctx, repos, CreateOptions{Branch: "feature", Root: root, Into: into, CreateBranch: true})
+	apiTree := filepath.Join(into, "acme", "api")
+	webTree := filepath.Join(into, "acme", "web")
+
+	worktrees, err := List(ctx, repos, StaleOptions{}, 2)
+	require.NoError(t, err)
+	require.Len(t, worktrees, 2)
+	for _, w := range worktrees {
+		assert.Equal(t, "feature", w.Branch)
+		assert.False(t, w.Stale(), "a new worktree is in use: %v", w.Reasons)
+	}
+
+	// api: main moves past the feature branch, which is now merged.
+	// web: the worktree directory is deleted.
+	require.NoError(t, os.WriteFile(filepath.Join(api.Path, "CHANGES"), []byte("x"), 0o644))
+	git(t, api.Path, "add", ".")
+	git(t, api.Path, "commit", "--quiet", "-m", "change")
+	git(t, api.Path, "push", "--quiet", "origin", "HEAD:main")
+	git(t, api.Path, "fetch", "--quiet", "origin")
+	require.NoError(t, os.RemoveAll(webTree))
+
+	worktrees, err = List(ctx, repos, StaleOptions{}, 2)
+	require.NoError(t, err)
+	require.Len(t, worktrees, 2)
+	byRepo := map[string]Worktree{}
+	for _, w := range worktrees {
+		byRepo[w.Repository.Name] = w
+	}
+	assert.Equal(t, []string{ReasonMerged}, byRepo["api"].Reasons)
+	assert.Equal(t, []string{ReasonMissing}, byRepo["web"].Reasons)
+
+	worktrees, err = List(ctx, []depupdate.Repository{web}, StaleOptions{
+		MaxAge: time.Hour,
+		Now:    func() time.Time { return time.Now().Add(48 * time.Hour) },
+	}, 1)
+	require.NoError(t, err)
+	assert.Contains(t, worktrees[0].Reasons, ReasonInactive)
+
+	// Uncommitted changes keep a stale worktree unless forced.
+	require.NoError(t, os.WriteFile(filepath.Join(apiTree, "WIP"), []byte("x"), 0o644))
+	results := Prune(ctx, repos, PruneOptions{DeleteBranch: true})
+	require.Len(t, results, 2)
+	assert.Equal(t, StatusKept, results[0].Status)
+	assert.Equal(t, "uncommitted changes", results[0].Detail)
+	assert.Equal(t, StatusRemoved, results[1].Status, results[1].Error)
+
+	results = Prune(ctx, repos, PruneOptions{DeleteBranch: true, Force: true, DryRun: true})
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusWouldRemove, results[0].Status)
+	assert.DirExists(t, apiTree)
+
+	results = Prune(ctx, repos, PruneOptions{DeleteBranch: true, Force: true})
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusRemoved, results[0].Status, results[0].Error)
+	assert.Equal(t, "merged, branch deleted", results[0].Detail)
+	assert.NoDirExists(t, apiTree)
+
+	worktrees, err = List(ctx, repos, StaleOptions{}, 2)
+	require.NoError(t, err)
+	assert.Empty(t, worktrees)
+	assert.NotContains(t, git(t, api.Path, "branch"), "feature")
+	assert.Contains(t, git(t, web.Path, "branch"), "feature", "unmerged branches are kept")
+}
+
+func TestParsePorcelain(t *testing.T) {
+	out := "worktree /src/api\nHEAD aaa\nbranch refs/heads/main\n\n" +
+		"worktree /src/api@rel\nHEAD bbb\nbranch refs/heads/release/1.0\nlocked busy\n\n" +
+		"worktree /tmp/gone\nHEAD ccc\ndetached\nprunable gitdir file points to non-existent location\n\n"
+	worktrees := parsePorcelain(out)
+	require.Len(t, worktrees, 3)
+	assert.Equal(t, Worktree{Path: "/src/api@rel", Head: "bbb", Branch: "release/1.0", Locked: true}, worktrees[1])
+	assert.Equal(t, Worktree{Path: "/tmp/gone", Head: "ccc", Missing: true}, worktrees[2])
+	assert.Equal(t, "release-1.0", BranchSlug("release/1.0"))
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package htmlreport
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Chart dimensions in SVG user units.
+const (
+	chartWidth   = 1000
+	chartHeight  = 260
+	chartLeft    = 50
+	chartRight   = 20
+	chartTop     = 20
+	chartBottom  = 50
+	chartGridY   = 4
+	chartMaxTick = 8
+)
+
+// seriesColors are assigned to series without a color, in order.
+var seriesColors = []string{"#0969da", "#cf222e", "#bf8700", "#1a7f37", "#8250df", "#57606a"}
+
+// Chart is a line chart of values over time.
+type Chart struct {
+	Title string
+	// Unit is appended to the y axis labels, e.g. "%".
+	Unit   string
+	Series []Series
+}
+
+// Series is one line of a chart.
+type Series struct {
+	Name   string
+	Color  string
+	Points []Point
+}
+
+// Point is a value at a point in time.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// hasData reports whether the chart has a line to draw, which needs at
+// least two points in some series.
+func (c *Chart) hasData() bool {
+	if c == nil {
+		return false
+	}
+	for _, s := range c.Series {
+		if len(s.Points) >= 2 {
+			return true
+		}
+	}
+	return false
+}
+
+// svg renders the chart as an inline SVG element.
+func (c *Chart) svg() string {
+	var minT, maxT time.Time
+	maxV := 0.0
+	for _, s := range c.Series {
+		for _, p := range s.Points {
+			if minT.IsZero() || p.Time.Before(minT) {
+				minT = p.Time
+			}
+			if p.Time.After(maxT) {
+				maxT = p.Time
+			}
+			maxV = math.Max(maxV, p.Value)
+		}
+	}
+	maxV = niceCeil(maxV)
+	span := maxT.Sub(minT)
+
+	plotW := float64(chartWidth - chartLeft - chartRight)
+	plotH := float64(chartHeight - chartTop - chartBottom)
+	x := func(t time.Time) float64 {
+		if span <= 0 {
+			return chartLeft + plotW/2
+		}
+		return chartLeft + plotW*float64(t.Sub(minT))/float64(span)
+	}
+	y := func(v float64) float64 {
+		return chartTop + plotH - plotH*v/maxV
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg viewBox="0 0 %d %d" width="100%%" role="img" aria-label="%s">`,
+		chartWidth, chartHeight, html.EscapeString(c.Title))
+
+	for i := 0; i <= chartGridY; i++ {
+		v := maxV * float64(i) / chartGridY
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#eaeef2"/>`,
+			chartLeft, y(v), chartWidth-chartRight, y(v))
+		fmt.Fprintf(&sb, `<text x="%d" y="%.1f" text-anchor="end">%s%s</text>`,
+			chartLeft-6, y(v)+4, formatValue(v), html.EscapeString(c.Unit))
+	}
+
+	layout := "2006-01-02"
+	if span < 48*time.Hour {
+		layout = "01-02 15:04"
+	}
+	for _, t := range timeTicks(c.Series) {
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%d" text-anchor="middle">%s</text>`,
+			x(t), chartHeight-chartBottom+16, t.Format(layout))
+	}
+
+	for i, s := range c.Series {
+		color := s.Color
+		if color == "" {
+			color = seriesColors[i%len(seriesColors)]
+		}
+		color = html.EscapeString(color)
+
+		points := make([]string, 0, len(s.Points))
+		for _, p := range s.Points {
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x(p.Time), y(p.Value)))
+		}
+		fmt.Fprintf(&sb, `<polyline fill="none" stroke="%s" stroke-width="2" points="%s"/>`,
+			color, strings.Join(points, " "))
+		for _, p := range s.Points {
+			fmt.Fprintf(&sb, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%s: %s%s (%s)</title></circle>`,
+				x(p.Time), y(p.Value), color, html.EscapeString(s.Name), formatValue(p.Value),
+				html.EscapeString(c.Unit), p.Time.Format(time.RFC3339))
+		}
+
+		// Legend below the time axis.
+		lx := chartLeft + i*150
+		ly := chartHeight - 12
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="10" height="10" fill="%s"/>`, lx, ly-9, color)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d">%s</text>`, lx+14, ly, html.EscapeString(s.Name))
+	}
+
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+// timeTicks picks up to chartMaxTick distinct points in time to label.
+func timeTicks(series []Series) []time.Time {
+	seen := map[int64]bool{}
+	var all []time.Time
+	for _, s := range series {
+		for _, p := range s.Points {
+			if !seen[p.Time.Unix()] {
+				seen[p.Time.Unix()] = true
+				all = append(all, p.Time)
+			}
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Before(all[j]) })
+	if len(all) <= chartMaxTick {
+		return all
+	}
+	ticks := make([]time.Time, 0, chartMaxTick)
+	step := float64(len(all)-1) / float64(chartMaxTick-1)
+	for i := 0; i < chartMaxTick; i++ {
+		ticks = append(ticks, all[int(math.Round(float64(i)*step))])
+	}
+	return ticks
+}
+
+// niceCeil rounds v up to 1, 2 or 5 times a power of ten, so the y axis
+// labels stay readable.
+func niceCeil(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	exp := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if v <= m*exp {
+			return m * exp
+		}
+	}
+	return 10 * exp
+}
+
+func formatValue(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
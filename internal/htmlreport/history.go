@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package htmlreport
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// maxHistory bounds how many runs a history keeps.
+const maxHistory = 500
+
+// Entry is the recorded metrics of one run.
+type Entry struct {
+	Time   time.Time          `json:"time"`
+	Values map[string]float64 `json:"values"`
+}
+
+// History is a JSON-lines file of per-run metrics, one Entry per line,
+// from which reports chart trends over time.
+type History struct {
+	path string
+}
+
+// DefaultHistoryDir returns the directory holding report histories,
+// ~/.gzh/history unless GZH_HISTORY_DIR is set.
+func DefaultHistoryDir() (string, error) {
+	if dir := os.Getenv("GZH_HISTORY_DIR"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".gzh", "history"), nil
+}
+
+// OpenHistory returns the history named name in the default directory.
+func OpenHistory(name string) (*History, error) {
+	dir, err := DefaultHistoryDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewHistory(filepath.Join(dir, name+".jsonl")), nil
+}
+
+// NewHistory returns the history stored at path. The file is created on
+// the first Append.
+func NewHistory(path string) *History {
+	return &History{path: path}
+}
+
+// Path returns the history file.
+func (h *History) Path() string {
+	return h.path
+}
+
+// Load returns the recorded entries, oldest first. A missing file is an
+// empty history; lines that fail to decode are skipped.
+func (h *History) Load() ([]Entry, error) {
+	file, err := os.Open(h.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil && !e.Time.IsZero() {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+	return entries, nil
+}
+
+// Append records an entry. The oldest entries are dropped once the
+// history exceeds its limit.
+func (h *History) Append(e Entry) error {
+	entries, err := h.Load()
+	if err != nil {
+		return err
+	}
+	entries = append(entries, e)
+	if len(entries) > maxHistory {
+		entries = entries[len(entries)-maxHistory:]
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	tmp := h.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	enc := json.NewEncoder(file)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			_ = file.Close()
+			_ = os.Remove(tmp)
+			return fmt.Errorf("failed to write history: %w", err)
+		}
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	if err := os.Rename(tmp, h.path); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return nil
+}
+
+// TrendChart builds a chart of the named values over the entries. Entries
+// without a value leave a gap in that series. The chart has no data, and
+// is left out of the report, until at least two runs were recorded.
+func TrendChart(title, unit string, entries []Entry, names ...string) *Chart {
+	chart := &Chart{Title: title, Unit: unit}
+	for _, name := range names {
+		s := Series{Name: name}
+		for _, e := range entries {
+			if v, ok := e.Values[name]; ok {
+				s.Points = append(s.Points, Point{Time: e.Time, Value: v})
+			}
+		}
+		chart.Series = append(chart.Series, s)
+	}
+	return chart
+}
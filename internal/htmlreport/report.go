@@ -0,0 +1,200 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package htmlreport renders analysis results as a single self-contained
+// HTML file. Styles and charts are inlined, so the file can be attached to
+// an audit or mailed around without any other assets or network access.
+package htmlreport
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Statuses understood by the renderer. Any other status is shown neutral.
+const (
+	StatusPass = "pass"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+	StatusSkip = "skip"
+)
+
+// Report is the content of an HTML report.
+type Report struct {
+	Title       string
+	Subtitle    string
+	GeneratedAt time.Time
+	// Stats are shown as summary tiles at the top of the report.
+	Stats []Stat
+	// Charts are shown below the tiles. Charts without data are omitted.
+	Charts   []*Chart
+	Sections []Section
+}
+
+// Stat is one summary tile.
+type Stat struct {
+	Label  string
+	Value  string
+	Status string
+}
+
+// Section is a titled table of results.
+type Section struct {
+	Title       string
+	Description string
+	Columns     []string
+	Rows        []Row
+}
+
+// Row is one table row. Status colors the row marker.
+type Row struct {
+	Status string
+	Cells  []string
+	// Detail is shown under the row in smaller type, e.g. a fix suggestion.
+	Detail string
+}
+
+// Render writes the report to w.
+func Render(w io.Writer, r *Report) error {
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"chartSVG": func(c *Chart) template.HTML {
+			return template.HTML(c.svg()) //nolint:gosec // SVG is built from escaped values
+		},
+		"statusClass": statusClass,
+	}).Parse(reportTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse report template: %w", err)
+	}
+
+	charts := make([]*Chart, 0, len(r.Charts))
+	for _, c := range r.Charts {
+		if c.hasData() {
+			charts = append(charts, c)
+		}
+	}
+
+	data := struct {
+		*Report
+		Charts    []*Chart
+		Generated string
+	}{
+		Report:    r,
+		Charts:    charts,
+		Generated: r.GeneratedAt.Format(time.RFC1123),
+	}
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
+
+// WriteFile renders the report to path, creating parent directories.
+func WriteFile(path string, r *Report) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	if err := Render(file, r); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func statusClass(status string) string {
+	switch status {
+	case StatusPass, StatusWarn, StatusFail, StatusSkip:
+		return status
+	default:
+		return "info"
+	}
+}
+
+const reportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; background: #f5f6f8; color: #24292f; }
+header { background: #24292f; color: #fff; padding: 24px 32px; }
+header h1 { margin: 0 0 4px; font-size: 24px; }
+header p { margin: 0; color: #c9d1d9; font-size: 14px; }
+main { max-width: 1100px; margin: 0 auto; padding: 24px 32px; }
+.stats { display: flex; flex-wrap: wrap; gap: 12px; margin-bottom: 24px; }
+.stat { background: #fff; border-radius: 6px; padding: 12px 16px; min-width: 130px; border-top: 4px solid #8c959f; box-shadow: 0 1px 2px rgba(0,0,0,.08); }
+.stat .value { font-size: 24px; font-weight: 600; }
+.stat .label { font-size: 12px; color: #57606a; text-transform: uppercase; letter-spacing: .04em; }
+.stat.pass { border-top-color: #1a7f37; } .stat.warn { border-top-color: #bf8700; } .stat.fail { border-top-color: #cf222e; }
+.card { background: #fff; border-radius: 6px; padding: 16px 20px; margin-bottom: 24px; box-shadow: 0 1px 2px rgba(0,0,0,.08); }
+.card h2 { margin: 0 0 8px; font-size: 18px; }
+.card p.description { margin: 0 0 12px; color: #57606a; font-size: 14px; }
+table { width: 100%; border-collapse: collapse; font-size: 14px; }
+th { text-align: left; color: #57606a; font-weight: 600; border-bottom: 2px solid #d0d7de; padding: 6px 8px; }
+td { border-bottom: 1px solid #eaeef2; padding: 6px 8px; vertical-align: top; }
+td.marker { width: 8px; padding: 0; }
+tr.pass td.marker { background: #1a7f37; } tr.warn td.marker { background: #bf8700; }
+tr.fail td.marker { background: #cf222e; } tr.skip td.marker { background: #8c959f; }
+tr.detail td { color: #57606a; font-size: 12px; border-bottom: 1px solid #eaeef2; padding-top: 0; }
+svg text { font-family: inherit; font-size: 11px; fill: #57606a; }
+footer { text-align: center; color: #8c959f; font-size: 12px; padding: 16px; }
+@media print { body { background: #fff; } .card, .stat { box-shadow: none; border: 1px solid #d0d7de; } }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+<p>{{if .Subtitle}}{{.Subtitle}} · {{end}}Generated {{.Generated}}</p>
+</header>
+<main>
+{{- if .Stats}}
+<div class="stats">
+{{- range .Stats}}
+<div class="stat {{statusClass .Status}}"><div class="value">{{.Value}}</div><div class="label">{{.Label}}</div></div>
+{{- end}}
+</div>
+{{- end}}
+{{- range .Charts}}
+<div class="card">
+<h2>{{.Title}}</h2>
+{{chartSVG .}}
+</div>
+{{- end}}
+{{- range .Sections}}
+<div class="card">
+<h2>{{.Title}}</h2>
+{{- if .Description}}
+<p class="description">{{.Description}}</p>
+{{- end}}
+{{- if .Rows}}
+<table>
+<thead><tr><th></th>{{range .Columns}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr class="{{statusClass .Status}}"><td class="marker"></td>{{range .Cells}}<td>{{.}}</td>{{end}}</tr>
+{{- if .Detail}}
+<tr class="detail"><td></td><td colspan="{{len .Cells}}">{{.Detail}}</td></tr>
+{{- end}}
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p class="description">Nothing to report.</p>
+{{- end}}
+</div>
+{{- end}}
+</main>
+<footer>Generated by gz</footer>
+</body>
+</html>
+`
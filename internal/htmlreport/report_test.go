@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package htmlreport
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderIsSelfContainedAndEscaped(t *testing.T) {
+	report := &Report{
+		Title:       "Doctor <report>",
+		GeneratedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Stats:       []Stat{{Label: "Failed", Value: "2", Status: StatusFail}},
+		Sections: []Section{{
+			Title:   "Checks",
+			Columns: []string{"Check", "Message"},
+			Rows: []Row{
+				{Status: StatusFail, Cells: []string{"git", "<script>alert(1)</script>"}, Detail: "install git"},
+				{Status: "unknown", Cells: []string{"go", "ok"}},
+			},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, report))
+	out := buf.String()
+
+	assert.Contains(t, out, "Doctor &lt;report&gt;")
+	assert.Contains(t, out, "&lt;script&gt;alert(1)&lt;/script&gt;")
+	assert.NotContains(t, out, "<script>")
+	assert.Contains(t, out, `<tr class="fail">`)
+	assert.Contains(t, out, `<tr class="info">`)
+	assert.Contains(t, out, "install git")
+	for _, external := range []string{"<link", "src=\"http", "@import"} {
+		assert.NotContains(t, out, external)
+	}
+}
+
+func TestRenderOmitsChartsWithoutHistory(t *testing.T) {
+	now := time.Now()
+	single := TrendChart("Coverage", "%", []Entry{{Time: now, Values: map[string]float64{"coverage": 80}}}, "coverage")
+	trend := TrendChart("Violations", "", []Entry{
+		{Time: now.Add(-time.Hour), Values: map[string]float64{"violations": 4}},
+		{Time: now, Values: map[string]float64{"violations": 1}},
+	}, "violations")
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, &Report{Title: "t", Charts: []*Chart{single, trend, nil}}))
+	out := buf.String()
+
+	assert.NotContains(t, out, "<h2>Coverage</h2>")
+	assert.Contains(t, out, "<h2>Violations</h2>")
+	assert.Equal(t, 1, strings.Count(out, "<svg"))
+	assert.Equal(t, 1, strings.Count(out, "<polyline"))
+}
+
+func TestHistoryAppendAndLoad(t *testing.T) {
+	h := NewHistory(filepath.Join(t.TempDir(), "nested", "doctor.jsonl"))
+
+	entries, err := h.Load()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, h.Append(Entry{Time: base.Add(time.Hour), Values: map[string]float64{"fail": 1}}))
+	require.NoError(t, h.Append(Entry{Time: base, Values: map[string]float64{"fail": 3}}))
+
+	entries, err = h.Load()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.True(t, entries[0].Time.Equal(base), "entries are sorted by time")
+	assert.InDelta(t, 3, entries[0].Values["fail"], 0)
+
+	chart := TrendChart("Failures", "", entries, "fail", "missing")
+	require.Len(t, chart.Series, 2)
+	assert.Len(t, chart.Series[0].Points, 2)
+	assert.Empty(t, chart.Series[1].Points)
+	assert.True(t, chart.hasData())
+}
+
+func TestNiceCeil(t *testing.T) {
+	for in, want := range map[float64]float64{0: 1, 0.3: 0.5, 1: 1, 7: 10, 42: 50, 100: 100, 101: 200} {
+		assert.InDelta(t, want, niceCeil(in), 1e-9, "niceCeil(%v)", in)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package httpcache implements conditional HTTP requests for provider APIs.
+// Responses carrying an ETag or Last-Modified validator are stored, and later
+// requests for the same resource send If-None-Match / If-Modified-Since. A
+// 304 Not Modified answer is served from the store; on GitHub such requests
+// do not count against the rate limit.
+package httpcache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry is a stored response.
+type Entry struct {
+	URL          string      `json:"url"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+	StoredAt     time.Time   `json:"stored_at"`
+}
+
+// Store keeps entries by key. Implementations must be safe for concurrent
+// use.
+type Store interface {
+	Get(key string) (*Entry, bool)
+	Set(key string, e *Entry)
+}
+
+// MemoryStore is a bounded in-memory store that evicts the least recently
+// used entry.
+type MemoryStore struct {
+	mu      sync.Mutex
+	max     int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryItem struct {
+	key   string
+	entry *Entry
+}
+
+// NewMemoryStore creates a store holding at most maxEntries entries.
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+	return &MemoryStore{max: maxEntries, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(key string) (*Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	s.order.MoveToFront(el)
+	return el.Value.(*memoryItem).entry, true
+}
+
+// Set implements Store.
+func (s *MemoryStore) Set(key string, e *Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.entries[key]; ok {
+		el.Value.(*memoryItem).entry = e
+		s.order.MoveToFront(el)
+		return
+	}
+	s.entries[key] = s.order.PushFront(&memoryItem{key: key, entry: e})
+	if s.order.Len() > s.max {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryItem).key)
+	}
+}
+
+// FileStore defaults.
+const (
+	// DefaultMaxSize bounds the total size of the entries of a FileStore.
+	DefaultMaxSize = 256 << 20
+	// DefaultMaxAge is how long a FileStore keeps an entry that is not used.
+	DefaultMaxAge = 30 * 24 * time.Hour
+)
+
+// FileStore keeps one JSON file per entry in a directory, so that separate
+// gz invocations share validators. Write failures are ignored: the cache is
+// an optimization and can always be rebuilt.
+//
+// Entries unused for longer than the maximum age are dropped, and when the
+// directory grows past the maximum size the least recently used entries are
+// removed. Collection runs on the first write of a process and then after
+// every tenth of the maximum size written.
+type FileStore struct {
+	dir     string
+	maxSize int64
+	maxAge  time.Duration
+
+	mu        sync.Mutex
+	collected bool
+	// written counts the bytes stored since the last collection.
+	written int64
+}
+
+// DefaultCacheDir returns ~/.gzh/cache/http. It fails when the home
+// directory cannot be determined, in which case no disk cache should be
+// used.
+func DefaultCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".gzh", "cache", "http"), nil
+}
+
+// NewFileStore creates a store in dir holding at most maxSize bytes of
+// entries, each kept for at most maxAge since it was last used.
+// Non-positive limits select DefaultMaxSize and DefaultMaxAge.
+func NewFileStore(dir string, maxSize int64, maxAge time.Duration) *FileStore {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	return &FileStore{dir: dir, maxSize: maxSize, maxAge: maxAge}
+}
+
+func (s *FileStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(s.dir, name[:2], name+".json")
+}
+
+// Get implements Store. The modification time of an entry records its last
+// use.
+func (s *FileStore) Get(key string) (*Entry, bool) {
+	path := s.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	now := time.Now()
+	if now.Sub(info.ModTime()) > s.maxAge {
+		os.Remove(path)
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, false
+	}
+	_ = os.Chtimes(path, now, now)
+	return &e, true
+}
+
+// Set implements Store.
+func (s *FileStore) Set(key string, e *Entry) {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.Write(data)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	s.wrote(int64(len(data)))
+}
+
+// wrote accounts for n stored bytes and collects when due.
+func (s *FileStore) wrote(n int64) {
+	s.mu.Lock()
+	s.written += n
+	due := !s.collected || s.written > s.maxSize/10
+	if due {
+		s.collected = true
+		s.written = 0
+	}
+	s.mu.Unlock()
+	if due {
+		s.Collect()
+	}
+}
+
+// Collect removes the entries unused for longer than the maximum age, then
+// the least recently used entries until the store fits its maximum size.
+func (s *FileStore) Collect() {
+	type file struct {
+		path string
+		size int64
+		used time.Time
+	}
+	var (
+		files []file
+		total int64
+	)
+	now := time.Now()
+	_ = filepath.WalkDir(s.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		age := now.Sub(info.ModTime())
+		// 중단된 쓰기가 남긴 임시 파일도 정리한다
+		if age > s.maxAge || (strings.HasPrefix(d.Name(), ".entry-") && age > time.Hour) {
+			os.Remove(p)
+			return nil
+		}
+		files = append(files, file{path: p, size: info.Size(), used: info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+	if total <= s.maxSize {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].used.Before(files[j].used) })
+	for _, f := range files {
+		if total <= s.maxSize {
+			return
+		}
+		if err := os.Remove(f.path); err == nil || errors.Is(err, fs.ErrNotExist) {
+			total -= f.size
+		}
+	}
+}
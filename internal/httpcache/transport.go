@@ -0,0 +1,218 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+)
+
+// maxBodySize bounds the responses that are stored.
+const maxBodySize = 8 << 20
+
+// FromCacheHeader is set on responses served from the store.
+const FromCacheHeader = "X-Gz-From-Cache"
+
+// EnvCacheMode selects the cache used for provider API clients: "disk"
+// (default), "memory" or "off". Without a home directory "disk" falls back
+// to "memory".
+const EnvCacheMode = "GZH_API_CACHE"
+
+// Stats counts conditional request outcomes.
+type Stats struct {
+	// Hits are 304 responses served from the store.
+	Hits int64 `json:"hits"`
+	// Misses are cacheable requests answered with a full response.
+	Misses int64 `json:"misses"`
+}
+
+// HitRatio returns hits / (hits + misses), or 0 without requests.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// Transport adds conditional request handling to GET requests.
+type Transport struct {
+	provider string
+	next     http.RoundTripper
+	store    Store
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// NewTransport wraps next. provider labels the cache metrics. A nil next
+// uses http.DefaultTransport.
+func NewTransport(provider string, next http.RoundTripper, store Store) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{provider: provider, next: next, store: store}
+}
+
+// NewTransportFromEnv wraps next with the cache selected by GZH_API_CACHE,
+// or returns next unchanged when caching is off.
+func NewTransportFromEnv(provider string, next http.RoundTripper) http.RoundTripper {
+	switch strings.ToLower(os.Getenv(EnvCacheMode)) {
+	case "off", "false", "0":
+		return next
+	case "memory":
+		return NewTransport(provider, next, NewMemoryStore(0))
+	default:
+		dir, err := DefaultCacheDir()
+		if err != nil {
+			return NewTransport(provider, next, NewMemoryStore(0))
+		}
+		return NewTransport(provider, next, NewFileStore(dir, 0, 0))
+	}
+}
+
+// Stats returns the outcomes counted so far.
+func (t *Transport) Stats() Stats {
+	return Stats{Hits: t.hits.Load(), Misses: t.misses.Load()}
+}
+
+// CloseIdleConnections forwards to the wrapped transport so that
+// http.Client.CloseIdleConnections keeps working.
+func (t *Transport) CloseIdleConnections() {
+	if c, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !cacheable(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	key := cacheKey(req)
+	cached, ok := t.store.Get(key)
+	if ok {
+		req = req.Clone(req.Context())
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		t.record(true)
+		return cachedResponse(req, resp, cached), nil
+	}
+	t.record(false)
+
+	if resp.StatusCode != http.StatusOK || !storable(resp) {
+		return resp, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) > maxBodySize {
+		// Too large to store; hand the whole body on regardless.
+		return resp, nil
+	}
+
+	t.store.Set(key, &Entry{
+		URL:          req.URL.String(),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+		StoredAt:     time.Now(),
+	})
+	return resp, nil
+}
+
+func (t *Transport) record(hit bool) {
+	result := "miss"
+	if hit {
+		t.hits.Add(1)
+		result = "hit"
+	} else {
+		t.misses.Add(1)
+	}
+	metrics.APICacheRequests.With(t.provider, result).Inc()
+	metrics.APICacheHitRatio.With(t.provider).Set(t.Stats().HitRatio())
+}
+
+// cacheable reports whether req may use a stored validator. Requests that
+// already carry their own validators or ranges are left alone.
+func cacheable(req *http.Request) bool {
+	return req.Method == http.MethodGet &&
+		req.Header.Get("Range") == "" &&
+		req.Header.Get("If-None-Match") == "" &&
+		req.Header.Get("If-Modified-Since") == ""
+}
+
+func storable(resp *http.Response) bool {
+	if resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "" {
+		return false
+	}
+	return !strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-store")
+}
+
+// cacheKey identifies a resource per credential and representation, so that
+// responses are never shared between tokens.
+func cacheKey(req *http.Request) string {
+	auth := req.Header.Get("Authorization") + req.Header.Get("PRIVATE-TOKEN")
+	sum := sha256.Sum256([]byte(auth))
+	return strings.Join([]string{
+		req.URL.String(),
+		req.Header.Get("Accept"),
+		hex.EncodeToString(sum[:8]),
+	}, "\n")
+}
+
+// cachedResponse builds the response for a 304 from the stored entry. Fresh
+// headers of the 304, such as rate limit counters, override stored ones.
+func cachedResponse(req *http.Request, notModified *http.Response, e *Entry) *http.Response {
+	io.Copy(io.Discard, notModified.Body) //nolint:errcheck // draining for connection reuse
+	notModified.Body.Close()
+
+	header := e.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	for k, v := range notModified.Header {
+		header[k] = v
+	}
+	header.Set(FromCacheHeader, "1")
+
+	return &http.Response{
+		Status:        http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package httpcache
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newETagServer(t *testing.T, fullResponses *atomic.Int64) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"name":"repo"}`)
+	}))
+}
+
+func get(t *testing.T, client *http.Client, url, token string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestTransport_ServesNotModifiedFromCache(t *testing.T) {
+	var full atomic.Int64
+	srv := newETagServer(t, &full)
+	defer srv.Close()
+
+	tr := NewTransport("test", nil, NewMemoryStore(10))
+	client := &http.Client{Transport: tr}
+
+	resp, body := get(t, client, srv.URL+"/repos/a/b", "t1")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(FromCacheHeader))
+	assert.JSONEq(t, `{"name":"repo"}`, body)
+
+	resp, body = get(t, client, srv.URL+"/repos/a/b", "t1")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get(FromCacheHeader))
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "42", resp.Header.Get("X-RateLimit-Remaining"))
+	assert.JSONEq(t, `{"name":"repo"}`, body)
+
+	assert.Equal(t, int64(1), full.Load())
+	assert.Equal(t, Stats{Hits: 1, Misses: 1}, tr.Stats())
+	assert.InDelta(t, 0.5, tr.Stats().HitRatio(), 0.001)
+}
+
+func TestTransport_SeparatesCredentials(t *testing.T) {
+	var full atomic.Int64
+	srv := newETagServer(t, &full)
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewTransport("test", nil, NewMemoryStore(10))}
+	get(t, client, srv.URL+"/user", "t1")
+	resp, _ := get(t, client, srv.URL+"/user", "t2")
+
+	assert.Empty(t, resp.Header.Get(FromCacheHeader))
+	assert.Equal(t, int64(2), full.Load())
+}
+
+func TestTransport_SkipsNonGetAndUnvalidated(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	tr := NewTransport("test", nil, NewMemoryStore(10))
+	client := &http.Client{Transport: tr}
+	get(t, client, srv.URL, "")
+	get(t, client, srv.URL, "")
+	_, err := client.Post(srv.URL, "text/plain", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), calls.Load())
+	assert.Equal(t, int64(0), tr.Stats().Hits)
+}
+
+func TestFileStore_RoundTrip(t *testing.T) {
+	store := NewFileStore(t.TempDir(), 0, 0)
+	_, ok := store.Get("k")
+	assert.False(t, ok)
+
+	store.Set("k", &Entry{ETag: `"v1"`, StatusCode: 200, Body: []byte("body")})
+	e, ok := store.Get("k")
+	require.True(t, ok)
+	assert.Equal(t, `"v1"`, e.ETag)
+	assert.Equal(t, []byte("body"), e.Body)
+}
+
+func TestFileStore_ExpiresUnusedEntries(t *testing.T) {
+	store := NewFileStore(t.TempDir(), 0, time.Hour)
+	store.Set("old", &Entry{Body: []byte("old")})
+	store.Set("new", &Entry{Body: []byte("new")})
+	stale := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(store.path("old"), stale, stale))
+
+	_, ok := store.Get("old")
+	assert.False(t, ok)
+	assert.NoFileExists(t, store.path("old"))
+	_, ok = store.Get("new")
+	assert.True(t, ok)
+}
+
+func TestFileStore_CollectsLeastRecentlyUsed(t *testing.T) {
+	body := bytes.Repeat([]byte("x"), 1000)
+	dir := t.TempDir()
+	store := NewFileStore(dir, 0, 0)
+	store.Set("a", &Entry{Body: body})
+	info, err := os.Stat(store.path("a"))
+	require.NoError(t, err)
+
+	// 항목 세 개 반까지만 들어간다
+	store = NewFileStore(dir, info.Size()*7/2, 0)
+	base := time.Now().Add(-time.Hour)
+	for i, key := range []string{"a", "b", "c"} {
+		store.Set(key, &Entry{Body: body})
+		used := base.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(store.path(key), used, used))
+	}
+	// a는 사용되어 가장 최근 항목이 된다
+	_, ok := store.Get("a")
+	require.True(t, ok)
+
+	store.Set("d", &Entry{Body: body})
+	for key, kept := range map[string]bool{"a": true, "b": false, "c": true, "d": true} {
+		_, ok := store.Get(key)
+		assert.Equal(t, kept, ok, key)
+	}
+}
+
+func TestNewTransportFromEnv_WithoutHome(t *testing.T) {
+	t.Setenv(EnvCacheMode, "")
+	t.Setenv("HOME", "")
+	_, err := DefaultCacheDir()
+	require.Error(t, err)
+
+	tr, ok := NewTransportFromEnv("github", http.DefaultTransport).(*Transport)
+	require.True(t, ok)
+	assert.IsType(t, &MemoryStore{}, tr.store, "no disk cache without a home directory")
+}
+
+func TestMemoryStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	store := NewMemoryStore(2)
+	store.Set("a", &Entry{})
+	store.Set("b", &Entry{})
+	store.Get("a")
+	store.Set("c", &Entry{})
+
+	_, ok := store.Get("b")
+	assert.False(t, ok)
+	_, ok = store.Get("a")
+	assert.True(t, ok)
+}
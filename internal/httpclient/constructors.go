@@ -90,11 +90,16 @@ func NewHTTPClient(
 	}
 
 	transport := &http.Transport{
+		Proxy:               Proxy,
 		MaxIdleConns:        config.MaxIdleConns,
 		MaxConnsPerHost:     config.MaxConnsPerHost,
 		IdleConnTimeout:     config.IdleConnTimeout,
 		TLSHandshakeTimeout: config.TLSHandshakeTimeout,
 	}
+	// Configure가 이미 검증했으므로 여기서의 오류는 무시한다
+	if tlsConfig, err := TLSConfig(); err == nil {
+		transport.TLSClientConfig = tlsConfig
+	}
 
 	client := &http.Client{
 		Timeout:   config.Timeout,
@@ -0,0 +1,398 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/api"
+)
+
+// NetworkConfig describes how outbound HTTP connections are made. Empty
+// fields fall back to the environment: HTTPS_PROXY, HTTP_PROXY, ALL_PROXY and
+// NO_PROXY for proxies, and the system certificate pool for TLS.
+type NetworkConfig struct {
+	// Proxy is the proxy URL for every request; http, https, socks5 and
+	// socks5h schemes are supported.
+	Proxy string
+	// NoProxy lists hosts reached directly: host names, domain suffixes
+	// (".corp.example.com"), IP addresses, CIDR ranges, host:port pairs or
+	// "*" for all hosts.
+	NoProxy []string
+	// CABundle is a PEM file of additional trusted root certificates, such
+	// as a corporate TLS inspection CA.
+	CABundle string
+	// ClientCert and ClientKey are PEM files of a client certificate for
+	// mutual TLS.
+	ClientCert string
+	ClientKey  string
+	// DNSServers are resolvers queried instead of the system ones, as
+	// "ip" or "ip:port", for split-horizon names only resolvable on the
+	// corporate network.
+	DNSServers []string
+	// Hosts maps host names to IP addresses, like /etc/hosts, taking
+	// precedence over DNS.
+	Hosts map[string]string
+	// VPNHosts lists hosts only reachable through a VPN, with the same
+	// syntax as NoProxy host entries; "*" marks every host.
+	VPNHosts []string
+	// VPNInterfaces are name patterns ("utun*", "wg0") of the interfaces
+	// that indicate an active VPN. Empty uses DefaultVPNInterfaces.
+	VPNInterfaces []string
+}
+
+// IsZero reports whether nothing is configured.
+func (c NetworkConfig) IsZero() bool {
+	return c.Proxy == "" && len(c.NoProxy) == 0 && c.CABundle == "" && c.ClientCert == "" && c.ClientKey == "" &&
+		len(c.DNSServers) == 0 && len(c.Hosts) == 0 && len(c.VPNHosts) == 0 && len(c.VPNInterfaces) == 0
+}
+
+// Merge returns c with the fields set in override replacing its own. Host
+// overrides are combined, with those of override winning.
+func (c NetworkConfig) Merge(override NetworkConfig) NetworkConfig {
+	if override.Proxy != "" {
+		c.Proxy = override.Proxy
+	}
+	if override.NoProxy != nil {
+		c.NoProxy = override.NoProxy
+	}
+	if override.CABundle != "" {
+		c.CABundle = override.CABundle
+	}
+	if override.ClientCert != "" || override.ClientKey != "" {
+		c.ClientCert, c.ClientKey = override.ClientCert, override.ClientKey
+	}
+	if override.DNSServers != nil {
+		c.DNSServers = override.DNSServers
+	}
+	if len(override.Hosts) > 0 {
+		hosts := make(map[string]string, len(c.Hosts)+len(override.Hosts))
+		for host, ip := range c.Hosts {
+			hosts[host] = ip
+		}
+		for host, ip := range override.Hosts {
+			hosts[host] = ip
+		}
+		c.Hosts = hosts
+	}
+	if override.VPNHosts != nil {
+		c.VPNHosts = override.VPNHosts
+	}
+	if override.VPNInterfaces != nil {
+		c.VPNInterfaces = override.VPNInterfaces
+	}
+	return c
+}
+
+// Validate checks the proxy URL, that client certificate and key are given
+// together, and the addresses and interface patterns of the name
+// resolution and VPN settings.
+func (c NetworkConfig) Validate() error {
+	if c.Proxy != "" {
+		if _, err := parseProxyURL(c.Proxy); err != nil {
+			return err
+		}
+	}
+	if (c.ClientCert == "") != (c.ClientKey == "") {
+		return fmt.Errorf("client certificate and key must be configured together")
+	}
+	for _, server := range c.DNSServers {
+		if _, err := dnsServerAddr(server); err != nil {
+			return err
+		}
+	}
+	for host, ip := range c.Hosts {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("invalid IP address %q for host %s", ip, host)
+		}
+	}
+	for _, pattern := range c.VPNInterfaces {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid VPN interface pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// baseTransport is the pristine default transport, captured before
+// Configure replaces http.DefaultTransport.
+var baseTransport = http.DefaultTransport.(*http.Transport).Clone()
+
+var (
+	networkMu          sync.RWMutex
+	defaultNetwork     NetworkConfig
+	providerNetworks   = map[string]NetworkConfig{}
+	providerTransports = map[string]*http.Transport{}
+)
+
+// Configure installs the network settings for the process. The global
+// transport replaces http.DefaultTransport, so clients without an explicit
+// transport, including those of third-party libraries, use it as well.
+// Providers listed in overrides get their own transport from
+// ProviderTransport, built from the global settings merged with the
+// override.
+func Configure(global NetworkConfig, overrides map[string]NetworkConfig) error {
+	transport, err := NewTransport(global)
+	if err != nil {
+		return err
+	}
+
+	configs := make(map[string]NetworkConfig, len(overrides))
+	transports := make(map[string]*http.Transport, len(overrides))
+	for provider, override := range overrides {
+		cfg := global.Merge(override)
+		t, err := NewTransport(cfg)
+		if err != nil {
+			return fmt.Errorf("network settings of %s: %w", provider, err)
+		}
+		configs[provider] = cfg
+		transports[provider] = t
+	}
+
+	networkMu.Lock()
+	defer networkMu.Unlock()
+	defaultNetwork = global
+	providerNetworks = configs
+	providerTransports = transports
+	http.DefaultTransport = transport
+	return nil
+}
+
+// ProviderTransport returns the transport for a Git provider: its override
+// transport when one is configured, http.DefaultTransport otherwise.
+func ProviderTransport(provider string) http.RoundTripper {
+	networkMu.RLock()
+	defer networkMu.RUnlock()
+	if t, ok := providerTransports[provider]; ok {
+		return t
+	}
+	return http.DefaultTransport
+}
+
+// ProviderNetwork returns the effective network settings of a Git provider:
+// the global settings merged with its override.
+func ProviderNetwork(provider string) NetworkConfig {
+	networkMu.RLock()
+	defer networkMu.RUnlock()
+	if cfg, ok := providerNetworks[provider]; ok {
+		return cfg
+	}
+	return defaultNetwork
+}
+
+// NewProviderClient returns an HTTP client for a Git provider that honors
+// the configured proxy, CA bundle and client certificate. Its calls are
+// recorded for `gz debug api-usage`.
+func NewProviderClient(provider string, timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: api.Transport(provider, ProviderTransport(provider))}
+}
+
+// Proxy is a drop-in replacement for http.ProxyFromEnvironment that honors
+// the configured global proxy settings. Transports built outside this
+// package use it so they do not bypass the proxy.
+func Proxy(req *http.Request) (*url.URL, error) {
+	networkMu.RLock()
+	cfg := defaultNetwork
+	networkMu.RUnlock()
+	return proxyFunc(cfg)(req)
+}
+
+// TLSConfig returns the TLS settings of the global configuration, for
+// transports built outside this package. It is nil when only system roots
+// are trusted and no client certificate is configured.
+func TLSConfig() (*tls.Config, error) {
+	networkMu.RLock()
+	cfg := defaultNetwork
+	networkMu.RUnlock()
+	return newTLSConfig(cfg)
+}
+
+// NewTransport builds a transport from the default transport settings and
+// cfg.
+func NewTransport(cfg NetworkConfig) (*http.Transport, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	t := baseTransport.Clone()
+	t.Proxy = proxyFunc(cfg)
+	if len(cfg.Hosts) > 0 || len(cfg.DNSServers) > 0 {
+		t.DialContext = cfg.DialFunc(&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second})
+	}
+	if tlsConfig != nil {
+		t.TLSClientConfig = tlsConfig
+	}
+	return t, nil
+}
+
+func newTLSConfig(cfg NetworkConfig) (*tls.Config, error) {
+	if cfg.CABundle == "" && cfg.ClientCert == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CABundle != "" {
+		pem, err := os.ReadFile(cfg.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		// 시스템 루트를 유지한 채 사내 CA를 추가한다
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", cfg.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCert, cfg.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// proxyFunc returns the proxy selector for cfg. Without a configured proxy
+// the environment is read on every call, like http.ProxyFromEnvironment
+// but including ALL_PROXY, which carries SOCKS proxies by convention.
+func proxyFunc(cfg NetworkConfig) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		noProxy := cfg.NoProxy
+		if noProxy == nil {
+			noProxy = splitList(getenvAny("NO_PROXY", "no_proxy"))
+		}
+		if bypassProxy(req.URL, noProxy) {
+			return nil, nil
+		}
+
+		raw := cfg.Proxy
+		if raw == "" {
+			if req.URL.Scheme == "https" {
+				raw = getenvAny("HTTPS_PROXY", "https_proxy")
+			} else if os.Getenv("REQUEST_METHOD") == "" {
+				// CGI 환경에서는 HTTP_PROXY가 요청 헤더에서 올 수 있어 무시한다
+				raw = getenvAny("HTTP_PROXY", "http_proxy")
+			}
+			if raw == "" {
+				raw = getenvAny("ALL_PROXY", "all_proxy")
+			}
+		}
+		if raw == "" {
+			return nil, nil
+		}
+		return parseProxyURL(raw)
+	}
+}
+
+func parseProxyURL(raw string) (*url.URL, error) {
+	// "proxy.corp:3128"처럼 스킴 없이 적은 값은 HTTP 프록시로 본다
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", raw)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return u, nil
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (supported: http, https, socks5, socks5h)", u.Scheme)
+	}
+}
+
+// bypassProxy reports whether u matches a NO_PROXY entry.
+func bypassProxy(u *url.URL, noProxy []string) bool {
+	host := u.Hostname()
+	port := u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[u.Scheme]
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip != nil && ip.IsLoopback() {
+		return true
+	}
+
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		entryHost, entryPort := entry, ""
+		if h, p, err := net.SplitHostPort(entry); err == nil {
+			entryHost, entryPort = h, p
+		}
+		if entryPort != "" && entryPort != port {
+			continue
+		}
+		if ip != nil {
+			if entryIP := net.ParseIP(entryHost); entryIP != nil && entryIP.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		h := strings.ToLower(host)
+		suffix := strings.TrimPrefix(entryHost, "*")
+		if strings.HasPrefix(suffix, ".") {
+			if strings.HasSuffix(h, suffix) || h == suffix[1:] {
+				return true
+			}
+			continue
+		}
+		if h == suffix || strings.HasSuffix(h, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func getenvAny(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
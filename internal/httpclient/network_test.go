@@ -0,0 +1,225 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreNetwork undoes Configure after a test.
+func restoreNetwork(t *testing.T) {
+	t.Helper()
+	saved := http.DefaultTransport
+	t.Cleanup(func() {
+		networkMu.Lock()
+		defer networkMu.Unlock()
+		http.DefaultTransport = saved
+		defaultNetwork = NetworkConfig{}
+		providerNetworks = map[string]NetworkConfig{}
+		providerTransports = map[string]*http.Transport{}
+	})
+}
+
+func clearProxyEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy", "ALL_PROXY", "all_proxy", "NO_PROXY", "no_proxy", "REQUEST_METHOD"} {
+		t.Setenv(name, "")
+	}
+}
+
+func TestBypassProxy(t *testing.T) {
+	noProxy := []string{".corp.example.com", "git.internal", "10.0.0.0/8", "192.168.1.5", "api.example.org:8443"}
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://localhost/api", true},
+		{"http://127.0.0.1:8080", true},
+		{"https://gitlab.corp.example.com/api", true},
+		{"https://corp.example.com", true},
+		{"https://git.internal/api", true},
+		{"https://mirror.git.internal/api", true},
+		{"https://notgit.internal/api", false},
+		{"http://10.1.2.3/x", true},
+		{"http://192.168.1.5/x", true},
+		{"http://192.168.1.6/x", false},
+		{"https://api.example.org:8443/x", true},
+		{"https://api.example.org/x", false},
+		{"https://api.github.com", false},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, bypassProxy(u, noProxy), tt.url)
+	}
+
+	u, _ := url.Parse("https://api.github.com")
+	assert.True(t, bypassProxy(u, []string{"*"}))
+}
+
+func TestProxyFunc(t *testing.T) {
+	clearProxyEnv(t)
+	proxyFor := func(cfg NetworkConfig, rawURL string) string {
+		req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+		require.NoError(t, err)
+		u, err := proxyFunc(cfg)(req)
+		require.NoError(t, err)
+		if u == nil {
+			return ""
+		}
+		return u.String()
+	}
+
+	assert.Empty(t, proxyFor(NetworkConfig{}, "https://api.github.com"))
+
+	t.Setenv("HTTPS_PROXY", "http://secure-proxy:3128")
+	t.Setenv("HTTP_PROXY", "http://plain-proxy:3128")
+	t.Setenv("NO_PROXY", "internal.example.com")
+	assert.Equal(t, "http://secure-proxy:3128", proxyFor(NetworkConfig{}, "https://api.github.com"))
+	assert.Equal(t, "http://plain-proxy:3128", proxyFor(NetworkConfig{}, "http://api.github.com"))
+	assert.Empty(t, proxyFor(NetworkConfig{}, "https://git.internal.example.com"))
+
+	// 설정 파일의 프록시와 NO_PROXY가 환경 변수보다 우선한다
+	cfg := NetworkConfig{Proxy: "socks5://socks.corp:1080", NoProxy: []string{"github.com"}}
+	assert.Empty(t, proxyFor(cfg, "https://api.github.com"))
+	assert.Equal(t, "socks5://socks.corp:1080", proxyFor(cfg, "https://git.internal.example.com"))
+
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("ALL_PROXY", "socks5h://all-proxy:1080")
+	assert.Equal(t, "socks5h://all-proxy:1080", proxyFor(NetworkConfig{}, "https://api.github.com"))
+	assert.Equal(t, "http://proxy.corp:3128", proxyFor(NetworkConfig{Proxy: "proxy.corp:3128"}, "https://api.github.com"))
+}
+
+func TestNetworkConfigValidateAndMerge(t *testing.T) {
+	assert.NoError(t, NetworkConfig{Proxy: "https://proxy:443"}.Validate())
+	assert.Error(t, NetworkConfig{Proxy: "ftp://proxy:21"}.Validate())
+	assert.Error(t, NetworkConfig{ClientCert: "cert.pem"}.Validate())
+
+	global := NetworkConfig{Proxy: "http://proxy:3128", NoProxy: []string{"a"}, CABundle: "ca.pem"}
+	merged := global.Merge(NetworkConfig{Proxy: "socks5://other:1080", ClientCert: "c.pem", ClientKey: "k.pem"})
+	assert.Equal(t, NetworkConfig{
+		Proxy:      "socks5://other:1080",
+		NoProxy:    []string{"a"},
+		CABundle:   "ca.pem",
+		ClientCert: "c.pem",
+		ClientKey:  "k.pem",
+	}, merged)
+	assert.True(t, NetworkConfig{}.IsZero())
+}
+
+func TestConfigureProxy(t *testing.T) {
+	clearProxyEnv(t)
+	restoreNetwork(t)
+
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		_, _ = io.WriteString(w, "via proxy")
+	}))
+	defer proxy.Close()
+
+	require.NoError(t, Configure(NetworkConfig{}, map[string]NetworkConfig{"gitea": {Proxy: proxy.URL}}))
+
+	resp, err := NewProviderClient("gitea", 5*time.Second).Get("http://gitea.example.invalid/api/v1/version")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "via proxy", string(body))
+	assert.Equal(t, "http://gitea.example.invalid/api/v1/version", proxied)
+
+	// 재정의가 없는 프로바이더는 전역 설정(프록시 없음)을 따른다
+	assert.Same(t, http.DefaultTransport, ProviderTransport("github"))
+	assert.Error(t, Configure(NetworkConfig{Proxy: "gopher://x"}, nil))
+}
+
+func TestConfigureCABundleAndClientCert(t *testing.T) {
+	clearProxyEnv(t)
+	restoreNetwork(t)
+	dir := t.TempDir()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			http.Error(w, "client certificate required", http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	caPath := filepath.Join(dir, "ca.pem")
+	writePEM(t, caPath, "CERTIFICATE", server.Certificate().Raw)
+	certPath, keyPath := writeClientCert(t, dir, "gz-client")
+
+	// 시스템 루트만으로는 테스트 서버를 신뢰하지 않는다
+	require.NoError(t, Configure(NetworkConfig{}, nil))
+	_, err := (&http.Client{Timeout: 5 * time.Second}).Get(server.URL)
+	require.Error(t, err)
+
+	require.NoError(t, Configure(NetworkConfig{CABundle: caPath}, map[string]NetworkConfig{
+		"gitlab": {ClientCert: certPath, ClientKey: keyPath},
+	}))
+
+	resp, err := (&http.Client{Timeout: 5 * time.Second}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, err = NewProviderClient("gitlab", 5*time.Second).Get(server.URL)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "gz-client", string(body))
+
+	assert.Error(t, Configure(NetworkConfig{CABundle: filepath.Join(dir, "missing.pem")}, nil))
+	assert.Error(t, Configure(NetworkConfig{}, map[string]NetworkConfig{"gitlab": {ClientCert: caPath, ClientKey: caPath}}))
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+}
+
+// writeClientCert writes a self-signed client certificate and its key.
+func writeClientCert(t *testing.T, dir, commonName string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath, keyPath := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	writePEM(t, certPath, "CERTIFICATE", der)
+	writePEM(t, keyPath, "EC PRIVATE KEY", keyDER)
+	return certPath, keyPath
+}
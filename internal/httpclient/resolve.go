@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// dnsServerAddr returns server as host:port, adding the DNS port when it
+// is missing.
+func dnsServerAddr(server string) (string, error) {
+	if ip := net.ParseIP(strings.Trim(server, "[]")); ip != nil {
+		return net.JoinHostPort(ip.String(), "53"), nil
+	}
+	host, port, err := net.SplitHostPort(server)
+	if err != nil || net.ParseIP(host) == nil || port == "" {
+		return "", fmt.Errorf("invalid DNS server %q: use an IP address with an optional port", server)
+	}
+	return server, nil
+}
+
+// hostOverride returns the IP address configured for host in Hosts.
+func (c NetworkConfig) hostOverride(host string) (string, bool) {
+	if len(c.Hosts) == 0 {
+		return "", false
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for name, ip := range c.Hosts {
+		if strings.TrimSuffix(strings.ToLower(name), ".") == host {
+			return ip, true
+		}
+	}
+	return "", false
+}
+
+// Resolver returns a resolver that queries DNSServers, or the system
+// resolver when none are configured. Queries rotate over the servers so
+// that a dead one does not fail every lookup.
+func (c NetworkConfig) Resolver() *net.Resolver {
+	servers := make([]string, 0, len(c.DNSServers))
+	for _, server := range c.DNSServers {
+		if addr, err := dnsServerAddr(server); err == nil {
+			servers = append(servers, addr)
+		}
+	}
+	if len(servers) == 0 {
+		return net.DefaultResolver
+	}
+
+	var next atomic.Uint32
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{Timeout: 5 * time.Second}
+			start := int(next.Add(1))
+			var lastErr error
+			for i := range servers {
+				conn, err := dialer.DialContext(ctx, network, servers[(start+i)%len(servers)])
+				if err == nil {
+					return conn, nil
+				}
+				lastErr = err
+			}
+			return nil, lastErr
+		},
+	}
+}
+
+// LookupHost resolves host through Hosts and then Resolver.
+func (c NetworkConfig) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if ip, ok := c.hostOverride(host); ok {
+		return []string{ip}, nil
+	}
+	return c.Resolver().LookupHost(ctx, host)
+}
+
+// DialFunc returns a dial function for transports that connects through
+// dialer after mapping the host with Hosts and resolving it with
+// DNSServers. Requests through a proxy only apply them to the proxy host;
+// the proxy resolves the target itself.
+func (c NetworkConfig) DialFunc(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	d := *dialer
+	d.Resolver = c.Resolver()
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if host, port, err := net.SplitHostPort(addr); err == nil {
+			if ip, ok := c.hostOverride(host); ok {
+				addr = net.JoinHostPort(ip, port)
+			}
+		}
+		return d.DialContext(ctx, network, addr)
+	}
+}
+
+// GitResolveArgs returns git options that pin the HTTP(S) hosts of rawURLs
+// to the addresses the network settings of provider resolve them to, so
+// that git clones follow Hosts and DNSServers like the API clients do. It is
+// empty when neither is configured; hosts that fail to resolve are left to
+// git. The options need git 2.37 or newer and are ignored by older
+// versions and SSH URLs.
+func GitResolveArgs(ctx context.Context, provider string, rawURLs ...string) []string {
+	cfg := ProviderNetwork(provider)
+	if len(cfg.Hosts) == 0 && len(cfg.DNSServers) == 0 {
+		return nil
+	}
+
+	var args []string
+	seen := make(map[string]bool)
+	for _, raw := range rawURLs {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Hostname() == "" {
+			continue
+		}
+		port := u.Port()
+		if port == "" {
+			port = map[string]string{"http": "80", "https": "443"}[u.Scheme]
+		}
+		key := u.Hostname() + ":" + port
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		addrs, err := cfg.LookupHost(ctx, u.Hostname())
+		if err != nil || len(addrs) == 0 {
+			continue
+		}
+		ip := addrs[0]
+		if strings.Contains(ip, ":") {
+			ip = "[" + ip + "]"
+		}
+		args = append(args, "-c", fmt.Sprintf("http.curloptResolve=%s:%s", key, ip))
+	}
+	return args
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkConfigValidateResolution(t *testing.T) {
+	assert.NoError(t, NetworkConfig{DNSServers: []string{"10.0.0.53", "10.0.0.54:5353", "fd00::53", "[fd00::53]:53"}}.Validate())
+	assert.Error(t, NetworkConfig{DNSServers: []string{"dns.corp"}}.Validate())
+	assert.NoError(t, NetworkConfig{Hosts: map[string]string{"git.corp": "10.1.2.3"}}.Validate())
+	assert.Error(t, NetworkConfig{Hosts: map[string]string{"git.corp": "git.other"}}.Validate())
+	assert.Error(t, NetworkConfig{VPNInterfaces: []string{"tun["}}.Validate())
+
+	global := NetworkConfig{Hosts: map[string]string{"a": "10.0.0.1", "b": "10.0.0.2"}, VPNHosts: []string{".corp"}}
+	merged := global.Merge(NetworkConfig{Hosts: map[string]string{"b": "10.0.0.3"}, DNSServers: []string{"10.0.0.53"}})
+	assert.Equal(t, map[string]string{"a": "10.0.0.1", "b": "10.0.0.3"}, merged.Hosts)
+	assert.Equal(t, []string{"10.0.0.53"}, merged.DNSServers)
+	assert.Equal(t, []string{".corp"}, merged.VPNHosts)
+	assert.Equal(t, map[string]string{"a": "10.0.0.1", "b": "10.0.0.2"}, global.Hosts, "merge must not modify the global hosts")
+	assert.False(t, NetworkConfig{VPNHosts: []string{"*"}}.IsZero())
+}
+
+func TestLookupHost(t *testing.T) {
+	cfg := NetworkConfig{Hosts: map[string]string{"Git.Corp.Example.com": "10.1.2.3"}}
+	addrs, err := cfg.LookupHost(context.Background(), "git.corp.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.1.2.3"}, addrs)
+
+	assert.Same(t, net.DefaultResolver, NetworkConfig{}.Resolver())
+	resolver := NetworkConfig{DNSServers: []string{"10.0.0.53"}}.Resolver()
+	assert.NotSame(t, net.DefaultResolver, resolver)
+	assert.True(t, resolver.PreferGo)
+}
+
+func TestConfigureHostOverride(t *testing.T) {
+	clearProxyEnv(t)
+	restoreNetwork(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Host)
+	}))
+	defer srv.Close()
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	require.NoError(t, Configure(NetworkConfig{}, map[string]NetworkConfig{
+		"gitlab": {Hosts: map[string]string{"gitlab.corp.invalid": "127.0.0.1"}},
+	}))
+
+	resp, err := NewProviderClient("gitlab", 5*time.Second).Get("http://gitlab.corp.invalid:" + port + "/api/v4/version")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "gitlab.corp.invalid:"+port, string(body), "the Host header keeps the name")
+
+	_, err = NewProviderClient("github", 5*time.Second).Get("http://gitlab.corp.invalid:" + port + "/")
+	assert.Error(t, err, "other providers do not see the override")
+}
+
+func TestGitResolveArgs(t *testing.T) {
+	restoreNetwork(t)
+	ctx := context.Background()
+
+	assert.Empty(t, GitResolveArgs(ctx, "gitlab", "https://gitlab.corp.invalid/a/b.git"))
+
+	require.NoError(t, Configure(NetworkConfig{}, map[string]NetworkConfig{
+		"gitlab": {Hosts: map[string]string{"gitlab.corp.invalid": "10.1.2.3", "v6.corp.invalid": "fd00::1"}},
+	}))
+	args := GitResolveArgs(ctx, "gitlab",
+		"https://gitlab.corp.invalid/a/b.git",
+		"https://gitlab.corp.invalid/a/c.git",
+		"http://v6.corp.invalid:8080/a/d.git",
+		"git@gitlab.corp.invalid:a/e.git",
+	)
+	assert.Equal(t, []string{
+		"-c", "http.curloptResolve=gitlab.corp.invalid:443:10.1.2.3",
+		"-c", "http.curloptResolve=v6.corp.invalid:8080:[fd00::1]",
+	}, args)
+}
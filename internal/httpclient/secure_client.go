@@ -8,9 +8,12 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gizzahub/gzh-cli/internal/constants"
+	"github.com/gizzahub/gzh-cli/internal/httpcache"
+	"github.com/gizzahub/gzh-cli/internal/metrics"
 )
 
 // SecureClientConfig defines configuration for secure HTTP clients.
@@ -115,6 +118,7 @@ func (f *SecureHTTPClientFactory) CreateClient() *http.Client {
 	// Create custom transport with security settings
 	transport := &http.Transport{
 		// Connection settings
+		Proxy: Proxy,
 		DialContext: (&net.Dialer{
 			Timeout:   f.config.DialTimeout,
 			KeepAlive: f.config.KeepAlive,
@@ -145,6 +149,11 @@ func (f *SecureHTTPClientFactory) CreateClient() *http.Client {
 		DisableCompression: false,
 		ForceAttemptHTTP2:  true,
 	}
+	// 사내 CA와 클라이언트 인증서는 전역 네트워크 설정을 따른다
+	if network, err := TLSConfig(); err == nil && network != nil {
+		transport.TLSClientConfig.RootCAs = network.RootCAs
+		transport.TLSClientConfig.Certificates = network.Certificates
+	}
 
 	client := &http.Client{
 		Transport: transport,
@@ -227,6 +236,7 @@ func (rt *SecureRoundTripper) RoundTrip(req *http.Request) (*http.Response, erro
 
 // ClientPool manages HTTP client instances for connection reusing.
 type ClientPool struct {
+	mu      sync.Mutex
 	clients map[string]*http.Client
 	factory *SecureHTTPClientFactory
 }
@@ -239,8 +249,12 @@ func NewClientPool() *ClientPool {
 	}
 }
 
-// GetClient returns a cached client or creates a new one.
+// GetClient returns a cached client or creates a new one. GitHub and GitLab
+// clients send conditional requests with cached ETags (see GZH_API_CACHE).
 func (p *ClientPool) GetClient(clientType string) *http.Client {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if client, exists := p.clients[clientType]; exists {
 		return client
 	}
@@ -259,6 +273,10 @@ func (p *ClientPool) GetClient(clientType string) *http.Client {
 
 	factory := NewSecureHTTPClientFactory(config)
 	client := factory.CreateClient()
+	client.Transport = metrics.InstrumentTransport(clientType, client.Transport)
+	if clientType == "github" || clientType == "gitlab" {
+		client.Transport = httpcache.NewTransportFromEnv(clientType, client.Transport)
+	}
 	p.clients[clientType] = client
 
 	return client
@@ -266,10 +284,11 @@ func (p *ClientPool) GetClient(clientType string) *http.Client {
 
 // CloseIdleConnections closes idle connections for all clients.
 func (p *ClientPool) CloseIdleConnections() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	for _, client := range p.clients {
-		if transport, ok := client.Transport.(*http.Transport); ok {
-			transport.CloseIdleConnections()
-		}
+		client.CloseIdleConnections()
 	}
 }
 
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DefaultVPNInterfaces match the tunnel devices of common VPN clients:
+// OpenVPN, WireGuard, IPsec, PPP, GlobalProtect, AnyConnect, Tailscale,
+// ZeroTier and the macOS utun devices.
+var DefaultVPNInterfaces = []string{
+	"utun*", "tun*", "tap*", "wg*", "ppp*", "ipsec*", "gpd*", "cscotun*", "tailscale*", "zt*",
+}
+
+// netInterface is the part of a network interface that tunnel detection
+// looks at.
+type netInterface struct {
+	Name  string
+	Up    bool
+	Addrs []net.IP
+}
+
+// listInterfaces returns the interfaces of the host; replaced in tests.
+var listInterfaces = func() ([]netInterface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]netInterface, 0, len(ifaces))
+	for _, iface := range ifaces {
+		ni := netInterface{Name: iface.Name, Up: iface.Flags&net.FlagUp != 0}
+		addrs, _ := iface.Addrs()
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok {
+				ni.Addrs = append(ni.Addrs, ipNet.IP)
+			}
+		}
+		out = append(out, ni)
+	}
+	return out, nil
+}
+
+// ActiveTunnels returns the names of the interfaces that match patterns
+// (DefaultVPNInterfaces when empty), are up and carry a routable address.
+// macOS keeps idle utun devices with only link-local addresses around, so
+// those do not count.
+func ActiveTunnels(patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		patterns = DefaultVPNInterfaces
+	}
+	ifaces, err := listInterfaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	var active []string
+	for _, iface := range ifaces {
+		if !iface.Up || !matchesAny(iface.Name, patterns) {
+			continue
+		}
+		for _, ip := range iface.Addrs {
+			if !ip.IsLinkLocalUnicast() && !ip.IsLoopback() {
+				active = append(active, iface.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(active)
+	return active, nil
+}
+
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresVPN reports whether host matches an entry of VPNHosts.
+func (c NetworkConfig) RequiresVPN(host string) bool {
+	if len(c.VPNHosts) == 0 || host == "" {
+		return false
+	}
+	u := &url.URL{Scheme: "https", Host: host}
+	return bypassProxy(u, c.VPNHosts) && !isLocal(u.Hostname())
+}
+
+// isLocal reports whether host is localhost or a loopback address, which
+// bypassProxy always matches.
+func isLocal(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// VPNRequiredError reports a host that is only reachable through a VPN
+// while no tunnel interface is active.
+type VPNRequiredError struct {
+	Host string
+	// Patterns are the interface patterns that were looked for.
+	Patterns []string
+}
+
+func (e *VPNRequiredError) Error() string {
+	return fmt.Sprintf("host %s requires VPN: no active tunnel interface matching %s",
+		e.Host, strings.Join(e.Patterns, ", "))
+}
+
+// CheckVPN returns a *VPNRequiredError for the first of hosts that requires
+// a VPN when no tunnel interface is active. Hosts may carry a port.
+func (c NetworkConfig) CheckVPN(hosts ...string) error {
+	var required string
+	for _, host := range hosts {
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if c.RequiresVPN(host) {
+			required = host
+			break
+		}
+	}
+	if required == "" {
+		return nil
+	}
+
+	tunnels, err := ActiveTunnels(c.VPNInterfaces)
+	if err != nil {
+		return err
+	}
+	if len(tunnels) > 0 {
+		return nil
+	}
+	patterns := c.VPNInterfaces
+	if len(patterns) == 0 {
+		patterns = DefaultVPNInterfaces
+	}
+	return &VPNRequiredError{Host: required, Patterns: patterns}
+}
+
+// CheckProviderVPN runs CheckVPN with the network settings of provider.
+// Bulk operations call it before starting so that a missing VPN is
+// reported up front rather than as a failure of every repository.
+func CheckProviderVPN(provider string, hosts ...string) error {
+	return ProviderNetwork(provider).CheckVPN(hosts...)
+}
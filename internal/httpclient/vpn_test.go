@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package httpclient
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeInterfaces(t *testing.T, ifaces ...netInterface) {
+	t.Helper()
+	saved := listInterfaces
+	listInterfaces = func() ([]netInterface, error) { return ifaces, nil }
+	t.Cleanup(func() { listInterfaces = saved })
+}
+
+func TestActiveTunnels(t *testing.T) {
+	fakeInterfaces(t,
+		netInterface{Name: "en0", Up: true, Addrs: []net.IP{net.ParseIP("192.168.1.10")}},
+		netInterface{Name: "utun0", Up: true, Addrs: []net.IP{net.ParseIP("fe80::1")}},
+		netInterface{Name: "utun4", Up: true, Addrs: []net.IP{net.ParseIP("10.8.0.2")}},
+		netInterface{Name: "wg0", Up: false, Addrs: []net.IP{net.ParseIP("10.9.0.2")}},
+	)
+
+	tunnels, err := ActiveTunnels(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"utun4"}, tunnels, "idle link-local and down interfaces do not count")
+
+	tunnels, err = ActiveTunnels([]string{"en*"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en0"}, tunnels)
+}
+
+func TestRequiresVPN(t *testing.T) {
+	cfg := NetworkConfig{VPNHosts: []string{".corp.example.com", "10.0.0.0/8"}}
+	assert.True(t, cfg.RequiresVPN("gitlab.corp.example.com"))
+	assert.True(t, cfg.RequiresVPN("10.1.2.3"))
+	assert.False(t, cfg.RequiresVPN("github.com"))
+	assert.False(t, NetworkConfig{}.RequiresVPN("gitlab.corp.example.com"))
+
+	all := NetworkConfig{VPNHosts: []string{"*"}}
+	assert.True(t, all.RequiresVPN("git.internal"))
+	assert.False(t, all.RequiresVPN("localhost"))
+}
+
+func TestCheckVPN(t *testing.T) {
+	restoreNetwork(t)
+	fakeInterfaces(t, netInterface{Name: "en0", Up: true, Addrs: []net.IP{net.ParseIP("192.168.1.10")}})
+	require.NoError(t, Configure(NetworkConfig{}, map[string]NetworkConfig{
+		"gitlab": {VPNHosts: []string{"*"}, VPNInterfaces: []string{"gpd*"}},
+	}))
+
+	assert.NoError(t, CheckProviderVPN("github", "github.com"))
+	err := CheckProviderVPN("gitlab", "github.com", "gitlab.corp.example.com:443")
+	var vpnErr *VPNRequiredError
+	require.True(t, errors.As(err, &vpnErr))
+	assert.Equal(t, "github.com", vpnErr.Host)
+	assert.Contains(t, err.Error(), "host github.com requires VPN")
+	assert.Contains(t, err.Error(), "gpd*")
+
+	fakeInterfaces(t, netInterface{Name: "gpd0", Up: true, Addrs: []net.IP{net.ParseIP("10.20.0.5")}})
+	assert.NoError(t, CheckProviderVPN("gitlab", "gitlab.corp.example.com"))
+}
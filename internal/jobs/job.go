@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package jobs runs long bulk operations such as organization clones in the
+// background. Jobs are queued on a worker pool, persisted to disk so that
+// their outcome survives restarts, and publish progress events to
+// subscribers.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+)
+
+// Status is the lifecycle state of a job.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCanceled  Status = "canceled"
+)
+
+// Done reports whether the status is final.
+func (s Status) Done() bool {
+	return s == StatusSucceeded || s == StatusFailed || s == StatusCanceled
+}
+
+var (
+	// ErrNotFound is returned for unknown job IDs.
+	ErrNotFound = errors.New("job not found")
+	// ErrQueueFull is returned when the queue cannot take another job.
+	ErrQueueFull = errors.New("job queue is full")
+	// ErrFinished is returned when canceling a job that already ended.
+	ErrFinished = errors.New("job already finished")
+	// ErrUnknownType is returned when no runner is registered for a type.
+	ErrUnknownType = errors.New("unknown job type")
+	// ErrNotRunning is returned by Check before Start and after Stop.
+	ErrNotRunning = errors.New("job workers are not running")
+)
+
+// Progress counts the items a job has processed. Runners report it through
+// the "start", "success", "fail" and "skip" events.
+type Progress struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+}
+
+// Job is a snapshot of a submitted job.
+type Job struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	Status Status          `json:"status"`
+	Params json.RawMessage `json:"params,omitempty"`
+	// Priority is the worker pool class: interactive for API submissions,
+	// scheduled for schedule runs.
+	Priority   string     `json:"priority,omitempty"`
+	Progress   Progress   `json:"progress"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// Runner executes jobs of one type.
+type Runner interface {
+	// Validate checks the parameters at submission time so that bad
+	// requests are rejected before a job is created.
+	Validate(params json.RawMessage) error
+	// Run performs the job, reporting progress through emit, which is safe
+	// for concurrent use. Run must return promptly once ctx is canceled.
+	Run(ctx context.Context, params json.RawMessage, emit func(cli.Event)) error
+}
+
+// applyEvent updates the progress counters from a runner event.
+func (p *Progress) applyEvent(event cli.Event) {
+	switch event.Type {
+	case "start":
+		if total, ok := event.Data["total"].(int); ok {
+			p.Total = total
+		}
+	case "success":
+		p.Completed++
+	case "fail":
+		p.Failed++
+	case "skip":
+		p.Skipped++
+	}
+}
@@ -0,0 +1,469 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/internal/workerpool"
+)
+
+const (
+	// DefaultMaxEvents is the number of recent events kept per job.
+	DefaultMaxEvents = 500
+	// saveEvery persists a running job after this many events.
+	saveEvery = 25
+	// subscriberBuffer is the number of events a slow subscriber may lag
+	// behind before events are dropped for it.
+	subscriberBuffer = 64
+)
+
+// Config configures a Manager.
+type Config struct {
+	// Dir stores one file per job.
+	Dir string
+	// Workers is the number of jobs run at the same time.
+	Workers int
+	// QueueSize is the number of jobs that may wait for a worker.
+	QueueSize int
+	// JobTimeout bounds the run time of a single job.
+	JobTimeout time.Duration
+	// MaxEvents is the number of recent events kept per job; 0 uses
+	// DefaultMaxEvents.
+	MaxEvents int
+}
+
+// Manager queues, runs and tracks jobs. It is safe for concurrent use.
+type Manager struct {
+	cfg     Config
+	store   *store
+	pool    *workerpool.Pool[string]
+	runners map[string]Runner
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	running bool
+}
+
+// entry is the in-memory state of a job.
+type entry struct {
+	job         Job
+	events      []cli.Event
+	unsaved     int
+	cancel      context.CancelFunc
+	cancelAsked bool
+	subscribers map[chan cli.Event]struct{}
+}
+
+// NewManager creates a manager. Register runners, then call Start.
+func NewManager(cfg Config) (*Manager, error) {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 2
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 100
+	}
+	if cfg.JobTimeout <= 0 {
+		cfg.JobTimeout = 2 * time.Hour
+	}
+	if cfg.MaxEvents <= 0 {
+		cfg.MaxEvents = DefaultMaxEvents
+	}
+
+	st, err := newStore(cfg.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		cfg:   cfg,
+		store: st,
+		pool: workerpool.New[string](workerpool.WorkerPoolConfig{
+			WorkerCount: cfg.Workers,
+			BufferSize:  cfg.QueueSize,
+			Timeout:     cfg.JobTimeout,
+			Name:        "jobs",
+		}),
+		runners: make(map[string]Runner),
+		entries: make(map[string]*entry),
+	}, nil
+}
+
+// Register adds the runner for a job type.
+func (m *Manager) Register(jobType string, runner Runner) {
+	m.runners[jobType] = runner
+}
+
+// Types returns the registered job types.
+func (m *Manager) Types() []string {
+	types := make([]string, 0, len(m.runners))
+	for t := range m.runners {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Start loads persisted jobs and starts the workers. Jobs that were queued
+// when the previous process stopped are queued again; jobs that were running
+// are marked failed because their progress cannot be recovered.
+func (m *Manager) Start() error {
+	records, errs := m.store.load()
+	for _, err := range errs {
+		logger.SimpleWarn("Skipping persisted job", "error", err)
+	}
+
+	if err := m.pool.Start(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	m.running = true
+	m.mu.Unlock()
+	go func() {
+		for range m.pool.Results() {
+			// 결과는 run에서 job 상태로 기록되므로 채널만 비운다
+		}
+	}()
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Job.CreatedAt.Before(records[j].Job.CreatedAt)
+	})
+
+	m.mu.Lock()
+	requeue := make(map[string]workerpool.SubmitOptions)
+	var order []string
+	for _, rec := range records {
+		e := &entry{job: rec.Job, events: rec.Events, subscribers: make(map[chan cli.Event]struct{})}
+		m.entries[rec.Job.ID] = e
+		switch e.job.Status {
+		case StatusRunning:
+			m.finishLocked(e, StatusFailed, "interrupted by server restart")
+		case StatusQueued:
+			requeue[e.job.ID] = m.submitOptions(e.job)
+			order = append(order, e.job.ID)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, id := range order {
+		if err := m.pool.SubmitWithOptions(id, m.run, requeue[id]); err != nil {
+			m.mu.Lock()
+			m.finishLocked(m.entries[id], StatusFailed, "could not be queued after restart: "+err.Error())
+			m.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// Stop cancels running jobs and waits up to timeout for the workers to exit.
+// Queued jobs stay queued on disk and resume on the next Start.
+func (m *Manager) Stop(timeout time.Duration) error {
+	m.mu.Lock()
+	m.running = false
+	for _, e := range m.entries {
+		if e.job.Status == StatusRunning && e.cancel != nil {
+			e.cancel()
+		}
+	}
+	m.mu.Unlock()
+	return m.pool.StopWithTimeout(timeout)
+}
+
+// Running reports whether the workers have been started and not stopped.
+func (m *Manager) Running() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.running
+}
+
+// Check reports whether the manager accepts new jobs: ErrNotRunning before
+// Start and after Stop, ErrQueueFull when every queue slot is taken.
+func (m *Manager) Check() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.running {
+		return ErrNotRunning
+	}
+	queued := 0
+	for _, e := range m.entries {
+		if e.job.Status == StatusQueued {
+			queued++
+		}
+	}
+	if queued >= m.cfg.QueueSize {
+		return fmt.Errorf("%w (%d jobs waiting)", ErrQueueFull, queued)
+	}
+	return nil
+}
+
+// Submit validates params and queues an interactive job.
+func (m *Manager) Submit(jobType string, params json.RawMessage) (Job, error) {
+	return m.submit(jobType, params, workerpool.ClassInteractive)
+}
+
+// submit validates params and queues a job with the given priority class.
+func (m *Manager) submit(jobType string, params json.RawMessage, class workerpool.Class) (Job, error) {
+	runner, ok := m.runners[jobType]
+	if !ok {
+		return Job{}, fmt.Errorf("%w: %s", ErrUnknownType, jobType)
+	}
+	if err := runner.Validate(params); err != nil {
+		return Job{}, err
+	}
+
+	e := &entry{
+		job: Job{
+			ID:        newID(),
+			Type:      jobType,
+			Status:    StatusQueued,
+			Params:    params,
+			Priority:  class.String(),
+			CreatedAt: time.Now(),
+		},
+		subscribers: make(map[chan cli.Event]struct{}),
+	}
+
+	m.mu.Lock()
+	m.entries[e.job.ID] = e
+	if err := m.saveLocked(e); err != nil {
+		delete(m.entries, e.job.ID)
+		m.mu.Unlock()
+		return Job{}, err
+	}
+	m.mu.Unlock()
+
+	if err := m.pool.SubmitWithOptions(e.job.ID, m.run, m.submitOptions(e.job)); err != nil {
+		m.mu.Lock()
+		delete(m.entries, e.job.ID)
+		m.mu.Unlock()
+		_ = m.store.remove(e.job.ID)
+		if strings.Contains(err.Error(), "queue is full") {
+			return Job{}, ErrQueueFull
+		}
+		return Job{}, err
+	}
+	return m.snapshot(e), nil
+}
+
+// Get returns the job with the given ID.
+func (m *Manager) Get(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[id]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+	return e.job, nil
+}
+
+// List returns all jobs, newest first.
+func (m *Manager) List() []Job {
+	m.mu.Lock()
+	out := make([]Job, 0, len(m.entries))
+	for _, e := range m.entries {
+		out = append(out, e.job)
+	}
+	m.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	return out
+}
+
+// Cancel stops a queued or running job. A running job is marked canceled
+// once its runner returns.
+func (m *Manager) Cancel(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[id]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+
+	switch {
+	case e.job.Status.Done():
+		return e.job, ErrFinished
+	case e.job.Status == StatusQueued:
+		m.finishLocked(e, StatusCanceled, "")
+	default:
+		e.cancelAsked = true
+		if e.cancel != nil {
+			e.cancel()
+		}
+	}
+	return e.job, nil
+}
+
+// Subscribe returns the events recorded so far and a channel of live events
+// that is closed when the job finishes. Call the returned function to
+// unsubscribe. Events are dropped for subscribers that fall behind.
+func (m *Manager) Subscribe(id string) ([]cli.Event, <-chan cli.Event, func(), error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[id]
+	if !ok {
+		return nil, nil, nil, ErrNotFound
+	}
+
+	past := append([]cli.Event(nil), e.events...)
+	ch := make(chan cli.Event, subscriberBuffer)
+	if e.job.Status.Done() {
+		close(ch)
+		return past, ch, func() {}, nil
+	}
+
+	e.subscribers[ch] = struct{}{}
+	unsubscribe := func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if _, ok := e.subscribers[ch]; ok {
+			delete(e.subscribers, ch)
+			close(ch)
+		}
+	}
+	return past, ch, unsubscribe, nil
+}
+
+// submitOptions returns the queue of a job: its priority class, shared
+// fairly between the organizations named in the job parameters.
+func (m *Manager) submitOptions(job Job) workerpool.SubmitOptions {
+	class, err := workerpool.ParseClass(job.Priority)
+	if err != nil {
+		// 우선순위가 없는 이전 작업 기록
+		class = workerpool.ClassInteractive
+	}
+	var params struct {
+		Org string `json:"org"`
+	}
+	_ = json.Unmarshal(job.Params, &params)
+	return workerpool.SubmitOptions{Class: class, Group: params.Org}
+}
+
+// run is the worker pool callback for one job.
+func (m *Manager) run(ctx context.Context, id string) error {
+	m.mu.Lock()
+	e, ok := m.entries[id]
+	if !ok || e.job.Status != StatusQueued {
+		// 대기 중 취소된 작업
+		m.mu.Unlock()
+		return nil
+	}
+	runner := m.runners[e.job.Type]
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	now := time.Now()
+	e.job.Status = StatusRunning
+	e.job.StartedAt = &now
+	e.cancel = cancel
+	m.saveOrWarnLocked(e)
+	m.mu.Unlock()
+
+	var err error
+	if runner == nil {
+		err = fmt.Errorf("%w: %s", ErrUnknownType, e.job.Type)
+	} else {
+		err = runner.Run(ctx, e.job.Params, func(event cli.Event) { m.record(e, event) })
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e.cancel = nil
+	switch {
+	case e.cancelAsked:
+		m.finishLocked(e, StatusCanceled, "")
+	case err != nil:
+		if ctx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("timed out after %v: %w", m.cfg.JobTimeout, err)
+		}
+		m.finishLocked(e, StatusFailed, err.Error())
+	default:
+		m.finishLocked(e, StatusSucceeded, "")
+	}
+	return err
+}
+
+// record stores a runner event and forwards it to subscribers.
+func (m *Manager) record(e *entry, event cli.Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e.job.Progress.applyEvent(event)
+	m.appendLocked(e, event)
+	if e.unsaved >= saveEvery {
+		m.saveOrWarnLocked(e)
+	}
+}
+
+func (m *Manager) appendLocked(e *entry, event cli.Event) {
+	e.events = append(e.events, event)
+	if over := len(e.events) - m.cfg.MaxEvents; over > 0 {
+		e.events = append(e.events[:0], e.events[over:]...)
+	}
+	e.unsaved++
+	for ch := range e.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// finishLocked moves a job to a final status, publishes a closing event,
+// persists it and closes its subscriptions.
+func (m *Manager) finishLocked(e *entry, status Status, message string) {
+	now := time.Now()
+	e.job.Status = status
+	e.job.Error = message
+	e.job.FinishedAt = &now
+
+	m.appendLocked(e, cli.Event{
+		Time:  now,
+		Type:  "job",
+		Error: message,
+		Data:  map[string]any{"status": string(status)},
+	})
+	m.saveOrWarnLocked(e)
+
+	for ch := range e.subscribers {
+		close(ch)
+	}
+	e.subscribers = make(map[chan cli.Event]struct{})
+}
+
+func (m *Manager) saveLocked(e *entry) error {
+	e.unsaved = 0
+	return m.store.save(record{Job: e.job, Events: e.events})
+}
+
+func (m *Manager) saveOrWarnLocked(e *entry) {
+	if err := m.saveLocked(e); err != nil {
+		logger.SimpleWarn("Failed to persist job", "job", e.job.ID, "error", err)
+	}
+}
+
+func (m *Manager) snapshot(e *entry) Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return e.job
+}
+
+// newID returns a random job identifier.
+func newID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
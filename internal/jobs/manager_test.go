@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+)
+
+// fakeRunner reports three items and then blocks until release is closed
+// or the job is canceled.
+type fakeRunner struct {
+	release chan struct{}
+	fail    bool
+}
+
+func (r *fakeRunner) Validate(params json.RawMessage) error {
+	var p struct {
+		Org string `json:"org"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil || p.Org == "" {
+		return errors.New("org is required")
+	}
+	return nil
+}
+
+func (r *fakeRunner) Run(ctx context.Context, _ json.RawMessage, emit func(cli.Event)) error {
+	emit(cli.Event{Type: "start", Data: map[string]any{"total": 3}})
+	emit(cli.Event{Type: "success", Target: "org/a"})
+	emit(cli.Event{Type: "fail", Target: "org/b", Error: "boom"})
+	emit(cli.Event{Type: "skip", Target: "org/c"})
+	select {
+	case <-r.release:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if r.fail {
+		return errors.New("1 repository failed")
+	}
+	return nil
+}
+
+func newTestManager(t *testing.T, dir string, runner Runner) *Manager {
+	t.Helper()
+	m, err := NewManager(Config{Dir: dir, Workers: 1, QueueSize: 2, JobTimeout: time.Minute})
+	require.NoError(t, err)
+	m.Register("bulk-clone", runner)
+	require.NoError(t, m.Start())
+	t.Cleanup(func() { _ = m.Stop(5 * time.Second) })
+	return m
+}
+
+func waitStatus(t *testing.T, m *Manager, id string, want Status) Job {
+	t.Helper()
+	var job Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = m.Get(id)
+		return err == nil && job.Status == want
+	}, 5*time.Second, 10*time.Millisecond, "job never reached %s", want)
+	return job
+}
+
+func TestManagerRunsJob(t *testing.T) {
+	runner := &fakeRunner{release: make(chan struct{})}
+	m := newTestManager(t, t.TempDir(), runner)
+
+	_, err := m.Submit("bulk-clone", json.RawMessage(`{}`))
+	assert.EqualError(t, err, "org is required")
+	_, err = m.Submit("mirror", json.RawMessage(`{"org":"x"}`))
+	assert.ErrorIs(t, err, ErrUnknownType)
+
+	job, err := m.Submit("bulk-clone", json.RawMessage(`{"org":"acme"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "interactive", job.Priority)
+	waitStatus(t, m, job.ID, StatusRunning)
+
+	past, live, unsubscribe, err := m.Subscribe(job.ID)
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	close(runner.release)
+	var events []cli.Event
+	events = append(events, past...)
+	for ev := range live {
+		events = append(events, ev)
+	}
+
+	job = waitStatus(t, m, job.ID, StatusSucceeded)
+	assert.Equal(t, Progress{Total: 3, Completed: 1, Failed: 1, Skipped: 1}, job.Progress)
+	assert.NotNil(t, job.FinishedAt)
+	require.NotEmpty(t, events)
+	last := events[len(events)-1]
+	assert.Equal(t, "job", last.Type)
+	assert.Equal(t, "succeeded", last.Data["status"])
+}
+
+func TestManagerCancel(t *testing.T) {
+	runner := &fakeRunner{release: make(chan struct{})}
+	m := newTestManager(t, t.TempDir(), runner)
+
+	running, err := m.Submit("bulk-clone", json.RawMessage(`{"org":"a"}`))
+	require.NoError(t, err)
+	waitStatus(t, m, running.ID, StatusRunning)
+
+	queued, err := m.Submit("bulk-clone", json.RawMessage(`{"org":"b"}`))
+	require.NoError(t, err)
+
+	job, err := m.Cancel(queued.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCanceled, job.Status)
+
+	_, err = m.Cancel(running.ID)
+	require.NoError(t, err)
+	waitStatus(t, m, running.ID, StatusCanceled)
+
+	_, err = m.Cancel(running.ID)
+	assert.ErrorIs(t, err, ErrFinished)
+	_, err = m.Cancel("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestManagerQueueFull(t *testing.T) {
+	runner := &fakeRunner{release: make(chan struct{})}
+	m := newTestManager(t, t.TempDir(), runner)
+	defer close(runner.release)
+
+	first, err := m.Submit("bulk-clone", json.RawMessage(`{"org":"a"}`))
+	require.NoError(t, err)
+	waitStatus(t, m, first.ID, StatusRunning)
+	assert.NoError(t, m.Check())
+
+	for i := 0; i < 2; i++ {
+		_, err := m.Submit("bulk-clone", json.RawMessage(`{"org":"a"}`))
+		require.NoError(t, err)
+	}
+	_, err = m.Submit("bulk-clone", json.RawMessage(`{"org":"a"}`))
+	assert.ErrorIs(t, err, ErrQueueFull)
+	assert.Len(t, m.List(), 3)
+	assert.ErrorIs(t, m.Check(), ErrQueueFull)
+	assert.True(t, m.Running())
+}
+
+func TestManagerRestoresJobs(t *testing.T) {
+	dir := t.TempDir()
+	st, err := newStore(dir)
+	require.NoError(t, err)
+
+	created := time.Now().Add(-time.Hour)
+	for _, rec := range []record{
+		{Job: Job{ID: "done", Type: "bulk-clone", Status: StatusSucceeded, CreatedAt: created}},
+		{Job: Job{ID: "interrupted", Type: "bulk-clone", Status: StatusRunning, CreatedAt: created}},
+		{Job: Job{ID: "pending", Type: "bulk-clone", Status: StatusQueued, Params: json.RawMessage(`{"org":"a"}`), CreatedAt: created}},
+	} {
+		require.NoError(t, st.save(rec))
+	}
+
+	runner := &fakeRunner{release: make(chan struct{})}
+	close(runner.release)
+	runner.fail = true
+	m := newTestManager(t, dir, runner)
+
+	job, err := m.Get("interrupted")
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, job.Status)
+	assert.Contains(t, job.Error, "restart")
+
+	job = waitStatus(t, m, "pending", StatusFailed)
+	assert.Equal(t, "1 repository failed", job.Error)
+
+	job, err = m.Get("done")
+	require.NoError(t, err)
+	assert.Equal(t, StatusSucceeded, job.Status)
+}
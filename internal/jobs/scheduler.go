@@ -0,0 +1,480 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/filesystem"
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/internal/workerpool"
+)
+
+var (
+	// ErrScheduleNotFound is returned for unknown schedule names.
+	ErrScheduleNotFound = errors.New("schedule not found")
+	// ErrOverlap is returned when a schedule's previous run is still active.
+	ErrOverlap = errors.New("previous run is still active")
+	// ErrSchedulerStopped is returned by Check while Run is not active.
+	ErrSchedulerStopped = errors.New("scheduler is not running")
+)
+
+// maxScheduleLag is how long a due run may wait before Check reports the
+// scheduler as stuck.
+const maxScheduleLag = time.Minute
+
+// Triggers recorded for a run.
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+// ScheduledJob submits a job of Type with Params whenever Cron matches.
+type ScheduledJob struct {
+	Name   string
+	Cron   string
+	Type   string
+	Params json.RawMessage
+	// Jitter delays each run by a random duration below this value so that
+	// several servers with the same schedule do not hit a provider at once.
+	Jitter time.Duration
+	// Location is the time zone Cron is evaluated in; nil uses local time.
+	Location *time.Location
+	// Paused is the initial state when no state has been persisted yet.
+	Paused bool
+}
+
+// SchedulerConfig configures a Scheduler.
+type SchedulerConfig struct {
+	// StatePath persists pause state and the last run of each schedule.
+	// Empty keeps the state in memory only.
+	StatePath string
+	// Now overrides the clock (for tests).
+	Now func() time.Time
+	// Jitter returns a random duration in [0, n); overridable for tests.
+	Jitter func(n time.Duration) time.Duration
+}
+
+// Run is the outcome of the last job a schedule submitted.
+type Run struct {
+	JobID       string     `json:"jobId,omitempty"`
+	Trigger     string     `json:"trigger"`
+	Status      Status     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	SubmittedAt time.Time  `json:"submittedAt"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+}
+
+// ScheduleStatus is a snapshot of a schedule.
+type ScheduleStatus struct {
+	Name          string     `json:"name"`
+	Cron          string     `json:"cron"`
+	Type          string     `json:"type"`
+	Jitter        string     `json:"jitter,omitempty"`
+	Paused        bool       `json:"paused"`
+	NextRun       *time.Time `json:"nextRun,omitempty"`
+	LastRun       *Run       `json:"lastRun,omitempty"`
+	SkippedRuns   int        `json:"skippedRuns"`
+	LastSkippedAt *time.Time `json:"lastSkippedAt,omitempty"`
+}
+
+// scheduleState is the persisted part of a schedule.
+type scheduleState struct {
+	Paused        bool       `json:"paused"`
+	LastRun       *Run       `json:"lastRun,omitempty"`
+	SkippedRuns   int        `json:"skippedRuns,omitempty"`
+	LastSkippedAt *time.Time `json:"lastSkippedAt,omitempty"`
+}
+
+type scheduleEntry struct {
+	job     ScheduledJob
+	cron    *Cron
+	state   scheduleState
+	nominal time.Time // cron time of the next run
+	next    time.Time // nominal plus jitter
+}
+
+// Scheduler submits jobs to a Manager on cron schedules. A schedule never
+// runs twice at once: a run that comes due while the previous job is still
+// queued or running is skipped. It is safe for concurrent use.
+type Scheduler struct {
+	manager   *Manager
+	statePath string
+	now       func() time.Time
+	jitter    func(time.Duration) time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*scheduleEntry
+	wake    chan struct{}
+	running bool
+}
+
+// NewScheduler validates the schedules against the manager's runners and
+// restores persisted state. Register runners on the manager first.
+func NewScheduler(manager *Manager, scheduled []ScheduledJob, cfg SchedulerConfig) (*Scheduler, error) {
+	s := &Scheduler{
+		manager:   manager,
+		statePath: cfg.StatePath,
+		now:       cfg.Now,
+		jitter:    cfg.Jitter,
+		entries:   make(map[string]*scheduleEntry, len(scheduled)),
+		wake:      make(chan struct{}, 1),
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	if s.jitter == nil {
+		s.jitter = func(n time.Duration) time.Duration { return rand.N(n) }
+	}
+
+	for _, job := range scheduled {
+		if job.Name == "" {
+			return nil, errors.New("schedule name is required")
+		}
+		if _, dup := s.entries[job.Name]; dup {
+			return nil, fmt.Errorf("duplicate schedule %q", job.Name)
+		}
+		cron, err := ParseCron(job.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", job.Name, err)
+		}
+		runner, ok := manager.runners[job.Type]
+		if !ok {
+			return nil, fmt.Errorf("schedule %q: %w: %s", job.Name, ErrUnknownType, job.Type)
+		}
+		if err := runner.Validate(job.Params); err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", job.Name, err)
+		}
+		if job.Jitter < 0 {
+			return nil, fmt.Errorf("schedule %q: jitter must not be negative", job.Name)
+		}
+		if job.Location == nil {
+			job.Location = time.Local
+		}
+		s.entries[job.Name] = &scheduleEntry{job: job, cron: cron, state: scheduleState{Paused: job.Paused}}
+	}
+
+	if err := s.loadState(); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	for _, e := range s.entries {
+		s.planLocked(e, now)
+	}
+	return s, nil
+}
+
+// Run fires schedules as they come due until ctx is canceled.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	s.running = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+	}()
+
+	for {
+		s.mu.Lock()
+		var next time.Time
+		for _, e := range s.entries {
+			if !e.state.Paused && !e.next.IsZero() && (next.IsZero() || e.next.Before(next)) {
+				next = e.next
+			}
+		}
+		s.mu.Unlock()
+
+		var (
+			timer *time.Timer
+			due   <-chan time.Time
+		)
+		if !next.IsZero() {
+			timer = time.NewTimer(next.Sub(s.now()))
+			due = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-s.wake:
+		case <-due:
+			s.fireDue(s.now())
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// Check reports ErrSchedulerStopped while Run is not active and an error
+// when an unpaused schedule is more than a minute past its next run, which
+// means the loop no longer fires.
+func (s *Scheduler) Check() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running {
+		return ErrSchedulerStopped
+	}
+	now := s.now()
+	for name, e := range s.entries {
+		if lag := now.Sub(e.next); !e.state.Paused && !e.next.IsZero() && lag > maxScheduleLag {
+			return fmt.Errorf("schedule %q is %s overdue", name, lag.Round(time.Second))
+		}
+	}
+	return nil
+}
+
+// fireDue submits every unpaused schedule whose next run is not after now
+// and plans its following run.
+func (s *Scheduler) fireDue(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := false
+	for _, e := range s.entries {
+		if e.state.Paused || e.next.IsZero() || e.next.After(now) {
+			continue
+		}
+		if _, err := s.submitLocked(e, TriggerSchedule, now); errors.Is(err, ErrOverlap) {
+			e.state.SkippedRuns++
+			skipped := now
+			e.state.LastSkippedAt = &skipped
+			logger.SimpleWarn("Skipping scheduled run", "schedule", e.job.Name, "reason", err)
+		} else if err != nil {
+			logger.SimpleWarn("Scheduled run failed to start", "schedule", e.job.Name, "error", err)
+		}
+		changed = true
+
+		// 밀린 실행은 따라잡지 않고 다음 예정 시각부터 다시 계획한다
+		from := e.nominal
+		if next := e.cron.Next(from.In(e.job.Location)); next.IsZero() || !next.After(now) {
+			from = now
+		}
+		s.planLocked(e, from)
+	}
+	if changed {
+		s.saveStateOrWarnLocked()
+	}
+}
+
+// submitLocked submits the schedule's job unless its last run is active.
+// Submission failures are recorded as a failed run.
+func (s *Scheduler) submitLocked(e *scheduleEntry, trigger string, now time.Time) (Job, error) {
+	s.refreshLocked(e)
+	if e.state.LastRun != nil && e.state.LastRun.JobID != "" && !e.state.LastRun.Status.Done() {
+		return Job{}, fmt.Errorf("%w (job %s)", ErrOverlap, e.state.LastRun.JobID)
+	}
+
+	job, err := s.manager.submit(e.job.Type, e.job.Params, workerpool.ClassScheduled)
+	if err != nil {
+		finished := now
+		e.state.LastRun = &Run{Trigger: trigger, Status: StatusFailed, Error: err.Error(), SubmittedAt: now, FinishedAt: &finished}
+		return Job{}, err
+	}
+	e.state.LastRun = &Run{JobID: job.ID, Trigger: trigger, Status: job.Status, SubmittedAt: now}
+	return job, nil
+}
+
+// refreshLocked copies the status of the last submitted job.
+func (s *Scheduler) refreshLocked(e *scheduleEntry) bool {
+	run := e.state.LastRun
+	if run == nil || run.JobID == "" || run.Status.Done() {
+		return false
+	}
+
+	job, err := s.manager.Get(run.JobID)
+	if err != nil {
+		// 작업 기록이 사라졌다면 다시 실행할 수 있도록 실패로 닫는다
+		run.Status = StatusFailed
+		run.Error = "job record no longer exists"
+		return true
+	}
+	if job.Status == run.Status {
+		return false
+	}
+	run.Status = job.Status
+	run.Error = job.Error
+	run.FinishedAt = job.FinishedAt
+	return true
+}
+
+// planLocked computes the next run after from, including jitter.
+func (s *Scheduler) planLocked(e *scheduleEntry, from time.Time) {
+	e.nominal = e.cron.Next(from.In(e.job.Location))
+	e.next = e.nominal
+	if !e.nominal.IsZero() && e.job.Jitter > 0 {
+		e.next = e.nominal.Add(s.jitter(e.job.Jitter))
+	}
+}
+
+// List returns every schedule sorted by name.
+func (s *Scheduler) List() []ScheduleStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]ScheduleStatus, 0, len(s.entries))
+	changed := false
+	for _, e := range s.entries {
+		changed = s.refreshLocked(e) || changed
+		out = append(out, e.status())
+	}
+	if changed {
+		s.saveStateOrWarnLocked()
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Get returns the named schedule.
+func (s *Scheduler) Get(name string) (ScheduleStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[name]
+	if !ok {
+		return ScheduleStatus{}, fmt.Errorf("%w: %s", ErrScheduleNotFound, name)
+	}
+	if s.refreshLocked(e) {
+		s.saveStateOrWarnLocked()
+	}
+	return e.status(), nil
+}
+
+// Pause stops a schedule from firing until it is resumed. Manual triggers
+// still work while paused.
+func (s *Scheduler) Pause(name string) (ScheduleStatus, error) {
+	return s.setPaused(name, true)
+}
+
+// Resume re-enables a paused schedule from its next cron time.
+func (s *Scheduler) Resume(name string) (ScheduleStatus, error) {
+	return s.setPaused(name, false)
+}
+
+func (s *Scheduler) setPaused(name string, paused bool) (ScheduleStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[name]
+	if !ok {
+		return ScheduleStatus{}, fmt.Errorf("%w: %s", ErrScheduleNotFound, name)
+	}
+	if e.state.Paused != paused {
+		e.state.Paused = paused
+		if !paused {
+			s.planLocked(e, s.now())
+		}
+		s.saveStateOrWarnLocked()
+		s.notify()
+	}
+	return e.status(), nil
+}
+
+// Trigger submits the schedule's job now. It returns ErrOverlap when the
+// previous run is still active.
+func (s *Scheduler) Trigger(name string) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[name]
+	if !ok {
+		return Job{}, fmt.Errorf("%w: %s", ErrScheduleNotFound, name)
+	}
+	job, err := s.submitLocked(e, TriggerManual, s.now())
+	if errors.Is(err, ErrOverlap) {
+		return Job{}, err
+	}
+	s.saveStateOrWarnLocked()
+	return job, err
+}
+
+// notify wakes Run so that it recomputes the next due time.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (e *scheduleEntry) status() ScheduleStatus {
+	st := ScheduleStatus{
+		Name:          e.job.Name,
+		Cron:          e.cron.String(),
+		Type:          e.job.Type,
+		Paused:        e.state.Paused,
+		SkippedRuns:   e.state.SkippedRuns,
+		LastSkippedAt: e.state.LastSkippedAt,
+	}
+	if e.job.Jitter > 0 {
+		st.Jitter = e.job.Jitter.String()
+	}
+	if !e.state.Paused && !e.next.IsZero() {
+		next := e.next
+		st.NextRun = &next
+	}
+	if e.state.LastRun != nil {
+		run := *e.state.LastRun
+		st.LastRun = &run
+	}
+	return st
+}
+
+// loadState restores persisted state for schedules that still exist.
+func (s *Scheduler) loadState() error {
+	if s.statePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read schedule state: %w", err)
+	}
+
+	var states map[string]scheduleState
+	if err := json.Unmarshal(data, &states); err != nil {
+		return fmt.Errorf("invalid schedule state %s: %w", s.statePath, err)
+	}
+	for name, st := range states {
+		if e, ok := s.entries[name]; ok {
+			e.state = st
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) saveStateOrWarnLocked() {
+	if s.statePath == "" {
+		return
+	}
+
+	states := make(map[string]scheduleState, len(s.entries))
+	for name, e := range s.entries {
+		states[name] = e.state
+	}
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(s.statePath), 0o700)
+	}
+	if err == nil {
+		err = filesystem.WriteFileAtomic(filesystem.OS(), s.statePath, data, 0o600)
+	}
+	if err != nil {
+		logger.SimpleWarn("Failed to persist schedule state", "path", s.statePath, "error", err)
+	}
+}
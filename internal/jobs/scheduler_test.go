@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package jobs
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestScheduler(t *testing.T, m *Manager, statePath string, now *time.Time) *Scheduler {
+	t.Helper()
+	s, err := NewScheduler(m, []ScheduledJob{{
+		Name:     "nightly",
+		Cron:     "0 2 * * *",
+		Type:     "bulk-clone",
+		Params:   json.RawMessage(`{"org":"acme"}`),
+		Jitter:   time.Minute,
+		Location: time.UTC,
+	}}, SchedulerConfig{
+		StatePath: statePath,
+		Now:       func() time.Time { return *now },
+		Jitter:    func(time.Duration) time.Duration { return 30 * time.Second },
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestSchedulerFiresAndPreventsOverlap(t *testing.T) {
+	runner := &fakeRunner{release: make(chan struct{})}
+	m := newTestManager(t, t.TempDir(), runner)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	s := newTestScheduler(t, m, "", &now)
+
+	st, err := s.Get("nightly")
+	require.NoError(t, err)
+	require.NotNil(t, st.NextRun)
+	assert.Equal(t, time.Date(2025, 1, 16, 2, 0, 30, 0, time.UTC), *st.NextRun)
+	assert.Equal(t, "1m0s", st.Jitter)
+
+	// Not due yet.
+	s.fireDue(now)
+	st, _ = s.Get("nightly")
+	assert.Nil(t, st.LastRun)
+
+	now = *st.NextRun
+	s.fireDue(now)
+	st, _ = s.Get("nightly")
+	require.NotNil(t, st.LastRun)
+	first := st.LastRun.JobID
+	assert.Equal(t, TriggerSchedule, st.LastRun.Trigger)
+	firstJob, err := m.Get(first)
+	require.NoError(t, err)
+	assert.Equal(t, "scheduled", firstJob.Priority)
+	assert.Equal(t, time.Date(2025, 1, 17, 2, 0, 30, 0, time.UTC), *st.NextRun)
+
+	// The first run is still blocked, so the next one is skipped.
+	now = *st.NextRun
+	s.fireDue(now)
+	st, _ = s.Get("nightly")
+	assert.Equal(t, first, st.LastRun.JobID)
+	assert.Equal(t, 1, st.SkippedRuns)
+	_, err = s.Trigger("nightly")
+	assert.ErrorIs(t, err, ErrOverlap)
+
+	close(runner.release)
+	require.Eventually(t, func() bool {
+		st, _ := s.Get("nightly")
+		return st.LastRun.Status == StatusSucceeded
+	}, 5*time.Second, 10*time.Millisecond)
+
+	job, err := s.Trigger("nightly")
+	require.NoError(t, err)
+	st, _ = s.Get("nightly")
+	assert.Equal(t, job.ID, st.LastRun.JobID)
+	assert.Equal(t, TriggerManual, st.LastRun.Trigger)
+}
+
+func TestSchedulerPausePersists(t *testing.T) {
+	runner := &fakeRunner{release: make(chan struct{})}
+	close(runner.release)
+	m := newTestManager(t, t.TempDir(), runner)
+	statePath := filepath.Join(t.TempDir(), "schedules.json")
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	s := newTestScheduler(t, m, statePath, &now)
+
+	st, err := s.Pause("nightly")
+	require.NoError(t, err)
+	assert.True(t, st.Paused)
+	assert.Nil(t, st.NextRun)
+
+	now = now.Add(48 * time.Hour)
+	s.fireDue(now)
+	st, _ = s.Get("nightly")
+	assert.Nil(t, st.LastRun, "paused schedules do not fire")
+
+	// Manual triggers work while paused.
+	_, err = s.Trigger("nightly")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		st, _ := s.Get("nightly")
+		return st.LastRun.Status == StatusSucceeded
+	}, 5*time.Second, 10*time.Millisecond)
+
+	restored := newTestScheduler(t, m, statePath, &now)
+	st, err = restored.Get("nightly")
+	require.NoError(t, err)
+	assert.True(t, st.Paused)
+	require.NotNil(t, st.LastRun)
+	assert.Equal(t, StatusSucceeded, st.LastRun.Status)
+
+	st, err = restored.Resume("nightly")
+	require.NoError(t, err)
+	assert.False(t, st.Paused)
+	assert.Equal(t, time.Date(2025, 1, 18, 2, 0, 30, 0, time.UTC), *st.NextRun)
+
+	_, err = restored.Pause("missing")
+	assert.ErrorIs(t, err, ErrScheduleNotFound)
+}
+
+func TestNewSchedulerValidates(t *testing.T) {
+	m := newTestManager(t, t.TempDir(), &fakeRunner{release: make(chan struct{})})
+	params := json.RawMessage(`{"org":"acme"}`)
+
+	for name, job := range map[string]ScheduledJob{
+		"bad cron":     {Name: "a", Cron: "* *", Type: "bulk-clone", Params: params},
+		"unknown type": {Name: "a", Cron: "@daily", Type: "mirror", Params: params},
+		"bad params":   {Name: "a", Cron: "@daily", Type: "bulk-clone", Params: json.RawMessage(`{}`)},
+		"no name":      {Cron: "@daily", Type: "bulk-clone", Params: params},
+	} {
+		_, err := NewScheduler(m, []ScheduledJob{job}, SchedulerConfig{})
+		assert.Error(t, err, name)
+	}
+
+	_, err := NewScheduler(m, []ScheduledJob{
+		{Name: "a", Cron: "@daily", Type: "bulk-clone", Params: params},
+		{Name: "a", Cron: "@hourly", Type: "bulk-clone", Params: params},
+	}, SchedulerConfig{})
+	assert.ErrorContains(t, err, "duplicate")
+}
+
+func TestSchedulerCheck(t *testing.T) {
+	m := newTestManager(t, t.TempDir(), &fakeRunner{release: make(chan struct{})})
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	s := newTestScheduler(t, m, "", &now)
+
+	assert.ErrorIs(t, s.Check(), ErrSchedulerStopped)
+
+	s.running = true
+	require.NoError(t, s.Check())
+
+	now = time.Date(2025, 1, 16, 2, 5, 0, 0, time.UTC)
+	assert.ErrorContains(t, s.Check(), `schedule "nightly" is 4m30s overdue`)
+
+	_, err := s.Pause("nightly")
+	require.NoError(t, err)
+	assert.NoError(t, s.Check())
+}
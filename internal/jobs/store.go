@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package jobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/cli"
+)
+
+// record is the persisted form of a job.
+type record struct {
+	Job    Job         `json:"job"`
+	Events []cli.Event `json:"events,omitempty"`
+}
+
+// store keeps one JSON file per job in dir.
+type store struct {
+	dir string
+}
+
+func newStore(dir string) (*store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create job directory %s: %w", dir, err)
+	}
+	return &store{dir: dir}, nil
+}
+
+func (s *store) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// save writes the record atomically so that a crash never leaves a
+// truncated file behind.
+func (s *store) save(rec record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job %s: %w", rec.Job.ID, err)
+	}
+	tmp := s.path(rec.Job.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write job %s: %w", rec.Job.ID, err)
+	}
+	return os.Rename(tmp, s.path(rec.Job.ID))
+}
+
+func (s *store) remove(id string) error {
+	if err := os.Remove(s.path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// load reads every persisted job. Unreadable files are skipped and returned
+// as errors so the caller can report them without refusing to start.
+func (s *store) load() ([]record, []error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to read job directory %s: %w", s.dir, err)}
+	}
+
+	var (
+		records []record
+		errs    []error
+	)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var rec record
+		if err := json.Unmarshal(data, &rec); err != nil || rec.Job.ID == "" {
+			errs = append(errs, fmt.Errorf("invalid job file %s", entry.Name()))
+			continue
+		}
+		records = append(records, rec)
+	}
+	return records, errs
+}
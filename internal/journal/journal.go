@@ -0,0 +1,254 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package journal records destructive operations so they can be undone.
+//
+// Commands that delete, archive or reconfigure things on a platform start an
+// Operation and add a Record for every change they make, holding the state
+// from before the change and, where one was made, the path of a backup.
+// 'gz undo' replays the inverse of each record through the handler
+// registered for its kind.
+package journal
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record kinds.
+const (
+	KindRepoDelete    = "repo.delete"
+	KindRepoArchive   = "repo.archive"
+	KindRepoUnarchive = "repo.unarchive"
+	KindWebhookDelete = "webhook.delete"
+	KindProtection    = "protection.change"
+)
+
+// ErrOperationNotFound is returned when no operation has the requested ID.
+var ErrOperationNotFound = errors.New("operation not found")
+
+// Record is one reversible change.
+type Record struct {
+	Kind     string `json:"kind"`
+	Provider string `json:"provider"`
+	// Target identifies what was changed, e.g. org/repo or org/repo#hook.
+	Target string `json:"target"`
+	// State is the kind-specific state needed to reverse the change.
+	State json.RawMessage `json:"state,omitempty"`
+	// Backup is the path of a backup taken before the change.
+	Backup     string     `json:"backup,omitempty"`
+	RecordedAt time.Time  `json:"recordedAt"`
+	UndoneAt   *time.Time `json:"undoneAt,omitempty"`
+	UndoError  string     `json:"undoError,omitempty"`
+}
+
+// Decode unmarshals the record's state into v.
+func (r *Record) Decode(v any) error {
+	if err := json.Unmarshal(r.State, v); err != nil {
+		return fmt.Errorf("invalid %s state for %s: %w", r.Kind, r.Target, err)
+	}
+	return nil
+}
+
+// Operation is a command run and the changes it made.
+type Operation struct {
+	ID        string     `json:"id"`
+	Command   string     `json:"command"`
+	StartedAt time.Time  `json:"startedAt"`
+	Records   []Record   `json:"records"`
+	UndoneAt  *time.Time `json:"undoneAt,omitempty"`
+
+	journal *Journal
+	mu      sync.Mutex
+}
+
+// Journal stores operations as one JSON file each in a directory.
+type Journal struct {
+	dir string
+}
+
+// DefaultDir returns the journal directory: GZH_JOURNAL_DIR, or
+// ~/.gzh/journal.
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("GZH_JOURNAL_DIR"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".gzh", "journal"), nil
+}
+
+// Open returns the journal in dir.
+func Open(dir string) *Journal {
+	return &Journal{dir: dir}
+}
+
+// Default returns the journal in DefaultDir.
+func Default() (*Journal, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return Open(dir), nil
+}
+
+// Dir returns the journal directory.
+func (j *Journal) Dir() string {
+	return j.dir
+}
+
+// Begin starts an operation for command. Nothing is written until the
+// first record is added.
+func (j *Journal) Begin(command string) *Operation {
+	return &Operation{
+		ID:        newID(time.Now()),
+		Command:   command,
+		StartedAt: time.Now().UTC(),
+		journal:   j,
+	}
+}
+
+// Start begins an operation in the default journal. Commands journal on a
+// best-effort basis: when the journal is unavailable the error is returned
+// with a nil operation, whose Add does nothing.
+func Start(command string) (*Operation, error) {
+	j, err := Default()
+	if err != nil {
+		return nil, err
+	}
+	return j.Begin(command), nil
+}
+
+func newID(t time.Time) string {
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return t.UTC().Format("20060102-150405") + "-" + hex.EncodeToString(suffix)
+}
+
+// Add records a change and saves the operation. state is marshaled to JSON.
+// It is safe for concurrent use and does nothing on a nil operation.
+func (op *Operation) Add(kind, provider, target string, state any, backup string) error {
+	if op == nil {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s state: %w", kind, err)
+	}
+
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	op.Records = append(op.Records, Record{
+		Kind:       kind,
+		Provider:   provider,
+		Target:     target,
+		State:      data,
+		Backup:     backup,
+		RecordedAt: time.Now().UTC(),
+	})
+	return op.saveLocked()
+}
+
+// Len returns the number of records.
+func (op *Operation) Len() int {
+	if op == nil {
+		return 0
+	}
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return len(op.Records)
+}
+
+func (op *Operation) save() error {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return op.saveLocked()
+}
+
+func (op *Operation) saveLocked() error {
+	if err := os.MkdirAll(op.journal.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	data, err := json.MarshalIndent(op, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode operation: %w", err)
+	}
+
+	// 기록 중 중단되어도 이전 내용이 남도록 임시 파일을 거쳐 교체한다
+	path := op.journal.path(op.ID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+func (j *Journal) path(id string) string {
+	return filepath.Join(j.dir, id+".json")
+}
+
+// Load reads an operation. A unique prefix of the ID is accepted.
+func (j *Journal) Load(id string) (*Operation, error) {
+	if strings.ContainsAny(id, `/\`) || id == "" {
+		return nil, fmt.Errorf("%w: %q", ErrOperationNotFound, id)
+	}
+
+	path := j.path(id)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		matches, _ := filepath.Glob(filepath.Join(j.dir, id+"*.json"))
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("%w: %s", ErrOperationNotFound, id)
+		case 1:
+			path = matches[0]
+		default:
+			return nil, fmt.Errorf("operation ID %s is ambiguous (%d matches)", id, len(matches))
+		}
+	}
+	return j.read(path)
+}
+
+func (j *Journal) read(path string) (*Operation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	op := &Operation{journal: j}
+	if err := json.Unmarshal(data, op); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	return op, nil
+}
+
+// List returns the recorded operations, newest first.
+func (j *Journal) List() ([]*Operation, error) {
+	paths, err := filepath.Glob(filepath.Join(j.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]*Operation, 0, len(paths))
+	for _, path := range paths {
+		op, err := j.read(path)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(a, b int) bool { return ops[a].StartedAt.After(ops[b].StartedAt) })
+	return ops, nil
+}
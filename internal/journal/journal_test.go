@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package journal
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type repoState struct {
+	Name string `json:"name"`
+}
+
+func TestJournal(t *testing.T) {
+	j := Open(t.TempDir())
+
+	empty := j.Begin("gz git repo archive")
+	_, err := os.Stat(j.path(empty.ID))
+	assert.ErrorIs(t, err, os.ErrNotExist, "operations without records are not written")
+
+	op := j.Begin("gz git repo delete")
+	require.NoError(t, op.Add(KindRepoDelete, "github", "org/api", repoState{Name: "api"}, "/backups/api"))
+	require.NoError(t, op.Add(KindRepoDelete, "github", "org/web", repoState{Name: "web"}, ""))
+
+	loaded, err := j.Load(op.ID[:len(op.ID)-2])
+	require.NoError(t, err)
+	assert.Equal(t, op.ID, loaded.ID)
+	require.Len(t, loaded.Records, 2)
+	assert.Equal(t, "/backups/api", loaded.Records[0].Backup)
+
+	var state repoState
+	require.NoError(t, loaded.Records[1].Decode(&state))
+	assert.Equal(t, "web", state.Name)
+
+	ops, err := j.List()
+	require.NoError(t, err)
+	assert.Len(t, ops, 1)
+
+	_, err = j.Load("missing")
+	assert.ErrorIs(t, err, ErrOperationNotFound)
+	_, err = j.Load("../etc")
+	assert.ErrorIs(t, err, ErrOperationNotFound)
+
+	var nilOp *Operation
+	assert.NoError(t, nilOp.Add(KindRepoArchive, "github", "org/api", nil, ""))
+	assert.Zero(t, nilOp.Len())
+}
+
+func TestUndo(t *testing.T) {
+	const kind = "test.undo"
+	var undone []string
+	fail := map[string]bool{"org/web": true}
+	RegisterUndoer(kind, func(_ context.Context, rec Record, opts UndoOptions) (string, error) {
+		if fail[rec.Target] {
+			return "", errors.New("boom")
+		}
+		if !opts.DryRun {
+			undone = append(undone, rec.Target)
+		}
+		return "restored", nil
+	})
+
+	j := Open(t.TempDir())
+	op := j.Begin("test")
+	for _, target := range []string{"org/api", "org/web", "org/docs"} {
+		require.NoError(t, op.Add(kind, "github", target, nil, ""))
+	}
+	require.NoError(t, op.Add("unknown.kind", "github", "org/x", nil, ""))
+
+	results, err := op.Undo(context.Background(), UndoOptions{DryRun: true})
+	require.Error(t, err)
+	assert.Len(t, results, 4)
+	assert.Empty(t, undone)
+
+	results, err = op.Undo(context.Background(), UndoOptions{})
+	assert.ErrorIs(t, err, ErrNoUndoer)
+	assert.ErrorContains(t, err, "boom")
+	assert.Equal(t, []string{"org/docs", "org/api"}, undone, "records are undone newest first")
+	assert.Len(t, results, 4)
+
+	loaded, err := j.Load(op.ID)
+	require.NoError(t, err)
+	assert.Nil(t, loaded.UndoneAt)
+	assert.NotNil(t, loaded.Records[0].UndoneAt)
+	assert.Equal(t, "boom", loaded.Records[1].UndoError)
+
+	// A retry only replays the records that have not been undone
+	fail = map[string]bool{}
+	RegisterUndoer("unknown.kind", func(context.Context, Record, UndoOptions) (string, error) { return "", nil })
+	undone = nil
+	_, err = loaded.Undo(context.Background(), UndoOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org/web"}, undone)
+	assert.NotNil(t, loaded.UndoneAt)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNoUndoer is returned for records whose kind has no registered undoer.
+var ErrNoUndoer = errors.New("no undo handler registered")
+
+// UndoOptions controls how records are undone.
+type UndoOptions struct {
+	// DryRun reports what would be undone without changing anything.
+	DryRun bool
+	// Force undoes changes even when the target has changed since.
+	Force bool
+}
+
+// Undoer reverses one record. The returned note is shown to the user, e.g.
+// to point out what could not be restored.
+type Undoer func(ctx context.Context, rec Record, opts UndoOptions) (note string, err error)
+
+var (
+	undoersMu sync.RWMutex
+	undoers   = map[string]Undoer{}
+)
+
+// RegisterUndoer registers the undoer for a record kind. Packages that record
+// a kind register its undoer in init.
+func RegisterUndoer(kind string, fn Undoer) {
+	undoersMu.Lock()
+	defer undoersMu.Unlock()
+	undoers[kind] = fn
+}
+
+func undoerFor(kind string) (Undoer, bool) {
+	undoersMu.RLock()
+	defer undoersMu.RUnlock()
+	fn, ok := undoers[kind]
+	return fn, ok
+}
+
+// UndoResult is the outcome of undoing one record.
+type UndoResult struct {
+	Record  Record
+	Note    string
+	Skipped bool
+	Err     error
+}
+
+// Undo reverses the records of op, newest first. Records that were already
+// undone are skipped, so a partly failed undo can be retried. Unless this is
+// a dry run, every result is saved to the journal as it happens.
+func (op *Operation) Undo(ctx context.Context, opts UndoOptions) ([]UndoResult, error) {
+	results := make([]UndoResult, 0, len(op.Records))
+	var errs []error
+
+	for i := len(op.Records) - 1; i >= 0; i-- {
+		rec := &op.Records[i]
+		if rec.UndoneAt != nil {
+			results = append(results, UndoResult{Record: *rec, Skipped: true, Note: "already undone"})
+			continue
+		}
+
+		var (
+			note string
+			err  error
+		)
+		if fn, ok := undoerFor(rec.Kind); ok {
+			note, err = fn(ctx, *rec, opts)
+		} else {
+			err = fmt.Errorf("%w for %s", ErrNoUndoer, rec.Kind)
+		}
+		results = append(results, UndoResult{Record: *rec, Note: note, Err: err})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", rec.Kind, rec.Target, err))
+		}
+		if opts.DryRun {
+			continue
+		}
+
+		if err != nil {
+			rec.UndoError = err.Error()
+		} else {
+			now := time.Now().UTC()
+			rec.UndoneAt = &now
+			rec.UndoError = ""
+		}
+		if err := op.save(); err != nil {
+			return results, err
+		}
+	}
+
+	if !opts.DryRun && len(errs) == 0 {
+		now := time.Now().UTC()
+		op.UndoneAt = &now
+		if err := op.save(); err != nil {
+			return results, err
+		}
+	}
+	return results, errors.Join(errs...)
+}
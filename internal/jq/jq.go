@@ -0,0 +1,686 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package jq evaluates a subset of the jq query language against decoded
+// JSON, for extracting fields from API responses in scripts:
+//
+//	.                    the input
+//	.name .a.b ."x-y"    object fields (null on null input)
+//	.[0] .[-1]           array elements
+//	.[] .items[]         every element of an array or value of an object
+//	.name?               suppress errors from a step
+//	a | b                feed every result of a into b
+//	a, b                 results of a followed by results of b
+//	length keys          length of strings, arrays and objects; sorted keys
+//	select(a == b)       keep inputs for which the condition holds; the
+//	                     operators are == != < <= > >= and the operands
+//	                     paths or literals ("str", 42, true, false, null)
+//	not                  logical negation
+//
+// Every expression produces a stream of results.
+package jq
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrSyntax is returned for queries that cannot be parsed.
+var ErrSyntax = errors.New("jq syntax error")
+
+// Query is a parsed query.
+type Query struct {
+	src  string
+	root node
+}
+
+// Parse parses a query.
+func Parse(src string) (*Query, error) {
+	p := &parser{src: src}
+	p.next()
+	root, err := p.parsePipe()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %q", p.tok.text)
+	}
+	return &Query{src: src, root: root}, nil
+}
+
+// String returns the source of the query.
+func (q *Query) String() string {
+	return q.src
+}
+
+// Run evaluates the query against a value decoded with encoding/json and
+// returns its results.
+func (q *Query) Run(input any) ([]any, error) {
+	return q.root.eval(input)
+}
+
+// RunJSON decodes data and evaluates the query against it.
+func (q *Query) RunJSON(data []byte) ([]any, error) {
+	var input any
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	if err := dec.Decode(&input); err != nil {
+		return nil, fmt.Errorf("invalid JSON input: %w", err)
+	}
+	return q.Run(input)
+}
+
+// Format renders a result the way `jq -r` does: strings without quotes,
+// everything else as compact JSON.
+func Format(v any) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// node is an expression.
+type node interface {
+	eval(input any) ([]any, error)
+}
+
+type identity struct{}
+
+func (identity) eval(input any) ([]any, error) { return []any{input}, nil }
+
+type literal struct{ value any }
+
+func (l literal) eval(any) ([]any, error) { return []any{l.value}, nil }
+
+// pipe feeds every result of left into right.
+type pipe struct{ left, right node }
+
+func (p pipe) eval(input any) ([]any, error) {
+	lefts, err := p.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, v := range lefts {
+		rights, err := p.right.eval(v)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rights...)
+	}
+	return out, nil
+}
+
+// comma concatenates the results of its parts.
+type comma struct{ parts []node }
+
+func (c comma) eval(input any) ([]any, error) {
+	var out []any
+	for _, part := range c.parts {
+		vs, err := part.eval(input)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vs...)
+	}
+	return out, nil
+}
+
+// field is .name.
+type field struct {
+	name     string
+	optional bool
+}
+
+func (f field) eval(input any) ([]any, error) {
+	switch v := input.(type) {
+	case nil:
+		return []any{nil}, nil
+	case map[string]any:
+		return []any{v[f.name]}, nil
+	default:
+		if f.optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot index %s with %q", typeName(input), f.name)
+	}
+}
+
+// index is .[n].
+type index struct {
+	n        int
+	optional bool
+}
+
+func (ix index) eval(input any) ([]any, error) {
+	switch v := input.(type) {
+	case nil:
+		return []any{nil}, nil
+	case []any:
+		n := ix.n
+		if n < 0 {
+			n += len(v)
+		}
+		if n < 0 || n >= len(v) {
+			return []any{nil}, nil
+		}
+		return []any{v[n]}, nil
+	default:
+		if ix.optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot index %s with number", typeName(input))
+	}
+}
+
+// iterate is .[].
+type iterate struct{ optional bool }
+
+func (it iterate) eval(input any) ([]any, error) {
+	switch v := input.(type) {
+	case []any:
+		return append([]any(nil), v...), nil
+	case map[string]any:
+		keys := sortedKeys(v)
+		out := make([]any, 0, len(keys))
+		for _, k := range keys {
+			out = append(out, v[k])
+		}
+		return out, nil
+	default:
+		if it.optional {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot iterate over %s", typeName(input))
+	}
+}
+
+type lengthFn struct{}
+
+func (lengthFn) eval(input any) ([]any, error) {
+	switch v := input.(type) {
+	case nil:
+		return []any{0}, nil
+	case string:
+		return []any{len([]rune(v))}, nil
+	case []any:
+		return []any{len(v)}, nil
+	case map[string]any:
+		return []any{len(v)}, nil
+	case json.Number:
+		f, _ := v.Float64()
+		return []any{math.Abs(f)}, nil
+	default:
+		return nil, fmt.Errorf("%s has no length", typeName(input))
+	}
+}
+
+type keysFn struct{}
+
+func (keysFn) eval(input any) ([]any, error) {
+	switch v := input.(type) {
+	case map[string]any:
+		keys := sortedKeys(v)
+		out := make([]any, len(keys))
+		for i, k := range keys {
+			out[i] = k
+		}
+		return []any{out}, nil
+	case []any:
+		out := make([]any, len(v))
+		for i := range v {
+			out[i] = i
+		}
+		return []any{out}, nil
+	default:
+		return nil, fmt.Errorf("%s has no keys", typeName(input))
+	}
+}
+
+type notFn struct{}
+
+func (notFn) eval(input any) ([]any, error) { return []any{!truthy(input)}, nil }
+
+// selectFn keeps its input when the condition yields a true value.
+type selectFn struct{ cond node }
+
+func (s selectFn) eval(input any) ([]any, error) {
+	results, err := s.cond.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, r := range results {
+		if truthy(r) {
+			out = append(out, input)
+		}
+	}
+	return out, nil
+}
+
+// compare evaluates both operands against the same input.
+type compare struct {
+	op          string
+	left, right node
+}
+
+func (c compare) eval(input any) ([]any, error) {
+	lefts, err := c.left.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	rights, err := c.right.eval(input)
+	if err != nil {
+		return nil, err
+	}
+	var out []any
+	for _, r := range rights {
+		for _, l := range lefts {
+			out = append(out, compareValues(c.op, l, r))
+		}
+	}
+	return out, nil
+}
+
+func compareValues(op string, l, r any) bool {
+	lf, lok := number(l)
+	rf, rok := number(r)
+	switch op {
+	case "==":
+		if lok && rok {
+			return lf == rf
+		}
+		return reflect.DeepEqual(l, r)
+	case "!=":
+		if lok && rok {
+			return lf != rf
+		}
+		return !reflect.DeepEqual(l, r)
+	}
+
+	var c int
+	switch {
+	case lok && rok:
+		c = cmpOrdered(lf, rf)
+	default:
+		ls, lsok := l.(string)
+		rs, rsok := r.(string)
+		if !lsok || !rsok {
+			return false
+		}
+		c = strings.Compare(ls, rs)
+	}
+	switch op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+func cmpOrdered(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func truthy(v any) bool {
+	b, isBool := v.(bool)
+	return v != nil && (!isBool || b)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number, float64, int:
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// Lexer and parser.
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokDot
+	tokIdent
+	tokString
+	tokNumber
+	tokLBracket
+	tokRBracket
+	tokLParen
+	tokRParen
+	tokPipe
+	tokComma
+	tokQuestion
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	text string
+	pos  int
+}
+
+type parser struct {
+	src string
+	pos int
+	tok token
+	err error
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w at position %d: %s", ErrSyntax, p.tok.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) next() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokEOF, pos: start}
+		return
+	}
+
+	c := p.src[p.pos]
+	single := map[byte]tokKind{
+		'.': tokDot, '[': tokLBracket, ']': tokRBracket, '(': tokLParen,
+		')': tokRParen, '|': tokPipe, ',': tokComma, '?': tokQuestion,
+	}
+	switch {
+	case single[c] != 0:
+		p.pos++
+		p.tok = token{kind: single[c], text: string(c), pos: start}
+	case c == '"':
+		p.pos++
+		var b strings.Builder
+		for p.pos < len(p.src) && p.src[p.pos] != '"' {
+			if p.src[p.pos] == '\\' && p.pos+1 < len(p.src) {
+				p.pos++
+			}
+			b.WriteByte(p.src[p.pos])
+			p.pos++
+		}
+		if p.pos >= len(p.src) {
+			p.err = fmt.Errorf("%w at position %d: unterminated string", ErrSyntax, start+1)
+			p.tok = token{kind: tokEOF, pos: start}
+			return
+		}
+		p.pos++
+		p.tok = token{kind: tokString, text: b.String(), pos: start}
+	case c == '-' || (c >= '0' && c <= '9'):
+		p.pos++
+		for p.pos < len(p.src) && (p.src[p.pos] == '.' || (p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+			p.pos++
+		}
+		p.tok = token{kind: tokNumber, text: p.src[start:p.pos], pos: start}
+	case strings.ContainsRune("=!<>", rune(c)):
+		p.pos++
+		if p.pos < len(p.src) && p.src[p.pos] == '=' {
+			p.pos++
+		}
+		p.tok = token{kind: tokOp, text: p.src[start:p.pos], pos: start}
+	case c == '_' || unicode.IsLetter(rune(c)):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || unicode.IsLetter(rune(p.src[p.pos])) || unicode.IsDigit(rune(p.src[p.pos]))) {
+			p.pos++
+		}
+		p.tok = token{kind: tokIdent, text: p.src[start:p.pos], pos: start}
+	default:
+		p.pos++
+		p.tok = token{kind: tokOp, text: string(c), pos: start}
+	}
+}
+
+func (p *parser) expect(kind tokKind, text string) error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.tok.kind != kind {
+		return p.errorf("expected %q", text)
+	}
+	p.next()
+	return nil
+}
+
+// parsePipe parses comma-separated expressions joined by pipes.
+func (p *parser) parsePipe() (node, error) {
+	left, err := p.parseComma()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokPipe {
+		p.next()
+		right, err := p.parseComma()
+		if err != nil {
+			return nil, err
+		}
+		left = pipe{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseComma() (node, error) {
+	first, err := p.parseCompare()
+	if err != nil {
+		return nil, err
+	}
+	parts := []node{first}
+	for p.tok.kind == tokComma {
+		p.next()
+		part, err := p.parseCompare()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) == 1 {
+		return first, nil
+	}
+	return comma{parts: parts}, nil
+}
+
+func (p *parser) parseCompare() (node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokOp {
+		return left, nil
+	}
+	op := p.tok.text
+	switch op {
+	case "==", "!=", "<", "<=", ">", ">=":
+	default:
+		return nil, p.errorf("unsupported operator %q", op)
+	}
+	p.next()
+	right, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	return compare{op: op, left: left, right: right}, nil
+}
+
+func (p *parser) parseTerm() (node, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	switch p.tok.kind {
+	case tokDot:
+		return p.parsePath()
+	case tokString:
+		v := p.tok.text
+		p.next()
+		return literal{value: v}, nil
+	case tokNumber:
+		text := p.tok.text
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return nil, p.errorf("invalid number %q", text)
+		}
+		p.next()
+		return literal{value: json.Number(text)}, nil
+	case tokLParen:
+		p.next()
+		inner, err := p.parsePipe()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(tokRParen, ")")
+	case tokIdent:
+		name := p.tok.text
+		p.next()
+		switch name {
+		case "true", "false":
+			return literal{value: name == "true"}, nil
+		case "null":
+			return literal{value: nil}, nil
+		case "length":
+			return lengthFn{}, nil
+		case "keys":
+			return keysFn{}, nil
+		case "not":
+			return notFn{}, nil
+		case "select":
+			if err := p.expect(tokLParen, "("); err != nil {
+				return nil, err
+			}
+			cond, err := p.parsePipe()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(tokRParen, ")"); err != nil {
+				return nil, err
+			}
+			return selectFn{cond: cond}, nil
+		}
+		return nil, p.errorf("unsupported function %q", name)
+	case tokEOF:
+		return nil, p.errorf("unexpected end of query")
+	default:
+		return nil, p.errorf("unexpected %q", p.tok.text)
+	}
+}
+
+// parsePath parses a path starting at a dot: ".", ".a.b", ".[0]", ".a[]?".
+func (p *parser) parsePath() (node, error) {
+	var steps []node
+	for {
+		switch {
+		case p.tok.kind == tokDot:
+			p.next()
+			switch p.tok.kind {
+			case tokIdent, tokString:
+				steps = append(steps, field{name: p.tok.text})
+				p.next()
+			case tokLBracket:
+				// ".[" is handled as a bracket step below
+			default:
+				if len(steps) > 0 {
+					return nil, p.errorf("expected field name after \".\"")
+				}
+			}
+		case p.tok.kind == tokLBracket:
+			p.next()
+			switch p.tok.kind {
+			case tokRBracket:
+				steps = append(steps, iterate{})
+			case tokNumber:
+				n, err := strconv.Atoi(p.tok.text)
+				if err != nil {
+					return nil, p.errorf("invalid index %q", p.tok.text)
+				}
+				steps = append(steps, index{n: n})
+				p.next()
+			case tokString:
+				steps = append(steps, field{name: p.tok.text})
+				p.next()
+			default:
+				return nil, p.errorf("expected index, string or \"]\"")
+			}
+			if err := p.expect(tokRBracket, "]"); err != nil {
+				return nil, err
+			}
+		case p.tok.kind == tokQuestion && len(steps) > 0:
+			p.next()
+			switch s := steps[len(steps)-1].(type) {
+			case field:
+				s.optional = true
+				steps[len(steps)-1] = s
+			case index:
+				s.optional = true
+				steps[len(steps)-1] = s
+			case iterate:
+				s.optional = true
+				steps[len(steps)-1] = s
+			}
+		default:
+			if p.err != nil {
+				return nil, p.err
+			}
+			if len(steps) == 0 {
+				return identity{}, nil
+			}
+			var n node = steps[0]
+			for _, s := range steps[1:] {
+				n = pipe{left: n, right: s}
+			}
+			return n, nil
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package jq
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const repos = `[
+	{"name": "app", "archived": false, "stars": 12, "owner": {"login": "acme"}, "topics": ["go", "cli"]},
+	{"name": "old", "archived": true, "stars": 3, "owner": {"login": "acme"}, "topics": []},
+	{"name": "web", "archived": false, "stars": 40, "owner": null, "topics": ["js"]}
+]`
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{".", []string{strings.Join(strings.Fields(`[{"archived":false,"name":"app","owner":{"login":"acme"},"stars":12,"topics":["go","cli"]},{"archived":true,"name":"old","owner":{"login":"acme"},"stars":3,"topics":[]},{"archived":false,"name":"web","owner":null,"stars":40,"topics":["js"]}]`), "")}},
+		{".[].name", []string{"app", "old", "web"}},
+		{".[0].name", []string{"app"}},
+		{".[-1].name", []string{"web"}},
+		{".[5]", []string{"null"}},
+		{".[].owner.login", []string{"acme", "acme", "null"}},
+		{`.[0]["owner"].login`, []string{"acme"}},
+		{".[0].topics[]", []string{"go", "cli"}},
+		{".[] | select(.archived) | .name", []string{"old"}},
+		{".[] | select(.archived | not) | .name", []string{"app", "web"}},
+		{`.[] | select(.name == "web") | .stars`, []string{"40"}},
+		{".[] | select(.stars >= 12) | .name", []string{"app", "web"}},
+		{".[] | select(.topics | length > 0) | .name", []string{"app", "web"}},
+		{"length", []string{"3"}},
+		{".[0] | keys", []string{`["archived","name","owner","stars","topics"]`}},
+		{".[0] | .name, .stars", []string{"app", "12"}},
+		{".[].name?", []string{"app", "old", "web"}},
+		{".[0].name[]?", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query)
+			require.NoError(t, err)
+			results, err := q.RunJSON([]byte(repos))
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range results {
+				s, err := Format(r)
+				require.NoError(t, err)
+				got = append(got, s)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	for _, query := range []string{"", ".[", ".a |", "map(.a)", `.a == "x`, ".a + 1"} {
+		_, err := Parse(query)
+		assert.ErrorIs(t, err, ErrSyntax, query)
+	}
+
+	q, err := Parse(".name")
+	require.NoError(t, err)
+	_, err = q.RunJSON([]byte(`[1, 2]`))
+	assert.ErrorContains(t, err, `cannot index array with "name"`)
+
+	_, err = q.RunJSON([]byte(`{`))
+	assert.ErrorContains(t, err, "invalid JSON input")
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package jsonstream decodes JSON arrays one element at a time, so provider
+// responses with thousands of items are processed with the memory of a
+// single item instead of the whole response.
+package jsonstream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotArray is returned when the input is not a JSON array.
+var ErrNotArray = errors.New("not a JSON array")
+
+// DecodeArray decodes the elements of the JSON array read from r and calls
+// fn for each, in order, as soon as it is decoded. An error from fn stops
+// decoding and is returned as is. A JSON null is an empty array. The number
+// of elements passed to fn is returned.
+func DecodeArray[T any](r io.Reader, fn func(T) error) (int, error) {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read array: %w", err)
+	}
+	if tok == nil {
+		return 0, nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return 0, fmt.Errorf("%w: starts with %v", ErrNotArray, tok)
+	}
+
+	n := 0
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return n, fmt.Errorf("failed to decode element %d: %w", n, err)
+		}
+		if err := fn(item); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if _, err := dec.Token(); err != nil {
+		return n, fmt.Errorf("failed to read array end: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package jsonstream
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Name string `json:"name"`
+}
+
+func TestDecodeArray(t *testing.T) {
+	var names []string
+	n, err := DecodeArray(strings.NewReader(`[{"name":"a","extra":[1,2]}, {"name":"b"}]`), func(it item) error {
+		names = append(names, it.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"a", "b"}, names)
+
+	n, err = DecodeArray(strings.NewReader(`null`), func(item) error { return nil })
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	_, err = DecodeArray(strings.NewReader(`{"message":"Not Found"}`), func(item) error { return nil })
+	assert.ErrorIs(t, err, ErrNotArray)
+
+	_, err = DecodeArray(strings.NewReader(`[{"name":"a"}, {"name":`), func(item) error { return nil })
+	assert.ErrorContains(t, err, "element 1")
+
+	stop := errors.New("stop")
+	n, err = DecodeArray(strings.NewReader(`[{"name":"a"}, {"name":"b"}]`), func(item) error { return stop })
+	assert.ErrorIs(t, err, stop)
+	assert.Zero(t, n)
+}
+
+// TestDecodeArrayStreams checks that elements are handed out before the
+// rest of the array has been read.
+func TestDecodeArrayStreams(t *testing.T) {
+	r, w := io.Pipe()
+	first := make(chan struct{})
+	go func() {
+		fmt.Fprint(w, `[{"name":"first"}`)
+		select {
+		case <-first:
+			fmt.Fprint(w, `,{"name":"second"}]`)
+			w.Close()
+		case <-time.After(5 * time.Second):
+			w.CloseWithError(errors.New("first element was not decoded before the rest arrived"))
+		}
+	}()
+
+	var names []string
+	_, err := DecodeArray(r, func(it item) error {
+		if len(names) == 0 {
+			close(first)
+		}
+		names = append(names, it.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, names)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package logquery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func entry(t *testing.T, line string) Entry {
+	t.Helper()
+	e, ok := ParseEntry([]byte(line))
+	require.True(t, ok)
+	return e
+}
+
+func TestParseEntry(t *testing.T) {
+	e := entry(t, `{"time":"2025-06-01T11:50:00Z","level":"WARN","msg":"retrying","component":"git","attempt":2,"error":{"type":"NetworkError"}}`)
+	assert.Equal(t, "WARN", e.Level.String())
+	assert.Equal(t, "retrying", e.Message)
+	assert.Equal(t, "git", e.Component())
+	v, ok := e.Field("error.type")
+	assert.True(t, ok)
+	assert.Equal(t, "NetworkError", v)
+	v, _ = e.Field("attempt")
+	assert.Equal(t, "2", v)
+
+	e = entry(t, `{"timestamp":"2025-06-01T11:00:00Z","level":"error","message":"failed","module":"synclone"}`)
+	assert.Equal(t, "ERROR", e.Level.String())
+	assert.Equal(t, "synclone", e.Component())
+
+	_, ok = ParseEntry([]byte("plain text line"))
+	assert.False(t, ok)
+}
+
+func TestQueryMatch(t *testing.T) {
+	warn := entry(t, `{"time":"2025-06-01T11:50:00Z","level":"WARN","msg":"Connection timeout","component":"git","attempt":2}`)
+	info := entry(t, `{"time":"2025-06-01T10:00:00Z","level":"INFO","msg":"cloned","component":"git","attempt":10}`)
+	errEntry := entry(t, `{"time":"2025-06-01T11:59:00Z","level":"ERROR","msg":"refused","component":"synclone"}`)
+
+	tests := []struct {
+		query string
+		want  []bool // warn, info, error
+	}{
+		{"module=git AND level>=warn SINCE 30m", []bool{true, false, false}},
+		{"level>=warn", []bool{true, false, true}},
+		{"level=info OR component=synclone", []bool{false, true, true}},
+		{"NOT module=git", []bool{false, false, true}},
+		{`msg~"timeout|refused"`, []bool{true, false, true}},
+		{`msg!~"(?i)timeout"`, []bool{false, true, true}},
+		{"attempt>5", []bool{false, true, false}},
+		{"attempt!=2", []bool{false, true, true}},
+		{"TIMEOUT", []bool{true, false, false}},
+		{"(level=warn OR level=error) module=git", []bool{true, false, false}},
+		{"SINCE 1h AND level<error", []bool{true, false, false}},
+		{"SINCE 2025-06-01T09:00:00Z UNTIL 2025-06-01T11:00:00Z", []bool{false, true, false}},
+		{"", []bool{true, true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, err := Parse(tt.query, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, []bool{q.Match(warn), q.Match(info), q.Match(errEntry)})
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, query := range []string{
+		"level>=loud",
+		"msg~(",
+		`msg="unterminated`,
+		"module=",
+		"(level=warn",
+		"level=warn)",
+		"SINCE yesterday",
+		"a => b",
+		"SINCE 1h UNTIL 2h",
+		"AND level=warn",
+	} {
+		_, err := Parse(query, now)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestStoreQuery(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gzh.log")
+	write := func(name string, lines ...string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+	}
+	write("gzh.log.2", `{"time":"2025-06-01T09:00:00Z","level":"ERROR","msg":"oldest"}`)
+	write("gzh.log.1", `{"time":"2025-06-01T10:00:00Z","level":"ERROR","msg":"older"}`, "not json")
+	write("gzh.log", `{"time":"2025-06-01T11:00:00Z","level":"INFO","msg":"noise"}`, `{"time":"2025-06-01T11:30:00Z","level":"ERROR","msg":"newest"}`)
+
+	store := NewStore(path)
+	files, err := store.Files()
+	require.NoError(t, err)
+	assert.Equal(t, []string{path + ".2", path + ".1", path}, files)
+
+	q, err := Parse("level=error", now)
+	require.NoError(t, err)
+
+	entries, err := store.Query(q, 0)
+	require.NoError(t, err)
+	var msgs []string
+	for _, e := range entries {
+		msgs = append(msgs, e.Message)
+	}
+	assert.Equal(t, []string{"oldest", "older", "newest"}, msgs)
+
+	entries, err = store.Query(q, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "older", entries[0].Message)
+	assert.Equal(t, "newest", entries[1].Message)
+
+	_, err = NewStore(filepath.Join(dir, "missing.log")).Query(q, 10)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
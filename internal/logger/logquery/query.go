@@ -0,0 +1,458 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package logquery filters the JSON log files written by the gz loggers with
+// a small query language, so recent logs can be searched locally.
+//
+// A query combines comparisons with AND, OR, NOT and parentheses, and may end
+// with SINCE and UNTIL time bounds:
+//
+//	module=git AND level>=warn SINCE 30m
+//	(msg~"timeout|refused" OR error.type=NetworkError) AND NOT module=synclone
+//	level=error SINCE 2025-06-01 UNTIL 2025-06-02T12:00:00Z
+//	"rate limit" SINCE 2h
+//
+// Operators are = != < <= > >= ~ (regular expression) and !~. level compares
+// by severity (debug < info < warn < error); time compares as a timestamp;
+// other fields compare numerically when both sides are numbers. msg and
+// message select the log message, module and component the logging
+// component, and dotted names reach into nested objects. A bare word or
+// quoted string matches messages containing it, ignoring case. Time bounds
+// are durations before now (30m, 12h, 7d) or RFC 3339 timestamps and dates.
+package logquery
+
+import (
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Query is a parsed log query.
+type Query struct {
+	// Since and Until bound the entry time; zero values are unbounded.
+	Since time.Time
+	Until time.Time
+
+	expr node
+}
+
+// Parse parses a query. now anchors relative SINCE and UNTIL durations.
+func Parse(input string, now time.Time) (*Query, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{}
+	tokens, err = q.extractTimeBounds(tokens, now)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if len(tokens) > 0 {
+		q.expr, err = p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok, ok := p.peek(); ok {
+			return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+		}
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && q.Until.Before(q.Since) {
+		return nil, fmt.Errorf("UNTIL is before SINCE")
+	}
+	return q, nil
+}
+
+// Match reports whether an entry satisfies the query.
+func (q *Query) Match(e Entry) bool {
+	if !q.Since.IsZero() && e.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && e.Time.After(q.Until) {
+		return false
+	}
+	return q.expr == nil || q.expr.match(e)
+}
+
+// extractTimeBounds removes top-level SINCE/UNTIL clauses, together with an
+// AND that joined them to the rest of the query.
+func (q *Query) extractTimeBounds(tokens []token, now time.Time) ([]token, error) {
+	out := make([]token, 0, len(tokens))
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch tok.kind {
+		case tokLParen:
+			depth++
+		case tokRParen:
+			depth--
+		}
+		keyword := strings.ToUpper(tok.text)
+		if tok.kind != tokWord || depth != 0 || (keyword != "SINCE" && keyword != "UNTIL") {
+			out = append(out, tok)
+			continue
+		}
+
+		if i+1 >= len(tokens) || (tokens[i+1].kind != tokWord && tokens[i+1].kind != tokString) {
+			return nil, fmt.Errorf("%s requires a duration or timestamp", keyword)
+		}
+		t, err := parseTime(tokens[i+1].text, now)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", keyword, err)
+		}
+		if keyword == "SINCE" {
+			q.Since = t
+		} else {
+			q.Until = t
+		}
+		i++
+
+		if n := len(out); n > 0 && isKeyword(out[n-1], "AND") {
+			out = out[:n-1]
+		} else if i+1 < len(tokens) && isKeyword(tokens[i+1], "AND") {
+			i++
+		}
+	}
+	return out, nil
+}
+
+// parseTime accepts durations before now (with a d suffix for days),
+// RFC 3339 timestamps and dates.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use a duration like 30m or 7d, or an RFC 3339 time)", s)
+}
+
+// Lexer
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func isKeyword(tok token, keyword string) bool {
+	return tok.kind == tokWord && strings.EqualFold(tok.text, keyword)
+}
+
+func isOpChar(r rune) bool {
+	return r == '=' || r == '!' || r == '<' || r == '>' || r == '~'
+}
+
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			var b strings.Builder
+			for i++; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && (runes[i+1] == r || runes[i+1] == '\\') {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, token{tokString, b.String(), start})
+		case isOpChar(r):
+			start := i
+			for i < len(runes) && isOpChar(runes[i]) {
+				i++
+			}
+			op := string(runes[start:i])
+			switch op {
+			case "=", "==", "!=", "<", "<=", ">", ">=", "~", "=~", "!~":
+			default:
+				return nil, fmt.Errorf("unknown operator %q at position %d", op, start)
+			}
+			tokens = append(tokens, token{tokOp, op, start})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' && !isOpChar(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{tokWord, string(runes[start:i]), start})
+		}
+	}
+	return tokens, nil
+}
+
+// Parser
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *parser) next() (token, bool) {
+	tok, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return tok, ok
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || !isKeyword(tok, "OR") {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || tok.kind == tokRParen || isKeyword(tok, "OR") {
+			return left, nil
+		}
+		// Adjacent terms without an operator are joined with AND.
+		if isKeyword(tok, "AND") {
+			p.pos++
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+func (p *parser) parseNot() (node, error) {
+	tok, ok := p.peek()
+	if ok && isKeyword(tok, "NOT") {
+		p.pos++
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok, ok := p.next()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+
+	switch tok.kind {
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.next(); !ok || closing.kind != tokRParen {
+			return nil, fmt.Errorf("missing ')' for '(' at position %d", tok.pos)
+		}
+		return inner, nil
+	case tokString:
+		return containsNode{strings.ToLower(tok.text)}, nil
+	case tokWord:
+		if isKeyword(tok, "AND") || isKeyword(tok, "OR") {
+			return nil, fmt.Errorf("unexpected %s at position %d", strings.ToUpper(tok.text), tok.pos)
+		}
+		op, ok := p.peek()
+		if !ok || op.kind != tokOp {
+			return containsNode{strings.ToLower(tok.text)}, nil
+		}
+		p.pos++
+		value, ok := p.next()
+		if !ok || (value.kind != tokWord && value.kind != tokString) {
+			return nil, fmt.Errorf("missing value after %s%s", tok.text, op.text)
+		}
+		return newCompareNode(tok.text, op.text, value.text)
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+}
+
+// Evaluation
+
+type node interface {
+	match(e Entry) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) match(e Entry) bool { return n.left.match(e) && n.right.match(e) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) match(e Entry) bool { return n.left.match(e) || n.right.match(e) }
+
+type notNode struct{ inner node }
+
+func (n notNode) match(e Entry) bool { return !n.inner.match(e) }
+
+type containsNode struct{ text string }
+
+func (n containsNode) match(e Entry) bool {
+	return strings.Contains(strings.ToLower(e.Message), n.text)
+}
+
+type compareNode struct {
+	field string
+	op    string
+	value string
+
+	re    *regexp.Regexp
+	level slog.Level
+	time  time.Time
+}
+
+func newCompareNode(field, op, value string) (node, error) {
+	switch op {
+	case "==":
+		op = "="
+	case "=~":
+		op = "~"
+	}
+	n := &compareNode{field: canonicalField(field), op: op, value: value}
+
+	switch {
+	case op == "~" || op == "!~":
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression for %s: %w", field, err)
+		}
+		n.re = re
+	case n.field == fieldLevel:
+		level, ok := ParseLevel(value)
+		if !ok {
+			return nil, fmt.Errorf("unknown level %q (use debug, info, warn or error)", value)
+		}
+		n.level = level
+	case n.field == fieldTime:
+		t, err := parseTime(value, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		n.time = t
+	}
+	return n, nil
+}
+
+func (n *compareNode) match(e Entry) bool {
+	if n.re != nil {
+		value, ok := e.Field(n.field)
+		return ok && n.re.MatchString(value) == (n.op == "~")
+	}
+
+	var cmp int
+	switch n.field {
+	case fieldLevel:
+		cmp = compareOrdered(e.Level, n.level)
+	case fieldTime:
+		if e.Time.IsZero() {
+			return false
+		}
+		cmp = e.Time.Compare(n.time)
+	default:
+		value, ok := e.Field(n.field)
+		if !ok {
+			return n.op == "!="
+		}
+		cmp = compareValues(value, n.value)
+	}
+
+	switch n.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+func compareOrdered[T int | float64 | slog.Level](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareValues compares numerically when both values are numbers.
+func compareValues(a, b string) int {
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			return compareOrdered(x, y)
+		}
+	}
+	return strings.Compare(a, b)
+}
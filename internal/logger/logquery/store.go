@@ -0,0 +1,265 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package logquery
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/config"
+)
+
+// Well-known fields.
+const (
+	fieldLevel     = "level"
+	fieldTime      = "time"
+	fieldMessage   = "msg"
+	fieldComponent = "component"
+)
+
+// canonicalField maps field aliases to the names the loggers write.
+func canonicalField(name string) string {
+	switch strings.ToLower(name) {
+	case "level", "severity":
+		return fieldLevel
+	case "time", "timestamp", "ts":
+		return fieldTime
+	case "msg", "message":
+		return fieldMessage
+	case "module", "component":
+		return fieldComponent
+	default:
+		return name
+	}
+}
+
+// ParseLevel parses a level name such as "warn", "WARNING" or "INFO+2".
+func ParseLevel(s string) (slog.Level, bool) {
+	switch strings.ToLower(s) {
+	case "warning":
+		s = "warn"
+	case "err":
+		s = "error"
+	case "fatal", "critical":
+		return slog.LevelError + 4, true
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return 0, false
+	}
+	return level, true
+}
+
+// Entry is one log record.
+type Entry struct {
+	Time    time.Time      `json:"time"`
+	Level   slog.Level     `json:"level"`
+	Message string         `json:"msg"`
+	Fields  map[string]any `json:"fields"`
+}
+
+// Component returns the logging component, or "" when unset.
+func (e Entry) Component() string {
+	v, _ := e.Field(fieldComponent)
+	return v
+}
+
+// Field returns a field as a string. Dotted names select nested values.
+func (e Entry) Field(name string) (string, bool) {
+	switch canonicalField(name) {
+	case fieldLevel:
+		return e.Level.String(), true
+	case fieldMessage:
+		return e.Message, true
+	case fieldTime:
+		if e.Time.IsZero() {
+			return "", false
+		}
+		return e.Time.Format(time.RFC3339Nano), true
+	case fieldComponent:
+		for _, key := range []string{"component", "module"} {
+			if s, ok := e.Fields[key].(string); ok {
+				return s, true
+			}
+		}
+		return "", false
+	}
+
+	var v any = e.Fields
+	for _, part := range strings.Split(name, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		if v, ok = m[part]; !ok {
+			return "", false
+		}
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case nil:
+		return "", false
+	case json.Number, bool:
+		return fmt.Sprint(v), true
+	default:
+		data, _ := json.Marshal(v)
+		return string(data), true
+	}
+}
+
+// ParseEntry decodes one JSON log line as written by slog's JSON handler or
+// the structured logger. ok is false for lines that are not JSON objects.
+func ParseEntry(line []byte) (Entry, bool) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var fields map[string]any
+	if err := dec.Decode(&fields); err != nil || fields == nil {
+		return Entry{}, false
+	}
+
+	e := Entry{Level: slog.LevelInfo, Fields: fields}
+	for _, key := range []string{"time", "timestamp"} {
+		if s, ok := fields[key].(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+				e.Time = t
+				break
+			}
+		}
+	}
+	if s, ok := fields["level"].(string); ok {
+		if level, ok := ParseLevel(s); ok {
+			e.Level = level
+		}
+	}
+	for _, key := range []string{"msg", "message"} {
+		if s, ok := fields[key].(string); ok {
+			e.Message = s
+			break
+		}
+	}
+	return e, true
+}
+
+// Store reads a log file and its rotated predecessors (path.1, path.2, ...).
+type Store struct {
+	path string
+}
+
+// NewStore creates a store for the log file at path.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultPath returns the log file configured in the global gz config.
+func DefaultPath() string {
+	cfg, _ := config.LoadGlobalConfig()
+	if cfg == nil {
+		cfg = config.DefaultGlobalConfig()
+	}
+	return cfg.Logging.FilePath
+}
+
+// Path returns the current log file path.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Files returns the existing log files, oldest first.
+func (s *Store) Files() ([]string, error) {
+	matches, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		return nil, fmt.Errorf("invalid log path %s: %w", s.path, err)
+	}
+
+	type rotated struct {
+		path string
+		n    int
+	}
+	var old []rotated
+	for _, m := range matches {
+		if n, err := strconv.Atoi(strings.TrimPrefix(m, s.path+".")); err == nil {
+			old = append(old, rotated{m, n})
+		}
+	}
+	// Higher suffixes are older.
+	sort.Slice(old, func(i, j int) bool { return old[i].n > old[j].n })
+
+	files := make([]string, 0, len(old)+1)
+	for _, r := range old {
+		files = append(files, r.path)
+	}
+	if _, err := os.Stat(s.path); err == nil {
+		files = append(files, s.path)
+	}
+	return files, nil
+}
+
+// Query returns the newest limit entries matching q in chronological order.
+// A limit of zero or less returns every match. Lines that are not JSON are
+// skipped.
+func (s *Store) Query(q *Query, limit int) ([]Entry, error) {
+	files, err := s.Files()
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no log files at %s: %w", s.path, os.ErrNotExist)
+	}
+
+	var matches []Entry
+	for _, file := range files {
+		if err := scanFile(file, func(e Entry) {
+			if !q.Match(e) {
+				return
+			}
+			matches = append(matches, e)
+			// Keep memory bounded on large logs.
+			if limit > 0 && len(matches) >= 2*limit {
+				matches = append(matches[:0], matches[len(matches)-limit:]...)
+			}
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Time.Before(matches[j].Time) })
+	if limit > 0 && len(matches) > limit {
+		matches = matches[len(matches)-limit:]
+	}
+	return matches, nil
+}
+
+func scanFile(path string, fn func(Entry)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		if e, ok := ParseEntry(scanner.Bytes()); ok {
+			fn(e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"maps"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gizzahub/gzh-cli/internal/config"
@@ -27,6 +28,9 @@ var (
 	globalVerbose bool
 	globalDebug   bool
 	globalQuiet   bool
+
+	// globalLevel overrides the configured CLI level at runtime (e.g. config reload).
+	globalLevel atomic.Value
 )
 
 // CommonLogger defines the common interface for both structured and simple loggers.
@@ -84,6 +88,7 @@ func (l *SimpleLogger) WithSession(sessionID string) *SimpleLogger {
 
 // Debug prints a debug message.
 func (l *SimpleLogger) Debug(msg string, args ...any) {
+	streamLog(SimpleLevelDebug, l.component, l.sessionID, msg, l.context, args)
 	if l.shouldLog(SimpleLevelDebug) {
 		l.print(SimpleLevelDebug, msg, args...)
 	}
@@ -91,6 +96,7 @@ func (l *SimpleLogger) Debug(msg string, args ...any) {
 
 // Info prints an info message.
 func (l *SimpleLogger) Info(msg string, args ...any) {
+	streamLog(SimpleLevelInfo, l.component, l.sessionID, msg, l.context, args)
 	if l.shouldLog(SimpleLevelInfo) {
 		l.print(SimpleLevelInfo, msg, args...)
 	}
@@ -98,6 +104,7 @@ func (l *SimpleLogger) Info(msg string, args ...any) {
 
 // Warn prints a warning message.
 func (l *SimpleLogger) Warn(msg string, args ...any) {
+	streamLog(SimpleLevelWarn, l.component, l.sessionID, msg, l.context, args)
 	if l.shouldLog(SimpleLevelWarn) {
 		l.print(SimpleLevelWarn, msg, args...)
 	}
@@ -105,6 +112,7 @@ func (l *SimpleLogger) Warn(msg string, args ...any) {
 
 // Error prints an error message.
 func (l *SimpleLogger) Error(msg string, args ...any) {
+	streamLog(SimpleLevelError, l.component, l.sessionID, msg, l.context, args)
 	if l.shouldLog(SimpleLevelError) {
 		l.print(SimpleLevelError, msg, args...)
 	}
@@ -112,8 +120,9 @@ func (l *SimpleLogger) Error(msg string, args ...any) {
 
 // ErrorWithStack prints an error message with error details.
 func (l *SimpleLogger) ErrorWithStack(err error, msg string, args ...any) {
+	fullMsg := fmt.Sprintf("%s: %v", msg, err)
+	streamLog(SimpleLevelError, l.component, l.sessionID, fullMsg, l.context, args)
 	if l.shouldLog(SimpleLevelError) {
-		fullMsg := fmt.Sprintf("%s: %v", msg, err)
 		l.print(SimpleLevelError, fullMsg, args...)
 	}
 }
@@ -236,6 +245,10 @@ func (l *SimpleLogger) shouldLog(level string) bool {
 		return level != SimpleLevelDebug // Show all except debug
 	}
 
+	if override, _ := globalLevel.Load().(string); override != "" {
+		return levelEnabled(override, level)
+	}
+
 	// Fall back to config-based logic
 	if l.config == nil {
 		return level == SimpleLevelError || level == SimpleLevelWarn // Default: only errors and warnings
@@ -256,9 +269,12 @@ func (l *SimpleLogger) shouldLog(level string) bool {
 		return level == SimpleLevelError || level == SimpleLevelWarn
 	}
 
-	// Check level hierarchy: DEBUG < INFO < WARN < ERROR
-	configLevel := strings.ToUpper(l.config.Level)
-	switch configLevel {
+	return levelEnabled(l.config.Level, level)
+}
+
+// levelEnabled applies the hierarchy DEBUG < INFO < WARN < ERROR.
+func levelEnabled(threshold, level string) bool {
+	switch strings.ToUpper(threshold) {
 	case SimpleLevelDebug:
 		return true // Show all levels
 	case SimpleLevelInfo:
@@ -362,6 +378,13 @@ func SetGlobalLoggingFlags(verbose, debug, quiet bool) {
 	globalQuiet = quiet
 }
 
+// SetGlobalLevel overrides the configured CLI log level (debug, info, warn,
+// error) while the process runs. An empty level restores the configuration.
+// Command line flags still take precedence.
+func SetGlobalLevel(level string) {
+	globalLevel.Store(level)
+}
+
 // IsVerboseEnabled returns whether global verbose logging is enabled.
 func IsVerboseEnabled() bool {
 	return globalVerbose
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/gizzahub/gzh-cli/internal/config"
 )
 
 func TestNewSimpleLogger(t *testing.T) {
@@ -117,6 +119,21 @@ func TestGlobalLogLevelFlags(t *testing.T) {
 	assert.True(t, globalQuiet)
 }
 
+func TestSetGlobalLevel(t *testing.T) {
+	SetGlobalLoggingFlags(false, false, false)
+	defer SetGlobalLevel("")
+
+	logger := &SimpleLogger{config: &config.CLILoggingConfig{Enabled: true, Level: "error"}}
+	assert.False(t, logger.shouldLog(SimpleLevelInfo))
+
+	SetGlobalLevel("info")
+	assert.True(t, logger.shouldLog(SimpleLevelInfo))
+	assert.False(t, logger.shouldLog(SimpleLevelDebug))
+
+	SetGlobalLevel("")
+	assert.False(t, logger.shouldLog(SimpleLevelInfo))
+}
+
 // formatMessage is a private method, so we test it indirectly through print output.
 func TestSimpleLogger_printFormatting(t *testing.T) {
 	// Enable debug mode to show all log levels
@@ -0,0 +1,286 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/trace"
+)
+
+// DefaultStreamBuffer is the number of events buffered per subscriber.
+const DefaultStreamBuffer = 256
+
+// StreamEvent is a log entry delivered to live subscribers.
+type StreamEvent struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"` // debug, info, warn or error
+	Module  string         `json:"module,omitempty"`
+	Message string         `json:"msg"`
+	TraceID string         `json:"traceId,omitempty"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+// StreamFilter selects the events a subscriber receives. Empty fields match
+// everything.
+type StreamFilter struct {
+	// Modules matches events of these modules or their sub-modules, e.g.
+	// "git" matches "git" and "git.clone".
+	Modules []string `json:"modules,omitempty"`
+	// Level is the minimum severity.
+	Level string `json:"level,omitempty"`
+	// TraceID matches events of one operation.
+	TraceID string `json:"traceId,omitempty"`
+}
+
+// Validate checks the filter values.
+func (f StreamFilter) Validate() error {
+	if f.Level != "" && levelRank(f.Level) < 0 {
+		return fmt.Errorf("invalid level %q (valid: debug, info, warn, error)", f.Level)
+	}
+	return nil
+}
+
+// Match reports whether ev passes the filter.
+func (f StreamFilter) Match(ev StreamEvent) bool {
+	if f.Level != "" && levelRank(ev.Level) < levelRank(f.Level) {
+		return false
+	}
+	if f.TraceID != "" && ev.TraceID != f.TraceID {
+		return false
+	}
+	if len(f.Modules) == 0 {
+		return true
+	}
+	for _, m := range f.Modules {
+		if ev.Module == m || strings.HasPrefix(ev.Module, m+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func levelRank(level string) int {
+	switch strings.ToLower(level) {
+	case "debug":
+		return 0
+	case "info":
+		return 1
+	case "warn", "warning":
+		return 2
+	case "error":
+		return 3
+	default:
+		return -1
+	}
+}
+
+// LogStream fans log events out to live subscribers. Publishing never
+// blocks: each subscriber has its own buffer, and events that do not fit
+// are dropped and counted for that subscriber only, so a slow consumer
+// cannot stall logging or other consumers.
+type LogStream struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	active      atomic.Int32
+
+	// recent keeps the last events for crash reports; see KeepRecent.
+	recentMu   sync.Mutex
+	recent     []StreamEvent
+	recentNext int
+	recentFull bool
+	keeping    atomic.Bool
+}
+
+// NewLogStream creates an empty stream.
+func NewLogStream() *LogStream {
+	return &LogStream{subscribers: make(map[*Subscription]struct{})}
+}
+
+var defaultLogStream = NewLogStream()
+
+// DefaultLogStream returns the stream the gz loggers publish to.
+func DefaultLogStream() *LogStream {
+	return defaultLogStream
+}
+
+// Subscription receives the events matching its filter.
+type Subscription struct {
+	stream  *LogStream
+	events  chan StreamEvent
+	filter  atomic.Pointer[StreamFilter]
+	dropped atomic.Uint64
+	once    sync.Once
+}
+
+// Subscribe registers a subscriber with a buffer of size events, or
+// DefaultStreamBuffer when size is not positive.
+func (s *LogStream) Subscribe(filter StreamFilter, size int) *Subscription {
+	if size <= 0 {
+		size = DefaultStreamBuffer
+	}
+	sub := &Subscription{stream: s, events: make(chan StreamEvent, size)}
+	sub.filter.Store(&filter)
+
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+	s.active.Add(1)
+	return sub
+}
+
+// Active reports whether anyone is subscribed or recent events are kept;
+// loggers skip building events otherwise.
+func (s *LogStream) Active() bool {
+	return s.active.Load() > 0 || s.keeping.Load()
+}
+
+// KeepRecent keeps the last n events of every level, whether or not anyone
+// is subscribed, so that they can be attached to a crash report. A
+// non-positive n stops keeping them.
+func (s *LogStream) KeepRecent(n int) {
+	s.recentMu.Lock()
+	defer s.recentMu.Unlock()
+	s.recentNext, s.recentFull = 0, false
+	if n <= 0 {
+		s.recent = nil
+		s.keeping.Store(false)
+		return
+	}
+	s.recent = make([]StreamEvent, n)
+	s.keeping.Store(true)
+}
+
+// Recent returns the kept events, oldest first.
+func (s *LogStream) Recent() []StreamEvent {
+	s.recentMu.Lock()
+	defer s.recentMu.Unlock()
+	if !s.recentFull {
+		return append([]StreamEvent(nil), s.recent[:s.recentNext]...)
+	}
+	out := make([]StreamEvent, 0, len(s.recent))
+	out = append(out, s.recent[s.recentNext:]...)
+	return append(out, s.recent[:s.recentNext]...)
+}
+
+func (s *LogStream) keep(ev StreamEvent) {
+	s.recentMu.Lock()
+	defer s.recentMu.Unlock()
+	if len(s.recent) == 0 {
+		return
+	}
+	s.recent[s.recentNext] = ev
+	s.recentNext++
+	if s.recentNext == len(s.recent) {
+		s.recentNext, s.recentFull = 0, true
+	}
+}
+
+// Publish delivers ev to every subscriber whose filter matches.
+func (s *LogStream) Publish(ev StreamEvent) {
+	if !s.Active() {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	if s.keeping.Load() {
+		s.keep(ev)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for sub := range s.subscribers {
+		if !sub.filter.Load().Match(ev) {
+			continue
+		}
+		select {
+		case sub.events <- ev:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+// Events returns the channel of matching events. It is closed by Close.
+func (s *Subscription) Events() <-chan StreamEvent {
+	return s.events
+}
+
+// SetFilter replaces the filter for subsequent events.
+func (s *Subscription) SetFilter(filter StreamFilter) {
+	s.filter.Store(&filter)
+}
+
+// Filter returns the current filter.
+func (s *Subscription) Filter() StreamFilter {
+	return *s.filter.Load()
+}
+
+// TakeDropped returns the number of events dropped since the last call.
+func (s *Subscription) TakeDropped() uint64 {
+	return s.dropped.Swap(0)
+}
+
+// Close unsubscribes and closes the event channel.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.stream.mu.Lock()
+		delete(s.stream.subscribers, s)
+		s.stream.mu.Unlock()
+		s.stream.active.Add(-1)
+		close(s.events)
+	})
+}
+
+// streamLog publishes a log call of the gz loggers to the default stream.
+// The session ID serves as trace ID unless a traceId attribute is given.
+func streamLog(level, module, sessionID, msg string, context map[string]any, args []any) {
+	if !defaultLogStream.Active() {
+		return
+	}
+
+	attrs := make(map[string]any, len(context)+len(args)/2)
+	for k, v := range context {
+		attrs[k] = v
+	}
+	for i := 0; i+1 < len(args); i += 2 {
+		if key, ok := args[i].(string); ok {
+			if err, isErr := args[i+1].(error); isErr {
+				attrs[key] = err.Error()
+			} else {
+				attrs[key] = args[i+1]
+			}
+		}
+	}
+
+	// 실행 단위 트레이스 ID가 있으면 세션 ID 대신 사용해 프로세스 간 로그를 잇는다
+	traceID := sessionID
+	if id := trace.ID(); id != "" {
+		traceID = id
+	}
+	for _, key := range []string{"traceId", "trace_id"} {
+		if v, ok := attrs[key]; ok {
+			traceID = fmt.Sprint(v)
+			delete(attrs, key)
+			break
+		}
+	}
+	if len(attrs) == 0 {
+		attrs = nil
+	}
+
+	defaultLogStream.Publish(StreamEvent{
+		Time:    time.Now(),
+		Level:   strings.ToLower(level),
+		Module:  module,
+		Message: msg,
+		TraceID: traceID,
+		Attrs:   attrs,
+	})
+}
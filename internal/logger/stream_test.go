@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamFilterMatch(t *testing.T) {
+	ev := StreamEvent{Level: "warn", Module: "git.clone", TraceID: "abc"}
+
+	assert.True(t, StreamFilter{}.Match(ev))
+	assert.True(t, StreamFilter{Modules: []string{"git"}}.Match(ev))
+	assert.True(t, StreamFilter{Modules: []string{"net", "git.clone"}}.Match(ev))
+	assert.False(t, StreamFilter{Modules: []string{"gi"}}.Match(ev))
+	assert.True(t, StreamFilter{Level: "info"}.Match(ev))
+	assert.True(t, StreamFilter{Level: "WARN"}.Match(ev))
+	assert.False(t, StreamFilter{Level: "error"}.Match(ev))
+	assert.True(t, StreamFilter{TraceID: "abc"}.Match(ev))
+	assert.False(t, StreamFilter{TraceID: "xyz"}.Match(ev))
+
+	require.NoError(t, StreamFilter{Level: "debug"}.Validate())
+	assert.ErrorContains(t, StreamFilter{Level: "loud"}.Validate(), `invalid level "loud"`)
+}
+
+func TestLogStreamSlowSubscriber(t *testing.T) {
+	stream := NewLogStream()
+	assert.False(t, stream.Active())
+
+	slow := stream.Subscribe(StreamFilter{}, 2)
+	fast := stream.Subscribe(StreamFilter{Level: "error"}, 10)
+	assert.True(t, stream.Active())
+
+	// 느린 구독자의 버퍼가 가득 차도 Publish는 막히지 않는다
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 5; i++ {
+			stream.Publish(StreamEvent{Level: "error", Message: "boom"})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Publish blocked on a slow subscriber")
+	}
+
+	assert.Len(t, slow.Events(), 2)
+	assert.Equal(t, uint64(3), slow.TakeDropped())
+	assert.Zero(t, slow.TakeDropped())
+	assert.Len(t, fast.Events(), 5)
+	assert.Zero(t, fast.TakeDropped())
+
+	fast.SetFilter(StreamFilter{Modules: []string{"net"}})
+	stream.Publish(StreamEvent{Level: "error", Module: "git"})
+	assert.Len(t, fast.Events(), 5)
+
+	slow.Close()
+	slow.Close()
+	fast.Close()
+	assert.False(t, stream.Active())
+	_, ok := <-fast.Events()
+	assert.True(t, ok, "buffered events remain readable after Close")
+}
+
+func TestStreamLogDefaultStream(t *testing.T) {
+	sub := DefaultLogStream().Subscribe(StreamFilter{Modules: []string{"stream-test"}}, 10)
+	defer sub.Close()
+
+	logger := NewSimpleLogger("stream-test")
+	logger.Info("cloned", "repo", "acme/api", "traceId", "op-1", "err", errors.New("slow"))
+
+	select {
+	case ev := <-sub.Events():
+		assert.Equal(t, "info", ev.Level)
+		assert.Equal(t, "stream-test", ev.Module)
+		assert.Equal(t, "op-1", ev.TraceID)
+		assert.Equal(t, "slow", ev.Attrs["err"])
+		assert.Equal(t, "acme/api", ev.Attrs["repo"])
+		assert.NotContains(t, ev.Attrs, "traceId")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event streamed")
+	}
+}
+
+func TestLogStreamKeepRecent(t *testing.T) {
+	stream := NewLogStream()
+	assert.Empty(t, stream.Recent())
+
+	stream.KeepRecent(3)
+	assert.True(t, stream.Active(), "kept events are built without subscribers")
+	for _, msg := range []string{"a", "b"} {
+		stream.Publish(StreamEvent{Level: "debug", Message: msg})
+	}
+	require.Len(t, stream.Recent(), 2)
+	assert.Equal(t, "a", stream.Recent()[0].Message)
+
+	for _, msg := range []string{"c", "d", "e"} {
+		stream.Publish(StreamEvent{Level: "info", Message: msg})
+	}
+	var got []string
+	for _, ev := range stream.Recent() {
+		got = append(got, ev.Message)
+	}
+	assert.Equal(t, []string{"c", "d", "e"}, got, "the oldest events are dropped first")
+
+	stream.KeepRecent(0)
+	assert.False(t, stream.Active())
+	assert.Empty(t, stream.Recent())
+}
@@ -14,6 +14,8 @@ import (
 	"runtime"
 	"strings"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/trace"
 )
 
 // StructuredLogger provides advanced logging capabilities.
@@ -46,6 +48,7 @@ type LogEntry struct {
 	Message     string           `json:"message"`
 	Component   string           `json:"component"`
 	SessionID   string           `json:"sessionId"`
+	TraceID     string           `json:"traceId,omitempty"`
 	Context     map[string]any   `json:"context,omitempty"`
 	Caller      *CallerInfo      `json:"caller,omitempty"`
 	Error       *ErrorInfo       `json:"error,omitempty"`
@@ -153,6 +156,9 @@ func (l *StructuredLogger) LogPerformance(operation string, duration time.Durati
 
 // log writes a log message with context.
 func (l *StructuredLogger) log(level slog.Level, msg string, args ...any) {
+	// Live subscribers get every entry regardless of the console level
+	streamLog(level.String(), l.component, l.sessionID, msg, l.context, args)
+
 	if !l.logger.Enabled(context.Background(), level) {
 		return
 	}
@@ -177,6 +183,10 @@ func (l *StructuredLogger) log(level slog.Level, msg string, args ...any) {
 		slog.String("callerFunction", caller.Function),
 	)
 
+	if id := trace.ID(); id != "" {
+		attrs = append(attrs, slog.String("traceId", id))
+	}
+
 	// Add context attributes
 	for k, v := range l.context {
 		attrs = append(attrs, slog.Any(k, v))
@@ -195,7 +205,9 @@ func (l *StructuredLogger) log(level slog.Level, msg string, args ...any) {
 }
 
 // logWithError logs a message with error information.
-func (l *StructuredLogger) logWithError(level slog.Level, err error, msg string, _ ...any) {
+func (l *StructuredLogger) logWithError(level slog.Level, err error, msg string, args ...any) {
+	streamLog(level.String(), l.component, l.sessionID, msg, l.context, append(args, "error", err))
+
 	if !l.logger.Enabled(context.Background(), level) {
 		return
 	}
@@ -212,6 +224,7 @@ func (l *StructuredLogger) logWithError(level slog.Level, err error, msg string,
 		Message:   msg,
 		Component: l.component,
 		SessionID: l.sessionID,
+		TraceID:   trace.ID(),
 		Context:   l.context,
 		Caller:    caller,
 		Error:     errorInfo,
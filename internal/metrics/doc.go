@@ -0,0 +1,10 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package metrics provides a lightweight Prometheus-compatible metrics registry
+// and an embeddable /metrics endpoint for long-running gz commands.
+//
+// Metrics are exposed in the Prometheus text exposition format (version 0.0.4),
+// so any Prometheus server or compatible agent can scrape them without gz
+// depending on the Prometheus client library.
+package metrics
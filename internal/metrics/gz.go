@@ -0,0 +1,169 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/trace"
+	"github.com/gizzahub/gzh-cli/pkg/api"
+)
+
+// gz 명령어에서 공통으로 사용하는 메트릭.
+var (
+	// CloneOperations counts repository clone/update operations by provider, operation and result.
+	CloneOperations = Default().Counter("gz_clone_operations_total",
+		"Repository clone and update operations.", "provider", "operation", "result")
+
+	// CloneDuration observes how long clone/update operations take.
+	CloneDuration = Default().Histogram("gz_clone_duration_seconds",
+		"Duration of repository clone and update operations.", nil, "provider", "operation")
+
+	// APIRequests counts provider API requests by provider, method and status code.
+	APIRequests = Default().Counter("gz_api_requests_total",
+		"Provider API requests.", "provider", "method", "code")
+
+	// APIRequestDuration observes provider API call latencies.
+	APIRequestDuration = Default().Histogram("gz_api_request_duration_seconds",
+		"Provider API request latency.", nil, "provider", "method")
+
+	// RateLimitRemaining reports remaining provider API requests in the current window.
+	RateLimitRemaining = Default().Gauge("gz_api_rate_limit_remaining",
+		"Remaining provider API requests before the rate limit resets.", "provider")
+
+	// RateLimitLimit reports the provider API request limit per window.
+	RateLimitLimit = Default().Gauge("gz_api_rate_limit_limit",
+		"Provider API request limit per rate limit window.", "provider")
+
+	// APICacheRequests counts conditional provider API requests by result (hit, miss).
+	APICacheRequests = Default().Counter("gz_api_cache_requests_total",
+		"Conditional provider API requests by cache result.", "provider", "result")
+
+	// APICacheHitRatio reports the share of conditional requests answered with 304.
+	APICacheHitRatio = Default().Gauge("gz_api_cache_hit_ratio",
+		"Share of conditional provider API requests served from the cache.", "provider")
+
+	// CacheRequests counts lookups of tiered caches by cache, tier and result (hit, miss, error).
+	CacheRequests = Default().Counter("gz_cache_requests_total",
+		"Cache lookups by cache, tier and result.", "cache", "tier", "result")
+
+	// CacheEvictions counts items evicted to keep cache tiers within their size limits.
+	CacheEvictions = Default().Counter("gz_cache_evictions_total",
+		"Items evicted from cache tiers to stay within their size limits.", "cache", "tier")
+
+	// WorkerPoolActive reports workers currently executing a job.
+	WorkerPoolActive = Default().Gauge("gz_workerpool_active_workers",
+		"Workers currently executing a job.", "pool")
+
+	// WorkerPoolSize reports the configured worker count.
+	WorkerPoolSize = Default().Gauge("gz_workerpool_workers",
+		"Configured number of workers.", "pool")
+
+	// WorkerPoolJobs counts completed jobs by result.
+	WorkerPoolJobs = Default().Counter("gz_workerpool_jobs_total",
+		"Jobs processed by worker pools.", "pool", "result")
+
+	// WorkerPoolQueueWait observes how long jobs waited for a worker.
+	WorkerPoolQueueWait = Default().Histogram("gz_workerpool_queue_wait_seconds",
+		"Time jobs waited in the worker pool queue.", nil, "pool", "class")
+
+	// WorkerPoolPreemptions counts background jobs preempted for interactive work.
+	WorkerPoolPreemptions = Default().Counter("gz_workerpool_preemptions_total",
+		"Background jobs preempted by interactive jobs.", "pool")
+
+	// RecoverySteps counts recovery steps by component and result.
+	RecoverySteps = Default().Counter("gz_recovery_steps_total",
+		"Component recovery steps by result.", "component", "result")
+
+	// RecoveryStepDuration observes how long recovery and verification of a component take.
+	RecoveryStepDuration = Default().Histogram("gz_recovery_step_duration_seconds",
+		"Duration of component recovery steps, including verification.", nil, "component")
+
+	// RecoveryProgress reports the share of steps finished in the current recovery run.
+	RecoveryProgress = Default().Gauge("gz_recovery_progress_ratio",
+		"Share of steps finished in the current or last recovery run.")
+
+	// Errors counts errors by component.
+	Errors = Default().Counter("gz_errors_total",
+		"Errors encountered by gz components.", "component")
+)
+
+// ResultLabel converts an error into a "success"/"error" label value.
+func ResultLabel(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// RecordRateLimit records provider rate limit headroom.
+func RecordRateLimit(provider string, remaining, limit int) {
+	RateLimitRemaining.With(provider).Set(float64(remaining))
+	if limit > 0 {
+		RateLimitLimit.With(provider).Set(float64(limit))
+	}
+}
+
+// RateLimitHeadroom returns the fraction of the provider's rate limit that is
+// still available, as last recorded. ok is false before any limit was seen.
+func RateLimitHeadroom(provider string) (headroom float64, ok bool) {
+	limit := RateLimitLimit.With(provider).Value()
+	if limit <= 0 {
+		return 0, false
+	}
+	return RateLimitRemaining.With(provider).Value() / limit, true
+}
+
+// ObserveClone records a finished clone/update operation.
+func ObserveClone(provider, operation string, started time.Time, err error) {
+	CloneOperations.With(provider, operation, ResultLabel(err)).Inc()
+	CloneDuration.With(provider, operation).Observe(time.Since(started).Seconds())
+}
+
+// InstrumentTransport wraps rt so every request records API latency and status
+// metrics for the given provider, is recorded for `gz debug api-usage` and
+// carries the run's trace ID.
+// A nil rt uses http.DefaultTransport.
+func InstrumentTransport(provider string, rt http.RoundTripper) http.RoundTripper {
+	return &instrumentedTransport{provider: provider, next: trace.Transport(api.Transport(provider, rt))}
+}
+
+type instrumentedTransport struct {
+	provider string
+	next     http.RoundTripper
+}
+
+// CloseIdleConnections forwards to the wrapped transport.
+func (t *instrumentedTransport) CloseIdleConnections() {
+	if c, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	APIRequestDuration.With(t.provider, req.Method).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		APIRequests.With(t.provider, req.Method, "error").Inc()
+		Errors.With("api:" + t.provider).Inc()
+		return resp, err
+	}
+
+	APIRequests.With(t.provider, req.Method, strconv.Itoa(resp.StatusCode)).Inc()
+
+	// GitHub and Gitea send X-RateLimit-*, GitLab RateLimit-*.
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		remaining, rerr := strconv.Atoi(resp.Header.Get(prefix + "Remaining"))
+		limit, lerr := strconv.Atoi(resp.Header.Get(prefix + "Limit"))
+		if rerr == nil && lerr == nil {
+			RecordRateLimit(t.provider, remaining, limit)
+			break
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_WritePrometheus(t *testing.T) {
+	reg := NewRegistry()
+	reg.Counter("test_requests_total", "Requests.", "code").With("200").Add(3)
+	reg.Gauge("test_in_flight", "In flight.").With().Set(2)
+
+	h := reg.Histogram("test_latency_seconds", "Latency.", []float64{0.1, 1}, "op")
+	h.With("clone").Observe(0.05)
+	h.With("clone").Observe(0.5)
+	h.With("clone").Observe(5)
+
+	var buf bytes.Buffer
+	require.NoError(t, reg.WritePrometheus(&buf))
+	out := buf.String()
+
+	assert.Contains(t, out, "# TYPE test_requests_total counter\n")
+	assert.Contains(t, out, `test_requests_total{code="200"} 3`)
+	assert.Contains(t, out, "test_in_flight 2\n")
+	assert.Contains(t, out, `test_latency_seconds_bucket{op="clone",le="0.1"} 1`)
+	assert.Contains(t, out, `test_latency_seconds_bucket{op="clone",le="1"} 2`)
+	assert.Contains(t, out, `test_latency_seconds_bucket{op="clone",le="+Inf"} 3`)
+	assert.Contains(t, out, `test_latency_seconds_count{op="clone"} 3`)
+}
+
+func TestRegistry_LabelEscaping(t *testing.T) {
+	reg := NewRegistry()
+	reg.Counter("test_total", "Test.", "name").With(`a"b\c`).Inc()
+
+	var buf bytes.Buffer
+	require.NoError(t, reg.WritePrometheus(&buf))
+	assert.Contains(t, buf.String(), `test_total{name="a\"b\\c"} 1`)
+}
+
+func TestRegistry_ReRegisterReturnsSameFamily(t *testing.T) {
+	reg := NewRegistry()
+	reg.Counter("dup_total", "Dup.", "x").With("a").Inc()
+	reg.Counter("dup_total", "Dup.", "x").With("a").Inc()
+
+	var buf bytes.Buffer
+	require.NoError(t, reg.WritePrometheus(&buf))
+	assert.Contains(t, buf.String(), `dup_total{x="a"} 2`)
+
+	assert.Panics(t, func() { reg.Gauge("dup_total", "Dup.", "x") })
+}
+
+func TestInstrumentTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: InstrumentTransport("test-provider", nil)}
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	var buf bytes.Buffer
+	require.NoError(t, Default().WritePrometheus(&buf))
+	assert.Contains(t, buf.String(), `gz_api_requests_total{provider="test-provider",method="GET",code="418"} 1`)
+}
+
+func TestAddFlag_ServesMetricsDuringRun(t *testing.T) {
+	var body string
+
+	root := &cobra.Command{Use: "root"}
+	sub := &cobra.Command{
+		Use: "sub",
+		RunE: func(c *cobra.Command, _ []string) error {
+			out := c.ErrOrStderr().(*bytes.Buffer).String()
+			url := strings.TrimSpace(strings.TrimPrefix(out, "📈 Serving metrics on "))
+
+			resp, err := http.Get(url)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+
+			data, err := io.ReadAll(resp.Body)
+			body = string(data)
+			return err
+		},
+	}
+	root.AddCommand(sub)
+	AddFlag(root)
+
+	var stderr bytes.Buffer
+	root.SetErr(&stderr)
+	root.SetArgs([]string{"sub", "--metrics-addr", "127.0.0.1:0"})
+
+	require.NoError(t, root.Execute())
+	assert.Contains(t, body, "# TYPE gz_clone_operations_total counter")
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Server serves a registry on /metrics.
+type Server struct {
+	listener net.Listener
+	server   *http.Server
+}
+
+// Start listens on addr and serves the registry on /metrics in the background.
+func Start(addr string, reg *Registry) (*Server, error) {
+	if reg == nil {
+		reg = Default()
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", reg.Handler())
+
+	s := &Server{
+		listener: listener,
+		server: &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			Errors.With("metrics").Inc()
+		}
+	}()
+
+	return s, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Shutdown stops the server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// AddFlag adds a --metrics-addr flag to cmd and wraps the RunE of cmd and all
+// of its subcommands so the /metrics endpoint runs for the command's lifetime.
+// Call it after all subcommands have been added.
+func AddFlag(cmd *cobra.Command) {
+	var addr string
+	cmd.PersistentFlags().StringVar(&addr, "metrics-addr", "", "Serve Prometheus metrics on this address (e.g. :9090)")
+
+	wrapRunE(cmd, &addr)
+}
+
+func wrapRunE(cmd *cobra.Command, addr *string) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(c *cobra.Command, args []string) error {
+			if *addr == "" {
+				return run(c, args)
+			}
+
+			server, err := Start(*addr, Default())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(c.ErrOrStderr(), "📈 Serving metrics on http://%s/metrics\n", server.Addr())
+
+			defer func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = server.Shutdown(ctx)
+			}()
+
+			return run(c, args)
+		}
+	}
+
+	for _, sub := range cmd.Commands() {
+		wrapRunE(sub, addr)
+	}
+}
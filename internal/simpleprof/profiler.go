@@ -35,6 +35,7 @@ const (
 type SimpleProfiler struct {
 	outputDir string
 	server    *http.Server
+	handlers  map[string]http.Handler
 }
 
 // NewSimpleProfiler creates a new simple profiler.
@@ -54,12 +55,29 @@ func NewSimpleProfiler(outputDir string) *SimpleProfiler {
 	}
 }
 
+// Handle registers an additional debug handler served next to the pprof
+// endpoints. It must be called before StartHTTPServer.
+func (p *SimpleProfiler) Handle(pattern string, handler http.Handler) {
+	if p.handlers == nil {
+		p.handlers = make(map[string]http.Handler)
+	}
+	p.handlers[pattern] = handler
+}
+
 // StartHTTPServer starts the pprof HTTP server on the specified port.
 func (p *SimpleProfiler) StartHTTPServer(port int) error {
 	addr := fmt.Sprintf("localhost:%d", port)
 
+	// pprof registers itself on the default mux
+	mux := http.NewServeMux()
+	mux.Handle("/", http.DefaultServeMux)
+	for pattern, handler := range p.handlers {
+		mux.Handle(pattern, handler)
+	}
+
 	p.server = &http.Server{
 		Addr:              addr,
+		Handler:           mux,
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
@@ -70,6 +88,9 @@ func (p *SimpleProfiler) StartHTTPServer(port int) error {
 	log.Printf("  - Goroutines: http://%s/debug/pprof/goroutine", addr)
 	log.Printf("  - Block: http://%s/debug/pprof/block", addr)
 	log.Printf("  - Mutex: http://%s/debug/pprof/mutex", addr)
+	for pattern := range p.handlers {
+		log.Printf("  - Extra: http://%s%s", addr, pattern)
+	}
 
 	go func() {
 		if err := p.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
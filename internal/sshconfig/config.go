@@ -0,0 +1,251 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package sshconfig parses OpenSSH client configuration files, diagnoses
+// common drift and rewrites them without disturbing unrelated formatting.
+package sshconfig
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Option is a single keyword/argument line.
+type Option struct {
+	Key   string // lower-cased keyword
+	Value string
+	Line  int // zero-based line index in the file
+}
+
+// Block is a Host or Match section. Options before the first section belong
+// to a Block with an empty Keyword.
+type Block struct {
+	Keyword  string // "host", "match" or "" for global options
+	Patterns []string
+	Options  []Option
+	// Header is the line index of the Host or Match keyword.
+	Header int
+	// Start and End delimit the block's lines, End exclusive. Comments
+	// directly above the header belong to the block; blank lines belong to
+	// the block they follow.
+	Start, End int
+}
+
+// Get returns the first value of key. OpenSSH uses the first value it
+// obtains for most options, so later duplicates are ignored.
+func (b *Block) Get(key string) (string, bool) {
+	key = strings.ToLower(key)
+	for _, o := range b.Options {
+		if o.Key == key {
+			return o.Value, true
+		}
+	}
+	return "", false
+}
+
+// All returns every value of key, in order.
+func (b *Block) All(key string) []string {
+	key = strings.ToLower(key)
+	var values []string
+	for _, o := range b.Options {
+		if o.Key == key {
+			values = append(values, o.Value)
+		}
+	}
+	return values
+}
+
+// Name returns the block's patterns as written in the config.
+func (b *Block) Name() string {
+	return strings.Join(b.Patterns, " ")
+}
+
+// IsWildcard reports whether pattern matches more than one literal host.
+func IsWildcard(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?!")
+}
+
+// File is one parsed config file. Lines holds the original text so that
+// unchanged parts are written back byte for byte.
+type File struct {
+	Path   string
+	Lines  []string
+	Blocks []*Block
+}
+
+// ParseFile reads and parses the config file at path.
+func ParseFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH config %s: %w", path, err)
+	}
+	return Parse(path, data), nil
+}
+
+// Parse parses config data. It never fails: lines it does not understand are
+// kept verbatim.
+func Parse(path string, data []byte) *File {
+	f := &File{Path: path}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		f.Lines = append(f.Lines, scanner.Text())
+	}
+
+	f.reindex()
+	return f
+}
+
+// reindex rebuilds Blocks from Lines.
+func (f *File) reindex() {
+	current := &Block{}
+	f.Blocks = []*Block{current}
+
+	for i, line := range f.Lines {
+		key, value, ok := splitOption(line)
+		if !ok {
+			continue
+		}
+		if key == "host" || key == "match" {
+			start := i
+			for start > current.Header && start > 0 && isComment(f.Lines[start-1]) {
+				start--
+			}
+			current.End = start
+			current = &Block{Keyword: key, Patterns: strings.Fields(value), Header: i, Start: start}
+			f.Blocks = append(f.Blocks, current)
+			continue
+		}
+		current.Options = append(current.Options, Option{Key: key, Value: value, Line: i})
+	}
+	current.End = len(f.Lines)
+
+	// Drop an empty global block so callers only see real content.
+	if g := f.Blocks[0]; len(g.Options) == 0 && g.End == 0 {
+		f.Blocks = f.Blocks[1:]
+	}
+}
+
+func isComment(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "#")
+}
+
+// splitOption splits a config line into a lower-cased keyword and its value.
+// Both "Key value" and "Key=value" forms are accepted.
+func splitOption(line string) (key, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+
+	idx := strings.IndexAny(line, " \t=")
+	if idx < 0 {
+		return strings.ToLower(line), "", true
+	}
+	key = strings.ToLower(line[:idx])
+	value = strings.TrimSpace(line[idx:])
+	value = strings.TrimSpace(strings.TrimPrefix(value, "="))
+	return key, unquote(value), true
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// Hosts returns the Host blocks.
+func (f *File) Hosts() []*Block {
+	var hosts []*Block
+	for _, b := range f.Blocks {
+		if b.Keyword == "host" {
+			hosts = append(hosts, b)
+		}
+	}
+	return hosts
+}
+
+// Bytes renders the file.
+func (f *File) Bytes() []byte {
+	if len(f.Lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(f.Lines, "\n") + "\n")
+}
+
+// Includes returns the files referenced by Include directives, resolved
+// relative to sshDir and glob-expanded.
+func (f *File) Includes(sshDir string) []string {
+	var paths []string
+	for _, b := range f.Blocks {
+		for _, value := range b.All("include") {
+			for _, pattern := range strings.Fields(value) {
+				pattern = ExpandHome(pattern)
+				if !filepath.IsAbs(pattern) {
+					pattern = filepath.Join(sshDir, pattern)
+				}
+				matches, _ := filepath.Glob(pattern)
+				for _, m := range matches {
+					if info, err := os.Stat(m); err == nil && info.Mode().IsRegular() {
+						paths = append(paths, m)
+					}
+				}
+			}
+		}
+	}
+	return paths
+}
+
+// ExpandHome expands a leading ~ or %d to the user's home directory.
+func ExpandHome(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	switch {
+	case path == "~":
+		return home
+	case strings.HasPrefix(path, "~/"):
+		return filepath.Join(home, path[2:])
+	case strings.HasPrefix(path, "%d/"):
+		return filepath.Join(home, path[3:])
+	}
+	return path
+}
+
+// Config is a main config file together with everything it includes.
+type Config struct {
+	Files []*File
+}
+
+// Load parses the config at path and, recursively, its Include files.
+func Load(path string) (*Config, error) {
+	main, err := ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{Files: []*File{main}}
+	seen := map[string]bool{path: true}
+	sshDir := filepath.Dir(path)
+	for i := 0; i < len(cfg.Files); i++ {
+		for _, inc := range cfg.Files[i].Includes(sshDir) {
+			if seen[inc] {
+				continue
+			}
+			seen[inc] = true
+			f, err := ParseFile(inc)
+			if err != nil {
+				continue
+			}
+			cfg.Files = append(cfg.Files, f)
+		}
+	}
+	return cfg, nil
+}
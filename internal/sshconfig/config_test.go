@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sshconfig
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // known_hosts hashing is defined as HMAC-SHA1
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleConfig = `# global
+ServerAliveInterval 60
+
+Host github.com
+    User git
+    IdentityFile ~/.ssh/id_github
+
+Host work
+  HostName=gitlab.com
+  Port 2222
+
+# second github block
+Host github.com
+    User other
+    IdentityFile ~/.ssh/id_backup
+    IdentitiesOnly yes
+`
+
+func TestParse(t *testing.T) {
+	f := Parse("config", []byte(sampleConfig))
+
+	require.Len(t, f.Blocks, 4)
+	assert.Empty(t, f.Blocks[0].Keyword)
+	v, ok := f.Blocks[0].Get("ServerAliveInterval")
+	assert.True(t, ok)
+	assert.Equal(t, "60", v)
+
+	hosts := f.Hosts()
+	require.Len(t, hosts, 3)
+	hostname, _ := hosts[1].Get("hostname")
+	assert.Equal(t, "gitlab.com", hostname)
+	assert.Equal(t, []string{"~/.ssh/id_backup"}, hosts[2].All("identityfile"))
+	assert.Equal(t, sampleConfig, string(f.Bytes()))
+}
+
+func TestMergeDuplicateHosts(t *testing.T) {
+	f := Parse("config", []byte(sampleConfig))
+	require.Len(t, f.DuplicateHosts(), 1)
+
+	assert.Equal(t, 1, f.MergeDuplicateHosts())
+	assert.Empty(t, f.DuplicateHosts())
+
+	want := `# global
+ServerAliveInterval 60
+
+Host github.com
+    User git
+    IdentityFile ~/.ssh/id_github
+    IdentityFile ~/.ssh/id_backup
+    IdentitiesOnly yes
+
+Host work
+  HostName=gitlab.com
+  Port 2222
+`
+	assert.Equal(t, want, string(f.Bytes()))
+	assert.Zero(t, f.MergeDuplicateHosts())
+}
+
+func TestWriteAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte("old\n"), 0o600))
+
+	backup, err := WriteAtomic(path, []byte("new\n"))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new\n", string(data))
+
+	old, err := os.ReadFile(backup)
+	require.NoError(t, err)
+	assert.Equal(t, "old\n", string(old))
+	assert.True(t, strings.HasPrefix(filepath.Base(backup), "config.bak-"))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestLoad_FollowsIncludes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "config.d"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.d", "work"), []byte("Host work\n  User me\n"), 0o600))
+	path := filepath.Join(dir, "config")
+	require.NoError(t, os.WriteFile(path, []byte("Include config.d/*\nHost a\n"), 0o600))
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.Files, 2)
+	assert.Equal(t, "work", cfg.Files[1].Hosts()[0].Name())
+}
+
+func TestKnownHosts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	content := `github.com,140.82.112.3 ssh-ed25519 AAAA
+[gitlab.com]:2222 ssh-ed25519 AAAA
+@revoked bitbucket.org ssh-rsa AAAA
+` + hashHost("salt", "git.example.com") + ` ssh-rsa AAAA
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	known := LoadKnownHosts(path, filepath.Join(t.TempDir(), "missing"))
+	assert.True(t, known.Contains("GitHub.com"))
+	assert.True(t, known.Contains(knownHostsName("gitlab.com", "2222")))
+	assert.False(t, known.Contains("gitlab.com"))
+	assert.False(t, known.Contains("bitbucket.org"))
+	assert.True(t, known.Contains("git.example.com"))
+	assert.False(t, known.Contains("git.example.org"))
+
+	keys := known.Keys("git.example.com")
+	require.Len(t, keys, 1)
+	assert.Equal(t, HostKey{Host: "git.example.com", Type: "ssh-rsa", Key: "AAAA"}, keys[0])
+	assert.Len(t, known.Keys("github.com"), 1)
+	assert.Empty(t, known.Keys("bitbucket.org"), "revoked keys are not host keys")
+}
+
+// hashHost returns a HashKnownHosts entry for host.
+func hashHost(salt, host string) string {
+	mac := hmac.New(sha1.New, []byte(salt))
+	mac.Write([]byte(host))
+	return "|1|" + base64.StdEncoding.EncodeToString([]byte(salt)) + "|" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,566 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sshconfig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Check names reported in Issue.Check.
+const (
+	CheckDuplicateHost       = "duplicate-host"
+	CheckConflict            = "conflict"
+	CheckIdentityPermissions = "identity-permissions"
+	CheckIdentityMissing     = "identity-missing"
+	CheckKnownHosts          = "known-hosts"
+	CheckHostKey             = "host-key"
+	CheckConnectivity        = "connectivity"
+)
+
+// Severity ranks an issue.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// DefaultProviders are the Git hosting SSH endpoints the doctor verifies
+// known_hosts entries and connectivity for.
+var DefaultProviders = []string{
+	"github.com",
+	"gitlab.com",
+	"bitbucket.org",
+	"ssh.dev.azure.com",
+	"codeberg.org",
+	"gitea.com",
+}
+
+// Issue is one finding.
+type Issue struct {
+	Check    string   `json:"check"`
+	Severity Severity `json:"severity"`
+	Host     string   `json:"host,omitempty"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"` // one-based
+	Message  string   `json:"message"`
+	Fixable  bool     `json:"fixable"`
+
+	// target is the file or host the fix acts on.
+	target string
+	port   string
+}
+
+// Report is the result of a doctor run.
+type Report struct {
+	ConfigPath string   `json:"configPath"`
+	Files      []string `json:"files"`
+	Providers  []string `json:"providers"`
+	Issues     []Issue  `json:"issues"`
+}
+
+// HasErrors reports whether any issue has error severity.
+func (r *Report) HasErrors() bool {
+	for _, i := range r.Issues {
+		if i.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Fixable returns the issues Fix can repair.
+func (r *Report) Fixable() []Issue {
+	var out []Issue
+	for _, i := range r.Issues {
+		if i.Fixable {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// Doctor diagnoses an SSH client configuration.
+type Doctor struct {
+	ConfigPath string
+	// KnownHosts are the files searched for host keys. The first one
+	// receives keys added by Fix. Defaults to ~/.ssh/known_hosts.
+	KnownHosts []string
+	// Pins override the published fingerprints that known_hosts entries
+	// and fetched keys are verified against. May be nil.
+	Pins *Pins
+	// Providers are the hostnames whose known_hosts entries and
+	// connectivity are verified. Defaults to DefaultProviders.
+	Providers []string
+	// Hosts limits the provider checks to these Host aliases.
+	Hosts            []string
+	SkipConnectivity bool
+	Timeout          time.Duration
+
+	// run executes external commands; swapped out in tests.
+	run func(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// NewDoctor creates a doctor for the config at path with default settings.
+func NewDoctor(path string) *Doctor {
+	return &Doctor{
+		ConfigPath: path,
+		KnownHosts: []string{filepath.Join(filepath.Dir(path), "known_hosts")},
+		Providers:  DefaultProviders,
+		Timeout:    10 * time.Second,
+		run:        runCommand,
+	}
+}
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...) // #nosec G204 -- fixed binaries with config-derived hosts
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	return out.Bytes(), err
+}
+
+// providerHost is a Host alias that resolves to a Git provider.
+type providerHost struct {
+	alias    string
+	hostname string
+	port     string
+	file     string
+	line     int
+}
+
+// Run loads the configuration and runs every check.
+func (d *Doctor) Run(ctx context.Context) (*Report, error) {
+	cfg, err := Load(d.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{ConfigPath: d.ConfigPath, Providers: d.Providers}
+	for _, f := range cfg.Files {
+		report.Files = append(report.Files, f.Path)
+	}
+
+	d.checkDuplicates(cfg, report)
+	d.checkConflicts(cfg, report)
+	d.checkIdentityFiles(cfg, report)
+
+	hosts := d.providerHosts(cfg)
+	d.checkKnownHosts(cfg, hosts, report)
+	if !d.SkipConnectivity {
+		d.checkConnectivity(ctx, hosts, report)
+	}
+
+	return report, nil
+}
+
+func (d *Doctor) checkDuplicates(cfg *Config, report *Report) {
+	for _, f := range cfg.Files {
+		for _, group := range f.DuplicateHosts() {
+			for _, dup := range group[1:] {
+				report.Issues = append(report.Issues, Issue{
+					Check:    CheckDuplicateHost,
+					Severity: SeverityWarning,
+					Host:     dup.Name(),
+					File:     f.Path,
+					Line:     dup.Header + 1,
+					Message:  fmt.Sprintf("duplicate Host block (first defined at line %d); it can be merged into the first block", group[0].Header+1),
+					Fixable:  true,
+					target:   f.Path,
+				})
+			}
+		}
+	}
+}
+
+// checkConflicts reports single-valued options that two Host blocks matching
+// the same literal host set to different values. OpenSSH silently uses the
+// first one.
+func (d *Doctor) checkConflicts(cfg *Config, report *Report) {
+	type setting struct {
+		value string
+		file  string
+		line  int
+	}
+	first := make(map[string]map[string]setting) // host -> key -> first setting
+	reported := make(map[string]bool)
+
+	for _, f := range cfg.Files {
+		for _, b := range f.Hosts() {
+			for _, pattern := range b.Patterns {
+				if IsWildcard(pattern) {
+					continue
+				}
+				host := strings.ToLower(pattern)
+				if first[host] == nil {
+					first[host] = make(map[string]setting)
+				}
+				for _, o := range b.Options {
+					if multiValued[o.Key] || o.Key == "include" {
+						continue
+					}
+					prev, ok := first[host][o.Key]
+					if !ok {
+						first[host][o.Key] = setting{value: o.Value, file: f.Path, line: o.Line + 1}
+						continue
+					}
+					id := fmt.Sprintf("%s\x00%s\x00%d", f.Path, host, o.Line)
+					if prev.value == o.Value || reported[id] {
+						continue
+					}
+					reported[id] = true
+					report.Issues = append(report.Issues, Issue{
+						Check:    CheckConflict,
+						Severity: SeverityWarning,
+						Host:     pattern,
+						File:     f.Path,
+						Line:     o.Line + 1,
+						Message: fmt.Sprintf("%s %q is ignored; %s:%d already sets it to %q",
+							o.Key, o.Value, filepath.Base(prev.file), prev.line, prev.value),
+					})
+				}
+			}
+		}
+	}
+}
+
+func (d *Doctor) checkIdentityFiles(cfg *Config, report *Report) {
+	checked := make(map[string]bool)
+	for _, f := range cfg.Files {
+		for _, b := range f.Blocks {
+			for _, o := range b.Options {
+				if o.Key != "identityfile" || strings.EqualFold(o.Value, "none") {
+					continue
+				}
+				path := ExpandHome(o.Value)
+				// Other percent tokens depend on the connection; skip them.
+				if strings.Contains(path, "%") || !filepath.IsAbs(path) || checked[path] {
+					continue
+				}
+				checked[path] = true
+
+				info, err := os.Stat(path)
+				if err != nil {
+					report.Issues = append(report.Issues, Issue{
+						Check:    CheckIdentityMissing,
+						Severity: SeverityWarning,
+						Host:     b.Name(),
+						File:     f.Path,
+						Line:     o.Line + 1,
+						Message:  fmt.Sprintf("IdentityFile %s does not exist", o.Value),
+					})
+					continue
+				}
+
+				// Windows has no POSIX permission bits to check.
+				if runtime.GOOS == "windows" {
+					continue
+				}
+				if perm := info.Mode().Perm(); perm&0o077 != 0 {
+					report.Issues = append(report.Issues, Issue{
+						Check:    CheckIdentityPermissions,
+						Severity: SeverityError,
+						Host:     b.Name(),
+						File:     f.Path,
+						Line:     o.Line + 1,
+						Message:  fmt.Sprintf("IdentityFile %s has permissions %04o; ssh refuses keys readable by others (want 0600)", o.Value, perm),
+						Fixable:  true,
+						target:   path,
+					})
+				}
+			}
+		}
+	}
+}
+
+// providerHosts returns the Host aliases that resolve to a provider.
+func (d *Doctor) providerHosts(cfg *Config) []providerHost {
+	providers := make(map[string]bool, len(d.Providers))
+	for _, p := range d.Providers {
+		providers[strings.ToLower(p)] = true
+	}
+	only := make(map[string]bool, len(d.Hosts))
+	for _, h := range d.Hosts {
+		only[strings.ToLower(h)] = true
+	}
+
+	var hosts []providerHost
+	seen := make(map[string]bool)
+	for _, f := range cfg.Files {
+		for _, b := range f.Hosts() {
+			for _, pattern := range b.Patterns {
+				alias := strings.ToLower(pattern)
+				if IsWildcard(pattern) || seen[alias] || (len(only) > 0 && !only[alias]) {
+					continue
+				}
+				hostname := alias
+				if v, ok := b.Get("hostname"); ok {
+					hostname = strings.ToLower(v)
+				}
+				if !providers[hostname] {
+					continue
+				}
+				seen[alias] = true
+				port, _ := b.Get("port")
+				hosts = append(hosts, providerHost{alias: pattern, hostname: hostname, port: port, file: f.Path, line: b.Header + 1})
+			}
+		}
+	}
+	return hosts
+}
+
+func (d *Doctor) checkKnownHosts(cfg *Config, hosts []providerHost, report *Report) {
+	files := append([]string(nil), d.KnownHosts...)
+	for _, f := range cfg.Files {
+		for _, b := range f.Blocks {
+			for _, v := range b.All("userknownhostsfile") {
+				for _, path := range strings.Fields(v) {
+					files = append(files, ExpandHome(path))
+				}
+			}
+		}
+	}
+	known := LoadKnownHosts(files...)
+
+	reported := make(map[string]bool)
+	for _, h := range hosts {
+		name := knownHostsName(h.hostname, h.port)
+		if reported[name] {
+			continue
+		}
+		if known.Contains(name) {
+			reported[name] = true
+			d.checkHostKey(known, h, name, report)
+			continue
+		}
+		reported[name] = true
+		report.Issues = append(report.Issues, Issue{
+			Check:    CheckKnownHosts,
+			Severity: SeverityError,
+			Host:     h.alias,
+			File:     h.file,
+			Line:     h.line,
+			Message:  fmt.Sprintf("no known_hosts entry for %s; the first connection will prompt or fail in batch mode", name),
+			Fixable:  len(d.KnownHosts) > 0,
+			target:   h.hostname,
+			port:     h.port,
+		})
+	}
+}
+
+// checkHostKey reports known_hosts keys of a provider host that do not match
+// its pinned or published fingerprints.
+func (d *Doctor) checkHostKey(known *KnownHosts, h providerHost, name string, report *Report) {
+	expected, source := d.Pins.Expected(name)
+	if len(expected) == 0 {
+		return
+	}
+	bad := unexpected(known.Keys(name), expected)
+	if len(bad) == 0 {
+		return
+	}
+	report.Issues = append(report.Issues, Issue{
+		Check:    CheckHostKey,
+		Severity: SeverityError,
+		Host:     h.alias,
+		File:     h.file,
+		Line:     h.line,
+		Message: fmt.Sprintf("known_hosts records %s for %s, which does not match the %s fingerprints; the host key changed unexpectedly or the entry is stale",
+			strings.Join(fingerprints(bad), ", "), name, source),
+	})
+}
+
+// authenticated matches the banners providers print on a successful
+// `ssh -T`, which most of them pair with a non-zero exit status.
+var authenticated = regexp.MustCompile(`(?i)successfully authenticated|welcome to gitlab|authenticated via|shell access is not supported|logged in as`)
+
+func (d *Doctor) checkConnectivity(ctx context.Context, hosts []providerHost, report *Report) {
+	for _, h := range hosts {
+		tctx, cancel := context.WithTimeout(ctx, d.Timeout+5*time.Second)
+		out, err := d.run(tctx, "ssh", "-T",
+			"-F", d.ConfigPath,
+			"-o", "BatchMode=yes",
+			"-o", fmt.Sprintf("ConnectTimeout=%d", int(d.Timeout.Seconds())),
+			h.alias)
+		cancel()
+
+		if err == nil || authenticated.Match(out) {
+			continue
+		}
+		report.Issues = append(report.Issues, Issue{
+			Check:    CheckConnectivity,
+			Severity: SeverityError,
+			Host:     h.alias,
+			File:     h.file,
+			Line:     h.line,
+			Message:  fmt.Sprintf("ssh -T %s failed: %s", h.alias, lastLine(out, err)),
+		})
+	}
+}
+
+func lastLine(out []byte, err error) string {
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return last
+	}
+	return err.Error()
+}
+
+// FixResult describes one applied repair.
+type FixResult struct {
+	Check   string `json:"check"`
+	Target  string `json:"target"`
+	Message string `json:"message"`
+	Backup  string `json:"backup,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Fix repairs the fixable issues in report. Config and known_hosts files are
+// rewritten atomically after being backed up. It keeps going after a failed
+// repair and reports every outcome.
+func (d *Doctor) Fix(ctx context.Context, report *Report) []FixResult {
+	var results []FixResult
+
+	// Merge duplicate blocks once per file.
+	mergeFiles := make(map[string]bool)
+	var scans []Issue
+	for _, issue := range report.Fixable() {
+		switch issue.Check {
+		case CheckDuplicateHost:
+			mergeFiles[issue.target] = true
+		case CheckIdentityPermissions:
+			result := FixResult{Check: issue.Check, Target: issue.target, Message: "set permissions to 0600"}
+			if err := os.Chmod(issue.target, 0o600); err != nil {
+				result.Error = err.Error()
+			}
+			results = append(results, result)
+		case CheckKnownHosts:
+			scans = append(scans, issue)
+		}
+	}
+
+	paths := make([]string, 0, len(mergeFiles))
+	for path := range mergeFiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		results = append(results, d.mergeFile(path))
+	}
+
+	if len(scans) > 0 {
+		results = append(results, d.addKnownHosts(ctx, scans)...)
+	}
+	return results
+}
+
+func (d *Doctor) mergeFile(path string) FixResult {
+	result := FixResult{Check: CheckDuplicateHost, Target: path}
+	f, err := ParseFile(path)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	removed := f.MergeDuplicateHosts()
+	result.Message = fmt.Sprintf("merged %d duplicate Host block(s)", removed)
+	if removed == 0 {
+		return result
+	}
+	backup, err := WriteAtomic(path, f.Bytes())
+	result.Backup = backup
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// addKnownHosts fetches host keys with ssh-keyscan and appends them to the
+// first KnownHosts file. Keys of hosts with pinned or published fingerprints
+// are only added when they match.
+func (d *Doctor) addKnownHosts(ctx context.Context, issues []Issue) []FixResult {
+	path := d.KnownHosts[0]
+	var (
+		results []FixResult
+		added   []byte
+	)
+	for _, issue := range issues {
+		name := knownHostsName(issue.target, issue.port)
+		result := FixResult{Check: CheckKnownHosts, Target: name}
+
+		args := []string{"-T", fmt.Sprintf("%d", int(d.Timeout.Seconds())), "-t", "ed25519,ecdsa,rsa"}
+		if issue.port != "" && issue.port != "22" {
+			args = append(args, "-p", issue.port)
+		}
+		args = append(args, issue.target)
+
+		tctx, cancel := context.WithTimeout(ctx, d.Timeout+5*time.Second)
+		out, err := d.run(tctx, "ssh-keyscan", args...)
+		cancel()
+
+		keys := scannedKeys(out)
+		expected, source := d.Pins.Expected(name)
+		switch {
+		case len(keys) == 0 && err != nil:
+			result.Error = fmt.Sprintf("ssh-keyscan failed: %s", lastLine(out, err))
+		case len(keys) == 0:
+			result.Error = "ssh-keyscan returned no host keys"
+		case len(expected) > 0 && len(unexpected(parseHostKeys(keys), expected)) > 0:
+			result.Error = fmt.Sprintf("fetched host keys do not match the %s fingerprints; not adding them", source)
+		default:
+			added = append(added, keys...)
+			result.Message = fmt.Sprintf("added host keys to %s", path)
+		}
+		results = append(results, result)
+	}
+	if len(added) == 0 {
+		return results
+	}
+
+	backup, err := appendKnownHosts(path, added)
+	if err != nil {
+		return markFailed(results, err)
+	}
+	for i := range results {
+		if results[i].Error == "" {
+			results[i].Backup = backup
+		}
+	}
+	return results
+}
+
+func markFailed(results []FixResult, err error) []FixResult {
+	for i := range results {
+		if results[i].Error == "" {
+			results[i].Error = err.Error()
+			results[i].Message = ""
+		}
+	}
+	return results
+}
+
+// scannedKeys returns the host key lines of ssh-keyscan output, skipping
+// its "# host:22 SSH-2.0-..." comments.
+func scannedKeys(out []byte) []byte {
+	var keys []byte
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || len(strings.Fields(line)) < 3 {
+			continue
+		}
+		keys = append(keys, line...)
+		keys = append(keys, '\n')
+	}
+	return keys
+}
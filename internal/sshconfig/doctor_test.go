@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sshconfig
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRunner struct {
+	calls  [][]string
+	output map[string]string // host -> ssh -T output
+}
+
+func (f *fakeRunner) run(_ context.Context, name string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, append([]string{name}, args...))
+	host := args[len(args)-1]
+	if name == "ssh-keyscan" {
+		// ssh-keyscan names non-default ports the way known_hosts does.
+		entry := host
+		for i, a := range args {
+			if a == "-p" {
+				entry = "[" + host + "]:" + args[i+1]
+			}
+		}
+		return []byte("# " + host + ":22 SSH-2.0-babeld\n" + entry + " ssh-ed25519 AAAAC3Nza\n"), nil
+	}
+	out, ok := f.output[host]
+	if !ok {
+		return []byte("git@" + host + ": Permission denied (publickey).\n"), errors.New("exit status 255")
+	}
+	return []byte(out), errors.New("exit status 1")
+}
+
+func setupSSHDir(t *testing.T, config string) (string, *Doctor, *fakeRunner) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config")
+	require.NoError(t, os.WriteFile(path, []byte(strings.ReplaceAll(config, "$DIR", dir)), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "known_hosts"), []byte("github.com ssh-ed25519 AAAA\n"), 0o644))
+
+	runner := &fakeRunner{output: map[string]string{
+		"github.com": "Hi alice! You've successfully authenticated, but GitHub does not provide shell access.",
+	}}
+	d := NewDoctor(path)
+	d.run = runner.run
+	return dir, d, runner
+}
+
+func issuesByCheck(r *Report) map[string][]Issue {
+	out := make(map[string][]Issue)
+	for _, i := range r.Issues {
+		out[i.Check] = append(out[i.Check], i)
+	}
+	return out
+}
+
+func TestDoctor_Run(t *testing.T) {
+	dir, d, runner := setupSSHDir(t, `Host github.com
+    User git
+    IdentityFile $DIR/id_github
+
+Host gitlab.com
+    User git
+    IdentityFile $DIR/id_missing
+
+Host github.com
+    User alice
+`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "id_github"), []byte("key"), 0o644))
+
+	report, err := d.Run(context.Background())
+	require.NoError(t, err)
+	byCheck := issuesByCheck(report)
+
+	require.Len(t, byCheck[CheckDuplicateHost], 1)
+	assert.Equal(t, 9, byCheck[CheckDuplicateHost][0].Line)
+	require.Len(t, byCheck[CheckConflict], 1)
+	assert.Contains(t, byCheck[CheckConflict][0].Message, `user "alice" is ignored`)
+	require.Len(t, byCheck[CheckIdentityMissing], 1)
+
+	if runtime.GOOS != "windows" {
+		require.Len(t, byCheck[CheckIdentityPermissions], 1)
+		assert.True(t, byCheck[CheckIdentityPermissions][0].Fixable)
+	}
+
+	// github.com is known and authenticates; gitlab.com is neither.
+	require.Len(t, byCheck[CheckKnownHosts], 1)
+	assert.Equal(t, "gitlab.com", byCheck[CheckKnownHosts][0].Host)
+	// The recorded github.com key is not one of the published ones.
+	require.Len(t, byCheck[CheckHostKey], 1)
+	assert.Contains(t, byCheck[CheckHostKey][0].Message, "published fingerprints")
+	require.Len(t, byCheck[CheckConnectivity], 1)
+	assert.Contains(t, byCheck[CheckConnectivity][0].Message, "Permission denied")
+	assert.True(t, report.HasErrors())
+	assert.Len(t, runner.calls, 2)
+}
+
+func TestDoctor_Fix(t *testing.T) {
+	dir, d, _ := setupSSHDir(t, `Host github.com
+    User git
+    IdentityFile $DIR/id_github
+
+Host gitlab.com
+    Port 2222
+
+Host github.com
+    IdentitiesOnly yes
+`)
+	key := filepath.Join(dir, "id_github")
+	require.NoError(t, os.WriteFile(key, []byte("key"), 0o644))
+	d.SkipConnectivity = true
+
+	report, err := d.Run(context.Background())
+	require.NoError(t, err)
+	results := d.Fix(context.Background(), report)
+	for _, r := range results {
+		assert.Empty(t, r.Error, r.Target)
+	}
+
+	// Duplicate blocks were merged and the original kept as a backup.
+	config, err := os.ReadFile(d.ConfigPath)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(config), "Host github.com"))
+	assert.Contains(t, string(config), "IdentitiesOnly yes")
+	backups, _ := filepath.Glob(d.ConfigPath + ".bak-*")
+	assert.NotEmpty(t, backups)
+
+	known := LoadKnownHosts(filepath.Join(dir, "known_hosts"))
+	assert.True(t, known.Contains("[gitlab.com]:2222"))
+	assert.True(t, known.Contains("github.com"))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(key)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	// A second run is clean.
+	report, err = d.Run(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, report.Fixable())
+}
+
+func TestDoctor_FixVerifiesPublishedFingerprints(t *testing.T) {
+	dir, d, _ := setupSSHDir(t, "Host gitlab.com\n    User git\n")
+	d.SkipConnectivity = true
+
+	report, err := d.Run(context.Background())
+	require.NoError(t, err)
+	results := d.Fix(context.Background(), report)
+	require.Len(t, results, 1)
+	assert.Contains(t, results[0].Error, "do not match the published fingerprints")
+
+	known := LoadKnownHosts(filepath.Join(dir, "known_hosts"))
+	assert.False(t, known.Contains("gitlab.com"))
+}
@@ -0,0 +1,492 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sshconfig
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PublishedFingerprints are the SHA256 host key fingerprints providers
+// publish for their SSH endpoints. Keys fetched for these hosts are only
+// trusted when they match.
+//
+// https://docs.github.com/en/authentication/keeping-your-account-and-data-secure/githubs-ssh-key-fingerprints
+// https://docs.gitlab.com/ee/user/gitlab_com/#ssh-host-keys-fingerprints
+var PublishedFingerprints = map[string][]string{
+	"github.com": {
+		"SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s", // RSA
+		"SHA256:p2QAMXNIC1TJYWeIOttrVc98/R1BUFWu3/LiyKgUfQM", // ECDSA
+		"SHA256:+DiY3wvvV6TuJJhbpZisF/zLDA0zPMSvHdkr4UvCOqU", // Ed25519
+	},
+	"gitlab.com": {
+		"SHA256:ROQFvPThGrW4RuWLoL9tq9I9zJ42fK4XywyRtbOz/EQ", // RSA
+		"SHA256:HbW3g8zUjNSksFbqTiUWPWg2Bq1x8xdGUrliXFzSnUw", // ECDSA
+		"SHA256:eUXGGm1YGsMAS7vkcx6JOJdOGHPem5gQp4taiCfCLB8", // Ed25519
+	},
+}
+
+// HostKey is a public host key as found in known_hosts or ssh-keyscan output.
+type HostKey struct {
+	Host string `json:"host"`
+	Type string `json:"type"`
+	Key  string `json:"key"` // base64 key blob
+}
+
+// Fingerprint returns the key's fingerprint in the SHA256:... form printed
+// by ssh-keygen -l, or "" if the key blob is not valid base64.
+func (k HostKey) Fingerprint() string {
+	blob, err := base64.StdEncoding.DecodeString(k.Key)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+func (k HostKey) line() string {
+	return k.Host + " " + k.Type + " " + k.Key
+}
+
+func fingerprints(keys []HostKey) []string {
+	fps := make([]string, 0, len(keys))
+	for _, k := range keys {
+		fps = append(fps, k.Fingerprint())
+	}
+	return fps
+}
+
+// HostKeyTarget is an SSH endpoint whose host keys are managed.
+type HostKeyTarget struct {
+	Host string `json:"host"`
+	Port string `json:"port,omitempty"`
+}
+
+// ParseHostKeyTarget parses host, host:port or the known_hosts form
+// [host]:port.
+func ParseHostKeyTarget(s string) HostKeyTarget {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if rest, ok := strings.CutPrefix(s, "["); ok {
+		if host, port, ok := strings.Cut(rest, "]:"); ok {
+			return HostKeyTarget{Host: host, Port: port}
+		}
+	}
+	if host, port, ok := strings.Cut(s, ":"); ok && !strings.Contains(port, ":") {
+		return HostKeyTarget{Host: host, Port: port}
+	}
+	return HostKeyTarget{Host: s}
+}
+
+// Name returns how the target is named in known_hosts and in the pins.
+func (t HostKeyTarget) Name() string {
+	return knownHostsName(t.Host, t.Port)
+}
+
+// ConfiguredTargets returns the endpoints of the Host blocks in the config at
+// path that resolve to one of providers, followed by the providers with
+// published fingerprints that the config does not mention.
+func ConfiguredTargets(path string, providers []string) ([]HostKeyTarget, error) {
+	var hosts []providerHost
+	if _, err := os.Stat(path); err == nil {
+		cfg, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		hosts = (&Doctor{Providers: providers}).providerHosts(cfg)
+	}
+
+	var targets []HostKeyTarget
+	seen := make(map[string]bool)
+	add := func(t HostKeyTarget) {
+		if !seen[t.Name()] {
+			seen[t.Name()] = true
+			targets = append(targets, t)
+		}
+	}
+	for _, h := range hosts {
+		add(HostKeyTarget{Host: h.hostname, Port: h.port})
+	}
+	for _, p := range providers {
+		if _, ok := PublishedFingerprints[strings.ToLower(p)]; ok {
+			add(HostKeyTarget{Host: strings.ToLower(p)})
+		}
+	}
+	return targets, nil
+}
+
+// Pins are host key fingerprints the user trusts for a host, overriding the
+// published ones. They are kept in ~/.config/gzh-manager/ssh-host-keys.yaml:
+//
+//	pins:
+//	  git.example.com:
+//	    - SHA256:...
+//	  "[git.example.com]:2222":
+//	    - SHA256:...
+type Pins struct {
+	Hosts map[string][]string `yaml:"pins"`
+}
+
+// DefaultPinsPath returns ~/.config/gzh-manager/ssh-host-keys.yaml.
+func DefaultPinsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "ssh-host-keys.yaml"
+	}
+	return filepath.Join(home, ".config", "gzh-manager", "ssh-host-keys.yaml")
+}
+
+// LoadPins reads the pins file at path. A missing file yields no pins.
+func LoadPins(path string) (*Pins, error) {
+	p := &Pins{Hosts: make(map[string][]string)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read host key pins %s: %w", path, err)
+	}
+
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid host key pins %s: %w", path, err)
+	}
+	if p.Hosts == nil {
+		p.Hosts = make(map[string][]string)
+	}
+	for host, fps := range p.Hosts {
+		for _, fp := range fps {
+			if !strings.HasPrefix(fp, "SHA256:") {
+				return nil, fmt.Errorf("invalid host key pins %s: %s: fingerprint %q is not in SHA256:... form", path, host, fp)
+			}
+		}
+	}
+	return p, nil
+}
+
+// Save writes the pins to path.
+func (p *Pins) Save(path string) error {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write host key pins %s: %w", path, err)
+	}
+	return nil
+}
+
+// Pin records fingerprints for name, keeping the ones already pinned.
+func (p *Pins) Pin(name string, fps ...string) {
+	name = strings.ToLower(name)
+	for _, fp := range fps {
+		if !slices.Contains(p.Hosts[name], fp) {
+			p.Hosts[name] = append(p.Hosts[name], fp)
+		}
+	}
+	sort.Strings(p.Hosts[name])
+}
+
+// Unpin removes the pins of name and reports whether there were any.
+func (p *Pins) Unpin(name string) bool {
+	name = strings.ToLower(name)
+	_, ok := p.Hosts[name]
+	delete(p.Hosts, name)
+	return ok
+}
+
+// Trusted sources reported in HostKeyResult.Source.
+const (
+	SourcePinned    = "pinned"
+	SourcePublished = "published"
+	SourceKnown     = "known_hosts"
+	SourceConfirmed = "confirmed"
+)
+
+// Expected returns the fingerprints trusted for name and where they come
+// from. Pins take precedence over published fingerprints. p may be nil.
+func (p *Pins) Expected(name string) ([]string, string) {
+	name = strings.ToLower(name)
+	if p != nil && len(p.Hosts[name]) > 0 {
+		return p.Hosts[name], SourcePinned
+	}
+	if fps := PublishedFingerprints[name]; len(fps) > 0 {
+		return fps, SourcePublished
+	}
+	return nil, ""
+}
+
+// unexpected returns the keys whose fingerprint is not in expected.
+func unexpected(keys []HostKey, expected []string) []HostKey {
+	var bad []HostKey
+	for _, k := range keys {
+		if !slices.Contains(expected, k.Fingerprint()) {
+			bad = append(bad, k)
+		}
+	}
+	return bad
+}
+
+// HostKeyStatus is the outcome of syncing one endpoint.
+type HostKeyStatus string
+
+const (
+	// HostKeyOK means known_hosts already holds every trusted key.
+	HostKeyOK HostKeyStatus = "ok"
+	// HostKeyAdded means trusted keys were added to known_hosts.
+	HostKeyAdded HostKeyStatus = "added"
+	// HostKeyMissing means trusted keys are not in known_hosts yet; only
+	// reported by a dry run.
+	HostKeyMissing HostKeyStatus = "missing"
+	// HostKeyChanged means the host presents, or known_hosts records, a key
+	// that does not match the trusted fingerprints.
+	HostKeyChanged HostKeyStatus = "changed"
+	// HostKeyRejected means the user did not trust an unverifiable key.
+	HostKeyRejected HostKeyStatus = "rejected"
+	// HostKeyFailed means the keys could not be fetched or written.
+	HostKeyFailed HostKeyStatus = "failed"
+)
+
+// HostKeyResult reports what Sync did for one endpoint.
+type HostKeyResult struct {
+	Host         string        `json:"host"`
+	Status       HostKeyStatus `json:"status"`
+	Source       string        `json:"source,omitempty"`
+	Fingerprints []string      `json:"fingerprints,omitempty"`
+	Message      string        `json:"message,omitempty"`
+	Backup       string        `json:"backup,omitempty"`
+}
+
+// HostKeyManager populates a known_hosts file with verified host keys.
+type HostKeyManager struct {
+	// KnownHosts receives new keys; it is also searched for existing ones.
+	KnownHosts string
+	Pins       *Pins
+	Timeout    time.Duration
+	// DryRun reports what would change without prompting or writing.
+	DryRun bool
+	// PinConfirmed pins the fingerprints of keys the user confirmed.
+	PinConfirmed bool
+	// Confirm asks whether to trust keys of a host without pinned or
+	// published fingerprints. A nil Confirm trusts none.
+	Confirm func(host string, keys []HostKey) bool
+
+	run func(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// NewHostKeyManager creates a manager for the known_hosts file at path.
+func NewHostKeyManager(path string, pins *Pins) *HostKeyManager {
+	return &HostKeyManager{KnownHosts: path, Pins: pins, Timeout: 10 * time.Second, run: runCommand}
+}
+
+// Sync fetches the keys of every target with ssh-keyscan, verifies them and
+// appends the trusted ones missing from known_hosts. Keys that contradict
+// pinned, published or already known keys are never written.
+func (m *HostKeyManager) Sync(ctx context.Context, targets []HostKeyTarget) []HostKeyResult {
+	known := LoadKnownHosts(m.KnownHosts)
+
+	results := make([]HostKeyResult, 0, len(targets))
+	var added []byte
+	for _, t := range targets {
+		result, keys := m.sync(ctx, t, known)
+		for _, k := range keys {
+			added = append(added, k.line()...)
+			added = append(added, '\n')
+		}
+		results = append(results, result)
+	}
+	if len(added) == 0 {
+		return results
+	}
+
+	backup, err := appendKnownHosts(m.KnownHosts, added)
+	for i := range results {
+		if results[i].Status != HostKeyAdded {
+			continue
+		}
+		if err != nil {
+			results[i].Status = HostKeyFailed
+			results[i].Message = err.Error()
+			continue
+		}
+		results[i].Backup = backup
+	}
+	return results
+}
+
+// sync verifies one target and returns the keys to add.
+func (m *HostKeyManager) sync(ctx context.Context, t HostKeyTarget, known *KnownHosts) (HostKeyResult, []HostKey) {
+	name := t.Name()
+	result := HostKeyResult{Host: name}
+
+	scanned, err := m.scan(ctx, t)
+	if err != nil {
+		result.Status = HostKeyFailed
+		result.Message = err.Error()
+		return result, nil
+	}
+	result.Fingerprints = fingerprints(scanned)
+
+	existing := known.Keys(name)
+	expected, source := m.Pins.Expected(name)
+	result.Source = source
+
+	switch {
+	case len(expected) > 0:
+		if bad := unexpected(scanned, expected); len(bad) > 0 {
+			result.Status = HostKeyChanged
+			result.Message = fmt.Sprintf("%s presents %s, which does not match the %s fingerprints", name, strings.Join(fingerprints(bad), ", "), source)
+			return result, nil
+		}
+		if bad := unexpected(existing, expected); len(bad) > 0 {
+			result.Status = HostKeyChanged
+			result.Message = fmt.Sprintf("known_hosts records %s for %s, which does not match the %s fingerprints; remove it with 'ssh-keygen -R %s'",
+				strings.Join(fingerprints(bad), ", "), name, source, name)
+			return result, nil
+		}
+
+	case len(existing) > 0:
+		// 알려진 키와 같은 종류인데 다른 키를 제시하면 변경으로 본다
+		if changed := changedKeys(scanned, existing); len(changed) > 0 {
+			result.Status = HostKeyChanged
+			result.Message = fmt.Sprintf("%s presents %s, which differs from the key in known_hosts", name, strings.Join(fingerprints(changed), ", "))
+			return result, nil
+		}
+		result.Source = SourceKnown
+
+	case m.DryRun:
+		result.Status = HostKeyMissing
+		result.Message = "no pinned or published fingerprints; keys need confirmation"
+		return result, nil
+
+	default:
+		if m.Confirm == nil || !m.Confirm(name, scanned) {
+			result.Status = HostKeyRejected
+			result.Message = "keys were not confirmed"
+			return result, nil
+		}
+		result.Source = SourceConfirmed
+		if m.PinConfirmed && m.Pins != nil {
+			m.Pins.Pin(name, result.Fingerprints...)
+		}
+	}
+
+	missing := missingKeys(scanned, existing)
+	switch {
+	case len(missing) == 0:
+		result.Status = HostKeyOK
+	case m.DryRun:
+		result.Status = HostKeyMissing
+		result.Message = fmt.Sprintf("%d verified key(s) not in known_hosts", len(missing))
+		missing = nil
+	default:
+		result.Status = HostKeyAdded
+		result.Message = fmt.Sprintf("added %d key(s) to %s", len(missing), m.KnownHosts)
+	}
+	return result, missing
+}
+
+func (m *HostKeyManager) scan(ctx context.Context, t HostKeyTarget) ([]HostKey, error) {
+	args := []string{"-T", fmt.Sprintf("%d", int(m.Timeout.Seconds())), "-t", "ed25519,ecdsa,rsa"}
+	if t.Port != "" && t.Port != "22" {
+		args = append(args, "-p", t.Port)
+	}
+	args = append(args, t.Host)
+
+	tctx, cancel := context.WithTimeout(ctx, m.Timeout+5*time.Second)
+	out, err := m.run(tctx, "ssh-keyscan", args...)
+	cancel()
+
+	keys := parseHostKeys(scannedKeys(out))
+	switch {
+	case len(keys) == 0 && err != nil:
+		return nil, fmt.Errorf("ssh-keyscan failed: %s", lastLine(out, err))
+	case len(keys) == 0:
+		return nil, fmt.Errorf("ssh-keyscan returned no host keys")
+	}
+	for i := range keys {
+		keys[i].Host = t.Name()
+	}
+	return keys, nil
+}
+
+// parseHostKeys parses "host type key" lines.
+func parseHostKeys(data []byte) []HostKey {
+	var keys []HostKey
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		keys = append(keys, HostKey{Host: fields[0], Type: fields[1], Key: fields[2]})
+	}
+	return keys
+}
+
+// changedKeys returns the keys of scanned whose type is in existing with a
+// different key.
+func changedKeys(scanned, existing []HostKey) []HostKey {
+	var changed []HostKey
+	for _, s := range scanned {
+		sameType, same := false, false
+		for _, e := range existing {
+			if e.Type == s.Type {
+				sameType = true
+				same = same || e.Key == s.Key
+			}
+		}
+		if sameType && !same {
+			changed = append(changed, s)
+		}
+	}
+	return changed
+}
+
+// missingKeys returns the keys of scanned that are not in existing.
+func missingKeys(scanned, existing []HostKey) []HostKey {
+	var missing []HostKey
+	for _, s := range scanned {
+		if !slices.ContainsFunc(existing, func(e HostKey) bool { return e.Type == s.Type && e.Key == s.Key }) {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+// appendKnownHosts appends lines to the known_hosts file at path. An
+// existing file is backed up and replaced atomically; the backup path is
+// returned.
+func appendKnownHosts(path string, lines []byte) (string, error) {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if len(existing) > 0 && existing[len(existing)-1] != '\n' {
+		existing = append(existing, '\n')
+	}
+	data := append(existing, lines...)
+
+	if os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return "", err
+		}
+		return "", os.WriteFile(path, data, 0o600)
+	}
+	return WriteAtomic(path, data)
+}
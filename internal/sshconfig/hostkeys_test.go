@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sshconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// githubEd25519 is the Ed25519 host key GitHub publishes.
+const githubEd25519 = "AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"
+
+var otherKey = base64.StdEncoding.EncodeToString([]byte("not the published key"))
+
+// keyscan returns a runner whose ssh-keyscan prints the given key per host.
+func keyscan(keys map[string]string) func(context.Context, string, ...string) ([]byte, error) {
+	return func(_ context.Context, _ string, args ...string) ([]byte, error) {
+		host := args[len(args)-1]
+		return []byte("# " + host + ":22 SSH-2.0-OpenSSH\n" + host + " ssh-ed25519 " + keys[host] + "\n"), nil
+	}
+}
+
+func TestHostKeyFingerprint(t *testing.T) {
+	key := HostKey{Type: "ssh-ed25519", Key: githubEd25519}
+	assert.Contains(t, PublishedFingerprints["github.com"], key.Fingerprint())
+	assert.Empty(t, HostKey{Key: "%%%"}.Fingerprint())
+
+	assert.Equal(t, HostKeyTarget{Host: "git.example.com", Port: "2222"}, ParseHostKeyTarget("[git.example.com]:2222"))
+	assert.Equal(t, "[git.example.com]:2222", ParseHostKeyTarget("Git.example.com:2222").Name())
+	assert.Equal(t, "github.com", ParseHostKeyTarget("github.com").Name())
+}
+
+func TestPins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh-manager", "ssh-host-keys.yaml")
+	pins, err := LoadPins(path)
+	require.NoError(t, err)
+	fps, source := pins.Expected("GitHub.com")
+	assert.Equal(t, SourcePublished, source)
+	assert.Len(t, fps, 3)
+
+	pins.Pin("github.com", "SHA256:b", "SHA256:a", "SHA256:b")
+	require.NoError(t, pins.Save(path))
+
+	pins, err = LoadPins(path)
+	require.NoError(t, err)
+	fps, source = pins.Expected("github.com")
+	assert.Equal(t, SourcePinned, source, "pins override published fingerprints")
+	assert.Equal(t, []string{"SHA256:a", "SHA256:b"}, fps)
+	assert.True(t, pins.Unpin("github.com"))
+	assert.False(t, pins.Unpin("github.com"))
+
+	fps, _ = (*Pins)(nil).Expected("git.example.com")
+	assert.Empty(t, fps)
+
+	require.NoError(t, os.WriteFile(path, []byte("pins:\n  github.com: [MD5:aa:bb]\n"), 0o600))
+	_, err = LoadPins(path)
+	assert.ErrorContains(t, err, "not in SHA256:... form")
+}
+
+func TestHostKeyManager_Sync(t *testing.T) {
+	dir := t.TempDir()
+	knownHosts := filepath.Join(dir, "known_hosts")
+	pins := &Pins{Hosts: map[string][]string{}}
+
+	m := NewHostKeyManager(knownHosts, pins)
+	m.run = keyscan(map[string]string{"github.com": githubEd25519, "git.example.com": otherKey})
+
+	var asked []string
+	m.Confirm = func(host string, keys []HostKey) bool {
+		asked = append(asked, host)
+		return false
+	}
+
+	targets := []HostKeyTarget{{Host: "github.com"}, {Host: "git.example.com"}}
+	results := m.Sync(context.Background(), targets)
+	require.Len(t, results, 2)
+	assert.Equal(t, HostKeyAdded, results[0].Status)
+	assert.Equal(t, SourcePublished, results[0].Source)
+	assert.Equal(t, HostKeyRejected, results[1].Status)
+	assert.Equal(t, []string{"git.example.com"}, asked, "only hosts without trusted fingerprints are confirmed")
+
+	data, err := os.ReadFile(knownHosts)
+	require.NoError(t, err)
+	assert.Equal(t, "github.com ssh-ed25519 "+githubEd25519+"\n", string(data))
+
+	// 확인된 키는 추가되고 고정된다
+	m.Confirm = func(string, []HostKey) bool { return true }
+	m.PinConfirmed = true
+	results = m.Sync(context.Background(), targets)
+	assert.Equal(t, HostKeyOK, results[0].Status)
+	assert.Equal(t, HostKeyAdded, results[1].Status)
+	assert.NotEmpty(t, results[1].Backup)
+	fps, source := pins.Expected("git.example.com")
+	assert.Equal(t, SourcePinned, source)
+	assert.Equal(t, results[1].Fingerprints, fps)
+}
+
+func TestHostKeyManager_SyncDetectsChangedKeys(t *testing.T) {
+	dir := t.TempDir()
+	knownHosts := filepath.Join(dir, "known_hosts")
+	original := "git.example.com ssh-ed25519 " + githubEd25519 + "\n"
+	require.NoError(t, os.WriteFile(knownHosts, []byte(original), 0o600))
+
+	m := NewHostKeyManager(knownHosts, nil)
+	m.run = keyscan(map[string]string{"github.com": otherKey, "gitlab.com": otherKey, "git.example.com": otherKey})
+	m.Confirm = func(string, []HostKey) bool {
+		t.Fatal("changed keys must not be offered for confirmation")
+		return true
+	}
+
+	results := m.Sync(context.Background(), []HostKeyTarget{{Host: "github.com"}, {Host: "git.example.com"}})
+	require.Len(t, results, 2)
+	assert.Equal(t, HostKeyChanged, results[0].Status)
+	assert.Contains(t, results[0].Message, "does not match the published fingerprints")
+	assert.Equal(t, HostKeyChanged, results[1].Status)
+	assert.Contains(t, results[1].Message, "differs from the key in known_hosts")
+
+	data, err := os.ReadFile(knownHosts)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(data), "changed keys are never written")
+
+	// A stale known_hosts entry for a pinned host is reported as well.
+	pins := &Pins{Hosts: map[string][]string{}}
+	pins.Pin("git.example.com", HostKey{Key: otherKey}.Fingerprint())
+	m.Pins = pins
+	m.DryRun = true
+	results = m.Sync(context.Background(), []HostKeyTarget{{Host: "git.example.com"}, {Host: "gitlab.com"}})
+	assert.Equal(t, HostKeyChanged, results[0].Status)
+	assert.Contains(t, results[0].Message, "ssh-keygen -R git.example.com")
+	assert.Equal(t, HostKeyChanged, results[1].Status)
+}
+
+func TestHostKeyManager_DryRun(t *testing.T) {
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	m := NewHostKeyManager(knownHosts, nil)
+	m.run = keyscan(map[string]string{"github.com": githubEd25519, "git.example.com": otherKey})
+	m.DryRun = true
+
+	results := m.Sync(context.Background(), []HostKeyTarget{{Host: "github.com"}, {Host: "git.example.com"}})
+	assert.Equal(t, HostKeyMissing, results[0].Status)
+	assert.Equal(t, HostKeyMissing, results[1].Status)
+	assert.Contains(t, results[1].Message, "need confirmation")
+	assert.NoFileExists(t, knownHosts)
+}
+
+func TestConfiguredTargets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join([]string{
+		"Host work-gitlab",
+		"    HostName gitlab.com",
+		"    Port 2222",
+		"Host codeberg.org",
+		"Host internal",
+		"    HostName 10.0.0.5",
+	}, "\n")), 0o600))
+
+	targets, err := ConfiguredTargets(path, DefaultProviders)
+	require.NoError(t, err)
+	assert.Equal(t, []HostKeyTarget{
+		{Host: "gitlab.com", Port: "2222"},
+		{Host: "codeberg.org"},
+		{Host: "github.com"},
+		{Host: "gitlab.com"},
+	}, targets)
+
+	targets, err = ConfiguredTargets(filepath.Join(t.TempDir(), "missing"), []string{"github.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []HostKeyTarget{{Host: "github.com"}}, targets)
+}
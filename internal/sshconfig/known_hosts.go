@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sshconfig
+
+import (
+	"bufio"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // known_hosts hashing is defined as HMAC-SHA1
+	"encoding/base64"
+	"os"
+	"strings"
+)
+
+// KnownHosts is the set of host names found in known_hosts files, together
+// with the keys recorded for them.
+type KnownHosts struct {
+	plain   map[string]bool
+	hashed  []hashedHost
+	entries []knownHostsEntry
+}
+
+// knownHostsEntry is one key line; hosts is its comma-separated name list.
+type knownHostsEntry struct {
+	hosts string
+	key   HostKey
+}
+
+type hashedHost struct {
+	salt, hash []byte
+}
+
+// LoadKnownHosts reads the given known_hosts files, ignoring missing ones.
+func LoadKnownHosts(paths ...string) *KnownHosts {
+	k := &KnownHosts{plain: make(map[string]bool)}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			k.add(scanner.Text())
+		}
+		_ = f.Close()
+	}
+	return k
+}
+
+func (k *KnownHosts) add(line string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return
+	}
+	if strings.HasPrefix(fields[0], "@") {
+		// @revoked entries do not make a host known; @cert-authority
+		// entries are trusted for certificates only.
+		return
+	}
+
+	if len(fields) >= 3 {
+		k.entries = append(k.entries, knownHostsEntry{
+			hosts: fields[0],
+			key:   HostKey{Host: fields[0], Type: fields[1], Key: fields[2]},
+		})
+	}
+
+	for _, name := range strings.Split(fields[0], ",") {
+		if h, ok := parseHashed(name); ok {
+			k.hashed = append(k.hashed, h)
+			continue
+		}
+		k.plain[strings.ToLower(name)] = true
+	}
+}
+
+// parseHashed parses a HashKnownHosts entry of the form |1|salt|hash.
+func parseHashed(name string) (hashedHost, bool) {
+	parts := strings.Split(name, "|")
+	if len(parts) != 4 || parts[0] != "" || parts[1] != "1" {
+		return hashedHost{}, false
+	}
+	salt, err1 := base64.StdEncoding.DecodeString(parts[2])
+	hash, err2 := base64.StdEncoding.DecodeString(parts[3])
+	if err1 != nil || err2 != nil {
+		return hashedHost{}, false
+	}
+	return hashedHost{salt: salt, hash: hash}, true
+}
+
+// Contains reports whether name (as returned by knownHostsName) has an entry.
+func (k *KnownHosts) Contains(name string) bool {
+	name = strings.ToLower(name)
+	if k.plain[name] {
+		return true
+	}
+	for _, h := range k.hashed {
+		if h.matches(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h hashedHost) matches(name string) bool {
+	mac := hmac.New(sha1.New, h.salt)
+	mac.Write([]byte(name))
+	return hmac.Equal(mac.Sum(nil), h.hash)
+}
+
+// Keys returns the keys recorded for name (as returned by knownHostsName),
+// in file order.
+func (k *KnownHosts) Keys(name string) []HostKey {
+	name = strings.ToLower(name)
+	var keys []HostKey
+	for _, e := range k.entries {
+		for _, host := range strings.Split(e.hosts, ",") {
+			h, hashed := parseHashed(host)
+			if (hashed && h.matches(name)) || (!hashed && strings.ToLower(host) == name) {
+				key := e.key
+				key.Host = name
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+	return keys
+}
+
+// knownHostsName returns how OpenSSH names host in known_hosts.
+func knownHostsName(host, port string) string {
+	if port == "" || port == "22" {
+		return host
+	}
+	return "[" + host + "]:" + port
+}
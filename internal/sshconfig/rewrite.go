@@ -0,0 +1,183 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package sshconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// multiValued lists keywords OpenSSH accumulates instead of taking the first
+// value.
+var multiValued = map[string]bool{
+	"identityfile":    true,
+	"certificatefile": true,
+	"localforward":    true,
+	"remoteforward":   true,
+	"dynamicforward":  true,
+	"sendenv":         true,
+	"setenv":          true,
+}
+
+// hostKey identifies Host blocks that match exactly the same hosts.
+func hostKey(b *Block) string {
+	patterns := make([]string, len(b.Patterns))
+	for i, p := range b.Patterns {
+		patterns[i] = strings.ToLower(p)
+	}
+	sort.Strings(patterns)
+	return strings.Join(patterns, " ")
+}
+
+// DuplicateHosts groups Host blocks with identical pattern lists. Each group
+// is in file order; only groups with more than one block are returned.
+func (f *File) DuplicateHosts() [][]*Block {
+	groups := make(map[string][]*Block)
+	var order []string
+	for _, b := range f.Hosts() {
+		key := hostKey(b)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], b)
+	}
+
+	var dups [][]*Block
+	for _, key := range order {
+		if len(groups[key]) > 1 {
+			dups = append(dups, groups[key])
+		}
+	}
+	return dups
+}
+
+// MergeDuplicateHosts folds each duplicate Host block into the first block
+// with the same patterns and removes the duplicates. Options the first block
+// already sets are dropped, since OpenSSH would ignore them anyway; options
+// it lacks are appended to it. It returns the number of blocks removed.
+func (f *File) MergeDuplicateHosts() int {
+	dups := f.DuplicateHosts()
+	if len(dups) == 0 {
+		return 0
+	}
+
+	insertAfter := make(map[int][]string)
+	remove := make(map[int]bool)
+	removed := 0
+
+	for _, group := range dups {
+		first := group[0]
+		indent := blockIndent(f, first)
+		seen := make(map[string]bool)
+		for _, o := range first.Options {
+			seen[o.Key] = true
+			if multiValued[o.Key] {
+				seen[o.Key+"\x00"+o.Value] = true
+			}
+		}
+
+		anchor := first.Header
+		if n := len(first.Options); n > 0 {
+			anchor = first.Options[n-1].Line
+		}
+
+		for _, dup := range group[1:] {
+			for _, o := range dup.Options {
+				id := o.Key
+				if multiValued[o.Key] {
+					id = o.Key + "\x00" + o.Value
+				}
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+				seen[o.Key] = true
+				insertAfter[anchor] = append(insertAfter[anchor], indent+strings.TrimSpace(f.Lines[o.Line]))
+			}
+			for i := dup.Start; i < dup.End; i++ {
+				remove[i] = true
+			}
+			removed++
+		}
+	}
+
+	lines := make([]string, 0, len(f.Lines))
+	for i, line := range f.Lines {
+		if !remove[i] {
+			lines = append(lines, line)
+		}
+		lines = append(lines, insertAfter[i]...)
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	f.Lines = lines
+	f.reindex()
+	return removed
+}
+
+func blockIndent(f *File, b *Block) string {
+	for _, o := range b.Options {
+		line := f.Lines[o.Line]
+		if indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]; indent != "" {
+			return indent
+		}
+	}
+	return "    "
+}
+
+// WriteAtomic replaces path with data. The previous contents are first
+// copied to a timestamped backup next to it, whose path is returned. The new
+// file is written to a temporary file in the same directory and renamed into
+// place so readers never see a partial config.
+func WriteAtomic(path string, data []byte) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	old, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	backup := fmt.Sprintf("%s.bak-%s", path, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(backup, old, info.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("failed to write backup %s: %w", backup, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return backup, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	cleanup := func() { _ = os.Remove(tmpPath) }
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		cleanup()
+		return backup, fmt.Errorf("failed to write %s: %w", tmpPath, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		cleanup()
+		return backup, fmt.Errorf("failed to sync %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return backup, fmt.Errorf("failed to close %s: %w", tmpPath, err)
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+		cleanup()
+		return backup, fmt.Errorf("failed to set permissions on %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		cleanup()
+		return backup, fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return backup, nil
+}
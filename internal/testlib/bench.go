@@ -0,0 +1,419 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package testlib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/filesystem"
+)
+
+// Benchmark kinds.
+const (
+	// BenchMicro measures a single in-process operation.
+	BenchMicro = "micro"
+	// BenchMacro measures an end-to-end operation involving processes, disk
+	// or network.
+	BenchMacro = "macro"
+)
+
+// ErrBenchSkipped is returned by a benchmark's Setup when the environment
+// cannot run it (for example, git is not installed).
+var ErrBenchSkipped = errors.New("benchmark skipped")
+
+// BenchOp runs one measured operation and returns the number of bytes it
+// processed, or 0 when throughput is meaningless.
+type BenchOp func(ctx context.Context) (int64, error)
+
+// Benchmark is a registered benchmark.
+type Benchmark struct {
+	Name        string
+	Kind        string
+	Description string
+	// Setup prepares the fixtures once before sampling and returns the
+	// operation to measure and an optional cleanup function.
+	Setup func(ctx context.Context) (op BenchOp, cleanup func(), err error)
+}
+
+// BenchRegistry holds benchmarks by unique name.
+type BenchRegistry struct {
+	mu      sync.RWMutex
+	benches []Benchmark
+}
+
+// NewBenchRegistry creates an empty registry.
+func NewBenchRegistry() *BenchRegistry {
+	return &BenchRegistry{}
+}
+
+// Register adds a benchmark.
+func (r *BenchRegistry) Register(b Benchmark) error {
+	if b.Name == "" || b.Setup == nil {
+		return fmt.Errorf("benchmark needs a name and a setup function")
+	}
+	if b.Kind != BenchMicro && b.Kind != BenchMacro {
+		return fmt.Errorf("benchmark %s: invalid kind %q", b.Name, b.Kind)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.benches {
+		if existing.Name == b.Name {
+			return fmt.Errorf("benchmark %s is already registered", b.Name)
+		}
+	}
+	r.benches = append(r.benches, b)
+	return nil
+}
+
+// Select returns the benchmarks whose name matches the regular expression
+// filter, sorted by name. An empty filter selects every benchmark.
+func (r *BenchRegistry) Select(filter string) ([]Benchmark, error) {
+	var re *regexp.Regexp
+	if filter != "" {
+		var err error
+		if re, err = regexp.Compile(filter); err != nil {
+			return nil, fmt.Errorf("invalid benchmark filter: %w", err)
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var selected []Benchmark
+	for _, b := range r.benches {
+		if re == nil || re.MatchString(b.Name) {
+			selected = append(selected, b)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Name < selected[j].Name })
+	return selected, nil
+}
+
+// BenchOptions control how benchmarks are sampled.
+type BenchOptions struct {
+	// Samples is the number of timed samples per benchmark (default 10).
+	Samples int
+	// MinSampleTime is the minimum duration of one sample; the number of
+	// operations per sample is calibrated to reach it (default 100ms).
+	MinSampleTime time.Duration
+	// Progress, if set, is called before each benchmark starts.
+	Progress func(b Benchmark)
+}
+
+// BenchResult is the measurement of one benchmark.
+type BenchResult struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	// OpsPerSample is the number of operations timed in each sample.
+	OpsPerSample int `json:"opsPerSample,omitempty"`
+	// Samples holds the nanoseconds per operation of each sample.
+	Samples    []float64 `json:"samples,omitempty"`
+	MeanNs     float64   `json:"meanNs,omitempty"`
+	StdDevNs   float64   `json:"stdDevNs,omitempty"`
+	BytesPerOp int64     `json:"bytesPerOp,omitempty"`
+	// Throughput is bytes per second, when the benchmark reports bytes.
+	Throughput float64 `json:"throughput,omitempty"`
+	Skipped    string  `json:"skipped,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// BenchRun is a set of results recorded for one revision.
+type BenchRun struct {
+	Revision  string        `json:"revision"`
+	Dirty     bool          `json:"dirty,omitempty"`
+	Timestamp time.Time     `json:"timestamp"`
+	GoVersion string        `json:"goVersion"`
+	Platform  string        `json:"platform"`
+	NumCPU    int           `json:"numCpu"`
+	Results   []BenchResult `json:"results"`
+}
+
+// Key identifies the run in a BenchStore. Runs of a dirty working tree are
+// kept apart from the committed revision.
+func (r *BenchRun) Key() string {
+	if r.Dirty {
+		return r.Revision + "-dirty"
+	}
+	return r.Revision
+}
+
+// Result returns the result with the given name.
+func (r *BenchRun) Result(name string) (BenchResult, bool) {
+	for _, res := range r.Results {
+		if res.Name == name {
+			return res, true
+		}
+	}
+	return BenchResult{}, false
+}
+
+// RunBenchmarks measures every benchmark. Failures of individual benchmarks
+// are recorded in their result; only cancellation aborts the run.
+func RunBenchmarks(ctx context.Context, benches []Benchmark, opts BenchOptions) (*BenchRun, error) {
+	if opts.Samples <= 0 {
+		opts.Samples = 10
+	}
+	if opts.MinSampleTime <= 0 {
+		opts.MinSampleTime = 100 * time.Millisecond
+	}
+
+	run := &BenchRun{
+		Timestamp: time.Now().UTC(),
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		NumCPU:    runtime.NumCPU(),
+	}
+	for _, b := range benches {
+		if err := ctx.Err(); err != nil {
+			return run, err
+		}
+		if opts.Progress != nil {
+			opts.Progress(b)
+		}
+		run.Results = append(run.Results, runBenchmark(ctx, b, opts))
+	}
+	return run, ctx.Err()
+}
+
+func runBenchmark(ctx context.Context, b Benchmark, opts BenchOptions) BenchResult {
+	result := BenchResult{Name: b.Name, Kind: b.Kind}
+
+	op, cleanup, err := b.Setup(ctx)
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil {
+		if errors.Is(err, ErrBenchSkipped) {
+			result.Skipped = err.Error()
+		} else {
+			result.Error = err.Error()
+		}
+		return result
+	}
+
+	// 보정 단계는 워밍업을 겸한다
+	n := 1
+	for {
+		elapsed, bytes, err := timeOps(ctx, op, n)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.BytesPerOp = bytes / int64(n)
+		if elapsed >= opts.MinSampleTime || n >= 1e9 {
+			break
+		}
+		n = nextOpCount(n, elapsed, opts.MinSampleTime)
+	}
+	result.OpsPerSample = n
+
+	for i := 0; i < opts.Samples; i++ {
+		elapsed, _, err := timeOps(ctx, op, n)
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Samples = append(result.Samples, float64(elapsed.Nanoseconds())/float64(n))
+	}
+
+	result.MeanNs, result.StdDevNs = meanStdDev(result.Samples)
+	if result.BytesPerOp > 0 && result.MeanNs > 0 {
+		result.Throughput = float64(result.BytesPerOp) / (result.MeanNs / 1e9)
+	}
+	return result
+}
+
+// timeOps runs op n times.
+func timeOps(ctx context.Context, op BenchOp, n int) (time.Duration, int64, error) {
+	var total int64
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		bytes, err := op(ctx)
+		if err != nil {
+			return 0, 0, err
+		}
+		total += bytes
+	}
+	return time.Since(start), total, nil
+}
+
+// nextOpCount predicts the operations needed to fill target, growing at
+// most 100x per round like the testing package does.
+func nextOpCount(n int, elapsed, target time.Duration) int {
+	next := n * 100
+	if elapsed > 0 {
+		predicted := int(float64(n) * 1.2 * float64(target) / float64(elapsed))
+		if predicted < next {
+			next = predicted
+		}
+	}
+	if next <= n {
+		next = n + 1
+	}
+	return next
+}
+
+func meanStdDev(xs []float64) (float64, float64) {
+	if len(xs) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / float64(len(xs))
+	if len(xs) < 2 {
+		return mean, 0
+	}
+	var sq float64
+	for _, x := range xs {
+		sq += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(xs)-1))
+}
+
+// GitRevision returns the commit checked out in dir and whether the working
+// tree has uncommitted changes.
+func GitRevision(ctx context.Context, dir string) (string, bool, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "HEAD")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to resolve git revision: %w", err)
+	}
+	revision := strings.TrimSpace(string(out))
+
+	cmd = exec.CommandContext(ctx, "git", "status", "--porcelain", "--untracked-files=no")
+	cmd.Dir = dir
+	status, err := cmd.Output()
+	if err != nil {
+		return revision, false, fmt.Errorf("failed to read git status: %w", err)
+	}
+	return revision, len(strings.TrimSpace(string(status))) > 0, nil
+}
+
+// BenchStore keeps one JSON file per run key in a directory.
+type BenchStore struct {
+	dir string
+}
+
+// DefaultBenchDir returns the directory holding stored benchmark runs.
+func DefaultBenchDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "gzh-manager", "bench")
+}
+
+// NewBenchStore creates a store in dir.
+func NewBenchStore(dir string) *BenchStore {
+	return &BenchStore{dir: dir}
+}
+
+// Save stores run, replacing an earlier run of the same key.
+func (s *BenchStore) Save(run *BenchRun) error {
+	if run.Revision == "" {
+		return fmt.Errorf("benchmark run has no revision")
+	}
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal benchmark run: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create benchmark directory: %w", err)
+	}
+	return filesystem.WriteFileAtomic(filesystem.OS(), filepath.Join(s.dir, run.Key()+".json"), data, 0o600)
+}
+
+// Load returns the run stored under key, which is a full revision
+// optionally suffixed with -dirty. A unique prefix of a revision is
+// accepted as well.
+func (s *BenchStore) Load(key string) (*BenchRun, error) {
+	if run, err := s.read(filepath.Join(s.dir, key+".json")); err == nil {
+		return run, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	runs, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	var match *BenchRun
+	for _, run := range runs {
+		if !run.Dirty && strings.HasPrefix(run.Revision, key) {
+			if match != nil {
+				return nil, fmt.Errorf("revision %s is ambiguous", key)
+			}
+			match = run
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no benchmark results stored for %s", key)
+	}
+	return match, nil
+}
+
+// List returns every stored run, newest first.
+func (s *BenchStore) List() ([]*BenchRun, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read benchmark directory: %w", err)
+	}
+
+	var runs []*BenchRun
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		run, err := s.read(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Timestamp.After(runs[j].Timestamp) })
+	return runs, nil
+}
+
+// Latest returns the newest stored run with a key other than exclude, or
+// nil when there is none.
+func (s *BenchStore) Latest(exclude string) (*BenchRun, error) {
+	runs, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range runs {
+		if run.Key() != exclude {
+			return run, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *BenchStore) read(path string) (*BenchRun, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var run BenchRun
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &run, nil
+}
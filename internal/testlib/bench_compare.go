@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package testlib
+
+import (
+	"math"
+	"sort"
+)
+
+// Comparison verdicts.
+const (
+	VerdictRegression  = "regression"
+	VerdictImprovement = "improvement"
+	VerdictUnchanged   = "unchanged"
+	VerdictNew         = "new"
+	VerdictIncomplete  = "incomplete"
+)
+
+// CompareOptions control regression detection.
+type CompareOptions struct {
+	// Threshold is the minimum change of the mean time per operation, in
+	// percent, that counts as a regression or improvement (default 5).
+	Threshold float64
+	// Confidence is the required confidence that the samples differ
+	// (default 0.95).
+	Confidence float64
+}
+
+// BenchComparison compares one benchmark between two runs.
+type BenchComparison struct {
+	Name         string  `json:"name"`
+	BaselineNs   float64 `json:"baselineNs,omitempty"`
+	CurrentNs    float64 `json:"currentNs,omitempty"`
+	DeltaPercent float64 `json:"deltaPercent"`
+	PValue       float64 `json:"pValue"`
+	Significant  bool    `json:"significant"`
+	Verdict      string  `json:"verdict"`
+}
+
+// CompareRuns compares every result of current with the same benchmark in
+// baseline. A change is significant when a two-sided Mann-Whitney U test
+// rejects equal distributions at the requested confidence; it is a
+// regression or improvement when it is also larger than the threshold. The
+// rank test makes no normality assumption, which suits timing samples with
+// outliers.
+func CompareRuns(baseline, current *BenchRun, opts CompareOptions) []BenchComparison {
+	if opts.Threshold <= 0 {
+		opts.Threshold = 5
+	}
+	if opts.Confidence <= 0 || opts.Confidence >= 1 {
+		opts.Confidence = 0.95
+	}
+	alpha := 1 - opts.Confidence
+
+	comparisons := make([]BenchComparison, 0, len(current.Results))
+	for _, cur := range current.Results {
+		c := BenchComparison{Name: cur.Name, CurrentNs: cur.MeanNs, PValue: 1}
+		base, ok := BenchResult{}, false
+		if baseline != nil {
+			base, ok = baseline.Result(cur.Name)
+		}
+		switch {
+		case !ok:
+			c.Verdict = VerdictNew
+		case len(base.Samples) < 2 || len(cur.Samples) < 2:
+			c.BaselineNs = base.MeanNs
+			c.Verdict = VerdictIncomplete
+		default:
+			c.BaselineNs = base.MeanNs
+			c.DeltaPercent = (cur.MeanNs - base.MeanNs) / base.MeanNs * 100
+			c.PValue = MannWhitneyU(base.Samples, cur.Samples)
+			c.Significant = c.PValue < alpha
+			switch {
+			case c.Significant && c.DeltaPercent > opts.Threshold:
+				c.Verdict = VerdictRegression
+			case c.Significant && c.DeltaPercent < -opts.Threshold:
+				c.Verdict = VerdictImprovement
+			default:
+				c.Verdict = VerdictUnchanged
+			}
+		}
+		comparisons = append(comparisons, c)
+	}
+	return comparisons
+}
+
+// Regressions returns the comparisons with a regression verdict.
+func Regressions(comparisons []BenchComparison) []BenchComparison {
+	var out []BenchComparison
+	for _, c := range comparisons {
+		if c.Verdict == VerdictRegression {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// MannWhitneyU returns the two-sided p-value of the Mann-Whitney U test for
+// samples x and y, using the normal approximation with tie and continuity
+// corrections.
+func MannWhitneyU(x, y []float64) float64 {
+	n1, n2 := float64(len(x)), float64(len(y))
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type obs struct {
+		value float64
+		first bool
+	}
+	all := make([]obs, 0, len(x)+len(y))
+	for _, v := range x {
+		all = append(all, obs{v, true})
+	}
+	for _, v := range y {
+		all = append(all, obs{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].value < all[j].value })
+
+	// 동점은 평균 순위를 받는다
+	var rankSum, tieTerm float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].value == all[i].value {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].first {
+				rankSum += rank
+			}
+		}
+		t := float64(j - i)
+		tieTerm += t*t*t - t
+		i = j
+	}
+
+	n := n1 + n2
+	u := rankSum - n1*(n1+1)/2
+	mu := n1 * n2 / 2
+	sigma := math.Sqrt(n1 * n2 / 12 * ((n + 1) - tieTerm/(n*(n-1))))
+	if sigma == 0 {
+		return 1
+	}
+	z := (math.Abs(u-mu) - 0.5) / sigma
+	if z < 0 {
+		z = 0
+	}
+	return math.Erfc(z / math.Sqrt2)
+}
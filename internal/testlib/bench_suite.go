@@ -0,0 +1,242 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package testlib
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpcache"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+// DefaultBenchmarks returns a registry with the built-in benchmarks run by
+// `gz doctor bench`.
+func DefaultBenchmarks() *BenchRegistry {
+	r := NewBenchRegistry()
+	for _, b := range []Benchmark{
+		{
+			Name:        "config/parse-yaml",
+			Kind:        BenchMicro,
+			Description: "Parse and validate a gzh.yaml with 40 clone targets",
+			Setup:       setupConfigParse,
+		},
+		{
+			Name:        "cache/memory-set-get",
+			Kind:        BenchMicro,
+			Description: "Set and get HTTP cache entries in a bounded memory store with eviction",
+			Setup:       setupMemoryCache,
+		},
+		{
+			Name:        "cache/file-set-get",
+			Kind:        BenchMacro,
+			Description: "Set and get HTTP cache entries in the on-disk store",
+			Setup:       setupFileCache,
+		},
+		{
+			Name:        "clone/http-mock",
+			Kind:        BenchMacro,
+			Description: "Clone a 1 MiB repository from a local smart HTTP git server",
+			Setup:       setupCloneHTTP,
+		},
+	} {
+		if err := r.Register(b); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func setupConfigParse(_ context.Context) (BenchOp, func(), error) {
+	var sb strings.Builder
+	sb.WriteString("version: \"1.0.0\"\ndefault_provider: github\nproviders:\n")
+	sb.WriteString("  github:\n    token: \"bench-token\"\n    orgs:\n")
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&sb, "      - name: \"org-%d\"\n        visibility: \"all\"\n        match: \"^svc-.*\"\n        exclude: [\"legacy-*\", \"tmp-*\"]\n        cloneDir: \"~/src/github/org-%d\"\n", i, i)
+	}
+	sb.WriteString("  gitlab:\n    token: \"bench-token\"\n    groups:\n")
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&sb, "      - name: \"group-%d\"\n        recursive: true\n        strategy: \"pull\"\n", i)
+	}
+	data := []byte(sb.String())
+
+	op := func(_ context.Context) (int64, error) {
+		if _, err := config.ParseYAML(bytes.NewReader(data)); err != nil {
+			return 0, err
+		}
+		return int64(len(data)), nil
+	}
+	return op, nil, nil
+}
+
+// cacheOp sets and reads back entries over a key space twice the size of a
+// bounded store, so the memory store evicts continuously.
+func cacheOp(store httpcache.Store, keys int) BenchOp {
+	entry := &httpcache.Entry{
+		URL:        "https://api.github.com/orgs/gizzahub/repos",
+		ETag:       `W/"bench"`,
+		StatusCode: 200,
+		Body:       bytes.Repeat([]byte("x"), 2048),
+		StoredAt:   time.Unix(0, 0),
+	}
+	i := 0
+	return func(_ context.Context) (int64, error) {
+		key := fmt.Sprintf("GET https://api.github.com/repos/%d", i%keys)
+		i++
+		store.Set(key, entry)
+		if _, ok := store.Get(key); !ok {
+			return 0, fmt.Errorf("cache entry %s not found after set", key)
+		}
+		return int64(len(entry.Body)), nil
+	}
+}
+
+func setupMemoryCache(_ context.Context) (BenchOp, func(), error) {
+	return cacheOp(httpcache.NewMemoryStore(1000), 2000), nil, nil
+}
+
+func setupFileCache(_ context.Context) (BenchOp, func(), error) {
+	dir, err := os.MkdirTemp("", "gz-bench-cache-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { _ = os.RemoveAll(dir) }
+	return cacheOp(httpcache.NewFileStore(dir, 0, 0), 500), cleanup, nil
+}
+
+// setupCloneHTTP serves a bare repository through git http-backend behind
+// an httptest server, so clones exercise the smart HTTP protocol without
+// touching the network.
+func setupCloneHTTP(ctx context.Context) (BenchOp, func(), error) {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: git not found", ErrBenchSkipped)
+	}
+
+	root, err := os.MkdirTemp("", "gz-bench-clone-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { _ = os.RemoveAll(root) }
+
+	bare := filepath.Join(root, "serve", "fixture.git")
+	if err := createCloneFixture(ctx, filepath.Join(root, "work"), bare); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	packBytes, err := packSize(bare)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	server := httptest.NewServer(&cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env: []string{
+			"GIT_PROJECT_ROOT=" + filepath.Dir(bare),
+			"GIT_HTTP_EXPORT_ALL=1",
+		},
+	})
+	teardown := func() {
+		server.Close()
+		cleanup()
+	}
+
+	url := server.URL + "/fixture.git"
+	clones := filepath.Join(root, "clones")
+	n := 0
+	op := func(ctx context.Context) (int64, error) {
+		n++
+		dest := filepath.Join(clones, fmt.Sprintf("%d", n))
+		if err := benchGit(ctx, "", "clone", "--quiet", url, dest); err != nil {
+			return 0, err
+		}
+		// 디스크 사용량이 샘플 수에 비례해 늘지 않도록 바로 지운다
+		if err := os.RemoveAll(dest); err != nil {
+			return 0, err
+		}
+		return packBytes, nil
+	}
+	return op, teardown, nil
+}
+
+// createCloneFixture commits 64 files of incompressible content and packs
+// them into a bare repository.
+func createCloneFixture(ctx context.Context, work, bare string) error {
+	if err := os.MkdirAll(work, 0o755); err != nil {
+		return err
+	}
+	if err := benchGit(ctx, work, "init", "--quiet"); err != nil {
+		return err
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	buf := make([]byte, 16*1024)
+	for i := 0; i < 64; i++ {
+		rng.Read(buf)
+		name := filepath.Join(work, fmt.Sprintf("pkg%d", i%8), fmt.Sprintf("file%d.bin", i))
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(name, buf, 0o644); err != nil {
+			return err
+		}
+	}
+
+	for _, args := range [][]string{
+		{"add", "."},
+		{"commit", "--quiet", "-m", "benchmark fixture"},
+	} {
+		if err := benchGit(ctx, work, args...); err != nil {
+			return err
+		}
+	}
+	if err := benchGit(ctx, "", "clone", "--quiet", "--bare", "--no-local", work, bare); err != nil {
+		return err
+	}
+	return benchGit(ctx, bare, "repack", "-a", "-d", "-q")
+}
+
+func packSize(bare string) (int64, error) {
+	packs, err := filepath.Glob(filepath.Join(bare, "objects", "pack", "*.pack"))
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, p := range packs {
+		info, err := os.Stat(p)
+		if err != nil {
+			return 0, err
+		}
+		total += info.Size()
+	}
+	return total, nil
+}
+
+// benchGit runs git isolated from the user's configuration.
+func benchGit(ctx context.Context, dir string, args ...string) error {
+	args = append([]string{
+		"-c", "user.name=gz bench",
+		"-c", "user.email=bench@example.com",
+		"-c", "commit.gpgsign=false",
+		"-c", "init.defaultBranch=main",
+	}, args...)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_NOSYSTEM=1", "GIT_CONFIG_GLOBAL="+os.DevNull, "GIT_TERMINAL_PROMPT=0")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s failed: %w: %s", strings.Join(args[8:], " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package testlib
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noopBench(name string) Benchmark {
+	return Benchmark{
+		Name: name,
+		Kind: BenchMicro,
+		Setup: func(context.Context) (BenchOp, func(), error) {
+			return func(context.Context) (int64, error) { return 8, nil }, nil, nil
+		},
+	}
+}
+
+func TestBenchRegistry(t *testing.T) {
+	r := NewBenchRegistry()
+	require.NoError(t, r.Register(noopBench("cache/b")))
+	require.NoError(t, r.Register(noopBench("config/a")))
+	assert.Error(t, r.Register(noopBench("config/a")))
+	assert.Error(t, r.Register(Benchmark{Name: "x", Kind: "huge", Setup: noopBench("x").Setup}))
+
+	all, err := r.Select("")
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "cache/b", all[0].Name)
+
+	cfg, err := r.Select("^config/")
+	require.NoError(t, err)
+	require.Len(t, cfg, 1)
+	assert.Equal(t, "config/a", cfg[0].Name)
+
+	_, err = r.Select("(")
+	assert.Error(t, err)
+}
+
+func TestRunBenchmarks(t *testing.T) {
+	skipped := Benchmark{
+		Name: "skipped",
+		Kind: BenchMacro,
+		Setup: func(context.Context) (BenchOp, func(), error) {
+			return nil, nil, ErrBenchSkipped
+		},
+	}
+	failing := Benchmark{
+		Name: "failing",
+		Kind: BenchMicro,
+		Setup: func(context.Context) (BenchOp, func(), error) {
+			return func(context.Context) (int64, error) { return 0, errors.New("boom") }, nil, nil
+		},
+	}
+
+	run, err := RunBenchmarks(context.Background(), []Benchmark{noopBench("noop"), skipped, failing},
+		BenchOptions{Samples: 4, MinSampleTime: time.Millisecond})
+	require.NoError(t, err)
+	require.Len(t, run.Results, 3)
+
+	noop := run.Results[0]
+	assert.Len(t, noop.Samples, 4)
+	assert.Positive(t, noop.OpsPerSample)
+	assert.Positive(t, noop.MeanNs)
+	assert.Equal(t, int64(8), noop.BytesPerOp)
+	assert.Positive(t, noop.Throughput)
+
+	assert.NotEmpty(t, run.Results[1].Skipped)
+	assert.Equal(t, "boom", run.Results[2].Error)
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	base := []float64{100, 102, 98, 101, 99, 100, 103, 97, 100, 101}
+	slower := []float64{120, 118, 122, 121, 119, 120, 123, 117, 120, 121}
+	noisy := []float64{101, 99, 100, 102, 98, 100, 97, 103, 101, 100}
+
+	assert.Less(t, MannWhitneyU(base, slower), 0.001)
+	assert.Greater(t, MannWhitneyU(base, noisy), 0.5)
+	assert.Equal(t, 1.0, MannWhitneyU([]float64{5, 5}, []float64{5, 5}))
+	assert.Equal(t, 1.0, MannWhitneyU(nil, slower))
+}
+
+func TestCompareRuns(t *testing.T) {
+	result := func(name string, samples ...float64) BenchResult {
+		mean, sd := meanStdDev(samples)
+		return BenchResult{Name: name, Samples: samples, MeanNs: mean, StdDevNs: sd}
+	}
+	baseline := &BenchRun{Results: []BenchResult{
+		result("slower", 100, 101, 99, 100, 102, 98),
+		result("faster", 100, 101, 99, 100, 102, 98),
+		result("slightly", 100, 101, 99, 100, 102, 98),
+		result("broken", 100, 101),
+	}}
+	current := &BenchRun{Results: []BenchResult{
+		result("slower", 130, 131, 129, 130, 132, 128),
+		result("faster", 50, 51, 49, 50, 52, 48),
+		result("slightly", 102, 103, 101, 102, 104, 100),
+		{Name: "broken", Error: "boom"},
+		result("added", 10, 11, 9),
+	}}
+
+	comparisons := CompareRuns(baseline, current, CompareOptions{Threshold: 5, Confidence: 0.95})
+	verdicts := make(map[string]string)
+	for _, c := range comparisons {
+		verdicts[c.Name] = c.Verdict
+	}
+	assert.Equal(t, map[string]string{
+		"slower":   VerdictRegression,
+		"faster":   VerdictImprovement,
+		"slightly": VerdictUnchanged,
+		"broken":   VerdictIncomplete,
+		"added":    VerdictNew,
+	}, verdicts)
+
+	regressions := Regressions(comparisons)
+	require.Len(t, regressions, 1)
+	assert.InDelta(t, 30, regressions[0].DeltaPercent, 0.01)
+
+	for _, c := range CompareRuns(nil, current, CompareOptions{}) {
+		assert.Equal(t, VerdictNew, c.Verdict)
+	}
+}
+
+func TestBenchStore(t *testing.T) {
+	store := NewBenchStore(t.TempDir())
+
+	latest, err := store.Latest("")
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	now := time.Now().UTC()
+	older := &BenchRun{Revision: "aaaa1111", Timestamp: now.Add(-time.Hour)}
+	newer := &BenchRun{Revision: "bbbb2222", Timestamp: now}
+	dirty := &BenchRun{Revision: "bbbb2222", Dirty: true, Timestamp: now.Add(time.Minute)}
+	for _, run := range []*BenchRun{older, newer, dirty} {
+		require.NoError(t, store.Save(run))
+	}
+	assert.Error(t, store.Save(&BenchRun{}))
+
+	loaded, err := store.Load("aaaa")
+	require.NoError(t, err)
+	assert.Equal(t, "aaaa1111", loaded.Revision)
+
+	loaded, err = store.Load("bbbb2222-dirty")
+	require.NoError(t, err)
+	assert.True(t, loaded.Dirty)
+
+	_, err = store.Load("cccc")
+	assert.Error(t, err)
+
+	latest, err = store.Latest(dirty.Key())
+	require.NoError(t, err)
+	assert.Equal(t, "bbbb2222", latest.Key())
+}
+
+func TestDefaultBenchmarks(t *testing.T) {
+	filter := "^(config|cache)/"
+	if _, err := exec.LookPath("git"); err == nil && !testing.Short() {
+		filter = ""
+	}
+	benches, err := DefaultBenchmarks().Select(filter)
+	require.NoError(t, err)
+	require.NotEmpty(t, benches)
+
+	run, err := RunBenchmarks(context.Background(), benches, BenchOptions{Samples: 2, MinSampleTime: time.Millisecond})
+	require.NoError(t, err)
+	for _, res := range run.Results {
+		assert.Empty(t, res.Error, res.Name)
+		assert.Len(t, res.Samples, 2, res.Name)
+		assert.Positive(t, res.Throughput, res.Name)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package mocks
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+)
+
+// CallLog records the changes a fake provider backend was asked to make.
+// Embed it in the fake and report each write through Call. It is safe for
+// concurrent use, as syncers call backends from several workers.
+type CallLog struct {
+	// FailOn makes Call fail for calls containing it.
+	FailOn string
+
+	mu    sync.Mutex
+	calls []string
+}
+
+// Call records the call described by format and args. It returns an error
+// when the description contains FailOn.
+func (l *CallLog) Call(format string, args ...any) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c := fmt.Sprintf(format, args...)
+	l.calls = append(l.calls, c)
+	if l.FailOn != "" && strings.Contains(c, l.FailOn) {
+		return fmt.Errorf("boom")
+	}
+	return nil
+}
+
+// Calls returns the recorded calls in order.
+func (l *CallLog) Calls() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.calls...)
+}
+
+// Reset forgets the recorded calls.
+func (l *CallLog) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = nil
+}
+
+// AuditRecorder keeps recorded audit events in memory.
+type AuditRecorder struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+// Record implements audit.Recorder.
+func (r *AuditRecorder) Record(e audit.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+	return nil
+}
+
+// Events returns the recorded events in order.
+func (r *AuditRecorder) Events() []audit.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]audit.Event(nil), r.events...)
+}
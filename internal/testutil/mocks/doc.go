@@ -2,5 +2,8 @@
 // SPDX-License-Identifier: MIT
 
 // Package mocks provides mock implementations for testing purposes.
-// This includes HTTP client mocks and other service interfaces for unit testing.
+// This includes HTTP client mocks and other service interfaces for unit testing,
+// a recording transport that captures real provider HTTP interactions
+// to sanitized cassette files and replays them deterministically, and
+// call and audit logs for fake provider backends.
 package mocks
@@ -0,0 +1,434 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package mocks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// RecorderModeEnv selects the recorder mode of RecorderFor. Set it to
+// "record" to refresh cassettes against the live APIs.
+const RecorderModeEnv = "GZH_VCR_MODE"
+
+// Redacted replaces scrubbed secrets in cassettes.
+const Redacted = "[REDACTED]"
+
+// RecorderMode selects whether a Recorder talks to the network.
+type RecorderMode string
+
+const (
+	// ModeReplay answers requests from the cassette and never touches the
+	// network.
+	ModeReplay RecorderMode = "replay"
+	// ModeRecord forwards requests to the real transport and saves the
+	// sanitized interactions to the cassette on Stop.
+	ModeRecord RecorderMode = "record"
+)
+
+// secretHeaders have their values replaced by Redacted.
+var secretHeaders = []string{
+	"Authorization",
+	"Private-Token",
+	"Job-Token",
+	"X-Api-Key",
+	"Cookie",
+	"Set-Cookie",
+	"Proxy-Authorization",
+}
+
+// volatileHeaders change on every call and are dropped so that cassettes
+// stay stable across recordings.
+var volatileHeaders = []string{
+	"Date",
+	"Etag",
+	"Last-Modified",
+	"Age",
+	"X-Request-Id",
+	"X-Github-Request-Id",
+	"X-Runtime",
+	"X-Served-By",
+	"X-Timer",
+	"X-Ratelimit-Limit",
+	"X-Ratelimit-Remaining",
+	"X-Ratelimit-Reset",
+	"X-Ratelimit-Used",
+	"X-Ratelimit-Resource",
+	"Ratelimit-Limit",
+	"Ratelimit-Remaining",
+	"Ratelimit-Reset",
+	"Ratelimit-Observed",
+	"Ratelimit-Resettime",
+	"Cf-Ray",
+	"Strict-Transport-Security",
+	"Content-Length",
+}
+
+// secretFields are JSON body fields and query parameters whose values are
+// replaced by Redacted, matched case-insensitively.
+var secretFields = map[string]bool{
+	"token":         true,
+	"access_token":  true,
+	"private_token": true,
+	"refresh_token": true,
+	"client_secret": true,
+	"password":      true,
+	"secret":        true,
+	"private_key":   true,
+	"key":           true,
+}
+
+// RecorderOptions configure a Recorder.
+type RecorderOptions struct {
+	// Mode defaults to ModeReplay.
+	Mode RecorderMode
+	// Transport performs real requests in ModeRecord; nil means
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+	// Secrets are literal values, such as the token in use, scrubbed from
+	// every part of a recorded interaction.
+	Secrets []string
+	// DropHeaders are additional headers left out of the cassette.
+	DropHeaders []string
+}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the sanitized form of a request.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the sanitized form of a response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// cassette is the fixture file format.
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records provider interactions to
+// a cassette file, or replays them from it. Recorded interactions are
+// sanitized: secret headers, query parameters and JSON fields are
+// redacted, volatile headers are dropped and header names are
+// canonicalized. Replay matches requests on method, URL and body in
+// recording order, so repeated calls to the same endpoint return their
+// responses in sequence; once exhausted, the last match is repeated.
+type Recorder struct {
+	path string
+	opts RecorderOptions
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewRecorder creates a recorder for the cassette at path. In ModeReplay
+// the cassette must exist.
+func NewRecorder(path string, opts RecorderOptions) (*Recorder, error) {
+	if opts.Mode == "" {
+		opts.Mode = ModeReplay
+	}
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	r := &Recorder{path: path, opts: opts}
+
+	switch opts.Mode {
+	case ModeRecord:
+		return r, nil
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette %s (record it with %s=record): %w", path, RecorderModeEnv, err)
+		}
+		var c cassette
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+		}
+		r.interactions = c.Interactions
+		r.used = make([]bool, len(c.Interactions))
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unknown recorder mode %q (replay, record)", opts.Mode)
+	}
+}
+
+// RecorderFor creates a recorder for the cassette testdata/cassettes/<name>.json
+// in the mode selected by GZH_VCR_MODE, saving it when the test ends. secrets
+// are scrubbed as in RecorderOptions.Secrets; empty values are ignored.
+func RecorderFor(t testing.TB, name string, secrets ...string) *Recorder {
+	t.Helper()
+	mode := RecorderMode(os.Getenv(RecorderModeEnv))
+	r, err := NewRecorder(filepath.Join("testdata", "cassettes", name+".json"), RecorderOptions{Mode: mode, Secrets: secrets})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := r.Stop(); err != nil {
+			t.Errorf("failed to save cassette: %v", err)
+		}
+	})
+	return r
+}
+
+// Client returns an HTTP client using the recorder as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Mode returns the recorder mode.
+func (r *Recorder) Mode() RecorderMode {
+	return r.opts.Mode
+}
+
+// Interactions returns the recorded or loaded interactions.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The request is cloned before its body is consumed, as RoundTrippers
+	// must not modify the caller's request.
+	req = req.Clone(req.Context())
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	recorded := r.sanitizeRequest(req, reqBody)
+
+	if r.opts.Mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.sanitizeHeaders(resp.Header),
+			Body:       r.scrubBody(respBody),
+		},
+	})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Stop saves the cassette in ModeRecord and does nothing in ModeReplay.
+func (r *Recorder) Stop() error {
+	if r.opts.Mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	last := -1
+	for i, it := range r.interactions {
+		if !sameRequest(it.Request, recorded) {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return it.Response.toResponse(req), nil
+		}
+		last = i
+	}
+	if last >= 0 {
+		return r.interactions[last].Response.toResponse(req), nil
+	}
+	return nil, fmt.Errorf("no recorded interaction for %s %s in %s", recorded.Method, recorded.URL, r.path)
+}
+
+func sameRequest(a, b RecordedRequest) bool {
+	return a.Method == b.Method && a.URL == b.URL && normalizeJSON(a.Body) == normalizeJSON(b.Body)
+}
+
+func (rr RecordedResponse) toResponse(req *http.Request) *http.Response {
+	header := rr.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rr.StatusCode, http.StatusText(rr.StatusCode)),
+		StatusCode:    rr.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(rr.Body)),
+		ContentLength: int64(len(rr.Body)),
+		Request:       req,
+	}
+}
+
+// readBody reads and replaces *body so that it can still be consumed.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, err
+}
+
+func (r *Recorder) sanitizeRequest(req *http.Request, body []byte) RecordedRequest {
+	return RecordedRequest{
+		Method:  req.Method,
+		URL:     r.scrubString(sanitizeURL(req.URL)),
+		Headers: r.sanitizeHeaders(req.Header),
+		Body:    r.scrubBody(body),
+	}
+}
+
+// sanitizeURL drops user info, redacts secret query parameters and sorts
+// the query so that parameter order does not affect matching.
+func sanitizeURL(u *url.URL) string {
+	clean := *u
+	clean.User = nil
+	query := clean.Query()
+	for key := range query {
+		if secretFields[strings.ToLower(key)] {
+			query[key] = []string{Redacted}
+		}
+	}
+	clean.RawQuery = query.Encode()
+	return clean.String()
+}
+
+func (r *Recorder) sanitizeHeaders(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(http.Header, len(h))
+	for name, values := range h {
+		out[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+	}
+	for _, name := range append(append([]string{}, volatileHeaders...), r.opts.DropHeaders...) {
+		out.Del(name)
+	}
+	for _, name := range secretHeaders {
+		if out.Get(name) != "" {
+			out.Set(name, Redacted)
+		}
+	}
+	for name, values := range out {
+		for i, v := range values {
+			values[i] = r.scrubString(v)
+		}
+		sort.Strings(values)
+		out[name] = values
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// scrubBody redacts secret fields of JSON bodies and literal secrets in any
+// body.
+func (r *Recorder) scrubBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		if data, err := json.Marshal(redactJSON(v)); err == nil {
+			return r.scrubString(string(data))
+		}
+	}
+	return r.scrubString(string(body))
+}
+
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if secretFields[strings.ToLower(key)] {
+				if _, ok := value.(string); ok {
+					v[key] = Redacted
+					continue
+				}
+			}
+			v[key] = redactJSON(value)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func (r *Recorder) scrubString(s string) string {
+	for _, secret := range r.opts.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, Redacted)
+		}
+	}
+	return s
+}
+
+// normalizeJSON returns body re-encoded with sorted keys when it is JSON, so
+// that field order does not affect matching.
+func normalizeJSON(body string) string {
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return body
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package mocks
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "ghp_supersecret123"
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Date", "Mon, 01 Jan 2025 00:00:00 GMT")
+		w.Header().Set("X-GitHub-Request-Id", "ABC")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/user":
+			io.WriteString(w, `{"login":"octocat","token":"`+testToken+`"}`)
+		case "/orgs/acme/repos":
+			io.WriteString(w, `[{"name":"page`+r.URL.Query().Get("page")+`"}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "github.json")
+	rec, err := NewRecorder(path, RecorderOptions{Mode: ModeRecord, Secrets: []string{testToken}})
+	require.NoError(t, err)
+
+	get := func(client *http.Client, target string) (int, string) {
+		req, err := http.NewRequest(http.MethodGet, target, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+testToken)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	_, body := get(rec.Client(), server.URL+"/user?access_token="+testToken)
+	assert.Contains(t, body, testToken, "the caller sees the real response while recording")
+	get(rec.Client(), server.URL+"/orgs/acme/repos?per_page=1&page=1")
+	get(rec.Client(), server.URL+"/orgs/acme/repos?page=2&per_page=1")
+	require.NoError(t, rec.Stop())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	cassette := string(data)
+	assert.NotContains(t, cassette, testToken, "secrets must be scrubbed")
+	assert.NotContains(t, cassette, "session=abc")
+	assert.NotContains(t, cassette, "Mon, 01 Jan 2025", "volatile headers must be dropped")
+	assert.NotContains(t, cassette, "X-Github-Request-Id")
+	assert.Contains(t, cassette, Redacted)
+
+	// Replay works without the server.
+	server.Close()
+	replay, err := NewRecorder(path, RecorderOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, ModeReplay, replay.Mode())
+
+	status, body := get(replay.Client(), server.URL+"/user?access_token=other")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"login":"octocat"`)
+	assert.False(t, strings.Contains(body, testToken))
+
+	_, body = get(replay.Client(), server.URL+"/orgs/acme/repos?page=2&per_page=1")
+	assert.Contains(t, body, "page2", "query parameter order must not matter")
+	_, body = get(replay.Client(), server.URL+"/orgs/acme/repos?page=1&per_page=1")
+	assert.Contains(t, body, "page1")
+
+	_, err = replay.Client().Get(server.URL + "/unknown")
+	assert.ErrorContains(t, err, "no recorded interaction for GET")
+	assert.Equal(t, 3, calls)
+}
+
+func TestRecorderReplaysRepeatedRequestsInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"interactions":[
+  {"request":{"method":"POST","url":"https://api.example.com/items","body":"{\"b\":2,\"a\":1}"},"response":{"statusCode":201,"body":"first"}},
+  {"request":{"method":"POST","url":"https://api.example.com/items","body":"{\"a\":1,\"b\":2}"},"response":{"statusCode":409,"body":"second"}}
+]}`), 0o600))
+
+	rec, err := NewRecorder(path, RecorderOptions{})
+	require.NoError(t, err)
+
+	post := func() (int, string) {
+		resp, err := rec.Client().Post("https://api.example.com/items", "application/json", strings.NewReader(`{"a":1,"b":2}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := post()
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "first", body)
+	status, body = post()
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "second", body)
+	status, _ = post()
+	assert.Equal(t, http.StatusConflict, status, "the last match repeats once exhausted")
+}
+
+func TestNewRecorderMissingCassette(t *testing.T) {
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), RecorderOptions{})
+	assert.ErrorContains(t, err, RecorderModeEnv+"=record")
+
+	_, err = NewRecorder("x.json", RecorderOptions{Mode: "live"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package trace carries the run-level trace ID that correlates one gz
+// invocation across structured logs, provider API calls, plugins and audit
+// events.
+//
+// The ID is a 32 character lowercase hex string, the W3C Trace Context
+// trace-id format, so remote systems that understand traceparent can join
+// their spans to the run. Child processes inherit it through GZ_TRACE_ID.
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// EnvVar holds the trace ID of the run. It is set for child processes
+	// and, when set by a caller, continues its trace.
+	EnvVar = "GZ_TRACE_ID"
+	// PropagateEnvVar disables the trace headers on outgoing requests when
+	// false, for servers or proxies that reject unknown headers.
+	PropagateEnvVar = "GZ_TRACE_PROPAGATE"
+	// Header carries the trace ID on provider API requests.
+	Header = "X-Gz-Trace-Id"
+	// ParentHeader is the W3C Trace Context header.
+	ParentHeader = "traceparent"
+)
+
+var (
+	mu      sync.RWMutex
+	current string
+)
+
+// Start sets the trace ID of the run and exports it to child processes.
+// An empty or invalid id continues the trace of $GZ_TRACE_ID or starts a
+// new one. It returns the ID in use.
+func Start(id string) string {
+	if !Valid(id) {
+		id = os.Getenv(EnvVar)
+	}
+	if !Valid(id) {
+		id = NewID()
+	}
+
+	mu.Lock()
+	current = id
+	mu.Unlock()
+	_ = os.Setenv(EnvVar, id)
+	return id
+}
+
+// ID returns the trace ID of the run: the one set by Start, else
+// $GZ_TRACE_ID when valid, else "".
+func ID() string {
+	mu.RLock()
+	id := current
+	mu.RUnlock()
+	if id != "" {
+		return id
+	}
+	if env := os.Getenv(EnvVar); Valid(env) {
+		return env
+	}
+	return ""
+}
+
+// NewID returns a random trace ID.
+func NewID() string {
+	return randomHex(16)
+}
+
+// Valid reports whether id is a 32 character lowercase hex string that is
+// not all zeros, as W3C Trace Context requires.
+func Valid(id string) bool {
+	if len(id) != 32 {
+		return false
+	}
+	zero := true
+	for _, c := range id {
+		switch {
+		case c == '0':
+		case c >= '1' && c <= '9', c >= 'a' && c <= 'f':
+			zero = false
+		default:
+			return false
+		}
+	}
+	return !zero
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand는 실패하지 않지만 0으로 채운 ID는 무효이므로 마지막 바이트를 세운다
+		b[n-1] = 1
+	}
+	return hex.EncodeToString(b)
+}
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx carrying a trace ID, for work such as a
+// server request that belongs to a different trace than the run.
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the trace ID carried by ctx, else the run's ID.
+func FromContext(ctx context.Context) string {
+	if ctx != nil {
+		if id, ok := ctx.Value(contextKey{}).(string); ok && id != "" {
+			return id
+		}
+	}
+	return ID()
+}
+
+// Env returns the environment entry that hands the trace ID of ctx to a
+// child process, or "" when there is no trace.
+func Env(ctx context.Context) string {
+	id := FromContext(ctx)
+	if id == "" {
+		return ""
+	}
+	return EnvVar + "=" + id
+}
+
+// AppendEnv adds the trace ID of ctx to env, replacing an inherited entry.
+func AppendEnv(ctx context.Context, env []string) []string {
+	entry := Env(ctx)
+	if entry == "" {
+		return env
+	}
+	out := make([]string, 0, len(env)+1)
+	for _, e := range env {
+		if strings.HasPrefix(e, EnvVar+"=") {
+			continue
+		}
+		out = append(out, e)
+	}
+	return append(out, entry)
+}
+
+// Propagate reports whether trace headers are added to outgoing requests.
+func Propagate() bool {
+	v, err := strconv.ParseBool(os.Getenv(PropagateEnvVar))
+	return err != nil || v
+}
+
+// Transport wraps rt so requests carry the trace ID of their context in
+// Header and a traceparent with a fresh span ID. Headers already set by the
+// caller are kept. A nil rt uses http.DefaultTransport.
+func Transport(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &transport{next: rt}
+}
+
+type transport struct {
+	next http.RoundTripper
+}
+
+// CloseIdleConnections forwards to the wrapped transport.
+func (t *transport) CloseIdleConnections() {
+	if c, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := FromContext(req.Context())
+	if id == "" || !Propagate() {
+		return t.next.RoundTrip(req)
+	}
+	if req.Header.Get(Header) != "" && req.Header.Get(ParentHeader) != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	// RoundTripper는 요청을 수정하면 안 되므로 복제한다
+	req = req.Clone(req.Context())
+	if req.Header.Get(Header) == "" {
+		req.Header.Set(Header, id)
+	}
+	if req.Header.Get(ParentHeader) == "" {
+		req.Header.Set(ParentHeader, "00-"+id+"-"+randomHex(8)+"-01")
+	}
+	return t.next.RoundTrip(req)
+}
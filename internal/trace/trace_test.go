@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package trace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reset(t *testing.T) {
+	t.Helper()
+	t.Setenv(EnvVar, "")
+	mu.Lock()
+	current = ""
+	mu.Unlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		current = ""
+		mu.Unlock()
+	})
+}
+
+func TestStart(t *testing.T) {
+	reset(t)
+	assert.Empty(t, ID())
+
+	id := Start("")
+	assert.True(t, Valid(id))
+	assert.Equal(t, id, ID())
+	assert.Equal(t, id, os.Getenv(EnvVar))
+
+	const given = "4bf92f3577b34da6a3ce929d0e0e4736"
+	assert.Equal(t, given, Start(given))
+
+	// 유효하지 않은 값은 상속된 트레이스를 이어간다
+	assert.Equal(t, given, Start("not-a-trace"))
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid(NewID()))
+	assert.False(t, Valid(strings.Repeat("0", 32)))
+	assert.False(t, Valid(strings.ToUpper(NewID())))
+	assert.False(t, Valid("abc"))
+}
+
+func TestFromContextAndEnv(t *testing.T) {
+	reset(t)
+	run := Start("")
+	other := NewID()
+
+	assert.Equal(t, run, FromContext(context.Background()))
+	ctx := WithContext(context.Background(), other)
+	assert.Equal(t, other, FromContext(ctx))
+
+	env := AppendEnv(ctx, []string{"PATH=/bin", EnvVar + "=" + run})
+	assert.Equal(t, []string{"PATH=/bin", EnvVar + "=" + other}, env)
+}
+
+func TestTransport(t *testing.T) {
+	reset(t)
+	id := Start("")
+
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: Transport(nil)}
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, id, got.Get(Header))
+	parts := strings.Split(got.Get(ParentHeader), "-")
+	require.Len(t, parts, 4)
+	assert.Equal(t, []string{"00", id}, parts[:2])
+	assert.Len(t, parts[2], 16)
+	assert.Empty(t, req.Header.Get(Header), "caller's request must not be modified")
+
+	t.Setenv(PropagateEnvVar, "false")
+	resp, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, got.Get(Header))
+	assert.Empty(t, got.Get(ParentHeader))
+}
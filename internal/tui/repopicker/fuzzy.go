@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repopicker
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Score bonuses for fuzzy matching. Matches at the start of a word and runs
+// of consecutive characters rank higher, so "api" prefers "api-server" over
+// "legacy-pipeline".
+const (
+	scoreMatch       = 1
+	scoreWordStart   = 8
+	scoreConsecutive = 4
+	scoreGapPenalty  = 1
+)
+
+// fuzzyScore matches pattern against text as a case-insensitive subsequence.
+// ok is false when some character of pattern does not occur in order.
+func fuzzyScore(pattern, text string) (score int, ok bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+	if len(p) == 0 {
+		return 0, true
+	}
+
+	pi, last := 0, -1
+	for ti := 0; ti < len(t) && pi < len(p); ti++ {
+		if t[ti] != p[pi] {
+			continue
+		}
+		score += scoreMatch
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += scoreWordStart
+		}
+		if last >= 0 {
+			if ti == last+1 {
+				score += scoreConsecutive
+			} else {
+				score -= min(ti-last-1, 5) * scoreGapPenalty
+			}
+		}
+		last = ti
+		pi++
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+	return score, true
+}
+
+// matchItem scores an item against a query. Every whitespace-separated term
+// must match the name, the language or a topic; the name counts double.
+func matchItem(query string, item Item) (int, bool) {
+	total := 0
+	for _, term := range strings.Fields(query) {
+		best, found := 0, false
+		if s, ok := fuzzyScore(term, item.Name); ok {
+			best, found = 2*s, true
+		}
+		for _, field := range append([]string{item.Language}, item.Topics...) {
+			if s, ok := fuzzyScore(term, field); ok && (!found || s > best) {
+				best, found = s, true
+			}
+		}
+		if !found {
+			return 0, false
+		}
+		total += best
+	}
+	return total, true
+}
@@ -0,0 +1,373 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package repopicker implements a keyboard-driven, fuzzy-searchable
+// multi-select list of repositories.
+package repopicker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gizzahub/gzh-cli/internal/tui/common"
+)
+
+// ErrCancelled is returned by Run when the user leaves without confirming.
+var ErrCancelled = errors.New("repository selection cancelled")
+
+// Item is one repository in the picker.
+type Item struct {
+	Name        string
+	Description string
+	Language    string
+	Topics      []string
+	// SizeKB is the repository size in kilobytes, as reported by GitHub.
+	SizeKB   int64
+	Stars    int
+	Private  bool
+	Archived bool
+	Fork     bool
+}
+
+// Model is the bubbletea model of the picker.
+type Model struct {
+	title    string
+	items    []Item
+	selected map[int]bool
+
+	query   string
+	visible []int // indices into items, in display order
+	cursor  int   // index into visible
+	offset  int   // first visible row
+
+	width, height int
+	done          bool
+	cancelled     bool
+
+	styles pickerStyles
+}
+
+type pickerStyles struct {
+	title    lipgloss.Style
+	header   lipgloss.Style
+	cursor   lipgloss.Style
+	selected lipgloss.Style
+	subtle   lipgloss.Style
+}
+
+// New creates a picker over items. Items are shown in the given order until
+// a query ranks them.
+func New(title string, items []Item) *Model {
+	theme := common.DefaultTheme()
+	m := &Model{
+		title:    title,
+		items:    items,
+		selected: make(map[int]bool),
+		width:    100,
+		height:   24,
+		styles: pickerStyles{
+			title:    lipgloss.NewStyle().Bold(true).Foreground(theme.Primary),
+			header:   lipgloss.NewStyle().Bold(true).Foreground(theme.Subtle),
+			cursor:   lipgloss.NewStyle().Bold(true).Foreground(theme.Highlight),
+			selected: lipgloss.NewStyle().Foreground(theme.Success),
+			subtle:   lipgloss.NewStyle().Foreground(theme.Subtle),
+		},
+	}
+	m.refilter()
+	return m
+}
+
+// Init implements tea.Model.
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+	case tea.KeyMsg:
+		return m, m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *Model) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.cancelled = true
+		return tea.Quit
+	case tea.KeyEsc:
+		// 검색어가 있으면 먼저 지우고, 없을 때만 취소한다
+		if m.query != "" {
+			m.setQuery("")
+			return nil
+		}
+		m.cancelled = true
+		return tea.Quit
+	case tea.KeyEnter:
+		if len(m.selected) == 0 && len(m.visible) > 0 {
+			m.selected[m.visible[m.cursor]] = true
+		}
+		m.done = true
+		return tea.Quit
+	case tea.KeyUp, tea.KeyCtrlP, tea.KeyCtrlK:
+		m.move(-1)
+	case tea.KeyDown, tea.KeyCtrlN, tea.KeyCtrlJ:
+		m.move(1)
+	case tea.KeyPgUp:
+		m.move(-m.pageSize())
+	case tea.KeyPgDown:
+		m.move(m.pageSize())
+	case tea.KeyHome:
+		m.move(-len(m.visible))
+	case tea.KeyEnd:
+		m.move(len(m.visible))
+	case tea.KeySpace, tea.KeyTab:
+		m.toggle()
+		m.move(1)
+	case tea.KeyShiftTab:
+		m.toggle()
+		m.move(-1)
+	case tea.KeyCtrlA:
+		m.toggleAll()
+	case tea.KeyBackspace:
+		if r := []rune(m.query); len(r) > 0 {
+			m.setQuery(string(r[:len(r)-1]))
+		}
+	case tea.KeyCtrlU:
+		m.setQuery("")
+	case tea.KeyRunes:
+		m.setQuery(m.query + string(msg.Runes))
+	}
+	return nil
+}
+
+func (m *Model) setQuery(query string) {
+	m.query = query
+	m.refilter()
+}
+
+// refilter recomputes the visible items for the query, best matches first.
+func (m *Model) refilter() {
+	type ranked struct {
+		index, score int
+	}
+	var matches []ranked
+	for i, item := range m.items {
+		if score, ok := matchItem(m.query, item); ok {
+			matches = append(matches, ranked{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool { return matches[a].score > matches[b].score })
+
+	m.visible = m.visible[:0]
+	for _, r := range matches {
+		m.visible = append(m.visible, r.index)
+	}
+	m.cursor, m.offset = 0, 0
+}
+
+func (m *Model) move(delta int) {
+	if len(m.visible) == 0 {
+		return
+	}
+	m.cursor = max(0, min(len(m.visible)-1, m.cursor+delta))
+	m.scroll()
+}
+
+// scroll keeps the cursor row on screen.
+func (m *Model) scroll() {
+	page := m.pageSize()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+page {
+		m.offset = m.cursor - page + 1
+	}
+}
+
+// pageSize is the number of list rows that fit next to the title, the
+// query line, the column header and the help line.
+func (m *Model) pageSize() int {
+	return max(1, m.height-5)
+}
+
+func (m *Model) toggle() {
+	if len(m.visible) == 0 {
+		return
+	}
+	i := m.visible[m.cursor]
+	if m.selected[i] {
+		delete(m.selected, i)
+	} else {
+		m.selected[i] = true
+	}
+}
+
+// toggleAll selects every visible item, or clears them when all are
+// selected already.
+func (m *Model) toggleAll() {
+	all := true
+	for _, i := range m.visible {
+		if !m.selected[i] {
+			all = false
+			break
+		}
+	}
+	for _, i := range m.visible {
+		if all {
+			delete(m.selected, i)
+		} else {
+			m.selected[i] = true
+		}
+	}
+}
+
+// Selected returns the selected items in their original order.
+func (m *Model) Selected() []Item {
+	indices := make([]int, 0, len(m.selected))
+	for i := range m.selected {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	out := make([]Item, len(indices))
+	for n, i := range indices {
+		out[n] = m.items[i]
+	}
+	return out
+}
+
+// Cancelled reports whether the user left without confirming.
+func (m *Model) Cancelled() bool {
+	return m.cancelled
+}
+
+// View implements tea.Model.
+func (m *Model) View() string {
+	if m.done || m.cancelled {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s  %s\n",
+		m.styles.title.Render(m.title),
+		m.styles.subtle.Render(fmt.Sprintf("%d/%d selected, %d shown", len(m.selected), len(m.items), len(m.visible))))
+	fmt.Fprintf(&b, "> %s█\n", m.query)
+
+	nameWidth, langWidth, topicWidth := m.columnWidths()
+	header := fmt.Sprintf("      %-*s  %-*s  %8s  %6s  %s", nameWidth, "NAME", langWidth, "LANGUAGE", "SIZE", "STARS", "TOPICS")
+	b.WriteString(m.styles.header.Render(truncate(header, m.width)) + "\n")
+
+	end := min(len(m.visible), m.offset+m.pageSize())
+	for row := m.offset; row < end; row++ {
+		i := m.visible[row]
+		item := m.items[i]
+
+		pointer, check := " ", "[ ]"
+		if row == m.cursor {
+			pointer = "▸"
+		}
+		if m.selected[i] {
+			check = "[x]"
+		}
+		name := item.Name
+		if item.Archived {
+			name += " (archived)"
+		} else if item.Fork {
+			name += " (fork)"
+		}
+		line := fmt.Sprintf("%s %s %-*s  %-*s  %8s  %6d  %s", pointer, check,
+			nameWidth, truncate(name, nameWidth),
+			langWidth, truncate(item.Language, langWidth),
+			formatSize(item.SizeKB), item.Stars,
+			truncate(strings.Join(item.Topics, ", "), topicWidth))
+		line = truncate(line, m.width)
+
+		switch {
+		case row == m.cursor:
+			line = m.styles.cursor.Render(line)
+		case m.selected[i]:
+			line = m.styles.selected.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if len(m.visible) == 0 {
+		b.WriteString(m.styles.subtle.Render("  no repositories match") + "\n")
+	}
+
+	b.WriteString(m.styles.subtle.Render("type to search · ↑/↓ move · space/tab toggle · ctrl+a toggle all · enter confirm · esc cancel"))
+	return b.String()
+}
+
+// columnWidths sizes the name and language columns to their content and
+// gives the topics what is left of the terminal width.
+func (m *Model) columnWidths() (name, language, topics int) {
+	name, language = len("NAME"), len("LANGUAGE")
+	for _, i := range m.visible {
+		name = max(name, len([]rune(m.items[i].Name))+len(" (archived)"))
+		language = max(language, len([]rune(m.items[i].Language)))
+	}
+	name = min(name, 40)
+	language = min(language, 14)
+	topics = max(10, m.width-(6+name+2+language+2+8+2+6+2))
+	return name, language, topics
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if width <= 0 || len(r) <= width {
+		return s
+	}
+	if width == 1 {
+		return "…"
+	}
+	return string(r[:width-1]) + "…"
+}
+
+func formatSize(kb int64) string {
+	if kb <= 0 {
+		return "-"
+	}
+	const unit = 1024
+	if kb < unit {
+		return fmt.Sprintf("%d KB", kb)
+	}
+	if kb < unit*unit {
+		return fmt.Sprintf("%.1f MB", float64(kb)/unit)
+	}
+	return fmt.Sprintf("%.1f GB", float64(kb)/(unit*unit))
+}
+
+// Run shows the picker on the terminal and returns the confirmed
+// selection, or ErrCancelled.
+func Run(ctx context.Context, title string, items []Item, in io.Reader, out io.Writer) ([]Item, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	model := New(title, items)
+	program := tea.NewProgram(model,
+		tea.WithContext(ctx),
+		tea.WithInput(in),
+		tea.WithOutput(out),
+		tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("repository picker failed: %w", err)
+	}
+	if model.Cancelled() {
+		return nil, ErrCancelled
+	}
+	return model.Selected(), nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package repopicker
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testItems() []Item {
+	return []Item{
+		{Name: "legacy-pipeline", Language: "Python"},
+		{Name: "api-server", Language: "Go", Topics: []string{"backend"}},
+		{Name: "web", Language: "TypeScript", Topics: []string{"frontend"}},
+		{Name: "docs", Language: "Markdown"},
+	}
+}
+
+func typeQuery(m *Model, query string) {
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(query)})
+}
+
+func visibleNames(m *Model) []string {
+	names := make([]string, len(m.visible))
+	for i, idx := range m.visible {
+		names[i] = m.items[idx].Name
+	}
+	return names
+}
+
+func TestFuzzyScore(t *testing.T) {
+	_, ok := fuzzyScore("asv", "api-server")
+	assert.True(t, ok)
+	_, ok = fuzzyScore("xyz", "api-server")
+	assert.False(t, ok)
+
+	wordStart, _ := fuzzyScore("api", "api-server")
+	inner, _ := fuzzyScore("api", "legacy-pipeline")
+	assert.Greater(t, wordStart, inner)
+}
+
+func TestModelFilter(t *testing.T) {
+	m := New("test", testItems())
+	assert.Len(t, m.visible, 4)
+
+	typeQuery(m, "api")
+	assert.Equal(t, []string{"api-server", "legacy-pipeline"}, visibleNames(m))
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	typeQuery(m, "frontend")
+	assert.Equal(t, []string{"web"}, visibleNames(m), "topics are searched")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	typeQuery(m, "go back")
+	assert.Equal(t, []string{"api-server"}, visibleNames(m), "every term must match")
+
+	// esc는 먼저 검색어를 지운다
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Len(t, m.visible, 4)
+	assert.False(t, m.Cancelled())
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.NotNil(t, cmd)
+	assert.True(t, m.Cancelled())
+}
+
+func TestModelSelect(t *testing.T) {
+	m := New("test", testItems())
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	m.Update(tea.KeyMsg{Type: tea.KeySpace})
+	assert.Equal(t, []string{"docs"}, names(m.Selected()), "space toggles the item under the cursor")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	assert.Len(t, m.Selected(), 4)
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	assert.Empty(t, m.Selected())
+
+	typeQuery(m, "web")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, []string{"web"}, names(m.Selected()), "enter picks the cursor item when nothing is selected")
+	assert.False(t, m.Cancelled())
+	assert.Empty(t, m.View())
+}
+
+func TestModelView(t *testing.T) {
+	items := testItems()
+	items[1].SizeKB = 2048
+	m := New("Select repositories", items)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 10})
+
+	view := m.View()
+	assert.Contains(t, view, "Select repositories")
+	assert.Contains(t, view, "LANGUAGE")
+	assert.Contains(t, view, "2.0 MB")
+	assert.Contains(t, view, "backend")
+
+	typeQuery(m, "zzz")
+	assert.Contains(t, m.View(), "no repositories match")
+}
+
+func names(items []Item) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = item.Name
+	}
+	return out
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package syncstatus implements a live view of watched working trees and
+// how far each lags behind its mirror.
+package syncstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	gitsync "github.com/gizzahub/gzh-cli/internal/git/sync"
+	"github.com/gizzahub/gzh-cli/internal/tui/common"
+)
+
+// refreshInterval is how often the lag column is recomputed.
+const refreshInterval = time.Second
+
+type tickMsg time.Time
+
+// Model is the bubbletea model of the status view.
+type Model struct {
+	title   string
+	source  func() []gitsync.WatchStatus
+	syncNow func(path string)
+	now     func() time.Time
+
+	rows   []gitsync.WatchStatus
+	cursor int
+	width  int
+	quit   bool
+
+	styles statusStyles
+}
+
+type statusStyles struct {
+	title    lipgloss.Style
+	header   lipgloss.Style
+	cursor   lipgloss.Style
+	subtle   lipgloss.Style
+	idle     lipgloss.Style
+	pending  lipgloss.Style
+	conflict lipgloss.Style
+}
+
+// New creates a view over the statuses returned by source. syncNow, when
+// set, syncs the working tree under the cursor on demand.
+func New(title string, source func() []gitsync.WatchStatus, syncNow func(path string)) *Model {
+	theme := common.DefaultTheme()
+	m := &Model{
+		title:   title,
+		source:  source,
+		syncNow: syncNow,
+		now:     time.Now,
+		width:   100,
+		styles: statusStyles{
+			title:    lipgloss.NewStyle().Bold(true).Foreground(theme.Primary),
+			header:   lipgloss.NewStyle().Bold(true).Foreground(theme.Subtle),
+			cursor:   lipgloss.NewStyle().Bold(true).Foreground(theme.Highlight),
+			subtle:   lipgloss.NewStyle().Foreground(theme.Subtle),
+			idle:     lipgloss.NewStyle().Foreground(theme.Success),
+			pending:  lipgloss.NewStyle().Foreground(theme.Warning),
+			conflict: lipgloss.NewStyle().Foreground(theme.Error),
+		},
+	}
+	m.rows = source()
+	return m
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(refreshInterval, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+// Init implements tea.Model.
+func (m *Model) Init() tea.Cmd {
+	return tick()
+}
+
+// Update implements tea.Model.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case tickMsg:
+		m.rows = m.source()
+		return m, tick()
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			m.quit = true
+			return m, tea.Quit
+		case "up", "k":
+			m.cursor = max(0, m.cursor-1)
+		case "down", "j":
+			m.cursor = max(0, min(len(m.rows)-1, m.cursor+1))
+		case "s", "enter":
+			if m.syncNow != nil && m.cursor < len(m.rows) {
+				path := m.rows[m.cursor].Path
+				// 동기화는 네트워크를 타므로 UI 루프 밖에서 실행한다
+				return m, func() tea.Msg {
+					m.syncNow(path)
+					return nil
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// View implements tea.Model.
+func (m *Model) View() string {
+	if m.quit {
+		return ""
+	}
+	now := m.now()
+
+	var b strings.Builder
+	var pending, conflicts int
+	for _, s := range m.rows {
+		switch s.State {
+		case gitsync.WatchPending, gitsync.WatchSyncing:
+			pending++
+		case gitsync.WatchConflict, gitsync.WatchError:
+			conflicts++
+		}
+	}
+	fmt.Fprintf(&b, "%s  %s\n", m.styles.title.Render(m.title),
+		m.styles.subtle.Render(fmt.Sprintf("%d repositories, %d pending, %d need attention", len(m.rows), pending, conflicts)))
+
+	nameWidth := len("REPOSITORY")
+	for _, s := range m.rows {
+		nameWidth = max(nameWidth, len([]rune(filepath.Base(s.Path))))
+	}
+	nameWidth = min(nameWidth, 40)
+	header := fmt.Sprintf("  %-*s  %-8s  %8s  %10s  %-8s  %s", nameWidth, "REPOSITORY", "STATE", "LAG", "LAST SYNC", "COMMIT", "DETAIL")
+	b.WriteString(m.styles.header.Render(truncate(header, m.width)) + "\n")
+
+	for i, s := range m.rows {
+		pointer := " "
+		if i == m.cursor {
+			pointer = "▸"
+		}
+		line := fmt.Sprintf("%s %-*s  %-8s  %8s  %10s  %-8s  %s", pointer,
+			nameWidth, truncate(filepath.Base(s.Path), nameWidth),
+			s.State, formatLag(s.Lag(now)), formatAgo(now, s.LastSync), shortCommit(s.Commit), s.Error)
+		line = truncate(line, m.width)
+
+		switch {
+		case i == m.cursor:
+			line = m.styles.cursor.Render(line)
+		case s.State == gitsync.WatchConflict || s.State == gitsync.WatchError:
+			line = m.styles.conflict.Render(line)
+		case s.State == gitsync.WatchIdle:
+			line = m.styles.idle.Render(line)
+		default:
+			line = m.styles.pending.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	help := "↑/↓ move · q quit"
+	if m.syncNow != nil {
+		help = "↑/↓ move · s sync now · q quit"
+	}
+	b.WriteString(m.styles.subtle.Render(help))
+	return b.String()
+}
+
+func formatLag(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return d.Truncate(time.Second).String()
+}
+
+func formatAgo(now, t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return formatLag(now.Sub(t)) + " ago"
+}
+
+func shortCommit(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if width <= 0 || len(r) <= width {
+		return s
+	}
+	if width == 1 {
+		return "…"
+	}
+	return string(r[:width-1]) + "…"
+}
+
+// Run shows the status view until the user quits or ctx is done.
+func Run(ctx context.Context, title string, source func() []gitsync.WatchStatus, syncNow func(path string), in io.Reader, out io.Writer) error {
+	program := tea.NewProgram(New(title, source, syncNow),
+		tea.WithContext(ctx),
+		tea.WithInput(in),
+		tea.WithOutput(out),
+		tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("sync status view failed: %w", err)
+	}
+	return nil
+}
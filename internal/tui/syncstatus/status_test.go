@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package syncstatus
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	gitsync "github.com/gizzahub/gzh-cli/internal/git/sync"
+)
+
+func TestModelView(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	statuses := []gitsync.WatchStatus{
+		{Path: "/src/api", State: gitsync.WatchIdle, LastSync: now.Add(-90 * time.Second), Commit: "0123456789abcdef"},
+		{Path: "/src/web", State: gitsync.WatchPending, Changed: now.Add(-5 * time.Second)},
+		{Path: "/src/docs", State: gitsync.WatchConflict, Error: "sync conflict: unmerged paths in the working tree"},
+	}
+	var synced []string
+	m := New("gz sync watch", func() []gitsync.WatchStatus { return statuses }, func(p string) { synced = append(synced, p) })
+	m.now = func() time.Time { return now }
+	m.width = 200
+
+	view := m.View()
+	assert.Contains(t, view, "3 repositories, 1 pending, 1 need attention")
+	assert.Contains(t, view, "1m30s ago")
+	assert.Contains(t, view, "01234567")
+	assert.Contains(t, view, "5s")
+	assert.Contains(t, view, "never")
+	assert.Contains(t, view, "unmerged paths")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	cmd()
+	assert.Equal(t, []string{"/src/web"}, synced)
+
+	statuses[1].State = gitsync.WatchIdle
+	m.Update(tickMsg(now))
+	assert.Contains(t, m.View(), "0 pending")
+}
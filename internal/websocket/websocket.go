@@ -0,0 +1,245 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package websocket implements the small subset of RFC 6455 that gz's event
+// and log streams need: the server sends text frames and reads client frames
+// to answer pings, notice when the client goes away and receive short text
+// messages such as filter updates.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // RFC 6455 mandates SHA-1 for the handshake
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xA
+)
+
+const (
+	// maxControlPayload is the largest payload RFC 6455 allows for control
+	// frames.
+	maxControlPayload = 125
+	// MaxMessageSize is the largest client text message accepted; longer or
+	// fragmented messages end the connection.
+	MaxMessageSize = 4096
+)
+
+// ErrUnexpectedMessage is returned by ReadLoop when the client sends a
+// message the caller does not accept.
+var ErrUnexpectedMessage = errors.New("unexpected websocket message")
+
+// IsUpgrade reports whether r asks for a WebSocket connection.
+func IsUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		headerContainsToken(r.Header, "Connection", "upgrade")
+}
+
+func headerContainsToken(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AcceptKey computes the Sec-WebSocket-Accept value for a key.
+func AcceptKey(key string) string {
+	h := sha1.New() //nolint:gosec // see import
+	h.Write([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// Conn is a server side WebSocket connection.
+type Conn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+	mu   sync.Mutex // serializes writes
+}
+
+// ErrCrossOrigin is returned by Upgrade for handshakes from a foreign
+// origin.
+var ErrCrossOrigin = errors.New("cross-origin websocket handshake")
+
+// SameOrigin reports whether the Origin header of r is absent, names the
+// host r was sent to, or is one of allowed (e.g. "https://ops.example.com").
+// Browsers always send Origin with WebSocket handshakes, so this keeps web
+// pages from reading streams of a server on the user's machine.
+func SameOrigin(r *http.Request, allowed ...string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// 브라우저가 아닌 클라이언트는 Origin을 보내지 않는다
+		return true
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(strings.TrimSuffix(a, "/"), origin) {
+			return true
+		}
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// Upgrade completes the opening handshake. Handshakes whose Origin is
+// neither the requested host nor one of allowedOrigins are refused with
+// 403. On failure it has already written an HTTP error response.
+func Upgrade(w http.ResponseWriter, r *http.Request, allowedOrigins ...string) (*Conn, error) {
+	if !SameOrigin(r, allowedOrigins...) {
+		http.Error(w, "cross-origin websocket handshake refused", http.StatusForbidden)
+		return nil, ErrCrossOrigin
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || key == "" || r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket handshake", http.StatusBadRequest)
+		return nil, errors.New("bad websocket handshake")
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return nil, errors.New("response writer cannot be hijacked")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hijack connection: %w", err)
+	}
+
+	fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\n"+
+		"Upgrade: websocket\r\n"+
+		"Connection: Upgrade\r\n"+
+		"Sec-WebSocket-Accept: %s\r\n\r\n", AcceptKey(key))
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &Conn{conn: conn, rw: rw}, nil
+}
+
+// WriteText sends data as a single text frame.
+func (c *Conn) WriteText(data []byte) error {
+	return c.writeFrame(opText, data)
+}
+
+// Close sends a normal closure frame and closes the connection.
+func (c *Conn) Close() error {
+	_ = c.writeFrame(opClose, []byte{0x03, 0xE8}) // 1000: normal closure
+	return c.conn.Close()
+}
+
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(n))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(n))
+	}
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(payload); err != nil {
+		return err
+	}
+	return c.rw.Flush()
+}
+
+// ReadLoop consumes client frames until the client closes the connection,
+// answering pings. Text messages are passed to onText; when onText is nil or
+// returns an error, the loop ends.
+func (c *Conn) ReadLoop(onText func([]byte) error) error {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return err
+		}
+		switch opcode {
+		case opClose:
+			return io.EOF
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return err
+			}
+		case opPong:
+		case opText:
+			if onText == nil {
+				return ErrUnexpectedMessage
+			}
+			if err := onText(payload); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: opcode %#x", ErrUnexpectedMessage, opcode)
+		}
+	}
+}
+
+// readFrame reads one masked, unfragmented client frame.
+func (c *Conn) readFrame() (byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.rw, head[:]); err != nil {
+		return 0, nil, err
+	}
+	final := head[0]&0x80 != 0
+	opcode := head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+	length := int(head[1] & 0x7F)
+	if length == 126 {
+		var ext [2]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+
+	limit := maxControlPayload
+	if opcode == opText {
+		limit = MaxMessageSize
+	}
+	if !masked || !final || length == 127 || length > limit {
+		return 0, nil, errors.New("invalid client websocket frame")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.rw, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.rw, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSameOrigin(t *testing.T) {
+	for origin, want := range map[string]bool{
+		"":                          true,
+		"http://127.0.0.1:8080":     true,
+		"https://127.0.0.1:8080":    true,
+		"http://127.0.0.1:9090":     false,
+		"http://localhost:8080":     false,
+		"https://evil.example.com":  false,
+		"null":                      false,
+		"file:///tmp/page.html":     false,
+		"https://ops.example.com":   true,
+		"https://ops.example.com/":  false,
+		"https://ops.example.com.x": false,
+	} {
+		r := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/events", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		assert.Equal(t, want, SameOrigin(r, "https://ops.example.com/"), origin)
+	}
+}
+
+func TestUpgradeRejectsCrossOrigin(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/events", nil)
+	r.Header.Set("Origin", "https://evil.example.com")
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set("Connection", "Upgrade")
+	r.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	r.Header.Set("Sec-WebSocket-Version", "13")
+	w := httptest.NewRecorder()
+
+	_, err := Upgrade(w, r)
+	assert.ErrorIs(t, err, ErrCrossOrigin)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workerpool
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+	"github.com/gizzahub/gzh-cli/pkg/memory"
+)
+
+// AutoscaleConfig enables autoscaling of a pool's active workers. Zero
+// values select defaults.
+type AutoscaleConfig struct {
+	// MinWorkers and MaxWorkers bound the active workers. Defaults are 1 and
+	// 4 × runtime.NumCPU().
+	MinWorkers int
+	MaxWorkers int
+	// Interval between scaling decisions. Defaults to 2s.
+	Interval time.Duration
+	// ScaleUpAfter is the number of consecutive healthy intervals with queued
+	// jobs before workers are added. Defaults to 3.
+	ScaleUpAfter int
+	// ScaleDownAfter is the number of consecutive intervals under pressure
+	// before workers are removed. Defaults to 1.
+	ScaleDownAfter int
+
+	// LowHeadroom shrinks the pool when less than this fraction of the rate
+	// limit remains. Defaults to 0.1.
+	LowHeadroom float64
+	// HighHeadroom is the fraction of the rate limit that must remain before
+	// the pool grows. Defaults to 0.25.
+	HighHeadroom float64
+	// MaxErrorRate shrinks the pool when more jobs than this fraction fail
+	// within an interval. Growing requires half of it. Defaults to 0.2.
+	MaxErrorRate float64
+	// MaxMemoryPressure shrinks the pool when the live heap exceeds this
+	// fraction of the memory limit. Growing requires 0.1 less. Defaults to 0.85.
+	MaxMemoryPressure float64
+
+	// RateLimitHeadroom reports the remaining fraction of the rate limit, or
+	// ok=false when unknown. ProviderRateLimit builds one from the metrics
+	// recorded by provider clients. Without it, the rate limit is ignored.
+	RateLimitHeadroom func() (headroom float64, ok bool)
+	// MemoryPressure reports the live heap relative to the memory limit.
+	// Defaults to memory.Pressure.
+	MemoryPressure func() float64
+}
+
+// ProviderRateLimit returns a RateLimitHeadroom source that reads the rate
+// limit last recorded for provider.
+func ProviderRateLimit(provider string) func() (float64, bool) {
+	return func() (float64, bool) {
+		return metrics.RateLimitHeadroom(provider)
+	}
+}
+
+func (c AutoscaleConfig) withDefaults() AutoscaleConfig {
+	if c.MinWorkers <= 0 {
+		c.MinWorkers = 1
+	}
+	if c.MaxWorkers <= 0 {
+		c.MaxWorkers = 4 * runtime.NumCPU()
+	}
+	c.MaxWorkers = max(c.MaxWorkers, c.MinWorkers)
+	if c.Interval <= 0 {
+		c.Interval = 2 * time.Second
+	}
+	if c.ScaleUpAfter <= 0 {
+		c.ScaleUpAfter = 3
+	}
+	if c.ScaleDownAfter <= 0 {
+		c.ScaleDownAfter = 1
+	}
+	if c.LowHeadroom <= 0 {
+		c.LowHeadroom = 0.1
+	}
+	if c.HighHeadroom <= c.LowHeadroom {
+		c.HighHeadroom = max(0.25, c.LowHeadroom*2)
+	}
+	if c.MaxErrorRate <= 0 {
+		c.MaxErrorRate = 0.2
+	}
+	if c.MaxMemoryPressure <= 0 {
+		c.MaxMemoryPressure = 0.85
+	}
+	if c.MemoryPressure == nil {
+		c.MemoryPressure = memory.Pressure
+	}
+	return c
+}
+
+// ScaleSignals are the inputs of one scaling decision.
+type ScaleSignals struct {
+	// RateLimitHeadroom is the remaining fraction of the rate limit, or -1
+	// when unknown.
+	RateLimitHeadroom float64
+	// ErrorRate is the fraction of jobs that failed during the interval.
+	ErrorRate float64
+	// MemoryPressure is the live heap relative to the memory limit, 0 if unknown.
+	MemoryPressure float64
+	// Backlog is the number of queued jobs.
+	Backlog int
+}
+
+// Autoscaler adjusts the active workers of a pool between MinWorkers and
+// MaxWorkers. Under rate limit, error or memory pressure it removes a quarter
+// of the workers; while all signals are comfortably healthy and jobs are
+// queued it adds a quarter. The gap between the shrink and grow thresholds
+// and the consecutive interval counts keep it from oscillating.
+type Autoscaler struct {
+	config AutoscaleConfig
+	gate   *gate
+
+	completed atomic.Int64
+	failed    atomic.Int64
+
+	upStreak   int
+	downStreak int
+}
+
+func newAutoscaler(config AutoscaleConfig, initial int) *Autoscaler {
+	config = config.withDefaults()
+	initial = max(config.MinWorkers, min(initial, config.MaxWorkers))
+	return &Autoscaler{config: config, gate: newGate(initial)}
+}
+
+// Workers returns the current number of active workers.
+func (a *Autoscaler) Workers() int {
+	return a.gate.Limit()
+}
+
+// record counts a finished job for the error rate.
+func (a *Autoscaler) record(err error) {
+	a.completed.Add(1)
+	if err != nil {
+		a.failed.Add(1)
+	}
+}
+
+// signals gathers the inputs for the interval that just ended and resets the
+// job counters.
+func (a *Autoscaler) signals(backlog int) ScaleSignals {
+	s := ScaleSignals{RateLimitHeadroom: -1, Backlog: backlog, MemoryPressure: a.config.MemoryPressure()}
+	if a.config.RateLimitHeadroom != nil {
+		if headroom, ok := a.config.RateLimitHeadroom(); ok {
+			s.RateLimitHeadroom = headroom
+		}
+	}
+	if completed := a.completed.Swap(0); completed > 0 {
+		s.ErrorRate = float64(a.failed.Swap(0)) / float64(completed)
+	} else {
+		a.failed.Store(0)
+	}
+	return s
+}
+
+// Observe applies one scaling decision and returns the new worker count.
+func (a *Autoscaler) Observe(s ScaleSignals) int {
+	c := a.config
+	current := a.gate.Limit()
+
+	pressured := (s.RateLimitHeadroom >= 0 && s.RateLimitHeadroom < c.LowHeadroom) ||
+		s.ErrorRate > c.MaxErrorRate ||
+		s.MemoryPressure > c.MaxMemoryPressure
+	healthy := (s.RateLimitHeadroom < 0 || s.RateLimitHeadroom >= c.HighHeadroom) &&
+		s.ErrorRate <= c.MaxErrorRate/2 &&
+		s.MemoryPressure <= c.MaxMemoryPressure-0.1
+
+	next := current
+	switch {
+	case pressured:
+		a.upStreak = 0
+		a.downStreak++
+		if a.downStreak >= c.ScaleDownAfter {
+			next = max(c.MinWorkers, current-max(1, current/4))
+			a.downStreak = 0
+		}
+	case healthy && s.Backlog > 0:
+		a.downStreak = 0
+		a.upStreak++
+		if a.upStreak >= c.ScaleUpAfter {
+			next = min(c.MaxWorkers, current+max(1, current/4))
+			a.upStreak = 0
+		}
+	default:
+		a.upStreak, a.downStreak = 0, 0
+	}
+
+	if next != current {
+		a.gate.SetLimit(next)
+	}
+	return next
+}
+
+// run makes a decision every interval until ctx is done.
+func (a *Autoscaler) run(ctx context.Context, backlog func() int, resized func(int)) {
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			before := a.Workers()
+			if after := a.Observe(a.signals(backlog())); after != before {
+				resized(after)
+			}
+		}
+	}
+}
+
+// gate is a semaphore whose capacity can change while it is in use.
+type gate struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	limit   int
+	inUse   int
+	waiting int
+}
+
+func newGate(limit int) *gate {
+	g := &gate{limit: limit}
+	g.cond = sync.NewCond(&g.mu)
+	return g
+}
+
+// Acquire blocks until a slot is free.
+func (g *gate) Acquire() {
+	g.mu.Lock()
+	g.waiting++
+	for g.inUse >= g.limit {
+		g.cond.Wait()
+	}
+	g.waiting--
+	g.inUse++
+	g.mu.Unlock()
+}
+
+// Release frees a slot.
+func (g *gate) Release() {
+	g.mu.Lock()
+	g.inUse--
+	g.mu.Unlock()
+	g.cond.Signal()
+}
+
+// Waiting returns the number of callers blocked in Acquire.
+func (g *gate) Waiting() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.waiting
+}
+
+// Limit returns the capacity.
+func (g *gate) Limit() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.limit
+}
+
+// SetLimit changes the capacity. Holders above a lowered limit finish their
+// current job; no new slots are handed out until usage drops below it.
+func (g *gate) SetLimit(limit int) {
+	g.mu.Lock()
+	g.limit = limit
+	g.mu.Unlock()
+	g.cond.Broadcast()
+}
@@ -0,0 +1,135 @@
+//nolint:testpackage // White-box testing needed for internal function access
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noMemoryPressure() float64 { return 0 }
+
+func TestAutoscaler_ScalesUpAfterHealthyStreak(t *testing.T) {
+	a := newAutoscaler(AutoscaleConfig{MinWorkers: 2, MaxWorkers: 10, ScaleUpAfter: 2, MemoryPressure: noMemoryPressure}, 4)
+	healthy := ScaleSignals{RateLimitHeadroom: 0.9, Backlog: 5}
+
+	assert.Equal(t, 4, a.Observe(healthy), "first healthy interval only builds the streak")
+	assert.Equal(t, 5, a.Observe(healthy))
+	assert.Equal(t, 5, a.Observe(healthy))
+	assert.Equal(t, 6, a.Observe(healthy))
+}
+
+func TestAutoscaler_NoGrowthWithoutBacklog(t *testing.T) {
+	a := newAutoscaler(AutoscaleConfig{MaxWorkers: 10, ScaleUpAfter: 1, MemoryPressure: noMemoryPressure}, 4)
+	for range 5 {
+		assert.Equal(t, 4, a.Observe(ScaleSignals{RateLimitHeadroom: -1}))
+	}
+}
+
+func TestAutoscaler_ScalesDownUnderPressure(t *testing.T) {
+	tests := []struct {
+		name    string
+		signals ScaleSignals
+	}{
+		{name: "rate limit", signals: ScaleSignals{RateLimitHeadroom: 0.05, Backlog: 10}},
+		{name: "errors", signals: ScaleSignals{RateLimitHeadroom: -1, ErrorRate: 0.5, Backlog: 10}},
+		{name: "memory", signals: ScaleSignals{RateLimitHeadroom: -1, MemoryPressure: 0.95, Backlog: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAutoscaler(AutoscaleConfig{MinWorkers: 2, MaxWorkers: 16, MemoryPressure: noMemoryPressure}, 8)
+			assert.Equal(t, 6, a.Observe(tt.signals))
+			assert.Equal(t, 5, a.Observe(tt.signals))
+			assert.Equal(t, 4, a.Observe(tt.signals))
+			assert.Equal(t, 3, a.Observe(tt.signals))
+			assert.Equal(t, 2, a.Observe(tt.signals))
+			assert.Equal(t, 2, a.Observe(tt.signals), "never below MinWorkers")
+		})
+	}
+}
+
+func TestAutoscaler_HysteresisBandHolds(t *testing.T) {
+	a := newAutoscaler(AutoscaleConfig{MinWorkers: 1, MaxWorkers: 10, ScaleUpAfter: 1, MemoryPressure: noMemoryPressure}, 4)
+
+	// Between LowHeadroom (0.1) and HighHeadroom (0.25): neither shrink nor grow.
+	for range 5 {
+		assert.Equal(t, 4, a.Observe(ScaleSignals{RateLimitHeadroom: 0.15, Backlog: 10}))
+	}
+	// Error rate between half of MaxErrorRate and MaxErrorRate also holds.
+	assert.Equal(t, 4, a.Observe(ScaleSignals{RateLimitHeadroom: -1, ErrorRate: 0.15, Backlog: 10}))
+	// A pressured interval resets the upscale streak.
+	assert.Equal(t, 5, a.Observe(ScaleSignals{RateLimitHeadroom: 0.5, Backlog: 10}))
+	assert.Equal(t, 4, a.Observe(ScaleSignals{RateLimitHeadroom: 0.01, Backlog: 10}))
+}
+
+func TestAutoscaler_Signals(t *testing.T) {
+	a := newAutoscaler(AutoscaleConfig{
+		MemoryPressure:    func() float64 { return 0.4 },
+		RateLimitHeadroom: func() (float64, bool) { return 0.3, true },
+	}, 2)
+	a.record(nil)
+	a.record(errors.New("boom"))
+	a.record(nil)
+	a.record(errors.New("boom"))
+
+	s := a.signals(7)
+	assert.InDelta(t, 0.5, s.ErrorRate, 0.001)
+	assert.InDelta(t, 0.3, s.RateLimitHeadroom, 0.001)
+	assert.InDelta(t, 0.4, s.MemoryPressure, 0.001)
+	assert.Equal(t, 7, s.Backlog)
+
+	assert.Zero(t, a.signals(0).ErrorRate, "counters reset after each interval")
+}
+
+func TestPool_AutoscaleLimitsConcurrency(t *testing.T) {
+	pool := New[int](WorkerPoolConfig{
+		WorkerCount: 2,
+		BufferSize:  20,
+		Timeout:     time.Second,
+		Autoscale: &AutoscaleConfig{
+			MinWorkers:     1,
+			MaxWorkers:     8,
+			Interval:       time.Hour, // decisions are driven manually
+			MemoryPressure: noMemoryPressure,
+		},
+	})
+	require.NoError(t, pool.Start())
+	defer pool.Stop()
+	assert.Equal(t, 2, pool.Workers())
+
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	job := func(ctx context.Context, _ int) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-release
+		running.Add(-1)
+		return nil
+	}
+	for i := range 6 {
+		require.NoError(t, pool.Submit(i, job))
+	}
+
+	require.Eventually(t, func() bool { return running.Load() == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 4, pool.scaler.gate.Waiting(), "idle workers wait at the gate")
+
+	pool.scaler.gate.SetLimit(4)
+	require.Eventually(t, func() bool { return running.Load() == 4 }, time.Second, 5*time.Millisecond)
+
+	close(release)
+	for range 6 {
+		<-pool.Results()
+	}
+	assert.Equal(t, int32(4), peak.Load())
+}
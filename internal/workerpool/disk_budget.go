@@ -0,0 +1,266 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrDiskBudgetExceeded is returned when a job can never fit in the disk budget,
+// even with no other clones in flight.
+var ErrDiskBudgetExceeded = errors.New("disk budget exceeded")
+
+// errDiskStatsUnsupported is returned by diskStats on platforms without a
+// free-space query; usage thresholds are not enforced there.
+var errDiskStatsUnsupported = errors.New("disk statistics not supported on this platform")
+
+// DiskBudgetConfig configures a DiskBudget. Zero values disable the
+// corresponding limit.
+type DiskBudgetConfig struct {
+	// MaxUsagePercent is the highest filesystem usage (0-100) a clone may push
+	// the target filesystem to.
+	MaxUsagePercent float64
+	// MaxBytes caps the bytes reserved at once under a single target path.
+	MaxBytes int64
+	// SizeMultiplier scales provider-reported sizes to account for the working
+	// tree checked out next to the packed history. Defaults to 2.
+	SizeMultiplier float64
+	// PollInterval is how often a queued job re-checks free space. Defaults to 2s.
+	PollInterval time.Duration
+}
+
+// DiskBudget schedules clones against the free space of their target
+// filesystem. Each clone reserves its estimated size under its target path
+// before running; jobs that do not fit wait until earlier clones release
+// their reservation or free space grows, and jobs that could never fit are
+// refused with ErrDiskBudgetExceeded.
+type DiskBudget struct {
+	config DiskBudgetConfig
+
+	mu       sync.Mutex
+	reserved map[string]int64
+	changed  chan struct{}
+
+	// diskStats is swapped out in tests.
+	diskStats func(path string) (free, total uint64, err error)
+}
+
+// NewDiskBudget creates a disk budget with the given configuration.
+func NewDiskBudget(config DiskBudgetConfig) *DiskBudget {
+	if config.SizeMultiplier <= 0 {
+		config.SizeMultiplier = 2
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 2 * time.Second
+	}
+
+	return &DiskBudget{
+		config:    config,
+		reserved:  make(map[string]int64),
+		changed:   make(chan struct{}),
+		diskStats: diskStats,
+	}
+}
+
+// ParseDiskBudget parses a --max-disk-usage value. A percentage such as "90%"
+// limits filesystem usage; a size such as "50GB" caps the bytes reserved under
+// a target path. An empty value returns nil (no budget).
+func ParseDiskBudget(value string) (*DiskBudget, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil //nolint:nilnil // nil budget means unlimited
+	}
+
+	if pct, ok := strings.CutSuffix(value, "%"); ok {
+		p, err := strconv.ParseFloat(strings.TrimSpace(pct), 64)
+		if err != nil || p <= 0 || p > 100 {
+			return nil, fmt.Errorf("invalid disk usage percentage %q: must be between 0 and 100", value)
+		}
+		return NewDiskBudget(DiskBudgetConfig{MaxUsagePercent: p}), nil
+	}
+
+	size, err := ParseByteSize(value)
+	if err != nil {
+		return nil, err
+	}
+	return NewDiskBudget(DiskBudgetConfig{MaxBytes: size}), nil
+}
+
+// ParseByteSize parses sizes such as "512MB", "2GB" or "1.5TiB". Units are
+// binary (1KB = 1024 bytes); a bare number is taken as bytes.
+func ParseByteSize(value string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	s = strings.Replace(s, "IB", "B", 1)
+
+	units := []struct {
+		suffix string
+		mult   float64
+	}{
+		{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+		{"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1},
+	}
+
+	mult := 1.0
+	for _, u := range units {
+		if num, ok := strings.CutSuffix(s, u.suffix); ok {
+			s, mult = num, u.mult
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+	return int64(n * mult), nil
+}
+
+// Estimate converts a provider-reported repository size into the bytes to
+// reserve for a clone.
+func (b *DiskBudget) Estimate(reportedBytes int64) int64 {
+	if reportedBytes <= 0 {
+		return 0
+	}
+	return int64(float64(reportedBytes) * b.config.SizeMultiplier)
+}
+
+// Reserved returns the bytes currently reserved under targetPath.
+func (b *DiskBudget) Reserved(targetPath string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.reserved[accountKey(targetPath)]
+}
+
+// Reserve blocks until size bytes fit under targetPath, then records the
+// reservation. The returned release function must be called once the clone
+// finishes, successfully or not. Unknown sizes (0) still wait for the usage
+// threshold but reserve nothing.
+func (b *DiskBudget) Reserve(ctx context.Context, targetPath string, size int64) (func(), error) {
+	key := accountKey(targetPath)
+
+	for {
+		b.mu.Lock()
+		fits, err := b.fitsLocked(key, size)
+		if err != nil {
+			b.mu.Unlock()
+			return nil, err
+		}
+		if fits {
+			b.reserved[key] += size
+			b.mu.Unlock()
+			return b.releaseFunc(key, size), nil
+		}
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		case <-time.After(b.config.PollInterval):
+		}
+	}
+}
+
+func (b *DiskBudget) releaseFunc(key string, size int64) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			b.reserved[key] -= size
+			if b.reserved[key] <= 0 {
+				delete(b.reserved, key)
+			}
+			close(b.changed)
+			b.changed = make(chan struct{})
+			b.mu.Unlock()
+		})
+	}
+}
+
+// fitsLocked reports whether size bytes fit under key right now. It returns
+// ErrDiskBudgetExceeded when the job could not fit even with nothing else
+// reserved, so the caller refuses it instead of queueing forever.
+func (b *DiskBudget) fitsLocked(key string, size int64) (bool, error) {
+	reserved := b.reserved[key]
+
+	if b.config.MaxBytes > 0 {
+		if size > b.config.MaxBytes {
+			return false, fmt.Errorf("%w: estimated %s exceeds the %s limit for %s",
+				ErrDiskBudgetExceeded, formatBytes(size), formatBytes(b.config.MaxBytes), key)
+		}
+		if reserved+size > b.config.MaxBytes {
+			return false, nil
+		}
+	}
+
+	if b.config.MaxUsagePercent > 0 {
+		free, total, err := b.diskStats(existingAncestor(key))
+		if errors.Is(err, errDiskStatsUnsupported) || total == 0 {
+			return true, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to query free space for %s: %w", key, err)
+		}
+
+		allowed := int64(float64(total) * b.config.MaxUsagePercent / 100)
+		used := int64(total - free)
+		// In-flight reservations have not been written yet, so count them as used.
+		if used+size > allowed && reserved == 0 {
+			return false, fmt.Errorf("%w: %s would use %s of %s with %s free (limit %.0f%%)",
+				ErrDiskBudgetExceeded, key, formatBytes(used+size), formatBytes(int64(total)),
+				formatBytes(int64(free)), b.config.MaxUsagePercent)
+		}
+		if used+reserved+size > allowed {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// accountKey normalizes a target path so reservations for the same target
+// share one account.
+func accountKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return filepath.Clean(abs)
+	}
+	return filepath.Clean(path)
+}
+
+// existingAncestor returns the nearest existing directory, since clone targets
+// usually do not exist until the clone starts.
+func existingAncestor(path string) string {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
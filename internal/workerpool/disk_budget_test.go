@@ -0,0 +1,116 @@
+//nolint:testpackage // White-box testing needed for internal function access
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiskBudget(t *testing.T) {
+	budget, err := ParseDiskBudget("90%")
+	require.NoError(t, err)
+	assert.InDelta(t, 90, budget.config.MaxUsagePercent, 0.001)
+
+	budget, err = ParseDiskBudget("1.5GB")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1.5*(1<<30)), budget.config.MaxBytes)
+
+	budget, err = ParseDiskBudget("")
+	require.NoError(t, err)
+	assert.Nil(t, budget)
+
+	for _, bad := range []string{"150%", "0%", "abc", "-1GB"} {
+		_, err := ParseDiskBudget(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestDiskBudget_MaxBytesQueuesAndRefuses(t *testing.T) {
+	budget := NewDiskBudget(DiskBudgetConfig{MaxBytes: 100, PollInterval: time.Hour})
+	ctx := context.Background()
+	target := t.TempDir()
+
+	release, err := budget.Reserve(ctx, target, 60)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), budget.Reserved(target))
+
+	_, err = budget.Reserve(ctx, target, 200)
+	assert.True(t, errors.Is(err, ErrDiskBudgetExceeded))
+
+	// A second 60-byte job must wait for the first to release.
+	acquired := make(chan func())
+	go func() {
+		r, err := budget.Reserve(ctx, target, 60)
+		assert.NoError(t, err)
+		acquired <- r
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("reservation should be queued while the budget is exhausted")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release()
+	select {
+	case r := <-acquired:
+		r()
+	case <-time.After(time.Second):
+		t.Fatal("queued reservation was not granted after release")
+	}
+	assert.Zero(t, budget.Reserved(target))
+}
+
+func TestDiskBudget_UsagePercent(t *testing.T) {
+	budget := NewDiskBudget(DiskBudgetConfig{MaxUsagePercent: 80, PollInterval: 10 * time.Millisecond})
+	budget.diskStats = func(string) (uint64, uint64, error) {
+		return 300, 1000, nil // 70% used
+	}
+	target := t.TempDir()
+
+	release, err := budget.Reserve(context.Background(), target, 50)
+	require.NoError(t, err)
+
+	// 70% used + 50 reserved + 100 requested exceeds 80%: the job queues.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = budget.Reserve(ctx, target, 100)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+
+	// With nothing in flight the job can never fit and is refused.
+	_, err = budget.Reserve(context.Background(), target, 200)
+	assert.ErrorIs(t, err, ErrDiskBudgetExceeded)
+}
+
+func TestRepositoryWorkerPool_DiskBudgetRefusesClone(t *testing.T) {
+	config := DefaultRepositoryPoolConfig()
+	config.CloneWorkers = 1
+	config.RetryAttempts = 0
+	config.DiskBudget = NewDiskBudget(DiskBudgetConfig{MaxBytes: 1024, SizeMultiplier: 1})
+
+	pool := NewRepositoryWorkerPool(config)
+	require.NoError(t, pool.Start())
+	defer pool.Stop()
+
+	called := false
+	results, err := pool.ProcessRepositories(context.Background(), []RepositoryJob{{
+		Repository:    "huge",
+		Operation:     OperationClone,
+		Path:          t.TempDir() + "/huge",
+		EstimatedSize: 4096,
+	}}, func(context.Context, RepositoryJob) error {
+		called = true
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, called)
+	assert.ErrorIs(t, results[0].Error, ErrDiskBudgetExceeded)
+}
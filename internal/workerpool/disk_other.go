@@ -0,0 +1,10 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build !unix && !windows
+
+package workerpool
+
+func diskStats(string) (free, total uint64, err error) {
+	return 0, 0, errDiskStatsUnsupported
+}
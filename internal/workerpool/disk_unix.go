@@ -0,0 +1,21 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build unix
+
+package workerpool
+
+import "syscall"
+
+// diskStats returns the bytes available to unprivileged users and the total
+// size of the filesystem containing path.
+func diskStats(path string) (free, total uint64, err error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, 0, err
+	}
+
+	// Field types differ between platforms, hence the conversions.
+	bsize := uint64(st.Bsize)                                        //nolint:gosec,unconvert
+	return uint64(st.Bavail) * bsize, uint64(st.Blocks) * bsize, nil //nolint:unconvert
+}
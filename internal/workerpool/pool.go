@@ -9,6 +9,8 @@ import (
 	"runtime"
 	"sync"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/metrics"
 )
 
 // WorkerPoolConfig represents worker pool configuration.
@@ -19,6 +21,14 @@ type WorkerPoolConfig struct {
 	BufferSize int
 	// Timeout specifies the maximum time to wait for a job to complete
 	Timeout time.Duration
+	// Name labels the pool in exported metrics. If empty, defaults to "default"
+	Name string
+	// Autoscale, when set, adjusts the active workers at runtime. WorkerCount
+	// is then the initial count, clamped to the autoscaler's bounds.
+	Autoscale *AutoscaleConfig
+	// Preemption, when set, lets interactive jobs preempt long running
+	// background jobs while all workers are busy.
+	Preemption *PreemptionConfig
 }
 
 // DefaultConfig returns a sensible default configuration.
@@ -34,6 +44,11 @@ func DefaultWorkerPoolConfig() WorkerPoolConfig {
 type Job[T any] struct {
 	Data T
 	Fn   func(context.Context, T) error
+
+	class       Class
+	group       string
+	enqueued    time.Time
+	preemptions int
 }
 
 // Result represents the result of processing a job.
@@ -45,13 +60,15 @@ type Result[T any] struct {
 // Pool represents a generic worker pool.
 type Pool[T any] struct {
 	config  WorkerPoolConfig
-	jobs    chan Job[T]
+	queue   *jobQueue[T]
+	sched   *scheduler
 	results chan Result[T]
 	wg      sync.WaitGroup
 	ctx     context.Context
 	cancel  context.CancelFunc
 	started bool
 	mu      sync.RWMutex
+	scaler  *Autoscaler
 }
 
 // New creates a new worker pool with the given configuration.
@@ -68,15 +85,37 @@ func New[T any](config WorkerPoolConfig) *Pool[T] {
 		config.Timeout = 30 * time.Second
 	}
 
+	if config.Name == "" {
+		config.Name = "default"
+	}
+
+	// With autoscaling, MaxWorkers goroutines run and a gate limits how many
+	// of them execute jobs at once.
+	var scaler *Autoscaler
+	if config.Autoscale != nil {
+		scaler = newAutoscaler(*config.Autoscale, config.WorkerCount)
+		config.WorkerCount = scaler.config.MaxWorkers
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &Pool[T]{
 		config:  config,
-		jobs:    make(chan Job[T], config.BufferSize),
+		queue:   newJobQueue[T](config.BufferSize),
+		sched:   newScheduler(config.Name, config.Preemption),
 		results: make(chan Result[T], config.BufferSize),
 		ctx:     ctx,
 		cancel:  cancel,
+		scaler:  scaler,
+	}
+}
+
+// Workers returns the number of workers currently allowed to run jobs.
+func (p *Pool[T]) Workers() int {
+	if p.scaler != nil {
+		return p.scaler.Workers()
 	}
+	return p.config.WorkerCount
 }
 
 // Start initializes and starts the worker pool.
@@ -96,27 +135,53 @@ func (p *Pool[T]) Start() error {
 	}
 
 	p.started = true
+	size := metrics.WorkerPoolSize.With(p.config.Name)
+	size.Set(float64(p.Workers()))
+
+	if p.scaler != nil {
+		// Idle workers take jobs off the queue and wait at the gate, so both
+		// count as backlog.
+		backlog := func() int { return p.queue.len() + p.scaler.gate.Waiting() }
+		go p.scaler.run(p.ctx, backlog, func(n int) { size.Set(float64(n)) })
+	}
 
 	return nil
 }
 
-// Submit submits a job to the worker pool.
+// Submit submits an interactive job to the worker pool.
 func (p *Pool[T]) Submit(data T, fn func(context.Context, T) error) error {
+	return p.SubmitWithOptions(data, fn, SubmitOptions{Class: ClassInteractive})
+}
+
+// SubmitWithOptions submits a job with the given priority class and
+// fairness group.
+func (p *Pool[T]) SubmitWithOptions(data T, fn func(context.Context, T) error, opts SubmitOptions) error {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
 	if !p.started {
 		return fmt.Errorf("worker pool not started")
 	}
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+	if opts.Class < 0 || opts.Class >= numClasses {
+		return fmt.Errorf("invalid priority class %d", opts.Class)
+	}
 
-	select {
-	case p.jobs <- Job[T]{Data: data, Fn: fn}:
-		return nil
-	case <-p.ctx.Done():
-		return p.ctx.Err()
-	default:
-		return fmt.Errorf("job queue is full")
+	job := Job[T]{Data: data, Fn: fn, class: opts.Class, group: opts.Group, enqueued: time.Now()}
+	if err := p.queue.push(job, false); err != nil {
+		return err
 	}
+	if opts.Class == ClassInteractive {
+		p.sched.preempt(p.Workers(), p.queue.queued(ClassInteractive))
+	}
+	return nil
+}
+
+// WaitStats returns how long jobs of each class waited for a worker.
+func (p *Pool[T]) WaitStats() map[Class]WaitStats {
+	return p.sched.waitStats()
 }
 
 // Results returns a channel to receive job results.
@@ -133,8 +198,8 @@ func (p *Pool[T]) Stop() {
 		return
 	}
 
-	// Signal shutdown and close job channel
-	close(p.jobs)
+	// Signal shutdown and close job queue
+	p.queue.close()
 
 	// Wait for all workers to finish
 	p.wg.Wait()
@@ -170,12 +235,51 @@ func (p *Pool[T]) StopWithTimeout(timeout time.Duration) error {
 func (p *Pool[T]) worker(_ int) {
 	defer p.wg.Done()
 
-	for job := range p.jobs {
+	active := metrics.WorkerPoolActive.With(p.config.Name)
+
+	for {
+		job, ok := p.queue.pop()
+		if !ok {
+			return
+		}
+
+		// Wait for a slot first so the timeout only covers execution
+		if p.scaler != nil {
+			p.scaler.gate.Acquire()
+		}
+
 		// Create a context with timeout for this job
-		jobCtx, jobCancel := context.WithTimeout(p.ctx, p.config.Timeout)
+		timeoutCtx, timeoutCancel := context.WithTimeout(p.ctx, p.config.Timeout)
+		jobCtx, preempt := context.WithCancelCause(timeoutCtx)
+		jobCancel := func() {
+			preempt(nil)
+			timeoutCancel()
+		}
+		running := p.sched.started(job.class, job.enqueued, job.preemptions, preempt)
 
 		// Execute the job
+		active.Add(1)
 		err := job.Fn(jobCtx, job.Data)
+		active.Add(-1)
+		preempted := p.sched.finished(running)
+		if p.scaler != nil {
+			p.scaler.gate.Release()
+		}
+
+		// 선점된 작업은 결과 없이 대기열 맨 앞으로 돌아가 다시 실행됨
+		if preempted && err != nil && p.ctx.Err() == nil {
+			job.preemptions++
+			job.enqueued = time.Now()
+			if p.queue.push(job, true) == nil {
+				jobCancel()
+				continue
+			}
+		}
+
+		if p.scaler != nil {
+			p.scaler.record(err)
+		}
+		metrics.WorkerPoolJobs.With(p.config.Name, metrics.ResultLabel(err)).Inc()
 
 		// Send result
 		result := Result[T]{
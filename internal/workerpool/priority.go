@@ -0,0 +1,331 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+)
+
+// Class is the priority class of a job. Workers always take the oldest job
+// of the highest class that has queued jobs.
+type Class int
+
+// Priority classes, highest first.
+const (
+	// ClassInteractive is work a user is waiting for at the terminal.
+	ClassInteractive Class = iota
+	// ClassScheduled is work started by a schedule or the job API.
+	ClassScheduled
+	// ClassBackground is work nobody waits for, e.g. cache warming. Long
+	// running background jobs may be preempted by interactive jobs.
+	ClassBackground
+
+	numClasses = 3
+)
+
+// Classes lists the priority classes, highest first.
+var Classes = []Class{ClassInteractive, ClassScheduled, ClassBackground}
+
+func (c Class) String() string {
+	switch c {
+	case ClassInteractive:
+		return "interactive"
+	case ClassScheduled:
+		return "scheduled"
+	case ClassBackground:
+		return "background"
+	default:
+		return fmt.Sprintf("class(%d)", int(c))
+	}
+}
+
+// ParseClass parses a class name as returned by Class.String.
+func ParseClass(s string) (Class, error) {
+	for _, c := range Classes {
+		if c.String() == s {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown priority class %q (expected interactive, scheduled or background)", s)
+}
+
+// ErrPreempted is the context cause of a background job canceled to free a
+// worker for an interactive job. The job is queued again and restarted from
+// the beginning, so background jobs must be safe to rerun.
+var ErrPreempted = errors.New("job preempted by interactive work")
+
+// SubmitOptions select the queue a job waits in.
+type SubmitOptions struct {
+	Class Class
+	// Group shares a class fairly between groups, e.g. organizations:
+	// workers take jobs from the groups of a class in turn, so one large
+	// organization cannot starve the others.
+	Group string
+}
+
+// PreemptionConfig enables preemption of background jobs. Zero values
+// select defaults.
+type PreemptionConfig struct {
+	// MinRuntime is how long a background job must have run before it may
+	// be preempted, so short jobs are allowed to finish. Defaults to 30s.
+	MinRuntime time.Duration
+	// MaxPreemptions is how often one job may be preempted; after that it
+	// runs to completion. Defaults to 3.
+	MaxPreemptions int
+}
+
+func (c PreemptionConfig) withDefaults() PreemptionConfig {
+	if c.MinRuntime <= 0 {
+		c.MinRuntime = 30 * time.Second
+	}
+	if c.MaxPreemptions <= 0 {
+		c.MaxPreemptions = 3
+	}
+	return c
+}
+
+// WaitStats summarizes how long the jobs of one class waited in the queue.
+type WaitStats struct {
+	Jobs  int
+	Total time.Duration
+	Max   time.Duration
+}
+
+// Mean returns the average queue wait.
+func (s WaitStats) Mean() time.Duration {
+	if s.Jobs == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Jobs)
+}
+
+// classQueue holds the jobs of one class, one FIFO per group.
+type classQueue[T any] struct {
+	groups map[string][]Job[T]
+	// ring lists the groups with queued jobs in round-robin order.
+	ring []string
+	next int
+}
+
+// jobQueue is the bounded priority queue of a pool.
+type jobQueue[T any] struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	capacity int
+	size     int
+	idle     int // pop calls waiting for a job
+	closed   bool
+	classes  [numClasses]classQueue[T]
+}
+
+func newJobQueue[T any](capacity int) *jobQueue[T] {
+	q := &jobQueue[T]{capacity: capacity}
+	q.cond = sync.NewCond(&q.mu)
+	for i := range q.classes {
+		q.classes[i].groups = make(map[string][]Job[T])
+	}
+	return q
+}
+
+// push queues a job. Like a buffered channel, jobs handed to idle workers
+// do not count against the capacity. Requeued jobs go to the front of their
+// group and may exceed the capacity, since they were already admitted once.
+func (q *jobQueue[T]) push(job Job[T], requeue bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return fmt.Errorf("worker pool not started")
+	}
+	if !requeue && q.size >= q.capacity+q.idle {
+		return fmt.Errorf("job queue is full")
+	}
+
+	cq := &q.classes[job.class]
+	queued, ok := cq.groups[job.group]
+	if !ok || len(queued) == 0 {
+		cq.ring = append(cq.ring, job.group)
+	}
+	if requeue {
+		cq.groups[job.group] = append([]Job[T]{job}, queued...)
+	} else {
+		cq.groups[job.group] = append(queued, job)
+	}
+	q.size++
+	q.cond.Signal()
+	return nil
+}
+
+// pop blocks until a job is queued and returns the next one, or false once
+// the queue is closed and empty.
+func (q *jobQueue[T]) pop() (Job[T], bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.size == 0 && !q.closed {
+		q.idle++
+		q.cond.Wait()
+		q.idle--
+	}
+	if q.size == 0 {
+		return Job[T]{}, false
+	}
+
+	for i := range q.classes {
+		cq := &q.classes[i]
+		if len(cq.ring) == 0 {
+			continue
+		}
+		idx := cq.next % len(cq.ring)
+		group := cq.ring[idx]
+		queued := cq.groups[group]
+		job := queued[0]
+		if len(queued) == 1 {
+			delete(cq.groups, group)
+			cq.ring = append(cq.ring[:idx], cq.ring[idx+1:]...)
+			cq.next = idx
+		} else {
+			cq.groups[group] = queued[1:]
+			cq.next = idx + 1
+		}
+		q.size--
+		return job, true
+	}
+	return Job[T]{}, false
+}
+
+// len returns the number of queued jobs.
+func (q *jobQueue[T]) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.size
+}
+
+// queued returns the number of queued jobs of a class.
+func (q *jobQueue[T]) queued(class Class) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := 0
+	for _, jobs := range q.classes[class].groups {
+		n += len(jobs)
+	}
+	return n
+}
+
+// close makes pop return false once the remaining jobs are taken.
+func (q *jobQueue[T]) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+// runningJob is a job a worker is executing.
+type runningJob struct {
+	class       Class
+	started     time.Time
+	preemptions int
+	cancel      context.CancelCauseFunc
+	preempted   bool
+}
+
+// scheduler tracks running jobs for preemption and queue waits for stats.
+type scheduler struct {
+	name       string
+	preemption *PreemptionConfig
+
+	mu      sync.Mutex
+	running map[*runningJob]struct{}
+	waits   [numClasses]WaitStats
+}
+
+func newScheduler(name string, preemption *PreemptionConfig) *scheduler {
+	if preemption != nil {
+		c := preemption.withDefaults()
+		preemption = &c
+	}
+	return &scheduler{name: name, preemption: preemption, running: make(map[*runningJob]struct{})}
+}
+
+// started records the queue wait of a job and tracks it while it runs.
+func (s *scheduler) started(class Class, enqueued time.Time, preemptions int, cancel context.CancelCauseFunc) *runningJob {
+	now := time.Now()
+	wait := now.Sub(enqueued)
+	metrics.WorkerPoolQueueWait.With(s.name, class.String()).Observe(wait.Seconds())
+
+	r := &runningJob{class: class, started: now, preemptions: preemptions, cancel: cancel}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := &s.waits[class]
+	w.Jobs++
+	w.Total += wait
+	w.Max = max(w.Max, wait)
+	s.running[r] = struct{}{}
+	return r
+}
+
+// finished stops tracking a job and reports whether it was preempted.
+func (s *scheduler) finished(r *runningJob) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, r)
+	return r.preempted
+}
+
+// preempt cancels the longest running preemptible background job when
+// waiting interactive jobs outnumber the idle workers.
+func (s *scheduler) preempt(workers, interactiveQueued int) bool {
+	if s.preemption == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 이미 선점된 작업의 워커는 곧 비므로 유휴로 센다
+	idle := workers - len(s.running)
+	for r := range s.running {
+		if r.preempted {
+			idle++
+		}
+	}
+	if interactiveQueued <= idle {
+		return false
+	}
+
+	var victim *runningJob
+	now := time.Now()
+	for r := range s.running {
+		if r.class != ClassBackground || r.preempted || r.preemptions >= s.preemption.MaxPreemptions ||
+			now.Sub(r.started) < s.preemption.MinRuntime {
+			continue
+		}
+		if victim == nil || r.started.Before(victim.started) {
+			victim = r
+		}
+	}
+	if victim == nil {
+		return false
+	}
+	victim.preempted = true
+	victim.cancel(ErrPreempted)
+	metrics.WorkerPoolPreemptions.With(s.name).Inc()
+	return true
+}
+
+// waitStats returns a copy of the per-class queue waits.
+func (s *scheduler) waitStats() map[Class]WaitStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := make(map[Class]WaitStats, numClasses)
+	for _, c := range Classes {
+		stats[c] = s.waits[c]
+	}
+	return stats
+}
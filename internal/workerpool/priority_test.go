@@ -0,0 +1,167 @@
+//nolint:testpackage // White-box testing needed for internal function access
+package workerpool
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pushJob(t *testing.T, q *jobQueue[string], data string, class Class, group string) {
+	t.Helper()
+	require.NoError(t, q.push(Job[string]{Data: data, class: class, group: group}, false))
+}
+
+func popAll(q *jobQueue[string]) []string {
+	q.close()
+	var order []string
+	for {
+		job, ok := q.pop()
+		if !ok {
+			return order
+		}
+		order = append(order, job.Data)
+	}
+}
+
+func TestJobQueue_ClassOrderAndGroupFairness(t *testing.T) {
+	q := newJobQueue[string](10)
+	pushJob(t, q, "bg", ClassBackground, "")
+	pushJob(t, q, "acme-1", ClassScheduled, "acme")
+	pushJob(t, q, "acme-2", ClassScheduled, "acme")
+	pushJob(t, q, "acme-3", ClassScheduled, "acme")
+	pushJob(t, q, "beta-1", ClassScheduled, "beta")
+	pushJob(t, q, "gamma-1", ClassScheduled, "gamma")
+	pushJob(t, q, "cli", ClassInteractive, "")
+
+	assert.Equal(t, 7, q.len())
+	assert.Equal(t, 5, q.queued(ClassScheduled))
+	assert.Equal(t,
+		[]string{"cli", "acme-1", "beta-1", "gamma-1", "acme-2", "acme-3", "bg"},
+		popAll(q))
+}
+
+func TestJobQueue_CapacityAndRequeue(t *testing.T) {
+	q := newJobQueue[string](2)
+	pushJob(t, q, "a", ClassBackground, "")
+	pushJob(t, q, "b", ClassBackground, "")
+	assert.EqualError(t, q.push(Job[string]{Data: "c"}, false), "job queue is full")
+
+	// Requeued jobs were admitted before, so they skip the capacity check.
+	require.NoError(t, q.push(Job[string]{Data: "preempted", class: ClassBackground}, true))
+	assert.Equal(t, []string{"preempted", "a", "b"}, popAll(q))
+	assert.Error(t, q.push(Job[string]{Data: "late"}, false))
+}
+
+func TestParseClass(t *testing.T) {
+	for _, c := range Classes {
+		parsed, err := ParseClass(c.String())
+		require.NoError(t, err)
+		assert.Equal(t, c, parsed)
+	}
+	_, err := ParseClass("urgent")
+	assert.ErrorContains(t, err, "unknown priority class")
+}
+
+func TestPool_RunsHigherClassesFirst(t *testing.T) {
+	pool := New[string](WorkerPoolConfig{WorkerCount: 1, BufferSize: 10, Name: "priority-test"})
+	require.NoError(t, pool.Start())
+	defer pool.Stop()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	require.NoError(t, pool.Submit("blocker", func(context.Context, string) error {
+		close(started)
+		<-release
+		return nil
+	}))
+	<-started
+
+	noop := func(context.Context, string) error { return nil }
+	require.NoError(t, pool.SubmitWithOptions("background", noop, SubmitOptions{Class: ClassBackground}))
+	require.NoError(t, pool.SubmitWithOptions("scheduled", noop, SubmitOptions{Class: ClassScheduled, Group: "acme"}))
+	require.NoError(t, pool.Submit("interactive", noop))
+	assert.ErrorContains(t, pool.SubmitWithOptions("x", noop, SubmitOptions{Class: Class(7)}), "invalid priority class")
+	close(release)
+
+	var order []string
+	for range 4 {
+		order = append(order, (<-pool.Results()).Data)
+	}
+	assert.Equal(t, []string{"blocker", "interactive", "scheduled", "background"}, order)
+
+	stats := pool.WaitStats()
+	assert.Equal(t, 2, stats[ClassInteractive].Jobs)
+	assert.Equal(t, 1, stats[ClassBackground].Jobs)
+	assert.Positive(t, stats[ClassBackground].Max)
+}
+
+func TestPool_InteractivePreemptsBackground(t *testing.T) {
+	pool := New[string](WorkerPoolConfig{
+		WorkerCount: 1,
+		Name:        "preempt-test",
+		Preemption:  &PreemptionConfig{MinRuntime: time.Millisecond},
+	})
+	require.NoError(t, pool.Start())
+	defer pool.Stop()
+
+	var runs atomic.Int32
+	var cause atomic.Value
+	started := make(chan struct{}, 2)
+	require.NoError(t, pool.SubmitWithOptions("background", func(ctx context.Context, _ string) error {
+		if runs.Add(1) > 1 {
+			return nil
+		}
+		started <- struct{}{}
+		<-ctx.Done()
+		cause.Store(context.Cause(ctx))
+		return ctx.Err()
+	}, SubmitOptions{Class: ClassBackground}))
+
+	<-started
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, pool.Submit("interactive", func(context.Context, string) error { return nil }))
+
+	first := <-pool.Results()
+	second := <-pool.Results()
+	assert.Equal(t, "interactive", first.Data)
+	assert.Equal(t, "background", second.Data)
+	require.NoError(t, second.Error)
+	assert.Equal(t, int32(2), runs.Load())
+	assert.Equal(t, ErrPreempted, cause.Load())
+}
+
+func TestPool_ShortBackgroundJobsAreNotPreempted(t *testing.T) {
+	pool := New[string](WorkerPoolConfig{
+		WorkerCount: 1,
+		Name:        "preempt-test",
+		Preemption:  &PreemptionConfig{MinRuntime: time.Hour},
+	})
+	require.NoError(t, pool.Start())
+	defer pool.Stop()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	require.NoError(t, pool.SubmitWithOptions("background", func(ctx context.Context, _ string) error {
+		close(started)
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, SubmitOptions{Class: ClassBackground}))
+	<-started
+
+	require.NoError(t, pool.Submit("interactive", func(context.Context, string) error { return nil }))
+	close(release)
+
+	first := <-pool.Results()
+	assert.Equal(t, "background", first.Data)
+	assert.NoError(t, first.Error)
+	assert.Equal(t, "interactive", (<-pool.Results()).Data)
+}
@@ -6,7 +6,10 @@ package workerpool
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/metrics"
 )
 
 // RepositoryOperation represents a repository operation type.
@@ -24,10 +27,12 @@ const (
 // RepositoryJob represents a repository operation job.
 type RepositoryJob struct {
 	Repository       string
+	Provider         string // Provider name used to label metrics (e.g. "github")
 	Operation        RepositoryOperation
 	Path             string
 	Branch           string
 	Strategy         string
+	EstimatedSize    int64          // Provider-reported repository size in bytes, 0 if unknown
 	WorkerPoolConfig map[string]any // For configuration operations
 }
 
@@ -54,6 +59,11 @@ type RepositoryPoolConfig struct {
 	RetryAttempts int
 	// RetryDelay specifies delay between retry attempts
 	RetryDelay time.Duration
+	// DiskBudget, when set, gates clone operations on free space of the target filesystem
+	DiskBudget *DiskBudget
+	// Autoscale, when set, lets the clone and update pools adjust their workers
+	// at runtime, starting from CloneWorkers and UpdateWorkers
+	Autoscale *AutoscaleConfig
 }
 
 // DefaultRepositoryPoolConfig returns default configuration for repository operations.
@@ -88,18 +98,23 @@ func NewRepositoryWorkerPool(config RepositoryPoolConfig) *RepositoryWorkerPool
 		WorkerCount: config.CloneWorkers,
 		BufferSize:  config.CloneWorkers * 2,
 		Timeout:     config.OperationTimeout,
+		Name:        "repository-clone",
+		Autoscale:   config.Autoscale,
 	})
 
 	updatePool := New[RepositoryJob](WorkerPoolConfig{
 		WorkerCount: config.UpdateWorkers,
 		BufferSize:  config.UpdateWorkers * 2,
 		Timeout:     config.OperationTimeout,
+		Name:        "repository-update",
+		Autoscale:   config.Autoscale,
 	})
 
 	configPool := New[RepositoryJob](WorkerPoolConfig{
 		WorkerCount: config.ConfigWorkers,
 		BufferSize:  config.ConfigWorkers * 2,
 		Timeout:     config.OperationTimeout,
+		Name:        "repository-config",
 	})
 
 	return &RepositoryWorkerPool{
@@ -167,6 +182,9 @@ func (rp *RepositoryWorkerPool) SubmitJob(job RepositoryJob,
 
 	// Wrap processFn with retry logic
 	wrappedFn := rp.wrapWithRetry(processFn)
+	if job.Operation == OperationClone && rp.config.DiskBudget != nil {
+		wrappedFn = rp.wrapWithDiskBudget(wrappedFn)
+	}
 
 	return pool.Submit(job, wrappedFn)
 }
@@ -243,6 +261,7 @@ func (rp *RepositoryWorkerPool) wrapWithRetry( //nolint:gocognit // Complex work
 			startTime := time.Now()
 			err := processFn(ctx, job)
 			duration := time.Since(startTime)
+			metrics.ObserveClone(job.Provider, string(job.Operation), startTime, err)
 
 			if err == nil {
 				// Success - log if this was a retry
@@ -272,6 +291,24 @@ func (rp *RepositoryWorkerPool) wrapWithRetry( //nolint:gocognit // Complex work
 	}
 }
 
+// wrapWithDiskBudget reserves the job's estimated size under its target
+// directory before cloning, queueing the job while the budget is exhausted.
+func (rp *RepositoryWorkerPool) wrapWithDiskBudget(
+	processFn func(context.Context, RepositoryJob) error,
+) func(context.Context, RepositoryJob) error {
+	budget := rp.config.DiskBudget
+
+	return func(ctx context.Context, job RepositoryJob) error {
+		release, err := budget.Reserve(ctx, filepath.Dir(job.Path), budget.Estimate(job.EstimatedSize))
+		if err != nil {
+			return fmt.Errorf("repository %s not cloned: %w", job.Repository, err)
+		}
+		defer release()
+
+		return processFn(ctx, job)
+	}
+}
+
 // isRetryableError determines if an error is worth retrying.
 func isRetryableError(err error) bool {
 	if err == nil {
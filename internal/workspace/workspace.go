@@ -0,0 +1,293 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package workspace runs Git operations over the repositories of a
+// workspace in parallel.
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/git/objcache"
+	"github.com/gizzahub/gzh-cli/internal/workerpool"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+// DefaultTimeout limits the operation on a single repository.
+const DefaultTimeout = 30 * time.Minute
+
+// Actions reported in Result.Action.
+const (
+	ActionCloned  = "cloned"
+	ActionUpdated = "updated"
+	ActionFetched = "fetched"
+	ActionReset   = "reset"
+	ActionRan     = "ran"
+	ActionSkipped = "skipped"
+	ActionFailed  = "failed"
+)
+
+// Result is the outcome of an operation on one repository.
+type Result struct {
+	Repo     string        `json:"repo"`
+	Path     string        `json:"path"`
+	Action   string        `json:"action"`
+	Output   string        `json:"output,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Failed reports whether the operation failed.
+func (r Result) Failed() bool {
+	return r.Error != ""
+}
+
+// Status is the working tree state of one repository.
+type Status struct {
+	Repo   string `json:"repo"`
+	Path   string `json:"path"`
+	Cloned bool   `json:"cloned"`
+	Branch string `json:"branch,omitempty"`
+	// Upstream is empty when the branch tracks nothing.
+	Upstream string `json:"upstream,omitempty"`
+	Ahead    int    `json:"ahead"`
+	Behind   int    `json:"behind"`
+	// Changed counts modified, staged and untracked files.
+	Changed int    `json:"changed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Clean reports whether the repository is cloned, has no local changes and
+// is in step with its upstream.
+func (s Status) Clean() bool {
+	return s.Cloned && s.Error == "" && s.Changed == 0 && s.Ahead == 0 && s.Behind == 0
+}
+
+// Runner executes operations over a resolved workspace.
+type Runner struct {
+	Workspace *config.ResolvedWorkspace
+	// Timeout limits the operation on a single repository.
+	Timeout time.Duration
+	// ObjectCache, when set, lets new clones borrow objects from a shared
+	// store instead of downloading them again.
+	ObjectCache *objcache.Cache
+
+	// run executes a command in dir; swapped out in tests.
+	run func(ctx context.Context, dir, name string, args ...string) ([]byte, error)
+}
+
+// NewRunner creates a runner for ws.
+func NewRunner(ws *config.ResolvedWorkspace) *Runner {
+	return &Runner{Workspace: ws, Timeout: DefaultTimeout, run: runCommand}
+}
+
+func runCommand(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...) // #nosec G204 -- git or the command given by the user
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	cmd.WaitDelay = 5 * time.Second
+	err := cmd.Run()
+	return out.Bytes(), err
+}
+
+// each runs fn for every target with workerpool parallelism and returns the
+// values in target order.
+func each[T any](ctx context.Context, r *Runner, fn func(context.Context, config.WorkspaceTarget) T) ([]T, error) {
+	targets := r.Workspace.Targets
+	out := make([]T, len(targets))
+
+	workers := r.Workspace.Concurrency
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	indexes := make([]int, len(targets))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	_, err := workerpool.ProcessBatch(ctx, indexes, workerpool.WorkerPoolConfig{
+		WorkerCount: workers,
+		Timeout:     timeout,
+		Name:        "workspace",
+	}, func(ctx context.Context, i int) error {
+		out[i] = fn(ctx, targets[i])
+		return nil
+	})
+	return out, err
+}
+
+// Sync clones missing repositories and updates the others with the
+// workspace strategy.
+func (r *Runner) Sync(ctx context.Context) ([]Result, error) {
+	return each(ctx, r, r.sync)
+}
+
+func (r *Runner) sync(ctx context.Context, t config.WorkspaceTarget) Result {
+	start := time.Now()
+	result := Result{Repo: t.Name, Path: t.Path}
+
+	var steps [][]string
+	var output []byte
+	dir := t.Path
+	if !isRepository(t.Path) {
+		if err := os.MkdirAll(filepath.Dir(t.Path), 0o755); err != nil {
+			return failed(result, start, nil, err)
+		}
+		args := []string{"clone"}
+		if t.Branch != "" {
+			args = append(args, "--branch", t.Branch)
+		}
+		store, err := r.referenceStore(ctx, t)
+		if err != nil {
+			// 캐시 없이도 클론은 진행한다
+			output = fmt.Appendf(output, "object cache: %v\n", err)
+		}
+		if store != "" {
+			args = append(args, "--reference-if-able", store)
+		}
+		steps = [][]string{append(args, "--", t.URL, t.Path)}
+		dir = filepath.Dir(t.Path)
+		result.Action = ActionCloned
+	} else {
+		switch r.Workspace.Strategy {
+		case config.WorkspaceStrategyFetch:
+			steps = [][]string{{"fetch", "--prune", "origin"}}
+			result.Action = ActionFetched
+		case config.WorkspaceStrategyReset:
+			steps = [][]string{{"fetch", "--prune", "origin"}, {"reset", "--hard", "@{upstream}"}}
+			result.Action = ActionReset
+		default:
+			steps = [][]string{{"pull", "--ff-only"}}
+			result.Action = ActionUpdated
+		}
+	}
+
+	for _, args := range steps {
+		out, err := r.run(ctx, dir, "git", args...)
+		output = append(output, out...)
+		if err != nil {
+			return failed(result, start, output, err)
+		}
+	}
+	result.Output = strings.TrimSpace(string(output))
+	result.Duration = time.Since(start)
+	return result
+}
+
+// referenceStore prepares the object cache store for a new clone of t. A
+// failed fetch still returns an older store, which the clone can use.
+func (r *Runner) referenceStore(ctx context.Context, t config.WorkspaceTarget) (string, error) {
+	if r.ObjectCache == nil {
+		return "", nil
+	}
+	store, err := r.ObjectCache.Prepare(ctx, t.URL)
+	if store == "" {
+		return "", err
+	}
+	if regErr := r.ObjectCache.Register(store, t.Path); regErr != nil {
+		return "", regErr
+	}
+	return store, err
+}
+
+func failed(result Result, start time.Time, output []byte, err error) Result {
+	result.Action = ActionFailed
+	result.Output = strings.TrimSpace(string(output))
+	result.Error = err.Error()
+	if errors.Is(err, context.DeadlineExceeded) {
+		result.Error = "timed out"
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// Exec runs argv in every cloned repository. Repositories that are not
+// cloned yet are skipped.
+func (r *Runner) Exec(ctx context.Context, argv []string) ([]Result, error) {
+	if len(argv) == 0 {
+		return nil, fmt.Errorf("no command given")
+	}
+	return each(ctx, r, func(ctx context.Context, t config.WorkspaceTarget) Result {
+		start := time.Now()
+		result := Result{Repo: t.Name, Path: t.Path, Action: ActionRan}
+		if !isRepository(t.Path) {
+			result.Action = ActionSkipped
+			result.Output = "not cloned"
+			return result
+		}
+		out, err := r.run(ctx, t.Path, argv[0], argv[1:]...)
+		if err != nil {
+			return failed(result, start, out, err)
+		}
+		result.Output = strings.TrimRight(string(out), "\n")
+		result.Duration = time.Since(start)
+		return result
+	})
+}
+
+// Status reports the working tree state of every repository.
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	return each(ctx, r, func(ctx context.Context, t config.WorkspaceTarget) Status {
+		status := Status{Repo: t.Name, Path: t.Path}
+		if !isRepository(t.Path) {
+			return status
+		}
+		status.Cloned = true
+
+		out, err := r.run(ctx, t.Path, "git", "status", "--porcelain=v2", "--branch")
+		if err != nil {
+			status.Error = strings.TrimSpace(string(out))
+			if status.Error == "" {
+				status.Error = err.Error()
+			}
+			return status
+		}
+		parseStatus(out, &status)
+		return status
+	})
+}
+
+// parseStatus reads `git status --porcelain=v2 --branch` output.
+func parseStatus(out []byte, status *Status) {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			status.Branch = strings.TrimPrefix(line, "# branch.head ")
+		case strings.HasPrefix(line, "# branch.upstream "):
+			status.Upstream = strings.TrimPrefix(line, "# branch.upstream ")
+		case strings.HasPrefix(line, "# branch.ab "):
+			fields := strings.Fields(strings.TrimPrefix(line, "# branch.ab "))
+			if len(fields) == 2 {
+				status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[0], "+"))
+				status.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "-"))
+			}
+		case strings.HasPrefix(line, "#"), line == "":
+		default:
+			status.Changed++
+		}
+	}
+}
+
+func isRepository(path string) bool {
+	_, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package workspace
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/git/objcache"
+	"github.com/gizzahub/gzh-cli/pkg/config"
+)
+
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+// origin creates a repository with one commit to clone from.
+func origin(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, "origin", name)
+	require.NoError(t, os.MkdirAll(path, 0o755))
+	git(t, path, "init", "-q", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(path, "README"), []byte(name), 0o644))
+	git(t, path, "add", ".")
+	git(t, path, "commit", "-q", "-m", "init")
+	return path
+}
+
+func TestRunner_SyncStatusExec(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	api := origin(t, dir, "api")
+	web := origin(t, dir, "web")
+	root := filepath.Join(dir, "ws")
+
+	ws := &config.ResolvedWorkspace{
+		Name:     "teamA",
+		Root:     root,
+		Strategy: config.WorkspaceStrategyPull,
+		Targets: []config.WorkspaceTarget{
+			{Name: "acme/api", URL: api, Path: filepath.Join(root, "acme", "api")},
+			{Name: "acme/web", URL: web, Path: filepath.Join(root, "acme", "web")},
+			{Name: "acme/gone", URL: filepath.Join(dir, "origin", "missing"), Path: filepath.Join(root, "acme", "gone")},
+		},
+	}
+	runner := NewRunner(ws)
+	ctx := context.Background()
+
+	results, err := runner.Sync(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, ActionCloned, results[0].Action)
+	assert.Equal(t, ActionCloned, results[1].Action)
+	assert.True(t, results[2].Failed(), "results keep the target order")
+	assert.FileExists(t, filepath.Join(root, "acme", "web", "README"))
+
+	// 원격에 새 커밋이 생기면 pull 전략으로 따라간다
+	require.NoError(t, os.WriteFile(filepath.Join(api, "CHANGELOG"), []byte("v2"), 0o644))
+	git(t, api, "add", ".")
+	git(t, api, "commit", "-q", "-m", "v2")
+	git(t, filepath.Join(root, "acme", "api"), "fetch", "-q")
+
+	statuses, err := runner.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "main", statuses[0].Branch)
+	assert.Equal(t, "origin/main", statuses[0].Upstream)
+	assert.Equal(t, 1, statuses[0].Behind)
+	assert.True(t, statuses[1].Clean())
+	assert.False(t, statuses[2].Cloned)
+
+	ws.Targets = ws.Targets[:2]
+	results, err = runner.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ActionUpdated, results[0].Action)
+	assert.FileExists(t, filepath.Join(root, "acme", "api", "CHANGELOG"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "acme", "web", "notes"), nil, 0o644))
+	statuses, err = runner.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Clean())
+	assert.Equal(t, 1, statuses[1].Changed)
+
+	results, err = runner.Exec(ctx, []string{"git", "log", "-1", "--format=%s"})
+	require.NoError(t, err)
+	assert.Equal(t, "v2", results[0].Output)
+	assert.Equal(t, "init", results[1].Output)
+
+	_, err = runner.Exec(ctx, nil)
+	assert.EqualError(t, err, "no command given")
+}
+
+func TestRunner_Strategies(t *testing.T) {
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0o755))
+
+	var calls [][]string
+	runner := NewRunner(&config.ResolvedWorkspace{
+		Strategy: config.WorkspaceStrategyReset,
+		Targets:  []config.WorkspaceTarget{{Name: "repo", Path: repo}},
+	})
+	runner.run = func(_ context.Context, _ string, name string, args ...string) ([]byte, error) {
+		calls = append(calls, append([]string{name}, args...))
+		return nil, nil
+	}
+
+	results, err := runner.Sync(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ActionReset, results[0].Action)
+	assert.Equal(t, [][]string{
+		{"git", "fetch", "--prune", "origin"},
+		{"git", "reset", "--hard", "@{upstream}"},
+	}, calls)
+}
+
+func TestRunner_SyncObjectCache(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	api := origin(t, dir, "api")
+	root := filepath.Join(dir, "ws")
+
+	runner := NewRunner(&config.ResolvedWorkspace{
+		Name:    "teamA",
+		Root:    root,
+		Targets: []config.WorkspaceTarget{{Name: "acme/api", URL: api, Path: filepath.Join(root, "api")}},
+	})
+	runner.ObjectCache = objcache.New(filepath.Join(dir, "cache"))
+
+	results, err := runner.Sync(context.Background())
+	require.NoError(t, err)
+	require.False(t, results[0].Failed(), results[0].Output)
+	assert.FileExists(t, filepath.Join(root, "api", ".git", "objects", "info", "alternates"))
+
+	stores, err := runner.ObjectCache.List()
+	require.NoError(t, err)
+	require.Len(t, stores, 1)
+	assert.Len(t, stores[0].LiveUsers, 1)
+}
+
+func TestParseStatus(t *testing.T) {
+	var s Status
+	parseStatus([]byte(`# branch.oid 1234
+# branch.head feature
+# branch.upstream origin/feature
+# branch.ab +2 -1
+1 .M N... 100644 100644 100644 abc abc main.go
+? new.txt
+`), &s)
+	assert.Equal(t, Status{Branch: "feature", Upstream: "origin/feature", Ahead: 2, Behind: 1, Changed: 2}, s)
+}
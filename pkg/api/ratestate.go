@@ -0,0 +1,373 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateStateEnv disables rate state persistence when set to "0", "false" or
+// "off".
+const RateStateEnv = "GZH_RATE_STATE"
+
+// Limits of persisted rate state.
+const (
+	// RollingWindow is how long per-minute request counts are kept.
+	RollingWindow = time.Hour
+	// StaleAfter drops the state of tokens that were not used for a day.
+	StaleAfter = 24 * time.Hour
+	// DefaultMaxWait is the longest a request waits for a limit to reset
+	// before it fails with a RateLimitError instead.
+	DefaultMaxWait = 5 * time.Minute
+)
+
+// DefaultMinuteBudgets are the requests per minute and token a provider
+// tolerates before its secondary limits trip. GitHub documents 900 points
+// per minute for REST endpoints.
+var DefaultMinuteBudgets = map[string]int{"github": 900}
+
+// RateState is the last known rate limit of one token on one host.
+type RateState struct {
+	Provider string `json:"provider"`
+	Host     string `json:"host"`
+	Token    string `json:"token"`
+	// Limit and Remaining are the primary limit, -1 when unknown.
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Reset     time.Time `json:"reset,omitzero"`
+	// BlockedUntil is set from Retry-After when a secondary limit was hit.
+	BlockedUntil time.Time `json:"blockedUntil,omitzero"`
+	// Minutes counts the request cost per minute, keyed by Unix minute.
+	Minutes   map[int64]int `json:"minutes,omitempty"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+// RecentRequests returns the request cost in the minutes overlapping the
+// d before now.
+func (s *RateState) RecentRequests(now time.Time, d time.Duration) int {
+	from := now.Add(-d).Unix() / 60
+	total := 0
+	for minute, n := range s.Minutes {
+		if minute >= from {
+			total += n
+		}
+	}
+	return total
+}
+
+// expire rolls the state forward to now: a passed reset restores the
+// primary limit, a passed block is cleared and old counts are dropped.
+func (s *RateState) expire(now time.Time) {
+	if !s.Reset.IsZero() && !now.Before(s.Reset) {
+		s.Remaining = s.Limit
+		s.Reset = time.Time{}
+	}
+	if !s.BlockedUntil.IsZero() && !now.Before(s.BlockedUntil) {
+		s.BlockedUntil = time.Time{}
+	}
+	cutoff := now.Add(-RollingWindow).Unix() / 60
+	for minute := range s.Minutes {
+		if minute <= cutoff {
+			delete(s.Minutes, minute)
+		}
+	}
+}
+
+// RateLimitError is returned when a token is known to be limited for
+// longer than the transport is willing to wait.
+type RateLimitError struct {
+	Provider string
+	Host     string
+	Until    time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s rate limit for %s exhausted until %s", e.Provider, e.Host, e.Until.Format(time.RFC3339))
+}
+
+// RateBook holds the rate state of every token gz has used, persisted
+// between runs so that a new process does not start with a fresh budget
+// the provider has not granted. It is safe for concurrent use.
+type RateBook struct {
+	path    string
+	mu      sync.Mutex
+	states  map[string]*RateState
+	budgets map[string]int
+	maxWait time.Duration
+	now     func() time.Time
+	sleep   func(context.Context, time.Duration) error
+}
+
+// RateStateEnabled reports whether persistence is enabled in the
+// environment.
+func RateStateEnabled() bool {
+	switch strings.ToLower(os.Getenv(RateStateEnv)) {
+	case "0", "false", "off":
+		return false
+	default:
+		return true
+	}
+}
+
+// DefaultRateStatePath returns ~/.gzh/rate-state.json.
+func DefaultRateStatePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".gzh", "rate-state.json"), nil
+}
+
+// NewRateBook returns an empty book persisted at path.
+func NewRateBook(path string) *RateBook {
+	budgets := make(map[string]int, len(DefaultMinuteBudgets))
+	for provider, n := range DefaultMinuteBudgets {
+		budgets[provider] = n
+	}
+	return &RateBook{
+		path:    path,
+		states:  map[string]*RateState{},
+		budgets: budgets,
+		maxWait: DefaultMaxWait,
+		now:     time.Now,
+		sleep:   sleepContext,
+	}
+}
+
+// LoadRateBook restores the book at path. A missing or unreadable file is
+// an empty book; stale state is dropped.
+func LoadRateBook(path string) (*RateBook, error) {
+	return loadRateBook(path, time.Now)
+}
+
+func loadRateBook(path string, now func() time.Time) (*RateBook, error) {
+	b := NewRateBook(path)
+	b.now = now
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return b, fmt.Errorf("failed to read rate state: %w", err)
+	}
+
+	var states []*RateState
+	if err := json.Unmarshal(data, &states); err != nil {
+		return b, fmt.Errorf("failed to parse rate state: %w", err)
+	}
+	at := b.now()
+	for _, s := range states {
+		if at.Sub(s.UpdatedAt) > StaleAfter {
+			continue
+		}
+		if s.Minutes == nil {
+			s.Minutes = map[int64]int{}
+		}
+		s.expire(at)
+		b.states[rateKey(s.Provider, s.Host, s.Token)] = s
+	}
+	return b, nil
+}
+
+// SetMinuteBudget sets the requests per minute and token allowed for
+// provider; 0 removes the budget.
+func (b *RateBook) SetMinuteBudget(provider string, perMinute int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if perMinute <= 0 {
+		delete(b.budgets, provider)
+		return
+	}
+	b.budgets[provider] = perMinute
+}
+
+// SetMaxWait sets how long Wait blocks before failing.
+func (b *RateBook) SetMaxWait(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.maxWait = d
+}
+
+// Lookup returns a copy of the state of a token.
+func (b *RateBook) Lookup(provider, host, token string) (RateState, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s, ok := b.states[rateKey(provider, host, token)]
+	if !ok {
+		return RateState{}, false
+	}
+	s.expire(b.now())
+	c := *s
+	c.Minutes = make(map[int64]int, len(s.Minutes))
+	for k, v := range s.Minutes {
+		c.Minutes[k] = v
+	}
+	return c, true
+}
+
+// Delay returns how long a request with the token has to wait: until a
+// secondary limit block ends, the exhausted primary limit resets, or the
+// minute budget frees up.
+func (b *RateBook) Delay(provider, host, token string) (time.Duration, time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s, ok := b.states[rateKey(provider, host, token)]
+	if !ok {
+		return 0, time.Time{}
+	}
+	now := b.now()
+	s.expire(now)
+
+	var until time.Time
+	if !s.BlockedUntil.IsZero() {
+		until = s.BlockedUntil
+	}
+	if s.Remaining == 0 && s.Reset.After(until) {
+		until = s.Reset
+	}
+	if budget := b.budgets[provider]; budget > 0 && s.Minutes[now.Unix()/60] >= budget {
+		if next := now.Truncate(time.Minute).Add(time.Minute); next.After(until) {
+			until = next
+		}
+	}
+	if until.IsZero() {
+		return 0, time.Time{}
+	}
+	return until.Sub(now), until
+}
+
+// Wait blocks until a request with the token may be sent, or fails with a
+// RateLimitError when that is further away than the maximum wait.
+func (b *RateBook) Wait(ctx context.Context, provider, host, token string) error {
+	delay, until := b.Delay(provider, host, token)
+	if delay <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	maxWait := b.maxWait
+	b.mu.Unlock()
+	if delay > maxWait {
+		return &RateLimitError{Provider: provider, Host: host, Until: until}
+	}
+	return b.sleep(ctx, delay)
+}
+
+// Observe updates the state of the call's token from the response. resp
+// may be nil when the request failed without a response.
+func (b *RateBook) Observe(c Call, resp *http.Response) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := rateKey(c.Provider, c.Host, c.Token)
+	s, ok := b.states[key]
+	if !ok {
+		s = &RateState{
+			Provider: c.Provider, Host: c.Host, Token: c.Token,
+			Limit: -1, Remaining: -1, Minutes: map[int64]int{},
+		}
+		b.states[key] = s
+	}
+	now := b.now()
+	s.expire(now)
+	s.Minutes[c.Time.Unix()/60] += c.Cost
+	s.UpdatedAt = now
+
+	if resp == nil {
+		return
+	}
+	// GitHub and Gitea send X-RateLimit-*, GitLab RateLimit-*. Reset is
+	// epoch seconds for all three.
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		remaining, err := strconv.Atoi(resp.Header.Get(prefix + "Remaining"))
+		if err != nil {
+			continue
+		}
+		s.Remaining = remaining
+		if limit, err := strconv.Atoi(resp.Header.Get(prefix + "Limit")); err == nil {
+			s.Limit = limit
+		}
+		if reset, err := strconv.ParseInt(resp.Header.Get(prefix+"Reset"), 10, 64); err == nil {
+			s.Reset = time.Unix(reset, 0)
+		}
+		break
+	}
+	if c.Result == ResultRateLimited {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			s.BlockedUntil = now.Add(time.Duration(seconds) * time.Second)
+		}
+	}
+}
+
+// Save writes the book to its file, dropping stale state.
+func (b *RateBook) Save() error {
+	b.mu.Lock()
+	now := b.now()
+	states := make([]*RateState, 0, len(b.states))
+	for key, s := range b.states {
+		if now.Sub(s.UpdatedAt) > StaleAfter {
+			delete(b.states, key)
+			continue
+		}
+		s.expire(now)
+		states = append(states, s)
+	}
+	data, err := json.MarshalIndent(states, "", "  ")
+	b.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode rate state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create rate state directory: %w", err)
+	}
+	tmp := b.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write rate state: %w", err)
+	}
+	if err := os.Rename(tmp, b.path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write rate state: %w", err)
+	}
+	return nil
+}
+
+var defaultRates atomic.Pointer[RateBook]
+
+// SetDefaultRates installs the book consulted and updated by provider
+// transports; nil disables rate state.
+func SetDefaultRates(b *RateBook) {
+	defaultRates.Store(b)
+}
+
+// DefaultRates returns the installed book, or nil.
+func DefaultRates() *RateBook {
+	return defaultRates.Load()
+}
+
+func rateKey(provider, host, token string) string {
+	return provider + "|" + host + "|" + token
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
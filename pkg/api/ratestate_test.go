@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rateResponse(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: http.Header{}}
+	for k, v := range headers {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
+
+func TestRateBookPersistsAcrossRestarts(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 30, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "rate-state.json")
+	reset := now.Add(20 * time.Minute)
+
+	book := NewRateBook(path)
+	book.now = func() time.Time { return now }
+	call := Call{Time: now, Provider: "github", Host: "api.github.com", Token: Fingerprint("abc"), Cost: 1, Result: ResultOK}
+	book.Observe(call, rateResponse(http.StatusOK, map[string]string{
+		"X-RateLimit-Limit":     "5000",
+		"X-RateLimit-Remaining": "0",
+		"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+	}))
+	call.Cost = 5
+	book.Observe(call, nil)
+	require.NoError(t, book.Save())
+
+	restored, err := loadRateBook(path, func() time.Time { return now.Add(time.Minute) })
+	require.NoError(t, err)
+
+	state, ok := restored.Lookup("github", "api.github.com", Fingerprint("abc"))
+	require.True(t, ok)
+	assert.Equal(t, 5000, state.Limit)
+	assert.Equal(t, 0, state.Remaining)
+	assert.Equal(t, 6, state.RecentRequests(now.Add(time.Minute), time.Hour))
+
+	delay, until := restored.Delay("github", "api.github.com", Fingerprint("abc"))
+	assert.Equal(t, 19*time.Minute, delay)
+	assert.True(t, until.Equal(reset))
+
+	var rle *RateLimitError
+	require.True(t, errors.As(restored.Wait(context.Background(), "github", "api.github.com", Fingerprint("abc")), &rle))
+	assert.True(t, rle.Until.Equal(reset))
+
+	// Once the reset passed the budget is restored.
+	restored.now = func() time.Time { return reset.Add(time.Second) }
+	state, _ = restored.Lookup("github", "api.github.com", Fingerprint("abc"))
+	assert.Equal(t, 5000, state.Remaining)
+	delay, _ = restored.Delay("github", "api.github.com", Fingerprint("abc"))
+	assert.Zero(t, delay)
+}
+
+func TestLoadRateBookDropsStaleState(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "rate-state.json")
+
+	book := NewRateBook(path)
+	book.now = func() time.Time { return now.Add(-StaleAfter + time.Hour) }
+	book.Observe(Call{Time: book.now(), Provider: "gitlab", Host: "gitlab.com", Token: "old", Cost: 1}, nil)
+	book.now = func() time.Time { return now }
+	book.Observe(Call{Time: now, Provider: "gitlab", Host: "gitlab.com", Token: "new", Cost: 1}, nil)
+	require.NoError(t, book.Save())
+
+	restored, err := loadRateBook(path, func() time.Time { return now.Add(2 * time.Hour) })
+	require.NoError(t, err)
+	_, ok := restored.Lookup("gitlab", "gitlab.com", "old")
+	assert.False(t, ok, "state unused for longer than StaleAfter is dropped")
+	state, ok := restored.Lookup("gitlab", "gitlab.com", "new")
+	require.True(t, ok)
+	assert.Zero(t, state.RecentRequests(now.Add(2*time.Hour), time.Hour), "counts outside the rolling window are dropped")
+}
+
+func TestRateBookSecondaryLimits(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 10, 0, time.UTC)
+	book := NewRateBook(filepath.Join(t.TempDir(), "rate-state.json"))
+	book.now = func() time.Time { return now }
+
+	call := Call{Time: now, Provider: "github", Host: "api.github.com", Token: "t", Cost: 1, Result: ResultRateLimited}
+	book.Observe(call, rateResponse(http.StatusForbidden, map[string]string{"Retry-After": "30"}))
+	delay, _ := book.Delay("github", "api.github.com", "t")
+	assert.Equal(t, 30*time.Second, delay)
+
+	// The minute budget holds requests until the next minute.
+	book.SetMinuteBudget("github", 3)
+	now = now.Add(time.Minute)
+	for range 3 {
+		book.Observe(Call{Time: now, Provider: "github", Host: "api.github.com", Token: "t", Cost: 1, Result: ResultOK}, nil)
+	}
+	delay, _ = book.Delay("github", "api.github.com", "t")
+	assert.Equal(t, 50*time.Second, delay)
+
+	var slept time.Duration
+	book.sleep = func(_ context.Context, d time.Duration) error { slept = d; return nil }
+	require.NoError(t, book.Wait(context.Background(), "github", "api.github.com", "t"))
+	assert.Equal(t, 50*time.Second, slept)
+}
+
+func TestTransportUpdatesRateBook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("RateLimit-Limit", "2000")
+		w.Header().Set("RateLimit-Remaining", "1999")
+	}))
+	defer srv.Close()
+
+	book := NewRateBook(filepath.Join(t.TempDir(), "rate-state.json"))
+	SetDefaultRates(book)
+	defer SetDefaultRates(nil)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/api/v4/projects", nil)
+	require.NoError(t, err)
+	req.Header.Set("PRIVATE-TOKEN", "glpat")
+	resp, err := (&http.Client{Transport: Transport("gitlab", nil)}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	state, ok := book.Lookup("gitlab", req.URL.Host, Fingerprint("glpat"))
+	require.True(t, ok)
+	assert.Equal(t, 2000, state.Limit)
+	assert.Equal(t, 1999, state.Remaining)
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"sort"
+	"time"
+)
+
+// Filter narrows calls for a report. Zero values match everything.
+type Filter struct {
+	Since    time.Time
+	Provider string
+	Token    string
+}
+
+func (f Filter) match(c Call) bool {
+	if !f.Since.IsZero() && c.Time.Before(f.Since) {
+		return false
+	}
+	if f.Provider != "" && c.Provider != f.Provider {
+		return false
+	}
+	return f.Token == "" || c.Token == f.Token
+}
+
+// Group aggregates the calls of one provider, token, endpoint or command.
+type Group struct {
+	Key         string        `json:"key"`
+	Provider    string        `json:"provider,omitempty"`
+	Calls       int           `json:"calls"`
+	Cost        int           `json:"cost"`
+	Errors      int           `json:"errors"`
+	RateLimited int           `json:"rateLimited"`
+	AvgLatency  time.Duration `json:"avgLatency"`
+	// MinRemaining is the lowest rate limit left after a call, -1 when no
+	// call reported one.
+	MinRemaining int `json:"minRemaining"`
+
+	latency time.Duration
+}
+
+// Bucket is one interval of the usage trend.
+type Bucket struct {
+	Start       time.Time `json:"start"`
+	Calls       int       `json:"calls"`
+	Cost        int       `json:"cost"`
+	Errors      int       `json:"errors"`
+	RateLimited int       `json:"rateLimited"`
+}
+
+// Report summarizes recorded calls.
+type Report struct {
+	From        time.Time     `json:"from"`
+	To          time.Time     `json:"to"`
+	Calls       int           `json:"calls"`
+	Cost        int           `json:"cost"`
+	Errors      int           `json:"errors"`
+	RateLimited int           `json:"rateLimited"`
+	AvgLatency  time.Duration `json:"avgLatency"`
+
+	// Groups are ordered by cost, then calls, highest first; all but
+	// Providers are cut to the requested number of top consumers.
+	Providers []Group `json:"providers"`
+	Tokens    []Group `json:"tokens"`
+	Endpoints []Group `json:"endpoints"`
+	Commands  []Group `json:"commands"`
+
+	// RateLimitHits are the most recent rate limited calls, newest first.
+	RateLimitHits []Call `json:"rateLimitHits"`
+
+	// Trend has hourly buckets for up to two days of calls, daily ones
+	// otherwise.
+	Interval time.Duration `json:"interval"`
+	Trend    []Bucket      `json:"trend"`
+}
+
+// Summarize builds a report of the calls matching filter, keeping the top
+// consumers of each kind.
+func Summarize(calls []Call, filter Filter, top int) *Report {
+	r := &Report{}
+	providers := make(map[string]*Group)
+	tokens := make(map[string]*Group)
+	endpoints := make(map[string]*Group)
+	commands := make(map[string]*Group)
+	var matched []Call
+	var latency time.Duration
+
+	for _, c := range calls {
+		if !filter.match(c) {
+			continue
+		}
+		matched = append(matched, c)
+		if r.From.IsZero() || c.Time.Before(r.From) {
+			r.From = c.Time
+		}
+		if c.Time.After(r.To) {
+			r.To = c.Time
+		}
+		r.Calls++
+		r.Cost += c.Cost
+		latency += c.Latency
+		switch {
+		case c.Result == ResultRateLimited:
+			r.RateLimited++
+			r.RateLimitHits = append(r.RateLimitHits, c)
+		case isError(c):
+			r.Errors++
+		}
+
+		command := c.Command
+		if command == "" {
+			command = "(unknown)"
+		}
+		add(providers, c.Provider, "", c)
+		add(tokens, c.Provider+" "+c.Token, c.Provider, c)
+		add(endpoints, c.Method+" "+c.Class, c.Provider, c)
+		add(commands, command, "", c)
+	}
+	if r.Calls > 0 {
+		r.AvgLatency = latency / time.Duration(r.Calls)
+	}
+
+	r.Providers = sorted(providers, 0)
+	r.Tokens = sorted(tokens, top)
+	r.Endpoints = sorted(endpoints, top)
+	r.Commands = sorted(commands, top)
+
+	sort.SliceStable(r.RateLimitHits, func(i, j int) bool { return r.RateLimitHits[i].Time.After(r.RateLimitHits[j].Time) })
+	if top > 0 && len(r.RateLimitHits) > top {
+		r.RateLimitHits = r.RateLimitHits[:top]
+	}
+
+	r.Interval, r.Trend = trend(matched, r.From, r.To)
+	return r
+}
+
+func isError(c Call) bool {
+	switch c.Result {
+	case ResultClientError, ResultServerError, ResultError:
+		return true
+	default:
+		return false
+	}
+}
+
+// add counts c in the group of key; endpoint groups of different providers
+// are kept apart by keying them with the provider.
+func add(groups map[string]*Group, key, provider string, c Call) {
+	id := provider + "\x00" + key
+	g, ok := groups[id]
+	if !ok {
+		g = &Group{Key: key, Provider: provider, MinRemaining: -1}
+		groups[id] = g
+	}
+	g.Calls++
+	g.Cost += c.Cost
+	g.latency += c.Latency
+	switch {
+	case c.Result == ResultRateLimited:
+		g.RateLimited++
+	case isError(c):
+		g.Errors++
+	}
+	if c.Remaining >= 0 && (g.MinRemaining < 0 || c.Remaining < g.MinRemaining) {
+		g.MinRemaining = c.Remaining
+	}
+}
+
+func sorted(groups map[string]*Group, top int) []Group {
+	out := make([]Group, 0, len(groups))
+	for _, g := range groups {
+		g.AvgLatency = g.latency / time.Duration(g.Calls)
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Cost != b.Cost {
+			return a.Cost > b.Cost
+		}
+		if a.Calls != b.Calls {
+			return a.Calls > b.Calls
+		}
+		return a.Provider+a.Key < b.Provider+b.Key
+	})
+	if top > 0 && len(out) > top {
+		out = out[:top]
+	}
+	return out
+}
+
+// trend buckets calls by hour or day of their local time.
+func trend(calls []Call, from, to time.Time) (time.Duration, []Bucket) {
+	if len(calls) == 0 {
+		return 0, nil
+	}
+	interval := 24 * time.Hour
+	if to.Sub(from) <= 48*time.Hour {
+		interval = time.Hour
+	}
+	truncate := func(t time.Time) time.Time {
+		t = t.Local()
+		if interval == time.Hour {
+			return t.Truncate(time.Hour)
+		}
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+
+	var buckets []Bucket
+	index := make(map[time.Time]int)
+	for start := truncate(from); !start.After(to); start = next(start, interval) {
+		index[start] = len(buckets)
+		buckets = append(buckets, Bucket{Start: start})
+	}
+	for _, c := range calls {
+		i, ok := index[truncate(c.Time)]
+		if !ok {
+			continue
+		}
+		b := &buckets[i]
+		b.Calls++
+		b.Cost += c.Cost
+		switch {
+		case c.Result == ResultRateLimited:
+			b.RateLimited++
+		case isError(c):
+			b.Errors++
+		}
+	}
+	return interval, buckets
+}
+
+// next advances a bucket start; days follow the calendar so that daylight
+// saving changes do not shift the buckets.
+func next(t time.Time, interval time.Duration) time.Time {
+	if interval == time.Hour {
+		return t.Add(time.Hour)
+	}
+	return t.AddDate(0, 0, 1)
+}
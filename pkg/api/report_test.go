@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2025, 6, 1, 10, 0, 0, 0, time.Local)
+	call := func(offset time.Duration, provider, token, class, result string, cost, remaining int) Call {
+		return Call{
+			Time: start.Add(offset), Provider: provider, Method: "GET", Class: class, Token: token,
+			Cost: cost, Latency: 100 * time.Millisecond, Result: result, Remaining: remaining, Command: "gz synclone",
+		}
+	}
+	calls := []Call{
+		call(0, "github", "aaaa", "repos/pulls", ResultOK, 1, 4000),
+		call(time.Minute, "github", "aaaa", "repos/pulls", ResultOK, 1, 3999),
+		call(2*time.Minute, "github", "aaaa", "graphql", ResultOK, 10, 3989),
+		call(3*time.Hour, "github", "bbbb", "repos/pulls", ResultRateLimited, 1, 0),
+		call(4*time.Hour, "gitlab", "cccc", "projects", ResultServerError, 1, -1),
+	}
+
+	r := Summarize(calls, Filter{}, 2)
+	assert.Equal(t, 5, r.Calls)
+	assert.Equal(t, 14, r.Cost)
+	assert.Equal(t, 1, r.RateLimited)
+	assert.Equal(t, 1, r.Errors)
+	assert.Equal(t, 100*time.Millisecond, r.AvgLatency)
+
+	require.Len(t, r.Providers, 2)
+	assert.Equal(t, "github", r.Providers[0].Key)
+	assert.Equal(t, 13, r.Providers[0].Cost)
+	assert.Equal(t, 0, r.Providers[0].MinRemaining)
+
+	require.Len(t, r.Tokens, 2, "cut to the top consumers")
+	assert.Equal(t, "github aaaa", r.Tokens[0].Key)
+	assert.Equal(t, 12, r.Tokens[0].Cost)
+	assert.Equal(t, 3989, r.Tokens[0].MinRemaining)
+
+	assert.Equal(t, "GET graphql", r.Endpoints[0].Key)
+	assert.Equal(t, "GET repos/pulls", r.Endpoints[1].Key)
+	assert.Equal(t, 1, r.Endpoints[1].RateLimited)
+
+	require.Len(t, r.RateLimitHits, 1)
+	assert.Equal(t, "bbbb", r.RateLimitHits[0].Token)
+
+	assert.Equal(t, time.Hour, r.Interval)
+	require.Len(t, r.Trend, 5)
+	assert.Equal(t, 3, r.Trend[0].Calls)
+	assert.Equal(t, 1, r.Trend[3].RateLimited)
+	assert.Equal(t, 1, r.Trend[4].Errors)
+
+	gitlab := Summarize(calls, Filter{Provider: "gitlab"}, 0)
+	assert.Equal(t, 1, gitlab.Calls)
+	assert.Equal(t, -1, gitlab.Providers[0].MinRemaining)
+
+	assert.Zero(t, Summarize(calls, Filter{Since: start.Add(5 * time.Hour)}, 0).Calls)
+}
+
+func TestSummarizeDailyTrend(t *testing.T) {
+	start := time.Date(2025, 6, 1, 10, 0, 0, 0, time.Local)
+	calls := []Call{
+		{Time: start, Cost: 1},
+		{Time: start.AddDate(0, 0, 4), Cost: 1},
+	}
+	r := Summarize(calls, Filter{}, 0)
+	assert.Equal(t, 24*time.Hour, r.Interval)
+	require.Len(t, r.Trend, 5)
+	assert.Equal(t, 1, r.Trend[4].Calls)
+}
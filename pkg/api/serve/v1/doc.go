@@ -0,0 +1,16 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package servev1 contains the protobuf messages and gRPC clients and
+// servers of the `gz serve` API, generated from serve.proto.
+//
+// Connect to a server started with --grpc-addr and pass the API token as
+// "authorization: Bearer <token>" metadata:
+//
+//	conn, err := grpc.NewClient("localhost:9090", grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	jobs := servev1.NewJobServiceClient(conn)
+//	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+//	job, err := jobs.SubmitJob(ctx, &servev1.SubmitJobRequest{Type: "token-check"})
+package servev1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative serve.proto
@@ -0,0 +1,170 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Retention limits of a Store.
+const (
+	DefaultRetention = 30 * 24 * time.Hour
+	DefaultMaxCalls  = 200000
+)
+
+// Enabled reports whether recording is enabled in the environment.
+func Enabled() bool {
+	switch strings.ToLower(os.Getenv(DisableEnv)) {
+	case "0", "false", "off":
+		return false
+	default:
+		return true
+	}
+}
+
+// DefaultPath returns ~/.gzh/api-usage.db.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".gzh", "api-usage.db"), nil
+}
+
+// Store is a rolling JSON-lines file of Calls. Calls older than the
+// retention period or beyond the newest MaxCalls are dropped as the file
+// grows.
+type Store struct {
+	path      string
+	retention time.Duration
+	maxCalls  int
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+// NewStore opens the store at path. The file is created on first append.
+func NewStore(path string) *Store {
+	return &Store{path: path, retention: DefaultRetention, maxCalls: DefaultMaxCalls, now: time.Now}
+}
+
+// Path returns the store file path.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Append adds calls and prunes the store once it is plausibly over its
+// limits.
+func (s *Store) Append(calls []Call) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create api usage directory: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open api usage store: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, c := range calls {
+		if err := enc.Encode(c); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("failed to encode api call: %w", err)
+		}
+	}
+	werr := w.Flush()
+	cerr := f.Close()
+	if werr != nil {
+		return fmt.Errorf("failed to write api usage: %w", werr)
+	}
+	if cerr != nil {
+		return fmt.Errorf("failed to write api usage: %w", cerr)
+	}
+
+	// Calls are about 250 bytes; expired ones are only dropped when the
+	// file is rewritten anyway.
+	if info, err := os.Stat(s.path); err == nil && info.Size() > int64(s.maxCalls)*300 {
+		return s.prune()
+	}
+	return nil
+}
+
+// Load returns the calls within the retention period, oldest first.
+// Unparseable lines are skipped.
+func (s *Store) Load() ([]Call, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+func (s *Store) load() ([]Call, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open api usage store: %w", err)
+	}
+	defer f.Close()
+
+	cutoff := s.now().Add(-s.retention)
+	var calls []Call
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var c Call
+		if err := json.Unmarshal(scanner.Bytes(), &c); err != nil || c.Time.Before(cutoff) {
+			continue
+		}
+		calls = append(calls, c)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read api usage store: %w", err)
+	}
+	return calls, nil
+}
+
+func (s *Store) prune() error {
+	calls, err := s.load()
+	if err != nil {
+		return err
+	}
+	if len(calls) > s.maxCalls {
+		calls = calls[len(calls)-s.maxCalls:]
+	}
+
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to prune api usage store: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, c := range calls {
+		if err := enc.Encode(c); err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmp)
+			return fmt.Errorf("failed to prune api usage store: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to prune api usage store: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to prune api usage store: %w", err)
+	}
+	return os.Rename(tmp, s.path)
+}
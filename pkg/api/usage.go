@@ -0,0 +1,302 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package api records the provider API calls gz makes so that heavy
+// consumers and rate limit hits can be analyzed after the fact.
+//
+// Every request sent through a provider transport (see
+// internal/httpclient.ProviderTransport) is recorded by the default Tracker
+// with its endpoint class, a fingerprint of the token, its rate limit cost,
+// latency and result. Tokens themselves are never stored.
+//
+// The default RateBook keeps the last known rate limit of each token across
+// runs, and provider transports wait for it before sending requests.
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DisableEnv disables usage recording when set to "0", "false" or "off".
+const DisableEnv = "GZH_API_USAGE"
+
+// Call results.
+const (
+	ResultOK          = "ok"
+	ResultNotModified = "not_modified"
+	ResultClientError = "client_error"
+	ResultServerError = "server_error"
+	ResultRateLimited = "rate_limited"
+	ResultError       = "error" // transport error, no response
+)
+
+// AnonymousToken is the fingerprint of unauthenticated calls.
+const AnonymousToken = "anonymous"
+
+// Call is one provider API request.
+type Call struct {
+	Time     time.Time `json:"time"`
+	Provider string    `json:"provider"`
+	Host     string    `json:"host,omitempty"`
+	Method   string    `json:"method"`
+	// Class is the endpoint without its parameters, e.g. "repos/pulls".
+	Class string `json:"class"`
+	// Token is a fingerprint of the credential, see TokenFingerprint.
+	Token string `json:"token"`
+	// Cost is the rate limit points the call is charged, 1 unless the
+	// caller set it with WithCost.
+	Cost    int           `json:"cost"`
+	Latency time.Duration `json:"latency"`
+	Status  int           `json:"status,omitempty"`
+	Result  string        `json:"result"`
+	// Remaining is the rate limit left after the call, -1 when unknown.
+	Remaining int    `json:"remaining"`
+	Command   string `json:"command,omitempty"`
+}
+
+// flushThreshold bounds the calls buffered by a Tracker between flushes.
+const flushThreshold = 1000
+
+// Tracker buffers calls and appends them to a Store. It is safe for
+// concurrent use.
+type Tracker struct {
+	store   *Store
+	mu      sync.Mutex
+	command string
+	calls   []Call
+}
+
+// NewTracker returns a tracker writing to store.
+func NewTracker(store *Store) *Tracker {
+	return &Tracker{store: store}
+}
+
+var defaultTracker atomic.Pointer[Tracker]
+
+// SetDefault installs the tracker used by provider transports; nil stops
+// recording.
+func SetDefault(t *Tracker) {
+	defaultTracker.Store(t)
+}
+
+// Default returns the installed tracker, or nil.
+func Default() *Tracker {
+	return defaultTracker.Load()
+}
+
+// SetCommand attributes the following calls to a gz command.
+func (t *Tracker) SetCommand(command string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.command = command
+}
+
+// Record buffers a call, flushing once many calls have accumulated so that
+// long-running commands do not hold them all in memory.
+func (t *Tracker) Record(c Call) {
+	t.mu.Lock()
+	if c.Command == "" {
+		c.Command = t.command
+	}
+	t.calls = append(t.calls, c)
+	full := len(t.calls) >= flushThreshold
+	t.mu.Unlock()
+
+	if full {
+		_ = t.Flush() //nolint:errcheck // Recording never fails a call
+	}
+}
+
+// Flush appends the buffered calls to the store.
+func (t *Tracker) Flush() error {
+	t.mu.Lock()
+	calls := t.calls
+	t.calls = nil
+	t.mu.Unlock()
+
+	if len(calls) == 0 || t.store == nil {
+		return nil
+	}
+	return t.store.Append(calls)
+}
+
+type costKey struct{}
+
+// WithCost sets the rate limit cost of the requests made with ctx, for calls
+// such as GraphQL queries that are charged more than one point.
+func WithCost(ctx context.Context, points int) context.Context {
+	return context.WithValue(ctx, costKey{}, points)
+}
+
+func costOf(ctx context.Context) int {
+	if points, ok := ctx.Value(costKey{}).(int); ok && points > 0 {
+		return points
+	}
+	return 1
+}
+
+// Transport wraps rt so that every request is recorded by the default
+// tracker and rate book, when they are installed, and waits for limits the
+// rate book knows to be exhausted. A nil rt uses http.DefaultTransport.
+func Transport(provider string, rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &trackingTransport{provider: provider, next: rt}
+}
+
+type trackingTransport struct {
+	provider string
+	next     http.RoundTripper
+}
+
+// CloseIdleConnections forwards to the wrapped transport.
+func (t *trackingTransport) CloseIdleConnections() {
+	if c, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+func (t *trackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	tracker, rates := Default(), DefaultRates()
+	if tracker == nil && rates == nil {
+		return t.next.RoundTrip(req)
+	}
+
+	if rates != nil {
+		if err := rates.Wait(req.Context(), t.provider, req.URL.Host, TokenFingerprint(req)); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	call := NewCall(t.provider, req, resp, time.Since(start), start)
+	if tracker != nil {
+		tracker.Record(call)
+	}
+	if rates != nil {
+		rates.Observe(call, resp)
+	}
+	return resp, err
+}
+
+// NewCall describes a finished request; resp is nil when no response was
+// received.
+func NewCall(provider string, req *http.Request, resp *http.Response, latency time.Duration, at time.Time) Call {
+	c := Call{
+		Time:      at,
+		Provider:  provider,
+		Host:      req.URL.Host,
+		Method:    req.Method,
+		Class:     EndpointClass(req.URL.EscapedPath()),
+		Token:     TokenFingerprint(req),
+		Cost:      costOf(req.Context()),
+		Latency:   latency,
+		Result:    ResultError,
+		Remaining: -1,
+	}
+	if resp == nil {
+		return c
+	}
+
+	c.Status = resp.StatusCode
+	// GitHub and Gitea send X-RateLimit-*, GitLab RateLimit-*.
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		if remaining, err := strconv.Atoi(resp.Header.Get(prefix + "Remaining")); err == nil {
+			c.Remaining = remaining
+			break
+		}
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && (c.Remaining == 0 || resp.Header.Get("Retry-After") != ""):
+		c.Result = ResultRateLimited
+	case resp.StatusCode == http.StatusNotModified:
+		c.Result = ResultNotModified
+	case resp.StatusCode >= 500:
+		c.Result = ResultServerError
+	case resp.StatusCode >= 400:
+		c.Result = ResultClientError
+	default:
+		c.Result = ResultOK
+	}
+	return c
+}
+
+// apiPrefixes are leading path segments that only select the API version.
+var apiPrefixes = map[string]bool{"api": true, "v1": true, "v3": true, "v4": true, "a": true}
+
+// EndpointClass reduces a request path to its resource and sub-resource,
+// dropping owners, names and IDs: /repos/acme/api/pulls/7 becomes
+// "repos/pulls", /api/v4/projects/12/merge_requests "projects/merge_requests"
+// and /search/code "search/code". GitHub and Gitea address repositories with
+// two segments, the other resources with one.
+func EndpointClass(path string) string {
+	var segs []string
+	for _, s := range strings.Split(path, "/") {
+		if s != "" {
+			segs = append(segs, s)
+		}
+	}
+	for len(segs) > 0 && apiPrefixes[segs[0]] {
+		segs = segs[1:]
+	}
+	if len(segs) == 0 {
+		return "/"
+	}
+
+	sub := -1
+	switch segs[0] {
+	case "repos":
+		sub = 3
+	case "search", "user", "rate_limit", "graphql", "app", "meta":
+		sub = 1
+	default:
+		sub = 2
+	}
+	if sub < len(segs) {
+		return segs[0] + "/" + segs[sub]
+	}
+	return segs[0]
+}
+
+// TokenFingerprint identifies the credential of a request by the first
+// eight hex digits of its SHA-256 hash.
+func TokenFingerprint(req *http.Request) string {
+	token := req.Header.Get("PRIVATE-TOKEN")
+	if auth := req.Header.Get("Authorization"); token == "" && auth != "" {
+		scheme, credential, ok := strings.Cut(auth, " ")
+		switch {
+		case !ok:
+			token = auth
+		case strings.EqualFold(scheme, "Basic"):
+			// user:token; the user is not part of the secret identity
+			if decoded, err := base64.StdEncoding.DecodeString(credential); err == nil {
+				_, password, _ := strings.Cut(string(decoded), ":")
+				token = password
+			}
+		default:
+			token = credential
+		}
+	}
+	return Fingerprint(strings.TrimSpace(token))
+}
+
+// Fingerprint returns the fingerprint of a token, or AnonymousToken.
+func Fingerprint(token string) string {
+	if token == "" {
+		return AnonymousToken
+	}
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointClass(t *testing.T) {
+	tests := map[string]string{
+		"/repos/acme/api/pulls/7/comments":           "repos/pulls",
+		"/repos/acme/api":                            "repos",
+		"/orgs/acme/repos":                           "orgs/repos",
+		"/api/v4/projects/acme%2Fapi/merge_requests": "projects/merge_requests",
+		"/api/v4/groups/12":                          "groups",
+		"/api/v1/repos/acme/api/issues":              "repos/issues",
+		"/search/code":                               "search/code",
+		"/graphql":                                   "graphql",
+		"/a/changes/":                                "changes",
+		"/user/repos":                                "user/repos",
+		"/":                                          "/",
+		"/api/v3/repos/acme/api/actions/runs/99/attempts": "repos/actions",
+	}
+	for path, want := range tests {
+		assert.Equal(t, want, EndpointClass(path), path)
+	}
+}
+
+func TestTokenFingerprint(t *testing.T) {
+	req := func(header, value string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "https://api.github.com/user", nil)
+		if header != "" {
+			r.Header.Set(header, value)
+		}
+		return r
+	}
+
+	fp := Fingerprint("ghp_secret")
+	assert.Len(t, fp, 8)
+	assert.Equal(t, fp, TokenFingerprint(req("Authorization", "token ghp_secret")))
+	assert.Equal(t, fp, TokenFingerprint(req("Authorization", "Bearer ghp_secret")))
+	assert.Equal(t, fp, TokenFingerprint(req("PRIVATE-TOKEN", "ghp_secret")))
+	basic := httptest.NewRequest(http.MethodGet, "https://gitea.example.com/api/v1/user", nil)
+	basic.SetBasicAuth("x-access-token", "ghp_secret")
+	assert.Equal(t, fp, TokenFingerprint(basic))
+	assert.Equal(t, AnonymousToken, TokenFingerprint(req("", "")))
+}
+
+func TestTransportRecordsCalls(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/limited":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Header().Set("RateLimit-Remaining", "4999")
+		}
+	}))
+	defer srv.Close()
+
+	store := NewStore(filepath.Join(t.TempDir(), "api-usage.db"))
+	tracker := NewTracker(store)
+	tracker.SetCommand("gz git repo sync")
+	SetDefault(tracker)
+	defer SetDefault(nil)
+
+	client := &http.Client{Transport: Transport("github", nil)}
+	get := func(ctx context.Context, path string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "token abc")
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	get(context.Background(), "/orgs/acme/repos")
+	get(WithCost(context.Background(), 5), "/graphql")
+	get(context.Background(), "/limited")
+	get(context.Background(), "/missing")
+
+	require.NoError(t, tracker.Flush())
+	calls, err := store.Load()
+	require.NoError(t, err)
+	require.Len(t, calls, 4)
+
+	assert.Equal(t, "orgs/repos", calls[0].Class)
+	assert.Equal(t, ResultOK, calls[0].Result)
+	assert.Equal(t, 4999, calls[0].Remaining)
+	assert.Equal(t, Fingerprint("abc"), calls[0].Token)
+	assert.Equal(t, "gz git repo sync", calls[0].Command)
+	assert.Equal(t, 5, calls[1].Cost)
+	assert.Equal(t, ResultRateLimited, calls[2].Result)
+	assert.Equal(t, ResultClientError, calls[3].Result)
+}
+
+func TestStoreRetention(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	store := NewStore(filepath.Join(t.TempDir(), "api-usage.db"))
+	store.now = func() time.Time { return now }
+	store.maxCalls = 3
+
+	var calls []Call
+	for i := 3; i >= 0; i-- {
+		calls = append(calls, Call{Time: now.Add(-time.Duration(i) * time.Hour), Class: "repos"})
+	}
+	calls = append([]Call{{Time: now.AddDate(0, -2, 0), Class: "expired"}}, calls...)
+	require.NoError(t, store.Append(calls))
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Len(t, loaded, 4, "expired calls are not loaded")
+
+	require.NoError(t, store.prune())
+	loaded, err = store.Load()
+	require.NoError(t, err)
+	assert.Len(t, loaded, 3)
+	assert.Equal(t, now.Add(-2*time.Hour), loaded[0].Time, "the newest calls are kept")
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package async provides the in-process event bus that connects producers
+// of events, such as the webhook endpoints of gz serve, to the components
+// reacting to them, such as the repository caches.
+//
+// Events do not leave the process. gz processes on one machine share their
+// caches on disk, so evicting an entry there reaches all of them; the caches
+// have no shared server tier, so there is nothing to propagate to other
+// machines, over Redis pub/sub or otherwise.
+package async
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Bus delivers published events to the handlers subscribed to their type.
+// It is safe for concurrent use.
+type Bus struct {
+	mu          sync.RWMutex
+	nextID      int
+	subscribers []subscriber
+}
+
+type subscriber struct {
+	id     int
+	handle func(ctx context.Context, event any) error
+}
+
+// NewBus creates an empty bus.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers fn for events of type E until the returned function
+// is called. Handlers run in subscription order on the publishing
+// goroutine.
+func Subscribe[E any](b *Bus, fn func(context.Context, E) error) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subscribers = append(b.subscribers, subscriber{
+		id: id,
+		handle: func(ctx context.Context, event any) error {
+			typed, ok := event.(E)
+			if !ok {
+				return nil
+			}
+			return fn(ctx, typed)
+		},
+	})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, s := range b.subscribers {
+			if s.id == id {
+				// 진행 중인 Publish가 보는 슬라이스는 바꾸지 않는다
+				b.subscribers = append(b.subscribers[:i:i], b.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish passes event to every handler subscribed to its type and returns
+// their errors joined. It returns once all handlers have run.
+func (b *Bus) Publish(ctx context.Context, event any) error {
+	b.mu.RLock()
+	subscribers := b.subscribers
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subscribers {
+		if err := s.handle(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
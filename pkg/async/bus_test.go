@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package async
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type created struct{ name string }
+
+type deleted struct{ name string }
+
+func TestBus(t *testing.T) {
+	bus := NewBus()
+	ctx := context.Background()
+
+	var got []string
+	Subscribe(bus, func(_ context.Context, e created) error {
+		got = append(got, "created "+e.name)
+		return nil
+	})
+	unsubscribe := Subscribe(bus, func(_ context.Context, e deleted) error {
+		got = append(got, "deleted "+e.name)
+		return errors.New("boom")
+	})
+	Subscribe(bus, func(_ context.Context, e created) error {
+		got = append(got, "created again "+e.name)
+		return nil
+	})
+
+	assert.NoError(t, bus.Publish(ctx, created{"a"}))
+	assert.EqualError(t, bus.Publish(ctx, deleted{"b"}), "boom")
+	assert.Equal(t, []string{"created a", "created again a", "deleted b"}, got)
+
+	// 구독을 끊으면 더 이상 전달되지 않는다
+	unsubscribe()
+	assert.NoError(t, bus.Publish(ctx, deleted{"c"}))
+	assert.NoError(t, bus.Publish(ctx, "unrelated"))
+	assert.Len(t, got, 3)
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package audit ships audit events to external SIEM systems. Events are
+// spooled to disk before delivery and removed only after a sink acknowledges
+// them, so every configured sink receives each event at least once.
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Sink types supported by NewSink.
+const (
+	SinkTypeSplunk  = "splunk"
+	SinkTypeElastic = "elastic"
+	SinkTypeSyslog  = "syslog"
+)
+
+// Config configures audit log shipping. It is embedded in the unified
+// configuration under the "audit" key.
+type Config struct {
+	// Enable shipping to the configured sinks
+	Enabled bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// Directory holding undelivered events
+	SpoolDir string `yaml:"spool_dir,omitempty" json:"spoolDir,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Maximum events per delivery batch
+	BatchSize int `yaml:"batch_size,omitempty" json:"batchSize,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Maximum time an event waits before its batch is flushed
+	FlushInterval time.Duration `yaml:"flush_interval,omitempty" json:"flushInterval,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Delivery attempts per batch before waiting for the next flush
+	MaxRetries int `yaml:"max_retries,omitempty" json:"maxRetries,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Initial backoff between attempts, doubled after each failure
+	RetryBackoff time.Duration `yaml:"retry_backoff,omitempty" json:"retryBackoff,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Destinations events are shipped to
+	Sinks []SinkConfig `yaml:"sinks,omitempty" json:"sinks,omitempty"`
+}
+
+// SinkConfig configures a single SIEM destination. Which fields apply
+// depends on Type.
+type SinkConfig struct {
+	// Unique name, used for the spool subdirectory
+	Name string `yaml:"name" json:"name"`
+
+	// Sink type: splunk, elastic or syslog
+	Type string `yaml:"type" json:"type"`
+
+	// Endpoint URL (splunk, elastic)
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
+
+	// HEC token (splunk)
+	Token string `yaml:"token,omitempty" json:"token,omitempty"`
+
+	// Target index (splunk, elastic)
+	Index string `yaml:"index,omitempty" json:"index,omitempty"`
+
+	// Event sourcetype (splunk)
+	SourceType string `yaml:"source_type,omitempty" json:"sourceType,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Basic auth credentials (elastic)
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	Password string `yaml:"password,omitempty" json:"password,omitempty"`
+
+	// API key, sent as "ApiKey <key>" (elastic)
+	APIKey string `yaml:"api_key,omitempty" json:"apiKey,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Transport: udp, tcp or tls (syslog)
+	Network string `yaml:"network,omitempty" json:"network,omitempty"`
+
+	// host:port of the collector (syslog)
+	Address string `yaml:"address,omitempty" json:"address,omitempty"`
+
+	// APP-NAME header field (syslog)
+	AppName string `yaml:"app_name,omitempty" json:"appName,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Facility keyword such as local0 or auth (syslog)
+	Facility string `yaml:"facility,omitempty" json:"facility,omitempty"`
+
+	// Skip TLS certificate verification
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify,omitempty" json:"insecureSkipVerify,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Per-request timeout
+	Timeout time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// DefaultConfig returns the default audit shipping configuration. Shipping is
+// disabled until sinks are configured.
+func DefaultConfig() *Config {
+	homeDir, _ := os.UserHomeDir()
+
+	return &Config{
+		Enabled:       false,
+		SpoolDir:      filepath.Join(homeDir, ".config", "gzh-manager", "audit", "spool"),
+		BatchSize:     100,
+		FlushInterval: 5 * time.Second,
+		MaxRetries:    5,
+		RetryBackoff:  time.Second,
+	}
+}
+
+// withDefaults returns a copy of c with unset fields taken from DefaultConfig.
+func (c Config) withDefaults() Config {
+	d := DefaultConfig()
+	if c.SpoolDir == "" {
+		c.SpoolDir = d.SpoolDir
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = d.BatchSize
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = d.FlushInterval
+	}
+	if c.MaxRetries <= 0 {
+		c.MaxRetries = d.MaxRetries
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = d.RetryBackoff
+	}
+	return c
+}
+
+// Validate checks that every sink has a unique name and the fields its type
+// requires.
+func (c *Config) Validate() error {
+	seen := make(map[string]bool, len(c.Sinks))
+	for i, s := range c.Sinks {
+		if s.Name == "" {
+			return fmt.Errorf("audit sink %d: name is required", i)
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("audit sink %q: duplicate name", s.Name)
+		}
+		seen[s.Name] = true
+
+		switch s.Type {
+		case SinkTypeSplunk:
+			if s.URL == "" || s.Token == "" {
+				return fmt.Errorf("audit sink %q: splunk requires url and token", s.Name)
+			}
+		case SinkTypeElastic:
+			if s.URL == "" || s.Index == "" {
+				return fmt.Errorf("audit sink %q: elastic requires url and index", s.Name)
+			}
+		case SinkTypeSyslog:
+			if s.Address == "" {
+				return fmt.Errorf("audit sink %q: syslog requires address", s.Name)
+			}
+			switch s.Network {
+			case "", "udp", "tcp", "tls":
+			default:
+				return fmt.Errorf("audit sink %q: unsupported syslog network %q", s.Name, s.Network)
+			}
+		default:
+			return fmt.Errorf("audit sink %q: unsupported type %q", s.Name, s.Type)
+		}
+	}
+	return nil
+}
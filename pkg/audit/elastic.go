@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ElasticSink indexes events through the Elasticsearch bulk API. Documents
+// are indexed under the event ID, so redelivered batches overwrite rather
+// than duplicate.
+type ElasticSink struct {
+	name     string
+	endpoint string
+	index    string
+	username string
+	password string
+	apiKey   string
+	client   *http.Client
+}
+
+type elasticBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error,omitempty"`
+	} `json:"items"`
+}
+
+// NewElasticSink creates an Elasticsearch bulk API sink.
+func NewElasticSink(cfg SinkConfig) *ElasticSink {
+	endpoint := strings.TrimRight(cfg.URL, "/")
+	if !strings.HasSuffix(endpoint, "/_bulk") {
+		endpoint += "/_bulk"
+	}
+
+	return &ElasticSink{
+		name:     cfg.Name,
+		endpoint: endpoint,
+		index:    cfg.Index,
+		username: cfg.Username,
+		password: cfg.Password,
+		apiKey:   cfg.APIKey,
+		client:   newHTTPClient(cfg),
+	}
+}
+
+// Name returns the configured sink name.
+func (s *ElasticSink) Name() string { return s.name }
+
+// Send indexes the batch in a single bulk request. Per-item failures are
+// retried unless every failure is a non-retryable client error.
+func (s *ElasticSink) Send(ctx context.Context, events []Event) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, e := range events {
+		action := map[string]any{"index": map[string]string{"_index": s.index, "_id": e.ID}}
+		if err := enc.Encode(action); err != nil {
+			return &PermanentError{Err: err}
+		}
+		doc := struct {
+			Event
+			Timestamp string `json:"@timestamp"`
+		}{Event: e, Timestamp: e.Timestamp.UTC().Format("2006-01-02T15:04:05.000Z07:00")}
+		if err := enc.Encode(doc); err != nil {
+			return &PermanentError{Err: fmt.Errorf("failed to encode event %s: %w", e.ID, err)}
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, &body)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	switch {
+	case s.apiKey != "":
+		req.Header.Set("Authorization", "ApiKey "+s.apiKey)
+	case s.username != "":
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("elasticsearch bulk request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return classifyStatus("elasticsearch", resp.StatusCode, truncate(string(respBody), 512))
+	}
+
+	var result elasticBulkResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("failed to parse elasticsearch bulk response: %w", err)
+	}
+	if !result.Errors {
+		return nil
+	}
+
+	failed, retryable := 0, false
+	var first string
+	for _, item := range result.Items {
+		for _, r := range item {
+			if r.Status < 300 {
+				continue
+			}
+			failed++
+			if r.Status == http.StatusTooManyRequests || r.Status >= 500 {
+				retryable = true
+			}
+			if first == "" && r.Error != nil {
+				first = r.Error.Type + ": " + r.Error.Reason
+			}
+		}
+	}
+
+	err = fmt.Errorf("elasticsearch rejected %d of %d events: %s", failed, len(events), first)
+	if retryable {
+		return err
+	}
+	return &PermanentError{Err: err}
+}
+
+// Close releases idle connections.
+func (s *ElasticSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
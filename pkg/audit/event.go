@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/trace"
+)
+
+// Severity of an audit event, aligned with the change logger levels.
+type Severity string
+
+const (
+	SeverityDebug Severity = "debug"
+	SeverityInfo  Severity = "info"
+	SeverityWarn  Severity = "warn"
+	SeverityError Severity = "error"
+)
+
+// Event is a single audit record shipped to SIEM sinks.
+type Event struct {
+	// ID uniquely identifies the event; sinks use it to deduplicate redeliveries
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Severity  Severity  `json:"severity"`
+	Action    string    `json:"action"`
+	Category  string    `json:"category,omitempty"`
+	Actor     string    `json:"actor,omitempty"`
+	Source    string    `json:"source,omitempty"`
+	Resource  string    `json:"resource,omitempty"`
+	Outcome   string    `json:"outcome,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	RequestID string    `json:"requestId,omitempty"`
+	// TraceID is the trace ID of the gz run that produced the event
+	TraceID    string         `json:"traceId,omitempty"`
+	Metadata   map[string]any `json:"metadata,omitempty"`
+	ErrMessage string         `json:"error,omitempty"`
+}
+
+// normalize fills in the ID, timestamp, severity and trace ID when unset.
+func (e *Event) normalize() {
+	if e.ID == "" {
+		e.ID = NewEventID()
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+	if e.Severity == "" {
+		e.Severity = SeverityInfo
+	}
+	if e.TraceID == "" {
+		e.TraceID = trace.ID()
+	}
+}
+
+// NewEventID returns a random event ID. Callers that record an event with
+// several recorders set it up front, so that sinks can deduplicate it.
+func NewEventID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().UTC().Format("20060102T150405.000000000")
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package audit
+
+// Recorder receives audit events. *Shipper implements it, as do the local
+// audit logs of the git subcommands.
+type Recorder interface {
+	Record(event Event) error
+}
+
+// Recorders fans events out to several recorders.
+type Recorders []Recorder
+
+// Record passes event to every recorder and returns the first error.
+func (rs Recorders) Record(event Event) error {
+	var first error
+	for _, r := range rs {
+		if err := r.Record(event); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,436 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	currentSegment = "current.jsonl"
+	batchSuffix    = ".batch"
+	deadLetterDir  = "dead"
+)
+
+// SinkStats reports delivery progress for one sink.
+type SinkStats struct {
+	Name         string
+	Delivered    int64
+	DeadLettered int64
+	Pending      int
+	LastError    string
+}
+
+// Shipper spools audit events to disk and delivers them to each sink in
+// batches. An event is removed from a sink's spool only after that sink
+// accepts it, so events survive collector outages and process restarts.
+// Retryable failures back off exponentially; permanent failures move the
+// batch to a dead-letter directory for inspection.
+type Shipper struct {
+	config Config
+	queues []*sinkQueue
+
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// sinkQueue is the on-disk spool for a single sink. Events are appended to
+// current.jsonl, which is sealed into a sequence-numbered .batch file when it
+// reaches the batch size or the flush interval elapses.
+type sinkQueue struct {
+	sink   Sink
+	dir    string
+	config *Config
+
+	mu      sync.Mutex
+	current *os.File
+	count   int
+	lastSeq int64
+
+	deliverMu sync.Mutex
+	kick      chan struct{}
+
+	statsMu sync.Mutex
+	stats   SinkStats
+}
+
+// NewShipperFromConfig builds the configured sinks and starts a shipper. It
+// returns nil when shipping is disabled; Record and Close are no-ops on a nil
+// shipper.
+func NewShipperFromConfig(cfg *Config) (*Shipper, error) {
+	if cfg == nil || !cfg.Enabled || len(cfg.Sinks) == 0 {
+		return nil, nil //nolint:nilnil // nil shipper means shipping is disabled
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	sinks := make([]Sink, 0, len(cfg.Sinks))
+	for _, sc := range cfg.Sinks {
+		sink, err := NewSink(sc)
+		if err != nil {
+			for _, s := range sinks {
+				_ = s.Close()
+			}
+			return nil, fmt.Errorf("audit sink %q: %w", sc.Name, err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return NewShipper(*cfg, sinks...)
+}
+
+// NewShipper starts a shipper delivering to the given sinks. Events left in
+// the spool by a previous run are queued for delivery immediately.
+func NewShipper(cfg Config, sinks ...Sink) (*Shipper, error) {
+	cfg = cfg.withDefaults()
+
+	s := &Shipper{config: cfg}
+	for _, sink := range sinks {
+		q, err := newSinkQueue(sink, filepath.Join(cfg.SpoolDir, sink.Name()), &s.config)
+		if err != nil {
+			return nil, err
+		}
+		s.queues = append(s.queues, q)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, q := range s.queues {
+		s.wg.Add(1)
+		go func(q *sinkQueue) {
+			defer s.wg.Done()
+			q.run(ctx)
+		}(q)
+	}
+
+	return s, nil
+}
+
+// Record durably spools an event for every sink. It returns once the event
+// is on disk; delivery happens in the background.
+func (s *Shipper) Record(event Event) error {
+	if s == nil {
+		return nil
+	}
+
+	event.normalize()
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+	line = append(line, '\n')
+
+	for _, q := range s.queues {
+		if err := q.append(line); err != nil {
+			return fmt.Errorf("failed to spool audit event for %s: %w", q.sink.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// Flush seals pending events and attempts delivery to every sink now.
+func (s *Shipper) Flush(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	var errs []string
+	for _, q := range s.queues {
+		if err := q.flush(ctx); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", q.sink.Name(), err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("audit delivery incomplete: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Stats returns per-sink delivery statistics.
+func (s *Shipper) Stats() []SinkStats {
+	if s == nil {
+		return nil
+	}
+
+	stats := make([]SinkStats, 0, len(s.queues))
+	for _, q := range s.queues {
+		stats = append(stats, q.snapshot())
+	}
+	return stats
+}
+
+// Close stops background delivery, makes a final delivery attempt bounded by
+// ctx, and closes the sinks. Undelivered events stay in the spool for the
+// next run.
+func (s *Shipper) Close(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	var err error
+	s.closeOnce.Do(func() {
+		s.cancel()
+		s.wg.Wait()
+
+		err = s.Flush(ctx)
+		for _, q := range s.queues {
+			q.closeSegment()
+			if cerr := q.sink.Close(); cerr != nil && err == nil {
+				err = cerr
+			}
+		}
+	})
+	return err
+}
+
+func newSinkQueue(sink Sink, dir string, cfg *Config) (*sinkQueue, error) {
+	if err := os.MkdirAll(filepath.Join(dir, deadLetterDir), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create audit spool %s: %w", dir, err)
+	}
+
+	q := &sinkQueue{
+		sink:   sink,
+		dir:    dir,
+		config: cfg,
+		kick:   make(chan struct{}, 1),
+		stats:  SinkStats{Name: sink.Name()},
+	}
+
+	// A segment left behind by a crash is sealed as-is so it is delivered
+	// ahead of anything recorded in this run.
+	if info, err := os.Stat(filepath.Join(dir, currentSegment)); err == nil && info.Size() > 0 {
+		q.count = 1
+		if err := q.seal(); err != nil {
+			return nil, err
+		}
+	}
+
+	return q, nil
+}
+
+func (q *sinkQueue) append(line []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.current == nil {
+		f, err := os.OpenFile(filepath.Join(q.dir, currentSegment), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return err
+		}
+		q.current = f
+	}
+
+	if _, err := q.current.Write(line); err != nil {
+		return err
+	}
+	if err := q.current.Sync(); err != nil {
+		return err
+	}
+
+	q.count++
+	if q.count >= q.config.BatchSize {
+		select {
+		case q.kick <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// seal renames the current segment to the next batch file.
+func (q *sinkQueue) seal() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.count == 0 {
+		return nil
+	}
+	if q.current != nil {
+		_ = q.current.Close()
+		q.current = nil
+	}
+
+	seq := time.Now().UnixNano()
+	if seq <= q.lastSeq {
+		seq = q.lastSeq + 1
+	}
+	q.lastSeq = seq
+
+	name := filepath.Join(q.dir, fmt.Sprintf("%020d%s", seq, batchSuffix))
+	if err := os.Rename(filepath.Join(q.dir, currentSegment), name); err != nil {
+		return fmt.Errorf("failed to seal audit batch: %w", err)
+	}
+	q.count = 0
+	return nil
+}
+
+func (q *sinkQueue) closeSegment() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.current != nil {
+		_ = q.current.Close()
+		q.current = nil
+	}
+}
+
+func (q *sinkQueue) run(ctx context.Context) {
+	ticker := time.NewTicker(q.config.FlushInterval)
+	defer ticker.Stop()
+
+	// Deliver anything left over from a previous run.
+	_ = q.flush(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-q.kick:
+		}
+		_ = q.flush(ctx)
+	}
+}
+
+// flush seals the current segment and delivers batches oldest first. It
+// stops at the first batch that cannot be delivered so ordering is kept.
+func (q *sinkQueue) flush(ctx context.Context) error {
+	q.deliverMu.Lock()
+	defer q.deliverMu.Unlock()
+
+	if err := q.seal(); err != nil {
+		return err
+	}
+
+	batches, err := q.batches()
+	if err != nil {
+		return err
+	}
+
+	for i, path := range batches {
+		if err := q.deliver(ctx, path); err != nil {
+			q.setPending(len(batches) - i)
+			return err
+		}
+	}
+	q.setPending(0)
+	return nil
+}
+
+func (q *sinkQueue) batches() ([]string, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var batches []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), batchSuffix) {
+			batches = append(batches, filepath.Join(q.dir, e.Name()))
+		}
+	}
+	sort.Strings(batches)
+	return batches, nil
+}
+
+func (q *sinkQueue) deliver(ctx context.Context, path string) error {
+	events, err := readBatch(path)
+	if err != nil {
+		return err
+	}
+
+	backoff := q.config.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		if len(events) == 0 {
+			break
+		}
+		err = q.sink.Send(ctx, events)
+		if err == nil {
+			break
+		}
+
+		q.recordError(err)
+		if IsPermanent(err) {
+			dead := filepath.Join(q.dir, deadLetterDir, filepath.Base(path))
+			if rerr := os.Rename(path, dead); rerr != nil {
+				return rerr
+			}
+			q.statsMu.Lock()
+			q.stats.DeadLettered += int64(len(events))
+			q.statsMu.Unlock()
+			return nil
+		}
+		if attempt >= q.config.MaxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	q.statsMu.Lock()
+	q.stats.Delivered += int64(len(events))
+	q.stats.LastError = ""
+	q.statsMu.Unlock()
+	return nil
+}
+
+// readBatch loads a batch file. A line truncated by a crash mid-write is
+// skipped rather than blocking the rest of the batch.
+func readBatch(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, scanner.Err()
+}
+
+func (q *sinkQueue) recordError(err error) {
+	q.statsMu.Lock()
+	q.stats.LastError = err.Error()
+	q.statsMu.Unlock()
+}
+
+func (q *sinkQueue) setPending(n int) {
+	q.statsMu.Lock()
+	q.stats.Pending = n
+	q.statsMu.Unlock()
+}
+
+func (q *sinkQueue) snapshot() SinkStats {
+	q.statsMu.Lock()
+	defer q.statsMu.Unlock()
+	return q.stats
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSink struct {
+	name string
+
+	mu       sync.Mutex
+	failures []error
+	batches  [][]Event
+}
+
+func (f *fakeSink) Name() string { return f.name }
+
+func (f *fakeSink) Send(_ context.Context, events []Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.failures) > 0 {
+		err := f.failures[0]
+		f.failures = f.failures[1:]
+		return err
+	}
+	f.batches = append(f.batches, events)
+	return nil
+}
+
+func (f *fakeSink) Close() error { return nil }
+
+func (f *fakeSink) delivered() []Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var all []Event
+	for _, b := range f.batches {
+		all = append(all, b...)
+	}
+	return all
+}
+
+func testConfig(t *testing.T) Config {
+	t.Helper()
+	return Config{
+		SpoolDir:      t.TempDir(),
+		BatchSize:     10,
+		FlushInterval: time.Hour,
+		MaxRetries:    3,
+		RetryBackoff:  time.Millisecond,
+	}
+}
+
+func TestShipper_DeliversToEverySink(t *testing.T) {
+	a, b := &fakeSink{name: "a"}, &fakeSink{name: "b"}
+	shipper, err := NewShipper(testConfig(t), a, b)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, shipper.Record(Event{Action: "test"}))
+	}
+	require.NoError(t, shipper.Close(context.Background()))
+
+	assert.Len(t, a.delivered(), 3)
+	assert.Len(t, b.delivered(), 3)
+	assert.NotEmpty(t, a.delivered()[0].ID)
+	assert.Equal(t, a.delivered()[0].ID, b.delivered()[0].ID)
+}
+
+func TestShipper_BatchSizeTriggersFlush(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.BatchSize = 2
+	sink := &fakeSink{name: "s"}
+	shipper, err := NewShipper(cfg, sink)
+	require.NoError(t, err)
+	defer shipper.Close(context.Background())
+
+	require.NoError(t, shipper.Record(Event{Action: "one"}))
+	require.NoError(t, shipper.Record(Event{Action: "two"}))
+
+	assert.Eventually(t, func() bool { return len(sink.delivered()) == 2 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestShipper_RetriesTransientFailures(t *testing.T) {
+	sink := &fakeSink{name: "s", failures: []error{errors.New("down"), errors.New("down")}}
+	shipper, err := NewShipper(testConfig(t), sink)
+	require.NoError(t, err)
+
+	require.NoError(t, shipper.Record(Event{Action: "test"}))
+	require.NoError(t, shipper.Flush(context.Background()))
+	require.NoError(t, shipper.Close(context.Background()))
+
+	assert.Len(t, sink.delivered(), 1)
+	stats := shipper.Stats()
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(1), stats[0].Delivered)
+}
+
+func TestShipper_UndeliveredEventsSurviveRestart(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.MaxRetries = 1
+
+	down := &fakeSink{name: "s", failures: []error{errors.New("down"), errors.New("down"), errors.New("down")}}
+	shipper, err := NewShipper(cfg, down)
+	require.NoError(t, err)
+	require.NoError(t, shipper.Record(Event{ID: "evt-1", Action: "test"}))
+	assert.Error(t, shipper.Close(context.Background()))
+	assert.Empty(t, down.delivered())
+
+	up := &fakeSink{name: "s"}
+	shipper, err = NewShipper(cfg, up)
+	require.NoError(t, err)
+	require.NoError(t, shipper.Close(context.Background()))
+
+	require.Len(t, up.delivered(), 1)
+	assert.Equal(t, "evt-1", up.delivered()[0].ID)
+}
+
+func TestShipper_RecoversUnsealedSegment(t *testing.T) {
+	cfg := testConfig(t)
+	dir := filepath.Join(cfg.SpoolDir, "s")
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	segment := `{"id":"left-over","action":"test","timestamp":"2025-01-01T00:00:00Z"}` + "\n" + `{"id":"trunc`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, currentSegment), []byte(segment), 0o600))
+
+	sink := &fakeSink{name: "s"}
+	shipper, err := NewShipper(cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, shipper.Close(context.Background()))
+
+	require.Len(t, sink.delivered(), 1)
+	assert.Equal(t, "left-over", sink.delivered()[0].ID)
+}
+
+func TestShipper_PermanentFailureDeadLetters(t *testing.T) {
+	cfg := testConfig(t)
+	sink := &fakeSink{name: "s", failures: []error{&PermanentError{Err: errors.New("bad token")}}}
+	shipper, err := NewShipper(cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, shipper.Record(Event{Action: "test"}))
+	require.NoError(t, shipper.Record(Event{Action: "test"}))
+	require.NoError(t, shipper.Close(context.Background()))
+
+	dead, err := os.ReadDir(filepath.Join(cfg.SpoolDir, "s", deadLetterDir))
+	require.NoError(t, err)
+	assert.Len(t, dead, 1)
+	assert.Equal(t, int64(2), shipper.Stats()[0].DeadLettered)
+}
+
+func TestNewShipperFromConfig_Disabled(t *testing.T) {
+	shipper, err := NewShipperFromConfig(&Config{Enabled: false})
+	require.NoError(t, err)
+	assert.Nil(t, shipper)
+	assert.NoError(t, shipper.Record(Event{Action: "noop"}))
+	assert.NoError(t, shipper.Close(context.Background()))
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Sink delivers batches of events to an external system. Send must return
+// nil only once the destination has accepted every event in the batch.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, events []Event) error
+	Close() error
+}
+
+// PermanentError marks a delivery failure that retrying cannot fix, such as
+// a rejected token or a malformed request. The shipper moves the batch to the
+// dead-letter directory instead of retrying it.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return "permanent delivery failure: " + e.Err.Error() }
+
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// IsPermanent reports whether err is a PermanentError.
+func IsPermanent(err error) bool {
+	var pe *PermanentError
+	return errors.As(err, &pe)
+}
+
+// NewSink creates a sink from its configuration. Secrets may reference
+// environment variables, e.g. token: ${SPLUNK_HEC_TOKEN}.
+func NewSink(cfg SinkConfig) (Sink, error) {
+	cfg.Token = os.ExpandEnv(cfg.Token)
+	cfg.Password = os.ExpandEnv(cfg.Password)
+	cfg.APIKey = os.ExpandEnv(cfg.APIKey)
+
+	switch cfg.Type {
+	case SinkTypeSplunk:
+		return NewSplunkHECSink(cfg), nil
+	case SinkTypeElastic:
+		return NewElasticSink(cfg), nil
+	case SinkTypeSyslog:
+		return NewSyslogSink(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported audit sink type %q", cfg.Type)
+	}
+}
+
+func newHTTPClient(cfg SinkConfig) *http.Client {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.InsecureSkipVerify {
+		// 전역 네트워크 설정의 클라이언트 인증서는 유지한다
+		tlsConfig := &tls.Config{}
+		if transport.TLSClientConfig != nil {
+			tlsConfig = transport.TLSClientConfig.Clone()
+		}
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec // opt-in for self-signed collectors
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// classifyStatus turns a non-2xx HTTP status into a delivery error. Rate
+// limiting and server errors are retryable; other client errors are not.
+func classifyStatus(sink string, status int, body string) error {
+	err := fmt.Errorf("%s returned HTTP %d: %s", sink, status, body)
+	if status == http.StatusTooManyRequests || status == http.StatusRequestTimeout || status >= 500 {
+		return err
+	}
+	return &PermanentError{Err: err}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/trace"
+)
+
+func testEvents(n int) []Event {
+	events := make([]Event, n)
+	for i := range events {
+		events[i] = Event{Action: "repo.update", Actor: "alice", Resource: "org/repo-" + strconv.Itoa(i)}
+		events[i].normalize()
+	}
+	return events
+}
+
+func TestEventNormalize_TraceID(t *testing.T) {
+	id := trace.NewID()
+	t.Setenv(trace.EnvVar, id)
+
+	e := Event{Action: "repo.update"}
+	e.normalize()
+	assert.Equal(t, id, e.TraceID)
+
+	e = Event{Action: "repo.update", TraceID: "upstream"}
+	e.normalize()
+	assert.Equal(t, "upstream", e.TraceID)
+}
+
+func TestSplunkHECSink_Send(t *testing.T) {
+	var gotAuth string
+	var lines []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/collector/event", r.URL.Path)
+		gotAuth = r.Header.Get("Authorization")
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		_, _ = io.WriteString(w, `{"text":"Success","code":0}`)
+	}))
+	defer server.Close()
+
+	sink := NewSplunkHECSink(SinkConfig{Name: "splunk", URL: server.URL, Token: "secret", Index: "audit"})
+	require.NoError(t, sink.Send(context.Background(), testEvents(2)))
+
+	assert.Equal(t, "Splunk secret", gotAuth)
+	require.Len(t, lines, 2)
+
+	var payload splunkEvent
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &payload))
+	assert.Equal(t, "audit", payload.Index)
+	assert.Equal(t, "gzh:audit", payload.SourceType)
+	assert.Equal(t, "org/repo-0", payload.Event.Resource)
+}
+
+func TestSplunkHECSink_StatusClassification(t *testing.T) {
+	status := http.StatusForbidden
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink := NewSplunkHECSink(SinkConfig{Name: "splunk", URL: server.URL, Token: "bad"})
+
+	err := sink.Send(context.Background(), testEvents(1))
+	require.Error(t, err)
+	assert.True(t, IsPermanent(err))
+
+	status = http.StatusServiceUnavailable
+	err = sink.Send(context.Background(), testEvents(1))
+	require.Error(t, err)
+	assert.False(t, IsPermanent(err))
+}
+
+func TestElasticSink_Send(t *testing.T) {
+	itemStatus := 201
+	var body []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_bulk", r.URL.Path)
+		assert.Equal(t, "ApiKey k", r.Header.Get("Authorization"))
+		data, _ := io.ReadAll(r.Body)
+		body = strings.Split(strings.TrimSpace(string(data)), "\n")
+
+		errs := itemStatus >= 300
+		resp := `{"errors":` + strconv.FormatBool(errs) + `,"items":[{"index":{"status":` + strconv.Itoa(itemStatus) +
+			`,"error":{"type":"mapper_parsing_exception","reason":"bad"}}}]}`
+		_, _ = io.WriteString(w, resp)
+	}))
+	defer server.Close()
+
+	sink := NewElasticSink(SinkConfig{Name: "es", URL: server.URL, Index: "gzh-audit", APIKey: "k"})
+	events := testEvents(1)
+	require.NoError(t, sink.Send(context.Background(), events))
+
+	require.Len(t, body, 2)
+	assert.JSONEq(t, `{"index":{"_index":"gzh-audit","_id":"`+events[0].ID+`"}}`, body[0])
+	assert.Contains(t, body[1], `"@timestamp"`)
+
+	itemStatus = 400
+	err := sink.Send(context.Background(), events)
+	require.Error(t, err)
+	assert.True(t, IsPermanent(err))
+
+	itemStatus = 429
+	err = sink.Send(context.Background(), events)
+	require.Error(t, err)
+	assert.False(t, IsPermanent(err))
+}
+
+func TestSyslogSink_TCPFraming(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	received := make(chan string, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			lenStr, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(lenStr))
+			msg := make([]byte, n)
+			if _, err := io.ReadFull(r, msg); err != nil {
+				return
+			}
+			received <- string(msg)
+		}
+	}()
+
+	sink, err := NewSyslogSink(SinkConfig{Name: "syslog", Network: "tcp", Address: ln.Addr().String(), Facility: "auth"})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	events := testEvents(2)
+	events[1].Severity = SeverityError
+	require.NoError(t, sink.Send(context.Background(), events))
+
+	for i, wantPri := range []string{"<38>1 ", "<35>1 "} {
+		select {
+		case msg := <-received:
+			assert.True(t, strings.HasPrefix(msg, wantPri), msg)
+			assert.Contains(t, msg, " gzh-cli ")
+			assert.Contains(t, msg, `[audit@32473 id="`+events[i].ID+`" action="repo.update" actor="alice"`)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for syslog message")
+		}
+	}
+}
+
+func TestSyslogSink_UnknownFacility(t *testing.T) {
+	_, err := NewSyslogSink(SinkConfig{Name: "syslog", Address: "127.0.0.1:514", Facility: "nope"})
+	assert.Error(t, err)
+}
+
+func TestEscapeSDParam(t *testing.T) {
+	assert.Equal(t, `a\"b\\c\]`, escapeSDParam(`a"b\c]`))
+	assert.Equal(t, "-", headerField("", 10))
+	assert.Equal(t, "a_b", headerField("a b", 10))
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := &Config{Sinks: []SinkConfig{
+		{Name: "s", Type: SinkTypeSplunk, URL: "https://splunk:8088", Token: "t"},
+		{Name: "e", Type: SinkTypeElastic, URL: "https://es:9200", Index: "audit"},
+		{Name: "l", Type: SinkTypeSyslog, Address: "syslog:6514", Network: "tls"},
+	}}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Sinks = append(cfg.Sinks, SinkConfig{Name: "s", Type: SinkTypeSyslog, Address: "x:514"})
+	assert.ErrorContains(t, cfg.Validate(), "duplicate")
+
+	cfg.Sinks = []SinkConfig{{Name: "s", Type: SinkTypeSplunk, URL: "https://splunk:8088"}}
+	assert.ErrorContains(t, cfg.Validate(), "token")
+
+	cfg.Sinks = []SinkConfig{{Name: "x", Type: "kafka"}}
+	assert.ErrorContains(t, cfg.Validate(), "unsupported type")
+}
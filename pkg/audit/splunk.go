@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SplunkHECSink sends events to a Splunk HTTP Event Collector.
+type SplunkHECSink struct {
+	name       string
+	endpoint   string
+	token      string
+	index      string
+	sourceType string
+	client     *http.Client
+}
+
+type splunkEvent struct {
+	Time       float64 `json:"time"`
+	Host       string  `json:"host,omitempty"`
+	Source     string  `json:"source,omitempty"`
+	SourceType string  `json:"sourcetype,omitempty"`
+	Index      string  `json:"index,omitempty"`
+	Event      Event   `json:"event"`
+}
+
+// NewSplunkHECSink creates a Splunk HEC sink. The URL may be the collector
+// base URL or the full /services/collector/event endpoint.
+func NewSplunkHECSink(cfg SinkConfig) *SplunkHECSink {
+	endpoint := strings.TrimRight(cfg.URL, "/")
+	if !strings.Contains(endpoint, "/services/collector") {
+		endpoint += "/services/collector/event"
+	}
+
+	sourceType := cfg.SourceType
+	if sourceType == "" {
+		sourceType = "gzh:audit"
+	}
+
+	return &SplunkHECSink{
+		name:       cfg.Name,
+		endpoint:   endpoint,
+		token:      cfg.Token,
+		index:      cfg.Index,
+		sourceType: sourceType,
+		client:     newHTTPClient(cfg),
+	}
+}
+
+// Name returns the configured sink name.
+func (s *SplunkHECSink) Name() string { return s.name }
+
+// Send posts the batch as concatenated HEC event objects in one request.
+func (s *SplunkHECSink) Send(ctx context.Context, events []Event) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, e := range events {
+		if err := enc.Encode(splunkEvent{
+			Time:       float64(e.Timestamp.UnixNano()) / 1e9,
+			Source:     "gzh-cli",
+			SourceType: s.sourceType,
+			Index:      s.index,
+			Event:      e,
+		}); err != nil {
+			return &PermanentError{Err: fmt.Errorf("failed to encode event %s: %w", e.ID, err)}
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, &body)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+	req.Header.Set("Authorization", "Splunk "+s.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("splunk HEC request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return classifyStatus("splunk HEC", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
+
+// Close releases idle connections.
+func (s *SplunkHECSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package audit
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// syslogEnterpriseID is the private enterprise number used for the
+// structured data ID. 32473 is reserved for documentation by RFC 5612.
+const syslogEnterpriseID = "32473"
+
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+var syslogSeverities = map[Severity]int{
+	SeverityError: 3,
+	SeverityWarn:  4,
+	SeverityInfo:  6,
+	SeverityDebug: 7,
+}
+
+// SyslogSink writes RFC 5424 messages to a syslog collector over UDP, TCP or
+// TLS. Stream transports use octet-counting framing (RFC 6587).
+type SyslogSink struct {
+	name      string
+	network   string
+	address   string
+	appName   string
+	hostname  string
+	facility  int
+	timeout   time.Duration
+	tlsConfig *tls.Config
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogSink creates a syslog sink. The connection is opened lazily on the
+// first Send and re-established after write failures.
+func NewSyslogSink(cfg SinkConfig) (*SyslogSink, error) {
+	network := cfg.Network
+	if network == "" {
+		network = "udp"
+	}
+
+	facility := syslogFacilities["local0"]
+	if cfg.Facility != "" {
+		f, ok := syslogFacilities[strings.ToLower(cfg.Facility)]
+		if !ok {
+			return nil, fmt.Errorf("unknown syslog facility %q", cfg.Facility)
+		}
+		facility = f
+	}
+
+	appName := cfg.AppName
+	if appName == "" {
+		appName = "gzh-cli"
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	s := &SyslogSink{
+		name:     cfg.Name,
+		network:  network,
+		address:  cfg.Address,
+		appName:  appName,
+		hostname: hostname,
+		facility: facility,
+		timeout:  timeout,
+	}
+	if network == "tls" {
+		host, _, _ := net.SplitHostPort(cfg.Address)
+		s.tlsConfig = &tls.Config{ServerName: host, InsecureSkipVerify: cfg.InsecureSkipVerify} //nolint:gosec // opt-in for self-signed collectors
+	}
+
+	return s, nil
+}
+
+// Name returns the configured sink name.
+func (s *SyslogSink) Name() string { return s.name }
+
+// Send writes one syslog message per event. A failed write drops the
+// connection so the retry reconnects; events already written may be
+// delivered twice.
+func (s *SyslogSink) Send(ctx context.Context, events []Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog %s: %w", s.address, err)
+		}
+		s.conn = conn
+	}
+
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = s.conn.SetWriteDeadline(deadline)
+
+	for _, e := range events {
+		msg, err := s.format(e)
+		if err != nil {
+			return &PermanentError{Err: err}
+		}
+		if s.network != "udp" {
+			msg = strconv.Itoa(len(msg)) + " " + msg
+		}
+		if _, err := s.conn.Write([]byte(msg)); err != nil {
+			_ = s.conn.Close()
+			s.conn = nil
+			return fmt.Errorf("failed to write to syslog %s: %w", s.address, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SyslogSink) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	if s.network == "tls" {
+		td := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}
+		return td.DialContext(ctx, "tcp", s.address)
+	}
+	return dialer.DialContext(ctx, s.network, s.address)
+}
+
+// format renders an event as an RFC 5424 message. The key fields go into
+// structured data so collectors can index them without parsing MSG, which
+// carries the full event as JSON.
+func (s *SyslogSink) format(e Event) (string, error) {
+	severity, ok := syslogSeverities[e.Severity]
+	if !ok {
+		severity = syslogSeverities[SeverityInfo]
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode event %s: %w", e.ID, err)
+	}
+
+	var sd strings.Builder
+	sd.WriteString("[audit@" + syslogEnterpriseID)
+	for _, p := range [][2]string{
+		{"id", e.ID}, {"action", e.Action}, {"category", e.Category},
+		{"actor", e.Actor}, {"resource", e.Resource}, {"outcome", e.Outcome},
+	} {
+		if p[1] != "" {
+			sd.WriteString(" " + p[0] + `="` + escapeSDParam(p[1]) + `"`)
+		}
+	}
+	sd.WriteString("]")
+
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s %s %s",
+		s.facility*8+severity,
+		e.Timestamp.UTC().Format(time.RFC3339Nano),
+		headerField(s.hostname, 255),
+		headerField(s.appName, 48),
+		os.Getpid(),
+		headerField(e.Action, 32),
+		sd.String(),
+		payload,
+	), nil
+}
+
+// Close closes the collector connection.
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// headerField makes v a valid RFC 5424 header field: printable ASCII without
+// spaces, at most n characters, "-" when empty.
+func headerField(v string, n int) string {
+	v = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, v)
+	if len(v) > n {
+		v = v[:n]
+	}
+	if v == "" {
+		return "-"
+	}
+	return v
+}
+
+func escapeSDParam(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(v)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package cache provides pluggable key/value cache backends and a tiered
+// cache that chains them, for example memory → disk → a shared server.
+//
+// All backends share the same TTL semantics: an item with a zero ExpiresAt
+// never expires, and an expired item is reported as missing and removed
+// lazily. Backends bound their size and evict the least recently used items
+// first.
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrClosed is returned by backends used after Close.
+var ErrClosed = errors.New("cache is closed")
+
+// Item is a cached value.
+type Item struct {
+	Value []byte
+	// ExpiresAt is when the item stops being served. Zero means never.
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the item is expired at now.
+func (i Item) Expired(now time.Time) bool {
+	return !i.ExpiresAt.IsZero() && !now.Before(i.ExpiresAt)
+}
+
+// TTL returns the time the item has left at now, or 0 when it never expires.
+func (i Item) TTL(now time.Time) time.Duration {
+	if i.ExpiresAt.IsZero() {
+		return 0
+	}
+	return i.ExpiresAt.Sub(now)
+}
+
+// Backend stores items by key. Implementations must be safe for concurrent
+// use and must not return expired items.
+type Backend interface {
+	Get(ctx context.Context, key string) (Item, bool, error)
+	Set(ctx context.Context, key string, item Item) error
+	Delete(ctx context.Context, key string) error
+	Close() error
+}
+
+// Evicter is implemented by backends that evict items to stay within their
+// size limits.
+type Evicter interface {
+	// Evictions returns the number of items evicted so far. Expired items
+	// are not counted.
+	Evictions() int64
+}
+
+// expiresAt converts a TTL into an absolute expiry; ttl <= 0 never expires.
+func expiresAt(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
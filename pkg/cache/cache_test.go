@@ -0,0 +1,378 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/async"
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newClock() *fakeClock {
+	return &fakeClock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func getValue(t *testing.T, b Backend, key string) (string, bool) {
+	t.Helper()
+	item, ok, err := b.Get(context.Background(), key)
+	require.NoError(t, err)
+	return string(item.Value), ok
+}
+
+func TestMemoryBackendEvictionAndTTL(t *testing.T) {
+	ctx := context.Background()
+	clock := newClock()
+	m := NewMemoryBackend(15)
+	m.now = clock.now
+
+	require.NoError(t, m.Set(ctx, "a", Item{Value: []byte("12345")}))
+	require.NoError(t, m.Set(ctx, "b", Item{Value: []byte("12345")}))
+	_, _ = getValue(t, m, "a") // a가 최근에 사용되었으므로 b가 먼저 밀려난다
+	require.NoError(t, m.Set(ctx, "c", Item{Value: []byte("12345")}))
+
+	_, ok := getValue(t, m, "b")
+	assert.False(t, ok)
+	v, ok := getValue(t, m, "a")
+	assert.True(t, ok)
+	assert.Equal(t, "12345", v)
+	assert.Equal(t, int64(1), m.Evictions())
+	assert.Equal(t, int64(12), m.Size())
+
+	require.NoError(t, m.Set(ctx, "ttl", Item{Value: []byte("x"), ExpiresAt: clock.now().Add(time.Minute)}))
+	_, ok = getValue(t, m, "ttl")
+	assert.True(t, ok)
+	clock.advance(time.Minute)
+	_, ok = getValue(t, m, "ttl")
+	assert.False(t, ok, "items expire at ExpiresAt")
+
+	require.NoError(t, m.Set(ctx, "huge", Item{Value: make([]byte, 100)}))
+	_, ok = getValue(t, m, "huge")
+	assert.False(t, ok, "items larger than the backend are dropped")
+
+	require.NoError(t, m.Close())
+	_, _, err := m.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestDiskBackendPersistence(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	clock := newClock()
+
+	d, err := OpenDiskBackend(dir, 0)
+	require.NoError(t, err)
+	d.now = clock.now
+	require.NoError(t, d.Set(ctx, "repos/acme", Item{Value: []byte(`["api","web"]`)}))
+	require.NoError(t, d.Set(ctx, "expired", Item{Value: []byte("old"), ExpiresAt: time.Now().Add(-time.Hour)}))
+	require.NoError(t, d.Close())
+
+	reopened, err := OpenDiskBackend(dir, 0)
+	require.NoError(t, err)
+	v, ok := getValue(t, reopened, "repos/acme")
+	assert.True(t, ok, "items survive a restart")
+	assert.Equal(t, `["api","web"]`, v)
+	_, ok = getValue(t, reopened, "expired")
+	assert.False(t, ok)
+
+	// 다른 프로세스가 나중에 쓴 항목도 읽힌다
+	other, err := OpenDiskBackend(dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, other.Set(ctx, "late", Item{Value: []byte("v")}))
+	_, ok = getValue(t, reopened, "late")
+	assert.True(t, ok)
+
+	require.NoError(t, reopened.Delete(ctx, "late"))
+	_, ok = getValue(t, other, "late")
+	assert.False(t, ok)
+}
+
+// Environment of the helper processes of TestDiskBackendSharedByProcesses.
+const (
+	helperDirEnv = "GZH_CACHE_TEST_DIR"
+	helperIDEnv  = "GZH_CACHE_TEST_ID"
+)
+
+const (
+	helperProcesses = 4
+	helperKeys      = 50
+)
+
+// sharedValue returns the value process id writes under the shared key. It
+// is large enough to be compressed and written in several chunks.
+func sharedValue(id int) []byte {
+	return bytes.Repeat([]byte(fmt.Sprintf("process %d ", id)), 2000)
+}
+
+// TestDiskBackendHelperProcess is the body of the processes started by
+// TestDiskBackendSharedByProcesses; it does nothing in a normal run.
+func TestDiskBackendHelperProcess(t *testing.T) {
+	dir := os.Getenv(helperDirEnv)
+	if dir == "" {
+		t.Skip("helper process only")
+	}
+	id, err := strconv.Atoi(os.Getenv(helperIDEnv))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	d, err := OpenDiskBackend(dir, 0)
+	require.NoError(t, err)
+	defer d.Close()
+	for j := range helperKeys {
+		require.NoError(t, d.Set(ctx, fmt.Sprintf("p%d-%d", id, j), Item{Value: []byte(strconv.Itoa(j))}))
+		require.NoError(t, d.Set(ctx, "shared", Item{Value: sharedValue(id)}))
+
+		// 다른 프로세스가 쓰는 중인 항목도 온전한 값으로만 읽혀야 한다
+		v, ok := getValue(t, d, "shared")
+		if ok {
+			assert.Contains(t, validSharedValues(), v)
+		}
+		if v, ok := getValue(t, d, fmt.Sprintf("p%d-%d", (id+1)%helperProcesses, j)); ok {
+			assert.Equal(t, strconv.Itoa(j), v)
+		}
+	}
+	require.NoError(t, d.Delete(ctx, fmt.Sprintf("p%d-deleted", id)))
+}
+
+func validSharedValues() []string {
+	var values []string
+	for id := range helperProcesses {
+		values = append(values, string(sharedValue(id)))
+	}
+	return values
+}
+
+// TestDiskBackendSharedByProcesses runs gz processes against one cache
+// directory at the same time, as concurrent gz invocations do.
+func TestDiskBackendSharedByProcesses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts processes")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// 이 프로세스가 열어 둔 백엔드는 다른 프로세스의 쓰기와 삭제를 본다
+	d, err := OpenDiskBackend(dir, 0)
+	require.NoError(t, err)
+	defer d.Close()
+	for id := range helperProcesses {
+		require.NoError(t, d.Set(ctx, fmt.Sprintf("p%d-deleted", id), Item{Value: []byte("gone")}))
+	}
+
+	cmds := make([]*exec.Cmd, helperProcesses)
+	outputs := make([]bytes.Buffer, helperProcesses)
+	for id := range cmds {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestDiskBackendHelperProcess$", "-test.count=1")
+		cmd.Env = append(os.Environ(), helperDirEnv+"="+dir, helperIDEnv+"="+strconv.Itoa(id))
+		cmd.Stdout, cmd.Stderr = &outputs[id], &outputs[id]
+		require.NoError(t, cmd.Start())
+		cmds[id] = cmd
+	}
+	for id, cmd := range cmds {
+		assert.NoError(t, cmd.Wait(), "process %d:\n%s", id, outputs[id].String())
+	}
+
+	for id := range helperProcesses {
+		_, ok := getValue(t, d, fmt.Sprintf("p%d-deleted", id))
+		assert.False(t, ok, "deleted by process %d", id)
+		for j := range helperKeys {
+			v, ok := getValue(t, d, fmt.Sprintf("p%d-%d", id, j))
+			require.True(t, ok, "p%d-%d", id, j)
+			assert.Equal(t, strconv.Itoa(j), v)
+		}
+	}
+	v, ok := getValue(t, d, "shared")
+	require.True(t, ok)
+	assert.Contains(t, validSharedValues(), v)
+
+	// 임시 파일이 남지 않고, 다시 열면 크기 제한을 지킨다
+	temps, err := filepath.Glob(filepath.Join(dir, "*", ".tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, temps)
+	small, err := OpenDiskBackend(dir, d.Size()/2)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, small.Size(), d.Size()/2)
+	assert.Positive(t, small.Evictions())
+}
+
+func TestDiskBackendEviction(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	d, err := OpenDiskBackend(dir, 0)
+	require.NoError(t, err)
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, d.Set(ctx, key, Item{Value: make([]byte, 100)}))
+	}
+	entrySize := d.Size() / 3
+
+	// 크기 제한을 낮춰 다시 열면 가장 오래 사용하지 않은 항목부터 지운다
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, touch(d, "a", old.Add(2*time.Minute)))
+	require.NoError(t, touch(d, "b", old))
+	require.NoError(t, touch(d, "c", old.Add(time.Minute)))
+
+	small, err := OpenDiskBackend(dir, 2*entrySize)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), small.Evictions())
+	_, ok := getValue(t, small, "b")
+	assert.False(t, ok)
+	_, ok = getValue(t, small, "a")
+	assert.True(t, ok)
+
+	require.NoError(t, small.Set(ctx, "d", Item{Value: make([]byte, 100)}))
+	_, ok = getValue(t, small, "c")
+	assert.False(t, ok)
+	assert.Equal(t, int64(2), small.Evictions())
+}
+
+func TestDiskBackendCompression(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	d, err := OpenDiskBackend(dir, 0)
+	require.NoError(t, err)
+
+	large := bytes.Repeat([]byte(`{"name":"api","archived":false},`), 100)
+	require.NoError(t, d.Set(ctx, "large", Item{Value: large}))
+	require.NoError(t, d.Set(ctx, "small", Item{Value: []byte("tiny")}))
+	assert.Less(t, d.Size(), int64(len(large)/4))
+
+	data, err := os.ReadFile(d.path(hashKey("large")))
+	require.NoError(t, err)
+	header, _, err := decodeEntry(data)
+	require.NoError(t, err)
+	assert.Equal(t, compress.Default(compress.UseCache).Name(), header.Codec)
+
+	// 코덱을 바꿔도 이전 항목은 기록된 코덱으로 읽힌다
+	d.SetCodec(compress.Zstd)
+	require.NoError(t, d.Set(ctx, "zstd", Item{Value: large}))
+	d.SetCodec(compress.None)
+	require.NoError(t, d.Set(ctx, "plain", Item{Value: large}))
+	for _, key := range []string{"large", "zstd", "plain"} {
+		v, ok := getValue(t, d, key)
+		assert.True(t, ok, key)
+		assert.Equal(t, string(large), v, key)
+	}
+	v, ok := getValue(t, d, "small")
+	assert.True(t, ok)
+	assert.Equal(t, "tiny", v)
+}
+
+func touch(d *DiskBackend, key string, at time.Time) error {
+	return os.Chtimes(d.path(hashKey(key)), at, at)
+}
+
+type failingBackend struct{ *MemoryBackend }
+
+func (f *failingBackend) Get(context.Context, string) (Item, bool, error) {
+	return Item{}, false, errors.New("connection refused")
+}
+
+func TestTieredPromotionAndStats(t *testing.T) {
+	ctx := context.Background()
+	clock := newClock()
+	memory := NewMemoryBackend(0)
+	memory.now = clock.now
+	disk, err := OpenDiskBackend(t.TempDir(), 0)
+	require.NoError(t, err)
+	disk.now = clock.now
+
+	c := NewTiered("test-promotion", time.Hour, Tier{Name: "memory", Backend: memory}, Tier{Name: "disk", Backend: disk})
+	c.now = clock.now
+	require.NoError(t, c.Set(ctx, "k", []byte("v")))
+	require.NoError(t, memory.Delete(ctx, "k"))
+
+	clock.advance(30 * time.Minute)
+	v, ok := c.Get(ctx, "k")
+	require.True(t, ok)
+	assert.Equal(t, "v", string(v))
+
+	item, ok, err := memory.Get(ctx, "k")
+	require.NoError(t, err)
+	require.True(t, ok, "disk hits are promoted to memory")
+	assert.Equal(t, 30*time.Minute, item.TTL(clock.now()), "promotion keeps the remaining TTL")
+
+	clock.advance(31 * time.Minute)
+	_, ok = c.Get(ctx, "k")
+	assert.False(t, ok)
+
+	stats := c.Stats()
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.InDelta(t, 0.5, stats.HitRatio(), 0.001)
+	require.Len(t, stats.Tiers, 2)
+	assert.Equal(t, TierStats{Name: "memory", Misses: 2}, stats.Tiers[0])
+	assert.Equal(t, TierStats{Name: "disk", Hits: 1, Misses: 1}, stats.Tiers[1])
+
+	type meta struct{ Stars int }
+	require.NoError(t, c.SetJSON(ctx, "meta", meta{Stars: 3}))
+	var got meta
+	assert.True(t, c.GetJSON(ctx, "meta", &got))
+	assert.Equal(t, 3, got.Stars)
+
+	found, err := c.Evict(ctx, "meta")
+	require.NoError(t, err)
+	assert.True(t, found)
+	found, err = c.Evict(ctx, "meta")
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.NoError(t, c.Close())
+}
+
+func TestTieredBackendErrorsAreMisses(t *testing.T) {
+	ctx := context.Background()
+	remote := &failingBackend{MemoryBackend: NewMemoryBackend(0)}
+	c := NewTiered("test-errors", 0, Tier{Name: "memory", Backend: NewMemoryBackend(0)}, Tier{Name: "remote", Backend: remote})
+
+	_, ok := c.Get(ctx, "k")
+	assert.False(t, ok)
+	assert.Equal(t, int64(1), c.Stats().Tiers[1].Errors)
+
+	require.NoError(t, c.Set(ctx, "k", []byte("v")))
+	_, ok = c.Get(ctx, "k")
+	assert.True(t, ok, "the memory tier still serves")
+}
+
+func TestTieredSubscribeInvalidations(t *testing.T) {
+	ctx := context.Background()
+	bus := async.NewBus()
+	repos := NewTiered("repos", time.Hour, Tier{Name: "memory", Backend: NewMemoryBackend(0)})
+	other := NewTiered("other", time.Hour, Tier{Name: "memory", Backend: NewMemoryBackend(0)})
+	for _, c := range []*Tiered{repos, other} {
+		require.NoError(t, c.Set(ctx, "a", []byte("1")))
+		require.NoError(t, c.Set(ctx, "b", []byte("2")))
+	}
+	unsubscribe := repos.SubscribeInvalidations(bus)
+	other.SubscribeInvalidations(bus)
+
+	// 이름이 같은 캐시에서만 키를 지운다
+	require.NoError(t, bus.Publish(ctx, Invalidation{Cache: "repos", Keys: []string{"a", "missing"}}))
+	_, found := repos.Get(ctx, "a")
+	assert.False(t, found)
+	_, found = repos.Get(ctx, "b")
+	assert.True(t, found)
+	_, found = other.Get(ctx, "a")
+	assert.True(t, found)
+
+	unsubscribe()
+	require.NoError(t, bus.Publish(ctx, Invalidation{Cache: "repos", Keys: []string{"b"}}))
+	_, found = repos.Get(ctx, "b")
+	assert.True(t, found)
+}
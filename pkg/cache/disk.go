@@ -0,0 +1,387 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+)
+
+// DefaultDiskMaxBytes bounds a DiskBackend created without a limit.
+const DefaultDiskMaxBytes = 512 << 20
+
+// entrySuffix marks cache files; anything else in the directory is ignored.
+const entrySuffix = ".entry"
+
+// minCompressedValue is the smallest value worth compressing.
+const minCompressedValue = 256
+
+// DiskBackend is a persistent backend that keeps one file per item, so the
+// cache survives process restarts and is shared by separate gz invocations
+// using the same directory. It is bounded by the total file size and evicts
+// the least recently read items first; access times are kept in the file
+// modification times, so the order survives restarts too.
+//
+// Each file starts with a JSON header line holding the key, expiry and
+// codec, followed by the value, so opening the backend only reads headers.
+// Values are compressed with the cache codec when that makes them smaller;
+// entries are read with the codec they were written with.
+//
+// The backend does not use an embedded database such as bbolt. bbolt locks
+// its file exclusively while a process has it open for writing, so a
+// long-running gz serve would block every other gz invocation using the
+// cache. Files replaced by atomic renames need no lock: processes read and
+// write the directory concurrently, and each sees whole entries only. Each
+// process bounds the files it has indexed, so the directory may briefly
+// exceed maxBytes while several processes write; the next open trims it.
+type DiskBackend struct {
+	dir      string
+	maxBytes int64
+	codec    compress.Codec
+
+	mu        sync.Mutex
+	size      int64
+	order     *list.List // front = most recently used
+	entries   map[string]*list.Element
+	evictions int64
+	closed    bool
+	now       func() time.Time
+}
+
+type diskEntry struct {
+	name      string // hashed file name without suffix
+	size      int64
+	expiresAt time.Time
+}
+
+type diskHeader struct {
+	Key       string    `json:"key"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+	Codec     string    `json:"codec,omitempty"`
+}
+
+// DefaultDir returns ~/.gzh/cache/<name>, next to the HTTP response cache.
+func DefaultDir(name string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".gzh", "cache", name)
+	}
+	return filepath.Join(home, ".gzh", "cache", name)
+}
+
+// OpenDiskBackend opens or creates a backend in dir holding at most
+// maxBytes of files. maxBytes <= 0 uses DefaultDiskMaxBytes. Values are
+// compressed with the codec configured for caches. Expired and unreadable
+// files found while opening are removed.
+func OpenDiskBackend(dir string, maxBytes int64) (*DiskBackend, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultDiskMaxBytes
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	d := &DiskBackend{
+		dir:      dir,
+		maxBytes: maxBytes,
+		codec:    compress.ForUse(compress.UseCache),
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+	if err := d.load(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// load indexes the existing files, most recently used first.
+func (d *DiskBackend) load() error {
+	type found struct {
+		entry   *diskEntry
+		modTime time.Time
+	}
+	var files []found
+	now := d.now()
+	err := filepath.WalkDir(d.dir, func(path string, de fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if de.IsDir() || !strings.HasSuffix(path, entrySuffix) {
+			return nil
+		}
+		info, err := de.Info()
+		if err != nil {
+			return nil
+		}
+		header, err := readHeader(path)
+		if err != nil || (Item{ExpiresAt: header.ExpiresAt}).Expired(now) {
+			_ = os.Remove(path)
+			return nil
+		}
+		name := strings.TrimSuffix(filepath.Base(path), entrySuffix)
+		files = append(files, found{
+			entry:   &diskEntry{name: name, size: info.Size(), expiresAt: header.ExpiresAt},
+			modTime: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to index cache directory: %w", err)
+	}
+
+	// 최근에 읽은 파일이 목록 앞에 오도록 수정 시각 순으로 정렬한다
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+	for _, f := range files {
+		d.entries[f.entry.name] = d.order.PushBack(f.entry)
+		d.size += f.entry.size
+	}
+	d.evictLocked()
+	return nil
+}
+
+func readHeader(path string) (diskHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return diskHeader{}, err
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil {
+		return diskHeader{}, err
+	}
+	var h diskHeader
+	if err := json.Unmarshal(line, &h); err != nil {
+		return diskHeader{}, err
+	}
+	return h, nil
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func (d *DiskBackend) path(name string) string {
+	return filepath.Join(d.dir, name[:2], name+entrySuffix)
+}
+
+// Get implements Backend. Files written by other processes after the
+// backend was opened are picked up on first access.
+func (d *DiskBackend) Get(_ context.Context, key string) (Item, bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return Item{}, false, ErrClosed
+	}
+	name := hashKey(key)
+	el, indexed := d.entries[name]
+	now := d.now()
+	if indexed && (Item{ExpiresAt: el.Value.(*diskEntry).expiresAt}).Expired(now) {
+		d.removeLocked(el)
+		return Item{}, false, nil
+	}
+
+	path := d.path(name)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// 다른 프로세스가 지운 경우
+		if indexed {
+			d.forgetLocked(el)
+		}
+		return Item{}, false, nil
+	}
+	if err != nil {
+		return Item{}, false, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+	header, value, err := decodeEntry(data)
+	if err == nil && header.Codec != "" {
+		value, err = decompressValue(header.Codec, value)
+	}
+	item := Item{Value: value, ExpiresAt: header.ExpiresAt}
+	if err != nil || header.Key != key || item.Expired(now) {
+		if indexed {
+			d.forgetLocked(el)
+		}
+		_ = os.Remove(path)
+		return Item{}, false, nil
+	}
+
+	if indexed {
+		d.forgetLocked(el)
+	}
+	d.entries[name] = d.order.PushFront(&diskEntry{name: name, size: int64(len(data)), expiresAt: header.ExpiresAt})
+	d.size += int64(len(data))
+	_ = os.Chtimes(path, now, now)
+	d.evictLocked()
+	return item, true, nil
+}
+
+func decodeEntry(data []byte) (diskHeader, []byte, error) {
+	line, value, ok := bytes.Cut(data, []byte{'\n'})
+	if !ok {
+		return diskHeader{}, nil, io.ErrUnexpectedEOF
+	}
+	var h diskHeader
+	if err := json.Unmarshal(line, &h); err != nil {
+		return diskHeader{}, nil, err
+	}
+	return h, value, nil
+}
+
+func decompressValue(name string, value []byte) ([]byte, error) {
+	c, err := compress.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return compress.Decode(c, value)
+}
+
+// SetCodec changes the codec new entries are compressed with.
+func (d *DiskBackend) SetCodec(c compress.Codec) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.codec = c
+}
+
+// Set implements Backend. Items larger than the whole backend are dropped.
+func (d *DiskBackend) Set(_ context.Context, key string, item Item) error {
+	d.mu.Lock()
+	codec := d.codec
+	d.mu.Unlock()
+
+	h := diskHeader{Key: key, ExpiresAt: item.ExpiresAt}
+	value := item.Value
+	if codec != compress.None && len(value) >= minCompressedValue {
+		// 압축해도 작아지지 않는 값은 그대로 저장한다
+		if compressed, err := compress.Encode(codec, value); err == nil && len(compressed) < len(value) {
+			h.Codec, value = codec.Name(), compressed
+		}
+	}
+	header, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+	data := make([]byte, 0, len(header)+1+len(value))
+	data = append(append(append(data, header...), '\n'), value...)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return ErrClosed
+	}
+	name := hashKey(key)
+	if el, ok := d.entries[name]; ok {
+		d.forgetLocked(el)
+	}
+	if int64(len(data)) > d.maxBytes {
+		_ = os.Remove(d.path(name))
+		return nil
+	}
+	if err := writeAtomic(d.path(name), data); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	entry := &diskEntry{name: name, size: int64(len(data)), expiresAt: item.ExpiresAt}
+	d.entries[name] = d.order.PushFront(entry)
+	d.size += entry.size
+	d.evictLocked()
+	return nil
+}
+
+// writeAtomic writes through a unique temporary file, so concurrent gz
+// processes writing the same key never see a partial file.
+func writeAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, werr := tmp.Write(data)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil {
+		_ = os.Remove(tmp.Name())
+		return errors.Join(werr, cerr)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// Delete implements Backend.
+func (d *DiskBackend) Delete(_ context.Context, key string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	name := hashKey(key)
+	if el, ok := d.entries[name]; ok {
+		d.forgetLocked(el)
+	}
+	if err := os.Remove(d.path(name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete cache entry: %w", err)
+	}
+	return nil
+}
+
+// Close stops the backend. The files are kept for the next process.
+func (d *DiskBackend) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.closed = true
+	return nil
+}
+
+// Evictions implements Evicter.
+func (d *DiskBackend) Evictions() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.evictions
+}
+
+// Size returns the bytes of cache files currently indexed.
+func (d *DiskBackend) Size() int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.size
+}
+
+func (d *DiskBackend) evictLocked() {
+	for d.size > d.maxBytes && d.order.Len() > 0 {
+		d.removeLocked(d.order.Back())
+		d.evictions++
+	}
+}
+
+// removeLocked drops the entry from the index and deletes its file.
+func (d *DiskBackend) removeLocked(el *list.Element) {
+	entry := el.Value.(*diskEntry)
+	d.forgetLocked(el)
+	_ = os.Remove(d.path(entry.name))
+}
+
+// forgetLocked drops the entry from the index only.
+func (d *DiskBackend) forgetLocked(el *list.Element) {
+	entry := el.Value.(*diskEntry)
+	d.order.Remove(el)
+	delete(d.entries, entry.name)
+	d.size -= entry.size
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"context"
+
+	"github.com/gizzahub/gzh-cli/pkg/async"
+)
+
+// Invalidation is published on an async.Bus when cached data changed at
+// its source, for example when a provider webhook reports a renamed
+// repository.
+type Invalidation struct {
+	// Cache is the name of the caches holding the keys.
+	Cache string
+	Keys  []string
+}
+
+// SubscribeInvalidations evicts the keys of the invalidations published on
+// bus for caches named like t, until the returned function is called.
+// Eviction errors are reported to the publisher.
+func (t *Tiered) SubscribeInvalidations(bus *async.Bus) (unsubscribe func()) {
+	return async.Subscribe(bus, func(ctx context.Context, inv Invalidation) error {
+		if inv.Cache != t.name {
+			return nil
+		}
+		var first error
+		for _, key := range inv.Keys {
+			if _, err := t.Evict(ctx, key); err != nil && first == nil {
+				first = err
+			}
+		}
+		return first
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMemoryMaxBytes bounds a MemoryBackend created without a limit.
+const DefaultMemoryMaxBytes = 64 << 20
+
+// MemoryBackend is an in-process LRU backend bounded by the total size of
+// its keys and values.
+type MemoryBackend struct {
+	mu        sync.Mutex
+	maxBytes  int64
+	size      int64
+	order     *list.List
+	entries   map[string]*list.Element
+	evictions int64
+	closed    bool
+	now       func() time.Time
+}
+
+type memoryEntry struct {
+	key  string
+	item Item
+}
+
+func (e *memoryEntry) size() int64 {
+	return int64(len(e.key) + len(e.item.Value))
+}
+
+// NewMemoryBackend creates a backend holding at most maxBytes of keys and
+// values. maxBytes <= 0 uses DefaultMemoryMaxBytes.
+func NewMemoryBackend(maxBytes int64) *MemoryBackend {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMemoryMaxBytes
+	}
+	return &MemoryBackend{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Get implements Backend.
+func (m *MemoryBackend) Get(_ context.Context, key string) (Item, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return Item{}, false, ErrClosed
+	}
+	el, ok := m.entries[key]
+	if !ok {
+		return Item{}, false, nil
+	}
+	entry := el.Value.(*memoryEntry)
+	if entry.item.Expired(m.now()) {
+		m.removeLocked(el)
+		return Item{}, false, nil
+	}
+	m.order.MoveToFront(el)
+	return entry.item, true, nil
+}
+
+// Set implements Backend. Items larger than the whole backend are dropped.
+func (m *MemoryBackend) Set(_ context.Context, key string, item Item) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	if el, ok := m.entries[key]; ok {
+		m.removeLocked(el)
+	}
+	entry := &memoryEntry{key: key, item: item}
+	if entry.size() > m.maxBytes {
+		return nil
+	}
+	m.entries[key] = m.order.PushFront(entry)
+	m.size += entry.size()
+	for m.size > m.maxBytes {
+		m.removeLocked(m.order.Back())
+		m.evictions++
+	}
+	return nil
+}
+
+// Delete implements Backend.
+func (m *MemoryBackend) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.entries[key]; ok {
+		m.removeLocked(el)
+	}
+	return nil
+}
+
+// Close drops all items.
+func (m *MemoryBackend) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	m.order.Init()
+	m.entries = make(map[string]*list.Element)
+	m.size = 0
+	return nil
+}
+
+// Evictions implements Evicter.
+func (m *MemoryBackend) Evictions() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.evictions
+}
+
+// Size returns the bytes of keys and values currently held.
+func (m *MemoryBackend) Size() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.size
+}
+
+func (m *MemoryBackend) removeLocked(el *list.Element) {
+	entry := el.Value.(*memoryEntry)
+	m.order.Remove(el)
+	delete(m.entries, entry.key)
+	m.size -= entry.size()
+}
@@ -0,0 +1,242 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+)
+
+// Tier is one level of a Tiered cache.
+type Tier struct {
+	// Name labels the tier in stats and metrics, e.g. "memory" or "disk".
+	Name    string
+	Backend Backend
+}
+
+// TierStats counts the outcomes of one tier.
+type TierStats struct {
+	Name      string `json:"name"`
+	Hits      int64  `json:"hits"`
+	Misses    int64  `json:"misses"`
+	Errors    int64  `json:"errors"`
+	Evictions int64  `json:"evictions"`
+}
+
+// Stats summarizes a Tiered cache. Hits and Misses count lookups of the
+// cache as a whole; a lookup served by the second tier is one hit.
+type Stats struct {
+	Hits   int64       `json:"hits"`
+	Misses int64       `json:"misses"`
+	Tiers  []TierStats `json:"tiers"`
+}
+
+// HitRatio returns hits / (hits + misses), or 0 without lookups.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type tierCounters struct {
+	hits, misses, errors atomic.Int64
+	evictions            atomic.Int64 // last value reported by the backend
+}
+
+// Tiered chains backends from fastest to slowest. Reads try each tier in
+// order and copy a hit into the faster tiers with its remaining TTL; writes
+// go to every tier. Backend errors are counted and treated as misses, since
+// a cache can always be rebuilt from the source.
+type Tiered struct {
+	name     string
+	ttl      time.Duration
+	tiers    []Tier
+	counters []*tierCounters
+	hits     atomic.Int64
+	misses   atomic.Int64
+	now      func() time.Time
+}
+
+// NewTiered creates a cache named name, which labels its metrics. ttl is
+// the default lifetime of items; ttl <= 0 keeps them until evicted.
+func NewTiered(name string, ttl time.Duration, tiers ...Tier) *Tiered {
+	t := &Tiered{name: name, ttl: ttl, tiers: tiers, now: time.Now}
+	for range tiers {
+		t.counters = append(t.counters, &tierCounters{})
+	}
+	return t
+}
+
+// Default memory tier size of caches created by OpenDefault.
+const defaultMemoryTierBytes = 16 << 20
+
+// OpenDefault opens the standard memory → disk cache stored in
+// DefaultDir(name).
+func OpenDefault(name string, ttl time.Duration) (*Tiered, error) {
+	disk, err := OpenDiskBackend(DefaultDir(name), 0)
+	if err != nil {
+		return nil, err
+	}
+	return NewTiered(name, ttl,
+		Tier{Name: "memory", Backend: NewMemoryBackend(defaultMemoryTierBytes)},
+		Tier{Name: "disk", Backend: disk},
+	), nil
+}
+
+// Get returns the value stored under key.
+func (t *Tiered) Get(ctx context.Context, key string) ([]byte, bool) {
+	for i, tier := range t.tiers {
+		item, ok, err := tier.Backend.Get(ctx, key)
+		switch {
+		case err != nil:
+			t.record(i, "error")
+			continue
+		case !ok:
+			t.record(i, "miss")
+			continue
+		}
+		t.record(i, "hit")
+		t.hits.Add(1)
+
+		// 상위 계층에 남은 TTL 그대로 채워 넣는다
+		for j := 0; j < i; j++ {
+			if err := t.tiers[j].Backend.Set(ctx, key, item); err != nil {
+				t.record(j, "error")
+			}
+			t.syncEvictions(j)
+		}
+		return item.Value, true
+	}
+	t.misses.Add(1)
+	return nil, false
+}
+
+// Set stores value under key with the default TTL.
+func (t *Tiered) Set(ctx context.Context, key string, value []byte) error {
+	return t.SetWithTTL(ctx, key, value, t.ttl)
+}
+
+// SetWithTTL stores value under key in every tier. ttl <= 0 keeps the item
+// until evicted. The errors of all failing tiers are returned together.
+func (t *Tiered) SetWithTTL(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	item := Item{Value: value, ExpiresAt: expiresAt(t.now(), ttl)}
+	var errs []error
+	for i, tier := range t.tiers {
+		if err := tier.Backend.Set(ctx, key, item); err != nil {
+			t.record(i, "error")
+			errs = append(errs, fmt.Errorf("%s cache: %w", tier.Name, err))
+		}
+		t.syncEvictions(i)
+	}
+	return errors.Join(errs...)
+}
+
+// GetJSON decodes the value stored under key into v. Undecodable values
+// are deleted and reported as missing.
+func (t *Tiered) GetJSON(ctx context.Context, key string, v any) bool {
+	data, ok := t.Get(ctx, key)
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		_ = t.Delete(ctx, key)
+		return false
+	}
+	return true
+}
+
+// SetJSON stores v encoded as JSON with the default TTL.
+func (t *Tiered) SetJSON(ctx context.Context, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache value: %w", err)
+	}
+	return t.Set(ctx, key, data)
+}
+
+// Delete removes key from every tier.
+func (t *Tiered) Delete(ctx context.Context, key string) error {
+	var errs []error
+	for i, tier := range t.tiers {
+		if err := tier.Backend.Delete(ctx, key); err != nil {
+			t.record(i, "error")
+			errs = append(errs, fmt.Errorf("%s cache: %w", tier.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Evict removes key from every tier and reports whether any tier held it.
+// Unlike Get it does not count lookups.
+func (t *Tiered) Evict(ctx context.Context, key string) (bool, error) {
+	found := false
+	for _, tier := range t.tiers {
+		if _, ok, err := tier.Backend.Get(ctx, key); err == nil && ok {
+			found = true
+		}
+	}
+	return found, t.Delete(ctx, key)
+}
+
+// Stats returns the outcomes counted so far.
+func (t *Tiered) Stats() Stats {
+	s := Stats{Hits: t.hits.Load(), Misses: t.misses.Load()}
+	for i, tier := range t.tiers {
+		t.syncEvictions(i)
+		c := t.counters[i]
+		s.Tiers = append(s.Tiers, TierStats{
+			Name:      tier.Name,
+			Hits:      c.hits.Load(),
+			Misses:    c.misses.Load(),
+			Errors:    c.errors.Load(),
+			Evictions: c.evictions.Load(),
+		})
+	}
+	return s
+}
+
+// Close closes every tier.
+func (t *Tiered) Close() error {
+	var errs []error
+	for _, tier := range t.tiers {
+		if err := tier.Backend.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s cache: %w", tier.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t *Tiered) record(tier int, result string) {
+	c := t.counters[tier]
+	switch result {
+	case "hit":
+		c.hits.Add(1)
+	case "miss":
+		c.misses.Add(1)
+	default:
+		c.errors.Add(1)
+	}
+	metrics.CacheRequests.With(t.name, t.tiers[tier].Name, result).Inc()
+}
+
+// syncEvictions forwards new evictions reported by the backend to the
+// metrics registry.
+func (t *Tiered) syncEvictions(tier int) {
+	e, ok := t.tiers[tier].Backend.(Evicter)
+	if !ok {
+		return
+	}
+	current := e.Evictions()
+	if previous := t.counters[tier].evictions.Swap(current); current > previous {
+		metrics.CacheEvictions.With(t.name, t.tiers[tier].Name).Add(float64(current - previous))
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+// Tags gz puts on the cloud resources it creates. TagResource holds
+// "<kind>/<name>", e.g. "backup-bucket/gz-backups" or "runner/linux-x64".
+const (
+	TagManaged  = "gz-managed"
+	TagResource = "gz-resource"
+)
+
+// Kinds of gz-managed resources.
+const (
+	ResourceBackupBucket = "backup-bucket"
+	ResourceRunner       = "runner"
+	// ResourceOther is a tagged resource gz does not know.
+	ResourceOther = "other"
+	// ResourceUntagged collects costs without a resource tag.
+	ResourceUntagged = "untagged"
+)
+
+// costTimeout bounds a cost query; billing APIs can take several seconds
+// for a multi-month range.
+const costTimeout = 2 * time.Minute
+
+// CostQuery selects the costs to report.
+type CostQuery struct {
+	// Start and End are the first day of the first month and the first day
+	// after the last month.
+	Start time.Time
+	End   time.Time
+
+	// Tags restricts the costs to resources carrying all of these tags.
+	Tags map[string]string
+
+	// GroupTag is the tag whose value identifies the resource of a cost.
+	// Defaults to TagResource.
+	GroupTag string
+}
+
+// MonthlyCostQuery covers the given number of whole months up to and
+// including the month of now.
+func MonthlyCostQuery(now time.Time, months int) CostQuery {
+	if months < 1 {
+		months = 1
+	}
+	end := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	return CostQuery{
+		Start:    end.AddDate(0, -months, 0),
+		End:      end,
+		Tags:     map[string]string{TagManaged: "true"},
+		GroupTag: TagResource,
+	}
+}
+
+func (q CostQuery) groupTag() string {
+	if q.GroupTag == "" {
+		return TagResource
+	}
+	return q.GroupTag
+}
+
+// months lists the months of the query as YYYY-MM.
+func (q CostQuery) months() []string {
+	var months []string
+	for m := q.Start; m.Before(q.End); m = m.AddDate(0, 1, 0) {
+		months = append(months, m.Format("2006-01"))
+	}
+	return months
+}
+
+// sortedTags returns the tag filter in key order so requests are stable.
+func (q CostQuery) sortedTags() []string {
+	keys := make([]string, 0, len(q.Tags))
+	for k := range q.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CostItem is the cost of one service for one resource in one month.
+type CostItem struct {
+	Provider string `json:"provider"`
+	Month    string `json:"month"`
+	Service  string `json:"service"`
+	// Resource is the value of the group tag; empty when untagged.
+	Resource string  `json:"resource,omitempty"`
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// CostSource reads costs from a provider's billing API.
+type CostSource interface {
+	Provider() string
+	Costs(ctx context.Context, q CostQuery) ([]CostItem, error)
+}
+
+// ManagedResource is a resource gz manages. Costs are attributed to it by
+// the value of the group tag.
+type ManagedResource struct {
+	Kind     string `yaml:"kind" json:"kind"`
+	Name     string `yaml:"name" json:"name"`
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty"`
+	// Tag is the group tag value; defaults to "<kind>/<name>".
+	Tag string `yaml:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// LoadManagedResources reads a YAML list of resources.
+func LoadManagedResources(path string) ([]ManagedResource, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // User-provided resource file
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resources file: %w", err)
+	}
+	var resources []ManagedResource
+	if err := yaml.Unmarshal(data, &resources); err != nil {
+		return nil, fmt.Errorf("failed to parse resources file %s: %w", path, err)
+	}
+	for i, r := range resources {
+		if r.Kind == "" || r.Name == "" {
+			return nil, fmt.Errorf("resource %d in %s needs a kind and a name", i+1, path)
+		}
+	}
+	return resources, nil
+}
+
+// ResourceCost is the monthly cost of one resource.
+type ResourceCost struct {
+	Kind     string   `json:"kind"`
+	Name     string   `json:"name"`
+	Provider string   `json:"provider"`
+	Services []string `json:"services"`
+	// Monthly is aligned with CostReport.Months.
+	Monthly []float64 `json:"monthly"`
+	Total   float64   `json:"total"`
+	// Change is the change of the last month over the one before, in
+	// percent. Zero when there is no previous cost.
+	Change float64 `json:"change"`
+}
+
+// CostReport is a monthly cost trend per resource.
+type CostReport struct {
+	Months   []string `json:"months"`
+	Currency string   `json:"currency"`
+	// Resources are ordered by total cost, highest first.
+	Resources []ResourceCost `json:"resources"`
+	// Kinds sums the resources of each kind per month.
+	Kinds map[string][]float64 `json:"kinds"`
+	// Totals is the cost of every resource per month.
+	Totals []float64 `json:"totals"`
+	Total  float64   `json:"total"`
+	// Errors lists providers whose costs could not be read.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// BuildCostReport correlates items with the managed resources and sums
+// them per resource and month. Known resources without costs are listed
+// with zeros so that idle resources show up.
+func BuildCostReport(q CostQuery, items []CostItem, resources []ManagedResource) *CostReport {
+	months := q.months()
+	monthIndex := make(map[string]int, len(months))
+	for i, m := range months {
+		monthIndex[m] = i
+	}
+
+	byTag := make(map[string]ManagedResource, len(resources))
+	for _, r := range resources {
+		tag := r.Tag
+		if tag == "" {
+			tag = r.Kind + "/" + r.Name
+		}
+		byTag[tag] = r
+	}
+
+	report := &CostReport{
+		Months: months,
+		Kinds:  make(map[string][]float64),
+		Totals: make([]float64, len(months)),
+	}
+	costs := make(map[string]*ResourceCost)
+	services := make(map[string]map[string]bool)
+	get := func(key string, r ManagedResource) *ResourceCost {
+		rc, ok := costs[key]
+		if !ok {
+			rc = &ResourceCost{Kind: r.Kind, Name: r.Name, Provider: r.Provider, Monthly: make([]float64, len(months))}
+			costs[key] = rc
+			services[key] = make(map[string]bool)
+		}
+		return rc
+	}
+	for tag, r := range byTag {
+		get(tag, r)
+	}
+
+	currencies := make(map[string]bool)
+	for _, item := range items {
+		i, ok := monthIndex[item.Month]
+		if !ok {
+			continue
+		}
+		r := correlate(item, byTag)
+		key := item.Resource
+		if r.Kind == ResourceUntagged {
+			key = "\x00" + item.Provider
+		}
+		rc := get(key, r)
+		if rc.Provider == "" {
+			rc.Provider = item.Provider
+		}
+		rc.Monthly[i] += item.Amount
+		if item.Service != "" {
+			services[key][item.Service] = true
+		}
+		if item.Currency != "" {
+			currencies[item.Currency] = true
+		}
+	}
+
+	for key, rc := range costs {
+		for s := range services[key] {
+			rc.Services = append(rc.Services, s)
+		}
+		sort.Strings(rc.Services)
+		if report.Kinds[rc.Kind] == nil {
+			report.Kinds[rc.Kind] = make([]float64, len(months))
+		}
+		for i, amount := range rc.Monthly {
+			rc.Total += amount
+			report.Kinds[rc.Kind][i] += amount
+			report.Totals[i] += amount
+		}
+		report.Total += rc.Total
+		if n := len(rc.Monthly); n >= 2 && rc.Monthly[n-2] > 0 {
+			rc.Change = (rc.Monthly[n-1] - rc.Monthly[n-2]) / rc.Monthly[n-2] * 100
+		}
+		report.Resources = append(report.Resources, *rc)
+	}
+	sort.Slice(report.Resources, func(a, b int) bool {
+		ra, rb := report.Resources[a], report.Resources[b]
+		if ra.Total != rb.Total {
+			return ra.Total > rb.Total
+		}
+		return ra.Kind+"/"+ra.Name < rb.Kind+"/"+rb.Name
+	})
+
+	for c := range currencies {
+		if report.Currency == "" {
+			report.Currency = c
+		} else if report.Currency != c {
+			report.Currency = "mixed"
+		}
+	}
+	return report
+}
+
+// correlate finds the managed resource of a cost item. Unknown tag values
+// following the "<kind>/<name>" convention keep their kind.
+func correlate(item CostItem, byTag map[string]ManagedResource) ManagedResource {
+	if item.Resource == "" {
+		return ManagedResource{Kind: ResourceUntagged, Name: "(untagged)", Provider: item.Provider}
+	}
+	if r, ok := byTag[item.Resource]; ok {
+		return r
+	}
+	if kind, name, ok := strings.Cut(item.Resource, "/"); ok && kind != "" && name != "" {
+		return ManagedResource{Kind: kind, Name: name, Provider: item.Provider}
+	}
+	return ManagedResource{Kind: ResourceOther, Name: item.Resource, Provider: item.Provider}
+}
+
+// CollectCosts queries every source. A failing provider is recorded in the
+// report errors instead of failing the whole report.
+func CollectCosts(ctx context.Context, q CostQuery, sources []CostSource, resources []ManagedResource) *CostReport {
+	var items []CostItem
+	errs := make(map[string]string)
+	for _, src := range sources {
+		got, err := src.Costs(ctx, q)
+		if err != nil {
+			errs[src.Provider()] = err.Error()
+			continue
+		}
+		items = append(items, got...)
+	}
+	report := BuildCostReport(q, items, resources)
+	if len(errs) > 0 {
+		report.Errors = errs
+	}
+	return report
+}
+
+// defaultCostClient returns the client of cost sources without an explicit
+// HTTP client.
+func defaultCostClient(provider string) *http.Client {
+	return httpclient.NewProviderClient(provider, costTimeout)
+}
+
+// postCostQuery sends a JSON query and decodes the JSON response into out.
+func postCostQuery(client *http.Client, req *http.Request, out any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 32<<20))
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("cost query: %s: %s", resp.Status, strings.TrimSpace(string(body[:min(len(body), 1024)])))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode cost response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+// Cost Explorer is a global service served from us-east-1.
+const (
+	awsCostEndpoint = "https://ce.us-east-1.amazonaws.com"
+	awsCostRegion   = "us-east-1"
+)
+
+// AWSCostOptions configures an AWSCostExplorer.
+type AWSCostOptions struct {
+	// Metric is the Cost Explorer metric, UnblendedCost by default.
+	Metric string
+
+	// Endpoint overrides the Cost Explorer endpoint.
+	Endpoint string
+
+	// Credentials defaults to the AWS SDK default credential chain.
+	Credentials aws.CredentialsProvider
+
+	HTTPClient *http.Client
+}
+
+// AWSCostExplorer reads costs from AWS Cost Explorer.
+type AWSCostExplorer struct {
+	opts   AWSCostOptions
+	signer *v4.Signer
+}
+
+// NewAWSCostExplorer creates a Cost Explorer source.
+func NewAWSCostExplorer(ctx context.Context, opts AWSCostOptions) (*AWSCostExplorer, error) {
+	if opts.Metric == "" {
+		opts.Metric = "UnblendedCost"
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = awsCostEndpoint
+	}
+	opts.Endpoint = strings.TrimRight(opts.Endpoint, "/")
+	if opts.Credentials == nil {
+		cfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("load AWS configuration: %w", err)
+		}
+		opts.Credentials = cfg.Credentials
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = defaultCostClient("aws")
+	}
+
+	return &AWSCostExplorer{opts: opts, signer: v4.NewSigner()}, nil
+}
+
+// Provider implements CostSource.
+func (e *AWSCostExplorer) Provider() string { return "aws" }
+
+type awsCostRequest struct {
+	TimePeriod    awsDateInterval   `json:"TimePeriod"`
+	Granularity   string            `json:"Granularity"`
+	Metrics       []string          `json:"Metrics"`
+	Filter        *awsExpression    `json:"Filter,omitempty"`
+	GroupBy       []awsGroupDefault `json:"GroupBy"`
+	NextPageToken string            `json:"NextPageToken,omitempty"`
+}
+
+type awsDateInterval struct {
+	Start string `json:"Start"`
+	End   string `json:"End"`
+}
+
+type awsExpression struct {
+	And  []awsExpression `json:"And,omitempty"`
+	Tags *awsTagValues   `json:"Tags,omitempty"`
+}
+
+type awsTagValues struct {
+	Key    string   `json:"Key"`
+	Values []string `json:"Values"`
+}
+
+type awsGroupDefault struct {
+	Type string `json:"Type"`
+	Key  string `json:"Key"`
+}
+
+type awsCostResponse struct {
+	ResultsByTime []struct {
+		TimePeriod awsDateInterval `json:"TimePeriod"`
+		Groups     []struct {
+			Keys    []string `json:"Keys"`
+			Metrics map[string]struct {
+				Amount string `json:"Amount"`
+				Unit   string `json:"Unit"`
+			} `json:"Metrics"`
+		} `json:"Groups"`
+	} `json:"ResultsByTime"`
+	NextPageToken string `json:"NextPageToken"`
+}
+
+// Costs implements CostSource with GetCostAndUsage grouped by service and
+// the group tag.
+func (e *AWSCostExplorer) Costs(ctx context.Context, q CostQuery) ([]CostItem, error) {
+	req := awsCostRequest{
+		TimePeriod:  awsDateInterval{Start: q.Start.Format(time.DateOnly), End: q.End.Format(time.DateOnly)},
+		Granularity: "MONTHLY",
+		Metrics:     []string{e.opts.Metric},
+		GroupBy: []awsGroupDefault{
+			{Type: "DIMENSION", Key: "SERVICE"},
+			{Type: "TAG", Key: q.groupTag()},
+		},
+	}
+	var filters []awsExpression
+	for _, k := range q.sortedTags() {
+		filters = append(filters, awsExpression{Tags: &awsTagValues{Key: k, Values: []string{q.Tags[k]}}})
+	}
+	switch len(filters) {
+	case 0:
+	case 1:
+		req.Filter = &filters[0]
+	default:
+		req.Filter = &awsExpression{And: filters}
+	}
+
+	var items []CostItem
+	for {
+		var resp awsCostResponse
+		if err := e.call(ctx, req, &resp); err != nil {
+			return nil, fmt.Errorf("aws cost explorer: %w", err)
+		}
+		for _, period := range resp.ResultsByTime {
+			month := strings.TrimSpace(period.TimePeriod.Start)
+			if len(month) >= 7 {
+				month = month[:7]
+			}
+			for _, g := range period.Groups {
+				metric, ok := g.Metrics[e.opts.Metric]
+				if !ok || len(g.Keys) < 2 {
+					continue
+				}
+				amount, err := strconv.ParseFloat(metric.Amount, 64)
+				if err != nil {
+					return nil, fmt.Errorf("aws cost explorer: invalid amount %q", metric.Amount)
+				}
+				// Tag groups are reported as "<key>$<value>".
+				_, resource, _ := strings.Cut(g.Keys[1], "$")
+				items = append(items, CostItem{
+					Provider: "aws",
+					Month:    month,
+					Service:  g.Keys[0],
+					Resource: resource,
+					Amount:   amount,
+					Currency: metric.Unit,
+				})
+			}
+		}
+		if resp.NextPageToken == "" {
+			return items, nil
+		}
+		req.NextPageToken = resp.NextPageToken
+	}
+}
+
+func (e *AWSCostExplorer) call(ctx context.Context, body any, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.opts.Endpoint+"/", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "AWSInsightsIndexService.GetCostAndUsage")
+
+	creds, err := e.opts.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("retrieve AWS credentials: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	if err := e.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(sum[:]), "ce", awsCostRegion, time.Now()); err != nil {
+		return fmt.Errorf("sign request: %w", err)
+	}
+
+	return postCostQuery(e.opts.HTTPClient, req, out)
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	azureManagementEndpoint = "https://management.azure.com"
+	azureCostAPIVersion     = "2023-03-01"
+)
+
+// AzureCostOptions configures an AzureCostManagement source.
+type AzureCostOptions struct {
+	// Scope is the Cost Management scope, e.g. /subscriptions/<id> or
+	// /providers/Microsoft.Billing/billingAccounts/<id>.
+	Scope string
+
+	// Endpoint overrides the Resource Manager endpoint.
+	Endpoint string
+
+	// TokenSource returns a Resource Manager access token. Defaults to
+	// AZURE_ACCESS_TOKEN, then `az account get-access-token`.
+	TokenSource func(ctx context.Context) (string, error)
+
+	HTTPClient *http.Client
+}
+
+// AzureCostManagement reads costs from the Azure Cost Management query API.
+type AzureCostManagement struct {
+	opts AzureCostOptions
+}
+
+// NewAzureCostManagement creates a Cost Management source.
+func NewAzureCostManagement(opts AzureCostOptions) (*AzureCostManagement, error) {
+	if opts.Scope == "" {
+		return nil, fmt.Errorf("azure cost management scope is required")
+	}
+	opts.Scope = "/" + strings.Trim(opts.Scope, "/")
+	if opts.Endpoint == "" {
+		opts.Endpoint = azureManagementEndpoint
+	}
+	opts.Endpoint = strings.TrimRight(opts.Endpoint, "/")
+	if opts.TokenSource == nil {
+		opts.TokenSource = azureToken
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = defaultCostClient("azure")
+	}
+
+	return &AzureCostManagement{opts: opts}, nil
+}
+
+// azureToken reads an access token from the environment or the Azure CLI.
+func azureToken(ctx context.Context) (string, error) {
+	if token := os.Getenv("AZURE_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	out, err := exec.CommandContext(ctx, "az", "account", "get-access-token",
+		"--resource", azureManagementEndpoint, "--query", "accessToken", "--output", "tsv").Output()
+	if err != nil {
+		return "", fmt.Errorf("no Azure credentials: set AZURE_ACCESS_TOKEN or log in with az: %w", err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Provider implements CostSource.
+func (c *AzureCostManagement) Provider() string { return "azure" }
+
+type azureCostResponse struct {
+	Properties struct {
+		NextLink string `json:"nextLink"`
+		Columns  []struct {
+			Name string `json:"name"`
+		} `json:"columns"`
+		Rows [][]any `json:"rows"`
+	} `json:"properties"`
+}
+
+// Costs implements CostSource with a monthly ActualCost query grouped by
+// service and the group tag.
+func (c *AzureCostManagement) Costs(ctx context.Context, q CostQuery) ([]CostItem, error) {
+	var filters []map[string]any
+	for _, k := range q.sortedTags() {
+		filters = append(filters, map[string]any{
+			"tags": map[string]any{"name": k, "operator": "In", "values": []string{q.Tags[k]}},
+		})
+	}
+	dataset := map[string]any{
+		"granularity": "Monthly",
+		"aggregation": map[string]any{"totalCost": map[string]string{"name": "Cost", "function": "Sum"}},
+		"grouping": []map[string]string{
+			{"type": "Dimension", "name": "ServiceName"},
+			{"type": "TagKey", "name": q.groupTag()},
+		},
+	}
+	switch len(filters) {
+	case 0:
+	case 1:
+		dataset["filter"] = filters[0]
+	default:
+		dataset["filter"] = map[string]any{"and": filters}
+	}
+	body, err := json.Marshal(map[string]any{
+		"type":      "ActualCost",
+		"timeframe": "Custom",
+		"timePeriod": map[string]string{
+			"from": q.Start.Format(time.RFC3339),
+			"to":   q.End.Add(-time.Second).Format(time.RFC3339),
+		},
+		"dataset": dataset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var items []CostItem
+	next := fmt.Sprintf("%s%s/providers/Microsoft.CostManagement/query?api-version=%s", c.opts.Endpoint, c.opts.Scope, azureCostAPIVersion)
+	for next != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, next, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		token, err := c.opts.TokenSource(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		var resp azureCostResponse
+		if err := postCostQuery(c.opts.HTTPClient, req, &resp); err != nil {
+			return nil, fmt.Errorf("azure cost management: %w", err)
+		}
+		page, err := parseAzureRows(resp, q.groupTag())
+		if err != nil {
+			return nil, fmt.Errorf("azure cost management: %w", err)
+		}
+		items = append(items, page...)
+		next = resp.Properties.NextLink
+	}
+	return items, nil
+}
+
+// parseAzureRows maps rows by column name, as the column order depends on
+// the grouping.
+func parseAzureRows(resp azureCostResponse, groupTag string) ([]CostItem, error) {
+	col := make(map[string]int)
+	for i, c := range resp.Properties.Columns {
+		col[c.Name] = i
+	}
+	for _, name := range []string{"Cost", "BillingMonth", "ServiceName", "Currency"} {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("response has no %s column", name)
+		}
+	}
+	str := func(row []any, name string) string {
+		i, ok := col[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		s, _ := row[i].(string)
+		return s
+	}
+
+	items := make([]CostItem, 0, len(resp.Properties.Rows))
+	for _, row := range resp.Properties.Rows {
+		amount, ok := row[col["Cost"]].(float64)
+		if !ok {
+			return nil, fmt.Errorf("invalid cost %v", row[col["Cost"]])
+		}
+		month := str(row, "BillingMonth")
+		if len(month) >= 7 {
+			month = month[:7]
+		}
+		resource := ""
+		// Rows of resources without the tag have an empty TagKey.
+		if strings.EqualFold(str(row, "TagKey"), groupTag) {
+			resource = str(row, "TagValue")
+		}
+		items = append(items, CostItem{
+			Provider: "azure",
+			Month:    month,
+			Service:  str(row, "ServiceName"),
+			Resource: resource,
+			Amount:   amount,
+			Currency: str(row, "Currency"),
+		})
+	}
+	return items, nil
+}
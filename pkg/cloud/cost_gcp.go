@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const gcpBigQueryEndpoint = "https://bigquery.googleapis.com"
+
+// GCPBillingOptions configures a GCPBilling source. GCP has no API for
+// per-label costs; they are read from the Cloud Billing export to BigQuery.
+type GCPBillingOptions struct {
+	// Project runs the query job and is billed for it.
+	Project string
+
+	// Table is the billing export table, project.dataset.table.
+	Table string
+
+	// Endpoint overrides the BigQuery API endpoint.
+	Endpoint string
+
+	// TokenSource returns an OAuth2 access token. Defaults to
+	// GOOGLE_OAUTH_ACCESS_TOKEN, then `gcloud auth print-access-token`.
+	TokenSource func(ctx context.Context) (string, error)
+
+	HTTPClient *http.Client
+}
+
+// GCPBilling reads costs from a Cloud Billing BigQuery export.
+type GCPBilling struct {
+	opts GCPBillingOptions
+}
+
+// NewGCPBilling creates a billing export source.
+func NewGCPBilling(opts GCPBillingOptions) (*GCPBilling, error) {
+	if opts.Project == "" || opts.Table == "" {
+		return nil, fmt.Errorf("gcp billing needs a project and a billing export table")
+	}
+	if strings.Count(opts.Table, ".") != 2 || strings.ContainsAny(opts.Table, "`;") {
+		return nil, fmt.Errorf("invalid billing export table %q (expected project.dataset.table)", opts.Table)
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = gcpBigQueryEndpoint
+	}
+	opts.Endpoint = strings.TrimRight(opts.Endpoint, "/")
+	if opts.TokenSource == nil {
+		opts.TokenSource = gcloudToken
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = defaultCostClient("gcp")
+	}
+
+	return &GCPBilling{opts: opts}, nil
+}
+
+// Provider implements CostSource.
+func (b *GCPBilling) Provider() string { return "gcp" }
+
+type bqParameter struct {
+	Name string `json:"name"`
+	Type struct {
+		Type string `json:"type"`
+	} `json:"parameterType"`
+	Value struct {
+		Value string `json:"value"`
+	} `json:"parameterValue"`
+}
+
+func newBQParameter(name, typ, value string) bqParameter {
+	var p bqParameter
+	p.Name, p.Type.Type, p.Value.Value = name, typ, value
+	return p
+}
+
+type bqQueryResponse struct {
+	JobComplete bool `json:"jobComplete"`
+	Rows        []struct {
+		F []struct {
+			V *string `json:"v"`
+		} `json:"f"`
+	} `json:"rows"`
+}
+
+// query builds the SQL and its parameters. Labels are key/value rows in
+// the export schema.
+func (b *GCPBilling) query(q CostQuery) (string, []bqParameter) {
+	params := []bqParameter{
+		newBQParameter("start", "TIMESTAMP", q.Start.Format(time.DateOnly)),
+		newBQParameter("end", "TIMESTAMP", q.End.Format(time.DateOnly)),
+		newBQParameter("group_tag", "STRING", q.groupTag()),
+	}
+
+	var sql strings.Builder
+	sql.WriteString("SELECT FORMAT_TIMESTAMP('%Y-%m', usage_start_time) AS month, service.description AS service, ")
+	sql.WriteString("(SELECT value FROM UNNEST(labels) WHERE key = @group_tag) AS resource, ")
+	sql.WriteString("SUM(cost) + SUM(IFNULL((SELECT SUM(c.amount) FROM UNNEST(credits) c), 0)) AS cost, ANY_VALUE(currency) AS currency ")
+	fmt.Fprintf(&sql, "FROM `%s` WHERE usage_start_time >= @start AND usage_start_time < @end", b.opts.Table)
+	for i, k := range q.sortedTags() {
+		key, value := fmt.Sprintf("tag_key_%d", i), fmt.Sprintf("tag_value_%d", i)
+		fmt.Fprintf(&sql, " AND EXISTS (SELECT 1 FROM UNNEST(labels) l WHERE l.key = @%s AND l.value = @%s)", key, value)
+		params = append(params, newBQParameter(key, "STRING", k), newBQParameter(value, "STRING", q.Tags[k]))
+	}
+	sql.WriteString(" GROUP BY month, service, resource")
+	return sql.String(), params
+}
+
+// Costs implements CostSource with a synchronous BigQuery query.
+func (b *GCPBilling) Costs(ctx context.Context, q CostQuery) ([]CostItem, error) {
+	sql, params := b.query(q)
+	body, err := json.Marshal(map[string]any{
+		"query":           sql,
+		"useLegacySql":    false,
+		"parameterMode":   "NAMED",
+		"queryParameters": params,
+		"timeoutMs":       costTimeout.Milliseconds(),
+		"maxResults":      100000,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rawURL := fmt.Sprintf("%s/bigquery/v2/projects/%s/queries", b.opts.Endpoint, url.PathEscape(b.opts.Project))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token, err := b.opts.TokenSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var resp bqQueryResponse
+	if err := postCostQuery(b.opts.HTTPClient, req, &resp); err != nil {
+		return nil, fmt.Errorf("gcp billing export: %w", err)
+	}
+	if !resp.JobComplete {
+		return nil, fmt.Errorf("gcp billing export: query did not finish within %s", costTimeout)
+	}
+
+	items := make([]CostItem, 0, len(resp.Rows))
+	for _, row := range resp.Rows {
+		if len(row.F) < 5 {
+			continue
+		}
+		field := func(i int) string {
+			if row.F[i].V == nil {
+				return ""
+			}
+			return *row.F[i].V
+		}
+		amount, err := strconv.ParseFloat(field(3), 64)
+		if err != nil {
+			return nil, fmt.Errorf("gcp billing export: invalid cost %q", field(3))
+		}
+		items = append(items, CostItem{
+			Provider: "gcp",
+			Month:    field(0),
+			Service:  field(1),
+			Resource: field(2),
+			Amount:   amount,
+			Currency: field(4),
+		})
+	}
+	return items, nil
+}
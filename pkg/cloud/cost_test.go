@@ -0,0 +1,214 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func staticToken(context.Context) (string, error) { return "token", nil }
+
+func TestMonthlyCostQuery(t *testing.T) {
+	q := MonthlyCostQuery(time.Date(2025, 3, 17, 10, 0, 0, 0, time.UTC), 3)
+
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), q.Start)
+	assert.Equal(t, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), q.End)
+	assert.Equal(t, []string{"2025-01", "2025-02", "2025-03"}, q.months())
+	assert.Equal(t, "true", q.Tags[TagManaged])
+}
+
+func TestBuildCostReport(t *testing.T) {
+	q := MonthlyCostQuery(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), 2)
+	resources := []ManagedResource{
+		{Kind: ResourceBackupBucket, Name: "gz-backups", Provider: "aws"},
+		{Kind: ResourceRunner, Name: "linux-x64", Provider: "gcp", Tag: "runner-linux"},
+		{Kind: ResourceRunner, Name: "idle", Provider: "azure"},
+	}
+	items := []CostItem{
+		{Provider: "aws", Month: "2025-01", Service: "Amazon S3", Resource: "backup-bucket/gz-backups", Amount: 10, Currency: "USD"},
+		{Provider: "aws", Month: "2025-02", Service: "Amazon S3", Resource: "backup-bucket/gz-backups", Amount: 15, Currency: "USD"},
+		{Provider: "aws", Month: "2025-02", Service: "AWS KMS", Resource: "backup-bucket/gz-backups", Amount: 1, Currency: "USD"},
+		{Provider: "gcp", Month: "2025-01", Service: "Compute Engine", Resource: "runner-linux", Amount: 40, Currency: "USD"},
+		{Provider: "gcp", Month: "2025-02", Service: "Compute Engine", Resource: "runner-linux", Amount: 20, Currency: "USD"},
+		{Provider: "aws", Month: "2025-02", Service: "Amazon EC2", Resource: "runner/arm64", Amount: 5, Currency: "USD"},
+		{Provider: "aws", Month: "2025-02", Service: "Amazon EC2", Resource: "scratch", Amount: 2, Currency: "USD"},
+		{Provider: "aws", Month: "2025-02", Service: "AWS Lambda", Amount: 3, Currency: "USD"},
+		{Provider: "aws", Month: "2024-12", Service: "Amazon S3", Resource: "backup-bucket/gz-backups", Amount: 99, Currency: "USD"},
+	}
+
+	report := BuildCostReport(q, items, resources)
+
+	assert.Equal(t, []string{"2025-01", "2025-02"}, report.Months)
+	assert.Equal(t, "USD", report.Currency)
+	assert.InDelta(t, 96.0, report.Total, 0.001)
+	assert.Equal(t, []float64{50, 46}, report.Totals)
+	assert.Equal(t, []float64{40, 25}, report.Kinds[ResourceRunner])
+	assert.Equal(t, []float64{10, 16}, report.Kinds[ResourceBackupBucket])
+
+	byName := make(map[string]ResourceCost)
+	for _, r := range report.Resources {
+		byName[r.Kind+"/"+r.Name] = r
+	}
+	require.Len(t, byName, 6)
+
+	runner := byName["runner/linux-x64"]
+	assert.Equal(t, "gcp", runner.Provider)
+	assert.InDelta(t, -50.0, runner.Change, 0.001)
+
+	bucket := byName["backup-bucket/gz-backups"]
+	assert.Equal(t, []float64{10, 16}, bucket.Monthly)
+	assert.Equal(t, []string{"AWS KMS", "Amazon S3"}, bucket.Services)
+	assert.InDelta(t, 60.0, bucket.Change, 0.001)
+
+	assert.Equal(t, []float64{0, 0}, byName["runner/idle"].Monthly)
+	assert.Equal(t, []float64{0, 5}, byName["runner/arm64"].Monthly)
+	assert.Equal(t, ResourceOther, byName["other/scratch"].Kind)
+	assert.Equal(t, []float64{0, 3}, byName["untagged/(untagged)"].Monthly)
+
+	assert.Equal(t, "runner/linux-x64", report.Resources[0].Kind+"/"+report.Resources[0].Name)
+}
+
+type failingSource struct{}
+
+func (failingSource) Provider() string { return "azure" }
+
+func (failingSource) Costs(context.Context, CostQuery) ([]CostItem, error) {
+	return nil, assert.AnError
+}
+
+func TestCollectCostsRecordsProviderErrors(t *testing.T) {
+	q := MonthlyCostQuery(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), 1)
+	report := CollectCosts(context.Background(), q, []CostSource{failingSource{}}, nil)
+
+	assert.Contains(t, report.Errors, "azure")
+	assert.Zero(t, report.Total)
+}
+
+func TestAWSCostExplorer(t *testing.T) {
+	var requests []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AWSInsightsIndexService.GetCostAndUsage", r.Header.Get("X-Amz-Target"))
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/ce/aws4_request")
+
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+
+		if req["NextPageToken"] == nil {
+			_, _ = io.WriteString(w, `{"NextPageToken":"p2","ResultsByTime":[{"TimePeriod":{"Start":"2025-01-01","End":"2025-02-01"},
+				"Groups":[{"Keys":["Amazon S3","gz-resource$backup-bucket/gz-backups"],"Metrics":{"UnblendedCost":{"Amount":"12.5","Unit":"USD"}}}]}]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"ResultsByTime":[{"TimePeriod":{"Start":"2025-02-01","End":"2025-03-01"},
+			"Groups":[{"Keys":["Amazon EC2","gz-resource$"],"Metrics":{"UnblendedCost":{"Amount":"3","Unit":"USD"}}}]}]}`)
+	}))
+	defer srv.Close()
+
+	source, err := NewAWSCostExplorer(context.Background(), AWSCostOptions{
+		Endpoint: srv.URL,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}, nil
+		}),
+	})
+	require.NoError(t, err)
+
+	q := MonthlyCostQuery(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), 2)
+	q.Tags["team"] = "infra"
+	items, err := source.Costs(context.Background(), q)
+	require.NoError(t, err)
+
+	assert.Equal(t, []CostItem{
+		{Provider: "aws", Month: "2025-01", Service: "Amazon S3", Resource: "backup-bucket/gz-backups", Amount: 12.5, Currency: "USD"},
+		{Provider: "aws", Month: "2025-02", Service: "Amazon EC2", Amount: 3, Currency: "USD"},
+	}, items)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, map[string]any{"Start": "2025-01-01", "End": "2025-03-01"}, requests[0]["TimePeriod"])
+	filter, _ := json.Marshal(requests[0]["Filter"])
+	assert.JSONEq(t, `{"And":[{"Tags":{"Key":"gz-managed","Values":["true"]}},{"Tags":{"Key":"team","Values":["infra"]}}]}`, string(filter))
+}
+
+func TestGCPBilling(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/bigquery/v2/projects/billing-proj/queries", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		_, _ = io.WriteString(w, `{"jobComplete":true,"rows":[
+			{"f":[{"v":"2025-01"},{"v":"Compute Engine"},{"v":"runner/linux-x64"},{"v":"42.25"},{"v":"EUR"}]},
+			{"f":[{"v":"2025-02"},{"v":"Cloud Storage"},{"v":null},{"v":"1"},{"v":"EUR"}]}]}`)
+	}))
+	defer srv.Close()
+
+	_, err := NewGCPBilling(GCPBillingOptions{Project: "p", Table: "bad`table"})
+	require.Error(t, err)
+
+	source, err := NewGCPBilling(GCPBillingOptions{
+		Project:     "billing-proj",
+		Table:       "billing-proj.billing.gcp_billing_export_v1_0000",
+		Endpoint:    srv.URL,
+		TokenSource: staticToken,
+	})
+	require.NoError(t, err)
+
+	items, err := source.Costs(context.Background(), MonthlyCostQuery(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), 2))
+	require.NoError(t, err)
+
+	assert.Equal(t, []CostItem{
+		{Provider: "gcp", Month: "2025-01", Service: "Compute Engine", Resource: "runner/linux-x64", Amount: 42.25, Currency: "EUR"},
+		{Provider: "gcp", Month: "2025-02", Service: "Cloud Storage", Amount: 1, Currency: "EUR"},
+	}, items)
+
+	sql, _ := body["query"].(string)
+	assert.Contains(t, sql, "FROM `billing-proj.billing.gcp_billing_export_v1_0000`")
+	assert.Contains(t, sql, "l.key = @tag_key_0 AND l.value = @tag_value_0")
+	assert.Equal(t, false, body["useLegacySql"])
+}
+
+func TestAzureCostManagement(t *testing.T) {
+	var srv *httptest.Server
+	var bodies []string
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/subscriptions/sub-1/providers/Microsoft.CostManagement/query", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+
+		columns := `"columns":[{"name":"Cost"},{"name":"BillingMonth"},{"name":"ServiceName"},{"name":"TagKey"},{"name":"TagValue"},{"name":"Currency"}]`
+		if r.URL.Query().Get("page") == "" {
+			_, _ = io.WriteString(w, `{"properties":{"nextLink":"`+srv.URL+r.URL.Path+`?api-version=2023-03-01&page=2",`+columns+`,
+				"rows":[[7.5,"2025-01-01T00:00:00","Storage","gz-resource","backup-bucket/blobs","USD"]]}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"properties":{`+columns+`,"rows":[[2,"2025-02-01T00:00:00","Virtual Machines","","","USD"]]}}`)
+	}))
+	defer srv.Close()
+
+	source, err := NewAzureCostManagement(AzureCostOptions{Scope: "subscriptions/sub-1/", Endpoint: srv.URL, TokenSource: staticToken})
+	require.NoError(t, err)
+
+	items, err := source.Costs(context.Background(), MonthlyCostQuery(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), 2))
+	require.NoError(t, err)
+
+	assert.Equal(t, []CostItem{
+		{Provider: "azure", Month: "2025-01", Service: "Storage", Resource: "backup-bucket/blobs", Amount: 7.5, Currency: "USD"},
+		{Provider: "azure", Month: "2025-02", Service: "Virtual Machines", Amount: 2, Currency: "USD"},
+	}, items)
+
+	require.Len(t, bodies, 2)
+	assert.True(t, strings.Contains(bodies[0], `"granularity":"Monthly"`))
+	assert.True(t, strings.Contains(bodies[0], `"tags":{"name":"gz-managed","operator":"In","values":["true"]}`))
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+// ErrObjectNotFound is returned when an object does not exist in the store.
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo describes an object in an ObjectStore.
+type ObjectInfo struct {
+	Key      string    `json:"key"`
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// ObjectStore is a minimal blob storage interface implemented for S3, GCS,
+// Azure Blob Storage and local directories. Keys are slash separated and
+// relative to the store's prefix.
+type ObjectStore interface {
+	// Put uploads size bytes from r under key, replacing any existing object.
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+
+	// Get opens the object stored under key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// List returns the objects whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+
+	// Delete removes the object stored under key. Missing objects are ignored.
+	Delete(ctx context.Context, key string) error
+
+	// String returns the store location as a URL.
+	String() string
+}
+
+// OpenObjectStore opens an object store from a URL:
+//
+//	s3://bucket/prefix[?region=eu-west-1&endpoint=https://minio.local:9000]
+//	gs://bucket/prefix[?endpoint=http://localhost:4443]
+//	azblob://account/container/prefix[?endpoint=http://localhost:10000/account]
+//	file:///path/to/dir or a plain directory path
+//
+// Credentials are taken from the usual provider environment: the AWS SDK
+// credential chain, GOOGLE_OAUTH_ACCESS_TOKEN or gcloud, and
+// AZURE_STORAGE_KEY or AZURE_STORAGE_SAS_TOKEN.
+func OpenObjectStore(ctx context.Context, rawURL string) (ObjectStore, error) {
+	if !strings.Contains(rawURL, "://") {
+		return NewFileStore(rawURL)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object store URL %q: %w", rawURL, err)
+	}
+
+	q := u.Query()
+	prefix := strings.Trim(u.Path, "/")
+
+	switch u.Scheme {
+	case "file":
+		return NewFileStore(u.Path)
+	case "s3":
+		return NewS3Store(ctx, S3Options{
+			Bucket:   u.Host,
+			Prefix:   prefix,
+			Region:   q.Get("region"),
+			Endpoint: q.Get("endpoint"),
+		})
+	case "gs", "gcs":
+		return NewGCSStore(GCSOptions{
+			Bucket:   u.Host,
+			Prefix:   prefix,
+			Endpoint: q.Get("endpoint"),
+		})
+	case "azblob", "azure":
+		container, rest, _ := strings.Cut(prefix, "/")
+		return NewAzureBlobStore(AzureBlobOptions{
+			Account:   u.Host,
+			Container: container,
+			Prefix:    rest,
+			Endpoint:  q.Get("endpoint"),
+		})
+	default:
+		return nil, fmt.Errorf("unsupported object store scheme %q (use s3, gs, azblob or file)", u.Scheme)
+	}
+}
+
+// joinKey joins the store prefix and a key.
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return strings.TrimPrefix(key, "/")
+	}
+
+	return path.Join(prefix, key)
+}
+
+// trimKey strips the store prefix from a full object name.
+func trimKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return strings.TrimPrefix(name, prefix+"/")
+}
+
+// storageError converts an unsuccessful HTTP response into an error.
+func storageError(resp *http.Response, op, key string) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s %s: %w", op, key, ErrObjectNotFound)
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("%s %s: %s: %s", op, key, resp.Status, strings.TrimSpace(string(body)))
+}
+
+// defaultStorageClient returns the client of stores without an explicit
+// HTTP client. It follows the global network settings; uploads of large
+// bundles can take long, so only connection setup is bounded.
+func defaultStorageClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = httpclient.Proxy
+	transport.TLSHandshakeTimeout = 30 * time.Second
+	transport.ResponseHeaderTimeout = 5 * time.Minute
+	return &http.Client{Transport: transport}
+}
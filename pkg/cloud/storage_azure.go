@@ -0,0 +1,281 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const azureStorageVersion = "2021-08-06"
+
+// AzureBlobOptions configures an AzureBlobStore.
+type AzureBlobOptions struct {
+	Account   string
+	Container string
+	Prefix    string
+
+	// Endpoint overrides https://<account>.blob.core.windows.net, e.g. for
+	// Azurite (http://127.0.0.1:10000/<account>).
+	Endpoint string
+
+	// AccountKey signs requests with Shared Key authorization. Defaults to
+	// AZURE_STORAGE_KEY.
+	AccountKey string
+
+	// SASToken is appended to every request when no account key is set.
+	// Defaults to AZURE_STORAGE_SAS_TOKEN.
+	SASToken string
+
+	HTTPClient *http.Client
+}
+
+// AzureBlobStore is an ObjectStore backed by Azure Blob Storage.
+type AzureBlobStore struct {
+	opts AzureBlobOptions
+	key  []byte
+}
+
+// NewAzureBlobStore creates an Azure Blob Storage store.
+func NewAzureBlobStore(opts AzureBlobOptions) (*AzureBlobStore, error) {
+	if opts.Account == "" || opts.Container == "" {
+		return nil, fmt.Errorf("azure storage account and container are required")
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", opts.Account)
+	}
+	opts.Endpoint = strings.TrimRight(opts.Endpoint, "/")
+	if opts.AccountKey == "" {
+		opts.AccountKey = os.Getenv("AZURE_STORAGE_KEY")
+	}
+	if opts.SASToken == "" {
+		opts.SASToken = os.Getenv("AZURE_STORAGE_SAS_TOKEN")
+	}
+	opts.SASToken = strings.TrimPrefix(opts.SASToken, "?")
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = defaultStorageClient()
+	}
+
+	s := &AzureBlobStore{opts: opts}
+	if opts.AccountKey != "" {
+		key, err := base64.StdEncoding.DecodeString(opts.AccountKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid azure storage account key: %w", err)
+		}
+		s.key = key
+	} else if opts.SASToken == "" {
+		return nil, fmt.Errorf("no azure credentials: set AZURE_STORAGE_KEY or AZURE_STORAGE_SAS_TOKEN")
+	}
+
+	return s, nil
+}
+
+func (s *AzureBlobStore) blobURL(key string, query url.Values) string {
+	u := s.opts.Endpoint + "/" + s.opts.Container
+	if key != "" {
+		u += "/" + escapeKey(joinKey(s.opts.Prefix, key))
+	}
+
+	encoded := query.Encode()
+	if s.key == nil && s.opts.SASToken != "" {
+		encoded = strings.TrimPrefix(encoded+"&"+s.opts.SASToken, "&")
+	}
+	if encoded != "" {
+		u += "?" + encoded
+	}
+
+	return u
+}
+
+func (s *AzureBlobStore) do(ctx context.Context, method, rawURL string, body io.Reader, size int64, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureStorageVersion)
+
+	if s.key != nil {
+		req.Header.Set("Authorization", "SharedKey "+s.opts.Account+":"+s.sign(req))
+	}
+
+	return s.opts.HTTPClient.Do(req)
+}
+
+// sign computes the Shared Key signature of a Blob service request.
+func (s *AzureBlobStore) sign(req *http.Request) string {
+	length := ""
+	if req.ContentLength > 0 {
+		length = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	var headers []string
+	for name := range req.Header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-ms-") {
+			headers = append(headers, lower)
+		}
+	}
+	sort.Strings(headers)
+
+	var canonical strings.Builder
+	for _, name := range headers {
+		fmt.Fprintf(&canonical, "%s:%s\n", name, strings.TrimSpace(req.Header.Get(name)))
+	}
+
+	// 계정 이름 + 인코딩된 경로 (Azurite처럼 경로에 계정이 있으면 그대로 포함)
+	canonical.WriteString("/" + s.opts.Account + req.URL.EscapedPath())
+
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for name := range query {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	for _, name := range params {
+		values := query[name]
+		sort.Strings(values)
+		fmt.Fprintf(&canonical, "\n%s:%s", strings.ToLower(name), strings.Join(values, ","))
+	}
+
+	stringToSign := strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		length,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date (x-ms-date is used instead)
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+		canonical.String(),
+	}, "\n")
+
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(stringToSign))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Put uploads the object as a block blob (up to 5000 MiB).
+func (s *AzureBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	resp, err := s.do(ctx, http.MethodPut, s.blobURL(key, nil), r, size, map[string]string{
+		"x-ms-blob-type": "BlockBlob",
+		"Content-Type":   "application/octet-stream",
+	})
+	if err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return storageError(resp, "put", key)
+	}
+
+	return nil
+}
+
+// Get downloads the blob.
+func (s *AzureBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.blobURL(key, nil), nil, 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", key, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		return nil, storageError(resp, "get", key)
+	}
+
+	return resp.Body, nil
+}
+
+// azureListResult is the List Blobs response document.
+type azureListResult struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			ContentLength int64  `xml:"Content-Length"`
+			LastModified  string `xml:"Last-Modified"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+// List pages through List Blobs.
+func (s *AzureBlobStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	marker := ""
+
+	for {
+		q := url.Values{"restype": {"container"}, "comp": {"list"}, "prefix": {joinKey(s.opts.Prefix, prefix)}}
+		if marker != "" {
+			q.Set("marker", marker)
+		}
+
+		resp, err := s.do(ctx, http.MethodGet, s.blobURL("", q), nil, 0, nil)
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", prefix, err)
+		}
+
+		var page azureListResult
+		if resp.StatusCode/100 != 2 {
+			err = storageError(resp, "list", prefix)
+		} else {
+			err = xml.NewDecoder(resp.Body).Decode(&page)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, b := range page.Blobs {
+			modified, _ := time.Parse(http.TimeFormat, b.Properties.LastModified)
+			objects = append(objects, ObjectInfo{Key: trimKey(s.opts.Prefix, b.Name), Size: b.Properties.ContentLength, Modified: modified})
+		}
+
+		if page.NextMarker == "" {
+			return objects, nil
+		}
+		marker = page.NextMarker
+	}
+}
+
+// Delete removes the blob.
+func (s *AzureBlobStore) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, s.blobURL(key, nil), nil, 0, nil)
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return storageError(resp, "delete", key)
+	}
+
+	return nil
+}
+
+func (s *AzureBlobStore) String() string {
+	return "azblob://" + joinKey(s.opts.Account+"/"+s.opts.Container, s.opts.Prefix)
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const gcsDefaultEndpoint = "https://storage.googleapis.com"
+
+// GCSOptions configures a GCSStore.
+type GCSOptions struct {
+	Bucket string
+	Prefix string
+
+	// Endpoint overrides the JSON API endpoint, e.g. for an emulator.
+	Endpoint string
+
+	// TokenSource returns an OAuth2 access token. Defaults to
+	// GOOGLE_OAUTH_ACCESS_TOKEN, then `gcloud auth print-access-token`.
+	TokenSource func(ctx context.Context) (string, error)
+
+	HTTPClient *http.Client
+}
+
+// GCSStore is an ObjectStore backed by Google Cloud Storage.
+type GCSStore struct {
+	opts GCSOptions
+}
+
+// NewGCSStore creates a Google Cloud Storage store.
+func NewGCSStore(opts GCSOptions) (*GCSStore, error) {
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("gcs bucket is required")
+	}
+	if opts.Endpoint == "" {
+		opts.Endpoint = gcsDefaultEndpoint
+	}
+	opts.Endpoint = strings.TrimRight(opts.Endpoint, "/")
+	if opts.TokenSource == nil {
+		opts.TokenSource = gcloudToken
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = defaultStorageClient()
+	}
+
+	return &GCSStore{opts: opts}, nil
+}
+
+// gcloudToken reads an access token from the environment or the gcloud CLI.
+func gcloudToken(ctx context.Context) (string, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	out, err := exec.CommandContext(ctx, "gcloud", "auth", "print-access-token").Output()
+	if err != nil {
+		return "", fmt.Errorf("no GCS credentials: set GOOGLE_OAUTH_ACCESS_TOKEN or log in with gcloud: %w", err)
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (s *GCSStore) do(ctx context.Context, method, rawURL string, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+
+	token, err := s.opts.TokenSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return s.opts.HTTPClient.Do(req)
+}
+
+func (s *GCSStore) objectURL(key string) string {
+	return fmt.Sprintf("%s/storage/v1/b/%s/o/%s", s.opts.Endpoint, url.PathEscape(s.opts.Bucket),
+		url.PathEscape(joinKey(s.opts.Prefix, key)))
+}
+
+// Put uploads the object with a simple media upload.
+func (s *GCSStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	q := url.Values{"uploadType": {"media"}, "name": {joinKey(s.opts.Prefix, key)}}
+	rawURL := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?%s", s.opts.Endpoint, url.PathEscape(s.opts.Bucket), q.Encode())
+
+	resp, err := s.do(ctx, http.MethodPost, rawURL, r, size)
+	if err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return storageError(resp, "put", key)
+	}
+
+	return nil
+}
+
+// Get downloads the object contents.
+func (s *GCSStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.objectURL(key)+"?alt=media", nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", key, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		return nil, storageError(resp, "get", key)
+	}
+
+	return resp.Body, nil
+}
+
+// gcsListResult is the objects.list response document.
+type gcsListResult struct {
+	Items []struct {
+		Name    string    `json:"name"`
+		Size    string    `json:"size"`
+		Updated time.Time `json:"updated"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// List pages through objects.list.
+func (s *GCSStore) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	token := ""
+
+	for {
+		q := url.Values{"prefix": {joinKey(s.opts.Prefix, prefix)}}
+		if token != "" {
+			q.Set("pageToken", token)
+		}
+		rawURL := fmt.Sprintf("%s/storage/v1/b/%s/o?%s", s.opts.Endpoint, url.PathEscape(s.opts.Bucket), q.Encode())
+
+		resp, err := s.do(ctx, http.MethodGet, rawURL, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", prefix, err)
+		}
+
+		var page gcsListResult
+		if resp.StatusCode/100 != 2 {
+			err = storageError(resp, "list", prefix)
+		} else {
+			err = json.NewDecoder(resp.Body).Decode(&page)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Items {
+			size, _ := strconv.ParseInt(item.Size, 10, 64)
+			objects = append(objects, ObjectInfo{Key: trimKey(s.opts.Prefix, item.Name), Size: size, Modified: item.Updated})
+		}
+
+		if page.NextPageToken == "" {
+			return objects, nil
+		}
+		token = page.NextPageToken
+	}
+}
+
+// Delete removes the object.
+func (s *GCSStore) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, s.objectURL(key), nil, 0)
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return storageError(resp, "delete", key)
+	}
+
+	return nil
+}
+
+func (s *GCSStore) String() string {
+	return "gs://" + joinKey(s.opts.Bucket, s.opts.Prefix)
+}
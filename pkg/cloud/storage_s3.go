@@ -0,0 +1,311 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+// unsignedPayload lets uploads stream without hashing the body first.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Options configures an S3Store.
+type S3Options struct {
+	Bucket string
+	Prefix string
+
+	// Region defaults to the region of the AWS configuration, then us-east-1.
+	Region string
+
+	// Endpoint selects an S3-compatible service (MinIO, Ceph, ...) addressed
+	// path-style. Empty uses AWS with virtual-hosted addressing.
+	Endpoint string
+
+	// Credentials defaults to the AWS SDK default credential chain.
+	Credentials aws.CredentialsProvider
+
+	HTTPClient *http.Client
+}
+
+// S3Store is an ObjectStore backed by Amazon S3 or an S3-compatible service.
+type S3Store struct {
+	opts   S3Options
+	signer *v4.Signer
+}
+
+// NewS3Store creates an S3 store, resolving region and credentials from the
+// AWS configuration when not given.
+func NewS3Store(ctx context.Context, opts S3Options) (*S3Store, error) {
+	if opts.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket is required")
+	}
+
+	if opts.Credentials == nil || opts.Region == "" {
+		cfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("load AWS configuration: %w", err)
+		}
+		if opts.Credentials == nil {
+			opts.Credentials = cfg.Credentials
+		}
+		if opts.Region == "" {
+			opts.Region = cfg.Region
+		}
+	}
+	if opts.Region == "" {
+		opts.Region = "us-east-1"
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = defaultStorageClient()
+	}
+
+	signer := v4.NewSigner(func(o *v4.SignerOptions) {
+		// S3 expects the path escaped exactly once.
+		o.DisableURIPathEscaping = true
+	})
+
+	return &S3Store{opts: opts, signer: signer}, nil
+}
+
+// objectURL builds the URL of an object (or the bucket when key is empty).
+func (s *S3Store) objectURL(key string, query url.Values) string {
+	escaped := escapeKey(key)
+
+	var base string
+	if s.opts.Endpoint != "" {
+		base = strings.TrimRight(s.opts.Endpoint, "/") + "/" + s.opts.Bucket + "/" + escaped
+	} else {
+		base = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.opts.Bucket, s.opts.Region, escaped)
+	}
+
+	if len(query) > 0 {
+		base += "?" + strings.ReplaceAll(query.Encode(), "+", "%20")
+	}
+
+	return base
+}
+
+func (s *S3Store) do(ctx context.Context, method, rawURL string, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	creds, err := s.opts.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("retrieve AWS credentials: %w", err)
+	}
+	if err := s.signer.SignHTTP(ctx, creds, req, unsignedPayload, "s3", s.opts.Region, time.Now()); err != nil {
+		return nil, fmt.Errorf("sign request: %w", err)
+	}
+
+	return s.opts.HTTPClient.Do(req)
+}
+
+// Put uploads the object with a single PUT (up to 5 GiB).
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	resp, err := s.do(ctx, http.MethodPut, s.objectURL(joinKey(s.opts.Prefix, key), nil), r, size)
+	if err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return storageError(resp, "put", key)
+	}
+
+	return nil
+}
+
+// Get downloads the object.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, s.objectURL(joinKey(s.opts.Prefix, key), nil), nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", key, err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		return nil, storageError(resp, "get", key)
+	}
+
+	return resp.Body, nil
+}
+
+// s3ListResult is the ListObjectsV2 response document.
+type s3ListResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List pages through ListObjectsV2.
+func (s *S3Store) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	token := ""
+
+	for {
+		q := url.Values{"list-type": {"2"}, "prefix": {joinKey(s.opts.Prefix, prefix)}}
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+
+		resp, err := s.do(ctx, http.MethodGet, s.objectURL("", q), nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", prefix, err)
+		}
+
+		var page s3ListResult
+		if resp.StatusCode/100 != 2 {
+			err = storageError(resp, "list", prefix)
+		} else {
+			err = xml.NewDecoder(resp.Body).Decode(&page)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range page.Contents {
+			objects = append(objects, ObjectInfo{Key: trimKey(s.opts.Prefix, c.Key), Size: c.Size, Modified: c.LastModified})
+		}
+
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// Delete removes the object.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, s.objectURL(joinKey(s.opts.Prefix, key), nil), nil, 0)
+	if err != nil {
+		return fmt.Errorf("delete %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return storageError(resp, "delete", key)
+	}
+
+	return nil
+}
+
+func (s *S3Store) String() string {
+	return "s3://" + joinKey(s.opts.Bucket, s.opts.Prefix)
+}
+
+// escapeKey escapes each segment of a slash separated key.
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// CompletedPart identifies an uploaded part of a multipart upload.
+type CompletedPart struct {
+	PartNumber int    `xml:"PartNumber" json:"partNumber"`
+	ETag       string `xml:"ETag" json:"etag"`
+}
+
+// CreateMultipartUpload starts a multipart upload of key and returns its
+// upload ID. Parts other than the last must be at least 5 MiB.
+func (s *S3Store) CreateMultipartUpload(ctx context.Context, key string) (string, error) {
+	resp, err := s.do(ctx, http.MethodPost, s.objectURL(joinKey(s.opts.Prefix, key), url.Values{"uploads": {""}}), nil, 0)
+	if err != nil {
+		return "", fmt.Errorf("create multipart upload %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return "", storageError(resp, "create multipart upload", key)
+	}
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil || result.UploadID == "" {
+		return "", fmt.Errorf("create multipart upload %s: no upload ID in response", key)
+	}
+	return result.UploadID, nil
+}
+
+// UploadPart uploads part number n (from 1) of a multipart upload and
+// returns its ETag. Uploading a part number again replaces the part.
+func (s *S3Store) UploadPart(ctx context.Context, key, uploadID string, n int, r io.Reader, size int64) (string, error) {
+	q := url.Values{"partNumber": {strconv.Itoa(n)}, "uploadId": {uploadID}}
+	resp, err := s.do(ctx, http.MethodPut, s.objectURL(joinKey(s.opts.Prefix, key), q), r, size)
+	if err != nil {
+		return "", fmt.Errorf("upload part %d of %s: %w", n, key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return "", storageError(resp, "upload part of", key)
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+// CompleteMultipartUpload assembles the parts into the object.
+func (s *S3Store) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []CompletedPart) error {
+	doc := struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []CompletedPart `xml:"Part"`
+	}{Parts: parts}
+	body, err := xml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	q := url.Values{"uploadId": {uploadID}}
+	resp, err := s.do(ctx, http.MethodPost, s.objectURL(joinKey(s.opts.Prefix, key), q), bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return fmt.Errorf("complete multipart upload %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	// S3 reports failures after a 200 status once it started assembling
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode/100 != 2 || bytes.Contains(data, []byte("<Error>")) {
+		return fmt.Errorf("complete multipart upload %s: %s: %s", key, resp.Status, strings.TrimSpace(string(data)))
+	}
+	return nil
+}
+
+// AbortMultipartUpload discards an unfinished multipart upload and its
+// parts.
+func (s *S3Store) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	q := url.Values{"uploadId": {uploadID}}
+	resp, err := s.do(ctx, http.MethodDelete, s.objectURL(joinKey(s.opts.Prefix, key), q), nil, 0)
+	if err != nil {
+		return fmt.Errorf("abort multipart upload %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return storageError(resp, "abort multipart upload", key)
+	}
+	return nil
+}
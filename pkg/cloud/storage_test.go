@@ -0,0 +1,295 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package cloud
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBlobServer keeps uploaded objects in memory keyed by object name.
+type fakeBlobServer struct {
+	mu      sync.Mutex
+	objects map[string]string
+	auth    []string
+}
+
+func newFakeBlobServer() *fakeBlobServer {
+	return &fakeBlobServer{objects: map[string]string{}}
+}
+
+func (f *fakeBlobServer) put(name string, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	f.objects[name] = string(body)
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	f.mu.Unlock()
+}
+
+func (f *fakeBlobServer) get(w http.ResponseWriter, name string) {
+	f.mu.Lock()
+	body, ok := f.objects[name]
+	f.mu.Unlock()
+	if !ok {
+		http.NotFound(w, nil)
+		return
+	}
+	_, _ = io.WriteString(w, body)
+}
+
+func (f *fakeBlobServer) names(prefix string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var names []string
+	for name := range f.objects {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// exerciseStore runs the same round trip against any ObjectStore.
+func exerciseStore(t *testing.T, store ObjectStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "repo/manifest.json", strings.NewReader(`{"a":1}`), 7))
+	require.NoError(t, store.Put(ctx, "repo/000001.bundle", strings.NewReader("bundle"), 6))
+
+	r, err := store.Get(ctx, "repo/manifest.json")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, `{"a":1}`, string(data))
+
+	_, err = store.Get(ctx, "repo/missing")
+	require.ErrorIs(t, err, ErrObjectNotFound)
+
+	objects, err := store.List(ctx, "repo/")
+	require.NoError(t, err)
+	keys := make([]string, 0, len(objects))
+	for _, o := range objects {
+		keys = append(keys, o.Key)
+	}
+	assert.ElementsMatch(t, []string{"repo/manifest.json", "repo/000001.bundle"}, keys)
+
+	require.NoError(t, store.Delete(ctx, "repo/000001.bundle"))
+	_, err = store.Get(ctx, "repo/000001.bundle")
+	require.ErrorIs(t, err, ErrObjectNotFound)
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	exerciseStore(t, store)
+
+	require.Error(t, store.Put(context.Background(), "../escape", strings.NewReader("x"), 1))
+}
+
+func TestOpenObjectStore(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("AZURE_STORAGE_SAS_TOKEN", "sv=1&sig=x")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	tests := map[string]string{
+		"s3://bucket/mirrors?region=eu-west-1":   "s3://bucket/mirrors",
+		"gs://bucket/mirrors":                    "gs://bucket/mirrors",
+		"azblob://account/container/mirrors/git": "azblob://account/container/mirrors/git",
+		"file:///tmp/bundles":                    "file:///tmp/bundles",
+	}
+	for raw, want := range tests {
+		store, err := OpenObjectStore(ctx, raw)
+		require.NoError(t, err, raw)
+		assert.Equal(t, want, store.String())
+	}
+
+	_, err := OpenObjectStore(ctx, "ftp://host/path")
+	require.Error(t, err)
+}
+
+func TestS3Store(t *testing.T) {
+	fake := newFakeBlobServer()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/bucket/")
+		switch {
+		case r.Method == http.MethodPut:
+			fake.put(name, r)
+		case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+			fmt.Fprint(w, "<ListBucketResult>")
+			for _, n := range fake.names(r.URL.Query().Get("prefix")) {
+				fmt.Fprintf(w, "<Contents><Key>%s</Key><Size>1</Size></Contents>", n)
+			}
+			fmt.Fprint(w, "<IsTruncated>false</IsTruncated></ListBucketResult>")
+		case r.Method == http.MethodGet:
+			fake.get(w, name)
+		case r.Method == http.MethodDelete:
+			fake.mu.Lock()
+			delete(fake.objects, name)
+			fake.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	store, err := NewS3Store(context.Background(), S3Options{
+		Bucket:   "bucket",
+		Prefix:   "mirrors",
+		Region:   "eu-west-1",
+		Endpoint: srv.URL,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}, nil
+		}),
+	})
+	require.NoError(t, err)
+	exerciseStore(t, store)
+
+	require.NotEmpty(t, fake.auth)
+	assert.True(t, strings.HasPrefix(fake.auth[0], "AWS4-HMAC-SHA256 Credential=AKID/"), fake.auth[0])
+	assert.Contains(t, fake.auth[0], "/eu-west-1/s3/aws4_request")
+}
+
+func TestS3MultipartUpload(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		parts     = map[string]string{}
+		completed string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		q := r.URL.Query()
+		assert.Equal(t, "/bucket/support/report.tar.gz", r.URL.Path)
+		switch {
+		case r.Method == http.MethodPost && q.Has("uploads"):
+			fmt.Fprint(w, "<InitiateMultipartUploadResult><UploadId>up-1</UploadId></InitiateMultipartUploadResult>")
+		case r.Method == http.MethodPut && q.Get("uploadId") == "up-1":
+			body, _ := io.ReadAll(r.Body)
+			parts[q.Get("partNumber")] = string(body)
+			w.Header().Set("ETag", `"etag-`+q.Get("partNumber")+`"`)
+		case r.Method == http.MethodPost && q.Get("uploadId") == "up-1":
+			body, _ := io.ReadAll(r.Body)
+			completed = string(body)
+			fmt.Fprint(w, "<CompleteMultipartUploadResult/>")
+		case r.Method == http.MethodDelete && q.Get("uploadId") == "up-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	store, err := NewS3Store(context.Background(), S3Options{
+		Bucket:   "bucket",
+		Prefix:   "support",
+		Region:   "eu-west-1",
+		Endpoint: srv.URL,
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}, nil
+		}),
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	id, err := store.CreateMultipartUpload(ctx, "report.tar.gz")
+	require.NoError(t, err)
+	assert.Equal(t, "up-1", id)
+
+	var done []CompletedPart
+	for i, chunk := range []string{"first", "second"} {
+		etag, err := store.UploadPart(ctx, "report.tar.gz", id, i+1, strings.NewReader(chunk), int64(len(chunk)))
+		require.NoError(t, err)
+		done = append(done, CompletedPart{PartNumber: i + 1, ETag: etag})
+	}
+	require.NoError(t, store.CompleteMultipartUpload(ctx, "report.tar.gz", id, done))
+	require.NoError(t, store.AbortMultipartUpload(ctx, "report.tar.gz", id))
+
+	assert.Equal(t, map[string]string{"1": "first", "2": "second"}, parts)
+	assert.Equal(t, `<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>"etag-1"</ETag></Part>`+
+		`<Part><PartNumber>2</PartNumber><ETag>"etag-2"</ETag></Part></CompleteMultipartUpload>`,
+		strings.ReplaceAll(completed, "&#34;", `"`))
+}
+
+func TestGCSStore(t *testing.T) {
+	fake := newFakeBlobServer()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/upload/storage/v1/b/bucket/o":
+			fake.put(r.URL.Query().Get("name"), r)
+		case r.Method == http.MethodGet && r.URL.Path == "/storage/v1/b/bucket/o":
+			var items []string
+			for _, n := range fake.names(r.URL.Query().Get("prefix")) {
+				items = append(items, fmt.Sprintf(`{"name":%q,"size":"1"}`, n))
+			}
+			fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+		case r.Method == http.MethodGet:
+			fake.get(w, strings.TrimPrefix(r.URL.Path, "/storage/v1/b/bucket/o/"))
+		case r.Method == http.MethodDelete:
+			fake.mu.Lock()
+			delete(fake.objects, strings.TrimPrefix(r.URL.Path, "/storage/v1/b/bucket/o/"))
+			fake.mu.Unlock()
+		}
+	}))
+	defer srv.Close()
+
+	store, err := NewGCSStore(GCSOptions{
+		Bucket:      "bucket",
+		Prefix:      "mirrors",
+		Endpoint:    srv.URL,
+		TokenSource: func(context.Context) (string, error) { return "tok", nil },
+	})
+	require.NoError(t, err)
+	exerciseStore(t, store)
+	assert.Equal(t, "Bearer tok", fake.auth[0])
+}
+
+func TestAzureBlobStore(t *testing.T) {
+	fake := newFakeBlobServer()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/container/")
+		switch {
+		case r.Method == http.MethodPut:
+			assert.Equal(t, "BlockBlob", r.Header.Get("x-ms-blob-type"))
+			fake.put(name, r)
+		case r.Method == http.MethodGet && r.URL.Query().Get("comp") == "list":
+			fmt.Fprint(w, "<EnumerationResults><Blobs>")
+			for _, n := range fake.names(r.URL.Query().Get("prefix")) {
+				fmt.Fprintf(w, "<Blob><Name>%s</Name><Properties><Content-Length>1</Content-Length></Properties></Blob>", n)
+			}
+			fmt.Fprint(w, "</Blobs><NextMarker/></EnumerationResults>")
+		case r.Method == http.MethodGet:
+			fake.get(w, name)
+		case r.Method == http.MethodDelete:
+			fake.mu.Lock()
+			delete(fake.objects, name)
+			fake.mu.Unlock()
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer srv.Close()
+
+	store, err := NewAzureBlobStore(AzureBlobOptions{
+		Account:    "account",
+		Container:  "container",
+		Prefix:     "mirrors",
+		Endpoint:   srv.URL,
+		AccountKey: base64.StdEncoding.EncodeToString([]byte("key")),
+	})
+	require.NoError(t, err)
+	exerciseStore(t, store)
+	assert.True(t, strings.HasPrefix(fake.auth[0], "SharedKey account:"), fake.auth[0])
+}
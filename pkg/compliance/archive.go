@@ -0,0 +1,247 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package compliance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Archive layout.
+const (
+	ManifestFile  = "manifest.json"
+	SignatureFile = "manifest.json.sig"
+	objectsDir    = "objects"
+
+	// ManifestVersion is the manifest format written by this package.
+	ManifestVersion = 1
+)
+
+// Evidence kinds.
+const (
+	KindBranchProtection = "branch-protection"
+	KindAuditLog         = "audit-log"
+	KindScanResult       = "scan-result"
+	KindFile             = "file"
+)
+
+// ErrUnsupportedManifest is returned for manifests of a newer format.
+var ErrUnsupportedManifest = errors.New("unsupported evidence manifest version")
+
+// Artifact is a piece of evidence to add to an archive.
+type Artifact struct {
+	// Name is the artifact path within the archive, e.g.
+	// "branch-protection/acme/api.json".
+	Name string
+	Kind string
+	// Source describes where the artifact came from (a file path or API).
+	Source    string
+	MediaType string
+	Content   []byte
+}
+
+// Entry records one artifact in the manifest.
+type Entry struct {
+	Seq         int       `json:"seq"`
+	Name        string    `json:"name"`
+	Kind        string    `json:"kind"`
+	Source      string    `json:"source,omitempty"`
+	MediaType   string    `json:"mediaType,omitempty"`
+	Digest      string    `json:"digest"`
+	Size        int64     `json:"size"`
+	CollectedAt time.Time `json:"collectedAt"`
+	// Prev is the hash of the previous entry, empty for the first one.
+	Prev string `json:"prev"`
+	Hash string `json:"hash"`
+}
+
+// Manifest lists the entries of an archive in collection order.
+type Manifest struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+	// Head is the hash of the last entry.
+	Head    string  `json:"head"`
+	Entries []Entry `json:"entries"`
+}
+
+// Archive is an evidence archive opened for appending.
+type Archive struct {
+	dir      string
+	manifest Manifest
+	now      func() time.Time
+}
+
+// Open opens the archive in dir, creating it if it does not exist.
+// Existing entries are kept; new ones extend the chain.
+func Open(dir string) (*Archive, error) {
+	a := &Archive{dir: dir, now: time.Now}
+
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		a.manifest = Manifest{Version: ManifestVersion}
+		return a, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read evidence manifest: %w", err)
+	}
+
+	manifest, err := parseManifest(data)
+	if err != nil {
+		return nil, err
+	}
+	a.manifest = *manifest
+	return a, nil
+}
+
+// Dir returns the archive directory.
+func (a *Archive) Dir() string {
+	return a.dir
+}
+
+// Manifest returns the current manifest, including unsaved entries.
+func (a *Archive) Manifest() Manifest {
+	m := a.manifest
+	m.Entries = append([]Entry(nil), m.Entries...)
+	return m
+}
+
+// Add stores art and appends its entry to the chain. Content that is
+// already in the archive is stored only once.
+func (a *Archive) Add(art Artifact) (Entry, error) {
+	if art.Name == "" {
+		return Entry{}, fmt.Errorf("evidence artifact has no name")
+	}
+	kind := art.Kind
+	if kind == "" {
+		kind = KindFile
+	}
+
+	sum := sha256.Sum256(art.Content)
+	hexSum := hex.EncodeToString(sum[:])
+	if err := a.writeObject(hexSum, art.Content); err != nil {
+		return Entry{}, err
+	}
+
+	now := a.now().UTC()
+	if a.manifest.Created.IsZero() {
+		a.manifest.Created = now
+	}
+	a.manifest.Updated = now
+
+	entry := Entry{
+		Seq:         len(a.manifest.Entries) + 1,
+		Name:        filepath.ToSlash(art.Name),
+		Kind:        kind,
+		Source:      art.Source,
+		MediaType:   art.MediaType,
+		Digest:      "sha256:" + hexSum,
+		Size:        int64(len(art.Content)),
+		CollectedAt: now,
+		Prev:        a.manifest.Head,
+	}
+	entry.Hash = entryHash(entry)
+
+	a.manifest.Entries = append(a.manifest.Entries, entry)
+	a.manifest.Head = entry.Hash
+	return entry, nil
+}
+
+// Save writes the manifest. A signature over the previous manifest no
+// longer applies and is removed; sign the archive again after saving.
+func (a *Archive) Save() error {
+	data, err := json.MarshalIndent(a.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode evidence manifest: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(a.dir, ManifestFile), append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write evidence manifest: %w", err)
+	}
+	if err := os.Remove(filepath.Join(a.dir, SignatureFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale manifest signature: %w", err)
+	}
+	return nil
+}
+
+func (a *Archive) writeObject(hexSum string, content []byte) error {
+	path := objectPath(a.dir, hexSum)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create evidence object directory: %w", err)
+	}
+	if err := writeFileAtomic(path, content); err != nil {
+		return fmt.Errorf("failed to write evidence object: %w", err)
+	}
+	// 증거 객체는 내용 주소로 저장되므로 읽기 전용으로 둔다
+	_ = os.Chmod(path, 0o444)
+	return nil
+}
+
+func objectPath(dir, hexSum string) string {
+	return filepath.Join(dir, objectsDir, "sha256", hexSum)
+}
+
+func parseManifest(data []byte) (*Manifest, error) {
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse evidence manifest: %w", err)
+	}
+	if manifest.Version > ManifestVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedManifest, manifest.Version)
+	}
+	return &manifest, nil
+}
+
+// entryHash hashes the fields of e that the chain protects, including the
+// previous hash. Fields are newline separated in a fixed order so the hash
+// does not depend on JSON encoding details.
+func entryHash(e Entry) string {
+	fields := []string{
+		e.Prev,
+		strconv.Itoa(e.Seq),
+		e.Name,
+		e.Kind,
+		e.Source,
+		e.MediaType,
+		e.Digest,
+		strconv.FormatInt(e.Size, 10),
+		e.CollectedAt.UTC().Format(time.RFC3339Nano),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
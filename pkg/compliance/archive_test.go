@@ -0,0 +1,199 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package compliance
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/github"
+)
+
+func newTestArchive(t *testing.T) *Archive {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "evidence")
+	archive, err := Open(dir)
+	require.NoError(t, err)
+	for _, art := range []Artifact{
+		{Name: "audit-log/2025-06.jsonl", Kind: KindAuditLog, Content: []byte(`{"action":"repo.update"}`)},
+		{Name: "scan-result/trivy.json", Kind: KindScanResult, Content: []byte(`{"vulnerabilities":[]}`)},
+		{Name: "scan-result/copy.json", Kind: KindScanResult, Content: []byte(`{"vulnerabilities":[]}`)},
+	} {
+		_, err := archive.Add(art)
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Save())
+	return archive
+}
+
+func TestArchiveVerify(t *testing.T) {
+	archive := newTestArchive(t)
+
+	result, err := Verify(archive.Dir(), VerifyOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Entries)
+	assert.Equal(t, archive.Manifest().Head, result.Head)
+	assert.False(t, result.Signed)
+
+	objects, err := os.ReadDir(filepath.Join(archive.Dir(), "objects", "sha256"))
+	require.NoError(t, err)
+	assert.Len(t, objects, 2, "identical content is stored once")
+
+	// Appending to a reopened archive extends the chain
+	reopened, err := Open(archive.Dir())
+	require.NoError(t, err)
+	entry, err := reopened.Add(Artifact{Name: "file/notes.txt", Content: []byte("reviewed")})
+	require.NoError(t, err)
+	assert.Equal(t, 4, entry.Seq)
+	assert.Equal(t, archive.Manifest().Head, entry.Prev)
+	require.NoError(t, reopened.Save())
+
+	_, err = Verify(archive.Dir(), VerifyOptions{})
+	require.NoError(t, err)
+
+	_, err = Verify(archive.Dir(), VerifyOptions{RequireSignature: true})
+	require.ErrorIs(t, err, ErrVerificationFailed)
+}
+
+func TestArchiveVerifyDetectsTampering(t *testing.T) {
+	tests := map[string]func(t *testing.T, dir string, m *Manifest){
+		"modified artifact": func(t *testing.T, dir string, m *Manifest) {
+			path := objectPath(dir, m.Entries[0].Digest[len("sha256:"):])
+			require.NoError(t, os.Chmod(path, 0o644))
+			require.NoError(t, os.WriteFile(path, []byte(`{"action":"none"}`), 0o644))
+		},
+		"edited entry": func(t *testing.T, dir string, m *Manifest) {
+			m.Entries[1].Source = "elsewhere"
+		},
+		"dropped entry": func(t *testing.T, dir string, m *Manifest) {
+			m.Entries = append(m.Entries[:1], m.Entries[2:]...)
+		},
+		"rehashed entry": func(t *testing.T, dir string, m *Manifest) {
+			m.Entries[0].Name = "audit-log/other.jsonl"
+			m.Entries[0].Hash = entryHash(m.Entries[0])
+		},
+	}
+
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			archive := newTestArchive(t)
+			m := archive.Manifest()
+			tamper(t, archive.Dir(), &m)
+			data, err := json.Marshal(m)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(archive.Dir(), ManifestFile), data, 0o644))
+
+			result, err := Verify(archive.Dir(), VerifyOptions{})
+			require.ErrorIs(t, err, ErrVerificationFailed)
+			assert.NotEmpty(t, result.Problems)
+		})
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	var args []string
+	signer := &CosignSigner{Key: "cosign.key", run: func(_ context.Context, name string, a ...string) ([]byte, error) {
+		args = append([]string{name}, a...)
+		content, err := os.ReadFile(a[len(a)-1])
+		require.NoError(t, err)
+		digest := sha256.Sum256(content)
+		sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		return []byte(base64.StdEncoding.EncodeToString(sig) + "\n"), nil
+	}}
+
+	archive := newTestArchive(t)
+	require.NoError(t, Sign(context.Background(), archive.Dir(), signer))
+	assert.Contains(t, args, "sign-blob")
+	assert.Contains(t, args, "cosign.key")
+
+	result, err := Verify(archive.Dir(), VerifyOptions{PublicKey: publicKey})
+	require.NoError(t, err)
+	assert.True(t, result.SignatureVerified)
+
+	// A rewritten chain with a valid structure still fails the signature
+	m := archive.Manifest()
+	m.Entries = m.Entries[:2]
+	m.Head = m.Entries[1].Hash
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(archive.Dir(), ManifestFile), data, 0o644))
+
+	_, err = Verify(archive.Dir(), VerifyOptions{})
+	require.NoError(t, err, "the truncated chain is consistent on its own")
+	_, err = Verify(archive.Dir(), VerifyOptions{PublicKey: publicKey})
+	require.ErrorIs(t, err, ErrVerificationFailed)
+
+	// Saving invalidates the old signature
+	require.NoError(t, archive.Save())
+	_, err = os.Stat(filepath.Join(archive.Dir(), SignatureFile))
+	assert.True(t, os.IsNotExist(err))
+}
+
+type fakeProtectionClient struct{}
+
+func (fakeProtectionClient) ListRepositories(context.Context, string, *github.ListOptions) ([]*github.Repository, error) {
+	return []*github.Repository{
+		{Name: "web", DefaultBranch: "main"},
+		{Name: "api", DefaultBranch: "develop"},
+		{Name: "legacy", Archived: true},
+	}, nil
+}
+
+func (fakeProtectionClient) GetBranchProtection(_ context.Context, _, repo, _ string) (*github.BranchProtection, error) {
+	if repo == "web" {
+		return nil, &github.APIError{Message: "Branch not protected", StatusCode: 404}
+	}
+	return &github.BranchProtection{EnforceAdmins: true}, nil
+}
+
+func TestCollect(t *testing.T) {
+	logs := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(logs, "a.jsonl"), []byte("{}\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(logs, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(logs, "sub", "b.jsonl"), []byte("{}\n{}\n"), 0o644))
+
+	archive, err := Open(filepath.Join(t.TempDir(), "evidence"))
+	require.NoError(t, err)
+	entries, err := Collect(context.Background(), archive,
+		BranchProtectionSource{Client: fakeProtectionClient{}, Organization: "acme"},
+		FileSource{Kind: KindAuditLog, Path: logs},
+	)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, "branch-protection/acme/api.json", entries[0].Name)
+	assert.Equal(t, "branch-protection/acme/web.json", entries[1].Name)
+	assert.Equal(t, KindAuditLog, entries[2].Kind)
+	assert.Equal(t, "application/x-ndjson", entries[3].MediaType)
+
+	var dump BranchProtectionDump
+	content, err := os.ReadFile(objectPath(archive.Dir(), entries[1].Digest[len("sha256:"):]))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(content, &dump))
+	assert.Equal(t, "acme/web", dump.Repository)
+	assert.False(t, dump.Protected)
+
+	_, err = Verify(archive.Dir(), VerifyOptions{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package compliance collects audit evidence into tamper-evident archives.
+//
+// An archive is a directory holding content-addressed artifacts under
+// objects/sha256/ and a manifest.json listing them. Every manifest entry
+// hashes the previous one, so rewriting, reordering or dropping an entry
+// breaks the chain from that point on, and changing an artifact no longer
+// matches its digest. The manifest can be signed with cosign; a signature
+// over the manifest covers the chain head and with it every artifact.
+package compliance
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package compliance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrCosignNotFound is returned when signing without a cosign binary.
+var ErrCosignNotFound = errors.New("cosign not found in PATH")
+
+// Signer produces a detached signature over a file.
+type Signer interface {
+	// Sign returns the signature of the file at path.
+	Sign(ctx context.Context, path string) ([]byte, error)
+}
+
+// commandRunner runs name with args and returns its stdout. A non-zero exit
+// is reported as an *exec.ExitError carrying stderr.
+type commandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitErr.Stderr = stderr.Bytes()
+		}
+	}
+	return out, err
+}
+
+// CosignSigner signs with `cosign sign-blob --key`. Key is a cosign key
+// file or a KMS reference such as awskms:// or gcpkms://; the key password
+// is read by cosign from COSIGN_PASSWORD. Keyless signing is not supported
+// because the resulting certificate could not be checked offline.
+type CosignSigner struct {
+	Key string
+	run commandRunner
+}
+
+// NewCosignSigner creates a signer for key.
+func NewCosignSigner(key string) *CosignSigner {
+	return &CosignSigner{Key: key, run: runCommand}
+}
+
+// Sign signs the file at path and returns the base64 signature.
+func (s *CosignSigner) Sign(ctx context.Context, path string) ([]byte, error) {
+	if s.Key == "" {
+		return nil, fmt.Errorf("cosign signing key is required")
+	}
+	if s.run == nil {
+		if _, err := exec.LookPath("cosign"); err != nil {
+			return nil, ErrCosignNotFound
+		}
+		s.run = runCommand
+	}
+
+	out, err := s.run(ctx, "cosign", "sign-blob", "--yes", "--tlog-upload=false", "--key", s.Key, path)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("cosign sign-blob failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("cosign sign-blob failed: %w", err)
+	}
+
+	sig := bytes.TrimSpace(out)
+	if len(sig) == 0 {
+		return nil, fmt.Errorf("cosign sign-blob returned no signature")
+	}
+	return sig, nil
+}
+
+// Sign signs the saved manifest of the archive in dir and writes the
+// signature next to it.
+func Sign(ctx context.Context, dir string, signer Signer) error {
+	manifest := filepath.Join(dir, ManifestFile)
+	if _, err := os.Stat(manifest); err != nil {
+		return fmt.Errorf("failed to sign evidence manifest: %w", err)
+	}
+
+	sig, err := signer.Sign(ctx, manifest)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(dir, SignatureFile), append(sig, '\n')); err != nil {
+		return fmt.Errorf("failed to write manifest signature: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package compliance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/github"
+)
+
+// Source gathers evidence artifacts.
+type Source interface {
+	// Name describes the source in progress and error messages.
+	Name() string
+	Collect(ctx context.Context) ([]Artifact, error)
+}
+
+// Collect adds the artifacts of every source to the archive and saves it.
+// Sources are collected in order; the first failing source stops the
+// collection without saving, leaving the archive as it was.
+func Collect(ctx context.Context, archive *Archive, sources ...Source) ([]Entry, error) {
+	var entries []Entry
+	for _, src := range sources {
+		artifacts, err := src.Collect(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect %s evidence: %w", src.Name(), err)
+		}
+		for _, art := range artifacts {
+			entry, err := archive.Add(art)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+	}
+	if err := archive.Save(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FileSource collects a file, or every regular file below a directory, as
+// evidence of Kind, such as audit logs or scanner reports.
+type FileSource struct {
+	Kind string
+	Path string
+}
+
+// Name returns the kind and path.
+func (s FileSource) Name() string {
+	return fmt.Sprintf("%s (%s)", s.Kind, s.Path)
+}
+
+// Collect reads the files in lexical order.
+func (s FileSource) Collect(_ context.Context) ([]Artifact, error) {
+	info, err := os.Stat(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		art, err := s.read(s.Path, filepath.Base(s.Path))
+		if err != nil {
+			return nil, err
+		}
+		return []Artifact{art}, nil
+	}
+
+	var artifacts []Artifact
+	err = filepath.WalkDir(s.Path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(s.Path, p)
+		if err != nil {
+			return err
+		}
+		art, err := s.read(p, path.Join(filepath.Base(s.Path), filepath.ToSlash(rel)))
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, art)
+		return nil
+	})
+	return artifacts, err
+}
+
+func (s FileSource) read(p, name string) (Artifact, error) {
+	content, err := os.ReadFile(p)
+	if err != nil {
+		return Artifact{}, err
+	}
+	source := p
+	if abs, err := filepath.Abs(p); err == nil {
+		source = abs
+	}
+	return Artifact{
+		Name:      path.Join(s.Kind, name),
+		Kind:      s.Kind,
+		Source:    source,
+		MediaType: mediaType(p),
+		Content:   content,
+	}, nil
+}
+
+func mediaType(p string) string {
+	switch filepath.Ext(p) {
+	case ".json":
+		return "application/json"
+	case ".sarif":
+		return "application/sarif+json"
+	case ".jsonl", ".ndjson":
+		return "application/x-ndjson"
+	case ".yaml", ".yml":
+		return "application/yaml"
+	default:
+		return "text/plain"
+	}
+}
+
+// BranchProtectionClient is the part of github.RepoConfigClient used to
+// dump branch protection rules.
+type BranchProtectionClient interface {
+	ListRepositories(ctx context.Context, org string, options *github.ListOptions) ([]*github.Repository, error)
+	GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.BranchProtection, error)
+}
+
+// BranchProtectionSource dumps the protection of the default branch of
+// every unarchived repository in an organization, one artifact per
+// repository. Unprotected branches are recorded as such.
+type BranchProtectionSource struct {
+	Client       BranchProtectionClient
+	Organization string
+	// Repositories limits the dump to these repository names.
+	Repositories []string
+}
+
+// BranchProtectionDump is the content of a branch protection artifact.
+type BranchProtectionDump struct {
+	Repository string                   `json:"repository"`
+	Branch     string                   `json:"branch"`
+	Protected  bool                     `json:"protected"`
+	Protection *github.BranchProtection `json:"protection,omitempty"`
+	Retrieved  time.Time                `json:"retrieved"`
+}
+
+// Name returns "branch protection".
+func (s BranchProtectionSource) Name() string {
+	return "branch protection"
+}
+
+// Collect fetches the rules repository by repository.
+func (s BranchProtectionSource) Collect(ctx context.Context) ([]Artifact, error) {
+	repos, err := s.Client.ListRepositories(ctx, s.Organization, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(s.Repositories))
+	for _, name := range s.Repositories {
+		wanted[name] = true
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Name < repos[j].Name })
+
+	var artifacts []Artifact
+	for _, repo := range repos {
+		if repo.Archived || (len(wanted) > 0 && !wanted[repo.Name]) {
+			continue
+		}
+		branch := repo.DefaultBranch
+		if branch == "" {
+			branch = "main"
+		}
+
+		dump := BranchProtectionDump{Repository: s.Organization + "/" + repo.Name, Branch: branch, Retrieved: time.Now().UTC()}
+		protection, err := s.Client.GetBranchProtection(ctx, s.Organization, repo.Name, branch)
+		var apiErr *github.APIError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+			// GitHub은 보호 규칙이 없는 브랜치에 404를 반환
+		case err != nil:
+			return nil, fmt.Errorf("%s: %w", dump.Repository, err)
+		default:
+			dump.Protected = true
+			dump.Protection = protection
+		}
+
+		content, err := json.MarshalIndent(dump, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, Artifact{
+			Name:      path.Join(KindBranchProtection, s.Organization, repo.Name+".json"),
+			Kind:      KindBranchProtection,
+			Source:    fmt.Sprintf("github:/repos/%s/branches/%s/protection", dump.Repository, branch),
+			MediaType: "application/json",
+			Content:   content,
+		})
+	}
+	return artifacts, nil
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package compliance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/pkg/plugins"
+)
+
+// ErrVerificationFailed is returned when an archive does not verify.
+var ErrVerificationFailed = errors.New("evidence archive verification failed")
+
+// VerifyOptions configures Verify.
+type VerifyOptions struct {
+	// PublicKey is a PEM encoded cosign public key. When set, the manifest
+	// signature must be present and valid.
+	PublicKey []byte
+	// RequireSignature fails verification of unsigned archives.
+	RequireSignature bool
+}
+
+// Problem is one verification failure. Seq is 0 for archive-wide problems.
+type Problem struct {
+	Seq    int    `json:"seq,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// VerifyResult reports the outcome of Verify.
+type VerifyResult struct {
+	Entries int    `json:"entries"`
+	Head    string `json:"head"`
+	// Signed reports whether a signature file is present; SignatureVerified
+	// whether it was checked against a public key and is valid.
+	Signed            bool      `json:"signed"`
+	SignatureVerified bool      `json:"signatureVerified"`
+	Problems          []Problem `json:"problems,omitempty"`
+}
+
+// OK reports whether no problems were found.
+func (r *VerifyResult) OK() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *VerifyResult) fail(e *Entry, format string, args ...any) {
+	p := Problem{Reason: fmt.Sprintf(format, args...)}
+	if e != nil {
+		p.Seq, p.Name = e.Seq, e.Name
+	}
+	r.Problems = append(r.Problems, p)
+}
+
+// Verify checks the archive in dir: every artifact against its digest,
+// every entry against the hash chain, and the manifest signature when a
+// public key is given. A result is returned even when verification fails,
+// together with an error wrapping ErrVerificationFailed.
+func Verify(dir string, opts VerifyOptions) (*VerifyResult, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read evidence manifest: %w", err)
+	}
+	manifest, err := parseManifest(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerifyResult{Entries: len(manifest.Entries), Head: manifest.Head}
+
+	prev := ""
+	for i := range manifest.Entries {
+		e := &manifest.Entries[i]
+		if e.Seq != i+1 {
+			result.fail(e, "sequence number %d, expected %d", e.Seq, i+1)
+		}
+		if e.Prev != prev {
+			result.fail(e, "chain broken: previous hash does not match entry %d", i)
+		}
+		if hash := entryHash(*e); hash != e.Hash {
+			result.fail(e, "entry hash mismatch: recorded %s, computed %s", shortHash(e.Hash), shortHash(hash))
+		}
+		verifyObject(dir, e, result)
+		prev = e.Hash
+	}
+	if manifest.Head != prev {
+		result.fail(nil, "manifest head %s does not match the last entry %s", shortHash(manifest.Head), shortHash(prev))
+	}
+
+	verifySignature(dir, data, opts, result)
+
+	if !result.OK() {
+		return result, fmt.Errorf("%w: %d problem(s)", ErrVerificationFailed, len(result.Problems))
+	}
+	return result, nil
+}
+
+func verifyObject(dir string, e *Entry, result *VerifyResult) {
+	hexSum, ok := strings.CutPrefix(e.Digest, "sha256:")
+	if !ok || len(hexSum) != sha256.Size*2 {
+		result.fail(e, "unsupported digest %q", e.Digest)
+		return
+	}
+
+	content, err := os.ReadFile(objectPath(dir, hexSum))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			result.fail(e, "artifact %s is missing", shortHash(hexSum))
+		} else {
+			result.fail(e, "failed to read artifact: %v", err)
+		}
+		return
+	}
+
+	sum := sha256.Sum256(content)
+	if actual := hex.EncodeToString(sum[:]); actual != hexSum {
+		result.fail(e, "artifact content does not match digest %s (got %s)", shortHash(hexSum), shortHash(actual))
+	}
+	if int64(len(content)) != e.Size {
+		result.fail(e, "artifact size %d, recorded %d", len(content), e.Size)
+	}
+}
+
+func verifySignature(dir string, manifest []byte, opts VerifyOptions, result *VerifyResult) {
+	sig, err := os.ReadFile(filepath.Join(dir, SignatureFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if opts.RequireSignature || len(opts.PublicKey) > 0 {
+			result.fail(nil, "manifest is not signed")
+		}
+		return
+	case err != nil:
+		result.fail(nil, "failed to read manifest signature: %v", err)
+		return
+	}
+	result.Signed = true
+
+	if len(opts.PublicKey) == 0 {
+		if opts.RequireSignature {
+			result.fail(nil, "a public key is required to check the manifest signature")
+		}
+		return
+	}
+
+	verifier, err := plugins.ParseVerifier(opts.PublicKey)
+	if err != nil {
+		result.fail(nil, "invalid public key: %v", err)
+		return
+	}
+	if err := verifier.Verify(manifest, sig); err != nil {
+		result.fail(nil, "manifest signature: %v", err)
+		return
+	}
+	result.SignatureVerified = true
+}
+
+func shortHash(h string) string {
+	if len(h) > 12 {
+		return h[:12]
+	}
+	if h == "" {
+		return "(none)"
+	}
+	return h
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package compress provides the compression codecs used for disk caches,
+// git bundle exports and diagnostic and backup archives. Codecs stream, so
+// archives of any size are compressed without buffering them in memory,
+// and each use has its own default codec that configuration can override.
+package compress
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec compresses and decompresses streams in one format.
+type Codec interface {
+	// Name identifies the codec in configuration and metadata, such as "zstd".
+	Name() string
+
+	// Extension is the file extension of compressed files, such as ".zst".
+	Extension() string
+
+	// Magic is the prefix of every compressed stream; nil when there is none.
+	Magic() []byte
+
+	// NewWriter returns a writer compressing into w. Close flushes the
+	// stream but does not close w.
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+
+	// NewReader returns a reader decompressing r. Close releases the
+	// decoder but does not close r.
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// Built-in codec names.
+const (
+	NameNone = "none"
+	NameGzip = "gzip"
+	NameZstd = "zstd"
+	NameLZ4  = "lz4"
+)
+
+// Built-in codecs.
+var (
+	// None stores data as is.
+	None Codec = noneCodec{}
+
+	// Gzip is readable everywhere; use it for files people open by hand.
+	Gzip Codec = gzipCodec{}
+
+	// Zstd compresses better and faster than gzip.
+	Zstd Codec = zstdCodec{}
+
+	// LZ4 trades compression ratio for the fastest speed.
+	LZ4 Codec = lz4Codec{}
+)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Codec{}
+)
+
+func init() {
+	for _, c := range []Codec{None, Gzip, Zstd, LZ4} {
+		Register(c)
+	}
+}
+
+// Register makes a codec available under its name, replacing a codec
+// registered under the same name.
+func Register(c Codec) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[strings.ToLower(c.Name())] = c
+}
+
+// Lookup returns the codec registered under name. The extension without
+// the dot, such as "zst" or "gz", is accepted too, and an empty name is
+// None.
+func Lookup(name string) (Codec, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return None, nil
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	if c, ok := registry[name]; ok {
+		return c, nil
+	}
+	for _, c := range registry {
+		if c.Extension() == "."+name {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown compression codec %q (valid: %s)", name, strings.Join(namesLocked(), ", "))
+}
+
+// Names returns the names of the registered codecs, sorted.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return namesLocked()
+}
+
+func namesLocked() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FromExtension returns the codec whose extension ends path, such as Zstd
+// for "report.tar.zst".
+func FromExtension(path string) (Codec, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, c := range registry {
+		if ext := c.Extension(); ext != "" && strings.HasSuffix(path, ext) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Detect returns the codec whose magic prefixes header, or None.
+func Detect(header []byte) Codec {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, c := range registry {
+		if magic := c.Magic(); len(magic) > 0 && bytes.HasPrefix(header, magic) {
+			return c
+		}
+	}
+	return None
+}
+
+// maxMagicLen is the number of bytes NewDetectingReader peeks at.
+const maxMagicLen = 8
+
+// NewDetectingReader decompresses r with the codec detected from its first
+// bytes, so data written by any codec, or uncompressed, can be read back.
+func NewDetectingReader(r io.Reader) (io.ReadCloser, Codec, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(maxMagicLen)
+	if err != nil && err != io.EOF {
+		return nil, nil, fmt.Errorf("failed to read compression header: %w", err)
+	}
+
+	c := Detect(header)
+	rc, err := c.NewReader(br)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rc, c, nil
+}
+
+// Encode compresses data in memory. Prefer NewWriter for anything that
+// may be large.
+func Encode(c Codec, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := c.NewWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to compress with %s: %w", c.Name(), err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress with %s: %w", c.Name(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode decompresses data compressed by c in memory.
+func Decode(c Codec, data []byte) ([]byte, error) {
+	r, err := c.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", c.Name(), err)
+	}
+	defer r.Close()
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %w", c.Name(), err)
+	}
+	return out, nil
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+type noneCodec struct{}
+
+func (noneCodec) Name() string      { return NameNone }
+func (noneCodec) Extension() string { return "" }
+func (noneCodec) Magic() []byte     { return nil }
+
+func (noneCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return nopWriteCloser{w}, nil
+}
+
+func (noneCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(r), nil
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Name() string      { return NameGzip }
+func (gzipCodec) Extension() string { return ".gz" }
+func (gzipCodec) Magic() []byte     { return []byte{0x1f, 0x8b} }
+
+func (gzipCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+func (gzipCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+type zstdCodec struct{}
+
+func (zstdCodec) Name() string      { return NameZstd }
+func (zstdCodec) Extension() string { return ".zst" }
+func (zstdCodec) Magic() []byte     { return []byte{0x28, 0xb5, 0x2f, 0xfd} }
+
+func (zstdCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w)
+}
+
+func (zstdCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	d, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
+
+type lz4Codec struct{}
+
+func (lz4Codec) Name() string      { return NameLZ4 }
+func (lz4Codec) Extension() string { return ".lz4" }
+func (lz4Codec) Magic() []byte     { return []byte{0x04, 0x22, 0x4d, 0x18} }
+
+func (lz4Codec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return newLZ4Writer(w), nil
+}
+
+func (lz4Codec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return newLZ4Reader(r), nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package compress
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodecsRoundTrip(t *testing.T) {
+	input := bytes.Repeat([]byte(`{"name":"api","private":true,"topics":["go","cli"]}`+"\n"), 2000)
+
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			c, err := Lookup(name)
+			require.NoError(t, err)
+
+			data, err := Encode(c, input)
+			require.NoError(t, err)
+			if c != None {
+				assert.Less(t, len(data), len(input)/4)
+			}
+			assert.Equal(t, c, Detect(data))
+
+			r, detected, err := NewDetectingReader(bytes.NewReader(data))
+			require.NoError(t, err)
+			defer r.Close()
+			assert.Equal(t, c, detected)
+			out, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, input, out)
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for name, want := range map[string]Codec{"": None, "ZSTD": Zstd, "zst": Zstd, "gz": Gzip, " lz4 ": LZ4} {
+		c, err := Lookup(name)
+		require.NoError(t, err)
+		assert.Equal(t, want, c, name)
+	}
+
+	_, err := Lookup("xz")
+	assert.ErrorContains(t, err, `unknown compression codec "xz" (valid: gzip, lz4, none, zstd)`)
+
+	c, ok := FromExtension("gz-report.tar.zst")
+	assert.True(t, ok)
+	assert.Equal(t, Zstd, c)
+	_, ok = FromExtension("gz-report.tar")
+	assert.False(t, ok)
+}
+
+func TestForUse(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, SetOverrides(nil)) })
+
+	assert.Equal(t, LZ4, ForUse(UseCache))
+	assert.Equal(t, None, ForUse(UseBundle))
+	assert.Equal(t, Gzip, ForUse(UseDiagnostics))
+
+	require.NoError(t, SetOverrides(map[string]string{"cache": "zstd", "Bundle": "gzip"}))
+	assert.Equal(t, Zstd, ForUse(UseCache))
+	assert.Equal(t, Gzip, ForUse(UseBundle))
+	assert.Equal(t, Zstd, ForUse(UseArchive), "uses without override keep their default")
+
+	assert.ErrorContains(t, SetOverrides(map[string]string{"logs": "gzip"}), `unknown compression use "logs"`)
+	assert.ErrorContains(t, SetOverrides(map[string]string{"cache": "brotli"}), "compression.cache: unknown compression codec")
+	assert.Equal(t, Zstd, ForUse(UseCache), "invalid settings leave the overrides unchanged")
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package compress
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/pierrec/lz4/v4"
+)
+
+// newLZ4Writer returns a writer of one LZ4 frame with the library
+// defaults: independent blocks and a content checksum, as the lz4 command
+// writes them.
+func newLZ4Writer(w io.Writer) io.WriteCloser {
+	return lz4.NewWriter(w)
+}
+
+// lz4Reader reads concatenated LZ4 frames, as the lz4 command writes when
+// files are appended to each other. The library reader stops at the end of
+// the first frame.
+type lz4Reader struct {
+	src     *bufio.Reader
+	zr      *lz4.Reader
+	started bool
+}
+
+func newLZ4Reader(r io.Reader) io.ReadCloser {
+	src := bufio.NewReader(r)
+	return &lz4Reader{src: src, zr: lz4.NewReader(src)}
+}
+
+func (z *lz4Reader) Read(p []byte) (int, error) {
+	if !z.started {
+		// 빈 입력은 빈 프레임이 아니라 잘린 스트림이다
+		if _, err := z.src.Peek(1); errors.Is(err, io.EOF) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		z.started = true
+	}
+	for {
+		n, err := z.zr.Read(p)
+		if !errors.Is(err, io.EOF) {
+			return n, err
+		}
+		// 다음 프레임이 없을 때만 스트림이 끝난다
+		if _, peekErr := z.src.Peek(1); peekErr != nil {
+			return n, io.EOF
+		}
+		z.zr.Reset(z.src)
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// Close does not close the underlying reader.
+func (z *lz4Reader) Close() error { return nil }
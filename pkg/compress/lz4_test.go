@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package compress
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// foxText is the input of the reference frames below.
+var foxText = strings.Repeat("the quick brown fox jumps over the lazy dog\n", 4)
+
+// Frames written by the lz4 command line interface v1.9.4 from foxText.
+var referenceFrames = map[string]string{
+	"lz4":                "04224d186440a739000000f01074686520717569636b2062726f776e20666f78206a756d7073206f766572201f00906c617a7920646f670a0d000f2c00685020646f670a00000000174317ce",
+	"lz4 -BD -BX":        "04224d187440bd39000000f01074686520717569636b2062726f776e20666f78206a756d7073206f766572201f00906c617a7920646f670a0d000f2c00685020646f670a5b680e2500000000174317ce",
+	"lz4 --content-size": "04224d186c40b000000000000000d739000000f01074686520717569636b2062726f776e20666f78206a756d7073206f766572201f00906c617a7920646f670a0d000f2c00685020646f670a00000000174317ce",
+	"lz4 -9 -B4":         "04224d186440a736000000f01074686520717569636b2062726f776e20666f78206a756d7073206f766572201f009f6c617a7920646f670a2c006c5020646f670a00000000174317ce",
+}
+
+func referenceFrame(t testing.TB, name string) []byte {
+	t.Helper()
+	frame, err := hex.DecodeString(referenceFrames[name])
+	require.NoError(t, err)
+	return frame
+}
+
+func TestLZ4ReadsReferenceFrames(t *testing.T) {
+	for name := range referenceFrames {
+		t.Run(name, func(t *testing.T) {
+			frame := referenceFrame(t, name)
+			assert.Equal(t, LZ4, Detect(frame))
+			out, err := Decode(LZ4, frame)
+			require.NoError(t, err)
+			assert.Equal(t, foxText, string(out))
+		})
+	}
+}
+
+func TestLZ4WritesReferenceReadableFrames(t *testing.T) {
+	cli, err := exec.LookPath("lz4")
+	if err != nil {
+		t.Skip("lz4 command not installed")
+	}
+	rng := rand.New(rand.NewSource(1))
+	random := make([]byte, 200<<10)
+	rng.Read(random)
+	input := append(bytes.Repeat([]byte(foxText), 2000), random...)
+
+	data, err := Encode(LZ4, input)
+	require.NoError(t, err)
+	cmd := exec.Command(cli, "-d", "-c")
+	cmd.Stdin = bytes.NewReader(data)
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	assert.Equal(t, input, out)
+}
+
+func TestLZ4EmptyFrame(t *testing.T) {
+	data, err := Encode(LZ4, nil)
+	require.NoError(t, err)
+	out, err := Decode(LZ4, data)
+	require.NoError(t, err)
+	assert.Empty(t, out)
+}
+
+func TestLZ4RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := make([]byte, 200<<10)
+	rng.Read(random)
+	text := bytes.Repeat([]byte("the quick brown fox jumps over the lazy dog\n"), 5000)
+
+	for name, input := range map[string][]byte{
+		"short":  []byte("hello"),
+		"run":    bytes.Repeat([]byte{'a'}, 100000),
+		"text":   text,
+		"random": random,
+		"mixed":  append(append([]byte(nil), text[:70000]...), random[:70000]...),
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := Encode(LZ4, input)
+			require.NoError(t, err)
+			if name == "text" || name == "run" {
+				assert.Less(t, len(data), len(input)/10)
+			}
+			out, err := Decode(LZ4, data)
+			require.NoError(t, err)
+			assert.Equal(t, input, out)
+		})
+	}
+}
+
+func TestLZ4ConcatenatedAndSkippableFrames(t *testing.T) {
+	skippable := binary.LittleEndian.AppendUint32(nil, 0x184d2a53)
+	skippable = binary.LittleEndian.AppendUint32(skippable, 2)
+	skippable = append(skippable, 'x', 'y')
+
+	// 이어 붙인 프레임과 건너뛸 프레임도 하나의 스트림으로 읽힌다
+	tail, err := Encode(LZ4, []byte(" and more"))
+	require.NoError(t, err)
+	var stream []byte
+	stream = append(stream, skippable...)
+	stream = append(stream, referenceFrame(t, "lz4 -BD -BX")...)
+	stream = append(stream, skippable...)
+	stream = append(stream, tail...)
+
+	out, err := Decode(LZ4, stream)
+	require.NoError(t, err)
+	assert.Equal(t, foxText+" and more", string(out))
+}
+
+func TestLZ4Corrupt(t *testing.T) {
+	data, err := Encode(LZ4, bytes.Repeat([]byte("corruption "), 1000))
+	require.NoError(t, err)
+
+	_, err = Decode(LZ4, data[:len(data)-6])
+	assert.Error(t, err)
+
+	flipped := bytes.Clone(data)
+	flipped[len(flipped)-1] ^= 0xff
+	_, err = Decode(LZ4, flipped)
+	assert.Error(t, err)
+
+	_, err = Decode(LZ4, []byte("not lz4 at all"))
+	assert.Error(t, err)
+
+	_, err = Decode(LZ4, nil)
+	assert.Error(t, err)
+}
+
+func FuzzDecode(f *testing.F) {
+	for name := range referenceFrames {
+		f.Add(referenceFrame(f, name))
+	}
+	empty, err := Encode(LZ4, nil)
+	require.NoError(f, err)
+	f.Add(empty)
+	f.Add([]byte("not lz4 at all"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// 어떤 입력에도 패닉 없이 데이터나 오류를 돌려줘야 한다
+		out, err := Decode(LZ4, data)
+		if err != nil {
+			return
+		}
+		again, err := Encode(LZ4, out)
+		require.NoError(t, err)
+		back, err := Decode(LZ4, again)
+		require.NoError(t, err)
+		assert.Equal(t, out, back)
+	})
+}
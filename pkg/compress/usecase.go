@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package compress
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Use is something gz compresses, each with its own default codec.
+type Use string
+
+// Uses of compression.
+const (
+	// UseCache compresses disk cache entries, which are read often.
+	UseCache Use = "cache"
+
+	// UseBundle compresses exported git bundles.
+	UseBundle Use = "bundle"
+
+	// UseArchive compresses mirror archives.
+	UseArchive Use = "archive"
+
+	// UseDiagnostics compresses support bundles written by gz debug report.
+	UseDiagnostics Use = "diagnostics"
+
+	// UseBackup compresses the payload of gz backup archives.
+	UseBackup Use = "backup"
+)
+
+// defaults are the codecs used when configuration does not override them.
+var defaults = map[Use]string{
+	// 캐시는 자주 읽히므로 압축률보다 속도를 우선한다
+	UseCache: NameLZ4,
+	// 번들의 pack 데이터는 이미 zlib으로 압축되어 있다
+	UseBundle:      NameNone,
+	UseArchive:     NameZstd,
+	UseDiagnostics: NameGzip,
+	UseBackup:      NameZstd,
+}
+
+var (
+	overridesMu sync.RWMutex
+	overrides   = map[Use]Codec{}
+)
+
+// Uses returns every use, sorted.
+func Uses() []Use {
+	uses := make([]Use, 0, len(defaults))
+	for use := range defaults {
+		uses = append(uses, use)
+	}
+	sort.Slice(uses, func(i, j int) bool { return uses[i] < uses[j] })
+	return uses
+}
+
+// Default returns the built-in codec of use.
+func Default(use Use) Codec {
+	c, err := Lookup(defaults[use])
+	if err != nil {
+		return None
+	}
+	return c
+}
+
+// ForUse returns the codec configured for use, or its default.
+func ForUse(use Use) Codec {
+	overridesMu.RLock()
+	c, ok := overrides[use]
+	overridesMu.RUnlock()
+
+	if ok {
+		return c
+	}
+	return Default(use)
+}
+
+// ValidateOverrides checks that every key of settings is a use and every
+// value a registered codec.
+func ValidateOverrides(settings map[string]string) error {
+	_, err := parseOverrides(settings)
+	return err
+}
+
+// SetOverrides replaces the configured codecs, mapping uses such as
+// "cache" to codec names. Uses not in settings fall back to their
+// defaults. Nothing is changed when settings are invalid.
+func SetOverrides(settings map[string]string) error {
+	parsed, err := parseOverrides(settings)
+	if err != nil {
+		return err
+	}
+
+	overridesMu.Lock()
+	defer overridesMu.Unlock()
+
+	overrides = parsed
+	return nil
+}
+
+func parseOverrides(settings map[string]string) (map[Use]Codec, error) {
+	parsed := make(map[Use]Codec, len(settings))
+	for key, name := range settings {
+		use := Use(strings.ToLower(strings.TrimSpace(key)))
+		if _, ok := defaults[use]; !ok {
+			valid := make([]string, 0, len(defaults))
+			for _, u := range Uses() {
+				valid = append(valid, string(u))
+			}
+			return nil, fmt.Errorf("unknown compression use %q (valid: %s)", key, strings.Join(valid, ", "))
+		}
+		c, err := Lookup(name)
+		if err != nil {
+			return nil, fmt.Errorf("compression.%s: %w", key, err)
+		}
+		parsed[use] = c
+	}
+	return parsed, nil
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+)
+
+// ApplyCompressionSettings installs global.compression of cfg, which maps
+// uses such as "cache" or "backup" to codecs. Uses it leaves out keep
+// their defaults.
+func ApplyCompressionSettings(cfg *UnifiedConfig) error {
+	var settings map[string]string
+	if cfg != nil && cfg.Global != nil {
+		settings = cfg.Global.Compression
+	}
+	if err := compress.SetOverrides(settings); err != nil {
+		return fmt.Errorf("invalid compression settings: %w", err)
+	}
+	return nil
+}
+
+// ConfigureCompression loads gzh.yaml, if there is one, and applies its
+// compression settings.
+func ConfigureCompression() error {
+	facade := NewUnifiedConfigFacade()
+	if err := facade.LoadConfiguration(); err != nil {
+		return ApplyCompressionSettings(nil)
+	}
+	return ApplyCompressionSettings(facade.GetConfiguration())
+}
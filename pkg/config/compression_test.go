@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+)
+
+const compressionConfig = `version: "1.0.0"
+global:
+  compression:
+    cache: zstd
+    diagnostics: zstd
+providers:
+  github:
+    token: ghp-123456
+    organizations:
+      - name: acme
+profiles:
+  laptop:
+    global:
+      compression:
+        cache: none
+`
+
+func TestApplyCompressionSettings(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, ApplyCompressionSettings(nil)) })
+
+	cfg, err := ReadProfiles(writeProfilesConfig(t, compressionConfig))
+	require.NoError(t, err)
+	require.NoError(t, ApplyCompressionSettings(cfg))
+	assert.Equal(t, compress.Zstd, compress.ForUse(compress.UseCache))
+	assert.Equal(t, compress.Zstd, compress.ForUse(compress.UseDiagnostics))
+	assert.Equal(t, compress.Default(compress.UseBackup), compress.ForUse(compress.UseBackup))
+
+	laptop, err := cfg.WithProfile("laptop")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"cache": "none", "diagnostics": "zstd"}, laptop.Global.Compression)
+	assert.Equal(t, "zstd", cfg.Global.Compression["cache"], "profiles do not change the base configuration")
+
+	cfg.Global.Compression["backup"] = "rar"
+	assert.ErrorContains(t, ApplyCompressionSettings(cfg), "invalid compression settings")
+	assert.Error(t, NewUnifiedLoader().validateUnifiedConfig(cfg))
+}
@@ -0,0 +1,477 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Secret fields can be committed to git encrypted, either value by value
+// with age or as a whole file with SOPS. Both are driven through their
+// command line tools, like the OS secret stores. An age-encrypted value is
+// written as ENC[age,<base64 ciphertext>]; a SOPS file is recognized by its
+// top-level "sops" metadata key.
+
+// Encrypted value markers.
+const (
+	encryptedValuePrefix = "ENC[age,"
+	encryptedValueSuffix = "]"
+	sopsMetadataKey      = "sops"
+)
+
+// AgeIdentityAccount is the secret store account holding an age identity
+// when none is given in the environment.
+const AgeIdentityAccount = "age-identity"
+
+// Environment variables that select the age keys.
+const (
+	EnvAgeIdentity     = "GZH_AGE_IDENTITY"      // inline AGE-SECRET-KEY-...
+	EnvAgeIdentityFile = "GZH_AGE_IDENTITY_FILE" // path to an identity file
+	EnvAgeRecipients   = "GZH_AGE_RECIPIENTS"    // comma separated age1... keys
+)
+
+// minGuardedSecretLen keeps short values such as "true" out of the
+// plaintext guard, where they would block unrelated writes.
+const minGuardedSecretLen = 8
+
+var (
+	// ErrNoAgeIdentity is returned when encrypted values are found but no
+	// age identity is configured.
+	ErrNoAgeIdentity = errors.New("no age identity: set " + EnvAgeIdentity + ", " + EnvAgeIdentityFile +
+		" or SOPS_AGE_KEY_FILE, or store one in the secret store as " + AgeIdentityAccount)
+	// ErrNoAgeRecipients is returned when encrypting without recipients.
+	ErrNoAgeRecipients = errors.New("no age recipients: pass --recipient or set " + EnvAgeRecipients)
+	// ErrPlaintextSecret is returned instead of writing a decrypted secret
+	// back to disk.
+	ErrPlaintextSecret = errors.New("refusing to write a decrypted secret to disk")
+)
+
+// DefaultEncryptedKeys are the field names encrypted by EncryptConfigFile.
+func DefaultEncryptedKeys() []string {
+	return []string{
+		"token", "password", "secret", "client_secret", "private_key",
+		"api_key", "access_token", "secret_key", "webhook_url",
+	}
+}
+
+// IsEncryptedValue reports whether value is an age-encrypted value.
+func IsEncryptedValue(value string) bool {
+	return strings.HasPrefix(value, encryptedValuePrefix) && strings.HasSuffix(value, encryptedValueSuffix)
+}
+
+// envCommandRunner is a commandRunner with extra environment variables.
+type envCommandRunner func(env []string, stdin, name string, args ...string) (string, error)
+
+// configCipher decrypts and encrypts configuration files.
+type configCipher struct {
+	run       envCommandRunner
+	getenv    func(string) string
+	openStore func() (SecretStore, error)
+	home      func() (string, error)
+}
+
+func defaultConfigCipher() *configCipher {
+	return &configCipher{
+		run:       runCommandEnv,
+		getenv:    os.Getenv,
+		openStore: func() (SecretStore, error) { return NewSecretStore(nil) },
+		home:      os.UserHomeDir,
+	}
+}
+
+// DecryptConfigData decrypts the configuration read from path. Plain files
+// are returned unchanged without looking for keys. The decrypted secrets are
+// remembered so that WriteFile refuses to persist them.
+func DecryptConfigData(path string, data []byte) ([]byte, error) {
+	return defaultConfigCipher().decrypt(path, data)
+}
+
+// DecryptConfigFile reads and decrypts the configuration file at path.
+func DecryptConfigFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // User-provided config file
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return DecryptConfigData(path, data)
+}
+
+func (c *configCipher) decrypt(path string, data []byte) ([]byte, error) {
+	isSOPS := bytes.Contains(data, []byte(sopsMetadataKey+":"))
+	if !isSOPS && !bytes.Contains(data, []byte(encryptedValuePrefix)) {
+		return data, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidYAML, err)
+	}
+	if isSOPS && mappingValue(documentRoot(&doc), sopsMetadataKey) != nil {
+		return c.decryptSOPS(path)
+	}
+
+	var values []*yaml.Node
+	walkScalars(&doc, func(_ string, node *yaml.Node) {
+		if IsEncryptedValue(node.Value) {
+			values = append(values, node)
+		}
+	})
+	if len(values) == 0 {
+		return data, nil
+	}
+
+	identity, cleanup, err := c.identityFile()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	for _, node := range values {
+		ciphertext, err := base64.StdEncoding.DecodeString(
+			strings.TrimSuffix(strings.TrimPrefix(node.Value, encryptedValuePrefix), encryptedValueSuffix))
+		if err != nil {
+			return nil, fmt.Errorf("invalid encrypted value at line %d: %w", node.Line, err)
+		}
+		plaintext, err := c.run(nil, string(ciphertext), "age", "--decrypt", "--identity", identity)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", node.Line, commandError("age", err))
+		}
+		node.Value = plaintext
+		node.Tag = "!!str"
+		node.Style = yaml.DoubleQuotedStyle
+		guardPlaintextSecret(plaintext)
+	}
+
+	return encodeYAML(&doc)
+}
+
+// decryptSOPS lets sops decrypt the file. The age identity found by gz is
+// passed on; without one sops falls back to its own key sources.
+func (c *configCipher) decryptSOPS(path string) ([]byte, error) {
+	var env []string
+	identity, cleanup, err := c.identityFile()
+	switch {
+	case err == nil:
+		defer cleanup()
+		env = append(env, "SOPS_AGE_KEY_FILE="+identity)
+	case !errors.Is(err, ErrNoAgeIdentity):
+		return nil, err
+	}
+
+	out, err := c.run(env, "", "sops", "--decrypt", "--input-type", "yaml", "--output-type", "yaml", path)
+	if err != nil {
+		return nil, commandError("sops", err)
+	}
+
+	// SOPS does not say which values it decrypted; guard the secret fields.
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(out), &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidYAML, err)
+	}
+	keys := secretKeySet(nil)
+	walkScalars(&doc, func(key string, node *yaml.Node) {
+		if keys[strings.ToLower(key)] {
+			guardPlaintextSecret(node.Value)
+		}
+	})
+	return []byte(out), nil
+}
+
+// identityFile returns the path of an age identity file. Inline identities
+// are written to a private temporary file that cleanup removes.
+func (c *configCipher) identityFile() (path string, cleanup func(), err error) {
+	noop := func() {}
+	for _, env := range []string{EnvAgeIdentityFile, "SOPS_AGE_KEY_FILE"} {
+		if file := c.getenv(env); file != "" {
+			return file, noop, nil
+		}
+	}
+
+	inline := c.getenv(EnvAgeIdentity)
+	if inline == "" {
+		inline = c.getenv("SOPS_AGE_KEY")
+	}
+	if inline == "" {
+		if home, err := c.home(); err == nil {
+			file := filepath.Join(home, ".config", "sops", "age", "keys.txt")
+			if FileExists(file) {
+				return file, noop, nil
+			}
+		}
+		if store, err := c.openStore(); err == nil {
+			if secret, err := store.Get(DefaultSecretService, AgeIdentityAccount); err == nil {
+				inline = secret
+			}
+		}
+	}
+	if inline == "" {
+		return "", noop, ErrNoAgeIdentity
+	}
+
+	f, err := os.CreateTemp("", "gz-age-identity-*")
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to write age identity: %w", err)
+	}
+	cleanup = func() { _ = os.Remove(f.Name()) }
+	_, err = f.WriteString(strings.TrimSpace(inline) + "\n")
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", noop, fmt.Errorf("failed to write age identity: %w", err)
+	}
+	return f.Name(), cleanup, nil
+}
+
+// EncryptOptions controls EncryptConfigFile.
+type EncryptOptions struct {
+	// Recipients are the age public keys to encrypt to. Defaults to
+	// GZH_AGE_RECIPIENTS, then the public key of the configured identity.
+	Recipients []string
+	// Keys are additional field names to encrypt.
+	Keys []string
+	// SOPS encrypts the file with sops instead of value by value.
+	SOPS bool
+	// DryRun lists the fields without changing the file.
+	DryRun bool
+}
+
+// EncryptResult lists the fields EncryptConfigFile encrypted and the secret
+// fields it left in plaintext because they are references.
+type EncryptResult struct {
+	Method    string // "age" or "sops"
+	Encrypted []string
+	Skipped   []string
+}
+
+// envReferencePattern matches a value that refers to an environment
+// variable: ${VAR} anywhere, or a value that is only $VAR. A "$" elsewhere,
+// e.g. in "pa$$word", is part of the secret.
+var envReferencePattern = regexp.MustCompile(`\$\{[A-Za-z_][A-Za-z0-9_]*\}|^\$[A-Za-z_][A-Za-z0-9_]*$`)
+
+// EncryptConfigFile encrypts the secret fields of the configuration file at
+// path in place. Values that are empty, already encrypted, secret store
+// references or environment variable references are left alone, as is the
+// rest of the file including comments; the references are listed in
+// EncryptResult.Skipped.
+func EncryptConfigFile(path string, opts EncryptOptions) (*EncryptResult, error) {
+	return defaultConfigCipher().encryptFile(path, opts)
+}
+
+func (c *configCipher) encryptFile(path string, opts EncryptOptions) (*EncryptResult, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // User-provided config file
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidYAML, err)
+	}
+	if mappingValue(documentRoot(&doc), sopsMetadataKey) != nil {
+		return nil, fmt.Errorf("%s is already encrypted with sops", path)
+	}
+
+	result := &EncryptResult{Method: "age"}
+	if opts.SOPS {
+		result.Method = "sops"
+	}
+	keys := secretKeySet(opts.Keys)
+	var nodes []*yaml.Node
+	walkScalars(&doc, func(key string, node *yaml.Node) {
+		if !keys[strings.ToLower(key)] || node.Tag == "!!null" {
+			return
+		}
+		switch value := node.Value; {
+		case value == "", IsEncryptedValue(value):
+			return
+		case IsSecretRef(value):
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s (line %d): secret store reference", key, node.Line))
+			return
+		case envReferencePattern.MatchString(value):
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s (line %d): environment variable reference", key, node.Line))
+			return
+		}
+		nodes = append(nodes, node)
+		result.Encrypted = append(result.Encrypted, fmt.Sprintf("%s (line %d)", key, node.Line))
+	})
+	if opts.DryRun || len(nodes) == 0 {
+		return result, nil
+	}
+
+	recipients, err := c.recipients(opts.Recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.SOPS {
+		names := make([]string, 0, len(keys))
+		for k := range keys {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		regex := "^(" + strings.Join(names, "|") + ")$"
+		if _, err := c.run(nil, "", "sops", "--encrypt", "--in-place",
+			"--age", strings.Join(recipients, ","), "--encrypted-regex", regex, path); err != nil {
+			return nil, commandError("sops", err)
+		}
+		return result, nil
+	}
+
+	args := []string{"--encrypt"}
+	for _, r := range recipients {
+		args = append(args, "--recipient", r)
+	}
+	for _, node := range nodes {
+		ciphertext, err := c.run(nil, node.Value, "age", args...)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", node.Line, commandError("age", err))
+		}
+		node.Value = encryptedValuePrefix + base64.StdEncoding.EncodeToString([]byte(ciphertext)) + encryptedValueSuffix
+		node.Tag = "!!str"
+		node.Style = 0
+	}
+
+	out, err := encodeYAML(&doc)
+	if err != nil {
+		return nil, err
+	}
+	mode := os.FileMode(0o600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(path, out, mode); err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+	return result, nil
+}
+
+// recipients returns the explicit recipients, GZH_AGE_RECIPIENTS, or the
+// public key of the configured identity.
+func (c *configCipher) recipients(explicit []string) ([]string, error) {
+	var recipients []string
+	for _, r := range explicit {
+		recipients = append(recipients, splitRecipients(r)...)
+	}
+	if len(recipients) == 0 {
+		recipients = splitRecipients(c.getenv(EnvAgeRecipients))
+	}
+	if len(recipients) > 0 {
+		return recipients, nil
+	}
+
+	identity, cleanup, err := c.identityFile()
+	if errors.Is(err, ErrNoAgeIdentity) {
+		return nil, ErrNoAgeRecipients
+	} else if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+	out, err := c.run(nil, "", "age-keygen", "-y", identity)
+	if err != nil {
+		return nil, commandError("age-keygen", err)
+	}
+	if recipients = strings.Fields(out); len(recipients) == 0 {
+		return nil, ErrNoAgeRecipients
+	}
+	return recipients, nil
+}
+
+func splitRecipients(value string) []string {
+	var recipients []string
+	for _, r := range strings.Split(value, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			recipients = append(recipients, r)
+		}
+	}
+	return recipients
+}
+
+func secretKeySet(extra []string) map[string]bool {
+	keys := make(map[string]bool)
+	for _, k := range append(DefaultEncryptedKeys(), extra...) {
+		keys[strings.ToLower(strings.TrimSpace(k))] = true
+	}
+	return keys
+}
+
+// walkScalars calls fn for every scalar mapping value with its key, and for
+// every scalar sequence item with the key of the sequence.
+func walkScalars(node *yaml.Node, fn func(key string, node *yaml.Node)) {
+	var walk func(key string, n *yaml.Node)
+	walk = func(key string, n *yaml.Node) {
+		switch n.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, child := range n.Content {
+				walk(key, child)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				walk(n.Content[i].Value, n.Content[i+1])
+			}
+		case yaml.ScalarNode:
+			fn(key, n)
+		}
+	}
+	walk("", node)
+}
+
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0]
+	}
+	return doc
+}
+
+func encodeYAML(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// plaintextGuard holds the secrets decrypted in this process.
+var plaintextGuard = struct {
+	sync.Mutex
+	secrets map[string]struct{}
+}{secrets: make(map[string]struct{})}
+
+func guardPlaintextSecret(secret string) {
+	if len(secret) < minGuardedSecretLen {
+		return
+	}
+	plaintextGuard.Lock()
+	defer plaintextGuard.Unlock()
+	plaintextGuard.secrets[secret] = struct{}{}
+}
+
+// CheckNoPlaintextSecrets returns ErrPlaintextSecret if content contains a
+// secret decrypted from an encrypted configuration.
+func CheckNoPlaintextSecrets(content []byte) error {
+	plaintextGuard.Lock()
+	defer plaintextGuard.Unlock()
+	for secret := range plaintextGuard.secrets {
+		if bytes.Contains(content, []byte(secret)) {
+			return fmt.Errorf("%w: the configuration was loaded from an encrypted file; "+
+				"edit the encrypted file and run gz config encrypt instead", ErrPlaintextSecret)
+		}
+	}
+	return nil
+}
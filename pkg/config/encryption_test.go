@@ -0,0 +1,207 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAge stands in for the age, age-keygen and sops binaries. Ciphertext
+// is the reversed plaintext behind a marker.
+type fakeAge struct {
+	env   map[string]string
+	calls []recordedCall
+	envs  [][]string
+	sops  string
+}
+
+func (f *fakeAge) cipher() *configCipher {
+	return &configCipher{
+		run: func(env []string, stdin, name string, args ...string) (string, error) {
+			f.calls = append(f.calls, recordedCall{stdin: stdin, args: append([]string{name}, args...)})
+			f.envs = append(f.envs, env)
+			switch {
+			case name == "age-keygen":
+				return "age1derived\n", nil
+			case name == "sops":
+				return f.sops, nil
+			case args[0] == "--encrypt":
+				return "\x00cipher:" + reverse(stdin), nil
+			default:
+				if !strings.HasPrefix(stdin, "\x00cipher:") {
+					return "", errors.New("bad ciphertext")
+				}
+				return reverse(strings.TrimPrefix(stdin, "\x00cipher:")), nil
+			}
+		},
+		getenv:    func(key string) string { return f.env[key] },
+		openStore: func() (SecretStore, error) { return nil, ErrSecretStoreUnavailable },
+		home:      func() (string, error) { return "", errors.New("no home") },
+	}
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+const plainConfig = `version: "1.0.0"
+providers:
+  github:
+    # deploy token
+    token: ghp_encryptiontest0001
+    organizations:
+      - name: acme
+        clone_dir: ./acme
+  gitlab:
+    token: ${GITLAB_TOKEN}
+`
+
+func TestEncryptAndDecryptConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(plainConfig), 0o640))
+
+	fake := &fakeAge{env: map[string]string{EnvAgeRecipients: "age1a, age1b", EnvAgeIdentityFile: "/keys.txt"}}
+	result, err := fake.cipher().encryptFile(path, EncryptOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "age", result.Method)
+	assert.Equal(t, []string{"token (line 5)"}, result.Encrypted)
+	assert.Equal(t, []string{"token (line 10): environment variable reference"}, result.Skipped)
+	assert.Equal(t, []string{"age", "--encrypt", "--recipient", "age1a", "--recipient", "age1b"}, fake.calls[0].args)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "ghp_encryptiontest0001")
+	assert.Contains(t, string(data), "token: ENC[age,")
+	assert.Contains(t, string(data), "# deploy token")
+	assert.Contains(t, string(data), "${GITLAB_TOKEN}")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+
+	decrypted, err := fake.cipher().decrypt(path, data)
+	require.NoError(t, err)
+	assert.Contains(t, string(decrypted), `token: "ghp_encryptiontest0001"`)
+	assert.Equal(t, []string{"age", "--decrypt", "--identity", "/keys.txt"}, fake.calls[len(fake.calls)-1].args)
+
+	// The decrypted token must not be written back.
+	err = WriteFile(filepath.Join(t.TempDir(), "out.yaml"), string(decrypted))
+	require.ErrorIs(t, err, ErrPlaintextSecret)
+	require.NoError(t, WriteFile(filepath.Join(t.TempDir(), "out.yaml"), string(data)))
+}
+
+func TestEncryptConfigDerivesRecipientFromIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(plainConfig), 0o600))
+
+	fake := &fakeAge{env: map[string]string{EnvAgeIdentity: "AGE-SECRET-KEY-1TEST"}}
+	_, err := fake.cipher().encryptFile(path, EncryptOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "age-keygen", fake.calls[0].args[0])
+	assert.NoFileExists(t, fake.calls[0].args[2], "inline identity file is removed")
+	assert.Equal(t, []string{"age", "--encrypt", "--recipient", "age1derived"}, fake.calls[1].args)
+}
+
+func TestEncryptConfigWithoutRecipients(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(plainConfig), 0o600))
+
+	_, err := (&fakeAge{}).cipher().encryptFile(path, EncryptOptions{})
+	require.ErrorIs(t, err, ErrNoAgeRecipients)
+
+	result, err := (&fakeAge{}).cipher().encryptFile(path, EncryptOptions{DryRun: true, Keys: []string{"name"}})
+	require.NoError(t, err)
+	assert.Len(t, result.Encrypted, 2)
+}
+
+func TestEncryptConfigDollarSigns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`providers:
+  github:
+    token: pa$$word
+  gitlab:
+    token: $GITLAB_TOKEN
+  gitea:
+    token: tok-${GITEA_SUFFIX}
+  gogs:
+    token: pa$Sw0rd
+`), 0o600))
+
+	result, err := (&fakeAge{}).cipher().encryptFile(path, EncryptOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"token (line 3)", "token (line 9)"}, result.Encrypted)
+	assert.Equal(t, []string{
+		"token (line 5): environment variable reference",
+		"token (line 7): environment variable reference",
+	}, result.Skipped)
+}
+
+func TestEncryptConfigWithSOPS(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(plainConfig), 0o600))
+
+	fake := &fakeAge{}
+	result, err := fake.cipher().encryptFile(path, EncryptOptions{SOPS: true, Recipients: []string{"age1a"}})
+	require.NoError(t, err)
+	assert.Equal(t, "sops", result.Method)
+	args := fake.calls[0].args
+	assert.Equal(t, []string{"sops", "--encrypt", "--in-place", "--age", "age1a", "--encrypted-regex"}, args[:6])
+	assert.Contains(t, args[6], "|token|")
+	assert.Equal(t, path, args[7])
+}
+
+func TestDecryptConfigData(t *testing.T) {
+	t.Run("plain file is unchanged", func(t *testing.T) {
+		fake := &fakeAge{}
+		out, err := fake.cipher().decrypt("gzh.yaml", []byte(plainConfig))
+		require.NoError(t, err)
+		assert.Equal(t, plainConfig, string(out))
+		assert.Empty(t, fake.calls)
+	})
+
+	t.Run("encrypted value without identity", func(t *testing.T) {
+		_, err := (&fakeAge{}).cipher().decrypt("gzh.yaml", []byte("token: ENC[age,AAAA]\n"))
+		require.ErrorIs(t, err, ErrNoAgeIdentity)
+	})
+
+	t.Run("identity from the secret store", func(t *testing.T) {
+		store := newMemorySecretStore()
+		require.NoError(t, store.Set(DefaultSecretService, AgeIdentityAccount, "AGE-SECRET-KEY-1STORE"))
+		fake := &fakeAge{}
+		c := fake.cipher()
+		c.openStore = func() (SecretStore, error) { return store, nil }
+
+		path, cleanup, err := c.identityFile()
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "AGE-SECRET-KEY-1STORE\n", string(content))
+		cleanup()
+		assert.NoFileExists(t, path)
+	})
+
+	t.Run("sops file", func(t *testing.T) {
+		fake := &fakeAge{
+			env:  map[string]string{"SOPS_AGE_KEY_FILE": "/sops/keys.txt"},
+			sops: "providers:\n  github:\n    token: ghp_sopsdecrypted0001\n",
+		}
+		data := []byte("providers:\n  github:\n    token: ENC[AES256_GCM,data:x]\nsops:\n  version: 3.8.1\n")
+		out, err := fake.cipher().decrypt("/etc/gzh.yaml", data)
+		require.NoError(t, err)
+		assert.Equal(t, fake.sops, string(out))
+		assert.Equal(t, []string{"sops", "--decrypt", "--input-type", "yaml", "--output-type", "yaml", "/etc/gzh.yaml"}, fake.calls[0].args)
+		assert.Equal(t, []string{"SOPS_AGE_KEY_FILE=/sops/keys.txt"}, fake.envs[0])
+		require.ErrorIs(t, CheckNoPlaintextSecrets(out), ErrPlaintextSecret)
+	})
+}
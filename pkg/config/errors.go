@@ -13,6 +13,7 @@ var (
 	ErrInvalidVisibility = errors.New("invalid visibility: must be 'public', 'private', or 'all'")
 	ErrInvalidStrategy   = errors.New("invalid strategy: must be 'reset', 'pull', or 'fetch'")
 	ErrInvalidRegex      = errors.New("invalid regex pattern")
+	ErrInvalidFilter     = errors.New("invalid filter expression")
 	ErrFileNotFound      = errors.New("configuration file not found")
 	ErrInvalidYAML       = errors.New("invalid YAML format")
 	ErrInvalidCloneDir   = errors.New("invalid clone directory")
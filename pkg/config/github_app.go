@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/gizzahub/gzh-cli/pkg/github"
+)
+
+// GitHubAppConfig configures authentication as a GitHub App. Installation
+// tokens are minted per organization and refreshed before they expire, so
+// no personal access token is needed.
+type GitHubAppConfig struct {
+	// Numeric App ID from the App settings page
+	AppID int64 `yaml:"app_id" json:"appId"` //nolint:tagliatelle // YAML compatibility required
+
+	// Installation to use for every organization; looked up per organization when empty
+	InstallationID int64 `yaml:"installation_id,omitempty" json:"installationId,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Path to the PEM private key
+	PrivateKeyPath string `yaml:"private_key_path,omitempty" json:"privateKeyPath,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Inline PEM private key (supports environment variables)
+	PrivateKey string `yaml:"private_key,omitempty" json:"privateKey,omitempty"` //nolint:tagliatelle // YAML compatibility required
+}
+
+// Validate checks that the App can be authenticated.
+func (a *GitHubAppConfig) Validate() error {
+	if a.AppID <= 0 {
+		return fmt.Errorf("app.app_id is required")
+	}
+	if a.InstallationID < 0 {
+		return fmt.Errorf("app.installation_id must be positive")
+	}
+	if (a.PrivateKey == "") == (a.PrivateKeyPath == "") {
+		return fmt.Errorf("exactly one of app.private_key and app.private_key_path is required")
+	}
+	return nil
+}
+
+// privateKey returns the PEM encoded key.
+func (a *GitHubAppConfig) privateKey() ([]byte, error) {
+	if a.PrivateKey != "" {
+		return []byte(ExpandEnvironmentVariables(a.PrivateKey)), nil
+	}
+
+	path := expandPath(ExpandEnvironmentVariables(a.PrivateKeyPath))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+	}
+	return data, nil
+}
+
+// appTokenSources shares token sources, and with them the token cache,
+// between every ProviderConfig that uses the same App.
+var appTokenSources = struct {
+	sync.Mutex
+	sources map[string]*github.AppTokenSource
+}{sources: make(map[string]*github.AppTokenSource)}
+
+// AppTokenSource returns the token source for the configured GitHub App.
+func (p *ProviderConfig) AppTokenSource() (*github.AppTokenSource, error) {
+	if p.App == nil {
+		return nil, fmt.Errorf("provider has no GitHub App configured")
+	}
+	if err := p.App.Validate(); err != nil {
+		return nil, err
+	}
+
+	key, err := p.App.privateKey()
+	if err != nil {
+		return nil, err
+	}
+	cacheKey := fmt.Sprintf("%s|%d|%d|%x", p.APIURL, p.App.AppID, p.App.InstallationID, sha256.Sum256(key))
+
+	appTokenSources.Lock()
+	defer appTokenSources.Unlock()
+
+	if source, ok := appTokenSources.sources[cacheKey]; ok {
+		return source, nil
+	}
+	source, err := github.NewAppTokenSource(github.AppAuthConfig{
+		AppID:          p.App.AppID,
+		PrivateKey:     key,
+		InstallationID: p.App.InstallationID,
+		BaseURL:        p.APIURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	appTokenSources.sources[cacheKey] = source
+
+	return source, nil
+}
+
+// TokenForOrg returns the token to use for an organization. Providers with
+// a GitHub App get an installation token for the installation on org;
+// otherwise Token is returned.
+func (p *ProviderConfig) TokenForOrg(ctx context.Context, org string) (string, error) {
+	if p.App == nil {
+		return p.Token, nil
+	}
+
+	source, err := p.AppTokenSource()
+	if err != nil {
+		return "", err
+	}
+	return source.Token(ctx, org)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubAppConfig_Validate(t *testing.T) {
+	assert.Error(t, (&GitHubAppConfig{PrivateKey: "pem"}).Validate())
+	assert.Error(t, (&GitHubAppConfig{AppID: 1}).Validate())
+	assert.Error(t, (&GitHubAppConfig{AppID: 1, PrivateKey: "pem", PrivateKeyPath: "key.pem"}).Validate())
+	assert.NoError(t, (&GitHubAppConfig{AppID: 1, PrivateKeyPath: "key.pem"}).Validate())
+}
+
+func TestProviderConfig_TokenForOrg(t *testing.T) {
+	ctx := context.Background()
+
+	plain := &ProviderConfig{Token: "ghp_plain"}
+	token, err := plain.TokenForOrg(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_plain", token)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{
+		Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}), 0o600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/acme/installation":
+			_, _ = w.Write([]byte(`{"id": 5}`))
+		case "/app/installations/5/access_tokens":
+			_, _ = w.Write([]byte(`{"token": "ghs_app", "expires_at": "` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	app := &ProviderConfig{APIURL: server.URL, App: &GitHubAppConfig{AppID: 7, PrivateKeyPath: keyPath}}
+	token, err = app.TokenForOrg(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_app", token)
+
+	first, err := app.AppTokenSource()
+	require.NoError(t, err)
+	second, err := (&ProviderConfig{APIURL: server.URL, App: &GitHubAppConfig{AppID: 7, PrivateKeyPath: keyPath}}).AppTokenSource()
+	require.NoError(t, err)
+	assert.Same(t, first, second, "configs for the same App share a token cache")
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+
+	synclone "github.com/gizzahub/gzh-cli/pkg/synclone"
 )
 
 // BulkCloneIntegration provides integration between gzh.yaml config and bulk-clone operations.
@@ -30,9 +32,13 @@ type BulkCloneTarget struct {
 	Visibility string   // public, private, all
 	Strategy   string   // reset, pull, fetch
 	Match      string   // regex pattern for filtering
+	Filter     string   // filter expression (internal/git/repofilter)
 	Exclude    []string // patterns to exclude
 	Recursive  bool     // for GitLab groups
 	Flatten    bool     // flatten directory structure
+
+	Hooks  *synclone.Hooks         // lifecycle hooks run per repository
+	Sparse *synclone.SparseProfile // sparse checkout of owned paths
 }
 
 // GetAllTargets returns all configured targets for bulk cloning.
@@ -50,9 +56,12 @@ func (b *BulkCloneIntegration) GetAllTargets() ([]BulkCloneTarget, error) {
 				Visibility: org.Visibility,
 				Strategy:   org.Strategy,
 				Match:      org.Match,
+				Filter:     org.Filter,
 				Exclude:    org.Exclude,
 				Recursive:  false, // Not applicable for orgs
 				Flatten:    org.Flatten,
+				Hooks:      org.Hooks,
+				Sparse:     org.Sparse,
 			}
 			targets = append(targets, target)
 		}
@@ -67,9 +76,12 @@ func (b *BulkCloneIntegration) GetAllTargets() ([]BulkCloneTarget, error) {
 				Visibility: group.Visibility,
 				Strategy:   group.Strategy,
 				Match:      group.Match,
+				Filter:     group.Filter,
 				Exclude:    group.Exclude,
 				Recursive:  group.Recursive,
 				Flatten:    group.Flatten,
+				Hooks:      group.Hooks,
+				Sparse:     group.Sparse,
 			}
 			targets = append(targets, target)
 		}
@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+	yamlUnmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// GenerateJSONSchema derives a JSON Schema (draft-07) from a configuration
+// struct. Property names follow the yaml tags, unknown keys are rejected, and
+// the `validate` tags used by StartupValidator are translated: required,
+// oneof (enum) and min/max.
+func GenerateJSONSchema(v any, title string) ([]byte, error) {
+	g := &schemaGenerator{
+		names:       make(map[reflect.Type]string),
+		definitions: make(map[string]any),
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	root := g.structSchema(t)
+	root["$schema"] = "http://json-schema.org/draft-07/schema#"
+	root["title"] = title
+	if len(g.definitions) > 0 {
+		root["definitions"] = g.definitions
+	}
+	return json.MarshalIndent(root, "", "  ")
+}
+
+type schemaGenerator struct {
+	names       map[reflect.Type]string
+	definitions map[string]any
+}
+
+// typeSchema returns the schema of t. Structs other than the root become
+// definitions so that recursive types terminate.
+func (g *schemaGenerator) typeSchema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == durationType:
+		// "30s" or nanoseconds
+		return map[string]any{"type": []string{"string", "integer"}}
+	case t == timeType:
+		return map[string]any{"type": "string"}
+	case reflect.PointerTo(t).Implements(yamlUnmarshalerType),
+		reflect.PointerTo(t).Implements(textUnmarshalerType):
+		// Custom decoding; the accepted shape is not known.
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string"}
+		}
+		return map[string]any{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return g.structRef(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+func (g *schemaGenerator) structRef(t reflect.Type) map[string]any {
+	name, ok := g.names[t]
+	if !ok {
+		name = t.Name()
+		if name == "" {
+			return g.structSchema(t)
+		}
+		// Disambiguate equally named types from different packages.
+		for _, used := range g.names {
+			if used == name {
+				name = t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:] + "." + name
+				break
+			}
+		}
+		// Register the name first so that recursive fields terminate.
+		g.names[t] = name
+		g.definitions[name] = g.structSchema(t)
+	}
+	return map[string]any{"$ref": "#/definitions/" + name}
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	g.addFields(t, properties, &required)
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (g *schemaGenerator) addFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(ft, properties, required)
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+
+		prop := g.typeSchema(f.Type)
+		if applyValidateTag(prop, f.Type, f.Tag.Get("validate")) {
+			*required = append(*required, name)
+		}
+		properties[name] = prop
+	}
+}
+
+// applyValidateTag translates validator rules into schema keywords and
+// reports whether the field is required. $ref schemas are left untouched.
+func applyValidateTag(prop map[string]any, t reflect.Type, tag string) bool {
+	required := false
+	if _, isRef := prop["$ref"]; isRef {
+		return strings.Contains(","+tag+",", ",required,")
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch key {
+		case "required":
+			required = true
+		case "oneof":
+			var values []any
+			for _, v := range strings.Fields(value) {
+				if isIntegerKind(t.Kind()) {
+					if n, err := strconv.Atoi(v); err == nil {
+						values = append(values, n)
+						continue
+					}
+				}
+				values = append(values, v)
+			}
+			prop["enum"] = values
+		case "min", "max":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			prop[boundKeyword(t.Kind(), key)] = n
+		}
+	}
+	return required
+}
+
+func boundKeyword(kind reflect.Kind, rule string) string {
+	pick := func(lower, upper string) string {
+		if rule == "min" {
+			return lower
+		}
+		return upper
+	}
+	switch kind {
+	case reflect.String:
+		return pick("minLength", "maxLength")
+	case reflect.Slice, reflect.Array:
+		return pick("minItems", "maxItems")
+	case reflect.Map:
+		return pick("minProperties", "maxProperties")
+	default:
+		return pick("minimum", "maximum")
+	}
+}
+
+func isIntegerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
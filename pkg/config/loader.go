@@ -29,7 +29,9 @@ func convertUnifiedToLegacyConfig(unified *UnifiedConfig) *Config {
 	// Copy providers
 	for name, provider := range unified.Providers {
 		legacyProvider := Provider{
-			Token: provider.Token,
+			Token:    provider.Token,
+			URL:      provider.APIURL,
+			Username: provider.Username,
 		}
 
 		// Convert organizations to GitTargets
@@ -42,6 +44,8 @@ func convertUnifiedToLegacyConfig(unified *UnifiedConfig) *Config {
 				Strategy:   org.Strategy,
 				Match:      org.Include,
 				Exclude:    org.Exclude,
+				Hooks:      unified.OrganizationHooks(org),
+				Sparse:     unified.OrganizationSparse(org),
 			}
 			orgs = append(orgs, target)
 		}
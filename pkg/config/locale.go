@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfiguredLocale returns global.locale of the default configuration, or
+// an empty string when there is no configuration or no locale is set.
+func ConfiguredLocale() string {
+	facade := NewUnifiedConfigFacade()
+	if err := facade.LoadConfiguration(); err != nil {
+		return ""
+	}
+	if global := facade.GetGlobalSettings(); global != nil {
+		return global.Locale
+	}
+	return ""
+}
+
+// SetGlobalSetting sets global.<key> to value in the config file at path.
+// Like AddProfile, the document is edited in place so comments and key
+// order are kept.
+func SetGlobalSetting(path, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("config file %s is not a YAML mapping", path)
+	}
+	root := doc.Content[0]
+
+	global := mappingValue(root, "global")
+	if global == nil || global.Kind != yaml.MappingNode {
+		global = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(root, "global", global)
+	}
+	setMappingValue(global, key, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+
+	return writeYAMLDocument(path, &doc)
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+// NetworkSettings configures outbound HTTP connections. Unset fields fall
+// back to HTTPS_PROXY, HTTP_PROXY, ALL_PROXY and NO_PROXY and to the system
+// certificate pool.
+type NetworkSettings struct {
+	// Proxy URL; http://, https://, socks5:// and socks5h:// are supported.
+	Proxy string `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+
+	// Hosts, domain suffixes, IPs or CIDR ranges reached without the proxy.
+	NoProxy []string `yaml:"no_proxy,omitempty" json:"noProxy,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// PEM file of additional trusted CAs, e.g. a TLS inspection CA.
+	CABundle string `yaml:"ca_bundle,omitempty" json:"caBundle,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// PEM client certificate and key for mutual TLS.
+	ClientCert string `yaml:"client_cert,omitempty" json:"clientCert,omitempty"` //nolint:tagliatelle // YAML compatibility required
+	ClientKey  string `yaml:"client_key,omitempty" json:"clientKey,omitempty"`   //nolint:tagliatelle // YAML compatibility required
+
+	// Resolvers ("ip" or "ip:port") used instead of the system ones for
+	// names only resolvable on the corporate network.
+	DNSServers []string `yaml:"dns_servers,omitempty" json:"dnsServers,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Host name to IP address overrides, taking precedence over DNS.
+	Hosts map[string]string `yaml:"hosts,omitempty" json:"hosts,omitempty"`
+
+	// Hosts only reachable through a VPN, in no_proxy syntax; "*" for all.
+	// Bulk operations stop before starting when no tunnel is active.
+	VPNHosts []string `yaml:"vpn_hosts,omitempty" json:"vpnHosts,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Interface name patterns of the VPN, e.g. "utun*" or "wg0".
+	VPNInterfaces []string `yaml:"vpn_interfaces,omitempty" json:"vpnInterfaces,omitempty"` //nolint:tagliatelle // YAML compatibility required
+}
+
+// httpClientConfig converts the settings, expanding ~ in file paths.
+func (n *NetworkSettings) httpClientConfig() httpclient.NetworkConfig {
+	if n == nil {
+		return httpclient.NetworkConfig{}
+	}
+	return httpclient.NetworkConfig{
+		Proxy:      n.Proxy,
+		NoProxy:    n.NoProxy,
+		CABundle:   expandFilePath(n.CABundle),
+		ClientCert: expandFilePath(n.ClientCert),
+		ClientKey:  expandFilePath(n.ClientKey),
+
+		DNSServers:    n.DNSServers,
+		Hosts:         n.Hosts,
+		VPNHosts:      n.VPNHosts,
+		VPNInterfaces: n.VPNInterfaces,
+	}
+}
+
+func expandFilePath(path string) string {
+	if path == "" {
+		return ""
+	}
+	return expandPath(path)
+}
+
+// ApplyNetworkSettings installs the network settings of cfg for every HTTP
+// client of the process: global.network applies to all clients and
+// providers.<name>.network overrides it for that provider's API clients.
+func ApplyNetworkSettings(cfg *UnifiedConfig) error {
+	var global httpclient.NetworkConfig
+	overrides := make(map[string]httpclient.NetworkConfig)
+	if cfg != nil {
+		if cfg.Global != nil {
+			global = cfg.Global.Network.httpClientConfig()
+		}
+		for name, provider := range cfg.Providers {
+			if provider != nil && provider.Network != nil {
+				overrides[name] = provider.Network.httpClientConfig()
+			}
+		}
+	}
+
+	if err := httpclient.Configure(global, overrides); err != nil {
+		return fmt.Errorf("invalid network settings: %w", err)
+	}
+	return nil
+}
+
+// ConfigureNetwork loads gzh.yaml, if there is one, and applies its network
+// settings. Without a configuration file only the proxy environment
+// variables apply.
+func ConfigureNetwork() error {
+	facade := NewUnifiedConfigFacade()
+	if err := facade.LoadConfiguration(); err != nil {
+		return ApplyNetworkSettings(nil)
+	}
+	return ApplyNetworkSettings(facade.GetConfiguration())
+}
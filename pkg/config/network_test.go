@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+const networkConfig = `version: "1.0.0"
+global:
+  network:
+    proxy: http://proxy.corp.example.com:3128
+    no_proxy: [".corp.example.com", "10.0.0.0/8"]
+providers:
+  github:
+    token: ghp-123456
+    organizations:
+      - name: acme
+  gitlab:
+    token: glpat-123456
+    api_url: https://gitlab.partner.example.org
+    network:
+      proxy: socks5h://socks.corp.example.com:1080
+      dns_servers: ["10.0.0.53"]
+      hosts:
+        gitlab.partner.example.org: 10.20.0.8
+      vpn_hosts: ["*"]
+      vpn_interfaces: ["gpd*"]
+    organizations:
+      - name: platform
+`
+
+func TestApplyNetworkSettings(t *testing.T) {
+	saved := http.DefaultTransport
+	t.Cleanup(func() {
+		require.NoError(t, httpclient.Configure(httpclient.NetworkConfig{}, nil))
+		http.DefaultTransport = saved
+	})
+
+	cfg, err := ReadProfiles(writeProfilesConfig(t, networkConfig))
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Global.Network)
+	assert.Equal(t, []string{".corp.example.com", "10.0.0.0/8"}, cfg.Global.Network.NoProxy)
+	require.NoError(t, ApplyNetworkSettings(cfg))
+
+	proxyOf := func(rt http.RoundTripper, rawURL string) string {
+		transport, ok := rt.(*http.Transport)
+		require.True(t, ok)
+		req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+		require.NoError(t, err)
+		u, err := transport.Proxy(req)
+		require.NoError(t, err)
+		if u == nil {
+			return ""
+		}
+		return u.String()
+	}
+
+	assert.Equal(t, "http://proxy.corp.example.com:3128", proxyOf(http.DefaultTransport, "https://api.github.com"))
+	assert.Empty(t, proxyOf(http.DefaultTransport, "https://git.corp.example.com"))
+	assert.Same(t, http.DefaultTransport, httpclient.ProviderTransport("github"))
+	assert.Equal(t, "socks5h://socks.corp.example.com:1080",
+		proxyOf(httpclient.ProviderTransport("gitlab"), "https://gitlab.partner.example.org/api/v4"))
+	gitlab := httpclient.ProviderNetwork("gitlab")
+	assert.Equal(t, []string{"10.0.0.53"}, gitlab.DNSServers)
+	assert.Equal(t, map[string]string{"gitlab.partner.example.org": "10.20.0.8"}, gitlab.Hosts)
+	assert.True(t, gitlab.RequiresVPN("gitlab.partner.example.org"))
+	assert.Equal(t, []string{"gpd*"}, gitlab.VPNInterfaces)
+	assert.False(t, httpclient.ProviderNetwork("github").RequiresVPN("api.github.com"))
+
+	cfg.Providers["gitlab"].Network = &NetworkSettings{CABundle: filepath.Join(t.TempDir(), "missing.pem")}
+	assert.Error(t, ApplyNetworkSettings(cfg))
+
+	u, err := httpclient.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "api.github.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, "proxy.corp.example.com:3128", u.Host, "a failed update keeps the previous settings")
+}
+
+func TestProfileOverridesNetwork(t *testing.T) {
+	cfg := &UnifiedConfig{
+		Global:    &GlobalSettings{Network: &NetworkSettings{Proxy: "http://office:3128"}},
+		Providers: map[string]*ProviderConfig{"github": {Token: "t"}},
+		Profiles: map[string]*ProfileConfig{
+			"home": {
+				Global:    &GlobalSettings{Network: &NetworkSettings{}},
+				Providers: map[string]*ProviderConfig{"github": {Network: &NetworkSettings{Proxy: "socks5://127.0.0.1:1080"}}},
+			},
+		},
+	}
+	merged, err := cfg.WithProfile("home")
+	require.NoError(t, err)
+	assert.Empty(t, merged.Global.Network.Proxy)
+	assert.Equal(t, "socks5://127.0.0.1:1080", merged.Providers["github"].Network.Proxy)
+}
@@ -0,0 +1,396 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/internal/env"
+	"github.com/gizzahub/gzh-cli/internal/filesystem"
+)
+
+// ProfileEnvVar selects the active profile when --profile is not given.
+const ProfileEnvVar = "GZH_PROFILE"
+
+// ProfileConfig is a named overlay on the base configuration, such as
+// "work", "personal" or one per customer. Set fields replace the base
+// values; providers are merged by name, so a profile can change just the
+// token of a provider and keep its organizations.
+type ProfileConfig struct {
+	// Profile this one builds on; the base configuration when empty
+	Extends string `yaml:"extends,omitempty" json:"extends,omitempty"`
+
+	// Human readable purpose, shown by gz config profile list
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Default provider override
+	DefaultProvider string `yaml:"defaultProvider,omitempty" json:"defaultProvider,omitempty"`
+
+	// Global overrides, e.g. clone_base_dir and log_level
+	Global *GlobalSettings `yaml:"global,omitempty" json:"global,omitempty"`
+
+	// Provider overrides and additions, e.g. per-profile credentials
+	Providers map[string]*ProviderConfig `yaml:"providers,omitempty" json:"providers,omitempty"`
+}
+
+// ProfileNames returns the configured profile names in sorted order.
+func (c *UnifiedConfig) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProfileChain returns name and the profiles it extends, base-most first.
+func (c *UnifiedConfig) ProfileChain(name string) ([]string, error) {
+	var chain []string
+	for current := name; current != ""; {
+		if slices.Contains(chain, current) {
+			return nil, fmt.Errorf("profile %q extends itself through %s", name, strings.Join(chain, " -> "))
+		}
+		profile, ok := c.Profiles[current]
+		if !ok || profile == nil {
+			if current == name {
+				return nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(c.ProfileNames(), ", "))
+			}
+			return nil, fmt.Errorf("profile %q extends unknown profile %q", chain[len(chain)-1], current)
+		}
+		chain = append(chain, current)
+		current = profile.Extends
+	}
+	slices.Reverse(chain)
+	return chain, nil
+}
+
+// WithProfile returns the configuration with profile name and the profiles
+// it extends applied over the base. The receiver is not modified. An empty
+// name returns the base configuration.
+func (c *UnifiedConfig) WithProfile(name string) (*UnifiedConfig, error) {
+	if name == "" {
+		return c, nil
+	}
+	chain, err := c.ProfileChain(name)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := *c
+	if c.Global != nil {
+		global := *c.Global
+		merged.Global = &global
+	}
+	merged.Providers = make(map[string]*ProviderConfig, len(c.Providers))
+	for providerName, provider := range c.Providers {
+		if provider != nil {
+			copied := *provider
+			merged.Providers[providerName] = &copied
+		}
+	}
+
+	for _, profileName := range chain {
+		merged.applyProfile(c.Profiles[profileName])
+	}
+	return &merged, nil
+}
+
+func (c *UnifiedConfig) applyProfile(p *ProfileConfig) {
+	if p.DefaultProvider != "" {
+		c.DefaultProvider = p.DefaultProvider
+	}
+
+	if p.Global != nil {
+		if c.Global == nil {
+			c.Global = &GlobalSettings{}
+		}
+		overlayGlobal(c.Global, p.Global)
+	}
+
+	for name, override := range p.Providers {
+		if override == nil {
+			continue
+		}
+		base, ok := c.Providers[name]
+		if !ok {
+			copied := *override
+			c.Providers[name] = &copied
+			continue
+		}
+		overlayProvider(base, override)
+	}
+}
+
+func overlayGlobal(dst, src *GlobalSettings) {
+	if src.CloneBaseDir != "" {
+		dst.CloneBaseDir = src.CloneBaseDir
+	}
+	if src.DefaultStrategy != "" {
+		dst.DefaultStrategy = src.DefaultStrategy
+	}
+	if src.GlobalIgnores != nil {
+		dst.GlobalIgnores = src.GlobalIgnores
+	}
+	if src.DefaultVisibility != "" {
+		dst.DefaultVisibility = src.DefaultVisibility
+	}
+	if src.LogLevel != "" {
+		dst.LogLevel = src.LogLevel
+	}
+	if src.Locale != "" {
+		dst.Locale = src.Locale
+	}
+	if src.Timeouts != nil {
+		dst.Timeouts = src.Timeouts
+	}
+	if src.Concurrency != nil {
+		dst.Concurrency = src.Concurrency
+	}
+	if src.SecretStore != nil {
+		dst.SecretStore = src.SecretStore
+	}
+	if src.Network != nil {
+		dst.Network = src.Network
+	}
+	if len(src.Compression) > 0 {
+		// 기본 설정과 맵을 공유하지 않도록 새로 만든다
+		merged := make(map[string]string, len(dst.Compression)+len(src.Compression))
+		for use, codec := range dst.Compression {
+			merged[use] = codec
+		}
+		for use, codec := range src.Compression {
+			merged[use] = codec
+		}
+		dst.Compression = merged
+	}
+}
+
+func overlayProvider(dst, src *ProviderConfig) {
+	if src.Token != "" {
+		dst.Token = src.Token
+	}
+	if src.APIURL != "" {
+		dst.APIURL = src.APIURL
+	}
+	if src.Username != "" {
+		dst.Username = src.Username
+	}
+	if len(src.Organizations) > 0 {
+		dst.Organizations = src.Organizations
+	}
+	if src.Settings != nil {
+		dst.Settings = src.Settings
+	}
+	if src.Network != nil {
+		dst.Network = src.Network
+	}
+	if src.App != nil {
+		dst.App = src.App
+		// 앱 인증을 지정한 프로필은 기본 토큰을 물려받지 않는다
+		if src.Token == "" {
+			dst.Token = ""
+		}
+	}
+}
+
+// ActiveProfileName returns the profile selected by flag, falling back to
+// the GZH_PROFILE environment variable.
+func ActiveProfileName(flag string, environment env.Environment) string {
+	if flag != "" {
+		return flag
+	}
+	if environment == nil {
+		environment = env.NewOSEnvironment()
+	}
+	return strings.TrimSpace(environment.Get(ProfileEnvVar))
+}
+
+// ReadProfiles reads a unified config file without validating or applying
+// profiles, for inspecting and editing them.
+func ReadProfiles(path string) (*UnifiedConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var cfg UnifiedConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal unified config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// AddProfile adds a profile to the config file at path. The document is
+// edited in place, so comments and key order are kept.
+func AddProfile(path, name string, profile *ProfileConfig) error {
+	if name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("config file %s is not a YAML mapping", path)
+	}
+	root := doc.Content[0]
+
+	cfg, err := ReadProfiles(path)
+	if err != nil {
+		return err
+	}
+	if _, exists := cfg.Profiles[name]; exists {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	if profile.Extends != "" {
+		if _, ok := cfg.Profiles[profile.Extends]; !ok {
+			return fmt.Errorf("profile %q extends unknown profile %q", name, profile.Extends)
+		}
+	}
+
+	var value yaml.Node
+	if err := value.Encode(profile); err != nil {
+		return fmt.Errorf("failed to encode profile: %w", err)
+	}
+
+	profiles := mappingValue(root, "profiles")
+	if profiles == nil || profiles.Kind != yaml.MappingNode {
+		profiles = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(root, "profiles", profiles)
+	}
+	profiles.Content = append(profiles.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, &value)
+
+	return writeYAMLDocument(path, &doc)
+}
+
+// writeYAMLDocument encodes doc back to path, keeping the file mode.
+func writeYAMLDocument(path string, doc *yaml.Node) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return filesystem.WriteFileAtomic(filesystem.OS(), path, buf.Bytes(), info.Mode().Perm())
+}
+
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// ProfileDifference is a setting that differs between two profiles.
+type ProfileDifference struct {
+	Path string `json:"path"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DiffProfiles compares the effective configurations of two profiles; an
+// empty name stands for the base configuration. Tokens are masked.
+func (c *UnifiedConfig) DiffProfiles(from, to string) ([]ProfileDifference, error) {
+	left, err := c.effectiveSettings(from)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.effectiveSettings(to)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(left)+len(right))
+	for path := range left {
+		paths = append(paths, path)
+	}
+	for path := range right {
+		if _, ok := left[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var diffs []ProfileDifference
+	for _, path := range paths {
+		if left[path] != right[path] {
+			diffs = append(diffs, ProfileDifference{Path: path, From: left[path], To: right[path]})
+		}
+	}
+	return diffs, nil
+}
+
+// effectiveSettings flattens the configuration of a profile into dotted
+// paths, leaving out the profile definitions themselves.
+func (c *UnifiedConfig) effectiveSettings(profile string) (map[string]string, error) {
+	cfg, err := c.WithProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	view := *cfg
+	view.Profiles = nil
+
+	data, err := yaml.Marshal(&view)
+	if err != nil {
+		return nil, err
+	}
+	var tree any
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string)
+	flattenSettings("", tree, out)
+	return out, nil
+}
+
+func flattenSettings(prefix string, node any, out map[string]string) {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, child := range v {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			flattenSettings(path, child, out)
+		}
+	case []any:
+		for i, child := range v {
+			flattenSettings(fmt.Sprintf("%s[%d]", prefix, i), child, out)
+		}
+	case nil:
+	default:
+		value := fmt.Sprint(v)
+		if value == "" {
+			return
+		}
+		if strings.HasSuffix(prefix, ".token") {
+			value = maskToken(value)
+		}
+		out[prefix] = value
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/env"
+)
+
+const profilesConfig = `# team config
+version: "1.0.0"
+defaultProvider: github
+global:
+  clone_base_dir: ~/repos
+  log_level: warn
+providers:
+  github:
+    token: base-token-123456
+    organizations:
+      - name: acme
+        clone_dir: ~/repos/acme
+profiles:
+  work:
+    description: Day job
+    global:
+      clone_base_dir: ~/work
+    providers:
+      github:
+        token: ${WORK_GITHUB_TOKEN}
+  customer-x:
+    extends: work
+    global:
+      log_level: debug
+    providers:
+      gitlab:
+        token: ${CUSTOMER_X_TOKEN}
+        api_url: https://git.customer-x.com
+        organizations:
+          - name: platform
+            clone_dir: ~/work/customer-x
+`
+
+func writeProfilesConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestWithProfileInheritance(t *testing.T) {
+	cfg, err := ReadProfiles(writeProfilesConfig(t, profilesConfig))
+	require.NoError(t, err)
+
+	chain, err := cfg.ProfileChain("customer-x")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"work", "customer-x"}, chain)
+
+	merged, err := cfg.WithProfile("customer-x")
+	require.NoError(t, err)
+	assert.Equal(t, "~/work", merged.Global.CloneBaseDir)
+	assert.Equal(t, "debug", merged.Global.LogLevel)
+	assert.Equal(t, "${WORK_GITHUB_TOKEN}", merged.Providers["github"].Token)
+	assert.Len(t, merged.Providers["github"].Organizations, 1, "organizations are inherited from the base")
+	assert.Equal(t, "https://git.customer-x.com", merged.Providers["gitlab"].APIURL)
+
+	// 원본 설정은 변경되지 않아야 한다
+	assert.Equal(t, "~/repos", cfg.Global.CloneBaseDir)
+	assert.Equal(t, "base-token-123456", cfg.Providers["github"].Token)
+	assert.NotContains(t, cfg.Providers, "gitlab")
+
+	base, err := cfg.WithProfile("")
+	require.NoError(t, err)
+	assert.Same(t, cfg, base)
+}
+
+func TestProfileChainErrors(t *testing.T) {
+	cfg := &UnifiedConfig{Profiles: map[string]*ProfileConfig{
+		"a":      {Extends: "b"},
+		"b":      {Extends: "a"},
+		"orphan": {Extends: "missing"},
+	}}
+
+	_, err := cfg.ProfileChain("a")
+	assert.ErrorContains(t, err, "extends itself")
+	_, err = cfg.ProfileChain("orphan")
+	assert.ErrorContains(t, err, `profile "orphan" extends unknown profile "missing"`)
+	_, err = cfg.WithProfile("nope")
+	assert.ErrorContains(t, err, `unknown profile "nope" (available: a, b, orphan)`)
+}
+
+func TestLoaderAppliesProfile(t *testing.T) {
+	// 기본 설정에 토큰이 없어도 프로필이 제공하면 검증을 통과한다
+	path := writeProfilesConfig(t, `version: "1.0.0"
+providers:
+  github:
+    organizations:
+      - name: acme
+        clone_dir: ./acme
+profiles:
+  personal:
+    providers:
+      github:
+        token: personal-token
+`)
+
+	_, err := (&UnifiedLoader{}).LoadConfigFromPath(path)
+	require.Error(t, err)
+
+	result, err := (&UnifiedLoader{Profile: "personal"}).LoadConfigFromPath(path)
+	require.NoError(t, err)
+	assert.Equal(t, "personal", result.Profile)
+	assert.Equal(t, "personal-token", result.Config.Providers["github"].Token)
+
+	mockEnv := env.NewMockEnvironment(map[string]string{ProfileEnvVar: "personal"})
+	cfg, err := NewConfigFactoryWithOptions(&ConfigFactoryOptions{Environment: mockEnv}).LoadConfigFromPath(path)
+	require.NoError(t, err)
+	assert.Equal(t, "personal-token", cfg.Providers["github"].Token)
+
+	_, err = NewConfigFactoryWithOptions(&ConfigFactoryOptions{Environment: mockEnv, Profile: "missing"}).LoadConfigFromPath(path)
+	assert.ErrorContains(t, err, `unknown profile "missing"`)
+}
+
+func TestActiveProfileName(t *testing.T) {
+	mockEnv := env.NewMockEnvironment(map[string]string{ProfileEnvVar: " work "})
+	assert.Equal(t, "work", ActiveProfileName("", mockEnv))
+	assert.Equal(t, "personal", ActiveProfileName("personal", mockEnv))
+	assert.Empty(t, ActiveProfileName("", env.NewMockEnvironment(nil)))
+}
+
+func TestDiffProfiles(t *testing.T) {
+	cfg, err := ReadProfiles(writeProfilesConfig(t, profilesConfig))
+	require.NoError(t, err)
+
+	diffs, err := cfg.DiffProfiles("", "work")
+	require.NoError(t, err)
+	assert.Equal(t, []ProfileDifference{
+		{Path: "global.clone_base_dir", From: "~/repos", To: "~/work"},
+		{Path: "providers.github.token", From: "base***3456", To: "${WORK_GITHUB_TOKEN}"},
+	}, diffs)
+
+	diffs, err = cfg.DiffProfiles("work", "customer-x")
+	require.NoError(t, err)
+	paths := make([]string, 0, len(diffs))
+	for _, d := range diffs {
+		paths = append(paths, d.Path)
+	}
+	assert.Contains(t, paths, "global.log_level")
+	assert.Contains(t, paths, "providers.gitlab.api_url")
+	assert.NotContains(t, paths, "providers.github.token")
+
+	diffs, err = cfg.DiffProfiles("work", "work")
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+}
+
+func TestAddProfile(t *testing.T) {
+	path := writeProfilesConfig(t, profilesConfig)
+
+	err := AddProfile(path, "personal", &ProfileConfig{
+		Extends: "work",
+		Global:  &GlobalSettings{CloneBaseDir: "~/oss"},
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# team config", "comments are kept")
+
+	cfg, err := ReadProfiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"customer-x", "personal", "work"}, cfg.ProfileNames())
+	merged, err := cfg.WithProfile("personal")
+	require.NoError(t, err)
+	assert.Equal(t, "~/oss", merged.Global.CloneBaseDir)
+
+	assert.ErrorContains(t, AddProfile(path, "work", &ProfileConfig{}), "already exists")
+	assert.ErrorContains(t, AddProfile(path, "x", &ProfileConfig{Extends: "nope"}), "unknown profile")
+
+	// profiles 키가 없는 파일에는 새로 추가한다
+	bare := writeProfilesConfig(t, "version: \"1.0.0\"\nproviders: {}\n")
+	require.NoError(t, AddProfile(bare, "work", &ProfileConfig{Description: "Day job"}))
+	cfg, err = ReadProfiles(bare)
+	require.NoError(t, err)
+	assert.Equal(t, "Day job", cfg.Profiles["work"].Description)
+}
+
+func TestSetGlobalSetting(t *testing.T) {
+	path := writeProfilesConfig(t, profilesConfig)
+
+	require.NoError(t, SetGlobalSetting(path, "locale", "ko"))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# team config", "comments are kept")
+
+	cfg, err := ReadProfiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ko", cfg.Global.Locale)
+
+	// 프로필은 전역 locale을 덮어쓴다
+	cfg.Profiles["work"].Global.Locale = "ja"
+	merged, err := cfg.WithProfile("work")
+	require.NoError(t, err)
+	assert.Equal(t, "ja", merged.Global.Locale)
+
+	// global 키가 없는 파일에는 새로 추가한다
+	bare := writeProfilesConfig(t, "version: \"1.0.0\"\nproviders: {}\n")
+	require.NoError(t, SetGlobalSetting(bare, "locale", "zh"))
+	cfg, err = ReadProfiles(bare)
+	require.NoError(t, err)
+	assert.Equal(t, "zh", cfg.Global.Locale)
+}
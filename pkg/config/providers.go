@@ -6,9 +6,11 @@ package config
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/gizzahub/gzh-cli/internal/env"
+	"github.com/gizzahub/gzh-cli/pkg/gerrit"
 )
 
 // ProviderCloner defines the interface for provider-specific cloning operations.
@@ -195,11 +197,81 @@ func CreateProviderCloner(providerName, token string) (ProviderCloner, error) {
 		return NewGitLabClonerWithEnv(token, environment), nil
 	case ProviderGitea:
 		return NewGiteaClonerWithEnv(token, environment), nil
+	case ProviderGerrit:
+		return nil, fmt.Errorf("gerrit requires a server URL, use NewGerritCloner")
 	default:
 		return nil, fmt.Errorf("unsupported provider: %s", providerName)
 	}
 }
 
+// GerritCloner implements ProviderCloner for Gerrit. Organizations are
+// project name prefixes, e.g. "platform/" clones every project below it.
+type GerritCloner struct {
+	baseURL  string
+	username string
+	token    string
+}
+
+// NewGerritCloner creates a new Gerrit cloner. token is the HTTP password;
+// empty credentials fall back to GERRIT_USERNAME and GERRIT_HTTP_PASSWORD.
+func NewGerritCloner(baseURL, username, token string) *GerritCloner {
+	return &GerritCloner{
+		baseURL:  baseURL,
+		username: username,
+		token:    token,
+	}
+}
+
+// CloneOrganization clones all projects whose name starts with prefix.
+func (g *GerritCloner) CloneOrganization(prefix, targetPath, strategy string) error {
+	username := ExpandEnvironmentVariables(g.username)
+	if username == "" {
+		username = os.Getenv(gerrit.EnvUsername)
+	}
+	password := ExpandEnvironmentVariables(g.token)
+	if password == "" {
+		password = os.Getenv(gerrit.EnvPassword)
+	}
+
+	client, err := gerrit.NewClient(gerrit.ClientOptions{
+		BaseURL:  ExpandEnvironmentVariables(g.baseURL),
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Gerrit client: %w", err)
+	}
+
+	return gerrit.RefreshAll(context.Background(), client, targetPath, prefix, strategy)
+}
+
+// CloneGroup clones all projects below a prefix (alias for organization).
+func (g *GerritCloner) CloneGroup(groupName, targetPath, strategy string) error {
+	return g.CloneOrganization(groupName, targetPath, strategy)
+}
+
+// SetToken configures the Gerrit HTTP password.
+func (g *GerritCloner) SetToken(token string) {
+	g.token = token
+}
+
+// GetName returns the provider name identifier for Gerrit.
+func (g *GerritCloner) GetName() string {
+	return ProviderGerrit
+}
+
+// createClonerForProvider creates the cloner for a configured provider.
+// Gerrit needs the server URL and account name in addition to the token.
+func createClonerForProvider(providerName string, provider Provider) (ProviderCloner, error) {
+	if strings.ToLower(providerName) == ProviderGerrit {
+		if provider.URL == "" {
+			return nil, fmt.Errorf("provider %s: url is required", providerName)
+		}
+		return NewGerritCloner(provider.URL, provider.Username, provider.Token), nil
+	}
+	return CreateProviderCloner(providerName, provider.Token)
+}
+
 // BulkCloneExecutor handles bulk cloning operations with filtering and processing.
 type BulkCloneExecutor struct {
 	integration *BulkCloneIntegration
@@ -218,7 +290,7 @@ func NewBulkCloneExecutorWithFactory(config *Config, _ ProviderFactory) (*BulkCl
 
 	// Create cloners for each configured provider using the legacy interface
 	for providerName, provider := range config.Providers {
-		cloner, err := CreateProviderCloner(providerName, provider.Token)
+		cloner, err := createClonerForProvider(providerName, provider)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create cloner for %s: %w", providerName, err)
 		}
@@ -0,0 +1,399 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ReloadEvent is a typed change delivered to ConfigWatcher subscribers.
+type ReloadEvent interface {
+	// inverse returns the event that undoes this one during rollback.
+	inverse() ReloadEvent
+}
+
+// LogLevelChanged reports a new global.log_level.
+type LogLevelChanged struct {
+	Old, New string
+}
+
+func (e LogLevelChanged) inverse() ReloadEvent { return LogLevelChanged{Old: e.New, New: e.Old} }
+
+// ConcurrencyChanged reports new global.concurrency worker counts.
+type ConcurrencyChanged struct {
+	Old, New ConcurrencySettings
+}
+
+func (e ConcurrencyChanged) inverse() ReloadEvent { return ConcurrencyChanged{Old: e.New, New: e.Old} }
+
+// ProviderTokenChanged reports a new, resolved token for a provider. New is
+// empty when the provider was removed from the configuration.
+type ProviderTokenChanged struct {
+	Provider string
+	Old, New string
+}
+
+func (e ProviderTokenChanged) inverse() ReloadEvent {
+	return ProviderTokenChanged{Provider: e.Provider, Old: e.New, New: e.Old}
+}
+
+// ConfigReloaded is delivered after the typed events of a reload with the
+// complete new configuration.
+type ConfigReloaded struct {
+	Old, New *UnifiedConfig
+}
+
+func (e ConfigReloaded) inverse() ReloadEvent { return ConfigReloaded{Old: e.New, New: e.Old} }
+
+// ConfigWatcherOptions configures a ConfigWatcher.
+type ConfigWatcherOptions struct {
+	// Debounce coalesces the burst of events editors produce on save.
+	// Defaults to 250ms.
+	Debounce time.Duration
+
+	// Load reads and validates the configuration. Defaults to LoadReloadable.
+	Load func(path string) (*UnifiedConfig, error)
+
+	// OnError receives reloads that were rejected; the previous configuration
+	// stays active. Defaults to ignoring them.
+	OnError func(error)
+}
+
+// subscriber is a registered event handler.
+type subscriber struct {
+	handle func(ReloadEvent) (handled bool, err error)
+}
+
+// ConfigWatcher reloads a unified configuration file when it changes and
+// propagates the differences to subscribers. An edit that fails validation,
+// or that a subscriber rejects, is rolled back: subscribers that already
+// applied it receive the inverse events and the previous configuration stays
+// current.
+type ConfigWatcher struct {
+	path string
+	opts ConfigWatcherOptions
+
+	// reloadMu serializes reloads; mu guards the fields below so handlers may
+	// call Current while a reload is in progress.
+	reloadMu    sync.Mutex
+	digest      [sha256.Size]byte
+	mu          sync.Mutex
+	current     *UnifiedConfig
+	subscribers []subscriber
+}
+
+// NewConfigWatcher loads path and returns a watcher for it. The initial
+// configuration must be valid.
+func NewConfigWatcher(path string, opts ConfigWatcherOptions) (*ConfigWatcher, error) {
+	if opts.Debounce <= 0 {
+		opts.Debounce = 250 * time.Millisecond
+	}
+	if opts.Load == nil {
+		opts.Load = LoadReloadable
+	}
+	if opts.OnError == nil {
+		opts.OnError = func(error) {}
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config path: %w", err)
+	}
+
+	w := &ConfigWatcher{path: absPath, opts: opts}
+
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	cfg, err := opts.Load(absPath)
+	if err != nil {
+		return nil, err
+	}
+	w.current = cfg
+	w.digest = sha256.Sum256(data)
+
+	return w, nil
+}
+
+// Path returns the watched file.
+func (w *ConfigWatcher) Path() string {
+	return w.path
+}
+
+// Current returns the active configuration. It must not be modified.
+func (w *ConfigWatcher) Current() *UnifiedConfig {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Subscribe registers fn for events of type E. Use ReloadEvent as E to
+// receive every event. Returning an error rejects the reload.
+// Handlers run in subscription order, one reload at a time.
+func Subscribe[E ReloadEvent](w *ConfigWatcher, fn func(E) error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscribers = append(w.subscribers, subscriber{
+		handle: func(ev ReloadEvent) (bool, error) {
+			typed, ok := ev.(E)
+			if !ok {
+				return false, nil
+			}
+			return true, fn(typed)
+		},
+	})
+}
+
+// Reload reads the file and applies any changes. It returns nil when the
+// file content is unchanged.
+func (w *ConfigWatcher) Reload() error {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	digest := sha256.Sum256(data)
+
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	if digest == w.digest {
+		return nil
+	}
+
+	next, err := w.opts.Load(w.path)
+	if err != nil {
+		return fmt.Errorf("invalid configuration, keeping previous: %w", err)
+	}
+
+	w.mu.Lock()
+	previous := w.current
+	subscribers := append([]subscriber(nil), w.subscribers...)
+	w.mu.Unlock()
+
+	events := DiffReloadable(previous, next)
+	events = append(events, ConfigReloaded{Old: previous, New: next})
+	if err := apply(subscribers, events); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	w.current = next
+	w.mu.Unlock()
+	w.digest = digest
+
+	return nil
+}
+
+// apply delivers events in order and undoes them when a subscriber fails.
+func apply(subscribers []subscriber, events []ReloadEvent) error {
+	type delivered struct {
+		sub   subscriber
+		event ReloadEvent
+	}
+	var done []delivered
+
+	for _, ev := range events {
+		for _, sub := range subscribers {
+			handled, err := sub.handle(ev)
+			if err == nil {
+				if handled {
+					done = append(done, delivered{sub, ev})
+				}
+				continue
+			}
+
+			// 역순으로 되돌려 구독자 상태를 이전 설정과 일치시킨다
+			var rollbackErrs []error
+			for i := len(done) - 1; i >= 0; i-- {
+				if _, rbErr := done[i].sub.handle(done[i].event.inverse()); rbErr != nil {
+					rollbackErrs = append(rollbackErrs, rbErr)
+				}
+			}
+			if len(rollbackErrs) > 0 {
+				return fmt.Errorf("configuration change rejected: %w (rollback: %w)", err, errors.Join(rollbackErrs...))
+			}
+			return fmt.Errorf("configuration change rejected, keeping previous: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Watch reloads the configuration whenever the file changes until ctx is
+// done. The directory is watched so that editors which save by renaming a
+// temporary file are detected.
+func (w *ConfigWatcher) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", filepath.Dir(w.path), err)
+	}
+
+	timer := time.NewTimer(w.opts.Debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != w.path || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			timer.Reset(w.opts.Debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.opts.OnError(fmt.Errorf("config watcher: %w", err))
+		case <-timer.C:
+			if err := w.Reload(); err != nil {
+				w.opts.OnError(err)
+			}
+		}
+	}
+}
+
+// LoadReloadable loads a unified configuration, validates the settings that
+// can change at runtime and resolves provider tokens.
+func LoadReloadable(path string) (*UnifiedConfig, error) {
+	result, err := NewUnifiedLoader().LoadConfigFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := result.Config
+
+	if err := validateReloadable(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := ResolveSecrets(cfg, nil); err != nil {
+		return nil, err
+	}
+	for _, provider := range cfg.Providers {
+		if provider != nil {
+			provider.Token = ExpandEnvironmentVariables(provider.Token)
+		}
+	}
+
+	return cfg, nil
+}
+
+// validateReloadable checks the runtime settings against their schema bounds.
+func validateReloadable(cfg *UnifiedConfig) error {
+	if cfg.Global == nil {
+		return nil
+	}
+
+	switch strings.ToLower(cfg.Global.LogLevel) {
+	case "", "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("global.log_level must be one of debug, info, warn, error (got %q)", cfg.Global.LogLevel)
+	}
+
+	if c := cfg.Global.Concurrency; c != nil {
+		bounds := []struct {
+			name  string
+			value int
+			max   int
+		}{
+			{"clone_workers", c.CloneWorkers, 50},
+			{"update_workers", c.UpdateWorkers, 50},
+			{"api_workers", c.APIWorkers, 20},
+		}
+		for _, b := range bounds {
+			if b.value < 0 || b.value > b.max {
+				return fmt.Errorf("global.concurrency.%s must be between 1 and %d (got %d)", b.name, b.max, b.value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// DiffReloadable returns the typed events that turn old into next.
+func DiffReloadable(old, next *UnifiedConfig) []ReloadEvent {
+	var events []ReloadEvent
+
+	oldGlobal, nextGlobal := globalOf(old), globalOf(next)
+
+	if oldLevel, nextLevel := strings.ToLower(oldGlobal.LogLevel), strings.ToLower(nextGlobal.LogLevel); oldLevel != nextLevel {
+		events = append(events, LogLevelChanged{Old: oldLevel, New: nextLevel})
+	}
+
+	if oldConc, nextConc := concurrencyOf(oldGlobal), concurrencyOf(nextGlobal); oldConc != nextConc {
+		events = append(events, ConcurrencyChanged{Old: oldConc, New: nextConc})
+	}
+
+	names := make(map[string]struct{})
+	for name := range providersOf(old) {
+		names[name] = struct{}{}
+	}
+	for name := range providersOf(next) {
+		names[name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		oldToken, nextToken := tokenOf(old, name), tokenOf(next, name)
+		if oldToken != nextToken {
+			events = append(events, ProviderTokenChanged{Provider: name, Old: oldToken, New: nextToken})
+		}
+	}
+
+	return events
+}
+
+func globalOf(cfg *UnifiedConfig) GlobalSettings {
+	if cfg == nil || cfg.Global == nil {
+		return GlobalSettings{}
+	}
+	return *cfg.Global
+}
+
+func concurrencyOf(g GlobalSettings) ConcurrencySettings {
+	if g.Concurrency == nil {
+		return ConcurrencySettings{}
+	}
+	return *g.Concurrency
+}
+
+func providersOf(cfg *UnifiedConfig) map[string]*ProviderConfig {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Providers
+}
+
+func tokenOf(cfg *UnifiedConfig, provider string) string {
+	if p := providersOf(cfg)[provider]; p != nil {
+		return p.Token
+	}
+	return ""
+}
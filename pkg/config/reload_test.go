@@ -0,0 +1,148 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeReloadConfig(t *testing.T, path, level string, workers int, token string) {
+	t.Helper()
+
+	content := fmt.Sprintf(`version: "1.0.0"
+global:
+  log_level: %s
+  concurrency:
+    clone_workers: %d
+providers:
+  github:
+    token: %q
+    organizations:
+      - name: myorg
+        clone_dir: /tmp/myorg
+`, level, workers, token)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestConfigWatcher_ReloadDeliversTypedEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	writeReloadConfig(t, path, "info", 5, "old-token")
+
+	w, err := NewConfigWatcher(path, ConfigWatcherOptions{})
+	require.NoError(t, err)
+
+	var (
+		levels  []LogLevelChanged
+		workers []ConcurrencyChanged
+		tokens  []ProviderTokenChanged
+		all     int
+	)
+	Subscribe(w, func(e LogLevelChanged) error { levels = append(levels, e); return nil })
+	Subscribe(w, func(e ConcurrencyChanged) error { workers = append(workers, e); return nil })
+	Subscribe(w, func(e ProviderTokenChanged) error { tokens = append(tokens, e); return nil })
+	Subscribe(w, func(ReloadEvent) error { all++; return nil })
+
+	require.NoError(t, w.Reload(), "unchanged file is a no-op")
+	assert.Zero(t, all)
+
+	t.Setenv("GZ_TEST_RELOAD_TOKEN", "new-token")
+	writeReloadConfig(t, path, "debug", 8, "${GZ_TEST_RELOAD_TOKEN}")
+	require.NoError(t, w.Reload())
+
+	assert.Equal(t, []LogLevelChanged{{Old: "info", New: "debug"}}, levels)
+	require.Len(t, workers, 1)
+	assert.Equal(t, 8, workers[0].New.CloneWorkers)
+	assert.Equal(t, []ProviderTokenChanged{{Provider: "github", Old: "old-token", New: "new-token"}}, tokens)
+	assert.Equal(t, 4, all, "three changes and ConfigReloaded")
+	assert.Equal(t, "debug", w.Current().Global.LogLevel)
+}
+
+func TestConfigWatcher_InvalidEditKeepsPrevious(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	writeReloadConfig(t, path, "info", 5, "token")
+
+	w, err := NewConfigWatcher(path, ConfigWatcherOptions{})
+	require.NoError(t, err)
+
+	called := false
+	Subscribe(w, func(ReloadEvent) error { called = true; return nil })
+
+	writeReloadConfig(t, path, "loud", 5, "token")
+	err = w.Reload()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "log_level")
+
+	writeReloadConfig(t, path, "info", 500, "token")
+	require.Error(t, w.Reload())
+
+	assert.False(t, called)
+	assert.Equal(t, "info", w.Current().Global.LogLevel)
+	assert.Equal(t, 5, w.Current().Global.Concurrency.CloneWorkers)
+}
+
+func TestConfigWatcher_RejectedChangeRollsBack(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	writeReloadConfig(t, path, "info", 5, "token")
+
+	w, err := NewConfigWatcher(path, ConfigWatcherOptions{})
+	require.NoError(t, err)
+
+	level := "info"
+	Subscribe(w, func(e LogLevelChanged) error { level = e.New; return nil })
+	Subscribe(w, func(e ConcurrencyChanged) error {
+		if e.New.CloneWorkers > 6 {
+			return errors.New("pool cannot grow beyond 6")
+		}
+		return nil
+	})
+
+	writeReloadConfig(t, path, "debug", 10, "token")
+	err = w.Reload()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pool cannot grow")
+
+	assert.Equal(t, "info", level, "applied change is undone")
+	assert.Equal(t, "info", w.Current().Global.LogLevel)
+}
+
+func TestConfigWatcher_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	writeReloadConfig(t, path, "info", 5, "token")
+
+	w, err := NewConfigWatcher(path, ConfigWatcherOptions{Debounce: 20 * time.Millisecond})
+	require.NoError(t, err)
+
+	changed := make(chan string, 1)
+	Subscribe(w, func(e LogLevelChanged) error { changed <- e.New; return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- w.Watch(ctx) }()
+
+	// fsnotify 등록을 기다린 뒤 편집기처럼 임시 파일을 rename으로 교체
+	time.Sleep(100 * time.Millisecond)
+	tmp := path + ".tmp"
+	writeReloadConfig(t, tmp, "warn", 5, "token")
+	require.NoError(t, os.Rename(tmp, path))
+
+	select {
+	case level := <-changed:
+		assert.Equal(t, "warn", level)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change was not detected")
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
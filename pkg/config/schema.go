@@ -3,6 +3,13 @@
 
 package config
 
+import (
+	"fmt"
+
+	"github.com/gizzahub/gzh-cli/internal/git/repofilter"
+	synclone "github.com/gizzahub/gzh-cli/pkg/synclone"
+)
+
 // Config represents the top-level gzh.yaml configuration.
 type Config struct {
 	Version         string              `yaml:"version" json:"version"`
@@ -12,9 +19,11 @@ type Config struct {
 
 // Provider represents a Git provider configuration.
 type Provider struct {
-	Token  string      `yaml:"token" json:"token"`
-	Orgs   []GitTarget `yaml:"orgs,omitempty" json:"orgs,omitempty"`     // For GitHub/Gitea
-	Groups []GitTarget `yaml:"groups,omitempty" json:"groups,omitempty"` // For GitLab
+	Token    string      `yaml:"token" json:"token"`
+	URL      string      `yaml:"url,omitempty" json:"url,omitempty"`           // Server URL (required for Gerrit)
+	Username string      `yaml:"username,omitempty" json:"username,omitempty"` // Account name (Gerrit HTTP credentials)
+	Orgs     []GitTarget `yaml:"orgs,omitempty" json:"orgs,omitempty"`         // For GitHub/Gitea, project prefixes for Gerrit
+	Groups   []GitTarget `yaml:"groups,omitempty" json:"groups,omitempty"`     // For GitLab
 }
 
 // GitTarget represents an organization or group configuration.
@@ -24,9 +33,13 @@ type GitTarget struct {
 	Recursive  bool     `yaml:"recursive,omitempty" json:"recursive,omitempty"`   // For GitLab subgroups
 	Flatten    bool     `yaml:"flatten,omitempty" json:"flatten,omitempty"`       // Flatten directory structure
 	Match      string   `yaml:"match,omitempty" json:"match,omitempty"`           // Regex pattern filter
+	Filter     string   `yaml:"filter,omitempty" json:"filter,omitempty"`         // Filter expression, e.g. "language == go and not archived"
 	CloneDir   string   `yaml:"cloneDir,omitempty" json:"cloneDir,omitempty"`     // Target directory
 	Exclude    []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`       // Repos to exclude
 	Strategy   string   `yaml:"strategy,omitempty" json:"strategy,omitempty"`     // reset, pull, fetch
+
+	Hooks  *synclone.Hooks         `yaml:"hooks,omitempty" json:"hooks,omitempty"`   // Lifecycle hooks
+	Sparse *synclone.SparseProfile `yaml:"sparse,omitempty" json:"sparse,omitempty"` // Sparse checkout of owned paths
 }
 
 // Visibility constants.
@@ -49,6 +62,7 @@ const (
 	ProviderGitLab = "gitlab"
 	ProviderGitea  = "gitea"
 	ProviderGogs   = "gogs"
+	ProviderGerrit = "gerrit"
 )
 
 // SetDefaults sets default values for GitTarget.
@@ -128,5 +142,11 @@ func (g *GitTarget) Validate() error {
 		}
 	}
 
+	if g.Filter != "" {
+		if _, err := repofilter.Parse(g.Filter); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidFilter, err)
+		}
+	}
+
 	return nil
 }
@@ -120,6 +120,14 @@ func TestGitTarget_Validate(t *testing.T) {
 			},
 			wantErr: ErrInvalidRegex,
 		},
+		{
+			name: "invalid filter",
+			target: GitTarget{
+				Name:   "test",
+				Filter: "language ==",
+			},
+			wantErr: ErrInvalidFilter,
+		},
 		{
 			name: "valid target",
 			target: GitTarget{
@@ -127,6 +135,7 @@ func TestGitTarget_Validate(t *testing.T) {
 				Visibility: VisibilityPublic,
 				Strategy:   StrategyPull,
 				Match:      "^test-.*",
+				Filter:     "not archived and pushed < 1y",
 			},
 			wantErr: nil,
 		},
@@ -0,0 +1,487 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+
+	synclone "github.com/gizzahub/gzh-cli/pkg/synclone"
+)
+
+// Config types with a JSON Schema.
+const (
+	ConfigTypeGZH        = "gzh"
+	ConfigTypeRepoConfig = "repo-config"
+	ConfigTypeBulkClone  = "bulk-clone"
+)
+
+// ConfigSchema describes one configuration file type.
+type ConfigSchema struct {
+	Name        string
+	Description string
+	// Schema returns the JSON Schema document.
+	Schema func() ([]byte, error)
+	// Detect reports whether a decoded document looks like this type.
+	Detect func(doc map[string]any) bool
+}
+
+var (
+	configSchemasMu sync.RWMutex
+	configSchemas   = []ConfigSchema{
+		{
+			Name:        ConfigTypeGZH,
+			Description: "Unified gzh.yaml configuration",
+			Schema:      func() ([]byte, error) { return GenerateJSONSchema(UnifiedConfig{}, "gzh.yaml configuration") },
+			Detect: func(doc map[string]any) bool {
+				_, ok := doc["providers"].(map[string]any)
+				return ok
+			},
+		},
+		{
+			Name:        ConfigTypeRepoConfig,
+			Description: "Repository configuration (gz repo-config)",
+			Schema:      func() ([]byte, error) { return GenerateJSONSchema(RepoConfig{}, "Repository configuration") },
+			Detect: func(doc map[string]any) bool {
+				_, ok := doc["organization"].(string)
+				return ok
+			},
+		},
+		{
+			Name:        ConfigTypeBulkClone,
+			Description: "Legacy bulk-clone.yaml configuration (gz synclone)",
+			Schema:      func() ([]byte, error) { return []byte(synclone.SchemaJSON()), nil },
+			Detect:      func(doc map[string]any) bool { return hasAnyKey(doc, "repo_roots", "ignore_names") },
+		},
+	}
+)
+
+// RegisterConfigSchema adds a configuration type, replacing one with the
+// same name.
+func RegisterConfigSchema(s ConfigSchema) {
+	configSchemasMu.Lock()
+	defer configSchemasMu.Unlock()
+
+	for i := range configSchemas {
+		if configSchemas[i].Name == s.Name {
+			configSchemas[i] = s
+			return
+		}
+	}
+	configSchemas = append(configSchemas, s)
+}
+
+// ConfigSchemas returns every known configuration type.
+func ConfigSchemas() []ConfigSchema {
+	configSchemasMu.RLock()
+	defer configSchemasMu.RUnlock()
+
+	return append([]ConfigSchema(nil), configSchemas...)
+}
+
+// LookupConfigSchema returns the configuration type with the given name.
+func LookupConfigSchema(name string) (ConfigSchema, error) {
+	var names []string
+	for _, s := range ConfigSchemas() {
+		if s.Name == name {
+			return s, nil
+		}
+		names = append(names, s.Name)
+	}
+	return ConfigSchema{}, fmt.Errorf("unknown config type %q (valid: %s)", name, strings.Join(names, ", "))
+}
+
+// DetectConfigSchema guesses the configuration type of a decoded document.
+func DetectConfigSchema(doc map[string]any) (ConfigSchema, error) {
+	for _, s := range ConfigSchemas() {
+		if s.Detect != nil && s.Detect(doc) {
+			return s, nil
+		}
+	}
+	return ConfigSchema{}, fmt.Errorf("cannot detect the config type; specify it with --type")
+}
+
+// DetectConfigFileType returns the config type of the YAML file at path.
+func DetectConfigFileType(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", fmt.Errorf("%s: %w: %v", path, ErrInvalidYAML, err)
+	}
+	s, err := DetectConfigSchema(doc)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+	return s.Name, nil
+}
+
+func hasAnyKey(doc map[string]any, keys ...string) bool {
+	for _, k := range keys {
+		if _, ok := doc[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SchemaIssue is one schema violation with its location in the YAML file.
+type SchemaIssue struct {
+	// Path is the failing path, e.g. providers.github.organizations[0].visibility.
+	Path    string   `json:"path"`
+	Line    int      `json:"line,omitempty"`
+	Column  int      `json:"column,omitempty"`
+	Message string   `json:"message"`
+	Allowed []string `json:"allowed,omitempty"`
+	// Suggestion is the closest allowed key or value for likely typos.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// String formats the issue as "line:column: path: message".
+func (i SchemaIssue) String() string {
+	var b strings.Builder
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "%d:%d: ", i.Line, i.Column)
+	}
+	if i.Path != "" {
+		b.WriteString(i.Path + ": ")
+	}
+	b.WriteString(i.Message)
+	if len(i.Allowed) > 0 {
+		fmt.Fprintf(&b, " (allowed: %s)", strings.Join(i.Allowed, ", "))
+	}
+	if i.Suggestion != "" {
+		fmt.Fprintf(&b, " - did you mean %q?", i.Suggestion)
+	}
+	return b.String()
+}
+
+// SchemaValidationResult is the outcome of ValidateFileWithSchema.
+type SchemaValidationResult struct {
+	File   string        `json:"file"`
+	Type   string        `json:"type"`
+	Valid  bool          `json:"valid"`
+	Issues []SchemaIssue `json:"issues,omitempty"`
+}
+
+// ValidateFileWithSchema validates the YAML file at path. An empty
+// configType detects the type from the document.
+func ValidateFileWithSchema(path, configType string) (*SchemaValidationResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	result, err := ValidateYAMLWithSchema(data, configType)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	result.File = path
+	return result, nil
+}
+
+// ValidateYAMLWithSchema validates a YAML document. YAML syntax errors are
+// returned as issues rather than errors.
+func ValidateYAMLWithSchema(data []byte, configType string) (*SchemaValidationResult, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return &SchemaValidationResult{Type: configType, Issues: []SchemaIssue{yamlSyntaxIssue(err)}}, nil
+	}
+
+	var decoded any
+	if len(root.Content) > 0 {
+		if err := root.Decode(&decoded); err != nil {
+			return &SchemaValidationResult{Type: configType, Issues: []SchemaIssue{yamlSyntaxIssue(err)}}, nil
+		}
+	}
+	doc := jsonCompatible(decoded)
+	if doc == nil {
+		doc = map[string]any{}
+	}
+
+	var schema ConfigSchema
+	var err error
+	if configType == "" {
+		docMap, _ := doc.(map[string]any)
+		schema, err = DetectConfigSchema(docMap)
+	} else {
+		schema, err = LookupConfigSchema(configType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	schemaJSON, err := schema.Schema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s schema: %w", schema.Name, err)
+	}
+	var schemaDoc map[string]any
+	if err := json.Unmarshal(schemaJSON, &schemaDoc); err != nil {
+		return nil, fmt.Errorf("invalid %s schema: %w", schema.Name, err)
+	}
+
+	res, err := gojsonschema.Validate(gojsonschema.NewGoLoader(schemaDoc), gojsonschema.NewGoLoader(doc))
+	if err != nil {
+		return nil, fmt.Errorf("schema validation error: %w", err)
+	}
+
+	result := &SchemaValidationResult{Type: schema.Name, Valid: res.Valid()}
+	for _, e := range res.Errors() {
+		result.Issues = append(result.Issues, newSchemaIssue(e, &root, schemaDoc))
+	}
+	sort.SliceStable(result.Issues, func(i, j int) bool {
+		if result.Issues[i].Line != result.Issues[j].Line {
+			return result.Issues[i].Line < result.Issues[j].Line
+		}
+		return result.Issues[i].Column < result.Issues[j].Column
+	})
+	return result, nil
+}
+
+func yamlSyntaxIssue(err error) SchemaIssue {
+	issue := SchemaIssue{Message: "invalid YAML: " + err.Error()}
+	// yaml.v3 messages look like "yaml: line 3: ..."
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	if rest, ok := strings.CutPrefix(msg, "line "); ok {
+		if n, after, found := strings.Cut(rest, ":"); found {
+			if line, convErr := strconv.Atoi(n); convErr == nil {
+				issue.Line = line
+				issue.Message = "invalid YAML:" + after
+			}
+		}
+	}
+	return issue
+}
+
+// newSchemaIssue converts a gojsonschema error into an issue located in the
+// YAML tree, with allowed values and typo suggestions where they apply.
+func newSchemaIssue(e gojsonschema.ResultError, root *yaml.Node, schemaDoc map[string]any) SchemaIssue {
+	segments := contextSegments(e.Context())
+	issue := SchemaIssue{Path: formatSchemaPath(segments), Message: e.Description()}
+
+	node := yamlNodeAt(root, segments)
+	switch e.Type() {
+	case "additional_property_not_allowed":
+		property, _ := e.Details()["property"].(string)
+		issue.Path = formatSchemaPath(append(segments, property))
+		issue.Message = fmt.Sprintf("unknown key %q", property)
+		if key := mappingKey(node, property); key != nil {
+			node = key
+		}
+		allowed := schemaPropertyNames(schemaAt(schemaDoc, schemaDoc, segments), schemaDoc)
+		issue.Suggestion = closestMatch(property, allowed)
+	case "required":
+		property, _ := e.Details()["property"].(string)
+		issue.Message = fmt.Sprintf("missing required key %q", property)
+	case "enum":
+		if sub := schemaAt(schemaDoc, schemaDoc, segments); sub != nil {
+			for _, v := range asSlice(sub["enum"]) {
+				issue.Allowed = append(issue.Allowed, fmt.Sprint(v))
+			}
+		}
+		issue.Message = fmt.Sprintf("invalid value %v", e.Value())
+		if s, ok := e.Value().(string); ok {
+			issue.Message = fmt.Sprintf("invalid value %q", s)
+			issue.Suggestion = closestMatch(s, issue.Allowed)
+		}
+	}
+
+	if node != nil {
+		issue.Line, issue.Column = node.Line, node.Column
+	}
+	return issue
+}
+
+// contextSegments splits a gojsonschema context into path segments, without
+// the "(root)" head. A NUL delimiter keeps keys containing dots intact.
+func contextSegments(ctx *gojsonschema.JsonContext) []string {
+	if ctx == nil {
+		return nil
+	}
+	parts := strings.Split(ctx.String("\x00"), "\x00")
+	if len(parts) > 0 && parts[0] == gojsonschema.STRING_CONTEXT_ROOT {
+		parts = parts[1:]
+	}
+	return parts
+}
+
+// formatSchemaPath renders segments as a.b[0].c.
+func formatSchemaPath(segments []string) string {
+	var b strings.Builder
+	for _, s := range segments {
+		if _, err := strconv.Atoi(s); err == nil {
+			fmt.Fprintf(&b, "[%s]", s)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// yamlNodeAt returns the node at segments, or the deepest existing ancestor.
+func yamlNodeAt(root *yaml.Node, segments []string) *yaml.Node {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, seg := range segments {
+		if node.Kind == yaml.AliasNode && node.Alias != nil {
+			node = node.Alias
+		}
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			next = mappingValue(node, seg)
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(seg); err == nil && i >= 0 && i < len(node.Content) {
+				next = node.Content[i]
+			}
+		}
+		if next == nil {
+			return node
+		}
+		node = next
+	}
+	return node
+}
+
+func mappingKey(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+// schemaAt walks the schema along the document path segments.
+func schemaAt(schema, root map[string]any, segments []string) map[string]any {
+	schema = resolveSchemaRef(schema, root)
+	for _, seg := range segments {
+		if schema == nil {
+			return nil
+		}
+		var next any
+		if props, ok := schema["properties"].(map[string]any); ok && props[seg] != nil {
+			next = props[seg]
+		} else if items, ok := schema["items"]; ok {
+			next = items
+		} else if ap, ok := schema["additionalProperties"].(map[string]any); ok {
+			next = ap
+		}
+		sub, _ := next.(map[string]any)
+		schema = resolveSchemaRef(sub, root)
+	}
+	return schema
+}
+
+func resolveSchemaRef(schema, root map[string]any) map[string]any {
+	for schema != nil {
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			return schema
+		}
+		var next map[string]any
+		if name, found := strings.CutPrefix(ref, "#/definitions/"); found {
+			defs, _ := root["definitions"].(map[string]any)
+			next, _ = defs[name].(map[string]any)
+		}
+		schema = next
+	}
+	return nil
+}
+
+func schemaPropertyNames(schema, root map[string]any) []string {
+	schema = resolveSchemaRef(schema, root)
+	props, _ := schema["properties"].(map[string]any)
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func asSlice(v any) []any {
+	s, _ := v.([]any)
+	return s
+}
+
+// jsonCompatible converts YAML-decoded values into types the JSON Schema
+// validator understands, e.g. map[any]any into map[string]any.
+func jsonCompatible(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			t[k] = jsonCompatible(val)
+		}
+		return t
+	case map[any]any:
+		m := make(map[string]any, len(t))
+		for k, val := range t {
+			m[fmt.Sprint(k)] = jsonCompatible(val)
+		}
+		return m
+	case []any:
+		for i, val := range t {
+			t[i] = jsonCompatible(val)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// closestMatch returns the candidate nearest to s if it is close enough to
+// be a likely typo.
+func closestMatch(s string, candidates []string) string {
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := levenshtein(strings.ToLower(s), strings.ToLower(c))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	limit := max(2, len(s)/3)
+	if bestDist < 0 || bestDist > limit || bestDist == len(s) {
+		return ""
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
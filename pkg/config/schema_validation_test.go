@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateJSONSchema(t *testing.T) {
+	data, err := GenerateJSONSchema(UnifiedConfig{}, "gzh")
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, false, schema["additionalProperties"])
+	assert.ElementsMatch(t, []any{"version", "providers"}, schema["required"])
+
+	props := schema["properties"].(map[string]any)
+	assert.Equal(t, []any{"1.0.0"}, props["version"].(map[string]any)["enum"])
+	assert.Equal(t, float64(1), props["providers"].(map[string]any)["minProperties"])
+
+	org := schemaAt(schema, schema, []string{"providers", "github", "organizations", "0"})
+	require.NotNil(t, org)
+	assert.Equal(t, []any{"public", "private", "all"}, org["properties"].(map[string]any)["visibility"].(map[string]any)["enum"])
+
+	workers := schemaAt(schema, schema, []string{"global", "concurrency", "clone_workers"})
+	assert.Equal(t, float64(50), workers["maximum"])
+}
+
+func TestValidateYAMLWithSchema(t *testing.T) {
+	doc := `version: "1.0.0"
+providers:
+  github:
+    token: ${GITHUB_TOKEN}
+    organizations:
+      - name: acme
+        clone_dir: ~/repos/acme
+        visibilty: public
+        strategy: rebase
+global:
+  concurrency:
+    clone_workers: 100
+`
+	result, err := ValidateYAMLWithSchema([]byte(doc), "")
+	require.NoError(t, err)
+	assert.Equal(t, ConfigTypeGZH, result.Type)
+	assert.False(t, result.Valid)
+	require.Len(t, result.Issues, 3)
+
+	typo := result.Issues[0]
+	assert.Equal(t, "providers.github.organizations[0].visibilty", typo.Path)
+	assert.Equal(t, 8, typo.Line)
+	assert.Equal(t, 9, typo.Column)
+	assert.Equal(t, "visibility", typo.Suggestion)
+	assert.Contains(t, typo.String(), `8:9: providers.github.organizations[0].visibilty: unknown key "visibilty" - did you mean "visibility"?`)
+
+	enum := result.Issues[1]
+	assert.Equal(t, "providers.github.organizations[0].strategy", enum.Path)
+	assert.Equal(t, 9, enum.Line)
+	assert.Equal(t, []string{"reset", "pull", "fetch"}, enum.Allowed)
+
+	assert.Equal(t, "global.concurrency.clone_workers", result.Issues[2].Path)
+	assert.Equal(t, 12, result.Issues[2].Line)
+}
+
+func TestValidateYAMLWithSchemaTypes(t *testing.T) {
+	result, err := ValidateYAMLWithSchema([]byte("version: \"1.0.0\"\nproviders:\n  github:\n    token: x\n"), ConfigTypeGZH)
+	require.NoError(t, err)
+	assert.True(t, result.Valid, result.Issues)
+
+	result, err = ValidateYAMLWithSchema([]byte("version: \"1.0\"\nrepo_roots:\n  - root_path: ./x\n    provider: github\n    protocl: https\n    org_name: acme\n"), "")
+	require.NoError(t, err)
+	assert.Equal(t, ConfigTypeBulkClone, result.Type)
+	var messages []string
+	for _, issue := range result.Issues {
+		messages = append(messages, issue.String())
+	}
+	assert.Contains(t, messages, `5:5: repo_roots[0].protocl: unknown key "protocl" - did you mean "protocol"?`)
+	assert.Contains(t, messages, `3:5: repo_roots[0]: missing required key "protocol"`)
+
+	result, err = ValidateYAMLWithSchema([]byte("providers: [\n"), ConfigTypeGZH)
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	assert.Contains(t, result.Issues[0].Message, "invalid YAML")
+
+	_, err = ValidateYAMLWithSchema([]byte("foo: bar\n"), "")
+	assert.ErrorContains(t, err, "cannot detect")
+
+	_, err = ValidateYAMLWithSchema([]byte("foo: bar\n"), "ide")
+	assert.ErrorContains(t, err, "unknown config type")
+}
+
+func TestValidateFileWithSchemaRepoConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repo-config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("version: \"1.0.0\"\norganization: acme\ntemplats: {}\n"), 0o600))
+
+	result, err := ValidateFileWithSchema(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, ConfigTypeRepoConfig, result.Type)
+	assert.Equal(t, path, result.File)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, "templates", result.Issues[0].Suggestion)
+}
+
+func TestClosestMatch(t *testing.T) {
+	assert.Equal(t, "clone_dir", closestMatch("clonedir", []string{"name", "clone_dir", "include"}))
+	assert.Equal(t, "", closestMatch("zzz", []string{"name", "include"}))
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SecretMigrationOptions controls MigrateTokensToSecretStore.
+type SecretMigrationOptions struct {
+	// Service is the secret store service name; defaults to
+	// DefaultSecretService.
+	Service string
+	// DryRun reports what would be migrated without changing anything.
+	DryRun bool
+	// CreateBackup keeps a copy of the original file. The copy still holds
+	// the plaintext tokens and is created with owner-only permissions.
+	CreateBackup bool
+}
+
+// SecretMigrationResult lists what MigrateTokensToSecretStore did.
+type SecretMigrationResult struct {
+	Store      string
+	Migrated   []string          // providers whose token moved to the store
+	Skipped    map[string]string // provider -> reason
+	BackupPath string
+}
+
+// MigrateTokensToSecretStore moves plaintext provider tokens from the
+// configuration file at path into store and replaces them with "keyring:"
+// references. Tokens taken from environment variables and existing references
+// are left alone. The rest of the file, including comments, is preserved.
+func MigrateTokensToSecretStore(path string, store SecretStore, opts SecretMigrationOptions) (*SecretMigrationResult, error) {
+	if opts.Service == "" {
+		opts.Service = DefaultSecretService
+	}
+	result := &SecretMigrationResult{Store: store.Name(), Skipped: make(map[string]string)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidYAML, err)
+	}
+
+	tokens := providerTokenNodes(&doc)
+	names := make([]string, 0, len(tokens))
+	for name := range tokens {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		node := tokens[name]
+		switch value := node.Value; {
+		case value == "":
+			result.Skipped[name] = "no token"
+			continue
+		case IsSecretRef(value):
+			result.Skipped[name] = "already in secret store"
+			continue
+		case strings.Contains(value, "$"):
+			result.Skipped[name] = "token comes from an environment variable"
+			continue
+		}
+
+		if !opts.DryRun {
+			if err := storeAndVerify(store, opts.Service, name, node.Value); err != nil {
+				return result, fmt.Errorf("provider %s: %w", name, err)
+			}
+			node.Value = SecretRef(name)
+			node.Style = yaml.DoubleQuotedStyle
+		}
+		result.Migrated = append(result.Migrated, name)
+	}
+
+	if opts.DryRun || len(result.Migrated) == 0 {
+		return result, nil
+	}
+
+	if opts.CreateBackup {
+		backupPath := fmt.Sprintf("%s.backup.%s", path, GenerateTimestamp())
+		if err := CopyFile(path, backupPath); err != nil {
+			return result, fmt.Errorf("failed to create backup: %w", err)
+		}
+		if err := os.Chmod(backupPath, 0o600); err != nil {
+			return result, fmt.Errorf("failed to restrict backup permissions: %w", err)
+		}
+		result.BackupPath = backupPath
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return result, fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return result, err
+	}
+
+	mode := os.FileMode(0o600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(path, buf.Bytes(), mode); err != nil {
+		return result, fmt.Errorf("failed to write config file: %w", err)
+	}
+	return result, nil
+}
+
+// storeAndVerify saves the secret and reads it back, so that the plaintext
+// token is only removed from the file once the store holds it.
+func storeAndVerify(store SecretStore, service, account, secret string) error {
+	if err := store.Set(service, account, secret); err != nil {
+		return fmt.Errorf("failed to store token in %s: %w", store.Name(), err)
+	}
+	got, err := store.Get(service, account)
+	if err != nil {
+		return fmt.Errorf("failed to verify token in %s: %w", store.Name(), err)
+	}
+	if got != secret {
+		return fmt.Errorf("token read back from %s does not match", store.Name())
+	}
+	return nil
+}
+
+// providerTokenNodes returns the scalar nodes of providers.<name>.token.
+func providerTokenNodes(doc *yaml.Node) map[string]*yaml.Node {
+	tokens := make(map[string]*yaml.Node)
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	providers := mappingValue(root, "providers")
+	if providers == nil || providers.Kind != yaml.MappingNode {
+		return tokens
+	}
+	for i := 0; i+1 < len(providers.Content); i += 2 {
+		name := providers.Content[i].Value
+		if token := mappingValue(providers.Content[i+1], "token"); token != nil && token.Kind == yaml.ScalarNode {
+			tokens[name] = token
+		}
+	}
+	return tokens
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
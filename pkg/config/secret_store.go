@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Secret store backend names, as used in the secret_store.preference setting.
+const (
+	SecretStoreKeychain      = "keychain"       // macOS Keychain
+	SecretStoreWinCred       = "wincred"        // Windows Credential Manager
+	SecretStoreSecretService = "secret-service" // Linux Secret Service (GNOME Keyring, KWallet)
+)
+
+// DefaultSecretService is the service name secrets are stored under.
+const DefaultSecretService = "gzh-cli"
+
+// SecretRefPrefix marks a token value that lives in the OS secret store, e.g.
+// `token: "keyring:github"` refers to the secret stored for account "github".
+const SecretRefPrefix = "keyring:"
+
+// Secret store errors.
+var (
+	ErrSecretNotFound         = errors.New("secret not found in secret store")
+	ErrSecretStoreUnavailable = errors.New("no OS secret store is available")
+)
+
+// SecretStore stores tokens in an OS-native credential store.
+type SecretStore interface {
+	// Name returns the backend name, e.g. SecretStoreKeychain.
+	Name() string
+	// Available reports whether the backend can be used on this system.
+	Available() bool
+	// Get returns the secret for account, or ErrSecretNotFound.
+	Get(service, account string) (string, error)
+	// Set creates or replaces the secret for account.
+	Set(service, account, secret string) error
+	// Delete removes the secret for account. Deleting a missing secret is
+	// not an error.
+	Delete(service, account string) error
+}
+
+// SecretStoreSettings configures the OS secret store.
+type SecretStoreSettings struct {
+	// Preference lists backends in the order they are tried; the first
+	// available one is used. Defaults to DefaultSecretStorePreference.
+	Preference []string `yaml:"preference,omitempty" json:"preference,omitempty"`
+
+	// Service is the service name secrets are stored under.
+	Service string `yaml:"service,omitempty" json:"service,omitempty"`
+}
+
+// ServiceName returns the configured service or DefaultSecretService.
+func (s *SecretStoreSettings) ServiceName() string {
+	if s == nil || s.Service == "" {
+		return DefaultSecretService
+	}
+	return s.Service
+}
+
+// DefaultSecretStorePreference returns the native backend of the running OS
+// first, followed by the others.
+func DefaultSecretStorePreference() []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{SecretStoreKeychain, SecretStoreSecretService}
+	case "windows":
+		return []string{SecretStoreWinCred}
+	default:
+		return []string{SecretStoreSecretService, SecretStoreKeychain}
+	}
+}
+
+// newSecretStoreBackend creates the backend with the given name.
+func newSecretStoreBackend(name string) (SecretStore, error) {
+	switch name {
+	case SecretStoreKeychain:
+		return newKeychainStore(), nil
+	case SecretStoreWinCred:
+		return newWinCredStore(), nil
+	case SecretStoreSecretService:
+		return newSecretServiceStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown secret store %q (valid: %s, %s, %s)",
+			name, SecretStoreKeychain, SecretStoreWinCred, SecretStoreSecretService)
+	}
+}
+
+// NewSecretStore returns the first available backend in preference order.
+// An empty preference uses DefaultSecretStorePreference.
+func NewSecretStore(preference []string) (SecretStore, error) {
+	if len(preference) == 0 {
+		preference = DefaultSecretStorePreference()
+	}
+	for _, name := range preference {
+		store, err := newSecretStoreBackend(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		if store.Available() {
+			return store, nil
+		}
+	}
+	return nil, fmt.Errorf("%w (tried: %s)", ErrSecretStoreUnavailable, strings.Join(preference, ", "))
+}
+
+// IsSecretRef reports whether value refers to the secret store.
+func IsSecretRef(value string) bool {
+	return strings.HasPrefix(value, SecretRefPrefix)
+}
+
+// SecretRef returns the token value that refers to account.
+func SecretRef(account string) string {
+	return SecretRefPrefix + account
+}
+
+// ResolveSecret returns value unchanged unless it is a secret reference, in
+// which case the secret is read from store.
+func ResolveSecret(store SecretStore, service, value string) (string, error) {
+	if !IsSecretRef(value) {
+		return value, nil
+	}
+	account := strings.TrimPrefix(value, SecretRefPrefix)
+	if account == "" {
+		return "", fmt.Errorf("empty secret reference %q", value)
+	}
+	if store == nil {
+		return "", fmt.Errorf("%w to resolve %s", ErrSecretStoreUnavailable, value)
+	}
+	secret, err := store.Get(service, account)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s from %s: %w", value, store.Name(), err)
+	}
+	return secret, nil
+}
+
+// ResolveSecrets replaces secret references in provider tokens with the
+// stored secrets. The secret store is only opened when a reference exists;
+// store may be nil to open the one configured in cfg.
+func ResolveSecrets(cfg *UnifiedConfig, store SecretStore) error {
+	if cfg == nil {
+		return nil
+	}
+
+	var settings *SecretStoreSettings
+	if cfg.Global != nil {
+		settings = cfg.Global.SecretStore
+	}
+
+	for name, provider := range cfg.Providers {
+		if provider == nil || !IsSecretRef(provider.Token) {
+			continue
+		}
+		if store == nil {
+			var preference []string
+			if settings != nil {
+				preference = settings.Preference
+			}
+			s, err := NewSecretStore(preference)
+			if err != nil {
+				return fmt.Errorf("provider %s: %w", name, err)
+			}
+			store = s
+		}
+		token, err := ResolveSecret(store, settings.ServiceName(), provider.Token)
+		if err != nil {
+			return fmt.Errorf("provider %s: %w", name, err)
+		}
+		provider.Token = token
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// The macOS Keychain and the Linux Secret Service are driven through their
+// standard command line clients, `security` and libsecret's `secret-tool`,
+// which talk to the keychain daemon and the D-Bus Secret Service API. This
+// avoids cgo and keeps the secrets out of process arguments where the tools
+// allow it.
+
+// commandRunner runs name with args, feeding stdin, and returns stdout.
+// A non-zero exit is reported as an *exec.ExitError.
+type commandRunner func(stdin, name string, args ...string) (string, error)
+
+func runCommand(stdin, name string, args ...string) (string, error) {
+	return runCommandEnv(nil, stdin, name, args...)
+}
+
+// runCommandEnv is runCommand with env added to the process environment.
+func runCommandEnv(env []string, stdin, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitErr.Stderr = stderr.Bytes()
+		}
+		return stdout.String(), err
+	}
+	return stdout.String(), nil
+}
+
+// exitCode returns the exit status of a failed command, or -1.
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// commandStderr returns the trimmed stderr of a failed command.
+func commandStderr(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return strings.TrimSpace(string(exitErr.Stderr))
+	}
+	return ""
+}
+
+// commandError adds the tool's stderr to err.
+func commandError(tool string, err error) error {
+	if stderr := commandStderr(err); stderr != "" {
+		return fmt.Errorf("%s failed: %s", tool, stderr)
+	}
+	return fmt.Errorf("%s failed: %w", tool, err)
+}
+
+func commandAvailable(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// keychainStore uses the macOS login keychain.
+type keychainStore struct {
+	run       commandRunner
+	available func() bool
+}
+
+// keychainItemNotFound is the exit status of `security` for missing items.
+const keychainItemNotFound = 44
+
+func newKeychainStore() *keychainStore {
+	return &keychainStore{
+		run: runCommand,
+		available: func() bool {
+			return runtime.GOOS == "darwin" && commandAvailable("security")
+		},
+	}
+}
+
+func (s *keychainStore) Name() string    { return SecretStoreKeychain }
+func (s *keychainStore) Available() bool { return s.available() }
+
+func (s *keychainStore) Get(service, account string) (string, error) {
+	out, err := s.run("", "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	if err != nil {
+		if exitCode(err) == keychainItemNotFound {
+			return "", ErrSecretNotFound
+		}
+		return "", commandError("security", err)
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+// Set passes the secret through `security -i` on stdin instead of the
+// command line, where it would be visible to other users.
+func (s *keychainStore) Set(service, account, secret string) error {
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		shellQuote(service), shellQuote(account), shellQuote(secret))
+	if _, err := s.run(command, "security", "-i"); err != nil {
+		return commandError("security", err)
+	}
+	return nil
+}
+
+func (s *keychainStore) Delete(service, account string) error {
+	if _, err := s.run("", "security", "delete-generic-password", "-s", service, "-a", account); err != nil {
+		if exitCode(err) == keychainItemNotFound {
+			return nil
+		}
+		return commandError("security", err)
+	}
+	return nil
+}
+
+// secretServiceStore uses the freedesktop.org Secret Service over D-Bus.
+type secretServiceStore struct {
+	run       commandRunner
+	available func() bool
+}
+
+func newSecretServiceStore() *secretServiceStore {
+	return &secretServiceStore{
+		run: runCommand,
+		available: func() bool {
+			return runtime.GOOS != "windows" && runtime.GOOS != "darwin" && commandAvailable("secret-tool")
+		},
+	}
+}
+
+func (s *secretServiceStore) Name() string    { return SecretStoreSecretService }
+func (s *secretServiceStore) Available() bool { return s.available() }
+
+func (s *secretServiceStore) Get(service, account string) (string, error) {
+	out, err := s.run("", "secret-tool", "lookup", "service", service, "account", account)
+	if err != nil {
+		// secret-tool exits 1 silently when nothing matches
+		if exitCode(err) == 1 && out == "" && commandStderr(err) == "" {
+			return "", ErrSecretNotFound
+		}
+		return "", commandError("secret-tool", err)
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+// Set passes the secret on stdin, which secret-tool reads when it is not a
+// terminal.
+func (s *secretServiceStore) Set(service, account, secret string) error {
+	label := fmt.Sprintf("%s: %s", service, account)
+	if _, err := s.run(secret, "secret-tool", "store", "--label", label, "service", service, "account", account); err != nil {
+		return commandError("secret-tool", err)
+	}
+	return nil
+}
+
+func (s *secretServiceStore) Delete(service, account string) error {
+	if _, err := s.run("", "secret-tool", "clear", "service", service, "account", account); err != nil {
+		// Nothing to clear is reported as a silent exit status 1
+		if exitCode(err) == 1 && commandStderr(err) == "" {
+			return nil
+		}
+		return commandError("secret-tool", err)
+	}
+	return nil
+}
+
+// shellQuote quotes s for the argument parser of `security -i`.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
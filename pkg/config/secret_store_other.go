@@ -0,0 +1,26 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package config
+
+// winCredStore is only available on Windows.
+type winCredStore struct{}
+
+func newWinCredStore() *winCredStore { return &winCredStore{} }
+
+func (s *winCredStore) Name() string    { return SecretStoreWinCred }
+func (s *winCredStore) Available() bool { return false }
+
+func (s *winCredStore) Get(string, string) (string, error) {
+	return "", ErrSecretStoreUnavailable
+}
+
+func (s *winCredStore) Set(string, string, string) error {
+	return ErrSecretStoreUnavailable
+}
+
+func (s *winCredStore) Delete(string, string) error {
+	return ErrSecretStoreUnavailable
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySecretStore is an in-memory SecretStore for tests.
+type memorySecretStore struct {
+	secrets map[string]string
+}
+
+func newMemorySecretStore() *memorySecretStore {
+	return &memorySecretStore{secrets: make(map[string]string)}
+}
+
+func (s *memorySecretStore) Name() string    { return "memory" }
+func (s *memorySecretStore) Available() bool { return true }
+
+func (s *memorySecretStore) Get(service, account string) (string, error) {
+	secret, ok := s.secrets[service+"/"+account]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return secret, nil
+}
+
+func (s *memorySecretStore) Set(service, account, secret string) error {
+	s.secrets[service+"/"+account] = secret
+	return nil
+}
+
+func (s *memorySecretStore) Delete(service, account string) error {
+	delete(s.secrets, service+"/"+account)
+	return nil
+}
+
+// exitWith returns a real *exec.ExitError with the given status.
+func exitWith(t *testing.T, code int, stderr string) error {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	_, err := runCommand("", "sh", "-c", "printf '"+stderr+"' >&2; exit "+strconv.Itoa(code))
+	require.Error(t, err)
+	return err
+}
+
+type recordedCall struct {
+	stdin string
+	args  []string
+}
+
+func fakeRunner(calls *[]recordedCall, out string, err error) commandRunner {
+	return func(stdin, name string, args ...string) (string, error) {
+		*calls = append(*calls, recordedCall{stdin: stdin, args: append([]string{name}, args...)})
+		return out, err
+	}
+}
+
+func TestKeychainStore(t *testing.T) {
+	var calls []recordedCall
+	s := &keychainStore{run: fakeRunner(&calls, "tok3n\n", nil)}
+
+	secret, err := s.Get("gzh-cli", "github")
+	require.NoError(t, err)
+	assert.Equal(t, "tok3n", secret)
+	assert.Equal(t, []string{"security", "find-generic-password", "-s", "gzh-cli", "-a", "github", "-w"}, calls[0].args)
+
+	require.NoError(t, s.Set("gzh-cli", "github", "it's secret"))
+	assert.Equal(t, []string{"security", "-i"}, calls[1].args)
+	assert.Equal(t, `add-generic-password -U -s 'gzh-cli' -a 'github' -w 'it'"'"'s secret'`+"\n", calls[1].stdin)
+
+	s.run = fakeRunner(&calls, "", exitWith(t, keychainItemNotFound, ""))
+	_, err = s.Get("gzh-cli", "gitlab")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+	assert.NoError(t, s.Delete("gzh-cli", "gitlab"))
+}
+
+func TestSecretServiceStore(t *testing.T) {
+	var calls []recordedCall
+	s := &secretServiceStore{run: fakeRunner(&calls, "tok3n", nil)}
+
+	require.NoError(t, s.Set("gzh-cli", "gitlab", "tok3n"))
+	assert.Equal(t, "tok3n", calls[0].stdin, "secret must be passed on stdin")
+	assert.NotContains(t, strings.Join(calls[0].args, " "), "tok3n")
+
+	secret, err := s.Get("gzh-cli", "gitlab")
+	require.NoError(t, err)
+	assert.Equal(t, "tok3n", secret)
+
+	s.run = fakeRunner(&calls, "", exitWith(t, 1, ""))
+	_, err = s.Get("gzh-cli", "gitea")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+	assert.NoError(t, s.Delete("gzh-cli", "gitea"))
+
+	s.run = fakeRunner(&calls, "", exitWith(t, 1, "Cannot autolaunch D-Bus"))
+	_, err = s.Get("gzh-cli", "gitea")
+	assert.ErrorContains(t, err, "Cannot autolaunch D-Bus")
+}
+
+func TestNewSecretStore(t *testing.T) {
+	_, err := NewSecretStore([]string{"vault"})
+	assert.ErrorContains(t, err, "unknown secret store")
+
+	if runtime.GOOS != "windows" {
+		_, err = NewSecretStore([]string{SecretStoreWinCred})
+		assert.ErrorIs(t, err, ErrSecretStoreUnavailable)
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	store := newMemorySecretStore()
+	require.NoError(t, store.Set("team", "github", "ghp_stored"))
+
+	cfg := &UnifiedConfig{
+		Global: &GlobalSettings{SecretStore: &SecretStoreSettings{Service: "team"}},
+		Providers: map[string]*ProviderConfig{
+			"github": {Token: "keyring:github"},
+			"gitlab": {Token: "plain"},
+		},
+	}
+	require.NoError(t, ResolveSecrets(cfg, store))
+	assert.Equal(t, "ghp_stored", cfg.Providers["github"].Token)
+	assert.Equal(t, "plain", cfg.Providers["gitlab"].Token)
+
+	cfg.Providers["gitea"] = &ProviderConfig{Token: "keyring:gitea"}
+	err := ResolveSecrets(cfg, store)
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
+
+func TestMigrateTokensToSecretStore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gzh.yaml")
+	content := `version: "1.0.0"
+# tokens below
+providers:
+  github:
+    token: ghp_plaintext # personal token
+    organizations:
+      - name: acme
+        clone_dir: ~/repos/acme
+  gitlab:
+    token: ${GITLAB_TOKEN}
+    organizations:
+      - name: acme
+        clone_dir: ~/repos/gl
+  gitea:
+    token: "keyring:gitea"
+    organizations:
+      - name: acme
+        clone_dir: ~/repos/gt
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	store := newMemorySecretStore()
+
+	result, err := MigrateTokensToSecretStore(path, store, SecretMigrationOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github"}, result.Migrated)
+	data, _ := os.ReadFile(path)
+	assert.Equal(t, content, string(data))
+
+	result, err = MigrateTokensToSecretStore(path, store, SecretMigrationOptions{CreateBackup: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github"}, result.Migrated)
+	assert.Contains(t, result.Skipped["gitlab"], "environment variable")
+	assert.Contains(t, result.Skipped["gitea"], "already")
+
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "ghp_plaintext")
+	assert.Contains(t, string(data), `token: "keyring:github" # personal token`)
+	assert.Contains(t, string(data), "# tokens below")
+
+	secret, err := store.Get(DefaultSecretService, "github")
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_plaintext", secret)
+
+	info, err := os.Stat(result.BackupPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// The migrated file loads with the tokens resolved from the store.
+	require.NoError(t, store.Set(DefaultSecretService, "gitea", "gitea_stored"))
+	t.Setenv("GITLAB_TOKEN", "gl_env")
+	factory := NewConfigFactoryWithOptions(&ConfigFactoryOptions{SecretStore: store})
+	cfg, err := factory.LoadConfigFromPath(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_plaintext", cfg.Providers["github"].Token)
+	assert.Equal(t, "gitea_stored", cfg.Providers["gitea"].Token)
+}
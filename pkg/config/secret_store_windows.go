@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package config
+
+import (
+	"errors"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW  = advapi32.NewProc("CredReadW")
+	procCredWriteW = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+// credential mirrors the Win32 CREDENTIALW structure.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// winCredStore uses the Windows Credential Manager. Secrets are generic
+// credentials named "service:account".
+type winCredStore struct{}
+
+func newWinCredStore() *winCredStore { return &winCredStore{} }
+
+func (s *winCredStore) Name() string { return SecretStoreWinCred }
+
+func (s *winCredStore) Available() bool {
+	return procCredReadW.Find() == nil
+}
+
+func credTarget(service, account string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(service + ":" + account)
+}
+
+func (s *winCredStore) Get(service, account string) (string, error) {
+	target, err := credTarget(service, account)
+	if err != nil {
+		return "", err
+	}
+
+	var cred *credential
+	r, _, callErr := procCredReadW.Call(
+		uintptr(unsafe.Pointer(target)),
+		credTypeGeneric,
+		0,
+		uintptr(unsafe.Pointer(&cred)),
+	)
+	if r == 0 {
+		if errors.Is(callErr, errorNotFound) {
+			return "", ErrSecretNotFound
+		}
+		return "", callErr
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred))) //nolint:errcheck // CredFree has no failure mode
+
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+func (s *winCredStore) Set(service, account, secret string) error {
+	target, err := credTarget(service, account)
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+
+	blob := []byte(secret)
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		UserName:           user,
+		Persist:            credPersistLocalMachine,
+		CredentialBlobSize: uint32(len(blob)),
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+
+	r, _, callErr := procCredWriteW.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if r == 0 {
+		return callErr
+	}
+	return nil
+}
+
+func (s *winCredStore) Delete(service, account string) error {
+	target, err := credTarget(service, account)
+	if err != nil {
+		return err
+	}
+	r, _, callErr := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if r == 0 && !errors.Is(callErr, errorNotFound) {
+		return callErr
+	}
+	return nil
+}
@@ -143,6 +143,13 @@ func (sv *StartupValidator) validateBusinessRules(config *UnifiedConfig) {
 	if config.Global != nil {
 		sv.validateGlobalSettings(config.Global)
 	}
+
+	// Validate audit sinks
+	if config.Audit != nil && config.Audit.Enabled {
+		if err := config.Audit.Validate(); err != nil {
+			sv.addError("Audit.Sinks", "audit_sink", "", err.Error())
+		}
+	}
 }
 
 // validateConfigBusinessRules performs validation for regular Config struct.
@@ -162,10 +169,15 @@ func (sv *StartupValidator) validateConfigBusinessRules(config *Config) {
 func (sv *StartupValidator) validateProviderConfig(providerName string, provider *ProviderConfig) {
 	fieldPrefix := fmt.Sprintf("Providers[%s]", providerName)
 
-	// Validate token format
-	if provider.Token == "" {
+	// Validate authentication
+	switch {
+	case provider.App != nil:
+		if err := provider.App.Validate(); err != nil {
+			sv.addError(fieldPrefix+".App", "app", "", err.Error())
+		}
+	case provider.Token == "":
 		sv.addError(fieldPrefix+".Token", "required", "", "provider token is required")
-	} else {
+	default:
 		sv.validateTokenFormat(fieldPrefix+".Token", provider.Token)
 	}
 
@@ -414,7 +426,7 @@ func isValidStrategy(strategy string) bool {
 
 func isValidProvider(provider string) bool {
 	switch provider {
-	case ProviderGitHub, ProviderGitLab, ProviderGitea, ProviderGogs:
+	case ProviderGitHub, ProviderGitLab, ProviderGitea, ProviderGogs, ProviderGerrit:
 		return true
 	default:
 		return false
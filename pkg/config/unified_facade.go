@@ -12,6 +12,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	errors "github.com/gizzahub/gzh-cli/internal/errors"
+	"github.com/gizzahub/gzh-cli/pkg/audit"
 )
 
 // UnifiedConfigFacade provides a unified interface for configuration management.
@@ -137,6 +138,15 @@ func (f *UnifiedConfigFacade) GetSSHConfig() *SSHConfigSettings {
 	return f.config.SSHConfig
 }
 
+// GetAuditConfig returns the audit log shipping configuration.
+func (f *UnifiedConfigFacade) GetAuditConfig() *audit.Config {
+	if f.config == nil {
+		return nil
+	}
+
+	return f.config.Audit
+}
+
 // GetGlobalSettings returns the global settings.
 func (f *UnifiedConfigFacade) GetGlobalSettings() *GlobalSettings {
 	if f.config == nil {
@@ -434,6 +444,8 @@ func (f *UnifiedConfigFacade) convertToLegacyFormat(unifiedConfig *UnifiedConfig
 				CloneDir:   org.CloneDir,
 				Exclude:    org.Exclude,
 				Strategy:   org.Strategy,
+				Hooks:      unifiedConfig.OrganizationHooks(org),
+				Sparse:     unifiedConfig.OrganizationSparse(org),
 			}
 
 			if providerName == ProviderGitLab {
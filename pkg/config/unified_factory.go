@@ -22,6 +22,8 @@ type ConfigFactory struct {
 	autoMigrate   bool
 	preferUnified bool
 	createBackup  bool
+	secretStore   SecretStore // nil: opened on demand from the config
+	profile       string      // empty: taken from GZH_PROFILE
 }
 
 // NewConfigFactory creates a new configuration factory with default settings.
@@ -44,6 +46,12 @@ type ConfigFactoryOptions struct {
 	AutoMigrate   bool
 	PreferUnified bool
 	CreateBackup  bool
+	// SecretStore resolves "keyring:" token references; when nil, the
+	// store configured in global.secret_store is used.
+	SecretStore SecretStore
+	// Profile is applied over the base configuration; when empty, the
+	// GZH_PROFILE environment variable selects it.
+	Profile string
 }
 
 // NewConfigFactoryWithOptions creates a new configuration factory with custom options.
@@ -63,6 +71,8 @@ func NewConfigFactoryWithOptions(opts *ConfigFactoryOptions) *ConfigFactory {
 		factory.autoMigrate = opts.AutoMigrate
 		factory.preferUnified = opts.PreferUnified
 		factory.createBackup = opts.CreateBackup
+		factory.secretStore = opts.SecretStore
+		factory.profile = opts.Profile
 	}
 
 	return factory
@@ -83,6 +93,7 @@ func (f *ConfigFactory) LoadConfigFromPath(configPath string) (*UnifiedConfig, e
 		AutoMigrate:   f.autoMigrate,
 		PreferUnified: f.preferUnified,
 		CreateBackup:  f.createBackup,
+		Profile:       ActiveProfileName(f.profile, f.environment),
 	}
 
 	result, err := loader.LoadConfigFromPath(configPath)
@@ -99,10 +110,18 @@ func (f *ConfigFactory) LoadConfigFromPath(configPath string) (*UnifiedConfig, e
 		f.logger.Info("Required action", "message", action)
 	}
 
+	if result.Profile != "" {
+		f.logger.Debug("Applied configuration profile", "profile", result.Profile)
+	}
+
 	if result.WasMigrated {
 		f.logger.Info("Configuration was migrated", "from", result.ConfigPath, "to", result.MigrationPath)
 	}
 
+	if err := ResolveSecrets(result.Config, f.secretStore); err != nil {
+		return nil, fmt.Errorf("failed to resolve secret references: %w", err)
+	}
+
 	return result.Config, nil
 }
 
@@ -10,6 +10,7 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/gizzahub/gzh-cli/pkg/compress"
 	synclone "github.com/gizzahub/gzh-cli/pkg/synclone"
 )
 
@@ -21,6 +22,8 @@ type UnifiedLoader struct {
 	AutoMigrate   bool
 	PreferUnified bool
 	CreateBackup  bool
+	// Profile is applied over the base configuration before validation.
+	Profile string
 }
 
 // NewUnifiedLoader creates a new configuration loader.
@@ -51,6 +54,8 @@ type LoadResult struct {
 	MigrationPath   string
 	Warnings        []string
 	RequiredActions []string
+	// Profile is the profile applied to Config, if any.
+	Profile string
 }
 
 // LoadConfig loads configuration from available files.
@@ -112,18 +117,29 @@ func (l *UnifiedLoader) loadUnifiedConfig(configPath string, result *LoadResult)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
+	if data, err = DecryptConfigData(configPath, data); err != nil {
+		return nil, fmt.Errorf("failed to decrypt config file: %w", err)
+	}
 
 	var config UnifiedConfig
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal unified config: %w", err)
 	}
 
+	// Apply the profile first so that it can supply credentials the base
+	// configuration leaves out
+	effective, err := config.WithProfile(l.Profile)
+	if err != nil {
+		return nil, err
+	}
+	result.Profile = l.Profile
+
 	// Validate configuration
-	if err := l.validateUnifiedConfig(&config); err != nil {
+	if err := l.validateUnifiedConfig(effective); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
-	result.Config = &config
+	result.Config = effective
 
 	return result, nil
 }
@@ -158,6 +174,11 @@ func (l *UnifiedLoader) loadLegacyConfig(configPath string, result *LoadResult)
 			"Consider migrating to unified configuration format for better features")
 	}
 
+	if l.Profile != "" {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("profile %q ignored: profiles require the unified configuration format", l.Profile))
+	}
+
 	return result, nil
 }
 
@@ -204,6 +225,18 @@ func (l *UnifiedLoader) validateUnifiedConfig(config *UnifiedConfig) error {
 		return fmt.Errorf("at least one provider must be configured")
 	}
 
+	if config.Global != nil {
+		if err := config.Global.Hooks.Validate(); err != nil {
+			return fmt.Errorf("global hooks: %w", err)
+		}
+		if err := config.Global.Sparse.Validate(); err != nil {
+			return fmt.Errorf("global sparse profile: %w", err)
+		}
+		if err := compress.ValidateOverrides(config.Global.Compression); err != nil {
+			return fmt.Errorf("global compression: %w", err)
+		}
+	}
+
 	// Validate each provider
 	for providerName, provider := range config.Providers {
 		if err := l.validateProvider(providerName, provider); err != nil {
@@ -211,15 +244,26 @@ func (l *UnifiedLoader) validateUnifiedConfig(config *UnifiedConfig) error {
 		}
 	}
 
-	return nil
+	return config.validateWorkspaces()
 }
 
 // validateProvider validates a provider configuration.
 func (l *UnifiedLoader) validateProvider(providerName string, provider *ProviderConfig) error {
-	if provider.Token == "" {
+	if provider.App != nil {
+		if providerName != ProviderGitHub {
+			return fmt.Errorf("app authentication is only supported for GitHub, not %s", providerName)
+		}
+		if err := provider.App.Validate(); err != nil {
+			return err
+		}
+	} else if provider.Token == "" {
 		return fmt.Errorf("token is required for provider %s", providerName)
 	}
 
+	if providerName == ProviderGerrit && provider.APIURL == "" {
+		return fmt.Errorf("api_url is required for provider %s", providerName)
+	}
+
 	if len(provider.Organizations) == 0 {
 		return fmt.Errorf("at least one organization must be configured for provider %s", providerName)
 	}
@@ -254,6 +298,14 @@ func (l *UnifiedLoader) validateOrganization(org *OrganizationConfig) error {
 		return fmt.Errorf("invalid strategy %s for organization %s", org.Strategy, org.Name)
 	}
 
+	if err := org.Hooks.Validate(); err != nil {
+		return fmt.Errorf("invalid hooks for organization %s: %w", org.Name, err)
+	}
+
+	if err := org.Sparse.Validate(); err != nil {
+		return fmt.Errorf("invalid sparse profile for organization %s: %w", org.Name, err)
+	}
+
 	// Validate regex pattern
 	if org.Include != "" {
 		if _, err := CompileRegex(org.Include); err != nil {
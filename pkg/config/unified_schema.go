@@ -6,6 +6,9 @@ package config
 import (
 	"slices"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+	synclone "github.com/gizzahub/gzh-cli/pkg/synclone"
 )
 
 // UnifiedConfig represents the new unified configuration format
@@ -37,6 +40,15 @@ type UnifiedConfig struct {
 
 	// SSH configuration management
 	SSHConfig *SSHConfigSettings `yaml:"sshConfig,omitempty" json:"sshConfig,omitempty"`
+
+	// Audit log shipping to SIEM systems
+	Audit *audit.Config `yaml:"audit,omitempty" json:"audit,omitempty"`
+
+	// Named overlays selected with --profile or GZH_PROFILE
+	Profiles map[string]*ProfileConfig `yaml:"profiles,omitempty" json:"profiles,omitempty"`
+
+	// Named groups of repositories operated on by gz workspace
+	Workspaces map[string]*WorkspaceConfig `yaml:"workspaces,omitempty" json:"workspaces,omitempty"`
 }
 
 // GlobalSettings contains settings that apply across all providers.
@@ -53,11 +65,32 @@ type GlobalSettings struct {
 	// Default visibility filter
 	DefaultVisibility string `yaml:"default_visibility,omitempty" json:"defaultVisibility,omitempty" validate:"oneof=public private all"` //nolint:tagliatelle,revive // YAML compatibility and custom validation tags
 
+	// Log level of long-running commands (debug, info, warn, error); reloaded live
+	LogLevel string `yaml:"log_level,omitempty" json:"logLevel,omitempty" validate:"omitempty,oneof=debug info warn error"` //nolint:tagliatelle,revive // YAML compatibility and custom validation tags
+
+	// Language of CLI messages (en, ja, ko, zh); empty detects it from LANG
+	Locale string `yaml:"locale,omitempty" json:"locale,omitempty"`
+
 	// Timeout settings
 	Timeouts *TimeoutSettings `yaml:"timeouts,omitempty" json:"timeouts,omitempty"`
 
 	// Concurrency settings
 	Concurrency *ConcurrencySettings `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
+
+	// OS secret store used for "keyring:" token references
+	SecretStore *SecretStoreSettings `yaml:"secret_store,omitempty" json:"secretStore,omitempty"` //nolint:tagliatelle // YAML compatibility required
+
+	// Proxy, CA bundle and client certificate of every HTTP client
+	Network *NetworkSettings `yaml:"network,omitempty" json:"network,omitempty"`
+
+	// Lifecycle hooks run for repositories of every organization
+	Hooks *synclone.Hooks `yaml:"hooks,omitempty" json:"hooks,omitempty"`
+
+	// Sparse checkout of the paths a team owns, for every organization
+	Sparse *synclone.SparseProfile `yaml:"sparse,omitempty" json:"sparse,omitempty"`
+
+	// Compression codec per use (cache, bundle, archive, diagnostics, backup)
+	Compression map[string]string `yaml:"compression,omitempty" json:"compression,omitempty"`
 }
 
 // TimeoutSettings contains timeout configurations.
@@ -89,17 +122,26 @@ type ProviderConfig struct {
 	// Authentication token (supports environment variables)
 	Token string `yaml:"token,omitempty" json:"token,omitempty" ` //nolint:revive // Custom validation tag for environment token
 
-	// API endpoint URL (for self-hosted instances)
+	// API endpoint URL (for self-hosted instances; the server URL for Gerrit)
 	APIURL string `yaml:"api_url,omitempty" json:"apiUrl,omitempty" validate:"url"` //nolint:tagliatelle // YAML compatibility required
 
+	// Account name used with Token as HTTP password (Gerrit)
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+
 	// Organizations/groups to manage
 	Organizations []*OrganizationConfig `yaml:"organizations,omitempty" json:"organizations,omitempty" validate:"min=1"`
 
 	// Provider-specific settings
 	Settings *ProviderSettings `yaml:"settings,omitempty" json:"settings,omitempty"`
 
+	// GitHub App authentication used instead of Token (GitHub only)
+	App *GitHubAppConfig `yaml:"app,omitempty" json:"app,omitempty"`
+
 	// Legacy support for bulk-clone.yaml format
 	Legacy *LegacyProviderConfig `yaml:"legacy,omitempty" json:"legacy,omitempty"`
+
+	// Network settings overriding global.network for this provider
+	Network *NetworkSettings `yaml:"network,omitempty" json:"network,omitempty"`
 }
 
 // OrganizationConfig represents configuration for an organization/group.
@@ -133,6 +175,12 @@ type OrganizationConfig struct {
 
 	// Custom labels for organization
 	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	// Lifecycle hooks, run after the global hooks
+	Hooks *synclone.Hooks `yaml:"hooks,omitempty" json:"hooks,omitempty"`
+
+	// Sparse checkout profile, overriding the global one
+	Sparse *synclone.SparseProfile `yaml:"sparse,omitempty" json:"sparse,omitempty"`
 }
 
 // ProviderSettings contains provider-specific settings.
@@ -1081,3 +1129,24 @@ type SSHHostAlias struct {
 	// Additional SSH options
 	Options map[string]string `yaml:"options,omitempty" json:"options,omitempty"`
 }
+
+// OrganizationHooks returns the global hooks followed by those of org.
+func (c *UnifiedConfig) OrganizationHooks(org *OrganizationConfig) *synclone.Hooks {
+	var global *synclone.Hooks
+	if c.Global != nil {
+		global = c.Global.Hooks
+	}
+
+	return global.Merge(org.Hooks)
+}
+
+// OrganizationSparse returns the global sparse profile overridden by that
+// of org.
+func (c *UnifiedConfig) OrganizationSparse(org *OrganizationConfig) *synclone.SparseProfile {
+	var global *synclone.SparseProfile
+	if c.Global != nil {
+		global = c.Global.Sparse
+	}
+
+	return global.Merge(org.Sparse)
+}
@@ -14,8 +14,12 @@ func CreateDirectory(dir string) error {
 	return os.MkdirAll(dir, 0o750)
 }
 
-// WriteFile writes content to a file.
+// WriteFile writes content to a file. Content holding a secret decrypted
+// from an encrypted configuration is refused.
 func WriteFile(filename, content string) error {
+	if err := CheckNoPlaintextSecrets([]byte(content)); err != nil {
+		return err
+	}
 	return os.WriteFile(filename, []byte(content), 0o600)
 }
 
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/git/repofilter"
 )
 
 // ConfigValidator provides configuration validation functionality.
@@ -91,7 +93,7 @@ func (v *ConfigValidator) validateDefaultProvider(provider string) {
 		return // Optional field
 	}
 
-	validProviders := []string{ProviderGitHub, ProviderGitLab, ProviderGitea}
+	validProviders := []string{ProviderGitHub, ProviderGitLab, ProviderGitea, ProviderGerrit}
 	if !contains(validProviders, provider) {
 		v.addError(fmt.Sprintf("invalid default_provider '%s', must be one of: %s",
 			provider, strings.Join(validProviders, ", ")))
@@ -108,12 +110,16 @@ func (v *ConfigValidator) validateProviders(providers map[string]Provider) {
 // validateProvider validates a single provider configuration.
 func (v *ConfigValidator) validateProvider(name string, provider Provider) {
 	// Validate provider name
-	validProviders := []string{ProviderGitHub, ProviderGitLab, ProviderGitea}
+	validProviders := []string{ProviderGitHub, ProviderGitLab, ProviderGitea, ProviderGerrit}
 	if !contains(validProviders, name) {
 		v.addError(fmt.Sprintf("invalid provider name '%s', must be one of: %s",
 			name, strings.Join(validProviders, ", ")))
 	}
 
+	if name == ProviderGerrit && provider.URL == "" {
+		v.addError(fmt.Sprintf("provider '%s': missing required field 'url'", name))
+	}
+
 	// Validate token
 	if provider.Token == "" {
 		v.addError(fmt.Sprintf("provider '%s': missing required field 'token'", name))
@@ -209,6 +215,13 @@ func (v *ConfigValidator) validateGitTarget(path string, target GitTarget) {
 		}
 	}
 
+	// Validate filter expression
+	if target.Filter != "" {
+		if _, err := repofilter.Parse(target.Filter); err != nil {
+			v.addError(fmt.Sprintf("%s: %v", path, err))
+		}
+	}
+
 	// Validate clone directory
 	if target.CloneDir != "" {
 		if strings.Contains(target.CloneDir, "..") {
@@ -0,0 +1,345 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Workspace sync strategies for repositories that are already cloned.
+const (
+	WorkspaceStrategyPull  = "pull"
+	WorkspaceStrategyFetch = "fetch"
+	WorkspaceStrategyReset = "reset"
+)
+
+// WorkspaceConfig is a named group of repositories, possibly hosted on
+// different providers, that is cloned, synced and inspected as one unit.
+type WorkspaceConfig struct {
+	// Human readable purpose, shown by gz workspace list
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Directory the repositories are cloned into; defaults to <clone_base_dir>/<name>
+	Root string `yaml:"root,omitempty" json:"root,omitempty"`
+
+	// How existing clones are updated: pull (default), fetch or reset
+	Strategy string `yaml:"strategy,omitempty" json:"strategy,omitempty" validate:"omitempty,oneof=pull fetch reset"` //nolint:revive // Custom validation tags are valid
+
+	// Protocol of derived clone URLs: https (default) or ssh
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty" validate:"omitempty,oneof=https ssh"` //nolint:revive // Custom validation tags are valid
+
+	// Repositories processed in parallel; defaults to the number of CPUs
+	Concurrency int `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
+
+	// Repositories of the workspace
+	Repos []WorkspaceRepo `yaml:"repos" json:"repos"`
+
+	// Saved filters narrowing the repositories operated on
+	Filters *WorkspaceFilters `yaml:"filters,omitempty" json:"filters,omitempty"`
+}
+
+// WorkspaceRepo is one repository of a workspace.
+type WorkspaceRepo struct {
+	// Provider hosting the repository (github, gitlab, gitea, gogs, gerrit)
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty"`
+
+	// Repository as <owner>/<name>; derived from URL when empty
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+
+	// Clone URL; derived from provider and name for github and gitlab
+	URL string `yaml:"url,omitempty" json:"url,omitempty"`
+
+	// Clone directory relative to the workspace root; defaults to Name
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+
+	// Branch checked out by the initial clone; the remote default when empty
+	Branch string `yaml:"branch,omitempty" json:"branch,omitempty"`
+
+	// Free-form tags used by filters, e.g. backend or frontend
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// WorkspaceFilters select repositories by name and tag. Include and Exclude
+// are glob patterns matched against <owner>/<name> and against <name>.
+type WorkspaceFilters struct {
+	Include []string `yaml:"include,omitempty" json:"include,omitempty"`
+	Exclude []string `yaml:"exclude,omitempty" json:"exclude,omitempty"`
+	// Only repositories with at least one of these tags
+	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// providerHosts are the hosts clone URLs are derived for.
+var providerHosts = map[string]string{
+	ProviderGitHub: "github.com",
+	ProviderGitLab: "gitlab.com",
+}
+
+// Validate checks the workspace for repositories that cannot be cloned.
+func (w *WorkspaceConfig) Validate() error {
+	switch w.Strategy {
+	case "", WorkspaceStrategyPull, WorkspaceStrategyFetch, WorkspaceStrategyReset:
+	default:
+		return fmt.Errorf("invalid strategy %q (valid: pull, fetch, reset)", w.Strategy)
+	}
+	switch w.Protocol {
+	case "", "https", "ssh":
+	default:
+		return fmt.Errorf("invalid protocol %q (valid: https, ssh)", w.Protocol)
+	}
+	if w.Concurrency < 0 {
+		return fmt.Errorf("concurrency must not be negative")
+	}
+	if len(w.Repos) == 0 {
+		return fmt.Errorf("at least one repository is required")
+	}
+	if err := w.Filters.validate(); err != nil {
+		return err
+	}
+
+	paths := make(map[string]string, len(w.Repos))
+	for i, repo := range w.Repos {
+		name := repo.name()
+		if name == "" {
+			return fmt.Errorf("repository %d: name or url is required", i+1)
+		}
+		if repo.URL == "" {
+			if _, ok := providerHosts[repo.Provider]; !ok {
+				return fmt.Errorf("repository %s: url is required for provider %q", name, repo.Provider)
+			}
+			if strings.Count(repo.Name, "/") != 1 {
+				return fmt.Errorf("repository %s: name must be <owner>/<name>", name)
+			}
+		}
+		dir := filepath.Clean(filepath.FromSlash(repo.path()))
+		if filepath.IsAbs(dir) || dir == "." || strings.HasPrefix(dir, "..") {
+			return fmt.Errorf("repository %s: path must be relative to the workspace root", name)
+		}
+		if other, ok := paths[dir]; ok {
+			return fmt.Errorf("repositories %s and %s use the same path %s", other, name, dir)
+		}
+		paths[dir] = name
+	}
+	return nil
+}
+
+func (f *WorkspaceFilters) validate() error {
+	if f == nil {
+		return nil
+	}
+	for _, pattern := range slices.Concat(f.Include, f.Exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid filter pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// name returns Name or the <owner>/<name> part of URL.
+func (r WorkspaceRepo) name() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	u := strings.TrimSuffix(strings.TrimRight(r.URL, "/"), ".git")
+	// git@host:owner/name uses a colon before the path
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+	} else if i := strings.Index(u, ":"); i >= 0 {
+		u = strings.Replace(u, ":", "/", 1)
+	}
+	parts := strings.Split(u, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.Join(parts[len(parts)-2:], "/")
+}
+
+func (r WorkspaceRepo) path() string {
+	if r.Path != "" {
+		return r.Path
+	}
+	return r.name()
+}
+
+func (r WorkspaceRepo) cloneURL(protocol string) string {
+	if r.URL != "" {
+		return r.URL
+	}
+	host := providerHosts[r.Provider]
+	if protocol == "ssh" {
+		return fmt.Sprintf("git@%s:%s.git", host, r.Name)
+	}
+	return fmt.Sprintf("https://%s/%s.git", host, r.Name)
+}
+
+// Matches reports whether repo passes the filters. A nil filter matches
+// every repository.
+func (f *WorkspaceFilters) Matches(repo WorkspaceRepo) bool {
+	if f == nil {
+		return true
+	}
+	name := repo.name()
+	if len(f.Include) > 0 && !matchAny(f.Include, name) {
+		return false
+	}
+	if matchAny(f.Exclude, name) {
+		return false
+	}
+	if len(f.Tags) > 0 && !slices.ContainsFunc(repo.Tags, func(tag string) bool { return slices.Contains(f.Tags, tag) }) {
+		return false
+	}
+	return true
+}
+
+func matchAny(patterns []string, name string) bool {
+	base := path.Base(name)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkspaceTarget is a repository of a resolved workspace.
+type WorkspaceTarget struct {
+	Name     string   `json:"name"`
+	Provider string   `json:"provider,omitempty"`
+	URL      string   `json:"url"`
+	Path     string   `json:"path"` // absolute clone directory
+	Branch   string   `json:"branch,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// ResolvedWorkspace is a workspace with defaults applied and its filters
+// evaluated.
+type ResolvedWorkspace struct {
+	Name        string            `json:"name"`
+	Root        string            `json:"root"`
+	Strategy    string            `json:"strategy"`
+	Concurrency int               `json:"concurrency,omitempty"`
+	Targets     []WorkspaceTarget `json:"targets"`
+}
+
+// WorkspaceNames returns the configured workspace names in sorted order.
+func (c *UnifiedConfig) WorkspaceNames() []string {
+	names := make([]string, 0, len(c.Workspaces))
+	for name := range c.Workspaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Workspace returns the workspace called name.
+func (c *UnifiedConfig) Workspace(name string) (*WorkspaceConfig, error) {
+	w, ok := c.Workspaces[name]
+	if !ok || w == nil {
+		return nil, fmt.Errorf("unknown workspace %q (available: %s)", name, strings.Join(c.WorkspaceNames(), ", "))
+	}
+	return w, nil
+}
+
+// WorkspaceRoot returns the absolute clone directory of workspace name.
+func (c *UnifiedConfig) WorkspaceRoot(name string) (string, error) {
+	w, err := c.Workspace(name)
+	if err != nil {
+		return "", err
+	}
+	root := w.Root
+	if root == "" {
+		base := "$HOME/repos"
+		if c.Global != nil && c.Global.CloneBaseDir != "" {
+			base = c.Global.CloneBaseDir
+		}
+		root = filepath.Join(base, name)
+	}
+	return expandPath(os.ExpandEnv(root)), nil
+}
+
+// ResolveWorkspace applies defaults to workspace name and returns the
+// repositories that pass both its saved filters and extra. extra may be nil.
+func (c *UnifiedConfig) ResolveWorkspace(name string, extra *WorkspaceFilters) (*ResolvedWorkspace, error) {
+	w, err := c.Workspace(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := extra.validate(); err != nil {
+		return nil, err
+	}
+	root, err := c.WorkspaceRoot(name)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := &ResolvedWorkspace{
+		Name:        name,
+		Root:        root,
+		Strategy:    w.Strategy,
+		Concurrency: w.Concurrency,
+	}
+	if resolved.Strategy == "" {
+		resolved.Strategy = WorkspaceStrategyPull
+	}
+	for _, repo := range w.Repos {
+		if !w.Filters.Matches(repo) || !extra.Matches(repo) {
+			continue
+		}
+		resolved.Targets = append(resolved.Targets, WorkspaceTarget{
+			Name:     repo.name(),
+			Provider: repo.Provider,
+			URL:      repo.cloneURL(w.Protocol),
+			Path:     filepath.Join(root, filepath.FromSlash(repo.path())),
+			Branch:   repo.Branch,
+			Tags:     repo.Tags,
+		})
+	}
+	return resolved, nil
+}
+
+// validateWorkspaces validates every workspace of c.
+func (c *UnifiedConfig) validateWorkspaces() error {
+	for _, name := range c.WorkspaceNames() {
+		w := c.Workspaces[name]
+		if w == nil {
+			return fmt.Errorf("workspace %s: empty definition", name)
+		}
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("workspace %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ReadWorkspaces reads the workspaces of a config file with profile applied.
+// Unlike the full loader it does not require provider credentials, which
+// workspace operations do not use.
+func ReadWorkspaces(path, profile string) (*UnifiedConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var cfg UnifiedConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal unified config: %w", err)
+	}
+	effective, err := cfg.WithProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	if err := effective.validateWorkspaces(); err != nil {
+		return nil, err
+	}
+	return effective, nil
+}
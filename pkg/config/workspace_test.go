@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const workspaceYAML = `version: "1.0.0"
+global:
+  clone_base_dir: /srv/repos
+providers:
+  github:
+    token: ${GITHUB_TOKEN}
+    organizations:
+      - name: acme
+workspaces:
+  teamA:
+    description: Team A services
+    protocol: ssh
+    repos:
+      - {provider: github, name: acme/api, tags: [backend]}
+      - {provider: gitlab, name: acme/web, tags: [frontend]}
+      - {url: https://git.example.com/tools/ci-archive.git, path: tools/ci}
+    filters:
+      exclude: ["*-archive"]
+  teamB:
+    root: /work/b
+    strategy: reset
+    repos:
+      - {url: "git@git.example.com:b/svc.git", branch: develop}
+profiles:
+  laptop:
+    global:
+      clone_base_dir: /home/me/src
+`
+
+func TestResolveWorkspace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(workspaceYAML), 0o600))
+
+	cfg, err := ReadWorkspaces(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"teamA", "teamB"}, cfg.WorkspaceNames())
+
+	ws, err := cfg.ResolveWorkspace("teamA", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/srv/repos/teamA", ws.Root)
+	assert.Equal(t, WorkspaceStrategyPull, ws.Strategy)
+	require.Len(t, ws.Targets, 2, "the saved filter excludes the archive")
+	assert.Equal(t, WorkspaceTarget{
+		Name: "acme/api", Provider: "github", URL: "git@github.com:acme/api.git",
+		Path: "/srv/repos/teamA/acme/api", Tags: []string{"backend"},
+	}, ws.Targets[0])
+	assert.Equal(t, "git@gitlab.com:acme/web.git", ws.Targets[1].URL)
+
+	// 명령행 필터는 저장된 필터와 함께 적용된다
+	ws, err = cfg.ResolveWorkspace("teamA", &WorkspaceFilters{Tags: []string{"frontend"}})
+	require.NoError(t, err)
+	require.Len(t, ws.Targets, 1)
+	assert.Equal(t, "acme/web", ws.Targets[0].Name)
+
+	ws, err = cfg.ResolveWorkspace("teamB", &WorkspaceFilters{Include: []string{"svc"}})
+	require.NoError(t, err)
+	assert.Equal(t, WorkspaceStrategyReset, ws.Strategy)
+	require.Len(t, ws.Targets, 1)
+	assert.Equal(t, WorkspaceTarget{Name: "b/svc", URL: "git@git.example.com:b/svc.git", Path: "/work/b/b/svc", Branch: "develop"}, ws.Targets[0])
+
+	_, err = cfg.ResolveWorkspace("teamC", nil)
+	assert.EqualError(t, err, `unknown workspace "teamC" (available: teamA, teamB)`)
+
+	// The profile's clone_base_dir moves workspaces without an explicit root.
+	cfg, err = ReadWorkspaces(path, "laptop")
+	require.NoError(t, err)
+	root, err := cfg.WorkspaceRoot("teamA")
+	require.NoError(t, err)
+	assert.Equal(t, "/home/me/src/teamA", root)
+}
+
+func TestWorkspaceValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		ws   WorkspaceConfig
+		err  string
+	}{
+		{"no repos", WorkspaceConfig{}, "at least one repository is required"},
+		{"strategy", WorkspaceConfig{Strategy: "merge", Repos: []WorkspaceRepo{{URL: "https://h/o/r"}}}, `invalid strategy "merge"`},
+		{"no url", WorkspaceConfig{Repos: []WorkspaceRepo{{Provider: "gitea", Name: "o/r"}}}, `repository o/r: url is required for provider "gitea"`},
+		{"no name", WorkspaceConfig{Repos: []WorkspaceRepo{{Provider: "github"}}}, "repository 1: name or url is required"},
+		{"escaping path", WorkspaceConfig{Repos: []WorkspaceRepo{{URL: "https://h/o/r", Path: "../r"}}}, "path must be relative"},
+		{"same path", WorkspaceConfig{Repos: []WorkspaceRepo{{URL: "https://a/o/r"}, {URL: "https://b/o/r"}}}, "use the same path o/r"},
+		{"pattern", WorkspaceConfig{Repos: []WorkspaceRepo{{URL: "https://h/o/r"}}, Filters: &WorkspaceFilters{Include: []string{"["}}}, "invalid filter pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.ws.Validate(), tt.err)
+		})
+	}
+
+	valid := WorkspaceConfig{Repos: []WorkspaceRepo{{Provider: "github", Name: "acme/api"}, {URL: "https://h/o/r.git"}}}
+	assert.NoError(t, valid.Validate())
+}
+
+func TestUnifiedLoaderValidatesWorkspaces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gzh.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`version: "1.0.0"
+providers:
+  github:
+    token: token
+    organizations:
+      - name: acme
+        clone_dir: /tmp/acme
+workspaces:
+  broken:
+    repos: []
+`), 0o600))
+
+	_, err := NewUnifiedLoader().LoadConfigFromPath(path)
+	assert.ErrorContains(t, err, "workspace broken: at least one repository is required")
+}
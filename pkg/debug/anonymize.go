@@ -0,0 +1,557 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// minKnownLength is the shortest learned field value that is also replaced
+// in free text; shorter values would rewrite unrelated words.
+const minKnownLength = 3
+
+// AnonymizeConfig customizes the anonymizer.
+//
+//	salt: shared-by-the-team   # same salt, same placeholders across bundles
+//	keep: [git.example.com]
+//	rules:
+//	  - name: internal-host
+//	    category: host
+//	    regex: '[a-z0-9-]+\.corp\.example\.com'
+//	  - name: jira-project
+//	    category: project
+//	    fields: [project, jira.key]
+type AnonymizeConfig struct {
+	// Salt keys the placeholders. Without one a random salt is used, so
+	// placeholders are consistent within a bundle but not across bundles.
+	Salt string `yaml:"salt"`
+	// DisableDefaults drops the built-in rules.
+	DisableDefaults bool `yaml:"disable_defaults"`
+	// Keep lists values that are never replaced, compared case-insensitively.
+	Keep  []string              `yaml:"keep"`
+	Rules []AnonymizeRuleConfig `yaml:"rules"`
+}
+
+// AnonymizeRuleConfig declares a custom rule. A rule needs a regex, fields
+// or both.
+type AnonymizeRuleConfig struct {
+	Name     string   `yaml:"name"`
+	Category string   `yaml:"category"`
+	Regex    string   `yaml:"regex"`
+	Group    int      `yaml:"group"`
+	Fields   []string `yaml:"fields"`
+}
+
+// AnonymizeRule finds one kind of sensitive value.
+type AnonymizeRule struct {
+	Name string
+	// Category prefixes the placeholders, e.g. "host" gives host-1a2b3c4d.
+	Category string
+	// Regex matches the value in free text. Group selects the capture group
+	// holding the value; zero replaces the whole match.
+	Regex *regexp.Regexp
+	Group int
+	// Fields are lower-case keys of structured documents (JSON and YAML)
+	// whose values are replaced. A dotted entry such as
+	// "organizations.name" matches the end of the key path; list indices
+	// are not part of the path.
+	Fields []string
+}
+
+// DefaultAnonymizeRules returns the built-in rules. Tokens and credentials
+// come first so that the URL and e-mail rules never see them, and URL hosts
+// before e-mail addresses so user@host in a URL is taken as a host.
+func DefaultAnonymizeRules() []*AnonymizeRule {
+	return []*AnonymizeRule{
+		{
+			Name:     "github-token",
+			Category: "token",
+			Regex:    regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`),
+		},
+		{
+			Name:     "gitlab-token",
+			Category: "token",
+			Regex:    regexp.MustCompile(`\bgl(?:pat|dt|rt|ptt|oas)-[A-Za-z0-9_-]{20,}`),
+		},
+		{
+			Name:     "slack-token",
+			Category: "token",
+			Regex:    regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`),
+		},
+		{
+			Name:     "aws-access-key",
+			Category: "token",
+			Regex:    regexp.MustCompile(`\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`),
+		},
+		{
+			Name:     "bearer-token",
+			Category: "token",
+			Regex:    regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9._~+/=-]{8,})`),
+			Group:    1,
+		},
+		{
+			Name:     "url-credentials",
+			Category: "credential",
+			Regex:    regexp.MustCompile(`://([^/@\s"']+)@`),
+			Group:    1,
+		},
+		{
+			Name:     "assigned-secret",
+			Category: "secret",
+			Regex:    regexp.MustCompile(`(?i)\b(?:token|password|passwd|secret|api[_-]?key)\s*[=:]\s*["']?([^\s"',;&]{4,})`),
+			Group:    1,
+			Fields: []string{
+				"token", "password", "passwd", "secret", "client_secret", "webhook_secret",
+				"api_key", "apikey", "private_key", "access_key", "secret_key",
+			},
+		},
+		{
+			Name:     "ssh-host",
+			Category: "host",
+			Regex:    regexp.MustCompile(`\b[\w.-]+@([A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}):`),
+			Group:    1,
+		},
+		{
+			Name:     "url-host",
+			Category: "host",
+			Regex:    regexp.MustCompile(`(?i)\b[a-z][a-z0-9+.-]*://(?:[^/@\s]*@)?([a-z0-9-]+(?:\.[a-z0-9-]+)+)`),
+			Group:    1,
+			Fields:   []string{"host", "hostname"},
+		},
+		{
+			Name:     "email",
+			Category: "email",
+			Regex:    regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`),
+			Fields:   []string{"email", "mail"},
+		},
+		{
+			Name:     "ipv4",
+			Category: "ip",
+			Regex:    regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`),
+		},
+		{
+			Name:     "organization",
+			Category: "org",
+			Fields:   []string{"org", "organization", "organizations.name", "owner", "group", "groups.name", "namespace"},
+		},
+		{
+			Name:     "user",
+			Category: "user",
+			Fields:   []string{"user", "username", "login", "author"},
+		},
+	}
+}
+
+// defaultKeep are public values that carry no information about the user.
+var defaultKeep = []string{
+	"github.com", "api.github.com", "raw.githubusercontent.com",
+	"gitlab.com", "gitea.com", "codeberg.org",
+	"localhost", "127.0.0.1", "0.0.0.0",
+}
+
+var placeholderPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*-[0-9a-f]{8}$`)
+
+// Anonymizer replaces sensitive values with placeholders. The same value
+// always gets the same placeholder: the placeholder is a keyed hash of the
+// category and the value. It is safe for concurrent use.
+type Anonymizer struct {
+	rules []*AnonymizeRule
+	key   []byte
+	keep  map[string]bool
+
+	mu           sync.Mutex
+	originals    map[string]string // placeholder → original
+	counts       map[string]int    // category → distinct values
+	known        map[string]string // lower-cased learned value → category
+	knownPattern *regexp.Regexp
+}
+
+// LoadAnonymizeConfig reads an anonymizer config file.
+func LoadAnonymizeConfig(path string) (*AnonymizeConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read anonymizer config %s: %w", path, err)
+	}
+	var cfg AnonymizeConfig
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid anonymizer config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// NewAnonymizer creates an anonymizer from cfg, which may be nil for the
+// built-in rules with a random salt. Custom rules run after the built-in
+// ones.
+func NewAnonymizer(cfg *AnonymizeConfig) (*Anonymizer, error) {
+	if cfg == nil {
+		cfg = &AnonymizeConfig{}
+	}
+
+	a := &Anonymizer{
+		keep:      make(map[string]bool),
+		originals: make(map[string]string),
+		counts:    make(map[string]int),
+		known:     make(map[string]string),
+	}
+	if cfg.Salt != "" {
+		a.key = []byte(cfg.Salt)
+	} else {
+		a.key = make([]byte, 32)
+		if _, err := rand.Read(a.key); err != nil {
+			return nil, fmt.Errorf("failed to generate salt: %w", err)
+		}
+	}
+
+	if !cfg.DisableDefaults {
+		a.rules = DefaultAnonymizeRules()
+		for _, v := range defaultKeep {
+			a.keep[v] = true
+		}
+	}
+	for _, v := range cfg.Keep {
+		a.keep[strings.ToLower(v)] = true
+	}
+	for _, rc := range cfg.Rules {
+		rule, err := rc.compile()
+		if err != nil {
+			return nil, err
+		}
+		a.rules = append(a.rules, rule)
+	}
+	return a, nil
+}
+
+func (rc AnonymizeRuleConfig) compile() (*AnonymizeRule, error) {
+	if rc.Name == "" {
+		return nil, fmt.Errorf("anonymizer rule without a name")
+	}
+	if rc.Regex == "" && len(rc.Fields) == 0 {
+		return nil, fmt.Errorf("anonymizer rule %s: needs a regex or fields", rc.Name)
+	}
+	rule := &AnonymizeRule{Name: rc.Name, Category: rc.Category, Group: rc.Group}
+	if rule.Category == "" {
+		rule.Category = "value"
+	}
+	if !regexp.MustCompile(`^[a-z][a-z0-9_]*$`).MatchString(rule.Category) {
+		return nil, fmt.Errorf("anonymizer rule %s: category %q must be lower-case letters, digits or _", rc.Name, rule.Category)
+	}
+	if rc.Regex != "" {
+		re, err := regexp.Compile(rc.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("anonymizer rule %s: invalid regex: %w", rc.Name, err)
+		}
+		if rc.Group < 0 || rc.Group > re.NumSubexp() {
+			return nil, fmt.Errorf("anonymizer rule %s: regex has no group %d", rc.Name, rc.Group)
+		}
+		rule.Regex = re
+	}
+	for _, f := range rc.Fields {
+		rule.Fields = append(rule.Fields, strings.ToLower(f))
+	}
+	return rule, nil
+}
+
+// Pseudonym returns the placeholder for value, e.g. org-1a2b3c4d. Values
+// are compared case-insensitively.
+func (a *Anonymizer) Pseudonym(category, value string) string {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(category + "\x00" + strings.ToLower(value)))
+	placeholder := category + "-" + hex.EncodeToString(mac.Sum(nil))[:8]
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.originals[placeholder]; !ok {
+		a.originals[placeholder] = value
+		a.counts[category]++
+	}
+	return placeholder
+}
+
+// IsPlaceholder reports whether s is a placeholder issued by a.
+func (a *Anonymizer) IsPlaceholder(s string) bool {
+	if !placeholderPattern.MatchString(s) {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.originals[s]
+	return ok
+}
+
+// Mapping returns placeholder → original for every replaced value, for the
+// user to decode replies about the bundle. It must not be shared.
+func (a *Anonymizer) Mapping() map[string]string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make(map[string]string, len(a.originals))
+	for k, v := range a.originals {
+		out[k] = v
+	}
+	return out
+}
+
+// Summary returns the number of distinct values replaced per category.
+func (a *Anonymizer) Summary() map[string]int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make(map[string]int, len(a.counts))
+	for k, v := range a.counts {
+		out[k] = v
+	}
+	return out
+}
+
+func (a *Anonymizer) kept(value string) bool {
+	return a.keep[strings.ToLower(value)]
+}
+
+// Text replaces sensitive values in free text: first the regex rules, then
+// every value learned from structured fields.
+func (a *Anonymizer) Text(s string) string {
+	for _, r := range a.rules {
+		if r.Regex != nil {
+			s = a.replaceRule(r, s)
+		}
+	}
+	return a.replaceKnown(s)
+}
+
+func (a *Anonymizer) replaceRule(r *AnonymizeRule, s string) string {
+	matches := r.Regex.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[2*r.Group], m[2*r.Group+1]
+		if start < 0 {
+			continue
+		}
+		value := s[start:end]
+		if a.kept(value) || a.IsPlaceholder(value) {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(a.Pseudonym(r.Category, value))
+		last = end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// learn records a field value so it is also replaced in free text.
+func (a *Anonymizer) learn(category, value string) {
+	if len(value) < minKnownLength || a.kept(value) {
+		return
+	}
+	lower := strings.ToLower(value)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.known[lower]; ok {
+		return
+	}
+	a.known[lower] = category
+	a.knownPattern = nil
+}
+
+// knownRegexp returns an alternation of the learned values, longest first.
+func (a *Anonymizer) knownRegexp() *regexp.Regexp {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.knownPattern != nil || len(a.known) == 0 {
+		return a.knownPattern
+	}
+	values := make([]string, 0, len(a.known))
+	for v := range a.known {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+	for i, v := range values {
+		values[i] = regexp.QuoteMeta(v)
+	}
+	a.knownPattern = regexp.MustCompile(`(?i)` + strings.Join(values, "|"))
+	return a.knownPattern
+}
+
+func (a *Anonymizer) replaceKnown(s string) string {
+	re := a.knownRegexp()
+	if re == nil {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringIndex(s, -1) {
+		if !wordBoundary(s, m[0], m[1]) {
+			continue
+		}
+		value := s[m[0]:m[1]]
+		a.mu.Lock()
+		category := a.known[strings.ToLower(value)]
+		a.mu.Unlock()
+		b.WriteString(s[last:m[0]])
+		b.WriteString(a.Pseudonym(category, value))
+		last = m[1]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// findKnown returns the learned values still present in s.
+func (a *Anonymizer) findKnown(s string) []string {
+	re := a.knownRegexp()
+	if re == nil {
+		return nil
+	}
+	var found []string
+	for _, m := range re.FindAllStringIndex(s, -1) {
+		if wordBoundary(s, m[0], m[1]) {
+			found = append(found, s[m[0]:m[1]])
+		}
+	}
+	return found
+}
+
+// wordBoundary reports whether s[start:end] is not part of a longer word.
+func wordBoundary(s string, start, end int) bool {
+	return (start == 0 || !isWordByte(s[start-1])) && (end == len(s) || !isWordByte(s[end]))
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// fieldRule returns the rule whose fields match the key path, or nil.
+func (a *Anonymizer) fieldRule(path []string) *AnonymizeRule {
+	if len(path) == 0 {
+		return nil
+	}
+	joined := strings.ToLower(strings.Join(path, "."))
+	for _, r := range a.rules {
+		for _, f := range r.Fields {
+			if joined == f || strings.HasSuffix(joined, "."+f) {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+// Learn collects the values of sensitive fields in a decoded JSON document
+// without changing it, so that a later Text or Value call also replaces
+// them where they appear in free text.
+func (a *Anonymizer) Learn(v any) {
+	a.walk(v, nil, true)
+}
+
+// Value anonymizes a decoded JSON document: values of sensitive fields are
+// replaced as a whole and every other string goes through Text.
+func (a *Anonymizer) Value(v any) any {
+	return a.walk(v, nil, false)
+}
+
+func (a *Anonymizer) walk(v any, path []string, learnOnly bool) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, child := range val {
+			key := k
+			if !learnOnly {
+				key = a.Text(k)
+			}
+			out[key] = a.walk(child, append(path[:len(path):len(path)], k), learnOnly)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, child := range val {
+			out[i] = a.walk(child, path, learnOnly)
+		}
+		return out
+	case string:
+		if r := a.fieldRule(path); r != nil && val != "" {
+			return a.field(r, val, learnOnly)
+		}
+		if learnOnly {
+			return val
+		}
+		return a.Text(val)
+	default:
+		return v
+	}
+}
+
+func (a *Anonymizer) field(r *AnonymizeRule, value string, learnOnly bool) string {
+	if a.kept(value) || a.IsPlaceholder(value) {
+		return value
+	}
+	a.learn(r.Category, value)
+	if learnOnly {
+		return value
+	}
+	return a.Pseudonym(r.Category, value)
+}
+
+// LearnYAML is Learn for a YAML document.
+func (a *Anonymizer) LearnYAML(node *yaml.Node) {
+	a.walkYAML(node, nil, true)
+}
+
+// YAML anonymizes a YAML document in place, including its comments.
+func (a *Anonymizer) YAML(node *yaml.Node) {
+	a.walkYAML(node, nil, false)
+}
+
+func (a *Anonymizer) walkYAML(node *yaml.Node, path []string, learnOnly bool) {
+	if node == nil {
+		return
+	}
+	if !learnOnly {
+		node.HeadComment = a.Text(node.HeadComment)
+		node.LineComment = a.Text(node.LineComment)
+		node.FootComment = a.Text(node.FootComment)
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			a.walkYAML(child, path, learnOnly)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			childPath := append(path[:len(path):len(path)], key.Value)
+			a.walkYAML(key, nil, learnOnly)
+			a.walkYAML(value, childPath, learnOnly)
+		}
+	case yaml.ScalarNode:
+		if r := a.fieldRule(path); r != nil && node.Value != "" && node.ShortTag() == "!!str" {
+			node.Value = a.field(r, node.Value, learnOnly)
+			return
+		}
+		if !learnOnly {
+			node.Value = a.Text(node.Value)
+		}
+	case yaml.AliasNode:
+		// 앵커가 가리키는 노드는 원래 위치에서 처리된다
+	}
+}
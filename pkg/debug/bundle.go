@@ -0,0 +1,268 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/pkg/compress"
+	"github.com/gizzahub/gzh-cli/pkg/security/secrets"
+)
+
+// BundleFile is one file of a diagnostic bundle.
+type BundleFile struct {
+	Name string
+	Data []byte
+}
+
+// Bundle is a diagnostic bundle assembled in memory, so it can be
+// anonymized and verified before anything is written.
+type Bundle struct {
+	Files []BundleFile
+}
+
+// Add appends a file. Names are slash-separated paths inside the bundle.
+func (b *Bundle) Add(name string, data []byte) {
+	b.Files = append(b.Files, BundleFile{Name: name, Data: data})
+}
+
+// AddJSON appends v as an indented JSON file.
+func (b *Bundle) AddJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+	b.Add(name, append(data, '\n'))
+	return nil
+}
+
+// AddJSONLines appends each item as one JSON line.
+func (b *Bundle) AddJSONLines(name string, items []any) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+	}
+	b.Add(name, buf.Bytes())
+	return nil
+}
+
+// Anonymize returns a copy of b with sensitive values replaced. JSON,
+// JSON-lines and YAML files are anonymized field by field; other files as
+// text. Sensitive fields of every structured file are learned first, so an
+// organization named in the config is also replaced in log messages.
+func (a *Anonymizer) Anonymize(b *Bundle) (*Bundle, error) {
+	for _, f := range b.Files {
+		if err := a.learnFile(f); err != nil {
+			return nil, err
+		}
+	}
+
+	out := &Bundle{Files: make([]BundleFile, 0, len(b.Files))}
+	for _, f := range b.Files {
+		data, err := a.anonymizeFileData(f)
+		if err != nil {
+			return nil, err
+		}
+		out.Add(f.Name, data)
+	}
+	return out, nil
+}
+
+func (a *Anonymizer) learnFile(f BundleFile) error {
+	switch fileFormat(f.Name) {
+	case "json":
+		var v any
+		if err := json.Unmarshal(f.Data, &v); err != nil {
+			return fmt.Errorf("invalid JSON in %s: %w", f.Name, err)
+		}
+		a.Learn(v)
+	case "jsonl":
+		return forEachJSONLine(f, func(v any) error {
+			a.Learn(v)
+			return nil
+		})
+	case "yaml":
+		var node yaml.Node
+		if err := yaml.Unmarshal(f.Data, &node); err != nil {
+			return fmt.Errorf("invalid YAML in %s: %w", f.Name, err)
+		}
+		a.LearnYAML(&node)
+	}
+	return nil
+}
+
+func (a *Anonymizer) anonymizeFileData(f BundleFile) ([]byte, error) {
+	switch fileFormat(f.Name) {
+	case "json":
+		var v any
+		if err := json.Unmarshal(f.Data, &v); err != nil {
+			return nil, fmt.Errorf("invalid JSON in %s: %w", f.Name, err)
+		}
+		data, err := json.MarshalIndent(a.Value(v), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case "jsonl":
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		err := forEachJSONLine(f, func(v any) error {
+			return enc.Encode(a.Value(v))
+		})
+		return buf.Bytes(), err
+	case "yaml":
+		var node yaml.Node
+		if err := yaml.Unmarshal(f.Data, &node); err != nil {
+			return nil, fmt.Errorf("invalid YAML in %s: %w", f.Name, err)
+		}
+		if node.Kind == 0 {
+			return nil, nil
+		}
+		a.YAML(&node)
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&node); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", f.Name, err)
+		}
+		return buf.Bytes(), enc.Close()
+	default:
+		return []byte(a.Text(string(f.Data))), nil
+	}
+}
+
+func fileFormat(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json":
+		return "json"
+	case ".jsonl":
+		return "jsonl"
+	case ".yaml", ".yml":
+		return "yaml"
+	default:
+		return "text"
+	}
+}
+
+func forEachJSONLine(f BundleFile, fn func(v any) error) error {
+	scanner := bufio.NewScanner(bytes.NewReader(f.Data))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal(scanner.Bytes(), &v); err != nil {
+			return fmt.Errorf("invalid JSON in %s line %d: %w", f.Name, line, err)
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Leak is sensitive data found in a bundle by Verify.
+type Leak struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	// Rule is the anonymizer rule, "known-value" for a learned field value
+	// or "secret:<id>" for a secret scanner rule.
+	Rule string `json:"rule"`
+	// Value is the leaked value, redacted.
+	Value string `json:"value"`
+}
+
+// Verify scans b for data that should have been anonymized: matches of
+// the regex rules, learned field values and anything the secret scanner
+// detects. Placeholders issued by a are not reported.
+func (a *Anonymizer) Verify(b *Bundle) ([]Leak, error) {
+	scanner, err := secrets.NewScanner(&secrets.Config{
+		Allowlist: secrets.Allowlist{Regexes: []string{placeholderPattern.String()}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var leaks []Leak
+	for _, f := range b.Files {
+		for i, line := range strings.Split(string(f.Data), "\n") {
+			for _, r := range a.rules {
+				if r.Regex == nil {
+					continue
+				}
+				for _, m := range r.Regex.FindAllStringSubmatch(line, -1) {
+					value := m[r.Group]
+					if value == "" || a.kept(value) || a.IsPlaceholder(value) {
+						continue
+					}
+					leaks = append(leaks, Leak{File: f.Name, Line: i + 1, Rule: r.Name, Value: secrets.Redact(value)})
+				}
+			}
+			for _, value := range a.findKnown(line) {
+				leaks = append(leaks, Leak{File: f.Name, Line: i + 1, Rule: "known-value", Value: secrets.Redact(value)})
+			}
+		}
+
+		for _, finding := range scanner.ScanBytes("bundle", f.Name, f.Data) {
+			leaks = append(leaks, Leak{File: f.Name, Line: finding.Line, Rule: "secret:" + finding.RuleID, Value: finding.Secret})
+		}
+	}
+
+	sort.SliceStable(leaks, func(i, j int) bool {
+		if leaks[i].File != leaks[j].File {
+			return leaks[i].File < leaks[j].File
+		}
+		return leaks[i].Line < leaks[j].Line
+	})
+	return leaks, nil
+}
+
+// WriteTarGz writes b as a gzip-compressed tar archive whose entries live
+// under prefix.
+func (b *Bundle) WriteTarGz(w io.Writer, prefix string, modTime time.Time) error {
+	return b.WriteArchive(w, compress.Gzip, prefix, modTime)
+}
+
+// WriteArchive writes b as a tar archive compressed with codec whose
+// entries live under prefix.
+func (b *Bundle) WriteArchive(w io.Writer, codec compress.Codec, prefix string, modTime time.Time) error {
+	zw, err := codec.NewWriter(w)
+	if err != nil {
+		return fmt.Errorf("failed to compress bundle: %w", err)
+	}
+	tw := tar.NewWriter(zw)
+	for _, f := range b.Files {
+		hdr := &tar.Header{
+			Name:    path.Join(prefix, f.Name),
+			Mode:    0o600,
+			Size:    int64(len(f.Data)),
+			ModTime: modTime,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.Name, err)
+		}
+		if _, err := tw.Write(f.Data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.Name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
@@ -0,0 +1,365 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/internal/trace"
+)
+
+// CrashReportsEnv disables crash reports when set to "0", "false" or "off".
+const CrashReportsEnv = "GZH_CRASH_REPORTS"
+
+// CrashOpenIssueEnv opens a pre-filled GitHub issue in the browser after a
+// crash when set to "1", "true" or "on".
+const CrashOpenIssueEnv = "GZH_CRASH_OPEN_ISSUE"
+
+// CrashLogEvents is the number of recent log events kept for crash reports.
+const CrashLogEvents = 200
+
+// newIssueURL is where crashes are reported.
+const newIssueURL = "https://github.com/Gizzahub/gzh-cli/issues/new"
+
+// maxIssueURL keeps pre-filled issue URLs within what browsers and GitHub
+// accept.
+const maxIssueURL = 6000
+
+// maxGoroutineDump bounds the goroutine dump of a crash report.
+const maxGoroutineDump = 8 << 20
+
+// CrashReport describes a panic. The configuration is only fingerprinted,
+// never included, and credentials in the command line are masked.
+type CrashReport struct {
+	ID           string               `json:"id"`
+	Time         time.Time            `json:"time"`
+	Version      string               `json:"version"`
+	GoVersion    string               `json:"goVersion"`
+	OS           string               `json:"os"`
+	Arch         string               `json:"arch"`
+	Args         []string             `json:"args,omitempty"`
+	TraceID      string               `json:"traceId,omitempty"`
+	Panic        string               `json:"panic"`
+	Stack        string               `json:"stack"`
+	NumGoroutine int                  `json:"numGoroutine"`
+	Goroutines   string               `json:"goroutines"`
+	Config       *ConfigFingerprint   `json:"config,omitempty"`
+	RecentLogs   []logger.StreamEvent `json:"recentLogs,omitempty"`
+}
+
+// ConfigFingerprint identifies a configuration file without its contents:
+// its hash, size and top-level keys are enough to tell whether two crashes
+// ran with the same configuration.
+type ConfigFingerprint struct {
+	Path    string    `json:"path"`
+	SHA256  string    `json:"sha256"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	Keys    []string  `json:"keys,omitempty"`
+}
+
+// CrashOptions control HandleCrash.
+type CrashOptions struct {
+	// Dir receives the report; empty means DefaultCrashDir.
+	Dir string
+	// Version is the gz version.
+	Version string
+	// Args is the command line without the program name.
+	Args []string
+	// ConfigPath is the configuration file to fingerprint, if any.
+	ConfigPath string
+	// OpenIssue opens a pre-filled GitHub issue in the browser.
+	OpenIssue bool
+	// Open opens a URL; nil means OpenURL.
+	Open func(url string) error
+	// Now returns the current time; nil means time.Now.
+	Now func() time.Time
+}
+
+// CrashReportsEnabled reports whether crash reports are enabled in the
+// environment.
+func CrashReportsEnabled() bool {
+	switch strings.ToLower(os.Getenv(CrashReportsEnv)) {
+	case "0", "false", "off":
+		return false
+	default:
+		return true
+	}
+}
+
+// CrashOpenIssueRequested reports whether CrashOpenIssueEnv asks for an
+// issue to be opened after a crash.
+func CrashOpenIssueRequested() bool {
+	switch strings.ToLower(os.Getenv(CrashOpenIssueEnv)) {
+	case "1", "true", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// DefaultCrashDir returns ~/.gzh/crash.
+func DefaultCrashDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".gzh", "crash"), nil
+}
+
+// NewCrashReport builds a report for a recovered panic value. stack is the
+// panicking goroutine's stack as returned by runtime/debug.Stack in the
+// deferred function that recovered it.
+func NewCrashReport(value any, stack []byte, opts CrashOptions) *CrashReport {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	r := &CrashReport{
+		ID:           newCrashID(),
+		Time:         now().UTC(),
+		Version:      opts.Version,
+		GoVersion:    runtime.Version(),
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		Args:         redactCommandLine(opts.Args),
+		TraceID:      trace.ID(),
+		Panic:        fmt.Sprint(value),
+		Stack:        string(stack),
+		NumGoroutine: runtime.NumGoroutine(),
+		Goroutines:   goroutineDump(),
+		RecentLogs:   logger.DefaultLogStream().Recent(),
+	}
+	if opts.ConfigPath != "" {
+		if fp, err := FingerprintConfig(opts.ConfigPath); err == nil {
+			r.Config = fp
+		}
+	}
+	return r
+}
+
+// FingerprintConfig fingerprints the configuration file at path.
+func FingerprintConfig(path string) (*ConfigFingerprint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat configuration: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	fp := &ConfigFingerprint{
+		Path:    homeRelative(path),
+		SHA256:  hex.EncodeToString(sum[:]),
+		Size:    info.Size(),
+		ModTime: info.ModTime().UTC(),
+	}
+	var top map[string]any
+	if yaml.Unmarshal(data, &top) == nil {
+		for k := range top {
+			fp.Keys = append(fp.Keys, k)
+		}
+		sort.Strings(fp.Keys)
+	}
+	return fp, nil
+}
+
+// Write stores the report in dir and returns its path. The report may
+// contain paths and log messages, so only the owner can read it.
+func (r *CrashReport) Write(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create crash directory: %w", err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal crash report: %w", err)
+	}
+	name := fmt.Sprintf("crash-%s-%s.json", r.Time.Format("20060102-150405"), r.ID[:8])
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write crash report: %w", err)
+	}
+	return path, nil
+}
+
+// IssueURL returns a GitHub new-issue URL pre-filled with the panic, the
+// top of the stack and the environment. Logs, goroutines and the
+// configuration fingerprint stay in the report file.
+func (r *CrashReport) IssueURL() string {
+	title := "Crash: " + firstLine(r.Panic)
+	if len(title) > 100 {
+		title = title[:97] + "..."
+	}
+
+	stack := r.Stack
+	for {
+		var body strings.Builder
+		fmt.Fprintf(&body, "### What happened\n\n<!-- What were you doing when gz crashed? -->\n\n")
+		fmt.Fprintf(&body, "### Crash\n\n```\npanic: %s\n\n%s\n```\n\n", r.Panic, strings.TrimSpace(stack))
+		fmt.Fprintf(&body, "### Environment\n\n")
+		fmt.Fprintf(&body, "- gz: %s\n- Go: %s\n- OS: %s/%s\n", r.Version, r.GoVersion, r.OS, r.Arch)
+		if len(r.Args) > 0 {
+			fmt.Fprintf(&body, "- Command: `gz %s`\n", strings.Join(r.Args, " "))
+		}
+		fmt.Fprintf(&body, "- Crash report ID: %s\n", r.ID)
+
+		q := url.Values{}
+		q.Set("title", title)
+		q.Set("labels", "bug")
+		q.Set("body", body.String())
+		u := newIssueURL + "?" + q.Encode()
+		if len(u) <= maxIssueURL || stack == "" {
+			return u
+		}
+		// 스택을 절반씩 줄여 URL 길이 제한에 맞춘다
+		lines := strings.Split(stack, "\n")
+		if len(lines) <= 3 {
+			stack = ""
+		} else {
+			stack = strings.Join(lines[:len(lines)/2], "\n") + "\n..."
+		}
+	}
+}
+
+// HandleCrash writes a crash report for a recovered panic value, tells the
+// user where it is on w and, when requested, opens a pre-filled issue. It
+// returns the error the process exits with.
+func HandleCrash(w io.Writer, value any, stack []byte, opts CrashOptions) error {
+	crashErr := fmt.Errorf("gz crashed: %v", value)
+	report := NewCrashReport(value, stack, opts)
+
+	fmt.Fprintf(w, "\n💥 gz crashed unexpectedly: %s\n", firstLine(report.Panic))
+	fmt.Fprintln(w, "   This is a bug in gz, not something you did wrong.")
+
+	dir := opts.Dir
+	if dir == "" {
+		var err error
+		if dir, err = DefaultCrashDir(); err != nil {
+			fmt.Fprintf(w, "   The crash report could not be saved: %v\n\n%s\n", err, report.Stack)
+			return crashErr
+		}
+	}
+	path, err := report.Write(dir)
+	if err != nil {
+		fmt.Fprintf(w, "   The crash report could not be saved: %v\n\n%s\n", err, report.Stack)
+		return crashErr
+	}
+	fmt.Fprintf(w, "   📄 Crash report: %s\n", path)
+
+	if opts.OpenIssue {
+		open := opts.Open
+		if open == nil {
+			open = OpenURL
+		}
+		issue := report.IssueURL()
+		if err := open(issue); err != nil {
+			fmt.Fprintf(w, "   🐛 Report it here (the browser could not be opened):\n   %s\n", issue)
+		} else {
+			fmt.Fprintln(w, "   🐛 A pre-filled issue was opened in your browser.")
+		}
+	} else {
+		fmt.Fprintf(w, "   🐛 Please report it at %s\n", newIssueURL)
+		fmt.Fprintf(w, "      (set %s=1 to open a pre-filled issue next time)\n", CrashOpenIssueEnv)
+	}
+	fmt.Fprintln(w, "   Review the report before sharing it; it includes recent log messages.")
+	return crashErr
+}
+
+// OpenURL opens target in the default browser.
+func OpenURL(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// goroutineDump returns the stacks of all goroutines.
+func goroutineDump() string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= maxGoroutineDump {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// redactCommandLine masks the values of flags that look like credentials
+// and credentials embedded in URLs.
+func redactCommandLine(args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	out := make([]string, len(args))
+	maskNext := false
+	for i, a := range args {
+		switch {
+		case maskNext:
+			out[i], maskNext = "***", false
+		case strings.HasPrefix(a, "-") && a != "--":
+			name, _, hasValue := strings.Cut(strings.TrimLeft(a, "-"), "=")
+			if !sensitiveFlag.MatchString(name) {
+				out[i] = redact(a)
+			} else if hasValue {
+				out[i] = a[:strings.Index(a, "=")+1] + "***"
+			} else {
+				out[i], maskNext = a, true
+			}
+		default:
+			out[i] = redact(a)
+		}
+	}
+	return out
+}
+
+// homeRelative shortens paths under the home directory to ~/...
+func homeRelative(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(home, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.Join("~", rel)
+	}
+	return path
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+func newCrashID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
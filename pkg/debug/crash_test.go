@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/logger"
+)
+
+func recoverCrash(t *testing.T, fn func()) (value any, stack []byte) {
+	t.Helper()
+	func() {
+		defer func() {
+			value = recover()
+			stack = debug.Stack()
+		}()
+		fn()
+	}()
+	require.NotNil(t, value)
+	return value, stack
+}
+
+func TestHandleCrash(t *testing.T) {
+	stream := logger.DefaultLogStream()
+	stream.KeepRecent(CrashLogEvents)
+	defer stream.KeepRecent(0)
+	logger.NewSimpleLogger("crash-test").Debug("about to clone", "repo", "acme/api")
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "gzh.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("version: 1\nproviders:\n  github:\n    token: secret\n"), 0o600))
+
+	value, stack := recoverCrash(t, func() {
+		var m map[string]int
+		m["boom"]++
+	})
+
+	var opened string
+	var out bytes.Buffer
+	err := HandleCrash(&out, value, stack, CrashOptions{
+		Dir:        filepath.Join(dir, "crash"),
+		Version:    "1.2.3",
+		Args:       []string{"git", "repo", "clone", "--token", "ghp_x", "--api-key=abc", "https://user:pw@example.com/r.git"},
+		ConfigPath: configPath,
+		OpenIssue:  true,
+		Open:       func(u string) error { opened = u; return nil },
+		Now:        func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) },
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "assignment to entry in nil map")
+
+	entries, err := os.ReadDir(filepath.Join(dir, "crash"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, strings.HasPrefix(entries[0].Name(), "crash-20250301-120000-"))
+	path := filepath.Join(dir, "crash", entries[0].Name())
+	assert.Contains(t, out.String(), "Crash report: "+path)
+	assert.Contains(t, out.String(), "pre-filled issue was opened")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret", "the configuration is only fingerprinted")
+	assert.NotContains(t, string(data), "ghp_x")
+
+	var report CrashReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, "1.2.3", report.Version)
+	assert.Equal(t, []string{"git", "repo", "clone", "--token", "***", "--api-key=***", "https://***@example.com/r.git"}, report.Args)
+	assert.Contains(t, report.Stack, "TestHandleCrash")
+	assert.Contains(t, report.Goroutines, "goroutine ")
+	require.NotNil(t, report.Config)
+	assert.Equal(t, []string{"providers", "version"}, report.Config.Keys)
+	assert.Len(t, report.Config.SHA256, 64)
+	require.NotEmpty(t, report.RecentLogs)
+	assert.Equal(t, "about to clone", report.RecentLogs[len(report.RecentLogs)-1].Message)
+
+	issue, err := url.Parse(opened)
+	require.NoError(t, err)
+	assert.Equal(t, "Crash: assignment to entry in nil map", issue.Query().Get("title"))
+	assert.Contains(t, issue.Query().Get("body"), "- gz: 1.2.3")
+	assert.Contains(t, issue.Query().Get("body"), report.ID)
+	assert.NotContains(t, issue.Query().Get("body"), "about to clone", "logs stay in the report")
+}
+
+func TestHandleCrashBrowserFails(t *testing.T) {
+	var out bytes.Buffer
+	err := HandleCrash(&out, "boom", []byte("goroutine 1 [running]:\nmain.main()"), CrashOptions{
+		Dir:       t.TempDir(),
+		OpenIssue: true,
+		Open:      func(string) error { return errors.New("no browser") },
+	})
+	require.EqualError(t, err, "gz crashed: boom")
+	assert.Contains(t, out.String(), newIssueURL+"?")
+
+	out.Reset()
+	_ = HandleCrash(&out, "boom", nil, CrashOptions{Dir: t.TempDir()})
+	assert.Contains(t, out.String(), CrashOpenIssueEnv+"=1")
+}
+
+func TestCrashReportIssueURLLength(t *testing.T) {
+	report := &CrashReport{ID: "abc", Panic: "boom", Stack: strings.Repeat("pkg.fn(...)\n\t/src/file.go:10 +0x1f\n", 2000)}
+	u := report.IssueURL()
+	assert.LessOrEqual(t, len(u), maxIssueURL)
+	assert.Contains(t, u, "pkg.fn", "the top of the stack is kept")
+}
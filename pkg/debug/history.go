@@ -0,0 +1,273 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package debug records per-invocation execution profiles so slow or
+// regressing commands can be found after the fact.
+package debug
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DisableEnv disables history recording when set to "0", "false" or "off".
+const DisableEnv = "GZH_HISTORY"
+
+// DefaultMaxRecords is the number of records kept once the history is pruned.
+const DefaultMaxRecords = 5000
+
+// Record is one gz invocation.
+type Record struct {
+	Command    string            `json:"command"`
+	Args       []string          `json:"args,omitempty"`
+	Flags      map[string]string `json:"flags,omitempty"`
+	Start      time.Time         `json:"start"`
+	Duration   time.Duration     `json:"duration"`
+	ExitCode   int               `json:"exitCode"`
+	Error      string            `json:"error,omitempty"`
+	Version    string            `json:"version,omitempty"`
+	TraceID    string            `json:"traceId,omitempty"`
+	UserCPU    time.Duration     `json:"userCpu"`
+	SystemCPU  time.Duration     `json:"systemCpu"`
+	MaxRSS     int64             `json:"maxRss"`
+	HeapAlloc  uint64            `json:"heapAlloc"`
+	Goroutines int               `json:"goroutines"`
+}
+
+// History is an append-only JSON-lines file of Records.
+type History struct {
+	path       string
+	maxRecords int
+	mu         sync.Mutex
+}
+
+// DefaultPath returns ~/.gzh/history.db.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".gzh", "history.db"), nil
+}
+
+// Enabled reports whether recording is enabled in the environment.
+func Enabled() bool {
+	switch strings.ToLower(os.Getenv(DisableEnv)) {
+	case "0", "false", "off":
+		return false
+	default:
+		return true
+	}
+}
+
+// NewHistory opens the history at path. The file is created on first append.
+func NewHistory(path string) *History {
+	return &History{path: path, maxRecords: DefaultMaxRecords}
+}
+
+// Path returns the history file path.
+func (h *History) Path() string {
+	return h.path
+}
+
+// Append adds a record, pruning the oldest ones once the file holds more than
+// twice the retention limit.
+func (h *History) Append(r Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode history record: %w", err)
+	}
+
+	f, err := os.OpenFile(h.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	_, werr := f.Write(append(data, '\n'))
+	cerr := f.Close()
+	if werr != nil {
+		return fmt.Errorf("failed to write history: %w", werr)
+	}
+	if cerr != nil {
+		return fmt.Errorf("failed to write history: %w", cerr)
+	}
+
+	// Records are a few hundred bytes; only pay for a full read once the file
+	// is plausibly over the limit.
+	if info, err := os.Stat(h.path); err == nil && info.Size() > int64(h.maxRecords)*1024 {
+		return h.prune()
+	}
+	return nil
+}
+
+// Load returns all records, oldest first. Unparseable lines are skipped so a
+// torn write from a killed process does not hide the rest of the history.
+func (h *History) Load() ([]Record, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.load()
+}
+
+func (h *History) load() ([]Record, error) {
+	f, err := os.Open(h.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return records, nil
+}
+
+func (h *History) prune() error {
+	records, err := h.load()
+	if err != nil || len(records) <= h.maxRecords*2 {
+		return err
+	}
+	records = records[len(records)-h.maxRecords:]
+
+	tmp := h.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmp)
+			return fmt.Errorf("failed to prune history: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	return os.Rename(tmp, h.path)
+}
+
+// Filter narrows records for a query. Zero values match everything.
+type Filter struct {
+	// Command matches records whose command path starts with this prefix.
+	Command string
+	Since   time.Time
+}
+
+func (f Filter) match(r Record) bool {
+	if f.Command != "" && !strings.HasPrefix(r.Command, f.Command) {
+		return false
+	}
+	return f.Since.IsZero() || !r.Start.Before(f.Since)
+}
+
+// Slowest returns up to n matching records ordered by duration, longest first.
+func Slowest(records []Record, filter Filter, n int) []Record {
+	var out []Record
+	for _, r := range records {
+		if filter.match(r) {
+			out = append(out, r)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Duration > out[j].Duration })
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// Regression is a command whose latest successful run was notably slower
+// than its baseline.
+type Regression struct {
+	Command  string        `json:"command"`
+	Latest   Record        `json:"latest"`
+	Baseline time.Duration `json:"baseline"`
+	Runs     int           `json:"runs"`
+	Ratio    float64       `json:"ratio"`
+}
+
+// Regressions compares each command's most recent successful run with the
+// median duration of its earlier successful runs and reports those at least
+// threshold times slower. Commands with fewer than minRuns earlier runs are
+// skipped because their baseline is noise. Results are ordered by ratio.
+func Regressions(records []Record, filter Filter, minRuns int, threshold float64) []Regression {
+	byCommand := make(map[string][]Record)
+	var order []string
+	for _, r := range records {
+		if r.ExitCode != 0 || !filter.match(r) {
+			continue
+		}
+		if _, ok := byCommand[r.Command]; !ok {
+			order = append(order, r.Command)
+		}
+		byCommand[r.Command] = append(byCommand[r.Command], r)
+	}
+
+	var out []Regression
+	for _, command := range order {
+		runs := byCommand[command]
+		sort.SliceStable(runs, func(i, j int) bool { return runs[i].Start.Before(runs[j].Start) })
+		earlier, latest := runs[:len(runs)-1], runs[len(runs)-1]
+		if len(earlier) < minRuns {
+			continue
+		}
+
+		baseline := median(earlier)
+		if baseline <= 0 {
+			continue
+		}
+		ratio := float64(latest.Duration) / float64(baseline)
+		if ratio >= threshold {
+			out = append(out, Regression{Command: command, Latest: latest, Baseline: baseline, Runs: len(earlier), Ratio: ratio})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Ratio > out[j].Ratio })
+	return out
+}
+
+func median(records []Record) time.Duration {
+	durations := make([]time.Duration, len(records))
+	for i, r := range records {
+		durations[i] = r.Duration
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	mid := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[mid-1] + durations[mid]) / 2
+	}
+	return durations[mid]
+}
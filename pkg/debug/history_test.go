@@ -0,0 +1,153 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory_AppendAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "history.db")
+	h := NewHistory(path)
+
+	records, err := h.Load()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, h.Append(Record{Command: "gz synclone", Start: start, Duration: time.Second}))
+	require.NoError(t, h.Append(Record{Command: "gz git", Start: start.Add(time.Minute), ExitCode: 1}))
+
+	// A torn line from an interrupted write is skipped.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, _ = f.WriteString(`{"command":"gz`)
+	require.NoError(t, f.Close())
+
+	records, err = h.Load()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "gz synclone", records[0].Command)
+	assert.Equal(t, time.Second, records[0].Duration)
+	assert.Equal(t, 1, records[1].ExitCode)
+}
+
+func TestHistory_Prune(t *testing.T) {
+	h := NewHistory(filepath.Join(t.TempDir(), "history.db"))
+	h.maxRecords = 2
+
+	for i := range 10 {
+		require.NoError(t, h.Append(Record{Command: "gz", Args: []string{string(rune('a' + i))}, Flags: map[string]string{"pad": string(make([]byte, 900))}}))
+	}
+
+	records, err := h.Load()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(records), 4)
+	assert.Equal(t, []string{"j"}, records[len(records)-1].Args)
+}
+
+func TestSlowest(t *testing.T) {
+	now := time.Now()
+	records := []Record{
+		{Command: "gz synclone github", Duration: 3 * time.Second, Start: now},
+		{Command: "gz git repo", Duration: 5 * time.Second, Start: now},
+		{Command: "gz synclone gitlab", Duration: 9 * time.Second, Start: now.Add(-48 * time.Hour)},
+		{Command: "gz synclone github", Duration: time.Second, Start: now},
+	}
+
+	got := Slowest(records, Filter{}, 2)
+	require.Len(t, got, 2)
+	assert.Equal(t, 9*time.Second, got[0].Duration)
+	assert.Equal(t, 5*time.Second, got[1].Duration)
+
+	got = Slowest(records, Filter{Command: "gz synclone", Since: now.Add(-time.Hour)}, 0)
+	require.Len(t, got, 2)
+	assert.Equal(t, 3*time.Second, got[0].Duration)
+}
+
+func TestRegressions(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var records []Record
+	for i, d := range []time.Duration{10, 12, 11, 9, 10} {
+		records = append(records,
+			Record{Command: "gz synclone", Start: base.Add(time.Duration(i) * time.Hour), Duration: d * time.Second},
+			Record{Command: "gz git", Start: base.Add(time.Duration(i) * time.Hour), Duration: d * time.Second},
+		)
+	}
+	records = append(records,
+		// A failed slow run is not a regression.
+		Record{Command: "gz git", Start: base.Add(10 * time.Hour), Duration: time.Minute, ExitCode: 1},
+		Record{Command: "gz git", Start: base.Add(11 * time.Hour), Duration: 11 * time.Second},
+		Record{Command: "gz synclone", Start: base.Add(11 * time.Hour), Duration: 25 * time.Second},
+		// Too little history to judge.
+		Record{Command: "gz pm", Start: base, Duration: time.Second},
+		Record{Command: "gz pm", Start: base.Add(time.Hour), Duration: time.Hour},
+	)
+
+	got := Regressions(records, Filter{}, 5, 1.5)
+	require.Len(t, got, 1)
+	assert.Equal(t, "gz synclone", got[0].Command)
+	assert.Equal(t, 10*time.Second, got[0].Baseline)
+	assert.InDelta(t, 2.5, got[0].Ratio, 0.001)
+	assert.Equal(t, 5, got[0].Runs)
+}
+
+func TestRecorder_Finish(t *testing.T) {
+	h := NewHistory(filepath.Join(t.TempDir(), "history.db"))
+
+	root := &cobra.Command{Use: "gz"}
+	var executed *cobra.Command
+	sub := &cobra.Command{Use: "clone", RunE: func(cmd *cobra.Command, _ []string) error {
+		executed = cmd
+		return errors.New("boom")
+	}}
+	sub.Flags().String("token", "", "")
+	sub.Flags().String("url", "", "")
+	sub.Flags().Int("parallel", 1, "")
+	root.AddCommand(sub)
+	root.SetArgs([]string{"clone", "--token", "s3cret", "--url", "https://user:pw@example.com/x.git", "target"})
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	recorder := NewRecorder(h, "v1.2.3")
+	_, runErr := root.ExecuteC()
+	require.Error(t, runErr)
+	require.NoError(t, recorder.Finish(executed, runErr))
+
+	records, err := h.Load()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	r := records[0]
+	assert.Equal(t, "gz clone", r.Command)
+	assert.Equal(t, []string{"target"}, r.Args)
+	assert.Equal(t, map[string]string{"token": "***", "url": "https://***@example.com/x.git"}, r.Flags)
+	assert.Equal(t, 1, r.ExitCode)
+	assert.Equal(t, "boom", r.Error)
+	assert.Equal(t, "v1.2.3", r.Version)
+	assert.Positive(t, r.HeapAlloc)
+
+	// A nil recorder (recording disabled) is a no-op.
+	var disabled *Recorder
+	assert.NoError(t, disabled.Finish(executed, nil))
+}
+
+func TestIsHistoryCommand(t *testing.T) {
+	root := &cobra.Command{Use: "gz"}
+	debugCmd := &cobra.Command{Use: "debug"}
+	history := &cobra.Command{Use: "history"}
+	debugCmd.AddCommand(history)
+	root.AddCommand(debugCmd, &cobra.Command{Use: "git"})
+
+	assert.True(t, IsHistoryCommand(history))
+	assert.False(t, IsHistoryCommand(root))
+	assert.False(t, IsHistoryCommand(root.Commands()[1]))
+}
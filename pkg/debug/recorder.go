@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/gizzahub/gzh-cli/internal/trace"
+)
+
+// Recorder measures a single invocation. Create it as early as possible and
+// call Finish once the command has returned.
+type Recorder struct {
+	history *History
+	version string
+	start   time.Time
+}
+
+// NewRecorder starts timing an invocation that will be appended to history.
+// A nil history yields a Recorder whose Finish does nothing.
+func NewRecorder(history *History, version string) *Recorder {
+	return &Recorder{history: history, version: version, start: time.Now()}
+}
+
+// Finish records the executed command and its outcome. Recording failures
+// are returned but should never fail the command itself.
+func (r *Recorder) Finish(cmd *cobra.Command, runErr error) error {
+	if r == nil || r.history == nil || cmd == nil {
+		return nil
+	}
+	return r.history.Append(r.record(cmd, runErr))
+}
+
+func (r *Recorder) record(cmd *cobra.Command, runErr error) Record {
+	rec := Record{
+		Command:    cmd.CommandPath(),
+		Args:       redactArgs(cmd.Flags().Args()),
+		Flags:      changedFlags(cmd),
+		Start:      r.start,
+		Duration:   time.Since(r.start),
+		Version:    r.version,
+		TraceID:    trace.ID(),
+		Goroutines: runtime.NumGoroutine(),
+	}
+	if runErr != nil {
+		rec.ExitCode = 1
+		rec.Error = runErr.Error()
+	}
+
+	rec.UserCPU, rec.SystemCPU, rec.MaxRSS = resourceUsage()
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	rec.HeapAlloc = mem.HeapAlloc
+
+	return rec
+}
+
+var sensitiveFlag = regexp.MustCompile(`(?i)token|password|secret|key|credential`)
+
+// changedFlags returns the flags set on the command line. Values of flags
+// that look like credentials are masked.
+func changedFlags(cmd *cobra.Command) map[string]string {
+	flags := make(map[string]string)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if sensitiveFlag.MatchString(f.Name) {
+			flags[f.Name] = "***"
+			return
+		}
+		flags[f.Name] = redact(f.Value.String())
+	})
+	if len(flags) == 0 {
+		return nil
+	}
+	return flags
+}
+
+var urlCredentials = regexp.MustCompile(`://[^/@\s]+@`)
+
+func redact(s string) string {
+	return urlCredentials.ReplaceAllString(s, "://***@")
+}
+
+func redactArgs(args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	out := make([]string, len(args))
+	for i, a := range args {
+		out[i] = redact(a)
+	}
+	return out
+}
+
+// IsHistoryCommand reports whether cmd is part of the debug command tree,
+// whose own runs are not worth recording.
+func IsHistoryCommand(cmd *cobra.Command) bool {
+	path := strings.Fields(cmd.CommandPath())
+	return len(path) > 1 && path[1] == "debug"
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build !unix
+
+package debug
+
+import "time"
+
+// resourceUsage is not implemented on this platform; only wall-clock time and
+// Go heap statistics are recorded.
+func resourceUsage() (user, system time.Duration, maxRSS int64) {
+	return 0, 0, 0
+}
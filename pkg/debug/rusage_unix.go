@@ -0,0 +1,27 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build unix
+
+package debug
+
+import (
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// resourceUsage returns the process's CPU time and peak resident set size.
+func resourceUsage() (user, system time.Duration, maxRSS int64) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0, 0, 0
+	}
+
+	maxRSS = int64(ru.Maxrss)
+	// Linux and the BSDs report kilobytes, Darwin reports bytes.
+	if runtime.GOOS != "darwin" {
+		maxRSS *= 1024
+	}
+	return time.Duration(ru.Utime.Nano()), time.Duration(ru.Stime.Nano()), maxRSS
+}
@@ -0,0 +1,252 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/logger"
+	"github.com/gizzahub/gzh-cli/internal/websocket"
+)
+
+// LogStreamPath is where the debug server streams log events.
+const LogStreamPath = "/debug/logs/stream"
+
+// maxStreamBuffer caps the per-client buffer a client may ask for.
+const maxStreamBuffer = 10000
+
+// droppedInterval is how often clients are told about dropped events while
+// no new events arrive.
+const droppedInterval = time.Second
+
+// Server is the debug HTTP server of a running gz command.
+type Server struct {
+	listener net.Listener
+	server   *http.Server
+}
+
+// StartServer listens on addr and serves the debug endpoints in the
+// background. A nil stream serves the default log stream.
+func StartServer(addr string, stream *logger.LogStream) (*Server, error) {
+	if stream == nil {
+		stream = logger.DefaultLogStream()
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(LogStreamPath, LogStreamHandler(stream))
+
+	s := &Server{
+		listener: listener,
+		server: &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+	go func() { _ = s.server.Serve(listener) }()
+
+	return s, nil
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Shutdown stops the server. Hijacked WebSocket connections are not tracked
+// by http.Server, so they end when the process exits.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// streamMessage is one text frame sent to a log stream client.
+type streamMessage struct {
+	Type string `json:"type"` // subscribed, log, dropped or error
+	*logger.StreamEvent
+	Filter *logger.StreamFilter `json:"filter,omitempty"`
+	Count  uint64               `json:"count,omitempty"`
+	Error  string               `json:"error,omitempty"`
+}
+
+// LogStreamHandler streams log events to WebSocket clients.
+//
+// The initial filter is taken from the query: module (repeatable or comma
+// separated), level (minimum severity), trace and buffer (events buffered
+// for the client). Clients change the filter at any time by sending a JSON
+// StreamFilter, e.g. {"modules":["git"],"level":"warn"}. Events that do not
+// fit a slow client's buffer are dropped for that client and reported in a
+// "dropped" message.
+func LogStreamHandler(stream *logger.LogStream) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, buffer, err := parseStreamQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !websocket.IsUpgrade(r) {
+			w.Header().Set("Upgrade", "websocket")
+			http.Error(w, "log streaming requires a WebSocket connection", http.StatusUpgradeRequired)
+			return
+		}
+
+		ws, err := websocket.Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+
+		sub := stream.Subscribe(filter, buffer)
+		defer sub.Close()
+
+		send := func(msg streamMessage) bool {
+			data, err := json.Marshal(msg)
+			return err == nil && ws.WriteText(data) == nil
+		}
+		subscribed := func() bool {
+			current := sub.Filter()
+			return send(streamMessage{Type: "subscribed", Filter: &current})
+		}
+
+		// 필터 변경 요청은 읽기 루프에서 처리하고 응답은 쓰기 루프가 보낸다
+		replies := make(chan streamMessage, 4)
+		clientGone := make(chan struct{})
+		go func() {
+			defer close(clientGone)
+			_ = ws.ReadLoop(func(data []byte) error {
+				var next logger.StreamFilter
+				reply := streamMessage{Type: "subscribed"}
+				if err := json.Unmarshal(data, &next); err != nil {
+					reply = streamMessage{Type: "error", Error: "invalid filter: " + err.Error()}
+				} else if err := next.Validate(); err != nil {
+					reply = streamMessage{Type: "error", Error: err.Error()}
+				} else {
+					sub.SetFilter(next)
+					reply.Filter = &next
+				}
+				select {
+				case replies <- reply:
+				default:
+				}
+				return nil
+			})
+		}()
+
+		if !subscribed() {
+			return
+		}
+
+		ticker := time.NewTicker(droppedInterval)
+		defer ticker.Stop()
+		reportDropped := func() bool {
+			if n := sub.TakeDropped(); n > 0 {
+				return send(streamMessage{Type: "dropped", Count: n})
+			}
+			return true
+		}
+
+		for {
+			select {
+			case ev, ok := <-sub.Events():
+				if !ok || !reportDropped() || !send(streamMessage{Type: "log", StreamEvent: &ev}) {
+					return
+				}
+			case reply := <-replies:
+				if !send(reply) {
+					return
+				}
+			case <-ticker.C:
+				if !reportDropped() {
+					return
+				}
+			case <-clientGone:
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+}
+
+func parseStreamQuery(r *http.Request) (logger.StreamFilter, int, error) {
+	q := r.URL.Query()
+	filter := logger.StreamFilter{
+		Level:   q.Get("level"),
+		TraceID: q.Get("trace"),
+	}
+	for _, value := range q["module"] {
+		for _, m := range strings.Split(value, ",") {
+			if m = strings.TrimSpace(m); m != "" {
+				filter.Modules = append(filter.Modules, m)
+			}
+		}
+	}
+	if err := filter.Validate(); err != nil {
+		return filter, 0, err
+	}
+
+	buffer := logger.DefaultStreamBuffer
+	if value := q.Get("buffer"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 || n > maxStreamBuffer {
+			return filter, 0, fmt.Errorf("invalid buffer %q (1-%d)", value, maxStreamBuffer)
+		}
+		buffer = n
+	}
+	return filter, buffer, nil
+}
+
+// AddServerFlag adds a --debug-addr flag to cmd and wraps the RunE of cmd
+// and all of its subcommands so the debug server runs for the command's
+// lifetime. Call it after all subcommands have been added.
+func AddServerFlag(cmd *cobra.Command) {
+	var addr string
+	cmd.PersistentFlags().StringVar(&addr, "debug-addr", "",
+		"Serve live log streaming on this address (e.g. 127.0.0.1:6060); logs may contain sensitive data")
+
+	wrapServerRunE(cmd, &addr)
+}
+
+func wrapServerRunE(cmd *cobra.Command, addr *string) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(c *cobra.Command, args []string) error {
+			if *addr == "" {
+				return run(c, args)
+			}
+
+			server, err := StartServer(*addr, nil)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(c.ErrOrStderr(), "🔎 Streaming logs on ws://%s%s\n", server.Addr(), LogStreamPath)
+
+			defer func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := server.Shutdown(ctx); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+					fmt.Fprintf(c.ErrOrStderr(), "⚠️  Failed to stop debug server: %v\n", err)
+				}
+			}()
+
+			return run(c, args)
+		}
+	}
+
+	for _, sub := range cmd.Commands() {
+		wrapServerRunE(sub, addr)
+	}
+}
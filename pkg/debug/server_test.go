@@ -0,0 +1,209 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/internal/logger"
+)
+
+type wsClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dialLogStream(t *testing.T, addr, query string) *wsClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	_, err = io.WriteString(conn, "GET "+LogStreamPath+query+" HTTP/1.1\r\n"+
+		"Host: test\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	return &wsClient{conn: conn, reader: reader}
+}
+
+// next reads the next text message.
+func (c *wsClient) next(t *testing.T) map[string]any {
+	t.Helper()
+	var head [2]byte
+	_, err := io.ReadFull(c.reader, head[:])
+	require.NoError(t, err)
+	require.Equal(t, byte(0x1), head[0]&0x0F) // text
+	length := int(head[1] & 0x7F)
+	if length == 126 {
+		var ext [2]byte
+		_, err = io.ReadFull(c.reader, ext[:])
+		require.NoError(t, err)
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	_, err = io.ReadFull(c.reader, payload)
+	require.NoError(t, err)
+
+	var msg map[string]any
+	require.NoError(t, json.Unmarshal(payload, &msg))
+	return msg
+}
+
+// send writes a masked client text frame.
+func (c *wsClient) send(t *testing.T, text string) {
+	t.Helper()
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x81, 0x80 | byte(len(text))}
+	frame = append(frame, mask[:]...)
+	for i := 0; i < len(text); i++ {
+		frame = append(frame, text[i]^mask[i%4])
+	}
+	_, err := c.conn.Write(frame)
+	require.NoError(t, err)
+}
+
+func startTestServer(t *testing.T, stream *logger.LogStream) *Server {
+	t.Helper()
+	server, err := StartServer("127.0.0.1:0", stream)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = server.Shutdown(context.Background()) })
+	return server
+}
+
+// waitActive waits until the client is subscribed.
+func waitActive(t *testing.T, stream *logger.LogStream) {
+	t.Helper()
+	require.Eventually(t, stream.Active, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLogStreamFilters(t *testing.T) {
+	stream := logger.NewLogStream()
+	server := startTestServer(t, stream)
+
+	client := dialLogStream(t, server.Addr(), "?module=git&level=warn")
+	msg := client.next(t)
+	assert.Equal(t, "subscribed", msg["type"])
+	assert.Equal(t, map[string]any{"modules": []any{"git"}, "level": "warn"}, msg["filter"])
+	waitActive(t, stream)
+
+	stream.Publish(logger.StreamEvent{Level: "info", Module: "git", Message: "too quiet"})
+	stream.Publish(logger.StreamEvent{Level: "error", Module: "net", Message: "other module"})
+	stream.Publish(logger.StreamEvent{Level: "warn", Module: "git.clone", Message: "retrying", TraceID: "op-1"})
+
+	msg = client.next(t)
+	assert.Equal(t, "log", msg["type"])
+	assert.Equal(t, "retrying", msg["msg"])
+	assert.Equal(t, "git.clone", msg["module"])
+	assert.Equal(t, "op-1", msg["traceId"])
+
+	// 연결 중에 필터를 바꿀 수 있다
+	client.send(t, `{"traceId":"op-2"}`)
+	msg = client.next(t)
+	assert.Equal(t, "subscribed", msg["type"])
+	assert.Equal(t, map[string]any{"traceId": "op-2"}, msg["filter"])
+
+	stream.Publish(logger.StreamEvent{Level: "error", Module: "git", Message: "wrong trace", TraceID: "op-1"})
+	stream.Publish(logger.StreamEvent{Level: "debug", Module: "net", Message: "traced", TraceID: "op-2"})
+	msg = client.next(t)
+	assert.Equal(t, "traced", msg["msg"])
+
+	client.send(t, `{"level":"loud"}`)
+	msg = client.next(t)
+	assert.Equal(t, "error", msg["type"])
+	assert.Contains(t, msg["error"], `invalid level "loud"`)
+}
+
+func TestLogStreamReportsDropped(t *testing.T) {
+	stream := logger.NewLogStream()
+	server := startTestServer(t, stream)
+
+	client := dialLogStream(t, server.Addr(), "?buffer=1")
+	assert.Equal(t, "subscribed", client.next(t)["type"])
+	waitActive(t, stream)
+
+	// 클라이언트가 읽지 않는 동안 TCP 버퍼가 차면 이벤트는 버려지고 Publish는 계속된다
+	payload := make([]byte, 2000)
+	for i := range payload {
+		payload[i] = 'x'
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 5000; i++ {
+			stream.Publish(logger.StreamEvent{Level: "info", Message: string(payload)})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Publish blocked on a slow client")
+	}
+
+	var dropped float64
+	for dropped == 0 {
+		msg := client.next(t)
+		if msg["type"] == "dropped" {
+			dropped = msg["count"].(float64)
+		}
+	}
+	assert.Positive(t, dropped)
+}
+
+func TestLogStreamRejectsBadRequests(t *testing.T) {
+	server := startTestServer(t, logger.NewLogStream())
+	base := "http://" + server.Addr() + LogStreamPath
+
+	resp, err := http.Get(base)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUpgradeRequired, resp.StatusCode)
+
+	for _, query := range []string{"?level=loud", "?buffer=0", "?buffer=many"} {
+		resp, err := http.Get(base + query)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestLogStreamRejectsCrossOrigin(t *testing.T) {
+	server := startTestServer(t, logger.NewLogStream())
+
+	handshake := func(origin string) int {
+		conn, err := net.Dial("tcp", server.Addr())
+		require.NoError(t, err)
+		defer conn.Close()
+		_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+		_, err = io.WriteString(conn, "GET "+LogStreamPath+" HTTP/1.1\r\n"+
+			"Host: "+server.Addr()+"\r\nOrigin: "+origin+"\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+			"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n")
+		require.NoError(t, err)
+		resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// 사용자가 방문한 웹 페이지는 로그 스트림을 열 수 없다
+	assert.Equal(t, http.StatusForbidden, handshake("https://evil.example.com"))
+	assert.Equal(t, http.StatusForbidden, handshake("null"))
+	assert.Equal(t, http.StatusSwitchingProtocols, handshake("http://"+server.Addr()))
+}
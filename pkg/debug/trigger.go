@@ -0,0 +1,521 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+)
+
+// Trigger names recorded with each capture.
+const (
+	TriggerCPU        = "cpu"
+	TriggerHeap       = "heap"
+	TriggerGoroutines = "goroutines"
+)
+
+// ActionProfileCapture is the audit action logged for each capture.
+const ActionProfileCapture = "debug.profile.captured"
+
+// captureLog is the JSON lines file in the diagnostics directory that
+// receives one audit event per capture.
+const captureLog = "captures.jsonl"
+
+// TriggerConfig configures a ProfileTrigger. A zero threshold disables that
+// rule; the other zero values select defaults.
+type TriggerConfig struct {
+	// Dir receives one subdirectory per capture. Defaults to
+	// DefaultDiagnosticsDir().
+	Dir string
+	// Interval between samples. Defaults to 5s.
+	Interval time.Duration
+
+	// CPUPercent fires when process CPU usage, in percent of one core,
+	// stays above it for CPUFor.
+	CPUPercent float64
+	// CPUFor defaults to 30s.
+	CPUFor time.Duration
+	// HeapGrowthMB fires when the live heap grows this many MiB above the
+	// lowest value seen since the last capture.
+	HeapGrowthMB uint64
+	// Goroutines fires when the goroutine count exceeds it.
+	Goroutines int
+
+	// CPUProfile is how long the CPU profile of a capture runs. Defaults
+	// to 10s.
+	CPUProfile time.Duration
+	// MaxCaptures is the number of captures kept; older ones are removed.
+	// Defaults to 10.
+	MaxCaptures int
+	// Cooldown suppresses captures after one was taken. Defaults to 5m.
+	Cooldown time.Duration
+
+	// Recorder also receives the audit events, e.g. an *audit.Shipper.
+	Recorder interface{ Record(audit.Event) error }
+	// Logger receives a warning per capture. Defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// Enabled reports whether any trigger rule is set.
+func (c TriggerConfig) Enabled() bool {
+	return c.CPUPercent > 0 || c.HeapGrowthMB > 0 || c.Goroutines > 0
+}
+
+// DefaultDiagnosticsDir returns ~/.gzh/diagnostics.
+func DefaultDiagnosticsDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".gzh", "diagnostics")
+	}
+	return filepath.Join(home, ".gzh", "diagnostics")
+}
+
+// triggerSample is one observation of the process.
+type triggerSample struct {
+	At         time.Time
+	CPU        time.Duration // user plus system CPU time so far
+	HeapBytes  uint64
+	Goroutines int
+}
+
+// Capture is one set of profiles written after a trigger fired.
+type Capture struct {
+	Trigger string    `json:"trigger"`
+	Reason  string    `json:"reason"`
+	At      time.Time `json:"at"`
+	// Value is the measurement that fired the rule and Threshold its
+	// limit: CPU percent, heap growth in MiB or goroutines.
+	Value     float64  `json:"value"`
+	Threshold float64  `json:"threshold"`
+	Dir       string   `json:"dir"`
+	Profiles  []string `json:"profiles"`
+	Error     string   `json:"error,omitempty"`
+	// Removed lists older captures deleted to stay within MaxCaptures.
+	Removed []string `json:"removed,omitempty"`
+}
+
+// ProfileTrigger samples CPU usage, the live heap and the goroutine count
+// of a long-running process and captures cpu, heap and goroutine profiles
+// when a rule fires, so intermittent problems leave evidence behind.
+type ProfileTrigger struct {
+	config TriggerConfig
+
+	mu        sync.Mutex
+	last      *triggerSample
+	cpuSince  time.Time // start of the current high-CPU streak
+	heapFloor uint64
+	lastFired time.Time
+	capturing bool
+	captures  []Capture
+	cancel    context.CancelFunc
+	done      chan struct{}
+	wg        sync.WaitGroup
+
+	// sample and profile are swapped out in tests.
+	sample  func() triggerSample
+	profile func(ctx context.Context, dir string) ([]string, error)
+}
+
+// NewProfileTrigger creates a trigger. It does nothing until Start is
+// called.
+func NewProfileTrigger(config TriggerConfig) *ProfileTrigger {
+	if config.Dir == "" {
+		config.Dir = DefaultDiagnosticsDir()
+	}
+	if config.Interval <= 0 {
+		config.Interval = 5 * time.Second
+	}
+	if config.CPUFor <= 0 {
+		config.CPUFor = 30 * time.Second
+	}
+	if config.CPUProfile <= 0 {
+		config.CPUProfile = 10 * time.Second
+	}
+	if config.MaxCaptures <= 0 {
+		config.MaxCaptures = 10
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = 5 * time.Minute
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	t := &ProfileTrigger{config: config, sample: sampleTrigger}
+	t.profile = t.writeProfiles
+	return t
+}
+
+// Start begins sampling in the background.
+func (t *ProfileTrigger) Start(ctx context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	t.cancel = cancel
+	t.done = make(chan struct{})
+	go t.loop(ctx)
+}
+
+// Stop stops sampling and waits for a capture in progress; its CPU profile
+// is cut short.
+func (t *ProfileTrigger) Stop() {
+	t.mu.Lock()
+	cancel, done := t.cancel, t.done
+	t.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-done
+	t.wg.Wait()
+
+	t.mu.Lock()
+	t.cancel = nil
+	t.mu.Unlock()
+}
+
+// Captures returns the captures taken so far.
+func (t *ProfileTrigger) Captures() []Capture {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]Capture, len(t.captures))
+	copy(out, t.captures)
+	return out
+}
+
+func (t *ProfileTrigger) loop(ctx context.Context) {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.config.Interval)
+	defer ticker.Stop()
+
+	t.observe(t.sample())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if c := t.observe(t.sample()); c != nil {
+				t.wg.Add(1)
+				go func() {
+					defer t.wg.Done()
+					t.capture(ctx, c)
+				}()
+			}
+		}
+	}
+}
+
+// observe records a sample and returns the capture to take, if a rule
+// fired and no capture is running or cooling down.
+func (t *ProfileTrigger) observe(s triggerSample) *Capture {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prev := t.last
+	t.last = &s
+	if t.heapFloor == 0 || s.HeapBytes < t.heapFloor {
+		t.heapFloor = s.HeapBytes
+	}
+
+	var fired *Capture
+	cfg := t.config
+	if cfg.CPUPercent > 0 && prev != nil {
+		wall := s.At.Sub(prev.At)
+		percent := 0.0
+		if wall > 0 {
+			percent = float64(s.CPU-prev.CPU) / float64(wall) * 100
+		}
+		switch {
+		case percent <= cfg.CPUPercent:
+			t.cpuSince = time.Time{}
+		case t.cpuSince.IsZero():
+			t.cpuSince = prev.At
+		}
+		if !t.cpuSince.IsZero() && s.At.Sub(t.cpuSince) >= cfg.CPUFor {
+			fired = &Capture{
+				Trigger:   TriggerCPU,
+				Reason:    fmt.Sprintf("CPU %.0f%% for %s (threshold %.0f%%)", percent, s.At.Sub(t.cpuSince).Round(time.Second), cfg.CPUPercent),
+				Value:     percent,
+				Threshold: cfg.CPUPercent,
+			}
+		}
+	}
+	if growth := s.HeapBytes - t.heapFloor; fired == nil && cfg.HeapGrowthMB > 0 && growth > cfg.HeapGrowthMB<<20 {
+		fired = &Capture{
+			Trigger:   TriggerHeap,
+			Reason:    fmt.Sprintf("heap grew %s to %s (threshold %dMB)", formatBytes(growth), formatBytes(s.HeapBytes), cfg.HeapGrowthMB),
+			Value:     float64(growth >> 20),
+			Threshold: float64(cfg.HeapGrowthMB),
+		}
+	}
+	if fired == nil && cfg.Goroutines > 0 && s.Goroutines > cfg.Goroutines {
+		fired = &Capture{
+			Trigger:   TriggerGoroutines,
+			Reason:    fmt.Sprintf("%d goroutines (threshold %d)", s.Goroutines, cfg.Goroutines),
+			Value:     float64(s.Goroutines),
+			Threshold: float64(cfg.Goroutines),
+		}
+	}
+
+	if fired == nil || t.capturing || (!t.lastFired.IsZero() && s.At.Sub(t.lastFired) < cfg.Cooldown) {
+		return nil
+	}
+	t.capturing, t.lastFired = true, s.At
+	// 캡처 후에는 새 기준으로 다시 측정한다
+	t.cpuSince, t.heapFloor = time.Time{}, s.HeapBytes
+	fired.At = s.At
+	return fired
+}
+
+// capture writes the profiles, rotates old captures and records the audit
+// event.
+func (t *ProfileTrigger) capture(ctx context.Context, c *Capture) {
+	c.Dir = filepath.Join(t.config.Dir, fmt.Sprintf("%s-%s", c.At.Format("20060102-150405"), c.Trigger))
+	profiles, err := t.profile(ctx, c.Dir)
+	c.Profiles = profiles
+	if err != nil {
+		c.Error = err.Error()
+	}
+	c.Removed = t.rotate()
+
+	t.record(*c)
+
+	t.mu.Lock()
+	t.capturing = false
+	t.captures = append(t.captures, *c)
+	t.mu.Unlock()
+}
+
+// writeProfiles writes heap and goroutine profiles at once, then a CPU
+// profile over CPUProfile.
+func (t *ProfileTrigger) writeProfiles(ctx context.Context, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create capture directory: %w", err)
+	}
+
+	var paths []string
+	for _, name := range []string{"heap", "goroutine"} {
+		path := filepath.Join(dir, name+".pprof")
+		if err := writeLookupProfile(name, path); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+
+	path := filepath.Join(dir, "cpu.pprof")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return paths, fmt.Errorf("failed to create cpu profile: %w", err)
+	}
+	defer f.Close()
+	if err := pprof.StartCPUProfile(f); err != nil {
+		// Another CPU profile, e.g. from gz profile, is already running.
+		_ = os.Remove(path)
+		return paths, fmt.Errorf("failed to start cpu profile: %w", err)
+	}
+	timer := time.NewTimer(t.config.CPUProfile)
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		timer.Stop()
+	}
+	pprof.StopCPUProfile()
+	return append(paths, path), nil
+}
+
+func writeLookupProfile(name, path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s profile: %w", name, err)
+	}
+	if err := pprof.Lookup(name).WriteTo(f, 0); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s profile: %w", name, err)
+	}
+	return f.Close()
+}
+
+// rotate removes the oldest capture directories beyond MaxCaptures. Capture
+// directory names start with a timestamp, so they sort by age.
+func (t *ProfileTrigger) rotate() []string {
+	entries, err := os.ReadDir(t.config.Dir)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, e := range entries {
+		if e.IsDir() && isCaptureDir(e.Name()) {
+			dirs = append(dirs, e.Name())
+		}
+	}
+	sort.Strings(dirs)
+
+	var removed []string
+	for len(dirs) > t.config.MaxCaptures {
+		path := filepath.Join(t.config.Dir, dirs[0])
+		if err := os.RemoveAll(path); err != nil {
+			t.config.Logger.Warn("failed to remove old profile capture", "dir", path, "error", err)
+		} else {
+			removed = append(removed, path)
+		}
+		dirs = dirs[1:]
+	}
+	return removed
+}
+
+func isCaptureDir(name string) bool {
+	for _, trigger := range []string{TriggerCPU, TriggerHeap, TriggerGoroutines} {
+		if strings.HasSuffix(name, "-"+trigger) {
+			return true
+		}
+	}
+	return false
+}
+
+// record logs the capture and appends its audit event to the capture log
+// and the configured recorder.
+func (t *ProfileTrigger) record(c Capture) {
+	args := []any{"trigger", c.Trigger, "reason", c.Reason, "dir", c.Dir}
+	if c.Error != "" {
+		args = append(args, "error", c.Error)
+	}
+	t.config.Logger.Warn("anomaly detected, profiles captured", args...)
+
+	event := audit.Event{
+		Timestamp: c.At.UTC(),
+		Severity:  audit.SeverityWarn,
+		Action:    ActionProfileCapture,
+		Category:  "diagnostics",
+		Source:    "gz",
+		Resource:  c.Dir,
+		Outcome:   "success",
+		Message:   c.Reason,
+		Metadata: map[string]any{
+			"trigger":   c.Trigger,
+			"threshold": c.Threshold,
+			"value":     c.Value,
+			"profiles":  c.Profiles,
+		},
+		ErrMessage: c.Error,
+	}
+	if c.Error != "" {
+		event.Outcome = "failure"
+	}
+	if len(c.Removed) > 0 {
+		event.Metadata["rotated"] = c.Removed
+	}
+
+	if err := appendEvent(filepath.Join(t.config.Dir, captureLog), event); err != nil {
+		t.config.Logger.Warn("failed to write profile capture log", "error", err)
+	}
+	if t.config.Recorder != nil {
+		if err := t.config.Recorder.Record(event); err != nil {
+			t.config.Logger.Warn("failed to record profile capture audit event", "error", err)
+		}
+	}
+}
+
+func appendEvent(path string, event audit.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+const metricTriggerHeap = "/memory/classes/heap/objects:bytes"
+
+// sampleTrigger observes the running process.
+func sampleTrigger() triggerSample {
+	m := []metrics.Sample{{Name: metricTriggerHeap}}
+	metrics.Read(m)
+
+	user, system, _ := resourceUsage()
+	s := triggerSample{At: time.Now(), CPU: user + system, Goroutines: runtime.NumGoroutine()}
+	if m[0].Value.Kind() == metrics.KindUint64 {
+		s.HeapBytes = m[0].Value.Uint64()
+	}
+	return s
+}
+
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// AddTriggerFlags adds --capture-* flags to a long-running cmd and wraps its
+// RunE so a ProfileTrigger runs for the command's lifetime when any
+// threshold is set.
+func AddTriggerFlags(cmd *cobra.Command) {
+	var config TriggerConfig
+	cmd.Flags().Float64Var(&config.CPUPercent, "capture-cpu", 0, "Capture profiles when CPU usage stays above this percent of one core (0 disables)")
+	cmd.Flags().DurationVar(&config.CPUFor, "capture-cpu-for", 30*time.Second, "How long CPU usage must stay above --capture-cpu")
+	cmd.Flags().Uint64Var(&config.HeapGrowthMB, "capture-heap-growth", 0, "Capture profiles when the live heap grows by this many MB (0 disables)")
+	cmd.Flags().IntVar(&config.Goroutines, "capture-goroutines", 0, "Capture profiles when goroutines exceed this count (0 disables)")
+	cmd.Flags().StringVar(&config.Dir, "capture-dir", DefaultDiagnosticsDir(), "Directory for captured cpu, heap and goroutine profiles")
+	cmd.Flags().IntVar(&config.MaxCaptures, "capture-keep", 10, "Number of captures kept in --capture-dir")
+
+	run := cmd.RunE
+	if run == nil {
+		return
+	}
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		if !config.Enabled() {
+			return run(c, args)
+		}
+		if config.CPUPercent < 0 || config.Goroutines < 0 || config.MaxCaptures < 1 {
+			return fmt.Errorf("--capture-cpu and --capture-goroutines must not be negative and --capture-keep must be at least 1")
+		}
+
+		cfg := config
+		cfg.Logger = slog.New(slog.NewTextHandler(c.ErrOrStderr(), nil))
+		trigger := NewProfileTrigger(cfg)
+		trigger.Start(c.Context())
+		defer trigger.Stop()
+
+		fmt.Fprintf(c.ErrOrStderr(), "📸 Profile capture on anomalies enabled (dir=%s, keep=%d)\n", cfg.Dir, cfg.MaxCaptures)
+
+		return run(c, args)
+	}
+}
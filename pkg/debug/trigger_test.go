@@ -0,0 +1,148 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/audit"
+)
+
+func newTestTrigger(t *testing.T, config TriggerConfig) (*ProfileTrigger, *bytes.Buffer) {
+	t.Helper()
+	var logs bytes.Buffer
+	config.Dir = t.TempDir()
+	config.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+	trigger := NewProfileTrigger(config)
+	trigger.profile = func(_ context.Context, dir string) ([]string, error) {
+		require.NoError(t, os.MkdirAll(dir, 0o750))
+		return []string{filepath.Join(dir, "heap.pprof")}, nil
+	}
+	return trigger, &logs
+}
+
+func TestProfileTrigger_CPU(t *testing.T) {
+	trigger, _ := newTestTrigger(t, TriggerConfig{CPUPercent: 80, CPUFor: 20 * time.Second})
+	start := time.Now()
+	sample := func(sec int, cpu time.Duration) *Capture {
+		return trigger.observe(triggerSample{At: start.Add(time.Duration(sec) * time.Second), CPU: cpu})
+	}
+
+	assert.Nil(t, sample(0, 0))
+	assert.Nil(t, sample(10, 9*time.Second)) // 90% for 10s
+	assert.Nil(t, sample(20, 12*time.Second), "a dip resets the streak")
+	assert.Nil(t, sample(30, 21*time.Second))
+
+	c := sample(40, 31*time.Second)
+	require.NotNil(t, c)
+	assert.Equal(t, TriggerCPU, c.Trigger)
+	assert.Equal(t, "CPU 100% for 20s (threshold 80%)", c.Reason)
+
+	// The capture is still running, then cooling down.
+	assert.Nil(t, sample(50, 41*time.Second))
+	trigger.capture(context.Background(), c)
+	assert.Nil(t, sample(90, 81*time.Second))
+}
+
+func TestProfileTrigger_HeapAndGoroutines(t *testing.T) {
+	trigger, _ := newTestTrigger(t, TriggerConfig{HeapGrowthMB: 100, Goroutines: 500, Cooldown: time.Minute})
+	start := time.Now()
+
+	assert.Nil(t, trigger.observe(triggerSample{At: start, HeapBytes: 200 << 20, Goroutines: 10}))
+	assert.Nil(t, trigger.observe(triggerSample{At: start.Add(5 * time.Second), HeapBytes: 150 << 20, Goroutines: 10}))
+
+	c := trigger.observe(triggerSample{At: start.Add(10 * time.Second), HeapBytes: 260 << 20, Goroutines: 10})
+	require.NotNil(t, c, "growth is measured from the lowest heap")
+	assert.Equal(t, TriggerHeap, c.Trigger)
+	assert.Equal(t, 110.0, c.Value)
+	trigger.capture(context.Background(), c)
+
+	start = start.Add(2 * time.Minute)
+	assert.Nil(t, trigger.observe(triggerSample{At: start, HeapBytes: 300 << 20, Goroutines: 10}),
+		"the heap baseline moves to the capture")
+	c = trigger.observe(triggerSample{At: start.Add(5 * time.Second), HeapBytes: 300 << 20, Goroutines: 800})
+	require.NotNil(t, c)
+	assert.Equal(t, TriggerGoroutines, c.Trigger)
+	assert.Equal(t, "800 goroutines (threshold 500)", c.Reason)
+}
+
+// eventRecorder collects audit events.
+type eventRecorder []audit.Event
+
+func (r *eventRecorder) Record(e audit.Event) error {
+	*r = append(*r, e)
+	return nil
+}
+
+func TestProfileTrigger_CaptureRotatesAndAudits(t *testing.T) {
+	var recorded eventRecorder
+	trigger, logs := newTestTrigger(t, TriggerConfig{Goroutines: 1, MaxCaptures: 2, Recorder: &recorded})
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := range 3 {
+		at := start.Add(time.Duration(i) * time.Hour)
+		c := trigger.observe(triggerSample{At: at, Goroutines: 10})
+		require.NotNil(t, c)
+		trigger.capture(context.Background(), c)
+	}
+
+	captures := trigger.Captures()
+	require.Len(t, captures, 3)
+	assert.Equal(t, filepath.Join(trigger.config.Dir, "20250301-140000-goroutines"), captures[2].Dir)
+	assert.Equal(t, []string{captures[0].Dir}, captures[2].Removed)
+
+	entries, err := os.ReadDir(trigger.config.Dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.Equal(t, []string{"20250301-130000-goroutines", "20250301-140000-goroutines", captureLog}, names)
+
+	f, err := os.Open(filepath.Join(trigger.config.Dir, captureLog))
+	require.NoError(t, err)
+	defer f.Close()
+	var logged []audit.Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e audit.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		logged = append(logged, e)
+	}
+	require.Len(t, logged, 3)
+	assert.Equal(t, ActionProfileCapture, logged[0].Action)
+	assert.Equal(t, audit.SeverityWarn, logged[0].Severity)
+	assert.Equal(t, "goroutines", logged[0].Metadata["trigger"])
+	assert.Len(t, recorded, 3)
+	assert.Contains(t, logs.String(), "anomaly detected, profiles captured")
+}
+
+func TestWriteProfiles(t *testing.T) {
+	trigger := NewProfileTrigger(TriggerConfig{CPUProfile: 10 * time.Millisecond})
+	dir := filepath.Join(t.TempDir(), "capture")
+
+	paths, err := trigger.writeProfiles(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "heap.pprof"),
+		filepath.Join(dir, "goroutine.pprof"),
+		filepath.Join(dir, "cpu.pprof"),
+	}, paths)
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		require.NoError(t, err)
+		assert.Positive(t, info.Size(), p)
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/filesystem"
+)
+
+// ActionBundleUploaded is the audit action recorded for every bundle
+// upload attempt; its outcome is "success" or "failure".
+const ActionBundleUploaded = "debug.bundle.uploaded"
+
+// DefaultChunkSize is the chunk size of bundle uploads. S3 requires parts
+// of at least 5 MiB except the last one.
+const DefaultChunkSize = 8 << 20
+
+// ErrUploadExpired is returned by Uploader.Resume when the destination no
+// longer knows an interrupted upload; the upload then starts over.
+var ErrUploadExpired = errors.New("upload expired")
+
+// UploadPart is a chunk the destination has acknowledged.
+type UploadPart struct {
+	Number int    `json:"number"`
+	Size   int64  `json:"size"`
+	ETag   string `json:"etag,omitempty"`
+}
+
+// UploadState is the progress of a bundle upload. It is saved after every
+// chunk so that an interrupted transfer continues where it stopped.
+type UploadState struct {
+	Target    string       `json:"target"`
+	File      string       `json:"file"`
+	Size      int64        `json:"size"`
+	SHA256    string       `json:"sha256"`
+	ChunkSize int64        `json:"chunkSize"`
+	UploadID  string       `json:"uploadId"`
+	Parts     []UploadPart `json:"parts,omitempty"`
+	Started   time.Time    `json:"started"`
+}
+
+// Offset returns the number of bytes acknowledged so far.
+func (s *UploadState) Offset() int64 {
+	var n int64
+	for _, p := range s.Parts {
+		n += p.Size
+	}
+	return n
+}
+
+// Uploader is a destination for chunked bundle uploads.
+type Uploader interface {
+	// Start begins a new upload and sets state.UploadID.
+	Start(ctx context.Context, state *UploadState) error
+	// Resume checks an interrupted upload and returns the number of bytes
+	// the destination holds, or ErrUploadExpired.
+	Resume(ctx context.Context, state *UploadState) (int64, error)
+	// WriteChunk uploads the chunk at offset as part number part and
+	// returns the part's ETag, if the destination has one.
+	WriteChunk(ctx context.Context, state *UploadState, part int, offset int64, data []byte) (string, error)
+	// Finish completes the upload and returns the reference ID under which
+	// support finds the bundle.
+	Finish(ctx context.Context, state *UploadState) (string, error)
+	// String returns the destination for messages and the audit log,
+	// without credentials.
+	String() string
+}
+
+// UploadOptions control UploadBundle.
+type UploadOptions struct {
+	// StateDir holds the state of unfinished uploads; default
+	// DefaultUploadStateDir.
+	StateDir string
+	// ChunkSize defaults to DefaultChunkSize.
+	ChunkSize int64
+	// Retries is the number of extra attempts per chunk.
+	Retries int
+	// Progress is called after every chunk.
+	Progress func(done, total int64)
+}
+
+// UploadResult describes a finished upload.
+type UploadResult struct {
+	Reference string        `json:"reference"`
+	Target    string        `json:"target"`
+	Size      int64         `json:"size"`
+	SHA256    string        `json:"sha256"`
+	Chunks    int           `json:"chunks"`
+	Resumed   bool          `json:"resumed"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// retryDelay is the wait before the first retry of a chunk; it doubles
+// with every attempt. Tests shorten it.
+var retryDelay = time.Second
+
+// DefaultUploadStateDir returns the directory of unfinished uploads.
+func DefaultUploadStateDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".gzh", "debug", "uploads")
+}
+
+// DefaultUploadAuditLogPath returns the local audit log of bundle uploads.
+func DefaultUploadAuditLogPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "gzh-manager", "debug", "audit.jsonl")
+}
+
+// UploadBundle uploads file to uploader in chunks. An earlier interrupted
+// upload of the same file to the same destination is resumed.
+func UploadBundle(ctx context.Context, uploader Uploader, file string, opts UploadOptions) (*UploadResult, error) {
+	if opts.StateDir == "" {
+		opts.StateDir = DefaultUploadStateDir()
+	}
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = DefaultChunkSize
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer f.Close()
+	sum, size, err := fileDigest(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	start := time.Now()
+	statePath := filepath.Join(opts.StateDir, stateKey(uploader.String(), sum)+".json")
+	state, resumed := loadUploadState(statePath, uploader.String(), sum, size)
+	if resumed {
+		offset, err := uploader.Resume(ctx, state)
+		switch {
+		case errors.Is(err, ErrUploadExpired):
+			resumed = false
+		case err != nil:
+			return nil, fmt.Errorf("failed to resume upload: %w", err)
+		default:
+			state.Parts = acknowledgedParts(state.Parts, offset)
+		}
+	}
+	if !resumed {
+		abs, _ := filepath.Abs(file)
+		state = &UploadState{
+			Target:    uploader.String(),
+			File:      abs,
+			Size:      size,
+			SHA256:    sum,
+			ChunkSize: opts.ChunkSize,
+			Started:   start.UTC(),
+		}
+		if err := uploader.Start(ctx, state); err != nil {
+			return nil, fmt.Errorf("failed to start upload: %w", err)
+		}
+		if err := saveUploadState(statePath, state); err != nil {
+			return nil, err
+		}
+	}
+
+	buf := make([]byte, state.ChunkSize)
+	for offset := state.Offset(); offset < size; offset = state.Offset() {
+		n, err := f.ReadAt(buf[:min(state.ChunkSize, size-offset)], offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		part := len(state.Parts) + 1
+		etag, err := writeChunkWithRetry(ctx, uploader, state, part, offset, buf[:n], opts.Retries)
+		if err != nil {
+			return nil, fmt.Errorf("upload interrupted at %d of %d bytes: %w", offset, size, err)
+		}
+		state.Parts = append(state.Parts, UploadPart{Number: part, Size: int64(n), ETag: etag})
+		if err := saveUploadState(statePath, state); err != nil {
+			return nil, err
+		}
+		if opts.Progress != nil {
+			opts.Progress(state.Offset(), size)
+		}
+	}
+
+	reference, err := uploader.Finish(ctx, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete upload: %w", err)
+	}
+	_ = os.Remove(statePath)
+
+	return &UploadResult{
+		Reference: reference,
+		Target:    uploader.String(),
+		Size:      size,
+		SHA256:    sum,
+		Chunks:    len(state.Parts),
+		Resumed:   resumed,
+		Duration:  time.Since(start),
+	}, nil
+}
+
+func writeChunkWithRetry(ctx context.Context, uploader Uploader, state *UploadState, part int, offset int64, data []byte, retries int) (string, error) {
+	delay := retryDelay
+	for attempt := 0; ; attempt++ {
+		etag, err := uploader.WriteChunk(ctx, state, part, offset, data)
+		if err == nil || attempt >= retries {
+			return etag, err
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// acknowledgedParts keeps the parts that lie within offset; a destination
+// that lost the tail of an upload gets those chunks again.
+func acknowledgedParts(parts []UploadPart, offset int64) []UploadPart {
+	var n int64
+	for i, p := range parts {
+		if n+p.Size > offset {
+			return parts[:i]
+		}
+		n += p.Size
+	}
+	return parts
+}
+
+func fileDigest(f *os.File) (string, int64, error) {
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+func stateKey(target, sum string) string {
+	h := sha256.Sum256([]byte(target + "\n" + sum))
+	return hex.EncodeToString(h[:8])
+}
+
+// loadUploadState returns the saved state of an upload of the same content
+// to the same destination, if there is one.
+func loadUploadState(path, target, sum string, size int64) (*UploadState, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var state UploadState
+	if json.Unmarshal(data, &state) != nil || state.Target != target || state.SHA256 != sum ||
+		state.Size != size || state.UploadID == "" || state.ChunkSize <= 0 {
+		return nil, false
+	}
+	return &state, true
+}
+
+func saveUploadState(path string, state *UploadState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create upload state directory: %w", err)
+	}
+	if err := filesystem.WriteFileAtomic(filesystem.OS(), path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to save upload state: %w", err)
+	}
+	return nil
+}
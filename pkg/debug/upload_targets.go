@@ -0,0 +1,231 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/cloud"
+)
+
+// NewUploader returns the uploader for a destination URL:
+//
+//	https://support.example.com/files   tus 1.0 resumable upload endpoint
+//	s3://bucket/prefix?region=...       S3 multipart upload
+//
+// token, when set, is sent as a bearer token to HTTPS endpoints. Plain
+// http is only accepted for loopback addresses.
+func NewUploader(ctx context.Context, rawURL, token string) (Uploader, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" {
+		return nil, fmt.Errorf("invalid upload URL %q: use https:// or s3://", rawURL)
+	}
+	switch u.Scheme {
+	case "https", "http":
+		if u.Scheme == "http" && !isLoopback(u.Hostname()) {
+			return nil, fmt.Errorf("refusing to upload over plain http to %s: use https", u.Host)
+		}
+		return &TusUploader{Endpoint: rawURL, Token: token, Client: &http.Client{Timeout: 10 * time.Minute}}, nil
+	case "s3":
+		store, err := cloud.OpenObjectStore(ctx, rawURL)
+		if err != nil {
+			return nil, err
+		}
+		return &S3Uploader{Store: store.(*cloud.S3Store)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported upload scheme %q (use https or s3)", u.Scheme)
+	}
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// tusVersion is the version of the tus resumable upload protocol spoken
+// to HTTPS endpoints (https://tus.io/protocols/resumable-upload).
+const tusVersion = "1.0.0"
+
+// TusUploader uploads to an HTTPS endpoint implementing the tus protocol:
+// POST creates the upload, PATCH appends chunks and HEAD reports the
+// received offset for resuming. The reference ID is the Upload-Reference
+// response header of the last chunk, or else the last path segment of the
+// upload URL.
+type TusUploader struct {
+	Endpoint string
+	Token    string
+	Client   *http.Client
+}
+
+func (t *TusUploader) String() string {
+	u, err := url.Parse(t.Endpoint)
+	if err != nil {
+		return t.Endpoint
+	}
+	u.User, u.RawQuery = nil, ""
+	return u.String()
+}
+
+func (t *TusUploader) newRequest(ctx context.Context, method, rawURL string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Tus-Resumable", tusVersion)
+	if t.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.Token)
+	}
+	return req, nil
+}
+
+// Start creates the upload.
+func (t *TusUploader) Start(ctx context.Context, state *UploadState) error {
+	req, err := t.newRequest(ctx, http.MethodPost, t.Endpoint, http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Upload-Length", strconv.FormatInt(state.Size, 10))
+	req.Header.Set("Upload-Metadata", "filename "+base64.StdEncoding.EncodeToString([]byte(filepath.Base(state.File)))+
+		",sha256 "+base64.StdEncoding.EncodeToString([]byte(state.SHA256)))
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return httpError(resp)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return fmt.Errorf("upload endpoint returned no upload location")
+	}
+	base, _ := url.Parse(t.Endpoint)
+	state.UploadID = base.ResolveReference(location).String()
+	return nil
+}
+
+// Resume asks the endpoint for the received offset.
+func (t *TusUploader) Resume(ctx context.Context, state *UploadState) (int64, error) {
+	req, err := t.newRequest(ctx, http.MethodHead, state.UploadID, http.NoBody)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return 0, ErrUploadExpired
+	case resp.StatusCode/100 != 2:
+		return 0, httpError(resp)
+	}
+	offset, err := strconv.ParseInt(resp.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("upload endpoint returned no offset")
+	}
+	return offset, nil
+}
+
+// WriteChunk appends data at offset.
+func (t *TusUploader) WriteChunk(ctx context.Context, state *UploadState, _ int, offset int64, data []byte) (string, error) {
+	req, err := t.newRequest(ctx, http.MethodPatch, state.UploadID, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/offset+octet-stream")
+	req.Header.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return "", httpError(resp)
+	}
+	if got := resp.Header.Get("Upload-Offset"); got != strconv.FormatInt(offset+int64(len(data)), 10) {
+		return "", fmt.Errorf("upload endpoint acknowledged offset %q, expected %d", got, offset+int64(len(data)))
+	}
+	return resp.Header.Get("Upload-Reference"), nil
+}
+
+// Finish returns the reference ID; the last chunk already completed the
+// upload.
+func (t *TusUploader) Finish(_ context.Context, state *UploadState) (string, error) {
+	if n := len(state.Parts); n > 0 && state.Parts[n-1].ETag != "" {
+		return state.Parts[n-1].ETag, nil
+	}
+	u, err := url.Parse(state.UploadID)
+	if err != nil {
+		return "", err
+	}
+	return path.Base(u.Path), nil
+}
+
+func httpError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("upload endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
+
+// S3Uploader uploads to an S3 bucket with a multipart upload to
+// <prefix>/<sha256 prefix>/<file name>. The reference ID is the object URL.
+type S3Uploader struct {
+	Store *cloud.S3Store
+}
+
+func (s *S3Uploader) String() string { return s.Store.String() }
+
+func (s *S3Uploader) key(state *UploadState) string {
+	return path.Join(state.SHA256[:12], filepath.Base(state.File))
+}
+
+// Start creates the multipart upload.
+func (s *S3Uploader) Start(ctx context.Context, state *UploadState) error {
+	id, err := s.Store.CreateMultipartUpload(ctx, s.key(state))
+	if err != nil {
+		return err
+	}
+	state.UploadID = id
+	return nil
+}
+
+// Resume trusts the saved parts: S3 keeps uploaded parts until the upload
+// is completed or aborted, and a part uploaded again replaces itself.
+func (s *S3Uploader) Resume(_ context.Context, state *UploadState) (int64, error) {
+	return state.Offset(), nil
+}
+
+// WriteChunk uploads one part.
+func (s *S3Uploader) WriteChunk(ctx context.Context, state *UploadState, part int, _ int64, data []byte) (string, error) {
+	return s.Store.UploadPart(ctx, s.key(state), state.UploadID, part, bytes.NewReader(data), int64(len(data)))
+}
+
+// Finish completes the multipart upload.
+func (s *S3Uploader) Finish(ctx context.Context, state *UploadState) (string, error) {
+	parts := make([]cloud.CompletedPart, len(state.Parts))
+	for i, p := range state.Parts {
+		parts[i] = cloud.CompletedPart{PartNumber: p.Number, ETag: p.ETag}
+	}
+	if err := s.Store.CompleteMultipartUpload(ctx, s.key(state), state.UploadID, parts); err != nil {
+		return "", err
+	}
+	return s.Store.String() + "/" + s.key(state), nil
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package debug
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTusServer implements the tus subset used by TusUploader. failAt makes
+// the PATCH at that offset fail once.
+type fakeTusServer struct {
+	mu      sync.Mutex
+	uploads map[string]*bytes.Buffer
+	failAt  int64
+	created int
+	auth    string
+}
+
+func newFakeTusServer(t *testing.T) (*fakeTusServer, *httptest.Server) {
+	t.Helper()
+	f := &fakeTusServer{uploads: map[string]*bytes.Buffer{}, failAt: -1}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeTusServer) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Header.Get("Tus-Resumable") != tusVersion {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	f.auth = r.Header.Get("Authorization")
+	id := strings.TrimPrefix(r.URL.Path, "/files/")
+
+	switch r.Method {
+	case http.MethodPost:
+		if r.Header.Get("Upload-Length") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.created++
+		id = fmt.Sprintf("ref-%d", f.created)
+		f.uploads[id] = &bytes.Buffer{}
+		w.Header().Set("Location", "/files/"+id)
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodHead:
+		buf, ok := f.uploads[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Upload-Offset", strconv.Itoa(buf.Len()))
+	case http.MethodPatch:
+		buf, ok := f.uploads[id]
+		offset, _ := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+		if !ok || offset != int64(buf.Len()) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		if offset == f.failAt {
+			f.failAt = -1
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = io.Copy(buf, r.Body)
+		w.Header().Set("Upload-Offset", strconv.Itoa(buf.Len()))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeTusServer) content(id string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.uploads[id].String()
+}
+
+func writeBundle(t *testing.T, size int) (string, []byte) {
+	t.Helper()
+	data := bytes.Repeat([]byte("0123456789abcdef"), size/16+1)[:size]
+	file := filepath.Join(t.TempDir(), "gz-report.tar.gz")
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+	return file, data
+}
+
+func TestUploadBundleResumes(t *testing.T) {
+	fake, srv := newFakeTusServer(t)
+	file, data := writeBundle(t, 100)
+	stateDir := t.TempDir()
+
+	uploader, err := NewUploader(context.Background(), srv.URL+"/files", "s3cret")
+	require.NoError(t, err)
+	opts := UploadOptions{StateDir: stateDir, ChunkSize: 32}
+
+	fake.failAt = 64
+	_, err = UploadBundle(context.Background(), uploader, file, opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "upload interrupted at 64 of 100 bytes")
+	states, _ := filepath.Glob(filepath.Join(stateDir, "*.json"))
+	require.Len(t, states, 1, "the state of the interrupted upload is kept")
+
+	var progress []int64
+	opts.Progress = func(done, _ int64) { progress = append(progress, done) }
+	result, err := UploadBundle(context.Background(), uploader, file, opts)
+	require.NoError(t, err)
+	assert.True(t, result.Resumed)
+	assert.Equal(t, "ref-1", result.Reference)
+	assert.Equal(t, 4, result.Chunks)
+	assert.Equal(t, []int64{96, 100}, progress, "only the missing chunks are sent")
+	assert.Equal(t, string(data), fake.content("ref-1"))
+	assert.Equal(t, "Bearer s3cret", fake.auth)
+
+	states, _ = filepath.Glob(filepath.Join(stateDir, "*.json"))
+	assert.Empty(t, states, "a finished upload leaves no state")
+}
+
+func TestUploadBundleRestartsExpiredUpload(t *testing.T) {
+	fake, srv := newFakeTusServer(t)
+	file, data := writeBundle(t, 50)
+	opts := UploadOptions{StateDir: t.TempDir(), ChunkSize: 20}
+	uploader := &TusUploader{Endpoint: srv.URL + "/files", Client: srv.Client()}
+
+	fake.failAt = 20
+	_, err := UploadBundle(context.Background(), uploader, file, opts)
+	require.Error(t, err)
+
+	fake.mu.Lock()
+	delete(fake.uploads, "ref-1")
+	fake.mu.Unlock()
+
+	result, err := UploadBundle(context.Background(), uploader, file, opts)
+	require.NoError(t, err)
+	assert.False(t, result.Resumed)
+	assert.Equal(t, "ref-2", result.Reference)
+	assert.Equal(t, string(data), fake.content("ref-2"))
+}
+
+func TestUploadBundleRetriesChunks(t *testing.T) {
+	saved := retryDelay
+	retryDelay = 0
+	t.Cleanup(func() { retryDelay = saved })
+
+	fake, srv := newFakeTusServer(t)
+	file, data := writeBundle(t, 40)
+	fake.failAt = 16
+
+	uploader := &TusUploader{Endpoint: srv.URL + "/files", Client: srv.Client()}
+	result, err := UploadBundle(context.Background(), uploader, file, UploadOptions{StateDir: t.TempDir(), ChunkSize: 16, Retries: 2})
+	require.NoError(t, err)
+	assert.False(t, result.Resumed)
+	assert.Equal(t, 3, result.Chunks)
+	assert.Equal(t, string(data), fake.content("ref-1"))
+}
+
+func TestNewUploader(t *testing.T) {
+	ctx := context.Background()
+	_, err := NewUploader(ctx, "http://support.example.com/files", "")
+	assert.ErrorContains(t, err, "plain http")
+	_, err = NewUploader(ctx, "ftp://support.example.com/files", "")
+	assert.Error(t, err)
+
+	u, err := NewUploader(ctx, "https://user:pw@support.example.com/files?key=x", "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://support.example.com/files", u.String())
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	u, err = NewUploader(ctx, "s3://bucket/support?region=eu-west-1", "")
+	require.NoError(t, err)
+	assert.IsType(t, &S3Uploader{}, u)
+}
@@ -0,0 +1,357 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// xssiPrefix is prepended by Gerrit to every JSON response.
+const xssiPrefix = ")]}'"
+
+// timeLayout is the timestamp format of the Gerrit REST API (always UTC).
+const timeLayout = "2006-01-02 15:04:05.000000000"
+
+// ClientOptions configures a Gerrit client.
+type ClientOptions struct {
+	// BaseURL is the Gerrit server, e.g. https://review.example.com.
+	BaseURL string
+
+	// Username and Password are the account name and HTTP password from
+	// Settings → HTTP Credentials. Requests are anonymous when empty.
+	Username string
+	Password string
+
+	// HTTPClient overrides the client used for API requests.
+	HTTPClient *http.Client
+}
+
+// Client is a Gerrit REST API client.
+type Client struct {
+	baseURL    *url.URL
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the Gerrit server at opts.BaseURL.
+func NewClient(opts ClientOptions) (*Client, error) {
+	if opts.BaseURL == "" {
+		return nil, fmt.Errorf("gerrit base URL is required")
+	}
+	base, err := url.Parse(strings.TrimSuffix(opts.BaseURL, "/"))
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid gerrit base URL: %s", opts.BaseURL)
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: metrics.InstrumentTransport("gerrit", httpclient.ProviderTransport("gerrit")),
+		}
+	}
+
+	return &Client{
+		baseURL:    base,
+		username:   opts.Username,
+		password:   opts.Password,
+		httpClient: httpClient,
+	}, nil
+}
+
+// BaseURL returns the server URL.
+func (c *Client) BaseURL() string {
+	return c.baseURL.String()
+}
+
+// Authenticated reports whether requests carry credentials.
+func (c *Client) Authenticated() bool {
+	return c.username != "" && c.password != ""
+}
+
+// endpoint builds the URL of a REST endpoint, using the authenticated "/a/"
+// prefix when credentials are configured.
+func (c *Client) endpoint(path string, query url.Values) string {
+	u := *c.baseURL
+	prefix := u.Path
+	if c.Authenticated() {
+		prefix += "/a"
+	}
+	// 프로젝트 이름의 '/'는 경로 구분자가 아니므로 %2F 인코딩을 유지한다
+	u.RawPath = prefix + path
+	u.Path, _ = url.PathUnescape(u.RawPath)
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// get performs a GET request and decodes the JSON response into out.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	body, err := c.do(ctx, path, query)
+	if err != nil {
+		return err
+	}
+
+	body = bytes.TrimPrefix(body, []byte(xssiPrefix))
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode gerrit response: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint(path, query), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "gzh-cli")
+	if c.Authenticated() {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", provider.ErrNetworkError, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gerrit response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("%w: check the gerrit HTTP password", provider.ErrUnauthorized)
+	case http.StatusForbidden:
+		return nil, provider.ErrForbidden
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", provider.ErrNotFound, path)
+	default:
+		return nil, fmt.Errorf("gerrit request %s failed: %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+}
+
+// Project is a Gerrit project (repository).
+type Project struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	State       string `json:"state,omitempty"` // ACTIVE, READ_ONLY, HIDDEN
+}
+
+// ProjectListOptions filters ListProjects.
+type ProjectListOptions struct {
+	// Prefix limits the result to projects whose name starts with Prefix.
+	Prefix string
+
+	// Match limits the result to projects whose name contains Match.
+	Match string
+
+	// Limit caps the number of projects (0 = all).
+	Limit int
+}
+
+// projectPageSize is the page size used while listing projects.
+const projectPageSize = 500
+
+// ListProjects lists projects sorted by name.
+func (c *Client) ListProjects(ctx context.Context, opts ProjectListOptions) ([]Project, error) {
+	var projects []Project
+
+	for skip := 0; ; skip += projectPageSize {
+		query := url.Values{}
+		query.Set("d", "")
+		query.Set("n", strconv.Itoa(projectPageSize))
+		query.Set("S", strconv.Itoa(skip))
+		if opts.Prefix != "" {
+			query.Set("p", opts.Prefix)
+		}
+		if opts.Match != "" {
+			query.Set("m", opts.Match)
+		}
+
+		var page map[string]Project
+		if err := c.get(ctx, "/projects/", query, &page); err != nil {
+			return nil, err
+		}
+
+		names := make([]string, 0, len(page))
+		for name := range page {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			project := page[name]
+			project.Name = name
+			projects = append(projects, project)
+		}
+
+		if len(page) < projectPageSize || (opts.Limit > 0 && len(projects) >= opts.Limit) {
+			break
+		}
+	}
+
+	if opts.Limit > 0 && len(projects) > opts.Limit {
+		projects = projects[:opts.Limit]
+	}
+	return projects, nil
+}
+
+// GetProject returns a single project.
+func (c *Client) GetProject(ctx context.Context, name string) (*Project, error) {
+	var project Project
+	if err := c.get(ctx, "/projects/"+url.PathEscape(name), nil, &project); err != nil {
+		return nil, err
+	}
+	project.Name = name
+	return &project, nil
+}
+
+// GetHead returns the default branch of a project without the refs/heads/ prefix.
+func (c *Client) GetHead(ctx context.Context, name string) (string, error) {
+	var head string
+	if err := c.get(ctx, "/projects/"+url.PathEscape(name)+"/HEAD", nil, &head); err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(head, "refs/heads/"), nil
+}
+
+// Account is a Gerrit user account.
+type Account struct {
+	ID       int    `json:"_account_id"` //nolint:tagliatelle // Gerrit API field
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// Self returns the authenticated account.
+func (c *Client) Self(ctx context.Context) (*Account, error) {
+	if !c.Authenticated() {
+		return nil, fmt.Errorf("%w: gerrit credentials are not configured", provider.ErrUnauthorized)
+	}
+	var account Account
+	if err := c.get(ctx, "/accounts/self", nil, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Version returns the Gerrit server version.
+func (c *Client) Version(ctx context.Context) (string, error) {
+	var version string
+	if err := c.get(ctx, "/config/server/version", nil, &version); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// Change is a Gerrit change (code review).
+type Change struct {
+	ID         string    `json:"id"`
+	Number     int       `json:"_number"`   //nolint:tagliatelle // Gerrit API field
+	ChangeID   string    `json:"change_id"` //nolint:tagliatelle // Gerrit API field
+	Project    string    `json:"project"`
+	Branch     string    `json:"branch"`
+	Topic      string    `json:"topic,omitempty"`
+	Subject    string    `json:"subject"`
+	Status     string    `json:"status"` // NEW, MERGED, ABANDONED
+	Owner      Account   `json:"owner"`
+	Insertions int       `json:"insertions"`
+	Deletions  int       `json:"deletions"`
+	Created    Timestamp `json:"created"`
+	Updated    Timestamp `json:"updated"`
+}
+
+// Timestamp is a Gerrit REST API timestamp.
+type Timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON parses the "2006-01-02 15:04:05.000000000" UTC format.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		return nil
+	}
+	parsed, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return fmt.Errorf("invalid gerrit timestamp %q: %w", s, err)
+	}
+	t.Time = parsed.UTC()
+	return nil
+}
+
+// ChangeQuery selects changes for ListChanges.
+type ChangeQuery struct {
+	// Query uses Gerrit search syntax, e.g. "status:open project:platform/api".
+	// Defaults to "status:open".
+	Query string
+
+	// Limit caps the number of changes (0 = server default).
+	Limit int
+}
+
+// ListChanges returns changes matching the query, most recently updated first.
+func (c *Client) ListChanges(ctx context.Context, q ChangeQuery) ([]Change, error) {
+	query := url.Values{}
+	if q.Query == "" {
+		q.Query = "status:open"
+	}
+	query.Set("q", q.Query)
+	query.Set("o", "DETAILED_ACCOUNTS")
+	if q.Limit > 0 {
+		query.Set("n", strconv.Itoa(q.Limit))
+	}
+
+	var changes []Change
+	if err := c.get(ctx, "/changes/", query, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// CommitMsgHook downloads the commit-msg hook that adds Change-Id footers.
+func (c *Client) CommitMsgHook(ctx context.Context) ([]byte, error) {
+	u := *c.baseURL
+	u.Path += "/tools/hooks/commit-msg"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "gzh-cli")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download commit-msg hook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download commit-msg hook: %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gerrit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a/projects/", func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "alice" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.EscapedPath() {
+		case "/a/projects/":
+			assert.Equal(t, "platform/", r.URL.Query().Get("p"))
+			_, _ = w.Write([]byte(")]}'\n" + `{
+  "platform/web": {"id": "platform%2Fweb", "state": "ACTIVE"},
+  "platform/api": {"id": "platform%2Fapi", "description": "API", "state": "ACTIVE"}
+}`))
+		case "/a/projects/platform%2Fapi":
+			_, _ = w.Write([]byte(")]}'\n" + `{"id": "platform%2Fapi", "description": "API"}`))
+		case "/a/projects/platform%2Fapi/HEAD":
+			_, _ = w.Write([]byte(")]}'\n" + `"refs/heads/main"`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("/a/changes/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "status:open project:platform/api", r.URL.Query().Get("q"))
+		_, _ = w.Write([]byte(")]}'\n" + `[{
+  "id": "platform%2Fapi~main~I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "_number": 3965,
+  "change_id": "I8473b95934b5732ac55d26311a706c9c2bde9940",
+  "project": "platform/api",
+  "branch": "main",
+  "subject": "Add retry to client",
+  "status": "NEW",
+  "owner": {"_account_id": 1000096, "name": "Alice", "email": "alice@example.com", "username": "alice"},
+  "insertions": 34,
+  "deletions": 101,
+  "created": "2013-02-12 11:43:31.000000000",
+  "updated": "2013-02-21 11:16:36.775000000"
+}]`))
+	})
+	mux.HandleFunc("/tools/hooks/commit-msg", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("#!/bin/sh\n# Change-Id hook\n"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestClient(t *testing.T, server *httptest.Server) *Client {
+	t.Helper()
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, Username: "alice", Password: "secret"})
+	require.NoError(t, err)
+	return client
+}
+
+func TestClient_ListProjects(t *testing.T) {
+	client := newTestClient(t, newTestServer(t))
+
+	projects, err := client.ListProjects(context.Background(), ProjectListOptions{Prefix: "platform/"})
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+	assert.Equal(t, "platform/api", projects[0].Name, "sorted by name")
+	assert.Equal(t, "API", projects[0].Description)
+	assert.Equal(t, "platform/web", projects[1].Name)
+}
+
+func TestClient_ProjectNamesAreEscaped(t *testing.T) {
+	client := newTestClient(t, newTestServer(t))
+
+	project, err := client.GetProject(context.Background(), "platform/api")
+	require.NoError(t, err)
+	assert.Equal(t, "platform/api", project.Name)
+
+	head, err := client.GetHead(context.Background(), "platform/api")
+	require.NoError(t, err)
+	assert.Equal(t, "main", head)
+}
+
+func TestClient_Unauthorized(t *testing.T) {
+	server := newTestServer(t)
+	client, err := NewClient(ClientOptions{BaseURL: server.URL, Username: "alice", Password: "wrong"})
+	require.NoError(t, err)
+
+	_, err = client.ListProjects(context.Background(), ProjectListOptions{Prefix: "platform/"})
+	assert.ErrorIs(t, err, provider.ErrUnauthorized)
+}
+
+func TestClient_ListChanges(t *testing.T) {
+	client := newTestClient(t, newTestServer(t))
+
+	changes, err := client.ListChanges(context.Background(), ChangeQuery{Query: "status:open project:platform/api"})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+
+	change := changes[0]
+	assert.Equal(t, 3965, change.Number)
+	assert.Equal(t, "NEW", change.Status)
+	assert.Equal(t, "alice", change.Owner.Username)
+	assert.Equal(t, time.Date(2013, 2, 21, 11, 16, 36, 775000000, time.UTC), change.Updated.Time)
+}
+
+func TestClient_CloneURL(t *testing.T) {
+	anonymous, err := NewClient(ClientOptions{BaseURL: "https://review.example.com/"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://review.example.com/platform/api.git", anonymous.CloneURL("platform/api"))
+
+	authenticated, err := NewClient(ClientOptions{BaseURL: "https://review.example.com", Username: "alice", Password: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://alice@review.example.com/a/platform/api.git", authenticated.CloneURL("platform/api"),
+		"the password is not part of the stored remote")
+
+	_, err = NewClient(ClientOptions{BaseURL: "review.example.com"})
+	assert.Error(t, err)
+}
+
+func TestInstallCommitMsgHook(t *testing.T) {
+	client := newTestClient(t, newTestServer(t))
+	repo := t.TempDir()
+
+	require.NoError(t, InstallCommitMsgHook(context.Background(), client, repo))
+
+	info, err := os.Stat(filepath.Join(repo, ".git", "hooks", "commit-msg"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode().Perm()&0o100, "hook is executable")
+}
+
+func TestGerritProvider_ListRepositories(t *testing.T) {
+	server := newTestServer(t)
+	p, err := NewGerritProvider(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, p.Authenticate(context.Background(), provider.Credentials{
+		Type: provider.CredentialTypeToken, Username: "alice", Token: "secret",
+	}))
+
+	list, err := p.ListRepositories(context.Background(), provider.ListOptions{Organization: "platform/", PerPage: 1, Page: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.TotalCount)
+	require.Len(t, list.Repositories, 1)
+	assert.Equal(t, "platform/web", list.Repositories[0].FullName)
+	assert.Equal(t, "web", list.Repositories[0].Name)
+	assert.False(t, list.HasNext)
+
+	_, err = p.CreateRepository(context.Background(), provider.CreateRepoRequest{})
+	assert.ErrorIs(t, err, provider.ErrNotSupported)
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/gizzahub/gzh-cli/internal/git"
+)
+
+// CloneURL returns the HTTPS clone URL of a project. Authenticated clients
+// get the "/a/" URL with the username, so git asks its credential helper for
+// the HTTP password instead of storing it in the remote.
+func (c *Client) CloneURL(project string) string {
+	u := *c.baseURL
+	if c.Authenticated() {
+		u.Path += "/a"
+		u.User = url.User(c.username)
+	}
+	u.Path += "/" + project + ".git"
+	return u.String()
+}
+
+// cloneURLWithPassword embeds the HTTP password for the initial clone only.
+func (c *Client) cloneURLWithPassword(project string) string {
+	if !c.Authenticated() {
+		return c.CloneURL(project)
+	}
+	u, _ := url.Parse(c.CloneURL(project))
+	u.User = url.UserPassword(c.username, c.password)
+	return u.String()
+}
+
+// Clone clones a project into targetPath and installs the commit-msg hook.
+// An empty branch checks out the project HEAD.
+func Clone(ctx context.Context, client *Client, project, targetPath, branch string) error {
+	executor, err := git.NewSecureGitExecutor()
+	if err != nil {
+		return fmt.Errorf("failed to create secure git executor: %w", err)
+	}
+
+	if err := os.MkdirAll(targetPath, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", targetPath, err)
+	}
+
+	if err := executor.ExecuteSecure(ctx, targetPath, "clone", client.cloneURLWithPassword(project), "."); err != nil {
+		return fmt.Errorf("clone failed (project: %s, targetPath: %s): %w", project, targetPath, err)
+	}
+	if branch != "" {
+		if err := executor.ExecuteSecure(ctx, targetPath, "checkout", branch); err != nil {
+			return fmt.Errorf("failed to check out %s: %w", branch, err)
+		}
+	}
+
+	// 비밀번호가 .git/config에 남지 않도록 원격 URL을 되돌린다
+	if client.Authenticated() {
+		if err := executor.ExecuteSecure(ctx, targetPath, "remote", "set-url", "origin", client.CloneURL(project)); err != nil {
+			return fmt.Errorf("failed to reset remote URL: %w", err)
+		}
+	}
+
+	return InstallCommitMsgHook(ctx, client, targetPath)
+}
+
+// InstallCommitMsgHook installs the server's commit-msg hook into the
+// repository at repoPath. An existing hook is replaced.
+func InstallCommitMsgHook(ctx context.Context, client *Client, repoPath string) error {
+	hook, err := client.CommitMsgHook(ctx)
+	if err != nil {
+		return err
+	}
+
+	hooksDir := filepath.Join(repoPath, ".git", "hooks")
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	//nolint:gosec // git hooks must be executable
+	if err := os.WriteFile(filepath.Join(hooksDir, "commit-msg"), hook, 0o755); err != nil {
+		return fmt.Errorf("failed to install commit-msg hook: %w", err)
+	}
+	return nil
+}
+
+// update refreshes an existing clone with the given strategy.
+func update(ctx context.Context, executor *git.SecureGitExecutor, repoPath, strategy string) error {
+	switch strategy {
+	case "pull":
+		return executor.ExecuteSecure(ctx, repoPath, "pull")
+	case "fetch":
+		return executor.ExecuteSecure(ctx, repoPath, "fetch", "--prune")
+	case "", "reset":
+		if err := executor.ExecuteSecure(ctx, repoPath, "fetch", "--prune"); err != nil {
+			return err
+		}
+		return executor.ExecuteSecure(ctx, repoPath, "reset", "--hard", "FETCH_HEAD")
+	default:
+		return fmt.Errorf("unsupported strategy: %s", strategy)
+	}
+}
+
+// RefreshAll clones every project under prefix into targetPath and updates
+// projects that were cloned before. Project names keep their hierarchy, so
+// "platform/api" is cloned to targetPath/platform/api. Failures of single
+// projects are reported and do not stop the others.
+func RefreshAll(ctx context.Context, client *Client, targetPath, prefix, strategy string) error {
+	projects, err := client.ListProjects(ctx, ProjectListOptions{Prefix: prefix})
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	executor, err := git.NewSecureGitExecutor()
+	if err != nil {
+		return fmt.Errorf("failed to create secure git executor: %w", err)
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	sem := semaphore.NewWeighted(5) // Max 5 concurrent git operations
+
+	var mu sync.Mutex
+
+	for _, project := range projects {
+		// All-Projects와 All-Users는 서버 설정 저장소이므로 건너뛴다
+		if project.Name == "All-Projects" || project.Name == "All-Users" || project.State == "HIDDEN" {
+			continue
+		}
+
+		g.Go(func() error {
+			if err := sem.Acquire(gCtx, 1); err != nil {
+				return err
+			}
+			defer sem.Release(1)
+
+			repoPath := filepath.Join(targetPath, filepath.FromSlash(project.Name))
+			var opErr error
+			if _, statErr := os.Stat(filepath.Join(repoPath, ".git")); statErr == nil {
+				opErr = update(gCtx, executor, repoPath, strategy)
+			} else {
+				opErr = Clone(gCtx, client, project.Name, repoPath, "")
+			}
+			if opErr != nil {
+				mu.Lock()
+				fmt.Printf("failed to refresh project %s: %v\n", project.Name, opErr)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("error in concurrent git operations: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package gerrit provides Gerrit Code Review integration for repository
+// discovery, cloning and change listing.
+//
+// This package handles:
+//   - Gerrit REST API client (projects and changes endpoints)
+//   - Project listing by name prefix, used as the "organization" of bulk clone
+//   - Cloning with the Gerrit commit-msg hook installed, so new commits get
+//     a Change-Id footer and can be pushed for review
+//   - Listing changes (reviews) with Gerrit query syntax
+//   - The unified provider.GitProvider implementation
+//
+// Gerrit prefixes JSON responses with ")]}'" to prevent XSSI; the client
+// strips it. Authenticated requests use the "/a/" endpoint prefix with the
+// account's HTTP password.
+//
+// Main functions:
+//   - NewClient: Create a REST API client for a Gerrit server
+//   - Clone: Clone a project and install the commit-msg hook
+//   - RefreshAll: Clone or update every project under a prefix
+//   - CreateGerritProvider: Create the unified provider from configuration
+package gerrit
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// Environment variables used when the configuration has no credentials.
+const (
+	EnvUsername = "GERRIT_USERNAME"
+	EnvPassword = "GERRIT_HTTP_PASSWORD"
+)
+
+// CreateGerritProvider creates a new Gerrit provider instance from
+// configuration. BaseURL is required; Username and Token (the HTTP
+// password) fall back to GERRIT_USERNAME and GERRIT_HTTP_PASSWORD.
+func CreateGerritProvider(config *provider.ProviderConfig) (provider.GitProvider, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if config.BaseURL == "" {
+		return nil, fmt.Errorf("gerrit provider requires base_url")
+	}
+
+	gerritProvider, err := NewGerritProvider(config.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	username := config.Username
+	if username == "" {
+		username = os.Getenv(EnvUsername)
+	}
+	password := config.Token
+	if password == "" {
+		password = config.Password
+	}
+	if password == "" {
+		password = os.Getenv(EnvPassword)
+	}
+
+	// 자격 증명이 없으면 익명 접근으로 공개 프로젝트만 조회한다
+	if username != "" && password != "" {
+		creds := provider.Credentials{Type: provider.CredentialTypeBasic, Username: username, Password: password}
+		if err := gerritProvider.Authenticate(context.Background(), creds); err != nil {
+			return nil, fmt.Errorf("failed to authenticate Gerrit provider: %w", err)
+		}
+	}
+
+	return gerritProvider, nil
+}
+
+// RegisterGerritProvider registers the Gerrit provider with a factory.
+func RegisterGerritProvider(factory *provider.ProviderFactory) error {
+	return factory.RegisterProvider("gerrit", CreateGerritProvider)
+}
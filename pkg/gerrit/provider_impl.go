@@ -0,0 +1,357 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// GerritProvider implements the unified GitProvider interface for Gerrit.
+// Gerrit has no organizations; the Organization of ListOptions is used as a
+// project name prefix. Releases, webhooks and events are not supported.
+type GerritProvider struct {
+	*provider.BaseProvider
+	client *Client
+}
+
+// Ensure GerritProvider implements GitProvider interface
+var _ provider.GitProvider = (*GerritProvider)(nil)
+
+// NewGerritProvider creates a Gerrit provider for the server at baseURL.
+func NewGerritProvider(baseURL string) (*GerritProvider, error) {
+	client, err := NewClient(ClientOptions{BaseURL: baseURL})
+	if err != nil {
+		return nil, err
+	}
+	return &GerritProvider{
+		BaseProvider: provider.NewBaseProvider("gerrit", client.BaseURL(), ""),
+		client:       client,
+	}, nil
+}
+
+// Client returns the underlying REST API client.
+func (g *GerritProvider) Client() *Client {
+	return g.client
+}
+
+// GetCapabilities returns the list of supported capabilities.
+func (g *GerritProvider) GetCapabilities() []provider.Capability {
+	return []provider.Capability{
+		provider.CapabilityRepositories,
+		provider.CapabilityCodeReview,
+	}
+}
+
+// Authenticate sets the account name and HTTP password. Token credentials
+// need a Username; the token is used as the HTTP password.
+func (g *GerritProvider) Authenticate(ctx context.Context, creds provider.Credentials) error {
+	var password string
+	switch creds.Type {
+	case provider.CredentialTypeBasic:
+		password = creds.Password
+	case provider.CredentialTypeToken:
+		password = creds.Token
+	default:
+		return g.FormatError("authenticate", fmt.Errorf("unsupported credential type: %s", creds.Type))
+	}
+	if creds.Username == "" {
+		return g.FormatError("authenticate", fmt.Errorf("gerrit requires a username with the HTTP password"))
+	}
+
+	client, err := NewClient(ClientOptions{
+		BaseURL:    g.GetBaseURL(),
+		Username:   creds.Username,
+		Password:   password,
+		HTTPClient: g.client.httpClient,
+	})
+	if err != nil {
+		return g.FormatError("authenticate", err)
+	}
+	g.client = client
+	g.SetToken(password)
+
+	return nil
+}
+
+// ValidateToken checks the credentials against the accounts/self endpoint.
+func (g *GerritProvider) ValidateToken(ctx context.Context) (*provider.TokenInfo, error) {
+	account, err := g.client.Self(ctx)
+	if err != nil {
+		return &provider.TokenInfo{Valid: false}, g.FormatError("validate token", err)
+	}
+	return &provider.TokenInfo{
+		Valid: true,
+		User:  account.Username,
+		Email: account.Email,
+	}, nil
+}
+
+// ListRepositories lists projects whose name starts with opts.Organization.
+func (g *GerritProvider) ListRepositories(ctx context.Context, opts provider.ListOptions) (*provider.RepositoryList, error) {
+	projects, err := g.client.ListProjects(ctx, ProjectListOptions{Prefix: opts.Organization})
+	if err != nil {
+		return nil, g.FormatError("list repositories", err)
+	}
+
+	repositories := make([]provider.Repository, 0, len(projects))
+	for _, project := range projects {
+		repositories = append(repositories, g.toRepository(project))
+	}
+
+	total := len(repositories)
+	page, perPage := opts.Page, opts.PerPage
+	if perPage > 0 {
+		if page < 1 {
+			page = 1
+		}
+		start := min((page-1)*perPage, total)
+		end := min(start+perPage, total)
+		repositories = repositories[start:end]
+	}
+
+	return &provider.RepositoryList{
+		Repositories: repositories,
+		TotalCount:   total,
+		Page:         page,
+		PerPage:      perPage,
+		HasNext:      perPage > 0 && page*perPage < total,
+		HasPrev:      page > 1,
+	}, nil
+}
+
+// GetRepository retrieves a project by name.
+func (g *GerritProvider) GetRepository(ctx context.Context, id string) (*provider.Repository, error) {
+	project, err := g.client.GetProject(ctx, id)
+	if err != nil {
+		return nil, g.FormatError("get repository", err)
+	}
+	repo := g.toRepository(*project)
+	if head, err := g.client.GetHead(ctx, id); err == nil {
+		repo.DefaultBranch = head
+	}
+	return &repo, nil
+}
+
+// CloneRepository clones a project and installs the commit-msg hook.
+func (g *GerritProvider) CloneRepository(ctx context.Context, repo provider.Repository, target string, opts provider.CloneOptions) error {
+	name := repo.FullName
+	if name == "" {
+		name = repo.Name
+	}
+	if err := Clone(ctx, g.client, name, target, opts.Branch); err != nil {
+		return g.FormatError("clone repository", err)
+	}
+	return nil
+}
+
+// AfterClone installs the commit-msg hook into a repository cloned by the
+// bulk clone executor.
+func (g *GerritProvider) AfterClone(ctx context.Context, repoPath string) error {
+	return InstallCommitMsgHook(ctx, g.client, repoPath)
+}
+
+// SearchRepositories finds projects whose name contains the query.
+func (g *GerritProvider) SearchRepositories(ctx context.Context, query provider.SearchQuery) (*provider.SearchResult, error) {
+	projects, err := g.client.ListProjects(ctx, ProjectListOptions{Match: query.Query, Prefix: query.Organization})
+	if err != nil {
+		return nil, g.FormatError("search repositories", err)
+	}
+
+	repositories := make([]provider.Repository, 0, len(projects))
+	for _, project := range projects {
+		repositories = append(repositories, g.toRepository(project))
+	}
+	return &provider.SearchResult{
+		TotalCount:   len(repositories),
+		Repositories: repositories,
+	}, nil
+}
+
+// ListChanges lists changes (code reviews) matching a Gerrit search query.
+func (g *GerritProvider) ListChanges(ctx context.Context, query ChangeQuery) ([]Change, error) {
+	changes, err := g.client.ListChanges(ctx, query)
+	if err != nil {
+		return nil, g.FormatError("list changes", err)
+	}
+	return changes, nil
+}
+
+// toRepository converts a project to the unified repository type.
+func (g *GerritProvider) toRepository(project Project) provider.Repository {
+	host := g.client.baseURL.Hostname()
+	return provider.Repository{
+		ID:           project.Name,
+		Name:         path.Base(project.Name),
+		FullName:     project.Name,
+		Description:  project.Description,
+		Archived:     project.State == "READ_ONLY",
+		CloneURL:     g.client.CloneURL(project.Name),
+		SSHURL:       fmt.Sprintf("ssh://%s:29418/%s", host, project.Name),
+		HTMLURL:      g.client.BaseURL() + "/admin/repos/" + url.PathEscape(project.Name),
+		ProviderType: g.GetName(),
+		ProviderData: map[string]any{"state": project.State},
+	}
+}
+
+// notSupported formats an error for operations Gerrit does not offer.
+func (g *GerritProvider) notSupported(operation string) error {
+	return g.FormatError(operation, provider.ErrNotSupported)
+}
+
+// Repository administration is done in Gerrit itself.
+
+func (g *GerritProvider) CreateRepository(ctx context.Context, req provider.CreateRepoRequest) (*provider.Repository, error) {
+	return nil, g.notSupported("create repository")
+}
+
+func (g *GerritProvider) UpdateRepository(ctx context.Context, id string, updates provider.UpdateRepoRequest) (*provider.Repository, error) {
+	return nil, g.notSupported("update repository")
+}
+
+func (g *GerritProvider) DeleteRepository(ctx context.Context, id string) error {
+	return g.notSupported("delete repository")
+}
+
+func (g *GerritProvider) ArchiveRepository(ctx context.Context, id string) error {
+	return g.notSupported("archive repository")
+}
+
+func (g *GerritProvider) UnarchiveRepository(ctx context.Context, id string) error {
+	return g.notSupported("unarchive repository")
+}
+
+func (g *GerritProvider) ForkRepository(ctx context.Context, id string, opts provider.ForkOptions) (*provider.Repository, error) {
+	return nil, g.notSupported("fork repository")
+}
+
+// Gerrit has no release objects.
+
+func (g *GerritProvider) ListReleases(ctx context.Context, repoID string, opts provider.ListReleasesOptions) (*provider.ReleaseList, error) {
+	return nil, g.notSupported("list releases")
+}
+
+func (g *GerritProvider) GetRelease(ctx context.Context, repoID, releaseID string) (*provider.Release, error) {
+	return nil, g.notSupported("get release")
+}
+
+func (g *GerritProvider) GetReleaseByTag(ctx context.Context, repoID, tagName string) (*provider.Release, error) {
+	return nil, g.notSupported("get release by tag")
+}
+
+func (g *GerritProvider) CreateRelease(ctx context.Context, repoID string, req provider.CreateReleaseRequest) (*provider.Release, error) {
+	return nil, g.notSupported("create release")
+}
+
+func (g *GerritProvider) UpdateRelease(ctx context.Context, repoID, releaseID string, updates provider.UpdateReleaseRequest) (*provider.Release, error) {
+	return nil, g.notSupported("update release")
+}
+
+func (g *GerritProvider) DeleteRelease(ctx context.Context, repoID, releaseID string) error {
+	return g.notSupported("delete release")
+}
+
+func (g *GerritProvider) ListReleaseAssets(ctx context.Context, repoID, releaseID string) ([]provider.Asset, error) {
+	return nil, g.notSupported("list release assets")
+}
+
+func (g *GerritProvider) UploadReleaseAsset(ctx context.Context, repoID string, req provider.UploadAssetRequest) (*provider.Asset, error) {
+	return nil, g.notSupported("upload release asset")
+}
+
+func (g *GerritProvider) DeleteReleaseAsset(ctx context.Context, repoID, assetID string) error {
+	return g.notSupported("delete release asset")
+}
+
+func (g *GerritProvider) DownloadReleaseAsset(ctx context.Context, repoID, assetID string) ([]byte, error) {
+	return nil, g.notSupported("download release asset")
+}
+
+// Webhooks and events require the Gerrit webhooks plugin and are not supported.
+
+func (g *GerritProvider) ListWebhooks(ctx context.Context, repoID string) ([]provider.Webhook, error) {
+	return nil, g.notSupported("list webhooks")
+}
+
+func (g *GerritProvider) GetWebhook(ctx context.Context, repoID, webhookID string) (*provider.Webhook, error) {
+	return nil, g.notSupported("get webhook")
+}
+
+func (g *GerritProvider) CreateWebhook(ctx context.Context, repoID string, webhook provider.CreateWebhookRequest) (*provider.Webhook, error) {
+	return nil, g.notSupported("create webhook")
+}
+
+func (g *GerritProvider) UpdateWebhook(ctx context.Context, repoID, webhookID string, updates provider.UpdateWebhookRequest) (*provider.Webhook, error) {
+	return nil, g.notSupported("update webhook")
+}
+
+func (g *GerritProvider) DeleteWebhook(ctx context.Context, repoID, webhookID string) error {
+	return g.notSupported("delete webhook")
+}
+
+func (g *GerritProvider) TestWebhook(ctx context.Context, repoID, webhookID string) (*provider.WebhookTestResult, error) {
+	return nil, g.notSupported("test webhook")
+}
+
+func (g *GerritProvider) ValidateWebhookURL(ctx context.Context, url string) error {
+	return g.notSupported("validate webhook URL")
+}
+
+func (g *GerritProvider) ListEvents(ctx context.Context, opts provider.EventListOptions) ([]provider.Event, error) {
+	return nil, g.notSupported("list events")
+}
+
+func (g *GerritProvider) GetEvent(ctx context.Context, eventID string) (*provider.Event, error) {
+	return nil, g.notSupported("get event")
+}
+
+func (g *GerritProvider) ProcessEvent(ctx context.Context, event provider.Event) error {
+	return g.notSupported("process event")
+}
+
+func (g *GerritProvider) RegisterEventHandler(eventType string, handler provider.EventHandler) error {
+	return g.notSupported("register event handler")
+}
+
+func (g *GerritProvider) StreamEvents(ctx context.Context, opts provider.StreamOptions) (<-chan provider.Event, error) {
+	return nil, g.notSupported("stream events")
+}
+
+// Health and monitoring methods
+
+// HealthCheck queries the server version.
+func (g *GerritProvider) HealthCheck(ctx context.Context) (*provider.HealthStatus, error) {
+	startTime := time.Now()
+	version, err := g.client.Version(ctx)
+	status := &provider.HealthStatus{
+		LastChecked: time.Now(),
+		Latency:     time.Since(startTime),
+		Details:     make(map[string]any),
+	}
+
+	if err != nil {
+		status.Status = provider.HealthStatusUnhealthy
+		status.Message = err.Error()
+		return status, nil
+	}
+	status.Status = provider.HealthStatusHealthy
+	status.Message = "Gerrit API accessible"
+	status.Details["version"] = version
+
+	return status, nil
+}
+
+// GetRateLimit reports that Gerrit does not expose rate limits.
+func (g *GerritProvider) GetRateLimit(ctx context.Context) (*provider.RateLimit, error) {
+	return nil, g.notSupported("get rate limit")
+}
+
+func (g *GerritProvider) GetMetrics(ctx context.Context) (*provider.ProviderMetrics, error) {
+	return &provider.ProviderMetrics{CollectedAt: time.Now()}, nil
+}
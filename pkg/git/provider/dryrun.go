@@ -0,0 +1,505 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ChangeAction is what a planned change does to a resource.
+type ChangeAction string
+
+const (
+	ChangeCreate ChangeAction = "create"
+	ChangeUpdate ChangeAction = "update"
+	ChangeDelete ChangeAction = "delete"
+)
+
+// Resource types recorded by DryRunProvider and the bulk commands that
+// render plans.
+const (
+	ResourceRepository       = "repository"
+	ResourceRelease          = "release"
+	ResourceReleaseAsset     = "release_asset"
+	ResourceWebhook          = "webhook"
+	ResourceBranchProtection = "branch_protection"
+	ResourceTeam             = "team"
+	ResourceTeamMember       = "team_member"
+	ResourceTeamRepository   = "team_repository"
+	ResourceLabel            = "label"
+	ResourceMilestone        = "milestone"
+)
+
+// sensitiveValue replaces the values of sensitiveAttributes in a plan.
+const sensitiveValue = "(sensitive)"
+
+// sensitiveAttributes are never shown in a plan.
+var sensitiveAttributes = map[string]bool{
+	"secret":   true,
+	"token":    true,
+	"password": true,
+}
+
+// AttributeChange is one attribute of a planned change. Before is nil for
+// attributes being set on a new resource or whose current value is unknown.
+type AttributeChange struct {
+	Name   string `json:"name" yaml:"name"`
+	Before any    `json:"before,omitempty" yaml:"before,omitempty"`
+	After  any    `json:"after,omitempty" yaml:"after,omitempty"`
+}
+
+// PlannedChange is a mutating call that was captured instead of executed.
+type PlannedChange struct {
+	Action     ChangeAction      `json:"action" yaml:"action"`
+	Resource   string            `json:"resource" yaml:"resource"`
+	Target     string            `json:"target" yaml:"target"`
+	Attributes []AttributeChange `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+}
+
+// Plan collects planned changes. It is safe for concurrent use.
+type Plan struct {
+	mu      sync.Mutex
+	changes []PlannedChange
+}
+
+// NewPlan creates an empty plan.
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+// Record adds a change to the plan.
+func (p *Plan) Record(change PlannedChange) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changes = append(p.changes, change)
+}
+
+// Create records the creation of a resource with the given attributes, a
+// struct or map whose JSON form lists them.
+func (p *Plan) Create(resource, target string, attributes any) {
+	p.Record(PlannedChange{
+		Action:     ChangeCreate,
+		Resource:   resource,
+		Target:     target,
+		Attributes: DiffAttributes(nil, attributes),
+	})
+}
+
+// Update records the update of a resource. Only attributes present in
+// after and different from before are listed; before may be nil when the
+// current state is unknown. An update that changes nothing is not recorded.
+func (p *Plan) Update(resource, target string, before, after any) {
+	attrs := DiffAttributes(before, after)
+	if len(attrs) == 0 {
+		return
+	}
+	p.Record(PlannedChange{Action: ChangeUpdate, Resource: resource, Target: target, Attributes: attrs})
+}
+
+// Delete records the deletion of a resource.
+func (p *Plan) Delete(resource, target string) {
+	p.Record(PlannedChange{Action: ChangeDelete, Resource: resource, Target: target})
+}
+
+// Changes returns the recorded changes in the order they were made.
+func (p *Plan) Changes() []PlannedChange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]PlannedChange, len(p.changes))
+	copy(out, p.changes)
+	return out
+}
+
+// Empty reports whether nothing would change.
+func (p *Plan) Empty() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.changes) == 0
+}
+
+// Counts returns the number of resources that would be created, updated
+// and deleted.
+func (p *Plan) Counts() (create, update, remove int) {
+	for _, c := range p.Changes() {
+		switch c.Action {
+		case ChangeCreate:
+			create++
+		case ChangeUpdate:
+			update++
+		case ChangeDelete:
+			remove++
+		}
+	}
+	return create, update, remove
+}
+
+// MarshalJSON encodes the changes together with their counts.
+func (p *Plan) MarshalJSON() ([]byte, error) {
+	create, update, remove := p.Counts()
+	changes := p.Changes()
+	if changes == nil {
+		changes = []PlannedChange{}
+	}
+	return json.Marshal(struct {
+		Changes []PlannedChange `json:"changes"`
+		Summary map[string]int  `json:"summary"`
+	}{changes, map[string]int{"create": create, "update": update, "delete": remove}})
+}
+
+// Render writes the plan as a diff: + for created resources and
+// attributes, ~ for updated ones and - for deleted ones, followed by the
+// totals.
+func (p *Plan) Render(w io.Writer) {
+	changes := p.Changes()
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes. The remote state already matches.")
+		return
+	}
+
+	for _, c := range changes {
+		symbol := actionSymbol(c.Action)
+		fmt.Fprintf(w, "  %s %s %q\n", symbol, c.Resource, c.Target)
+
+		width := 0
+		for _, a := range c.Attributes {
+			width = max(width, len(a.Name))
+		}
+		for _, a := range c.Attributes {
+			switch {
+			case c.Action == ChangeCreate:
+				fmt.Fprintf(w, "      + %-*s = %s\n", width, a.Name, formatAttribute(a.After))
+			case a.Before == nil:
+				fmt.Fprintf(w, "      ~ %-*s = (unknown) → %s\n", width, a.Name, formatAttribute(a.After))
+			default:
+				fmt.Fprintf(w, "      ~ %-*s = %s → %s\n", width, a.Name,
+					formatAttribute(a.Before), formatAttribute(a.After))
+			}
+		}
+	}
+
+	create, update, remove := p.Counts()
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete.\n", create, update, remove)
+}
+
+func actionSymbol(action ChangeAction) string {
+	switch action {
+	case ChangeCreate:
+		return "+"
+	case ChangeDelete:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+func formatAttribute(v any) string {
+	switch v := v.(type) {
+	case string:
+		if v == sensitiveValue {
+			return v
+		}
+		return fmt.Sprintf("%q", v)
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = formatAttribute(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case []string:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprintf("%q", item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// DiffAttributes compares the JSON forms of before and after and returns
+// the attributes of after that differ, sorted by name. Nested objects are
+// flattened to slash-separated names and empty values in after are
+// ignored, so requests with omitempty fields only list what they set.
+// Secrets are compared but their values are replaced by (sensitive).
+func DiffAttributes(before, after any) []AttributeChange {
+	old := flattenAttributes(before)
+	var changes []AttributeChange
+	for name, value := range flattenAttributes(after) {
+		if isEmptyAttribute(value) {
+			continue
+		}
+		prev, known := old[name]
+		if known && reflect.DeepEqual(prev, value) {
+			continue
+		}
+		if sensitiveAttributes[path.Base(name)] {
+			value = sensitiveValue
+			if known {
+				prev = sensitiveValue
+			}
+		}
+		changes = append(changes, AttributeChange{Name: name, Before: prev, After: value})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+func flattenAttributes(v any) map[string]any {
+	out := make(map[string]any)
+	if v == nil {
+		return out
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return out
+	}
+	var fields map[string]any
+	if json.Unmarshal(data, &fields) != nil {
+		return out
+	}
+
+	var walk func(prefix string, fields map[string]any)
+	walk = func(prefix string, fields map[string]any) {
+		for name, value := range fields {
+			if nested, ok := value.(map[string]any); ok {
+				walk(prefix+name+"/", nested)
+				continue
+			}
+			out[prefix+name] = value
+		}
+	}
+	walk("", fields)
+	return out
+}
+
+func isEmptyAttribute(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case []any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// DryRunProvider wraps a provider so that read operations reach the
+// platform while mutating operations are recorded in a Plan and never
+// executed. Values returned by captured calls are synthesized from the
+// request.
+type DryRunProvider struct {
+	GitProvider
+	plan *Plan
+}
+
+// NewDryRunProvider wraps p, recording mutating calls in plan.
+func NewDryRunProvider(p GitProvider, plan *Plan) *DryRunProvider {
+	return &DryRunProvider{GitProvider: p, plan: plan}
+}
+
+// Plan returns the plan the provider records into.
+func (d *DryRunProvider) Plan() *Plan {
+	return d.plan
+}
+
+// repositoryName returns the full name of repository id, falling back to
+// id when it cannot be read.
+func (d *DryRunProvider) repositoryName(ctx context.Context, id string) (string, *Repository) {
+	repo, err := d.GitProvider.GetRepository(ctx, id)
+	if err != nil || repo == nil {
+		return id, nil
+	}
+	if repo.FullName != "" {
+		return repo.FullName, repo
+	}
+	return id, repo
+}
+
+// CreateRepository records the creation of a repository.
+func (d *DryRunProvider) CreateRepository(_ context.Context, req CreateRepoRequest) (*Repository, error) {
+	d.plan.Create(ResourceRepository, req.Name, req)
+	return &Repository{
+		Name:          req.Name,
+		FullName:      req.Name,
+		Description:   req.Description,
+		Private:       req.Private,
+		Visibility:    req.Visibility,
+		DefaultBranch: req.DefaultBranch,
+		Topics:        req.Topics,
+		ProviderType:  d.GetName(),
+	}, nil
+}
+
+// UpdateRepository records an update of repository id against its current
+// state.
+func (d *DryRunProvider) UpdateRepository(ctx context.Context, id string, updates UpdateRepoRequest) (*Repository, error) {
+	name, current := d.repositoryName(ctx, id)
+	var before any
+	if current != nil {
+		before = current
+	}
+	d.plan.Update(ResourceRepository, name, before, updates)
+	if current == nil {
+		current = &Repository{ID: id}
+	}
+	return current, nil
+}
+
+// DeleteRepository records the deletion of repository id.
+func (d *DryRunProvider) DeleteRepository(ctx context.Context, id string) error {
+	name, _ := d.repositoryName(ctx, id)
+	d.plan.Delete(ResourceRepository, name)
+	return nil
+}
+
+// ArchiveRepository records archiving repository id.
+func (d *DryRunProvider) ArchiveRepository(ctx context.Context, id string) error {
+	return d.setArchived(ctx, id, true)
+}
+
+// UnarchiveRepository records unarchiving repository id.
+func (d *DryRunProvider) UnarchiveRepository(ctx context.Context, id string) error {
+	return d.setArchived(ctx, id, false)
+}
+
+func (d *DryRunProvider) setArchived(ctx context.Context, id string, archived bool) error {
+	name, current := d.repositoryName(ctx, id)
+	var before any
+	if current != nil {
+		before = map[string]any{"archived": current.Archived}
+	}
+	d.plan.Update(ResourceRepository, name, before, map[string]any{"archived": archived})
+	return nil
+}
+
+// CloneRepository records a clone into target; nothing is written to disk.
+func (d *DryRunProvider) CloneRepository(_ context.Context, repo Repository, target string, opts CloneOptions) error {
+	d.plan.Create("clone", target, map[string]any{"repository": repo.FullName, "branch": opts.Branch, "depth": opts.Depth})
+	return nil
+}
+
+// ForkRepository records forking repository id.
+func (d *DryRunProvider) ForkRepository(ctx context.Context, id string, opts ForkOptions) (*Repository, error) {
+	source, current := d.repositoryName(ctx, id)
+	name := opts.Name
+	if name == "" && current != nil {
+		name = current.Name
+	}
+	if name == "" {
+		name = path.Base(source)
+	}
+	target := name
+	if opts.Organization != "" {
+		target = opts.Organization + "/" + name
+	}
+	d.plan.Create(ResourceRepository, target, map[string]any{"fork_of": source, "default_branch_only": opts.DefaultBranchOnly})
+	return &Repository{Name: name, FullName: target, Fork: true, ProviderType: d.GetName()}, nil
+}
+
+// CreateRelease records the creation of a release.
+func (d *DryRunProvider) CreateRelease(_ context.Context, repoID string, req CreateReleaseRequest) (*Release, error) {
+	d.plan.Create(ResourceRelease, repoID+"@"+req.TagName, req)
+	return &Release{
+		TagName:      req.TagName,
+		Name:         req.Name,
+		Body:         req.Body,
+		Draft:        req.Draft,
+		Prerelease:   req.Prerelease,
+		TargetBranch: req.TargetBranch,
+		ProviderType: d.GetName(),
+	}, nil
+}
+
+// UpdateRelease records an update of a release against its current state.
+func (d *DryRunProvider) UpdateRelease(ctx context.Context, repoID, releaseID string, updates UpdateReleaseRequest) (*Release, error) {
+	target := repoID + "@" + releaseID
+	current, err := d.GitProvider.GetRelease(ctx, repoID, releaseID)
+	if err != nil || current == nil {
+		d.plan.Update(ResourceRelease, target, nil, updates)
+		return &Release{ID: releaseID}, nil
+	}
+	if current.TagName != "" {
+		target = repoID + "@" + current.TagName
+	}
+	d.plan.Update(ResourceRelease, target, current, updates)
+	return current, nil
+}
+
+// DeleteRelease records the deletion of a release.
+func (d *DryRunProvider) DeleteRelease(_ context.Context, repoID, releaseID string) error {
+	d.plan.Delete(ResourceRelease, repoID+"@"+releaseID)
+	return nil
+}
+
+// UploadReleaseAsset records an asset upload; the content is summarized by
+// its size.
+func (d *DryRunProvider) UploadReleaseAsset(_ context.Context, repoID string, req UploadAssetRequest) (*Asset, error) {
+	d.plan.Create(ResourceReleaseAsset, repoID+"@"+req.ReleaseID+"/"+req.FileName, map[string]any{
+		"label":        req.Label,
+		"content_type": req.ContentType,
+		"size":         len(req.Content),
+	})
+	return &Asset{Name: req.FileName, Label: req.Label, ContentType: req.ContentType, Size: int64(len(req.Content))}, nil
+}
+
+// DeleteReleaseAsset records the deletion of a release asset.
+func (d *DryRunProvider) DeleteReleaseAsset(_ context.Context, repoID, assetID string) error {
+	d.plan.Delete(ResourceReleaseAsset, repoID+"/"+assetID)
+	return nil
+}
+
+// CreateWebhook records the creation of a webhook.
+func (d *DryRunProvider) CreateWebhook(_ context.Context, repoID string, webhook CreateWebhookRequest) (*Webhook, error) {
+	d.plan.Create(ResourceWebhook, repoID+"/"+webhookName(webhook.Name, webhook.Config.URL), webhook)
+	return &Webhook{
+		Name:   webhook.Name,
+		URL:    webhook.Config.URL,
+		Events: webhook.Events,
+		Active: webhook.Active,
+		Config: webhook.Config,
+	}, nil
+}
+
+// UpdateWebhook records an update of a webhook against its current state.
+func (d *DryRunProvider) UpdateWebhook(ctx context.Context, repoID, webhookID string, updates UpdateWebhookRequest) (*Webhook, error) {
+	target := repoID + "/" + webhookID
+	current, err := d.GitProvider.GetWebhook(ctx, repoID, webhookID)
+	if err != nil || current == nil {
+		d.plan.Update(ResourceWebhook, target, nil, updates)
+		return &Webhook{ID: webhookID}, nil
+	}
+	d.plan.Update(ResourceWebhook, repoID+"/"+webhookName(current.Name, current.URL), current, updates)
+	return current, nil
+}
+
+// DeleteWebhook records the deletion of a webhook.
+func (d *DryRunProvider) DeleteWebhook(_ context.Context, repoID, webhookID string) error {
+	d.plan.Delete(ResourceWebhook, repoID+"/"+webhookID)
+	return nil
+}
+
+// TestWebhook records a test delivery; no request is sent.
+func (d *DryRunProvider) TestWebhook(_ context.Context, repoID, webhookID string) (*WebhookTestResult, error) {
+	d.plan.Create("webhook_delivery", repoID+"/"+webhookID, nil)
+	return &WebhookTestResult{Success: true}, nil
+}
+
+func webhookName(name, url string) string {
+	if name != "" {
+		return name
+	}
+	return url
+}
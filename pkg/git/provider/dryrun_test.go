@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readOnlyProvider serves reads and fails the test on any mutating call
+// that reaches it.
+type readOnlyProvider struct {
+	GitProvider
+	t     *testing.T
+	repos map[string]*Repository
+}
+
+func (p *readOnlyProvider) GetName() string { return "fake" }
+
+func (p *readOnlyProvider) GetRepository(_ context.Context, id string) (*Repository, error) {
+	if repo, ok := p.repos[id]; ok {
+		return repo, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (p *readOnlyProvider) GetWebhook(context.Context, string, string) (*Webhook, error) {
+	return &Webhook{ID: "7", Name: "ci", URL: "https://ci.example.com/hook", Active: true,
+		Config: WebhookConfig{URL: "https://ci.example.com/hook", ContentType: "json", Secret: "old"}}, nil
+}
+
+func (p *readOnlyProvider) DeleteRepository(context.Context, string) error {
+	p.t.Fatal("DeleteRepository reached the provider")
+	return nil
+}
+
+func newDryRun(t *testing.T) *DryRunProvider {
+	t.Helper()
+	return NewDryRunProvider(&readOnlyProvider{t: t, repos: map[string]*Repository{
+		"42": {ID: "42", Name: "app", FullName: "myorg/app", Description: "old", Private: false},
+	}}, NewPlan())
+}
+
+func TestDryRunProvider_CapturesMutations(t *testing.T) {
+	ctx := context.Background()
+	d := newDryRun(t)
+
+	repo, err := d.CreateRepository(ctx, CreateRepoRequest{Name: "svc", Private: true, Topics: []string{"go"}})
+	require.NoError(t, err)
+	assert.Equal(t, "svc", repo.Name)
+	assert.Equal(t, "fake", repo.ProviderType)
+
+	description, private := "new", false
+	_, err = d.UpdateRepository(ctx, "42", UpdateRepoRequest{Description: &description, Private: &private})
+	require.NoError(t, err)
+	require.NoError(t, d.ArchiveRepository(ctx, "42"))
+	require.NoError(t, d.DeleteRepository(ctx, "42"))
+	require.NoError(t, d.DeleteRepository(ctx, "missing"))
+
+	active := false
+	_, err = d.UpdateWebhook(ctx, "myorg/app", "7", UpdateWebhookRequest{
+		Active: &active,
+		Config: &WebhookConfig{URL: "https://ci.example.com/hook", ContentType: "json", Secret: "new"},
+	})
+	require.NoError(t, err)
+
+	changes := d.Plan().Changes()
+	require.Len(t, changes, 6)
+
+	assert.Equal(t, ChangeCreate, changes[0].Action)
+	assert.Equal(t, "svc", changes[0].Target)
+	assert.Contains(t, changes[0].Attributes, AttributeChange{Name: "private", After: true})
+	assert.Contains(t, changes[0].Attributes, AttributeChange{Name: "topics", After: []any{"go"}})
+
+	// Only the description changes; private is already false.
+	assert.Equal(t, PlannedChange{
+		Action: ChangeUpdate, Resource: ResourceRepository, Target: "myorg/app",
+		Attributes: []AttributeChange{{Name: "description", Before: "old", After: "new"}},
+	}, changes[1])
+	assert.Equal(t, []AttributeChange{{Name: "archived", Before: false, After: true}}, changes[2].Attributes)
+	assert.Equal(t, PlannedChange{Action: ChangeDelete, Resource: ResourceRepository, Target: "myorg/app"}, changes[3])
+	assert.Equal(t, "missing", changes[4].Target)
+
+	assert.Equal(t, "myorg/app/ci", changes[5].Target)
+	assert.Equal(t, []AttributeChange{
+		{Name: "active", Before: true, After: false},
+		{Name: "config/secret", Before: "(sensitive)", After: "(sensitive)"},
+	}, changes[5].Attributes)
+
+	create, update, remove := d.Plan().Counts()
+	assert.Equal(t, []int{1, 3, 2}, []int{create, update, remove})
+}
+
+func TestPlan_Render(t *testing.T) {
+	ctx := context.Background()
+	d := newDryRun(t)
+	_, _ = d.CreateRepository(ctx, CreateRepoRequest{Name: "svc", Description: "Payments", Private: true})
+	name := "renamed"
+	_, _ = d.UpdateRepository(ctx, "42", UpdateRepoRequest{Name: &name})
+	_, _ = d.CreateWebhook(ctx, "myorg/app", CreateWebhookRequest{Config: WebhookConfig{URL: "https://hook", Secret: "s3cret"}})
+	_ = d.DeleteRepository(ctx, "42")
+
+	var out bytes.Buffer
+	d.Plan().Render(&out)
+	got := out.String()
+
+	assert.Contains(t, got, `  + repository "svc"`)
+	assert.Contains(t, got, `      + description        = "Payments"`)
+	assert.Contains(t, got, `  ~ repository "myorg/app"`)
+	assert.Contains(t, got, `      ~ name = "app" → "renamed"`)
+	assert.Contains(t, got, `      + config/secret       = (sensitive)`)
+	assert.NotContains(t, got, "s3cret")
+	assert.Contains(t, got, `  - repository "myorg/app"`)
+	assert.Contains(t, got, "Plan: 2 to create, 1 to update, 1 to delete.")
+
+	out.Reset()
+	NewPlan().Render(&out)
+	assert.Contains(t, out.String(), "No changes.")
+}
+
+func TestPlan_MarshalJSON(t *testing.T) {
+	plan := NewPlan()
+	plan.Delete(ResourceRepository, "myorg/old")
+	plan.Update(ResourceRepository, "myorg/app", map[string]any{"archived": true}, map[string]any{"archived": true})
+
+	data, err := json.Marshal(plan)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"changes": [{"action": "delete", "resource": "repository", "target": "myorg/old"}],
+		"summary": {"create": 0, "update": 0, "delete": 1}
+	}`, string(data))
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// EventRecord is one entry of an EventLog.
+type EventRecord struct {
+	Seq      uint64    `json:"seq"`
+	LoggedAt time.Time `json:"logged_at"`
+	Event    Event     `json:"event"`
+}
+
+// ReplayOptions selects the records passed to EventLog.Replay.
+type ReplayOptions struct {
+	// FromSeq is the first sequence number to replay; 0 starts at the beginning.
+	FromSeq uint64
+	// ToSeq is the last sequence number to replay; 0 replays to the end.
+	ToSeq uint64
+	// Since skips records logged before this time.
+	Since time.Time
+	// EventTypes limits replay to these event types.
+	EventTypes []string
+}
+
+func (o ReplayOptions) matches(r EventRecord) bool {
+	if r.Seq < o.FromSeq || (o.ToSeq > 0 && r.Seq > o.ToSeq) {
+		return false
+	}
+	if !o.Since.IsZero() && r.LoggedAt.Before(o.Since) {
+		return false
+	}
+	return len(o.EventTypes) == 0 || slices.Contains(o.EventTypes, r.Event.Type)
+}
+
+// EventLog is an append-only, file-backed log of events. Every event gets a
+// sequence number so that late subscribers can replay from a point in the
+// log, and named consumers can track the last event they processed across
+// restarts. The log is a JSON Lines file; consumer offsets are kept next to
+// it in <path>.offsets.
+type EventLog struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	lastSeq uint64
+	offsets map[string]uint64
+}
+
+// OpenEventLog opens or creates the event log at path. A partially written
+// last line, e.g. after a crash, is discarded.
+func OpenEventLog(path string) (*EventLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create event log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+
+	l := &EventLog{path: path, file: file, offsets: make(map[string]uint64)}
+	if err := l.recover(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := l.loadOffsets(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// recover finds the last sequence number and truncates a torn tail.
+func (l *EventLog) recover() error {
+	r := bufio.NewReader(l.file)
+	var good int64
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read event log: %w", err)
+		}
+		var rec EventRecord
+		if json.Unmarshal(line, &rec) != nil {
+			break
+		}
+		l.lastSeq = rec.Seq
+		good += int64(len(line))
+	}
+
+	if err := l.file.Truncate(good); err != nil {
+		return fmt.Errorf("failed to repair event log: %w", err)
+	}
+	if _, err := l.file.Seek(good, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to repair event log: %w", err)
+	}
+	return nil
+}
+
+// Append writes event to the log and returns its sequence number.
+func (l *EventLog) Append(event Event) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rec := EventRecord{Seq: l.lastSeq + 1, LoggedAt: time.Now().UTC(), Event: event}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+	}
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		return 0, fmt.Errorf("failed to append event %s: %w", event.ID, err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to sync event log: %w", err)
+	}
+	l.lastSeq = rec.Seq
+	return rec.Seq, nil
+}
+
+// LastSeq returns the sequence number of the newest record, 0 if empty.
+func (l *EventLog) LastSeq() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lastSeq
+}
+
+// Replay calls fn for each record matching opts, oldest first. Records
+// appended while replaying are included unless opts.ToSeq is set. Replay
+// stops at the first error returned by fn.
+func (l *EventLog) Replay(ctx context.Context, opts ReplayOptions, fn func(EventRecord) error) error {
+	f, err := os.Open(l.path)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			// A line without newline is still being written.
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read event log: %w", err)
+		}
+
+		var rec EventRecord
+		if err := json.Unmarshal(bytes.TrimSpace(line), &rec); err != nil {
+			return fmt.Errorf("corrupt event log record: %w", err)
+		}
+		if opts.ToSeq > 0 && rec.Seq > opts.ToSeq {
+			return nil
+		}
+		if !opts.matches(rec) {
+			continue
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+}
+
+// Offset returns the last sequence number committed by consumer.
+func (l *EventLog) Offset(consumer string) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.offsets[consumer]
+}
+
+// Commit records that consumer has processed every event up to seq.
+func (l *EventLog) Commit(consumer string, seq uint64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if seq <= l.offsets[consumer] {
+		return nil
+	}
+	l.offsets[consumer] = seq
+	return l.saveOffsets()
+}
+
+// Consume passes every event after consumer's committed offset to handler
+// and commits each one once handler succeeds. On error the event is not
+// committed, so the next Consume retries it; handlers therefore see each
+// event exactly once as long as they succeed.
+func (l *EventLog) Consume(ctx context.Context, consumer string, handler EventHandler) error {
+	opts := ReplayOptions{FromSeq: l.Offset(consumer) + 1}
+	return l.Replay(ctx, opts, func(rec EventRecord) error {
+		if err := handler(ctx, rec.Event); err != nil {
+			return fmt.Errorf("consumer %s failed on event %d: %w", consumer, rec.Seq, err)
+		}
+		return l.Commit(consumer, rec.Seq)
+	})
+}
+
+// Close closes the log file.
+func (l *EventLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+func (l *EventLog) offsetsPath() string {
+	return l.path + ".offsets"
+}
+
+func (l *EventLog) loadOffsets() error {
+	data, err := os.ReadFile(l.offsetsPath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read consumer offsets: %w", err)
+	}
+	if err := json.Unmarshal(data, &l.offsets); err != nil {
+		return fmt.Errorf("failed to parse consumer offsets: %w", err)
+	}
+	return nil
+}
+
+// saveOffsets writes the offsets atomically; callers hold l.mu.
+func (l *EventLog) saveOffsets() error {
+	data, err := json.MarshalIndent(l.offsets, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := l.offsetsPath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write consumer offsets: %w", err)
+	}
+	if err := os.Rename(tmp, l.offsetsPath()); err != nil {
+		return fmt.Errorf("failed to write consumer offsets: %w", err)
+	}
+	return nil
+}
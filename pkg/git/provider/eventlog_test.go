@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func replayIDs(t *testing.T, l *EventLog, opts ReplayOptions) []string {
+	t.Helper()
+	var ids []string
+	require.NoError(t, l.Replay(context.Background(), opts, func(rec EventRecord) error {
+		ids = append(ids, rec.Event.ID)
+		return nil
+	}))
+	return ids
+}
+
+func TestEventLogReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	l, err := OpenEventLog(path)
+	require.NoError(t, err)
+
+	for i, typ := range []string{"push", "release", "push"} {
+		seq, err := l.Append(Event{ID: string(rune('a' + i)), Type: typ})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i+1), seq)
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, replayIDs(t, l, ReplayOptions{}))
+	assert.Equal(t, []string{"b", "c"}, replayIDs(t, l, ReplayOptions{FromSeq: 2}))
+	assert.Equal(t, []string{"a", "b"}, replayIDs(t, l, ReplayOptions{ToSeq: 2}))
+	assert.Equal(t, []string{"a", "c"}, replayIDs(t, l, ReplayOptions{EventTypes: []string{"push"}}))
+	assert.Empty(t, replayIDs(t, l, ReplayOptions{Since: time.Now().Add(time.Hour)}))
+	require.NoError(t, l.Close())
+
+	// A torn last line is discarded on reopen and numbering continues
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"seq":4,"event":{"id":`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	l, err = OpenEventLog(path)
+	require.NoError(t, err)
+	defer l.Close()
+	assert.Equal(t, uint64(3), l.LastSeq())
+	seq, err := l.Append(Event{ID: "d"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), seq)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, replayIDs(t, l, ReplayOptions{}))
+}
+
+func TestEventLogConsume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	l, err := OpenEventLog(path)
+	require.NoError(t, err)
+	for _, id := range []string{"a", "b", "c"} {
+		_, err := l.Append(Event{ID: id})
+		require.NoError(t, err)
+	}
+
+	ctx := context.Background()
+	var seen []string
+	failOn := "b"
+	handler := func(_ context.Context, e Event) error {
+		if e.ID == failOn {
+			return errors.New("boom")
+		}
+		seen = append(seen, e.ID)
+		return nil
+	}
+
+	assert.ErrorContains(t, l.Consume(ctx, "plugin", handler), "failed on event 2")
+	assert.Equal(t, uint64(1), l.Offset("plugin"))
+	require.NoError(t, l.Close())
+
+	// Offsets survive a restart; the failed event is retried once
+	l, err = OpenEventLog(path)
+	require.NoError(t, err)
+	defer l.Close()
+	assert.Equal(t, uint64(1), l.Offset("plugin"))
+	assert.Equal(t, uint64(0), l.Offset("other"))
+
+	failOn = ""
+	require.NoError(t, l.Consume(ctx, "plugin", handler))
+	require.NoError(t, l.Consume(ctx, "plugin", handler))
+	assert.Equal(t, []string{"a", "b", "c"}, seen)
+	assert.Equal(t, uint64(3), l.Offset("plugin"))
+}
@@ -139,6 +139,7 @@ const (
 	CapabilityBranchProtection Capability = "branch_protection"
 	CapabilitySecurityAlerts   Capability = "security_alerts"
 	CapabilityDependabot       Capability = "dependabot"
+	CapabilityCodeReview       Capability = "code_review"
 )
 
 // Credentials represents authentication credentials for a provider.
@@ -217,6 +218,11 @@ type StreamOptions struct {
 	EventTypes []string       `json:"event_types,omitempty"`
 	Filters    map[string]any `json:"filters,omitempty"`
 	BufferSize int            `json:"buffer_size,omitempty"`
+
+	// Replay from the provider's event log before streaming live events,
+	// when the provider keeps one. FromSequence is inclusive.
+	FromSequence uint64    `json:"from_sequence,omitempty"`
+	Since        time.Time `json:"since,omitempty"`
 }
 
 // WebhookTestResult represents the result of a webhook test.
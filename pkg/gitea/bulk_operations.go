@@ -37,6 +37,7 @@ func RefreshAllWithWorkerPool(ctx context.Context, targetPath, org string) error
 
 		jobs = append(jobs, workerpool.RepositoryJob{
 			Repository: repo,
+			Provider:   "gitea",
 			Operation:  workerpool.OperationClone,
 			Path:       repoPath,
 		})
@@ -6,6 +6,8 @@
 //   - Repository cloning with branch support
 //   - Bulk refresh operations for existing repositories
 //   - Default branch detection
+//   - Webhook management and webhook delivery parsing for Gitea and Gogs
+//   - Organization, team, collaborator and repository settings management
 //   - Error handling for Gitea-specific operations
 //
 // The package implements direct HTTP API calls to Gitea instances (primarily gitea.com)
@@ -17,6 +19,8 @@
 //   - Clone: Clone a specific repository with branch support
 //   - RefreshAll: Bulk refresh all repositories in an organization
 //   - GetDefaultBranch: Get the default branch name for a repository
+//   - NewHookManager: Manage webhooks and dispatch webhook events (Gitea or Gogs flavor)
+//   - NewOrgManager: Manage organizations, teams, collaborators and repository settings
 //
 // Key features:
 //   - Automatic default branch detection
@@ -0,0 +1,462 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// maxWebhookPayload bounds the request bodies ParseWebhook reads.
+const maxWebhookPayload = 25 << 20
+
+// recentEventLimit is the number of processed events GetEvent can return.
+const recentEventLimit = 500
+
+// ErrInvalidSignature is returned when a webhook delivery's signature does
+// not match the configured secret.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// ValidateSignature reports whether signature is the hex HMAC-SHA256 of
+// payload keyed with secret, as sent in X-Gitea-Signature and
+// X-Gogs-Signature. A "sha256=" prefix (X-Hub-Signature-256) is accepted.
+func ValidateSignature(payload []byte, signature, secret string) bool {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(got) == 0 {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// headerNames returns the delivery headers in the order they are checked.
+// Gitea also sends the Gogs headers for compatibility; Gogs sends only its own.
+func (m *HookManager) headerNames(suffix string) []string {
+	if m.flavor == FlavorGogs {
+		return []string{"X-Gogs-" + suffix}
+	}
+	return []string{"X-Gitea-" + suffix, "X-Gogs-" + suffix}
+}
+
+func (m *HookManager) header(r *http.Request, suffix string) string {
+	for _, name := range m.headerNames(suffix) {
+		if v := r.Header.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ParseWebhook reads a webhook delivery, verifies its signature when secret
+// is set, and converts it into a provider.Event.
+func (m *HookManager) ParseWebhook(r *http.Request, secret string) (*provider.Event, error) {
+	eventType := m.header(r, "Event")
+	if eventType == "" {
+		return nil, fmt.Errorf("missing %s header", m.headerNames("Event")[0])
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookPayload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook payload: %w", err)
+	}
+
+	if secret != "" {
+		signature := m.header(r, "Signature")
+		if signature == "" && m.flavor == FlavorGitea {
+			signature = r.Header.Get("X-Hub-Signature-256")
+		}
+		if !ValidateSignature(payload, signature, secret) {
+			return nil, ErrInvalidSignature
+		}
+	}
+
+	return m.ParsePayload(eventType, m.header(r, "Delivery"), payload)
+}
+
+// nolint:tagliatelle // External API format - must match Gitea JSON output
+type apiUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Username  string `json:"username"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+func (u *apiUser) toActor() provider.Actor {
+	if u == nil {
+		return provider.Actor{}
+	}
+	login := u.Login
+	if login == "" {
+		login = u.Username
+	}
+	return provider.Actor{
+		ID:        strconv.FormatInt(u.ID, 10),
+		Login:     login,
+		Name:      u.FullName,
+		Email:     u.Email,
+		AvatarURL: u.AvatarURL,
+	}
+}
+
+// nolint:tagliatelle // External API format - must match Gitea JSON output
+type apiRepo struct {
+	ID            int64    `json:"id"`
+	Name          string   `json:"name"`
+	FullName      string   `json:"full_name"`
+	Description   string   `json:"description"`
+	Private       bool     `json:"private"`
+	Fork          bool     `json:"fork"`
+	Archived      bool     `json:"archived"`
+	HTMLURL       string   `json:"html_url"`
+	CloneURL      string   `json:"clone_url"`
+	SSHURL        string   `json:"ssh_url"`
+	DefaultBranch string   `json:"default_branch"`
+	Owner         *apiUser `json:"owner"`
+}
+
+func (r *apiRepo) toProvider(providerType string) provider.Repository {
+	if r == nil {
+		return provider.Repository{}
+	}
+	visibility := provider.VisibilityPublic
+	if r.Private {
+		visibility = provider.VisibilityPrivate
+	}
+	var owner provider.Owner
+	if actor := r.Owner.toActor(); actor.Login != "" {
+		owner = provider.Owner{ID: actor.ID, Login: actor.Login, Name: actor.Name, Email: actor.Email, AvatarURL: actor.AvatarURL}
+	}
+	return provider.Repository{
+		ID:            strconv.FormatInt(r.ID, 10),
+		Owner:         owner,
+		Name:          r.Name,
+		FullName:      r.FullName,
+		Description:   r.Description,
+		DefaultBranch: r.DefaultBranch,
+		CloneURL:      r.CloneURL,
+		SSHURL:        r.SSHURL,
+		HTMLURL:       r.HTMLURL,
+		Private:       r.Private,
+		Archived:      r.Archived,
+		Fork:          r.Fork,
+		Visibility:    visibility,
+		ProviderType:  providerType,
+	}
+}
+
+// ParsePayload converts a webhook payload of the given event type into a
+// provider.Event. The full payload is kept in Event.Payload.
+func (m *HookManager) ParsePayload(eventType, deliveryID string, payload []byte) (*provider.Event, error) {
+	var envelope struct {
+		Action     string   `json:"action"`
+		Sender     *apiUser `json:"sender"`
+		Pusher     *apiUser `json:"pusher"`
+		Repository *apiRepo `json:"repository"`
+	}
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse %s payload: %w", eventType, err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s payload: %w", eventType, err)
+	}
+
+	sender := envelope.Sender
+	if sender == nil {
+		sender = envelope.Pusher
+	}
+
+	event := &provider.Event{
+		ID:           deliveryID,
+		Type:         eventType,
+		Actor:        sender.toActor(),
+		Repository:   envelope.Repository.toProvider(string(m.flavor)),
+		Payload:      raw,
+		Public:       envelope.Repository != nil && !envelope.Repository.Private,
+		CreatedAt:    time.Now(),
+		ProviderType: string(m.flavor),
+		ProviderData: map[string]any{"action": envelope.Action},
+	}
+	if event.ID == "" {
+		event.ID = fmt.Sprintf("%s-%d", eventType, event.CreatedAt.UnixNano())
+	}
+	return event, nil
+}
+
+// eventBus dispatches processed events to handlers and stream subscribers.
+type eventBus struct {
+	mu          sync.RWMutex
+	handlers    map[string][]provider.EventHandler
+	subscribers map[*subscriber]struct{}
+	recent      map[string]provider.Event
+	order       []string
+	log         *provider.EventLog
+}
+
+type subscriber struct {
+	ch    chan provider.Event
+	types map[string]bool
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{
+		handlers:    make(map[string][]provider.EventHandler),
+		subscribers: make(map[*subscriber]struct{}),
+		recent:      make(map[string]provider.Event),
+	}
+}
+
+// SetEventLog persists every processed event to log, which enables replay
+// through StreamOptions.FromSequence and StreamOptions.Since and offset
+// tracking with log.Consume.
+func (m *HookManager) SetEventLog(log *provider.EventLog) {
+	m.events.mu.Lock()
+	defer m.events.mu.Unlock()
+	m.events.log = log
+}
+
+// RegisterEventHandler registers handler for eventType. Use "*" to receive
+// every event.
+func (m *HookManager) RegisterEventHandler(eventType string, handler provider.EventHandler) error {
+	if eventType == "" {
+		return fmt.Errorf("event type cannot be empty")
+	}
+	if handler == nil {
+		return fmt.Errorf("event handler cannot be nil")
+	}
+	m.events.mu.Lock()
+	defer m.events.mu.Unlock()
+	m.events.handlers[eventType] = append(m.events.handlers[eventType], handler)
+	return nil
+}
+
+// ProcessEvent runs the handlers registered for the event's type and
+// publishes it to active streams. With an event log the event is persisted
+// first; redeliveries of a recent event are not logged twice. All handlers
+// run; their errors are joined.
+func (m *HookManager) ProcessEvent(ctx context.Context, event provider.Event) error {
+	bus := m.events
+
+	bus.mu.Lock()
+	if _, ok := bus.recent[event.ID]; !ok {
+		if bus.log != nil {
+			if _, err := bus.log.Append(event); err != nil {
+				bus.mu.Unlock()
+				return err
+			}
+		}
+		bus.order = append(bus.order, event.ID)
+		if len(bus.order) > recentEventLimit {
+			delete(bus.recent, bus.order[0])
+			bus.order = bus.order[1:]
+		}
+	}
+	bus.recent[event.ID] = event
+	handlers := append(append([]provider.EventHandler(nil), bus.handlers[event.Type]...), bus.handlers["*"]...)
+	bus.mu.Unlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	bus.mu.RLock()
+	for sub := range bus.subscribers {
+		if len(sub.types) > 0 && !sub.types[event.Type] {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			// A slow consumer must not block webhook processing.
+		}
+	}
+	bus.mu.RUnlock()
+
+	return errors.Join(errs...)
+}
+
+// GetEvent returns a recently processed event by ID.
+func (m *HookManager) GetEvent(_ context.Context, eventID string) (*provider.Event, error) {
+	m.events.mu.RLock()
+	defer m.events.mu.RUnlock()
+	event, ok := m.events.recent[eventID]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found", eventID)
+	}
+	return &event, nil
+}
+
+// StreamEvents returns a channel receiving every event passed to
+// ProcessEvent until ctx is done. Events are dropped when the buffer is full.
+// With an event log, FromSequence or Since first replays the logged events
+// from that point; live events follow without gaps or duplicates.
+func (m *HookManager) StreamEvents(ctx context.Context, opts provider.StreamOptions) (<-chan provider.Event, error) {
+	size := opts.BufferSize
+	if size <= 0 {
+		size = 100
+	}
+	sub := &subscriber{ch: make(chan provider.Event, size)}
+	if len(opts.EventTypes) > 0 {
+		sub.types = make(map[string]bool, len(opts.EventTypes))
+		for _, t := range opts.EventTypes {
+			sub.types[t] = true
+		}
+	}
+
+	replay := opts.FromSequence > 0 || !opts.Since.IsZero()
+
+	m.events.mu.Lock()
+	log := m.events.log
+	if replay && log == nil {
+		m.events.mu.Unlock()
+		return nil, fmt.Errorf("event replay requires an event log")
+	}
+	m.events.subscribers[sub] = struct{}{}
+	// Events up to cutoff are replayed from the log; later ones reach sub.
+	var cutoff uint64
+	if replay {
+		cutoff = log.LastSeq()
+	}
+	m.events.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.events.mu.Lock()
+		delete(m.events.subscribers, sub)
+		m.events.mu.Unlock()
+		close(sub.ch)
+	}()
+
+	if cutoff == 0 {
+		// No replay requested, or nothing logged yet
+		return sub.ch, nil
+	}
+
+	out := make(chan provider.Event, size)
+	go func() {
+		defer close(out)
+		replayOpts := provider.ReplayOptions{
+			FromSeq:    opts.FromSequence,
+			ToSeq:      cutoff,
+			Since:      opts.Since,
+			EventTypes: opts.EventTypes,
+		}
+		err := log.Replay(ctx, replayOpts, func(rec provider.EventRecord) error {
+			select {
+			case out <- rec.Event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			return
+		}
+		for event := range sub.ch {
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// nolint:tagliatelle // External API format - must match Gitea JSON output
+type apiActivity struct {
+	ID      int64     `json:"id"`
+	OpType  string    `json:"op_type"`
+	ActUser *apiUser  `json:"act_user"`
+	Repo    *apiRepo  `json:"repo"`
+	RefName string    `json:"ref_name"`
+	Content string    `json:"content"`
+	Created time.Time `json:"created"`
+}
+
+// ListEvents lists activity feed entries for a repository, organization or
+// user (in that order of precedence). Activity feeds require Gitea 1.21 or
+// later; Gogs has no activity API.
+func (m *HookManager) ListEvents(ctx context.Context, opts provider.EventListOptions) ([]provider.Event, error) {
+	if m.flavor == FlavorGogs {
+		return nil, fmt.Errorf("listing events is not supported by Gogs")
+	}
+
+	var path string
+	switch {
+	case opts.Repository != "":
+		owner, repo, err := m.helpers.ParseRepositoryURL(opts.Repository)
+		if err != nil {
+			return nil, err
+		}
+		path = repoPath(owner, repo) + "/activities/feeds"
+	case opts.Organization != "":
+		path = "/orgs/" + url.PathEscape(opts.Organization) + "/activities/feeds"
+	case opts.User != "":
+		path = "/users/" + url.PathEscape(opts.User) + "/activities/feeds"
+	default:
+		return nil, fmt.Errorf("repository, organization or user must be specified")
+	}
+
+	params := url.Values{}
+	if opts.Page > 0 {
+		params.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage > 0 {
+		params.Set("limit", strconv.Itoa(opts.PerPage))
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var activities []apiActivity
+	if err := m.do(ctx, http.MethodGet, path, nil, &activities); err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	events := make([]provider.Event, 0, len(activities))
+	for _, a := range activities {
+		if opts.EventType != "" && a.OpType != opts.EventType {
+			continue
+		}
+		if !opts.Since.IsZero() && a.Created.Before(opts.Since) {
+			continue
+		}
+		if !opts.Until.IsZero() && a.Created.After(opts.Until) {
+			continue
+		}
+		events = append(events, provider.Event{
+			ID:           strconv.FormatInt(a.ID, 10),
+			Type:         a.OpType,
+			Actor:        a.ActUser.toActor(),
+			Repository:   a.Repo.toProvider(string(m.flavor)),
+			Public:       a.Repo != nil && !a.Repo.Private,
+			CreatedAt:    a.Created,
+			ProviderType: string(m.flavor),
+			ProviderData: map[string]any{"ref_name": a.RefName, "content": a.Content},
+		})
+	}
+	return events, nil
+}
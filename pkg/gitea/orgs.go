@@ -0,0 +1,335 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+// Team permissions. Gitea grants one permission per team that applies to
+// every repository of the team.
+const (
+	TeamPermissionRead  = "read"
+	TeamPermissionWrite = "write"
+	TeamPermissionAdmin = "admin"
+	// TeamPermissionOwner is only held by the Owners team.
+	TeamPermissionOwner = "owner"
+)
+
+// DefaultTeamUnits are the repository units a team gets access to when
+// created without units.
+var DefaultTeamUnits = []string{
+	"repo.code", "repo.issues", "repo.pulls", "repo.releases",
+	"repo.wiki", "repo.ext_wiki", "repo.ext_issues", "repo.projects", "repo.packages", "repo.actions",
+}
+
+// listLimit is the page size of list requests; Gitea caps it at 50 by
+// default.
+const listLimit = 50
+
+// maxListPages bounds listPages against servers that ignore the page
+// parameter.
+const maxListPages = 1000
+
+// Organization is a Gitea organization.
+// nolint:tagliatelle // External API format - must match Gitea JSON output
+type Organization struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"username"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	Website     string `json:"website"`
+	Location    string `json:"location"`
+	// Visibility is public, limited (signed-in users) or private.
+	Visibility                string `json:"visibility"`
+	RepoAdminChangeTeamAccess bool   `json:"repo_admin_change_team_access"`
+}
+
+// OrganizationOptions creates or edits an organization. Empty fields are
+// left unchanged on edit.
+// nolint:tagliatelle // External API format - must match Gitea JSON input
+type OrganizationOptions struct {
+	FullName                  string `json:"full_name,omitempty"`
+	Description               string `json:"description,omitempty"`
+	Website                   string `json:"website,omitempty"`
+	Location                  string `json:"location,omitempty"`
+	Visibility                string `json:"visibility,omitempty"`
+	RepoAdminChangeTeamAccess *bool  `json:"repo_admin_change_team_access,omitempty"`
+}
+
+// Team is a team of an organization.
+// nolint:tagliatelle // External API format - must match Gitea JSON output
+type Team struct {
+	ID                      int64             `json:"id"`
+	Name                    string            `json:"name"`
+	Description             string            `json:"description"`
+	Permission              string            `json:"permission"`
+	Units                   []string          `json:"units"`
+	UnitsMap                map[string]string `json:"units_map"`
+	IncludesAllRepositories bool              `json:"includes_all_repositories"`
+	CanCreateOrgRepo        bool              `json:"can_create_org_repo"`
+}
+
+// TeamOptions creates or edits a team.
+// nolint:tagliatelle // External API format - must match Gitea JSON input
+type TeamOptions struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Permission is read, write or admin.
+	Permission string `json:"permission,omitempty"`
+	// Units default to DefaultTeamUnits on create.
+	Units                   []string          `json:"units,omitempty"`
+	UnitsMap                map[string]string `json:"units_map,omitempty"`
+	IncludesAllRepositories *bool             `json:"includes_all_repositories,omitempty"`
+	CanCreateOrgRepo        *bool             `json:"can_create_org_repo,omitempty"`
+}
+
+// User is a Gitea user as listed in teams and collaborators.
+// nolint:tagliatelle // External API format - must match Gitea JSON output
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+}
+
+// OrgManager manages organizations, teams, repository collaborators and
+// repository settings of a Gitea instance.
+type OrgManager struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewOrgManager creates an organization manager for the API at baseURL,
+// e.g. https://gitea.example.com/api/v1.
+func NewOrgManager(baseURL, token string) *OrgManager {
+	if baseURL == "" {
+		baseURL = "https://gitea.com/api/v1"
+	}
+	return &OrgManager{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  httpclient.GetGlobalClient("gitea"),
+	}
+}
+
+// SetToken sets the API token.
+func (m *OrgManager) SetToken(token string) {
+	m.token = token
+}
+
+func (m *OrgManager) do(ctx context.Context, method, path string, in, out any) error {
+	return doAPI(ctx, m.client, m.baseURL, m.token, method, path, in, out)
+}
+
+// listPages fetches every page of a list endpoint.
+func listPages[T any](ctx context.Context, m *OrgManager, path string) ([]T, error) {
+	var all []T
+	for page := 1; page <= maxListPages; page++ {
+		var items []T
+		target := path + "?page=" + strconv.Itoa(page) + "&limit=" + strconv.Itoa(listLimit)
+		if err := m.do(ctx, http.MethodGet, target, nil, &items); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) < listLimit {
+			return all, nil
+		}
+	}
+	return nil, fmt.Errorf("GET %s: more than %d pages", path, maxListPages)
+}
+
+func orgPath(org string) string {
+	return "/orgs/" + url.PathEscape(org)
+}
+
+func teamPath(id int64) string {
+	return "/teams/" + strconv.FormatInt(id, 10)
+}
+
+func teamRepoPath(teamID int64, org, repo string) string {
+	return teamPath(teamID) + "/repos/" + url.PathEscape(org) + "/" + url.PathEscape(repo)
+}
+
+// ListOrganizations lists the organizations of the authenticated user.
+func (m *OrgManager) ListOrganizations(ctx context.Context) ([]Organization, error) {
+	orgs, err := listPages[Organization](ctx, m, "/user/orgs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	return orgs, nil
+}
+
+// GetOrganization returns an organization.
+func (m *OrgManager) GetOrganization(ctx context.Context, org string) (*Organization, error) {
+	var out Organization
+	if err := m.do(ctx, http.MethodGet, orgPath(org), nil, &out); err != nil {
+		return nil, fmt.Errorf("failed to get organization %s: %w", org, err)
+	}
+	return &out, nil
+}
+
+// CreateOrganization creates an organization owned by the authenticated
+// user.
+func (m *OrgManager) CreateOrganization(ctx context.Context, name string, opts OrganizationOptions) (*Organization, error) {
+	body := struct {
+		Name string `json:"username"`
+		OrganizationOptions
+	}{Name: name, OrganizationOptions: opts}
+
+	var out Organization
+	if err := m.do(ctx, http.MethodPost, "/orgs", body, &out); err != nil {
+		return nil, fmt.Errorf("failed to create organization %s: %w", name, err)
+	}
+	return &out, nil
+}
+
+// EditOrganization changes the profile and visibility of an organization.
+func (m *OrgManager) EditOrganization(ctx context.Context, org string, opts OrganizationOptions) (*Organization, error) {
+	var out Organization
+	if err := m.do(ctx, http.MethodPatch, orgPath(org), opts, &out); err != nil {
+		return nil, fmt.Errorf("failed to edit organization %s: %w", org, err)
+	}
+	return &out, nil
+}
+
+// DeleteOrganization deletes an organization. Gitea refuses while it still
+// owns repositories.
+func (m *OrgManager) DeleteOrganization(ctx context.Context, org string) error {
+	if err := m.do(ctx, http.MethodDelete, orgPath(org), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete organization %s: %w", org, err)
+	}
+	return nil
+}
+
+// ListTeams lists the teams of an organization.
+func (m *OrgManager) ListTeams(ctx context.Context, org string) ([]Team, error) {
+	teams, err := listPages[Team](ctx, m, orgPath(org)+"/teams")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams of %s: %w", org, err)
+	}
+	return teams, nil
+}
+
+// CreateTeam creates a team. Without units the team gets DefaultTeamUnits,
+// each at the team permission.
+func (m *OrgManager) CreateTeam(ctx context.Context, org string, opts TeamOptions) (*Team, error) {
+	if opts.Permission == "" {
+		opts.Permission = TeamPermissionRead
+	}
+	if len(opts.Units) == 0 {
+		opts.Units = DefaultTeamUnits
+	}
+	if opts.UnitsMap == nil {
+		opts.UnitsMap = unitsMap(opts.Units, opts.Permission)
+	}
+
+	var out Team
+	if err := m.do(ctx, http.MethodPost, orgPath(org)+"/teams", opts, &out); err != nil {
+		return nil, fmt.Errorf("failed to create team %s: %w", opts.Name, err)
+	}
+	return &out, nil
+}
+
+// EditTeam changes a team. When the permission changes without units, the
+// team's current units move to the new permission, since Gitea 1.20 and
+// later take unit permissions from units_map.
+func (m *OrgManager) EditTeam(ctx context.Context, team Team, opts TeamOptions) (*Team, error) {
+	if opts.Name == "" {
+		// Gitea는 수정 요청에도 이름을 요구함
+		opts.Name = team.Name
+	}
+	if opts.Permission != "" && opts.UnitsMap == nil {
+		units := opts.Units
+		if len(units) == 0 {
+			units = team.Units
+		}
+		opts.UnitsMap = unitsMap(units, opts.Permission)
+	}
+
+	var out Team
+	if err := m.do(ctx, http.MethodPatch, teamPath(team.ID), opts, &out); err != nil {
+		return nil, fmt.Errorf("failed to edit team %s: %w", team.Name, err)
+	}
+	return &out, nil
+}
+
+// DeleteTeam deletes a team.
+func (m *OrgManager) DeleteTeam(ctx context.Context, teamID int64) error {
+	if err := m.do(ctx, http.MethodDelete, teamPath(teamID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete team %d: %w", teamID, err)
+	}
+	return nil
+}
+
+// ListTeamMembers lists the members of a team.
+func (m *OrgManager) ListTeamMembers(ctx context.Context, teamID int64) ([]User, error) {
+	users, err := listPages[User](ctx, m, teamPath(teamID)+"/members")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of team %d: %w", teamID, err)
+	}
+	return users, nil
+}
+
+// AddTeamMember adds a user to a team.
+func (m *OrgManager) AddTeamMember(ctx context.Context, teamID int64, login string) error {
+	if err := m.do(ctx, http.MethodPut, teamPath(teamID)+"/members/"+url.PathEscape(login), nil, nil); err != nil {
+		return fmt.Errorf("failed to add %s to team %d: %w", login, teamID, err)
+	}
+	return nil
+}
+
+// RemoveTeamMember removes a user from a team.
+func (m *OrgManager) RemoveTeamMember(ctx context.Context, teamID int64, login string) error {
+	if err := m.do(ctx, http.MethodDelete, teamPath(teamID)+"/members/"+url.PathEscape(login), nil, nil); err != nil {
+		return fmt.Errorf("failed to remove %s from team %d: %w", login, teamID, err)
+	}
+	return nil
+}
+
+// ListTeamRepositories lists the names of the repositories of a team.
+func (m *OrgManager) ListTeamRepositories(ctx context.Context, teamID int64) ([]string, error) {
+	repos, err := listPages[apiRepo](ctx, m, teamPath(teamID)+"/repos")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories of team %d: %w", teamID, err)
+	}
+	names := make([]string, 0, len(repos))
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+	return names, nil
+}
+
+// AddTeamRepository gives a team access to a repository of org.
+func (m *OrgManager) AddTeamRepository(ctx context.Context, teamID int64, org, repo string) error {
+	if err := m.do(ctx, http.MethodPut, teamRepoPath(teamID, org, repo), nil, nil); err != nil {
+		return fmt.Errorf("failed to add %s/%s to team %d: %w", org, repo, teamID, err)
+	}
+	return nil
+}
+
+// RemoveTeamRepository revokes a team's access to a repository of org.
+func (m *OrgManager) RemoveTeamRepository(ctx context.Context, teamID int64, org, repo string) error {
+	if err := m.do(ctx, http.MethodDelete, teamRepoPath(teamID, org, repo), nil, nil); err != nil {
+		return fmt.Errorf("failed to remove %s/%s from team %d: %w", org, repo, teamID, err)
+	}
+	return nil
+}
+
+// unitsMap grants every unit the same permission.
+func unitsMap(units []string, permission string) map[string]string {
+	m := make(map[string]string, len(units))
+	for _, u := range units {
+		m[u] = permission
+	}
+	return m
+}
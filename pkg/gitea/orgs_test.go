@@ -0,0 +1,141 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+func TestOrgManager_OrganizationsAndTeams(t *testing.T) {
+	var createdOrg, createdTeam map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/orgs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret-token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&createdOrg))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"id":3,"username":"acme","visibility":"private"}`)
+	})
+	mux.HandleFunc("/api/v1/orgs/acme", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = io.WriteString(w, `{"message":"organization still owns repositories"}`)
+		default:
+			_, _ = io.WriteString(w, `{"id":3,"username":"acme","full_name":"Acme Corp"}`)
+		}
+	})
+	mux.HandleFunc("/api/v1/orgs/acme/teams", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&createdTeam))
+			_, _ = io.WriteString(w, `{"id":9,"name":"ops","permission":"write"}`)
+			return
+		}
+		// Two pages: a full one, then a short one.
+		count := listLimit
+		if r.URL.Query().Get("page") == "2" {
+			count = 2
+		}
+		teams := make([]string, count)
+		for i := range teams {
+			teams[i] = fmt.Sprintf(`{"id":%s%d,"name":"t%d"}`, r.URL.Query().Get("page"), i, i)
+		}
+		_, _ = io.WriteString(w, "["+strings.Join(teams, ",")+"]")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	m := NewOrgManager(server.URL+"/api/v1", "secret-token")
+	ctx := context.Background()
+
+	org, err := m.CreateOrganization(ctx, "acme", OrganizationOptions{FullName: "Acme Corp", Visibility: "private"})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", org.Name)
+	assert.Equal(t, map[string]any{"username": "acme", "full_name": "Acme Corp", "visibility": "private"}, createdOrg)
+
+	org, err = m.GetOrganization(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Corp", org.FullName)
+
+	err = m.DeleteOrganization(ctx, "acme")
+	assert.True(t, isStatus(err, http.StatusUnprocessableEntity))
+	assert.ErrorContains(t, err, "still owns repositories")
+
+	teams, err := m.ListTeams(ctx, "acme")
+	require.NoError(t, err)
+	assert.Len(t, teams, listLimit+2)
+
+	team, err := m.CreateTeam(ctx, "acme", TeamOptions{Name: "ops", Permission: TeamPermissionWrite, Units: []string{"repo.code", "repo.pulls"}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), team.ID)
+	assert.Equal(t, map[string]any{"repo.code": "write", "repo.pulls": "write"}, createdTeam["units_map"])
+}
+
+func TestOrgManager_CollaboratorsAndSettings(t *testing.T) {
+	var permissionSet map[string]string
+	var settings map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/app/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `[{"id":1,"login":"alice"},{"id":2,"login":"bob"}]`)
+	})
+	mux.HandleFunc("/repos/acme/app/collaborators/alice/permission", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"permission":"admin"}`)
+	})
+	mux.HandleFunc("/repos/acme/app/collaborators/bob/permission", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"permission":"read"}`)
+	})
+	mux.HandleFunc("/repos/acme/app/collaborators/carol", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&permissionSet))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/acme/app", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		settings = nil
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&settings))
+		_, _ = io.WriteString(w, `{"id":5,"name":"app","full_name":"acme/app","private":true,"archived":true,"default_branch":"trunk"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	m := NewOrgManager(server.URL, "")
+	ctx := context.Background()
+
+	collaborators, err := m.ListCollaborators(ctx, "acme", "app")
+	require.NoError(t, err)
+	require.Len(t, collaborators, 2)
+	assert.Equal(t, "alice", collaborators[0].Login)
+	assert.Equal(t, "admin", collaborators[0].Permission)
+	assert.Equal(t, "read", collaborators[1].Permission)
+
+	require.NoError(t, m.SetCollaborator(ctx, "acme", "app", "carol", TeamPermissionWrite))
+	assert.Equal(t, map[string]string{"permission": "write"}, permissionSet)
+	assert.ErrorContains(t, m.SetCollaborator(ctx, "acme", "app", "carol", "maintain"), "invalid collaborator permission")
+
+	p := NewGiteaProvider(server.URL)
+	branch := "trunk"
+	squash := false
+	repo, err := p.UpdateRepository(ctx, "acme/app", provider.UpdateRepoRequest{
+		DefaultBranch:    &branch,
+		AllowSquashMerge: &squash,
+		Visibility:       provider.VisibilityPrivate,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "trunk", repo.DefaultBranch)
+	assert.Equal(t, map[string]any{"default_branch": "trunk", "allow_squash_merge": false, "private": true}, settings)
+
+	require.NoError(t, p.ArchiveRepository(ctx, "acme/app"))
+	assert.Equal(t, map[string]any{"archived": true}, settings)
+}
@@ -16,6 +16,8 @@ import (
 type GiteaProvider struct {
 	*provider.BaseProvider
 	helpers *provider.CommonHelpers
+	hooks   *HookManager
+	orgs    *OrgManager
 }
 
 // Ensure GiteaProvider implements GitProvider interface
@@ -29,6 +31,8 @@ func NewGiteaProvider(baseURL string) *GiteaProvider {
 	return &GiteaProvider{
 		BaseProvider: provider.NewBaseProvider("gitea", baseURL, ""),
 		helpers:      provider.NewCommonHelpers(),
+		hooks:        NewHookManager(FlavorGitea, baseURL, ""),
+		orgs:         NewOrgManager(baseURL, ""),
 	}
 }
 
@@ -42,6 +46,8 @@ func (g *GiteaProvider) Authenticate(ctx context.Context, creds provider.Credent
 	switch creds.Type {
 	case provider.CredentialTypeToken:
 		g.SetToken(creds.Token)
+		g.hooks.SetToken(creds.Token)
+		g.orgs.SetToken(creds.Token)
 		return nil
 	default:
 		return g.FormatError("authenticate", fmt.Errorf("unsupported credential type: %s", creds.Type))
@@ -153,20 +159,35 @@ func (g *GiteaProvider) CreateRepository(ctx context.Context, req provider.Creat
 	return nil, g.FormatError("create repository", fmt.Errorf("not implemented"))
 }
 
+// UpdateRepository changes the settings of an owner/repo.
 func (g *GiteaProvider) UpdateRepository(ctx context.Context, id string, updates provider.UpdateRepoRequest) (*provider.Repository, error) {
-	return nil, g.FormatError("update repository", fmt.Errorf("not implemented"))
+	owner, repo, err := g.helpers.ParseRepositoryURL(id)
+	if err != nil {
+		return nil, g.FormatError("update repository", err)
+	}
+	updated, err := g.orgs.EditRepository(ctx, owner, repo, SettingsFromUpdate(updates))
+	if err != nil {
+		return nil, g.FormatError("update repository", err)
+	}
+	return updated, nil
 }
 
 func (g *GiteaProvider) DeleteRepository(ctx context.Context, id string) error {
 	return g.FormatError("delete repository", fmt.Errorf("not implemented"))
 }
 
+// ArchiveRepository makes an owner/repo read-only.
 func (g *GiteaProvider) ArchiveRepository(ctx context.Context, id string) error {
-	return g.FormatError("archive repository", fmt.Errorf("not implemented"))
+	archived := true
+	_, err := g.UpdateRepository(ctx, id, provider.UpdateRepoRequest{Archived: &archived})
+	return err
 }
 
+// UnarchiveRepository makes an archived owner/repo writable again.
 func (g *GiteaProvider) UnarchiveRepository(ctx context.Context, id string) error {
-	return g.FormatError("unarchive repository", fmt.Errorf("not implemented"))
+	archived := false
+	_, err := g.UpdateRepository(ctx, id, provider.UpdateRepoRequest{Archived: &archived})
+	return err
 }
 
 func (g *GiteaProvider) ForkRepository(ctx context.Context, id string, opts provider.ForkOptions) (*provider.Repository, error) {
@@ -177,60 +198,120 @@ func (g *GiteaProvider) SearchRepositories(ctx context.Context, query provider.S
 	return nil, g.FormatError("search repositories", fmt.Errorf("not implemented"))
 }
 
-// Webhook management methods (placeholder implementations)
+// Webhook management methods
+
+// ListWebhooks lists webhooks for a repository.
 func (g *GiteaProvider) ListWebhooks(ctx context.Context, repoID string) ([]provider.Webhook, error) {
-	return nil, g.FormatError("list webhooks", fmt.Errorf("not implemented"))
+	hooks, err := g.hooks.ListWebhooks(ctx, repoID)
+	if err != nil {
+		return nil, g.FormatError("list webhooks", err)
+	}
+	return hooks, nil
 }
 
+// GetWebhook gets a webhook by ID.
 func (g *GiteaProvider) GetWebhook(ctx context.Context, repoID, webhookID string) (*provider.Webhook, error) {
-	return nil, g.FormatError("get webhook", fmt.Errorf("not implemented"))
+	hook, err := g.hooks.GetWebhook(ctx, repoID, webhookID)
+	if err != nil {
+		return nil, g.FormatError("get webhook", err)
+	}
+	return hook, nil
 }
 
+// CreateWebhook creates a webhook.
 func (g *GiteaProvider) CreateWebhook(ctx context.Context, repoID string, webhook provider.CreateWebhookRequest) (*provider.Webhook, error) {
-	if err := g.helpers.ValidateWebhookRequest(repoID, "", webhook.Config.URL); err != nil {
+	hook, err := g.hooks.CreateWebhook(ctx, repoID, webhook)
+	if err != nil {
 		return nil, g.FormatError("create webhook", err)
 	}
-	return nil, g.FormatError("create webhook", fmt.Errorf("not implemented"))
+	return hook, nil
 }
 
+// UpdateWebhook updates a webhook.
 func (g *GiteaProvider) UpdateWebhook(ctx context.Context, repoID, webhookID string, updates provider.UpdateWebhookRequest) (*provider.Webhook, error) {
-	return nil, g.FormatError("update webhook", fmt.Errorf("not implemented"))
+	hook, err := g.hooks.UpdateWebhook(ctx, repoID, webhookID, updates)
+	if err != nil {
+		return nil, g.FormatError("update webhook", err)
+	}
+	return hook, nil
 }
 
+// DeleteWebhook deletes a webhook.
 func (g *GiteaProvider) DeleteWebhook(ctx context.Context, repoID, webhookID string) error {
-	return g.FormatError("delete webhook", fmt.Errorf("not implemented"))
+	if err := g.hooks.DeleteWebhook(ctx, repoID, webhookID); err != nil {
+		return g.FormatError("delete webhook", err)
+	}
+	return nil
 }
 
+// TestWebhook triggers a test delivery.
 func (g *GiteaProvider) TestWebhook(ctx context.Context, repoID, webhookID string) (*provider.WebhookTestResult, error) {
-	return nil, g.FormatError("test webhook", fmt.Errorf("not implemented"))
+	result, err := g.hooks.TestWebhook(ctx, repoID, webhookID)
+	if err != nil {
+		return nil, g.FormatError("test webhook", err)
+	}
+	return result, nil
 }
 
+// ValidateWebhookURL validates a webhook target URL.
 func (g *GiteaProvider) ValidateWebhookURL(ctx context.Context, url string) error {
-	if err := g.helpers.ValidateWebhookRequest("", "", url); err != nil {
+	if err := g.hooks.ValidateWebhookURL(ctx, url); err != nil {
 		return g.FormatError("validate webhook URL", err)
 	}
-	return g.FormatError("validate webhook URL", fmt.Errorf("not implemented"))
+	return nil
+}
+
+// Orgs returns the manager for organizations, teams, collaborators and
+// repository settings.
+func (g *GiteaProvider) Orgs() *OrgManager {
+	return g.orgs
 }
 
-// Event management methods (placeholder implementations)
+// Hooks returns the webhook and event manager, which also parses incoming
+// webhook deliveries.
+func (g *GiteaProvider) Hooks() *HookManager {
+	return g.hooks
+}
+
+// Event management methods
+
+// ListEvents lists activity feed events.
 func (g *GiteaProvider) ListEvents(ctx context.Context, opts provider.EventListOptions) ([]provider.Event, error) {
-	return nil, g.FormatError("list events", fmt.Errorf("not implemented"))
+	events, err := g.hooks.ListEvents(ctx, opts)
+	if err != nil {
+		return nil, g.FormatError("list events", err)
+	}
+	return events, nil
 }
 
+// GetEvent returns a recently processed webhook event.
 func (g *GiteaProvider) GetEvent(ctx context.Context, eventID string) (*provider.Event, error) {
-	return nil, g.FormatError("get event", fmt.Errorf("not implemented"))
+	event, err := g.hooks.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, g.FormatError("get event", err)
+	}
+	return event, nil
 }
 
+// ProcessEvent dispatches an event to registered handlers and streams.
 func (g *GiteaProvider) ProcessEvent(ctx context.Context, event provider.Event) error {
-	return g.FormatError("process event", fmt.Errorf("not implemented"))
+	if err := g.hooks.ProcessEvent(ctx, event); err != nil {
+		return g.FormatError("process event", err)
+	}
+	return nil
 }
 
+// RegisterEventHandler registers a handler for an event type.
 func (g *GiteaProvider) RegisterEventHandler(eventType string, handler provider.EventHandler) error {
-	return g.FormatError("register event handler", fmt.Errorf("not implemented"))
+	if err := g.hooks.RegisterEventHandler(eventType, handler); err != nil {
+		return g.FormatError("register event handler", err)
+	}
+	return nil
 }
 
+// StreamEvents streams processed events until ctx is done.
 func (g *GiteaProvider) StreamEvents(ctx context.Context, opts provider.StreamOptions) (<-chan provider.Event, error) {
-	return nil, g.FormatError("stream events", fmt.Errorf("not implemented"))
+	return g.hooks.StreamEvents(ctx, opts)
 }
 
 // Health and monitoring methods
@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// Collaborator is a user with direct access to a repository.
+type Collaborator struct {
+	User
+	// Permission is read, write, admin or owner.
+	Permission string `json:"permission"`
+}
+
+// RepositorySettings edits a repository. Nil fields are left unchanged.
+// nolint:tagliatelle // External API format - must match Gitea JSON input
+type RepositorySettings struct {
+	Name                          *string `json:"name,omitempty"`
+	Description                   *string `json:"description,omitempty"`
+	Website                       *string `json:"website,omitempty"`
+	Private                       *bool   `json:"private,omitempty"`
+	Template                      *bool   `json:"template,omitempty"`
+	HasIssues                     *bool   `json:"has_issues,omitempty"`
+	HasWiki                       *bool   `json:"has_wiki,omitempty"`
+	HasPullRequests               *bool   `json:"has_pull_requests,omitempty"`
+	HasProjects                   *bool   `json:"has_projects,omitempty"`
+	HasReleases                   *bool   `json:"has_releases,omitempty"`
+	HasActions                    *bool   `json:"has_actions,omitempty"`
+	DefaultBranch                 *string `json:"default_branch,omitempty"`
+	AllowMergeCommits             *bool   `json:"allow_merge_commits,omitempty"`
+	AllowRebase                   *bool   `json:"allow_rebase,omitempty"`
+	AllowSquashMerge              *bool   `json:"allow_squash_merge,omitempty"`
+	DefaultDeleteBranchAfterMerge *bool   `json:"default_delete_branch_after_merge,omitempty"`
+	// DefaultMergeStyle is merge, rebase, rebase-merge or squash.
+	DefaultMergeStyle *string `json:"default_merge_style,omitempty"`
+	Archived          *bool   `json:"archived,omitempty"`
+}
+
+// SettingsFromUpdate converts a provider update request. Fields Gitea does
+// not have, such as downloads and auto merge, are ignored.
+func SettingsFromUpdate(u provider.UpdateRepoRequest) RepositorySettings {
+	s := RepositorySettings{
+		Name:              u.Name,
+		Description:       u.Description,
+		Website:           u.Homepage,
+		Private:           u.Private,
+		HasIssues:         u.HasIssues,
+		HasWiki:           u.HasWiki,
+		HasProjects:       u.HasProjects,
+		DefaultBranch:     u.DefaultBranch,
+		AllowMergeCommits: u.AllowMergeCommit,
+		AllowRebase:       u.AllowRebaseMerge,
+		AllowSquashMerge:  u.AllowSquashMerge,
+		Archived:          u.Archived,
+	}
+	if s.Private == nil && u.Visibility != "" {
+		private := u.Visibility != provider.VisibilityPublic
+		s.Private = &private
+	}
+	return s
+}
+
+// GetRepository returns a repository.
+func (m *OrgManager) GetRepository(ctx context.Context, owner, repo string) (*provider.Repository, error) {
+	var out apiRepo
+	if err := m.do(ctx, http.MethodGet, repoPath(owner, repo), nil, &out); err != nil {
+		return nil, fmt.Errorf("failed to get repository %s/%s: %w", owner, repo, err)
+	}
+	r := out.toProvider("gitea")
+	return &r, nil
+}
+
+// EditRepository updates the settings of a repository.
+func (m *OrgManager) EditRepository(ctx context.Context, owner, repo string, settings RepositorySettings) (*provider.Repository, error) {
+	var out apiRepo
+	if err := m.do(ctx, http.MethodPatch, repoPath(owner, repo), settings, &out); err != nil {
+		return nil, fmt.Errorf("failed to update repository %s/%s: %w", owner, repo, err)
+	}
+	r := out.toProvider("gitea")
+	return &r, nil
+}
+
+func collaboratorPath(owner, repo, login string) string {
+	return repoPath(owner, repo) + "/collaborators/" + url.PathEscape(login)
+}
+
+// ListCollaborators lists the direct collaborators of a repository with
+// their permission. Members with access through a team are not listed.
+func (m *OrgManager) ListCollaborators(ctx context.Context, owner, repo string) ([]Collaborator, error) {
+	users, err := listPages[User](ctx, m, repoPath(owner, repo)+"/collaborators")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collaborators of %s/%s: %w", owner, repo, err)
+	}
+
+	out := make([]Collaborator, 0, len(users))
+	for _, u := range users {
+		perm, err := m.CollaboratorPermission(ctx, owner, repo, u.Login)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Collaborator{User: u, Permission: perm})
+	}
+	return out, nil
+}
+
+// CollaboratorPermission returns the effective permission of a user on a
+// repository, including access through teams: none, read, write, admin or
+// owner.
+func (m *OrgManager) CollaboratorPermission(ctx context.Context, owner, repo, login string) (string, error) {
+	var out struct {
+		Permission string `json:"permission"`
+	}
+	if err := m.do(ctx, http.MethodGet, collaboratorPath(owner, repo, login)+"/permission", nil, &out); err != nil {
+		return "", fmt.Errorf("failed to get permission of %s on %s/%s: %w", login, owner, repo, err)
+	}
+	return out.Permission, nil
+}
+
+// SetCollaborator adds a collaborator or changes their permission, which is
+// read, write or admin.
+func (m *OrgManager) SetCollaborator(ctx context.Context, owner, repo, login, permission string) error {
+	switch permission {
+	case TeamPermissionRead, TeamPermissionWrite, TeamPermissionAdmin:
+	default:
+		return fmt.Errorf("invalid collaborator permission %q (expected read, write or admin)", permission)
+	}
+	body := map[string]string{"permission": permission}
+	if err := m.do(ctx, http.MethodPut, collaboratorPath(owner, repo, login), body, nil); err != nil {
+		return fmt.Errorf("failed to set %s as collaborator on %s/%s: %w", login, owner, repo, err)
+	}
+	return nil
+}
+
+// RemoveCollaborator removes a collaborator from a repository.
+func (m *OrgManager) RemoveCollaborator(ctx context.Context, owner, repo, login string) error {
+	if err := m.do(ctx, http.MethodDelete, collaboratorPath(owner, repo, login), nil, nil); err != nil {
+		return fmt.Errorf("failed to remove collaborator %s from %s/%s: %w", login, owner, repo, err)
+	}
+	return nil
+}
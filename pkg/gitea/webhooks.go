@@ -0,0 +1,397 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// Flavor selects between the Gitea API and the Gogs API it was forked from.
+// Both serve webhooks under /api/v1/repos/{owner}/{repo}/hooks with the same
+// payload shapes; they differ in hook type, delivery headers and a few
+// endpoints Gogs never gained.
+type Flavor string
+
+const (
+	FlavorGitea Flavor = "gitea"
+	FlavorGogs  Flavor = "gogs"
+)
+
+// DefaultHookEvents are subscribed when a webhook is created without events.
+var DefaultHookEvents = []string{"push"}
+
+// HookManager implements provider.WebhookManager and provider.EventManager
+// for a Gitea or Gogs instance.
+type HookManager struct {
+	flavor  Flavor
+	baseURL string
+	token   string
+	client  *http.Client
+	helpers *provider.CommonHelpers
+
+	events *eventBus
+}
+
+var (
+	_ provider.WebhookManager = (*HookManager)(nil)
+	_ provider.EventManager   = (*HookManager)(nil)
+)
+
+// NewHookManager creates a hook manager for the API at baseURL, e.g.
+// https://gitea.com/api/v1.
+func NewHookManager(flavor Flavor, baseURL, token string) *HookManager {
+	return &HookManager{
+		flavor:  flavor,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  httpclient.GetGlobalClient("gitea"),
+		helpers: provider.NewCommonHelpers(),
+		events:  newEventBus(),
+	}
+}
+
+// NewGogsHookManager creates a hook manager for a Gogs instance.
+func NewGogsHookManager(baseURL, token string) *HookManager {
+	return NewHookManager(FlavorGogs, baseURL, token)
+}
+
+// SetToken sets the API token.
+func (m *HookManager) SetToken(token string) {
+	m.token = token
+}
+
+// Flavor returns whether the manager talks to Gitea or Gogs.
+func (m *HookManager) Flavor() Flavor {
+	return m.flavor
+}
+
+// apiHook is a webhook as returned by the Gitea and Gogs APIs.
+// nolint:tagliatelle // External API format - must match Gitea JSON output
+type apiHook struct {
+	ID        int64             `json:"id"`
+	Type      string            `json:"type"`
+	Config    map[string]string `json:"config"`
+	Events    []string          `json:"events"`
+	Active    bool              `json:"active"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// nolint:tagliatelle // External API format - must match Gitea JSON input
+type apiCreateHook struct {
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+type apiEditHook struct {
+	Config map[string]string `json:"config,omitempty"`
+	Events []string          `json:"events,omitempty"`
+	Active *bool             `json:"active,omitempty"`
+}
+
+func (h apiHook) toProvider() provider.Webhook {
+	return provider.Webhook{
+		ID:     strconv.FormatInt(h.ID, 10),
+		Name:   h.Type,
+		URL:    h.Config["url"],
+		Events: h.Events,
+		Active: h.Active,
+		Config: provider.WebhookConfig{
+			URL:         h.Config["url"],
+			ContentType: h.Config["content_type"],
+		},
+		CreatedAt: h.CreatedAt,
+		UpdatedAt: h.UpdatedAt,
+	}
+}
+
+func hookConfig(c provider.WebhookConfig) map[string]string {
+	contentType := c.ContentType
+	if contentType == "" {
+		contentType = "json"
+	}
+	config := map[string]string{"url": c.URL, "content_type": contentType}
+	if c.Secret != "" {
+		config["secret"] = c.Secret
+	}
+	return config
+}
+
+// ListWebhooks lists the webhooks of an owner/repo.
+func (m *HookManager) ListWebhooks(ctx context.Context, repoID string) ([]provider.Webhook, error) {
+	path, err := m.hooksPath(repoID)
+	if err != nil {
+		return nil, err
+	}
+
+	var hooks []apiHook
+	if err := m.do(ctx, http.MethodGet, path, nil, &hooks); err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	out := make([]provider.Webhook, 0, len(hooks))
+	for _, h := range hooks {
+		out = append(out, h.toProvider())
+	}
+	return out, nil
+}
+
+// GetWebhook returns a single webhook.
+func (m *HookManager) GetWebhook(ctx context.Context, repoID, webhookID string) (*provider.Webhook, error) {
+	path, err := m.hookPath(repoID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	var hook apiHook
+	err = m.do(ctx, http.MethodGet, path, nil, &hook)
+	if m.flavor == FlavorGogs && isStatus(err, http.StatusNotFound, http.StatusMethodNotAllowed) {
+		// Gogs has no single-hook endpoint; find it in the list.
+		hooks, listErr := m.ListWebhooks(ctx, repoID)
+		if listErr != nil {
+			return nil, listErr
+		}
+		for i := range hooks {
+			if hooks[i].ID == webhookID {
+				return &hooks[i], nil
+			}
+		}
+		return nil, fmt.Errorf("webhook %s not found", webhookID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	out := hook.toProvider()
+	return &out, nil
+}
+
+// CreateWebhook creates a webhook. Events default to DefaultHookEvents and
+// the content type to JSON.
+func (m *HookManager) CreateWebhook(ctx context.Context, repoID string, webhook provider.CreateWebhookRequest) (*provider.Webhook, error) {
+	owner, repo, err := m.helpers.ParseRepositoryURL(repoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.helpers.ValidateWebhookRequest(owner, repo, webhook.Config.URL); err != nil {
+		return nil, err
+	}
+
+	events := webhook.Events
+	if len(events) == 0 {
+		events = DefaultHookEvents
+	}
+	body := apiCreateHook{
+		Type:   string(m.flavor),
+		Config: hookConfig(webhook.Config),
+		Events: events,
+		Active: webhook.Active,
+	}
+
+	var hook apiHook
+	if err := m.do(ctx, http.MethodPost, repoPath(owner, repo)+"/hooks", body, &hook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	out := hook.toProvider()
+	return &out, nil
+}
+
+// UpdateWebhook changes a webhook's config, events or active state.
+func (m *HookManager) UpdateWebhook(ctx context.Context, repoID, webhookID string, updates provider.UpdateWebhookRequest) (*provider.Webhook, error) {
+	path, err := m.hookPath(repoID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	body := apiEditHook{Events: updates.Events, Active: updates.Active}
+	if updates.Config != nil {
+		if err := m.ValidateWebhookURL(ctx, updates.Config.URL); err != nil {
+			return nil, err
+		}
+		body.Config = hookConfig(*updates.Config)
+	}
+
+	var hook apiHook
+	if err := m.do(ctx, http.MethodPatch, path, body, &hook); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	out := hook.toProvider()
+	return &out, nil
+}
+
+// DeleteWebhook deletes a webhook.
+func (m *HookManager) DeleteWebhook(ctx context.Context, repoID, webhookID string) error {
+	path, err := m.hookPath(repoID, webhookID)
+	if err != nil {
+		return err
+	}
+	if err := m.do(ctx, http.MethodDelete, path, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	return nil
+}
+
+// TestWebhook asks the server to deliver a test push event. Gogs has no
+// test endpoint.
+func (m *HookManager) TestWebhook(ctx context.Context, repoID, webhookID string) (*provider.WebhookTestResult, error) {
+	if m.flavor == FlavorGogs {
+		return nil, fmt.Errorf("webhook testing is not supported by Gogs")
+	}
+	path, err := m.hookPath(repoID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	err = m.do(ctx, http.MethodPost, path+"/tests", nil, nil)
+	result := &provider.WebhookTestResult{
+		Success:      err == nil,
+		StatusCode:   http.StatusNoContent,
+		ResponseTime: time.Since(start),
+	}
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		result.StatusCode = apiErr.StatusCode
+		result.Error = apiErr.Error()
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to test webhook: %w", err)
+	}
+	return result, nil
+}
+
+// ValidateWebhookURL checks that url is an absolute HTTP(S) URL with a host.
+func (m *HookManager) ValidateWebhookURL(_ context.Context, rawURL string) error {
+	if err := m.helpers.ValidateWebhookRequest("-", "-", rawURL); err != nil {
+		return err
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("webhook URL must include a host")
+	}
+	return nil
+}
+
+func repoPath(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+func (m *HookManager) hooksPath(repoID string) (string, error) {
+	owner, repo, err := m.helpers.ParseRepositoryURL(repoID)
+	if err != nil {
+		return "", err
+	}
+	return repoPath(owner, repo) + "/hooks", nil
+}
+
+func (m *HookManager) hookPath(repoID, webhookID string) (string, error) {
+	path, err := m.hooksPath(repoID)
+	if err != nil {
+		return "", err
+	}
+	if _, err := strconv.ParseInt(webhookID, 10, 64); err != nil {
+		return "", fmt.Errorf("invalid webhook ID: %s", webhookID)
+	}
+	return path + "/" + webhookID, nil
+}
+
+// apiError is a non-2xx API response.
+type apiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+func isStatus(err error, codes ...int) bool {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, c := range codes {
+		if apiErr.StatusCode == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *HookManager) do(ctx context.Context, method, path string, in, out any) error {
+	return doAPI(ctx, m.client, m.baseURL, m.token, method, path, in, out)
+}
+
+// doAPI sends a JSON request to the API at baseURL and decodes the JSON
+// response into out when non-nil. Non-2xx responses become *apiError.
+func doAPI(ctx context.Context, client *http.Client, baseURL, token, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var msg struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &msg)
+		return &apiError{StatusCode: resp.StatusCode, Message: msg.Message}
+	}
+
+	if out == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+func TestHookManager_CRUD(t *testing.T) {
+	for _, flavor := range []Flavor{FlavorGitea, FlavorGogs} {
+		t.Run(string(flavor), func(t *testing.T) {
+			var created apiCreateHook
+			var edited apiEditHook
+			deleted := false
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v1/repos/org/app/hooks", func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "token secret-token", r.Header.Get("Authorization"))
+				if r.Method == http.MethodPost {
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+					w.WriteHeader(http.StatusCreated)
+					_, _ = io.WriteString(w, `{"id":7,"type":"`+string(flavor)+`","config":{"url":"https://ci.example.com/hook","content_type":"json"},"events":["push"],"active":true}`)
+					return
+				}
+				_, _ = io.WriteString(w, `[{"id":7,"type":"`+string(flavor)+`","config":{"url":"https://ci.example.com/hook"},"events":["push"],"active":true}]`)
+			})
+			mux.HandleFunc("/api/v1/repos/org/app/hooks/7", func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodPatch:
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&edited))
+					_, _ = io.WriteString(w, `{"id":7,"config":{"url":"https://ci.example.com/v2"},"events":["push","release"],"active":false}`)
+				case http.MethodDelete:
+					deleted = true
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					// Gogs has no single-hook GET endpoint.
+					if flavor == FlavorGogs {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					_, _ = io.WriteString(w, `{"id":7,"config":{"url":"https://ci.example.com/hook"},"active":true}`)
+				}
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			m := NewHookManager(flavor, server.URL+"/api/v1", "secret-token")
+			ctx := context.Background()
+
+			hook, err := m.CreateWebhook(ctx, "org/app", provider.CreateWebhookRequest{
+				Config: provider.WebhookConfig{URL: "https://ci.example.com/hook", Secret: "s3"},
+				Active: true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "7", hook.ID)
+			assert.Equal(t, string(flavor), created.Type)
+			assert.Equal(t, []string{"push"}, created.Events)
+			assert.Equal(t, "s3", created.Config["secret"])
+			assert.Equal(t, "json", created.Config["content_type"])
+
+			hooks, err := m.ListWebhooks(ctx, "org/app")
+			require.NoError(t, err)
+			require.Len(t, hooks, 1)
+			assert.Equal(t, "https://ci.example.com/hook", hooks[0].URL)
+
+			got, err := m.GetWebhook(ctx, "org/app", "7")
+			require.NoError(t, err)
+			assert.Equal(t, "7", got.ID)
+
+			active := false
+			updated, err := m.UpdateWebhook(ctx, "org/app", "7", provider.UpdateWebhookRequest{
+				Config: &provider.WebhookConfig{URL: "https://ci.example.com/v2"},
+				Events: []string{"push", "release"},
+				Active: &active,
+			})
+			require.NoError(t, err)
+			assert.False(t, updated.Active)
+			require.NotNil(t, edited.Active)
+			assert.False(t, *edited.Active)
+
+			require.NoError(t, m.DeleteWebhook(ctx, "org/app", "7"))
+			assert.True(t, deleted)
+
+			_, err = m.GetWebhook(ctx, "org/app", "not-a-number")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHookManager_GogsUnsupported(t *testing.T) {
+	m := NewGogsHookManager("https://gogs.example.com/api/v1", "")
+	_, err := m.TestWebhook(context.Background(), "org/app", "1")
+	assert.Error(t, err)
+	_, err = m.ListEvents(context.Background(), provider.EventListOptions{Repository: "org/app"})
+	assert.Error(t, err)
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	m := NewHookManager(FlavorGitea, "https://gitea.com/api/v1", "")
+	assert.NoError(t, m.ValidateWebhookURL(context.Background(), "https://ci.example.com/hook"))
+	assert.Error(t, m.ValidateWebhookURL(context.Background(), "ftp://ci.example.com"))
+	assert.Error(t, m.ValidateWebhookURL(context.Background(), "https://"))
+}
+
+func sign(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+const pushPayload = `{"ref":"refs/heads/main","pusher":{"id":1,"username":"alice"},
+	"repository":{"id":9,"name":"app","full_name":"org/app","private":true,"owner":{"id":2,"login":"org"}}}`
+
+func TestParseWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		flavor  Flavor
+		headers map[string]string
+		wantErr error
+	}{
+		{"gitea signature", FlavorGitea, map[string]string{"X-Gitea-Event": "push", "X-Gitea-Delivery": "d1", "X-Gitea-Signature": sign([]byte(pushPayload), "s3")}, nil},
+		{"gitea hub signature", FlavorGitea, map[string]string{"X-Gitea-Event": "push", "X-Gitea-Delivery": "d1", "X-Hub-Signature-256": "sha256=" + sign([]byte(pushPayload), "s3")}, nil},
+		{"gogs signature", FlavorGogs, map[string]string{"X-Gogs-Event": "push", "X-Gogs-Delivery": "d1", "X-Gogs-Signature": sign([]byte(pushPayload), "s3")}, nil},
+		{"gogs ignores gitea headers", FlavorGogs, map[string]string{"X-Gogs-Event": "push", "X-Gitea-Signature": sign([]byte(pushPayload), "s3")}, ErrInvalidSignature},
+		{"wrong secret", FlavorGitea, map[string]string{"X-Gitea-Event": "push", "X-Gitea-Signature": sign([]byte(pushPayload), "other")}, ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader([]byte(pushPayload)))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			event, err := NewHookManager(tt.flavor, "", "").ParseWebhook(req, "s3")
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "d1", event.ID)
+			assert.Equal(t, "push", event.Type)
+			assert.Equal(t, "alice", event.Actor.Login)
+			assert.Equal(t, "org/app", event.Repository.FullName)
+			assert.Equal(t, "org", event.Repository.Owner.Login)
+			assert.False(t, event.Public)
+			assert.Equal(t, string(tt.flavor), event.ProviderType)
+			assert.Equal(t, "refs/heads/main", event.Payload["ref"])
+		})
+	}
+}
+
+func TestHookManager_ProcessAndStreamEvents(t *testing.T) {
+	m := NewHookManager(FlavorGitea, "", "")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var handled []string
+	require.NoError(t, m.RegisterEventHandler("push", func(_ context.Context, e provider.Event) error {
+		handled = append(handled, "push:"+e.ID)
+		return nil
+	}))
+	require.NoError(t, m.RegisterEventHandler("*", func(_ context.Context, e provider.Event) error {
+		handled = append(handled, "any:"+e.ID)
+		return errors.New("handler failed")
+	}))
+	assert.Error(t, m.RegisterEventHandler("push", nil))
+
+	stream, err := m.StreamEvents(ctx, provider.StreamOptions{EventTypes: []string{"release"}})
+	require.NoError(t, err)
+
+	assert.Error(t, m.ProcessEvent(ctx, provider.Event{ID: "1", Type: "push"}))
+	assert.Error(t, m.ProcessEvent(ctx, provider.Event{ID: "2", Type: "release"}))
+	assert.Equal(t, []string{"push:1", "any:1", "any:2"}, handled)
+
+	select {
+	case e := <-stream:
+		assert.Equal(t, "2", e.ID)
+	case <-time.After(time.Second):
+		t.Fatal("no event streamed")
+	}
+
+	got, err := m.GetEvent(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "push", got.Type)
+	_, err = m.GetEvent(ctx, "missing")
+	assert.Error(t, err)
+
+	cancel()
+	for range stream {
+	}
+}
+
+func TestHookManager_ReplayEvents(t *testing.T) {
+	log, err := provider.OpenEventLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	require.NoError(t, err)
+	defer log.Close()
+
+	m := NewHookManager(FlavorGitea, "", "")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err = m.StreamEvents(ctx, provider.StreamOptions{FromSequence: 1})
+	assert.ErrorContains(t, err, "requires an event log")
+
+	m.SetEventLog(log)
+	require.NoError(t, m.ProcessEvent(ctx, provider.Event{ID: "1", Type: "push"}))
+	require.NoError(t, m.ProcessEvent(ctx, provider.Event{ID: "2", Type: "release"}))
+	require.NoError(t, m.ProcessEvent(ctx, provider.Event{ID: "2", Type: "release"})) // redelivery
+	assert.Equal(t, uint64(2), log.LastSeq())
+
+	stream, err := m.StreamEvents(ctx, provider.StreamOptions{FromSequence: 2})
+	require.NoError(t, err)
+	require.NoError(t, m.ProcessEvent(ctx, provider.Event{ID: "3", Type: "push"}))
+
+	var ids []string
+	for len(ids) < 2 {
+		select {
+		case e := <-stream:
+			ids = append(ids, e.ID)
+		case <-time.After(time.Second):
+			t.Fatalf("got %v, want replayed and live events", ids)
+		}
+	}
+	assert.Equal(t, []string{"2", "3"}, ids)
+
+	cancel()
+	for range stream {
+	}
+}
+
+func TestHookManager_ListEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/orgs/acme/activities/feeds", r.URL.Path)
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		_, _ = io.WriteString(w, `[
+			{"id":1,"op_type":"commit_repo","act_user":{"id":1,"login":"alice"},"repo":{"id":9,"full_name":"acme/app"},"created":"2025-01-02T00:00:00Z"},
+			{"id":2,"op_type":"create_issue","act_user":{"id":1,"login":"alice"},"repo":{"id":9,"full_name":"acme/app"},"created":"2025-01-03T00:00:00Z"}]`)
+	}))
+	defer server.Close()
+
+	m := NewHookManager(FlavorGitea, server.URL+"/api/v1", "")
+	events, err := m.ListEvents(context.Background(), provider.EventListOptions{Organization: "acme", EventType: "commit_repo", PerPage: 50})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "1", events[0].ID)
+	assert.Equal(t, "alice", events[0].Actor.Login)
+	assert.Equal(t, "acme/app", events[0].Repository.FullName)
+}
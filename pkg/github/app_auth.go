@@ -0,0 +1,294 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+)
+
+// ErrNoInstallation is returned when the GitHub App is not installed on an
+// organization or user account.
+var ErrNoInstallation = errors.New("github app is not installed")
+
+// AppAuthConfig configures GitHub App authentication.
+type AppAuthConfig struct {
+	// AppID is the numeric GitHub App ID.
+	AppID int64
+
+	// PrivateKey is the PEM encoded private key downloaded from the App settings.
+	PrivateKey []byte
+
+	// InstallationID pins every organization to one installation. When zero
+	// the installation is looked up per organization.
+	InstallationID int64
+
+	// BaseURL is the API endpoint. Defaults to https://api.github.com.
+	BaseURL string
+
+	// RefreshBefore renews installation tokens this long before they expire.
+	// Defaults to 5 minutes.
+	RefreshBefore time.Duration
+
+	// HTTPClient overrides the client used for API requests.
+	HTTPClient *http.Client
+
+	// Now overrides the clock (for tests).
+	Now func() time.Time
+}
+
+// installationToken is a cached installation access token.
+type installationToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// AppTokenSource mints and caches installation access tokens for a GitHub
+// App. It is safe for concurrent use.
+type AppTokenSource struct {
+	appID          int64
+	key            *rsa.PrivateKey
+	installationID int64
+	baseURL        string
+	refreshBefore  time.Duration
+	httpClient     *http.Client
+	now            func() time.Time
+
+	mu            sync.Mutex
+	installations map[string]int64 // org → installation ID
+	tokens        map[int64]installationToken
+}
+
+// NewAppTokenSource creates a token source from the App ID and private key.
+func NewAppTokenSource(cfg AppAuthConfig) (*AppTokenSource, error) {
+	if cfg.AppID <= 0 {
+		return nil, fmt.Errorf("github app: app ID is required")
+	}
+
+	key, err := parseAppPrivateKey(cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("github app %d: %w", cfg.AppID, err)
+	}
+
+	s := &AppTokenSource{
+		appID:          cfg.AppID,
+		key:            key,
+		installationID: cfg.InstallationID,
+		baseURL:        strings.TrimSuffix(cfg.BaseURL, "/"),
+		refreshBefore:  cfg.RefreshBefore,
+		httpClient:     cfg.HTTPClient,
+		now:            cfg.Now,
+		installations:  make(map[string]int64),
+		tokens:         make(map[int64]installationToken),
+	}
+	if s.baseURL == "" {
+		s.baseURL = "https://api.github.com"
+	}
+	if s.refreshBefore <= 0 {
+		s.refreshBefore = 5 * time.Minute
+	}
+	if s.httpClient == nil {
+		s.httpClient = httpclient.NewProviderClient("github", 30*time.Second)
+		s.httpClient.Transport = metrics.InstrumentTransport("github", s.httpClient.Transport)
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+
+	return s, nil
+}
+
+// parseAppPrivateKey accepts PKCS#1 keys as issued by GitHub and PKCS#8 keys.
+func parseAppPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key must be RSA")
+	}
+	return key, nil
+}
+
+// JWT returns a signed App JWT valid for the next nine minutes. GitHub
+// rejects JWTs that live longer than ten minutes; iat is backdated to
+// tolerate clock drift.
+func (s *AppTokenSource) JWT() (string, error) {
+	now := s.now()
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-60 * time.Second).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(s.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign github app JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Token returns an installation access token for org, minting a new one
+// when the cached token is missing or about to expire.
+func (s *AppTokenSource) Token(ctx context.Context, org string) (string, error) {
+	// 발급을 직렬화해 동시 요청이 같은 토큰을 중복 발급하지 않도록 한다
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	installationID, err := s.installationLocked(ctx, org)
+	if err != nil {
+		return "", err
+	}
+
+	if cached, ok := s.tokens[installationID]; ok && s.now().Add(s.refreshBefore).Before(cached.expiresAt) {
+		return cached.token, nil
+	}
+
+	minted, err := s.mintToken(ctx, installationID)
+	if err != nil {
+		return "", err
+	}
+	s.tokens[installationID] = minted
+
+	return minted.token, nil
+}
+
+// InstallationID returns the installation used for org.
+func (s *AppTokenSource) InstallationID(ctx context.Context, org string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.installationLocked(ctx, org)
+}
+
+// Invalidate drops the cached token for org so the next call mints a new one,
+// for example after the API answered 401.
+func (s *AppTokenSource) Invalidate(org string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.installations[org]; ok {
+		delete(s.tokens, id)
+	}
+	if s.installationID != 0 {
+		delete(s.tokens, s.installationID)
+	}
+}
+
+func (s *AppTokenSource) installationLocked(ctx context.Context, org string) (int64, error) {
+	if s.installationID != 0 {
+		return s.installationID, nil
+	}
+	if id, ok := s.installations[org]; ok {
+		return id, nil
+	}
+	if org == "" {
+		return 0, fmt.Errorf("github app %d: organization is required without an installation ID", s.appID)
+	}
+
+	// 조직 설치를 먼저 찾고, 없으면 사용자 계정 설치를 찾는다
+	var lastErr error
+	for _, kind := range []string{"orgs", "users"} {
+		var installation struct {
+			ID int64 `json:"id"`
+		}
+		status, err := s.appRequest(ctx, http.MethodGet, fmt.Sprintf("/%s/%s/installation", kind, org), &installation)
+		if status == http.StatusNotFound {
+			lastErr = fmt.Errorf("%w on %s", ErrNoInstallation, org)
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		s.installations[org] = installation.ID
+		return installation.ID, nil
+	}
+
+	return 0, lastErr
+}
+
+func (s *AppTokenSource) mintToken(ctx context.Context, installationID int64) (installationToken, error) {
+	var response struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"` //nolint:tagliatelle // GitHub API field
+	}
+	path := fmt.Sprintf("/app/installations/%d/access_tokens", installationID)
+	if _, err := s.appRequest(ctx, http.MethodPost, path, &response); err != nil {
+		return installationToken{}, err
+	}
+	if response.Token == "" {
+		return installationToken{}, fmt.Errorf("github app: empty token for installation %d", installationID)
+	}
+
+	return installationToken{token: response.Token, expiresAt: response.ExpiresAt}, nil
+}
+
+// appRequest performs an API request authenticated as the App itself.
+func (s *AppTokenSource) appRequest(ctx context.Context, method, path string, out any) (int, error) {
+	jwt, err := s.JWT()
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "gzh-cli")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("github app request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return resp.StatusCode, fmt.Errorf("github app %s %s: %s (%d)", method, path, apiErr.Message, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode github app response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
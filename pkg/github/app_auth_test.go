@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAppKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// fakeAppAPI serves the installation and access token endpoints.
+type fakeAppAPI struct {
+	key    *rsa.PublicKey
+	minted atomic.Int32
+	now    func() time.Time
+}
+
+func (f *fakeAppAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	parts := strings.Split(jwt, ".")
+	sig, _ := base64.RawURLEncoding.DecodeString(parts[len(parts)-1])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if len(parts) != 3 || rsa.VerifyPKCS1v15(f.key, crypto.SHA256, digest[:], sig) != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/orgs/acme/installation":
+		_, _ = w.Write([]byte(`{"id": 11}`))
+	case r.URL.Path == "/users/someone/installation":
+		_, _ = w.Write([]byte(`{"id": 22}`))
+	case strings.HasPrefix(r.URL.Path, "/app/installations/") && r.Method == http.MethodPost:
+		n := f.minted.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"token":      fmt.Sprintf("ghs_%s_%d", strings.Split(r.URL.Path, "/")[3], n),
+			"expires_at": f.now().Add(time.Hour),
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	}
+}
+
+func TestAppTokenSource_JWT(t *testing.T) {
+	key, pemKey := newTestAppKey(t)
+	now := time.Unix(1_700_000_000, 0)
+
+	source, err := NewAppTokenSource(AppAuthConfig{AppID: 42, PrivateKey: pemKey, Now: func() time.Time { return now }})
+	require.NoError(t, err)
+
+	jwt, err := source.JWT()
+	require.NoError(t, err)
+	parts := strings.Split(jwt, ".")
+	require.Len(t, parts, 3)
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims struct {
+		Iat int64  `json:"iat"`
+		Exp int64  `json:"exp"`
+		Iss string `json:"iss"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, "42", claims.Iss)
+	assert.Equal(t, now.Unix()-60, claims.Iat)
+	assert.LessOrEqual(t, claims.Exp-now.Unix(), int64(600))
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig))
+}
+
+func TestAppTokenSource_TokenCachingAndRefresh(t *testing.T) {
+	key, pemKey := newTestAppKey(t)
+	now := time.Now()
+	clock := func() time.Time { return now }
+
+	api := &fakeAppAPI{key: &key.PublicKey, now: clock}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	source, err := NewAppTokenSource(AppAuthConfig{AppID: 1, PrivateKey: pemKey, BaseURL: server.URL, Now: clock})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	token, err := source.Token(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_11_1", token)
+
+	token, err = source.Token(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_11_1", token, "cached token is reused")
+
+	now = now.Add(56 * time.Minute)
+	token, err = source.Token(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_11_2", token, "token is refreshed before expiry")
+
+	source.Invalidate("acme")
+	token, err = source.Token(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_11_3", token)
+}
+
+func TestAppTokenSource_InstallationSelection(t *testing.T) {
+	key, pemKey := newTestAppKey(t)
+	api := &fakeAppAPI{key: &key.PublicKey, now: time.Now}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	source, err := NewAppTokenSource(AppAuthConfig{AppID: 1, PrivateKey: pemKey, BaseURL: server.URL})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	id, err := source.InstallationID(ctx, "someone")
+	require.NoError(t, err)
+	assert.Equal(t, int64(22), id, "falls back to the user installation")
+
+	_, err = source.Token(ctx, "unknown")
+	require.ErrorIs(t, err, ErrNoInstallation)
+
+	pinned, err := NewAppTokenSource(AppAuthConfig{AppID: 1, PrivateKey: pemKey, BaseURL: server.URL, InstallationID: 99})
+	require.NoError(t, err)
+	token, err := pinned.Token(ctx, "unknown")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "ghs_99_"))
+}
+
+func TestNewAppTokenSource_InvalidKey(t *testing.T) {
+	_, err := NewAppTokenSource(AppAuthConfig{AppID: 1, PrivateKey: []byte("not a key")})
+	require.Error(t, err)
+
+	_, err = NewAppTokenSource(AppAuthConfig{PrivateKey: []byte("x")})
+	require.Error(t, err)
+}
@@ -94,6 +94,7 @@ func (b *BulkOperationsManager) RefreshAllWithWorkerPool(ctx context.Context,
 
 		jobs = append(jobs, workerpool.RepositoryJob{
 			Repository: repo,
+			Provider:   "github",
 			Operation:  operation,
 			Path:       repoPath,
 			Strategy:   strategy,
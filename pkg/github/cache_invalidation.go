@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gizzahub/gzh-cli/pkg/async"
+	pkgcache "github.com/gizzahub/gzh-cli/pkg/cache"
+)
+
+// CacheInvalidationHandler publishes a cache.Invalidation on its bus when
+// GitHub reports that repositories were created, renamed, transferred or
+// deleted, so that the repository caches subscribed with
+// CachedGitHubClient.SubscribeInvalidations do not serve stale lists.
+type CacheInvalidationHandler struct {
+	bus    *async.Bus
+	logger Logger
+}
+
+// NewCacheInvalidationHandler creates a handler publishing on bus.
+func NewCacheInvalidationHandler(bus *async.Bus, logger Logger) *CacheInvalidationHandler {
+	return &CacheInvalidationHandler{bus: bus, logger: logger}
+}
+
+// RegisterCacheInvalidation registers the handler for every event type that
+// changes an organization's repository list.
+func RegisterCacheInvalidation(processor EventProcessor, bus *async.Bus, logger Logger) error {
+	handler := NewCacheInvalidationHandler(bus, logger)
+
+	for _, eventType := range []EventType{EventTypeRepository, EventTypeInstallationRepos} {
+		if err := processor.RegisterEventHandler(eventType, handler); err != nil {
+			return fmt.Errorf("failed to register cache invalidation for %s events: %w", eventType, err)
+		}
+	}
+
+	return nil
+}
+
+// HandleEvent invalidates the organization's repository list and, for
+// events about a single repository, its per-repository entries under both
+// old and new names.
+func (h *CacheInvalidationHandler) HandleEvent(ctx context.Context, event *GitHubEvent) error {
+	if event.Organization == "" {
+		return nil
+	}
+
+	keys := []string{reposCacheKey(event.Organization)}
+	if event.Repository != "" {
+		keys = append(keys, defaultBranchCacheKey(event.Organization, event.Repository))
+	}
+	if oldName := previousRepositoryName(event.Payload); oldName != "" {
+		keys = append(keys, defaultBranchCacheKey(event.Organization, oldName))
+	}
+
+	if err := h.bus.Publish(ctx, pkgcache.Invalidation{Cache: RepositoryCacheName, Keys: keys}); err != nil {
+		return fmt.Errorf("failed to invalidate repository cache: %w", err)
+	}
+
+	if h.logger != nil {
+		h.logger.Debug("Invalidated repository cache",
+			"organization", event.Organization,
+			"repository", event.Repository,
+			"action", event.Action,
+			"keys", len(keys))
+	}
+
+	return nil
+}
+
+// GetSupportedActions returns nil: every repository action may change cached data.
+func (h *CacheInvalidationHandler) GetSupportedActions() []EventAction {
+	return nil
+}
+
+// GetPriority runs invalidation before other handlers so they observe fresh data.
+func (h *CacheInvalidationHandler) GetPriority() int {
+	return 100
+}
+
+// previousRepositoryName extracts changes.repository.name.from from a
+// "repository renamed" payload.
+func previousRepositoryName(payload map[string]any) string {
+	changes, _ := payload["changes"].(map[string]any)
+	repo, _ := changes["repository"].(map[string]any)
+	name, _ := repo["name"].(map[string]any)
+	from, _ := name["from"].(string)
+	return from
+}
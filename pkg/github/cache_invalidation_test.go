@@ -0,0 +1,106 @@
+//nolint:testpackage // White-box testing needed for internal function access
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/async"
+	pkgcache "github.com/gizzahub/gzh-cli/pkg/cache"
+)
+
+// newTestCachedClient returns a client whose cache lives in a temporary
+// directory instead of ~/.gzh/cache.
+func newTestCachedClient(t *testing.T) *CachedGitHubClient {
+	t.Helper()
+	disk, err := pkgcache.OpenDiskBackend(t.TempDir(), 0)
+	require.NoError(t, err)
+	client := newCachedGitHubClient("", pkgcache.NewTiered(RepositoryCacheName, time.Hour,
+		pkgcache.Tier{Name: "memory", Backend: pkgcache.NewMemoryBackend(0)},
+		pkgcache.Tier{Name: "disk", Backend: disk}))
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func cached(t *testing.T, client *CachedGitHubClient, key string) bool {
+	t.Helper()
+	_, found := client.cache.Get(context.Background(), key)
+	return found
+}
+
+func TestCacheInvalidationHandler_RenamedRepository(t *testing.T) {
+	ctx := context.Background()
+	client := newTestCachedClient(t)
+	require.NoError(t, client.cache.SetJSON(ctx, "repos:acme", []string{"old-name", "other"}))
+	require.NoError(t, client.cache.Set(ctx, "default_branch:acme/old-name", []byte("main")))
+	require.NoError(t, client.cache.Set(ctx, "default_branch:acme/other", []byte("main")))
+	require.NoError(t, client.cache.SetJSON(ctx, "repos:unrelated", []string{"x"}))
+
+	bus := async.NewBus()
+	client.SubscribeInvalidations(bus)
+	handler := NewCacheInvalidationHandler(bus, nil)
+	err := handler.HandleEvent(context.Background(), &GitHubEvent{
+		Type:         string(EventTypeRepository),
+		Action:       "renamed",
+		Organization: "acme",
+		Repository:   "new-name",
+		Payload: map[string]any{
+			"changes": map[string]any{
+				"repository": map[string]any{"name": map[string]any{"from": "old-name"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, cached(t, client, "repos:acme"))
+	assert.False(t, cached(t, client, "default_branch:acme/old-name"))
+	assert.True(t, cached(t, client, "default_branch:acme/other"))
+	assert.True(t, cached(t, client, "repos:unrelated"))
+}
+
+func TestCachedGitHubClient_SurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	open := func() *CachedGitHubClient {
+		disk, err := pkgcache.OpenDiskBackend(dir, 0)
+		require.NoError(t, err)
+		return newCachedGitHubClient("", pkgcache.NewTiered("github-test", time.Hour,
+			pkgcache.Tier{Name: "memory", Backend: pkgcache.NewMemoryBackend(0)},
+			pkgcache.Tier{Name: "disk", Backend: disk}))
+	}
+
+	first := open()
+	require.NoError(t, first.cache.SetJSON(ctx, reposCacheKey("acme"), []string{"api", "web"}))
+	require.NoError(t, first.Close())
+
+	second := open()
+	defer second.Close()
+	repos, err := second.ListRepositoriesWithCache(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "web"}, repos)
+	assert.Equal(t, 1, second.InvalidateOrgCache(ctx, "acme"))
+	assert.Equal(t, 0, second.InvalidateOrgCache(ctx, "acme"))
+}
+
+func TestRegisterCacheInvalidation(t *testing.T) {
+	storage := &mockEventStorage{}
+	storage.On("StoreEvent", mock.Anything, mock.Anything).Return(nil)
+
+	processor := NewEventProcessor(storage, &simpleLogger{})
+	client := newTestCachedClient(t)
+	require.NoError(t, client.cache.SetJSON(context.Background(), "repos:acme", []string{"a"}))
+
+	bus := async.NewBus()
+	client.SubscribeInvalidations(bus)
+	require.NoError(t, RegisterCacheInvalidation(processor, bus, nil))
+	require.NoError(t, processor.ProcessEvent(context.Background(), &GitHubEvent{
+		ID: "1", Type: string(EventTypeRepository), Action: "created", Organization: "acme", Repository: "b",
+	}))
+
+	assert.False(t, cached(t, client, "repos:acme"))
+}
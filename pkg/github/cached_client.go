@@ -3,8 +3,10 @@ package github
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/async"
+	pkgcache "github.com/gizzahub/gzh-cli/pkg/cache"
 )
 
 // SyncCloneStats represents statistics from sync clone operations.
@@ -18,121 +20,149 @@ type SyncCloneStats struct {
 	Failed            int
 }
 
-// CachedGitHubClient wraps GitHub API calls with caching - DISABLED (cache package removed)
-// Simple in-memory cache implementation to replace deleted cache package.
+// CachedGitHubClient wraps GitHub API calls with a memory → disk cache
+// stored in ~/.gzh/cache/github, so repository lists survive restarts and
+// are shared between gz processes until they expire or a webhook evicts
+// them.
 type CachedGitHubClient struct {
-	cache sync.Map // Simple in-memory cache replacement
+	cache *pkgcache.Tiered
 	token string
 }
 
-// NewCachedGitHubClient creates a new cached GitHub client - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// NewCachedGitHubClient creates a cached GitHub client. When the disk cache
+// cannot be opened, entries are only kept in memory.
 func NewCachedGitHubClient(token string) *CachedGitHubClient {
-	return &CachedGitHubClient{
-		cache: sync.Map{},
-		token: token,
+	return newCachedGitHubClient(token, OpenRepositoryCache())
+}
+
+func newCachedGitHubClient(token string, cache *pkgcache.Tiered) *CachedGitHubClient {
+	return &CachedGitHubClient{cache: cache, token: token}
+}
+
+// RepositoryCacheName names the cache of CachedGitHubClient, on disk and in
+// cache.Invalidation events.
+const RepositoryCacheName = "github"
+
+// OpenRepositoryCache opens the cache shared by CachedGitHubClient
+// instances, falling back to memory only when the disk cache is
+// unavailable.
+func OpenRepositoryCache() *pkgcache.Tiered {
+	ttl := DefaultCacheConfiguration().DefaultTTL
+	cache, err := pkgcache.OpenDefault(RepositoryCacheName, ttl)
+	if err != nil {
+		return pkgcache.NewTiered(RepositoryCacheName, ttl,
+			pkgcache.Tier{Name: "memory", Backend: pkgcache.NewMemoryBackend(0)})
 	}
+	return cache
 }
 
-// ListRepositoriesWithCache lists repositories with caching support - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+func reposCacheKey(org string) string {
+	return fmt.Sprintf("repos:%s", org)
+}
+
+func defaultBranchCacheKey(org, repo string) string {
+	return fmt.Sprintf("default_branch:%s/%s", org, repo)
+}
+
+// ListRepositoriesWithCache lists the organization's repositories, serving
+// them from the cache while it is fresh.
 func (c *CachedGitHubClient) ListRepositoriesWithCache(ctx context.Context, org string) ([]string, error) {
-	// Try to get from simple cache first
-	cacheKey := fmt.Sprintf("repos:%s", org)
-	if cached, found := c.cache.Load(cacheKey); found {
-		if repos, ok := cached.([]string); ok {
-			return repos, nil
-		}
+	cacheKey := reposCacheKey(org)
+
+	var repos []string
+	if c.cache.GetJSON(ctx, cacheKey, &repos) {
+		return repos, nil
 	}
 
-	// Cache miss - fetch from API
 	repos, err := List(ctx, org)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch repositories: %w", err)
 	}
 
-	// Store in simple cache (no TTL implementation)
-	c.cache.Store(cacheKey, repos)
+	// 캐시 저장 실패는 다음 호출에서 API를 다시 부를 뿐이다
+	_ = c.cache.SetJSON(ctx, cacheKey, repos)
 
 	return repos, nil
 }
 
-// GetDefaultBranchWithCache gets repository default branch with caching - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// GetDefaultBranchWithCache gets the repository's default branch, serving
+// it from the cache while it is fresh.
 func (c *CachedGitHubClient) GetDefaultBranchWithCache(ctx context.Context, org, repo string) (string, error) {
-	// Try to get from simple cache first
-	cacheKey := fmt.Sprintf("default_branch:%s/%s", org, repo)
-	if cached, found := c.cache.Load(cacheKey); found {
-		if branch, ok := cached.(string); ok {
-			return branch, nil
-		}
+	cacheKey := defaultBranchCacheKey(org, repo)
+
+	if branch, ok := c.cache.Get(ctx, cacheKey); ok {
+		return string(branch), nil
 	}
 
-	// Cache miss - fetch from API
 	branch, err := GetDefaultBranch(ctx, org, repo)
 	if err != nil {
 		return "", fmt.Errorf("failed to get default branch: %w", err)
 	}
 
-	// Store in simple cache (no TTL implementation)
-	c.cache.Store(cacheKey, branch)
+	_ = c.cache.Set(ctx, cacheKey, []byte(branch))
 
 	return branch, nil
 }
 
-// InvalidateOrgCache invalidates all cache entries for an organization - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// InvalidateOrgCache evicts the organization's repository list and returns
+// the number of evicted entries.
 func (c *CachedGitHubClient) InvalidateOrgCache(ctx context.Context, org string) int {
-	count := 0
-
-	cacheKey := fmt.Sprintf("repos:%s", org)
-	if _, found := c.cache.LoadAndDelete(cacheKey); found {
-		count++
-	}
-
-	return count
+	return c.evict(ctx, reposCacheKey(org))
 }
 
-// InvalidateRepoCache invalidates cache entries for a specific repository - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// InvalidateRepoCache evicts the entries of a single repository and
+// returns the number of evicted entries.
 func (c *CachedGitHubClient) InvalidateRepoCache(ctx context.Context, org, repo string) int {
-	count := 0
+	return c.evict(ctx, defaultBranchCacheKey(org, repo))
+}
 
-	cacheKey := fmt.Sprintf("default_branch:%s/%s", org, repo)
-	if _, found := c.cache.LoadAndDelete(cacheKey); found {
-		count++
-	}
+// SubscribeInvalidations evicts the entries named by the cache.Invalidation
+// events published on bus until the returned function is called.
+func (c *CachedGitHubClient) SubscribeInvalidations(bus *async.Bus) (unsubscribe func()) {
+	return c.cache.SubscribeInvalidations(bus)
+}
 
-	return count
+func (c *CachedGitHubClient) evict(ctx context.Context, key string) int {
+	if found, _ := c.cache.Evict(ctx, key); found {
+		return 1
+	}
+	return 0
 }
 
-// GetCacheStats returns GitHub cache statistics - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// GetCacheStats returns the hit and miss counts of the cache.
 func (c *CachedGitHubClient) GetCacheStats() map[string]any {
+	stats := c.cache.Stats()
 	return map[string]any{
-		"type": "simple_sync_map",
-		"note": "cache package removed, using simple sync.Map",
+		"type":      "tiered",
+		"hits":      stats.Hits,
+		"misses":    stats.Misses,
+		"hit_ratio": stats.HitRatio(),
+		"tiers":     stats.Tiers,
 	}
 }
 
+// Close releases the cache. Disk entries are kept for later processes.
+func (c *CachedGitHubClient) Close() error {
+	return c.cache.Close()
+}
+
 // CachedSyncCloneManager extends OptimizedSyncCloneManager with caching.
 type CachedSyncCloneManager struct {
 	*OptimizedSyncCloneManager
 	cachedClient *CachedGitHubClient
 }
 
-// NewCachedSyncCloneManager creates a new cached sync clone manager - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// NewCachedSyncCloneManager creates a sync clone manager that lists
+// repositories through the persistent repository cache.
 func NewCachedSyncCloneManager(token string, config OptimizedCloneConfig) (*CachedSyncCloneManager, error) {
-	// Create cached client with simple cache
-	cachedClient := NewCachedGitHubClient(token)
-
 	// Create optimized manager
 	optimizedManager, err := NewOptimizedSyncCloneManager(token, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create optimized manager: %w", err)
 	}
 
+	cachedClient := NewCachedGitHubClient(token)
+
 	return &CachedSyncCloneManager{
 		OptimizedSyncCloneManager: optimizedManager,
 		cachedClient:              cachedClient,
@@ -186,16 +216,17 @@ func (cbm *CachedSyncCloneManager) processRepositoriesOptimized(ctx context.Cont
 	return syncStats, nil
 }
 
-// Close cleans up cached manager resources - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// Close releases the repository cache and the optimized manager.
 func (cbm *CachedSyncCloneManager) Close() error {
-	// No cache manager to close - using simple sync.Map
-	// Close optimized manager
-	return cbm.OptimizedSyncCloneManager.Close()
+	cacheErr := cbm.cachedClient.Close()
+	if err := cbm.OptimizedSyncCloneManager.Close(); err != nil {
+		return err
+	}
+	return cacheErr
 }
 
-// RefreshAllOptimizedStreamingWithCache is the cached version of the streaming API - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// RefreshAllOptimizedStreamingWithCache is the cached version of the
+// streaming API.
 func RefreshAllOptimizedStreamingWithCache(ctx context.Context, targetPath, org, strategy, token string) error {
 	// Create cached manager
 	config := DefaultOptimizedCloneConfig()
@@ -223,42 +254,33 @@ func RefreshAllOptimizedStreamingWithCache(ctx context.Context, targetPath, org,
 		stats.Successful, stats.Failed,
 		float64(stats.Successful)/float64(stats.TotalRepositories)*100)
 
-	fmt.Printf("📊 Cache performance: %s\n", cacheStats["note"])
+	fmt.Printf("📊 Cache performance: %d hits, %d misses\n", cacheStats["hits"], cacheStats["misses"])
 
 	return nil
 }
 
-// CacheConfiguration provides cache configuration for GitHub operations - DISABLED (cache package removed)
-// Simple configuration struct without external cache dependency.
+// CacheConfiguration provides cache configuration for GitHub operations.
 type CacheConfiguration struct {
 	EnableLocalCache bool
-	// EnableRedisCache bool // Disabled - cache package removed
-	LocalCacheSize int
-	DefaultTTL     time.Duration
-	// RedisAddress     string // Disabled - cache package removed
-	// RedisPassword    string // Disabled - cache package removed
+	LocalCacheSize   int
+	DefaultTTL       time.Duration
 }
 
-// DefaultCacheConfiguration returns sensible defaults for GitHub caching - DISABLED (cache package removed)
-// Simple configuration without external cache dependency.
+// DefaultCacheConfiguration returns sensible defaults for GitHub caching.
 func DefaultCacheConfiguration() CacheConfiguration {
 	return CacheConfiguration{
 		EnableLocalCache: true,
-		// EnableRedisCache: false, // Disabled - cache package removed
-		LocalCacheSize: 1000,
-		DefaultTTL:     10 * time.Minute,
-		// RedisAddress:     "localhost:6379", // Disabled - cache package removed
-		// RedisPassword:    "", // Disabled - cache package removed
+		LocalCacheSize:   1000,
+		DefaultTTL:       10 * time.Minute,
 	}
 }
 
-// ToCacheManagerConfig converts to cache manager configuration - DISABLED (cache package removed)
-// Simple configuration conversion without external cache dependency.
+// ToCacheManagerConfig converts to cache manager configuration.
 func (cc CacheConfiguration) ToCacheManagerConfig() map[string]any {
 	return map[string]any{
 		"enable_local_cache": cc.EnableLocalCache,
 		"local_cache_size":   cc.LocalCacheSize,
 		"default_ttl":        cc.DefaultTTL,
-		"note":               "cache package removed, using simple sync.Map",
+		"backend":            "tiered",
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/gizzahub/gzh-cli/internal/env"
+	"github.com/gizzahub/gzh-cli/pkg/audit"
 )
 
 // ChangeLogger provides comprehensive logging for repository configuration changes.
@@ -35,6 +36,8 @@ type LoggerOptions struct {
 	EnableConsoleOutput bool
 	// EnableStructuredOutput enables structured JSON output
 	EnableStructuredOutput bool
+	// AuditShipper forwards entries to SIEM sinks when set
+	AuditShipper *audit.Shipper
 }
 
 // LogFormat represents the output format for logs.
@@ -262,9 +265,55 @@ func (cl *ChangeLogger) writeLogEntry(entry *logEntry) error {
 		}
 	}
 
+	// Spool for SIEM delivery if shipping is configured
+	if cl.options.AuditShipper != nil {
+		if err := cl.options.AuditShipper.Record(entry.auditEvent()); err != nil {
+			return fmt.Errorf("failed to record audit event: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// auditEvent converts a log entry into an audit event for SIEM shipping.
+func (e *logEntry) auditEvent() audit.Event {
+	event := audit.Event{
+		Timestamp:  e.Timestamp,
+		Severity:   audit.SeverityInfo,
+		Action:     e.Operation,
+		Category:   e.Category,
+		Actor:      e.User,
+		Source:     e.Source,
+		Resource:   e.Repository,
+		Outcome:    "success",
+		Message:    e.Message,
+		RequestID:  e.RequestID,
+		Metadata:   e.Metadata,
+		ErrMessage: e.Error,
+	}
+
+	switch e.Level {
+	case LogLevelTrace, LogLevelDebug:
+		event.Severity = audit.SeverityDebug
+	case LogLevelWarn:
+		event.Severity = audit.SeverityWarn
+	case LogLevelError:
+		event.Severity = audit.SeverityError
+	}
+	if e.Error != "" {
+		event.Outcome = "failure"
+	}
+	if e.ChangeID != "" {
+		event.Metadata = make(map[string]any, len(e.Metadata)+1)
+		for k, v := range e.Metadata {
+			event.Metadata[k] = v
+		}
+		event.Metadata["changeId"] = e.ChangeID
+	}
+
+	return event
+}
+
 // shouldLog determines if a log entry should be written based on level.
 func (cl *ChangeLogger) shouldLog(level LogLevel) bool {
 	levels := map[LogLevel]int{
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// ErrEventLogAppendOnly is returned when deleting from an event log.
+var ErrEventLogAppendOnly = errors.New("event log is append-only")
+
+// errEventFound stops a replay once the requested event was found.
+var errEventFound = errors.New("event found")
+
+// eventLogStorage persists GitHub events in a provider.EventLog, so that
+// webhook events are kept across restarts, can be replayed and are
+// consumed by plugins with tracked offsets.
+type eventLogStorage struct {
+	log *provider.EventLog
+}
+
+// NewEventLogStorage returns an EventStorage appending to log. Request
+// headers and signatures are not persisted.
+func NewEventLogStorage(log *provider.EventLog) EventStorage {
+	return &eventLogStorage{log: log}
+}
+
+// ToProviderEvent converts a GitHub webhook event to the provider-neutral
+// event stored in event logs.
+func ToProviderEvent(event *GitHubEvent) provider.Event {
+	fullName := event.Repository
+	if event.Organization != "" && event.Repository != "" {
+		fullName = event.Organization + "/" + event.Repository
+	}
+	return provider.Event{
+		ID:    event.ID,
+		Type:  event.Type,
+		Actor: provider.Actor{Login: event.Sender},
+		Repository: provider.Repository{
+			Name:         event.Repository,
+			FullName:     fullName,
+			Owner:        provider.Owner{Login: event.Organization},
+			ProviderType: "github",
+		},
+		Payload:      event.Payload,
+		CreatedAt:    event.Timestamp,
+		ProviderType: "github",
+		ProviderData: map[string]any{"action": event.Action, "organization": event.Organization},
+	}
+}
+
+func fromProviderEvent(event provider.Event) *GitHubEvent {
+	action, _ := event.ProviderData["action"].(string)
+	org, _ := event.ProviderData["organization"].(string)
+	return &GitHubEvent{
+		ID:           event.ID,
+		Type:         event.Type,
+		Action:       action,
+		Organization: org,
+		Repository:   event.Repository.Name,
+		Sender:       event.Actor.Login,
+		Timestamp:    event.CreatedAt,
+		Payload:      event.Payload,
+	}
+}
+
+func (s *eventLogStorage) StoreEvent(_ context.Context, event *GitHubEvent) error {
+	_, err := s.log.Append(ToProviderEvent(event))
+	return err
+}
+
+func (s *eventLogStorage) GetEvent(ctx context.Context, eventID string) (*GitHubEvent, error) {
+	var found *GitHubEvent
+	err := s.each(ctx, func(event *GitHubEvent) error {
+		if event.ID != eventID {
+			return nil
+		}
+		found = event
+		return errEventFound
+	})
+	if err != nil && !errors.Is(err, errEventFound) {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("event %s not found", eventID)
+	}
+	return found, nil
+}
+
+// ListEvents returns matching events, oldest first. The branch and file
+// patterns of filter are not applied.
+func (s *eventLogStorage) ListEvents(ctx context.Context, filter *EventFilter, limit, offset int) ([]*GitHubEvent, error) {
+	var events []*GitHubEvent
+	skipped := 0
+	err := s.each(ctx, func(event *GitHubEvent) error {
+		if !matchesStoredEvent(event, filter) {
+			return nil
+		}
+		if skipped < offset {
+			skipped++
+			return nil
+		}
+		events = append(events, event)
+		if limit > 0 && len(events) == limit {
+			return errEventFound
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errEventFound) {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (s *eventLogStorage) DeleteEvent(_ context.Context, _ string) error {
+	return ErrEventLogAppendOnly
+}
+
+func (s *eventLogStorage) CountEvents(ctx context.Context, filter *EventFilter) (int, error) {
+	count := 0
+	err := s.each(ctx, func(event *GitHubEvent) error {
+		if matchesStoredEvent(event, filter) {
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// each replays the GitHub events of the log.
+func (s *eventLogStorage) each(ctx context.Context, fn func(*GitHubEvent) error) error {
+	return s.log.Replay(ctx, provider.ReplayOptions{}, func(rec provider.EventRecord) error {
+		if rec.Event.ProviderType != "github" {
+			return nil
+		}
+		return fn(fromProviderEvent(rec.Event))
+	})
+}
+
+func matchesStoredEvent(event *GitHubEvent, filter *EventFilter) bool {
+	if filter == nil {
+		return true
+	}
+	switch {
+	case filter.Organization != "" && event.Organization != filter.Organization,
+		filter.Repository != "" && event.Repository != filter.Repository,
+		filter.Sender != "" && event.Sender != filter.Sender,
+		len(filter.EventTypes) > 0 && !slices.Contains(filter.EventTypes, EventType(event.Type)),
+		len(filter.Actions) > 0 && !slices.Contains(filter.Actions, EventAction(event.Action)):
+		return false
+	}
+	if tr := filter.TimeRange; tr != nil {
+		if (!tr.Start.IsZero() && event.Timestamp.Before(tr.Start)) || (!tr.End.IsZero() && event.Timestamp.After(tr.End)) {
+			return false
+		}
+	}
+	return true
+}
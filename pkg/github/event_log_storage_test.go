@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+func TestEventLogStorage(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.log")
+	log, err := provider.OpenEventLog(path)
+	require.NoError(t, err)
+
+	storage := NewEventLogStorage(log)
+	at := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+	for i, event := range []*GitHubEvent{
+		{ID: "1", Type: string(EventTypeRepository), Action: "created", Organization: "acme", Repository: "api", Sender: "jdoe", Timestamp: at},
+		{ID: "2", Type: string(EventTypePush), Organization: "acme", Repository: "web", Timestamp: at.Add(time.Hour)},
+		{ID: "3", Type: string(EventTypeRepository), Action: "deleted", Organization: "other", Repository: "x", Timestamp: at.Add(2 * time.Hour),
+			Headers: map[string]string{"X-Hub-Signature-256": "sha256=secret"}, Signature: "sha256=secret"},
+	} {
+		require.NoError(t, storage.StoreEvent(ctx, event), i)
+	}
+	require.NoError(t, log.Close())
+
+	// 재시작 후에도 이벤트가 남아 있다
+	log, err = provider.OpenEventLog(path)
+	require.NoError(t, err)
+	defer log.Close()
+	storage = NewEventLogStorage(log)
+
+	event, err := storage.GetEvent(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, &GitHubEvent{ID: "1", Type: "repository", Action: "created", Organization: "acme", Repository: "api", Sender: "jdoe", Timestamp: at}, event)
+	event, err = storage.GetEvent(ctx, "3")
+	require.NoError(t, err)
+	assert.Empty(t, event.Signature, "signatures are not persisted")
+	_, err = storage.GetEvent(ctx, "missing")
+	assert.Error(t, err)
+
+	events, err := storage.ListEvents(ctx, &EventFilter{EventTypes: []EventType{EventTypeRepository}}, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "3", events[1].ID)
+
+	events, err = storage.ListEvents(ctx, nil, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "2", events[0].ID)
+
+	count, err := storage.CountEvents(ctx, &EventFilter{Organization: "acme", TimeRange: &TimeRange{Start: at.Add(time.Minute)}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	assert.ErrorIs(t, storage.DeleteEvent(ctx, "1"), ErrEventLogAppendOnly)
+}
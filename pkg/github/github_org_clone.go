@@ -39,6 +39,8 @@ type RepoInfo struct {
 	Fork bool `json:"fork"`
 	// DefaultBranch is the name of the repository's default branch (e.g., "main", "master")
 	DefaultBranch string `json:"default_branch"`
+	// Size is the repository size in kilobytes as reported by the GitHub API
+	Size int64 `json:"size"`
 }
 
 // GetDefaultBranch retrieves the default branch name for a GitHub repository.
@@ -218,10 +220,11 @@ func List(ctx context.Context, org string) ([]string, error) {
 //   - targetPath: Local directory path where the repository will be cloned
 //   - org: GitHub organization or user name
 //   - repo: Repository name
+//   - options: Extra git clone options, such as those of a sparse checkout
 //
 // Returns an error if the clone operation fails due to network issues,
 // authentication problems, or local file system errors.
-func Clone(ctx context.Context, targetPath string, org string, repo string) error {
+func Clone(ctx context.Context, targetPath string, org string, repo string, options ...string) error {
 	// if branch == "" {
 	//	defaultBranch, err := GetDefaultBranch(ctx, org, repo)
 	//	if err != nil {
@@ -240,7 +243,8 @@ func Clone(ctx context.Context, targetPath string, org string, repo string) erro
 
 	// Execute secure git clone operation
 	// Clone directly to the target path
-	if err := executor.ExecuteSecure(ctx, filepath.Dir(targetPath), "clone", cloneURL, filepath.Base(targetPath)); err != nil {
+	args := append(append([]string{"clone"}, options...), cloneURL, filepath.Base(targetPath))
+	if err := executor.ExecuteSecure(ctx, filepath.Dir(targetPath), args...); err != nil {
 		return fmt.Errorf("Clone Failed (url: %s, targetPath: %s, err: %w)", cloneURL, targetPath, err)
 	}
 
@@ -250,8 +254,12 @@ func Clone(ctx context.Context, targetPath string, org string, repo string) erro
 // RefreshAllOptimizedStreaming performs optimized bulk repository refresh using streaming API and memory management
 // This is the recommended method for large-scale organization cloning (>1000 repositories).
 func RefreshAllOptimizedStreaming(ctx context.Context, targetPath, org, strategy, token string) error {
-	config := DefaultOptimizedCloneConfig()
+	return RefreshAllOptimizedStreamingWithConfig(ctx, targetPath, org, strategy, token, DefaultOptimizedCloneConfig())
+}
 
+// RefreshAllOptimizedStreamingWithConfig is RefreshAllOptimizedStreaming with a caller-supplied configuration,
+// e.g. to attach a disk budget to the worker pool.
+func RefreshAllOptimizedStreamingWithConfig(ctx context.Context, targetPath, org, strategy, token string, config OptimizedCloneConfig) error {
 	manager, err := NewOptimizedSyncCloneManager(token, config) //nolint:contextcheck // Manager creation doesn't require context propagation
 	if err != nil {
 		return fmt.Errorf("failed to create optimized bulk clone manager: %w", err)
@@ -0,0 +1,687 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+	"github.com/gizzahub/gzh-cli/pkg/api"
+)
+
+// GraphQL API limits, see
+// https://docs.github.com/en/graphql/overview/rate-limits-and-node-limits-for-the-graphql-api
+const (
+	// DefaultGraphQLEndpoint is the public GitHub GraphQL endpoint.
+	DefaultGraphQLEndpoint = "https://api.github.com/graphql"
+
+	// graphQLMaxFirst is the largest page any connection may request.
+	graphQLMaxFirst = 100
+
+	// graphQLMaxNodes is the node limit of a single query.
+	graphQLMaxNodes = 500000
+
+	// graphQLMetricsProvider labels GraphQL rate limit metrics; the GraphQL
+	// API has its own point budget, separate from REST requests.
+	graphQLMetricsProvider = "github-graphql"
+)
+
+// OrgRepoQuery builds the GraphQL query used to scan the repositories of an
+// organization with nested fields in one request per page. A zero count
+// leaves the corresponding connection out of the query.
+type OrgRepoQuery struct {
+	// PageSize is the number of repositories per request (1-100).
+	PageSize int
+
+	// Topics is the number of topics fetched per repository.
+	Topics int
+
+	// BranchProtectionRules is the number of branch protection rules fetched
+	// per repository. Reading them needs admin access; without it the rules
+	// come back empty.
+	BranchProtectionRules int
+
+	// LatestCommit fetches the head commit of the default branch.
+	LatestCommit bool
+}
+
+// DefaultOrgRepoQuery returns a query fetching full pages with topics,
+// branch protection and the latest commit, at 3 points per page.
+func DefaultOrgRepoQuery() OrgRepoQuery {
+	return OrgRepoQuery{PageSize: graphQLMaxFirst, Topics: 20, BranchProtectionRules: 10, LatestCommit: true}
+}
+
+// QueryCost is the predicted cost of one query.
+type QueryCost struct {
+	// Requests is the number of connection requests GitHub accounts for.
+	Requests int
+	// Points is the rate limit cost: Requests / 100, rounded, at least 1.
+	Points int
+	// Nodes is the maximum number of nodes the query can return.
+	Nodes int
+}
+
+// Validate checks the query against the GraphQL API limits.
+func (q OrgRepoQuery) Validate() error {
+	limits := []struct {
+		name  string
+		first int
+	}{{"page size", q.PageSize}, {"topics", q.Topics}, {"branch protection rules", q.BranchProtectionRules}}
+	for _, l := range limits {
+		if l.first < 0 || l.first > graphQLMaxFirst {
+			return fmt.Errorf("graphql %s must be between 0 and %d, got %d", l.name, graphQLMaxFirst, l.first)
+		}
+	}
+	if q.PageSize == 0 {
+		return fmt.Errorf("graphql page size must be at least 1")
+	}
+	if nodes := q.Cost().Nodes; nodes > graphQLMaxNodes {
+		return fmt.Errorf("graphql query would return up to %d nodes, more than the limit of %d", nodes, graphQLMaxNodes)
+	}
+
+	return nil
+}
+
+// Cost predicts the rate limit cost of one page. GitHub counts one request
+// per connection and parent node: the repository page itself, plus one per
+// repository for each nested connection.
+func (q OrgRepoQuery) Cost() QueryCost {
+	cost := QueryCost{Requests: 1, Nodes: q.PageSize}
+
+	for _, first := range q.nestedConnections() {
+		cost.Requests += q.PageSize
+		cost.Nodes += q.PageSize * first
+	}
+	cost.Points = max(1, int(math.Round(float64(cost.Requests)/100)))
+
+	return cost
+}
+
+// nestedConnections returns the first argument of each per-repository connection.
+func (q OrgRepoQuery) nestedConnections() []int {
+	var firsts []int
+	if q.Topics > 0 {
+		firsts = append(firsts, q.Topics)
+	}
+	if q.BranchProtectionRules > 0 {
+		firsts = append(firsts, q.BranchProtectionRules)
+	}
+	if q.LatestCommit {
+		firsts = append(firsts, 1)
+	}
+
+	return firsts
+}
+
+// Build returns the query text. It takes the variables $org and $cursor and
+// also asks for the rate limit state, so each page reports its actual cost.
+func (q OrgRepoQuery) Build() string {
+	var b strings.Builder
+
+	b.WriteString("query($org: String!, $cursor: String) {\n")
+	b.WriteString("  rateLimit { limit cost remaining resetAt }\n")
+	b.WriteString("  organization(login: $org) {\n")
+	fmt.Fprintf(&b, "    repositories(first: %d, after: $cursor, orderBy: {field: NAME, direction: ASC}) {\n", q.PageSize)
+	b.WriteString("      pageInfo { hasNextPage endCursor }\n")
+	b.WriteString("      nodes {\n")
+	b.WriteString("        name nameWithOwner description url isPrivate isArchived isFork diskUsage updatedAt\n")
+	b.WriteString("        primaryLanguage { name }\n")
+	if q.LatestCommit {
+		b.WriteString("        defaultBranchRef { name target { ... on Commit { history(first: 1) { nodes { oid messageHeadline committedDate author { name email } } } } } }\n")
+	} else {
+		b.WriteString("        defaultBranchRef { name }\n")
+	}
+	if q.Topics > 0 {
+		fmt.Fprintf(&b, "        repositoryTopics(first: %d) { nodes { topic { name } } }\n", q.Topics)
+	}
+	if q.BranchProtectionRules > 0 {
+		fmt.Fprintf(&b, "        branchProtectionRules(first: %d) { nodes { pattern requiresApprovingReviews requiredApprovingReviewCount requiresStatusChecks requiresCodeOwnerReviews isAdminEnforced } }\n", q.BranchProtectionRules)
+	}
+	b.WriteString("      }\n    }\n  }\n}\n")
+
+	return b.String()
+}
+
+// GraphQLRepository is a repository returned by an organization scan.
+type GraphQLRepository struct {
+	Name             string              `json:"name"`
+	FullName         string              `json:"full_name"`
+	Description      string              `json:"description,omitempty"`
+	URL              string              `json:"url"`
+	Private          bool                `json:"private"`
+	Archived         bool                `json:"archived"`
+	Fork             bool                `json:"fork"`
+	DiskUsageKB      int                 `json:"disk_usage_kb"`
+	Language         string              `json:"language,omitempty"`
+	DefaultBranch    string              `json:"default_branch,omitempty"`
+	UpdatedAt        time.Time           `json:"updated_at"`
+	Topics           []string            `json:"topics,omitempty"`
+	BranchProtection []GraphQLBranchRule `json:"branch_protection,omitempty"`
+	LatestCommit     *GraphQLCommit      `json:"latest_commit,omitempty"`
+}
+
+// GraphQLBranchRule is a branch protection rule of a repository.
+type GraphQLBranchRule struct {
+	Pattern                      string `json:"pattern"`
+	RequiresApprovingReviews     bool   `json:"requires_approving_reviews"`
+	RequiredApprovingReviewCount int    `json:"required_approving_review_count"`
+	RequiresStatusChecks         bool   `json:"requires_status_checks"`
+	RequiresCodeOwnerReviews     bool   `json:"requires_code_owner_reviews"`
+	IsAdminEnforced              bool   `json:"is_admin_enforced"`
+}
+
+// GraphQLCommit is the head commit of a default branch.
+type GraphQLCommit struct {
+	SHA         string    `json:"sha"`
+	Message     string    `json:"message"`
+	CommittedAt time.Time `json:"committed_at"`
+	AuthorName  string    `json:"author_name,omitempty"`
+	AuthorEmail string    `json:"author_email,omitempty"`
+}
+
+// GraphQLErrorItem is one entry of the errors array of a GraphQL response.
+type GraphQLErrorItem struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// GraphQLError is returned when a response carries errors.
+type GraphQLError struct {
+	Errors []GraphQLErrorItem
+}
+
+func (e *GraphQLError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, item := range e.Errors {
+		msgs = append(msgs, item.Message)
+	}
+
+	return "graphql: " + strings.Join(msgs, "; ")
+}
+
+// onlyForbidden reports whether every error is a missing permission on a
+// nested field, which leaves the rest of the data usable.
+func (e *GraphQLError) onlyForbidden() bool {
+	for _, item := range e.Errors {
+		if item.Type != "FORBIDDEN" {
+			return false
+		}
+	}
+
+	return len(e.Errors) > 0
+}
+
+func (e *GraphQLError) rateLimited() bool {
+	for _, item := range e.Errors {
+		if item.Type == "RATE_LIMITED" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GraphQLRateLimit is the rate limit state reported with a query.
+type GraphQLRateLimit struct {
+	Limit     int       `json:"limit"`
+	Cost      int       `json:"cost"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"resetAt"`
+}
+
+// GraphQLStats counts the work of a GraphQLClient.
+type GraphQLStats struct {
+	Requests int `json:"requests"`
+	// Points is the sum of the costs reported by GitHub.
+	Points  int `json:"points"`
+	Retries int `json:"retries"`
+	// SecondaryLimits counts secondary rate limit responses.
+	SecondaryLimits int `json:"secondary_limits"`
+	// PartialErrors counts pages returned with FORBIDDEN errors on nested
+	// fields, such as branch protection without admin access.
+	PartialErrors int `json:"partial_errors"`
+}
+
+// GraphQLConfig configures a GraphQLClient.
+type GraphQLConfig struct {
+	// Token authenticates requests.
+	Token string
+
+	// Endpoint defaults to DefaultGraphQLEndpoint.
+	Endpoint string
+
+	// HTTPClient defaults to the shared GitHub client.
+	HTTPClient *http.Client
+
+	// MaxRetries bounds retries after secondary rate limits, exhausted
+	// budgets and server errors. Defaults to 5.
+	MaxRetries int
+
+	// Reserve is the number of points left untouched: a page is only
+	// requested while remaining - cost >= Reserve, otherwise the client
+	// waits for the budget to reset.
+	Reserve int
+
+	// Sleep and Now override waiting and the clock (for tests).
+	Sleep func(ctx context.Context, d time.Duration) error
+	Now   func() time.Time
+}
+
+// GraphQLClient runs batched GraphQL queries against GitHub. It tracks the
+// point budget reported by each response and backs off on secondary rate
+// limits. It is safe for concurrent use.
+type GraphQLClient struct {
+	cfg GraphQLConfig
+
+	mu    sync.Mutex
+	limit *GraphQLRateLimit // last reported state, nil before the first response
+	stats GraphQLStats
+}
+
+// NewGraphQLClient creates a GraphQL client.
+func NewGraphQLClient(cfg GraphQLConfig) *GraphQLClient {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultGraphQLEndpoint
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.GetGlobalClient("github")
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = 5
+	}
+	if cfg.Sleep == nil {
+		cfg.Sleep = sleep
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	return &GraphQLClient{cfg: cfg}
+}
+
+// Stats returns the counters so far.
+func (c *GraphQLClient) Stats() GraphQLStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+// RateLimit returns the last reported rate limit state.
+func (c *GraphQLClient) RateLimit() (GraphQLRateLimit, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.limit == nil {
+		return GraphQLRateLimit{}, false
+	}
+
+	return *c.limit, true
+}
+
+// ScanOrgRepositories pages through the repositories of org and calls fn
+// with each page, in name order. Scanning stops at the first error of fn.
+func (c *GraphQLClient) ScanOrgRepositories(ctx context.Context, org string, q OrgRepoQuery, fn func([]GraphQLRepository) error) error {
+	if err := q.Validate(); err != nil {
+		return err
+	}
+
+	query := q.Build()
+	cost := q.Cost().Points
+	cursor := ""
+
+	for {
+		if err := c.waitForBudget(ctx, cost); err != nil {
+			return err
+		}
+
+		vars := map[string]any{"org": org, "cursor": nil}
+		if cursor != "" {
+			vars["cursor"] = cursor
+		}
+
+		var data orgReposData
+		err := c.Do(api.WithCost(ctx, cost), query, vars, &data)
+		var gqlErr *GraphQLError
+		if errors.As(err, &gqlErr) && gqlErr.onlyForbidden() && data.Organization != nil {
+			c.mu.Lock()
+			c.stats.PartialErrors++
+			c.mu.Unlock()
+			err = nil
+		}
+		if err != nil {
+			return fmt.Errorf("scan repositories of %s: %w", org, err)
+		}
+		if data.Organization == nil {
+			return fmt.Errorf("scan repositories of %s: organization not found", org)
+		}
+
+		conn := data.Organization.Repositories
+		page := make([]GraphQLRepository, 0, len(conn.Nodes))
+		for i := range conn.Nodes {
+			page = append(page, conn.Nodes[i].repository())
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+
+		if !conn.PageInfo.HasNextPage || conn.PageInfo.EndCursor == "" {
+			return nil
+		}
+		cursor = conn.PageInfo.EndCursor
+	}
+}
+
+// ListOrgRepositories returns all repositories of org.
+func (c *GraphQLClient) ListOrgRepositories(ctx context.Context, org string, q OrgRepoQuery) ([]GraphQLRepository, error) {
+	var repos []GraphQLRepository
+	err := c.ScanOrgRepositories(ctx, org, q, func(page []GraphQLRepository) error {
+		repos = append(repos, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repos, nil
+}
+
+// waitForBudget sleeps until the reset when the last reported budget
+// cannot pay for cost while keeping the reserve.
+func (c *GraphQLClient) waitForBudget(ctx context.Context, cost int) error {
+	c.mu.Lock()
+	limit := c.limit
+	c.mu.Unlock()
+
+	if limit == nil || limit.Remaining-cost >= c.cfg.Reserve {
+		return nil
+	}
+	if wait := limit.ResetAt.Sub(c.cfg.Now()); wait > 0 {
+		if err := c.cfg.Sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+
+	// 리셋 이후에는 다음 응답이 새 한도를 알려준다
+	c.mu.Lock()
+	c.limit = nil
+	c.mu.Unlock()
+
+	return nil
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage    `json:"data"`
+	Errors []GraphQLErrorItem `json:"errors"`
+}
+
+// Do runs a query and decodes its data into out. Secondary rate limits,
+// exhausted budgets and server errors are retried with backoff. If the
+// response carries errors, out is still filled with the partial data and a
+// *GraphQLError is returned.
+func (c *GraphQLClient) Do(ctx context.Context, query string, vars map[string]any, out any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": vars})
+	if err != nil {
+		return fmt.Errorf("encode graphql request: %w", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		wait, err := c.attempt(ctx, body, out, attempt)
+		if wait == 0 {
+			return err
+		}
+		if attempt >= c.cfg.MaxRetries {
+			return fmt.Errorf("giving up after %d retries: %w", attempt, err)
+		}
+
+		c.mu.Lock()
+		c.stats.Retries++
+		c.mu.Unlock()
+
+		if err := c.cfg.Sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// attempt sends one request. A non-zero wait asks the caller to retry
+// after that long.
+func (c *GraphQLClient) attempt(ctx context.Context, body []byte, out any, attempt int) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+
+	c.mu.Lock()
+	c.stats.Requests++
+	c.mu.Unlock()
+
+	resp, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		return CalculateBackoff(attempt), fmt.Errorf("graphql request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+	if err != nil {
+		return CalculateBackoff(attempt), fmt.Errorf("read graphql response: %w", err)
+	}
+
+	switch {
+	case isRateLimitResponse(resp, data):
+		c.mu.Lock()
+		c.stats.SecondaryLimits++
+		c.mu.Unlock()
+		return c.limitWait(resp, attempt), fmt.Errorf("github rate limit (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	case resp.StatusCode >= 500:
+		return CalculateBackoff(attempt), fmt.Errorf("github graphql server error (HTTP %d)", resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return 0, fmt.Errorf("github graphql request failed (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var parsed graphQLResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return 0, fmt.Errorf("decode graphql response: %w", err)
+	}
+	if len(parsed.Data) > 0 && string(parsed.Data) != "null" {
+		if err := json.Unmarshal(parsed.Data, out); err != nil {
+			return 0, fmt.Errorf("decode graphql data: %w", err)
+		}
+		c.recordRateLimit(parsed.Data)
+	}
+
+	if len(parsed.Errors) > 0 {
+		gqlErr := &GraphQLError{Errors: parsed.Errors}
+		if gqlErr.rateLimited() {
+			return c.limitWait(resp, attempt), gqlErr
+		}
+		return 0, gqlErr
+	}
+
+	return 0, nil
+}
+
+// isRateLimitResponse tells rate limit responses from other 403s, such as
+// missing access to the organization.
+func isRateLimitResponse(resp *http.Response, body []byte) bool {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return true
+	case resp.StatusCode != http.StatusForbidden:
+		return false
+	}
+
+	return resp.Header.Get("Retry-After") != "" ||
+		resp.Header.Get("X-RateLimit-Remaining") == "0" ||
+		strings.Contains(strings.ToLower(string(body)), "rate limit")
+}
+
+// limitWait decides how long to wait after a rate limit response:
+// Retry-After when given, the reset time when the primary budget is
+// exhausted, and otherwise an exponential backoff starting at one minute
+// as GitHub recommends for secondary limits.
+func (c *GraphQLClient) limitWait(resp *http.Response, attempt int) time.Duration {
+	if s := resp.Header.Get("Retry-After"); s != "" {
+		if seconds, err := strconv.Atoi(s); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if wait := time.Unix(reset, 0).Sub(c.cfg.Now()); wait > 0 {
+				return wait + time.Second
+			}
+		}
+	}
+
+	return min(time.Minute<<min(attempt, 4), 15*time.Minute)
+}
+
+func (c *GraphQLClient) recordRateLimit(data json.RawMessage) {
+	var state struct {
+		RateLimit *GraphQLRateLimit `json:"rateLimit"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil || state.RateLimit == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.limit = state.RateLimit
+	c.stats.Points += state.RateLimit.Cost
+	c.mu.Unlock()
+
+	metrics.RecordRateLimit(graphQLMetricsProvider, state.RateLimit.Remaining, state.RateLimit.Limit)
+}
+
+// orgReposData mirrors the response of OrgRepoQuery.
+type orgReposData struct {
+	Organization *struct {
+		Repositories struct {
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Nodes []gqlRepoNode `json:"nodes"`
+		} `json:"repositories"`
+	} `json:"organization"`
+}
+
+type gqlRepoNode struct {
+	Name            string    `json:"name"`
+	NameWithOwner   string    `json:"nameWithOwner"`
+	Description     string    `json:"description"`
+	URL             string    `json:"url"`
+	IsPrivate       bool      `json:"isPrivate"`
+	IsArchived      bool      `json:"isArchived"`
+	IsFork          bool      `json:"isFork"`
+	DiskUsage       int       `json:"diskUsage"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+	PrimaryLanguage *struct {
+		Name string `json:"name"`
+	} `json:"primaryLanguage"`
+	DefaultBranchRef *struct {
+		Name   string `json:"name"`
+		Target *struct {
+			History *struct {
+				Nodes []struct {
+					OID             string    `json:"oid"`
+					MessageHeadline string    `json:"messageHeadline"`
+					CommittedDate   time.Time `json:"committedDate"`
+					Author          *struct {
+						Name  string `json:"name"`
+						Email string `json:"email"`
+					} `json:"author"`
+				} `json:"nodes"`
+			} `json:"history"`
+		} `json:"target"`
+	} `json:"defaultBranchRef"`
+	RepositoryTopics *struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+	BranchProtectionRules *struct {
+		Nodes []struct {
+			Pattern                      string `json:"pattern"`
+			RequiresApprovingReviews     bool   `json:"requiresApprovingReviews"`
+			RequiredApprovingReviewCount int    `json:"requiredApprovingReviewCount"`
+			RequiresStatusChecks         bool   `json:"requiresStatusChecks"`
+			RequiresCodeOwnerReviews     bool   `json:"requiresCodeOwnerReviews"`
+			IsAdminEnforced              bool   `json:"isAdminEnforced"`
+		} `json:"nodes"`
+	} `json:"branchProtectionRules"`
+}
+
+func (n *gqlRepoNode) repository() GraphQLRepository {
+	repo := GraphQLRepository{
+		Name:        n.Name,
+		FullName:    n.NameWithOwner,
+		Description: n.Description,
+		URL:         n.URL,
+		Private:     n.IsPrivate,
+		Archived:    n.IsArchived,
+		Fork:        n.IsFork,
+		DiskUsageKB: n.DiskUsage,
+		UpdatedAt:   n.UpdatedAt,
+	}
+	if n.PrimaryLanguage != nil {
+		repo.Language = n.PrimaryLanguage.Name
+	}
+
+	if ref := n.DefaultBranchRef; ref != nil {
+		repo.DefaultBranch = ref.Name
+		if ref.Target != nil && ref.Target.History != nil && len(ref.Target.History.Nodes) > 0 {
+			head := ref.Target.History.Nodes[0]
+			repo.LatestCommit = &GraphQLCommit{SHA: head.OID, Message: head.MessageHeadline, CommittedAt: head.CommittedDate}
+			if head.Author != nil {
+				repo.LatestCommit.AuthorName = head.Author.Name
+				repo.LatestCommit.AuthorEmail = head.Author.Email
+			}
+		}
+	}
+
+	if n.RepositoryTopics != nil {
+		for _, t := range n.RepositoryTopics.Nodes {
+			repo.Topics = append(repo.Topics, t.Topic.Name)
+		}
+	}
+
+	if n.BranchProtectionRules != nil {
+		for _, r := range n.BranchProtectionRules.Nodes {
+			repo.BranchProtection = append(repo.BranchProtection, GraphQLBranchRule{
+				Pattern:                      r.Pattern,
+				RequiresApprovingReviews:     r.RequiresApprovingReviews,
+				RequiredApprovingReviewCount: r.RequiredApprovingReviewCount,
+				RequiresStatusChecks:         r.RequiresStatusChecks,
+				RequiresCodeOwnerReviews:     r.RequiresCodeOwnerReviews,
+				IsAdminEnforced:              r.IsAdminEnforced,
+			})
+		}
+	}
+
+	return repo
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgRepoQueryCost(t *testing.T) {
+	q := DefaultOrgRepoQuery()
+	require.NoError(t, q.Validate())
+	assert.Equal(t, QueryCost{Requests: 301, Points: 3, Nodes: 100 + 2000 + 1000 + 100}, q.Cost())
+
+	bare := OrgRepoQuery{PageSize: 50}
+	assert.Equal(t, QueryCost{Requests: 1, Points: 1, Nodes: 50}, bare.Cost())
+	assert.NotContains(t, bare.Build(), "repositoryTopics")
+	assert.NotContains(t, bare.Build(), "history(")
+
+	query := q.Build()
+	assert.Contains(t, query, "repositories(first: 100, after: $cursor")
+	assert.Contains(t, query, "repositoryTopics(first: 20)")
+	assert.Contains(t, query, "branchProtectionRules(first: 10)")
+	assert.Contains(t, query, "rateLimit { limit cost remaining resetAt }")
+
+	assert.Error(t, OrgRepoQuery{PageSize: 101}.Validate())
+	assert.Error(t, OrgRepoQuery{}.Validate())
+}
+
+type graphQLTestServer struct {
+	mu        sync.Mutex
+	requests  int
+	cursors   []any
+	responses []func(w http.ResponseWriter, cursor any)
+}
+
+func (s *graphQLTestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || r.Header.Get("Authorization") != "Bearer test-token" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	respond := s.responses[min(s.requests, len(s.responses)-1)]
+	s.requests++
+	s.cursors = append(s.cursors, body.Variables["cursor"])
+	s.mu.Unlock()
+
+	respond(w, body.Variables["cursor"])
+}
+
+func repoPage(names []string, next string, remaining int, extra string) func(http.ResponseWriter, any) {
+	return func(w http.ResponseWriter, _ any) {
+		nodes := make([]string, len(names))
+		for i, name := range names {
+			nodes[i] = fmt.Sprintf(`{"name":%q,"nameWithOwner":"acme/%s","diskUsage":12,"primaryLanguage":{"name":"Go"},
+				"defaultBranchRef":{"name":"main","target":{"history":{"nodes":[{"oid":"abc","messageHeadline":"init","committedDate":"2025-01-02T03:04:05Z","author":{"name":"dev"}}]}}},
+				"repositoryTopics":{"nodes":[{"topic":{"name":"cli"}}]},
+				"branchProtectionRules":{"nodes":[{"pattern":"main","requiresApprovingReviews":true,"requiredApprovingReviewCount":2}]}}`, name, name)
+		}
+		fmt.Fprintf(w, `{"data":{"rateLimit":{"limit":5000,"cost":3,"remaining":%d,"resetAt":"2030-01-01T00:00:00Z"},
+			"organization":{"repositories":{"pageInfo":{"hasNextPage":%t,"endCursor":%q},"nodes":[%s]}}}%s}`,
+			remaining, next != "", next, strings.Join(nodes, ","), extra)
+	}
+}
+
+func newTestGraphQLClient(t *testing.T, server *graphQLTestServer, sleeps *[]time.Duration) *GraphQLClient {
+	t.Helper()
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+
+	return NewGraphQLClient(GraphQLConfig{
+		Token:      "test-token",
+		Endpoint:   ts.URL,
+		HTTPClient: ts.Client(),
+		Reserve:    10,
+		Sleep: func(_ context.Context, d time.Duration) error {
+			*sleeps = append(*sleeps, d)
+			return nil
+		},
+		Now: func() time.Time { return time.Date(2029, 12, 31, 23, 0, 0, 0, time.UTC) },
+	})
+}
+
+func TestGraphQLClientScanOrgRepositories(t *testing.T) {
+	server := &graphQLTestServer{responses: []func(http.ResponseWriter, any){
+		repoPage([]string{"api", "cli"}, "cursor-1", 4000, ""),
+		repoPage([]string{"web"}, "", 3997, ""),
+	}}
+	var sleeps []time.Duration
+	client := newTestGraphQLClient(t, server, &sleeps)
+
+	repos, err := client.ListOrgRepositories(context.Background(), "acme", DefaultOrgRepoQuery())
+	require.NoError(t, err)
+	require.Len(t, repos, 3)
+	assert.Equal(t, []any{nil, "cursor-1"}, server.cursors)
+	assert.Empty(t, sleeps)
+
+	api := repos[0]
+	assert.Equal(t, "acme/api", api.FullName)
+	assert.Equal(t, "Go", api.Language)
+	assert.Equal(t, "main", api.DefaultBranch)
+	assert.Equal(t, []string{"cli"}, api.Topics)
+	require.Len(t, api.BranchProtection, 1)
+	assert.Equal(t, 2, api.BranchProtection[0].RequiredApprovingReviewCount)
+	require.NotNil(t, api.LatestCommit)
+	assert.Equal(t, "abc", api.LatestCommit.SHA)
+	assert.Equal(t, "dev", api.LatestCommit.AuthorName)
+
+	stats := client.Stats()
+	assert.Equal(t, 2, stats.Requests)
+	assert.Equal(t, 6, stats.Points)
+	limit, ok := client.RateLimit()
+	require.True(t, ok)
+	assert.Equal(t, 3997, limit.Remaining)
+}
+
+func TestGraphQLClientSecondaryRateLimit(t *testing.T) {
+	server := &graphQLTestServer{responses: []func(http.ResponseWriter, any){
+		func(w http.ResponseWriter, _ any) {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"You have exceeded a secondary rate limit"}`)
+		},
+		func(w http.ResponseWriter, _ any) {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"You have exceeded a secondary rate limit"}`)
+		},
+		func(w http.ResponseWriter, _ any) {
+			fmt.Fprint(w, `{"data":{"rateLimit":{"cost":1,"remaining":4000,"resetAt":"2030-01-01T00:00:00Z"}},"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded"}]}`)
+		},
+		repoPage([]string{"api"}, "", 3999, ""),
+	}}
+	var sleeps []time.Duration
+	client := newTestGraphQLClient(t, server, &sleeps)
+
+	repos, err := client.ListOrgRepositories(context.Background(), "acme", OrgRepoQuery{PageSize: 10})
+	require.NoError(t, err)
+	assert.Len(t, repos, 1)
+	assert.Equal(t, []time.Duration{30 * time.Second, 2 * time.Minute, 4 * time.Minute}, sleeps,
+		"Retry-After is honored, then backoff starts at one minute and doubles")
+	assert.Equal(t, 3, client.Stats().Retries)
+	assert.Equal(t, 2, client.Stats().SecondaryLimits)
+}
+
+func TestGraphQLClientBudgetAndErrors(t *testing.T) {
+	server := &graphQLTestServer{responses: []func(http.ResponseWriter, any){
+		repoPage([]string{"api"}, "cursor-1", 12, `,"errors":[{"type":"FORBIDDEN","message":"Resource not accessible by integration"}]`),
+		repoPage([]string{"web"}, "", 4997, ""),
+	}}
+	var sleeps []time.Duration
+	client := newTestGraphQLClient(t, server, &sleeps)
+
+	repos, err := client.ListOrgRepositories(context.Background(), "acme", DefaultOrgRepoQuery())
+	require.NoError(t, err, "FORBIDDEN on nested fields keeps the page")
+	assert.Len(t, repos, 2)
+	assert.Equal(t, 1, client.Stats().PartialErrors)
+	assert.Equal(t, []time.Duration{time.Hour}, sleeps, "12 remaining cannot pay 3 points with a reserve of 10")
+
+	missing := &graphQLTestServer{responses: []func(http.ResponseWriter, any){
+		func(w http.ResponseWriter, _ any) {
+			fmt.Fprint(w, `{"data":{"organization":null},"errors":[{"type":"NOT_FOUND","message":"Could not resolve to an Organization with the login of 'nope'."}]}`)
+		},
+	}}
+	client = newTestGraphQLClient(t, missing, &sleeps)
+	_, err = client.ListOrgRepositories(context.Background(), "nope", DefaultOrgRepoQuery())
+	var gqlErr *GraphQLError
+	require.ErrorAs(t, err, &gqlErr)
+	assert.Contains(t, err.Error(), "Could not resolve")
+
+	denied := &graphQLTestServer{responses: []func(http.ResponseWriter, any){
+		func(w http.ResponseWriter, _ any) {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message":"Resource not accessible"}`)
+		},
+	}}
+	client = newTestGraphQLClient(t, denied, &sleeps)
+	_, err = client.ListOrgRepositories(context.Background(), "acme", DefaultOrgRepoQuery())
+	require.Error(t, err)
+	assert.Equal(t, 0, client.Stats().Retries, "a plain 403 is not retried")
+}
@@ -0,0 +1,349 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/api"
+)
+
+// Mutation batching limits. GitHub counts each mutation against its
+// secondary rate limits no matter how many share a request, and asks
+// clients to send mutations serially; see
+// https://docs.github.com/en/graphql/overview/rate-limits-and-node-limits-for-the-graphql-api#secondary-rate-limits
+const (
+	// DefaultMutationBatchSize is the number of aliased mutations per request.
+	DefaultMutationBatchSize = 20
+
+	// DefaultMutationsPerMinute stays below the secondary limit on
+	// content-creating requests.
+	DefaultMutationsPerMinute = 80
+
+	// repositoryIDBatchSize is the number of repositories resolved per query.
+	repositoryIDBatchSize = 50
+)
+
+// Mutation is one GraphQL mutation of a batch, applied to one repository.
+type Mutation struct {
+	// Repository identifies the target ("owner/name") in the result.
+	Repository string
+
+	// Name is the mutation field, such as "updateRepository".
+	Name string
+
+	// Input is passed as the input argument.
+	Input map[string]any
+
+	// InputType defaults to the capitalized Name followed by "Input".
+	InputType string
+
+	// Selection is the selection set of the payload. Defaults to
+	// "clientMutationId".
+	Selection string
+}
+
+func (m Mutation) inputType() string {
+	if m.InputType != "" {
+		return m.InputType
+	}
+
+	return strings.ToUpper(m.Name[:1]) + m.Name[1:] + "Input"
+}
+
+func (m Mutation) selection() string {
+	if m.Selection != "" {
+		return m.Selection
+	}
+
+	return "clientMutationId"
+}
+
+// UpdateRepositoryMutation builds an updateRepository mutation setting
+// fields of UpdateRepositoryInput, such as hasWikiEnabled, on the
+// repository with node ID id. Settings GitHub only exposes through REST,
+// such as vulnerability alerts, cannot be batched.
+func UpdateRepositoryMutation(fullName, id string, fields map[string]any) Mutation {
+	input := make(map[string]any, len(fields)+1)
+	for k, v := range fields {
+		input[k] = v
+	}
+	input["repositoryId"] = id
+
+	return Mutation{
+		Repository: fullName,
+		Name:       "updateRepository",
+		Input:      input,
+		Selection:  "repository { nameWithOwner }",
+	}
+}
+
+// MutationBatchConfig configures RunMutations.
+type MutationBatchConfig struct {
+	// BatchSize is the number of mutations per request. Defaults to
+	// DefaultMutationBatchSize.
+	BatchSize int
+
+	// PerMinute paces requests so no more than this many mutations are
+	// sent per minute. Defaults to DefaultMutationsPerMinute.
+	PerMinute int
+
+	// OnBatch is called after each request with the number of mutations
+	// done so far.
+	OnBatch func(done, total int)
+}
+
+// MutationOutcome is the result of one mutation.
+type MutationOutcome struct {
+	Repository string          `json:"repository"`
+	Alias      string          `json:"alias"`
+	Success    bool            `json:"success"`
+	Error      string          `json:"error,omitempty"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
+
+// MutationResult is the consolidated result of RunMutations, with one
+// outcome per mutation in input order.
+type MutationResult struct {
+	Outcomes  []MutationOutcome `json:"outcomes"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Requests  int               `json:"requests"`
+	Duration  time.Duration     `json:"duration"`
+}
+
+// Failures returns the failed outcomes.
+func (r *MutationResult) Failures() []MutationOutcome {
+	var failed []MutationOutcome
+	for _, o := range r.Outcomes {
+		if !o.Success {
+			failed = append(failed, o)
+		}
+	}
+
+	return failed
+}
+
+// RunMutations sends mutations in aliased batches, one request at a time,
+// paced to cfg.PerMinute. A failing mutation only fails its own outcome;
+// errors of a whole request fail every mutation of that batch. The error
+// is only set for invalid mutations and when ctx ends, in which case the
+// outcomes so far are returned as well.
+func (c *GraphQLClient) RunMutations(ctx context.Context, mutations []Mutation, cfg MutationBatchConfig) (*MutationResult, error) {
+	for i, m := range mutations {
+		if m.Name == "" {
+			return nil, fmt.Errorf("mutation %d (%s) has no name", i, m.Repository)
+		}
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultMutationBatchSize
+	}
+	if cfg.PerMinute <= 0 {
+		cfg.PerMinute = DefaultMutationsPerMinute
+	}
+
+	start := c.cfg.Now()
+	result := &MutationResult{Outcomes: make([]MutationOutcome, 0, len(mutations))}
+	var next time.Time
+
+	for offset := 0; offset < len(mutations); offset += cfg.BatchSize {
+		batch := mutations[offset:min(offset+cfg.BatchSize, len(mutations))]
+
+		// 보조 한도는 요청이 아니라 뮤테이션 수로 계산되므로 배치 크기만큼 간격을 둔다
+		if wait := next.Sub(c.cfg.Now()); !next.IsZero() && wait > 0 {
+			if err := c.cfg.Sleep(ctx, wait); err != nil {
+				return c.finishMutations(result, start), err
+			}
+		}
+		if err := c.waitForBudget(ctx, 1); err != nil {
+			return c.finishMutations(result, start), err
+		}
+		next = c.cfg.Now().Add(time.Duration(len(batch)) * time.Minute / time.Duration(cfg.PerMinute))
+
+		outcomes := c.runMutationBatch(ctx, batch, offset)
+		if ctx.Err() != nil {
+			return c.finishMutations(result, start), ctx.Err()
+		}
+		result.Outcomes = append(result.Outcomes, outcomes...)
+		result.Requests++
+
+		if cfg.OnBatch != nil {
+			cfg.OnBatch(len(result.Outcomes), len(mutations))
+		}
+	}
+
+	return c.finishMutations(result, start), nil
+}
+
+func (c *GraphQLClient) finishMutations(result *MutationResult, start time.Time) *MutationResult {
+	result.Succeeded, result.Failed = 0, 0
+	for _, o := range result.Outcomes {
+		if o.Success {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	result.Duration = c.cfg.Now().Sub(start)
+
+	return result
+}
+
+// runMutationBatch sends one aliased request. Aliases are numbered from
+// offset so they stay unique across the run.
+func (c *GraphQLClient) runMutationBatch(ctx context.Context, batch []Mutation, offset int) []MutationOutcome {
+	query, vars := buildMutationBatch(batch, offset)
+	outcomes := make([]MutationOutcome, len(batch))
+	for i, m := range batch {
+		outcomes[i] = MutationOutcome{Repository: m.Repository, Alias: fmt.Sprintf("m%d", offset+i)}
+	}
+
+	var data map[string]json.RawMessage
+	err := c.Do(api.WithCost(ctx, 1), query, vars, &data)
+
+	aliasErrors := map[string][]string{}
+	var batchErr error
+	var gqlErr *GraphQLError
+	switch {
+	case errors.As(err, &gqlErr):
+		var general []string
+		for _, item := range gqlErr.Errors {
+			if alias, ok := errorAlias(item); ok {
+				aliasErrors[alias] = append(aliasErrors[alias], item.Message)
+			} else {
+				general = append(general, item.Message)
+			}
+		}
+		if len(general) > 0 {
+			batchErr = errors.New("graphql: " + strings.Join(general, "; "))
+		}
+	case err != nil:
+		batchErr = err
+	}
+
+	for i := range outcomes {
+		o := &outcomes[i]
+		payload := data[o.Alias]
+		switch {
+		case len(aliasErrors[o.Alias]) > 0:
+			o.Error = strings.Join(aliasErrors[o.Alias], "; ")
+		case len(payload) > 0 && string(payload) != "null":
+			o.Success = true
+			o.Data = payload
+		case batchErr != nil:
+			o.Error = batchErr.Error()
+		default:
+			o.Error = "no result returned"
+		}
+	}
+
+	return outcomes
+}
+
+// buildMutationBatch returns the aliased mutation document and its
+// variables: mutation($i0: UpdateRepositoryInput!) { m0: updateRepository(input: $i0) { ... } }.
+func buildMutationBatch(batch []Mutation, offset int) (string, map[string]any) {
+	vars := make(map[string]any, len(batch))
+	params := make([]string, len(batch))
+	var fields strings.Builder
+
+	for i, m := range batch {
+		n := offset + i
+		params[i] = fmt.Sprintf("$i%d: %s!", n, m.inputType())
+		vars[fmt.Sprintf("i%d", n)] = m.Input
+		fmt.Fprintf(&fields, "  m%d: %s(input: $i%d) { %s }\n", n, m.Name, n, m.selection())
+	}
+
+	return "mutation(" + strings.Join(params, ", ") + ") {\n" + fields.String() + "}\n", vars
+}
+
+// errorAlias returns the alias an error belongs to: the first element of
+// its path.
+func errorAlias(item GraphQLErrorItem) (string, bool) {
+	if len(item.Path) == 0 {
+		return "", false
+	}
+	alias, ok := item.Path[0].(string)
+
+	return alias, ok
+}
+
+// RepositoryIDs resolves repositories ("owner/name") to the node IDs
+// mutations take, with aliased queries of up to 50 repositories. Missing
+// or inaccessible repositories are returned in failed instead of failing
+// the whole lookup.
+func (c *GraphQLClient) RepositoryIDs(ctx context.Context, fullNames []string) (ids map[string]string, failed map[string]error, err error) {
+	ids = make(map[string]string, len(fullNames))
+	failed = map[string]error{}
+
+	for offset := 0; offset < len(fullNames); offset += repositoryIDBatchSize {
+		batch := fullNames[offset:min(offset+repositoryIDBatchSize, len(fullNames))]
+
+		var params, fields []string
+		vars := map[string]any{}
+		aliases := make(map[string]string, len(batch))
+		for i, fullName := range batch {
+			owner, name, ok := strings.Cut(fullName, "/")
+			if !ok || owner == "" || name == "" {
+				failed[fullName] = fmt.Errorf("invalid repository %q, want owner/name", fullName)
+				continue
+			}
+			alias := fmt.Sprintf("r%d", i)
+			aliases[alias] = fullName
+			params = append(params, fmt.Sprintf("$o%d: String!, $n%d: String!", i, i))
+			fields = append(fields, fmt.Sprintf("  %s: repository(owner: $o%d, name: $n%d) { id }", alias, i, i))
+			vars[fmt.Sprintf("o%d", i)] = owner
+			vars[fmt.Sprintf("n%d", i)] = name
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		if err := c.waitForBudget(ctx, 1); err != nil {
+			return ids, failed, err
+		}
+		query := "query(" + strings.Join(params, ", ") + ") {\n  rateLimit { limit cost remaining resetAt }\n" +
+			strings.Join(fields, "\n") + "\n}\n"
+
+		var data map[string]json.RawMessage
+		doErr := c.Do(api.WithCost(ctx, 1), query, vars, &data)
+		var gqlErr *GraphQLError
+		if doErr != nil && !errors.As(doErr, &gqlErr) {
+			return ids, failed, fmt.Errorf("resolve repository ids: %w", doErr)
+		}
+
+		aliasErrors := map[string]string{}
+		if gqlErr != nil {
+			for _, item := range gqlErr.Errors {
+				if alias, ok := errorAlias(item); ok {
+					aliasErrors[alias] = item.Message
+				}
+			}
+		}
+
+		for alias, fullName := range aliases {
+			var node struct {
+				ID string `json:"id"`
+			}
+			if raw := data[alias]; len(raw) > 0 && json.Unmarshal(raw, &node) == nil && node.ID != "" {
+				ids[fullName] = node.ID
+				continue
+			}
+			if msg, ok := aliasErrors[alias]; ok {
+				failed[fullName] = errors.New(msg)
+			} else if gqlErr != nil {
+				failed[fullName] = gqlErr
+			} else {
+				failed[fullName] = fmt.Errorf("repository %s not found", fullName)
+			}
+		}
+	}
+
+	return ids, failed, nil
+}
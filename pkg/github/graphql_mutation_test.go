@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mutationRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+func newMutationTestClient(t *testing.T, handler func(w http.ResponseWriter, req mutationRequest), sleeps *[]time.Duration) *GraphQLClient {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req mutationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		handler(w, req)
+	}))
+	t.Cleanup(ts.Close)
+
+	now := time.Date(2029, 12, 31, 23, 0, 0, 0, time.UTC)
+	return NewGraphQLClient(GraphQLConfig{
+		Token:      "test-token",
+		Endpoint:   ts.URL,
+		HTTPClient: ts.Client(),
+		Sleep: func(_ context.Context, d time.Duration) error {
+			*sleeps = append(*sleeps, d)
+			now = now.Add(d)
+			return nil
+		},
+		Now: func() time.Time { return now },
+	})
+}
+
+func TestBuildMutationBatch(t *testing.T) {
+	query, vars := buildMutationBatch([]Mutation{
+		UpdateRepositoryMutation("acme/api", "R_1", map[string]any{"hasWikiEnabled": false}),
+		{Repository: "acme/web", Name: "archiveRepository", Input: map[string]any{"repositoryId": "R_2"}},
+	}, 20)
+
+	assert.Equal(t, "mutation($i20: UpdateRepositoryInput!, $i21: ArchiveRepositoryInput!) {\n"+
+		"  m20: updateRepository(input: $i20) { repository { nameWithOwner } }\n"+
+		"  m21: archiveRepository(input: $i21) { clientMutationId }\n}\n", query)
+	assert.Equal(t, map[string]any{"repositoryId": "R_1", "hasWikiEnabled": false}, vars["i20"])
+	assert.Equal(t, map[string]any{"repositoryId": "R_2"}, vars["i21"])
+}
+
+func TestGraphQLClientRunMutations(t *testing.T) {
+	var queries []string
+	var sleeps []time.Duration
+	client := newMutationTestClient(t, func(w http.ResponseWriter, req mutationRequest) {
+		queries = append(queries, req.Query)
+		switch len(queries) {
+		case 1:
+			fmt.Fprint(w, `{"data":{"m0":{"repository":{"nameWithOwner":"acme/r0"}},"m1":null},
+				"errors":[{"type":"FORBIDDEN","message":"Must have admin rights","path":["m1"]}]}`)
+		case 2:
+			fmt.Fprint(w, `{"data":{"m2":{"repository":{"nameWithOwner":"acme/r2"}},"m3":{"repository":{"nameWithOwner":"acme/r3"}}}}`)
+		default:
+			fmt.Fprint(w, `{"data":null,"errors":[{"message":"Variable $i4 of type UpdateRepositoryInput! was provided invalid value"}]}`)
+		}
+	}, &sleeps)
+
+	var mutations []Mutation
+	for i := range 5 {
+		mutations = append(mutations, UpdateRepositoryMutation(fmt.Sprintf("acme/r%d", i), fmt.Sprintf("R_%d", i), map[string]any{"hasWikiEnabled": false}))
+	}
+
+	var progress []int
+	result, err := client.RunMutations(context.Background(), mutations, MutationBatchConfig{
+		BatchSize: 2,
+		PerMinute: 60,
+		OnBatch:   func(done, total int) { progress = append(progress, done) },
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, queries, 3)
+	assert.Equal(t, 3, result.Requests)
+	assert.Equal(t, []int{2, 4, 5}, progress)
+	assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second}, sleeps, "two mutations per request at 60 per minute")
+	assert.Equal(t, 4*time.Second, result.Duration)
+
+	require.Len(t, result.Outcomes, 5)
+	assert.Equal(t, 3, result.Succeeded)
+	assert.Equal(t, 2, result.Failed)
+	assert.True(t, result.Outcomes[0].Success)
+	assert.JSONEq(t, `{"repository":{"nameWithOwner":"acme/r0"}}`, string(result.Outcomes[0].Data))
+
+	failures := result.Failures()
+	require.Len(t, failures, 2)
+	assert.Equal(t, "acme/r1", failures[0].Repository)
+	assert.Equal(t, "Must have admin rights", failures[0].Error)
+	assert.Equal(t, "acme/r4", failures[1].Repository)
+	assert.Contains(t, failures[1].Error, "invalid value", "request-wide errors fail the whole batch")
+
+	_, err = client.RunMutations(context.Background(), []Mutation{{Repository: "acme/x"}}, MutationBatchConfig{})
+	assert.Error(t, err)
+}
+
+func TestGraphQLClientRepositoryIDs(t *testing.T) {
+	var sleeps []time.Duration
+	client := newMutationTestClient(t, func(w http.ResponseWriter, req mutationRequest) {
+		assert.Equal(t, "acme", req.Variables["o0"])
+		assert.Equal(t, "api", req.Variables["n0"])
+		fmt.Fprint(w, `{"data":{"rateLimit":{"limit":5000,"cost":1,"remaining":4999,"resetAt":"2030-01-01T00:00:00Z"},
+			"r0":{"id":"R_api"},"r1":null},
+			"errors":[{"type":"NOT_FOUND","message":"Could not resolve to a Repository with the name 'acme/gone'.","path":["r1"]}]}`)
+	}, &sleeps)
+
+	ids, failed, err := client.RepositoryIDs(context.Background(), []string{"acme/api", "acme/gone", "bad"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"acme/api": "R_api"}, ids)
+	require.Len(t, failed, 2)
+	assert.Contains(t, failed["acme/gone"].Error(), "Could not resolve")
+	assert.Contains(t, failed["bad"].Error(), "owner/name")
+	assert.Equal(t, 1, client.Stats().Points)
+}
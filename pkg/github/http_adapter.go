@@ -5,6 +5,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
 )
 
 // HTTPClientAdapter adapts the standard http.Client to the HTTPClient interface.
@@ -15,9 +17,7 @@ type HTTPClientAdapter struct {
 // NewHTTPClientAdapter creates a new HTTP client adapter.
 func NewHTTPClientAdapter() HTTPClient {
 	return &HTTPClientAdapter{
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		client: httpclient.NewProviderClient("github", 30*time.Second),
 	}
 }
 
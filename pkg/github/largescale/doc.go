@@ -6,6 +6,7 @@
 //
 // Key features:
 //   - Large-scale repository listing with pagination support
+//   - Streaming JSON decoding that hands out repositories as pages arrive
 //   - Bulk repository cloning with memory-efficient batching
 //   - Adaptive rate limiting based on GitHub API response headers
 //   - Progress tracking and statistics for long-running operations
@@ -26,4 +27,10 @@
 //	if err != nil {
 //		log.Fatal(err)
 //	}
+//
+// For organizations with tens of thousands of repositories, CloneOrganization
+// starts cloning while the listing is still running and keeps at most one
+// page of repositories in memory:
+//
+//	err = manager.CloneOrganization(ctx, "organization", "/target/path")
 package largescale
@@ -2,17 +2,20 @@ package largescale
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"runtime"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+	"github.com/gizzahub/gzh-cli/internal/jsonstream"
 )
 
 // LargeScaleConfig holds configuration for large-scale repository operations.
@@ -58,6 +61,7 @@ type ProgressCallback func(processed, total int, current string)
 type LargeScaleManager struct {
 	config           *LargeScaleConfig
 	client           *http.Client
+	apiURL           string
 	rateLimiter      *AdaptiveRateLimiter
 	progressCallback ProgressCallback
 	stats            *OperationStats
@@ -84,7 +88,8 @@ func NewLargeScaleManager(config *LargeScaleConfig, progressCallback ProgressCal
 
 	return &LargeScaleManager{
 		config:           config,
-		client:           &http.Client{Timeout: 30 * time.Second},
+		client:           httpclient.NewProviderClient("github", 30*time.Second),
+		apiURL:           "https://api.github.com",
 		rateLimiter:      NewAdaptiveRateLimiter(),
 		progressCallback: progressCallback,
 		stats: &OperationStats{
@@ -97,58 +102,83 @@ func NewLargeScaleManager(config *LargeScaleConfig, progressCallback ProgressCal
 func (m *LargeScaleManager) ListAllRepositories(ctx context.Context, org string) ([]LargeScaleRepository, error) {
 	var allRepos []LargeScaleRepository
 
+	_, err := m.StreamRepositories(ctx, org, func(repo LargeScaleRepository) error {
+		allRepos = append(allRepos, repo)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allRepos, nil
+}
+
+// StreamRepositories lists the repositories of an organization and calls fn
+// for each one as it is decoded from the response, so memory use does not
+// grow with the size of the organization. fn runs while the response body
+// is read; it should hand slow work off instead of blocking. An error from
+// fn stops the listing. The number of repositories listed is returned.
+func (m *LargeScaleManager) StreamRepositories(ctx context.Context, org string, fn func(LargeScaleRepository) error) (int, error) {
+	total := 0
+	err := m.streamPages(ctx, org, func(page, count int) {
+		total += count
+		if m.progressCallback != nil {
+			m.progressCallback(total, -1, fmt.Sprintf("Fetched page %d (%d repos)", page, count))
+		}
+	}, fn)
+
+	m.stats.mu.Lock()
+	m.stats.TotalRepos = total
+	m.stats.mu.Unlock()
+
+	return total, err
+}
+
+// streamPages fetches the pages of an organization's repositories in order,
+// passing each repository to fn and calling pageDone after every page.
+func (m *LargeScaleManager) streamPages(ctx context.Context, org string, pageDone func(page, count int), fn func(LargeScaleRepository) error) error {
 	page := 1
 	perPage := m.config.BatchSize
 
 	for {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return ctx.Err()
 		default:
 		}
 
 		// Wait for rate limit if necessary
 		if err := m.rateLimiter.Wait(ctx); err != nil {
-			return nil, fmt.Errorf("rate limit error: %w", err)
+			return fmt.Errorf("rate limit error: %w", err)
 		}
 
-		repos, hasMore, err := m.fetchRepositoryPage(ctx, org, page, perPage)
+		count, hasMore, err := m.fetchRepositoryPage(ctx, org, page, perPage, fn)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
+			return fmt.Errorf("failed to fetch page %d: %w", page, err)
 		}
 
-		allRepos = append(allRepos, repos...)
-		m.updateStats(len(repos), 0, 0)
-
-		// Update progress
-		if m.progressCallback != nil {
-			m.progressCallback(len(allRepos), -1, fmt.Sprintf("Fetched page %d (%d repos)", page, len(repos)))
-		}
+		m.updateStats(count, 0, 0)
+		pageDone(page, count)
 
 		if !hasMore {
-			break
+			return nil
 		}
 
 		page++
 
 		// Check memory usage and trigger GC if necessary
-		if m.shouldTriggerGC(len(allRepos)) {
+		if m.shouldTriggerGC(0) {
 			runtime.GC()
 		}
 	}
-
-	m.stats.mu.Lock()
-	m.stats.TotalRepos = len(allRepos)
-	m.stats.mu.Unlock()
-
-	return allRepos, nil
 }
 
-// fetchRepositoryPage fetches a single page of repositories.
-func (m *LargeScaleManager) fetchRepositoryPage(ctx context.Context, org string, page, perPage int) ([]LargeScaleRepository, bool, error) {
-	u, err := url.Parse(fmt.Sprintf("https://api.github.com/orgs/%s/repos", org))
+// fetchRepositoryPage fetches a single page of repositories, decoding them
+// one at a time into fn.
+func (m *LargeScaleManager) fetchRepositoryPage(ctx context.Context, org string, page, perPage int, fn func(LargeScaleRepository) error) (int, bool, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/orgs/%s/repos", m.apiURL, org))
 	if err != nil {
-		return nil, false, err
+		return 0, false, err
 	}
 
 	q := u.Query()
@@ -160,7 +190,7 @@ func (m *LargeScaleManager) fetchRepositoryPage(ctx context.Context, org string,
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
 	if err != nil {
-		return nil, false, err
+		return 0, false, err
 	}
 
 	// Add authentication if available
@@ -172,7 +202,7 @@ func (m *LargeScaleManager) fetchRepositoryPage(ctx context.Context, org string,
 
 	resp, err := m.client.Do(req)
 	if err != nil {
-		return nil, false, err
+		return 0, false, err
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -184,12 +214,12 @@ func (m *LargeScaleManager) fetchRepositoryPage(ctx context.Context, org string,
 	m.updateAPIStats(resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, false, fmt.Errorf("GitHub API error: %s", resp.Status)
+		return 0, false, fmt.Errorf("GitHub API error: %s", resp.Status)
 	}
 
-	var repos []LargeScaleRepository
-	if err := json.NewDecoder(resp.Body).Decode(&repos); err != nil {
-		return nil, false, err
+	count, err := jsonstream.DecodeArray(resp.Body, fn)
+	if err != nil {
+		return count, false, err
 	}
 
 	// Check if there are more pages using Link header
@@ -198,7 +228,7 @@ func (m *LargeScaleManager) fetchRepositoryPage(ctx context.Context, org string,
 		hasMore = containsNextLink(linkHeader)
 	}
 
-	return repos, hasMore, nil
+	return count, hasMore, nil
 }
 
 // BulkCloneRepositories clones multiple repositories with optimized concurrency.
@@ -253,45 +283,18 @@ func (m *LargeScaleManager) BulkCloneRepositories(ctx context.Context, repos []L
 			}
 			defer sem.Release(1)
 
-			// Check if we should skip this repository
-			if m.shouldSkipRepository(repo) {
-				m.updateStats(0, 0, 1)
+			skipped, err := m.cloneWithRetry(ctx, repo, targetPath)
+			if skipped {
 				return nil
 			}
 
-			// Clone with retry logic
-			var err error
-			for attempt := 0; attempt < m.config.MaxRetries; attempt++ {
-				err = m.cloneRepository(ctx, repo, targetPath)
-				if err == nil {
-					break
-				}
-
-				// Wait before retry with exponential backoff
-				if attempt < m.config.MaxRetries-1 {
-					backoff := time.Duration(attempt+1) * time.Second
-					select {
-					case <-time.After(backoff):
-					case <-ctx.Done():
-						return ctx.Err()
-					}
-				}
-			}
-
 			mu.Lock()
 
 			processed++
 
 			mu.Unlock()
 
-			if err != nil {
-				m.updateStats(0, 1, 0)
-				return fmt.Errorf("failed to clone %s after %d attempts: %w", repo.Name, m.config.MaxRetries, err)
-			}
-
-			m.updateStats(1, 0, 0)
-
-			return nil
+			return err
 		})
 
 		// Check memory pressure every 100 repositories
@@ -303,6 +306,92 @@ func (m *LargeScaleManager) BulkCloneRepositories(ctx context.Context, repos []L
 	return g.Wait()
 }
 
+// CloneOrganization clones the repositories of an organization while they
+// are listed: each page is handed to the clone workers as soon as it
+// arrives instead of after the full listing. Listing pauses while all
+// workers are busy, so at most one page of repositories is held in memory.
+func (m *LargeScaleManager) CloneOrganization(ctx context.Context, org, targetPath string) error {
+	// 전체 개수를 알 수 없으므로 대규모 작업 기준으로 동시성을 정한다
+	concurrency := m.calculateOptimalConcurrency(m.config.BatchSize)
+	sem := semaphore.NewWeighted(int64(concurrency))
+	g, gctx := errgroup.WithContext(ctx)
+
+	var (
+		batch  []LargeScaleRepository
+		listed int
+		cloned atomic.Int64
+	)
+	listErr := m.streamPages(gctx, org, func(page, count int) {
+		listed += count
+		for _, repo := range batch {
+			if err := sem.Acquire(gctx, 1); err != nil {
+				break
+			}
+			g.Go(func() error {
+				defer sem.Release(1)
+				_, err := m.cloneWithRetry(gctx, repo, targetPath)
+				cloned.Add(1)
+				return err
+			})
+		}
+		batch = batch[:0]
+
+		if m.progressCallback != nil {
+			m.progressCallback(int(cloned.Load()), listed, fmt.Sprintf("Listed page %d, cloning repositories...", page))
+		}
+	}, func(repo LargeScaleRepository) error {
+		batch = append(batch, repo)
+		return nil
+	})
+
+	m.stats.mu.Lock()
+	m.stats.TotalRepos = listed
+	m.stats.mu.Unlock()
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return listErr
+}
+
+// cloneWithRetry clones a repository unless it is skipped, retrying with
+// backoff, and records the outcome in the statistics.
+func (m *LargeScaleManager) cloneWithRetry(ctx context.Context, repo LargeScaleRepository, targetPath string) (bool, error) {
+	// Check if we should skip this repository
+	if m.shouldSkipRepository(repo) {
+		m.updateStats(0, 0, 1)
+		return true, nil
+	}
+
+	// Clone with retry logic
+	var err error
+	for attempt := 0; attempt < m.config.MaxRetries; attempt++ {
+		err = m.cloneRepository(ctx, repo, targetPath)
+		if err == nil {
+			break
+		}
+
+		// Wait before retry with exponential backoff
+		if attempt < m.config.MaxRetries-1 {
+			backoff := time.Duration(attempt+1) * time.Second
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return false, ctx.Err()
+			}
+		}
+	}
+
+	if err != nil {
+		m.updateStats(0, 1, 0)
+		return false, fmt.Errorf("failed to clone %s after %d attempts: %w", repo.Name, m.config.MaxRetries, err)
+	}
+
+	m.updateStats(1, 0, 0)
+
+	return false, nil
+}
+
 // cloneRepository clones a single repository with optimization.
 func (m *LargeScaleManager) cloneRepository(ctx context.Context, repo LargeScaleRepository, targetPath string) error {
 	args := []string{"clone"}
@@ -5,6 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -298,6 +302,88 @@ func TestContextCancellation(t *testing.T) {
 	}
 }
 
+// newPagedServer serves the repositories of an organization in pages of
+// two, linking each page to the next like the GitHub API.
+func newPagedServer(t *testing.T, names []string) *httptest.Server {
+	t.Helper()
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start := (page - 1) * 2
+		end := minInt(start+2, len(names))
+		if end < len(names) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=%d>; rel="next"`, srv.URL, r.URL.Path, page+1))
+		}
+
+		var items []string
+		for _, name := range names[start:end] {
+			items = append(items, fmt.Sprintf(`{"name":%q,"cloneUrl":"https://example.com/%s.git","archived":%t}`, name, name, name == "old"))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestStreamRepositories(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e"}
+	srv := newPagedServer(t, names)
+
+	manager := NewLargeScaleManager(&LargeScaleConfig{BatchSize: 2, MaxConcurrency: 2, MaxRetries: 1, MemoryThreshold: 1 << 40}, nil)
+	manager.apiURL = srv.URL
+	manager.client = srv.Client()
+
+	var streamed []string
+	count, err := manager.StreamRepositories(context.Background(), "org", func(repo LargeScaleRepository) error {
+		streamed = append(streamed, repo.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamRepositories failed: %v", err)
+	}
+
+	if count != len(names) || strings.Join(streamed, ",") != strings.Join(names, ",") {
+		t.Errorf("Expected %v, got %d repositories %v", names, count, streamed)
+	}
+
+	if stats := manager.GetStats(); stats.TotalRepos != len(names) || stats.APICallsUsed != 3 {
+		t.Errorf("Expected %d repos in 3 API calls, got %d in %d", len(names), stats.TotalRepos, stats.APICallsUsed)
+	}
+
+	// An error from the callback stops the listing
+	stop := errors.New("stop")
+	_, err = manager.StreamRepositories(context.Background(), "org", func(LargeScaleRepository) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("Expected callback error, got %v", err)
+	}
+}
+
+func TestCloneOrganization(t *testing.T) {
+	srv := newPagedServer(t, []string{"a", "b", "old", "c", "d"})
+
+	var lastProgress, lastTotal int
+	manager := NewLargeScaleManager(&LargeScaleConfig{BatchSize: 2, MaxConcurrency: 2, MaxRetries: 1, MemoryThreshold: 1 << 40}, func(processed, total int, _ string) {
+		lastProgress, lastTotal = processed, total
+	})
+	manager.apiURL = srv.URL
+	manager.client = srv.Client()
+
+	if err := manager.CloneOrganization(context.Background(), "org", t.TempDir()); err != nil {
+		t.Fatalf("CloneOrganization failed: %v", err)
+	}
+
+	stats := manager.GetStats()
+	if stats.TotalRepos != 5 || stats.SkippedRepos != 1 {
+		t.Errorf("Expected 5 repos with 1 archived skipped, got %d and %d", stats.TotalRepos, stats.SkippedRepos)
+	}
+
+	if lastTotal != 5 || lastProgress > lastTotal {
+		t.Errorf("Unexpected progress %d/%d", lastProgress, lastTotal)
+	}
+}
+
 func TestConfigurationValidation(t *testing.T) {
 	testCases := []struct {
 		config    *LargeScaleConfig
@@ -295,10 +295,12 @@ func (m *OptimizedSyncCloneManager) processBatch(ctx context.Context, targetPath
 		}
 
 		jobs = append(jobs, workerpool.RepositoryJob{
-			Repository: repo.Name,
-			Operation:  operation,
-			Path:       repoPath,
-			Strategy:   strategy,
+			Repository:    repo.Name,
+			Provider:      "github",
+			Operation:     operation,
+			Path:          repoPath,
+			Strategy:      strategy,
+			EstimatedSize: repo.Size * 1024,
 		})
 	}
 
@@ -8,6 +8,9 @@ import (
 	"strconv"
 	"sync"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+	"github.com/gizzahub/gzh-cli/pkg/api"
 )
 
 // RateLimiter handles GitHub API rate limiting with retry logic.
@@ -30,6 +33,32 @@ func NewRateLimiter() *RateLimiter {
 	}
 }
 
+// NewRateLimiterForToken creates a rate limiter that starts from the rate
+// state persisted for token by earlier runs, so it does not assume a full
+// budget the API has not granted.
+func NewRateLimiterForToken(host, token string) *RateLimiter {
+	rl := NewRateLimiter()
+	rates := api.DefaultRates()
+	if rates == nil {
+		return rl
+	}
+	state, ok := rates.Lookup("github", host, api.Fingerprint(token))
+	if !ok || state.Remaining < 0 {
+		return rl
+	}
+	rl.remaining = state.Remaining
+	if state.Limit > 0 {
+		rl.limit = state.Limit
+	}
+	if !state.Reset.IsZero() {
+		rl.resetTime = state.Reset
+	}
+	if wait := time.Until(state.BlockedUntil); wait > 0 {
+		rl.retryAfter = wait
+	}
+	return rl
+}
+
 // Wait blocks until rate limit allows making a request.
 func (rl *RateLimiter) Wait(ctx context.Context) error {
 	rl.mu.Lock()
@@ -91,6 +120,8 @@ func (rl *RateLimiter) Update(resp *http.Response) {
 		}
 	}
 
+	metrics.RecordRateLimit("github", rl.remaining, rl.limit)
+
 	// Check for Retry-After header (used for secondary rate limits)
 	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
 		if seconds, err := strconv.Atoi(retryAfter); err == nil {
@@ -14,6 +14,8 @@ import (
 
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
 )
 
 const (
@@ -47,6 +49,7 @@ type Repository struct {
 	UpdatedAt     string   `json:"updated_at"`
 	Language      string   `json:"language"`
 	Topics        []string `json:"topics"`
+	Size          int64    `json:"size"` // Size in kilobytes
 
 	// Repository settings
 	HasIssues    bool `json:"has_issues"`
@@ -179,12 +182,10 @@ func (e *APIError) Error() string {
 // NewRepoConfigClient creates a new GitHub API client for repository configuration.
 func NewRepoConfigClient(token string) *RepoConfigClient {
 	return &RepoConfigClient{
-		token:   token,
-		baseURL: "https://api.github.com",
-		httpClient: NewHTTPClientAdapterWithClient(&http.Client{
-			Timeout: 30 * time.Second,
-		}),
-		rateLimiter: NewRateLimiter(),
+		token:       token,
+		baseURL:     "https://api.github.com",
+		httpClient:  NewHTTPClientAdapterWithClient(httpclient.NewProviderClient("github", 30*time.Second)),
+		rateLimiter: NewRateLimiterForToken("api.github.com", token),
 	}
 }
 
@@ -196,9 +197,7 @@ func (c *RepoConfigClient) SetLogger(logger *ChangeLogger) {
 // SetTimeout configures the HTTP client timeout.
 func (c *RepoConfigClient) SetTimeout(timeout time.Duration) {
 	// If the underlying client is our adapter, recreate it with the new timeout
-	c.httpClient = NewHTTPClientAdapterWithClient(&http.Client{
-		Timeout: timeout,
-	})
+	c.httpClient = NewHTTPClientAdapterWithClient(httpclient.NewProviderClient("github", timeout))
 }
 
 // makeRequest performs an HTTP request with authentication, rate limiting, and retry logic.
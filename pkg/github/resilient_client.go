@@ -8,6 +8,9 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+	"github.com/gizzahub/gzh-cli/internal/metrics"
 )
 
 // ResilientGitHubClient provides GitHub API operations with network resilience - DISABLED (recovery package removed)
@@ -22,11 +25,9 @@ type ResilientGitHubClient struct {
 // Simple HTTP client implementation to replace deleted recovery package.
 func NewResilientGitHubClient(token string) *ResilientGitHubClient {
 	return &ResilientGitHubClient{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL: "https://api.github.com",
-		token:   token,
+		httpClient: newResilientHTTPClient(30 * time.Second),
+		baseURL:    "https://api.github.com",
+		token:      token,
 	}
 }
 
@@ -38,14 +39,20 @@ func NewResilientGitHubClientWithConfig(token string, timeout time.Duration) *Re
 	}
 
 	return &ResilientGitHubClient{
-		httpClient: &http.Client{
-			Timeout: timeout,
-		},
-		baseURL: "https://api.github.com",
-		token:   token,
+		httpClient: newResilientHTTPClient(timeout),
+		baseURL:    "https://api.github.com",
+		token:      token,
 	}
 }
 
+// newResilientHTTPClient returns the provider client for GitHub with its
+// calls counted in the API metrics.
+func newResilientHTTPClient(timeout time.Duration) *http.Client {
+	client := httpclient.NewProviderClient("github", timeout)
+	client.Transport = metrics.InstrumentTransport("github", client.Transport)
+	return client
+}
+
 // prepareRequest adds authentication and headers to requests.
 func (c *ResilientGitHubClient) prepareRequest(req *http.Request) {
 	if c.token != "" {
@@ -18,6 +18,9 @@ import (
 type ResumableCloneManager struct {
 	stateManager *synclonepkg.StateManager
 	config       BulkOperationsConfig
+	repoSizes    map[string]int64 // repository name -> size in bytes, for disk budgeting
+	hooks        *synclonepkg.HookRunner
+	sparse       *synclonepkg.SparseCheckout
 }
 
 // NewResumableCloneManager creates a new resumable clone manager.
@@ -28,6 +31,18 @@ func NewResumableCloneManager(config BulkOperationsConfig) *ResumableCloneManage
 	}
 }
 
+// SetHooks runs lifecycle hooks around every repository operation. A nil
+// runner disables hooks.
+func (rcm *ResumableCloneManager) SetHooks(hooks *synclonepkg.HookRunner) {
+	rcm.hooks = hooks
+}
+
+// SetSparse limits the working tree of every repository to the paths of a
+// sparse profile. A nil sparse checkout clones repositories in full.
+func (rcm *ResumableCloneManager) SetSparse(sparse *synclonepkg.SparseCheckout) {
+	rcm.sparse = sparse
+}
+
 // RefreshAllResumable performs bulk repository refresh with resumable support.
 func (rcm *ResumableCloneManager) RefreshAllResumable(ctx context.Context, targetPath, org, strategy string, parallel, maxRetries int, resume bool, progressMode string) error {
 	// Initialize or load state
@@ -120,10 +135,12 @@ func (rcm *ResumableCloneManager) RefreshAllResumable(ctx context.Context, targe
 		}
 
 		jobs = append(jobs, workerpool.RepositoryJob{
-			Repository: repo,
-			Operation:  operation,
-			Path:       repoPath,
-			Strategy:   strategy,
+			Repository:    repo,
+			Provider:      "github",
+			Operation:     operation,
+			Path:          repoPath,
+			Strategy:      strategy,
+			EstimatedSize: rcm.repoSizes[repo],
 		})
 	}
 
@@ -134,7 +151,13 @@ func (rcm *ResumableCloneManager) RefreshAllResumable(ctx context.Context, targe
 
 	// Process jobs using the correct pattern
 	processFn := func(ctx context.Context, job workerpool.RepositoryJob) error {
-		return processRepositoryJob(ctx, job, org)
+		hc := synclonepkg.HookContext{Provider: "github", Organization: org, Repository: job.Repository, Path: job.Path, Operation: string(job.Operation)}
+		return rcm.hooks.Wrap(ctx, hc, func(ctx context.Context) error {
+			if err := processRepositoryJob(ctx, job, org, rcm.sparse.CloneOptions()...); err != nil {
+				return err
+			}
+			return rcm.sparse.Apply(ctx, job.Path, job.Repository, job.Operation == workerpool.OperationClone)
+		})
 	}
 
 	// Submit jobs and collect results
@@ -205,10 +228,18 @@ func (rcm *ResumableCloneManager) RefreshAllResumable(ctx context.Context, targe
 	}
 
 	// Save final state
+	state.AddHookResults(rcm.hooks.Results())
 	if err := rcm.stateManager.SaveState(state); err != nil {
 		fmt.Printf("⚠️  Warning: failed to save final state: %v\n", err)
 	}
 
+	reportPath, err := rcm.stateManager.SaveHookReport(state)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+	}
+	synclonepkg.PrintHookSummary(state, reportPath)
+	rcm.sparse.PrintSummary()
+
 	// Skip detailed summary to avoid duplication
 
 	// Clean up state file if completed successfully
@@ -294,11 +325,18 @@ func (rcm *ResumableCloneManager) updateStateWithWarnings(state *synclonepkg.Clo
 // prepareRepositoryList gets all repositories and determines which need processing.
 func (rcm *ResumableCloneManager) prepareRepositoryList(ctx context.Context, org string, state *synclonepkg.CloneState, resume bool) ([]string, []string, error) {
 	// Get all repositories from GitHub
-	allRepos, err := List(ctx, org)
+	repos, err := ListRepos(ctx, org)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to list repositories: %w", err)
 	}
 
+	allRepos := make([]string, len(repos))
+	rcm.repoSizes = make(map[string]int64, len(repos))
+	for i, repo := range repos {
+		allRepos[i] = repo.Name
+		rcm.repoSizes[repo.Name] = repo.Size * 1024
+	}
+
 	if len(allRepos) == 0 {
 		fmt.Printf("No repositories found for organization: %s\n", org)
 		return allRepos, []string{}, nil
@@ -337,10 +375,10 @@ func (rcm *ResumableCloneManager) containsString(slice []string, str string) boo
 }
 
 // processRepositoryJob processes a single repository job for GitHub.
-func processRepositoryJob(ctx context.Context, job workerpool.RepositoryJob, org string) error {
+func processRepositoryJob(ctx context.Context, job workerpool.RepositoryJob, org string, cloneOptions ...string) error {
 	switch job.Operation {
 	case workerpool.OperationClone:
-		return Clone(ctx, job.Path, org, job.Repository)
+		return Clone(ctx, job.Path, org, job.Repository, cloneOptions...)
 
 	case workerpool.OperationPull:
 		return executeGitOperation(ctx, job.Path, "pull")
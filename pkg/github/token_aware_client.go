@@ -9,6 +9,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
 )
 
 // TokenAwareGitHubClient provides GitHub API operations with automatic token expiration handling - DISABLED (recovery package removed)
@@ -53,9 +55,7 @@ func NewTokenAwareGitHubClient(config TokenAwareGitHubClientConfig) (*TokenAware
 	}
 
 	return &TokenAwareGitHubClient{
-		httpClient: &http.Client{
-			Timeout: timeout,
-		},
+		httpClient:     httpclient.NewProviderClient("github", timeout),
 		baseURL:        config.BaseURL,
 		primaryToken:   config.PrimaryToken,
 		fallbackTokens: config.FallbackTokens,
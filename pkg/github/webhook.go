@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"slices"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
 )
 
 // WebhookInfo represents a GitHub webhook configuration.
@@ -175,7 +177,7 @@ type webhookServiceImpl struct {
 func NewWebhookService(apiClient APIClient, logger Logger) WebhookService {
 	return &webhookServiceImpl{
 		apiClient:  apiClient,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: httpclient.NewProviderClient("github", 30*time.Second),
 		baseURL:    "https://api.github.com",
 		logger:     logger,
 	}
@@ -185,7 +187,7 @@ func NewWebhookService(apiClient APIClient, logger Logger) WebhookService {
 func NewWebhookServiceWithToken(apiClient APIClient, token string, logger Logger) WebhookService {
 	return &webhookServiceImpl{
 		apiClient:  apiClient,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: httpclient.NewProviderClient("github", 30*time.Second),
 		baseURL:    "https://api.github.com",
 		token:      token,
 		logger:     logger,
@@ -70,6 +70,7 @@ func RefreshAllWithWorkerPool(ctx context.Context, targetPath, group, strategy s
 
 		jobs = append(jobs, workerpool.RepositoryJob{
 			Repository: repo,
+			Provider:   "gitlab",
 			Operation:  operation,
 			Path:       repoPath,
 			Strategy:   strategy,
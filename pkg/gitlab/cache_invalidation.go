@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	pkgcache "github.com/gizzahub/gzh-cli/pkg/cache"
+)
+
+// hookPayload is the subset of a GitLab system hook payload used for cache invalidation.
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type hookPayload struct {
+	EventName            string `json:"event_name"`
+	ProjectID            int    `json:"project_id"`
+	PathWithNamespace    string `json:"path_with_namespace"`
+	OldPathWithNamespace string `json:"old_path_with_namespace"`
+	GroupID              int    `json:"group_id"`
+	FullPath             string `json:"full_path"`
+	OldFullPath          string `json:"old_full_path"`
+}
+
+// InvalidateFromHook evicts cache entries affected by a GitLab system hook
+// payload, as listed by HookInvalidation. It returns the number of evicted
+// entries.
+func (c *CachedGitLabClient) InvalidateFromHook(ctx context.Context, payload []byte) (int, error) {
+	inv, err := c.HookInvalidation(payload)
+	if err != nil {
+		return 0, err
+	}
+	evicted := 0
+	for _, key := range inv.Keys {
+		evicted += c.evict(ctx, key)
+	}
+	return evicted, nil
+}
+
+// HookInvalidation returns the cache entries affected by a GitLab system
+// hook payload (project_create, project_rename, project_transfer,
+// project_destroy and the group/subgroup equivalents), to be published on
+// an async.Bus. Project listings are invalidated for the owning group and
+// every ancestor group, under both old and new paths. Unrelated events
+// invalidate nothing.
+func (c *CachedGitLabClient) HookInvalidation(payload []byte) (pkgcache.Invalidation, error) {
+	inv := pkgcache.Invalidation{Cache: ProjectCacheName}
+	var hook hookPayload
+	if err := json.Unmarshal(payload, &hook); err != nil {
+		return inv, fmt.Errorf("failed to parse hook payload: %w", err)
+	}
+
+	var groups []string
+	switch {
+	case strings.HasPrefix(hook.EventName, "project_"):
+		for _, p := range []string{hook.PathWithNamespace, hook.OldPathWithNamespace} {
+			if p == "" {
+				continue
+			}
+			inv.Keys = append(inv.Keys, c.projectKey(p))
+			if i := strings.LastIndex(p, "/"); i > 0 {
+				groups = append(groups, ancestorPaths(p[:i])...)
+			}
+		}
+		if hook.ProjectID != 0 {
+			inv.Keys = append(inv.Keys, c.projectKey(strconv.Itoa(hook.ProjectID)))
+		}
+	case strings.HasPrefix(hook.EventName, "group_"), strings.HasPrefix(hook.EventName, "subgroup_"):
+		for _, p := range []string{hook.FullPath, hook.OldFullPath} {
+			if p != "" {
+				groups = append(groups, ancestorPaths(p)...)
+			}
+		}
+		if hook.GroupID != 0 {
+			groups = append(groups, strconv.Itoa(hook.GroupID))
+		}
+	default:
+		return inv, nil
+	}
+
+	for _, g := range groups {
+		inv.Keys = append(inv.Keys, c.projectsKey(g))
+	}
+	return inv, nil
+}
+
+// ancestorPaths returns p and each of its parent namespaces, e.g.
+// "a/b/c" -> ["a/b/c", "a/b", "a"].
+func ancestorPaths(p string) []string {
+	var out []string
+	for p != "" {
+		out = append(out, p)
+		i := strings.LastIndex(p, "/")
+		if i < 0 {
+			break
+		}
+		p = p[:i]
+	}
+	return out
+}
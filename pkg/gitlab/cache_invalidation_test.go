@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pkgcache "github.com/gizzahub/gzh-cli/pkg/cache"
+)
+
+func TestCachedGitLabClient_InvalidateFromHook(t *testing.T) {
+	ctx := context.Background()
+	disk, err := pkgcache.OpenDiskBackend(t.TempDir(), 0)
+	require.NoError(t, err)
+	client := newCachedGitLabClient("", "https://gitlab.example.com", DefaultStreamingConfig(),
+		pkgcache.NewTiered("gitlab-test", time.Hour, pkgcache.Tier{Name: "disk", Backend: disk}))
+	defer client.Close()
+
+	for _, group := range []string{"org", "org/team", "org/legacy", "other"} {
+		require.NoError(t, client.cache.SetJSON(ctx, client.projectsKey(group), []*Project{}))
+	}
+	for _, project := range []string{"org/legacy/api", "74"} {
+		require.NoError(t, client.cache.SetJSON(ctx, client.projectKey(project), &Project{}))
+	}
+
+	evicted, err := client.InvalidateFromHook(ctx, []byte(`{
+		"event_name": "project_transfer",
+		"project_id": 74,
+		"path_with_namespace": "org/team/api",
+		"old_path_with_namespace": "org/legacy/api"
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, 5, evicted)
+
+	projects, err := client.ListGroupProjectsWithCache(ctx, "other")
+	require.NoError(t, err, "untouched listings are still served from the cache")
+	assert.Empty(t, projects)
+
+	evicted, err = client.InvalidateFromHook(ctx, []byte(`{"event_name": "user_create"}`))
+	require.NoError(t, err)
+	assert.Zero(t, evicted)
+}
@@ -3,51 +3,100 @@ package gitlab
 import (
 	"context"
 	"fmt"
-	"sync"
+	"net/url"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/pkg/async"
+	pkgcache "github.com/gizzahub/gzh-cli/pkg/cache"
 )
 
-// CachedGitLabClient wraps GitLab API calls with caching - DISABLED (cache package removed)
-// Simple in-memory cache implementation to replace deleted cache package.
+// DefaultCacheTTL is how long cached project listings are served. GitLab
+// listings are slower to fetch than GitHub's, so they are kept longer.
+const DefaultCacheTTL = 15 * time.Minute
+
+// ProjectCacheName names the cache of CachedGitLabClient, on disk and in
+// cache.Invalidation events.
+const ProjectCacheName = "gitlab"
+
+// OpenProjectCache opens the memory → disk cache in ~/.gzh/cache/gitlab
+// shared by the cached GitLab clients, falling back to memory only when
+// the disk cache is unavailable.
+func OpenProjectCache() *pkgcache.Tiered {
+	cache, err := pkgcache.OpenDefault(ProjectCacheName, DefaultCacheTTL)
+	if err != nil {
+		return pkgcache.NewTiered(ProjectCacheName, DefaultCacheTTL,
+			pkgcache.Tier{Name: "memory", Backend: pkgcache.NewMemoryBackend(0)})
+	}
+	return cache
+}
+
+// CachedGitLabClient wraps GitLab API calls with a persistent cache, so
+// project listings survive restarts and are shared between gz processes
+// until they expire or a system hook evicts them.
 type CachedGitLabClient struct {
-	cache           sync.Map // Simple in-memory cache replacement
+	cache           *pkgcache.Tiered
 	streamingClient *StreamingClient
 	token           string
 	baseURL         string
+	// host scopes cache keys, since the cache is shared by every instance.
+	host string
 }
 
-// NewCachedGitLabClient creates a new cached GitLab client - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// NewCachedGitLabClient creates a cached GitLab client for the instance at
+// baseURL, or the configured instance when baseURL is empty.
 func NewCachedGitLabClient(token, baseURL string) *CachedGitLabClient {
-	streamingConfig := DefaultStreamingConfig()
-	streamingClient := NewStreamingClient(token, baseURL, streamingConfig)
+	return newCachedGitLabClient(token, baseURL, DefaultStreamingConfig(), OpenProjectCache())
+}
+
+func newCachedGitLabClient(token, baseURL string, config StreamingConfig, cache *pkgcache.Tiered) *CachedGitLabClient {
+	if baseURL == "" {
+		baseURL = getWebBaseURL()
+	}
+	streamingClient := NewStreamingClient(token, baseURL, config)
 
 	return &CachedGitLabClient{
-		cache:           sync.Map{},
+		cache:           cache,
 		streamingClient: streamingClient,
 		token:           token,
 		baseURL:         baseURL,
+		host:            cacheHost(baseURL),
+	}
+}
+
+// cacheHost returns the host of baseURL, or baseURL itself when it does
+// not parse.
+func cacheHost(baseURL string) string {
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		return u.Host
 	}
+	return baseURL
+}
+
+func (c *CachedGitLabClient) projectsKey(groupID string) string {
+	return fmt.Sprintf("projects:%s:%s", c.host, groupID)
+}
+
+func (c *CachedGitLabClient) projectKey(projectID string) string {
+	return fmt.Sprintf("project:%s:%s", c.host, projectID)
 }
 
-// ListGroupProjectsWithCache lists group projects with caching support - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// ListGroupProjectsWithCache lists group projects, serving them from the
+// cache while it is fresh.
 func (c *CachedGitLabClient) ListGroupProjectsWithCache(ctx context.Context, groupID string) ([]*Project, error) {
-	// Try to get from simple cache first
-	cacheKey := fmt.Sprintf("projects:%s", groupID)
-	if cached, found := c.cache.Load(cacheKey); found {
-		if projects, ok := cached.([]*Project); ok {
-			return projects, nil
-		}
+	cacheKey := c.projectsKey(groupID)
+
+	var projects []*Project
+	if c.cache.GetJSON(ctx, cacheKey, &projects) {
+		return projects, nil
 	}
 
-	// Cache miss - fetch from streaming API
 	projects, err := c.fetchProjectsFromStream(ctx, groupID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch projects: %w", err)
 	}
 
-	// Store in simple cache (no TTL implementation)
-	c.cache.Store(cacheKey, projects)
+	// 캐시 저장 실패는 다음 호출에서 API를 다시 부를 뿐이다
+	_ = c.cache.SetJSON(ctx, cacheKey, projects)
 
 	return projects, nil
 }
@@ -76,270 +125,138 @@ func (c *CachedGitLabClient) fetchProjectsFromStream(ctx context.Context, groupI
 	return projects, nil
 }
 
-// GetProjectWithCache gets a specific project with caching - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
-func (c *CachedGitLabClient) GetProjectWithCache(_ context.Context, projectID string) (*Project, error) {
-	// Try to get from simple cache first
-	cacheKey := fmt.Sprintf("project:%s", projectID)
-	if cached, found := c.cache.Load(cacheKey); found {
-		if project, ok := cached.(*Project); ok {
-			return project, nil
-		}
+// GetProjectWithCache returns a cached project. Single projects are not
+// fetched from the API.
+func (c *CachedGitLabClient) GetProjectWithCache(ctx context.Context, projectID string) (*Project, error) {
+	var project Project
+	if c.cache.GetJSON(ctx, c.projectKey(projectID), &project) {
+		return &project, nil
 	}
 
-	// Cache miss - would fetch from GitLab API
-	// For now, return error as single project fetch is not implemented
 	return nil, fmt.Errorf("single project fetch not implemented")
 }
 
-// InvalidateGroupCache invalidates all cache entries for a group - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
-func (c *CachedGitLabClient) InvalidateGroupCache(_ context.Context, groupID string) int {
-	count := 0
-
-	cacheKey := fmt.Sprintf("projects:%s", groupID)
-	if _, found := c.cache.LoadAndDelete(cacheKey); found {
-		count++
-	}
+// InvalidateGroupCache evicts the group's project listing and returns the
+// number of evicted entries.
+func (c *CachedGitLabClient) InvalidateGroupCache(ctx context.Context, groupID string) int {
+	return c.evict(ctx, c.projectsKey(groupID))
+}
 
-	return count
+// InvalidateProjectCache evicts a single project and returns the number of
+// evicted entries.
+func (c *CachedGitLabClient) InvalidateProjectCache(ctx context.Context, projectID string) int {
+	return c.evict(ctx, c.projectKey(projectID))
 }
 
-// InvalidateProjectCache invalidates cache entries for a specific project - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
-func (c *CachedGitLabClient) InvalidateProjectCache(_ context.Context, projectID string) int {
-	count := 0
+// SubscribeInvalidations evicts the entries named by the cache.Invalidation
+// events published on bus until the returned function is called.
+func (c *CachedGitLabClient) SubscribeInvalidations(bus *async.Bus) (unsubscribe func()) {
+	return c.cache.SubscribeInvalidations(bus)
+}
 
-	cacheKey := fmt.Sprintf("project:%s", projectID)
-	if _, found := c.cache.LoadAndDelete(cacheKey); found {
-		count++
+func (c *CachedGitLabClient) evict(ctx context.Context, key string) int {
+	if found, _ := c.cache.Evict(ctx, key); found {
+		return 1
 	}
-
-	return count
+	return 0
 }
 
-// GetCacheStats returns GitLab cache statistics - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// GetCacheStats returns the hit and miss counts of the cache.
 func (c *CachedGitLabClient) GetCacheStats() map[string]any {
+	stats := c.cache.Stats()
 	return map[string]any{
-		"type": "simple_sync_map",
-		"note": "cache package removed, using simple sync.Map",
+		"type":      "tiered",
+		"hits":      stats.Hits,
+		"misses":    stats.Misses,
+		"hit_ratio": stats.HitRatio(),
+		"tiers":     stats.Tiers,
 	}
 }
 
-// Close cleans up cached client resources - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// Close releases the streaming client and the cache. Disk entries are kept
+// for later processes.
 func (c *CachedGitLabClient) Close() error {
-	// Close streaming client
 	if err := c.streamingClient.Close(); err != nil {
 		return fmt.Errorf("failed to close streaming client: %w", err)
 	}
 
-	// No cache manager to close - using simple sync.Map
-	return nil
+	return c.cache.Close()
 }
 
-// CachedStreamingClient extends StreamingClient with caching capabilities - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// CachedStreamingClient extends StreamingClient with the project listing
+// cache of CachedGitLabClient.
 type CachedStreamingClient struct {
 	*StreamingClient
-	cache sync.Map // Simple in-memory cache replacement
+	cached *CachedGitLabClient
 }
 
-// NewCachedStreamingClient creates a streaming client with caching - DISABLED (cache package removed)
-// Simple implementation without external cache dependency.
+// NewCachedStreamingClient creates a streaming client with caching.
 func NewCachedStreamingClient(token, baseURL string, config StreamingConfig) *CachedStreamingClient {
-	streamingClient := NewStreamingClient(token, baseURL, config)
+	cached := newCachedGitLabClient(token, baseURL, config, OpenProjectCache())
 
 	return &CachedStreamingClient{
-		StreamingClient: streamingClient,
-		cache:           sync.Map{},
+		StreamingClient: cached.streamingClient,
+		cached:          cached,
 	}
 }
 
-// StreamGroupProjectsWithCache streams projects with intelligent caching.
+// StreamGroupProjectsWithCache streams cached projects when the group's
+// listing is fresh. Otherwise it streams from the API and caches the
+// listing once it completed without errors.
 func (csc *CachedStreamingClient) StreamGroupProjectsWithCache(ctx context.Context, groupID string, config StreamingConfig) (<-chan ProjectStream, error) {
-	resultChan := make(chan ProjectStream, config.BufferSize)
-
-	// Check if we have cached results first
-	cacheKey := fmt.Sprintf("stream_projects:%s", groupID)
-	if cached, found := csc.cache.Load(cacheKey); found {
-		if projects, ok := cached.([]ProjectStream); ok {
-			go func() {
-				defer close(resultChan)
-
-				for _, project := range projects {
-					select {
-					case resultChan <- project:
-					case <-ctx.Done():
-						return
-					}
-				}
-			}()
-
-			return resultChan, nil
-		}
-	}
+	cacheKey := csc.cached.projectsKey(groupID)
 
-	// Cache miss - use streaming API
-	// For now, delegate to regular streaming
-	// In a full implementation, this would cache the results
-	return csc.StreamGroupProjects(ctx, groupID, config)
-	/*
-			// Original cache logic - disabled
-			// Check if we have cached results first
-			// cacheKey := cache.CacheKey{
-			//	Service:    "gitlab",
-			//	Resource:   "projects",
-			//	Identifier: groupID,
-			//	Params: map[string]string{
-					"page_size": fmt.Sprintf("%d", config.PageSize),
-				},
-			}
-
-			go func() {
-				defer close(resultChan)
-
-				// Try cache first
-				if cached, found := csc.cacheManager.Get(ctx, cacheKey); found {
-					if projects, ok := cached.([]*Project); ok {
-						// Stream cached results
-						for _, project := range projects {
-							select {
-							case <-ctx.Done():
-								return
-							case resultChan <- ProjectStream{
-								Project: project,
-								Metadata: StreamMetadata{
-									ProcessedAt: time.Now(),
-									CacheHit:    true,
-								},
-							}:
-							}
-						}
-						return
-					}
-				}
-
-				// Cache miss - stream from API and cache results
-				var allProjects []*Project
-
-				apiChan, err := csc.StreamingClient.StreamGroupProjects(ctx, groupID, config)
-				if err != nil {
-					resultChan <- ProjectStream{
-						Error: fmt.Errorf("failed to start API streaming: %w", err),
-					}
+	var projects []*Project
+	if csc.cached.cache.GetJSON(ctx, cacheKey, &projects) {
+		resultChan := make(chan ProjectStream, config.BufferSize)
+		go func() {
+			defer close(resultChan)
+
+			for _, project := range projects {
+				select {
+				case resultChan <- ProjectStream{Project: project, Metadata: StreamMetadata{ProcessedAt: time.Now(), CacheHit: true}}:
+				case <-ctx.Done():
 					return
 				}
+			}
+		}()
 
-				for projectStream := range apiChan {
-					// Forward to result channel
-					resultChan <- projectStream
-
-					// Collect for caching
-					if projectStream.Error == nil && projectStream.Project != nil {
-						allProjects = append(allProjects, projectStream.Project)
-					}
-				}
-
-				// Cache the complete result
-				if len(allProjects) > 0 {
-					csc.cacheManager.PutWithTTL(ctx, cacheKey, allProjects, 15*time.Minute)
-				}
-			}()
-
-			return resultChan, nil
-		}
-
-		// CacheConfiguration provides cache configuration for GitLab operations
-		type CacheConfiguration struct {
-			EnableLocalCache bool
-			EnableRedisCache bool
-			LocalCacheSize   int
-			DefaultTTL       time.Duration
-			RedisAddress     string
-			RedisPassword    string
-			StreamingConfig  StreamingConfig
-		}
+		return resultChan, nil
+	}
 
-		// DefaultGitLabCacheConfiguration returns sensible defaults for GitLab caching
-		func DefaultGitLabCacheConfiguration() CacheConfiguration {
-			return CacheConfiguration{
-				EnableLocalCache: true,
-				EnableRedisCache: false, // Disabled by default
-				LocalCacheSize:   1000,
-				DefaultTTL:       15 * time.Minute, // Longer TTL for GitLab
-				RedisAddress:     "localhost:6379",
-				RedisPassword:    "",
-				StreamingConfig:  DefaultStreamingConfig(),
-			}
-		}
+	apiChan, err := csc.StreamGroupProjects(ctx, groupID, config)
+	if err != nil {
+		return nil, err
+	}
 
-		// ToCacheManagerConfig converts to cache manager configuration - DISABLED (cache package removed)
-		// Simple configuration conversion without external cache dependency
-		func (cc CacheConfiguration) ToCacheManagerConfig() map[string]interface{} {
-			return map[string]interface{}{
-				"enable_local_cache": cc.EnableLocalCache,
-				"local_cache_size":   cc.LocalCacheSize,
-				"default_ttl":        cc.DefaultTTL,
-				"note":               "cache package removed, using simple sync.Map",
+	resultChan := make(chan ProjectStream, config.BufferSize)
+	go func() {
+		defer close(resultChan)
+
+		complete := true
+		for projectStream := range apiChan {
+			if projectStream.Error != nil {
+				complete = false
+			} else if projectStream.Project != nil {
+				projects = append(projects, projectStream.Project)
 			}
-		}
 
-		// RefreshAllOptimizedStreamingWithCache performs GitLab refresh with caching - DISABLED (cache package removed)
-		// Simple implementation without external cache dependency
-		func RefreshAllOptimizedStreamingWithCache(ctx context.Context, targetPath, groupID, strategy, token, baseURL string, cacheConfig CacheConfiguration) error {
-			// Create cached client with simple cache
-			cachedClient := NewCachedGitLabClient(token, baseURL)
-			defer cachedClient.Close()
-
-			// Fetch projects with caching
-			projects, err := cachedClient.ListGroupProjectsWithCache(ctx, groupID)
-			if err != nil {
-				return fmt.Errorf("failed to list group projects with cache: %w", err)
+			select {
+			case resultChan <- projectStream:
+			case <-ctx.Done():
+				return
 			}
-
-			fmt.Printf("🚀 Starting cached GitLab bulk clone for group: %s\n", groupID)
-			fmt.Printf("📦 Found %d projects (using cache)\n", len(projects))
-
-			// Process projects (this would integrate with existing GitLab bulk clone logic)
-			// For now, just report success
-			cacheStats := cachedClient.GetCacheStats()
-			fmt.Printf("📊 Cache performance: %.1f%% hit rate (local: %d hits, %d misses)\n",
-				cacheStats.Local.HitRate*100, cacheStats.Local.Hits, cacheStats.Local.Misses)
-
-			fmt.Printf("✅ GitLab cached bulk clone completed successfully\n")
-
-			return nil
 		}
 
-		// CacheInvalidationStrategy defines cache invalidation behaviors
-		type CacheInvalidationStrategy struct {
-			InvalidateOnError    bool
-			InvalidateAfterClone bool
-			InvalidateByTags     []string
+		if complete && ctx.Err() == nil {
+			_ = csc.cached.cache.SetJSON(ctx, cacheKey, projects)
 		}
+	}()
 
-		// DefaultCacheInvalidationStrategy returns default invalidation strategy
-		func DefaultCacheInvalidationStrategy() CacheInvalidationStrategy {
-			return CacheInvalidationStrategy{
-				InvalidateOnError:    false, // Don't invalidate on API errors
-				InvalidateAfterClone: false, // Don't invalidate after successful clone
-				InvalidateByTags:     []string{},
-			}
-		}
-
-		// ApplyCacheInvalidation applies the invalidation strategy
-		func (csc *CachedStreamingClient) ApplyCacheInvalidation(ctx context.Context, groupID string, strategy CacheInvalidationStrategy) {
-			if len(strategy.InvalidateByTags) > 0 {
-				for _, tag := range strategy.InvalidateByTags {
-					csc.cacheManager.InvalidateByService(ctx, tag)
-				}
-			}
+	return resultChan, nil
+}
 
-			if strategy.InvalidateAfterClone {
-				csc.cacheManager.InvalidateByIdentifier(ctx, "gitlab", groupID)
-			}
-		}
-	*/
+// Close releases the streaming client and the cache.
+func (csc *CachedStreamingClient) Close() error {
+	return csc.cached.Close()
 }
@@ -209,10 +209,11 @@ func List(ctx context.Context, group string) ([]string, error) {
 //   - group: GitLab group or user name
 //   - repo: Project name
 //   - branch: Specific branch to clone (if empty, uses default branch)
+//   - options: Extra git clone options, such as those of a sparse checkout
 //
 // Returns an error if the clone operation fails due to network issues,
 // authentication problems, or local file system errors.
-func Clone(ctx context.Context, targetPath string, group string, repo string, branch string) error {
+func Clone(ctx context.Context, targetPath string, group string, repo string, branch string, options ...string) error {
 	if branch == "" {
 		defaultBranch, err := GetDefaultBranch(ctx, group, repo)
 		if err != nil {
@@ -250,7 +251,8 @@ func Clone(ctx context.Context, targetPath string, group string, repo string, br
 	if os.Getenv("GZH_VERBOSE") == "1" {
 		fmt.Printf("git -C %s clone %s .\n", targetPath, cloneURL)
 	}
-	if err := executor.ExecuteSecure(ctx, targetPath, "clone", cloneURL, "."); err != nil {
+	args := append(append([]string{"clone"}, options...), cloneURL, ".")
+	if err := executor.ExecuteSecure(ctx, targetPath, args...); err != nil {
 		return fmt.Errorf("clone failed (url: %s, branch: %s, targetPath: %s, err: %w)", cloneURL, branch, targetPath, err)
 	}
 
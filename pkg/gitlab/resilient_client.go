@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
 )
 
 // ResilientGitLabClient provides GitLab API operations with network resilience - DISABLED (recovery package removed)
@@ -36,11 +38,9 @@ func NewResilientGitLabClientWithConfig(baseURL, token string, timeout time.Dura
 	}
 
 	return &ResilientGitLabClient{
-		httpClient: NewHTTPClientAdapterWithClient(&http.Client{
-			Timeout: timeout,
-		}),
-		baseURL: strings.TrimSuffix(baseURL, "/"),
-		token:   token,
+		httpClient: NewHTTPClientAdapterWithClient(httpclient.NewProviderClient("gitlab", timeout)),
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
 	}
 }
 
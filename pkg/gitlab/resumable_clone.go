@@ -17,6 +17,8 @@ import (
 type ResumableCloneManager struct {
 	stateManager *synclonepkg.StateManager
 	config       workerpool.RepositoryPoolConfig
+	hooks        *synclonepkg.HookRunner
+	sparse       *synclonepkg.SparseCheckout
 }
 
 // NewResumableCloneManager creates a new resumable clone manager for GitLab.
@@ -27,6 +29,18 @@ func NewResumableCloneManager(config workerpool.RepositoryPoolConfig) *Resumable
 	}
 }
 
+// SetHooks runs lifecycle hooks around every repository operation. A nil
+// runner disables hooks.
+func (rcm *ResumableCloneManager) SetHooks(hooks *synclonepkg.HookRunner) {
+	rcm.hooks = hooks
+}
+
+// SetSparse limits the working tree of every repository to the paths of a
+// sparse profile. A nil sparse checkout clones repositories in full.
+func (rcm *ResumableCloneManager) SetSparse(sparse *synclonepkg.SparseCheckout) {
+	rcm.sparse = sparse
+}
+
 // RefreshAllResumable performs bulk repository refresh with resumable support for GitLab.
 func (rcm *ResumableCloneManager) RefreshAllResumable(ctx context.Context, targetPath, group, strategy string, parallel, maxRetries int, resume bool, progressMode string) error {
 	// Initialize or load state
@@ -64,7 +78,7 @@ func (rcm *ResumableCloneManager) processRepositoryJob(ctx context.Context, job
 		if err := os.MkdirAll(job.Path, 0o755); err != nil {
 			return fmt.Errorf("failed to create repo directory %s: %w", job.Path, err)
 		}
-		return Clone(ctx, job.Path, group, job.Repository, "")
+		return Clone(ctx, job.Path, group, job.Repository, "", rcm.sparse.CloneOptions()...)
 
 	case workerpool.OperationPull:
 		return rcm.executeGitOperation(ctx, job.Path, "pull")
@@ -348,6 +362,7 @@ func (rcm *ResumableCloneManager) createRepositoryJobs(reposToProcess []string,
 
 		jobs = append(jobs, workerpool.RepositoryJob{
 			Repository: repo,
+			Provider:   "gitlab",
 			Operation:  operation,
 			Path:       repoPath,
 			Strategy:   strategy,
@@ -392,7 +407,13 @@ func isGitRepository(path string) bool {
 // submitJobs submits all jobs to the worker pool.
 func (rcm *ResumableCloneManager) submitJobs(pool *workerpool.RepositoryWorkerPool, jobs []workerpool.RepositoryJob, group string) error {
 	processFn := func(ctx context.Context, job workerpool.RepositoryJob) error {
-		return rcm.processRepositoryJob(ctx, job, group)
+		hc := synclonepkg.HookContext{Provider: "gitlab", Organization: group, Repository: job.Repository, Path: job.Path, Operation: string(job.Operation)}
+		return rcm.hooks.Wrap(ctx, hc, func(ctx context.Context) error {
+			if err := rcm.processRepositoryJob(ctx, job, group); err != nil {
+				return err
+			}
+			return rcm.sparse.Apply(ctx, job.Path, job.Repository, job.Operation == workerpool.OperationClone)
+		})
 	}
 
 	for _, job := range jobs {
@@ -465,12 +486,20 @@ func (rcm *ResumableCloneManager) finalizeOperation(state *synclonepkg.CloneStat
 	}
 
 	// Save final state
+	state.AddHookResults(rcm.hooks.Results())
 	if err := rcm.stateManager.SaveState(state); err != nil {
 		fmt.Printf("⚠️  Warning: failed to save final state: %v\n", err)
 	}
 
+	reportPath, err := rcm.stateManager.SaveHookReport(state)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: %v\n", err)
+	}
+
 	// Show final summary
 	fmt.Printf("\n%s\n", progressTracker.GetSummary())
+	synclonepkg.PrintHookSummary(state, reportPath)
+	rcm.sparse.PrintSummary()
 
 	// Clean up state file if completed successfully
 	if state.Status == "completed" && failureCount == 0 {
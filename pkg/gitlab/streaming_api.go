@@ -3,7 +3,6 @@ package gitlab
 import (
 	"bufio"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/gizzahub/gzh-cli/internal/constants"
 	"github.com/gizzahub/gzh-cli/internal/httpclient"
+	"github.com/gizzahub/gzh-cli/internal/jsonstream"
 )
 
 // StreamingClient provides streaming API access for GitLab large-scale operations.
@@ -205,28 +205,29 @@ func (sc *StreamingClient) StreamGroupProjects(ctx context.Context, groupID stri
 				return
 			}
 
-			// Fetch page with streaming
-			projects, newPagination, err := sc.fetchProjectPage(ctx, groupID, pagination)
-			if err != nil {
-				sc.sendError(resultChan, fmt.Errorf("failed to fetch page %d: %w", pagination.Page, err))
-				return
-			}
-
-			// Stream projects to channel
-			for _, project := range projects {
+			// Projects are sent as they are decoded from the response
+			newPagination, err := sc.fetchProjectPage(ctx, groupID, pagination, func(project *Project, pageInfo CursorPagination) error {
 				select {
 				case <-ctx.Done():
-					return
+					return ctx.Err()
 				case resultChan <- ProjectStream{
 					Project: project,
 					Metadata: StreamMetadata{
 						Page:        pagination.Page,
-						TotalPages:  newPagination.TotalPages,
+						TotalPages:  pageInfo.TotalPages,
 						ProcessedAt: time.Now(),
 						MemoryUsage: sc.getCurrentMemoryUsage(),
 					},
 				}:
+					return nil
 				}
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				sc.sendError(resultChan, fmt.Errorf("failed to fetch page %d: %w", pagination.Page, err))
+				return
 			}
 
 			// Check if we have more pages
@@ -248,14 +249,16 @@ func (sc *StreamingClient) StreamGroupProjects(ctx context.Context, groupID stri
 	return resultChan, nil
 }
 
-// fetchProjectPage fetches a single page of projects for a group.
-func (sc *StreamingClient) fetchProjectPage(ctx context.Context, groupID string, pagination CursorPagination) ([]*Project, CursorPagination, error) {
+// fetchProjectPage fetches a single page of projects for a group and
+// passes each project to fn as soon as it is decoded, together with the
+// pagination of the page, so a page is never held in memory as a whole.
+func (sc *StreamingClient) fetchProjectPage(ctx context.Context, groupID string, pagination CursorPagination, fn func(*Project, CursorPagination) error) (CursorPagination, error) {
 	endpoint := fmt.Sprintf("groups/%s/projects?per_page=%d&page=%d", url.PathEscape(groupID), pagination.PerPage, pagination.Page)
 	reqURL := buildAPIURL(endpoint)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
-		return nil, pagination, fmt.Errorf("failed to create request: %w", err)
+		return pagination, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	if sc.token != "" {
@@ -264,54 +267,39 @@ func (sc *StreamingClient) fetchProjectPage(ctx context.Context, groupID string,
 
 	resp, err := sc.httpClient.Do(req)
 	if err != nil {
-		return nil, pagination, fmt.Errorf("request failed: %w", err)
+		return pagination, fmt.Errorf("request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, pagination, fmt.Errorf("unexpected status: %s", resp.Status)
+		return pagination, fmt.Errorf("unexpected status: %s", resp.Status)
 	}
 
-	var projects []*Project
-	dec := json.NewDecoder(bufio.NewReader(resp.Body))
-	if err := dec.Decode(&projects); err != nil {
-		return nil, pagination, fmt.Errorf("decode failed: %w", err)
+	// 페이지 정보는 헤더에 있으므로 본문을 읽기 전에 확정된다
+	newPagination := sc.parsePaginationHeaders(resp.Header, pagination)
+	if err := sc.parseProjectResponse(resp.Body, func(project *Project) error {
+		return fn(project, newPagination)
+	}); err != nil {
+		return pagination, err
 	}
 
-	newPagination := sc.parsePaginationHeaders(resp.Header, pagination)
-	return projects, newPagination, nil
+	return newPagination, nil
 }
 
-// parseProjectResponse parses JSON response with streaming to minimize memory usage.
-func (sc *StreamingClient) parseProjectResponse(reader io.Reader) ([]*Project, error) {
+// parseProjectResponse decodes a JSON array of projects one element at a
+// time, so memory use is bounded by the largest project rather than the
+// response.
+func (sc *StreamingClient) parseProjectResponse(reader io.Reader, fn func(*Project) error) error {
 	// Use buffered reader for efficient streaming
 	bufReader := bufio.NewReaderSize(reader, 64*1024)
 
-	var rawProjects []json.RawMessage
-
-	decoder := json.NewDecoder(bufReader)
-
-	if err := decoder.Decode(&rawProjects); err != nil {
-		return nil, fmt.Errorf("failed to decode project array: %w", err)
-	}
-
-	projects := make([]*Project, 0, len(rawProjects))
-	for _, rawProject := range rawProjects {
-		projectInterface := sc.memoryPool.projectPool.Get()
-		project, ok := projectInterface.(*Project)
-		if !ok {
-			// Skip this entry if type assertion fails
-			continue
-		}
-		if err := json.Unmarshal(rawProject, project); err != nil {
-			sc.memoryPool.projectPool.Put(project)
-			continue // Skip malformed entries
-		}
-
-		projects = append(projects, project)
+	if _, err := jsonstream.DecodeArray(bufReader, func(project Project) error {
+		return fn(&project)
+	}); err != nil {
+		return fmt.Errorf("decode failed: %w", err)
 	}
 
-	return projects, nil
+	return nil
 }
 
 // buildProjectURL constructs the GitLab API URL with pagination.
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamGroupProjects(t *testing.T) {
+	names := []string{"api", "web", "docs", "worker", "infra"}
+	const perPage = 2
+	totalPages := (len(names) + perPage - 1) / perPage
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/groups/broken/projects") {
+			fmt.Fprint(w, `[{"id": 1, "name": "ok"}, {"id": "x"`)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start := (page - 1) * perPage
+		end := min(start+perPage, len(names))
+		w.Header().Set("X-Total-Pages", strconv.Itoa(totalPages))
+		if page < totalPages {
+			w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		}
+
+		var items []string
+		for i, name := range names[start:end] {
+			items = append(items, fmt.Sprintf(`{"id": %d, "name": %q, "path_with_namespace": "org/%s"}`, start+i+1, name, name))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+	}))
+	t.Cleanup(server.Close)
+	SetBaseURL(server.URL)
+	t.Cleanup(func() { configuredBaseAPIURL = "" })
+
+	config := DefaultStreamingConfig()
+	config.PageSize = perPage
+	client := NewStreamingClient("", server.URL, config)
+
+	results, err := client.StreamGroupProjects(context.Background(), "org", config)
+	require.NoError(t, err)
+
+	var streamed []string
+	for r := range results {
+		require.NoError(t, r.Error)
+		streamed = append(streamed, r.Project.PathWithNamespace)
+		assert.Equal(t, totalPages, r.Metadata.TotalPages)
+	}
+	assert.Equal(t, []string{"org/api", "org/web", "org/docs", "org/worker", "org/infra"}, streamed)
+
+	// Projects decoded before a malformed element are still delivered
+	results, err = client.StreamGroupProjects(context.Background(), "broken", config)
+	require.NoError(t, err)
+
+	var got []ProjectStream
+	for r := range results {
+		got = append(got, r)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, "ok", got[0].Project.Name)
+	assert.ErrorContains(t, got[1].Error, "decode failed")
+}
@@ -0,0 +1,451 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+// Built-in path templates for laying out a group tree on disk.
+const (
+	// LayoutNested mirrors the GitLab namespace hierarchy, e.g. org/team/backend/api.
+	LayoutNested = "{group}/{subgroup}/{project}"
+	// LayoutFlat places every project directly under the target directory.
+	LayoutFlat = "{project}"
+	// LayoutFlatPrefixed flattens the tree but keeps subgroups in the directory
+	// name to avoid collisions, e.g. team-backend-api.
+	LayoutFlatPrefixed = "{subgroup_flat}-{project}"
+)
+
+// ErrPathCollision is returned when a path template maps two projects to the same directory.
+var ErrPathCollision = errors.New("path template maps multiple projects to the same directory")
+
+// TreeProject is a project discovered while walking a group tree.
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type TreeProject struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	Path              string `json:"path"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	DefaultBranch     string `json:"default_branch"`
+	Archived          bool   `json:"archived"`
+
+	// Root is the full path of the walked root group.
+	Root string `json:"-"`
+	// Namespace is the full path of the group that owns the project.
+	Namespace string `json:"-"`
+	// Subgroup is Namespace relative to the walked root group ("" for the root itself).
+	Subgroup string `json:"-"`
+}
+
+// treeGroup is the subset of the GitLab group payload used while walking.
+// nolint:tagliatelle // External API format - must match GitLab JSON output
+type treeGroup struct {
+	ID       int    `json:"id"`
+	FullPath string `json:"full_path"`
+}
+
+// SubgroupFilter applies project include/exclude patterns to the subgroups
+// matching Subgroup. Patterns use path.Match syntax; Subgroup is matched
+// against the path relative to the root group, and "" selects the root group.
+type SubgroupFilter struct {
+	Subgroup string
+	Include  []string
+	Exclude  []string
+	// Skip excludes the matching subgroups, and everything below them, entirely.
+	Skip bool
+}
+
+// TreeOptions controls how a group tree is walked and laid out.
+type TreeOptions struct {
+	// PathTemplate is the local directory layout. Defaults to LayoutNested.
+	PathTemplate string
+	// MaxDepth limits subgroup recursion; 0 means unlimited.
+	MaxDepth int
+	// PerPage is the API page size. Defaults to 100, the GitLab maximum.
+	PerPage int
+	// IncludeArchived includes archived projects.
+	IncludeArchived bool
+	// Filters are evaluated in order; every matching filter applies.
+	Filters []SubgroupFilter
+}
+
+// TreeStats summarizes a completed walk.
+type TreeStats struct {
+	Groups   int
+	Projects int
+	Filtered int
+	Cycles   int
+}
+
+// ParseSubgroupFilter parses a "SUBGROUP=PATTERN" expression as used by the
+// --include/--exclude flags. Without "=", the pattern applies to every group.
+func ParseSubgroupFilter(expr string, exclude bool) (SubgroupFilter, error) {
+	subgroup, pattern := "*", expr
+	if i := strings.LastIndex(expr, "="); i >= 0 {
+		subgroup, pattern = expr[:i], expr[i+1:]
+	}
+	if pattern == "" {
+		return SubgroupFilter{}, fmt.Errorf("empty project pattern in filter %q", expr)
+	}
+	for _, p := range []string{subgroup, pattern} {
+		if _, err := path.Match(p, ""); err != nil {
+			return SubgroupFilter{}, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+	}
+
+	if exclude {
+		return SubgroupFilter{Subgroup: subgroup, Exclude: []string{pattern}}, nil
+	}
+	return SubgroupFilter{Subgroup: subgroup, Include: []string{pattern}}, nil
+}
+
+// matchesSubgroup reports whether the filter targets subgroup. "*" matches
+// every group including nested ones, since path.Match does not cross "/".
+func (f SubgroupFilter) matchesSubgroup(subgroup string) bool {
+	if f.Subgroup == "*" {
+		return true
+	}
+	ok, _ := path.Match(f.Subgroup, subgroup)
+	return ok
+}
+
+func (o TreeOptions) skipSubgroup(subgroup string) bool {
+	for _, f := range o.Filters {
+		if f.Skip && f.matchesSubgroup(subgroup) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o TreeOptions) allowProject(p *TreeProject) bool {
+	if p.Archived && !o.IncludeArchived {
+		return false
+	}
+
+	included, hasInclude := false, false
+	for _, f := range o.Filters {
+		if !f.matchesSubgroup(p.Subgroup) {
+			continue
+		}
+		for _, pattern := range f.Exclude {
+			if ok, _ := path.Match(pattern, p.Path); ok {
+				return false
+			}
+		}
+		for _, pattern := range f.Include {
+			hasInclude = true
+			if ok, _ := path.Match(pattern, p.Path); ok {
+				included = true
+			}
+		}
+	}
+
+	return !hasInclude || included
+}
+
+// RenderPathTemplate expands a path template for a project. Supported
+// placeholders are {group} (last component of the root group path),
+// {subgroup} (path relative to the root, may contain "/"), {subgroup_flat}
+// ({subgroup} joined with "-"), {namespace} (full owning group path) and
+// {project}. Empty segments are dropped, so LayoutNested places root-level
+// projects at {group}/{project}.
+func RenderPathTemplate(template string, p *TreeProject) (string, error) {
+	if template == "" {
+		template = LayoutNested
+	}
+
+	replacer := strings.NewReplacer(
+		"{group}", rootGroupName(p.Root),
+		"{subgroup}", p.Subgroup,
+		"{subgroup_flat}", strings.ReplaceAll(p.Subgroup, "/", "-"),
+		"{namespace}", p.Namespace,
+		"{project}", p.Path,
+	)
+	rendered := replacer.Replace(template)
+
+	var segments []string
+	for _, segment := range strings.Split(rendered, "/") {
+		// Trim separators left over from empty placeholders, e.g. "{subgroup_flat}-".
+		segment = strings.Trim(segment, "-_ ")
+		switch segment {
+		case "", ".":
+			continue
+		case "..":
+			return "", fmt.Errorf("path template %q escapes the target directory", template)
+		}
+		segments = append(segments, segment)
+	}
+	if len(segments) == 0 {
+		return "", fmt.Errorf("path template %q rendered an empty path for %s", template, p.PathWithNamespace)
+	}
+
+	return filepath.Join(segments...), nil
+}
+
+// WalkGroupTree walks group and its subgroups depth-first, calling fn for
+// every project that passes the filters. Projects are fetched one page at a
+// time and handed to fn before the next page is requested, so groups with
+// thousands of projects never need to be held in memory. Groups reached more
+// than once (e.g. through a misbehaving proxy or shared links) are visited
+// only once.
+func WalkGroupTree(ctx context.Context, group string, opts TreeOptions, fn func(*TreeProject) error) (TreeStats, error) {
+	w := &treeWalker{opts: opts, fn: fn, visited: make(map[int]bool)}
+	if w.opts.PerPage <= 0 || w.opts.PerPage > 100 {
+		w.opts.PerPage = 100
+	}
+
+	root, err := w.getGroup(ctx, group)
+	if err != nil {
+		return w.stats, err
+	}
+	w.root = root.FullPath
+
+	err = w.walk(ctx, root, 0)
+	return w.stats, err
+}
+
+type treeWalker struct {
+	opts    TreeOptions
+	fn      func(*TreeProject) error
+	root    string
+	visited map[int]bool
+	stats   TreeStats
+}
+
+func (w *treeWalker) walk(ctx context.Context, g treeGroup, depth int) error {
+	if w.visited[g.ID] {
+		w.stats.Cycles++
+		return nil
+	}
+	w.visited[g.ID] = true
+
+	subgroup := relativeSubgroup(w.root, g.FullPath)
+	if depth > 0 && w.opts.skipSubgroup(subgroup) {
+		return nil
+	}
+	w.stats.Groups++
+
+	projectsEndpoint := fmt.Sprintf("groups/%d/projects", g.ID)
+	err := paginate(ctx, projectsEndpoint, w.opts.PerPage, func(data []byte) error {
+		var projects []*TreeProject
+		if err := json.Unmarshal(data, &projects); err != nil {
+			return fmt.Errorf("%w: %w", ErrFailedToGetRepositories, err)
+		}
+		for _, p := range projects {
+			p.Root = w.root
+			p.Namespace = g.FullPath
+			p.Subgroup = subgroup
+			if !w.opts.allowProject(p) {
+				w.stats.Filtered++
+				continue
+			}
+			w.stats.Projects++
+			if err := w.fn(p); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if w.opts.MaxDepth > 0 && depth >= w.opts.MaxDepth {
+		return nil
+	}
+
+	// Subgroups are collected per page before recursing so that only one
+	// response body is open at a time.
+	var children []treeGroup
+	subgroupsEndpoint := fmt.Sprintf("groups/%d/subgroups", g.ID)
+	err = paginate(ctx, subgroupsEndpoint, w.opts.PerPage, func(data []byte) error {
+		var page []treeGroup
+		if err := json.Unmarshal(data, &page); err != nil {
+			return fmt.Errorf("%w: %w", ErrFailedToGetSubgroups, err)
+		}
+		children = append(children, page...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if err := w.walk(ctx, child, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *treeWalker) getGroup(ctx context.Context, group string) (treeGroup, error) {
+	var g treeGroup
+
+	data, _, err := getAPI(ctx, "groups/"+url.PathEscape(group)+"?with_projects=false")
+	if err != nil {
+		return g, fmt.Errorf("%w: %w", ErrFailedToGetSubgroups, err)
+	}
+	if err := json.Unmarshal(data, &g); err != nil {
+		return g, fmt.Errorf("%w: %w", ErrFailedToGetSubgroups, err)
+	}
+
+	return g, nil
+}
+
+// paginate requests endpoint page by page, following the X-Next-Page header.
+func paginate(ctx context.Context, endpoint string, perPage int, fn func([]byte) error) error {
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+
+	page := "1"
+	for page != "" {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		data, header, err := getAPI(ctx, fmt.Sprintf("%s%sper_page=%d&page=%s", endpoint, sep, perPage, page))
+		if err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+
+		next := header.Get("X-Next-Page")
+		if next == page {
+			break
+		}
+		page = next
+	}
+
+	return nil
+}
+
+func getAPI(ctx context.Context, endpoint string) ([]byte, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, buildAPIURL(endpoint), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	addAuthHeader(req)
+
+	resp, err := httpclient.GetGlobalClient("gitlab").Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		if (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden) && configuredToken == "" {
+			return nil, nil, fmt.Errorf("%s\n%s", resp.Status, accessGuidanceMessage())
+		}
+		return nil, nil, fmt.Errorf("%s: %s", endpoint, resp.Status)
+	}
+
+	var data json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, nil, err
+	}
+
+	return data, resp.Header, nil
+}
+
+func relativeSubgroup(root, fullPath string) string {
+	if fullPath == root {
+		return ""
+	}
+	return strings.TrimPrefix(fullPath, root+"/")
+}
+
+// rootGroupName returns the last path component of the walked root group.
+func rootGroupName(root string) string {
+	if i := strings.LastIndex(root, "/"); i >= 0 {
+		return root[i+1:]
+	}
+	return root
+}
+
+// CloneGroupTree clones or updates every project below group into targetPath,
+// laying out directories according to opts.PathTemplate. Cloning starts while
+// later pages are still being fetched.
+func CloneGroupTree(ctx context.Context, targetPath, group, strategy string, parallel int, opts TreeOptions) (TreeStats, error) {
+	if parallel <= 0 {
+		parallel = 5
+	}
+	template := opts.PathTemplate
+	if template == "" {
+		template = LayoutNested
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	sem := semaphore.NewWeighted(int64(parallel))
+	seen := make(map[string]string)
+
+	stats, err := WalkGroupTree(gCtx, group, opts, func(p *TreeProject) error {
+		rel, err := RenderPathTemplate(template, p)
+		if err != nil {
+			return err
+		}
+		if other, ok := seen[rel]; ok {
+			return fmt.Errorf("%w: %s and %s both map to %s", ErrPathCollision, other, p.PathWithNamespace, rel)
+		}
+		seen[rel] = p.PathWithNamespace
+
+		if err := sem.Acquire(gCtx, 1); err != nil {
+			return err
+		}
+		g.Go(func() error {
+			defer sem.Release(1)
+			syncTreeProject(gCtx, filepath.Join(targetPath, rel), p, strategy)
+			return nil
+		})
+		return nil
+	})
+
+	if waitErr := g.Wait(); err == nil {
+		err = waitErr
+	}
+
+	return stats, err
+}
+
+// syncTreeProject clones a project or refreshes an existing checkout. Failures
+// are reported and do not stop the remaining projects, matching RefreshAll.
+func syncTreeProject(ctx context.Context, repoPath string, p *TreeProject, strategy string) {
+	if isGitRepository(repoPath) {
+		executeGitStrategy(ctx, strategy, repoPath, p.PathWithNamespace)
+		fmt.Printf("Repo sync success with strategy %s: %s\n", strategy, repoPath)
+		return
+	}
+
+	if err := os.MkdirAll(repoPath, 0o755); err != nil {
+		fmt.Printf("failed to prepare directory %s: %v\n", repoPath, err)
+		return
+	}
+	if err := Clone(ctx, repoPath, p.Namespace, p.Path, p.DefaultBranch); err != nil {
+		fmt.Printf("failed to clone repository %s: %v\n", repoPath, err)
+		return
+	}
+	fmt.Printf("Cloned %s -> %s\n", p.PathWithNamespace, repoPath)
+}
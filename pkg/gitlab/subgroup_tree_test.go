@@ -0,0 +1,180 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGroupTree serves a GitLab-like API for a fixed group hierarchy. Each
+// group's project list is split into pages of perPage entries.
+type fakeGroupTree struct {
+	groups    map[int]string   // id -> full path
+	children  map[int][]int    // id -> subgroup ids
+	projects  map[int][]string // id -> project paths
+	perPage   int
+	pageCalls int
+}
+
+func (f *fakeGroupTree) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var id int
+	var kind string
+
+	switch {
+	case r.URL.Path == "/api/v4/groups/org":
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1, "full_path": "org"})
+		return
+	case sscanf(r.URL.Path, "/api/v4/groups/%d/projects", &id):
+		kind = "projects"
+	case sscanf(r.URL.Path, "/api/v4/groups/%d/subgroups", &id):
+		kind = "subgroups"
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	var items []map[string]any
+	if kind == "projects" {
+		f.pageCalls++
+		for _, p := range f.projects[id] {
+			items = append(items, map[string]any{
+				"id": len(items) + 1, "name": p, "path": p,
+				"path_with_namespace": f.groups[id] + "/" + p,
+			})
+		}
+	} else {
+		for _, c := range f.children[id] {
+			items = append(items, map[string]any{"id": c, "full_path": f.groups[c]})
+		}
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	perPage := f.perPage
+	start, end := (page-1)*perPage, page*perPage
+	if end >= len(items) {
+		end = len(items)
+	} else {
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+	if start > end {
+		start = end
+	}
+	if items == nil {
+		items = []map[string]any{}
+	}
+	_ = json.NewEncoder(w).Encode(items[start:end])
+}
+
+func sscanf(s, format string, id *int) bool {
+	n, err := fmt.Sscanf(s, format, id)
+	return err == nil && n == 1 && fmt.Sprintf(format, *id) == s
+}
+
+func newFakeGroupTree(t *testing.T) *fakeGroupTree {
+	t.Helper()
+
+	tree := &fakeGroupTree{
+		groups:   map[int]string{1: "org", 2: "org/team", 3: "org/team/backend", 4: "org/legacy"},
+		children: map[int][]int{1: {2, 4}, 2: {3}, 3: {2}}, // 3 -> 2 forms a cycle
+		projects: map[int][]string{
+			1: {"handbook"},
+			2: {"web", "web-e2e", "docs"},
+			3: {"api", "worker"},
+			4: {"old"},
+		},
+		perPage: 2,
+	}
+
+	server := httptest.NewServer(tree)
+	t.Cleanup(server.Close)
+	SetBaseURL(server.URL)
+	t.Cleanup(func() { configuredBaseAPIURL = "" })
+
+	return tree
+}
+
+func walkPaths(t *testing.T, opts TreeOptions) ([]string, TreeStats) {
+	t.Helper()
+
+	var paths []string
+	stats, err := WalkGroupTree(context.Background(), "org", opts, func(p *TreeProject) error {
+		rel, err := RenderPathTemplate(opts.PathTemplate, p)
+		require.NoError(t, err)
+		paths = append(paths, filepath.ToSlash(rel))
+		return nil
+	})
+	require.NoError(t, err)
+
+	return paths, stats
+}
+
+func TestWalkGroupTree_NestedLayoutAndCycle(t *testing.T) {
+	tree := newFakeGroupTree(t)
+
+	paths, stats := walkPaths(t, TreeOptions{PerPage: 2})
+
+	assert.Equal(t, []string{
+		"org/handbook",
+		"org/team/web", "org/team/web-e2e", "org/team/docs",
+		"org/team/backend/api", "org/team/backend/worker",
+		"org/legacy/old",
+	}, paths)
+	assert.Equal(t, 4, stats.Groups)
+	assert.Equal(t, 1, stats.Cycles)
+	assert.Equal(t, 5, tree.pageCalls, "team has two pages of projects")
+}
+
+func TestWalkGroupTree_FlatLayoutAndFilters(t *testing.T) {
+	newFakeGroupTree(t)
+
+	include, err := ParseSubgroupFilter("team=web*", false)
+	require.NoError(t, err)
+	exclude, err := ParseSubgroupFilter("*-e2e", true)
+	require.NoError(t, err)
+
+	paths, stats := walkPaths(t, TreeOptions{
+		PathTemplate: LayoutFlatPrefixed,
+		Filters: []SubgroupFilter{
+			include, exclude,
+			{Subgroup: "legacy", Skip: true},
+		},
+	})
+
+	assert.Equal(t, []string{"handbook", "team-web", "team-backend-api", "team-backend-worker"}, paths)
+	assert.Equal(t, 2, stats.Filtered)
+	assert.Equal(t, 3, stats.Groups)
+}
+
+func TestWalkGroupTree_MaxDepth(t *testing.T) {
+	newFakeGroupTree(t)
+
+	paths, _ := walkPaths(t, TreeOptions{PathTemplate: LayoutFlat, MaxDepth: 1})
+
+	assert.Equal(t, []string{"handbook", "web", "web-e2e", "docs", "old"}, paths)
+}
+
+func TestRenderPathTemplate(t *testing.T) {
+	p := &TreeProject{Path: "api", Root: "acme/org", Namespace: "acme/org/team/backend", Subgroup: "team/backend"}
+
+	got, err := RenderPathTemplate("{namespace}/{project}", p)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("acme", "org", "team", "backend", "api"), got)
+
+	got, err = RenderPathTemplate(LayoutNested, &TreeProject{Path: "api", Root: "org", Namespace: "org"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("org", "api"), got)
+
+	_, err = RenderPathTemplate("../{project}", p)
+	assert.Error(t, err)
+}
@@ -18,6 +18,13 @@ func PreflightCheckGroupAccess(ctx context.Context, group string) error {
 		return fmt.Errorf("group is required")
 	}
 
+	// VPN 전용 호스트인데 터널이 없으면 요청 전에 알린다
+	if u, err := url.Parse(getBaseAPIURL()); err == nil {
+		if err := httpclient.CheckProviderVPN("gitlab", u.Hostname()); err != nil {
+			return err
+		}
+	}
+
 	encodedGroup := url.PathEscape(group)
 	reqURL := buildAPIURL(fmt.Sprintf("groups/%s", encodedGroup))
 
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
 	"github.com/gizzahub/gzh-cli/pkg/github"
 	synclone "github.com/gizzahub/gzh-cli/pkg/synclone"
 )
@@ -342,7 +343,7 @@ func (c *Client) GitHubClient(token string) github.APIClient {
 	config := github.DefaultAPIClientConfig()
 	config.Token = token
 
-	httpClient := &httpClientWrapper{&http.Client{Timeout: c.config.Timeout}}
+	httpClient := &httpClientWrapper{httpclient.NewProviderClient("github", c.config.Timeout)}
 	logger := &silentLoggerImpl{}
 
 	return github.NewAPIClient(config, httpClient, logger)
@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package i18n translates user-facing CLI messages.
+//
+// Messages are looked up by their English text, so untranslated messages
+// and the default "en" locale print the source string unchanged. Catalogs
+// for other locales are YAML maps from the English text to the translation,
+// embedded from the catalogs directory. A message with format verbs is
+// translated first and formatted afterwards, so translations must keep the
+// verbs in the same order.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLocale is the locale of the source messages.
+const DefaultLocale = "en"
+
+// LocaleEnvVar overrides the configured and detected locale.
+const LocaleEnvVar = "GZH_LOCALE"
+
+// systemEnvVars are the POSIX locale variables, in order of precedence.
+var systemEnvVars = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+//go:embed catalogs/*.yaml
+var catalogFS embed.FS
+
+var (
+	catalogsOnce sync.Once
+	catalogs     map[string]map[string]string
+	catalogsErr  error
+)
+
+// loadCatalogs parses the embedded catalogs once.
+func loadCatalogs() (map[string]map[string]string, error) {
+	catalogsOnce.Do(func() {
+		entries, err := catalogFS.ReadDir("catalogs")
+		if err != nil {
+			catalogsErr = err
+			return
+		}
+		catalogs = make(map[string]map[string]string, len(entries))
+		for _, entry := range entries {
+			data, err := catalogFS.ReadFile(path.Join("catalogs", entry.Name()))
+			if err != nil {
+				catalogsErr = err
+				return
+			}
+			messages := make(map[string]string)
+			if err := yaml.Unmarshal(data, &messages); err != nil {
+				catalogsErr = fmt.Errorf("invalid catalog %s: %w", entry.Name(), err)
+				return
+			}
+			catalogs[strings.TrimSuffix(entry.Name(), ".yaml")] = messages
+		}
+	})
+	return catalogs, catalogsErr
+}
+
+// Supported returns the supported locales, sorted.
+func Supported() []string {
+	all, _ := loadCatalogs()
+	out := []string{DefaultLocale}
+	for locale := range all {
+		out = append(out, locale)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// IsSupported reports whether locale has a catalog or is the default locale.
+func IsSupported(locale string) bool {
+	return slices.Contains(Supported(), locale)
+}
+
+// Normalize reduces a locale name such as "ko_KR.UTF-8" or "zh-Hans-CN" to
+// its language ("ko", "zh"). The POSIX "C" and "POSIX" locales normalize to
+// the default locale.
+func Normalize(name string) string {
+	name = strings.TrimSpace(name)
+	if i := strings.IndexAny(name, ".@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.IndexAny(name, "_-"); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.ToLower(name)
+	if name == "c" || name == "posix" {
+		return DefaultLocale
+	}
+	return name
+}
+
+// Resolve picks the locale to use: $GZH_LOCALE, then configured (the
+// locale setting of gzh.yaml), then the first set of LC_ALL, LC_MESSAGES
+// and LANG. Unsupported values fall back to the default locale.
+func Resolve(configured string, getenv func(string) string) string {
+	candidates := []string{getenv(LocaleEnvVar), configured}
+	for _, name := range systemEnvVars {
+		if value := getenv(name); value != "" {
+			candidates = append(candidates, value)
+			break
+		}
+	}
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		if locale := Normalize(candidate); IsSupported(locale) {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// Localizer translates messages into one locale.
+type Localizer struct {
+	locale   string
+	messages map[string]string
+}
+
+// New returns a localizer for locale, which may be given in any form
+// accepted by Normalize.
+func New(locale string) (*Localizer, error) {
+	all, err := loadCatalogs()
+	if err != nil {
+		return nil, err
+	}
+	normalized := Normalize(locale)
+	if normalized == DefaultLocale {
+		return &Localizer{locale: DefaultLocale}, nil
+	}
+	messages, ok := all[normalized]
+	if !ok {
+		return nil, fmt.Errorf("unsupported locale %q (supported: %s)", locale, strings.Join(Supported(), ", "))
+	}
+	return &Localizer{locale: normalized, messages: messages}, nil
+}
+
+// Locale returns the locale of the localizer.
+func (l *Localizer) Locale() string {
+	return l.locale
+}
+
+// Lookup returns the translation of msg and whether one exists.
+func (l *Localizer) Lookup(msg string) (string, bool) {
+	translated, ok := l.messages[msg]
+	return translated, ok && translated != ""
+}
+
+// T translates msg and formats it with args when any are given.
+func (l *Localizer) T(msg string, args ...any) string {
+	if translated, ok := l.Lookup(msg); ok {
+		msg = translated
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+var current atomic.Pointer[Localizer]
+
+func init() {
+	current.Store(&Localizer{locale: DefaultLocale})
+}
+
+// Default returns the process-wide localizer used by T.
+func Default() *Localizer {
+	return current.Load()
+}
+
+// SetDefault replaces the process-wide localizer.
+func SetDefault(l *Localizer) {
+	current.Store(l)
+}
+
+// SetLocale switches the process-wide localizer to locale.
+func SetLocale(locale string) error {
+	l, err := New(locale)
+	if err != nil {
+		return err
+	}
+	SetDefault(l)
+	return nil
+}
+
+// T translates msg with the process-wide localizer.
+func T(msg string, args ...any) string {
+	return Default().T(msg, args...)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package i18n
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"ko_KR.UTF-8":      "ko",
+		"ja_JP.eucJP@euro": "ja",
+		"zh-Hans-CN":       "zh",
+		"EN_us":            "en",
+		"C":                "en",
+		"POSIX":            "en",
+		"":                 "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, Normalize(in), in)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	assert.Equal(t, "en", Resolve("", env(nil)))
+	assert.Equal(t, "ko", Resolve("", env(map[string]string{"LANG": "ko_KR.UTF-8"})))
+	assert.Equal(t, "ja", Resolve("", env(map[string]string{"LC_ALL": "ja_JP.UTF-8", "LANG": "ko_KR.UTF-8"})))
+	assert.Equal(t, "en", Resolve("", env(map[string]string{"LC_ALL": "C", "LANG": "ko_KR.UTF-8"})),
+		"the first set POSIX variable wins even when it is C")
+	assert.Equal(t, "zh", Resolve("zh", env(map[string]string{"LANG": "ko_KR.UTF-8"})), "the config setting beats LANG")
+	assert.Equal(t, "ja", Resolve("zh", env(map[string]string{LocaleEnvVar: "ja"})), "GZH_LOCALE beats the config setting")
+	assert.Equal(t, "ko", Resolve("fr", env(map[string]string{"LANG": "ko_KR.UTF-8"})), "unsupported values are skipped")
+}
+
+func TestLocalizer(t *testing.T) {
+	ko, err := New("ko_KR.UTF-8")
+	require.NoError(t, err)
+	assert.Equal(t, "ko", ko.Locale())
+	assert.Equal(t, "사용법:", ko.T("Usage:"))
+	assert.Equal(t, "2 / 3 페이지 (25행)", ko.T("Page %d of %d (%d rows)", 2, 3, 25))
+	assert.Equal(t, "untranslated 1", ko.T("untranslated %d", 1), "missing messages fall back to the source")
+
+	en, err := New("en")
+	require.NoError(t, err)
+	assert.Equal(t, "Usage:", en.T("Usage:"))
+
+	_, err = New("fr")
+	assert.ErrorContains(t, err, "supported: en, ja, ko, zh")
+}
+
+func TestSetLocale(t *testing.T) {
+	t.Cleanup(func() { SetDefault(&Localizer{locale: DefaultLocale}) })
+
+	require.NoError(t, SetLocale("ja"))
+	assert.Equal(t, "ja", Default().Locale())
+	assert.Equal(t, "例:", T("Examples:"))
+	assert.Error(t, SetLocale("xx"))
+	assert.Equal(t, "ja", Default().Locale(), "a failed switch keeps the current locale")
+}
+
+// TestCatalogsConsistent checks that every catalog translates the same
+// messages and keeps their format verbs.
+func TestCatalogsConsistent(t *testing.T) {
+	all, err := loadCatalogs()
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+
+	verbs := regexp.MustCompile(`%[-+# 0]*[0-9]*[a-zA-Z%]`)
+	reference := all["ko"]
+	for locale, messages := range all {
+		assert.Len(t, messages, len(reference), locale)
+		for msg, translated := range messages {
+			_, ok := reference[msg]
+			assert.True(t, ok, "%s: %q is missing from ko", locale, msg)
+			assert.NotEmpty(t, translated, "%s: %q", locale, msg)
+			assert.Equal(t, verbs.FindAllString(msg, -1), verbs.FindAllString(translated, -1), "%s: %q", locale, msg)
+		}
+	}
+}
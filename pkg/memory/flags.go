@@ -0,0 +1,170 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package memory
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/gizzahub/gzh-cli/internal/metrics"
+)
+
+var (
+	gogcGauge = metrics.Default().Gauge("gz_memory_gogc",
+		"GOGC value currently set by the GC tuner.")
+	limitGauge = metrics.Default().Gauge("gz_memory_limit_bytes",
+		"Memory limit the GC tuner plans the heap against.")
+	goroutinesGauge = metrics.Default().Gauge("gz_memory_goroutines",
+		"Goroutines observed by the leak detector.")
+	heapObjectsGauge = metrics.Default().Gauge("gz_memory_heap_objects",
+		"Live heap objects observed by the leak detector.")
+	leaksCounter = metrics.Default().Counter("gz_memory_leaks_total",
+		"Possible leaks reported by the leak detector.", "kind")
+)
+
+func recordSettings(gogc int, limit int64) {
+	gogcGauge.With().Set(float64(gogc))
+	limitGauge.With().Set(float64(limit))
+}
+
+func recordLeakSample(s LeakSample) {
+	goroutinesGauge.With().Set(float64(s.Goroutines))
+	heapObjectsGauge.With().Set(float64(s.HeapObjects))
+}
+
+// AddFlag adds --memory-auto-tune and --memory-tune-mode flags to cmd and
+// wraps the RunE of cmd and all of its subcommands so a GCTuner runs for the
+// command's lifetime. Call it after all subcommands have been added.
+func AddFlag(cmd *cobra.Command) {
+	var (
+		enabled bool
+		mode    string
+	)
+	cmd.PersistentFlags().BoolVar(&enabled, "memory-auto-tune", false, "Adjust GOGC/GOMEMLIMIT from runtime telemetry during bulk operations")
+	cmd.PersistentFlags().StringVar(&mode, "memory-tune-mode", string(OptimizeForThroughput), "GC tuning goal: throughput or latency")
+
+	wrapRunE(cmd, &enabled, &mode)
+}
+
+func wrapRunE(cmd *cobra.Command, enabled *bool, mode *string) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(c *cobra.Command, args []string) error {
+			if !*enabled {
+				return run(c, args)
+			}
+
+			m, err := ParseMode(*mode)
+			if err != nil {
+				return err
+			}
+
+			tuner := NewGCTuner(TunerConfig{Mode: m})
+			tuner.Start(c.Context())
+			defer tuner.Stop()
+
+			stats := tuner.Stats()
+			limit := "none"
+			if stats.MemoryLimit > 0 {
+				limit = formatBytes(stats.MemoryLimit)
+			}
+			fmt.Fprintf(c.ErrOrStderr(), "🧠 Memory auto-tune enabled (mode=%s, limit=%s)\n", m, limit)
+
+			return run(c, args)
+		}
+	}
+
+	for _, sub := range cmd.Commands() {
+		wrapRunE(sub, enabled, mode)
+	}
+}
+
+// AddLeakFlags adds --leak-detect, --leak-window and --leak-dump-dir flags to
+// a long-running cmd and wraps its RunE so a LeakDetector runs for the
+// command's lifetime.
+func AddLeakFlags(cmd *cobra.Command) {
+	var (
+		enabled bool
+		window  time.Duration
+		dumpDir string
+	)
+	cmd.Flags().BoolVar(&enabled, "leak-detect", false, "Warn when goroutines or the live heap grow monotonically")
+	cmd.Flags().DurationVar(&window, "leak-window", 10*time.Minute, "Window growth must persist over before a leak is reported")
+	cmd.Flags().StringVar(&dumpDir, "leak-dump-dir", "", "Directory for heap and goroutine profiles written when a leak is reported")
+
+	run := cmd.RunE
+	if run == nil {
+		return
+	}
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		if !enabled {
+			return run(c, args)
+		}
+		if window <= 0 {
+			return fmt.Errorf("--leak-window must be positive")
+		}
+
+		// Sample often enough to see a trend within one window.
+		interval := min(30*time.Second, max(window/20, time.Second))
+		detector := NewLeakDetector(LeakConfig{
+			Interval: interval,
+			Window:   window,
+			DumpDir:  dumpDir,
+			Logger:   slog.New(slog.NewTextHandler(c.ErrOrStderr(), nil)),
+		})
+		detector.Start(c.Context())
+		defer detector.Stop()
+
+		dumps := "off"
+		if dumpDir != "" {
+			dumps = dumpDir
+		}
+		fmt.Fprintf(c.ErrOrStderr(), "🔍 Leak detection enabled (window=%s, interval=%s, dumps=%s)\n", window, interval, dumps)
+
+		return run(c, args)
+	}
+}
+
+// cgroupMemoryLimit returns the container memory limit, or 0 when there is
+// none or it cannot be read.
+func cgroupMemoryLimit() int64 {
+	for _, path := range []string{
+		"/sys/fs/cgroup/memory.max",                   // cgroup v2
+		"/sys/fs/cgroup/memory/memory.limit_in_bytes", // cgroup v1
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		value := strings.TrimSpace(string(data))
+		if value == "max" {
+			return 0
+		}
+		limit, err := strconv.ParseInt(value, 10, 64)
+		// cgroup v1 reports an unlimited group as a huge page-aligned number.
+		if err != nil || limit <= 0 || limit >= 1<<62 {
+			return 0
+		}
+		return limit
+	}
+	return 0
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,444 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"runtime/pprof"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LeakKind is the resource a leak was detected in.
+type LeakKind string
+
+const (
+	// LeakGoroutines reports a goroutine count that keeps growing.
+	LeakGoroutines LeakKind = "goroutines"
+	// LeakHeap reports a live heap that keeps growing.
+	LeakHeap LeakKind = "heap"
+)
+
+// monotonicRatio is the share of steps in a window that must not decrease
+// for growth to count as monotonic. A little slack absorbs GC timing noise.
+const monotonicRatio = 0.8
+
+// LeakConfig configures a LeakDetector. Zero values select defaults.
+type LeakConfig struct {
+	// Interval between samples. Defaults to 30s.
+	Interval time.Duration
+	// Window growth must persist over before it is reported. Defaults to 10m.
+	Window time.Duration
+	// MinGoroutineGrowth is the net goroutine growth over a window that is
+	// reported. Defaults to 50.
+	MinGoroutineGrowth int
+	// MinHeapGrowth is the net live heap growth over a window, in bytes, that
+	// is reported. Defaults to 64 MiB.
+	MinHeapGrowth uint64
+	// TopSites limits the allocation sites listed in a heap report.
+	// Defaults to 5.
+	TopSites int
+	// Cooldown suppresses repeated reports of the same kind. Defaults to
+	// Window.
+	Cooldown time.Duration
+	// DumpDir receives heap and goroutine profiles when a leak is reported.
+	// Empty disables dumps.
+	DumpDir string
+	// Logger receives the structured warnings. Defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+// LeakSample is one observation of the process.
+type LeakSample struct {
+	At          time.Time
+	Goroutines  int
+	HeapObjects uint64 // live heap objects after the last GC
+	HeapBytes   uint64 // live heap bytes after the last GC
+	// Sites is in-use memory by allocation site, from the heap profile.
+	Sites map[string]SiteUsage
+}
+
+// SiteUsage is the in-use memory of one allocation site.
+type SiteUsage struct {
+	Objects int64
+	Bytes   int64
+}
+
+// SiteGrowth is the growth of one allocation site over a window.
+type SiteGrowth struct {
+	Site    string `json:"site"`
+	Objects int64  `json:"objects"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// Leak describes growth that persisted over a full window.
+type Leak struct {
+	Kind   LeakKind      `json:"kind"`
+	Window time.Duration `json:"window"`
+	From   uint64        `json:"from"`
+	To     uint64        `json:"to"`
+	// Sites lists the allocation sites that grew the most (heap leaks only).
+	Sites []SiteGrowth `json:"sites,omitempty"`
+	// Dumps lists the profiles written for post-mortem analysis.
+	Dumps []string `json:"dumps,omitempty"`
+}
+
+// LeakDetector samples goroutine counts and the heap profile of a
+// long-running process and warns when either grows monotonically over a
+// window, writing pprof dumps for later analysis.
+type LeakDetector struct {
+	config LeakConfig
+
+	mu       sync.Mutex
+	samples  []LeakSample
+	reported map[LeakKind]time.Time
+	leaks    []Leak
+	cancel   context.CancelFunc
+	done     chan struct{}
+
+	// sample and dump are swapped out in tests.
+	sample func() LeakSample
+	dump   func(dir string, at time.Time) ([]string, error)
+}
+
+// NewLeakDetector creates a detector. It does nothing until Start is called.
+func NewLeakDetector(config LeakConfig) *LeakDetector {
+	if config.Interval <= 0 {
+		config.Interval = 30 * time.Second
+	}
+	if config.Window <= 0 {
+		config.Window = 10 * time.Minute
+	}
+	if config.MinGoroutineGrowth <= 0 {
+		config.MinGoroutineGrowth = 50
+	}
+	if config.MinHeapGrowth == 0 {
+		config.MinHeapGrowth = 64 << 20
+	}
+	if config.TopSites <= 0 {
+		config.TopSites = 5
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = config.Window
+	}
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+
+	return &LeakDetector{
+		config:   config,
+		reported: make(map[LeakKind]time.Time),
+		sample:   sampleProcess,
+		dump:     writeDumps,
+	}
+}
+
+// Start begins sampling in the background.
+func (d *LeakDetector) Start(ctx context.Context) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	d.cancel = cancel
+	d.done = make(chan struct{})
+	go d.loop(ctx)
+}
+
+// Stop stops sampling.
+func (d *LeakDetector) Stop() {
+	d.mu.Lock()
+	cancel, done := d.cancel, d.done
+	d.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-done
+
+	d.mu.Lock()
+	d.cancel = nil
+	d.mu.Unlock()
+}
+
+// Leaks returns the leaks reported so far.
+func (d *LeakDetector) Leaks() []Leak {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.leaks)
+}
+
+func (d *LeakDetector) loop(ctx context.Context) {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+
+	d.observe(d.sample())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.observe(d.sample())
+		}
+	}
+}
+
+// observe records a sample and reports the leaks it completes.
+func (d *LeakDetector) observe(s LeakSample) []Leak {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	recordLeakSample(s)
+
+	// Keep the newest sample at or before the window start so the window is
+	// measured from it.
+	d.samples = append(d.samples, s)
+	start := s.At.Add(-d.config.Window)
+	for len(d.samples) > 1 && !d.samples[1].At.After(start) {
+		d.samples = d.samples[1:]
+	}
+	if d.samples[0].At.After(start) || len(d.samples) < 3 {
+		return nil
+	}
+
+	var found []Leak
+	for _, leak := range d.detect() {
+		if last, ok := d.reported[leak.Kind]; ok && s.At.Sub(last) < d.config.Cooldown {
+			continue
+		}
+		d.reported[leak.Kind] = s.At
+
+		if d.config.DumpDir != "" {
+			dumps, err := d.dump(d.config.DumpDir, s.At)
+			if err != nil {
+				d.config.Logger.Warn("failed to write leak profiles", "dir", d.config.DumpDir, "error", err)
+			}
+			leak.Dumps = dumps
+		}
+		d.warn(leak)
+		leaksCounter.With(string(leak.Kind)).Inc()
+		found = append(found, leak)
+	}
+	d.leaks = append(d.leaks, found...)
+	return found
+}
+
+// detect checks the current window for monotonic growth.
+func (d *LeakDetector) detect() []Leak {
+	first, last := d.samples[0], d.samples[len(d.samples)-1]
+	window := last.At.Sub(first.At)
+	var leaks []Leak
+
+	goroutines := make([]uint64, len(d.samples))
+	heap := make([]uint64, len(d.samples))
+	for i, s := range d.samples {
+		goroutines[i] = uint64(s.Goroutines)
+		heap[i] = s.HeapBytes
+	}
+
+	if monotonic(goroutines, uint64(d.config.MinGoroutineGrowth)) {
+		leaks = append(leaks, Leak{
+			Kind: LeakGoroutines, Window: window,
+			From: uint64(first.Goroutines), To: uint64(last.Goroutines),
+		})
+	}
+	if monotonic(heap, d.config.MinHeapGrowth) {
+		leaks = append(leaks, Leak{
+			Kind: LeakHeap, Window: window,
+			From: first.HeapBytes, To: last.HeapBytes,
+			Sites: topSites(first.Sites, last.Sites, d.config.TopSites),
+		})
+	}
+	return leaks
+}
+
+// monotonic reports whether values grew by at least minGrowth and did not
+// decrease in at least monotonicRatio of the steps.
+func monotonic(values []uint64, minGrowth uint64) bool {
+	first, last := values[0], values[len(values)-1]
+	if last <= first || last-first < minGrowth {
+		return false
+	}
+	steps := len(values) - 1
+	rising := 0
+	for i := 1; i < len(values); i++ {
+		if values[i] >= values[i-1] {
+			rising++
+		}
+	}
+	return float64(rising) >= float64(steps)*monotonicRatio
+}
+
+// topSites returns the n allocation sites whose in-use bytes grew the most
+// between two heap profiles.
+func topSites(before, after map[string]SiteUsage, n int) []SiteGrowth {
+	var growth []SiteGrowth
+	for site, usage := range after {
+		prev := before[site]
+		if usage.Bytes <= prev.Bytes {
+			continue
+		}
+		growth = append(growth, SiteGrowth{
+			Site:    site,
+			Objects: usage.Objects - prev.Objects,
+			Bytes:   usage.Bytes - prev.Bytes,
+		})
+	}
+	slices.SortFunc(growth, func(a, b SiteGrowth) int {
+		if c := cmp.Compare(b.Bytes, a.Bytes); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Site, b.Site)
+	})
+	if len(growth) > n {
+		growth = growth[:n]
+	}
+	return growth
+}
+
+func (d *LeakDetector) warn(leak Leak) {
+	args := []any{
+		"kind", string(leak.Kind),
+		"window", leak.Window.Round(time.Second).String(),
+		"from", leak.From,
+		"to", leak.To,
+	}
+	for i, site := range leak.Sites {
+		args = append(args, fmt.Sprintf("site%d", i+1),
+			fmt.Sprintf("%s (+%s, +%d objects)", site.Site, formatBytes(site.Bytes), site.Objects))
+	}
+	if len(leak.Dumps) > 0 {
+		args = append(args, "dumps", strings.Join(leak.Dumps, ","))
+	}
+	d.config.Logger.Warn("possible "+string(leak.Kind)+" leak: monotonic growth over window", args...)
+}
+
+const metricHeapObjects = "/gc/heap/objects:objects"
+
+// sampleProcess observes the running process.
+func sampleProcess() LeakSample {
+	m := []metrics.Sample{{Name: metricLiveHeap}, {Name: metricHeapObjects}}
+	metrics.Read(m)
+
+	s := LeakSample{At: time.Now(), Goroutines: runtime.NumGoroutine(), Sites: heapSites()}
+	if m[0].Value.Kind() == metrics.KindUint64 {
+		s.HeapBytes = m[0].Value.Uint64()
+	}
+	if m[1].Value.Kind() == metrics.KindUint64 {
+		s.HeapObjects = m[1].Value.Uint64()
+	}
+	return s
+}
+
+// heapSites reads the heap profile as of the last GC and groups in-use
+// memory by allocation site, the first frame outside the runtime. Values
+// are scaled up from the sampled records the way pprof does.
+func heapSites() map[string]SiteUsage {
+	var records []runtime.MemProfileRecord
+	n, _ := runtime.MemProfile(nil, false)
+	for {
+		records = make([]runtime.MemProfileRecord, n+50)
+		var ok bool
+		n, ok = runtime.MemProfile(records, false)
+		if ok {
+			records = records[:n]
+			break
+		}
+	}
+
+	rate := int64(runtime.MemProfileRate)
+	sites := make(map[string]SiteUsage)
+	for i := range records {
+		r := &records[i]
+		objects, bytes := scaleHeapSample(r.InUseObjects(), r.InUseBytes(), rate)
+		if objects == 0 {
+			continue
+		}
+		site := allocationSite(r.Stack())
+		usage := sites[site]
+		usage.Objects += objects
+		usage.Bytes += bytes
+		sites[site] = usage
+	}
+	return sites
+}
+
+// scaleHeapSample estimates the unsampled totals of a heap profile record,
+// matching runtime/pprof.
+func scaleHeapSample(count, size, rate int64) (int64, int64) {
+	if count == 0 || size == 0 {
+		return 0, 0
+	}
+	if rate <= 1 {
+		return count, size
+	}
+	avg := float64(size) / float64(count)
+	scale := 1 / (1 - math.Exp(-avg/float64(rate)))
+	return int64(float64(count) * scale), int64(float64(size) * scale)
+}
+
+func allocationSite(stack []uintptr) string {
+	frames := runtime.CallersFrames(stack)
+	fallback := "unknown"
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			if !strings.HasPrefix(frame.Function, "runtime.") {
+				return frame.Function
+			}
+			if fallback == "unknown" {
+				fallback = frame.Function
+			}
+		}
+		if !more {
+			return fallback
+		}
+	}
+}
+
+// writeDumps writes heap and goroutine profiles into dir and returns their
+// paths.
+func writeDumps(dir string, at time.Time) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create dump directory: %w", err)
+	}
+
+	stamp := at.Format("20060102-150405")
+	var paths []string
+	for _, name := range []string{"heap", "goroutine"} {
+		path := filepath.Join(dir, fmt.Sprintf("%s-%s.pprof", name, stamp))
+		if err := writeProfile(name, path); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func writeProfile(name, path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s profile: %w", name, err)
+	}
+	if err := pprof.Lookup(name).WriteTo(f, 0); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write %s profile: %w", name, err)
+	}
+	return f.Close()
+}
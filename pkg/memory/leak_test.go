@@ -0,0 +1,180 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package memory
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDetector(config LeakConfig) (*LeakDetector, *bytes.Buffer) {
+	var logs bytes.Buffer
+	config.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+	d := NewLeakDetector(config)
+	d.dump = func(dir string, at time.Time) ([]string, error) {
+		return []string{filepath.Join(dir, "heap.pprof"), filepath.Join(dir, "goroutine.pprof")}, nil
+	}
+	return d, &logs
+}
+
+func TestLeakDetector_GoroutineGrowth(t *testing.T) {
+	d, logs := newTestDetector(LeakConfig{Window: time.Minute, MinGoroutineGrowth: 10, DumpDir: "dumps"})
+	start := time.Now()
+
+	var leaks []Leak
+	for i := 0; i <= 6; i++ {
+		leaks = append(leaks, d.observe(LeakSample{At: start.Add(time.Duration(i) * 10 * time.Second), Goroutines: 10 + i*5})...)
+	}
+
+	require.Len(t, leaks, 1)
+	assert.Equal(t, LeakGoroutines, leaks[0].Kind)
+	assert.Equal(t, uint64(10), leaks[0].From)
+	assert.Equal(t, uint64(40), leaks[0].To)
+	assert.Equal(t, time.Minute, leaks[0].Window)
+	assert.Len(t, leaks[0].Dumps, 2)
+	assert.Contains(t, logs.String(), "possible goroutines leak")
+	assert.Contains(t, logs.String(), "dumps=")
+
+	// Further growth within the cooldown is not reported again.
+	assert.Empty(t, d.observe(LeakSample{At: start.Add(70 * time.Second), Goroutines: 50}))
+	assert.Len(t, d.Leaks(), 1)
+}
+
+func TestLeakDetector_NeedsFullWindow(t *testing.T) {
+	d, _ := newTestDetector(LeakConfig{Window: time.Minute, MinGoroutineGrowth: 10})
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		assert.Empty(t, d.observe(LeakSample{At: start.Add(time.Duration(i) * 10 * time.Second), Goroutines: 10 + i*20}))
+	}
+}
+
+func TestLeakDetector_IgnoresFluctuation(t *testing.T) {
+	d, _ := newTestDetector(LeakConfig{Window: time.Minute, MinGoroutineGrowth: 10, MinHeapGrowth: 1 << 20})
+	start := time.Now()
+
+	// Net growth but up and down every step, like a busy worker pool.
+	goroutines := []int{10, 40, 12, 45, 15, 50, 30}
+	for i, n := range goroutines {
+		assert.Empty(t, d.observe(LeakSample{
+			At:         start.Add(time.Duration(i) * 10 * time.Second),
+			Goroutines: n,
+			HeapBytes:  uint64(100+i) << 10, // steady growth below the threshold
+		}))
+	}
+}
+
+func TestLeakDetector_HeapGrowthReportsSites(t *testing.T) {
+	d, logs := newTestDetector(LeakConfig{Window: time.Minute, MinHeapGrowth: 10 << 20, TopSites: 1})
+	start := time.Now()
+
+	var leaks []Leak
+	for i := 0; i <= 6; i++ {
+		leaks = append(leaks, d.observe(LeakSample{
+			At:        start.Add(time.Duration(i) * 10 * time.Second),
+			HeapBytes: uint64(50+i*5) << 20,
+			Sites: map[string]SiteUsage{
+				"example.com/cache.(*Cache).Put": {Objects: int64(100 * (i + 1)), Bytes: int64(i*5) << 20},
+				"example.com/api.handle":         {Objects: 10, Bytes: 1 << 20},
+			},
+		})...)
+	}
+
+	require.Len(t, leaks, 1)
+	assert.Equal(t, LeakHeap, leaks[0].Kind)
+	assert.Empty(t, leaks[0].Dumps)
+	require.Len(t, leaks[0].Sites, 1)
+	assert.Equal(t, SiteGrowth{Site: "example.com/cache.(*Cache).Put", Objects: 600, Bytes: 30 << 20}, leaks[0].Sites[0])
+	assert.Contains(t, logs.String(), "example.com/cache.(*Cache).Put (+30.0MB, +600 objects)")
+}
+
+func TestMonotonic(t *testing.T) {
+	assert.True(t, monotonic([]uint64{1, 2, 2, 3, 10}, 5))
+	assert.True(t, monotonic([]uint64{1, 3, 2, 4, 5, 10}, 5)) // one dip in five steps
+	assert.False(t, monotonic([]uint64{1, 3, 2, 4, 3, 10}, 5))
+	assert.False(t, monotonic([]uint64{1, 2, 3}, 5))
+	assert.False(t, monotonic([]uint64{10, 9, 8}, 1))
+}
+
+func TestScaleHeapSample(t *testing.T) {
+	objects, bytes := scaleHeapSample(0, 0, 512*1024)
+	assert.Zero(t, objects)
+	assert.Zero(t, bytes)
+
+	objects, bytes = scaleHeapSample(4, 4096, 1)
+	assert.Equal(t, int64(4), objects)
+	assert.Equal(t, int64(4096), bytes)
+
+	// Small objects are sampled rarely, so their totals scale up.
+	objects, bytes = scaleHeapSample(2, 64, 512*1024)
+	assert.Greater(t, objects, int64(2))
+	assert.Greater(t, bytes, int64(64))
+}
+
+var leaked [][]byte
+
+func TestSampleProcess(t *testing.T) {
+	old := runtime.MemProfileRate
+	runtime.MemProfileRate = 1
+	defer func() { runtime.MemProfileRate = old }()
+
+	for i := 0; i < 64; i++ {
+		leaked = append(leaked, make([]byte, 1024))
+	}
+	runtime.GC()
+
+	s := sampleProcess()
+	assert.Positive(t, s.Goroutines)
+	assert.Positive(t, s.HeapBytes)
+	assert.Positive(t, s.HeapObjects)
+	usage, ok := s.Sites["github.com/gizzahub/gzh-cli/pkg/memory.TestSampleProcess"]
+	require.True(t, ok, "sites: %v", s.Sites)
+	assert.GreaterOrEqual(t, usage.Objects, int64(64))
+}
+
+func TestWriteDumps(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "profiles")
+	at := time.Date(2025, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	paths, err := writeDumps(dir, at)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "heap-20250301-123000.pprof"),
+		filepath.Join(dir, "goroutine-20250301-123000.pprof"),
+	}, paths)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Positive(t, info.Size())
+	}
+}
+
+func TestAddLeakFlags(t *testing.T) {
+	var stderr bytes.Buffer
+	ran := false
+	cmd := &cobra.Command{Use: "serve", RunE: func(*cobra.Command, []string) error {
+		ran = true
+		return nil
+	}}
+	AddLeakFlags(cmd)
+	cmd.SetErr(&stderr)
+
+	cmd.SetArgs([]string{"--leak-detect", "--leak-window", "1m"})
+	require.NoError(t, cmd.ExecuteContext(context.Background()))
+	assert.True(t, ran)
+	assert.Contains(t, stderr.String(), "Leak detection enabled (window=1m0s, interval=3s, dumps=off)")
+
+	cmd.SetArgs([]string{"--leak-detect", "--leak-window", "0s"})
+	assert.Error(t, cmd.Execute())
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package memory
+
+import (
+	"math"
+	"runtime/debug"
+	"runtime/metrics"
+)
+
+// Pressure returns the live heap as a fraction of the memory limit: the
+// GOMEMLIMIT in effect (including one set by a running GCTuner), or else the
+// cgroup limit. It returns 0 when no limit is known.
+func Pressure() float64 {
+	limit := debug.SetMemoryLimit(-1)
+	if limit == math.MaxInt64 {
+		limit = cgroupMemoryLimit()
+	}
+	if limit <= 0 {
+		return 0
+	}
+
+	sample := []metrics.Sample{{Name: metricLiveHeap}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return float64(sample[0].Value.Uint64()) / float64(limit)
+}
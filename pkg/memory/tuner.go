@@ -0,0 +1,378 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package memory tunes the Go garbage collector during large bulk operations
+// and detects goroutine and heap leaks in long-running commands.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"runtime/metrics"
+	"sync"
+	"time"
+)
+
+// Mode selects what the GC tuner optimizes for.
+type Mode string
+
+const (
+	// OptimizeForThroughput lets the heap grow to spend less CPU in GC, up to
+	// the memory limit.
+	OptimizeForThroughput Mode = "throughput"
+	// OptimizeForLatency keeps the heap small so collections and mark assists
+	// stay short.
+	OptimizeForLatency Mode = "latency"
+)
+
+// ParseMode parses a mode name.
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case OptimizeForThroughput, OptimizeForLatency:
+		return Mode(s), nil
+	default:
+		return "", fmt.Errorf("invalid memory tune mode %q: must be throughput or latency", s)
+	}
+}
+
+// TunerConfig configures a GCTuner. Zero values select defaults.
+type TunerConfig struct {
+	Mode Mode
+	// MemoryLimit is the soft limit applied through GOMEMLIMIT. When zero, an
+	// existing GOMEMLIMIT or the cgroup memory limit is used; without either
+	// the tuner only adjusts GOGC.
+	MemoryLimit int64
+	// Interval between telemetry samples. Defaults to 1s.
+	Interval time.Duration
+	// MinGOGC and MaxGOGC bound the GOGC values the tuner sets. Defaults are
+	// 50 and 400.
+	MinGOGC int
+	MaxGOGC int
+	// TargetGCCPU is the GC CPU fraction throughput mode aims to stay under.
+	// Defaults to 0.05.
+	TargetGCCPU float64
+	// TargetPause is the p99 stop-the-world pause latency mode aims to stay
+	// under. Defaults to 2ms.
+	TargetPause time.Duration
+}
+
+// Sample is one window of runtime telemetry.
+type Sample struct {
+	LiveHeap   uint64        // live heap after the last GC
+	HeapGrowth float64       // live heap growth over the window, bytes/sec
+	GCCPU      float64       // fraction of CPU time spent in GC over the window
+	PauseP99   time.Duration // p99 GC pause over the window
+	GCCycles   uint64        // GC cycles completed over the window
+}
+
+// TunerStats reports the tuner's current state.
+type TunerStats struct {
+	Mode        Mode
+	GOGC        int
+	MemoryLimit int64
+	Adjustments int
+	LastSample  Sample
+}
+
+// GCTuner samples runtime/metrics and adjusts GOGC (and GOMEMLIMIT when a
+// limit is known) while a bulk operation runs. Stop restores the settings
+// that were active before Start.
+type GCTuner struct {
+	config TunerConfig
+
+	mu       sync.Mutex
+	gogc     int
+	limit    int64
+	stats    TunerStats
+	cancel   context.CancelFunc
+	done     chan struct{}
+	origGOGC int
+	origLim  int64
+
+	// setGOGC and setLimit are swapped out in tests.
+	setGOGC  func(int) int
+	setLimit func(int64) int64
+}
+
+// NewGCTuner creates a tuner. It does nothing until Start is called.
+func NewGCTuner(config TunerConfig) *GCTuner {
+	if config.Mode == "" {
+		config.Mode = OptimizeForThroughput
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Second
+	}
+	if config.MinGOGC <= 0 {
+		config.MinGOGC = 50
+	}
+	if config.MaxGOGC <= 0 {
+		config.MaxGOGC = 400
+	}
+	if config.TargetGCCPU <= 0 {
+		config.TargetGCCPU = 0.05
+	}
+	if config.TargetPause <= 0 {
+		config.TargetPause = 2 * time.Millisecond
+	}
+
+	return &GCTuner{
+		config:   config,
+		setGOGC:  debug.SetGCPercent,
+		setLimit: debug.SetMemoryLimit,
+	}
+}
+
+// Start applies the initial settings and begins sampling in the background.
+func (t *GCTuner) Start(ctx context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cancel != nil {
+		return
+	}
+
+	// A negative limit queries GOMEMLIMIT without changing it.
+	t.origGOGC = t.setGOGC(100)
+	t.origLim = t.setLimit(-1)
+
+	t.limit = t.config.MemoryLimit
+	if t.limit <= 0 && t.origLim != math.MaxInt64 {
+		t.limit = t.origLim
+	}
+	if t.limit <= 0 {
+		t.limit = cgroupMemoryLimit()
+	}
+	if t.limit > 0 {
+		applied := t.limit
+		if t.config.Mode == OptimizeForLatency {
+			// Leave headroom so the limit-driven GC kicks in before spikes.
+			applied = t.limit * 9 / 10
+		}
+		t.setLimit(applied)
+	}
+
+	t.gogc = t.origGOGC
+	if t.gogc < 0 {
+		t.gogc = 100
+	}
+	t.gogc = clamp(t.gogc, t.config.MinGOGC, t.config.MaxGOGC)
+	t.setGOGC(t.gogc)
+	t.stats = TunerStats{Mode: t.config.Mode, GOGC: t.gogc, MemoryLimit: t.limit}
+	recordSettings(t.gogc, t.limit)
+
+	ctx, cancel := context.WithCancel(ctx)
+	t.cancel = cancel
+	t.done = make(chan struct{})
+	go t.loop(ctx)
+}
+
+// Stop stops sampling and restores the original GOGC and GOMEMLIMIT.
+func (t *GCTuner) Stop() {
+	t.mu.Lock()
+	cancel, done := t.cancel, t.done
+	t.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-done
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.setGOGC(t.origGOGC)
+	t.setLimit(t.origLim)
+	t.cancel = nil
+}
+
+// Stats returns the tuner's current state.
+func (t *GCTuner) Stats() TunerStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stats
+}
+
+func (t *GCTuner) loop(ctx context.Context) {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.config.Interval)
+	defer ticker.Stop()
+
+	sampler := newSampler()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.observe(sampler.sample())
+		}
+	}
+}
+
+// observe applies the policy to a sample and updates GOGC if it changed.
+func (t *GCTuner) observe(s Sample) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stats.LastSample = s
+	next := t.decide(s)
+	if next == t.gogc {
+		return
+	}
+
+	t.gogc = next
+	t.setGOGC(next)
+	t.stats.GOGC = next
+	t.stats.Adjustments++
+	recordSettings(next, t.limit)
+}
+
+// decide returns the GOGC value for the next window.
+//
+// Throughput mode raises GOGC while GC burns more CPU than TargetGCCPU and the
+// projected heap (live × (1 + GOGC/100)) still fits in the limit, and backs
+// off as the projection nears it. Latency mode lowers GOGC while pauses exceed
+// TargetPause or the heap is growing fast, and drifts back to 100 otherwise.
+func (t *GCTuner) decide(s Sample) int {
+	gogc := t.gogc
+	projected := func(g int) float64 { return float64(s.LiveHeap) * (1 + float64(g)/100) }
+
+	switch t.config.Mode {
+	case OptimizeForLatency:
+		fastGrowth := s.LiveHeap > 0 && s.HeapGrowth > float64(s.LiveHeap)*0.5
+		switch {
+		case s.PauseP99 > t.config.TargetPause || fastGrowth:
+			gogc = gogc * 3 / 4
+		case gogc < 100:
+			gogc = min(gogc+10, 100)
+		case gogc > 100:
+			gogc = max(gogc-10, 100)
+		}
+
+	default:
+		if s.GCCycles > 0 && s.GCCPU > t.config.TargetGCCPU {
+			gogc = gogc * 3 / 2
+		} else if s.GCCPU < t.config.TargetGCCPU/2 && gogc > 100 {
+			gogc = max(gogc-25, 100)
+		}
+	}
+
+	gogc = clamp(gogc, t.config.MinGOGC, t.config.MaxGOGC)
+
+	// Never plan a heap beyond 90% of the limit; GOMEMLIMIT is the backstop,
+	// but running into it makes the GC thrash.
+	if t.limit > 0 {
+		budget := float64(t.limit) * 0.9
+		for gogc > t.config.MinGOGC && projected(gogc) > budget {
+			gogc = max(gogc*3/4, t.config.MinGOGC)
+		}
+	}
+
+	return gogc
+}
+
+func clamp(v, lo, hi int) int {
+	return max(lo, min(v, hi))
+}
+
+// sampler turns cumulative runtime/metrics counters into per-window values.
+type sampler struct {
+	samples  []metrics.Sample
+	at       time.Time
+	live     uint64
+	gcCPU    float64
+	totalCPU float64
+	cycles   uint64
+	pauses   []uint64
+}
+
+const (
+	metricLiveHeap = "/gc/heap/live:bytes"
+	metricGCCPU    = "/cpu/classes/gc/total:cpu-seconds"
+	metricTotalCPU = "/cpu/classes/total:cpu-seconds"
+	metricCycles   = "/gc/cycles/total:gc-cycles"
+	metricPauses   = "/sched/pauses/total/gc:seconds"
+)
+
+func newSampler() *sampler {
+	s := &sampler{samples: []metrics.Sample{
+		{Name: metricLiveHeap}, {Name: metricGCCPU}, {Name: metricTotalCPU},
+		{Name: metricCycles}, {Name: metricPauses},
+	}}
+	s.sample()
+	return s
+}
+
+func (s *sampler) sample() Sample {
+	metrics.Read(s.samples)
+	now := time.Now()
+
+	var (
+		live, cycles    uint64
+		gcCPU, totalCPU float64
+		hist            *metrics.Float64Histogram
+	)
+	for _, m := range s.samples {
+		switch {
+		case m.Value.Kind() == metrics.KindBad:
+			continue
+		case m.Name == metricLiveHeap:
+			live = m.Value.Uint64()
+		case m.Name == metricGCCPU:
+			gcCPU = m.Value.Float64()
+		case m.Name == metricTotalCPU:
+			totalCPU = m.Value.Float64()
+		case m.Name == metricCycles:
+			cycles = m.Value.Uint64()
+		case m.Name == metricPauses:
+			hist = m.Value.Float64Histogram()
+		}
+	}
+
+	out := Sample{LiveHeap: live, GCCycles: cycles - s.cycles}
+	if elapsed := now.Sub(s.at).Seconds(); !s.at.IsZero() && elapsed > 0 {
+		out.HeapGrowth = (float64(live) - float64(s.live)) / elapsed
+	}
+	if d := totalCPU - s.totalCPU; d > 0 {
+		out.GCCPU = (gcCPU - s.gcCPU) / d
+	}
+	if hist != nil {
+		out.PauseP99 = s.pauseP99(hist)
+	}
+
+	s.at, s.live, s.gcCPU, s.totalCPU, s.cycles = now, live, gcCPU, totalCPU, cycles
+	return out
+}
+
+// pauseP99 computes the 99th percentile of pauses recorded since the
+// previous sample from the cumulative histogram.
+func (s *sampler) pauseP99(h *metrics.Float64Histogram) time.Duration {
+	delta := make([]uint64, len(h.Counts))
+	var total uint64
+	for i, c := range h.Counts {
+		if i < len(s.pauses) {
+			c -= s.pauses[i]
+		}
+		delta[i] = c
+		total += c
+	}
+	s.pauses = append(s.pauses[:0], h.Counts...)
+	if total == 0 {
+		return 0
+	}
+
+	threshold := uint64(math.Ceil(float64(total) * 0.99))
+	var seen uint64
+	for i, c := range delta {
+		seen += c
+		if seen >= threshold {
+			upper := h.Buckets[i+1]
+			if math.IsInf(upper, 1) {
+				upper = h.Buckets[i]
+			}
+			return time.Duration(upper * float64(time.Second))
+		}
+	}
+	return 0
+}
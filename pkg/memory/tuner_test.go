@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package memory
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTuner(config TunerConfig, gogc int, limit int64) *GCTuner {
+	t := NewGCTuner(config)
+	t.gogc = gogc
+	t.limit = limit
+	t.setGOGC = func(int) int { return 100 }
+	t.setLimit = func(int64) int64 { return math.MaxInt64 }
+	return t
+}
+
+func TestDecide_Throughput(t *testing.T) {
+	tuner := newTestTuner(TunerConfig{Mode: OptimizeForThroughput}, 100, 0)
+
+	// GC-heavy window: grow the heap.
+	assert.Equal(t, 150, tuner.decide(Sample{LiveHeap: 100 << 20, GCCPU: 0.2, GCCycles: 5}))
+
+	// Capped at MaxGOGC.
+	tuner.gogc = 350
+	assert.Equal(t, 400, tuner.decide(Sample{LiveHeap: 100 << 20, GCCPU: 0.2, GCCycles: 5}))
+
+	// Idle GC: drift back towards 100.
+	tuner.gogc = 200
+	assert.Equal(t, 175, tuner.decide(Sample{LiveHeap: 100 << 20, GCCPU: 0.001}))
+}
+
+func TestDecide_ThroughputRespectsLimit(t *testing.T) {
+	// 400 MiB live heap, 1 GiB limit: GOGC 150 would plan 1000 MiB > 90%.
+	tuner := newTestTuner(TunerConfig{Mode: OptimizeForThroughput}, 100, 1<<30)
+
+	got := tuner.decide(Sample{LiveHeap: 400 << 20, GCCPU: 0.2, GCCycles: 5})
+	assert.Less(t, float64(400<<20)*(1+float64(got)/100), float64(1<<30)*0.9)
+	assert.GreaterOrEqual(t, got, 50)
+}
+
+func TestDecide_Latency(t *testing.T) {
+	tuner := newTestTuner(TunerConfig{Mode: OptimizeForLatency}, 100, 0)
+
+	// Long pauses: shrink the heap.
+	assert.Equal(t, 75, tuner.decide(Sample{LiveHeap: 100 << 20, PauseP99: 10 * time.Millisecond}))
+
+	// Fast heap growth also shrinks it.
+	assert.Equal(t, 75, tuner.decide(Sample{LiveHeap: 100 << 20, HeapGrowth: 80 << 20}))
+
+	// Calm window: recover towards 100 and never below MinGOGC.
+	tuner.gogc = 60
+	assert.Equal(t, 70, tuner.decide(Sample{LiveHeap: 100 << 20}))
+	tuner.gogc = 50
+	assert.Equal(t, 50, tuner.decide(Sample{LiveHeap: 100 << 20, PauseP99: time.Second}))
+}
+
+func TestGCTuner_StartStopRestoresSettings(t *testing.T) {
+	var gogcCalls []int
+	var limitCalls []int64
+
+	tuner := NewGCTuner(TunerConfig{Mode: OptimizeForLatency, MemoryLimit: 1 << 30, Interval: time.Millisecond})
+	tuner.setGOGC = func(v int) int {
+		gogcCalls = append(gogcCalls, v)
+		return 120
+	}
+	tuner.setLimit = func(v int64) int64 {
+		limitCalls = append(limitCalls, v)
+		return math.MaxInt64
+	}
+
+	tuner.Start(context.Background())
+	runtime.GC()
+	time.Sleep(5 * time.Millisecond)
+	stats := tuner.Stats()
+	tuner.Stop()
+
+	assert.Equal(t, OptimizeForLatency, stats.Mode)
+	assert.Equal(t, int64(1<<30), stats.MemoryLimit)
+	require.NotEmpty(t, limitCalls)
+	assert.Contains(t, limitCalls, int64(1<<30)*9/10)
+	assert.Equal(t, int64(math.MaxInt64), limitCalls[len(limitCalls)-1])
+	assert.Equal(t, 120, gogcCalls[len(gogcCalls)-1])
+}
+
+func TestSampler_ReadsRuntimeMetrics(t *testing.T) {
+	s := newSampler()
+	garbage := make([][]byte, 0, 1024)
+	for i := 0; i < 1024; i++ {
+		garbage = append(garbage, make([]byte, 4096))
+	}
+	runtime.KeepAlive(garbage)
+	runtime.GC()
+
+	sample := s.sample()
+	assert.Positive(t, sample.LiveHeap)
+	assert.GreaterOrEqual(t, sample.GCCycles, uint64(1))
+	assert.GreaterOrEqual(t, sample.GCCPU, 0.0)
+}
+
+func TestParseMode(t *testing.T) {
+	m, err := ParseMode("latency")
+	require.NoError(t, err)
+	assert.Equal(t, OptimizeForLatency, m)
+
+	_, err = ParseMode("fast")
+	assert.Error(t, err)
+}
+
+func TestAddFlag_WrapsSubcommands(t *testing.T) {
+	ran := false
+	root := &cobra.Command{Use: "root"}
+	sub := &cobra.Command{Use: "sub", RunE: func(*cobra.Command, []string) error {
+		ran = true
+		return nil
+	}}
+	root.AddCommand(sub)
+	AddFlag(root)
+
+	root.SetArgs([]string{"sub", "--memory-auto-tune", "--memory-tune-mode", "latency"})
+	require.NoError(t, root.Execute())
+	assert.True(t, ran)
+
+	root.SetArgs([]string{"sub", "--memory-auto-tune", "--memory-tune-mode", "bogus"})
+	assert.Error(t, root.Execute())
+}
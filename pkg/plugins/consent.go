@@ -0,0 +1,441 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// consentFileName holds the current decision per plugin and permission.
+	consentFileName = "consent.json"
+
+	// consentLogName is the append-only log of every decision and revocation.
+	consentLogName = "consent.log"
+)
+
+// Permission is a single grantable access: "network", "read:<path>",
+// "write:<path>" or "env:<name>". Paths are absolute.
+type Permission string
+
+// NetworkPermission allows opening network connections.
+const NetworkPermission Permission = "network"
+
+// ReadPermission allows reading path.
+func ReadPermission(path string) Permission {
+	return Permission("read:" + path)
+}
+
+// WritePermission allows reading and writing path.
+func WritePermission(path string) Permission {
+	return Permission("write:" + path)
+}
+
+// EnvPermission allows the plugin to see the environment variable name.
+func EnvPermission(name string) Permission {
+	return Permission("env:" + name)
+}
+
+// ParsePermission parses the textual form of a permission. Paths are made
+// absolute and "~" expands to the home directory.
+func ParsePermission(s string) (Permission, error) {
+	if s == string(NetworkPermission) {
+		return NetworkPermission, nil
+	}
+
+	kind, path, ok := strings.Cut(s, ":")
+	if !ok || path == "" || (kind != "read" && kind != "write" && kind != "env") {
+		return "", fmt.Errorf("invalid permission %q (want network, read:<path>, write:<path> or env:<name>)", s)
+	}
+	if kind == "env" {
+		if strings.ContainsAny(path, "=\x00") {
+			return "", fmt.Errorf("invalid permission %q (bad variable name)", s)
+		}
+		return EnvPermission(path), nil
+	}
+
+	abs, err := expandPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	return Permission(kind + ":" + abs), nil
+}
+
+// Path returns the path of a read or write permission, or the variable
+// name of an env permission.
+func (p Permission) Path() string {
+	_, path, _ := strings.Cut(string(p), ":")
+	return path
+}
+
+// Kind returns "network", "read", "write" or "env".
+func (p Permission) Kind() string {
+	kind, _, _ := strings.Cut(string(p), ":")
+	return kind
+}
+
+func expandPath(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expand %s: %w", path, err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+
+	return filepath.Abs(path)
+}
+
+// List returns the declared permissions in a stable order.
+func (p Permissions) List() ([]Permission, error) {
+	var list []Permission
+	if p.Network {
+		list = append(list, NetworkPermission)
+	}
+
+	for _, path := range p.Read {
+		perm, err := ParsePermission("read:" + path)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, perm)
+	}
+	for _, path := range p.Write {
+		perm, err := ParsePermission("write:" + path)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, perm)
+	}
+	for _, name := range p.Env {
+		perm, err := ParsePermission("env:" + name)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, perm)
+	}
+
+	return list, nil
+}
+
+// Decision is the user's answer to a permission request.
+type Decision string
+
+// Decisions recorded in the consent store.
+const (
+	DecisionAllow Decision = "allow"
+	DecisionDeny  Decision = "deny"
+)
+
+// Grant is the recorded decision for one permission of a plugin.
+type Grant struct {
+	Permission Permission `json:"permission"`
+	Decision   Decision   `json:"decision"`
+	// Version is the plugin version the decision was made for.
+	Version   string    `json:"version,omitempty"`
+	DecidedAt time.Time `json:"decidedAt"`
+	// Source tells how the decision was made: "prompt" or "command".
+	Source string `json:"source"`
+}
+
+// ConsentEvent is an entry of the consent log.
+type ConsentEvent struct {
+	Time       time.Time  `json:"time"`
+	Plugin     string     `json:"plugin"`
+	Version    string     `json:"version,omitempty"`
+	Permission Permission `json:"permission"`
+	// Action is "allow", "deny" or "revoke".
+	Action string `json:"action"`
+	Source string `json:"source"`
+}
+
+// ConsentStore persists permission decisions per plugin. The current
+// decisions live in consent.json; every change is also appended to
+// consent.log so that grants and revocations can be audited.
+type ConsentStore struct {
+	dir string
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// NewConsentStore creates a store in the plugin directory dir.
+func NewConsentStore(dir string) *ConsentStore {
+	return &ConsentStore{dir: dir, now: time.Now}
+}
+
+// Lookup returns the recorded decision for a permission of a plugin.
+func (s *ConsentStore) Lookup(plugin string, perm Permission) (Decision, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.load()
+	if err != nil {
+		return "", false, err
+	}
+
+	for _, g := range state[plugin] {
+		if g.Permission == perm {
+			return g.Decision, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// Record stores a decision, replacing an earlier one for the same
+// permission, and logs it.
+func (s *ConsentStore) Record(plugin, version string, perm Permission, decision Decision, source string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	now := s.now().UTC()
+	grants := state[plugin][:0:0]
+	for _, g := range state[plugin] {
+		if g.Permission != perm {
+			grants = append(grants, g)
+		}
+	}
+	state[plugin] = append(grants, Grant{Permission: perm, Decision: decision, Version: version, DecidedAt: now, Source: source})
+
+	if err := s.save(state); err != nil {
+		return err
+	}
+
+	return s.appendLog(ConsentEvent{Time: now, Plugin: plugin, Version: version, Permission: perm, Action: string(decision), Source: source})
+}
+
+// Revoke forgets the decisions for perms of a plugin, or all of its
+// decisions when perms is empty. It returns the permissions revoked.
+func (s *ConsentStore) Revoke(plugin string, perms ...Permission) ([]Permission, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []Grant
+	var revoked []Permission
+	for _, g := range state[plugin] {
+		if len(perms) == 0 || containsPermission(perms, g.Permission) {
+			revoked = append(revoked, g.Permission)
+			continue
+		}
+		kept = append(kept, g)
+	}
+	if len(revoked) == 0 {
+		return nil, nil
+	}
+
+	if len(kept) == 0 {
+		delete(state, plugin)
+	} else {
+		state[plugin] = kept
+	}
+	if err := s.save(state); err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	for _, perm := range revoked {
+		if err := s.appendLog(ConsentEvent{Time: now, Plugin: plugin, Permission: perm, Action: "revoke", Source: "command"}); err != nil {
+			return nil, err
+		}
+	}
+
+	return revoked, nil
+}
+
+// Grants returns the recorded decisions per plugin, sorted by permission.
+func (s *ConsentStore) Grants() (map[string][]Grant, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, grants := range state {
+		sort.Slice(grants, func(a, b int) bool { return grants[a].Permission < grants[b].Permission })
+	}
+
+	return state, nil
+}
+
+// Log returns the consent log, oldest first.
+func (s *ConsentStore) Log() ([]ConsentEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.Open(filepath.Join(s.dir, consentLogName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read consent log: %w", err)
+	}
+	defer f.Close()
+
+	var events []ConsentEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e ConsentEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("parse consent log: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	return events, scanner.Err()
+}
+
+func containsPermission(perms []Permission, perm Permission) bool {
+	for _, p := range perms {
+		if p == perm {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *ConsentStore) load() (map[string][]Grant, error) {
+	state := make(map[string][]Grant)
+
+	data, err := os.ReadFile(filepath.Join(s.dir, consentFileName))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read plugin consent: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse plugin consent: %w", err)
+	}
+
+	return state, nil
+}
+
+func (s *ConsentStore) save(state map[string][]Grant) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("create plugin directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode plugin consent: %w", err)
+	}
+
+	return os.WriteFile(filepath.Join(s.dir, consentFileName), data, 0o600)
+}
+
+func (s *ConsentStore) appendLog(e ConsentEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encode consent event: %w", err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, consentLogName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("write consent log: %w", err)
+	}
+
+	_, werr := f.Write(append(data, '\n'))
+	return errors.Join(werr, f.Close())
+}
+
+// Answer is a response to a permission prompt.
+type Answer int
+
+// Prompt answers. The "always" answers are recorded in the consent store;
+// the others apply to the current run only.
+const (
+	AnswerDeny Answer = iota
+	AnswerAllowOnce
+	AnswerAllowAlways
+	AnswerDenyAlways
+)
+
+// Prompter asks the user whether a plugin may use a permission.
+type Prompter interface {
+	Ask(plugin string, perm Permission) (Answer, error)
+}
+
+// ResolvePermissions decides each requested permission of a plugin run.
+// Recorded decisions apply without asking; the remaining permissions are
+// put to prompter, and "always" answers are recorded. A nil prompter
+// (non-interactive mode) denies every permission without a recorded grant.
+func ResolvePermissions(store *ConsentStore, prompter Prompter, plugin, version string, requested []Permission) (granted, denied []Permission, err error) {
+	for _, perm := range requested {
+		decision, ok, err := store.Lookup(plugin, perm)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !ok {
+			decision = DecisionDeny
+			if prompter != nil {
+				answer, err := prompter.Ask(plugin, perm)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				switch answer {
+				case AnswerAllowOnce:
+					decision = DecisionAllow
+				case AnswerAllowAlways:
+					decision = DecisionAllow
+					err = store.Record(plugin, version, perm, DecisionAllow, "prompt")
+				case AnswerDenyAlways:
+					err = store.Record(plugin, version, perm, DecisionDeny, "prompt")
+				}
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+
+		if decision == DecisionAllow {
+			granted = append(granted, perm)
+		} else {
+			denied = append(denied, perm)
+		}
+	}
+
+	return granted, denied, nil
+}
+
+// Apply adds granted permissions to the policy.
+func (p *SandboxPolicy) Apply(granted []Permission) {
+	for _, perm := range granted {
+		switch perm.Kind() {
+		case "network":
+			p.AllowNetwork = true
+		case "read":
+			p.ReadOnlyPaths = append(p.ReadOnlyPaths, perm.Path())
+		case "write":
+			p.ReadWritePaths = append(p.ReadWritePaths, perm.Path())
+		case "env":
+			p.Env = append(p.Env, perm.Path())
+		}
+	}
+}
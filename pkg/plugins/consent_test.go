@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type scriptedPrompter struct {
+	answers map[Permission]Answer
+	asked   []Permission
+}
+
+func (p *scriptedPrompter) Ask(_ string, perm Permission) (Answer, error) {
+	p.asked = append(p.asked, perm)
+	return p.answers[perm], nil
+}
+
+func TestParsePermission(t *testing.T) {
+	perm, err := ParsePermission("network")
+	require.NoError(t, err)
+	assert.Equal(t, NetworkPermission, perm)
+
+	perm, err = ParsePermission("write:out")
+	require.NoError(t, err)
+	assert.Equal(t, "write", perm.Kind())
+	assert.True(t, filepath.IsAbs(perm.Path()))
+
+	perm, err = ParsePermission("env:GITHUB_TOKEN")
+	require.NoError(t, err)
+	assert.Equal(t, EnvPermission("GITHUB_TOKEN"), perm)
+
+	for _, bad := range []string{"", "exec:/bin/sh", "read:", "networks", "env:", "env:A=B"} {
+		_, err := ParsePermission(bad)
+		assert.Error(t, err, bad)
+	}
+
+	list, err := Permissions{Network: true, Read: []string{"/srv/data"}, Write: []string{"/tmp/out"}, Env: []string{"GITHUB_TOKEN"}}.List()
+	require.NoError(t, err)
+	assert.Equal(t, []Permission{NetworkPermission, ReadPermission("/srv/data"), WritePermission("/tmp/out"), EnvPermission("GITHUB_TOKEN")}, list)
+}
+
+func TestResolvePermissions(t *testing.T) {
+	store := NewConsentStore(t.TempDir())
+	requested := []Permission{NetworkPermission, ReadPermission("/srv/data"), WritePermission("/tmp/out"), WritePermission("/etc")}
+
+	prompter := &scriptedPrompter{answers: map[Permission]Answer{
+		NetworkPermission:           AnswerAllowAlways,
+		ReadPermission("/srv/data"): AnswerAllowOnce,
+		WritePermission("/tmp/out"): AnswerDeny,
+		WritePermission("/etc"):     AnswerDenyAlways,
+	}}
+	granted, denied, err := ResolvePermissions(store, prompter, "lint", "1.0.0", requested)
+	require.NoError(t, err)
+	assert.Equal(t, []Permission{NetworkPermission, ReadPermission("/srv/data")}, granted)
+	assert.Equal(t, []Permission{WritePermission("/tmp/out"), WritePermission("/etc")}, denied)
+
+	// 영구 결정만 기록되고 다음 실행에서는 묻지 않는다
+	prompter.asked = nil
+	_, _, err = ResolvePermissions(store, prompter, "lint", "1.0.0", requested)
+	require.NoError(t, err)
+	assert.Equal(t, []Permission{ReadPermission("/srv/data"), WritePermission("/tmp/out")}, prompter.asked)
+
+	granted, denied, err = ResolvePermissions(store, nil, "lint", "1.0.0", requested)
+	require.NoError(t, err)
+	assert.Equal(t, []Permission{NetworkPermission}, granted, "non-interactive runs use recorded grants only")
+	assert.Len(t, denied, 3)
+
+	grants, err := store.Grants()
+	require.NoError(t, err)
+	require.Len(t, grants["lint"], 2)
+	assert.Equal(t, NetworkPermission, grants["lint"][0].Permission)
+	assert.Equal(t, "prompt", grants["lint"][0].Source)
+	assert.Equal(t, WritePermission("/etc"), grants["lint"][1].Permission)
+	assert.Equal(t, DecisionDeny, grants["lint"][1].Decision)
+
+	revoked, err := store.Revoke("lint", NetworkPermission)
+	require.NoError(t, err)
+	assert.Equal(t, []Permission{NetworkPermission}, revoked)
+	_, ok, err := store.Lookup("lint", NetworkPermission)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	revoked, err = store.Revoke("lint")
+	require.NoError(t, err)
+	assert.Equal(t, []Permission{WritePermission("/etc")}, revoked)
+
+	events, err := store.Log()
+	require.NoError(t, err)
+	actions := make([]string, len(events))
+	for i, e := range events {
+		actions[i] = e.Action
+	}
+	assert.Equal(t, []string{"allow", "deny", "revoke", "revoke"}, actions)
+}
+
+func TestSandboxPolicyApply(t *testing.T) {
+	policy := SandboxPolicy{}
+	policy.Apply([]Permission{NetworkPermission, ReadPermission("/srv/data"), WritePermission("/tmp/out"), EnvPermission("GITHUB_TOKEN")})
+
+	assert.True(t, policy.AllowNetwork)
+	assert.Equal(t, []string{"/srv/data"}, policy.ReadOnlyPaths)
+	assert.Equal(t, []string{"/tmp/out"}, policy.ReadWritePaths)
+	assert.Equal(t, []string{"GITHUB_TOKEN"}, policy.Env)
+}
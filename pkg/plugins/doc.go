@@ -0,0 +1,9 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package plugins provides the plugin registry client and installer for gz.
+// Plugins are standalone executables distributed through an HTTP index or an
+// OCI registry, verified by checksum and signature before being installed,
+// and run out of process under a Sandbox that confines them with landlock,
+// seccomp and cgroups on Linux.
+package plugins
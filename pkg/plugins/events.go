@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// EventArg is the argument a plugin is run with to receive one event,
+// which it reads as JSON from stdin.
+const EventArg = "event"
+
+// EventConsumer returns the name a plugin's offset is committed under in
+// an event log.
+func EventConsumer(plugin string) string {
+	return "plugin:" + plugin
+}
+
+// Subscribes reports whether the plugin receives events of eventType.
+func (p *Installed) Subscribes(eventType string) bool {
+	return slices.Contains(p.Events, "*") || slices.Contains(p.Events, eventType)
+}
+
+// DeliverEvents runs each subscribed plugin once per event logged after
+// its committed offset and commits the event when the plugin exits
+// successfully, so every plugin processes each event once across
+// restarts. Events of types a plugin does not subscribe to are committed
+// without running it. A failing plugin stops at the failed event and gets
+// it again on the next call; the other plugins continue. The errors are
+// joined.
+func (m *Manager) DeliverEvents(ctx context.Context, log *provider.EventLog) error {
+	var errs []error
+	for _, p := range m.subscribers() {
+		err := log.Consume(ctx, EventConsumer(p.Name), func(ctx context.Context, event provider.Event) error {
+			if !p.Subscribes(event.Type) {
+				return nil
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("failed to encode event %s: %w", event.ID, err)
+			}
+
+			var stderr bytes.Buffer
+			if _, err := m.run(ctx, p.Name, []string{EventArg}, bytes.NewReader(data), io.Discard, &stderr); err != nil {
+				if msg := strings.TrimSpace(stderr.String()); msg != "" {
+					return fmt.Errorf("%w: %s", err, msg)
+				}
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// subscribers returns the active versions subscribed to any event, sorted
+// by name.
+func (m *Manager) subscribers() []Installed {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []Installed
+	for _, s := range m.slots {
+		if s.active != nil && len(s.active.info.Events) > 0 {
+			out = append(out, s.active.info)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
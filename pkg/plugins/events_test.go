@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gizzahub/gzh-cli/pkg/git/provider"
+)
+
+// subscribe records the event types of an installed plugin.
+func subscribe(t *testing.T, dir, name string, events ...string) {
+	t.Helper()
+	state, err := readState(dir)
+	require.NoError(t, err)
+	p := state[name]
+	p.Events = events
+	inst := &Installer{cfg: InstallerConfig{Dir: dir}}
+	require.NoError(t, inst.record(p))
+}
+
+func TestManagerDeliverEvents(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins")
+	}
+
+	dir := t.TempDir()
+	out := t.TempDir()
+	// audit은 push만, flaky는 모든 이벤트를 받고 처음 한 번은 실패한다
+	installScript(t, dir, "audit", "1.0.0", `[ "$1" = event ] || exit 0
+cat >> `+filepath.Join(out, "audit")+` && echo >> `+filepath.Join(out, "audit"))
+	installScript(t, dir, "flaky", "1.0.0", `[ "$1" = event ] || exit 0
+marker=`+filepath.Join(out, "failed")+`
+if [ ! -f "$marker" ]; then touch "$marker"; echo boom >&2; exit 3; fi
+cat >> `+filepath.Join(out, "flaky")+` && echo >> `+filepath.Join(out, "flaky"))
+	installScript(t, dir, "quiet", "1.0.0", `exit 0`)
+	subscribe(t, dir, "audit", "push")
+	subscribe(t, dir, "flaky", "*")
+
+	m := NewManager(ManagerConfig{Dir: dir})
+	require.NoError(t, m.Reload(context.Background()))
+
+	log, err := provider.OpenEventLog(filepath.Join(t.TempDir(), "events.log"))
+	require.NoError(t, err)
+	defer log.Close()
+	for _, event := range []provider.Event{{ID: "1", Type: "push"}, {ID: "2", Type: "issues"}, {ID: "3", Type: "push"}} {
+		_, err := log.Append(event)
+		require.NoError(t, err)
+	}
+
+	err = m.DeliverEvents(context.Background(), log)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+	assert.Equal(t, uint64(3), log.Offset(EventConsumer("audit")), "a failing plugin does not hold back the others")
+	assert.Zero(t, log.Offset(EventConsumer("flaky")))
+	assert.Zero(t, log.Offset(EventConsumer("quiet")), "plugins without subscriptions are skipped")
+
+	require.NoError(t, m.DeliverEvents(context.Background(), log))
+	require.NoError(t, m.DeliverEvents(context.Background(), log), "nothing is delivered twice")
+
+	ids := func(name string) []string {
+		data, err := os.ReadFile(filepath.Join(out, name))
+		require.NoError(t, err)
+		var ids []string
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var event provider.Event
+			require.NoError(t, json.Unmarshal([]byte(line), &event))
+			ids = append(ids, event.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"1", "3"}, ids("audit"))
+	assert.Equal(t, []string{"1", "2", "3"}, ids("flaky"))
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// stateFileName is the file tracking installed plugins inside the plugin directory.
+	stateFileName = "installed.json"
+
+	// maxArtifactSize limits the size of a downloaded plugin binary.
+	maxArtifactSize = 256 << 20
+)
+
+// ErrUnsigned is returned when a signature is required but the artifact has none.
+var ErrUnsigned = errors.New("plugin artifact is not signed")
+
+// InstallerConfig configures an Installer.
+type InstallerConfig struct {
+	// Dir is the root directory plugins are installed into.
+	Dir string
+
+	// Registry is the source plugins are downloaded from.
+	Registry Registry
+
+	// Verifiers are trusted signature verifiers. A signature is accepted if
+	// any verifier accepts it.
+	Verifiers []Verifier
+
+	// RequireSignature rejects artifacts without a verifiable signature.
+	RequireSignature bool
+
+	// GOOS and GOARCH override the target platform (defaults to the running one).
+	GOOS   string
+	GOARCH string
+}
+
+// Installer downloads, verifies and installs plugins.
+type Installer struct {
+	cfg InstallerConfig
+	mu  sync.Mutex
+}
+
+// DefaultPluginDir returns the default plugin installation directory.
+func DefaultPluginDir() string {
+	// 기본 경로: ~/.config/gzh-manager/plugins
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "gzh-manager", "plugins")
+	}
+
+	return filepath.Join(homeDir, ".config", "gzh-manager", "plugins")
+}
+
+// NewInstaller creates a new Installer.
+func NewInstaller(cfg InstallerConfig) (*Installer, error) {
+	if cfg.Dir == "" {
+		cfg.Dir = DefaultPluginDir()
+	}
+	if cfg.GOOS == "" {
+		cfg.GOOS = runtime.GOOS
+	}
+	if cfg.GOARCH == "" {
+		cfg.GOARCH = runtime.GOARCH
+	}
+	if cfg.RequireSignature && len(cfg.Verifiers) == 0 {
+		return nil, fmt.Errorf("signature verification required but no trusted keys configured")
+	}
+
+	return &Installer{cfg: cfg}, nil
+}
+
+// Dir returns the installation root.
+func (i *Installer) Dir() string {
+	return i.cfg.Dir
+}
+
+// Install downloads and installs a plugin version. An empty version installs the latest release.
+func (i *Installer) Install(ctx context.Context, name, version string) (*Installed, error) {
+	if i.cfg.Registry == nil {
+		return nil, fmt.Errorf("no plugin registry configured")
+	}
+
+	plugin, err := i.cfg.Registry.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	release, ok := plugin.Release(version)
+	if !ok {
+		return nil, fmt.Errorf("plugin %s has no version %q", name, version)
+	}
+
+	artifact, ok := release.ArtifactFor(i.cfg.GOOS, i.cfg.GOARCH)
+	if !ok {
+		return nil, fmt.Errorf("plugin %s %s is not available for %s/%s", name, release.Version, i.cfg.GOOS, i.cfg.GOARCH)
+	}
+
+	content, err := i.download(ctx, artifact.URL)
+	if err != nil {
+		return nil, fmt.Errorf("download %s %s: %w", name, release.Version, err)
+	}
+
+	// 로드 전 체크섬과 서명을 모두 확인
+	if err := VerifyChecksum(content, artifact.SHA256); err != nil {
+		return nil, fmt.Errorf("verify %s %s: %w", name, release.Version, err)
+	}
+
+	scheme, err := i.verifySignature(ctx, artifact, content)
+	if err != nil {
+		return nil, fmt.Errorf("verify %s %s: %w", name, release.Version, err)
+	}
+
+	path, err := i.writeBinary(name, release.Version, content)
+	if err != nil {
+		return nil, err
+	}
+
+	installed := Installed{
+		Name:        name,
+		Version:     release.Version,
+		Path:        path,
+		Source:      i.cfg.Registry.Name(),
+		SHA256:      artifact.SHA256,
+		Verified:    scheme,
+		InstalledAt: time.Now(),
+		Permissions: release.Permissions,
+		Events:      release.Events,
+	}
+
+	if err := i.record(installed); err != nil {
+		return nil, err
+	}
+
+	return &installed, nil
+}
+
+// Update installs the latest release of an installed plugin if it is newer.
+// It returns the installed record and whether an update took place.
+func (i *Installer) Update(ctx context.Context, name string) (*Installed, bool, error) {
+	current, err := i.Get(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	plugin, err := i.cfg.Registry.Get(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	latest, ok := plugin.Latest()
+	if !ok || CompareVersions(latest.Version, current.Version) <= 0 {
+		return current, false, nil
+	}
+
+	installed, err := i.Install(ctx, name, latest.Version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return installed, true, nil
+}
+
+// List returns installed plugins sorted by name.
+func (i *Installer) List() ([]Installed, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	state, err := i.loadState()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]Installed, 0, len(state))
+	for _, p := range state {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+
+	return list, nil
+}
+
+// Get returns the installed record for a plugin.
+func (i *Installer) Get(name string) (*Installed, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	state, err := i.loadState()
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := state[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not installed", ErrPluginNotFound, name)
+	}
+
+	return &p, nil
+}
+
+// Remove uninstalls a plugin and deletes all of its installed versions.
+func (i *Installer) Remove(name string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	state, err := i.loadState()
+	if err != nil {
+		return err
+	}
+	if _, ok := state[name]; !ok {
+		return fmt.Errorf("%w: %s is not installed", ErrPluginNotFound, name)
+	}
+
+	if err := os.RemoveAll(filepath.Join(i.cfg.Dir, name)); err != nil {
+		return fmt.Errorf("remove plugin files: %w", err)
+	}
+
+	delete(state, name)
+	return i.saveState(state)
+}
+
+func (i *Installer) download(ctx context.Context, location string) ([]byte, error) {
+	body, err := i.cfg.Registry.Open(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(body, maxArtifactSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxArtifactSize {
+		return nil, fmt.Errorf("artifact exceeds %d bytes", maxArtifactSize)
+	}
+
+	return content, nil
+}
+
+// verifySignature returns the name of the scheme that verified the artifact,
+// or an empty string if the artifact is unsigned and signatures are optional.
+func (i *Installer) verifySignature(ctx context.Context, artifact *Artifact, content []byte) (string, error) {
+	if artifact.Signature == "" || len(i.cfg.Verifiers) == 0 {
+		if i.cfg.RequireSignature {
+			return "", ErrUnsigned
+		}
+		return "", nil
+	}
+
+	signature, err := i.download(ctx, artifact.Signature)
+	if err != nil {
+		return "", fmt.Errorf("download signature: %w", err)
+	}
+
+	var errs []error
+	for _, v := range i.cfg.Verifiers {
+		if err := v.Verify(content, signature); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", v.Name(), err))
+			continue
+		}
+		return v.Name(), nil
+	}
+
+	return "", errors.Join(errs...)
+}
+
+func (i *Installer) writeBinary(name, version string, content []byte) (string, error) {
+	dir := filepath.Join(i.cfg.Dir, name, version)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create plugin directory: %w", err)
+	}
+
+	binary := "gz-" + name
+	if i.cfg.GOOS == "windows" {
+		binary += ".exe"
+	}
+	path := filepath.Join(dir, binary)
+
+	// 임시 파일에 쓴 후 rename하여 부분 설치 방지
+	tmp, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("write plugin binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("write plugin binary: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil { //nolint:gosec // 플러그인은 실행 파일
+		return "", fmt.Errorf("chmod plugin binary: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("install plugin binary: %w", err)
+	}
+
+	return path, nil
+}
+
+func (i *Installer) record(p Installed) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	state, err := i.loadState()
+	if err != nil {
+		return err
+	}
+
+	state[p.Name] = p
+	return i.saveState(state)
+}
+
+func (i *Installer) loadState() (map[string]Installed, error) {
+	state := make(map[string]Installed)
+
+	data, err := os.ReadFile(filepath.Join(i.cfg.Dir, stateFileName))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read plugin state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse plugin state: %w", err)
+	}
+
+	return state, nil
+}
+
+func (i *Installer) saveState(state map[string]Installed) error {
+	if err := os.MkdirAll(i.cfg.Dir, 0o755); err != nil {
+		return fmt.Errorf("create plugin directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode plugin state: %w", err)
+	}
+
+	return os.WriteFile(filepath.Join(i.cfg.Dir, stateFileName), data, 0o600)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRegistryServer serves an index with two releases of "hello".
+func newTestRegistryServer(t *testing.T, content, signature []byte) *httptest.Server {
+	t.Helper()
+
+	sum := sha256.Sum256(content)
+	artifact := Artifact{
+		OS: "linux", Arch: "amd64",
+		URL:    "bin/hello",
+		SHA256: hex.EncodeToString(sum[:]),
+	}
+	if signature != nil {
+		artifact.Signature = "bin/hello.minisig"
+	}
+
+	index := Index{
+		APIVersion: "v1",
+		Plugins: []Plugin{{
+			Name:        "hello",
+			Description: "Say hello",
+			Tags:        []string{"demo"},
+			Versions: []Release{
+				{Version: "1.0.0", Artifacts: []Artifact{artifact}},
+				{Version: "1.1.0", Artifacts: []Artifact{artifact}},
+			},
+		}},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/index.json", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(index)
+	})
+	mux.HandleFunc("/bin/hello", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(content)
+	})
+	mux.HandleFunc("/bin/hello.minisig", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(signature)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func newTestInstaller(t *testing.T, srv *httptest.Server, cfg InstallerConfig) *Installer {
+	t.Helper()
+
+	reg, err := NewRegistry(srv.URL+"/index.json", srv.Client())
+	require.NoError(t, err)
+
+	cfg.Dir = t.TempDir()
+	cfg.Registry = reg
+	cfg.GOOS, cfg.GOARCH = "linux", "amd64"
+
+	inst, err := NewInstaller(cfg)
+	require.NoError(t, err)
+
+	return inst
+}
+
+func TestHTTPRegistry_Search(t *testing.T) {
+	srv := newTestRegistryServer(t, []byte("bin"), nil)
+	reg, err := NewRegistry(srv.URL+"/index.json", srv.Client())
+	require.NoError(t, err)
+
+	found, err := reg.Search(context.Background(), "demo")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "hello", found[0].Name)
+
+	found, err = reg.Search(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	_, err = reg.Get(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrPluginNotFound)
+}
+
+func TestInstaller_InstallVerified(t *testing.T) {
+	content := []byte("#!/bin/sh\necho hello\n")
+	pubKey, signature := minisignFixture(t, content, true)
+	verifier, err := ParseVerifier(pubKey)
+	require.NoError(t, err)
+
+	srv := newTestRegistryServer(t, content, signature)
+	inst := newTestInstaller(t, srv, InstallerConfig{
+		Verifiers:        []Verifier{verifier},
+		RequireSignature: true,
+	})
+
+	installed, err := inst.Install(context.Background(), "hello", "1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", installed.Version)
+	assert.Equal(t, "minisign", installed.Verified)
+
+	data, err := os.ReadFile(installed.Path)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	updated, changed, err := inst.Update(context.Background(), "hello")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "1.1.0", updated.Version)
+
+	list, err := inst.List()
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "1.1.0", list[0].Version)
+
+	require.NoError(t, inst.Remove("hello"))
+	_, err = inst.Get("hello")
+	assert.ErrorIs(t, err, ErrPluginNotFound)
+}
+
+func TestInstaller_RejectsBadSignature(t *testing.T) {
+	content := []byte("binary")
+	_, signature := minisignFixture(t, content, false)
+	otherKey, _ := minisignFixture(t, content, false)
+	verifier, err := ParseVerifier(otherKey)
+	require.NoError(t, err)
+
+	srv := newTestRegistryServer(t, content, signature)
+	inst := newTestInstaller(t, srv, InstallerConfig{Verifiers: []Verifier{verifier}})
+
+	_, err = inst.Install(context.Background(), "hello", "")
+	assert.ErrorIs(t, err, ErrSignatureInvalid)
+
+	list, err := inst.List()
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestInstaller_RequireSignatureRejectsUnsigned(t *testing.T) {
+	pubKey, _ := minisignFixture(t, []byte("x"), false)
+	verifier, err := ParseVerifier(pubKey)
+	require.NoError(t, err)
+
+	srv := newTestRegistryServer(t, []byte("binary"), nil)
+	inst := newTestInstaller(t, srv, InstallerConfig{
+		Verifiers:        []Verifier{verifier},
+		RequireSignature: true,
+	})
+
+	_, err = inst.Install(context.Background(), "hello", "")
+	assert.ErrorIs(t, err, ErrUnsigned)
+}
+
+func TestOCIRegistry_Get(t *testing.T) {
+	content := []byte("oci plugin")
+	sum := sha256.Sum256(content)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/gz/plugins/hello/tags/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer anon" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="http://`+r.Host+`/token",service="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"tags":["0.1.0"]}`))
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"token":"anon"}`))
+	})
+	mux.HandleFunc("/v2/gz/plugins/hello/manifests/0.1.0", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(ociManifest{
+			Annotations: map[string]string{ociAnnotationDescription: "OCI hello"},
+			Layers: []ociDescriptor{{
+				Digest:      digest,
+				Annotations: map[string]string{ociAnnotationPlatform: "linux/amd64"},
+			}},
+		})
+	})
+	mux.HandleFunc("/v2/gz/plugins/hello/blobs/"+digest, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(content)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	reg, err := NewRegistry("oci://"+strings.TrimPrefix(srv.URL, "http://")+"/gz/plugins", srv.Client())
+	require.NoError(t, err)
+
+	inst, err := NewInstaller(InstallerConfig{Dir: t.TempDir(), Registry: reg, GOOS: "linux", GOARCH: "amd64"})
+	require.NoError(t, err)
+
+	installed, err := inst.Install(context.Background(), "hello", "")
+	require.NoError(t, err)
+	assert.Equal(t, "0.1.0", installed.Version)
+	assert.Empty(t, installed.Verified)
+}
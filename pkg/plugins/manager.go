@@ -0,0 +1,650 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/gizzahub/gzh-cli/internal/trace"
+)
+
+// ErrPluginNotLoaded is returned when running a plugin the Manager has not loaded.
+var ErrPluginNotLoaded = errors.New("plugin not loaded")
+
+// Manager event types.
+const (
+	EventLoaded     = "loaded"
+	EventSwitched   = "switched"
+	EventRejected   = "rejected"
+	EventRolledBack = "rolled-back"
+	EventDrained    = "drained"
+	EventUnloaded   = "unloaded"
+	EventError      = "error"
+)
+
+// CommandFunc prepares the process for one plugin execution. The returned
+// cleanup function is called once the process has finished.
+type CommandFunc func(ctx context.Context, p *Installed, args []string) (*exec.Cmd, func(), error)
+
+// HealthCheckFunc checks that a plugin version works before and after it
+// starts receiving executions.
+type HealthCheckFunc func(ctx context.Context, p *Installed) error
+
+// ManagerConfig configures a Manager.
+type ManagerConfig struct {
+	// Dir is the plugin installation directory watched for new versions.
+	Dir string
+
+	// Command builds plugin processes; the binary is run directly, with the
+	// same restricted environment as in the sandbox, when nil.
+	// Use SandboxCommand to confine executions.
+	Command CommandFunc
+
+	// HealthCheck defaults to running the plugin with --version, which
+	// must exit successfully within HealthTimeout.
+	HealthCheck   HealthCheckFunc
+	HealthTimeout time.Duration
+
+	// Probation is how long after a switch the new version is checked
+	// again. The previous version stays loaded until then and takes over
+	// again if the check fails. Zero disables the second check.
+	Probation time.Duration
+
+	// DrainTimeout bounds how long a replaced version may finish in-flight
+	// executions before they are cancelled.
+	DrainTimeout time.Duration
+
+	// Debounce delays reloads until the plugin directory has been quiet
+	// for this long, so that an install in progress is not picked up.
+	Debounce time.Duration
+
+	// OnEvent is told about loads, switches, rollbacks and drains.
+	OnEvent func(ManagerEvent)
+}
+
+// ManagerEvent reports a change of the loaded plugin versions.
+type ManagerEvent struct {
+	Type     string
+	Plugin   string
+	Version  string
+	Previous string
+	Err      error
+}
+
+func (e ManagerEvent) String() string {
+	switch {
+	case e.Err != nil:
+		return fmt.Sprintf("%s %s %s: %v", e.Type, e.Plugin, e.Version, e.Err)
+	case e.Previous != "":
+		return fmt.Sprintf("%s %s %s (was %s)", e.Type, e.Plugin, e.Version, e.Previous)
+	default:
+		return fmt.Sprintf("%s %s %s", e.Type, e.Plugin, e.Version)
+	}
+}
+
+// LoadedVersion describes a loaded plugin version.
+type LoadedVersion struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Active   bool   `json:"active"`
+	InFlight int    `json:"inFlight"`
+	// Draining versions finish their executions but take no new ones.
+	Draining bool `json:"draining,omitempty"`
+}
+
+// Manager runs installed plugins and hot-reloads them. New versions are
+// loaded alongside the running one, health checked, and then receive all
+// new executions while the old version drains its in-flight ones.
+type Manager struct {
+	cfg ManagerConfig
+
+	mu       sync.Mutex
+	slots    map[string]*slot
+	rejected map[string]string // 헬스 체크에 실패한 버전은 재시도하지 않음
+	wg       sync.WaitGroup
+	closed   bool
+}
+
+// slot holds the versions of one plugin. previous is the version replaced
+// by active, kept for rollback until active passes probation.
+type slot struct {
+	active   *loaded
+	previous *Installed
+	draining []*loaded
+}
+
+// loaded is one version that executions are counted against.
+type loaded struct {
+	info     Installed
+	ctx      context.Context
+	cancel   context.CancelFunc
+	mu       sync.Mutex
+	inflight int
+	draining bool
+	idle     chan struct{}
+}
+
+func newLoaded(p Installed) *loaded {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &loaded{info: p, ctx: ctx, cancel: cancel}
+}
+
+// acquire counts an execution; it fails once the version drains.
+func (l *loaded) acquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.draining {
+		return false
+	}
+	l.inflight++
+	return true
+}
+
+func (l *loaded) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inflight--
+	if l.draining && l.inflight == 0 && l.idle != nil {
+		close(l.idle)
+		l.idle = nil
+	}
+}
+
+// drain stops new executions and returns a channel closed when the
+// in-flight ones are done.
+func (l *loaded) drain() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.draining = true
+	idle := make(chan struct{})
+	if l.inflight == 0 {
+		close(idle)
+	} else {
+		l.idle = idle
+	}
+	return idle
+}
+
+// NewManager creates a manager for the plugins installed in cfg.Dir.
+// Call Reload to load them and Watch to follow later installs.
+func NewManager(cfg ManagerConfig) *Manager {
+	if cfg.Dir == "" {
+		cfg.Dir = DefaultPluginDir()
+	}
+	if cfg.HealthTimeout <= 0 {
+		cfg.HealthTimeout = 10 * time.Second
+	}
+	if cfg.DrainTimeout <= 0 {
+		cfg.DrainTimeout = 5 * time.Minute
+	}
+	if cfg.Debounce <= 0 {
+		cfg.Debounce = 500 * time.Millisecond
+	}
+
+	m := &Manager{cfg: cfg, slots: make(map[string]*slot), rejected: make(map[string]string)}
+	if m.cfg.HealthCheck == nil {
+		m.cfg.HealthCheck = m.versionHealthCheck
+	}
+	return m
+}
+
+// Run executes the active version of a plugin and returns the version that
+// ran. Executions started before a switch finish on the version they
+// started with.
+func (m *Manager) Run(ctx context.Context, name string, args []string, stdout, stderr io.Writer) (string, error) {
+	return m.run(ctx, name, args, nil, stdout, stderr)
+}
+
+func (m *Manager) run(ctx context.Context, name string, args []string, stdin io.Reader, stdout, stderr io.Writer) (string, error) {
+	l, err := m.acquire(name)
+	if err != nil {
+		return "", err
+	}
+	defer l.release()
+
+	// 드레인 제한 시간이 지나면 실행 중인 프로세스도 취소된다
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(l.ctx, cancel)
+	defer stop()
+
+	cmd, cleanup, err := m.command(ctx, &l.info, args)
+	if err != nil {
+		return l.info.Version, err
+	}
+	defer cleanup()
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return l.info.Version, fmt.Errorf("plugin %s %s exited with code %d", name, l.info.Version, exitErr.ExitCode())
+		}
+		return l.info.Version, fmt.Errorf("run plugin %s %s: %w", name, l.info.Version, err)
+	}
+
+	return l.info.Version, nil
+}
+
+func (m *Manager) acquire(name string) (*loaded, error) {
+	for {
+		m.mu.Lock()
+		s, ok := m.slots[name]
+		var l *loaded
+		if ok {
+			l = s.active
+		}
+		m.mu.Unlock()
+
+		if l == nil {
+			return nil, fmt.Errorf("%w: %s", ErrPluginNotLoaded, name)
+		}
+		if l.acquire() {
+			return l, nil
+		}
+		// 전환 직후라면 새 활성 버전으로 다시 시도
+	}
+}
+
+func (m *Manager) command(ctx context.Context, p *Installed, args []string) (*exec.Cmd, func(), error) {
+	if m.cfg.Command != nil {
+		return m.cfg.Command(ctx, p, args)
+	}
+	cmd := exec.CommandContext(ctx, p.Path, args...)
+	cmd.Env = trace.AppendEnv(ctx, pluginEnv(nil))
+	return cmd, func() {}, nil
+}
+
+// Loaded lists the loaded versions, active ones first for each plugin.
+func (m *Manager) Loaded() []LoadedVersion {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var out []LoadedVersion
+	for _, s := range m.slots {
+		if s.active != nil {
+			out = append(out, s.active.describe(true))
+		}
+		for _, l := range s.draining {
+			out = append(out, l.describe(false))
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (l *loaded) describe(active bool) LoadedVersion {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return LoadedVersion{Name: l.info.Name, Version: l.info.Version, Active: active, InFlight: l.inflight, Draining: l.draining}
+}
+
+// Reload brings the loaded versions in line with the installed ones: new
+// plugins are loaded, updated ones switched after a health check and
+// removed ones drained. A failing plugin does not stop the others; the
+// errors are joined.
+func (m *Manager) Reload(ctx context.Context) error {
+	state, err := readState(m.cfg.Dir)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, p := range state {
+		if err := m.reloadPlugin(ctx, p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	m.mu.Lock()
+	var removed []string
+	for name := range m.slots {
+		if _, ok := state[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	m.mu.Unlock()
+	for _, name := range removed {
+		m.unload(name)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (m *Manager) reloadPlugin(ctx context.Context, p Installed) error {
+	m.mu.Lock()
+	s := m.slots[p.Name]
+	current := ""
+	if s != nil && s.active != nil {
+		current = s.active.info.Version
+	}
+	rejected := m.rejected[p.Name] == p.Version
+	m.mu.Unlock()
+
+	if current == p.Version || rejected {
+		return nil
+	}
+
+	if err := m.check(ctx, &p); err != nil {
+		m.mu.Lock()
+		m.rejected[p.Name] = p.Version
+		m.mu.Unlock()
+		m.emit(ManagerEvent{Type: EventRejected, Plugin: p.Name, Version: p.Version, Previous: current, Err: err})
+		return fmt.Errorf("plugin %s %s: %w", p.Name, p.Version, err)
+	}
+
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	if s == nil {
+		s = &slot{}
+		m.slots[p.Name] = s
+	}
+	old := s.active
+	s.active = newLoaded(p)
+	if old != nil {
+		prev := old.info
+		s.previous = &prev
+	}
+	m.mu.Unlock()
+
+	if old == nil {
+		m.emit(ManagerEvent{Type: EventLoaded, Plugin: p.Name, Version: p.Version})
+		return nil
+	}
+
+	m.emit(ManagerEvent{Type: EventSwitched, Plugin: p.Name, Version: p.Version, Previous: old.info.Version})
+	m.retire(p.Name, old)
+	if m.cfg.Probation > 0 {
+		m.wg.Add(1)
+		go m.probation(p)
+	}
+	return nil
+}
+
+// check verifies the binary against its recorded checksum and runs the
+// health check.
+func (m *Manager) check(ctx context.Context, p *Installed) error {
+	if p.SHA256 != "" {
+		content, err := os.ReadFile(p.Path)
+		if err != nil {
+			return fmt.Errorf("read plugin binary: %w", err)
+		}
+		if err := VerifyChecksum(content, p.SHA256); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.HealthTimeout)
+	defer cancel()
+	if err := m.cfg.HealthCheck(ctx, p); err != nil {
+		return fmt.Errorf("health check failed: %w", err)
+	}
+	return nil
+}
+
+// probation checks a switched version again and rolls back to the
+// previous version if it fails.
+func (m *Manager) probation(p Installed) {
+	defer m.wg.Done()
+
+	timer := time.NewTimer(m.cfg.Probation)
+	defer timer.Stop()
+	<-timer.C
+
+	m.mu.Lock()
+	s := m.slots[p.Name]
+	if m.closed || s == nil || s.active == nil || s.active.info.Version != p.Version {
+		m.mu.Unlock()
+		return
+	}
+	m.mu.Unlock()
+
+	err := m.check(context.Background(), &p)
+
+	m.mu.Lock()
+	if s.active == nil || s.active.info.Version != p.Version || s.previous == nil {
+		m.mu.Unlock()
+		return
+	}
+	previous := *s.previous
+	s.previous = nil
+	if err == nil {
+		m.mu.Unlock()
+		return
+	}
+	failed := s.active
+	s.active = newLoaded(previous)
+	m.rejected[p.Name] = p.Version
+	m.mu.Unlock()
+
+	m.emit(ManagerEvent{Type: EventRolledBack, Plugin: p.Name, Version: previous.Version, Previous: p.Version, Err: err})
+	m.retire(p.Name, failed)
+}
+
+// retire drains l in the background, cancelling its executions after the
+// drain timeout.
+func (m *Manager) retire(name string, l *loaded) {
+	m.mu.Lock()
+	if s := m.slots[name]; s != nil {
+		s.draining = append(s.draining, l)
+	}
+	m.mu.Unlock()
+
+	idle := l.drain()
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		timer := time.NewTimer(m.cfg.DrainTimeout)
+		defer timer.Stop()
+
+		var err error
+		select {
+		case <-idle:
+		case <-timer.C:
+			err = fmt.Errorf("in-flight executions cancelled after %s", m.cfg.DrainTimeout)
+			l.cancel()
+			<-idle
+		}
+		l.cancel()
+
+		m.mu.Lock()
+		if s := m.slots[name]; s != nil {
+			for i, d := range s.draining {
+				if d == l {
+					s.draining = append(s.draining[:i], s.draining[i+1:]...)
+					break
+				}
+			}
+		}
+		m.mu.Unlock()
+		m.emit(ManagerEvent{Type: EventDrained, Plugin: name, Version: l.info.Version, Err: err})
+	}()
+}
+
+func (m *Manager) unload(name string) {
+	m.mu.Lock()
+	s := m.slots[name]
+	if s == nil || s.active == nil {
+		m.mu.Unlock()
+		return
+	}
+	l := s.active
+	s.active = nil
+	s.previous = nil
+	m.mu.Unlock()
+
+	m.emit(ManagerEvent{Type: EventUnloaded, Plugin: name, Version: l.info.Version})
+	m.retire(name, l)
+}
+
+// Watch reloads whenever the plugin directory changes until ctx is done.
+// Reload errors are reported as events and do not stop watching.
+func (m *Manager) Watch(ctx context.Context) error {
+	if err := os.MkdirAll(m.cfg.Dir, 0o755); err != nil {
+		return fmt.Errorf("create plugin directory: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watch plugin directory: %w", err)
+	}
+	defer watcher.Close()
+	if err := watcher.Add(m.cfg.Dir); err != nil {
+		return fmt.Errorf("watch plugin directory: %w", err)
+	}
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			// 설치기는 바이너리를 쓴 뒤 installed.json을 갱신한다
+			if filepath.Base(ev.Name) == stateFileName {
+				debounce = time.After(m.cfg.Debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			m.emit(ManagerEvent{Type: EventError, Err: err})
+		case <-debounce:
+			debounce = nil
+			if err := m.Reload(ctx); err != nil {
+				m.emit(ManagerEvent{Type: EventError, Err: err})
+			}
+		}
+	}
+}
+
+// Close stops new executions and waits for in-flight ones, cancelling
+// those still running when ctx is done.
+func (m *Manager) Close(ctx context.Context) error {
+	m.mu.Lock()
+	m.closed = true
+	names := make([]string, 0, len(m.slots))
+	for name := range m.slots {
+		names = append(names, name)
+	}
+	m.mu.Unlock()
+
+	for _, name := range names {
+		m.unload(name)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		m.mu.Lock()
+		for _, s := range m.slots {
+			for _, l := range s.draining {
+				l.cancel()
+			}
+		}
+		m.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (m *Manager) emit(e ManagerEvent) {
+	if m.cfg.OnEvent != nil {
+		m.cfg.OnEvent(e)
+	}
+}
+
+// readState reads the installer state without taking the installer lock,
+// which belongs to another process when plugins are installed with
+// 'gz plugin install' while a server runs.
+func readState(dir string) (map[string]Installed, error) {
+	return (&Installer{cfg: InstallerConfig{Dir: dir}}).loadState()
+}
+
+// versionHealthCheck runs the plugin with --version the way executions
+// are run, so that a sandboxed server checks it inside the sandbox.
+func (m *Manager) versionHealthCheck(ctx context.Context, p *Installed) error {
+	cmd, cleanup, err := m.command(ctx, p, []string{"--version"})
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(out.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// SandboxCommand returns a CommandFunc that runs plugins in the sandbox
+// with policy plus the permissions already granted to each plugin; nothing
+// is prompted for. Each plugin gets a data directory below dir.
+func SandboxCommand(dir string, policy SandboxPolicy) CommandFunc {
+	consent := NewConsentStore(dir)
+
+	return func(ctx context.Context, p *Installed, args []string) (*exec.Cmd, func(), error) {
+		requested, err := p.Permissions.List()
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid permissions in the manifest of %s: %w", p.Name, err)
+		}
+		granted, _, err := ResolvePermissions(consent, nil, p.Name, p.Version, requested)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		dataDir := filepath.Join(dir, "data", p.Name)
+		if err := os.MkdirAll(dataDir, 0o755); err != nil {
+			return nil, nil, fmt.Errorf("create plugin data directory: %w", err)
+		}
+
+		pol := policy
+		pol.ReadOnlyPaths = append([]string(nil), policy.ReadOnlyPaths...)
+		pol.ReadWritePaths = append(append([]string(nil), policy.ReadWritePaths...), dataDir)
+		pol.Apply(granted)
+
+		sandbox, err := NewSandbox(pol)
+		if err != nil {
+			return nil, nil, err
+		}
+		cmd, cleanup, err := sandbox.Command(ctx, p.Path, args...)
+		if err != nil {
+			return nil, nil, err
+		}
+		cmd.Env = append(trace.AppendEnv(ctx, cmd.Env), "GZ_PLUGIN_DATA_DIR="+dataDir)
+		return cmd, cleanup, nil
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// installScript installs a shell script as a plugin version and records it
+// in the installer state, as 'gz plugin install' would.
+func installScript(t *testing.T, dir, name, version, script string) {
+	t.Helper()
+
+	content := []byte("#!/bin/sh\n" + script + "\n")
+	path := filepath.Join(dir, name, version, "gz-"+name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, content, 0o755)) //nolint:gosec // test plugin
+
+	sum := sha256.Sum256(content)
+	inst := &Installer{cfg: InstallerConfig{Dir: dir}}
+	require.NoError(t, inst.record(Installed{Name: name, Version: version, Path: path, SHA256: hex.EncodeToString(sum[:])}))
+}
+
+type eventLog struct {
+	mu     sync.Mutex
+	events []ManagerEvent
+}
+
+func (l *eventLog) add(e ManagerEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+}
+
+func (l *eventLog) types() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var types []string
+	for _, e := range l.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestManagerSwitchDrainsInFlight(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins")
+	}
+
+	dir := t.TempDir()
+	gate := filepath.Join(t.TempDir(), "gate")
+	installScript(t, dir, "hello", "1.0.0", `[ "$1" = wait ] && while [ ! -f `+gate+` ]; do sleep 0.01; done; echo v1`)
+
+	var log eventLog
+	m := NewManager(ManagerConfig{Dir: dir, OnEvent: log.add, DrainTimeout: 10 * time.Second})
+	require.NoError(t, m.Reload(context.Background()))
+
+	// An execution is in flight on 1.0.0 while 1.1.0 is installed
+	var inflight bytes.Buffer
+	done := make(chan string, 1)
+	go func() {
+		version, err := m.Run(context.Background(), "hello", []string{"wait"}, &inflight, os.Stderr)
+		assert.NoError(t, err)
+		done <- version
+	}()
+	require.Eventually(t, func() bool {
+		loaded := m.Loaded()
+		return len(loaded) == 1 && loaded[0].InFlight == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	installScript(t, dir, "hello", "1.1.0", "echo v2")
+	require.NoError(t, m.Reload(context.Background()))
+
+	var out bytes.Buffer
+	version, err := m.Run(context.Background(), "hello", nil, &out, os.Stderr)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", version)
+	assert.Equal(t, "v2\n", out.String())
+
+	loaded := m.Loaded()
+	require.Len(t, loaded, 2, "both versions are loaded while the old one drains")
+	assert.True(t, loaded[0].Active)
+	assert.True(t, loaded[1].Draining)
+
+	require.NoError(t, os.WriteFile(gate, nil, 0o644))
+	assert.Equal(t, "1.0.0", <-done)
+	assert.Equal(t, "v1\n", inflight.String())
+	require.Eventually(t, func() bool { return len(m.Loaded()) == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, m.Close(context.Background()))
+	assert.Equal(t, []string{EventLoaded, EventSwitched, EventDrained, EventUnloaded, EventDrained}, log.types())
+}
+
+func TestManagerRejectsUnhealthyVersion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins")
+	}
+
+	dir := t.TempDir()
+	installScript(t, dir, "hello", "1.0.0", "echo v1")
+
+	var log eventLog
+	m := NewManager(ManagerConfig{Dir: dir, OnEvent: log.add})
+	require.NoError(t, m.Reload(context.Background()))
+
+	installScript(t, dir, "hello", "2.0.0", "exit 3")
+	require.Error(t, m.Reload(context.Background()))
+	require.NoError(t, m.Reload(context.Background()), "a rejected version is not retried")
+
+	version, err := m.Run(context.Background(), "hello", nil, &bytes.Buffer{}, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", version)
+	assert.Equal(t, []string{EventLoaded, EventRejected}, log.types())
+
+	// A tampered binary fails its checksum
+	installScript(t, dir, "hello", "2.0.1", "echo v2")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hello", "2.0.1", "gz-hello"), []byte("#!/bin/sh\necho evil\n"), 0o755)) //nolint:gosec // test plugin
+	err = m.Reload(context.Background())
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestManagerRollsBackAfterProbation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins")
+	}
+
+	dir := t.TempDir()
+	installScript(t, dir, "hello", "1.0.0", "echo v1")
+	installScript(t, dir, "other", "1.0.0", "echo other")
+
+	var checks atomic.Int32
+	var log eventLog
+	m := NewManager(ManagerConfig{
+		Dir:       dir,
+		OnEvent:   log.add,
+		Probation: 20 * time.Millisecond,
+		HealthCheck: func(_ context.Context, p *Installed) error {
+			// 2.0.0 passes the check before the switch and fails the one after
+			if p.Version == "2.0.0" && checks.Add(1) > 1 {
+				return errors.New("unhealthy")
+			}
+			return nil
+		},
+	})
+	require.NoError(t, m.Reload(context.Background()))
+
+	installScript(t, dir, "hello", "2.0.0", "echo v2")
+	require.NoError(t, m.Reload(context.Background()))
+
+	require.Eventually(t, func() bool {
+		version, err := m.Run(context.Background(), "hello", nil, &bytes.Buffer{}, &bytes.Buffer{})
+		return err == nil && version == "1.0.0"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, m.Close(context.Background()))
+	assert.Contains(t, log.types(), EventRolledBack)
+}
+
+func TestManagerWatch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script plugins")
+	}
+
+	dir := t.TempDir()
+	m := NewManager(ManagerConfig{Dir: dir, Debounce: 10 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watching := make(chan error, 1)
+	go func() { watching <- m.Watch(ctx) }()
+	time.Sleep(50 * time.Millisecond)
+
+	installScript(t, dir, "hello", "1.0.0", "echo v1")
+	require.Eventually(t, func() bool {
+		_, err := m.Run(context.Background(), "hello", nil, &bytes.Buffer{}, &bytes.Buffer{})
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+
+	_, err := m.Run(context.Background(), "missing", nil, &bytes.Buffer{}, &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrPluginNotLoaded)
+
+	cancel()
+	require.NoError(t, <-watching)
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// OCI 매니페스트에서 플러그인 정보를 식별하는 어노테이션 키.
+const (
+	ociAnnotationPlatform     = "dev.gzh.plugin.platform"
+	ociAnnotationSignatureFor = "dev.gzh.plugin.signature-for"
+	ociAnnotationDescription  = "org.opencontainers.image.description"
+	ociManifestMediaType      = "application/vnd.oci.image.manifest.v1+json"
+)
+
+// OCIRegistry reads plugins from an OCI distribution registry.
+// Each plugin is a repository under the configured namespace, each tag a
+// release and each layer a platform binary annotated with its platform.
+type OCIRegistry struct {
+	host      string
+	namespace string
+	scheme    string
+	client    *http.Client
+
+	mu     sync.Mutex
+	tokens map[string]string // repository -> bearer token
+}
+
+type ociManifest struct {
+	MediaType   string            `json:"mediaType"`
+	Annotations map[string]string `json:"annotations"`
+	Layers      []ociDescriptor   `json:"layers"`
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// NewOCIRegistry creates a registry client for a reference such as
+// ghcr.io/gizzahub/gz-plugins.
+func NewOCIRegistry(reference string, client *http.Client) (*OCIRegistry, error) {
+	host, namespace, ok := strings.Cut(strings.Trim(reference, "/"), "/")
+	if !ok || host == "" || namespace == "" {
+		return nil, fmt.Errorf("invalid OCI registry reference: %s", reference)
+	}
+
+	scheme := "https"
+	// 로컬 레지스트리는 TLS 없이 접근하는 경우가 많음
+	if strings.HasPrefix(host, "localhost") || strings.HasPrefix(host, "127.0.0.1") {
+		scheme = "http"
+	}
+
+	return &OCIRegistry{
+		host:      host,
+		namespace: namespace,
+		scheme:    scheme,
+		client:    client,
+		tokens:    make(map[string]string),
+	}, nil
+}
+
+// Name returns the registry reference.
+func (r *OCIRegistry) Name() string {
+	return "oci://" + r.host + "/" + r.namespace
+}
+
+// Search lists plugin repositories using the registry catalog API.
+func (r *OCIRegistry) Search(ctx context.Context, query string) ([]Plugin, error) {
+	var catalog struct {
+		Repositories []string `json:"repositories"`
+	}
+	if err := r.getJSON(ctx, "", "/v2/_catalog?n=1000", "", &catalog); err != nil {
+		return nil, fmt.Errorf("list OCI catalog: %w", err)
+	}
+
+	prefix := r.namespace + "/"
+	var found []Plugin
+	for _, repo := range catalog.Repositories {
+		if !strings.HasPrefix(repo, prefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(repo, prefix)
+		versions, err := r.tags(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		p := Plugin{Name: name}
+		for _, v := range versions {
+			p.Versions = append(p.Versions, Release{Version: v})
+		}
+		found = append(found, p)
+	}
+
+	return filterPlugins(found, query), nil
+}
+
+// Get returns the plugin with all releases resolved from their manifests.
+func (r *OCIRegistry) Get(ctx context.Context, name string) (*Plugin, error) {
+	versions, err := r.tags(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPluginNotFound, name)
+	}
+
+	plugin := &Plugin{Name: name}
+	for _, version := range versions {
+		var manifest ociManifest
+		path := fmt.Sprintf("/v2/%s/manifests/%s", r.repository(name), version)
+		if err := r.getJSON(ctx, name, path, ociManifestMediaType, &manifest); err != nil {
+			return nil, fmt.Errorf("fetch manifest %s:%s: %w", name, version, err)
+		}
+
+		if desc := manifest.Annotations[ociAnnotationDescription]; desc != "" {
+			plugin.Description = desc
+		}
+		plugin.Versions = append(plugin.Versions, r.releaseFromManifest(name, version, &manifest))
+	}
+
+	return plugin, nil
+}
+
+// Open downloads a blob referenced by an oci:// location.
+func (r *OCIRegistry) Open(ctx context.Context, location string) (io.ReadCloser, error) {
+	ref := strings.TrimPrefix(location, "oci://")
+	repoPath, digest, ok := strings.Cut(ref, "@")
+	if !ok {
+		return nil, fmt.Errorf("invalid OCI blob location: %s", location)
+	}
+
+	name := strings.TrimPrefix(strings.TrimPrefix(repoPath, r.host+"/"), r.namespace+"/")
+	path := fmt.Sprintf("/v2/%s/blobs/%s", r.repository(name), digest)
+
+	return r.get(ctx, name, path, "")
+}
+
+func (r *OCIRegistry) releaseFromManifest(name, version string, manifest *ociManifest) Release {
+	release := Release{Version: version}
+	signatures := make(map[string]string)
+
+	for _, layer := range manifest.Layers {
+		if target := layer.Annotations[ociAnnotationSignatureFor]; target != "" {
+			signatures[target] = r.blobLocation(name, layer.Digest)
+		}
+	}
+
+	for _, layer := range manifest.Layers {
+		platform := layer.Annotations[ociAnnotationPlatform]
+		goos, goarch, ok := strings.Cut(platform, "/")
+		if !ok {
+			continue
+		}
+
+		release.Artifacts = append(release.Artifacts, Artifact{
+			OS:        goos,
+			Arch:      goarch,
+			URL:       r.blobLocation(name, layer.Digest),
+			SHA256:    strings.TrimPrefix(layer.Digest, "sha256:"),
+			Signature: signatures[platform],
+		})
+	}
+
+	return release
+}
+
+func (r *OCIRegistry) blobLocation(name, digest string) string {
+	return fmt.Sprintf("oci://%s/%s@%s", r.host, r.repository(name), digest)
+}
+
+func (r *OCIRegistry) repository(name string) string {
+	return r.namespace + "/" + name
+}
+
+func (r *OCIRegistry) tags(ctx context.Context, name string) ([]string, error) {
+	var list struct {
+		Tags []string `json:"tags"`
+	}
+	if err := r.getJSON(ctx, name, fmt.Sprintf("/v2/%s/tags/list", r.repository(name)), "", &list); err != nil {
+		return nil, fmt.Errorf("list tags for %s: %w", name, err)
+	}
+
+	return list.Tags, nil
+}
+
+func (r *OCIRegistry) getJSON(ctx context.Context, name, path, accept string, out any) error {
+	body, err := r.get(ctx, name, path, accept)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	return json.NewDecoder(body).Decode(out)
+}
+
+// get performs an authenticated GET, negotiating an anonymous bearer token
+// when the registry responds with a challenge.
+func (r *OCIRegistry) get(ctx context.Context, name, path, accept string) (io.ReadCloser, error) {
+	target := r.scheme + "://" + r.host + path
+
+	for attempt := 0; attempt < 2; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, http.NoBody)
+		if err != nil {
+			return nil, fmt.Errorf("create request: %w", err)
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+
+		r.mu.Lock()
+		token := r.tokens[name]
+		r.mu.Unlock()
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := r.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("request %s: %w", target, err)
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+
+			if err := r.authenticate(ctx, name, challenge); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			return nil, fmt.Errorf("%w: %s", ErrPluginNotFound, name)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("request %s: unexpected status %d", target, resp.StatusCode)
+		}
+
+		return resp.Body, nil
+	}
+
+	return nil, fmt.Errorf("request %s: authentication failed", target)
+}
+
+func (r *OCIRegistry) authenticate(ctx context.Context, name, challenge string) error {
+	params := parseBearerChallenge(challenge)
+	realm := params["realm"]
+	if realm == "" {
+		return fmt.Errorf("registry requires unsupported authentication: %q", challenge)
+	}
+
+	query := url.Values{}
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	if name != "" {
+		query.Set("scope", fmt.Sprintf("repository:%s:pull", r.repository(name)))
+	} else if scope := params["scope"]; scope != "" {
+		query.Set("scope", scope)
+	}
+
+	body, err := httpGet(ctx, r.client, realm+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("fetch registry token: %w", err)
+	}
+	defer body.Close()
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"` //nolint:tagliatelle // OCI 토큰 응답 형식
+	}
+	if err := json.NewDecoder(body).Decode(&tokenResp); err != nil {
+		return fmt.Errorf("decode registry token: %w", err)
+	}
+
+	token := tokenResp.Token
+	if token == "" {
+		token = tokenResp.AccessToken
+	}
+
+	r.mu.Lock()
+	r.tokens[name] = token
+	r.mu.Unlock()
+
+	return nil
+}
+
+// parseBearerChallenge parses a WWW-Authenticate Bearer header into its parameters.
+func parseBearerChallenge(header string) map[string]string {
+	params := make(map[string]string)
+
+	rest, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return params
+	}
+
+	for _, part := range strings.Split(rest, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok {
+			params[key] = strings.Trim(value, `"`)
+		}
+	}
+
+	return params
+}
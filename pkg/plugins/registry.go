@@ -0,0 +1,207 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/httpclient"
+)
+
+// DefaultRegistryURL is the registry used when none is configured.
+const DefaultRegistryURL = "https://plugins.gzh.dev/index.json"
+
+// Registry is a source of installable plugins.
+type Registry interface {
+	// Name returns a human readable identifier of the registry.
+	Name() string
+
+	// Search returns plugins whose name, description or tags match query.
+	// An empty query lists every plugin.
+	Search(ctx context.Context, query string) ([]Plugin, error)
+
+	// Get returns the plugin with the given name.
+	Get(ctx context.Context, name string) (*Plugin, error)
+
+	// Open opens the content referenced by an artifact or signature location.
+	Open(ctx context.Context, location string) (io.ReadCloser, error)
+}
+
+// ErrPluginNotFound is returned when a registry does not contain a plugin.
+var ErrPluginNotFound = fmt.Errorf("plugin not found")
+
+// NewRegistry creates a registry client for the given source.
+// Sources prefixed with oci:// are served from an OCI distribution registry,
+// everything else is treated as an HTTP(S) index document.
+func NewRegistry(source string, client *http.Client) (Registry, error) {
+	if source == "" {
+		source = DefaultRegistryURL
+	}
+	if client == nil {
+		// 아티팩트 다운로드를 위해 전역 클라이언트의 전송 계층에 더 긴 제한 시간을 쓴다
+		global := *httpclient.GetGlobalClient("default")
+		global.Timeout = 60 * time.Second
+		client = &global
+	}
+
+	if strings.HasPrefix(source, "oci://") {
+		return NewOCIRegistry(strings.TrimPrefix(source, "oci://"), client)
+	}
+
+	u, err := url.Parse(source)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("unsupported registry source: %s", source)
+	}
+
+	return NewHTTPRegistry(source, client), nil
+}
+
+// HTTPRegistry reads plugins from a JSON index served over HTTP.
+type HTTPRegistry struct {
+	indexURL string
+	client   *http.Client
+
+	index *Index
+}
+
+// NewHTTPRegistry creates a new HTTPRegistry.
+func NewHTTPRegistry(indexURL string, client *http.Client) *HTTPRegistry {
+	return &HTTPRegistry{indexURL: indexURL, client: client}
+}
+
+// Name returns the index URL.
+func (r *HTTPRegistry) Name() string {
+	return r.indexURL
+}
+
+// Search returns plugins matching query.
+func (r *HTTPRegistry) Search(ctx context.Context, query string) ([]Plugin, error) {
+	index, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterPlugins(index.Plugins, query), nil
+}
+
+// Get returns the plugin with the given name.
+func (r *HTTPRegistry) Get(ctx context.Context, name string) (*Plugin, error) {
+	index, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range index.Plugins {
+		if index.Plugins[i].Name == name {
+			return &index.Plugins[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrPluginNotFound, name)
+}
+
+// Open downloads location, resolving relative URLs against the index URL.
+func (r *HTTPRegistry) Open(ctx context.Context, location string) (io.ReadCloser, error) {
+	resolved, err := r.resolve(location)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpGet(ctx, r.client, resolved, nil)
+}
+
+func (r *HTTPRegistry) load(ctx context.Context) (*Index, error) {
+	if r.index != nil {
+		return r.index, nil
+	}
+
+	body, err := httpGet(ctx, r.client, r.indexURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch plugin index: %w", err)
+	}
+	defer body.Close()
+
+	var index Index
+	if err := json.NewDecoder(body).Decode(&index); err != nil {
+		return nil, fmt.Errorf("decode plugin index: %w", err)
+	}
+
+	r.index = &index
+	return r.index, nil
+}
+
+func (r *HTTPRegistry) resolve(location string) (string, error) {
+	base, err := url.Parse(r.indexURL)
+	if err != nil {
+		return "", fmt.Errorf("parse index URL: %w", err)
+	}
+
+	ref, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("parse artifact URL: %w", err)
+	}
+
+	return base.ResolveReference(ref).String(), nil
+}
+
+// httpGet performs a GET request and returns the body on 200 OK.
+func httpGet(ctx context.Context, client *http.Client, target string, header http.Header) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", target, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("request %s: unexpected status %d", target, resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
+
+// filterPlugins returns plugins matching query sorted by name.
+func filterPlugins(all []Plugin, query string) []Plugin {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	var result []Plugin
+	for _, p := range all {
+		if query == "" || matchesQuery(p, query) {
+			result = append(result, p)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func matchesQuery(p Plugin, query string) bool {
+	if strings.Contains(strings.ToLower(p.Name), query) ||
+		strings.Contains(strings.ToLower(p.Description), query) {
+		return true
+	}
+
+	for _, tag := range p.Tags {
+		if strings.EqualFold(tag, query) {
+			return true
+		}
+	}
+
+	return false
+}
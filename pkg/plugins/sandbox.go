@@ -0,0 +1,245 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/gizzahub/gzh-cli/internal/trace"
+)
+
+// sandboxEnv carries the encoded policy to the re-executed sandbox helper.
+const sandboxEnv = "GZ_PLUGIN_SANDBOX"
+
+// ErrSandboxUnavailable is returned in strict mode when the policy needs an
+// isolation mechanism the running system cannot provide.
+var ErrSandboxUnavailable = errors.New("plugin sandbox unavailable")
+
+// ResourceLimits bounds the resources a sandboxed plugin may consume.
+// Zero values mean unlimited.
+type ResourceLimits struct {
+	MaxMemoryMB      int           `json:"maxMemoryMB,omitempty"`
+	MaxCPUPercent    float64       `json:"maxCPUPercent,omitempty"` // 100 = one full CPU
+	MaxExecutionTime time.Duration `json:"maxExecutionTime,omitempty"`
+	MaxFileHandles   int           `json:"maxFileHandles,omitempty"`
+	MaxProcesses     int           `json:"maxProcesses,omitempty"`
+}
+
+// SandboxPolicy describes how an out-of-process plugin is isolated.
+type SandboxPolicy struct {
+	Limits ResourceLimits `json:"limits"`
+
+	// ReadOnlyPaths and ReadWritePaths are the only filesystem locations the
+	// plugin may access. Paths that do not exist are ignored.
+	ReadOnlyPaths  []string `json:"readOnlyPaths,omitempty"`
+	ReadWritePaths []string `json:"readWritePaths,omitempty"`
+
+	// AllowNetwork permits opening IPv4/IPv6 sockets.
+	AllowNetwork bool `json:"allowNetwork,omitempty"`
+
+	// Env names environment variables passed to the plugin in addition to
+	// baseEnv. Everything else, credentials included, is withheld.
+	Env []string `json:"env,omitempty"`
+
+	// Strict fails instead of running the plugin with reduced isolation when
+	// a mechanism required by the policy is unavailable.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// DefaultSandboxPolicy returns the policy used for plugins unless overridden:
+// system libraries are readable, nothing is writable and networking is off.
+func DefaultSandboxPolicy() SandboxPolicy {
+	return SandboxPolicy{
+		Limits: ResourceLimits{
+			MaxMemoryMB:      512,
+			MaxCPUPercent:    100,
+			MaxExecutionTime: 10 * time.Minute,
+			MaxFileHandles:   256,
+			MaxProcesses:     64,
+		},
+		ReadOnlyPaths: []string{
+			"/bin", "/etc", "/lib", "/lib64", "/sbin", "/usr",
+			"/dev/urandom", "/dev/random", "/proc/self",
+		},
+		ReadWritePaths: []string{"/dev/null"},
+	}
+}
+
+// Mechanism names reported by Capabilities.
+const (
+	MechanismLandlock = "landlock"
+	MechanismSeccomp  = "seccomp"
+	MechanismCgroups  = "cgroups"
+	MechanismRlimits  = "rlimits"
+	MechanismTimeout  = "timeout"
+)
+
+// Capability reports whether one isolation mechanism is usable.
+type Capability struct {
+	Mechanism string `json:"mechanism"`
+	Available bool   `json:"available"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// Capabilities reports which isolation mechanisms the running system supports.
+type Capabilities struct {
+	OS         string       `json:"os"`
+	Mechanisms []Capability `json:"mechanisms"`
+}
+
+// Has reports whether mechanism is available.
+func (c Capabilities) Has(mechanism string) bool {
+	for _, m := range c.Mechanisms {
+		if m.Mechanism == mechanism {
+			return m.Available
+		}
+	}
+
+	return false
+}
+
+// ProbeSandbox detects the isolation mechanisms available on this system.
+func ProbeSandbox() Capabilities {
+	caps := Capabilities{OS: runtime.GOOS, Mechanisms: probeMechanisms()}
+	caps.Mechanisms = append(caps.Mechanisms, Capability{
+		Mechanism: MechanismTimeout, Available: true, Detail: "execution time limit",
+	})
+
+	return caps
+}
+
+// Sandbox runs plugin executables under a SandboxPolicy.
+type Sandbox struct {
+	policy SandboxPolicy
+	caps   Capabilities
+}
+
+// NewSandbox checks policy against the system's capabilities. In strict mode
+// it fails if a mechanism the policy relies on is unavailable.
+func NewSandbox(policy SandboxPolicy) (*Sandbox, error) {
+	s := &Sandbox{policy: policy, caps: ProbeSandbox()}
+
+	if missing := s.Missing(); policy.Strict && len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s not supported on %s", ErrSandboxUnavailable, strings.Join(missing, ", "), s.caps.OS)
+	}
+
+	return s, nil
+}
+
+// Capabilities returns the capabilities detected when the sandbox was created.
+func (s *Sandbox) Capabilities() Capabilities {
+	return s.caps
+}
+
+// Missing lists mechanisms the policy relies on that are unavailable, i.e. the
+// ways in which a plugin would run with less isolation than requested.
+func (s *Sandbox) Missing() []string {
+	var missing []string
+
+	if !s.caps.Has(MechanismLandlock) {
+		missing = append(missing, MechanismLandlock)
+	}
+	if !s.caps.Has(MechanismSeccomp) {
+		missing = append(missing, MechanismSeccomp)
+	}
+	// rlimits cover memory and file handles but not CPU share.
+	if s.policy.Limits.MaxCPUPercent > 0 && !s.caps.Has(MechanismCgroups) {
+		missing = append(missing, MechanismCgroups)
+	}
+	if (s.policy.Limits.MaxMemoryMB > 0 || s.policy.Limits.MaxFileHandles > 0) &&
+		!s.caps.Has(MechanismCgroups) && !s.caps.Has(MechanismRlimits) {
+		missing = append(missing, MechanismRlimits)
+	}
+
+	return missing
+}
+
+// Command prepares path to run inside the sandbox. The returned cleanup
+// function must be called once the command has finished.
+func (s *Sandbox) Command(ctx context.Context, path string, args ...string) (*exec.Cmd, func(), error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cancel := func() {}
+	if s.policy.Limits.MaxExecutionTime > 0 {
+		ctx, cancel = context.WithTimeout(ctx, s.policy.Limits.MaxExecutionTime)
+	}
+
+	cmd, release, err := s.command(ctx, abs, args)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	return cmd, func() {
+		release()
+		cancel()
+	}, nil
+}
+
+// SandboxMain must be called at the very start of main. When the process was
+// started as a sandbox helper it applies the policy and executes the plugin,
+// never returning; otherwise it returns immediately.
+func SandboxMain() {
+	if os.Getenv(sandboxEnv) == "" {
+		return
+	}
+
+	if err := runSandboxHelper(); err != nil {
+		fmt.Fprintf(os.Stderr, "gz plugin sandbox: %v\n", err)
+	}
+	os.Exit(126)
+}
+
+// baseEnv are the variables every plugin sees: what a program needs to find
+// tools, format output and join the gz trace. LC_* variables pass as well.
+var baseEnv = []string{
+	"PATH", "HOME", "USER", "LOGNAME", "SHELL", "LANG", "LANGUAGE", "TZ", "TMPDIR",
+	"TERM", "COLORTERM", "NO_COLOR", trace.EnvVar, trace.PropagateEnvVar,
+}
+
+// pluginEnv returns the environment passed to plugins: baseEnv plus the
+// forwarded variables, taken from the current environment.
+func pluginEnv(forward []string) []string {
+	allowed := make(map[string]bool, len(baseEnv)+len(forward))
+	for _, name := range baseEnv {
+		allowed[name] = true
+	}
+	for _, name := range forward {
+		allowed[name] = true
+	}
+
+	var env []string
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if allowed[name] || strings.HasPrefix(name, "LC_") {
+			env = append(env, kv)
+		}
+	}
+
+	return env
+}
+
+// helperEnv returns the environment of the sandbox helper, which the
+// parent already filtered, without helper state.
+func helperEnv() []string {
+	env := make([]string, 0, len(os.Environ()))
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, sandboxEnv+"=") {
+			env = append(env, kv)
+		}
+	}
+
+	return env
+}
@@ -0,0 +1,455 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+
+package plugins
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const cgroupRoot = "/sys/fs/cgroup"
+
+// Landlock access rights grouped by what the policy grants.
+const (
+	landlockRead = unix.LANDLOCK_ACCESS_FS_EXECUTE |
+		unix.LANDLOCK_ACCESS_FS_READ_FILE |
+		unix.LANDLOCK_ACCESS_FS_READ_DIR
+
+	landlockWrite = unix.LANDLOCK_ACCESS_FS_WRITE_FILE |
+		unix.LANDLOCK_ACCESS_FS_REMOVE_DIR |
+		unix.LANDLOCK_ACCESS_FS_REMOVE_FILE |
+		unix.LANDLOCK_ACCESS_FS_MAKE_CHAR |
+		unix.LANDLOCK_ACCESS_FS_MAKE_DIR |
+		unix.LANDLOCK_ACCESS_FS_MAKE_REG |
+		unix.LANDLOCK_ACCESS_FS_MAKE_SOCK |
+		unix.LANDLOCK_ACCESS_FS_MAKE_FIFO |
+		unix.LANDLOCK_ACCESS_FS_MAKE_BLOCK |
+		unix.LANDLOCK_ACCESS_FS_MAKE_SYM |
+		unix.LANDLOCK_ACCESS_FS_TRUNCATE
+
+	// landlockFile are the only rights that apply to a non-directory.
+	landlockFile = unix.LANDLOCK_ACCESS_FS_EXECUTE |
+		unix.LANDLOCK_ACCESS_FS_WRITE_FILE |
+		unix.LANDLOCK_ACCESS_FS_READ_FILE |
+		unix.LANDLOCK_ACCESS_FS_TRUNCATE
+)
+
+// deniedSyscalls are refused with EPERM: they let a process escape or tamper
+// with its confinement, inspect other processes or change system state.
+// io_uring is denied because its operations bypass the seccomp filter.
+var deniedSyscalls = []uint32{
+	unix.SYS_PTRACE, unix.SYS_PROCESS_VM_READV, unix.SYS_PROCESS_VM_WRITEV,
+	unix.SYS_MOUNT, unix.SYS_UMOUNT2, unix.SYS_PIVOT_ROOT, unix.SYS_CHROOT,
+	unix.SYS_SETNS, unix.SYS_UNSHARE, unix.SYS_OPEN_BY_HANDLE_AT,
+	unix.SYS_SWAPON, unix.SYS_SWAPOFF, unix.SYS_REBOOT, unix.SYS_ACCT,
+	unix.SYS_KEXEC_LOAD, unix.SYS_INIT_MODULE, unix.SYS_FINIT_MODULE, unix.SYS_DELETE_MODULE,
+	unix.SYS_BPF, unix.SYS_PERF_EVENT_OPEN, unix.SYS_USERFAULTFD,
+	unix.SYS_KEYCTL, unix.SYS_ADD_KEY, unix.SYS_REQUEST_KEY,
+	unix.SYS_IO_URING_SETUP, unix.SYS_IO_URING_ENTER, unix.SYS_IO_URING_REGISTER,
+}
+
+// helperSpec is passed to the re-executed helper through sandboxEnv.
+type helperSpec struct {
+	Policy SandboxPolicy `json:"policy"`
+	Path   string        `json:"path"`
+	// Cgroup is set when memory and CPU are already limited by a cgroup.
+	Cgroup bool `json:"cgroup,omitempty"`
+}
+
+var cgroupSeq atomic.Int64
+
+func probeMechanisms() []Capability {
+	landlock := Capability{Mechanism: MechanismLandlock}
+	if abi := landlockABI(); abi > 0 {
+		landlock.Available = true
+		landlock.Detail = fmt.Sprintf("filesystem access control (ABI v%d)", abi)
+	} else {
+		landlock.Detail = "not supported by this kernel"
+	}
+
+	seccomp := Capability{Mechanism: MechanismSeccomp}
+	if _, ok := seccompArch(); !ok {
+		seccomp.Detail = "no syscall filter for " + runtime.GOARCH
+	} else if _, err := unix.PrctlRetInt(unix.PR_GET_SECCOMP, 0, 0, 0, 0); err != nil {
+		seccomp.Detail = "not supported by this kernel"
+	} else {
+		seccomp.Available = true
+		seccomp.Detail = "syscall filter"
+	}
+
+	cgroups := Capability{Mechanism: MechanismCgroups}
+	if parent, err := cgroupParent(); err != nil {
+		cgroups.Detail = err.Error()
+	} else {
+		cgroups.Available = true
+		cgroups.Detail = "memory, cpu and pids limits under " + parent
+	}
+
+	return []Capability{
+		landlock,
+		seccomp,
+		cgroups,
+		{Mechanism: MechanismRlimits, Available: true, Detail: "address space and open file limits"},
+	}
+}
+
+func (s *Sandbox) command(ctx context.Context, path string, args []string) (*exec.Cmd, func(), error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, nil, fmt.Errorf("locate sandbox helper: %w", err)
+	}
+
+	spec := helperSpec{Policy: s.policy, Path: path}
+	release := func() {}
+	attr := &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+
+	limits := s.policy.Limits
+	if s.caps.Has(MechanismCgroups) && (limits.MaxMemoryMB > 0 || limits.MaxCPUPercent > 0 || limits.MaxProcesses > 0) {
+		dir, cg, err := createCgroup(limits)
+		switch {
+		case err == nil:
+			spec.Cgroup = true
+			attr.UseCgroupFD = true
+			attr.CgroupFD = int(cg.Fd())
+			release = func() {
+				_ = cg.Close()
+				removeCgroup(dir)
+			}
+		case s.policy.Strict:
+			return nil, nil, fmt.Errorf("%w: %w", ErrSandboxUnavailable, err)
+		}
+	}
+
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, self)
+	cmd.Args = append([]string{path}, args...)
+	cmd.Env = append(pluginEnv(s.policy.Env), sandboxEnv+"="+string(encoded))
+	cmd.SysProcAttr = attr
+
+	return cmd, release, nil
+}
+
+// runSandboxHelper confines the current process and replaces it with the plugin.
+// Everything is applied to the calling thread, which is the one calling execve.
+func runSandboxHelper() error {
+	var spec helperSpec
+	if err := json.Unmarshal([]byte(os.Getenv(sandboxEnv)), &spec); err != nil {
+		return fmt.Errorf("decode policy: %w", err)
+	}
+
+	runtime.LockOSThread()
+	policy := spec.Policy
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("set no_new_privs: %w", err)
+	}
+
+	if abi := landlockABI(); abi > 0 {
+		readOnly := append([]string{filepath.Dir(spec.Path)}, policy.ReadOnlyPaths...)
+		if err := applyLandlock(abi, readOnly, policy.ReadWritePaths); err != nil && policy.Strict {
+			return fmt.Errorf("landlock: %w", err)
+		}
+	} else if policy.Strict {
+		return fmt.Errorf("landlock: %w", ErrSandboxUnavailable)
+	}
+
+	if err := applySeccomp(policy.AllowNetwork); err != nil && policy.Strict {
+		return fmt.Errorf("seccomp: %w", err)
+	}
+
+	// 주소 공간 제한은 exec 직전에 적용한다 (헬퍼 자신의 할당이 실패하지 않도록).
+	if err := applyRlimits(policy.Limits, spec.Cgroup); err != nil && policy.Strict {
+		return fmt.Errorf("rlimits: %w", err)
+	}
+
+	return syscall.Exec(spec.Path, os.Args, helperEnv())
+}
+
+func landlockABI() int {
+	abi, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION)
+	if errno != 0 {
+		return 0
+	}
+
+	return int(abi)
+}
+
+func applyLandlock(abi int, readOnly, readWrite []string) error {
+	handled := uint64(landlockRead | landlockWrite)
+	if abi < 3 {
+		handled &^= unix.LANDLOCK_ACCESS_FS_TRUNCATE
+	}
+
+	attr := unix.LandlockRulesetAttr{Access_fs: handled}
+	fd, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr), 0)
+	if errno != 0 {
+		return fmt.Errorf("create ruleset: %w", errno)
+	}
+	defer unix.Close(int(fd))
+
+	for _, p := range readOnly {
+		if err := landlockAllow(int(fd), p, handled&landlockRead); err != nil {
+			return err
+		}
+	}
+	for _, p := range readWrite {
+		if err := landlockAllow(int(fd), p, handled); err != nil {
+			return err
+		}
+	}
+
+	if _, _, errno := unix.Syscall(unix.SYS_LANDLOCK_RESTRICT_SELF, fd, 0, 0); errno != 0 {
+		return fmt.Errorf("restrict self: %w", errno)
+	}
+
+	return nil
+}
+
+// landlockAllow grants access beneath path. Missing paths are skipped.
+func landlockAllow(ruleset int, path string, access uint64) error {
+	fd, err := unix.Open(path, unix.O_PATH|unix.O_CLOEXEC, 0)
+	if errors.Is(err, unix.ENOENT) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	defer unix.Close(fd)
+
+	var st unix.Stat_t
+	if err := unix.Fstat(fd, &st); err != nil {
+		return fmt.Errorf("stat %s: %w", path, err)
+	}
+	if st.Mode&unix.S_IFMT != unix.S_IFDIR {
+		access &= landlockFile
+	}
+
+	rule := unix.LandlockPathBeneathAttr{Allowed_access: access, Parent_fd: int32(fd)}
+	if _, _, errno := unix.Syscall6(unix.SYS_LANDLOCK_ADD_RULE, uintptr(ruleset),
+		unix.LANDLOCK_RULE_PATH_BENEATH, uintptr(unsafe.Pointer(&rule)), 0, 0, 0); errno != 0 {
+		return fmt.Errorf("add rule for %s: %w", path, errno)
+	}
+
+	return nil
+}
+
+func seccompArch() (uint32, bool) {
+	switch runtime.GOARCH {
+	case "amd64":
+		return unix.AUDIT_ARCH_X86_64, true
+	case "arm64":
+		return unix.AUDIT_ARCH_AARCH64, true
+	default:
+		return 0, false
+	}
+}
+
+func applySeccomp(allowNetwork bool) error {
+	arch, ok := seccompArch()
+	if !ok {
+		return ErrSandboxUnavailable
+	}
+
+	filter := seccompFilter(arch, deniedSyscalls, allowNetwork)
+	prog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+	err := unix.Prctl(unix.PR_SET_SECCOMP, unix.SECCOMP_MODE_FILTER, uintptr(unsafe.Pointer(&prog)), 0, 0)
+	runtime.KeepAlive(filter)
+
+	return err
+}
+
+// seccompFilter builds a BPF program over struct seccomp_data (nr at offset 0,
+// arch at 4, args from 16) that rejects denied syscalls and, unless networking
+// is allowed, IPv4/IPv6 socket creation.
+func seccompFilter(arch uint32, denied []uint32, allowNetwork bool) []unix.SockFilter {
+	const (
+		ld   = unix.BPF_LD | unix.BPF_W | unix.BPF_ABS
+		jeq  = unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K
+		jge  = unix.BPF_JMP | unix.BPF_JGE | unix.BPF_K
+		ret  = unix.BPF_RET | unix.BPF_K
+		x32  = 0x40000000 // __X32_SYSCALL_BIT
+		deny = unix.SECCOMP_RET_ERRNO | uint32(unix.EPERM)
+	)
+
+	filter := []unix.SockFilter{
+		{Code: ld, K: 4},
+		{Code: jeq, Jt: 1, K: arch},
+		{Code: ret, K: unix.SECCOMP_RET_KILL_PROCESS},
+		{Code: ld, K: 0},
+		{Code: jge, Jf: 1, K: x32},
+		{Code: ret, K: deny},
+	}
+
+	for _, nr := range denied {
+		filter = append(filter,
+			unix.SockFilter{Code: jeq, Jf: 1, K: nr},
+			unix.SockFilter{Code: ret, K: deny},
+		)
+	}
+
+	if !allowNetwork {
+		filter = append(filter,
+			unix.SockFilter{Code: jeq, Jf: 4, K: unix.SYS_SOCKET},
+			unix.SockFilter{Code: ld, K: 16}, // 첫 번째 인자(domain)의 하위 32비트
+			unix.SockFilter{Code: jeq, Jt: 1, K: unix.AF_INET},
+			unix.SockFilter{Code: jeq, Jf: 1, K: unix.AF_INET6},
+			unix.SockFilter{Code: ret, K: unix.SECCOMP_RET_ERRNO | uint32(unix.EACCES)},
+		)
+	}
+
+	return append(filter, unix.SockFilter{Code: ret, K: unix.SECCOMP_RET_ALLOW})
+}
+
+// applyRlimits lowers process limits; memory is left to the cgroup when one is used.
+func applyRlimits(limits ResourceLimits, cgroup bool) error {
+	if limits.MaxFileHandles > 0 {
+		if err := lowerRlimit(unix.RLIMIT_NOFILE, uint64(limits.MaxFileHandles)); err != nil {
+			return err
+		}
+	}
+
+	if limits.MaxMemoryMB > 0 && !cgroup {
+		return lowerRlimit(unix.RLIMIT_AS, uint64(limits.MaxMemoryMB)<<20)
+	}
+
+	return nil
+}
+
+func lowerRlimit(resource int, value uint64) error {
+	var lim unix.Rlimit
+	if err := unix.Getrlimit(resource, &lim); err != nil {
+		return err
+	}
+
+	lim.Cur = min(lim.Cur, value)
+	lim.Max = min(lim.Max, value)
+
+	return unix.Setrlimit(resource, &lim)
+}
+
+// cgroupParent returns the cgroup v2 directory plugin cgroups are created
+// under: the parent of our own cgroup, whose controllers our cgroup uses.
+// Our own cgroup cannot be used because it already contains processes.
+func cgroupParent() (string, error) {
+	f, err := os.Open("/proc/self/cgroup")
+	if err != nil {
+		return "", fmt.Errorf("cgroups unavailable: %w", err)
+	}
+	defer f.Close()
+
+	own := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if rest, ok := strings.CutPrefix(scanner.Text(), "0::"); ok {
+			own = rest
+		}
+	}
+	if own == "" {
+		return "", errors.New("cgroup v2 not in use")
+	}
+
+	parent := cgroupRoot
+	if own != "/" {
+		parent = filepath.Join(cgroupRoot, filepath.Dir(own))
+	}
+
+	controllers, err := os.ReadFile(filepath.Join(parent, "cgroup.subtree_control"))
+	if err != nil {
+		return "", errors.New("cgroup v2 hierarchy not mounted")
+	}
+	for _, want := range []string{"memory", "cpu"} {
+		if !strings.Contains(" "+strings.TrimSpace(string(controllers))+" ", " "+want+" ") {
+			return "", fmt.Errorf("%s controller not delegated to %s", want, parent)
+		}
+	}
+
+	if err := unix.Access(parent, unix.W_OK); err != nil {
+		return "", fmt.Errorf("%s is not writable", parent)
+	}
+
+	return parent, nil
+}
+
+// createCgroup creates a cgroup with the given limits and returns its path
+// and an open handle for clone-into-cgroup.
+func createCgroup(limits ResourceLimits) (string, *os.File, error) {
+	parent, err := cgroupParent()
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir := filepath.Join(parent, fmt.Sprintf("gz-plugin-%d-%d", os.Getpid(), cgroupSeq.Add(1)))
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return "", nil, fmt.Errorf("create cgroup: %w", err)
+	}
+
+	settings := map[string]string{}
+	if limits.MaxMemoryMB > 0 {
+		settings["memory.max"] = strconv.FormatInt(int64(limits.MaxMemoryMB)<<20, 10)
+	}
+	if limits.MaxCPUPercent > 0 {
+		const period = 100000
+		quota := max(int64(limits.MaxCPUPercent*period/100), 1000)
+		settings["cpu.max"] = fmt.Sprintf("%d %d", quota, period)
+	}
+	if limits.MaxProcesses > 0 {
+		settings["pids.max"] = strconv.Itoa(limits.MaxProcesses)
+	}
+
+	for file, value := range settings {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(value), 0o644); err != nil {
+			// pids 컨트롤러는 선택 사항
+			if file == "pids.max" && errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			removeCgroup(dir)
+			return "", nil, fmt.Errorf("set %s: %w", file, err)
+		}
+	}
+	if limits.MaxMemoryMB > 0 {
+		_ = os.WriteFile(filepath.Join(dir, "memory.swap.max"), []byte("0"), 0o644)
+	}
+
+	f, err := os.Open(dir)
+	if err != nil {
+		removeCgroup(dir)
+		return "", nil, err
+	}
+
+	return dir, f, nil
+}
+
+// removeCgroup kills anything left in the cgroup and removes it.
+func removeCgroup(dir string) {
+	_ = os.WriteFile(filepath.Join(dir, "cgroup.kill"), []byte("1"), 0o644)
+
+	for range 10 {
+		if err := os.Remove(dir); err == nil || errors.Is(err, os.ErrNotExist) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestSeccompFilter_Layout(t *testing.T) {
+	withNet := seccompFilter(unix.AUDIT_ARCH_X86_64, []uint32{1, 2}, true)
+	noNet := seccompFilter(unix.AUDIT_ARCH_X86_64, []uint32{1, 2}, false)
+
+	// arch check (3) + x32 check (3) + 2 per denied syscall + final allow
+	assert.Len(t, withNet, 3+3+4+1)
+	assert.Len(t, noNet, len(withNet)+5)
+
+	last := noNet[len(noNet)-1]
+	assert.Equal(t, uint32(unix.SECCOMP_RET_ALLOW), last.K)
+	assert.Equal(t, uint32(unix.AUDIT_ARCH_X86_64), noNet[1].K)
+}
+
+func TestDeniedSyscalls_IOUring(t *testing.T) {
+	for _, nr := range []uint32{unix.SYS_IO_URING_SETUP, unix.SYS_IO_URING_ENTER, unix.SYS_IO_URING_REGISTER} {
+		assert.Contains(t, deniedSyscalls, nr)
+	}
+}
+
+func TestSandbox_CommandEnv(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "secret")
+	t.Setenv("NPM_TOKEN", "secret")
+
+	policy := DefaultSandboxPolicy()
+	policy.Env = []string{"NPM_TOKEN"}
+	s, err := NewSandbox(policy)
+	require.NoError(t, err)
+
+	cmd, cleanup, err := s.Command(context.Background(), "/bin/true")
+	require.NoError(t, err)
+	defer cleanup()
+
+	assert.NotContains(t, cmd.Env, "GITHUB_TOKEN=secret")
+	assert.Contains(t, cmd.Env, "NPM_TOKEN=secret")
+}
+
+func TestSandbox_Command(t *testing.T) {
+	sh, err := os.Stat("/bin/sh")
+	if err != nil || sh.IsDir() {
+		t.Skip("/bin/sh not available")
+	}
+
+	allowed := t.TempDir()
+	denied := t.TempDir()
+
+	policy := DefaultSandboxPolicy()
+	policy.ReadWritePaths = append(policy.ReadWritePaths, allowed)
+
+	s, err := NewSandbox(policy)
+	require.NoError(t, err)
+
+	script := "echo ok > " + filepath.Join(allowed, "out") + "; echo no > " + filepath.Join(denied, "out") + "; echo done"
+	cmd, cleanup, err := s.Command(context.Background(), "/bin/sh", "-c", script)
+	require.NoError(t, err)
+	defer cleanup()
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	_ = cmd.Run()
+
+	assert.Contains(t, stdout.String(), "done", "plugin runs through the helper")
+	assert.FileExists(t, filepath.Join(allowed, "out"))
+
+	if s.Capabilities().Has(MechanismLandlock) {
+		assert.NoFileExists(t, filepath.Join(denied, "out"), "writes outside the policy are blocked")
+	}
+}
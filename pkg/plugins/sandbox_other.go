@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+
+package plugins
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+)
+
+func probeMechanisms() []Capability {
+	const detail = "only available on Linux"
+
+	return []Capability{
+		{Mechanism: MechanismLandlock, Detail: detail},
+		{Mechanism: MechanismSeccomp, Detail: detail},
+		{Mechanism: MechanismCgroups, Detail: detail},
+		{Mechanism: MechanismRlimits, Detail: detail},
+	}
+}
+
+// command runs the plugin directly; only the execution time limit applies.
+func (s *Sandbox) command(ctx context.Context, path string, args []string) (*exec.Cmd, func(), error) {
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Env = pluginEnv(s.policy.Env)
+
+	return cmd, func() {}, nil
+}
+
+func runSandboxHelper() error {
+	return errors.New("sandbox helper is only supported on Linux")
+}
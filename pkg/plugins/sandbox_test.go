@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain lets the test binary act as the sandbox helper it re-executes.
+func TestMain(m *testing.M) {
+	SandboxMain()
+	os.Exit(m.Run())
+}
+
+func TestProbeSandbox(t *testing.T) {
+	caps := ProbeSandbox()
+
+	names := make([]string, 0, len(caps.Mechanisms))
+	for _, m := range caps.Mechanisms {
+		names = append(names, m.Mechanism)
+		assert.NotEmpty(t, m.Detail, m.Mechanism)
+	}
+
+	assert.Equal(t, []string{MechanismLandlock, MechanismSeccomp, MechanismCgroups, MechanismRlimits, MechanismTimeout}, names)
+	assert.True(t, caps.Has(MechanismTimeout))
+	assert.False(t, caps.Has("unknown"))
+}
+
+func TestSandbox_Missing(t *testing.T) {
+	s := &Sandbox{
+		policy: SandboxPolicy{Limits: ResourceLimits{MaxMemoryMB: 64, MaxCPUPercent: 50}},
+		caps: Capabilities{Mechanisms: []Capability{
+			{Mechanism: MechanismLandlock, Available: true},
+			{Mechanism: MechanismRlimits, Available: true},
+		}},
+	}
+	assert.Equal(t, []string{MechanismSeccomp, MechanismCgroups}, s.Missing())
+
+	s.policy.Limits.MaxCPUPercent = 0
+	assert.Equal(t, []string{MechanismSeccomp}, s.Missing(), "rlimits cover memory without cgroups")
+}
+
+func TestNewSandbox_StrictFailsWhenDegraded(t *testing.T) {
+	policy := DefaultSandboxPolicy()
+	policy.Strict = true
+
+	s, err := NewSandbox(policy)
+	if err != nil {
+		require.ErrorIs(t, err, ErrSandboxUnavailable)
+		return
+	}
+	assert.Empty(t, s.Missing())
+}
+
+func TestPluginEnv_StripsHelperState(t *testing.T) {
+	t.Setenv(sandboxEnv, "{}")
+
+	for _, kv := range pluginEnv(nil) {
+		assert.NotContains(t, kv, sandboxEnv+"=")
+	}
+	for _, kv := range helperEnv() {
+		assert.NotContains(t, kv, sandboxEnv+"=")
+	}
+}
+
+func TestPluginEnv_Allowlist(t *testing.T) {
+	t.Setenv("PATH", "/usr/bin")
+	t.Setenv("LC_TIME", "C")
+	t.Setenv("GITHUB_TOKEN", "secret")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	env := pluginEnv(nil)
+	assert.Contains(t, env, "PATH=/usr/bin")
+	assert.Contains(t, env, "LC_TIME=C")
+	assert.NotContains(t, env, "GITHUB_TOKEN=secret")
+	assert.NotContains(t, env, "AWS_SECRET_ACCESS_KEY=secret")
+
+	// 허용된 변수만 추가로 전달된다
+	env = pluginEnv([]string{"GITHUB_TOKEN"})
+	assert.Contains(t, env, "GITHUB_TOKEN=secret")
+	assert.NotContains(t, env, "AWS_SECRET_ACCESS_KEY=secret")
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Plugin describes a plugin published in a registry.
+type Plugin struct {
+	Name        string    `json:"name" yaml:"name"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Homepage    string    `json:"homepage,omitempty" yaml:"homepage,omitempty"`
+	Tags        []string  `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Versions    []Release `json:"versions" yaml:"versions"`
+}
+
+// Release is a single published version of a plugin.
+type Release struct {
+	Version     string      `json:"version" yaml:"version"`
+	Published   time.Time   `json:"published,omitempty" yaml:"published,omitempty"`
+	Permissions Permissions `json:"permissions,omitzero" yaml:"permissions,omitempty"`
+	// Events are the provider event types, such as "push", delivered to
+	// the plugin by gz serve; "*" subscribes to every type.
+	Events    []string   `json:"events,omitempty" yaml:"events,omitempty"`
+	Artifacts []Artifact `json:"artifacts" yaml:"artifacts"`
+}
+
+// Permissions is the access a plugin release declares it needs beyond the
+// default sandbox. Each declared permission must be granted by the user
+// before the sandbox allows it; see ConsentStore.
+type Permissions struct {
+	Network bool     `json:"network,omitempty" yaml:"network,omitempty"`
+	Read    []string `json:"read,omitempty" yaml:"read,omitempty"`
+	Write   []string `json:"write,omitempty" yaml:"write,omitempty"`
+	// Env names environment variables, such as GITHUB_TOKEN, the plugin
+	// needs to see; plugins get only a small allowlist otherwise.
+	Env []string `json:"env,omitempty" yaml:"env,omitempty"`
+}
+
+// Artifact is a platform-specific plugin binary.
+type Artifact struct {
+	OS        string `json:"os" yaml:"os"`
+	Arch      string `json:"arch" yaml:"arch"`
+	URL       string `json:"url" yaml:"url"`
+	SHA256    string `json:"sha256" yaml:"sha256"`
+	Signature string `json:"signature,omitempty" yaml:"signature,omitempty"` // 서명 파일 URL (minisign 또는 cosign)
+}
+
+// Index is the document served by an HTTP plugin registry.
+type Index struct {
+	APIVersion string   `json:"apiVersion" yaml:"apiVersion"`
+	Plugins    []Plugin `json:"plugins" yaml:"plugins"`
+}
+
+// Installed records a plugin installed on the local machine.
+type Installed struct {
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Path        string    `json:"path"`
+	Source      string    `json:"source"`
+	SHA256      string    `json:"sha256"`
+	Verified    string    `json:"verified,omitempty"` // 서명 검증에 사용된 방식
+	InstalledAt time.Time `json:"installedAt"`
+	// Permissions are the permissions declared by the installed release.
+	Permissions Permissions `json:"permissions,omitzero"`
+	// Events are the event types the installed release subscribes to.
+	Events []string `json:"events,omitempty"`
+}
+
+// Latest returns the highest version release of the plugin.
+func (p *Plugin) Latest() (*Release, bool) {
+	if len(p.Versions) == 0 {
+		return nil, false
+	}
+
+	best := &p.Versions[0]
+	for i := 1; i < len(p.Versions); i++ {
+		if CompareVersions(p.Versions[i].Version, best.Version) > 0 {
+			best = &p.Versions[i]
+		}
+	}
+
+	return best, true
+}
+
+// Release returns the release matching version, or the latest if version is empty.
+func (p *Plugin) Release(version string) (*Release, bool) {
+	if version == "" || version == "latest" {
+		return p.Latest()
+	}
+
+	want := strings.TrimPrefix(version, "v")
+	for i := range p.Versions {
+		if strings.TrimPrefix(p.Versions[i].Version, "v") == want {
+			return &p.Versions[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// ArtifactFor returns the artifact matching the given platform.
+func (r *Release) ArtifactFor(goos, goarch string) (*Artifact, bool) {
+	for i := range r.Artifacts {
+		if r.Artifacts[i].OS == goos && r.Artifacts[i].Arch == goarch {
+			return &r.Artifacts[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// CurrentArtifact returns the artifact for the running platform.
+func (r *Release) CurrentArtifact() (*Artifact, bool) {
+	return r.ArtifactFor(runtime.GOOS, runtime.GOARCH)
+}
+
+// CompareVersions compares two dotted version strings.
+// It returns -1 if a < b, 0 if equal and 1 if a > b. Pre-release suffixes
+// (e.g. 1.2.0-rc.1) sort before the corresponding release.
+func CompareVersions(a, b string) int {
+	aCore, aPre, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	bCore, bPre, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+
+	aParts := strings.Split(aCore, ".")
+	bParts := strings.Split(bCore, ".")
+
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var ai, bi int
+		if i < len(aParts) {
+			ai, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bi, _ = strconv.Atoi(bParts[i])
+		}
+
+		switch {
+		case ai < bi:
+			return -1
+		case ai > bi:
+			return 1
+		}
+	}
+
+	// 정식 릴리스가 프리릴리스보다 높음
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	case aPre < bPre:
+		return -1
+	default:
+		return 1
+	}
+}
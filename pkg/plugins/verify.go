@@ -0,0 +1,239 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// ErrChecksumMismatch is returned when downloaded content does not match its checksum.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ErrSignatureInvalid is returned when a signature does not verify.
+var ErrSignatureInvalid = errors.New("signature verification failed")
+
+// Verifier verifies a detached signature over plugin content.
+type Verifier interface {
+	// Name identifies the signature scheme (e.g. "minisign", "cosign").
+	Name() string
+
+	// Verify checks signature against content.
+	Verify(content, signature []byte) error
+}
+
+// VerifyChecksum checks content against a hex encoded SHA-256 digest.
+func VerifyChecksum(content []byte, expected string) error {
+	expected = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(expected), "sha256:"))
+	if expected == "" {
+		return fmt.Errorf("%w: no checksum published", ErrChecksumMismatch)
+	}
+
+	sum := sha256.Sum256(content)
+	actual := hex.EncodeToString(sum[:])
+	if actual != expected {
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expected, actual)
+	}
+
+	return nil
+}
+
+// ParseVerifier creates a verifier from public key material.
+// PEM encoded keys are used for cosign signatures, minisign public key
+// files (or their bare base64 line) for minisign signatures.
+func ParseVerifier(keyData []byte) (Verifier, error) {
+	if block, _ := pem.Decode(keyData); block != nil {
+		return NewCosignVerifier(block.Bytes)
+	}
+
+	return ParseMinisignPublicKey(keyData)
+}
+
+// MinisignVerifier verifies minisign signatures.
+type MinisignVerifier struct {
+	keyID     [8]byte
+	publicKey ed25519.PublicKey
+}
+
+// ParseMinisignPublicKey parses a minisign public key file.
+func ParseMinisignPublicKey(data []byte) (*MinisignVerifier, error) {
+	line := lastNonCommentLine(data)
+
+	raw, err := base64.StdEncoding.DecodeString(line)
+	if err != nil {
+		return nil, fmt.Errorf("decode minisign public key: %w", err)
+	}
+	if len(raw) != 2+8+ed25519.PublicKeySize || string(raw[:2]) != "Ed" {
+		return nil, fmt.Errorf("invalid minisign public key")
+	}
+
+	v := &MinisignVerifier{publicKey: ed25519.PublicKey(raw[10:])}
+	copy(v.keyID[:], raw[2:10])
+
+	return v, nil
+}
+
+// Name returns "minisign".
+func (v *MinisignVerifier) Name() string {
+	return "minisign"
+}
+
+// KeyID returns the hex encoded minisign key id.
+func (v *MinisignVerifier) KeyID() string {
+	id := v.keyID
+	// minisign은 키 ID를 리틀 엔디안으로 표시
+	for i, j := 0, len(id)-1; i < j; i, j = i+1, j-1 {
+		id[i], id[j] = id[j], id[i]
+	}
+
+	return strings.ToUpper(hex.EncodeToString(id[:]))
+}
+
+// Verify checks a minisign signature file against content.
+// Both legacy ("Ed") and pre-hashed ("ED") signatures are supported, as is
+// the global signature over the trusted comment.
+func (v *MinisignVerifier) Verify(content, signature []byte) error {
+	lines := nonEmptyLines(signature)
+	if len(lines) < 2 {
+		return fmt.Errorf("%w: malformed minisign signature", ErrSignatureInvalid)
+	}
+
+	// 첫 줄은 untrusted comment
+	sigLine := lines[0]
+	if strings.HasPrefix(sigLine, "untrusted comment:") {
+		lines = lines[1:]
+		sigLine = lines[0]
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(sigLine)
+	if err != nil || len(raw) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed minisign signature", ErrSignatureInvalid)
+	}
+
+	if !bytes.Equal(raw[2:10], v.keyID[:]) {
+		return fmt.Errorf("%w: signature key id does not match trusted key", ErrSignatureInvalid)
+	}
+
+	message := content
+	switch string(raw[:2]) {
+	case "Ed":
+	case "ED":
+		sum := blake2b.Sum512(content)
+		message = sum[:]
+	default:
+		return fmt.Errorf("%w: unsupported minisign algorithm %q", ErrSignatureInvalid, raw[:2])
+	}
+
+	sig := raw[10:]
+	if !ed25519.Verify(v.publicKey, message, sig) {
+		return ErrSignatureInvalid
+	}
+
+	// trusted comment가 있으면 전역 서명도 검증
+	if len(lines) >= 3 {
+		trusted, ok := strings.CutPrefix(lines[1], "trusted comment: ")
+		if !ok {
+			return fmt.Errorf("%w: malformed trusted comment", ErrSignatureInvalid)
+		}
+
+		globalSig, err := base64.StdEncoding.DecodeString(lines[2])
+		if err != nil {
+			return fmt.Errorf("%w: malformed global signature", ErrSignatureInvalid)
+		}
+
+		if !ed25519.Verify(v.publicKey, append(append([]byte{}, sig...), trusted...), globalSig) {
+			return fmt.Errorf("%w: trusted comment signature", ErrSignatureInvalid)
+		}
+	}
+
+	return nil
+}
+
+// CosignVerifier verifies signatures produced by `cosign sign-blob --key`.
+// Keyless (Fulcio/Rekor) verification is not supported.
+type CosignVerifier struct {
+	publicKey any
+}
+
+// NewCosignVerifier creates a verifier from a DER encoded PKIX public key.
+func NewCosignVerifier(der []byte) (*CosignVerifier, error) {
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse cosign public key: %w", err)
+	}
+
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported cosign key type %T", key)
+	}
+
+	return &CosignVerifier{publicKey: key}, nil
+}
+
+// Name returns "cosign".
+func (v *CosignVerifier) Name() string {
+	return "cosign"
+}
+
+// Verify checks a base64 encoded cosign blob signature against content.
+func (v *CosignVerifier) Verify(content, signature []byte) error {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("%w: malformed cosign signature", ErrSignatureInvalid)
+	}
+
+	digest := sha256.Sum256(content)
+
+	var ok bool
+	switch key := v.publicKey.(type) {
+	case *ecdsa.PublicKey:
+		ok = ecdsa.VerifyASN1(key, digest[:], sig)
+	case *rsa.PublicKey:
+		ok = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(key, content, sig)
+	}
+
+	if !ok {
+		return ErrSignatureInvalid
+	}
+
+	return nil
+}
+
+func nonEmptyLines(data []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+func lastNonCommentLine(data []byte) string {
+	lines := nonEmptyLines(data)
+	for i := len(lines) - 1; i >= 0; i-- {
+		if !strings.HasPrefix(lines[i], "untrusted comment:") {
+			return lines[i]
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package plugins
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+// minisignFixture creates a minisign key pair and signs content.
+func minisignFixture(t *testing.T, content []byte, prehash bool) (pubKey, signature []byte) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	pubKey = fmt.Appendf(nil, "untrusted comment: minisign public key\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), pub...)))
+
+	alg, message := []byte("Ed"), content
+	if prehash {
+		sum := blake2b.Sum512(content)
+		alg, message = []byte("ED"), sum[:]
+	}
+
+	sig := ed25519.Sign(priv, message)
+	trusted := "timestamp:1700000000\tfile:plugin"
+	global := ed25519.Sign(priv, append(append([]byte{}, sig...), trusted...))
+
+	signature = fmt.Appendf(nil, "untrusted comment: signature\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(append(append(alg, keyID...), sig...)),
+		trusted,
+		base64.StdEncoding.EncodeToString(global))
+
+	return pubKey, signature
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	content := []byte("plugin binary")
+	sum := sha256.Sum256(content)
+
+	assert.NoError(t, VerifyChecksum(content, hex.EncodeToString(sum[:])))
+	assert.NoError(t, VerifyChecksum(content, "sha256:"+hex.EncodeToString(sum[:])))
+	assert.ErrorIs(t, VerifyChecksum(content, "deadbeef"), ErrChecksumMismatch)
+	assert.ErrorIs(t, VerifyChecksum(content, ""), ErrChecksumMismatch)
+}
+
+func TestMinisignVerifier(t *testing.T) {
+	content := []byte("plugin binary")
+
+	for _, prehash := range []bool{false, true} {
+		t.Run(fmt.Sprintf("prehash=%v", prehash), func(t *testing.T) {
+			pubKey, signature := minisignFixture(t, content, prehash)
+
+			v, err := ParseVerifier(pubKey)
+			require.NoError(t, err)
+			assert.Equal(t, "minisign", v.Name())
+
+			assert.NoError(t, v.Verify(content, signature))
+			assert.ErrorIs(t, v.Verify([]byte("tampered"), signature), ErrSignatureInvalid)
+		})
+	}
+}
+
+func TestMinisignVerifier_WrongKey(t *testing.T) {
+	content := []byte("plugin binary")
+	_, signature := minisignFixture(t, content, false)
+	otherKey, _ := minisignFixture(t, content, false)
+
+	v, err := ParseMinisignPublicKey(otherKey)
+	require.NoError(t, err)
+	assert.ErrorIs(t, v.Verify(content, signature), ErrSignatureInvalid)
+}
+
+func TestCosignVerifier(t *testing.T) {
+	content := []byte("plugin binary")
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	digest := sha256.Sum256(content)
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	require.NoError(t, err)
+	signature := []byte(base64.StdEncoding.EncodeToString(sig))
+
+	v, err := ParseVerifier(pemKey)
+	require.NoError(t, err)
+	assert.Equal(t, "cosign", v.Name())
+
+	assert.NoError(t, v.Verify(content, signature))
+	assert.ErrorIs(t, v.Verify([]byte("tampered"), signature), ErrSignatureInvalid)
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"v1.2.0", "1.10.0", -1},
+		{"2.0", "1.9.9", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0", "1.0.0-rc.1", 1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, CompareVersions(tt.a, tt.b), "%s vs %s", tt.a, tt.b)
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package imagescan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Normalize drops duplicate findings, such as one package reported for
+// several layers, and orders the rest by severity, then ID and package.
+func Normalize(findings []Finding) []Finding {
+	type key struct{ id, pkg, version string }
+	seen := make(map[key]bool, len(findings))
+	out := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		k := key{f.ID, f.Package, f.InstalledVersion}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, f)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Package < b.Package
+	})
+	return out
+}
+
+// Gate decides which findings fail a scan.
+type Gate struct {
+	// FailOn is the lowest severity that fails the scan.
+	FailOn Severity
+	// OnlyFixable ignores findings without a fixed version.
+	OnlyFixable bool
+	// Ignore lists vulnerability IDs that never fail the scan.
+	Ignore []string
+}
+
+// Violations returns the findings that fail the gate.
+func (g Gate) Violations(findings []Finding) []Finding {
+	var out []Finding
+	for _, f := range findings {
+		if f.Severity < g.FailOn || (g.OnlyFixable && !f.Fixable()) || slices.Contains(g.Ignore, f.ID) {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// Result is the scan of one target.
+type Result struct {
+	Target   Target    `json:"target"`
+	Findings []Finding `json:"findings"`
+	// Violations counts the findings that fail the gate.
+	Violations int    `json:"violations"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Report is the outcome of scanning several targets.
+type Report struct {
+	Scanner     string    `json:"scanner"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	// FailOn is the gate threshold; empty when no gate was applied.
+	FailOn  string   `json:"failOn,omitempty"`
+	Results []Result `json:"results"`
+}
+
+// Scan scans every target in turn and applies gate when it is not nil.
+// Targets that cannot be scanned are recorded in their result.
+func Scan(ctx context.Context, scanner Scanner, targets []Target, gate *Gate) *Report {
+	report := &Report{Scanner: scanner.Name(), GeneratedAt: time.Now(), Results: make([]Result, 0, len(targets))}
+	if gate != nil {
+		report.FailOn = gate.FailOn.String()
+	}
+	for _, target := range targets {
+		result := Result{Target: target, Findings: []Finding{}}
+		findings, err := scanner.Scan(ctx, target)
+		if err != nil {
+			result.Error = err.Error()
+		} else if findings != nil {
+			result.Findings = findings
+		}
+		if gate != nil {
+			result.Violations = len(gate.Violations(result.Findings))
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+// Violations returns the number of findings that failed the gate.
+func (r *Report) Violations() int {
+	n := 0
+	for _, res := range r.Results {
+		n += res.Violations
+	}
+	return n
+}
+
+// Failed returns the targets that could not be scanned.
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if res.Error != "" {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Counts returns the number of findings per severity over all targets.
+func (r *Report) Counts() map[Severity]int {
+	counts := make(map[Severity]int)
+	for _, res := range r.Results {
+		for _, f := range res.Findings {
+			counts[f.Severity]++
+		}
+	}
+	return counts
+}
+
+// WriteJSON writes the report to w.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("failed to encode scan report: %w", err)
+	}
+	return nil
+}
+
+// sarifLevels maps severities to SARIF result levels.
+var sarifLevels = map[Severity]string{
+	SeverityUnknown:  "note",
+	SeverityLow:      "note",
+	SeverityMedium:   "warning",
+	SeverityHigh:     "error",
+	SeverityCritical: "error",
+}
+
+// WriteSARIF writes the findings as a SARIF 2.1.0 log. Code scanning needs
+// a file to attach results to, so they are located in location, usually the
+// Dockerfile the image was built from.
+func (r *Report) WriteSARIF(w io.Writer, location string) error {
+	type result struct {
+		RuleID    string         `json:"ruleId"`
+		Level     string         `json:"level"`
+		Message   map[string]any `json:"message"`
+		Locations []any          `json:"locations"`
+	}
+
+	locations := []any{map[string]any{
+		"physicalLocation": map[string]any{
+			"artifactLocation": map[string]any{"uri": location},
+			"region":           map[string]any{"startLine": 1},
+		},
+	}}
+
+	rules := []any{}
+	seen := make(map[string]bool)
+	results := []result{}
+	for _, res := range r.Results {
+		for _, f := range res.Findings {
+			if !seen[f.ID] {
+				seen[f.ID] = true
+				rule := map[string]any{
+					"id":               f.ID,
+					"shortDescription": map[string]any{"text": valueOr(f.Title, f.ID)},
+					"properties":       map[string]any{"security-severity": securitySeverity(f.Severity), "tags": []string{"security", "vulnerability"}},
+				}
+				if f.URL != "" {
+					rule["helpUri"] = f.URL
+				}
+				rules = append(rules, rule)
+			}
+
+			text := fmt.Sprintf("%s: %s %s is affected by %s (%s)", res.Target, f.Package, f.InstalledVersion, f.ID, f.Severity)
+			if f.Fixable() {
+				text += fmt.Sprintf("; fixed in %s", f.FixedVersion)
+			}
+			results = append(results, result{
+				RuleID:    f.ID,
+				Level:     sarifLevels[f.Severity],
+				Message:   map[string]any{"text": text},
+				Locations: locations,
+			})
+		}
+	}
+
+	log := map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []any{map[string]any{
+			"tool": map[string]any{"driver": map[string]any{
+				"name":           "gz docker scan (" + r.Scanner + ")",
+				"informationUri": "https://github.com/gizzahub/gzh-cli",
+				"rules":          rules,
+			}},
+			"results": results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("failed to encode SARIF log: %w", err)
+	}
+	return nil
+}
+
+// securitySeverity returns the CVSS-like score GitHub code scanning uses
+// to rank security results.
+func securitySeverity(s Severity) string {
+	switch s {
+	case SeverityCritical:
+		return "9.5"
+	case SeverityHigh:
+		return "8.0"
+	case SeverityMedium:
+		return "5.5"
+	default:
+		return "2.0"
+	}
+}
+
+func valueOr(v, fallback string) string {
+	if v == "" {
+		return fallback
+	}
+	return v
+}
+
+// PrintSummary writes a table of findings per target followed by the
+// severity totals.
+func (r *Report) PrintSummary(w io.Writer) {
+	for _, res := range r.Results {
+		if res.Error != "" {
+			fmt.Fprintf(w, "\n❌ %s: %s\n", res.Target, res.Error)
+			continue
+		}
+		fmt.Fprintf(w, "\n🔍 %s: %d vulnerabilities\n", res.Target, len(res.Findings))
+		if len(res.Findings) == 0 {
+			continue
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "SEVERITY\tID\tPACKAGE\tINSTALLED\tFIXED")
+		for _, f := range res.Findings {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", f.Severity, f.ID, f.Package, f.InstalledVersion, valueOr(f.FixedVersion, "-"))
+		}
+		_ = tw.Flush()
+	}
+
+	counts := r.Counts()
+	parts := make([]string, 0, len(severityNames))
+	for s := SeverityCritical; s >= SeverityUnknown; s-- {
+		if counts[s] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[s], s))
+		}
+	}
+	summary := "no vulnerabilities"
+	if len(parts) > 0 {
+		summary = strings.Join(parts, ", ")
+	}
+	fmt.Fprintf(w, "\n📊 %d image(s) scanned with %s: %s\n", len(r.Results), r.Scanner, summary)
+	if r.FailOn != "" {
+		if n := r.Violations(); n > 0 {
+			fmt.Fprintf(w, "❌ %d finding(s) at or above %s\n", n, r.FailOn)
+		} else {
+			fmt.Fprintf(w, "✅ No findings at or above %s\n", r.FailOn)
+		}
+	}
+}
+
+// WriteFile writes the report to path in format json or sarif.
+func (r *Report) WriteFile(path, format, location string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	if format == "sarif" {
+		err = r.WriteSARIF(f, location)
+	} else {
+		err = r.WriteJSON(f)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
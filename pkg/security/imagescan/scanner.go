@@ -0,0 +1,314 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package imagescan scans container images for known vulnerabilities with
+// Trivy or Grype, normalizes their findings and applies severity gates.
+package imagescan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Severity ranks a vulnerability.
+type Severity int
+
+const (
+	SeverityUnknown Severity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityUnknown:  "unknown",
+	SeverityLow:      "low",
+	SeverityMedium:   "medium",
+	SeverityHigh:     "high",
+	SeverityCritical: "critical",
+}
+
+// String returns the lower-case severity name.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Severity) UnmarshalText(text []byte) error {
+	parsed, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// ParseSeverity parses a severity name.
+func ParseSeverity(name string) (Severity, error) {
+	for s, n := range severityNames {
+		if strings.EqualFold(n, name) {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q (expected low, medium, high or critical)", name)
+}
+
+// normalizeSeverity maps scanner severities, which include values like
+// Negligible, onto Severity.
+func normalizeSeverity(name string) Severity {
+	switch strings.ToLower(name) {
+	case "critical":
+		return SeverityCritical
+	case "high":
+		return SeverityHigh
+	case "medium", "moderate":
+		return SeverityMedium
+	case "low", "negligible":
+		return SeverityLow
+	default:
+		return SeverityUnknown
+	}
+}
+
+// Finding is one vulnerable package in an image.
+type Finding struct {
+	ID               string   `json:"id"`
+	Package          string   `json:"package"`
+	InstalledVersion string   `json:"installedVersion"`
+	FixedVersion     string   `json:"fixedVersion,omitempty"`
+	Severity         Severity `json:"severity"`
+	Title            string   `json:"title,omitempty"`
+	URL              string   `json:"url,omitempty"`
+}
+
+// Fixable reports whether a fixed version is available.
+func (f Finding) Fixable() bool {
+	return f.FixedVersion != ""
+}
+
+// Target is an image reference, optionally for one platform of a
+// multi-arch image.
+type Target struct {
+	Image    string `json:"image"`
+	Platform string `json:"platform,omitempty"`
+}
+
+// String returns image or image (platform).
+func (t Target) String() string {
+	if t.Platform == "" {
+		return t.Image
+	}
+	return t.Image + " (" + t.Platform + ")"
+}
+
+// Scanner scans one image.
+type Scanner interface {
+	Name() string
+	Scan(ctx context.Context, target Target) ([]Finding, error)
+}
+
+// Options configures the scanner backends.
+type Options struct {
+	// Server is the URL of a Trivy server; scans then run in client mode
+	// and the vulnerability database stays on the server.
+	Server string
+	// IgnoreUnfixed leaves out vulnerabilities without a fix.
+	IgnoreUnfixed bool
+}
+
+// Scanner names accepted by NewScanner.
+const (
+	ScannerAuto  = "auto"
+	ScannerTrivy = "trivy"
+	ScannerGrype = "grype"
+)
+
+// runner executes a scanner binary and returns its stdout.
+type runner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...) // #nosec G204 -- fixed scanner binaries
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s failed: %w: %s", name, err, lastLine(msg))
+		}
+		return nil, fmt.Errorf("%s failed: %w", name, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+func lastLine(s string) string {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
+
+// lookPath is swapped out in tests.
+var lookPath = exec.LookPath
+
+// NewScanner returns the backend called name. ScannerAuto picks Trivy when
+// it is installed or a Trivy server is configured, and Grype otherwise.
+func NewScanner(name string, opts Options) (Scanner, error) {
+	switch name {
+	case ScannerTrivy:
+		return &trivyScanner{opts: opts, run: runCommand}, nil
+	case ScannerGrype:
+		if opts.Server != "" {
+			return nil, errors.New("--server is only supported with trivy")
+		}
+		return &grypeScanner{opts: opts, run: runCommand}, nil
+	case "", ScannerAuto:
+		if _, err := lookPath(ScannerTrivy); err == nil || opts.Server != "" {
+			return &trivyScanner{opts: opts, run: runCommand}, nil
+		}
+		if _, err := lookPath(ScannerGrype); err == nil {
+			return &grypeScanner{opts: opts, run: runCommand}, nil
+		}
+		return nil, errors.New("neither trivy nor grype is installed; see https://trivy.dev or https://github.com/anchore/grype")
+	default:
+		return nil, fmt.Errorf("unknown scanner %q (expected auto, trivy or grype)", name)
+	}
+}
+
+type trivyScanner struct {
+	opts Options
+	run  runner
+}
+
+func (s *trivyScanner) Name() string { return ScannerTrivy }
+
+// trivyReport is the subset of `trivy image --format json` that is used.
+type trivyReport struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID  string `json:"VulnerabilityID"`
+			PkgName          string `json:"PkgName"`
+			InstalledVersion string `json:"InstalledVersion"`
+			FixedVersion     string `json:"FixedVersion"`
+			Severity         string `json:"Severity"`
+			Title            string `json:"Title"`
+			PrimaryURL       string `json:"PrimaryURL"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+func (s *trivyScanner) Scan(ctx context.Context, target Target) ([]Finding, error) {
+	args := []string{"image", "--format", "json", "--quiet", "--scanners", "vuln"}
+	if s.opts.Server != "" {
+		args = append(args, "--server", s.opts.Server)
+	}
+	if s.opts.IgnoreUnfixed {
+		args = append(args, "--ignore-unfixed")
+	}
+	if target.Platform != "" {
+		args = append(args, "--platform", target.Platform)
+	}
+	out, err := s.run(ctx, ScannerTrivy, append(args, target.Image)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var report trivyReport
+	if err := json.Unmarshal(out, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse trivy output: %w", err)
+	}
+	var findings []Finding
+	for _, result := range report.Results {
+		for _, v := range result.Vulnerabilities {
+			findings = append(findings, Finding{
+				ID:               v.VulnerabilityID,
+				Package:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Severity:         normalizeSeverity(v.Severity),
+				Title:            v.Title,
+				URL:              v.PrimaryURL,
+			})
+		}
+	}
+	return Normalize(findings), nil
+}
+
+type grypeScanner struct {
+	opts Options
+	run  runner
+}
+
+func (s *grypeScanner) Name() string { return ScannerGrype }
+
+// grypeReport is the subset of `grype -o json` that is used.
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID          string `json:"id"`
+			Severity    string `json:"severity"`
+			Description string `json:"description"`
+			DataSource  string `json:"dataSource"`
+			Fix         struct {
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+func (s *grypeScanner) Scan(ctx context.Context, target Target) ([]Finding, error) {
+	args := []string{target.Image, "-o", "json", "--quiet"}
+	if s.opts.IgnoreUnfixed {
+		args = append(args, "--only-fixed")
+	}
+	if target.Platform != "" {
+		args = append(args, "--platform", target.Platform)
+	}
+	out, err := s.run(ctx, ScannerGrype, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var report grypeReport
+	if err := json.Unmarshal(out, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse grype output: %w", err)
+	}
+	findings := make([]Finding, 0, len(report.Matches))
+	for _, m := range report.Matches {
+		findings = append(findings, Finding{
+			ID:               m.Vulnerability.ID,
+			Package:          m.Artifact.Name,
+			InstalledVersion: m.Artifact.Version,
+			FixedVersion:     strings.Join(m.Vulnerability.Fix.Versions, ", "),
+			Severity:         normalizeSeverity(m.Vulnerability.Severity),
+			Title:            firstSentence(m.Vulnerability.Description),
+			URL:              m.Vulnerability.DataSource,
+		})
+	}
+	return Normalize(findings), nil
+}
+
+func firstSentence(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, ". "); i > 0 {
+		return s[:i+1]
+	}
+	return s
+}
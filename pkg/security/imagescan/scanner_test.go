@@ -0,0 +1,230 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package imagescan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const trivyOutput = `{
+  "Results": [
+    {"Target": "alpine 3.18", "Vulnerabilities": [
+      {"VulnerabilityID": "CVE-2023-0001", "PkgName": "openssl", "InstalledVersion": "3.1.0", "FixedVersion": "3.1.1", "Severity": "HIGH", "Title": "OpenSSL overflow", "PrimaryURL": "https://avd.aquasec.com/nvd/cve-2023-0001"},
+      {"VulnerabilityID": "CVE-2023-0002", "PkgName": "busybox", "InstalledVersion": "1.36", "Severity": "CRITICAL"}
+    ]},
+    {"Target": "usr/lib/libssl.so", "Vulnerabilities": [
+      {"VulnerabilityID": "CVE-2023-0001", "PkgName": "openssl", "InstalledVersion": "3.1.0", "FixedVersion": "3.1.1", "Severity": "HIGH"}
+    ]}
+  ]
+}`
+
+const grypeOutput = `{
+  "matches": [
+    {"vulnerability": {"id": "GHSA-xxxx", "severity": "Negligible", "description": "Minor issue. More text.", "fix": {"versions": []}},
+     "artifact": {"name": "zlib", "version": "1.2.13"}},
+    {"vulnerability": {"id": "CVE-2024-1111", "severity": "Medium", "dataSource": "https://nvd.nist.gov/vuln/detail/CVE-2024-1111", "fix": {"versions": ["2.0.1"]}},
+     "artifact": {"name": "curl", "version": "2.0.0"}}
+  ]
+}`
+
+func fakeRunner(out string, calls *[]string) runner {
+	return func(_ context.Context, name string, args ...string) ([]byte, error) {
+		*calls = append(*calls, name)
+		*calls = append(*calls, args...)
+		return []byte(out), nil
+	}
+}
+
+func TestTrivyScanner_Scan(t *testing.T) {
+	var calls []string
+	s := &trivyScanner{opts: Options{Server: "http://trivy:4954", IgnoreUnfixed: true}, run: fakeRunner(trivyOutput, &calls)}
+
+	findings, err := s.Scan(context.Background(), Target{Image: "app:1.0", Platform: "linux/arm64"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"trivy", "image", "--format", "json", "--quiet", "--scanners", "vuln",
+		"--server", "http://trivy:4954", "--ignore-unfixed", "--platform", "linux/arm64", "app:1.0",
+	}, calls)
+
+	// The duplicate openssl finding is merged and critical sorts first.
+	require.Len(t, findings, 2)
+	assert.Equal(t, "CVE-2023-0002", findings[0].ID)
+	assert.Equal(t, SeverityCritical, findings[0].Severity)
+	assert.False(t, findings[0].Fixable())
+	assert.Equal(t, Finding{
+		ID: "CVE-2023-0001", Package: "openssl", InstalledVersion: "3.1.0", FixedVersion: "3.1.1",
+		Severity: SeverityHigh, Title: "OpenSSL overflow", URL: "https://avd.aquasec.com/nvd/cve-2023-0001",
+	}, findings[1])
+}
+
+func TestGrypeScanner_Scan(t *testing.T) {
+	var calls []string
+	s := &grypeScanner{opts: Options{IgnoreUnfixed: true}, run: fakeRunner(grypeOutput, &calls)}
+
+	findings, err := s.Scan(context.Background(), Target{Image: "app:1.0"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"grype", "app:1.0", "-o", "json", "--quiet", "--only-fixed"}, calls)
+	require.Len(t, findings, 2)
+	assert.Equal(t, "CVE-2024-1111", findings[0].ID)
+	assert.Equal(t, SeverityMedium, findings[0].Severity)
+	assert.Equal(t, "2.0.1", findings[0].FixedVersion)
+	assert.Equal(t, SeverityLow, findings[1].Severity)
+	assert.Equal(t, "Minor issue.", findings[1].Title)
+}
+
+func TestScanner_InvalidOutput(t *testing.T) {
+	var calls []string
+	s := &trivyScanner{run: fakeRunner("not json", &calls)}
+	_, err := s.Scan(context.Background(), Target{Image: "app"})
+	assert.ErrorContains(t, err, "failed to parse trivy output")
+}
+
+func TestNewScanner(t *testing.T) {
+	orig := lookPath
+	t.Cleanup(func() { lookPath = orig })
+
+	installed := map[string]bool{}
+	lookPath = func(name string) (string, error) {
+		if installed[name] {
+			return "/usr/bin/" + name, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	_, err := NewScanner(ScannerAuto, Options{})
+	assert.ErrorContains(t, err, "neither trivy nor grype")
+
+	installed[ScannerGrype] = true
+	s, err := NewScanner(ScannerAuto, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, ScannerGrype, s.Name())
+
+	// A Trivy server only needs the client, so it wins over a local grype.
+	s, err = NewScanner(ScannerAuto, Options{Server: "http://trivy:4954"})
+	require.NoError(t, err)
+	assert.Equal(t, ScannerTrivy, s.Name())
+
+	_, err = NewScanner(ScannerGrype, Options{Server: "http://trivy:4954"})
+	assert.Error(t, err)
+	_, err = NewScanner("clair", Options{})
+	assert.ErrorContains(t, err, "unknown scanner")
+}
+
+func TestParseSeverity(t *testing.T) {
+	s, err := ParseSeverity("HIGH")
+	require.NoError(t, err)
+	assert.Equal(t, SeverityHigh, s)
+
+	_, err = ParseSeverity("severe")
+	assert.Error(t, err)
+}
+
+func TestGate_Violations(t *testing.T) {
+	findings := []Finding{
+		{ID: "CVE-1", Severity: SeverityCritical},
+		{ID: "CVE-2", Severity: SeverityHigh, FixedVersion: "1.1"},
+		{ID: "CVE-3", Severity: SeverityMedium, FixedVersion: "2.0"},
+	}
+
+	assert.Len(t, Gate{FailOn: SeverityHigh}.Violations(findings), 2)
+	assert.Len(t, Gate{FailOn: SeverityMedium, OnlyFixable: true}.Violations(findings), 2)
+	assert.Empty(t, Gate{FailOn: SeverityHigh, Ignore: []string{"CVE-1", "CVE-2"}}.Violations(findings))
+}
+
+type fakeScanner struct {
+	findings map[string][]Finding
+}
+
+func (f fakeScanner) Name() string { return "fake" }
+
+func (f fakeScanner) Scan(_ context.Context, target Target) ([]Finding, error) {
+	findings, ok := f.findings[target.String()]
+	if !ok {
+		return nil, errors.New("image not found")
+	}
+	return findings, nil
+}
+
+func TestScan_Report(t *testing.T) {
+	scanner := fakeScanner{findings: map[string][]Finding{
+		"app (linux/amd64)": {{ID: "CVE-1", Package: "openssl", InstalledVersion: "3.1.0", Severity: SeverityCritical}},
+		"app (linux/arm64)": {
+			{ID: "CVE-1", Package: "openssl", InstalledVersion: "3.1.0", Severity: SeverityCritical},
+			{ID: "CVE-2", Package: "curl", InstalledVersion: "8.0", FixedVersion: "8.1", Severity: SeverityLow, URL: "https://example.com/CVE-2"},
+		},
+	}}
+	targets := []Target{
+		{Image: "app", Platform: "linux/amd64"},
+		{Image: "app", Platform: "linux/arm64"},
+		{Image: "missing"},
+	}
+
+	report := Scan(context.Background(), scanner, targets, &Gate{FailOn: SeverityHigh})
+	assert.Equal(t, "high", report.FailOn)
+	assert.Equal(t, 2, report.Violations())
+	require.Len(t, report.Failed(), 1)
+	assert.Equal(t, "image not found", report.Failed()[0].Error)
+	assert.Equal(t, map[Severity]int{SeverityCritical: 2, SeverityLow: 1}, report.Counts())
+
+	var summary bytes.Buffer
+	report.PrintSummary(&summary)
+	assert.Contains(t, summary.String(), "❌ missing: image not found")
+	assert.Contains(t, summary.String(), "3 image(s) scanned with fake: 2 critical, 1 low")
+	assert.Contains(t, summary.String(), "❌ 2 finding(s) at or above high")
+
+	var raw bytes.Buffer
+	require.NoError(t, report.WriteJSON(&raw))
+	assert.Contains(t, raw.String(), `"severity": "critical"`)
+
+	var sarif bytes.Buffer
+	require.NoError(t, report.WriteSARIF(&sarif, "build/Dockerfile"))
+	var log struct {
+		Runs []struct {
+			Tool struct {
+				Driver struct {
+					Rules []map[string]any `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(sarif.Bytes(), &log))
+	require.Len(t, log.Runs, 1)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, 2)
+	assert.Equal(t, "https://example.com/CVE-2", log.Runs[0].Tool.Driver.Rules[1]["helpUri"])
+	require.Len(t, log.Runs[0].Results, 3)
+	assert.Equal(t, "error", log.Runs[0].Results[0].Level)
+	assert.Equal(t, "note", log.Runs[0].Results[2].Level)
+	assert.Equal(t, "build/Dockerfile", log.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+}
+
+func TestNormalize_NoGate(t *testing.T) {
+	report := Scan(context.Background(), fakeScanner{findings: map[string][]Finding{"app": nil}}, []Target{{Image: "app"}}, nil)
+	assert.Empty(t, report.FailOn)
+	assert.NotNil(t, report.Results[0].Findings)
+
+	var summary bytes.Buffer
+	report.PrintSummary(&summary)
+	assert.Contains(t, summary.String(), "no vulnerabilities")
+	assert.NotContains(t, summary.String(), "at or above")
+}
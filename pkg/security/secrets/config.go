@@ -0,0 +1,216 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package secrets
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IgnoreFile is the per-repository file listing finding fingerprints to
+// ignore, one per line, as printed in reports.
+const IgnoreFile = ".gzh-secretsignore"
+
+// inlineAllowMarkers suppress findings on the line that contains them.
+var inlineAllowMarkers = []string{"gzh:allow-secret", "gitleaks:allow"}
+
+// Config customizes the scanner.
+//
+//	rules:
+//	  - id: internal-token
+//	    description: Internal service token
+//	    regex: 'itk_[a-z0-9]{32}'
+//	    keywords: [itk_]
+//	    severity: high
+//	disabled_rules: [generic-credential]
+//	allowlist:
+//	  paths: ['(^|/)testdata/', '\.lock$']
+//	  regexes: ['EXAMPLE']
+//	  stopwords: [dummy, placeholder]
+//	  fingerprints: ['myorg/app:config/dev.env:generic-credential:3']
+type Config struct {
+	Rules         []RuleConfig `yaml:"rules"`
+	DisabledRules []string     `yaml:"disabled_rules"`
+	Allowlist     Allowlist    `yaml:"allowlist"`
+	// MaxFileSize skips files larger than this many bytes; 0 uses
+	// DefaultMaxFileSize.
+	MaxFileSize int64 `yaml:"max_file_size"`
+}
+
+// RuleConfig declares a custom rule.
+type RuleConfig struct {
+	ID          string   `yaml:"id"`
+	Description string   `yaml:"description"`
+	Regex       string   `yaml:"regex"`
+	SecretGroup int      `yaml:"secret_group"`
+	Keywords    []string `yaml:"keywords"`
+	Entropy     float64  `yaml:"entropy"`
+	Severity    Severity `yaml:"severity"`
+	Path        string   `yaml:"path"`
+}
+
+// Allowlist suppresses known false positives.
+type Allowlist struct {
+	// Paths are regular expressions matched against slash-separated file
+	// paths relative to the repository root.
+	Paths []string `yaml:"paths"`
+	// Regexes are matched against the detected secret.
+	Regexes []string `yaml:"regexes"`
+	// Stopwords skip secrets containing any of these words.
+	Stopwords []string `yaml:"stopwords"`
+	// Fingerprints are finding fingerprints, with or without the leading
+	// "repository:" part.
+	Fingerprints []string `yaml:"fingerprints"`
+}
+
+// LoadConfig reads a scanner config file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret scan config %s: %w", path, err)
+	}
+	var cfg Config
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid secret scan config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// compile turns a custom rule into a Rule.
+func (rc RuleConfig) compile() (*Rule, error) {
+	if rc.ID == "" {
+		return nil, fmt.Errorf("custom rule without id")
+	}
+	re, err := regexp.Compile(rc.Regex)
+	if err != nil {
+		return nil, fmt.Errorf("rule %s: invalid regex: %w", rc.ID, err)
+	}
+	rule := &Rule{
+		ID:          rc.ID,
+		Description: rc.Description,
+		Severity:    rc.Severity,
+		Regex:       re,
+		SecretGroup: rc.SecretGroup,
+		Entropy:     rc.Entropy,
+	}
+	if rule.Severity == 0 {
+		rule.Severity = SeverityMedium
+	}
+	for _, k := range rc.Keywords {
+		rule.Keywords = append(rule.Keywords, strings.ToLower(k))
+	}
+	if rc.Path != "" {
+		if rule.Path, err = regexp.Compile(rc.Path); err != nil {
+			return nil, fmt.Errorf("rule %s: invalid path: %w", rc.ID, err)
+		}
+	}
+	return rule, nil
+}
+
+// allowlist is the compiled form of Allowlist plus per-repository ignores.
+type allowlist struct {
+	paths        []*regexp.Regexp
+	regexes      []*regexp.Regexp
+	stopwords    []string
+	fingerprints map[string]bool
+}
+
+func compileAllowlist(a Allowlist) (*allowlist, error) {
+	out := &allowlist{fingerprints: make(map[string]bool)}
+	for _, p := range a.Paths {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowlist path %q: %w", p, err)
+		}
+		out.paths = append(out.paths, re)
+	}
+	for _, p := range a.Regexes {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowlist regex %q: %w", p, err)
+		}
+		out.regexes = append(out.regexes, re)
+	}
+	for _, w := range a.Stopwords {
+		out.stopwords = append(out.stopwords, strings.ToLower(w))
+	}
+	for _, f := range a.Fingerprints {
+		out.fingerprints[f] = true
+	}
+	return out, nil
+}
+
+func (a *allowlist) allowsPath(path string) bool {
+	for _, re := range a.paths {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *allowlist) allowsSecret(secret string) bool {
+	for _, re := range a.regexes {
+		if re.MatchString(secret) {
+			return true
+		}
+	}
+	lower := strings.ToLower(secret)
+	for _, w := range a.stopwords {
+		if strings.Contains(lower, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsFinding checks the config fingerprints and the repository's own
+// ignore list.
+func (a *allowlist) allowsFinding(f Finding, repoIgnores map[string]bool) bool {
+	local := f.localFingerprint()
+	return a.fingerprints[f.Fingerprint] || a.fingerprints[local] || repoIgnores[local]
+}
+
+// loadIgnoreFile reads the fingerprints listed in a repository's IgnoreFile.
+func loadIgnoreFile(root string) map[string]bool {
+	ignores := make(map[string]bool)
+	file, err := os.Open(filepath.Join(root, IgnoreFile))
+	if err != nil {
+		return ignores
+	}
+	defer file.Close()
+	parseIgnores(bufio.NewScanner(file), ignores)
+	return ignores
+}
+
+func parseIgnores(scanner *bufio.Scanner, ignores map[string]bool) {
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ignores[line] = true
+	}
+}
+
+// NewScannerFromFile creates a scanner from the config file at path, or with
+// the built-in rules when path is empty.
+func NewScannerFromFile(path string) (*Scanner, error) {
+	if path == "" {
+		return NewScanner(nil)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewScanner(cfg)
+}
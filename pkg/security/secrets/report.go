@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Report consolidates the findings of a multi-repository run. It is safe for
+// concurrent use.
+type Report struct {
+	mu           sync.Mutex
+	generatedAt  time.Time
+	repositories int
+	findings     []Finding
+	errors       map[string]string
+}
+
+// NewReport creates an empty report.
+func NewReport() *Report {
+	return &Report{generatedAt: time.Now(), errors: make(map[string]string)}
+}
+
+// Add records the findings of one scanned repository.
+func (r *Report) Add(findings []Finding) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.repositories++
+	r.findings = append(r.findings, findings...)
+}
+
+// AddError records a repository that could not be scanned.
+func (r *Report) AddError(repository string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors[repository] = err.Error()
+}
+
+// Findings returns all findings ordered by repository, file and line.
+func (r *Report) Findings() []Finding {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := append([]Finding(nil), r.findings...)
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	return out
+}
+
+// CountAtLeast returns the number of findings of severity min or higher.
+func (r *Report) CountAtLeast(min Severity) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, f := range r.findings {
+		if f.Severity >= min {
+			n++
+		}
+	}
+	return n
+}
+
+type reportJSON struct {
+	GeneratedAt  time.Time         `json:"generatedAt"`
+	Repositories int               `json:"repositories"`
+	Summary      map[string]int    `json:"summary"`
+	Findings     []Finding         `json:"findings"`
+	Errors       map[string]string `json:"errors,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *Report) MarshalJSON() ([]byte, error) {
+	findings := r.Findings()
+	r.mu.Lock()
+	out := reportJSON{
+		GeneratedAt:  r.generatedAt,
+		Repositories: r.repositories,
+		Summary:      make(map[string]int),
+		Findings:     findings,
+		Errors:       r.errors,
+	}
+	r.mu.Unlock()
+	if out.Findings == nil {
+		out.Findings = []Finding{}
+	}
+	for _, f := range findings {
+		out.Summary[f.Severity.String()]++
+	}
+	return json.Marshal(out)
+}
+
+// WriteJSON writes the report to path.
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal secret scan report: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// PrintSummary writes a table of findings followed by scan errors.
+func (r *Report) PrintSummary(w io.Writer) {
+	findings := r.Findings()
+	r.mu.Lock()
+	repos, errs := r.repositories, len(r.errors)
+	r.mu.Unlock()
+
+	fmt.Fprintf(w, "\n🔐 Secret scan: %d finding(s) in %d repositories\n", len(findings), repos)
+	if len(findings) > 0 {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "SEVERITY\tRULE\tLOCATION\tSECRET")
+		for _, f := range findings {
+			fmt.Fprintf(tw, "%s\t%s\t%s:%s:%d\t%s\n", f.Severity, f.RuleID, f.Repository, f.File, f.Line, f.Secret)
+		}
+		_ = tw.Flush()
+	}
+	if errs > 0 {
+		fmt.Fprintf(w, "⚠️  %d repositories could not be scanned\n", errs)
+	}
+}
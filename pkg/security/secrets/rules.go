@@ -0,0 +1,217 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+// Package secrets scans repository contents for leaked credentials with a
+// gitleaks-style rules engine: each rule is a regular expression guarded by
+// cheap keyword checks and, for generic patterns, a minimum entropy.
+package secrets
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// Severity ranks how damaging a leaked secret is.
+type Severity int
+
+const (
+	SeverityLow Severity = iota + 1
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityLow:      "low",
+	SeverityMedium:   "medium",
+	SeverityHigh:     "high",
+	SeverityCritical: "critical",
+}
+
+// String returns the lower-case severity name.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Severity) UnmarshalText(text []byte) error {
+	parsed, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// ParseSeverity parses a severity name.
+func ParseSeverity(name string) (Severity, error) {
+	for s, n := range severityNames {
+		if strings.EqualFold(n, name) {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q (expected low, medium, high or critical)", name)
+}
+
+// Rule detects one kind of secret.
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity
+	// Regex matches the secret. When it has a capture group, SecretGroup
+	// selects the group holding the secret itself; otherwise the whole match
+	// is the secret.
+	Regex       *regexp.Regexp
+	SecretGroup int
+	// Keywords must appear (case-insensitively) on a line before the regex
+	// is tried. An empty list always tries the regex.
+	Keywords []string
+	// Entropy is the minimum Shannon entropy of the secret, used to skip
+	// placeholders such as "changeme" in generic rules.
+	Entropy float64
+	// Path restricts the rule to files whose path matches.
+	Path *regexp.Regexp
+}
+
+// secret extracts the secret from a regex submatch.
+func (r *Rule) secret(match []string) string {
+	if r.SecretGroup > 0 && r.SecretGroup < len(match) {
+		return match[r.SecretGroup]
+	}
+	if len(match) > 1 && r.SecretGroup == 0 {
+		for _, g := range match[1:] {
+			if g != "" {
+				return g
+			}
+		}
+	}
+	return match[0]
+}
+
+// hasKeyword reports whether lower (a lower-cased line) contains one of the
+// rule's keywords.
+func (r *Rule) hasKeyword(lower string) bool {
+	if len(r.Keywords) == 0 {
+		return true
+	}
+	for _, k := range r.Keywords {
+		if strings.Contains(lower, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRules returns the built-in rules.
+func DefaultRules() []*Rule {
+	return []*Rule{
+		{
+			ID:          "aws-access-key-id",
+			Description: "AWS access key ID",
+			Severity:    SeverityHigh,
+			Regex:       regexp.MustCompile(`\b((?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[A-Z0-9]{16})\b`),
+			Keywords:    []string{"akia", "asia", "agpa", "aida", "aroa", "anpa", "anva", "aipa"},
+		},
+		{
+			ID:          "aws-secret-access-key",
+			Description: "AWS secret access key",
+			Severity:    SeverityCritical,
+			Regex:       regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`),
+			Keywords:    []string{"aws"},
+			Entropy:     3.5,
+		},
+		{
+			ID:          "github-token",
+			Description: "GitHub personal access, OAuth or app token",
+			Severity:    SeverityCritical,
+			Regex:       regexp.MustCompile(`\b((?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{82})\b`),
+			Keywords:    []string{"ghp_", "gho_", "ghu_", "ghs_", "ghr_", "github_pat_"},
+		},
+		{
+			ID:          "gitlab-token",
+			Description: "GitLab personal access token",
+			Severity:    SeverityCritical,
+			Regex:       regexp.MustCompile(`\b(glpat-[A-Za-z0-9_\-]{20})\b`),
+			Keywords:    []string{"glpat-"},
+		},
+		{
+			ID:          "slack-token",
+			Description: "Slack token",
+			Severity:    SeverityHigh,
+			Regex:       regexp.MustCompile(`\b(xox[baprs]-[0-9A-Za-z\-]{10,72})\b`),
+			Keywords:    []string{"xoxb-", "xoxa-", "xoxp-", "xoxr-", "xoxs-"},
+		},
+		{
+			ID:          "slack-webhook",
+			Description: "Slack incoming webhook URL",
+			Severity:    SeverityMedium,
+			Regex:       regexp.MustCompile(`(https://hooks\.slack\.com/services/T[A-Za-z0-9_]+/B[A-Za-z0-9_]+/[A-Za-z0-9_]+)`),
+			Keywords:    []string{"hooks.slack.com"},
+		},
+		{
+			ID:          "stripe-secret-key",
+			Description: "Stripe live secret key",
+			Severity:    SeverityCritical,
+			Regex:       regexp.MustCompile(`\b((?:sk|rk)_live_[0-9A-Za-z]{24,99})\b`),
+			Keywords:    []string{"sk_live_", "rk_live_"},
+		},
+		{
+			ID:          "google-api-key",
+			Description: "Google API key",
+			Severity:    SeverityMedium,
+			Regex:       regexp.MustCompile(`\b(AIza[0-9A-Za-z\-_]{35})\b`),
+			Keywords:    []string{"aiza"},
+		},
+		{
+			ID:          "npm-token",
+			Description: "npm access token",
+			Severity:    SeverityHigh,
+			Regex:       regexp.MustCompile(`\b(npm_[A-Za-z0-9]{36})\b`),
+			Keywords:    []string{"npm_"},
+		},
+		{
+			ID:          "private-key",
+			Description: "Private key",
+			Severity:    SeverityCritical,
+			Regex:       regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`),
+			Keywords:    []string{"private key"},
+		},
+		{
+			ID:          "generic-credential",
+			Description: "Credential assigned to a secret-looking variable",
+			Severity:    SeverityMedium,
+			Regex: regexp.MustCompile(`(?i)(?:api_?key|secret|token|passw(?:or)?d|credential)s?["']?\s*(?::=|=>|[:=])\s*` +
+				`["']([A-Za-z0-9_\-+/=.]{16,})["']`),
+			Keywords: []string{"key", "secret", "token", "passw", "credential"},
+			Entropy:  3.5,
+		},
+	}
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	n := float64(len([]rune(s)))
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package secrets
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxFileSize is the largest file scanned by default.
+const DefaultMaxFileSize = 1 << 20
+
+// Finding is one detected secret.
+type Finding struct {
+	Repository  string   `json:"repository"`
+	File        string   `json:"file"`
+	Line        int      `json:"line"`
+	RuleID      string   `json:"ruleId"`
+	Description string   `json:"description"`
+	Severity    Severity `json:"severity"`
+	// Secret is the detected value, redacted.
+	Secret string `json:"secret"`
+	// Fingerprint identifies the finding for allowlists and ignore files:
+	// repository:file:rule:line.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// localFingerprint is the fingerprint without the repository part, as used
+// in a repository's own IgnoreFile.
+func (f Finding) localFingerprint() string {
+	return f.File + ":" + f.RuleID + ":" + strconv.Itoa(f.Line)
+}
+
+// Scanner detects secrets in repository contents. It is safe for concurrent
+// use.
+type Scanner struct {
+	rules       []*Rule
+	allow       *allowlist
+	maxFileSize int64
+}
+
+// NewScanner creates a scanner from cfg, which may be nil for the built-in
+// rules without an allowlist.
+func NewScanner(cfg *Config) (*Scanner, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	disabled := make(map[string]bool)
+	for _, id := range cfg.DisabledRules {
+		disabled[id] = true
+	}
+
+	s := &Scanner{maxFileSize: cfg.MaxFileSize}
+	if s.maxFileSize <= 0 {
+		s.maxFileSize = DefaultMaxFileSize
+	}
+	for _, r := range DefaultRules() {
+		if !disabled[r.ID] {
+			s.rules = append(s.rules, r)
+		}
+	}
+	for _, rc := range cfg.Rules {
+		rule, err := rc.compile()
+		if err != nil {
+			return nil, err
+		}
+		if !disabled[rule.ID] {
+			s.rules = append(s.rules, rule)
+		}
+	}
+
+	allow, err := compileAllowlist(cfg.Allowlist)
+	if err != nil {
+		return nil, err
+	}
+	s.allow = allow
+	return s, nil
+}
+
+// ScanDir scans the working tree at root, skipping the .git directory,
+// binary files and files above the size limit.
+func (s *Scanner) ScanDir(ctx context.Context, repository, root string) ([]Finding, error) {
+	var findings []Finding
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > s.maxFileSize {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if s.allow.allowsPath(rel) {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		findings = append(findings, s.scanContent(repository, rel, data)...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	return s.filter(findings, loadIgnoreFile(root)), nil
+}
+
+// ScanGitTree scans the files of rev in the repository at gitDir, which may
+// be a bare or mirror clone without a working tree.
+func (s *Scanner) ScanGitTree(ctx context.Context, repository, gitDir, rev string) ([]Finding, error) {
+	if rev == "" {
+		rev = "HEAD"
+	}
+	cmd := exec.CommandContext(ctx, "git", "--git-dir", gitDir, "archive", "--format=tar", rev)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run git archive: %w", err)
+	}
+
+	var findings []Finding
+	ignores := make(map[string]bool)
+	tr := tar.NewReader(stdout)
+	var readErr error
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			readErr = err
+			break
+		}
+		if hdr.Typeflag != tar.TypeReg || hdr.Size > s.maxFileSize {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			readErr = err
+			break
+		}
+		if hdr.Name == IgnoreFile {
+			parseIgnores(bufio.NewScanner(bytes.NewReader(data)), ignores)
+			continue
+		}
+		if s.allow.allowsPath(hdr.Name) {
+			continue
+		}
+		findings = append(findings, s.scanContent(repository, hdr.Name, data)...)
+	}
+	_, _ = io.Copy(io.Discard, stdout)
+
+	if err := cmd.Wait(); err != nil {
+		// An empty repository has no HEAD to archive and nothing to scan.
+		if strings.Contains(stderr.String(), "not a valid object name") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("git archive failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if readErr != nil {
+		return nil, fmt.Errorf("failed to read git archive: %w", readErr)
+	}
+	return s.filter(findings, ignores), nil
+}
+
+// ScanBytes scans a single file's contents. Allowlisted paths return nothing.
+func (s *Scanner) ScanBytes(repository, path string, data []byte) []Finding {
+	if s.allow.allowsPath(path) {
+		return nil
+	}
+	return s.filter(s.scanContent(repository, path, data), nil)
+}
+
+// scanContent applies every rule to each line of data.
+func (s *Scanner) scanContent(repository, path string, data []byte) []Finding {
+	if isBinary(data) {
+		return nil
+	}
+
+	var findings []Finding
+	lineNo := 0
+	for len(data) > 0 {
+		lineNo++
+		var line []byte
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			line, data = data, nil
+		}
+
+		text := string(line)
+		lower := strings.ToLower(text)
+		if hasInlineAllow(lower) {
+			continue
+		}
+		for _, rule := range s.rules {
+			if rule.Path != nil && !rule.Path.MatchString(path) {
+				continue
+			}
+			if !rule.hasKeyword(lower) {
+				continue
+			}
+			for _, match := range rule.Regex.FindAllStringSubmatch(text, -1) {
+				secret := rule.secret(match)
+				if rule.Entropy > 0 && shannonEntropy(secret) < rule.Entropy {
+					continue
+				}
+				if s.allow.allowsSecret(secret) {
+					continue
+				}
+				f := Finding{
+					Repository:  repository,
+					File:        path,
+					Line:        lineNo,
+					RuleID:      rule.ID,
+					Description: rule.Description,
+					Severity:    rule.Severity,
+					Secret:      Redact(secret),
+				}
+				f.Fingerprint = repository + ":" + f.localFingerprint()
+				findings = append(findings, f)
+			}
+		}
+	}
+	return findings
+}
+
+// filter drops allowlisted findings.
+func (s *Scanner) filter(findings []Finding, repoIgnores map[string]bool) []Finding {
+	out := findings[:0]
+	for _, f := range findings {
+		if !s.allow.allowsFinding(f, repoIgnores) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func hasInlineAllow(lower string) bool {
+	for _, marker := range inlineAllowMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBinary reports whether data looks like a binary file.
+func isBinary(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// Redact keeps the first four characters of a secret so that reports can
+// be cross-checked without leaking it again.
+func Redact(secret string) string {
+	if len(secret) <= 8 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + strings.Repeat("*", 8)
+}
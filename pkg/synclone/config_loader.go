@@ -180,12 +180,13 @@ func (cfg *bulkCloneConfig) GetGitlabGroupConfig(groupName string) (*BulkCloneGi
 	// Check defaults
 	if cfg.Default.Gitlab.GroupName == groupName {
 		return &BulkCloneGitlab{
-			RootPath:  cfg.Default.Gitlab.RootPath,
-			Provider:  cfg.Default.Gitlab.Provider,
-			URL:       cfg.Default.Gitlab.URL,
-			Protocol:  cfg.Default.Protocol,
-			GroupName: groupName,
-			Recursive: cfg.Default.Gitlab.Recursive,
+			RootPath:     cfg.Default.Gitlab.RootPath,
+			Provider:     cfg.Default.Gitlab.Provider,
+			URL:          cfg.Default.Gitlab.URL,
+			Protocol:     cfg.Default.Protocol,
+			GroupName:    groupName,
+			Recursive:    cfg.Default.Gitlab.Recursive,
+			PathTemplate: cfg.Default.Gitlab.PathTemplate,
 		}, nil
 	}
 
@@ -318,6 +319,10 @@ func (cfg *bulkCloneConfig) mergeDefaults(overlayDefault *bulkCloneDefault) {
 	if overlayDefault.Gitlab.GroupName != "" {
 		cfg.Default.Gitlab.GroupName = overlayDefault.Gitlab.GroupName
 	}
+
+	if overlayDefault.Gitlab.PathTemplate != "" {
+		cfg.Default.Gitlab.PathTemplate = overlayDefault.Gitlab.PathTemplate
+	}
 	// Recursive is a boolean, so we merge it unconditionally if it's true
 	if overlayDefault.Gitlab.Recursive {
 		cfg.Default.Gitlab.Recursive = overlayDefault.Gitlab.Recursive
@@ -0,0 +1,359 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package bulkclone
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultHookTimeout limits hooks without an explicit timeout.
+	DefaultHookTimeout = 5 * time.Minute
+
+	// maxHookOutput is how much output of a hook is kept in the run report.
+	// Longer output keeps its tail, where errors usually are.
+	maxHookOutput = 16 * 1024
+)
+
+// HookEvent is a point in the lifecycle of a repository sync.
+type HookEvent string
+
+// Lifecycle events hooks can be attached to.
+const (
+	// HookPreClone runs before a repository is cloned. A failing pre-clone
+	// hook skips the clone and marks the repository as failed.
+	HookPreClone HookEvent = "pre-clone"
+	// HookPostClone runs after a repository was cloned.
+	HookPostClone HookEvent = "post-clone"
+	// HookPostUpdate runs after an existing repository was updated.
+	HookPostUpdate HookEvent = "post-update"
+	// HookOnFailure runs when cloning or updating a repository failed.
+	HookOnFailure HookEvent = "on-failure"
+)
+
+// Hooks lists the hooks of each lifecycle event.
+type Hooks struct {
+	PreClone   []Hook `yaml:"pre_clone,omitempty" json:"preClone,omitempty"`     //nolint:tagliatelle // YAML compatibility required
+	PostClone  []Hook `yaml:"post_clone,omitempty" json:"postClone,omitempty"`   //nolint:tagliatelle // YAML compatibility required
+	PostUpdate []Hook `yaml:"post_update,omitempty" json:"postUpdate,omitempty"` //nolint:tagliatelle // YAML compatibility required
+	OnFailure  []Hook `yaml:"on_failure,omitempty" json:"onFailure,omitempty"`   //nolint:tagliatelle // YAML compatibility required
+}
+
+// Hook is a shell command or a gz plugin run for a lifecycle event.
+type Hook struct {
+	// Name identifies the hook in the run report; defaults to the command.
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Run is a shell command, executed with sh -c (cmd /C on Windows).
+	Run string `yaml:"run,omitempty" json:"run,omitempty"`
+	// Plugin is an installed gz plugin, executed with 'gz plugin run'.
+	Plugin string   `yaml:"plugin,omitempty" json:"plugin,omitempty"`
+	Args   []string `yaml:"args,omitempty" json:"args,omitempty"`
+	// Timeout defaults to DefaultHookTimeout.
+	Timeout time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+// Merge returns the hooks of h followed by those of other. Either may be
+// nil; the result is nil when both are.
+func (h *Hooks) Merge(other *Hooks) *Hooks {
+	if h == nil {
+		return other
+	}
+	if other == nil {
+		return h
+	}
+	return &Hooks{
+		PreClone:   append(append([]Hook(nil), h.PreClone...), other.PreClone...),
+		PostClone:  append(append([]Hook(nil), h.PostClone...), other.PostClone...),
+		PostUpdate: append(append([]Hook(nil), h.PostUpdate...), other.PostUpdate...),
+		OnFailure:  append(append([]Hook(nil), h.OnFailure...), other.OnFailure...),
+	}
+}
+
+// Empty reports whether no hook is configured.
+func (h *Hooks) Empty() bool {
+	return h == nil || len(h.PreClone)+len(h.PostClone)+len(h.PostUpdate)+len(h.OnFailure) == 0
+}
+
+// Validate checks that every hook runs either a command or a plugin.
+func (h *Hooks) Validate() error {
+	if h == nil {
+		return nil
+	}
+	for _, event := range []HookEvent{HookPreClone, HookPostClone, HookPostUpdate, HookOnFailure} {
+		for i, hook := range h.forEvent(event) {
+			if (hook.Run == "") == (hook.Plugin == "") {
+				return fmt.Errorf("%s hook %d: exactly one of run or plugin is required", event, i+1)
+			}
+			if hook.Timeout < 0 {
+				return fmt.Errorf("%s hook %d: timeout must not be negative", event, i+1)
+			}
+		}
+	}
+	return nil
+}
+
+func (h *Hooks) forEvent(event HookEvent) []Hook {
+	if h == nil {
+		return nil
+	}
+	switch event {
+	case HookPreClone:
+		return h.PreClone
+	case HookPostClone:
+		return h.PostClone
+	case HookPostUpdate:
+		return h.PostUpdate
+	case HookOnFailure:
+		return h.OnFailure
+	default:
+		return nil
+	}
+}
+
+func (h Hook) label() string {
+	switch {
+	case h.Name != "":
+		return h.Name
+	case h.Plugin != "":
+		return "plugin:" + h.Plugin
+	default:
+		return h.Run
+	}
+}
+
+// HookContext describes the repository a hook runs for. Hooks receive it
+// as JSON on stdin and as GZ_* environment variables.
+type HookContext struct {
+	Event        HookEvent `json:"event"`
+	Provider     string    `json:"provider"`
+	Organization string    `json:"organization"`
+	Repository   string    `json:"repository"`
+	Path         string    `json:"path"`
+	// Operation is the sync operation: clone, pull, fetch or reset.
+	Operation string `json:"operation"`
+	// Error is the failure that triggered an on-failure hook.
+	Error string `json:"error,omitempty"`
+}
+
+func (hc HookContext) env() []string {
+	return []string{
+		"GZ_HOOK_EVENT=" + string(hc.Event),
+		"GZ_PROVIDER=" + hc.Provider,
+		"GZ_ORG=" + hc.Organization,
+		"GZ_REPO_NAME=" + hc.Repository,
+		"GZ_REPO_PATH=" + hc.Path,
+		"GZ_OPERATION=" + hc.Operation,
+		"GZ_ERROR=" + hc.Error,
+	}
+}
+
+// HookResult is the outcome of one hook execution, kept in the run report.
+type HookResult struct {
+	Hook       string        `json:"hook"`
+	Event      HookEvent     `json:"event"`
+	Repository string        `json:"repository"`
+	ExitCode   int           `json:"exitCode"`
+	TimedOut   bool          `json:"timedOut,omitempty"`
+	Duration   time.Duration `json:"duration"`
+	Output     string        `json:"output,omitempty"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// Failed reports whether the hook did not complete successfully.
+func (r HookResult) Failed() bool {
+	return r.Error != ""
+}
+
+// HookRunner executes lifecycle hooks and collects their results. A nil
+// runner runs no hooks, so callers need not check whether hooks are
+// configured.
+type HookRunner struct {
+	hooks *Hooks
+	// gzPath is the gz binary used to run plugin hooks.
+	gzPath string
+
+	mu      sync.Mutex
+	results []HookResult
+}
+
+// NewHookRunner creates a runner for hooks. It returns nil when no hook is
+// configured.
+func NewHookRunner(hooks *Hooks) *HookRunner {
+	if hooks.Empty() {
+		return nil
+	}
+	gzPath, err := os.Executable()
+	if err != nil {
+		gzPath = "gz"
+	}
+	return &HookRunner{hooks: hooks, gzPath: gzPath}
+}
+
+// Wrap runs op for a repository between its lifecycle hooks: pre-clone
+// before a clone, then post-clone or post-update after success and
+// on-failure after an error. A failing pre-clone hook fails the repository
+// without running op; failing post hooks are only reported.
+func (r *HookRunner) Wrap(ctx context.Context, hc HookContext, op func(context.Context) error) error {
+	if r == nil {
+		return op(ctx)
+	}
+
+	fail := func(err error) error {
+		hc.Event = HookOnFailure
+		hc.Error = err.Error()
+		_ = r.Run(ctx, hc) //nolint:errcheck // Failures are recorded in the run report
+		return err
+	}
+
+	clone := hc.Operation == "clone"
+	if clone {
+		hc.Event = HookPreClone
+		if err := r.Run(ctx, hc); err != nil {
+			return fail(err)
+		}
+	}
+
+	if err := op(ctx); err != nil {
+		return fail(err)
+	}
+
+	hc.Event = HookPostUpdate
+	if clone {
+		hc.Event = HookPostClone
+	}
+	_ = r.Run(ctx, hc) //nolint:errcheck // Failures are recorded in the run report
+	return nil
+}
+
+// Run executes the hooks of hc.Event in order and stops at the first
+// failure, which is returned.
+func (r *HookRunner) Run(ctx context.Context, hc HookContext) error {
+	if r == nil {
+		return nil
+	}
+	for _, hook := range r.hooks.forEvent(hc.Event) {
+		result := r.execute(ctx, hook, hc)
+		r.mu.Lock()
+		r.results = append(r.results, result)
+		r.mu.Unlock()
+
+		if result.Failed() {
+			return fmt.Errorf("%s hook %s failed: %s", hc.Event, result.Hook, result.Error)
+		}
+	}
+	return nil
+}
+
+// Results returns the results collected so far.
+func (r *HookRunner) Results() []HookResult {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]HookResult(nil), r.results...)
+}
+
+func (r *HookRunner) execute(ctx context.Context, hook Hook, hc HookContext) HookResult {
+	timeout := hook.Timeout
+	if timeout == 0 {
+		timeout = DefaultHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	switch {
+	case hook.Plugin != "":
+		args := append([]string{"plugin", "run", hook.Plugin, "--"}, hook.Args...)
+		cmd = exec.CommandContext(ctx, r.gzPath, args...)
+	case runtime.GOOS == "windows":
+		cmd = exec.CommandContext(ctx, "cmd", "/C", hook.Run)
+	default:
+		cmd = exec.CommandContext(ctx, "sh", "-c", hook.Run)
+	}
+	cmd.WaitDelay = 5 * time.Second
+
+	// 클론 전에는 저장소 디렉토리가 없으므로 상위 디렉토리에서 실행한다
+	cmd.Dir = hc.Path
+	if _, err := os.Stat(hc.Path); err != nil {
+		cmd.Dir = filepath.Dir(hc.Path)
+	}
+	cmd.Env = append(os.Environ(), hc.env()...)
+
+	input, err := json.Marshal(hc)
+	if err != nil {
+		return HookResult{Hook: hook.label(), Event: hc.Event, Repository: hc.Repository, ExitCode: -1, Error: err.Error()}
+	}
+	cmd.Stdin = bytes.NewReader(input)
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	start := time.Now()
+	err = cmd.Run()
+	result := HookResult{
+		Hook:       hook.label(),
+		Event:      hc.Event,
+		Repository: hc.Repository,
+		Duration:   time.Since(start),
+		Output:     tail(output.String(), maxHookOutput),
+	}
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.ExitCode = -1
+		result.TimedOut = true
+		result.Error = fmt.Sprintf("timed out after %s", timeout)
+	case err != nil:
+		result.ExitCode = -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+		}
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func tail(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	s = s[len(s)-limit:]
+	// 잘린 UTF-8 문자가 앞에 남지 않도록 다음 줄부터 보존한다
+	if i := strings.IndexByte(s, '\n'); i >= 0 && i < len(s)-1 {
+		s = s[i+1:]
+	}
+	return "…\n" + s
+}
+
+// PrintHookSummary prints the failed hooks of state and where the full
+// hook report was written.
+func PrintHookSummary(state *CloneState, reportPath string) {
+	if len(state.HookResults) == 0 {
+		return
+	}
+
+	failed := state.FailedHooks()
+	fmt.Printf("🪝 Hooks: %d run, %d failed\n", len(state.HookResults), len(failed))
+	for _, r := range failed {
+		fmt.Printf("   ❌ %s %s (%s): %s\n", r.Repository, r.Event, r.Hook, r.Error)
+	}
+	if reportPath != "" {
+		fmt.Printf("   Hook output: %s\n", reportPath)
+	}
+}
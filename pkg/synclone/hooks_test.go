@@ -0,0 +1,144 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package bulkclone
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func skipWithoutShell(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook tests use POSIX shell commands")
+	}
+}
+
+func TestHooksConfig(t *testing.T) {
+	var hooks Hooks
+	require.NoError(t, yaml.Unmarshal([]byte(`
+pre_clone:
+  - run: echo pre
+post_clone:
+  - name: install
+    run: npm ci
+    timeout: 10m
+on_failure:
+  - plugin: notify
+    args: [--channel, ops]
+`), &hooks))
+	require.NoError(t, hooks.Validate())
+	assert.Equal(t, 10*time.Minute, hooks.PostClone[0].Timeout)
+	assert.Equal(t, "plugin:notify", hooks.OnFailure[0].label())
+
+	merged := (&Hooks{PostClone: []Hook{{Run: "global"}}}).Merge(&hooks)
+	require.Len(t, merged.PostClone, 2)
+	assert.Equal(t, "global", merged.PostClone[0].Run, "global hooks run first")
+	assert.Same(t, &hooks, (*Hooks)(nil).Merge(&hooks))
+
+	invalid := Hooks{PostUpdate: []Hook{{Run: "make", Plugin: "lint"}}}
+	assert.ErrorContains(t, invalid.Validate(), "post-update hook 1: exactly one of run or plugin")
+
+	assert.Nil(t, NewHookRunner(&Hooks{}), "no runner without hooks")
+}
+
+func TestHookRunnerLifecycle(t *testing.T) {
+	skipWithoutShell(t)
+	root := t.TempDir()
+	repoPath := filepath.Join(root, "api")
+
+	runner := NewHookRunner(&Hooks{
+		PreClone:  []Hook{{Name: "pre", Run: `echo "$GZ_HOOK_EVENT $GZ_REPO_NAME in $(basename "$PWD")"`}},
+		PostClone: []Hook{{Name: "post", Run: `cat; echo; echo "$GZ_ORG/$GZ_REPO_NAME $GZ_OPERATION"`}},
+	})
+	hc := HookContext{Provider: "github", Organization: "acme", Repository: "api", Path: repoPath, Operation: "clone"}
+
+	cloned := false
+	err := runner.Wrap(context.Background(), hc, func(context.Context) error {
+		cloned = true
+		return os.Mkdir(repoPath, 0o755)
+	})
+	require.NoError(t, err)
+	assert.True(t, cloned)
+
+	results := runner.Results()
+	require.Len(t, results, 2)
+	assert.Equal(t, HookPreClone, results[0].Event)
+	assert.Equal(t, "pre-clone api in "+filepath.Base(root)+"\n", results[0].Output, "pre-clone runs in the parent directory")
+	assert.Equal(t, HookPostClone, results[1].Event)
+	assert.Contains(t, results[1].Output, `"event":"post-clone"`, "the context is passed as JSON on stdin")
+	assert.Contains(t, results[1].Output, "acme/api clone")
+	assert.False(t, results[1].Failed())
+}
+
+func TestHookRunnerFailures(t *testing.T) {
+	skipWithoutShell(t)
+	hc := HookContext{Repository: "web", Path: filepath.Join(t.TempDir(), "web"), Operation: "clone"}
+
+	runner := NewHookRunner(&Hooks{
+		PreClone:  []Hook{{Name: "guard", Run: "echo too big >&2; exit 3"}},
+		OnFailure: []Hook{{Name: "report", Run: `echo "$GZ_ERROR"`}},
+	})
+	err := runner.Wrap(context.Background(), hc, func(context.Context) error {
+		t.Fatal("the clone must not run after a failing pre-clone hook")
+		return nil
+	})
+	require.ErrorContains(t, err, "pre-clone hook guard failed")
+
+	results := runner.Results()
+	require.Len(t, results, 2)
+	assert.Equal(t, 3, results[0].ExitCode)
+	assert.Equal(t, "too big\n", results[0].Output)
+	assert.Equal(t, HookOnFailure, results[1].Event)
+	assert.Contains(t, results[1].Output, "pre-clone hook guard failed")
+
+	// 업데이트 실패 시 on-failure가 실행되고, 느린 훅은 시간 제한에 걸린다
+	hc.Operation = "pull"
+	runner = NewHookRunner(&Hooks{OnFailure: []Hook{{Name: "slow", Run: "exec sleep 5", Timeout: 50 * time.Millisecond}}})
+	err = runner.Wrap(context.Background(), hc, func(context.Context) error { return errors.New("merge conflict") })
+	require.EqualError(t, err, "merge conflict")
+	results = runner.Results()
+	require.Len(t, results, 1)
+	assert.True(t, results[0].TimedOut)
+	assert.True(t, results[0].Failed())
+
+	state := NewCloneState("github", "acme", "/tmp", "pull", 1, 1)
+	state.AddHookResults(results)
+	assert.Len(t, state.FailedHooks(), 1)
+}
+
+func TestHookOutputKeepsTail(t *testing.T) {
+	out := tail(strings.Repeat("x", 10)+"\n"+strings.Repeat("y", 20), 25)
+	assert.Equal(t, "…\n"+strings.Repeat("y", 20), out)
+	assert.Equal(t, "short", tail("short", 25))
+}
+
+func TestSaveHookReport(t *testing.T) {
+	dir := t.TempDir()
+	sm := NewStateManager(filepath.Join(dir, "state"))
+	state := NewCloneState("gitlab", "platform", dir, "reset", 1, 1)
+
+	path, err := sm.SaveHookReport(state)
+	require.NoError(t, err)
+	assert.Empty(t, path, "no report without hook results")
+
+	state.AddHookResults([]HookResult{{Hook: "lint", Event: HookPostUpdate, Repository: "api", Output: "ok"}})
+	path, err = sm.SaveHookReport(state)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "reports", "gitlab_platform_hooks.json"), path)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"output": "ok"`)
+}
@@ -44,6 +44,12 @@ func LoadSchemaFromFileWithEnv(environment env.Environment) (string, error) {
 	return bulkCloneSchemaJSON, nil
 }
 
+// SchemaJSON returns the embedded JSON schema for bulk-clone configuration
+// files.
+func SchemaJSON() string {
+	return bulkCloneSchemaJSON
+}
+
 // bulkCloneSchemaJSON contains the embedded JSON schema for validating bulk-clone configuration files.
 var bulkCloneSchemaJSON = `{
   "$schema": "http://json-schema.org/draft-07/schema#",
@@ -144,6 +150,10 @@ var bulkCloneSchemaJSON = `{
         "group_name": {
           "type": "string",
           "description": "Default group name"
+        },
+        "path_template": {
+          "type": "string",
+          "description": "Local layout for recursive clones: nested, flat, flat-prefixed, or a template using {group}, {subgroup}, {subgroup_flat}, {namespace}, {project}"
         }
       },
       "additionalProperties": false
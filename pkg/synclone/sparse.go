@@ -0,0 +1,319 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package bulkclone
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+)
+
+// CodeOwnersLocations are the paths a CODEOWNERS file is read from, in
+// order of precedence.
+var CodeOwnersLocations = []string{".github/CODEOWNERS", ".gitlab/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// sparseCloneOptions make a clone fetch blobs on demand and leave the
+// working tree empty until the sparse patterns are set.
+var sparseCloneOptions = []string{"--no-checkout", "--filter=blob:none"}
+
+// SparseProfile limits the working tree of every repository to the paths
+// a team owns. The paths come from the repository's CODEOWNERS file, or
+// from an explicit mapping for repositories without a usable one.
+type SparseProfile struct {
+	// Owners are the CODEOWNERS owners whose paths are checked out, such
+	// as "@acme/payments" or "@alice". Compared case-insensitively.
+	Owners []string `yaml:"owners,omitempty" json:"owners,omitempty"`
+
+	// Paths are always checked out, in sparse-checkout (gitignore) syntax.
+	Paths []string `yaml:"paths,omitempty" json:"paths,omitempty"`
+
+	// Repositories maps repository names or glob patterns to the paths
+	// checked out instead of those from CODEOWNERS.
+	Repositories map[string][]string `yaml:"repositories,omitempty" json:"repositories,omitempty"`
+
+	// RootFiles also checks out the files at the top of the repository,
+	// such as README.md and go.mod.
+	RootFiles bool `yaml:"root_files,omitempty" json:"rootFiles,omitempty"` //nolint:tagliatelle // YAML compatibility required
+}
+
+// Merge returns p overridden by other: its owners replace those of p when
+// set, paths are appended and repository mappings are merged. Either may
+// be nil; the result is nil when both are.
+func (p *SparseProfile) Merge(other *SparseProfile) *SparseProfile {
+	if p == nil {
+		return other
+	}
+	if other == nil {
+		return p
+	}
+
+	merged := &SparseProfile{
+		Owners:       p.Owners,
+		Paths:        append(append([]string(nil), p.Paths...), other.Paths...),
+		Repositories: make(map[string][]string, len(p.Repositories)+len(other.Repositories)),
+		RootFiles:    p.RootFiles || other.RootFiles,
+	}
+	if len(other.Owners) > 0 {
+		merged.Owners = other.Owners
+	}
+	for name, paths := range p.Repositories {
+		merged.Repositories[name] = paths
+	}
+	for name, paths := range other.Repositories {
+		merged.Repositories[name] = paths
+	}
+	return merged
+}
+
+// Empty reports whether the profile selects nothing, so repositories are
+// checked out in full.
+func (p *SparseProfile) Empty() bool {
+	return p == nil || len(p.Owners)+len(p.Repositories) == 0
+}
+
+// Validate checks that no owner or pattern is blank and that repository
+// globs are well-formed. Owners may come from another profile, so a
+// profile without them is valid.
+func (p *SparseProfile) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for i, owner := range p.Owners {
+		if strings.TrimSpace(owner) == "" {
+			return fmt.Errorf("sparse: owner %d is empty", i+1)
+		}
+	}
+	if err := validateSparsePatterns("paths", p.Paths); err != nil {
+		return err
+	}
+	for name, paths := range p.Repositories {
+		if _, err := path.Match(name, ""); err != nil {
+			return fmt.Errorf("sparse: invalid repository pattern %q: %w", name, err)
+		}
+		if err := validateSparsePatterns("repositories."+name, paths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSparsePatterns(field string, patterns []string) error {
+	for i, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("sparse: %s pattern %d is empty", field, i+1)
+		}
+	}
+	return nil
+}
+
+// Patterns returns the sparse-checkout patterns of repository: the mapped
+// paths when the profile maps it, otherwise the paths codeowners assigns
+// to the owners. ok is false when neither applies, in which case the
+// repository is checked out in full. A team owning nothing in a
+// repository gets only the root files and extra paths.
+func (p *SparseProfile) Patterns(repository string, codeowners []byte) (patterns []string, ok bool) {
+	if p.Empty() {
+		return nil, false
+	}
+
+	selected, mapped := p.mappedPaths(repository)
+	if !mapped {
+		if codeowners == nil || len(p.Owners) == 0 {
+			return nil, false
+		}
+		selected = OwnedPatterns(ParseCodeOwners(codeowners), p.Owners)
+	}
+
+	// 마지막으로 일치한 패턴이 우선하므로 루트 파일 패턴을 가장 앞에 둔다
+	if p.RootFiles {
+		patterns = append(patterns, "/*", "!/*/")
+	}
+	patterns = append(patterns, selected...)
+	patterns = append(patterns, p.Paths...)
+	return patterns, true
+}
+
+func (p *SparseProfile) mappedPaths(repository string) ([]string, bool) {
+	if paths, ok := p.Repositories[repository]; ok {
+		return paths, true
+	}
+	for name, paths := range p.Repositories {
+		if matched, _ := path.Match(name, repository); matched {
+			return paths, true
+		}
+	}
+	return nil, false
+}
+
+// CodeOwnersRule is one line of a CODEOWNERS file.
+type CodeOwnersRule struct {
+	Pattern string
+	Owners  []string
+}
+
+// ParseCodeOwners parses a CODEOWNERS file. Comments, blank lines and
+// GitLab section headers are skipped.
+func ParseCodeOwners(data []byte) []CodeOwnersRule {
+	var rules []CodeOwnersRule
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		rule := CodeOwnersRule{Pattern: fields[0]}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			rule.Owners = append(rule.Owners, owner)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// OwnedPatterns converts CODEOWNERS rules into sparse-checkout patterns
+// for owners. Both files use gitignore syntax where the last matching line
+// wins, so a later rule giving a path to someone else becomes an exclusion
+// and ownership is reproduced exactly.
+func OwnedPatterns(rules []CodeOwnersRule, owners []string) []string {
+	var patterns []string
+	for _, rule := range rules {
+		switch {
+		case ownedBy(rule.Owners, owners):
+			patterns = append(patterns, rule.Pattern)
+		case len(patterns) > 0:
+			patterns = append(patterns, "!"+rule.Pattern)
+		}
+	}
+	return patterns
+}
+
+func ownedBy(ruleOwners, owners []string) bool {
+	for _, ro := range ruleOwners {
+		for _, o := range owners {
+			if strings.EqualFold(strings.TrimPrefix(ro, "@"), strings.TrimPrefix(o, "@")) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SparseCheckout applies a sparse profile to cloned and updated
+// repositories. It is safe for concurrent use; a nil SparseCheckout
+// leaves repositories untouched.
+type SparseCheckout struct {
+	profile *SparseProfile
+
+	mu      sync.Mutex
+	limited int
+	full    int
+}
+
+// NewSparseCheckout creates a sparse checkout for profile. It returns nil
+// when the profile is empty.
+func NewSparseCheckout(profile *SparseProfile) *SparseCheckout {
+	if profile.Empty() {
+		return nil
+	}
+	return &SparseCheckout{profile: profile}
+}
+
+// CloneOptions returns the git clone options of a sparse clone, which
+// Apply completes.
+func (s *SparseCheckout) CloneOptions() []string {
+	if s == nil {
+		return nil
+	}
+	return sparseCloneOptions
+}
+
+// Apply sets the sparse-checkout patterns of the repository at repoPath
+// from its current CODEOWNERS, so ownership changes are picked up on every
+// update. After a clone made with CloneOptions, cloned is true and the
+// working tree is checked out.
+func (s *SparseCheckout) Apply(ctx context.Context, repoPath, repository string, cloned bool) error {
+	if s == nil {
+		return nil
+	}
+	// 빈 저장소에는 체크아웃할 커밋이 없다
+	if _, err := sparseGit(ctx, repoPath, nil, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return nil
+	}
+
+	var codeowners []byte
+	for _, location := range CodeOwnersLocations {
+		if data, err := sparseGit(ctx, repoPath, nil, "show", "HEAD:"+location); err == nil {
+			codeowners = data
+			break
+		}
+	}
+
+	patterns, ok := s.profile.Patterns(repository, codeowners)
+	switch {
+	case ok:
+		input := []byte(strings.Join(patterns, "\n") + "\n")
+		if _, err := sparseGit(ctx, repoPath, input, "sparse-checkout", "set", "--no-cone", "--stdin"); err != nil {
+			return err
+		}
+	case !cloned:
+		// 소유 경로를 알 수 없게 된 저장소는 전체 체크아웃으로 되돌린다
+		if out, err := sparseGit(ctx, repoPath, nil, "config", "--bool", "core.sparseCheckout"); err == nil && strings.TrimSpace(string(out)) == "true" {
+			if _, err := sparseGit(ctx, repoPath, nil, "sparse-checkout", "disable"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if cloned {
+		if _, err := sparseGit(ctx, repoPath, nil, "checkout"); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	if ok {
+		s.limited++
+	} else {
+		s.full++
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// PrintSummary prints how many repositories were limited to owned paths.
+func (s *SparseCheckout) PrintSummary() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limited+s.full == 0 {
+		return
+	}
+	fmt.Printf("🌿 Sparse checkout: %d limited to owned paths, %d in full (no CODEOWNERS or mapping)\n", s.limited, s.full)
+}
+
+func sparseGit(ctx context.Context, repoPath string, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repoPath}, args...)...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed in %s: %s", args[0], repoPath, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025 Gizzahub
+// SPDX-License-Identifier: MIT
+
+package bulkclone
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const testCodeOwners = `# Default owners
+*                     @acme/core
+
+[Payments]
+/services/pay/        @acme/payments   # the payment services
+/services/pay/legacy/ @acme/core
+/libs/                @ACME/Payments @acme/core
+`
+
+func TestSparseProfileConfig(t *testing.T) {
+	var profile SparseProfile
+	require.NoError(t, yaml.Unmarshal([]byte(`
+owners: ["@acme/payments"]
+root_files: true
+paths: [/tools/]
+repositories:
+  legacy-*: [/billing/]
+`), &profile))
+	require.NoError(t, profile.Validate())
+	assert.True(t, profile.RootFiles)
+
+	merged := profile.Merge(&SparseProfile{Owners: []string{"@acme/core"}, Paths: []string{"/docs/"}})
+	assert.Equal(t, []string{"@acme/core"}, merged.Owners, "organization owners replace global ones")
+	assert.Equal(t, []string{"/tools/", "/docs/"}, merged.Paths)
+	assert.Contains(t, merged.Repositories, "legacy-*")
+	assert.Same(t, &profile, (*SparseProfile)(nil).Merge(&profile))
+
+	assert.ErrorContains(t, (&SparseProfile{Paths: []string{" "}}).Validate(), "paths pattern 1 is empty")
+	assert.ErrorContains(t, (&SparseProfile{Repositories: map[string][]string{"[": nil}}).Validate(), "invalid repository pattern")
+	assert.Nil(t, NewSparseCheckout(&SparseProfile{Paths: []string{"/tools/"}}), "no sparse checkout without owners or mappings")
+}
+
+func TestSparseProfilePatterns(t *testing.T) {
+	rules := ParseCodeOwners([]byte(testCodeOwners))
+	require.Len(t, rules, 4)
+	assert.Equal(t, []string{"@acme/payments"}, rules[1].Owners, "inline comments are dropped")
+
+	assert.Equal(t, []string{"/services/pay/", "!/services/pay/legacy/", "/libs/"},
+		OwnedPatterns(rules, []string{"acme/payments"}))
+	assert.Equal(t, []string{"*", "!/services/pay/", "/services/pay/legacy/", "/libs/"},
+		OwnedPatterns(rules, []string{"@acme/core"}))
+
+	profile := &SparseProfile{
+		Owners:       []string{"@acme/payments"},
+		RootFiles:    true,
+		Paths:        []string{"/tools/"},
+		Repositories: map[string][]string{"legacy-*": {"/billing/"}},
+	}
+	patterns, ok := profile.Patterns("api", []byte(testCodeOwners))
+	require.True(t, ok)
+	assert.Equal(t, []string{"/*", "!/*/", "/services/pay/", "!/services/pay/legacy/", "/libs/", "/tools/"}, patterns)
+
+	patterns, ok = profile.Patterns("legacy-billing", nil)
+	require.True(t, ok)
+	assert.Equal(t, []string{"/*", "!/*/", "/billing/", "/tools/"}, patterns)
+
+	_, ok = profile.Patterns("web", nil)
+	assert.False(t, ok, "without CODEOWNERS or a mapping the repository is checked out in full")
+}
+
+func TestSparseCheckoutApply(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin")
+	files := map[string]string{
+		".github/CODEOWNERS":     testCodeOwners,
+		"README.md":              "readme",
+		"services/pay/main.go":   "package main",
+		"services/pay/legacy/x":  "old",
+		"services/ship/main.go":  "package main",
+		"libs/money/money.go":    "package money",
+		"tools/lint/config.yaml": "rules: []",
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(origin, filepath.Dir(name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(origin, name), []byte(content), 0o644))
+	}
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git(origin, "init", "-q")
+	git(origin, "add", "-A")
+	git(origin, "commit", "-q", "-m", "init")
+
+	sparse := NewSparseCheckout(&SparseProfile{Owners: []string{"@acme/payments"}, RootFiles: true})
+	clone := filepath.Join(root, "api")
+	git(root, append(append([]string{"clone", "-q"}, sparse.CloneOptions()...), "file://"+origin, clone)...)
+	require.NoError(t, sparse.Apply(context.Background(), clone, "api", true))
+	assert.Equal(t, []string{"README.md", "libs/money/money.go", "services/pay/main.go"}, workingTree(t, clone))
+
+	// 소유권이 바뀌면 다음 업데이트에서 반영된다
+	require.NoError(t, os.WriteFile(filepath.Join(origin, ".github/CODEOWNERS"), []byte("/tools/ @acme/payments\n"), 0o644))
+	git(origin, "commit", "-q", "-am", "hand over")
+	git(clone, "pull", "-q")
+	require.NoError(t, sparse.Apply(context.Background(), clone, "api", false))
+	assert.Equal(t, []string{"README.md", "tools/lint/config.yaml"}, workingTree(t, clone))
+
+	var nilSparse *SparseCheckout
+	assert.Nil(t, nilSparse.CloneOptions())
+	assert.NoError(t, nilSparse.Apply(context.Background(), clone, "api", false))
+}
+
+func workingTree(t *testing.T, dir string) []string {
+	t.Helper()
+	var files []string
+	require.NoError(t, filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	}))
+	sort.Strings(files)
+	return files
+}
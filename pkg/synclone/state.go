@@ -33,6 +33,9 @@ type CloneState struct {
 
 	// Status
 	Status string `json:"status"` // "in_progress", "completed", "failed", "canceled"
+
+	// Lifecycle hook executions, with captured output
+	HookResults []HookResult `json:"hookResults,omitempty"`
 }
 
 // CompletedRepository represents a successfully processed repository.
@@ -147,6 +150,32 @@ func (sm *StateManager) DeleteState(provider, organization string) error {
 	return nil
 }
 
+// SaveHookReport writes the lifecycle hook results of state, including their
+// output, next to the state directory and returns the report path. The
+// report outlives the state file, which is removed after a complete run.
+func (sm *StateManager) SaveHookReport(state *CloneState) (string, error) {
+	if len(state.HookResults) == 0 {
+		return "", nil
+	}
+
+	reportDir := filepath.Join(filepath.Dir(sm.stateDir), "reports")
+	if err := os.MkdirAll(reportDir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create report directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state.HookResults, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal hook report: %w", err)
+	}
+
+	reportPath := filepath.Join(reportDir, fmt.Sprintf("%s_%s_hooks.json", state.Provider, state.Organization))
+	if err := os.WriteFile(reportPath, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write hook report: %w", err)
+	}
+
+	return reportPath, nil
+}
+
 // HasState checks if a state file exists for the given operation.
 func (sm *StateManager) HasState(provider, organization string) bool {
 	statePath := sm.GetStateFilePath(provider, organization)
@@ -228,6 +257,23 @@ func (cs *CloneState) AddCompletedRepository(name, path, operation, message stri
 	cs.updateTotalRepositories()
 }
 
+// AddHookResults records lifecycle hook executions in the run report.
+func (cs *CloneState) AddHookResults(results []HookResult) {
+	cs.HookResults = append(cs.HookResults, results...)
+}
+
+// FailedHooks returns the hook executions that failed or timed out.
+func (cs *CloneState) FailedHooks() []HookResult {
+	var failed []HookResult
+	for _, r := range cs.HookResults {
+		if r.Failed() {
+			failed = append(failed, r)
+		}
+	}
+
+	return failed
+}
+
 // AddFailedRepository adds a failed repository to the state.
 func (cs *CloneState) AddFailedRepository(name, path, operation, errorMsg string, attempts int) {
 	// Check if this repo already failed and update it
@@ -34,12 +34,13 @@ type bulkCloneDefaultGithub struct {
 
 // bulkCloneDefaultGitlab defines default GitLab-specific configuration.
 type bulkCloneDefaultGitlab struct {
-	RootPath  string `yaml:"rootPath"`
-	Provider  string `yaml:"provider"`
-	URL       string `yaml:"url"`
-	Recursive bool   `yaml:"recursive"`
-	Protocol  string `yaml:"protocol"`
-	GroupName string `yaml:"groupName"`
+	RootPath     string `yaml:"rootPath"`
+	Provider     string `yaml:"provider"`
+	URL          string `yaml:"url"`
+	Recursive    bool   `yaml:"recursive"`
+	Protocol     string `yaml:"protocol"`
+	GroupName    string `yaml:"groupName"`
+	PathTemplate string `yaml:"pathTemplate"`
 }
 
 // BulkCloneGithub represents GitHub bulk clone configuration.
@@ -52,12 +53,13 @@ type BulkCloneGithub struct { //nolint:revive // Type name maintained for clarit
 
 // BulkCloneGitlab represents GitLab bulk clone configuration.
 type BulkCloneGitlab struct { //nolint:revive // Type name maintained for clarity in configuration structs
-	RootPath  string `yaml:"rootPath" validate:"required"`
-	Provider  string `yaml:"provider" validate:"required"`
-	URL       string `yaml:"url"`
-	Recursive bool   `yaml:"recursive"`
-	Protocol  string `yaml:"protocol" validate:"required,oneof=http https ssh"`
-	GroupName string `yaml:"groupName" validate:"required"`
+	RootPath     string `yaml:"rootPath" validate:"required"`
+	Provider     string `yaml:"provider" validate:"required"`
+	URL          string `yaml:"url"`
+	Recursive    bool   `yaml:"recursive"`
+	Protocol     string `yaml:"protocol" validate:"required,oneof=http https ssh"`
+	GroupName    string `yaml:"groupName" validate:"required"`
+	PathTemplate string `yaml:"pathTemplate"`
 }
 
 type bulkCloneConfig struct {